@@ -126,6 +126,12 @@ func AllowAnonymousRequest(req *http.Request) bool {
 		}
 	}
 
+	// Authentication is performed in the SCIM handler itself, with the token of the auth.scim site
+	// configuration.
+	if strings.HasPrefix(req.URL.Path, "/.api/scim/") {
+		return true
+	}
+
 	// Permission is checked by a shared token
 	if strings.HasPrefix(req.URL.Path, "/.internal-code-intel") {
 		return true
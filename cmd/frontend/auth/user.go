@@ -129,6 +129,10 @@ func GetAndSaveUser(ctx context.Context, op GetAndSaveUserOp) (userID int32, saf
 		if err != nil {
 			return 0, "Unexpected error getting the Sourcegraph user account. Ask a site admin for help.", err
 		}
+		// 🚨 SECURITY: Deactivated users must not be able to sign in.
+		if user.Deactivated {
+			return 0, "Your Sourcegraph user account has been deactivated. Ask a site admin for help.", fmt.Errorf("user %d is deactivated", user.ID)
+		}
 		var userUpdate db.UserUpdate
 		if user.DisplayName != op.UserProps.DisplayName {
 			userUpdate.DisplayName = &op.UserProps.DisplayName
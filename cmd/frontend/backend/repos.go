@@ -12,6 +12,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/internal/inventory"
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
 	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/auditlog"
 	"github.com/sourcegraph/sourcegraph/internal/conf"
 	"github.com/sourcegraph/sourcegraph/internal/db"
 	"github.com/sourcegraph/sourcegraph/internal/errcode"
@@ -45,7 +46,13 @@ func (s *repos) Get(ctx context.Context, repo api.RepoID) (_ *types.Repo, err er
 	ctx, done := trace(ctx, "Repos", "Get", repo, &err)
 	defer done()
 
-	return db.Repos.Get(ctx, repo)
+	r, err := db.Repos.Get(ctx, repo)
+	if err != nil {
+		return nil, err
+	}
+	// Site admins bypass repository permissions, so their accesses are recorded.
+	auditlog.LogRepositoryAccess(ctx, r)
+	return r, nil
 }
 
 // GetByName retrieves the repository with the given name. On sourcegraph.com,
@@ -62,6 +69,8 @@ func (s *repos) GetByName(ctx context.Context, name api.RepoName) (_ *types.Repo
 
 	switch repo, err := db.Repos.GetByName(ctx, name); {
 	case err == nil:
+		// Site admins bypass repository permissions, so their accesses are recorded.
+		auditlog.LogRepositoryAccess(ctx, repo)
 		return repo, nil
 	case !errcode.IsNotFound(err):
 		return nil, err
@@ -2,6 +2,7 @@ package backend
 
 import (
 	"bytes"
+	"context"
 	"flag"
 	"fmt"
 	"io"
@@ -14,7 +15,9 @@ import (
 	"github.com/inconshreveable/log15"
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/internal/inventory"
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
 	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/auditlog"
 	"github.com/sourcegraph/sourcegraph/internal/db"
 	"github.com/sourcegraph/sourcegraph/internal/rcache"
 	"github.com/sourcegraph/sourcegraph/internal/repoupdater"
@@ -30,6 +33,13 @@ func TestReposService_Get(t *testing.T) {
 	wantRepo := &types.Repo{ID: 1, Name: "github.com/u/r"}
 
 	calledGet := db.Mocks.Repos.MockGet_Return(t, wantRepo)
+	db.Mocks.Users.GetByID = func(_ context.Context, id int32) (*types.User, error) {
+		return &types.User{ID: id}, nil
+	}
+	db.Mocks.AuditLog.Insert = func(context.Context, *db.AuditLogEntry) error {
+		t.Error("access by non-site admin must not be recorded")
+		return nil
+	}
 
 	repo, err := s.Get(ctx, 1)
 	if err != nil {
@@ -44,6 +54,31 @@ func TestReposService_Get(t *testing.T) {
 	}
 }
 
+func TestReposService_Get_SiteAdminAccess(t *testing.T) {
+	var s repos
+	ctx := actor.WithActor(testContext(), &actor.Actor{UID: 2})
+
+	db.Mocks.Repos.MockGet_Return(t, &types.Repo{ID: 3, Name: "github.com/u/r"})
+	db.Mocks.Users.GetByID = func(_ context.Context, id int32) (*types.User, error) {
+		return &types.User{ID: id, SiteAdmin: true}, nil
+	}
+	var logged []*db.AuditLogEntry
+	db.Mocks.AuditLog.Insert = func(_ context.Context, e *db.AuditLogEntry) error {
+		logged = append(logged, e)
+		return nil
+	}
+
+	if _, err := s.Get(ctx, 3); err != nil {
+		t.Fatal(err)
+	}
+	if len(logged) != 1 {
+		t.Fatalf("got %d audit log entries, want 1", len(logged))
+	}
+	if e := logged[0]; e.Action != auditlog.ActionRepositoryAccessed || e.ActorUserID != 2 || e.SubjectID != "3" {
+		t.Errorf("got audit log entry %+v", e)
+	}
+}
+
 func TestReposService_List(t *testing.T) {
 	var s repos
 	ctx := testContext()
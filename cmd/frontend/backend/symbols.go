@@ -11,10 +11,18 @@ import (
 // Symbols backend.
 var Symbols = &symbols{}
 
+type MockSymbols struct {
+	ListTags func(ctx context.Context, args search.SymbolsParameters) ([]protocol.Symbol, error)
+}
+
 type symbols struct{}
 
 // ListTags returns symbols in a repository from ctags.
 func (symbols) ListTags(ctx context.Context, args search.SymbolsParameters) ([]protocol.Symbol, error) {
+	if Mocks.Symbols.ListTags != nil {
+		return Mocks.Symbols.ListTags(ctx, args)
+	}
+
 	result, err := symbolsclient.DefaultClient.Search(ctx, args)
 	if result == nil {
 		return nil, err
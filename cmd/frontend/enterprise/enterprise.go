@@ -15,6 +15,7 @@ type Services struct {
 	BitbucketServerWebhook           http.Handler
 	NewCodeIntelUploadHandler        NewCodeIntelUploadHandler
 	NewCodeIntelInternalProxyHandler NewCodeIntelInternalProxyHandler
+	PermissionsImportHandler         http.Handler
 	AuthzResolver                    graphqlbackend.AuthzResolver
 	CampaignsResolver                graphqlbackend.CampaignsResolver
 	CodeIntelResolver                graphqlbackend.CodeIntelResolver
@@ -36,6 +37,7 @@ func DefaultServices() Services {
 		BitbucketServerWebhook:           makeNotFoundHandler("bitbucket server webhook"),
 		NewCodeIntelUploadHandler:        func(_ bool) http.Handler { return makeNotFoundHandler("code intel upload") },
 		NewCodeIntelInternalProxyHandler: func() http.Handler { return makeNotFoundHandler("code intel internal proxy") },
+		PermissionsImportHandler:         makeNotFoundHandler("permissions import"),
 		AuthzResolver:                    graphqlbackend.DefaultAuthzResolver,
 		CampaignsResolver:                graphqlbackend.DefaultCampaignsResolver,
 	}
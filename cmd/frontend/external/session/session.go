@@ -11,4 +11,5 @@ var (
 	GetData                      = session.GetData
 	InvalidateSessionsByID       = session.InvalidateSessionsByID
 	InvalidateSessionCurrentUser = session.InvalidateSessionCurrentUser
+	SessionIDFromContext         = session.SessionIDFromContext
 )
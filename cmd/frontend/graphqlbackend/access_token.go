@@ -64,3 +64,14 @@ func (r *accessTokenResolver) CreatedAt() DateTime { return DateTime{Time: r.acc
 func (r *accessTokenResolver) LastUsedAt() *DateTime {
 	return DateTimeOrNil(r.accessToken.LastUsedAt)
 }
+
+func (r *accessTokenResolver) LastUsedIP() *string {
+	if r.accessToken.LastUsedIP == "" {
+		return nil
+	}
+	return &r.accessToken.LastUsedIP
+}
+
+func (r *accessTokenResolver) ExpiresAt() *DateTime {
+	return DateTimeOrNil(r.accessToken.ExpiresAt)
+}
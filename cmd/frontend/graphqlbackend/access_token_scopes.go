@@ -0,0 +1,293 @@
+package graphqlbackend
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/graph-gophers/graphql-go/trace"
+
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+)
+
+// fieldSet is a set of GraphQL field names. A nil fieldSet permits all fields of a type.
+type fieldSet map[string]struct{}
+
+func fields(names ...string) fieldSet {
+	s := make(fieldSet, len(names))
+	for _, name := range names {
+		s[name] = struct{}{}
+	}
+	return s
+}
+
+func (s fieldSet) permits(fieldName string) bool {
+	if s == nil {
+		return true
+	}
+	_, ok := s[fieldName]
+	return ok
+}
+
+// searchReadFields are the fields, by object type, that actors authorized with the "search:read"
+// access token scope can resolve: searches, reading repositories and their Git data, and code
+// intelligence. Object types that aren't listed can't be resolved at all.
+var searchReadFields = map[string]fieldSet{
+	"Query": fields(
+		"search",
+		"parseSearchQuery",
+		"searchFilterSuggestions",
+		"repository",
+		"repositoryRedirect",
+		"repositories",
+		"repoGroups",
+		"versionContexts",
+		"highlightCode",
+	),
+
+	// Search.
+	"Search":                  nil,
+	"SearchResults":           nil,
+	"SearchResultsStats":      nil,
+	"SearchResultMatch":       nil,
+	"SearchFilter":            nil,
+	"SearchFilterSuggestions": nil,
+	"SearchAlert":             nil,
+	"SearchQueryDescription":  nil,
+	"FileMatch":               nil,
+	"LineMatch":               nil,
+	"CommitSearchResult":      nil,
+	"DiffSearchResult":        nil,
+	"Diff":                    nil,
+	"Hunk":                    nil,
+	"Language":                nil,
+	"Markdown":                nil,
+	"Highlight":               nil,
+	"HighlightedString":       nil,
+	"RepoGroup":               nil,
+	"VersionContext":          nil,
+
+	// Repositories and their Git data.
+	"Repository":                      nil,
+	"RepositoryConnection":            nil,
+	"Redirect":                        nil,
+	"ExternalRepository":              nil,
+	"ExternalLink":                    nil,
+	"RepositoryContributor":           nil,
+	"RepositoryContributorConnection": nil,
+	"RepositoryComparison":            nil,
+	"PreviewRepositoryComparison":     nil,
+	"FileDiff":                        nil,
+	"FileDiffConnection":              nil,
+	"FileDiffHunk":                    nil,
+	"FileDiffHunkRange":               nil,
+	"HighlightedDiffHunkBody":         nil,
+	"HighlightedDiffHunkLine":         nil,
+	"DiffStat":                        nil,
+	"GitCommit":                       nil,
+	"GitCommitConnection":             nil,
+	"GitRef":                          nil,
+	"GitRefConnection":                nil,
+	"GitObject":                       nil,
+	"GitRevSpecExpr":                  nil,
+	"GitRevisionRange":                nil,
+	"GitTree":                         nil,
+	"GitBlob":                         nil,
+	"File":                            nil,
+	"VirtualFile":                     nil,
+	"HighlightedFile":                 nil,
+	"Submodule":                       nil,
+	"Signature":                       nil,
+	"Person":                          nil,
+	"BehindAheadCounts":               nil,
+	"LanguageStatistics":              nil,
+	"PageInfo":                        nil,
+
+	// Symbols and code intelligence.
+	"Symbol":                          nil,
+	"SymbolConnection":                nil,
+	"Location":                        nil,
+	"LocationConnection":              nil,
+	"Range":                           nil,
+	"Position":                        nil,
+	"Hover":                           nil,
+	"Diagnostic":                      nil,
+	"DiagnosticConnection":            nil,
+	"CodeIntelligenceRange":           nil,
+	"CodeIntelligenceRangeConnection": nil,
+	"GitBlobLSIFData":                 nil,
+}
+
+// campaignsFields are the fields, by object type, that actors authorized with the
+// "campaigns:write" access token scope can resolve in addition to the fields permitted by
+// "search:read": reading and modifying campaigns and changesets, and the identity of their
+// namespaces.
+var campaignsFields = map[string]fieldSet{
+	"Query": fields(
+		"node",
+		"currentUser",
+		"user",
+		"organization",
+		"namespace",
+		"campaign",
+		"campaigns",
+		"campaignCredentials",
+	),
+	"Mutation": fields(
+		"createCampaign",
+		"applyCampaign",
+		"moveCampaign",
+		"closeCampaign",
+		"deleteCampaign",
+		"createCampaignCredential",
+		"deleteCampaignCredential",
+		"createChangesetSpec",
+		"createCampaignSpec",
+		"executeCampaignSpec",
+		"syncChangeset",
+		"createChangesetComments",
+		"reenqueueChangesets",
+		"mergeChangesets",
+		"closeChangesets",
+		"detachChangesets",
+	),
+
+	"User": fields("id", "databaseID", "username", "displayName", "avatarURL", "url", "namespaceName", "campaigns"),
+	"Org":  fields("id", "name", "displayName", "url", "namespaceName", "campaigns"),
+
+	"Campaign":                      nil,
+	"CampaignConnection":            nil,
+	"CampaignDescription":           nil,
+	"CampaignSpec":                  nil,
+	"CampaignSpecExecution":         nil,
+	"CampaignAnalytics":             nil,
+	"CampaignSnapshot":              nil,
+	"CampaignSnapshotConnection":    nil,
+	"CampaignCredential":            nil,
+	"CampaignCredentialConnection":  nil,
+	"ExternalChangeset":             nil,
+	"HiddenExternalChangeset":       nil,
+	"ChangesetConnection":           nil,
+	"ChangesetConnectionStats":      nil,
+	"ChangesetCounts":               nil,
+	"ChangesetEvent":                nil,
+	"ChangesetEventConnection":      nil,
+	"ChangesetLabel":                nil,
+	"ChangesetJobError":             nil,
+	"VisibleChangesetSpec":          nil,
+	"HiddenChangesetSpec":           nil,
+	"ChangesetSpecConnection":       nil,
+	"ExistingChangesetReference":    nil,
+	"GitBranchChangesetDescription": nil,
+	"GitCommitDescription":          nil,
+	"BulkOperation":                 nil,
+	"RepositoryStallTime":           nil,
+	"EmptyResponse":                 nil,
+}
+
+// siteAdminOnlyFields are the fields of the permitted object types that expose site
+// administration data. Actors authorized with a narrow access token scope can't resolve them,
+// even if they are site admins.
+var siteAdminOnlyFields = map[string]fieldSet{
+	"Repository": fields(
+		"externalServices",
+		"mirrorInfo",
+		"textSearchIndex",
+		"permissionsInfo",
+		"authorizedUsers",
+		"lsifUploads",
+		"lsifIndexes",
+		"lsifUploadRetention",
+	),
+}
+
+// accessTokenScopeFields are the fields that actors authorized with each narrow access token scope
+// can resolve. Actors authorized with any other scope (or without an access token) can resolve
+// all fields.
+var accessTokenScopeFields = map[string]map[string]fieldSet{
+	authz.ScopeSearchRead:     searchReadFields,
+	authz.ScopeCampaignsWrite: mergeFields(searchReadFields, campaignsFields), // "campaigns:write" grants "search:read"
+}
+
+// mergeFields returns the union of the fields permitted by a and b.
+func mergeFields(a, b map[string]fieldSet) map[string]fieldSet {
+	merged := make(map[string]fieldSet, len(a)+len(b))
+	for _, m := range []map[string]fieldSet{a, b} {
+		for typeName, s := range m {
+			prev, ok := merged[typeName]
+			switch {
+			case !ok:
+				merged[typeName] = s
+			case prev == nil || s == nil:
+				merged[typeName] = nil
+			default:
+				union := make(fieldSet, len(prev)+len(s))
+				for name := range prev {
+					union[name] = struct{}{}
+				}
+				for name := range s {
+					union[name] = struct{}{}
+				}
+				merged[typeName] = union
+			}
+		}
+	}
+	return merged
+}
+
+// checkAccessTokenScope returns an error if the access token scope that authorized the request of
+// the actor does not permit resolving the field of the (concrete) object type. Fields of nested
+// objects are restricted as well, so that a permitted Query field can't be used to reach data
+// that the scope does not grant.
+func checkAccessTokenScope(ctx context.Context, typeName, fieldName string) error {
+	if strings.HasPrefix(fieldName, "__") {
+		return nil
+	}
+
+	scope := actor.FromContext(ctx).AccessTokenScope
+	scopeFields, ok := accessTokenScopeFields[scope]
+	if !ok {
+		return nil
+	}
+	if _, siteAdminOnly := siteAdminOnlyFields[typeName][fieldName]; !siteAdminOnly {
+		if s, ok := scopeFields[typeName]; ok && s.permits(fieldName) {
+			return nil
+		}
+	}
+	return fmt.Errorf("the access token scope %q does not permit %s.%s", scope, typeName, fieldName)
+}
+
+// accessTokenScopeTracer is a GraphQL tracer that denies the fields that the access token scope of
+// the actor does not permit. It is called by the executor for every field before resolving it,
+// so that the restricted fields are determined by the parser that executes the request.
+type accessTokenScopeTracer struct {
+	trace.Tracer
+}
+
+func (t accessTokenScopeTracer) TraceField(ctx context.Context, label, typeName, fieldName string, trivial bool, args map[string]interface{}) (context.Context, trace.TraceFieldFinishFunc) {
+	fieldCtx, finish := t.Tracer.TraceField(ctx, label, typeName, fieldName, trivial, args)
+
+	// 🚨 SECURITY: Fields that the access token of the actor does not permit must not be resolved.
+	if err := checkAccessTokenScope(ctx, typeName, fieldName); err != nil {
+		return deniedContext{Context: fieldCtx, err: err}, finish
+	}
+	return fieldCtx, finish
+}
+
+// deniedContext is the context of a field that the actor may not resolve. The GraphQL executor
+// does not call the resolver of a field whose context is done, and reports the error of the
+// context as the error of the field instead.
+type deniedContext struct {
+	context.Context
+	err error
+}
+
+var closedChan = func() chan struct{} {
+	c := make(chan struct{})
+	close(c)
+	return c
+}()
+
+func (c deniedContext) Done() <-chan struct{} { return closedChan }
+func (c deniedContext) Err() error            { return c.err }
@@ -0,0 +1,169 @@
+package graphqlbackend
+
+import (
+	"context"
+	"testing"
+
+	gqlerrors "github.com/graph-gophers/graphql-go/errors"
+	"github.com/graph-gophers/graphql-go/gqltesting"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/db"
+)
+
+func TestCheckAccessTokenScope(t *testing.T) {
+	tests := []struct {
+		scope     string
+		typeName  string
+		fieldName string
+		wantErr   bool
+	}{
+		{scope: "", typeName: "Mutation", fieldName: "deleteAccessToken"},
+		{scope: authz.ScopeUserAll, typeName: "Mutation", fieldName: "deleteAccessToken"},
+		{scope: authz.ScopeSearchRead, typeName: "Query", fieldName: "search"},
+		{scope: authz.ScopeSearchRead, typeName: "Query", fieldName: "repository"},
+		{scope: authz.ScopeSearchRead, typeName: "Query", fieldName: "__schema"},
+		{scope: authz.ScopeSearchRead, typeName: "Repository", fieldName: "name"},
+		{scope: authz.ScopeSearchRead, typeName: "Query", fieldName: "currentUser", wantErr: true},
+		{scope: authz.ScopeSearchRead, typeName: "Query", fieldName: "site", wantErr: true},
+		{scope: authz.ScopeSearchRead, typeName: "Query", fieldName: "root", wantErr: true},
+		{scope: authz.ScopeSearchRead, typeName: "Mutation", fieldName: "applyCampaign", wantErr: true},
+		{scope: authz.ScopeSearchRead, typeName: "FileMatch", fieldName: "repository"},
+		{scope: authz.ScopeSearchRead, typeName: "GitBlob", fieldName: "content"},
+		{scope: authz.ScopeSearchRead, typeName: "Repository", fieldName: "externalServices", wantErr: true},
+		{scope: authz.ScopeSearchRead, typeName: "Repository", fieldName: "permissionsInfo", wantErr: true},
+		{scope: authz.ScopeSearchRead, typeName: "ExternalService", fieldName: "config", wantErr: true},
+		{scope: authz.ScopeSearchRead, typeName: "User", fieldName: "username", wantErr: true},
+		{scope: authz.ScopeSearchRead, typeName: "Campaign", fieldName: "name", wantErr: true},
+		{scope: authz.ScopeCampaignsWrite, typeName: "Query", fieldName: "currentUser"},
+		{scope: authz.ScopeCampaignsWrite, typeName: "Query", fieldName: "search"},
+		{scope: authz.ScopeCampaignsWrite, typeName: "Query", fieldName: "site", wantErr: true},
+		{scope: authz.ScopeCampaignsWrite, typeName: "Site", fieldName: "configuration", wantErr: true},
+		{scope: authz.ScopeCampaignsWrite, typeName: "SiteConfiguration", fieldName: "effectiveContents", wantErr: true},
+		{scope: authz.ScopeCampaignsWrite, typeName: "User", fieldName: "username"},
+		{scope: authz.ScopeCampaignsWrite, typeName: "User", fieldName: "emails", wantErr: true},
+		{scope: authz.ScopeCampaignsWrite, typeName: "User", fieldName: "accessTokens", wantErr: true},
+		{scope: authz.ScopeCampaignsWrite, typeName: "Campaign", fieldName: "changesets"},
+		{scope: authz.ScopeCampaignsWrite, typeName: "ExternalChangeset", fieldName: "repository"},
+		{scope: authz.ScopeCampaignsWrite, typeName: "Repository", fieldName: "name"},
+		{scope: authz.ScopeCampaignsWrite, typeName: "Repository", fieldName: "externalServices", wantErr: true},
+		{scope: authz.ScopeCampaignsWrite, typeName: "Mutation", fieldName: "applyCampaign"},
+		{scope: authz.ScopeCampaignsWrite, typeName: "Mutation", fieldName: "syncChangeset"},
+		{scope: authz.ScopeCampaignsWrite, typeName: "Mutation", fieldName: "deleteAccessToken", wantErr: true},
+		{scope: authz.ScopeCampaignsWrite, typeName: "Mutation", fieldName: "updateSiteConfiguration", wantErr: true},
+		{scope: authz.ScopeUserAll, typeName: "Site", fieldName: "configuration"},
+		{scope: authz.ScopeUserAll, typeName: "Repository", fieldName: "externalServices"},
+	}
+	for _, test := range tests {
+		ctx := actor.WithActor(context.Background(), &actor.Actor{UID: 1, AccessTokenScope: test.scope})
+		err := checkAccessTokenScope(ctx, test.typeName, test.fieldName)
+		if gotErr := err != nil; gotErr != test.wantErr {
+			t.Errorf("%q %s.%s: got error %v, want error %v", test.scope, test.typeName, test.fieldName, err, test.wantErr)
+		}
+	}
+}
+
+func TestAccessTokenScope_Schema(t *testing.T) {
+	resetMocks()
+	db.Mocks.Repos.MockGetByName(t, "github.com/gorilla/mux", 2)
+	db.Mocks.Users.GetByCurrentAuthUser = func(context.Context) (*types.User, error) {
+		return &types.User{ID: 1, Username: "alice"}, nil
+	}
+	db.Mocks.Users.GetByID = func(_ context.Context, id int32) (*types.User, error) {
+		return &types.User{ID: id, Username: "alice"}, nil
+	}
+	db.Mocks.AccessTokens.DeleteByID = func(int64, int32) error {
+		t.Error("deleteAccessToken must not be resolved")
+		return nil
+	}
+	defer resetMocks()
+
+	ctx := actor.WithActor(context.Background(), &actor.Actor{UID: 1, AccessTokenScope: authz.ScopeSearchRead})
+	gqltesting.RunTests(t, []*gqltesting.Test{
+		{
+			Context: ctx,
+			Schema:  mustParseGraphQLSchema(t),
+			Query: `
+				{
+					repository(name: "github.com/gorilla/mux") {
+						name
+					}
+					currentUser {
+						username
+					}
+				}
+			`,
+			ExpectedResult: `
+				{
+					"repository": {
+						"name": "github.com/gorilla/mux"
+					},
+					"currentUser": null
+				}
+			`,
+			ExpectedErrors: []*gqlerrors.QueryError{
+				{Message: `the access token scope "search:read" does not permit Query.currentUser`},
+			},
+		},
+		{
+			Context: ctx,
+			Schema:  mustParseGraphQLSchema(t),
+			Query: `
+				{
+					repository(name: "github.com/gorilla/mux") {
+						name
+						externalServices {
+							totalCount
+						}
+					}
+				}
+			`,
+			ExpectedResult: `
+				{
+					"repository": null
+				}
+			`,
+			ExpectedErrors: []*gqlerrors.QueryError{
+				{Message: `the access token scope "search:read" does not permit Repository.externalServices`},
+			},
+		},
+		{
+			Context: actor.WithActor(context.Background(), &actor.Actor{UID: 1, AccessTokenScope: authz.ScopeCampaignsWrite}),
+			Schema:  mustParseGraphQLSchema(t),
+			Query: `
+				{
+					currentUser {
+						username
+						emails {
+							email
+						}
+					}
+				}
+			`,
+			ExpectedResult: `
+				{
+					"currentUser": null
+				}
+			`,
+			ExpectedErrors: []*gqlerrors.QueryError{
+				{Message: `the access token scope "campaigns:write" does not permit User.emails`},
+			},
+		},
+		{
+			Context: ctx,
+			Schema:  mustParseGraphQLSchema(t),
+			Query: `
+				mutation {
+					deleteAccessToken(byID: "QWNjZXNzVG9rZW46MQ==") {
+						alwaysNil
+					}
+				}
+			`,
+			ExpectedResult: `null`,
+			ExpectedErrors: []*gqlerrors.QueryError{
+				{Message: `the access token scope "search:read" does not permit Mutation.deleteAccessToken`},
+			},
+		},
+	})
+}
@@ -5,21 +5,25 @@ import (
 	"errors"
 	"fmt"
 	"sort"
+	"strconv"
 	"sync"
+	"time"
 
 	"github.com/graph-gophers/graphql-go"
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend/graphqlutil"
 	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/auditlog"
 	"github.com/sourcegraph/sourcegraph/internal/authz"
 	"github.com/sourcegraph/sourcegraph/internal/conf"
 	"github.com/sourcegraph/sourcegraph/internal/db"
 )
 
 type createAccessTokenInput struct {
-	User   graphql.ID
-	Scopes []string
-	Note   string
+	User      graphql.ID
+	Scopes    []string
+	Note      string
+	ExpiresAt *DateTime
 }
 
 func (r *schemaResolver) CreateAccessToken(ctx context.Context, args *createAccessTokenInput) (*createAccessTokenResult, error) {
@@ -46,7 +50,7 @@ func (r *schemaResolver) CreateAccessToken(ctx context.Context, args *createAcce
 	}
 
 	// Validate scopes.
-	var hasUserAllScope bool
+	var hasUserAllScope, hasSudoScope bool
 	seenScope := map[string]struct{}{}
 	sort.Strings(args.Scopes)
 	for _, scope := range args.Scopes {
@@ -58,6 +62,9 @@ func (r *schemaResolver) CreateAccessToken(ctx context.Context, args *createAcce
 			if err := backend.CheckCurrentUserIsSiteAdmin(ctx); err != nil {
 				return nil, err
 			}
+			hasSudoScope = true
+		case authz.ScopeSearchRead, authz.ScopeCodeIntelUpload, authz.ScopeCampaignsWrite:
+			// Allow
 		default:
 			return nil, fmt.Errorf("unknown access token scope %q (valid scopes: %q)", scope, authz.AllScopes)
 		}
@@ -67,12 +74,47 @@ func (r *schemaResolver) CreateAccessToken(ctx context.Context, args *createAcce
 		}
 		seenScope[scope] = struct{}{}
 	}
-	if !hasUserAllScope {
-		return nil, fmt.Errorf("all access tokens must have scope %q", authz.ScopeUserAll)
+	if len(args.Scopes) == 0 {
+		return nil, errors.New("access tokens must have at least one scope")
+	}
+	if hasSudoScope && !hasUserAllScope {
+		return nil, fmt.Errorf("access tokens with scope %q must also have scope %q", authz.ScopeSiteAdminSudo, authz.ScopeUserAll)
+	}
+
+	var expiresAt *time.Time
+	if args.ExpiresAt != nil {
+		if !args.ExpiresAt.Time.After(time.Now()) {
+			return nil, errors.New("access token expiry date must be in the future")
+		}
+		expiresAt = &args.ExpiresAt.Time
 	}
 
-	id, token, err := db.AccessTokens.Create(ctx, userID, args.Scopes, args.Note, actor.FromContext(ctx).UID)
-	return &createAccessTokenResult{id: marshalAccessTokenID(id), token: token}, err
+	id, token, err := db.AccessTokens.Create(ctx, userID, args.Scopes, args.Note, actor.FromContext(ctx).UID, expiresAt)
+	if err != nil {
+		return nil, err
+	}
+	auditlog.Log(ctx, auditlog.Event{
+		Action:      auditlog.ActionAccessTokenCreated,
+		SubjectType: auditlog.SubjectAccessToken,
+		SubjectID:   strconv.FormatInt(id, 10),
+		After: accessTokenAuditState(&db.AccessToken{
+			SubjectUserID: userID,
+			Scopes:        args.Scopes,
+			Note:          args.Note,
+			ExpiresAt:     expiresAt,
+		}),
+	})
+	return &createAccessTokenResult{id: marshalAccessTokenID(id), token: token}, nil
+}
+
+// accessTokenAuditState returns the state of the access token that is recorded in the audit log.
+func accessTokenAuditState(t *db.AccessToken) interface{} {
+	return map[string]interface{}{
+		"subjectUserID": t.SubjectUserID,
+		"scopes":        t.Scopes,
+		"note":          t.Note,
+		"expiresAt":     t.ExpiresAt,
+	}
 }
 
 type createAccessTokenResult struct {
@@ -115,6 +157,12 @@ func (r *schemaResolver) DeleteAccessToken(ctx context.Context, args *deleteAcce
 		if err := db.AccessTokens.DeleteByID(ctx, token.ID, token.SubjectUserID); err != nil {
 			return nil, err
 		}
+		auditlog.Log(ctx, auditlog.Event{
+			Action:      auditlog.ActionAccessTokenDeleted,
+			SubjectType: auditlog.SubjectAccessToken,
+			SubjectID:   strconv.FormatInt(token.ID, 10),
+			Before:      accessTokenAuditState(token),
+		})
 
 	case args.ByToken != nil:
 		// 🚨 SECURITY: This is easier than the ByID case because anyone holding the access token's
@@ -122,6 +170,11 @@ func (r *schemaResolver) DeleteAccessToken(ctx context.Context, args *deleteAcce
 		if err := db.AccessTokens.DeleteByToken(ctx, *args.ByToken); err != nil {
 			return nil, err
 		}
+		// The ID of the access token is unknown, but its deletion is still recorded.
+		auditlog.Log(ctx, auditlog.Event{
+			Action:      auditlog.ActionAccessTokenDeleted,
+			SubjectType: auditlog.SubjectAccessToken,
+		})
 	}
 
 	return &EmptyResponse{}, nil
@@ -4,6 +4,7 @@ import (
 	"context"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/graph-gophers/graphql-go"
 	"github.com/graph-gophers/graphql-go/gqltesting"
@@ -17,7 +18,7 @@ import (
 // 🚨 SECURITY: This tests that users can't create tokens for users they aren't allowed to do so for.
 func TestMutation_CreateAccessToken(t *testing.T) {
 	mockAccessTokensCreate := func(t *testing.T, wantCreatorUserID int32, wantScopes []string) {
-		db.Mocks.AccessTokens.Create = func(subjectUserID int32, scopes []string, note string, creatorUserID int32) (int64, string, error) {
+		db.Mocks.AccessTokens.Create = func(subjectUserID int32, scopes []string, note string, creatorUserID int32, expiresAt *time.Time) (int64, string, error) {
 			if want := int32(1); subjectUserID != want {
 				t.Errorf("got %v, want %v", subjectUserID, want)
 			}
@@ -162,6 +163,61 @@ func TestMutation_CreateAccessToken(t *testing.T) {
 		})
 	})
 
+	t.Run("authenticated as user, using narrower scopes with expiry", func(t *testing.T) {
+		resetMocks()
+		expiresAt := time.Now().Add(time.Hour)
+		db.Mocks.AccessTokens.Create = func(subjectUserID int32, scopes []string, note string, creatorUserID int32, gotExpiresAt *time.Time) (int64, string, error) {
+			if want := []string{authz.ScopeCodeIntelUpload, authz.ScopeSearchRead}; !reflect.DeepEqual(scopes, want) {
+				t.Errorf("got %q, want %q", scopes, want)
+			}
+			if gotExpiresAt == nil || !gotExpiresAt.Equal(expiresAt) {
+				t.Errorf("got expiry %v, want %v", gotExpiresAt, expiresAt)
+			}
+			return 1, "t", nil
+		}
+
+		ctx := actor.WithActor(context.Background(), &actor.Actor{UID: 1})
+		if _, err := (&schemaResolver{}).CreateAccessToken(ctx, &createAccessTokenInput{
+			User:      uid1GQLID,
+			Scopes:    []string{authz.ScopeSearchRead, authz.ScopeCodeIntelUpload},
+			Note:      "n",
+			ExpiresAt: &DateTime{Time: expiresAt},
+		}); err != nil {
+			t.Fatal(err)
+		}
+
+		// Expiry dates in the past are rejected.
+		if _, err := (&schemaResolver{}).CreateAccessToken(ctx, &createAccessTokenInput{
+			User:      uid1GQLID,
+			Scopes:    []string{authz.ScopeSearchRead},
+			Note:      "n",
+			ExpiresAt: &DateTime{Time: time.Now().Add(-time.Hour)},
+		}); err == nil {
+			t.Error("Expected error, but there was none")
+		}
+	})
+
+	t.Run("authenticated as site admin, using sudo scope without user:all", func(t *testing.T) {
+		resetMocks()
+		db.Mocks.Users.GetByCurrentAuthUser = func(ctx context.Context) (*types.User, error) {
+			return &types.User{ID: 1, SiteAdmin: true}, nil
+		}
+		defer func() { db.Mocks.Users.GetByCurrentAuthUser = nil }()
+
+		ctx := actor.WithActor(context.Background(), &actor.Actor{UID: 1})
+		result, err := (&schemaResolver{}).CreateAccessToken(ctx, &createAccessTokenInput{
+			User:   uid1GQLID,
+			Scopes: []string{authz.ScopeSiteAdminSudo},
+			Note:   "n",
+		})
+		if err == nil {
+			t.Error("Expected error, but there was none")
+		}
+		if result != nil {
+			t.Errorf("got result %v, want nil", result)
+		}
+	})
+
 	t.Run("unauthenticated", func(t *testing.T) {
 		resetMocks()
 		db.Mocks.Users.GetByCurrentAuthUser = func(ctx context.Context) (*types.User, error) { return nil, db.ErrNoCurrentUser }
@@ -0,0 +1,219 @@
+package graphqlbackend
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"sync"
+
+	"github.com/graph-gophers/graphql-go"
+	"github.com/graph-gophers/graphql-go/relay"
+	"github.com/sergi/go-diff/diffmatchpatch"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend/graphqlutil"
+	"github.com/sourcegraph/sourcegraph/internal/db"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+)
+
+type auditLogArgs struct {
+	graphqlutil.ConnectionArgs
+	Actions     *[]string
+	Actor       *graphql.ID
+	SubjectType *string
+	SubjectID   *string
+	Since       *DateTime
+	Until       *DateTime
+}
+
+func (r *siteResolver) AuditLog(ctx context.Context, args *auditLogArgs) (*auditLogEntryConnectionResolver, error) {
+	// 🚨 SECURITY: Only site admins can view the audit log.
+	if err := backend.CheckCurrentUserIsSiteAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	var opt db.AuditLogListOptions
+	if args.Actions != nil {
+		opt.Actions = *args.Actions
+	}
+	if args.Actor != nil {
+		userID, err := UnmarshalUserID(*args.Actor)
+		if err != nil {
+			return nil, err
+		}
+		opt.ActorUserID = userID
+	}
+	if args.SubjectType != nil {
+		opt.SubjectType = *args.SubjectType
+	}
+	if args.SubjectID != nil {
+		opt.SubjectID = *args.SubjectID
+	}
+	if args.Since != nil {
+		opt.Since = &args.Since.Time
+	}
+	if args.Until != nil {
+		opt.Until = &args.Until.Time
+	}
+	args.ConnectionArgs.Set(&opt.LimitOffset)
+	return &auditLogEntryConnectionResolver{opt: opt}, nil
+}
+
+// auditLogEntryConnectionResolver resolves a list of audit log entries.
+//
+// 🚨 SECURITY: When instantiating an auditLogEntryConnectionResolver value, the caller MUST check
+// that the actor is a site admin.
+type auditLogEntryConnectionResolver struct {
+	opt db.AuditLogListOptions
+
+	// cache results because they are used by multiple fields
+	once    sync.Once
+	entries []*db.AuditLogEntry
+	err     error
+}
+
+func (r *auditLogEntryConnectionResolver) compute(ctx context.Context) ([]*db.AuditLogEntry, error) {
+	r.once.Do(func() {
+		opt2 := r.opt
+		if opt2.LimitOffset != nil {
+			tmp := *opt2.LimitOffset
+			opt2.LimitOffset = &tmp
+			opt2.Limit++ // so we can detect if there is a next page
+		}
+
+		r.entries, r.err = db.AuditLog.List(ctx, opt2)
+	})
+	return r.entries, r.err
+}
+
+func (r *auditLogEntryConnectionResolver) Nodes(ctx context.Context) ([]*auditLogEntryResolver, error) {
+	entries, err := r.compute(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if r.opt.LimitOffset != nil && len(entries) > r.opt.LimitOffset.Limit {
+		entries = entries[:r.opt.LimitOffset.Limit]
+	}
+
+	l := make([]*auditLogEntryResolver, 0, len(entries))
+	for _, entry := range entries {
+		l = append(l, &auditLogEntryResolver{entry: entry})
+	}
+	return l, nil
+}
+
+func (r *auditLogEntryConnectionResolver) TotalCount(ctx context.Context) (int32, error) {
+	count, err := db.AuditLog.Count(ctx, r.opt)
+	return int32(count), err
+}
+
+func (r *auditLogEntryConnectionResolver) PageInfo(ctx context.Context) (*graphqlutil.PageInfo, error) {
+	entries, err := r.compute(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return graphqlutil.HasNextPage(r.opt.LimitOffset != nil && len(entries) > r.opt.Limit), nil
+}
+
+type auditLogEntryResolver struct {
+	entry *db.AuditLogEntry
+}
+
+func marshalAuditLogEntryID(id int64) graphql.ID { return relay.MarshalID("AuditLogEntry", id) }
+
+func (r *auditLogEntryResolver) ID() graphql.ID { return marshalAuditLogEntryID(r.entry.ID) }
+
+func (r *auditLogEntryResolver) Action() string { return r.entry.Action }
+
+func (r *auditLogEntryResolver) Actor(ctx context.Context) (*UserResolver, error) {
+	if r.entry.ActorUserID == 0 {
+		return nil, nil
+	}
+	user, err := UserByIDInt32(ctx, r.entry.ActorUserID)
+	if errcode.IsNotFound(err) {
+		return nil, nil
+	}
+	return user, err
+}
+
+func (r *auditLogEntryResolver) ActorIP() string { return r.entry.ActorIP }
+
+func (r *auditLogEntryResolver) SubjectType() string { return r.entry.SubjectType }
+
+func (r *auditLogEntryResolver) SubjectID() string { return r.entry.SubjectID }
+
+func (r *auditLogEntryResolver) Before() (*JSONValue, error) { return jsonValueOrNil(r.entry.Before) }
+
+func (r *auditLogEntryResolver) After() (*JSONValue, error) { return jsonValueOrNil(r.entry.After) }
+
+func (r *auditLogEntryResolver) Diff() (*string, error) {
+	if r.entry.Before == nil && r.entry.After == nil {
+		return nil, nil
+	}
+	before, err := indentJSON(r.entry.Before)
+	if err != nil {
+		return nil, err
+	}
+	after, err := indentJSON(r.entry.After)
+	if err != nil {
+		return nil, err
+	}
+	diff := lineDiff(before, after)
+	return &diff, nil
+}
+
+func (r *auditLogEntryResolver) CreatedAt() DateTime { return DateTime{Time: r.entry.CreatedAt} }
+
+func jsonValueOrNil(raw json.RawMessage) (*JSONValue, error) {
+	if raw == nil {
+		return nil, nil
+	}
+	var v JSONValue
+	if err := json.Unmarshal(raw, &v); err != nil {
+		return nil, err
+	}
+	return &v, nil
+}
+
+// indentJSON returns the indented representation of the JSON value (with object keys sorted), so
+// that each property is on its own line. It returns "" for a nil value.
+func indentJSON(raw json.RawMessage) (string, error) {
+	if raw == nil {
+		return "", nil
+	}
+	var v interface{}
+	if err := json.Unmarshal(raw, &v); err != nil {
+		return "", err
+	}
+	b, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(b) + "\n", nil
+}
+
+// lineDiff returns a diff of the lines of a and b, in which each line is prefixed with "-" if it
+// was removed, "+" if it was added or " " if it is unchanged.
+func lineDiff(a, b string) string {
+	dmp := diffmatchpatch.New()
+	chars1, chars2, lines := dmp.DiffLinesToChars(a, b)
+	diffs := dmp.DiffCharsToLines(dmp.DiffMain(chars1, chars2, false), lines)
+
+	var sb strings.Builder
+	for _, d := range diffs {
+		var prefix string
+		switch d.Type {
+		case diffmatchpatch.DiffDelete:
+			prefix = "-"
+		case diffmatchpatch.DiffInsert:
+			prefix = "+"
+		default:
+			prefix = " "
+		}
+		for _, line := range strings.SplitAfter(d.Text, "\n") {
+			if line != "" {
+				sb.WriteString(prefix + line)
+			}
+		}
+	}
+	return sb.String()
+}
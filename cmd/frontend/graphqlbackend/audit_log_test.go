@@ -0,0 +1,92 @@
+package graphqlbackend
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/db"
+)
+
+func TestSiteAuditLog(t *testing.T) {
+	resetMocks()
+	defer resetMocks()
+
+	ctx := actor.WithActor(context.Background(), &actor.Actor{UID: 1})
+
+	t.Run("non-admin", func(t *testing.T) {
+		db.Mocks.Users.GetByCurrentAuthUser = func(context.Context) (*types.User, error) {
+			return &types.User{ID: 1}, nil
+		}
+		if _, err := (&siteResolver{}).AuditLog(ctx, &auditLogArgs{}); err != backend.ErrMustBeSiteAdmin {
+			t.Errorf("got error %v, want %v", err, backend.ErrMustBeSiteAdmin)
+		}
+	})
+
+	t.Run("site admin", func(t *testing.T) {
+		db.Mocks.Users.GetByCurrentAuthUser = func(context.Context) (*types.User, error) {
+			return &types.User{ID: 1, SiteAdmin: true}, nil
+		}
+		since := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+		db.Mocks.AuditLog.List = func(_ context.Context, opt db.AuditLogListOptions) ([]*db.AuditLogEntry, error) {
+			want := db.AuditLogListOptions{
+				Actions:     []string{"SiteConfigUpdated"},
+				ActorUserID: 2,
+				Since:       &since,
+				LimitOffset: &db.LimitOffset{Limit: 2},
+			}
+			if !reflect.DeepEqual(opt, want) {
+				t.Errorf("got options %+v, want %+v", opt, want)
+			}
+			return []*db.AuditLogEntry{
+				{ID: 3, Action: "SiteConfigUpdated", Before: json.RawMessage(`{"a":1,"b":true}`), After: json.RawMessage(`{"a":2,"b":true}`)},
+			}, nil
+		}
+
+		first := int32(1)
+		actions := []string{"SiteConfigUpdated"}
+		actorID := MarshalUserID(2)
+		args := &auditLogArgs{
+			Actions: &actions,
+			Actor:   &actorID,
+			Since:   &DateTime{Time: since},
+		}
+		args.First = &first
+		conn, err := (&siteResolver{}).AuditLog(ctx, args)
+		if err != nil {
+			t.Fatal(err)
+		}
+		nodes, err := conn.Nodes(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(nodes) != 1 {
+			t.Fatalf("got %d nodes, want 1", len(nodes))
+		}
+		diff, err := nodes[0].Diff()
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := " {\n-  \"a\": 1,\n+  \"a\": 2,\n   \"b\": true\n }\n"
+		if diff == nil || *diff != want {
+			t.Errorf("got diff %v, want %q", diff, want)
+		}
+	})
+}
+
+func TestLineDiff(t *testing.T) {
+	for _, tc := range []struct{ a, b, want string }{
+		{a: "", b: "x\ny\n", want: "+x\n+y\n"},
+		{a: "x\ny\n", b: "", want: "-x\n-y\n"},
+		{a: "x\ny\n", b: "x\nz\n", want: " x\n-y\n+z\n"},
+	} {
+		if got := lineDiff(tc.a, tc.b); got != tc.want {
+			t.Errorf("lineDiff(%q, %q) = %q, want %q", tc.a, tc.b, got, tc.want)
+		}
+	}
+}
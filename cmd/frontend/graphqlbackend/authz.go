@@ -10,8 +10,10 @@ import (
 type AuthzResolver interface {
 	// Mutations
 	SetRepositoryPermissionsForUsers(ctx context.Context, args *RepoPermsArgs) (*EmptyResponse, error)
+	SetSubRepositoryPermissionsForUsers(ctx context.Context, args *SubRepoPermsArgs) (*EmptyResponse, error)
 	ScheduleRepositoryPermissionsSync(ctx context.Context, args *RepositoryIDArgs) (*EmptyResponse, error)
 	ScheduleUserPermissionsSync(ctx context.Context, args *UserIDArgs) (*EmptyResponse, error)
+	SyncRepositoryPermissionsNow(ctx context.Context, args *UserRepositoryArgs) (RepositoryPermissionExplanationResolver, error)
 
 	// Queries
 	AuthorizedUserRepositories(ctx context.Context, args *AuthorizedRepoArgs) (RepositoryConnectionResolver, error)
@@ -21,6 +23,7 @@ type AuthzResolver interface {
 	// Helpers
 	RepositoryPermissionsInfo(ctx context.Context, repoID graphql.ID) (PermissionsInfoResolver, error)
 	UserPermissionsInfo(ctx context.Context, userID graphql.ID) (PermissionsInfoResolver, error)
+	ExplainRepositoryPermission(ctx context.Context, args *UserRepositoryArgs) (RepositoryPermissionExplanationResolver, error)
 }
 
 var authzInEnterprise = errors.New("authorization mutations and queries are only available in enterprise")
@@ -33,6 +36,10 @@ func (defaultAuthzResolver) SetRepositoryPermissionsForUsers(ctx context.Context
 	return nil, authzInEnterprise
 }
 
+func (defaultAuthzResolver) SetSubRepositoryPermissionsForUsers(ctx context.Context, args *SubRepoPermsArgs) (*EmptyResponse, error) {
+	return nil, authzInEnterprise
+}
+
 func (defaultAuthzResolver) ScheduleRepositoryPermissionsSync(ctx context.Context, args *RepositoryIDArgs) (*EmptyResponse, error) {
 	return nil, authzInEnterprise
 }
@@ -41,6 +48,10 @@ func (defaultAuthzResolver) ScheduleUserPermissionsSync(ctx context.Context, arg
 	return nil, authzInEnterprise
 }
 
+func (defaultAuthzResolver) SyncRepositoryPermissionsNow(ctx context.Context, args *UserRepositoryArgs) (RepositoryPermissionExplanationResolver, error) {
+	return nil, authzInEnterprise
+}
+
 func (defaultAuthzResolver) AuthorizedUserRepositories(ctx context.Context, args *AuthorizedRepoArgs) (RepositoryConnectionResolver, error) {
 	return nil, authzInEnterprise
 }
@@ -65,6 +76,10 @@ func (defaultAuthzResolver) UserPermissionsInfo(ctx context.Context, userID grap
 	return nil, nil
 }
 
+func (defaultAuthzResolver) ExplainRepositoryPermission(ctx context.Context, args *UserRepositoryArgs) (RepositoryPermissionExplanationResolver, error) {
+	return nil, authzInEnterprise
+}
+
 type RepositoryIDArgs struct {
 	Repository graphql.ID
 }
@@ -73,12 +88,27 @@ type UserIDArgs struct {
 	User graphql.ID
 }
 
+type UserRepositoryArgs struct {
+	User       graphql.ID
+	Repository graphql.ID
+}
+
 type RepoPermsArgs struct {
 	Repository      graphql.ID
 	UserPermissions []struct {
 		BindID     string
 		Permission string
 	}
+	Orgs *[]graphql.ID
+}
+
+type SubRepoPermsArgs struct {
+	Repository      graphql.ID
+	UserPermissions []struct {
+		BindID       string
+		PathIncludes *[]string
+		PathExcludes *[]string
+	}
 }
 
 type AuthorizedRepoArgs struct {
@@ -94,3 +124,25 @@ type PermissionsInfoResolver interface {
 	SyncedAt() *DateTime
 	UpdatedAt() DateTime
 }
+
+type RepositoryPermissionExplanationResolver interface {
+	User() *UserResolver
+	Repository() *RepositoryResolver
+	Access() bool
+	Reasons() []string
+	Private() bool
+	Unrestricted() bool
+	ExplicitPermissions() bool
+	AuthzProvider() AuthzProviderExplanationResolver
+	UserPermissions() PermissionsInfoResolver
+	UserPermissionsIncludeRepository() bool
+	RepositoryPermissions() PermissionsInfoResolver
+	RepositoryPermissionsIncludeUser() bool
+	PendingPermissions() []string
+}
+
+type AuthzProviderExplanationResolver interface {
+	ServiceType() string
+	ServiceID() string
+	AccountID() *string
+}
@@ -43,10 +43,39 @@ type DeleteCampaignArgs struct {
 	Campaign graphql.ID
 }
 
+type CreateCampaignCredentialArgs struct {
+	Namespace           *graphql.ID
+	ExternalServiceKind string
+	ExternalServiceURL  string
+	Username            *string
+	Credential          string
+}
+
+type DeleteCampaignCredentialArgs struct {
+	CampaignCredential graphql.ID
+}
+
+type ListCampaignCredentialsArgs struct {
+	Namespace *graphql.ID
+	First     int32
+	After     *string
+}
+
 type SyncChangesetArgs struct {
 	Changeset graphql.ID
 }
 
+type CreateChangesetCommentsArgs struct {
+	Campaign   graphql.ID
+	Changesets []graphql.ID
+	Body       string
+}
+
+type BulkChangesetsArgs struct {
+	Campaign   graphql.ID
+	Changesets []graphql.ID
+}
+
 type CreateChangesetSpecArgs struct {
 	ChangesetSpec string
 }
@@ -58,6 +87,10 @@ type CreateCampaignSpecArgs struct {
 	ChangesetSpecs []graphql.ID
 }
 
+type ExecuteCampaignSpecArgs struct {
+	CampaignSpec graphql.ID
+}
+
 type ChangesetSpecsConnectionArgs struct {
 	First int32
 	After *string
@@ -82,7 +115,15 @@ type CampaignsResolver interface {
 	DeleteCampaign(ctx context.Context, args *DeleteCampaignArgs) (*EmptyResponse, error)
 	CreateChangesetSpec(ctx context.Context, args *CreateChangesetSpecArgs) (ChangesetSpecResolver, error)
 	CreateCampaignSpec(ctx context.Context, args *CreateCampaignSpecArgs) (CampaignSpecResolver, error)
+	ExecuteCampaignSpec(ctx context.Context, args *ExecuteCampaignSpecArgs) (CampaignSpecResolver, error)
 	SyncChangeset(ctx context.Context, args *SyncChangesetArgs) (*EmptyResponse, error)
+	CreateChangesetComments(ctx context.Context, args *CreateChangesetCommentsArgs) (BulkOperationResolver, error)
+	ReenqueueChangesets(ctx context.Context, args *BulkChangesetsArgs) (BulkOperationResolver, error)
+	MergeChangesets(ctx context.Context, args *BulkChangesetsArgs) (BulkOperationResolver, error)
+	CloseChangesets(ctx context.Context, args *BulkChangesetsArgs) (BulkOperationResolver, error)
+	DetachChangesets(ctx context.Context, args *BulkChangesetsArgs) (BulkOperationResolver, error)
+	CreateCampaignCredential(ctx context.Context, args *CreateCampaignCredentialArgs) (CampaignCredentialResolver, error)
+	DeleteCampaignCredential(ctx context.Context, args *DeleteCampaignCredentialArgs) (*EmptyResponse, error)
 
 	// Queries
 	Campaigns(ctx context.Context, args *ListCampaignsArgs) (CampaignsConnectionResolver, error)
@@ -92,6 +133,23 @@ type CampaignsResolver interface {
 
 	CampaignSpecByID(ctx context.Context, id graphql.ID) (CampaignSpecResolver, error)
 	ChangesetSpecByID(ctx context.Context, id graphql.ID) (ChangesetSpecResolver, error)
+
+	CampaignCredentials(ctx context.Context, args *ListCampaignCredentialsArgs) (CampaignCredentialConnectionResolver, error)
+}
+
+type CampaignCredentialResolver interface {
+	ID() graphql.ID
+	Namespace(ctx context.Context) (*NamespaceResolver, error)
+	ExternalServiceKind() string
+	ExternalServiceURL() string
+	Username() *string
+	CreatedAt() DateTime
+	UpdatedAt() DateTime
+}
+
+type CampaignCredentialConnectionResolver interface {
+	Nodes(ctx context.Context) ([]CampaignCredentialResolver, error)
+	PageInfo(ctx context.Context) (*graphqlutil.PageInfo, error)
 }
 
 type CampaignSpecResolver interface {
@@ -116,6 +174,20 @@ type CampaignSpecResolver interface {
 	DiffStat(ctx context.Context) (*DiffStat, error)
 
 	AppliesToCampaign(ctx context.Context) (CampaignResolver, error)
+
+	Executions(ctx context.Context) ([]CampaignSpecExecutionResolver, error)
+}
+
+type CampaignSpecExecutionResolver interface {
+	Repository(ctx context.Context) (*RepositoryResolver, error)
+	BaseRef() string
+	BaseRev() string
+	State() campaigns.CampaignSpecExecutionState
+	FailureMessage() *string
+	ChangesetSpecs(ctx context.Context) ([]ChangesetSpecResolver, error)
+	CacheHit() bool
+	CreatedAt() DateTime
+	FinishedAt() *DateTime
 }
 
 type CampaignDescriptionResolver interface {
@@ -177,6 +249,7 @@ type GitBranchChangesetDescriptionResolver interface {
 	Commits() []GitCommitDescriptionResolver
 
 	Published() bool
+	Draft() bool
 }
 
 type GitCommitDescriptionResolver interface {
@@ -189,6 +262,11 @@ type ChangesetCountsArgs struct {
 	To   *DateTime
 }
 
+type CampaignAnalyticsArgs struct {
+	From *DateTime
+	To   *DateTime
+}
+
 type ListChangesetsArgs struct {
 	First                       int32
 	After                       *string
@@ -218,6 +296,61 @@ type CampaignResolver interface {
 	ClosedAt() *DateTime
 	DiffStat(ctx context.Context) (*DiffStat, error)
 	CurrentSpec(ctx context.Context) (CampaignSpecResolver, error)
+	BulkOperations(ctx context.Context) ([]BulkOperationResolver, error)
+	Analytics(ctx context.Context, args *CampaignAnalyticsArgs) (CampaignAnalyticsResolver, error)
+}
+
+type CampaignSnapshotsConnectionArgs struct {
+	First int32
+	After *string
+}
+
+type CampaignAnalyticsResolver interface {
+	Snapshots(ctx context.Context, args *CampaignSnapshotsConnectionArgs) (CampaignSnapshotConnectionResolver, error)
+	Current(ctx context.Context) (CampaignSnapshotResolver, error)
+}
+
+type CampaignSnapshotConnectionResolver interface {
+	Nodes(ctx context.Context) ([]CampaignSnapshotResolver, error)
+	TotalCount(ctx context.Context) (int32, error)
+	PageInfo(ctx context.Context) (*graphqlutil.PageInfo, error)
+}
+
+type CampaignSnapshotResolver interface {
+	Date() DateTime
+	Total() int32
+	Open() int32
+	Merged() int32
+	Closed() int32
+	Draft() int32
+	FailedChecks() int32
+	OpenApproved() int32
+	OpenChangesRequested() int32
+	OpenPending() int32
+	MedianTimeToMergeSeconds() *int32
+	RepositoryStallTimes(ctx context.Context) ([]RepositoryStallTimeResolver, error)
+}
+
+type RepositoryStallTimeResolver interface {
+	Repository() *RepositoryResolver
+	StallTimeSeconds() int32
+}
+
+type BulkOperationResolver interface {
+	ID() graphql.ID
+	Type() campaigns.ChangesetJobType
+	State() campaigns.BulkOperationState
+	Progress() float64
+	Errors(ctx context.Context) ([]ChangesetJobErrorResolver, error)
+	Initiator(ctx context.Context) (*UserResolver, error)
+	ChangesetCount() int32
+	CreatedAt() DateTime
+	FinishedAt() *DateTime
+}
+
+type ChangesetJobErrorResolver interface {
+	Changeset() ChangesetResolver
+	Error() *string
 }
 
 type CampaignsConnectionResolver interface {
@@ -285,6 +418,7 @@ type ExternalChangesetResolver interface {
 	ExternalURL() (*externallink.Resolver, error)
 	ReviewState(context.Context) *campaigns.ChangesetReviewState
 	CheckState() *campaigns.ChangesetCheckState
+	RebaseState() *campaigns.ChangesetRebaseState
 	Repository(ctx context.Context) *RepositoryResolver
 
 	Events(ctx context.Context, args *ChangesetEventsConnectionArgs) (ChangesetEventsConnectionResolver, error)
@@ -343,6 +477,10 @@ func (defaultCampaignsResolver) CreateCampaignSpec(ctx context.Context, args *Cr
 	return nil, campaignsOnlyInEnterprise
 }
 
+func (defaultCampaignsResolver) ExecuteCampaignSpec(ctx context.Context, args *ExecuteCampaignSpecArgs) (CampaignSpecResolver, error) {
+	return nil, campaignsOnlyInEnterprise
+}
+
 func (defaultCampaignsResolver) MoveCampaign(ctx context.Context, args *MoveCampaignArgs) (CampaignResolver, error) {
 	return nil, campaignsOnlyInEnterprise
 }
@@ -355,10 +493,38 @@ func (defaultCampaignsResolver) SyncChangeset(ctx context.Context, args *SyncCha
 	return nil, campaignsOnlyInEnterprise
 }
 
+func (defaultCampaignsResolver) CreateChangesetComments(ctx context.Context, args *CreateChangesetCommentsArgs) (BulkOperationResolver, error) {
+	return nil, campaignsOnlyInEnterprise
+}
+
+func (defaultCampaignsResolver) ReenqueueChangesets(ctx context.Context, args *BulkChangesetsArgs) (BulkOperationResolver, error) {
+	return nil, campaignsOnlyInEnterprise
+}
+
+func (defaultCampaignsResolver) MergeChangesets(ctx context.Context, args *BulkChangesetsArgs) (BulkOperationResolver, error) {
+	return nil, campaignsOnlyInEnterprise
+}
+
+func (defaultCampaignsResolver) CloseChangesets(ctx context.Context, args *BulkChangesetsArgs) (BulkOperationResolver, error) {
+	return nil, campaignsOnlyInEnterprise
+}
+
+func (defaultCampaignsResolver) DetachChangesets(ctx context.Context, args *BulkChangesetsArgs) (BulkOperationResolver, error) {
+	return nil, campaignsOnlyInEnterprise
+}
+
 func (defaultCampaignsResolver) DeleteCampaign(ctx context.Context, args *DeleteCampaignArgs) (*EmptyResponse, error) {
 	return nil, campaignsOnlyInEnterprise
 }
 
+func (defaultCampaignsResolver) CreateCampaignCredential(ctx context.Context, args *CreateCampaignCredentialArgs) (CampaignCredentialResolver, error) {
+	return nil, campaignsOnlyInEnterprise
+}
+
+func (defaultCampaignsResolver) DeleteCampaignCredential(ctx context.Context, args *DeleteCampaignCredentialArgs) (*EmptyResponse, error) {
+	return nil, campaignsOnlyInEnterprise
+}
+
 // Queries
 func (defaultCampaignsResolver) CampaignByID(ctx context.Context, id graphql.ID) (CampaignResolver, error) {
 	return nil, campaignsOnlyInEnterprise
@@ -383,3 +549,7 @@ func (defaultCampaignsResolver) CampaignSpecByID(ctx context.Context, id graphql
 func (defaultCampaignsResolver) ChangesetSpecByID(ctx context.Context, id graphql.ID) (ChangesetSpecResolver, error) {
 	return nil, campaignsOnlyInEnterprise
 }
+
+func (defaultCampaignsResolver) CampaignCredentials(ctx context.Context, args *ListCampaignCredentialsArgs) (CampaignCredentialConnectionResolver, error) {
+	return nil, campaignsOnlyInEnterprise
+}
@@ -15,6 +15,10 @@ type CodeIntelResolver interface {
 	LSIFUploads(ctx context.Context, args *LSIFUploadsQueryArgs) (LSIFUploadConnectionResolver, error)
 	LSIFUploadsByRepo(ctx context.Context, args *LSIFRepositoryUploadsQueryArgs) (LSIFUploadConnectionResolver, error)
 	DeleteLSIFUpload(ctx context.Context, id graphql.ID) (*EmptyResponse, error)
+	LSIFUploadRetentionByRepo(ctx context.Context, repositoryID graphql.ID) ([]LSIFUploadRetentionResolver, error)
+	LSIFDiagnostics(ctx context.Context, args *LSIFDiagnosticsQueryArgs) (DiagnosticConnectionResolver, error)
+	LSIFDiagnosticsByRepo(ctx context.Context, args *LSIFRepositoryDiagnosticsQueryArgs) (DiagnosticConnectionResolver, error)
+	LSIFDiagnosticCountsByRepo(ctx context.Context, repositoryID graphql.ID, args *LSIFDiagnosticFilterArgs) ([]LSIFDiagnosticCountResolver, error)
 	LSIFIndexByID(ctx context.Context, id graphql.ID) (LSIFIndexResolver, error)
 	LSIFIndexes(ctx context.Context, args *LSIFIndexesQueryArgs) (LSIFIndexConnectionResolver, error)
 	LSIFIndexesByRepo(ctx context.Context, args *LSIFRepositoryIndexesQueryArgs) (LSIFIndexConnectionResolver, error)
@@ -44,6 +48,22 @@ func (defaultCodeIntelResolver) DeleteLSIFUpload(ctx context.Context, id graphql
 	return nil, codeIntelOnlyInEnterprise
 }
 
+func (defaultCodeIntelResolver) LSIFUploadRetentionByRepo(ctx context.Context, repositoryID graphql.ID) ([]LSIFUploadRetentionResolver, error) {
+	return nil, codeIntelOnlyInEnterprise
+}
+
+func (defaultCodeIntelResolver) LSIFDiagnostics(ctx context.Context, args *LSIFDiagnosticsQueryArgs) (DiagnosticConnectionResolver, error) {
+	return nil, codeIntelOnlyInEnterprise
+}
+
+func (defaultCodeIntelResolver) LSIFDiagnosticsByRepo(ctx context.Context, args *LSIFRepositoryDiagnosticsQueryArgs) (DiagnosticConnectionResolver, error) {
+	return nil, codeIntelOnlyInEnterprise
+}
+
+func (defaultCodeIntelResolver) LSIFDiagnosticCountsByRepo(ctx context.Context, repositoryID graphql.ID, args *LSIFDiagnosticFilterArgs) ([]LSIFDiagnosticCountResolver, error) {
+	return nil, codeIntelOnlyInEnterprise
+}
+
 func (defaultCodeIntelResolver) LSIFIndexByID(ctx context.Context, id graphql.ID) (LSIFIndexResolver, error) {
 	return nil, codeIntelOnlyInEnterprise
 }
@@ -72,6 +92,10 @@ func (r *schemaResolver) LSIFIndexes(ctx context.Context, args *LSIFIndexesQuery
 	return r.CodeIntelResolver.LSIFIndexes(ctx, args)
 }
 
+func (r *schemaResolver) LSIFDiagnostics(ctx context.Context, args *LSIFDiagnosticsQueryArgs) (DiagnosticConnectionResolver, error) {
+	return r.CodeIntelResolver.LSIFDiagnostics(ctx, args)
+}
+
 func (r *schemaResolver) DeleteLSIFUpload(ctx context.Context, args *struct{ ID graphql.ID }) (*EmptyResponse, error) {
 	return r.CodeIntelResolver.DeleteLSIFUpload(ctx, args.ID)
 }
@@ -93,6 +117,30 @@ type LSIFRepositoryUploadsQueryArgs struct {
 	RepositoryID graphql.ID
 }
 
+type LSIFDiagnosticFilterArgs struct {
+	Severity *string
+	Source   *string
+	Code     *string
+}
+
+type LSIFDiagnosticsQueryArgs struct {
+	graphqlutil.ConnectionArgs
+	LSIFDiagnosticFilterArgs
+	After *string
+}
+
+type LSIFRepositoryDiagnosticsQueryArgs struct {
+	*LSIFDiagnosticsQueryArgs
+	RepositoryID graphql.ID
+}
+
+type LSIFDiagnosticCountResolver interface {
+	Upload(ctx context.Context) (LSIFUploadResolver, error)
+	Commit() string
+	FinishedAt() DateTime
+	Count() int32
+}
+
 type LSIFUploadResolver interface {
 	ID() graphql.ID
 	InputCommit() string
@@ -114,6 +162,13 @@ type LSIFUploadConnectionResolver interface {
 	PageInfo(ctx context.Context) (*graphqlutil.PageInfo, error)
 }
 
+type LSIFUploadRetentionResolver interface {
+	Upload() LSIFUploadResolver
+	RetainedBy() []string
+	Evaluated() bool
+	Expired() bool
+}
+
 type LSIFIndexesQueryArgs struct {
 	graphqlutil.ConnectionArgs
 	Query *string
@@ -180,7 +235,8 @@ type LSIFQueryPositionArgs struct {
 type LSIFPagedQueryPositionArgs struct {
 	LSIFQueryPositionArgs
 	graphqlutil.ConnectionArgs
-	After *string
+	After       *string
+	AllVersions bool
 }
 
 type LSIFDiagnosticsArgs struct {
@@ -201,6 +257,12 @@ type CodeIntelligenceRangeResolver interface {
 type LocationConnectionResolver interface {
 	Nodes(ctx context.Context) ([]LocationResolver, error)
 	PageInfo(ctx context.Context) (*graphqlutil.PageInfo, error)
+	RepositoryCounts(ctx context.Context) (*[]RepositoryReferenceCountResolver, error)
+}
+
+type RepositoryReferenceCountResolver interface {
+	Repository() *RepositoryResolver
+	Count() int32
 }
 
 type HoverResolver interface {
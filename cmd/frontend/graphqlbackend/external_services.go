@@ -18,6 +18,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
 	"github.com/sourcegraph/sourcegraph/internal/actor"
 	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/auditlog"
 	"github.com/sourcegraph/sourcegraph/internal/conf"
 	"github.com/sourcegraph/sourcegraph/internal/db"
 	"github.com/sourcegraph/sourcegraph/internal/env"
@@ -88,6 +89,12 @@ func (r *schemaResolver) AddExternalService(ctx context.Context, args *addExtern
 	if err := db.ExternalServices.Create(ctx, conf.Get, externalService); err != nil {
 		return nil, err
 	}
+	auditlog.Log(ctx, auditlog.Event{
+		Action:      auditlog.ActionExternalServiceCreated,
+		SubjectType: auditlog.SubjectExternalService,
+		SubjectID:   strconv.FormatInt(externalService.ID, 10),
+		After:       externalServiceAuditState(externalService),
+	})
 
 	res := &externalServiceResolver{externalService: externalService}
 	if err := syncExternalService(ctx, externalService); err != nil {
@@ -146,10 +153,18 @@ func (*schemaResolver) UpdateExternalService(ctx context.Context, args *updateEx
 	}
 
 	// Fetch from database again to get all fields with updated values.
+	before := es
 	es, err = db.ExternalServices.GetByID(ctx, id)
 	if err != nil {
 		return nil, err
 	}
+	auditlog.Log(ctx, auditlog.Event{
+		Action:      auditlog.ActionExternalServiceUpdated,
+		SubjectType: auditlog.SubjectExternalService,
+		SubjectID:   strconv.FormatInt(id, 10),
+		Before:      externalServiceAuditState(before),
+		After:       externalServiceAuditState(es),
+	})
 
 	res := &externalServiceResolver{externalService: es}
 	if err = syncExternalService(ctx, es); err != nil {
@@ -159,6 +174,17 @@ func (*schemaResolver) UpdateExternalService(ctx context.Context, args *updateEx
 	return res, nil
 }
 
+// externalServiceAuditState returns the state of the external service that is recorded in the audit
+// log.
+func externalServiceAuditState(es *types.ExternalService) interface{} {
+	return map[string]interface{}{
+		"kind":            es.Kind,
+		"displayName":     es.DisplayName,
+		"config":          auditlog.JSONC(es.Config),
+		"namespaceUserID": es.NamespaceUserID,
+	}
+}
+
 // Eagerly trigger a repo-updater sync.
 func syncExternalService(ctx context.Context, svc *types.ExternalService) error {
 	// Only give 5s to validate external service sync. Usually if there is a
@@ -217,6 +243,12 @@ func (*schemaResolver) DeleteExternalService(ctx context.Context, args *deleteEx
 	if err := db.ExternalServices.Delete(ctx, id); err != nil {
 		return nil, err
 	}
+	auditlog.Log(ctx, auditlog.Event{
+		Action:      auditlog.ActionExternalServiceDeleted,
+		SubjectType: auditlog.SubjectExternalService,
+		SubjectID:   strconv.FormatInt(id, 10),
+		Before:      externalServiceAuditState(es),
+	})
 	now := time.Now()
 	es.DeletedAt = &now
 
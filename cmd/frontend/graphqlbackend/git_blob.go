@@ -13,6 +13,11 @@ func (r *GitTreeEntryResolver) Blame(ctx context.Context,
 		StartLine int32
 		EndLine   int32
 	}) ([]*hunkResolver, error) {
+	// 🚨 SECURITY: Blame reveals file contents, so only return it if the user can read the file.
+	if err := checkSubRepoPerms(ctx, r.commit.repoResolver.repo, r.Path(), false); err != nil {
+		return nil, err
+	}
+
 	hunks, err := git.BlameFile(ctx, gitserver.Repo{Name: r.commit.repoResolver.repo.Name}, r.Path(), &git.BlameOptions{
 		NewestCommit: api.CommitID(r.commit.OID()),
 		StartLine:    int(args.StartLine),
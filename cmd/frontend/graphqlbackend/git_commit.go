@@ -197,6 +197,10 @@ func (r *GitCommitResolver) Tree(ctx context.Context, args *struct {
 	if !stat.Mode().IsDir() {
 		return nil, fmt.Errorf("not a directory: %q", args.Path)
 	}
+	// 🚨 SECURITY: Only return the tree if the user can read it.
+	if err := checkSubRepoPerms(ctx, r.repoResolver.repo, args.Path, true); err != nil {
+		return nil, err
+	}
 	return &GitTreeEntryResolver{
 		commit:      r,
 		stat:        stat,
@@ -218,6 +222,10 @@ func (r *GitCommitResolver) Blob(ctx context.Context, args *struct {
 	if !stat.Mode().IsRegular() {
 		return nil, fmt.Errorf("not a blob: %q", args.Path)
 	}
+	// 🚨 SECURITY: Only return the blob if the user can read it.
+	if err := checkSubRepoPerms(ctx, r.repoResolver.repo, args.Path, false); err != nil {
+		return nil, err
+	}
 	return &GitTreeEntryResolver{
 		commit: r,
 		stat:   stat,
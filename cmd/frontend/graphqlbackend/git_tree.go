@@ -9,7 +9,9 @@ import (
 
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend/graphqlutil"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
 	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/db"
 	"github.com/sourcegraph/sourcegraph/internal/vcs/git"
 )
 
@@ -52,6 +54,21 @@ func (r *GitTreeEntryResolver) entries(ctx context.Context, args *gitTreeEntryCo
 		}
 	}
 
+	// 🚨 SECURITY: Omit the entries the user cannot read.
+	subRepoPerms, err := db.SubRepoPermsForActor(ctx, r.commit.repoResolver.repo.ID)
+	if err != nil {
+		return nil, err
+	}
+	if subRepoPerms != nil {
+		readable := entries[:0]
+		for _, entry := range entries {
+			if subRepoPerms.CanRead(entry.Name(), entry.IsDir()) {
+				readable = append(readable, entry)
+			}
+		}
+		entries = readable
+	}
+
 	sort.Sort(byDirectory(entries))
 
 	if args.First != nil && len(entries) > int(*args.First) {
@@ -81,6 +98,19 @@ func (r *GitTreeEntryResolver) entries(ctx context.Context, args *gitTreeEntryCo
 	return l, nil
 }
 
+// checkSubRepoPerms returns an error for the path as if it did not exist if the
+// current user cannot read it within the repository.
+func checkSubRepoPerms(ctx context.Context, repo *types.Repo, path string, isDir bool) error {
+	m, err := db.SubRepoPermsForActor(ctx, repo.ID)
+	if err != nil {
+		return err
+	}
+	if !m.CanRead(path, isDir) {
+		return &os.PathError{Op: "ls-tree", Path: path, Err: os.ErrNotExist}
+	}
+	return nil
+}
+
 type byDirectory []os.FileInfo
 
 func (s byDirectory) Len() int {
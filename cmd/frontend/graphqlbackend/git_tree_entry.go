@@ -78,6 +78,12 @@ func (r *GitTreeEntryResolver) Content(ctx context.Context) (string, error) {
 		ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
 		defer cancel()
 
+		// 🚨 SECURITY: Tree entries are created in many places (e.g. search results and
+		// code intel locations), so check that the user can read the file before reading it.
+		if r.contentErr = checkSubRepoPerms(ctx, r.commit.repoResolver.repo, r.Path(), false); r.contentErr != nil {
+			return
+		}
+
 		cachedRepo, err := backend.CachedGitRepo(ctx, r.commit.repoResolver.repo)
 		if err != nil {
 			r.contentErr = err
@@ -299,6 +305,14 @@ func (r *GitTreeEntryResolver) IsSingleChild(ctx context.Context, args *gitTreeE
 func (r *GitTreeEntryResolver) LSIF(ctx context.Context, args *struct{ ToolName *string }) (GitBlobLSIFDataResolver, error) {
 	codeIntelRequests.WithLabelValues(trace.RequestOrigin(ctx)).Inc()
 
+	// 🚨 SECURITY: Don't return hovers, definitions, etc. for paths the user cannot read.
+	if err := checkSubRepoPerms(ctx, r.commit.repoResolver.repo, r.Path(), r.stat.IsDir()); err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
 	var toolName string
 	if args.ToolName != nil {
 		toolName = *args.ToolName
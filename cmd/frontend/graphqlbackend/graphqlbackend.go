@@ -347,7 +347,7 @@ func NewSchema(campaigns CampaignsResolver, codeIntel CodeIntelResolver, authz A
 	return graphql.ParseSchema(
 		Schema,
 		resolver,
-		graphql.Tracer(prometheusTracer{}),
+		graphql.Tracer(accessTokenScopeTracer{prometheusTracer{}}),
 		graphql.UseStringDescriptions(),
 	)
 }
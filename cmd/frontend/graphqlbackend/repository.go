@@ -320,6 +320,21 @@ func (r *RepositoryResolver) LSIFUploads(ctx context.Context, args *LSIFUploadsQ
 	})
 }
 
+func (r *RepositoryResolver) LSIFUploadRetention(ctx context.Context) ([]LSIFUploadRetentionResolver, error) {
+	return EnterpriseResolvers.codeIntelResolver.LSIFUploadRetentionByRepo(ctx, r.ID())
+}
+
+func (r *RepositoryResolver) LSIFDiagnostics(ctx context.Context, args *LSIFDiagnosticsQueryArgs) (DiagnosticConnectionResolver, error) {
+	return EnterpriseResolvers.codeIntelResolver.LSIFDiagnosticsByRepo(ctx, &LSIFRepositoryDiagnosticsQueryArgs{
+		LSIFDiagnosticsQueryArgs: args,
+		RepositoryID:             r.ID(),
+	})
+}
+
+func (r *RepositoryResolver) LSIFDiagnosticCounts(ctx context.Context, args *LSIFDiagnosticFilterArgs) ([]LSIFDiagnosticCountResolver, error) {
+	return EnterpriseResolvers.codeIntelResolver.LSIFDiagnosticCountsByRepo(ctx, r.ID(), args)
+}
+
 func (r *RepositoryResolver) LSIFIndexes(ctx context.Context, args *LSIFIndexesQueryArgs) (LSIFIndexConnectionResolver, error) {
 	return EnterpriseResolvers.codeIntelResolver.LSIFIndexesByRepo(ctx, &LSIFRepositoryIndexesQueryArgs{
 		LSIFIndexesQueryArgs: args,
@@ -15,6 +15,8 @@ import (
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend/graphqlutil"
 	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/db"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver"
 	"github.com/sourcegraph/sourcegraph/internal/highlight"
 	"github.com/sourcegraph/sourcegraph/internal/vcs/git"
@@ -247,6 +249,13 @@ func computeRepositoryComparisonDiff(cmp *RepositoryComparisonResolver) ComputeD
 				return
 			}
 
+			// 🚨 SECURITY: Omit the file diffs of paths the user cannot read.
+			var subRepoPerms *authz.SubRepoPermsMatcher
+			subRepoPerms, err = db.SubRepoPermsForActor(ctx, cmp.repo.repo.ID)
+			if err != nil {
+				return
+			}
+
 			var iter *git.DiffFileIterator
 			iter, err = git.Diff(ctx, git.DiffOptions{
 				Repo: *cachedRepo,
@@ -271,6 +280,9 @@ func computeRepositoryComparisonDiff(cmp *RepositoryComparisonResolver) ComputeD
 				if err != nil {
 					return
 				}
+				if !canReadFileDiff(subRepoPerms, fileDiff) {
+					continue
+				}
 				fileDiffs = append(fileDiffs, fileDiff)
 				if args.First != nil && len(fileDiffs) == int(*args.First+afterIdx) {
 					// Check for hasNextPage.
@@ -291,6 +303,17 @@ func computeRepositoryComparisonDiff(cmp *RepositoryComparisonResolver) ComputeD
 	}
 }
 
+// canReadFileDiff reports whether both sides of the file diff can be read, as
+// the diff reveals the contents of both.
+func canReadFileDiff(m *authz.SubRepoPermsMatcher, fileDiff *diff.FileDiff) bool {
+	for _, name := range []string{fileDiff.OrigName, fileDiff.NewName} {
+		if diffPathOrNull(name) != nil && !m.CanReadFile(name) {
+			return false
+		}
+	}
+	return true
+}
+
 // ComputeDiffFunc is a function that computes FileDiffs for the given args. It
 // returns the diffs, the starting index from which to return entries (`after`
 // param), whether there's a next page, and an optional error.
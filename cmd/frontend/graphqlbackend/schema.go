@@ -198,6 +198,26 @@ type Mutation {
     """
     updatePassword(oldPassword: String!, newPassword: String!): EmptyResponse
     """
+    Starts the enrollment of the current user in two-factor authentication, replacing the secret of a previous
+    enrollment that was not confirmed. The enrollment is completed with Mutation.confirmTwoFactorAuth.
+
+    Only users of the builtin username-password authentication provider may perform this mutation.
+    """
+    enrollTwoFactorAuth: TwoFactorAuthEnrollment!
+    """
+    Completes the enrollment of the current user in two-factor authentication with a code of their authenticator
+    app. The result is the user's recovery codes, which can each be used once instead of a code of the
+    authenticator app. They are not accessible after this mutation.
+    """
+    confirmTwoFactorAuth(code: String!): [String!]!
+    """
+    Disables two-factor authentication of a user. Users must provide a code of their authenticator app or a
+    recovery code, and can't disable two-factor authentication when the site configuration requires it. Site
+    admins may disable two-factor authentication of other users without a code, for example when they lost
+    their authenticator app and recovery codes.
+    """
+    disableTwoFactorAuth(user: ID!, code: String): EmptyResponse
+    """
     Creates an access token that grants the privileges of the specified user (referred to as the access token's
     "subject" user after token creation). The result is the access token value, which the caller is responsible
     for storing (it is not accessible by Sourcegraph after creation).
@@ -206,11 +226,16 @@ type Mutation {
 
     - "user:all": Full control of all resources accessible to the user account.
     - "site-admin:sudo": Ability to perform any action as any other user. (Only site admins may create tokens
-      with this scope.)
+      with this scope, and it must be combined with "user:all".)
+    - "search:read": Read-only access to searches and repositories, but no other queries and no mutations.
+    - "codeintel:upload": Ability to upload LSIF code intelligence data.
+    - "campaigns:write": Access to all queries plus the ability to create and modify campaigns and changesets.
+
+    If expiresAt is set, the access token can't be used after that date.
 
     Only the user or site admins may perform this mutation.
     """
-    createAccessToken(user: ID!, scopes: [String!]!, note: String!): CreateAccessTokenResult!
+    createAccessToken(user: ID!, scopes: [String!]!, note: String!, expiresAt: DateTime): CreateAccessTokenResult!
     """
     Deletes and immediately revokes the specified access token, specified by either its ID or by the token
     itself.
@@ -219,6 +244,12 @@ type Mutation {
     """
     deleteAccessToken(byID: ID, byToken: String): EmptyResponse!
     """
+    Revokes the specified browser session, signing out the browser that uses it.
+
+    Only site admins or the user who owns the session may perform this mutation.
+    """
+    revokeSession(session: ID!): EmptyResponse!
+    """
     Deletes the association between an external account and its Sourcegraph user. It does NOT delete the external
     account on the external service where it resides.
 
@@ -532,6 +563,10 @@ type Mutation {
     """
     Set the permissions of a repository (i.e., which users may view it on Sourcegraph). This
     operation overwrites the previous permissions for the repository.
+
+    Users that do not exist yet are granted the permissions once they sign up. When the
+    permissions.userMapping site configuration property selects external services, only the
+    permissions of repositories of those external services can be set.
     """
     setRepositoryPermissionsForUsers(
         """
@@ -544,6 +579,31 @@ type Mutation {
         permitted to view the repository on Sourcegraph.
         """
         userPermissions: [UserPermission!]!
+        """
+        A list of organizations whose members may view the repository in addition to the users
+        in userPermissions. The members are determined when the permissions are set, so the
+        permissions need to be set again after the membership of an organization changes.
+        """
+        orgs: [ID!]
+    ): EmptyResponse!
+    """
+    Set the path-level permissions of users within a repository (i.e., which files and directories
+    of the repository they may read on Sourcegraph). This operation overwrites the previous path
+    rules of the repository. Users not included in the list may read all paths of the repository,
+    as long as they have access to the repository.
+
+    When a repository has path rules, users that are not signed in can't read any of its paths.
+    Only site admins may perform this mutation.
+    """
+    setSubRepositoryPermissionsForUsers(
+        """
+        The repository whose path-level permissions to set.
+        """
+        repository: ID!
+        """
+        A list of user identifiers and the paths they may read within the repository.
+        """
+        userPermissions: [UserSubRepositoryPermission!]!
     ): EmptyResponse!
     """
     Schedule a permissions sync for given repository. This queries the repository's code host for
@@ -557,6 +617,13 @@ type Mutation {
     the user's operations on Sourcegraph.
     """
     scheduleUserPermissionsSync(user: ID!): EmptyResponse!
+    """
+    Sync the permissions of the given user and repository from code hosts immediately and wait until
+    it is done, instead of scheduling the syncs like scheduleUserPermissionsSync and
+    scheduleRepositoryPermissionsSync do. Returns the explanation of the user's access to the
+    repository after the sync. Only site admins may perform this mutation.
+    """
+    syncRepositoryPermissionsNow(user: ID!, repository: ID!): RepositoryPermissionExplanation!
 
     """
     CAMPAIGNS
@@ -576,6 +643,10 @@ type Mutation {
     Create or update a campaign from a campaign spec and locally computed changeset specs. If no
     campaign exists in the namespace with the name given in the campaign spec, a campaign will be
     created. Otherwise, the existing campaign will be updated. The campaign is returned.
+
+    Applying a campaign spec doesn't run its steps: the diffs of campaign specs executed with
+    executeCampaignSpec (including the ones taken from the step cache) are attached to the campaign
+    spec when each execution completes, and an error is returned while executions are pending.
     """
     applyCampaign(
         """
@@ -617,6 +688,43 @@ type Mutation {
     """
     deleteCampaign(campaign: ID!): EmptyResponse
 
+    """
+    Store a code host credential with which changesets of the campaigns in a namespace are pushed
+    and published, instead of the token configured in the external service. If the namespace
+    already has a credential for the code host, it's replaced.
+
+    If no namespace is given, a site credential is created. It's used for namespaces that don't
+    have a credential of their own and can only be created by site admins.
+    """
+    createCampaignCredential(
+        """
+        The namespace (user or organization) the credential is used for.
+        """
+        namespace: ID
+        """
+        The kind of the code host. Only GITHUB, GITLAB and BITBUCKETSERVER are supported.
+        """
+        externalServiceKind: ExternalServiceKind!
+        """
+        The URL of the code host, such as https://github.com.
+        """
+        externalServiceURL: String!
+        """
+        The username that belongs to the credential. Only required for Bitbucket Server.
+        """
+        username: String
+        """
+        The personal access token. It needs access to push to the repositories and to create
+        changesets on the code host.
+        """
+        credential: String!
+    ): CampaignCredential!
+
+    """
+    Delete a campaign credential.
+    """
+    deleteCampaignCredential(campaignCredential: ID!): EmptyResponse
+
     """
     Upload a changeset spec that will be used in a future update to a campaign. The changeset spec
     is stored and can be referenced by its ID in the applyCampaign mutation. Just uploading the
@@ -664,11 +772,68 @@ type Mutation {
         changesetSpecs: [ID!]!
     ): CampaignSpec!
 
+    """
+    Execute the steps of a campaign spec on Sourcegraph instead of running them locally with
+    src-cli. The repositories matched by the campaign spec's "on" clauses are resolved on their
+    default branch and an execution is enqueued for each of them. Once an execution completes, the
+    resulting changeset spec is attached to the campaign spec.
+
+    Only the creator of the campaign spec or a site admin can execute it, and a campaign spec can
+    only be executed once.
+    """
+    executeCampaignSpec(campaignSpec: ID!): CampaignSpec!
+
     """
     Enqueue the given changeset for high-priority syncing.
     """
     syncChangeset(changeset: ID!): EmptyResponse!
 
+    """
+    Post a comment on the given changesets of a campaign on their code hosts. The comments are
+    posted in the background; the returned bulk operation reports the progress and the changesets
+    on which the comment couldn't be posted. Only changesets that were created by the campaign can
+    be commented on.
+
+    Only the author of the campaign or a site admin can apply bulk operations to its changesets.
+    """
+    createChangesetComments(
+        campaign: ID!
+        """
+        The changesets to comment on. They must all be attached to the campaign.
+        """
+        changesets: [ID!]!
+        """
+        The body of the comment (as Markdown).
+        """
+        body: String!
+    ): BulkOperation!
+
+    """
+    Retry publishing the given changesets of a campaign that failed to be published or updated on
+    their code hosts.
+    """
+    reenqueueChangesets(campaign: ID!, changesets: [ID!]!): BulkOperation!
+
+    """
+    Merge the given open changesets of a campaign on their code hosts. Only changesets that were
+    created by the campaign can be merged. Changesets that the code host doesn't allow to be merged
+    (e.g., because of failing checks or missing approvals) are reported as errors of the returned
+    bulk operation.
+    """
+    mergeChangesets(campaign: ID!, changesets: [ID!]!): BulkOperation!
+
+    """
+    Close the given open changesets of a campaign on their code hosts. Only changesets that were
+    created by the campaign can be closed.
+    """
+    closeChangesets(campaign: ID!, changesets: [ID!]!): BulkOperation!
+
+    """
+    Detach the given changesets from a campaign. Changesets that were created by the campaign must
+    be closed or merged before they can be detached.
+    """
+    detachChangesets(campaign: ID!, changesets: [ID!]!): BulkOperation!
+
     """
     OBSERVABILITY
 
@@ -882,8 +1047,19 @@ type GitBranchChangesetDescription {
 
     Another ChangesetSpec with the same description, but "published: true",
     can later be applied publish the changeset.
+
+    This is also true if the changeset should be created as a draft, see draft.
     """
     published: Boolean!
+
+    """
+    Whether or not the changeset described here should be created as a draft
+    (a draft pull request on GitHub, a draft merge request on GitLab).
+
+    Applying another ChangesetSpec with the same description, but
+    "published: true", marks the draft as ready for review.
+    """
+    draft: Boolean!
 }
 
 """
@@ -1015,6 +1191,90 @@ type CampaignSpec implements Node {
     campaign doesn't yet exist.
     """
     appliesToCampaign: Campaign
+
+    """
+    The server-side executions of this campaign spec's steps, one per repository. This is empty
+    unless the campaign spec was executed with the executeCampaignSpec mutation.
+    """
+    executions: [CampaignSpecExecution!]!
+}
+
+"""
+The server-side execution of a campaign spec's steps in a single repository.
+"""
+type CampaignSpecExecution {
+    """
+    The repository the steps are executed in.
+    """
+    repository: Repository!
+
+    """
+    The branch the steps are executed on.
+    """
+    baseRef: String!
+
+    """
+    The commit the steps are executed on.
+    """
+    baseRev: String!
+
+    """
+    The state of the execution.
+    """
+    state: CampaignSpecExecutionState!
+
+    """
+    The error message if the execution failed.
+    """
+    failureMessage: String
+
+    """
+    The changeset specs that were created from the diff produced by the steps. This is empty if
+    the execution hasn't completed yet or if the steps didn't change anything, and contains more
+    than one changeset spec if the campaign spec groups changes with transformChanges.
+    """
+    changesetSpecs: [ChangesetSpec!]!
+
+    """
+    Whether the diff was taken from the step cache, because the same steps were already executed
+    on the same commit, instead of running the steps again.
+    """
+    cacheHit: Boolean!
+
+    """
+    The date when the execution was enqueued.
+    """
+    createdAt: DateTime!
+
+    """
+    The date when the execution finished, if it has.
+    """
+    finishedAt: DateTime
+}
+
+"""
+The state of a campaign spec execution.
+"""
+enum CampaignSpecExecutionState {
+    """
+    The execution is enqueued.
+    """
+    QUEUED
+
+    """
+    The steps are currently being executed.
+    """
+    PROCESSING
+
+    """
+    The execution failed.
+    """
+    ERRORED
+
+    """
+    The execution completed.
+    """
+    COMPLETED
 }
 
 """
@@ -1033,6 +1293,28 @@ input UserPermission {
     permission: RepositoryPermission = READ
 }
 
+"""
+The paths a user may read within a repository. Paths are relative to the repository root and
+matched as globs, where "*" matches within a single path segment and "**" matches across
+segments. A rule that matches a directory also applies to everything beneath it.
+"""
+input UserSubRepositoryPermission {
+    """
+    Depending on the bindID option in the permissions.userMapping site configuration property,
+    either the username (bindID of "username") or a verified email address (bindID of "email")
+    of an existing user.
+    """
+    bindID: String!
+    """
+    The paths the user may read. All paths are readable when it is empty or omitted.
+    """
+    pathIncludes: [String!]
+    """
+    The paths the user may not read, even if they are included.
+    """
+    pathExcludes: [String!]
+}
+
 """
 A campaign is a set of related changes to apply to code across one or more repositories.
 """
@@ -1164,6 +1446,259 @@ type Campaign implements Node {
     The current campaign spec this campaign reflects.
     """
     currentSpec: CampaignSpec!
+
+    """
+    The bulk operations that were applied to the changesets of this campaign, newest first.
+    """
+    bulkOperations: [BulkOperation!]!
+
+    """
+    Analytics about the progress of the campaign, based on snapshots of the states of its published
+    changesets that are taken periodically.
+    """
+    analytics(
+        """
+        Only include snapshots taken at or after this point in time. Defaults to Campaign.createdAt.
+        """
+        from: DateTime
+        """
+        Only include snapshots taken at or before this point in time. Defaults to the current time.
+        """
+        to: DateTime
+    ): CampaignAnalytics!
+}
+
+"""
+Analytics about the progress of a campaign.
+"""
+type CampaignAnalytics {
+    """
+    The snapshots of the campaign that were taken in the requested time range, oldest first.
+    Snapshots are taken of open campaigns about once an hour. Snapshots older than a week are
+    downsampled to the first snapshot of each day (in UTC).
+    """
+    snapshots(
+        """
+        Returns the first n snapshots from the list.
+        """
+        first: Int = 50
+        """
+        Opaque pagination cursor.
+        """
+        after: String
+    ): CampaignSnapshotConnection!
+
+    """
+    A snapshot of the current state of the campaign. It is computed on request and not stored.
+    """
+    current: CampaignSnapshot!
+}
+
+"""
+A list of campaign snapshots.
+"""
+type CampaignSnapshotConnection {
+    """
+    A list of campaign snapshots.
+    """
+    nodes: [CampaignSnapshot!]!
+
+    """
+    The total number of campaign snapshots in the connection.
+    """
+    totalCount: Int!
+
+    """
+    Pagination information.
+    """
+    pageInfo: PageInfo!
+}
+
+"""
+The states of the published changesets of a campaign at a point in time.
+"""
+type CampaignSnapshot {
+    """
+    The point in time the snapshot was taken.
+    """
+    date: DateTime!
+    """
+    The total number of changesets.
+    """
+    total: Int!
+    """
+    The number of open changesets (independent of draft, check and review state).
+    """
+    open: Int!
+    """
+    The number of merged changesets.
+    """
+    merged: Int!
+    """
+    The number of closed changesets.
+    """
+    closed: Int!
+    """
+    The number of open changesets that are drafts.
+    """
+    draft: Int!
+    """
+    The number of open changesets with failed checks.
+    """
+    failedChecks: Int!
+    """
+    The number of changesets that are both open and approved.
+    """
+    openApproved: Int!
+    """
+    The number of changesets that are both open and have requested changes.
+    """
+    openChangesRequested: Int!
+    """
+    The number of changesets that are both open and are pending review.
+    """
+    openPending: Int!
+    """
+    The median time in seconds between the opening and the merging of the merged changesets. Null if
+    no changeset has been merged.
+    """
+    medianTimeToMergeSeconds: Int
+    """
+    For each repository with open changesets, how long its least recently active open changeset
+    has seen no activity, longest first. Repositories the viewer can't access are omitted.
+    """
+    repositoryStallTimes: [RepositoryStallTime!]!
+}
+
+"""
+How long the least recently active open changeset of a campaign in a repository has seen no
+activity.
+"""
+type RepositoryStallTime {
+    """
+    The repository.
+    """
+    repository: Repository!
+    """
+    The time in seconds since the last activity.
+    """
+    stallTimeSeconds: Int!
+}
+
+"""
+A bulk operation applies the same operation to a set of changesets of a campaign. The operation is
+applied to each changeset in the background, independently of the others.
+"""
+type BulkOperation {
+    """
+    The unique ID of the bulk operation.
+    """
+    id: ID!
+
+    """
+    The operation that is applied to the changesets.
+    """
+    type: BulkOperationType!
+
+    """
+    The state of the bulk operation.
+    """
+    state: BulkOperationState!
+
+    """
+    The share of changesets the operation has been applied to so far, between 0 and 1.
+    """
+    progress: Float!
+
+    """
+    The changesets the operation couldn't be applied to, and why.
+    """
+    errors: [ChangesetJobError!]!
+
+    """
+    The user who started the bulk operation.
+    """
+    initiator: User!
+
+    """
+    The number of changesets the operation is applied to.
+    """
+    changesetCount: Int!
+
+    """
+    The date when the bulk operation was started.
+    """
+    createdAt: DateTime!
+
+    """
+    The date when the operation was applied to all changesets, if it has been.
+    """
+    finishedAt: DateTime
+}
+
+"""
+The operation applied to changesets by a bulk operation.
+"""
+enum BulkOperationType {
+    """
+    A comment is posted on the changesets.
+    """
+    COMMENT
+
+    """
+    The changesets that failed to be published are retried.
+    """
+    REENQUEUE
+
+    """
+    The changesets are merged.
+    """
+    MERGE
+
+    """
+    The changesets are closed.
+    """
+    CLOSE
+
+    """
+    The changesets are detached from the campaign.
+    """
+    DETACH
+}
+
+"""
+The state of a bulk operation.
+"""
+enum BulkOperationState {
+    """
+    The operation is still being applied to some of the changesets.
+    """
+    PROCESSING
+
+    """
+    The operation was applied to all changesets, but failed for some of them.
+    """
+    FAILED
+
+    """
+    The operation was applied to all changesets successfully.
+    """
+    COMPLETED
+}
+
+"""
+The failure of a bulk operation for a single changeset.
+"""
+type ChangesetJobError {
+    """
+    The changeset the operation failed for.
+    """
+    changeset: Changeset!
+
+    """
+    The error message.
+    """
+    error: String
 }
 
 """
@@ -1224,6 +1759,62 @@ type CampaignConnection {
     pageInfo: PageInfo!
 }
 
+"""
+A code host credential with which the changesets of the campaigns in a namespace are pushed and
+published. The credential itself is never returned.
+"""
+type CampaignCredential {
+    """
+    The unique ID of the credential.
+    """
+    id: ID!
+
+    """
+    The namespace the credential is used for, or null if it's a site credential.
+    """
+    namespace: Namespace
+
+    """
+    The kind of the code host the credential is for.
+    """
+    externalServiceKind: ExternalServiceKind!
+
+    """
+    The URL of the code host the credential is for.
+    """
+    externalServiceURL: String!
+
+    """
+    The username that belongs to the credential, if any.
+    """
+    username: String
+
+    """
+    The date and time when the credential was created.
+    """
+    createdAt: DateTime!
+
+    """
+    The date and time when the credential was last updated.
+    """
+    updatedAt: DateTime!
+}
+
+"""
+A list of campaign credentials.
+"""
+type CampaignCredentialConnection {
+    """
+    A list of campaign credentials.
+    """
+    nodes: [CampaignCredential!]!
+
+    """
+    Pagination information.
+    """
+    pageInfo: PageInfo!
+}
+
 """
 The publication state of a changeset on Sourcegraph
 """
@@ -1236,6 +1827,11 @@ enum ChangesetPublicationState {
     The changeset has been created on the code host.
     """
     PUBLISHED
+    """
+    The changeset has been created on the code host as a draft, which means
+    that it's not ready for review yet.
+    """
+    DRAFT
 }
 
 """
@@ -1296,6 +1892,20 @@ enum ChangesetCheckState {
     FAILED
 }
 
+"""
+The state of a changeset created by a campaign with respect to the current head of its base branch.
+"""
+enum ChangesetRebaseState {
+    """
+    The base branch has moved and the changeset's patch was re-applied on its new head.
+    """
+    UP_TO_DATE
+    """
+    The base branch has moved and the changeset's patch doesn't apply cleanly to its new head anymore.
+    """
+    CONFLICTING
+}
+
 """
 A label attached to a changeset on a code host.
 """
@@ -1559,6 +2169,11 @@ type ExternalChangeset implements Node & Changeset {
     """
     checkState: ChangesetCheckState
 
+    """
+    Whether the commit of this changeset is based on the current head of its base branch. Null if the changeset wasn't created by a campaign or its base branch hasn't moved since the commit was created.
+    """
+    rebaseState: ChangesetRebaseState
+
     """
     An error that has occurred when publishing or updating the changeset. This is only set when the changeset state is ERRORED and the viewer can administer this changeset.
     """
@@ -1887,6 +2502,20 @@ type CreateUserResult {
     resetPasswordURL: String
 }
 
+"""
+The result for Mutation.enrollTwoFactorAuth.
+"""
+type TwoFactorAuthEnrollment {
+    """
+    The base32-encoded secret to add to an authenticator app.
+    """
+    secret: String!
+    """
+    The otpauth URI of the secret, to be shown as a QR code that authenticator apps can scan.
+    """
+    uri: String!
+}
+
 """
 The result for Mutation.randomizeUserPassword.
 """
@@ -1964,6 +2593,24 @@ type Query {
         viewerCanAdminister: Boolean
     ): CampaignConnection!
     """
+    The campaign credentials of a namespace. If no namespace is given, the site credentials are
+    returned, which only site admins can see.
+    """
+    campaignCredentials(
+        """
+        The namespace (user or organization) whose credentials are returned.
+        """
+        namespace: ID
+        """
+        Returns the first n credentials from the list.
+        """
+        first: Int = 50
+        """
+        Opaque pagination cursor.
+        """
+        after: String
+    ): CampaignCredentialConnection!
+    """
     Looks up a campaign by namespace and campaign name.
     """
     campaign(
@@ -2315,6 +2962,13 @@ type Query {
     """
     usersWithPendingPermissions: [String!]!
 
+    """
+    Explains whether the given user can access the given repository and why, following the same
+    rules that are enforced when the user accesses the repository. It reports the permissions as
+    of their last sync and does not query code hosts. Only site admins may perform this query.
+    """
+    explainRepositoryPermission(user: ID!, repository: ID!): RepositoryPermissionExplanation!
+
     """
     (experimental) The LSIF API may change substantially in the near future as we
     continue to adjust it for our use cases. Changes will not be documented in the
@@ -2334,9 +2988,44 @@ type Query {
         state: LSIFUploadState
 
         """
-        When specified, shows only uploads that are latest for the given repository.
+        When specified, shows only uploads that are latest for the given repository.
+        """
+        isLatestForRepo: Boolean
+
+        """
+        When specified, indicates that this request should be paginated and
+        the first N results (relative to the cursor) should be returned. i.e.
+        how many results to return per page. It must be in the range of 0-5000.
+        """
+        first: Int
+
+        """
+        When specified, indicates that this request should be paginated and
+        to fetch results starting at this cursor.
+
+        A future request can be made for more results by passing in the
+        'LSIFUploadConnection.pageInfo.endCursor' that is returned.
+        """
+        after: String
+    ): LSIFUploadConnection!
+
+    """
+    (experimental) The LSIF API may change substantially in the near future as we
+    continue to adjust it for our use cases. Changes will not be documented in the
+    CHANGELOG during this time.
+    The repository's LSIF uploads.
+    """
+    lsifIndexes(
+        """
+        An (optional) search query that searches over the state, repository name,
+        and commit properties.
+        """
+        query: String
+
+        """
+        The state of returned uploads.
         """
-        isLatestForRepo: Boolean
+        state: LSIFIndexState
 
         """
         When specified, indicates that this request should be paginated and
@@ -2348,30 +3037,34 @@ type Query {
         """
         When specified, indicates that this request should be paginated and
         to fetch results starting at this cursor.
-
         A future request can be made for more results by passing in the
-        'LSIFUploadConnection.pageInfo.endCursor' that is returned.
+        'LSIFIndexConnection.pageInfo.endCursor' that is returned.
         """
         after: String
-    ): LSIFUploadConnection!
+    ): LSIFIndexConnection!
 
     """
     (experimental) The LSIF API may change substantially in the near future as we
     continue to adjust it for our use cases. Changes will not be documented in the
     CHANGELOG during this time.
-    The repository's LSIF uploads.
+    Diagnostics reported by the LSIF uploads visible at the tip of the default branch
+    of every repository. Only site admins may search diagnostics across repositories.
     """
-    lsifIndexes(
+    lsifDiagnostics(
         """
-        An (optional) search query that searches over the state, repository name,
-        and commit properties.
+        When specified, shows only diagnostics with the given severity.
         """
-        query: String
+        severity: DiagnosticSeverity
 
         """
-        The state of returned uploads.
+        When specified, shows only diagnostics reported by the given tool, e.g. "staticcheck".
         """
-        state: LSIFIndexState
+        source: String
+
+        """
+        When specified, shows only diagnostics with the given code, e.g. "SA1019".
+        """
+        code: String
 
         """
         When specified, indicates that this request should be paginated and
@@ -2384,10 +3077,10 @@ type Query {
         When specified, indicates that this request should be paginated and
         to fetch results starting at this cursor.
         A future request can be made for more results by passing in the
-        'LSIFIndexConnection.pageInfo.endCursor' that is returned.
+        'DiagnosticConnection.pageInfo.endCursor' that is returned.
         """
         after: String
-    ): LSIFIndexConnection!
+    ): DiagnosticConnection!
 }
 
 """
@@ -3302,6 +3995,80 @@ type Repository implements Node & GenericSearchResultInterface {
         after: String
     ): LSIFIndexConnection!
 
+    """
+    (experimental) The LSIF API may change substantially in the near future as we
+    continue to adjust it for our use cases. Changes will not be documented in the
+    CHANGELOG during this time.
+
+    A dry-run evaluation of the code intelligence retention policies (the codeIntel.retentionPolicies
+    site configuration setting) against the repository's completed LSIF uploads. No uploads are removed
+    by this query. Only site admins may view this report.
+    """
+    lsifUploadRetention: [LSIFUploadRetention!]!
+
+    """
+    (experimental) The LSIF API may change substantially in the near future as we
+    continue to adjust it for our use cases. Changes will not be documented in the
+    CHANGELOG during this time.
+    Diagnostics reported by the repository's LSIF uploads visible at the tip of the
+    default branch.
+    """
+    lsifDiagnostics(
+        """
+        When specified, shows only diagnostics with the given severity.
+        """
+        severity: DiagnosticSeverity
+
+        """
+        When specified, shows only diagnostics reported by the given tool, e.g. "staticcheck".
+        """
+        source: String
+
+        """
+        When specified, shows only diagnostics with the given code, e.g. "SA1019".
+        """
+        code: String
+
+        """
+        When specified, indicates that this request should be paginated and
+        the first N results (relative to the cursor) should be returned. i.e.
+        how many results to return per page. It must be in the range of 0-5000.
+        """
+        first: Int
+
+        """
+        When specified, indicates that this request should be paginated and
+        to fetch results starting at this cursor.
+        A future request can be made for more results by passing in the
+        'DiagnosticConnection.pageInfo.endCursor' that is returned.
+        """
+        after: String
+    ): DiagnosticConnection!
+
+    """
+    (experimental) The LSIF API may change substantially in the near future as we
+    continue to adjust it for our use cases. Changes will not be documented in the
+    CHANGELOG during this time.
+    The number of diagnostics reported by each of the repository's completed LSIF uploads,
+    ordered by the time the upload was processed.
+    """
+    lsifDiagnosticCounts(
+        """
+        When specified, shows only diagnostics with the given severity.
+        """
+        severity: DiagnosticSeverity
+
+        """
+        When specified, shows only diagnostics reported by the given tool, e.g. "staticcheck".
+        """
+        source: String
+
+        """
+        When specified, shows only diagnostics with the given code, e.g. "SA1019".
+        """
+        code: String
+    ): [LSIFDiagnosticCount!]!
+
     """
     A list of authorized users to access this repository with the given permission.
     This API currently only returns permissions from the Sourcegraph provider, i.e.
@@ -3349,6 +4116,92 @@ type PermissionsInfo {
     updatedAt: DateTime!
 }
 
+"""
+An explanation of whether a user can access a repository.
+"""
+type RepositoryPermissionExplanation {
+    """
+    The user.
+    """
+    user: User!
+    """
+    The repository.
+    """
+    repository: Repository!
+    """
+    Whether the user can read the repository.
+    """
+    access: Boolean!
+    """
+    The rules that determined the access, in the order they were evaluated.
+    """
+    reasons: [String!]!
+    """
+    Whether the repository is private on its code host.
+    """
+    private: Boolean!
+    """
+    Whether every user can read the repository regardless of their permissions, because it is
+    public or because no authorization provider is configured for its code host and
+    "authzAllowByDefault" is true in the site configuration.
+    """
+    unrestricted: Boolean!
+    """
+    Whether the permissions of the repository are exclusively managed via the explicit permissions
+    API, as selected by the permissions.userMapping site configuration property.
+    """
+    explicitPermissions: Boolean!
+    """
+    The authorization provider configured for the code host of the repository, if any.
+    """
+    authzProvider: AuthzProviderExplanation
+    """
+    The permissions of the user, which are updated by user-centric syncs. It is null when the
+    permissions of the user have never been stored.
+    """
+    userPermissions: PermissionsInfo
+    """
+    Whether the permissions of the user include the repository. Access to restricted repositories
+    is granted by these permissions.
+    """
+    userPermissionsIncludeRepository: Boolean!
+    """
+    The permissions of the repository, which are updated by repository-centric syncs. It is null
+    when the permissions of the repository have never been stored.
+    """
+    repositoryPermissions: PermissionsInfo
+    """
+    Whether the permissions of the repository include the user.
+    """
+    repositoryPermissionsIncludeUser: Boolean!
+    """
+    The usernames or email addresses of the user (depending on the bindID option in the
+    permissions.userMapping site configuration property) that have pending permissions for the
+    repository. Pending permissions of an email address are
+    granted once the user verifies it.
+    """
+    pendingPermissions: [String!]!
+}
+
+"""
+An authorization provider and the external account of a user on its code host.
+"""
+type AuthzProviderExplanation {
+    """
+    The type of the code host (e.g., "github").
+    """
+    serviceType: String!
+    """
+    The ID of the code host (e.g., "https://github.com/").
+    """
+    serviceID: String!
+    """
+    The ID of the user's external account on the code host, whose permissions are synced for the
+    user. It is null when the user has no external account on the code host yet.
+    """
+    accountID: String
+}
+
 """
 A reference to another Sourcegraph instance.
 """
@@ -5136,6 +5989,14 @@ type GitBlobLSIFData implements TreeEntryLSIFData {
         how many results to return per page.
         """
         first: Int
+
+        """
+        When true, references from other repositories that depend on any version of the
+        package defining the symbol are included. By default, only references from
+        dependents of the same package version are returned. This argument is ignored
+        when a cursor is supplied.
+        """
+        allVersions: Boolean = false
     ): LocationConnection!
 
     """
@@ -5368,6 +6229,11 @@ type User implements Node & SettingsSubject & Namespace {
     """
     builtinAuth: Boolean!
     """
+    Whether the user has enabled two-factor authentication.
+    Only the user and site admins can access this field.
+    """
+    twoFactorAuthEnabled: Boolean!
+    """
     The latest settings for the user.
     Only the user and site admins can access this field.
     """
@@ -5432,6 +6298,11 @@ type User implements Node & SettingsSubject & Namespace {
         first: Int
     ): AccessTokenConnection!
     """
+    The user's browser sessions that have not expired, most recently active first.
+    Only the user and site admins can access this field.
+    """
+    sessions: [UserSession!]!
+    """
     A list of external accounts that are associated with the user.
     """
     externalAccounts(
@@ -5541,6 +6412,117 @@ type AccessToken implements Node {
     The date when the access token was last used to authenticate a request.
     """
     lastUsedAt: DateTime
+    """
+    The IP address of the client that last used the access token to authenticate a request.
+    """
+    lastUsedIP: String
+    """
+    The date after which the access token can't be used, or null if it never expires.
+    """
+    expiresAt: DateTime
+}
+
+"""
+A browser session of a user.
+"""
+type UserSession {
+    """
+    The unique ID for the session.
+    """
+    id: ID!
+    """
+    The user agent of the browser that created the session.
+    """
+    userAgent: String!
+    """
+    The IP address of the client that last used the session.
+    """
+    lastIP: String!
+    """
+    The date when the session was created (i.e., when the user signed in).
+    """
+    createdAt: DateTime!
+    """
+    The date when the session was last active. This is updated at most every few minutes.
+    """
+    lastActiveAt: DateTime!
+    """
+    The date when the session expires unless it is used again.
+    """
+    expiresAt: DateTime!
+    """
+    Whether this is the session of the current request.
+    """
+    current: Boolean!
+}
+
+"""
+An entry of the audit log, which records a security-relevant action.
+"""
+type AuditLogEntry {
+    """
+    The unique ID for the entry.
+    """
+    id: ID!
+    """
+    The action that was performed, such as "SiteConfigUpdated", "ExternalServiceDeleted",
+    "RepositoryPermissionsUpdated", "AccessTokenCreated", "AccessTokenSudo", "UserDeleted" or
+    "RepositoryAccessedBySiteAdmin".
+    """
+    action: String!
+    """
+    The user who performed the action, or null if it was performed by Sourcegraph itself or the user
+    has since been deleted.
+    """
+    actor: User
+    """
+    The IP address of the client that performed the action, or an empty string if unknown.
+    """
+    actorIP: String!
+    """
+    The type of the resource that the action was performed on, such as "ExternalService".
+    """
+    subjectType: String!
+    """
+    The ID of the resource that the action was performed on.
+    """
+    subjectID: String!
+    """
+    The state of the resource before the action. Secrets are redacted.
+    """
+    before: JSONValue
+    """
+    The state of the resource after the action. Secrets are redacted.
+    """
+    after: JSONValue
+    """
+    A line-based diff of the state of the resource before and after the action, or null if the
+    state wasn't recorded.
+    """
+    diff: String
+    """
+    The date when the action was performed.
+    """
+    createdAt: DateTime!
+}
+
+"""
+A list of audit log entries.
+"""
+type AuditLogEntryConnection {
+    """
+    A list of audit log entries.
+    """
+    nodes: [AuditLogEntry!]!
+    """
+    The total count of audit log entries in the connection. This total count may be larger than the
+    number of nodes in this object when the result is paginated.
+    """
+    totalCount: Int!
+    """
+    Pagination information.
+    """
+    pageInfo: PageInfo!
 }
 
 """
@@ -6048,6 +7030,41 @@ type Site implements SettingsSubject {
         first: Int
     ): AccessTokenConnection!
     """
+    The audit log of security-relevant actions performed on this site, most recent first.
+    Only site admins can access this field.
+    """
+    auditLog(
+        """
+        Returns the first n entries from the list.
+        """
+        first: Int
+        """
+        Include only entries with one of these actions (such as "SiteConfigUpdated").
+        """
+        actions: [String!]
+        """
+        Include only entries of actions performed by this user.
+        """
+        actor: ID
+        """
+        Include only entries of actions performed on resources of this type (such as "ExternalService").
+        """
+        subjectType: String
+        """
+        Include only entries of actions performed on the resource with this ID (usually together with
+        subjectType).
+        """
+        subjectID: String
+        """
+        Include only entries created at or after this date.
+        """
+        since: DateTime
+        """
+        Include only entries created before this date.
+        """
+        until: DateTime
+    ): AuditLogEntryConnection!
+    """
     A list of all authentication providers. This information is visible to all viewers and does not contain any
     secret information.
     """
@@ -7075,6 +8092,28 @@ type LocationConnection {
     Pagination information.
     """
     pageInfo: PageInfo!
+
+    """
+    (experimental) The number of locations referencing the symbol in each repository
+    that depends on the package defining it. This is null when the symbol is not provided
+    by a package or when no repository references it.
+    """
+    repositoryCounts: [RepositoryReferenceCount!]
+}
+
+"""
+The number of locations within a repository that reference a symbol.
+"""
+type RepositoryReferenceCount {
+    """
+    The repository containing the references.
+    """
+    repository: Repository!
+
+    """
+    The number of references within the repository.
+    """
+    count: Int!
 }
 
 """
@@ -7190,6 +8229,60 @@ type LSIFUpload implements Node {
     placeInQueue: Int
 }
 
+"""
+The result of evaluating the code intelligence retention policies against an LSIF upload.
+"""
+type LSIFUploadRetention {
+    """
+    The upload.
+    """
+    upload: LSIFUpload!
+
+    """
+    The names of the retention policies that retain this upload. Uploads visible from the tip of the
+    default branch are always retained.
+    """
+    retainedBy: [String!]!
+
+    """
+    Whether or not the retention policies were evaluated against the upload. This is false when no
+    retention policies are configured, in which case every upload is kept, and for uploads whose commit
+    is not yet known to the commit graph of the repository. Uploads that are not evaluated never expire.
+    """
+    evaluated: Boolean!
+
+    """
+    Whether or not the upload was evaluated and is not retained by any policy. Expired uploads are removed
+    by the janitor unless the codeIntel.retentionDryRun site configuration setting is enabled.
+    """
+    expired: Boolean!
+}
+
+"""
+The number of diagnostics reported by an LSIF upload.
+"""
+type LSIFDiagnosticCount {
+    """
+    The upload.
+    """
+    upload: LSIFUpload!
+
+    """
+    The commit of the upload.
+    """
+    commit: String!
+
+    """
+    The time the upload was processed.
+    """
+    finishedAt: DateTime!
+
+    """
+    The number of matching diagnostics reported by the upload.
+    """
+    count: Int!
+}
+
 """
 A list of LSIF uploads.
 """
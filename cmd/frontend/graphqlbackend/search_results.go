@@ -29,6 +29,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/authz"
 	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/db"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver"
 	"github.com/sourcegraph/sourcegraph/internal/goroutine"
 	"github.com/sourcegraph/sourcegraph/internal/honey"
@@ -1512,6 +1513,10 @@ func (r *searchResolver) doResults(ctx context.Context, forceOnlyResultType stri
 
 	start := time.Now()
 
+	// permsCtx outlives the search timeout below, which is canceled once the
+	// optional searches ran out of budget.
+	permsCtx := ctx
+
 	ctx, cancel, err := r.withTimeout(ctx)
 	if err != nil {
 		return nil, err
@@ -1818,6 +1823,12 @@ func (r *searchResolver) doResults(ctx context.Context, forceOnlyResultType stri
 
 	timer.Stop()
 
+	// 🚨 SECURITY: Remove the results revealing paths the user cannot read.
+	results, err = filterSubRepoPermsResults(permsCtx, results)
+	if err != nil {
+		return nil, err
+	}
+
 	tr.LazyPrintf("results=%d limitHit=%v cloning=%d missing=%d excludedFork=%d excludedArchived=%d timedout=%d",
 		len(results),
 		common.limitHit,
@@ -1863,6 +1874,52 @@ func (r *searchResolver) doResults(ctx context.Context, forceOnlyResultType stri
 	return &resultsResolver, multiErr.ErrorOrNil()
 }
 
+// filterSubRepoPermsResults removes the file matches of paths the current user
+// cannot read. Diff results are removed for all repositories in which the user can
+// read only some paths, because diff previews may contain any path.
+func filterSubRepoPermsResults(ctx context.Context, results []SearchResultResolver) ([]SearchResultResolver, error) {
+	matchers := subRepoPermsMatchers{}
+	filtered := results[:0]
+	for _, result := range results {
+		if fm, ok := result.ToFileMatch(); ok {
+			m, err := matchers.get(ctx, fm.Repo.repo)
+			if err != nil {
+				return nil, err
+			}
+			if !m.CanReadFile(fm.JPath) {
+				continue
+			}
+		}
+		if cr, ok := result.ToCommitSearchResult(); ok && cr.diffPreview != nil {
+			m, err := matchers.get(ctx, cr.commit.repoResolver.repo)
+			if err != nil {
+				return nil, err
+			}
+			if m != nil {
+				continue
+			}
+		}
+		filtered = append(filtered, result)
+	}
+	return filtered, nil
+}
+
+// subRepoPermsMatchers caches the sub-repo permissions of the current user by
+// repository, for filtering many results of the same repositories.
+type subRepoPermsMatchers map[api.RepoID]*authz.SubRepoPermsMatcher
+
+func (matchers subRepoPermsMatchers) get(ctx context.Context, repo *types.Repo) (*authz.SubRepoPermsMatcher, error) {
+	if m, ok := matchers[repo.ID]; ok {
+		return m, nil
+	}
+	m, err := db.SubRepoPermsForActor(ctx, repo.ID)
+	if err != nil {
+		return nil, err
+	}
+	matchers[repo.ID] = m
+	return m, nil
+}
+
 // isContextError returns true if ctx.Err() is not nil or if err
 // is an error caused by context cancelation or timeout.
 func isContextError(ctx context.Context, err error) bool {
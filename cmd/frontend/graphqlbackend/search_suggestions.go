@@ -14,6 +14,7 @@ import (
 	"github.com/pkg/errors"
 	"github.com/sourcegraph/go-lsp"
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/db"
 	"github.com/sourcegraph/sourcegraph/internal/errcode"
@@ -335,6 +336,12 @@ func (r *searchResolver) Suggestions(ctx context.Context, args *searchSuggestion
 		log15.Error("error getting search suggestions: ", "error", err)
 	}
 
+	// 🚨 SECURITY: Remove the suggestions revealing paths the user cannot read.
+	allSuggestions, err := filterSubRepoPermsSuggestions(ctx, allSuggestions)
+	if err != nil {
+		return nil, err
+	}
+
 	// Eliminate duplicates.
 	type key struct {
 		repoName api.RepoName
@@ -386,6 +393,37 @@ func (r *searchResolver) Suggestions(ctx context.Context, args *searchSuggestion
 	return allSuggestions, nil
 }
 
+// filterSubRepoPermsSuggestions removes the file and symbol suggestions of paths
+// the current user cannot read.
+func filterSubRepoPermsSuggestions(ctx context.Context, suggestions []*searchSuggestionResolver) ([]*searchSuggestionResolver, error) {
+	matchers := subRepoPermsMatchers{}
+	filtered := suggestions[:0]
+	for _, s := range suggestions {
+		var (
+			repo  *types.Repo
+			path  string
+			isDir bool
+		)
+		switch r := s.result.(type) {
+		case *GitTreeEntryResolver:
+			repo, path, isDir = r.commit.repoResolver.repo, r.Path(), r.IsDirectory()
+		case *searchSymbolResult:
+			repo, path = r.commit.repoResolver.repo, r.symbol.Path
+		}
+		if repo != nil {
+			m, err := matchers.get(ctx, repo)
+			if err != nil {
+				return nil, err
+			}
+			if !m.CanRead(path, isDir) {
+				continue
+			}
+		}
+		filtered = append(filtered, s)
+	}
+	return filtered, nil
+}
+
 func allEmptyStrings(ss1, ss2 []string) bool {
 	for _, s := range ss1 {
 		if s != "" {
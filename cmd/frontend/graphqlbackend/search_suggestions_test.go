@@ -11,8 +11,10 @@ import (
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/internal/inventory"
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
 	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
 	"github.com/sourcegraph/sourcegraph/internal/db"
 	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/symbols/protocol"
 	"github.com/sourcegraph/sourcegraph/internal/vcs/git"
 	"github.com/sourcegraph/sourcegraph/schema"
 )
@@ -313,6 +315,48 @@ func TestSearchSuggestions(t *testing.T) {
 		}
 	})
 
+	t.Run("sub-repo permissions", func(t *testing.T) {
+		mockDecodedViewerFinalSettings = &schema.Settings{}
+		defer func() { mockDecodedViewerFinalSettings = nil }()
+
+		db.MockSubRepoPermsForActor = func(_ context.Context, repoID api.RepoID) (*authz.SubRepoPermsMatcher, error) {
+			if repoID != 1 {
+				return nil, nil
+			}
+			return authz.NewSubRepoPermsMatcher(&authz.SubRepoPermissions{PathExcludes: []string{"secret"}})
+		}
+		defer func() { db.MockSubRepoPermsForActor = nil }()
+
+		repo := &RepositoryResolver{repo: &types.Repo{ID: 1, Name: "repo"}}
+		commit := &GitCommitResolver{repoResolver: repo, oid: "deadbeef"}
+		otherCommit := &GitCommitResolver{repoResolver: &RepositoryResolver{repo: &types.Repo{ID: 2, Name: "other-repo"}}, oid: "deadbeef"}
+
+		mockShowRepoSuggestions = func() ([]*searchSuggestionResolver, error) { return nil, nil }
+		defer func() { mockShowRepoSuggestions = nil }()
+		mockShowLangSuggestions = func() ([]*searchSuggestionResolver, error) { return nil, nil }
+		defer func() { mockShowLangSuggestions = nil }()
+		mockShowFileSuggestions = func() ([]*searchSuggestionResolver, error) {
+			return []*searchSuggestionResolver{
+				newSearchSuggestionResolver(NewGitTreeEntryResolver(commit, CreateFileInfo("dir/file", false)), 6),
+				newSearchSuggestionResolver(NewGitTreeEntryResolver(commit, CreateFileInfo("secret", true)), 5),
+				newSearchSuggestionResolver(NewGitTreeEntryResolver(commit, CreateFileInfo("secret/file", false)), 4),
+				newSearchSuggestionResolver(NewGitTreeEntryResolver(otherCommit, CreateFileInfo("secret/file", false)), 3),
+			}, nil
+		}
+		defer func() { mockShowFileSuggestions = nil }()
+		mockShowSymbolMatches = func() ([]*searchSuggestionResolver, error) {
+			return []*searchSuggestionResolver{
+				newSearchSuggestionResolver(&searchSymbolResult{symbol: protocol.Symbol{Name: "Foo", Path: "dir/file"}, commit: commit}, 2),
+				newSearchSuggestionResolver(&searchSymbolResult{symbol: protocol.Symbol{Name: "Secret", Path: "secret/file"}, commit: commit}, 1),
+			}, nil
+		}
+		defer func() { mockShowSymbolMatches = nil }()
+
+		for _, v := range searchVersions {
+			testSuggestions(t, "file:file", v, []string{"file:dir/file", "file:secret/file", "symbol:Foo"})
+		}
+	})
+
 	t.Run("repo: and file: field", func(t *testing.T) {
 		var mu sync.Mutex
 
@@ -248,6 +248,8 @@ func testStringResult(result *searchSuggestionResolver) string {
 		name = "file:" + r.Path()
 	case *languageResolver:
 		name = "lang:" + r.name
+	case *searchSymbolResult:
+		name = "symbol:" + r.symbol.Name
 	default:
 		panic("never here")
 	}
@@ -13,6 +13,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/internal/pkg/siteid"
 	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/auditlog"
 	"github.com/sourcegraph/sourcegraph/internal/conf"
 	"github.com/sourcegraph/sourcegraph/internal/db"
 	"github.com/sourcegraph/sourcegraph/internal/env"
@@ -172,10 +173,17 @@ func (r *schemaResolver) UpdateSiteConfiguration(ctx context.Context, args *stru
 	}
 
 	prev := globals.ConfigurationServerFrontendOnly.Raw()
+	before := prev.Site
 	prev.Site = args.Input
 	// TODO(slimsag): future: actually pass lastID through to prevent race conditions
 	if err := globals.ConfigurationServerFrontendOnly.Write(ctx, prev); err != nil {
 		return false, err
 	}
+	auditlog.Log(ctx, auditlog.Event{
+		Action:      auditlog.ActionSiteConfigUpdated,
+		SubjectType: auditlog.SubjectSiteConfiguration,
+		Before:      auditlog.JSONC(before),
+		After:       auditlog.JSONC(args.Input),
+	})
 	return globals.ConfigurationServerFrontendOnly.NeedServerRestart(), nil
 }
@@ -2,11 +2,13 @@ package graphqlbackend
 
 import (
 	"context"
+	"strconv"
 
 	"github.com/graph-gophers/graphql-go"
 	"github.com/pkg/errors"
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/external/session"
+	"github.com/sourcegraph/sourcegraph/internal/auditlog"
 	"github.com/sourcegraph/sourcegraph/internal/authz"
 	"github.com/sourcegraph/sourcegraph/internal/db"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
@@ -73,7 +75,8 @@ func (*schemaResolver) DeleteUser(ctx context.Context, args *struct {
 		AccountIDs:  append(emailStrs, user.Username),
 	})
 
-	if args.Hard != nil && *args.Hard {
+	hard := args.Hard != nil && *args.Hard
+	if hard {
 		if err := db.Users.HardDelete(ctx, user.ID); err != nil {
 			return nil, err
 		}
@@ -82,6 +85,13 @@ func (*schemaResolver) DeleteUser(ctx context.Context, args *struct {
 			return nil, err
 		}
 	}
+	auditlog.Log(ctx, auditlog.Event{
+		Action:      auditlog.ActionUserDeleted,
+		SubjectType: auditlog.SubjectUser,
+		SubjectID:   strconv.Itoa(int(user.ID)),
+		Before:      map[string]interface{}{"username": user.Username, "siteAdmin": user.SiteAdmin},
+		After:       map[string]interface{}{"hardDelete": hard},
+	})
 
 	// NOTE: Practically, we don't reuse the ID for any new users, and the situation of left-over pending permissions
 	// is possible but highly unlikely. Therefore, there is no need to roll back user deletion even if this step failed.
@@ -141,6 +151,13 @@ func (*schemaResolver) SetUserIsSiteAdmin(ctx context.Context, args *struct {
 	if err := db.Users.SetIsSiteAdmin(ctx, userID, args.SiteAdmin); err != nil {
 		return nil, err
 	}
+	auditlog.Log(ctx, auditlog.Event{
+		Action:      auditlog.ActionUserSiteAdminUpdated,
+		SubjectType: auditlog.SubjectUser,
+		SubjectID:   strconv.Itoa(int(userID)),
+		Before:      map[string]bool{"siteAdmin": !args.SiteAdmin},
+		After:       map[string]bool{"siteAdmin": args.SiteAdmin},
+	})
 	return &EmptyResponse{}, nil
 }
 
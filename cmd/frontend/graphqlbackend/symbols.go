@@ -12,6 +12,8 @@ import (
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend/graphqlutil"
 	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/db"
 	"github.com/sourcegraph/sourcegraph/internal/gituri"
 	"github.com/sourcegraph/sourcegraph/internal/search"
 	"github.com/sourcegraph/sourcegraph/internal/symbols/protocol"
@@ -167,6 +169,15 @@ func searchZoektSymbols(ctx context.Context, commit *GitCommitResolver, branch s
 }
 
 func computeSymbols(ctx context.Context, commit *GitCommitResolver, query *string, first *int32, includePatterns *[]string) (res []*symbolResolver, err error) {
+	// 🚨 SECURITY: Remove the symbols of files the user cannot read.
+	subRepoPerms, err := db.SubRepoPermsForActor(ctx, commit.repoResolver.repo.ID)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		res = filterSubRepoPermsSymbols(subRepoPerms, res)
+	}()
+
 	// TODO(keegancsmith) we should be able to use indexedSearchRequest here
 	// and remove indexedSymbolsBranch.
 	if branch := indexedSymbolsBranch(ctx, string(commit.repoResolver.repo.Name), string(commit.oid)); branch != "" {
@@ -213,6 +224,21 @@ func computeSymbols(ctx context.Context, commit *GitCommitResolver, query *strin
 	return resolvers, err
 }
 
+// filterSubRepoPermsSymbols removes the symbols of files that cannot be read
+// according to m.
+func filterSubRepoPermsSymbols(m *authz.SubRepoPermsMatcher, symbols []*symbolResolver) []*symbolResolver {
+	if m == nil {
+		return symbols
+	}
+	filtered := symbols[:0]
+	for _, s := range symbols {
+		if m.CanReadFile(s.symbol.Path) {
+			filtered = append(filtered, s)
+		}
+	}
+	return filtered
+}
+
 func toSymbolResolver(symbol protocol.Symbol, baseURI *gituri.URI, lang string, commitResolver *GitCommitResolver) *symbolResolver {
 	resolver := &symbolResolver{
 		symbol:   symbol,
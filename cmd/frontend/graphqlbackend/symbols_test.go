@@ -0,0 +1,71 @@
+package graphqlbackend
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/db"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/symbols/protocol"
+)
+
+func TestSymbols_SubRepoPerms(t *testing.T) {
+	resetMocks()
+	defer resetMocks()
+
+	backend.Mocks.Symbols.ListTags = func(_ context.Context, args search.SymbolsParameters) ([]protocol.Symbol, error) {
+		return []protocol.Symbol{
+			{Name: "a", Path: "dir/a.go"},
+			{Name: "b", Path: "secret/b.go"},
+			{Name: "c", Path: "secret/nested/c.go"},
+			{Name: "d", Path: "secret.go"},
+		}, nil
+	}
+
+	db.MockSubRepoPermsForActor = func(_ context.Context, repoID api.RepoID) (*authz.SubRepoPermsMatcher, error) {
+		return authz.NewSubRepoPermsMatcher(&authz.SubRepoPermissions{PathExcludes: []string{"secret"}})
+	}
+	defer func() { db.MockSubRepoPermsForActor = nil }()
+
+	commit := &GitCommitResolver{
+		repoResolver: &RepositoryResolver{repo: &types.Repo{ID: 1, Name: "repo"}},
+		oid:          "deadbeef",
+	}
+	want := []string{"a", "d"}
+
+	symbolNames := func(t *testing.T, conn *symbolConnectionResolver, err error) []string {
+		t.Helper()
+		if err != nil {
+			t.Fatal(err)
+		}
+		nodes, err := conn.Nodes(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+		var names []string
+		for _, n := range nodes {
+			names = append(names, n.Name())
+		}
+		return names
+	}
+
+	t.Run("commit", func(t *testing.T) {
+		conn, err := commit.Symbols(context.Background(), &symbolsArgs{})
+		if have := symbolNames(t, conn, err); !reflect.DeepEqual(have, want) {
+			t.Errorf("have %v, want %v", have, want)
+		}
+	})
+
+	t.Run("tree entry", func(t *testing.T) {
+		entry := NewGitTreeEntryResolver(commit, CreateFileInfo("", true))
+		conn, err := entry.Symbols(context.Background(), &symbolsArgs{})
+		if have := symbolNames(t, conn, err); !reflect.DeepEqual(have, want) {
+			t.Errorf("have %v, want %v", have, want)
+		}
+	})
+}
@@ -1,6 +1,8 @@
 package graphqlbackend
 
 import (
+	"context"
+
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
 	"github.com/sourcegraph/sourcegraph/internal/db"
 )
@@ -8,4 +10,7 @@ import (
 func resetMocks() {
 	db.Mocks = db.MockStores{}
 	backend.Mocks = backend.MockServices{}
+
+	// Mutations record audit log entries, which tests discard unless they check them.
+	db.Mocks.AuditLog.Insert = func(context.Context, *db.AuditLogEntry) error { return nil }
 }
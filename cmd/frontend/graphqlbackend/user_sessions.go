@@ -0,0 +1,79 @@
+package graphqlbackend
+
+import (
+	"context"
+
+	"github.com/graph-gophers/graphql-go"
+	"github.com/graph-gophers/graphql-go/relay"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/external/session"
+	"github.com/sourcegraph/sourcegraph/internal/db"
+)
+
+func (r *UserResolver) Sessions(ctx context.Context) ([]*userSessionResolver, error) {
+	// 🚨 SECURITY: Only site admins and the user can list a user's sessions.
+	if err := backend.CheckSiteAdminOrSameUser(ctx, r.user.ID); err != nil {
+		return nil, err
+	}
+
+	sessions, err := db.UserSessions.ListByUserID(ctx, r.user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	currentID := session.SessionIDFromContext(ctx)
+	rs := make([]*userSessionResolver, 0, len(sessions))
+	for _, s := range sessions {
+		rs = append(rs, &userSessionResolver{session: *s, current: s.ID == currentID})
+	}
+	return rs, nil
+}
+
+func (r *schemaResolver) RevokeSession(ctx context.Context, args *struct {
+	Session graphql.ID
+}) (*EmptyResponse, error) {
+	id, err := unmarshalUserSessionID(args.Session)
+	if err != nil {
+		return nil, err
+	}
+	s, err := db.UserSessions.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// 🚨 SECURITY: Only site admins and the user can revoke a user's session.
+	if err := backend.CheckSiteAdminOrSameUser(ctx, s.UserID); err != nil {
+		return nil, err
+	}
+	if err := db.UserSessions.Delete(ctx, s.ID); err != nil {
+		return nil, err
+	}
+	return &EmptyResponse{}, nil
+}
+
+// userSessionResolver resolves a browser session of a user.
+type userSessionResolver struct {
+	session db.UserSession
+	current bool
+}
+
+func marshalUserSessionID(id int64) graphql.ID { return relay.MarshalID("UserSession", id) }
+
+func unmarshalUserSessionID(id graphql.ID) (sessionID int64, err error) {
+	err = relay.UnmarshalSpec(id, &sessionID)
+	return
+}
+
+func (r *userSessionResolver) ID() graphql.ID { return marshalUserSessionID(r.session.ID) }
+
+func (r *userSessionResolver) UserAgent() string { return r.session.UserAgent }
+
+func (r *userSessionResolver) LastIP() string { return r.session.LastIP }
+
+func (r *userSessionResolver) CreatedAt() DateTime { return DateTime{Time: r.session.CreatedAt} }
+
+func (r *userSessionResolver) LastActiveAt() DateTime { return DateTime{Time: r.session.LastActiveAt} }
+
+func (r *userSessionResolver) ExpiresAt() DateTime { return DateTime{Time: r.session.ExpiresAt} }
+
+func (r *userSessionResolver) Current() bool { return r.current }
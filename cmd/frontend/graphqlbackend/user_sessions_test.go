@@ -0,0 +1,72 @@
+package graphqlbackend
+
+import (
+	"context"
+	"testing"
+
+	"github.com/graph-gophers/graphql-go"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/db"
+)
+
+func TestUserSessions(t *testing.T) {
+	resetMocks()
+	defer resetMocks()
+
+	users := map[int32]*types.User{
+		1: {ID: 1, Username: "alice"},
+		2: {ID: 2, Username: "admin", SiteAdmin: true},
+		3: {ID: 3, Username: "mallory"},
+	}
+	db.Mocks.Users.GetByID = func(_ context.Context, id int32) (*types.User, error) {
+		return users[id], nil
+	}
+	db.Mocks.Users.GetByCurrentAuthUser = func(ctx context.Context) (*types.User, error) {
+		return users[actor.FromContext(ctx).UID], nil
+	}
+	db.Mocks.UserSessions.ListByUserID = func(_ context.Context, userID int32) ([]*db.UserSession, error) {
+		return []*db.UserSession{{ID: 10, UserID: userID}}, nil
+	}
+	db.Mocks.UserSessions.GetByID = func(_ context.Context, id int64) (*db.UserSession, error) {
+		return &db.UserSession{ID: id, UserID: 1}, nil
+	}
+	var revoked []int64
+	db.Mocks.UserSessions.Delete = func(_ context.Context, id int64) error {
+		revoked = append(revoked, id)
+		return nil
+	}
+
+	for _, tc := range []struct {
+		name    string
+		viewer  int32
+		wantErr bool
+	}{
+		{name: "same user", viewer: 1},
+		{name: "other user", viewer: 3, wantErr: true},
+		{name: "site admin", viewer: 2},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			revoked = nil
+			ctx := actor.WithActor(context.Background(), &actor.Actor{UID: tc.viewer})
+
+			sessions, err := NewUserResolver(users[1]).Sessions(ctx)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("Sessions: got error %v, want error %v", err, tc.wantErr)
+			}
+			if !tc.wantErr && (len(sessions) != 1 || sessions[0].ID() != marshalUserSessionID(10)) {
+				t.Fatalf("Sessions: got %+v", sessions)
+			}
+
+			_, err = (&schemaResolver{}).RevokeSession(ctx, &struct {
+				Session graphql.ID
+			}{Session: marshalUserSessionID(10)})
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("RevokeSession: got error %v, want error %v", err, tc.wantErr)
+			}
+			if gotRevoked := len(revoked) > 0; gotRevoked == tc.wantErr {
+				t.Fatalf("got session revoked %v", gotRevoked)
+			}
+		})
+	}
+}
@@ -0,0 +1,109 @@
+package graphqlbackend
+
+import (
+	"context"
+	"errors"
+
+	"github.com/graph-gophers/graphql-go"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/auth/providers"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/internal/auth/userpasswd"
+	"github.com/sourcegraph/sourcegraph/internal/db"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+)
+
+func (r *UserResolver) TwoFactorAuthEnabled(ctx context.Context) (bool, error) {
+	// 🚨 SECURITY: Only the user and site admins are allowed to see whether the user has enabled
+	// two-factor authentication.
+	if err := backend.CheckSiteAdminOrSameUser(ctx, r.user.ID); err != nil {
+		return false, err
+	}
+
+	a, err := db.UserTwoFactorAuth.GetByUserID(ctx, r.user.ID)
+	if errcode.IsNotFound(err) {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+	return a.EnabledAt != nil, nil
+}
+
+type twoFactorAuthEnrollmentResolver struct {
+	enrollment *userpasswd.TwoFactorAuthEnrollment
+}
+
+func (r *twoFactorAuthEnrollmentResolver) Secret() string { return r.enrollment.Secret }
+func (r *twoFactorAuthEnrollmentResolver) URI() string    { return r.enrollment.URI }
+
+func (*schemaResolver) EnrollTwoFactorAuth(ctx context.Context) (*twoFactorAuthEnrollmentResolver, error) {
+	// 🚨 SECURITY: A user can only enroll themselves.
+	user, err := db.Users.GetByCurrentAuthUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, errors.New("no authenticated user")
+	}
+	// Two-factor authentication only applies to signing in with a password.
+	if !user.BuiltinAuth || !providers.BuiltinAuthEnabled() {
+		return nil, errors.New("two-factor authentication is only available to users of the builtin authentication provider")
+	}
+
+	enrollment, err := userpasswd.BeginTwoFactorAuthEnrollment(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+	return &twoFactorAuthEnrollmentResolver{enrollment: enrollment}, nil
+}
+
+func (*schemaResolver) ConfirmTwoFactorAuth(ctx context.Context, args *struct {
+	Code string
+}) ([]string, error) {
+	// 🚨 SECURITY: A user can only confirm their own enrollment.
+	user, err := db.Users.GetByCurrentAuthUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, errors.New("no authenticated user")
+	}
+
+	return userpasswd.ConfirmTwoFactorAuthEnrollment(ctx, user.ID, args.Code)
+}
+
+func (*schemaResolver) DisableTwoFactorAuth(ctx context.Context, args *struct {
+	User graphql.ID
+	Code *string
+}) (*EmptyResponse, error) {
+	userID, err := UnmarshalUserID(args.User)
+	if err != nil {
+		return nil, err
+	}
+
+	// 🚨 SECURITY: Only the user and site admins may disable two-factor authentication. Users
+	// must prove that they still have their second factor, so that it can't be disabled with
+	// a stolen session alone. Site admins don't need a code to disable it for other users.
+	if err := backend.CheckSiteAdminOrSameUser(ctx, userID); err != nil {
+		return nil, err
+	}
+	user, err := db.Users.GetByCurrentAuthUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if user != nil && user.ID == userID {
+		if userpasswd.TwoFactorAuthRequired(user) {
+			return nil, errors.New("two-factor authentication is required by the site configuration")
+		}
+		if args.Code == nil {
+			return nil, errors.New("a two-factor authentication code is required")
+		}
+		if err := userpasswd.VerifyTwoFactorAuthCode(ctx, userID, *args.Code); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := db.UserTwoFactorAuth.Delete(ctx, userID); err != nil {
+		return nil, err
+	}
+	return &EmptyResponse{}, nil
+}
@@ -0,0 +1,67 @@
+package graphqlbackend
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/graph-gophers/graphql-go"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/db"
+)
+
+func TestDisableTwoFactorAuth(t *testing.T) {
+	resetMocks()
+	defer resetMocks()
+
+	users := map[int32]*types.User{
+		1: {ID: 1, Username: "alice"},
+		2: {ID: 2, Username: "admin", SiteAdmin: true},
+		3: {ID: 3, Username: "mallory"},
+	}
+	db.Mocks.Users.GetByID = func(_ context.Context, id int32) (*types.User, error) {
+		return users[id], nil
+	}
+	db.Mocks.Users.GetByCurrentAuthUser = func(ctx context.Context) (*types.User, error) {
+		return users[actor.FromContext(ctx).UID], nil
+	}
+	enabledAt := time.Now()
+	db.Mocks.UserTwoFactorAuth.GetByUserID = func(_ context.Context, userID int32) (*db.TwoFactorAuth, error) {
+		return &db.TwoFactorAuth{UserID: userID, TOTPSecret: "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ", EnabledAt: &enabledAt}, nil
+	}
+	db.Mocks.UserTwoFactorAuth.BeginAttempt = func(context.Context, int32) (bool, error) { return true, nil }
+	var deleted []int32
+	db.Mocks.UserTwoFactorAuth.Delete = func(_ context.Context, userID int32) error {
+		deleted = append(deleted, userID)
+		return nil
+	}
+
+	wrongCode := "000000"
+	for _, tc := range []struct {
+		name    string
+		viewer  int32
+		code    *string
+		wantErr bool
+	}{
+		{name: "user without code", viewer: 1, wantErr: true},
+		{name: "user with wrong code", viewer: 1, code: &wrongCode, wantErr: true},
+		{name: "other user", viewer: 3, wantErr: true},
+		{name: "site admin", viewer: 2},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			deleted = nil
+			ctx := actor.WithActor(context.Background(), &actor.Actor{UID: tc.viewer})
+			_, err := (&schemaResolver{}).DisableTwoFactorAuth(ctx, &struct {
+				User graphql.ID
+				Code *string
+			}{User: MarshalUserID(1), Code: tc.code})
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("got error %v, want error %v", err, tc.wantErr)
+			}
+			if disabled := len(deleted) > 0; disabled == tc.wantErr {
+				t.Fatalf("got two-factor authentication disabled %v", disabled)
+			}
+		})
+	}
+}
@@ -31,6 +31,7 @@ var BillingPublishableKey string
 
 type authProviderInfo struct {
 	IsBuiltin         bool   `json:"isBuiltin"`
+	ServiceType       string `json:"serviceType"`
 	DisplayName       string `json:"displayName"`
 	AuthenticationURL string `json:"authenticationURL"`
 }
@@ -119,6 +120,7 @@ func NewJSContextFromRequest(req *http.Request) JSContext {
 		if info != nil {
 			authProviders = append(authProviders, authProviderInfo{
 				IsBuiltin:         p.Config().Builtin != nil,
+				ServiceType:       p.ConfigID().Type,
 				DisplayName:       info.DisplayName,
 				AuthenticationURL: info.AuthenticationURL,
 			})
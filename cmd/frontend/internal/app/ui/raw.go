@@ -20,6 +20,7 @@ import (
 	"github.com/prometheus/client_golang/prometheus/promauto"
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
 	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/db"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver"
 	"github.com/sourcegraph/sourcegraph/internal/vcs/git"
 	"github.com/sourcegraph/sourcegraph/internal/vfsutil"
@@ -120,6 +121,12 @@ func serveRaw(w http.ResponseWriter, r *http.Request) (err error) {
 		contentType = applicationXTar
 	}
 
+	// 🚨 SECURITY: Paths the user cannot read are served as if they did not exist.
+	subRepoPerms, err := db.SubRepoPermsForActor(r.Context(), common.Repo.ID)
+	if err != nil {
+		return err
+	}
+
 	// Instrument to understand duration and errors
 	var (
 		start       = time.Now()
@@ -160,6 +167,14 @@ func serveRaw(w http.ResponseWriter, r *http.Request) (err error) {
 		if contentType == applicationXTar {
 			format = vfsutil.ArchiveFormatTar
 		}
+		// 🚨 SECURITY: Archives would include paths the user cannot read, so they're
+		// not available when the user can only read some paths of the repository.
+		if subRepoPerms != nil {
+			requestType = "403"
+			http.Error(w, "archives are not available for this repository", http.StatusForbidden)
+			return nil // request handled
+		}
+
 		relativePath := strings.TrimPrefix(requestedPath, "/")
 		if relativePath == "" {
 			relativePath = "."
@@ -237,6 +252,9 @@ func serveRaw(w http.ResponseWriter, r *http.Request) (err error) {
 		}
 
 		fi, err := git.Stat(r.Context(), *cachedRepo, common.CommitID, requestedPath)
+		if err == nil && !subRepoPerms.CanRead(requestedPath, fi.IsDir()) {
+			err = &os.PathError{Op: "ls-tree", Path: strings.TrimPrefix(requestedPath, "/"), Err: os.ErrNotExist}
+		}
 		if err != nil {
 			if os.IsNotExist(err) {
 				requestType = "404"
@@ -255,6 +273,9 @@ func serveRaw(w http.ResponseWriter, r *http.Request) (err error) {
 			size = int64(len(infos))
 			var names []string
 			for _, info := range infos {
+				if !subRepoPerms.CanRead(info.Name(), info.IsDir()) {
+					continue
+				}
 				// A previous version of this code returned relative paths so we trim the paths
 				// here too so as not to break backwards compatibility
 				name := path.Base(info.Name())
@@ -25,6 +25,10 @@ type credentials struct {
 	Email    string `json:"email"`
 	Username string `json:"username"`
 	Password string `json:"password"`
+
+	// TwoFactorAuthCode is a code of an authenticator app or a recovery code. It is only
+	// used to sign in.
+	TwoFactorAuthCode string `json:"twoFactorAuthCode"`
 }
 
 // HandleSignUp handles submission of the user signup form.
@@ -229,6 +233,15 @@ func HandleSignIn(w http.ResponseWriter, r *http.Request) {
 		httpLogAndError(w, "Authentication failed", http.StatusUnauthorized)
 		return
 	}
+	if usr.Deactivated {
+		httpLogAndError(w, "Your user account has been deactivated. Ask a site admin for help.", http.StatusUnauthorized, "userID", usr.ID)
+		return
+	}
+	// 🚨 SECURITY: check the second factor
+	recoveryCodes, ok := checkTwoFactorAuth(w, r, usr, creds.TwoFactorAuthCode)
+	if !ok {
+		return
+	}
 	actor := &actor.Actor{UID: usr.ID}
 
 	// Write the session cookie
@@ -236,6 +249,87 @@ func HandleSignIn(w http.ResponseWriter, r *http.Request) {
 		httpLogAndError(w, "Could not create new user session", http.StatusInternalServerError)
 		return
 	}
+
+	if recoveryCodes != nil {
+		writeJSON(w, http.StatusOK, twoFactorAuthResponse{RecoveryCodes: recoveryCodes})
+	}
+}
+
+// twoFactorAuthResponse is the JSON response to sign in requests that need a two-factor
+// authentication code, and to the sign in request that completed the enrollment of the user.
+type twoFactorAuthResponse struct {
+	// TwoFactorAuth is "required" if the user must provide a code of their authenticator app
+	// or a recovery code, and "enroll" if the user must add the secret to an authenticator app
+	// and provide a code of it to complete their enrollment.
+	TwoFactorAuth string `json:"twoFactorAuth,omitempty"`
+
+	Secret        string   `json:"secret,omitempty"`
+	URI           string   `json:"uri,omitempty"`
+	RecoveryCodes []string `json:"recoveryCodes,omitempty"`
+}
+
+// checkTwoFactorAuth checks the two-factor authentication code of a sign in of the user, whose
+// password has been verified. Users that are required to use two-factor authentication but
+// haven't enrolled yet enroll as part of signing in. It returns the recovery codes of the user
+// if the sign in completed their enrollment.
+//
+// If ok is false, it has responded to the request and the user must not be signed in.
+func checkTwoFactorAuth(w http.ResponseWriter, r *http.Request, usr *types.User, code string) (recoveryCodes []string, ok bool) {
+	ctx := r.Context()
+	a, err := db.UserTwoFactorAuth.GetByUserID(ctx, usr.ID)
+	if err != nil && !errcode.IsNotFound(err) {
+		httpLogAndError(w, "Error checking two-factor authentication", http.StatusInternalServerError, "err", err)
+		return nil, false
+	}
+	enabled := a != nil && a.EnabledAt != nil
+	if !enabled && !TwoFactorAuthRequired(usr) {
+		return nil, true
+	}
+
+	switch {
+	case enabled && code == "":
+		writeJSON(w, http.StatusUnauthorized, twoFactorAuthResponse{TwoFactorAuth: "required"})
+		return nil, false
+
+	case enabled:
+		err = verifyTwoFactorAuthCode(ctx, a, code)
+
+	case a == nil || code == "":
+		enrollment, err := BeginTwoFactorAuthEnrollment(ctx, usr)
+		if err != nil {
+			httpLogAndError(w, "Error enrolling in two-factor authentication", http.StatusInternalServerError, "err", err)
+			return nil, false
+		}
+		writeJSON(w, http.StatusUnauthorized, twoFactorAuthResponse{
+			TwoFactorAuth: "enroll",
+			Secret:        enrollment.Secret,
+			URI:           enrollment.URI,
+		})
+		return nil, false
+
+	default:
+		recoveryCodes, err = confirmTwoFactorAuthEnrollment(ctx, a, code)
+	}
+
+	switch err {
+	case nil:
+		return recoveryCodes, true
+	case ErrInvalidTwoFactorAuthCode:
+		httpLogAndError(w, "Invalid two-factor authentication code", http.StatusUnauthorized, "userID", usr.ID)
+	case ErrTwoFactorAuthRateLimit:
+		httpLogAndError(w, "Too many failed two-factor authentication attempts. Try again later.", http.StatusTooManyRequests, "userID", usr.ID)
+	default:
+		httpLogAndError(w, "Error checking two-factor authentication code", http.StatusInternalServerError, "err", err)
+	}
+	return nil, false
+}
+
+func writeJSON(w http.ResponseWriter, code int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(code)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		log15.Error("Failed to write response", "err", err)
+	}
 }
 
 func httpLogAndError(w http.ResponseWriter, msg string, code int, errArgs ...interface{}) {
@@ -0,0 +1,50 @@
+package userpasswd
+
+import (
+	"net/url"
+	"testing"
+	"time"
+)
+
+func TestTOTP(t *testing.T) {
+	// The SHA-1 test vectors of RFC 6238, truncated to 6 digits.
+	secret := totpEncoding.EncodeToString([]byte("12345678901234567890"))
+	for unix, want := range map[int64]string{
+		59:         "287082",
+		1111111109: "081804",
+		1234567890: "005924",
+		2000000000: "279037",
+	} {
+		now := time.Unix(unix, 0)
+		if got := totpCode([]byte("12345678901234567890"), totpTimeStep(now)); got != want {
+			t.Errorf("time %d: got code %q, want %q", unix, got, want)
+		}
+		if step, ok := validateTOTP(secret, want, now); !ok || step != totpTimeStep(now) {
+			t.Errorf("time %d: code %q not valid", unix, want)
+		}
+		// Codes of adjacent time steps are accepted, but not older ones.
+		if _, ok := validateTOTP(secret, want, now.Add(totpPeriod)); !ok {
+			t.Errorf("time %d: code %q of the previous time step not valid", unix, want)
+		}
+		if _, ok := validateTOTP(secret, want, now.Add(3*totpPeriod)); ok {
+			t.Errorf("time %d: code %q of an old time step valid", unix, want)
+		}
+	}
+
+	if _, ok := validateTOTP(secret, "28708", time.Unix(59, 0)); ok {
+		t.Error("truncated code is valid")
+	}
+}
+
+func TestTOTPURI(t *testing.T) {
+	u, err := url.Parse(totpURI("Sourcegraph", "alice@sourcegraph.example.com", "ABC"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if u.Scheme != "otpauth" || u.Host != "totp" || u.Path != "/Sourcegraph:alice@sourcegraph.example.com" {
+		t.Errorf("unexpected URI %s", u)
+	}
+	if q := u.Query(); q.Get("secret") != "ABC" || q.Get("issuer") != "Sourcegraph" {
+		t.Errorf("unexpected query %v", q)
+	}
+}
@@ -0,0 +1,188 @@
+package userpasswd
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/globals"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/db"
+)
+
+var (
+	ErrInvalidTwoFactorAuthCode = errors.New("invalid two-factor authentication code")
+	ErrTwoFactorAuthRateLimit   = errors.New("too many failed two-factor authentication attempts, try again later")
+)
+
+// timeNow is mocked in tests.
+var timeNow = time.Now
+
+// TwoFactorAuthRequired reports whether the site configuration requires the user to use
+// two-factor authentication to sign in with their password.
+func TwoFactorAuthRequired(user *types.User) bool {
+	pc, _ := getProviderConfig()
+	if pc == nil {
+		return false
+	}
+	switch pc.RequireTwoFactorAuth {
+	case "all":
+		return true
+	case "siteAdmins":
+		return user.SiteAdmin
+	default:
+		return false
+	}
+}
+
+// TwoFactorAuthEnrollment is a pending enrollment in two-factor authentication, which is
+// completed by confirming a code generated for the secret.
+type TwoFactorAuthEnrollment struct {
+	// Secret is the base32-encoded TOTP secret, for authenticator apps that can't scan QR
+	// codes.
+	Secret string
+	// URI is the otpauth URI of the secret, to be shown as a QR code.
+	URI string
+}
+
+// BeginTwoFactorAuthEnrollment generates a new TOTP secret for the user, replacing the secret
+// of a previous enrollment that was not confirmed.
+func BeginTwoFactorAuthEnrollment(ctx context.Context, user *types.User) (*TwoFactorAuthEnrollment, error) {
+	secret, err := generateTOTPSecret()
+	if err != nil {
+		return nil, err
+	}
+	if err := db.UserTwoFactorAuth.SetPending(ctx, user.ID, secret); err != nil {
+		return nil, err
+	}
+
+	account := user.Username
+	if u := globals.ExternalURL(); u != nil && u.Host != "" {
+		account += "@" + u.Host
+	}
+	return &TwoFactorAuthEnrollment{
+		Secret: secret,
+		URI:    totpURI("Sourcegraph", account, secret),
+	}, nil
+}
+
+// ConfirmTwoFactorAuthEnrollment enables two-factor authentication for the user if the code
+// is valid for the secret of the pending enrollment. It returns the recovery codes of the user,
+// which can't be retrieved later.
+func ConfirmTwoFactorAuthEnrollment(ctx context.Context, userID int32, code string) ([]string, error) {
+	a, err := db.UserTwoFactorAuth.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return confirmTwoFactorAuthEnrollment(ctx, a, code)
+}
+
+func confirmTwoFactorAuthEnrollment(ctx context.Context, a *db.TwoFactorAuth, code string) ([]string, error) {
+	if a.EnabledAt != nil {
+		return nil, db.ErrTwoFactorAuthEnabled
+	}
+
+	if err := beginTwoFactorAuthAttempt(ctx, a.UserID); err != nil {
+		return nil, err
+	}
+	step, ok := validateTOTP(a.TOTPSecret, normalizeTwoFactorAuthCode(code), timeNow())
+	if !ok {
+		return nil, ErrInvalidTwoFactorAuthCode
+	}
+
+	codes, hashes, err := generateRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+	if err := db.UserTwoFactorAuth.Enable(ctx, a.UserID, hashes, step); err != nil {
+		return nil, err
+	}
+	return codes, nil
+}
+
+// VerifyTwoFactorAuthCode verifies a code of an authenticator app or an unused recovery code
+// of a user who enabled two-factor authentication. Each code can only be used once, and
+// verifications are rate limited per user.
+func VerifyTwoFactorAuthCode(ctx context.Context, userID int32, code string) error {
+	a, err := db.UserTwoFactorAuth.GetByUserID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	return verifyTwoFactorAuthCode(ctx, a, code)
+}
+
+func verifyTwoFactorAuthCode(ctx context.Context, a *db.TwoFactorAuth, code string) error {
+	if a.EnabledAt == nil {
+		return db.ErrTwoFactorAuthPending
+	}
+
+	if err := beginTwoFactorAuthAttempt(ctx, a.UserID); err != nil {
+		return err
+	}
+
+	code = normalizeTwoFactorAuthCode(code)
+	if step, ok := validateTOTP(a.TOTPSecret, code, timeNow()); ok {
+		// 🚨 SECURITY: Reject codes that were already used.
+		if used, err := db.UserTwoFactorAuth.UseTimeStep(ctx, a.UserID, step); err != nil || used {
+			return err
+		}
+	} else if len(code) == recoveryCodeLength {
+		if used, err := db.UserTwoFactorAuth.UseRecoveryCode(ctx, a.UserID, hashRecoveryCode(code)); err != nil || used {
+			return err
+		}
+	}
+
+	return ErrInvalidTwoFactorAuthCode
+}
+
+// beginTwoFactorAuthAttempt counts an attempt to verify a code of the user, and returns
+// ErrTwoFactorAuthRateLimit if the user made too many failed attempts recently.
+func beginTwoFactorAuthAttempt(ctx context.Context, userID int32) error {
+	// 🚨 SECURITY: The attempt must be counted before the code is checked, otherwise
+	// concurrent requests could all pass the rate limit before any failure is recorded.
+	ok, err := db.UserTwoFactorAuth.BeginAttempt(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrTwoFactorAuthRateLimit
+	}
+	return nil
+}
+
+// normalizeTwoFactorAuthCode removes the spaces and dashes that users may enter as part of
+// codes, and lowercases recovery codes.
+func normalizeTwoFactorAuthCode(code string) string {
+	return strings.ToLower(strings.NewReplacer(" ", "", "-", "").Replace(code))
+}
+
+const (
+	recoveryCodeCount  = 10
+	recoveryCodeLength = 10 // hex digits
+)
+
+// generateRecoveryCodes returns new random recovery codes, formatted for display, and their
+// hashes for storage.
+func generateRecoveryCodes() (codes, hashes []string, err error) {
+	for i := 0; i < recoveryCodeCount; i++ {
+		var b [recoveryCodeLength / 2]byte
+		if _, err := rand.Read(b[:]); err != nil {
+			return nil, nil, err
+		}
+		code := hex.EncodeToString(b[:])
+		codes = append(codes, code[:recoveryCodeLength/2]+"-"+code[recoveryCodeLength/2:])
+		hashes = append(hashes, hashRecoveryCode(code))
+	}
+	return codes, hashes, nil
+}
+
+// hashRecoveryCode returns the hash of a normalized recovery code. Recovery codes are random,
+// so unlike passwords they don't need a slow salted hash.
+func hashRecoveryCode(code string) string {
+	sum := sha256.Sum256([]byte(code))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,172 @@
+package userpasswd
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/db"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// mockTwoFactorAuthStore mocks the two-factor authentication store with an in-memory record of
+// the given user, which is nil until the user enrolls.
+func mockTwoFactorAuthStore(t *testing.T, a **db.TwoFactorAuth) {
+	now := time.Unix(1600000000, 0)
+	timeNow = func() time.Time { return now }
+
+	db.Mocks.UserTwoFactorAuth = db.MockUserTwoFactorAuth{
+		GetByUserID: func(_ context.Context, userID int32) (*db.TwoFactorAuth, error) {
+			if *a == nil {
+				return nil, &mockNotFoundError{}
+			}
+			c := **a
+			return &c, nil
+		},
+		SetPending: func(_ context.Context, userID int32, secret string) error {
+			*a = &db.TwoFactorAuth{UserID: userID, TOTPSecret: secret}
+			return nil
+		},
+		Enable: func(_ context.Context, userID int32, hashes []string, step int64) error {
+			(*a).EnabledAt = &now
+			(*a).RecoveryCodes = hashes
+			(*a).LastUsedTimeStep = step
+			return nil
+		},
+		UseTimeStep: func(_ context.Context, userID int32, step int64) (bool, error) {
+			if step <= (*a).LastUsedTimeStep {
+				return false, nil
+			}
+			(*a).LastUsedTimeStep = step
+			(*a).FailedAttempts = 0
+			return true, nil
+		},
+		UseRecoveryCode: func(_ context.Context, userID int32, hash string) (bool, error) {
+			for i, h := range (*a).RecoveryCodes {
+				if h == hash {
+					(*a).RecoveryCodes = append((*a).RecoveryCodes[:i], (*a).RecoveryCodes[i+1:]...)
+					return true, nil
+				}
+			}
+			return false, nil
+		},
+		BeginAttempt: func(_ context.Context, userID int32) (bool, error) {
+			if (*a).FailedAttempts >= 5 {
+				return false, nil
+			}
+			(*a).FailedAttempts++
+			(*a).LastFailedAttemptAt = &now
+			return true, nil
+		},
+	}
+	t.Cleanup(func() {
+		timeNow = time.Now
+		db.Mocks.UserTwoFactorAuth = db.MockUserTwoFactorAuth{}
+		conf.Mock(nil)
+	})
+}
+
+type mockNotFoundError struct{}
+
+func (*mockNotFoundError) Error() string  { return "not found" }
+func (*mockNotFoundError) NotFound() bool { return true }
+
+func mockRequireTwoFactorAuth(require string) {
+	conf.Mock(&conf.Unified{SiteConfiguration: schema.SiteConfiguration{
+		AuthProviders: []schema.AuthProviders{{Builtin: &schema.BuiltinAuthProvider{Type: "builtin", RequireTwoFactorAuth: require}}},
+	}})
+}
+
+func currentCode(t *testing.T, secret string, offset int) string {
+	key, err := totpEncoding.DecodeString(secret)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return totpCode(key, totpTimeStep(timeNow())+int64(offset))
+}
+
+// signIn calls checkTwoFactorAuth like a sign in request with the code would, and returns
+// whether the user would be signed in and the response.
+func signIn(usr *types.User, code string) (bool, int, twoFactorAuthResponse) {
+	w := httptest.NewRecorder()
+	recoveryCodes, ok := checkTwoFactorAuth(w, httptest.NewRequest("POST", "/-/sign-in", nil), usr, code)
+	if ok {
+		return true, http.StatusOK, twoFactorAuthResponse{RecoveryCodes: recoveryCodes}
+	}
+	var resp twoFactorAuthResponse
+	_ = json.Unmarshal(w.Body.Bytes(), &resp)
+	return false, w.Code, resp
+}
+
+func TestCheckTwoFactorAuth(t *testing.T) {
+	var a *db.TwoFactorAuth
+	mockTwoFactorAuthStore(t, &a)
+	admin := &types.User{ID: 1, SiteAdmin: true}
+
+	t.Run("not required", func(t *testing.T) {
+		for _, require := range []string{"", "none", "siteAdmins"} {
+			mockRequireTwoFactorAuth(require)
+			if ok, _, _ := signIn(&types.User{ID: 1}, ""); !ok {
+				t.Errorf("requireTwoFactorAuth %q: user not signed in", require)
+			}
+		}
+	})
+
+	mockRequireTwoFactorAuth("siteAdmins")
+
+	var recoveryCodes []string
+	t.Run("enrollment", func(t *testing.T) {
+		ok, status, resp := signIn(admin, "")
+		if ok || status != http.StatusUnauthorized || resp.TwoFactorAuth != "enroll" || resp.Secret == "" {
+			t.Fatalf("got %v, %d, %+v, want enrollment", ok, status, resp)
+		}
+
+		if ok, status, _ := signIn(admin, "000000"); ok || status != http.StatusUnauthorized {
+			t.Fatalf("signed in with an invalid code: %v, %d", ok, status)
+		}
+
+		ok, _, resp = signIn(admin, currentCode(t, a.TOTPSecret, 0))
+		if !ok || len(resp.RecoveryCodes) != recoveryCodeCount {
+			t.Fatalf("got %v, %+v, want sign in with recovery codes", ok, resp)
+		}
+		recoveryCodes = resp.RecoveryCodes
+	})
+
+	t.Run("enabled", func(t *testing.T) {
+		// Two-factor authentication stays enabled if it is no longer required.
+		mockRequireTwoFactorAuth("none")
+		defer mockRequireTwoFactorAuth("siteAdmins")
+
+		if ok, status, resp := signIn(admin, ""); ok || status != http.StatusUnauthorized || resp.TwoFactorAuth != "required" {
+			t.Fatalf("got %v, %d, %+v, want code required", ok, status, resp)
+		}
+
+		// The code that confirmed the enrollment was used already.
+		if ok, _, _ := signIn(admin, currentCode(t, a.TOTPSecret, 0)); ok {
+			t.Fatal("signed in with a used code")
+		}
+		if ok, _, _ := signIn(admin, currentCode(t, a.TOTPSecret, 1)); !ok {
+			t.Fatal("not signed in with the code of the next time step")
+		}
+
+		for _, want := range []bool{true, false} {
+			if ok, _, _ := signIn(admin, recoveryCodes[0]); ok != want {
+				t.Fatalf("signing in with a recovery code: got %v, want %v", ok, want)
+			}
+		}
+	})
+
+	t.Run("rate limit", func(t *testing.T) {
+		for i := 0; i < 10; i++ {
+			signIn(admin, "000000")
+		}
+		if ok, status, _ := signIn(admin, recoveryCodes[1]); ok || status != http.StatusTooManyRequests {
+			t.Fatalf("got %v, %d, want rate limit", ok, status)
+		}
+	})
+}
@@ -8,6 +8,9 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/db/dbconn"
 )
 
+// DeleteOldEventLogsInPostgres periodically deletes event logs older than 93 days. Security-relevant
+// actions are recorded in the separate, append-only audit log (see package auditlog), which is
+// never deleted.
 func DeleteOldEventLogsInPostgres(ctx context.Context) {
 	for {
 		_, err := dbconn.Global.ExecContext(
@@ -22,5 +22,6 @@ func newTest() *httptestutil.Client {
 		enterpriseServices.GitLabWebhook,
 		enterpriseServices.BitbucketServerWebhook,
 		enterpriseServices.NewCodeIntelUploadHandler,
+		enterpriseServices.PermissionsImportHandler,
 	))
 }
@@ -2,14 +2,17 @@ package httpapi
 
 import (
 	"net/http"
+	"strconv"
 
 	"github.com/inconshreveable/log15"
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
 	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/auditlog"
 	"github.com/sourcegraph/sourcegraph/internal/authz"
 	"github.com/sourcegraph/sourcegraph/internal/conf"
 	"github.com/sourcegraph/sourcegraph/internal/db"
 	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/httputil"
 )
 
 // AccessTokenAuthMiddleware authenticates the user based on the
@@ -61,15 +64,23 @@ func AccessTokenAuthMiddleware(next http.Handler) http.Handler {
 			//
 			// 🚨 SECURITY: It's important we check for the correct scopes to know what this token
 			// is allowed to do.
-			var requiredScope string
+			scopes := []string{authz.ScopeSiteAdminSudo}
 			if sudoUser == "" {
-				requiredScope = authz.ScopeUserAll
-			} else {
-				requiredScope = authz.ScopeSiteAdminSudo
+				scopes = accessTokenScopes(r)
+			}
+			var (
+				requiredScope string
+				subjectUserID int32
+				err           error
+			)
+			for _, requiredScope = range scopes {
+				subjectUserID, err = db.AccessTokens.Lookup(r.Context(), token, requiredScope, httputil.RemoteAddr(r))
+				if err != db.ErrAccessTokenNotFound {
+					break
+				}
 			}
-			subjectUserID, err := db.AccessTokens.Lookup(r.Context(), token, requiredScope)
 			if err != nil {
-				log15.Error("Invalid access token.", "token", token, "err", err)
+				log15.Error("Invalid access token.", "token", token, "requiredScope", requiredScope, "err", err)
 				http.Error(w, "Invalid access token.", http.StatusUnauthorized)
 				return
 			}
@@ -103,9 +114,16 @@ func AccessTokenAuthMiddleware(next http.Handler) http.Handler {
 				}
 				actorUserID = user.ID
 				log15.Debug("HTTP request used sudo token.", "requestURI", r.URL.RequestURI(), "tokenSubjectUserID", subjectUserID, "actorUserID", actorUserID, "actorUsername", user.Username)
+				// The audit log attributes sudo usage to the token's subject, not the impersonated user.
+				auditlog.Log(actor.WithActor(r.Context(), &actor.Actor{UID: subjectUserID}), auditlog.Event{
+					Action:      auditlog.ActionAccessTokenSudo,
+					SubjectType: auditlog.SubjectUser,
+					SubjectID:   strconv.Itoa(int(user.ID)),
+					After:       map[string]string{"username": user.Username, "method": r.Method, "requestURI": r.URL.RequestURI()},
+				})
 			}
 
-			r = r.WithContext(actor.WithActor(r.Context(), &actor.Actor{UID: actorUserID}))
+			r = r.WithContext(actor.WithActor(r.Context(), &actor.Actor{UID: actorUserID, AccessTokenScope: requiredScope}))
 		}
 
 		next.ServeHTTP(w, r)
@@ -0,0 +1,28 @@
+package httpapi
+
+import (
+	"net/http"
+
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+)
+
+// accessTokenScopes returns the access token scopes that can authorize the request (without
+// sudo), from the broadest to the narrowest. Requests that aren't covered by one of the narrower
+// scopes require "user:all".
+//
+// GraphQL requests can be authorized by any scope that permits some GraphQL fields. Which fields
+// the request may resolve depends on the scope that authorized it, which is recorded on the actor
+// and enforced for every field by the GraphQL schema while executing the request (see
+// graphqlbackend.checkAccessTokenScope), so that the fields are determined by the same parser that
+// executes the request.
+func accessTokenScopes(r *http.Request) []string {
+	switch {
+	case r.Method == "POST" && r.URL.Path == "/.api/lsif/upload":
+		return []string{authz.ScopeCodeIntelUpload}
+	case r.Method == "GET" && r.URL.Path == "/.api/search/stream":
+		return []string{authz.ScopeSearchRead}
+	case r.Method == "POST" && r.URL.Path == "/.api/graphql":
+		return []string{authz.ScopeUserAll, authz.ScopeCampaignsWrite, authz.ScopeSearchRead}
+	}
+	return []string{authz.ScopeUserAll}
+}
@@ -0,0 +1,35 @@
+package httpapi
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+)
+
+func TestAccessTokenScopes(t *testing.T) {
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		want   []string
+	}{
+		{name: "app page", method: "GET", path: "/search", want: []string{authz.ScopeUserAll}},
+		{name: "LSIF upload", method: "POST", path: "/.api/lsif/upload", want: []string{authz.ScopeCodeIntelUpload}},
+		{name: "streaming search", method: "GET", path: "/.api/search/stream", want: []string{authz.ScopeSearchRead}},
+		{
+			name:   "GraphQL",
+			method: "POST", path: "/.api/graphql",
+			want: []string{authz.ScopeUserAll, authz.ScopeCampaignsWrite, authz.ScopeSearchRead},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			r := httptest.NewRequest(test.method, test.path, nil)
+			if diff := cmp.Diff(test.want, accessTokenScopes(r)); diff != "" {
+				t.Errorf("scopes mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -191,6 +191,11 @@ func TestAccessTokenAuthMiddleware(t *testing.T) {
 			}
 			return &types.User{ID: 456, SiteAdmin: true}, nil
 		}
+		var auditLogEntry *db.AuditLogEntry
+		db.Mocks.AuditLog.Insert = func(_ context.Context, e *db.AuditLogEntry) error {
+			auditLogEntry = e
+			return nil
+		}
 		defer func() { db.Mocks = db.MockStores{} }()
 		checkHTTPResponse(t, req, http.StatusOK, "user 456")
 		if !calledAccessTokensLookup {
@@ -202,6 +207,10 @@ func TestAccessTokenAuthMiddleware(t *testing.T) {
 		if !calledUsersGetByUsername {
 			t.Error("!calledUsersGetByUsername")
 		}
+		// Sudo usage is attributed to the token's subject in the audit log.
+		if auditLogEntry == nil || auditLogEntry.ActorUserID != 123 || auditLogEntry.SubjectID != "456" {
+			t.Errorf("got audit log entry %+v, want sudo by user 123 as user 456", auditLogEntry)
+		}
 	})
 
 	// Test that if a sudo token's subject user is not a site admin (which means they were demoted
@@ -281,3 +290,81 @@ func TestAccessTokenAuthMiddleware(t *testing.T) {
 		}
 	})
 }
+
+func TestAccessTokenAuthMiddleware_Scope(t *testing.T) {
+	handler := AccessTokenAuthMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		actor := actor.FromContext(r.Context())
+		fmt.Fprintf(w, "user %v scope %q", actor.UID, actor.AccessTokenScope)
+	}))
+
+	tests := []struct {
+		name        string
+		method      string
+		path        string
+		tokenScopes []string
+		wantStatus  int
+		wantBody    string
+	}{
+		{
+			name:   "GraphQL with user:all",
+			method: "POST", path: "/.api/graphql",
+			tokenScopes: []string{authz.ScopeUserAll},
+			wantStatus:  http.StatusOK,
+			wantBody:    `user 123 scope "user:all"`,
+		},
+		{
+			name:   "GraphQL with search:read",
+			method: "POST", path: "/.api/graphql",
+			tokenScopes: []string{authz.ScopeSearchRead},
+			wantStatus:  http.StatusOK,
+			wantBody:    `user 123 scope "search:read"`,
+		},
+		{
+			name:   "GraphQL with campaigns:write",
+			method: "POST", path: "/.api/graphql",
+			tokenScopes: []string{authz.ScopeSearchRead, authz.ScopeCampaignsWrite},
+			wantStatus:  http.StatusOK,
+			wantBody:    `user 123 scope "campaigns:write"`,
+		},
+		{
+			name:   "GraphQL with codeintel:upload",
+			method: "POST", path: "/.api/graphql",
+			tokenScopes: []string{authz.ScopeCodeIntelUpload},
+			wantStatus:  http.StatusUnauthorized,
+			wantBody:    "Invalid access token.\n",
+		},
+		{
+			name:   "app page with search:read",
+			method: "GET", path: "/search",
+			tokenScopes: []string{authz.ScopeSearchRead},
+			wantStatus:  http.StatusUnauthorized,
+			wantBody:    "Invalid access token.\n",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			db.Mocks.AccessTokens.Lookup = func(_, requiredScope string) (int32, error) {
+				for _, granting := range authz.ScopesGranting(requiredScope) {
+					for _, scope := range test.tokenScopes {
+						if scope == granting {
+							return 123, nil
+						}
+					}
+				}
+				return 0, db.ErrAccessTokenNotFound
+			}
+			defer func() { db.Mocks = db.MockStores{} }()
+
+			req := httptest.NewRequest(test.method, test.path, nil)
+			req.Header.Set("Authorization", "token abcdef")
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+			if rr.Code != test.wantStatus {
+				t.Errorf("got response status %d, want %d", rr.Code, test.wantStatus)
+			}
+			if got := rr.Body.String(); got != test.wantBody {
+				t.Errorf("got response body %q, want %q", got, test.wantBody)
+			}
+		})
+	}
+}
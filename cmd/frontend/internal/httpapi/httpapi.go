@@ -32,7 +32,7 @@ import (
 //
 // 🚨 SECURITY: The caller MUST wrap the returned handler in middleware that checks authentication
 // and sets the actor in the request context.
-func NewHandler(m *mux.Router, schema *graphql.Schema, githubWebhook, gitlabWebhook, bitbucketServerWebhook http.Handler, newCodeIntelUploadHandler enterprise.NewCodeIntelUploadHandler) http.Handler {
+func NewHandler(m *mux.Router, schema *graphql.Schema, githubWebhook, gitlabWebhook, bitbucketServerWebhook http.Handler, newCodeIntelUploadHandler enterprise.NewCodeIntelUploadHandler, permissionsImportHandler http.Handler) http.Handler {
 	if m == nil {
 		m = apirouter.New(nil)
 	}
@@ -54,6 +54,13 @@ func NewHandler(m *mux.Router, schema *graphql.Schema, githubWebhook, gitlabWebh
 	m.Get(apirouter.GitLabWebhooks).Handler(trace.TraceRoute(gitlabWebhook))
 	m.Get(apirouter.BitbucketServerWebhooks).Handler(trace.TraceRoute(bitbucketServerWebhook))
 	m.Get(apirouter.LSIFUpload).Handler(trace.TraceRoute(newCodeIntelUploadHandler(false)))
+	m.Get(apirouter.PermissionsImport).Handler(trace.TraceRoute(permissionsImportHandler))
+
+	m.Get(apirouter.SCIMServiceProviderConfig).Handler(trace.TraceRoute(scimHandler(serveSCIMServiceProviderConfig)))
+	m.Get(apirouter.SCIMUsers).Handler(trace.TraceRoute(scimHandler(serveSCIMUsers)))
+	m.Get(apirouter.SCIMUser).Handler(trace.TraceRoute(scimHandler(serveSCIMUser)))
+	m.Get(apirouter.SCIMGroups).Handler(trace.TraceRoute(scimHandler(serveSCIMGroups)))
+	m.Get(apirouter.SCIMGroup).Handler(trace.TraceRoute(scimHandler(serveSCIMGroup)))
 
 	if envvar.SourcegraphDotComMode() {
 		m.Path("/updates").Methods("GET", "POST").Name("updatecheck").Handler(trace.TraceRoute(http.HandlerFunc(updatecheck.Handler)))
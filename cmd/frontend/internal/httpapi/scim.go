@@ -0,0 +1,230 @@
+package httpapi
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/inconshreveable/log15"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+)
+
+// The SCIM 2.0 endpoint (RFC 7643 and RFC 7644) lets identity providers such as Okta and Azure AD
+// provision users and manage organization memberships. Users are Sourcegraph users, and groups
+// are organizations.
+//
+// Only the subset of the protocol that identity providers use in practice is supported: the
+// Users and Groups resources with "eq" filters, pagination, PUT and PATCH, and the
+// ServiceProviderConfig resource.
+
+const (
+	scimSchemaUser                  = "urn:ietf:params:scim:schemas:core:2.0:User"
+	scimSchemaGroup                 = "urn:ietf:params:scim:schemas:core:2.0:Group"
+	scimSchemaListResponse          = "urn:ietf:params:scim:api:messages:2.0:ListResponse"
+	scimSchemaPatchOp               = "urn:ietf:params:scim:api:messages:2.0:PatchOp"
+	scimSchemaError                 = "urn:ietf:params:scim:api:messages:2.0:Error"
+	scimSchemaServiceProviderConfig = "urn:ietf:params:scim:schemas:core:2.0:ServiceProviderConfig"
+
+	scimContentType = "application/scim+json"
+
+	// scimMaxResults is the maximum number of resources returned by a single list request.
+	scimMaxResults = 100
+
+	// maxSCIMRequestSize is the maximum size of a SCIM request body.
+	maxSCIMRequestSize = 1 << 20
+)
+
+// scimError is an error response of the SCIM endpoint.
+type scimError struct {
+	Status   int
+	ScimType string // e.g. "invalidFilter", "uniqueness", "invalidValue"
+	Detail   string
+}
+
+func (e *scimError) Error() string { return e.Detail }
+
+func scimErrorf(status int, scimType, format string, args ...interface{}) *scimError {
+	return &scimError{Status: status, ScimType: scimType, Detail: fmt.Sprintf(format, args...)}
+}
+
+func scimNotFound(resourceType, id string) *scimError {
+	return scimErrorf(http.StatusNotFound, "", "%s %q not found", resourceType, id)
+}
+
+// scimHandler returns an HTTP handler that authenticates SCIM requests and writes the error
+// returned by h as a SCIM error response.
+//
+// 🚨 SECURITY: SCIM requests are not authenticated as a Sourcegraph user. Instead, the SCIM client
+// must send the bearer token configured in the "auth.scim" site configuration.
+func scimHandler(h func(w http.ResponseWriter, r *http.Request) error) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		cfg := conf.Get().AuthScim
+		if cfg == nil || cfg.AuthToken == "" {
+			http.Error(w, "SCIM is not enabled", http.StatusNotFound)
+			return
+		}
+		if !scimAuthorized(r, cfg.AuthToken) {
+			writeSCIMError(w, scimErrorf(http.StatusUnauthorized, "", "invalid or missing bearer token"))
+			return
+		}
+
+		if err := h(w, r); err != nil {
+			e, ok := err.(*scimError)
+			if !ok {
+				log15.Error("SCIM request failed", "method", r.Method, "path", r.URL.Path, "error", err)
+				e = scimErrorf(http.StatusInternalServerError, "", "internal error")
+			}
+			writeSCIMError(w, e)
+		}
+	})
+}
+
+func scimAuthorized(r *http.Request, token string) bool {
+	const prefix = "bearer "
+	header := r.Header.Get("Authorization")
+	if len(header) <= len(prefix) || !strings.EqualFold(header[:len(prefix)], prefix) {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(header[len(prefix):]), []byte(token)) == 1
+}
+
+func writeSCIMError(w http.ResponseWriter, e *scimError) {
+	writeSCIMJSON(w, e.Status, struct {
+		Schemas  []string `json:"schemas"`
+		Status   string   `json:"status"`
+		ScimType string   `json:"scimType,omitempty"`
+		Detail   string   `json:"detail"`
+	}{
+		Schemas:  []string{scimSchemaError},
+		Status:   strconv.Itoa(e.Status),
+		ScimType: e.ScimType,
+		Detail:   e.Detail,
+	})
+}
+
+func writeSCIMJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", scimContentType)
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func decodeSCIMRequest(w http.ResponseWriter, r *http.Request, v interface{}) error {
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxSCIMRequestSize)).Decode(v); err != nil {
+		return scimErrorf(http.StatusBadRequest, "invalidSyntax", "invalid request body: %v", err)
+	}
+	return nil
+}
+
+// scimMeta is the "meta" attribute of a SCIM resource.
+type scimMeta struct {
+	ResourceType string    `json:"resourceType"`
+	Created      time.Time `json:"created"`
+	LastModified time.Time `json:"lastModified"`
+	Location     string    `json:"location,omitempty"`
+}
+
+// scimListResponse is the response of a list request.
+type scimListResponse struct {
+	Schemas      []string    `json:"schemas"`
+	TotalResults int         `json:"totalResults"`
+	StartIndex   int         `json:"startIndex"`
+	ItemsPerPage int         `json:"itemsPerPage"`
+	Resources    interface{} `json:"Resources"`
+}
+
+// scimPagination returns the 1-based start index and the count of a list request.
+func scimPagination(r *http.Request) (startIndex, count int) {
+	startIndex, count = 1, scimMaxResults
+	if v, err := strconv.Atoi(r.URL.Query().Get("startIndex")); err == nil && v > 1 {
+		startIndex = v
+	}
+	if v, err := strconv.Atoi(r.URL.Query().Get("count")); err == nil && v >= 0 && v < scimMaxResults {
+		count = v
+	}
+	return startIndex, count
+}
+
+// scimFilter is a parsed filter of a list request. Only filters of the form `attribute eq "value"`
+// are supported, which are the only ones identity providers send.
+type scimFilter struct {
+	Attribute string
+	Value     string
+}
+
+func parseSCIMFilter(s string) (*scimFilter, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return nil, nil
+	}
+	fields := strings.SplitN(s, " ", 3)
+	if len(fields) != 3 || !strings.EqualFold(fields[1], "eq") {
+		return nil, scimErrorf(http.StatusBadRequest, "invalidFilter", "unsupported filter %q (only `attribute eq \"value\"` is supported)", s)
+	}
+	value, err := strconv.Unquote(strings.TrimSpace(fields[2]))
+	if err != nil {
+		return nil, scimErrorf(http.StatusBadRequest, "invalidFilter", "invalid value in filter %q", s)
+	}
+	return &scimFilter{Attribute: fields[0], Value: value}, nil
+}
+
+// scimPatchRequest is the body of a PATCH request.
+type scimPatchRequest struct {
+	Schemas    []string             `json:"schemas"`
+	Operations []scimPatchOperation `json:"Operations"`
+}
+
+type scimPatchOperation struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path"`
+	Value json.RawMessage `json:"value"`
+}
+
+// scimBool decodes a boolean value of a PATCH operation. Azure AD sends booleans as strings.
+func scimBool(v json.RawMessage) (bool, error) {
+	var b bool
+	if err := json.Unmarshal(v, &b); err == nil {
+		return b, nil
+	}
+	var s string
+	if err := json.Unmarshal(v, &s); err != nil {
+		return false, scimErrorf(http.StatusBadRequest, "invalidValue", "expected a boolean, got %s", v)
+	}
+	b, err := strconv.ParseBool(s)
+	if err != nil {
+		return false, scimErrorf(http.StatusBadRequest, "invalidValue", "expected a boolean, got %q", s)
+	}
+	return b, nil
+}
+
+// serveSCIMServiceProviderConfig describes the features of the SCIM endpoint to SCIM clients.
+func serveSCIMServiceProviderConfig(w http.ResponseWriter, r *http.Request) error {
+	type supported struct {
+		Supported bool `json:"supported"`
+	}
+	writeSCIMJSON(w, http.StatusOK, map[string]interface{}{
+		"schemas": []string{scimSchemaServiceProviderConfig},
+		"patch":   supported{true},
+		"bulk": map[string]interface{}{
+			"supported":      false,
+			"maxOperations":  0,
+			"maxPayloadSize": 0,
+		},
+		"filter": map[string]interface{}{
+			"supported":  true,
+			"maxResults": scimMaxResults,
+		},
+		"changePassword": supported{false},
+		"sort":           supported{false},
+		"etag":           supported{false},
+		"authenticationSchemes": []map[string]interface{}{{
+			"type":        "oauthbearertoken",
+			"name":        "OAuth Bearer Token",
+			"description": "Authentication with the bearer token of the auth.scim site configuration",
+		}},
+	})
+	return nil
+}
@@ -0,0 +1,431 @@
+package httpapi
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/auth"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/db"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/lazyregexp"
+)
+
+// scimGroup is a SCIM Group resource, which is a Sourcegraph organization. The displayName of the
+// group is the display name of the organization, and the organization name is derived from it.
+type scimGroup struct {
+	Schemas     []string     `json:"schemas"`
+	ID          string       `json:"id,omitempty"`
+	DisplayName string       `json:"displayName"`
+	Members     []scimMember `json:"members"`
+	Meta        *scimMeta    `json:"meta,omitempty"`
+}
+
+// scimMember is a member of a SCIM Group. The value is the ID of the user.
+type scimMember struct {
+	Value   string `json:"value"`
+	Display string `json:"display,omitempty"`
+}
+
+// scimMemberFilterPath matches the path of a PATCH operation that removes a single member, such as
+// `members[value eq "42"]`.
+var scimMemberFilterPath = lazyregexp.New(`(?i)^members\[value eq "([^"]*)"\]$`)
+
+func serveSCIMGroups(w http.ResponseWriter, r *http.Request) error {
+	switch r.Method {
+	case "GET":
+		return listSCIMGroups(w, r)
+	case "POST":
+		return createSCIMGroup(w, r)
+	}
+	return scimErrorf(http.StatusMethodNotAllowed, "", "unsupported method %s", r.Method)
+}
+
+func serveSCIMGroup(w http.ResponseWriter, r *http.Request) error {
+	ctx := r.Context()
+	org, err := getSCIMGroup(ctx, mux.Vars(r)["id"])
+	if err != nil {
+		return err
+	}
+
+	switch r.Method {
+	case "GET":
+	case "PUT":
+		var req scimGroup
+		if err := decodeSCIMRequest(w, r, &req); err != nil {
+			return err
+		}
+		if err := renameSCIMGroup(ctx, org, req.DisplayName); err != nil {
+			return err
+		}
+		if err := setSCIMGroupMembers(ctx, org.ID, req.Members); err != nil {
+			return err
+		}
+	case "PATCH":
+		var req scimPatchRequest
+		if err := decodeSCIMRequest(w, r, &req); err != nil {
+			return err
+		}
+		if err := patchSCIMGroup(ctx, org, req.Operations); err != nil {
+			return err
+		}
+	case "DELETE":
+		if err := db.Orgs.Delete(ctx, org.ID); err != nil {
+			return errors.Wrap(err, "delete organization")
+		}
+		w.WriteHeader(http.StatusNoContent)
+		return nil
+	default:
+		return scimErrorf(http.StatusMethodNotAllowed, "", "unsupported method %s", r.Method)
+	}
+
+	if org, err = db.Orgs.GetByID(ctx, org.ID); err != nil {
+		return errors.Wrap(err, "get organization")
+	}
+	res, err := toSCIMGroup(ctx, org)
+	if err != nil {
+		return err
+	}
+	writeSCIMJSON(w, http.StatusOK, res)
+	return nil
+}
+
+func listSCIMGroups(w http.ResponseWriter, r *http.Request) error {
+	ctx := r.Context()
+	filter, err := parseSCIMFilter(r.URL.Query().Get("filter"))
+	if err != nil {
+		return err
+	}
+	startIndex, count := scimPagination(r)
+
+	var orgs []*types.Org
+	var total int
+	switch {
+	case filter == nil:
+		if total, err = db.Orgs.Count(ctx, db.OrgsListOptions{}); err != nil {
+			return errors.Wrap(err, "count organizations")
+		}
+		orgs, err = db.Orgs.List(ctx, &db.OrgsListOptions{
+			LimitOffset: &db.LimitOffset{Limit: count, Offset: startIndex - 1},
+		})
+		if err != nil {
+			return errors.Wrap(err, "list organizations")
+		}
+
+	case strings.EqualFold(filter.Attribute, "displayName"):
+		name, err := scimGroupOrgName(filter.Value)
+		if err != nil {
+			break // No organization can have this name.
+		}
+		org, err := db.Orgs.GetByName(ctx, name)
+		if err != nil && !errcode.IsNotFound(err) {
+			return errors.Wrap(err, "get organization by name")
+		}
+		if org != nil && startIndex == 1 && count > 0 {
+			orgs = append(orgs, org)
+		}
+		if org != nil {
+			total = 1
+		}
+
+	default:
+		return scimErrorf(http.StatusBadRequest, "invalidFilter", "filtering by attribute %q is not supported", filter.Attribute)
+	}
+
+	resources := make([]*scimGroup, 0, len(orgs))
+	for _, org := range orgs {
+		res, err := toSCIMGroup(ctx, org)
+		if err != nil {
+			return err
+		}
+		resources = append(resources, res)
+	}
+	writeSCIMJSON(w, http.StatusOK, &scimListResponse{
+		Schemas:      []string{scimSchemaListResponse},
+		TotalResults: total,
+		StartIndex:   startIndex,
+		ItemsPerPage: len(resources),
+		Resources:    resources,
+	})
+	return nil
+}
+
+func createSCIMGroup(w http.ResponseWriter, r *http.Request) error {
+	ctx := r.Context()
+	var req scimGroup
+	if err := decodeSCIMRequest(w, r, &req); err != nil {
+		return err
+	}
+	name, err := scimGroupOrgName(req.DisplayName)
+	if err != nil {
+		return err
+	}
+
+	if _, err := db.Orgs.GetByName(ctx, name); err == nil {
+		return scimErrorf(http.StatusConflict, "uniqueness", "an organization with the name %q already exists", name)
+	} else if !errcode.IsNotFound(err) {
+		return errors.Wrap(err, "get organization by name")
+	}
+
+	var displayName *string
+	if req.DisplayName != name {
+		displayName = &req.DisplayName
+	}
+	org, err := db.Orgs.Create(ctx, name, displayName)
+	if err != nil {
+		return scimErrorf(http.StatusBadRequest, "invalidValue", "%s", err)
+	}
+	if err := setSCIMGroupMembers(ctx, org.ID, req.Members); err != nil {
+		return err
+	}
+
+	res, err := toSCIMGroup(ctx, org)
+	if err != nil {
+		return err
+	}
+	writeSCIMJSON(w, http.StatusCreated, res)
+	return nil
+}
+
+func patchSCIMGroup(ctx context.Context, org *types.Org, ops []scimPatchOperation) error {
+	for _, op := range ops {
+		opName := strings.ToLower(op.Op)
+		path := strings.ToLower(op.Path)
+
+		switch {
+		case path == "" && (opName == "add" || opName == "replace"):
+			// The value is an object with the attributes to set.
+			var attrs struct {
+				DisplayName *string       `json:"displayName"`
+				Members     *[]scimMember `json:"members"`
+			}
+			if err := json.Unmarshal(op.Value, &attrs); err != nil {
+				return scimErrorf(http.StatusBadRequest, "invalidValue", "invalid value of operation without path: %v", err)
+			}
+			if attrs.DisplayName != nil {
+				if err := renameSCIMGroup(ctx, org, *attrs.DisplayName); err != nil {
+					return err
+				}
+			}
+			if attrs.Members != nil {
+				if err := updateSCIMGroupMembers(ctx, org.ID, opName == "replace", *attrs.Members, nil); err != nil {
+					return err
+				}
+			}
+
+		case path == "displayname" && (opName == "add" || opName == "replace"):
+			var displayName string
+			if err := json.Unmarshal(op.Value, &displayName); err != nil {
+				return scimErrorf(http.StatusBadRequest, "invalidValue", "expected a string value of %q", op.Path)
+			}
+			if err := renameSCIMGroup(ctx, org, displayName); err != nil {
+				return err
+			}
+
+		case path == "members":
+			var members []scimMember
+			if len(op.Value) > 0 {
+				if err := json.Unmarshal(op.Value, &members); err != nil {
+					return scimErrorf(http.StatusBadRequest, "invalidValue", "invalid members: %v", err)
+				}
+			}
+			var err error
+			switch opName {
+			case "add":
+				err = updateSCIMGroupMembers(ctx, org.ID, false, members, nil)
+			case "replace":
+				err = updateSCIMGroupMembers(ctx, org.ID, true, members, nil)
+			case "remove":
+				if len(op.Value) == 0 {
+					err = updateSCIMGroupMembers(ctx, org.ID, true, nil, nil) // remove all members
+				} else {
+					err = updateSCIMGroupMembers(ctx, org.ID, false, nil, members)
+				}
+			default:
+				return scimErrorf(http.StatusBadRequest, "invalidSyntax", "unsupported operation %q", op.Op)
+			}
+			if err != nil {
+				return err
+			}
+
+		case opName == "remove" && scimMemberFilterPath.MatchString(op.Path):
+			id := scimMemberFilterPath.FindStringSubmatch(op.Path)[1]
+			if err := updateSCIMGroupMembers(ctx, org.ID, false, nil, []scimMember{{Value: id}}); err != nil {
+				return err
+			}
+
+		default:
+			return scimErrorf(http.StatusBadRequest, "invalidPath", "unsupported %q operation on path %q", op.Op, op.Path)
+		}
+	}
+	return nil
+}
+
+// renameSCIMGroup updates the display name of the organization. Organizations cannot be renamed,
+// so the new display name must map to the same organization name.
+func renameSCIMGroup(ctx context.Context, org *types.Org, displayName string) error {
+	name, err := scimGroupOrgName(displayName)
+	if err != nil {
+		return err
+	}
+	if name != org.Name {
+		return scimErrorf(http.StatusBadRequest, "mutability", "the display name %q does not match the organization name %q, and organizations cannot be renamed", displayName, org.Name)
+	}
+	if (org.DisplayName != nil && *org.DisplayName == displayName) || (org.DisplayName == nil && displayName == name) {
+		return nil
+	}
+	if _, err := db.Orgs.Update(ctx, org.ID, &displayName); err != nil {
+		return errors.Wrap(err, "update organization")
+	}
+	return nil
+}
+
+func setSCIMGroupMembers(ctx context.Context, orgID int32, members []scimMember) error {
+	return updateSCIMGroupMembers(ctx, orgID, true, members, nil)
+}
+
+// updateSCIMGroupMembers adds the users of add to the organization and removes the users of
+// remove from it. If replace is true, all members not in add are removed.
+func updateSCIMGroupMembers(ctx context.Context, orgID int32, replace bool, add, remove []scimMember) error {
+	memberships, err := db.OrgMembers.GetByOrgID(ctx, orgID)
+	if err != nil {
+		return errors.Wrap(err, "list organization members")
+	}
+	isMember := make(map[int32]bool, len(memberships))
+	for _, m := range memberships {
+		isMember[m.UserID] = true
+	}
+
+	want := make(map[int32]bool, len(isMember))
+	if !replace {
+		for userID := range isMember {
+			want[userID] = true
+		}
+	}
+	for _, m := range add {
+		userID, err := scimMemberUserID(ctx, m)
+		if err != nil {
+			return err
+		}
+		want[userID] = true
+	}
+	for _, m := range remove {
+		userID, err := strconv.ParseInt(m.Value, 10, 32)
+		if err != nil {
+			continue // Not a member.
+		}
+		delete(want, int32(userID))
+	}
+
+	// 🚨 SECURITY: Members that are not managed by SCIM (see getSCIMUser) are never removed.
+	for userID := range isMember {
+		if want[userID] {
+			continue
+		}
+		if _, err := getSCIMUser(ctx, strconv.Itoa(int(userID))); err != nil {
+			if e, ok := err.(*scimError); ok && e.Status == http.StatusNotFound {
+				want[userID] = true
+				continue
+			}
+			return err
+		}
+	}
+
+	for userID := range want {
+		if !isMember[userID] {
+			if _, err := db.OrgMembers.Create(ctx, orgID, userID); err != nil {
+				return errors.Wrapf(err, "add user %d to organization", userID)
+			}
+		}
+	}
+	for userID := range isMember {
+		if !want[userID] {
+			if err := db.OrgMembers.Remove(ctx, orgID, userID); err != nil {
+				return errors.Wrapf(err, "remove user %d from organization", userID)
+			}
+		}
+	}
+	return nil
+}
+
+func scimMemberUserID(ctx context.Context, m scimMember) (int32, error) {
+	user, err := getSCIMUser(ctx, m.Value)
+	if err != nil {
+		if e, ok := err.(*scimError); ok && e.Status == http.StatusNotFound {
+			return 0, scimErrorf(http.StatusBadRequest, "invalidValue", "member %q is not a user", m.Value)
+		}
+		return 0, err
+	}
+	return user.ID, nil
+}
+
+func getSCIMGroup(ctx context.Context, id string) (*types.Org, error) {
+	orgID, err := strconv.ParseInt(id, 10, 32)
+	if err != nil {
+		return nil, scimNotFound("Group", id)
+	}
+	org, err := db.Orgs.GetByID(ctx, int32(orgID))
+	if errcode.IsNotFound(err) {
+		return nil, scimNotFound("Group", id)
+	} else if err != nil {
+		return nil, errors.Wrap(err, "get organization")
+	}
+	return org, nil
+}
+
+func toSCIMGroup(ctx context.Context, org *types.Org) (*scimGroup, error) {
+	memberships, err := db.OrgMembers.GetByOrgID(ctx, org.ID)
+	if err != nil {
+		return nil, errors.Wrap(err, "list organization members")
+	}
+	userIDs := make([]int32, 0, len(memberships))
+	for _, m := range memberships {
+		userIDs = append(userIDs, m.UserID)
+	}
+
+	members := []scimMember{}
+	if len(userIDs) > 0 {
+		users, err := db.Users.List(ctx, &db.UsersListOptions{UserIDs: userIDs})
+		if err != nil {
+			return nil, errors.Wrap(err, "list organization members")
+		}
+		for _, u := range users {
+			members = append(members, scimMember{Value: strconv.Itoa(int(u.ID)), Display: u.Username})
+		}
+	}
+	sort.Slice(members, func(i, j int) bool { return members[i].Display < members[j].Display })
+
+	displayName := org.Name
+	if org.DisplayName != nil && *org.DisplayName != "" {
+		displayName = *org.DisplayName
+	}
+	return &scimGroup{
+		Schemas:     []string{scimSchemaGroup},
+		ID:          strconv.Itoa(int(org.ID)),
+		DisplayName: displayName,
+		Members:     members,
+		Meta: &scimMeta{
+			ResourceType: "Group",
+			Created:      org.CreatedAt,
+			LastModified: org.UpdatedAt,
+		},
+	}, nil
+}
+
+// scimGroupOrgName returns the name of the organization of a group with the display name.
+func scimGroupOrgName(displayName string) (string, error) {
+	if displayName == "" {
+		return "", scimErrorf(http.StatusBadRequest, "invalidValue", "displayName is required")
+	}
+	name, err := auth.NormalizeUsername(displayName)
+	if err != nil {
+		return "", scimErrorf(http.StatusBadRequest, "invalidValue", "%s", err)
+	}
+	return name, nil
+}
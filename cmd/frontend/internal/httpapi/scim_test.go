@@ -0,0 +1,511 @@
+package httpapi
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/db"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+const testSCIMToken = "0123456789abcdef0123456789abcdef"
+
+func mockSCIMConfig(t *testing.T) {
+	conf.Mock(&conf.Unified{SiteConfiguration: schema.SiteConfiguration{
+		AuthScim: &schema.AuthScim{AuthToken: testSCIMToken},
+	}})
+	t.Cleanup(func() {
+		conf.Mock(nil)
+		db.Mocks = db.MockStores{}
+	})
+}
+
+func doSCIM(t *testing.T, method, path, body string) (int, map[string]interface{}) {
+	t.Helper()
+	req, err := http.NewRequest(method, path, strings.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Authorization", "Bearer "+testSCIMToken)
+	req.Header.Set("Content-Type", scimContentType)
+
+	resp, err := newTest().Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var v map[string]interface{}
+	if len(data) > 0 {
+		if err := json.Unmarshal(data, &v); err != nil {
+			t.Fatalf("invalid response body %q: %v", data, err)
+		}
+	}
+	return resp.StatusCode, v
+}
+
+// scimAccount returns the SCIM external account of the user with the given account ID.
+func scimAccount(userID int32, accountID string) *extsvc.Account {
+	data := extsvc.AccountData{}
+	data.SetAccountData(&scimAccountData{UserName: accountID})
+	return &extsvc.Account{
+		UserID:      userID,
+		AccountSpec: extsvc.AccountSpec{ServiceType: scimServiceType, ServiceID: scimServiceType, AccountID: accountID},
+		AccountData: data,
+	}
+}
+
+// mockSCIMUser mocks the stores used to look up and render the user.
+func mockSCIMUser(user *types.User, acct *extsvc.Account) {
+	db.Mocks.Users.GetByID = func(ctx context.Context, id int32) (*types.User, error) {
+		if id != user.ID {
+			return nil, db.NewUserNotFoundError(id)
+		}
+		return user, nil
+	}
+	db.Mocks.ExternalAccounts.List = func(opt db.ExternalAccountsListOptions) ([]*extsvc.Account, error) {
+		if acct == nil || opt.ServiceType != scimServiceType || (opt.UserID != 0 && opt.UserID != acct.UserID) || (opt.AccountID != "" && opt.AccountID != acct.AccountID) {
+			return nil, nil
+		}
+		return []*extsvc.Account{acct}, nil
+	}
+	db.Mocks.UserEmails.ListByUser = func(ctx context.Context, opt db.UserEmailsListOptions) ([]*db.UserEmail, error) {
+		now := time.Now()
+		return []*db.UserEmail{{UserID: opt.UserID, Email: "alice@example.com", VerifiedAt: &now}}, nil
+	}
+}
+
+type mockNotFoundError struct{}
+
+func (*mockNotFoundError) Error() string  { return "not found" }
+func (*mockNotFoundError) NotFound() bool { return true }
+
+func TestSCIM_Auth(t *testing.T) {
+	t.Run("not enabled", func(t *testing.T) {
+		resp, err := newTest().Get("/scim/v2/Users")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if resp.StatusCode != http.StatusNotFound {
+			t.Errorf("got status %d, want %d", resp.StatusCode, http.StatusNotFound)
+		}
+	})
+
+	mockSCIMConfig(t)
+	for _, header := range []string{"", "Bearer wrong", "token " + testSCIMToken} {
+		req, _ := http.NewRequest("GET", "/scim/v2/Users", nil)
+		if header != "" {
+			req.Header.Set("Authorization", header)
+		}
+		resp, err := newTest().Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if resp.StatusCode != http.StatusUnauthorized {
+			t.Errorf("Authorization %q: got status %d, want %d", header, resp.StatusCode, http.StatusUnauthorized)
+		}
+	}
+}
+
+func TestSCIM_ListUsers(t *testing.T) {
+	mockSCIMConfig(t)
+
+	user := &types.User{ID: 1, Username: "alice", DisplayName: "Alice"}
+	data := extsvc.AccountData{}
+	data.SetAccountData(&scimAccountData{UserName: "alice@example.com", ExternalID: "00u1"})
+	mockSCIMUser(user, &extsvc.Account{
+		UserID:      1,
+		AccountSpec: extsvc.AccountSpec{ServiceType: scimServiceType, ServiceID: scimServiceType, AccountID: "00u1"},
+		AccountData: data,
+	})
+	db.Mocks.Users.GetByUsername = func(ctx context.Context, username string) (*types.User, error) {
+		if username != "alice" {
+			return nil, db.NewUserNotFoundError(0)
+		}
+		return user, nil
+	}
+
+	for _, filter := range []string{`userName eq "alice@example.com"`, `externalId eq "00u1"`} {
+		status, res := doSCIM(t, "GET", "/scim/v2/Users?filter="+strings.Replace(filter, " ", "+", -1), "")
+		if status != http.StatusOK {
+			t.Fatalf("filter %q: got status %d: %v", filter, status, res)
+		}
+		if res["totalResults"] != 1.0 {
+			t.Fatalf("filter %q: got totalResults %v, want 1", filter, res["totalResults"])
+		}
+		got := res["Resources"].([]interface{})[0].(map[string]interface{})
+		want := map[string]interface{}{"id": "1", "userName": "alice@example.com", "externalId": "00u1", "active": true}
+		for k, v := range want {
+			if got[k] != v {
+				t.Errorf("filter %q: got %s %v, want %v", filter, k, got[k], v)
+			}
+		}
+	}
+
+	status, res := doSCIM(t, "GET", `/scim/v2/Users?filter=userName+eq+"bob"`, "")
+	if status != http.StatusOK || res["totalResults"] != 0.0 {
+		t.Errorf("got status %d and totalResults %v, want no results", status, res["totalResults"])
+	}
+
+	status, res = doSCIM(t, "GET", `/scim/v2/Users?filter=userName+co+"ali"`, "")
+	if status != http.StatusBadRequest || res["scimType"] != "invalidFilter" {
+		t.Errorf("got status %d and scimType %v, want invalidFilter", status, res["scimType"])
+	}
+}
+
+func TestSCIM_CreateUser(t *testing.T) {
+	mockSCIMConfig(t)
+
+	db.Mocks.Users.GetByUsername = func(ctx context.Context, username string) (*types.User, error) {
+		return nil, db.NewUserNotFoundError(0)
+	}
+	var created *types.User
+	db.Mocks.ExternalAccounts.CreateUserAndSave = func(u db.NewUser, spec extsvc.AccountSpec, data extsvc.AccountData) (int32, error) {
+		if want := (db.NewUser{Username: "bob", DisplayName: "Bob Smith", Email: "bob@example.com", EmailIsVerified: true}); !reflect.DeepEqual(u, want) {
+			t.Errorf("got new user %+v, want %+v", u, want)
+		}
+		if want := (extsvc.AccountSpec{ServiceType: scimServiceType, ServiceID: scimServiceType, AccountID: "00u2"}); spec != want {
+			t.Errorf("got account spec %+v, want %+v", spec, want)
+		}
+		created = &types.User{ID: 2, Username: u.Username, DisplayName: u.DisplayName}
+		mockSCIMUser(created, &extsvc.Account{UserID: 2, AccountSpec: spec, AccountData: data})
+		return 2, nil
+	}
+	db.Mocks.Authz.GrantPendingPermissions = func(context.Context, *db.GrantPendingPermissionsArgs) error { return nil }
+	var deactivated bool
+	db.Mocks.Users.SetDeactivated = func(ctx context.Context, id int32, d bool) error {
+		deactivated = d
+		created.Deactivated = d
+		return nil
+	}
+
+	status, res := doSCIM(t, "POST", "/scim/v2/Users", `{
+		"schemas": ["urn:ietf:params:scim:schemas:core:2.0:User"],
+		"userName": "bob@example.com",
+		"externalId": "00u2",
+		"name": {"givenName": "Bob", "familyName": "Smith"},
+		"emails": [{"value": "bob@example.com", "primary": true}],
+		"active": false
+	}`)
+	if status != http.StatusCreated {
+		t.Fatalf("got status %d: %v", status, res)
+	}
+	if !deactivated {
+		t.Error("user was not deactivated")
+	}
+	if res["id"] != "2" || res["userName"] != "bob@example.com" || res["active"] != false {
+		t.Errorf("unexpected user %v", res)
+	}
+}
+
+func TestSCIM_PatchUser(t *testing.T) {
+	for _, body := range []string{
+		`{"Operations": [{"op": "replace", "value": {"active": false}}]}`,         // Okta
+		`{"Operations": [{"op": "Replace", "path": "active", "value": "False"}]}`, // Azure AD
+	} {
+		t.Run(body, func(t *testing.T) {
+			mockSCIMConfig(t)
+
+			user := &types.User{ID: 1, Username: "alice"}
+			mockSCIMUser(user, scimAccount(1, "alice"))
+			var deactivated []int32
+			db.Mocks.Users.SetDeactivated = func(ctx context.Context, id int32, d bool) error {
+				if d {
+					deactivated = append(deactivated, id)
+				}
+				user.Deactivated = d
+				return nil
+			}
+
+			status, res := doSCIM(t, "PATCH", "/scim/v2/Users/1", body)
+			if status != http.StatusOK {
+				t.Fatalf("got status %d: %v", status, res)
+			}
+			if want := []int32{1}; !reflect.DeepEqual(deactivated, want) {
+				t.Errorf("got deactivated users %v, want %v", deactivated, want)
+			}
+			if res["active"] != false {
+				t.Errorf("got active %v, want false", res["active"])
+			}
+		})
+	}
+
+	t.Run("not found", func(t *testing.T) {
+		mockSCIMConfig(t)
+		mockSCIMUser(&types.User{ID: 1}, scimAccount(1, "alice"))
+
+		status, _ := doSCIM(t, "PATCH", "/scim/v2/Users/2", `{"Operations": []}`)
+		if status != http.StatusNotFound {
+			t.Errorf("got status %d, want %d", status, http.StatusNotFound)
+		}
+	})
+
+	t.Run("email", func(t *testing.T) {
+		mockSCIMConfig(t)
+		mockSCIMUser(&types.User{ID: 1, Username: "alice"}, scimAccount(1, "alice"))
+		db.Mocks.UserEmails.Get = func(userID int32, email string) (string, bool, error) {
+			return "", false, &mockNotFoundError{}
+		}
+		var added []string
+		db.Mocks.UserEmails.Add = func(userID int32, email string, code *string) error {
+			added = append(added, email)
+			return nil
+		}
+		db.Mocks.UserEmails.SetVerified = func(ctx context.Context, userID int32, email string, verified bool) error {
+			t.Errorf("email %q of an existing user was marked as verified", email)
+			return nil
+		}
+
+		status, res := doSCIM(t, "PATCH", "/scim/v2/Users/1", `{"Operations": [{"op": "replace", "path": "emails[type eq \"work\"].value", "value": "mallory@example.com"}]}`)
+		if status != http.StatusOK {
+			t.Fatalf("got status %d: %v", status, res)
+		}
+		if want := []string{"mallory@example.com"}; !reflect.DeepEqual(added, want) {
+			t.Errorf("got added emails %v, want %v", added, want)
+		}
+	})
+}
+
+func TestSCIM_UserNotManagedBySCIM(t *testing.T) {
+	mockSCIMConfig(t)
+
+	// The user has no SCIM external account, e.g. because they signed up before SCIM was enabled.
+	user := &types.User{ID: 1, Username: "alice"}
+	mockSCIMUser(user, nil)
+	db.Mocks.Users.GetByUsername = func(ctx context.Context, username string) (*types.User, error) {
+		return user, nil
+	}
+	db.Mocks.Users.Delete = func(ctx context.Context, id int32) error {
+		t.Errorf("user %d was deleted", id)
+		return nil
+	}
+	db.Mocks.Users.SetDeactivated = func(ctx context.Context, id int32, d bool) error {
+		t.Errorf("user %d was deactivated", id)
+		return nil
+	}
+
+	for _, req := range []struct{ method, body string }{
+		{"GET", ""},
+		{"PUT", `{"userName": "alice", "active": false}`},
+		{"PATCH", `{"Operations": [{"op": "replace", "value": {"active": false}}]}`},
+		{"DELETE", ""},
+	} {
+		if status, _ := doSCIM(t, req.method, "/scim/v2/Users/1", req.body); status != http.StatusNotFound {
+			t.Errorf("%s: got status %d, want %d", req.method, status, http.StatusNotFound)
+		}
+	}
+
+	status, res := doSCIM(t, "GET", `/scim/v2/Users?filter=userName+eq+"alice"`, "")
+	if status != http.StatusOK || res["totalResults"] != 0.0 {
+		t.Errorf("got status %d and totalResults %v, want no results", status, res["totalResults"])
+	}
+}
+
+func TestSCIM_AdoptUser(t *testing.T) {
+	const body = `{
+		"schemas": ["urn:ietf:params:scim:schemas:core:2.0:User"],
+		"userName": "alice@corp.example.com",
+		"externalId": "00u1",
+		"emails": [{"value": "alice@example.com", "primary": true}]
+	}`
+
+	mockAdoption := func(t *testing.T, user *types.User) (associated *[]int32) {
+		mockSCIMConfig(t)
+		conf.Mock(&conf.Unified{SiteConfiguration: schema.SiteConfiguration{
+			AuthScim: &schema.AuthScim{AuthToken: testSCIMToken, AdoptExistingUsers: true},
+		}})
+
+		// The user existed before SCIM was enabled.
+		mockSCIMUser(user, nil)
+		db.Mocks.Users.GetByVerifiedEmail = func(ctx context.Context, email string) (*types.User, error) {
+			if email != "alice@example.com" {
+				return nil, db.NewUserNotFoundError(0)
+			}
+			return user, nil
+		}
+		db.Mocks.Users.GetByUsername = func(ctx context.Context, username string) (*types.User, error) {
+			return user, nil
+		}
+		db.Mocks.ExternalAccounts.CreateUserAndSave = func(u db.NewUser, spec extsvc.AccountSpec, data extsvc.AccountData) (int32, error) {
+			t.Errorf("user %q was created", u.Username)
+			return 0, nil
+		}
+		associated = &[]int32{}
+		db.Mocks.ExternalAccounts.AssociateUserAndSave = func(userID int32, spec extsvc.AccountSpec, data extsvc.AccountData) error {
+			if want := (extsvc.AccountSpec{ServiceType: scimServiceType, ServiceID: scimServiceType, AccountID: "00u1"}); spec != want {
+				t.Errorf("got account spec %+v, want %+v", spec, want)
+			}
+			*associated = append(*associated, userID)
+			mockSCIMUser(user, &extsvc.Account{UserID: userID, AccountSpec: spec, AccountData: data})
+			return nil
+		}
+		db.Mocks.Users.SetDeactivated = func(ctx context.Context, id int32, d bool) error {
+			user.Deactivated = d
+			return nil
+		}
+		return associated
+	}
+
+	t.Run("adopt and deactivate", func(t *testing.T) {
+		user := &types.User{ID: 1, Username: "alice"}
+		associated := mockAdoption(t, user)
+
+		status, res := doSCIM(t, "POST", "/scim/v2/Users", body)
+		if status != http.StatusCreated {
+			t.Fatalf("got status %d: %v", status, res)
+		}
+		if want := []int32{1}; !reflect.DeepEqual(*associated, want) {
+			t.Errorf("got associated users %v, want %v", *associated, want)
+		}
+		if res["id"] != "1" || res["userName"] != "alice@corp.example.com" {
+			t.Errorf("unexpected user %v", res)
+		}
+
+		// The adopted user is now managed by SCIM.
+		status, res = doSCIM(t, "PATCH", "/scim/v2/Users/1", `{"Operations": [{"op": "replace", "value": {"active": false}}]}`)
+		if status != http.StatusOK {
+			t.Fatalf("got status %d: %v", status, res)
+		}
+		if !user.Deactivated {
+			t.Error("adopted user was not deactivated")
+		}
+	})
+
+	t.Run("not enabled", func(t *testing.T) {
+		associated := mockAdoption(t, &types.User{ID: 1, Username: "alice"})
+		mockSCIMConfig(t)
+
+		if status, res := doSCIM(t, "POST", "/scim/v2/Users", body); status != http.StatusConflict {
+			t.Errorf("got status %d: %v", status, res)
+		}
+		if len(*associated) != 0 {
+			t.Errorf("got associated users %v, want none", *associated)
+		}
+	})
+
+	t.Run("site admin", func(t *testing.T) {
+		associated := mockAdoption(t, &types.User{ID: 1, Username: "alice", SiteAdmin: true})
+
+		if status, res := doSCIM(t, "POST", "/scim/v2/Users", body); status != http.StatusConflict {
+			t.Errorf("got status %d: %v", status, res)
+		}
+		if len(*associated) != 0 {
+			t.Errorf("got associated users %v, want none", *associated)
+		}
+	})
+}
+
+func TestSCIM_SiteAdmin(t *testing.T) {
+	mockSCIMConfig(t)
+
+	mockSCIMUser(&types.User{ID: 1, Username: "admin", SiteAdmin: true}, scimAccount(1, "admin"))
+	db.Mocks.Users.Delete = func(ctx context.Context, id int32) error {
+		t.Errorf("user %d was deleted", id)
+		return nil
+	}
+	db.Mocks.Users.SetDeactivated = func(ctx context.Context, id int32, d bool) error {
+		t.Errorf("user %d was deactivated", id)
+		return nil
+	}
+
+	if status, res := doSCIM(t, "GET", "/scim/v2/Users/1", ""); status != http.StatusOK {
+		t.Errorf("GET: got status %d: %v", status, res)
+	}
+	for _, req := range []struct{ method, body string }{
+		{"PUT", `{"userName": "admin", "active": false}`},
+		{"PATCH", `{"Operations": [{"op": "replace", "value": {"active": false}}]}`},
+		{"DELETE", ""},
+	} {
+		if status, _ := doSCIM(t, req.method, "/scim/v2/Users/1", req.body); status != http.StatusForbidden {
+			t.Errorf("%s: got status %d, want %d", req.method, status, http.StatusForbidden)
+		}
+	}
+}
+
+func TestSCIM_PatchGroup(t *testing.T) {
+	mockSCIMConfig(t)
+
+	org := &types.Org{ID: 10, Name: "engineering"}
+	db.Mocks.Orgs.GetByID = func(ctx context.Context, id int32) (*types.Org, error) {
+		if id != org.ID {
+			return nil, &db.OrgNotFoundError{}
+		}
+		return org, nil
+	}
+	db.Mocks.Users.GetByID = func(ctx context.Context, id int32) (*types.User, error) {
+		return &types.User{ID: id}, nil
+	}
+	db.Mocks.Users.List = func(ctx context.Context, opt *db.UsersListOptions) ([]*types.User, error) {
+		return nil, nil
+	}
+	// Users 1-3 are managed by SCIM, user 4 (e.g. the site admin) and user 5 are not.
+	db.Mocks.ExternalAccounts.List = func(opt db.ExternalAccountsListOptions) ([]*extsvc.Account, error) {
+		if opt.UserID >= 1 && opt.UserID <= 3 {
+			return []*extsvc.Account{scimAccount(opt.UserID, strconv.Itoa(int(opt.UserID)))}, nil
+		}
+		return nil, nil
+	}
+
+	members := map[int32]bool{1: true, 2: true, 4: true}
+	db.Mocks.OrgMembers.GetByOrgID = func(ctx context.Context, orgID int32) ([]*types.OrgMembership, error) {
+		var ms []*types.OrgMembership
+		for userID := range members {
+			ms = append(ms, &types.OrgMembership{OrgID: orgID, UserID: userID})
+		}
+		return ms, nil
+	}
+	db.Mocks.OrgMembers.Create = func(ctx context.Context, orgID, userID int32) (*types.OrgMembership, error) {
+		members[userID] = true
+		return &types.OrgMembership{OrgID: orgID, UserID: userID}, nil
+	}
+	db.Mocks.OrgMembers.Remove = func(ctx context.Context, orgID, userID int32) error {
+		delete(members, userID)
+		return nil
+	}
+
+	status, res := doSCIM(t, "PATCH", "/scim/v2/Groups/10", `{"Operations": [
+		{"op": "add", "path": "members", "value": [{"value": "3"}]},
+		{"op": "remove", "path": "members[value eq \"1\"]"},
+		{"op": "Remove", "path": "members", "value": [{"value": "2"}]}
+	]}`)
+	if status != http.StatusOK {
+		t.Fatalf("got status %d: %v", status, res)
+	}
+	// Members that are not managed by SCIM are kept.
+	if want := map[int32]bool{3: true, 4: true}; !reflect.DeepEqual(members, want) {
+		t.Errorf("got members %v, want %v", members, want)
+	}
+
+	// Members can only be replaced by users that are managed by SCIM.
+	status, res = doSCIM(t, "PATCH", "/scim/v2/Groups/10", `{"Operations": [{"op": "replace", "path": "members", "value": [{"value": "1"}]}]}`)
+	if status != http.StatusOK {
+		t.Fatalf("got status %d: %v", status, res)
+	}
+	if want := map[int32]bool{1: true, 4: true}; !reflect.DeepEqual(members, want) {
+		t.Errorf("got members %v, want %v", members, want)
+	}
+	status, res = doSCIM(t, "PATCH", "/scim/v2/Groups/10", `{"Operations": [{"op": "add", "path": "members", "value": [{"value": "5"}]}]}`)
+	if status != http.StatusBadRequest || res["scimType"] != "invalidValue" {
+		t.Errorf("got status %d and scimType %v, want invalidValue error", status, res["scimType"])
+	}
+	if members[5] {
+		t.Error("user that is not managed by SCIM was added")
+	}
+
+	status, res = doSCIM(t, "PATCH", "/scim/v2/Groups/10", `{"Operations": [{"op": "replace", "path": "displayName", "value": "Sales"}]}`)
+	if status != http.StatusBadRequest || res["scimType"] != "mutability" {
+		t.Errorf("got status %d and scimType %v, want mutability error", status, res["scimType"])
+	}
+}
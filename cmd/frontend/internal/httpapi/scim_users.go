@@ -0,0 +1,690 @@
+package httpapi
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gorilla/mux"
+	"github.com/inconshreveable/log15"
+	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/auth"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/db"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+)
+
+// scimServiceType is the service type and service ID of the external accounts that link
+// Sourcegraph users to the users of the SCIM client.
+const scimServiceType = "scim"
+
+// scimUser is a SCIM User resource.
+type scimUser struct {
+	Schemas     []string    `json:"schemas"`
+	ID          string      `json:"id,omitempty"`
+	ExternalID  string      `json:"externalId,omitempty"`
+	UserName    string      `json:"userName"`
+	Name        *scimName   `json:"name,omitempty"`
+	DisplayName string      `json:"displayName,omitempty"`
+	Active      *bool       `json:"active,omitempty"`
+	Emails      []scimEmail `json:"emails,omitempty"`
+	Meta        *scimMeta   `json:"meta,omitempty"`
+}
+
+type scimName struct {
+	Formatted  string `json:"formatted,omitempty"`
+	GivenName  string `json:"givenName,omitempty"`
+	FamilyName string `json:"familyName,omitempty"`
+}
+
+type scimEmail struct {
+	Value   string `json:"value"`
+	Type    string `json:"type,omitempty"`
+	Primary bool   `json:"primary,omitempty"`
+}
+
+// scimAccountData is the account data of the external account of a SCIM-provisioned user.
+type scimAccountData struct {
+	UserName   string `json:"userName"`
+	ExternalID string `json:"externalId,omitempty"`
+}
+
+// accountID returns the account ID of the external account. The external ID is preferred, as the
+// userName may change in the identity provider.
+func (d *scimAccountData) accountID() string {
+	if d.ExternalID != "" {
+		return d.ExternalID
+	}
+	return d.UserName
+}
+
+// scimUserUpdate is a change to the attributes of a user requested by a PUT or PATCH request. Nil
+// fields are unchanged.
+type scimUserUpdate struct {
+	UserName    *string
+	DisplayName *string
+	ExternalID  *string
+	Email       *string
+	Active      *bool
+}
+
+func serveSCIMUsers(w http.ResponseWriter, r *http.Request) error {
+	switch r.Method {
+	case "GET":
+		return listSCIMUsers(w, r)
+	case "POST":
+		return createSCIMUser(w, r)
+	}
+	return scimErrorf(http.StatusMethodNotAllowed, "", "unsupported method %s", r.Method)
+}
+
+func serveSCIMUser(w http.ResponseWriter, r *http.Request) error {
+	user, err := getSCIMUser(r.Context(), mux.Vars(r)["id"])
+	if err != nil {
+		return err
+	}
+
+	// 🚨 SECURITY: Site admins can only be changed by site admins, not by the SCIM client, so that
+	// an identity provider can't lock out or take over the administrators of the instance.
+	if r.Method != "GET" && user.SiteAdmin {
+		return scimErrorf(http.StatusForbidden, "", "site admins can't be changed by SCIM")
+	}
+
+	switch r.Method {
+	case "GET":
+	case "PUT":
+		var req scimUser
+		if err := decodeSCIMRequest(w, r, &req); err != nil {
+			return err
+		}
+		if req.UserName == "" {
+			return scimErrorf(http.StatusBadRequest, "invalidValue", "userName is required")
+		}
+		active := req.Active == nil || *req.Active
+		email := primarySCIMEmail(req.Emails)
+		if err := updateSCIMUser(r.Context(), user, &scimUserUpdate{
+			UserName:    &req.UserName,
+			DisplayName: strPtr(scimDisplayName(&req)),
+			ExternalID:  &req.ExternalID,
+			Email:       &email,
+			Active:      &active,
+		}); err != nil {
+			return err
+		}
+	case "PATCH":
+		var req scimPatchRequest
+		if err := decodeSCIMRequest(w, r, &req); err != nil {
+			return err
+		}
+		update, err := scimUserPatch(req.Operations)
+		if err != nil {
+			return err
+		}
+		if err := updateSCIMUser(r.Context(), user, update); err != nil {
+			return err
+		}
+	case "DELETE":
+		if err := db.Users.Delete(r.Context(), user.ID); err != nil {
+			return errors.Wrap(err, "delete user")
+		}
+		w.WriteHeader(http.StatusNoContent)
+		return nil
+	default:
+		return scimErrorf(http.StatusMethodNotAllowed, "", "unsupported method %s", r.Method)
+	}
+
+	// Return the user as stored, which may differ from the request (e.g. the normalized username).
+	if user, err = db.Users.GetByID(r.Context(), user.ID); err != nil {
+		return errors.Wrap(err, "get user")
+	}
+	res, err := toSCIMUser(r.Context(), user)
+	if err != nil {
+		return err
+	}
+	writeSCIMJSON(w, http.StatusOK, res)
+	return nil
+}
+
+func listSCIMUsers(w http.ResponseWriter, r *http.Request) error {
+	ctx := r.Context()
+	filter, err := parseSCIMFilter(r.URL.Query().Get("filter"))
+	if err != nil {
+		return err
+	}
+	startIndex, count := scimPagination(r)
+
+	var users []*types.User
+	var total int
+	switch {
+	case filter == nil:
+		opt := db.ExternalAccountsListOptions{ServiceType: scimServiceType, ServiceID: scimServiceType}
+		if total, err = db.ExternalAccounts.Count(ctx, opt); err != nil {
+			return errors.Wrap(err, "count external accounts")
+		}
+		opt.LimitOffset = &db.LimitOffset{Limit: count, Offset: startIndex - 1}
+		accts, err := db.ExternalAccounts.List(ctx, opt)
+		if err != nil {
+			return errors.Wrap(err, "list external accounts")
+		}
+		if users, err = scimAccountUsers(ctx, accts); err != nil {
+			return err
+		}
+
+	case strings.EqualFold(filter.Attribute, "userName"):
+		username, err := auth.NormalizeUsername(filter.Value)
+		if err != nil {
+			break // No user can have this username.
+		}
+		user, err := db.Users.GetByUsername(ctx, username)
+		if err != nil && !errcode.IsNotFound(err) {
+			return errors.Wrap(err, "get user by username")
+		}
+		if user == nil {
+			break
+		}
+		if _, acct, err := getSCIMAccountData(ctx, user); err != nil {
+			return err
+		} else if acct != nil {
+			users = append(users, user)
+		}
+
+	case strings.EqualFold(filter.Attribute, "externalId"):
+		accts, err := db.ExternalAccounts.List(ctx, db.ExternalAccountsListOptions{
+			ServiceType: scimServiceType,
+			ServiceID:   scimServiceType,
+			AccountID:   filter.Value,
+		})
+		if err != nil {
+			return errors.Wrap(err, "list external accounts")
+		}
+		if users, err = scimAccountUsers(ctx, accts); err != nil {
+			return err
+		}
+
+	default:
+		return scimErrorf(http.StatusBadRequest, "invalidFilter", "filtering by attribute %q is not supported", filter.Attribute)
+	}
+
+	if filter != nil {
+		total = len(users)
+		if startIndex > len(users) {
+			users = nil
+		} else {
+			users = users[startIndex-1:]
+		}
+		if len(users) > count {
+			users = users[:count]
+		}
+	}
+
+	resources := make([]*scimUser, 0, len(users))
+	for _, user := range users {
+		res, err := toSCIMUser(ctx, user)
+		if err != nil {
+			return err
+		}
+		resources = append(resources, res)
+	}
+	writeSCIMJSON(w, http.StatusOK, &scimListResponse{
+		Schemas:      []string{scimSchemaListResponse},
+		TotalResults: total,
+		StartIndex:   startIndex,
+		ItemsPerPage: len(resources),
+		Resources:    resources,
+	})
+	return nil
+}
+
+// scimAccountUsers returns the users of the SCIM external accounts.
+func scimAccountUsers(ctx context.Context, accts []*extsvc.Account) ([]*types.User, error) {
+	users := make([]*types.User, 0, len(accts))
+	for _, acct := range accts {
+		user, err := db.Users.GetByID(ctx, acct.UserID)
+		if errcode.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return nil, errors.Wrap(err, "get user")
+		}
+		users = append(users, user)
+	}
+	return users, nil
+}
+
+func createSCIMUser(w http.ResponseWriter, r *http.Request) error {
+	ctx := r.Context()
+	var req scimUser
+	if err := decodeSCIMRequest(w, r, &req); err != nil {
+		return err
+	}
+	if req.UserName == "" {
+		return scimErrorf(http.StatusBadRequest, "invalidValue", "userName is required")
+	}
+	username, err := auth.NormalizeUsername(req.UserName)
+	if err != nil {
+		return scimErrorf(http.StatusBadRequest, "invalidValue", "%s", err)
+	}
+
+	email := primarySCIMEmail(req.Emails)
+	if cfg := conf.Get().AuthScim; cfg != nil && cfg.AdoptExistingUsers && email != "" {
+		user, err := getAdoptableSCIMUser(ctx, email)
+		if err != nil {
+			return err
+		}
+		if user != nil {
+			return adoptSCIMUser(w, r, user, &req)
+		}
+	}
+
+	if _, err := db.Users.GetByUsername(ctx, username); err == nil {
+		return scimErrorf(http.StatusConflict, "uniqueness", "a user with the username %q already exists", username)
+	} else if !errcode.IsNotFound(err) {
+		return errors.Wrap(err, "get user by username")
+	}
+
+	data := scimAccountData{UserName: req.UserName, ExternalID: req.ExternalID}
+	var accountData extsvc.AccountData
+	accountData.SetAccountData(&data)
+
+	userID, err := db.ExternalAccounts.CreateUserAndSave(ctx, db.NewUser{
+		Username:    username,
+		DisplayName: scimDisplayName(&req),
+		Email:       email,
+		// The identity provider is trusted to manage the email addresses of its users.
+		EmailIsVerified: email != "",
+	}, scimAccountSpec(&data), accountData)
+	switch {
+	case db.IsUsernameExists(err), db.IsEmailExists(err):
+		return scimErrorf(http.StatusConflict, "uniqueness", "%s", err)
+	case errcode.PresentationMessage(err) != "":
+		return scimErrorf(http.StatusBadRequest, "", "%s", errcode.PresentationMessage(err))
+	case err != nil:
+		return errors.Wrap(err, "create user")
+	}
+
+	if err = db.Authz.GrantPendingPermissions(ctx, &db.GrantPendingPermissionsArgs{
+		UserID: userID,
+		Perm:   authz.Read,
+		Type:   authz.PermRepos,
+	}); err != nil {
+		log15.Error("Failed to grant user pending permissions", "userID", userID, "error", err)
+	}
+
+	if req.Active != nil && !*req.Active {
+		if err := db.Users.SetDeactivated(ctx, userID, true); err != nil {
+			return errors.Wrap(err, "deactivate user")
+		}
+	}
+
+	user, err := db.Users.GetByID(ctx, userID)
+	if err != nil {
+		return errors.Wrap(err, "get user")
+	}
+	res, err := toSCIMUser(ctx, user)
+	if err != nil {
+		return err
+	}
+	writeSCIMJSON(w, http.StatusCreated, res)
+	return nil
+}
+
+// getAdoptableSCIMUser returns the existing user with the given verified email address, or nil if
+// there is none.
+//
+// 🚨 SECURITY: Only the verified email addresses of users match, so that the SCIM client can only
+// adopt users whose address was verified by both the identity provider and the user. Site admins
+// are never adopted, so that an identity provider can't lock out or take over the administrators
+// of the instance.
+func getAdoptableSCIMUser(ctx context.Context, email string) (*types.User, error) {
+	user, err := db.Users.GetByVerifiedEmail(ctx, email)
+	if errcode.IsNotFound(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Wrap(err, "get user by verified email")
+	}
+	if user.SiteAdmin {
+		return nil, scimErrorf(http.StatusConflict, "uniqueness", "the existing user with the email %q is a site admin and can't be managed by SCIM", email)
+	}
+	if _, acct, err := getSCIMAccountData(ctx, user); err != nil {
+		return nil, err
+	} else if acct != nil {
+		return nil, scimErrorf(http.StatusConflict, "uniqueness", "the user with the email %q is already provisioned by SCIM", email)
+	}
+	return user, nil
+}
+
+// adoptSCIMUser links the existing user to the SCIM client, which manages the user from then on.
+// The username of the user is kept until the SCIM client changes it.
+func adoptSCIMUser(w http.ResponseWriter, r *http.Request, user *types.User, req *scimUser) error {
+	ctx := r.Context()
+	if err := saveSCIMAccount(ctx, user.ID, &scimAccountData{UserName: req.UserName, ExternalID: req.ExternalID}); err != nil {
+		return err
+	}
+	log15.Info("Adopted existing user for SCIM", "userID", user.ID, "userName", req.UserName)
+
+	update := &scimUserUpdate{Active: req.Active}
+	if displayName := scimDisplayName(req); displayName != "" {
+		update.DisplayName = &displayName
+	}
+	if err := updateSCIMUser(ctx, user, update); err != nil {
+		return err
+	}
+
+	user, err := db.Users.GetByID(ctx, user.ID)
+	if err != nil {
+		return errors.Wrap(err, "get user")
+	}
+	res, err := toSCIMUser(ctx, user)
+	if err != nil {
+		return err
+	}
+	writeSCIMJSON(w, http.StatusCreated, res)
+	return nil
+}
+
+// updateSCIMUser applies the update to the user, its email addresses and its external account.
+func updateSCIMUser(ctx context.Context, user *types.User, update *scimUserUpdate) error {
+	var userUpdate db.UserUpdate
+	if update.UserName != nil {
+		username, err := auth.NormalizeUsername(*update.UserName)
+		if err != nil {
+			return scimErrorf(http.StatusBadRequest, "invalidValue", "%s", err)
+		}
+		if username != user.Username {
+			userUpdate.Username = username
+		}
+	}
+	if update.DisplayName != nil && *update.DisplayName != user.DisplayName {
+		userUpdate.DisplayName = update.DisplayName
+	}
+	if userUpdate != (db.UserUpdate{}) {
+		if err := db.Users.Update(ctx, user.ID, userUpdate); err != nil {
+			if db.IsUsernameExists(err) {
+				return scimErrorf(http.StatusConflict, "uniqueness", "%s", err)
+			}
+			return errors.Wrap(err, "update user")
+		}
+	}
+
+	if update.Email != nil && *update.Email != "" {
+		if err := addSCIMUserEmail(ctx, user.ID, *update.Email); err != nil {
+			return err
+		}
+	}
+
+	if update.UserName != nil || update.ExternalID != nil {
+		data, _, err := getSCIMAccountData(ctx, user)
+		if err != nil {
+			return err
+		}
+		if update.UserName != nil {
+			data.UserName = *update.UserName
+		}
+		if update.ExternalID != nil {
+			data.ExternalID = *update.ExternalID
+		}
+		if err := saveSCIMAccount(ctx, user.ID, data); err != nil {
+			return err
+		}
+	}
+
+	// 🚨 SECURITY: Deactivating a user also invalidates all of their sessions.
+	if update.Active != nil && *update.Active == user.Deactivated {
+		if err := db.Users.SetDeactivated(ctx, user.ID, !*update.Active); err != nil {
+			return errors.Wrap(err, "set user deactivated")
+		}
+	}
+	return nil
+}
+
+// addSCIMUserEmail adds the email address to the user unless the user already has it.
+//
+// 🚨 SECURITY: The email address is not marked as verified. Unlike for users created by the SCIM
+// client, the user's existing email addresses were not provided by the identity provider, so
+// verifying a new address would let the SCIM client take over the address of another user (e.g.
+// to receive their password reset emails). The user must verify it like any other address.
+func addSCIMUserEmail(ctx context.Context, userID int32, email string) error {
+	if _, _, err := db.UserEmails.Get(ctx, userID, email); err == nil {
+		return nil
+	} else if !errcode.IsNotFound(err) {
+		return errors.Wrap(err, "get email")
+	}
+
+	var code *string
+	if conf.EmailVerificationRequired() {
+		tmp, err := backend.MakeEmailVerificationCode()
+		if err != nil {
+			return err
+		}
+		code = &tmp
+	}
+	if err := db.UserEmails.Add(ctx, userID, email, code); err != nil {
+		return errors.Wrap(err, "add email")
+	}
+
+	if code != nil {
+		if err := backend.SendUserEmailVerificationEmail(ctx, email, *code); err != nil {
+			log15.Error("Failed to send email verification email", "userID", userID, "error", err)
+		} else if err := db.UserEmails.SetLastVerificationSentAt(ctx, userID, email); err != nil {
+			return errors.Wrap(err, "set last verification sent at")
+		}
+	}
+	return nil
+}
+
+// scimUserPatch converts the operations of a PATCH request to an update. Operations on attributes
+// that Sourcegraph does not store are ignored.
+func scimUserPatch(ops []scimPatchOperation) (*scimUserUpdate, error) {
+	var update scimUserUpdate
+	for _, op := range ops {
+		switch strings.ToLower(op.Op) {
+		case "add", "replace":
+		case "remove":
+			continue
+		default:
+			return nil, scimErrorf(http.StatusBadRequest, "invalidSyntax", "unsupported operation %q", op.Op)
+		}
+
+		attrs := map[string]json.RawMessage{op.Path: op.Value}
+		if op.Path == "" {
+			// The value is an object with the attributes to set.
+			attrs = nil
+			if err := json.Unmarshal(op.Value, &attrs); err != nil {
+				return nil, scimErrorf(http.StatusBadRequest, "invalidValue", "invalid value of operation without path: %v", err)
+			}
+		}
+
+		for path, value := range attrs {
+			switch attr := strings.ToLower(path); {
+			case attr == "active":
+				active, err := scimBool(value)
+				if err != nil {
+					return nil, err
+				}
+				update.Active = &active
+			case attr == "username", attr == "displayname", attr == "externalid", attr == "name.formatted":
+				var s string
+				if err := json.Unmarshal(value, &s); err != nil {
+					return nil, scimErrorf(http.StatusBadRequest, "invalidValue", "expected a string value of %q", path)
+				}
+				switch attr {
+				case "username":
+					update.UserName = &s
+				case "externalid":
+					update.ExternalID = &s
+				default:
+					update.DisplayName = &s
+				}
+			case attr == "emails":
+				var emails []scimEmail
+				if err := json.Unmarshal(value, &emails); err != nil {
+					return nil, scimErrorf(http.StatusBadRequest, "invalidValue", "invalid emails: %v", err)
+				}
+				email := primarySCIMEmail(emails)
+				update.Email = &email
+			case strings.HasPrefix(attr, "emails[") && strings.HasSuffix(attr, "].value"):
+				// E.g. `emails[type eq "work"].value`, as sent by Azure AD.
+				var s string
+				if err := json.Unmarshal(value, &s); err != nil {
+					return nil, scimErrorf(http.StatusBadRequest, "invalidValue", "expected a string value of %q", path)
+				}
+				update.Email = &s
+			}
+		}
+	}
+	return &update, nil
+}
+
+// getSCIMUser returns the user with the given ID.
+//
+// 🚨 SECURITY: Only users that are linked to the SCIM client by an external account (i.e. that
+// were provisioned or adopted by SCIM) are managed by SCIM. Other users are not found, so that the
+// SCIM client can't change or delete them.
+func getSCIMUser(ctx context.Context, id string) (*types.User, error) {
+	userID, err := strconv.ParseInt(id, 10, 32)
+	if err != nil {
+		return nil, scimNotFound("User", id)
+	}
+	user, err := db.Users.GetByID(ctx, int32(userID))
+	if errcode.IsNotFound(err) {
+		return nil, scimNotFound("User", id)
+	} else if err != nil {
+		return nil, errors.Wrap(err, "get user")
+	}
+	if _, acct, err := getSCIMAccountData(ctx, user); err != nil {
+		return nil, err
+	} else if acct == nil {
+		return nil, scimNotFound("User", id)
+	}
+	return user, nil
+}
+
+func toSCIMUser(ctx context.Context, user *types.User) (*scimUser, error) {
+	data, _, err := getSCIMAccountData(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+
+	emails, err := db.UserEmails.ListByUser(ctx, db.UserEmailsListOptions{UserID: user.ID})
+	if err != nil {
+		return nil, errors.Wrap(err, "list emails")
+	}
+	var scimEmails []scimEmail
+	hasPrimary := false
+	for _, e := range emails {
+		primary := !hasPrimary && e.VerifiedAt != nil
+		hasPrimary = hasPrimary || primary
+		scimEmails = append(scimEmails, scimEmail{Value: e.Email, Type: "work", Primary: primary})
+	}
+
+	active := !user.Deactivated
+	return &scimUser{
+		Schemas:     []string{scimSchemaUser},
+		ID:          strconv.Itoa(int(user.ID)),
+		ExternalID:  data.ExternalID,
+		UserName:    data.UserName,
+		DisplayName: user.DisplayName,
+		Active:      &active,
+		Emails:      scimEmails,
+		Meta: &scimMeta{
+			ResourceType: "User",
+			Created:      user.CreatedAt,
+			LastModified: user.UpdatedAt,
+		},
+	}, nil
+}
+
+// getSCIMAccountData returns the account data and the SCIM external account of the user. If the
+// user has none (i.e. it is not managed by SCIM), the account is nil and the username is used as
+// the userName.
+func getSCIMAccountData(ctx context.Context, user *types.User) (*scimAccountData, *extsvc.Account, error) {
+	accts, err := db.ExternalAccounts.List(ctx, db.ExternalAccountsListOptions{
+		UserID:      user.ID,
+		ServiceType: scimServiceType,
+		ServiceID:   scimServiceType,
+	})
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "list external accounts")
+	}
+
+	data := &scimAccountData{UserName: user.Username}
+	if len(accts) == 0 {
+		return data, nil, nil
+	}
+	acct := accts[0]
+	if acct.Data != nil {
+		if err := acct.GetAccountData(data); err != nil {
+			return nil, nil, errors.Wrapf(err, "get account data of external account %d", acct.ID)
+		}
+	}
+	return data, acct, nil
+}
+
+// saveSCIMAccount links the user to the user of the SCIM client described by data. A previous link
+// with a different account ID is removed.
+func saveSCIMAccount(ctx context.Context, userID int32, data *scimAccountData) error {
+	accts, err := db.ExternalAccounts.List(ctx, db.ExternalAccountsListOptions{
+		UserID:      userID,
+		ServiceType: scimServiceType,
+		ServiceID:   scimServiceType,
+	})
+	if err != nil {
+		return errors.Wrap(err, "list external accounts")
+	}
+	spec := scimAccountSpec(data)
+	for _, acct := range accts {
+		if acct.AccountID != spec.AccountID {
+			if err := db.ExternalAccounts.Delete(ctx, acct.ID); err != nil {
+				return errors.Wrapf(err, "delete external account %d", acct.ID)
+			}
+		}
+	}
+
+	var accountData extsvc.AccountData
+	accountData.SetAccountData(data)
+	if err := db.ExternalAccounts.AssociateUserAndSave(ctx, userID, spec, accountData); err != nil {
+		return scimErrorf(http.StatusConflict, "uniqueness", "%s", err)
+	}
+	return nil
+}
+
+func scimAccountSpec(data *scimAccountData) extsvc.AccountSpec {
+	return extsvc.AccountSpec{
+		ServiceType: scimServiceType,
+		ServiceID:   scimServiceType,
+		AccountID:   data.accountID(),
+	}
+}
+
+// primarySCIMEmail returns the primary email address, or the first one if none is primary.
+func primarySCIMEmail(emails []scimEmail) string {
+	for _, e := range emails {
+		if e.Primary {
+			return e.Value
+		}
+	}
+	if len(emails) > 0 {
+		return emails[0].Value
+	}
+	return ""
+}
+
+func scimDisplayName(u *scimUser) string {
+	if u.DisplayName != "" {
+		return u.DisplayName
+	}
+	if u.Name != nil {
+		if u.Name.Formatted != "" {
+			return u.Name.Formatted
+		}
+		return strings.TrimSpace(u.Name.GivenName + " " + u.Name.FamilyName)
+	}
+	return ""
+}
+
+func strPtr(s string) *string { return &s }
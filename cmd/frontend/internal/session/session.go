@@ -16,6 +16,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/db"
 	"github.com/sourcegraph/sourcegraph/internal/env"
 	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/httputil"
 	"github.com/sourcegraph/sourcegraph/internal/redispool"
 
 	"github.com/inconshreveable/log15"
@@ -56,8 +57,25 @@ type sessionInfo struct {
 	Actor        *actor.Actor  `json:"actor"`
 	LastActive   time.Time     `json:"lastActive"`
 	ExpiryPeriod time.Duration `json:"expiryPeriod"`
+
+	// SessionID is the ID of the session in the user_sessions table, which lets users list and
+	// revoke their sessions. It is 0 for sessions that were created before sessions were tracked
+	// there.
+	SessionID int64 `json:"sessionID,omitempty"`
+}
+
+// userSessionStore is the subset of db.UserSessions used to track sessions. ResetMockSessionStore
+// replaces it with an in-memory implementation.
+type userSessionStore interface {
+	Create(ctx context.Context, s db.UserSession) (int64, error)
+	GetByID(ctx context.Context, id int64) (*db.UserSession, error)
+	Touch(ctx context.Context, id int64, lastIP string, expiresAt time.Time) error
+	Delete(ctx context.Context, id int64) error
+	DeleteByUserID(ctx context.Context, userID int32) error
 }
 
+var userSessions userSessionStore = db.UserSessions
+
 // SetSessionStore sets the backing store used for storing sessions on the server. It should be called exactly once.
 func SetSessionStore(s sessions.Store) {
 	sessionStore = s
@@ -222,6 +240,9 @@ func GetData(r *http.Request, key string, value interface{}) error {
 //
 // If expiryPeriod is 0, the default expiry period is used.
 func SetActor(w http.ResponseWriter, r *http.Request, actor *actor.Actor, expiryPeriod time.Duration) error {
+	// The previous actor (if any) is signed out, so its session must no longer be listed.
+	revokeCurrentSession(r)
+
 	var value *sessionInfo
 	if actor != nil {
 		if expiryPeriod == 0 {
@@ -232,10 +253,46 @@ func SetActor(w http.ResponseWriter, r *http.Request, actor *actor.Actor, expiry
 			}
 		}
 		value = &sessionInfo{Actor: actor, ExpiryPeriod: expiryPeriod, LastActive: time.Now()}
+
+		id, err := userSessions.Create(r.Context(), db.UserSession{
+			UserID:    actor.UID,
+			UserAgent: r.UserAgent(),
+			LastIP:    httputil.RemoteAddr(r),
+			ExpiresAt: value.LastActive.Add(expiryPeriod),
+		})
+		if err != nil {
+			return errors.WithMessage(err, "recording session")
+		}
+		value.SessionID = id
 	}
 	return SetData(w, r, "actor", value)
 }
 
+// revokeCurrentSession revokes the session (if any) of the request in the user_sessions table.
+// Errors are logged but not returned, because the caller replaces the session data anyway.
+func revokeCurrentSession(r *http.Request) {
+	if !hasSessionCookie(r) {
+		return
+	}
+
+	var info *sessionInfo
+	if err := GetData(r, "actor", &info); err != nil || info == nil || info.SessionID == 0 {
+		return
+	}
+	if err := userSessions.Delete(r.Context(), info.SessionID); err != nil && !errcode.IsNotFound(err) {
+		log15.Warn("Error revoking session.", "sessionID", info.SessionID, "error", err)
+	}
+}
+
+type sessionIDKey struct{}
+
+// SessionIDFromContext returns the ID of the session that authenticated the request, or 0 if the
+// request was not authenticated by a tracked session cookie.
+func SessionIDFromContext(ctx context.Context) int64 {
+	id, _ := ctx.Value(sessionIDKey{}).(int64)
+	return id
+}
+
 func hasSessionCookie(r *http.Request) bool {
 	c, _ := r.Cookie(cookieName)
 	return c != nil
@@ -267,14 +324,17 @@ func deleteSession(w http.ResponseWriter, r *http.Request) error {
 // If an error occurs, return the error
 func InvalidateSessionCurrentUser(r *http.Request) error {
 	a := actor.FromContext(r.Context())
-	return db.Users.InvalidateSessionsByID(r.Context(), a.UID)
+	return InvalidateSessionsByID(r.Context(), a.UID)
 }
 
 // InvalidateSessionsByID invalidates all sessions for a user
 // If an error occurs, it returns the error
 func InvalidateSessionsByID(ctx context.Context, id int32) error {
 	// Get the user from the request context
-	return db.Users.InvalidateSessionsByID(ctx, id)
+	if err := db.Users.InvalidateSessionsByID(ctx, id); err != nil {
+		return err
+	}
+	return userSessions.DeleteByUserID(ctx, id)
 }
 
 // CookieMiddleware is an http.Handler middleware that authenticates
@@ -371,14 +431,27 @@ func authenticateByCookie(r *http.Request, w http.ResponseWriter) context.Contex
 		}
 
 		// Check that the session is still valid
-		if info.LastActive.Before(usr.InvalidatedSessionsAt) {
+		if usr.Deactivated || info.LastActive.Before(usr.InvalidatedSessionsAt) {
 			_ = deleteSession(w, r) // Delete the now invalid session
 			return r.Context()
 		}
 
+		// Check that the session has not been revoked
+		if info.SessionID != 0 {
+			if _, err := userSessions.GetByID(r.Context(), info.SessionID); err != nil {
+				if errcode.IsNotFound(err) {
+					_ = deleteSession(w, r) // Delete the now revoked session
+				} else {
+					log15.Error("Error looking up session.", "sessionID", info.SessionID, "error", err)
+				}
+				return r.Context() // not authenticated
+			}
+		}
+
 		// Renew session
 		if time.Since(info.LastActive) > 5*time.Minute {
 			info.LastActive = time.Now()
+			renewTrackedSession(r, info)
 			if err := SetData(w, r, "actor", info); err != nil {
 				log15.Error("error renewing session", "error", err)
 				return r.Context()
@@ -386,8 +459,36 @@ func authenticateByCookie(r *http.Request, w http.ResponseWriter) context.Contex
 		}
 
 		info.Actor.FromSessionCookie = true
-		return actor.WithActor(r.Context(), info.Actor)
+		ctx := actor.WithActor(r.Context(), info.Actor)
+		if info.SessionID != 0 {
+			ctx = context.WithValue(ctx, sessionIDKey{}, info.SessionID)
+		}
+		return ctx
 	}
 
 	return r.Context()
 }
+
+// renewTrackedSession extends the expiry of the session in the user_sessions table, recording it
+// there first if it was created before sessions were tracked. Errors are logged but not returned,
+// so that they don't sign out the user.
+func renewTrackedSession(r *http.Request, info *sessionInfo) {
+	expiresAt := info.LastActive.Add(info.ExpiryPeriod)
+	if info.SessionID == 0 {
+		id, err := userSessions.Create(r.Context(), db.UserSession{
+			UserID:    info.Actor.UID,
+			UserAgent: r.UserAgent(),
+			LastIP:    httputil.RemoteAddr(r),
+			ExpiresAt: expiresAt,
+		})
+		if err != nil {
+			log15.Error("Error recording session.", "uid", info.Actor.UID, "error", err)
+			return
+		}
+		info.SessionID = id
+		return
+	}
+	if err := userSessions.Touch(r.Context(), info.SessionID, httputil.RemoteAddr(r), expiresAt); err != nil {
+		log15.Error("Error renewing recorded session.", "sessionID", info.SessionID, "error", err)
+	}
+}
@@ -328,3 +328,46 @@ func TestRecoverFromInvalidCookieValue(t *testing.T) {
 		t.Errorf("got cookies %+v, want %+v", cookies, want)
 	}
 }
+
+func TestRevokedSession(t *testing.T) {
+	cleanup := ResetMockSessionStore(t)
+	defer cleanup()
+
+	db.Mocks.Users.GetByID = func(ctx context.Context, id int32) (*types.User, error) {
+		return &types.User{ID: id}, nil
+	}
+	defer func() { db.Mocks = db.MockStores{} }()
+	sessions := userSessions.(*mockUserSessionStore).sessions
+
+	// Start new session
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/", nil)
+	req.Header.Set("User-Agent", "test")
+	actr := &actor.Actor{UID: 123, FromSessionCookie: true}
+	if err := SetActor(w, req, actr, time.Hour); err != nil {
+		t.Fatal(err)
+	}
+	if len(sessions) != 1 || sessions[1].UserID != actr.UID || sessions[1].UserAgent != "test" {
+		t.Fatalf("got recorded sessions %+v, want 1 session of user %d", sessions, actr.UID)
+	}
+
+	authedReq := httptest.NewRequest("GET", "/", nil)
+	for _, cookie := range w.Result().Cookies() {
+		authedReq.AddCookie(cookie)
+	}
+	ctx := authenticateByCookie(authedReq, httptest.NewRecorder())
+	if gotActor := actor.FromContext(ctx); !reflect.DeepEqual(gotActor, actr) {
+		t.Fatalf("didn't find actor %v != %v", gotActor, actr)
+	}
+	if got, want := SessionIDFromContext(ctx), int64(1); got != want {
+		t.Fatalf("got session ID %d, want %d", got, want)
+	}
+
+	// Revoke the session, which must no longer authenticate requests.
+	delete(sessions, 1)
+	w = httptest.NewRecorder()
+	if gotActor := actor.FromContext(authenticateByCookie(authedReq, w)); gotActor.IsAuthenticated() {
+		t.Fatalf("revoked session authenticated actor %+v", gotActor)
+	}
+	checkCookieDeleted(t, w.Result())
+}
@@ -1,12 +1,17 @@
 package session
 
 import (
+	"context"
 	"io/ioutil"
 	"os"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/gorilla/securecookie"
 	"github.com/gorilla/sessions"
+	"github.com/sourcegraph/sourcegraph/internal/db"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
 )
 
 func ResetMockSessionStore(t *testing.T) (cleanup func()) {
@@ -23,7 +28,66 @@ func ResetMockSessionStore(t *testing.T) (cleanup func()) {
 	}()
 
 	SetSessionStore(sessions.NewFilesystemStore(tempdir, securecookie.GenerateRandomKey(2048)))
+	userSessions = &mockUserSessionStore{sessions: map[int64]*db.UserSession{}}
 	return func() {
 		os.RemoveAll(tempdir)
+		userSessions = db.UserSessions
 	}
 }
+
+// mockUserSessionStore is an in-memory userSessionStore.
+type mockUserSessionStore struct {
+	mu       sync.Mutex
+	sessions map[int64]*db.UserSession
+	nextID   int64
+}
+
+func (m *mockUserSessionStore) Create(_ context.Context, s db.UserSession) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.nextID++
+	s.ID = m.nextID
+	m.sessions[s.ID] = &s
+	return s.ID, nil
+}
+
+func (m *mockUserSessionStore) GetByID(_ context.Context, id int64) (*db.UserSession, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	s, ok := m.sessions[id]
+	if !ok {
+		return nil, &errcode.Mock{Message: "user session not found", IsNotFound: true}
+	}
+	tmp := *s
+	return &tmp, nil
+}
+
+func (m *mockUserSessionStore) Touch(_ context.Context, id int64, lastIP string, expiresAt time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if s, ok := m.sessions[id]; ok {
+		s.LastIP, s.ExpiresAt = lastIP, expiresAt
+	}
+	return nil
+}
+
+func (m *mockUserSessionStore) Delete(_ context.Context, id int64) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.sessions[id]; !ok {
+		return &errcode.Mock{Message: "user session not found", IsNotFound: true}
+	}
+	delete(m.sessions, id)
+	return nil
+}
+
+func (m *mockUserSessionStore) DeleteByUserID(_ context.Context, userID int32) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for id, s := range m.sessions {
+		if s.UserID == userID {
+			delete(m.sessions, id)
+		}
+	}
+	return nil
+}
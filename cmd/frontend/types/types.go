@@ -99,6 +99,9 @@ type User struct {
 	BuiltinAuth           bool
 	Tags                  []string
 	InvalidatedSessionsAt time.Time
+	// Deactivated is whether the user was deactivated, e.g. by a SCIM client. Deactivated users
+	// cannot sign in or use access tokens, and do not count towards the licensed user count.
+	Deactivated bool
 }
 
 type Org struct {
@@ -65,6 +65,9 @@ func (s *Server) createCommitFromPatch(ctx context.Context, req protocol.CreateC
 		resp.SetError(repo, "", "", errors.Wrap(err, "repoRemoteURL"))
 		return http.StatusInternalServerError, resp
 	}
+	if req.PushRemoteURL != "" {
+		remoteURL = req.PushRemoteURL
+	}
 
 	redactor := newURLRedactor(remoteURL)
 	defer func() {
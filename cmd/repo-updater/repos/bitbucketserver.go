@@ -11,6 +11,7 @@ import (
 	"github.com/inconshreveable/log15"
 	"github.com/pkg/errors"
 	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/campaigns"
 	"github.com/sourcegraph/sourcegraph/internal/conf/reposource"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketserver"
@@ -85,6 +86,7 @@ func (s BitbucketServerSource) ListRepos(ctx context.Context, results chan Sourc
 }
 
 var _ ChangesetSource = BitbucketServerSource{}
+var _ ChangesetListingSource = BitbucketServerSource{}
 
 // CreateChangeset creates the given *Changeset in the code host.
 func (s BitbucketServerSource) CreateChangeset(ctx context.Context, c *Changeset) (bool, error) {
@@ -144,6 +146,32 @@ func (s BitbucketServerSource) CloseChangeset(ctx context.Context, c *Changeset)
 	return nil
 }
 
+// CreateComment posts a comment on the Changeset.
+func (s BitbucketServerSource) CreateComment(ctx context.Context, c *Changeset, text string) error {
+	pr, ok := c.Changeset.Metadata.(*bitbucketserver.PullRequest)
+	if !ok {
+		return errors.New("Changeset is not a Bitbucket Server pull request")
+	}
+
+	return s.client.CreatePullRequestComment(ctx, pr, text)
+}
+
+// MergeChangeset merges a Changeset on the code host, if mergeable.
+func (s BitbucketServerSource) MergeChangeset(ctx context.Context, c *Changeset) error {
+	pr, ok := c.Changeset.Metadata.(*bitbucketserver.PullRequest)
+	if !ok {
+		return errors.New("Changeset is not a Bitbucket Server pull request")
+	}
+
+	if err := s.client.MergePullRequest(ctx, pr); err != nil {
+		return err
+	}
+
+	c.Changeset.Metadata = pr
+
+	return nil
+}
+
 // LoadChangesets loads the latest state of the given Changesets from the codehost.
 func (s BitbucketServerSource) LoadChangesets(ctx context.Context, cs ...*Changeset) error {
 	var notFound []*Changeset
@@ -188,6 +216,65 @@ func (s BitbucketServerSource) LoadChangesets(ctx context.Context, cs ...*Change
 	return nil
 }
 
+// ListChangesets returns the pull requests in the given repository that match
+// the given options. Bitbucket Server doesn't support labels on pull
+// requests, so an error is returned if the options contain labels.
+func (s BitbucketServerSource) ListChangesets(ctx context.Context, r *Repo, opts ListChangesetsOpts) ([]*Changeset, error) {
+	if len(opts.Labels) > 0 {
+		return nil, errors.New("Bitbucket Server doesn't support labels on pull requests")
+	}
+
+	repo := r.Metadata.(*bitbucketserver.Repo)
+
+	var listOpts bitbucketserver.ListPullRequestsOpts
+	if ref := opts.exactHeadRef(); ref != "" {
+		listOpts.FromRef = "refs/heads/" + ref
+	}
+	switch opts.State {
+	case "":
+	case campaigns.ChangesetExternalStateOpen:
+		listOpts.State = "OPEN"
+	case campaigns.ChangesetExternalStateClosed:
+		listOpts.State = "DECLINED"
+	case campaigns.ChangesetExternalStateMerged:
+		listOpts.State = "MERGED"
+	default:
+		return nil, errors.Errorf("listing pull requests in state %q is not supported", opts.State)
+	}
+
+	var (
+		cs   []*Changeset
+		next = &bitbucketserver.PageToken{Limit: 100}
+	)
+	for next.HasMore() && !opts.exceedsLimit(len(cs)) {
+		var (
+			prs []*bitbucketserver.PullRequest
+			err error
+		)
+		prs, next, err = s.client.ListPullRequests(ctx, repo.Project.Key, repo.Slug, next, listOpts)
+		if err != nil {
+			return nil, errors.Wrap(err, "listing pull requests")
+		}
+
+		page := make([]*Changeset, 0, len(prs))
+		for _, pr := range prs {
+			c := &Changeset{Changeset: &campaigns.Changeset{RepoID: r.ID}, Repo: r}
+			if err := c.SetMetadata(pr); err != nil {
+				return nil, errors.Wrap(err, "setting changeset metadata")
+			}
+			page = append(page, c)
+		}
+
+		page, err = opts.filter(page)
+		if err != nil {
+			return nil, err
+		}
+		cs = append(cs, page...)
+	}
+
+	return cs, nil
+}
+
 func (s BitbucketServerSource) loadPullRequestData(ctx context.Context, pr *bitbucketserver.PullRequest) error {
 	if err := s.client.LoadPullRequestActivities(ctx, pr); err != nil {
 		return errors.Wrap(err, "loading pr activities")
@@ -12,6 +12,7 @@ import (
 
 	"github.com/inconshreveable/log15"
 	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/internal/campaigns"
 	"github.com/sourcegraph/sourcegraph/internal/conf/reposource"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
@@ -152,9 +153,21 @@ func (s GithubSource) ExternalServices() ExternalServices {
 }
 
 var _ ChangesetSource = GithubSource{}
+var _ DraftChangesetSource = GithubSource{}
+var _ ChangesetListingSource = GithubSource{}
 
 // CreateChangeset creates the given *Changeset in the code host.
 func (s GithubSource) CreateChangeset(ctx context.Context, c *Changeset) (bool, error) {
+	return s.createChangeset(ctx, c, false)
+}
+
+// CreateDraftChangeset creates the given *Changeset in the code host as a
+// draft pull request.
+func (s GithubSource) CreateDraftChangeset(ctx context.Context, c *Changeset) (bool, error) {
+	return s.createChangeset(ctx, c, true)
+}
+
+func (s GithubSource) createChangeset(ctx context.Context, c *Changeset, draft bool) (bool, error) {
 	var exists bool
 	repo := c.Repo.Metadata.(*github.Repository)
 
@@ -164,6 +177,7 @@ func (s GithubSource) CreateChangeset(ctx context.Context, c *Changeset) (bool,
 		Body:         c.Body,
 		HeadRefName:  git.AbbreviateRef(c.HeadRef),
 		BaseRefName:  git.AbbreviateRef(c.BaseRef),
+		Draft:        draft,
 	})
 
 	if err != nil {
@@ -206,6 +220,49 @@ func (s GithubSource) CloseChangeset(ctx context.Context, c *Changeset) error {
 	return nil
 }
 
+// UndraftChangeset marks the draft pull request of the given *Changeset as
+// ready for review.
+func (s GithubSource) UndraftChangeset(ctx context.Context, c *Changeset) error {
+	pr, ok := c.Changeset.Metadata.(*github.PullRequest)
+	if !ok {
+		return errors.New("Changeset is not a GitHub pull request")
+	}
+
+	if err := s.client.MarkPullRequestReadyForReview(ctx, pr); err != nil {
+		return err
+	}
+
+	c.Changeset.Metadata = pr
+
+	return nil
+}
+
+// CreateComment posts a comment on the Changeset.
+func (s GithubSource) CreateComment(ctx context.Context, c *Changeset, text string) error {
+	pr, ok := c.Changeset.Metadata.(*github.PullRequest)
+	if !ok {
+		return errors.New("Changeset is not a GitHub pull request")
+	}
+
+	return s.client.CreatePullRequestComment(ctx, pr, text)
+}
+
+// MergeChangeset merges a Changeset on the code host, if mergeable.
+func (s GithubSource) MergeChangeset(ctx context.Context, c *Changeset) error {
+	pr, ok := c.Changeset.Metadata.(*github.PullRequest)
+	if !ok {
+		return errors.New("Changeset is not a GitHub pull request")
+	}
+
+	if err := s.client.MergePullRequest(ctx, pr); err != nil {
+		return err
+	}
+
+	c.Changeset.Metadata = pr
+
+	return nil
+}
+
 // LoadChangesets loads the latest state of the given Changesets from the codehost.
 func (s GithubSource) LoadChangesets(ctx context.Context, cs ...*Changeset) error {
 	prs := make([]*github.PullRequest, len(cs))
@@ -236,6 +293,61 @@ func (s GithubSource) LoadChangesets(ctx context.Context, cs ...*Changeset) erro
 	return nil
 }
 
+// ListChangesets returns the pull requests in the given repository that match
+// the given options.
+func (s GithubSource) ListChangesets(ctx context.Context, r *Repo, opts ListChangesetsOpts) ([]*Changeset, error) {
+	repo := r.Metadata.(*github.Repository)
+	owner, name, err := github.SplitRepositoryNameWithOwner(repo.NameWithOwner)
+	if err != nil {
+		return nil, errors.Wrap(err, "getting repo owner and name")
+	}
+
+	// GitHub lists the pull requests that have any of the labels, so we
+	// still need to filter the results.
+	listOpts := github.ListPullRequestsOpts{
+		Labels:      opts.Labels,
+		HeadRefName: opts.exactHeadRef(),
+	}
+	switch opts.State {
+	case "":
+	case campaigns.ChangesetExternalStateOpen, campaigns.ChangesetExternalStateClosed, campaigns.ChangesetExternalStateMerged:
+		listOpts.States = []string{string(opts.State)}
+	default:
+		return nil, errors.Errorf("listing pull requests in state %q is not supported", opts.State)
+	}
+
+	var (
+		cs    []*Changeset
+		after string
+	)
+	for {
+		prs, next, err := s.client.ListPullRequests(ctx, owner, name, listOpts, after)
+		if err != nil {
+			return nil, err
+		}
+
+		page := make([]*Changeset, 0, len(prs))
+		for _, pr := range prs {
+			c := &Changeset{Changeset: &campaigns.Changeset{RepoID: r.ID}, Repo: r}
+			if err := c.SetMetadata(pr); err != nil {
+				return nil, errors.Wrap(err, "setting changeset metadata")
+			}
+			page = append(page, c)
+		}
+
+		page, err = opts.filter(page)
+		if err != nil {
+			return nil, err
+		}
+		cs = append(cs, page...)
+
+		if next == "" || opts.exceedsLimit(len(cs)) {
+			return cs, nil
+		}
+		after = next
+	}
+}
+
 // UpdateChangeset updates the given *Changeset in the code host.
 func (s GithubSource) UpdateChangeset(ctx context.Context, c *Changeset) error {
 	pr, ok := c.Changeset.Metadata.(*github.PullRequest)
@@ -11,6 +11,7 @@ import (
 
 	"github.com/inconshreveable/log15"
 	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/internal/campaigns"
 	"github.com/sourcegraph/sourcegraph/internal/conf/reposource"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitlab"
@@ -302,18 +303,38 @@ func projectQueryToURL(projectQuery string, perPage int) (string, error) {
 	return u.String(), nil
 }
 
+var _ ChangesetSource = &GitLabSource{}
+var _ DraftChangesetSource = &GitLabSource{}
+var _ ChangesetListingSource = &GitLabSource{}
+
 // CreateChangeset creates a GitLab merge request. If it already exists,
 // *Changeset will be populated and the return value will be true.
 func (s *GitLabSource) CreateChangeset(ctx context.Context, c *Changeset) (bool, error) {
+	return s.createChangeset(ctx, c, false)
+}
+
+// CreateDraftChangeset creates a GitLab merge request marked as draft. If it
+// already exists, *Changeset will be populated and the return value will be
+// true.
+func (s *GitLabSource) CreateDraftChangeset(ctx context.Context, c *Changeset) (bool, error) {
+	return s.createChangeset(ctx, c, true)
+}
+
+func (s *GitLabSource) createChangeset(ctx context.Context, c *Changeset, draft bool) (bool, error) {
 	project := c.Repo.Metadata.(*gitlab.Project)
 	exists := false
 	source := git.AbbreviateRef(c.HeadRef)
 	target := git.AbbreviateRef(c.BaseRef)
 
+	title := c.Title
+	if draft {
+		title = gitLabDraftTitle(title)
+	}
+
 	mr, err := s.client.CreateMergeRequest(ctx, project, gitlab.CreateMergeRequestOpts{
 		SourceBranch: source,
 		TargetBranch: target,
-		Title:        c.Title,
+		Title:        title,
 		Description:  c.Body,
 	})
 	if err != nil {
@@ -359,6 +380,61 @@ func (s *GitLabSource) CloseChangeset(ctx context.Context, c *Changeset) error {
 	return nil
 }
 
+// UndraftChangeset marks the merge request as ready for review by removing the
+// draft prefix from its title.
+func (s *GitLabSource) UndraftChangeset(ctx context.Context, c *Changeset) error {
+	mr, ok := c.Changeset.Metadata.(*gitlab.MergeRequest)
+	if !ok {
+		return errors.New("Changeset is not a GitLab merge request")
+	}
+
+	// Title and TargetBranch are required, even though we're not actually
+	// changing the latter.
+	updated, err := s.client.UpdateMergeRequest(ctx, c.Repo.Metadata.(*gitlab.Project), mr, gitlab.UpdateMergeRequestOpts{
+		Title:        trimGitLabDraftPrefix(mr.Title),
+		TargetBranch: mr.TargetBranch,
+	})
+	if err != nil {
+		return errors.Wrap(err, "updating GitLab merge request")
+	}
+
+	if err := c.SetMetadata(updated); err != nil {
+		return errors.Wrap(err, "setting changeset metadata")
+	}
+	return nil
+}
+
+// CreateComment posts a comment on the Changeset.
+func (s *GitLabSource) CreateComment(ctx context.Context, c *Changeset, text string) error {
+	mr, ok := c.Changeset.Metadata.(*gitlab.MergeRequest)
+	if !ok {
+		return errors.New("Changeset is not a GitLab merge request")
+	}
+
+	if _, err := s.client.CreateMergeRequestNote(ctx, c.Repo.Metadata.(*gitlab.Project), mr, text); err != nil {
+		return errors.Wrap(err, "creating GitLab merge request note")
+	}
+	return nil
+}
+
+// MergeChangeset merges a Changeset on the code host, if mergeable.
+func (s *GitLabSource) MergeChangeset(ctx context.Context, c *Changeset) error {
+	mr, ok := c.Changeset.Metadata.(*gitlab.MergeRequest)
+	if !ok {
+		return errors.New("Changeset is not a GitLab merge request")
+	}
+
+	updated, err := s.client.MergeMergeRequest(ctx, c.Repo.Metadata.(*gitlab.Project), mr)
+	if err != nil {
+		return errors.Wrap(err, "merging GitLab merge request")
+	}
+
+	if err := c.SetMetadata(updated); err != nil {
+		return errors.Wrap(err, "setting changeset metadata")
+	}
+	return nil
+}
+
 // LoadChangesets loads the given merge requests from GitLab and updates them.
 // Note that this is an O(n) operation due to limitations in the GitLab REST
 // API.
@@ -393,6 +469,62 @@ func (s *GitLabSource) LoadChangesets(ctx context.Context, cs ...*Changeset) err
 	return nil
 }
 
+// ListChangesets returns the merge requests in the given repository that
+// match the given options. Their metadata only contains the fields returned
+// by the merge request list endpoint of GitLab.
+func (s *GitLabSource) ListChangesets(ctx context.Context, r *Repo, opts ListChangesetsOpts) ([]*Changeset, error) {
+	project := r.Metadata.(*gitlab.Project)
+
+	listOpts := gitlab.ListMergeRequestsOpts{
+		Labels:       opts.Labels,
+		SourceBranch: opts.exactHeadRef(),
+	}
+	switch opts.State {
+	case "":
+	case campaigns.ChangesetExternalStateOpen:
+		listOpts.State = gitlab.MergeRequestStateOpened
+	case campaigns.ChangesetExternalStateClosed:
+		listOpts.State = gitlab.MergeRequestStateClosed
+	case campaigns.ChangesetExternalStateMerged:
+		listOpts.State = gitlab.MergeRequestStateMerged
+	default:
+		return nil, errors.Errorf("listing merge requests in state %q is not supported", opts.State)
+	}
+
+	var cs []*Changeset
+	it := s.client.ListMergeRequests(ctx, project, listOpts)
+	for {
+		page, err := it()
+		if err != nil {
+			return nil, errors.Wrap(err, "listing merge requests")
+		}
+		if len(page) == 0 {
+			break
+		}
+
+		matched := make([]*Changeset, 0, len(page))
+		for _, mr := range page {
+			c := &Changeset{Changeset: &campaigns.Changeset{RepoID: r.ID}, Repo: r}
+			if err := c.SetMetadata(mr); err != nil {
+				return nil, errors.Wrapf(err, "setting changeset metadata for merge request %d", mr.IID)
+			}
+			matched = append(matched, c)
+		}
+
+		matched, err = opts.filter(matched)
+		if err != nil {
+			return nil, err
+		}
+		cs = append(cs, matched...)
+
+		if opts.exceedsLimit(len(cs)) {
+			break
+		}
+	}
+
+	return cs, nil
+}
+
 func (s *GitLabSource) decorateMergeRequestData(ctx context.Context, project *gitlab.Project, mr, old *gitlab.MergeRequest) error {
 	notes, err := s.getMergeRequestNotes(ctx, project, mr, old)
 	if err != nil {
@@ -543,8 +675,15 @@ func (s *GitLabSource) UpdateChangeset(ctx context.Context, c *Changeset) error
 		return errors.New("Changeset is not a GitLab merge request")
 	}
 
+	// GitLab tracks whether a merge request is a draft through its title, so
+	// we need to keep the prefix when updating the title of a draft.
+	title := c.Title
+	if mr.WorkInProgress {
+		title = gitLabDraftTitle(title)
+	}
+
 	updated, err := s.client.UpdateMergeRequest(ctx, c.Repo.Metadata.(*gitlab.Project), mr, gitlab.UpdateMergeRequestOpts{
-		Title:        c.Title,
+		Title:        title,
 		Description:  c.Body,
 		TargetBranch: git.AbbreviateRef(c.BaseRef),
 	})
@@ -555,3 +694,20 @@ func (s *GitLabSource) UpdateChangeset(ctx context.Context, c *Changeset) error
 	c.Changeset.Metadata = updated
 	return nil
 }
+
+// gitLabDraftPrefixes are the title prefixes that mark a merge request as a
+// draft. "WIP:" is the prefix used before GitLab 13.2.
+var gitLabDraftPrefixes = []string{"Draft:", "WIP:", "[Draft]", "[WIP]", "(Draft)"}
+
+func gitLabDraftTitle(title string) string {
+	return "Draft: " + trimGitLabDraftPrefix(title)
+}
+
+func trimGitLabDraftPrefix(title string) string {
+	for _, prefix := range gitLabDraftPrefixes {
+		if len(title) >= len(prefix) && strings.EqualFold(title[:len(prefix)], prefix) {
+			return strings.TrimSpace(title[len(prefix):])
+		}
+	}
+	return title
+}
@@ -313,6 +313,54 @@ func TestGitLabSource_ChangesetSource(t *testing.T) {
 		})
 	})
 
+	t.Run("CreateDraftChangeset", func(t *testing.T) {
+		p := newGitLabChangesetSourceTestProvider(t)
+		gitlab.MockCreateMergeRequest = func(client *gitlab.Client, ctx context.Context, project *gitlab.Project, opts gitlab.CreateMergeRequestOpts) (*gitlab.MergeRequest, error) {
+			p.testCommonParams(ctx, client, project)
+			if have, want := opts.Title, "Draft: title"; have != want {
+				t.Errorf("unexpected Title: have %q; want %q", have, want)
+			}
+			return p.mr, nil
+		}
+
+		exists, err := p.source.CreateDraftChangeset(p.ctx, p.changeset)
+		if exists {
+			t.Errorf("unexpected exists value: %v", exists)
+		}
+		if err != nil {
+			t.Errorf("unexpected non-nil err: %+v", err)
+		}
+		if p.changeset.Changeset.Metadata != p.mr {
+			t.Errorf("unexpected metadata: have %+v; want %+v", p.changeset.Changeset.Metadata, p.mr)
+		}
+	})
+
+	t.Run("UndraftChangeset", func(t *testing.T) {
+		for _, title := range []string{"Draft: title", "WIP: title", "[WIP] title"} {
+			t.Run(title, func(t *testing.T) {
+				in := &gitlab.MergeRequest{Title: title, WorkInProgress: true}
+				out := &gitlab.MergeRequest{Title: "title"}
+
+				p := newGitLabChangesetSourceTestProvider(t)
+				p.changeset.Changeset.Metadata = in
+				gitlab.MockUpdateMergeRequest = func(client *gitlab.Client, ctx context.Context, project *gitlab.Project, mrIn *gitlab.MergeRequest, opts gitlab.UpdateMergeRequestOpts) (*gitlab.MergeRequest, error) {
+					p.testCommonParams(ctx, client, project)
+					if have, want := opts.Title, "title"; have != want {
+						t.Errorf("unexpected Title: have %q; want %q", have, want)
+					}
+					return out, nil
+				}
+
+				if err := p.source.UndraftChangeset(p.ctx, p.changeset); err != nil {
+					t.Errorf("unexpected non-nil error: %+v", err)
+				}
+				if p.changeset.Changeset.Metadata != out {
+					t.Errorf("metadata not correctly updated: have %+v; want %+v", p.changeset.Changeset.Metadata, out)
+				}
+			})
+		}
+	})
+
 	t.Run("CloseChangeset", func(t *testing.T) {
 		t.Run("invalid metadata", func(t *testing.T) {
 			defer func() { _ = recover() }()
@@ -354,6 +402,66 @@ func TestGitLabSource_ChangesetSource(t *testing.T) {
 		})
 	})
 
+	t.Run("CreateComment", func(t *testing.T) {
+		t.Run("error from CreateMergeRequestNote", func(t *testing.T) {
+			inner := errors.New("foo")
+			mr := &gitlab.MergeRequest{}
+
+			p := newGitLabChangesetSourceTestProvider(t)
+			p.changeset.Changeset.Metadata = mr
+			p.mockCreateMergeRequestNote(mr, "hello", inner)
+
+			have := p.source.CreateComment(p.ctx, p.changeset, "hello")
+			if !errors.Is(have, inner) {
+				t.Errorf("error does not include inner error: have %+v; want %+v", have, inner)
+			}
+		})
+
+		t.Run("success", func(t *testing.T) {
+			mr := &gitlab.MergeRequest{}
+
+			p := newGitLabChangesetSourceTestProvider(t)
+			p.changeset.Changeset.Metadata = mr
+			p.mockCreateMergeRequestNote(mr, "hello", nil)
+
+			if err := p.source.CreateComment(p.ctx, p.changeset, "hello"); err != nil {
+				t.Errorf("unexpected error: %+v", err)
+			}
+		})
+	})
+
+	t.Run("MergeChangeset", func(t *testing.T) {
+		t.Run("error from MergeMergeRequest", func(t *testing.T) {
+			inner := errors.New("foo")
+			mr := &gitlab.MergeRequest{}
+
+			p := newGitLabChangesetSourceTestProvider(t)
+			p.changeset.Changeset.Metadata = mr
+			p.mockMergeMergeRequest(mr, nil, inner)
+
+			have := p.source.MergeChangeset(p.ctx, p.changeset)
+			if !errors.Is(have, inner) {
+				t.Errorf("error does not include inner error: have %+v; want %+v", have, inner)
+			}
+		})
+
+		t.Run("success", func(t *testing.T) {
+			want := &gitlab.MergeRequest{State: gitlab.MergeRequestStateMerged}
+			mr := &gitlab.MergeRequest{}
+
+			p := newGitLabChangesetSourceTestProvider(t)
+			p.changeset.Changeset.Metadata = mr
+			p.mockMergeMergeRequest(mr, want, nil)
+
+			if err := p.source.MergeChangeset(p.ctx, p.changeset); err != nil {
+				t.Errorf("unexpected error: %+v", err)
+			}
+			if have := p.changeset.Changeset.Metadata; have != want {
+				t.Errorf("merge request metadata not updated: have %p; want %p", have, want)
+			}
+		})
+	})
+
 	t.Run("LoadChangesets", func(t *testing.T) {
 		t.Run("invalid metadata", func(t *testing.T) {
 			defer func() { _ = recover() }()
@@ -528,6 +636,112 @@ func TestGitLabSource_ChangesetSource(t *testing.T) {
 		})
 	})
 
+	t.Run("ListChangesets", func(t *testing.T) {
+		t.Run("error from ListMergeRequests", func(t *testing.T) {
+			inner := errors.New("foo")
+
+			p := newGitLabChangesetSourceTestProvider(t)
+			p.mockListMergeRequests(gitlab.ListMergeRequestsOpts{}, nil, inner)
+
+			_, have := p.source.ListChangesets(p.ctx, p.changeset.Repo, ListChangesetsOpts{})
+			if !errors.Is(have, inner) {
+				t.Errorf("error does not include inner error: have %+v; want %+v", have, inner)
+			}
+		})
+
+		t.Run("unsupported state", func(t *testing.T) {
+			p := newGitLabChangesetSourceTestProvider(t)
+
+			_, err := p.source.ListChangesets(p.ctx, p.changeset.Repo, ListChangesetsOpts{
+				State: campaigns.ChangesetExternalStateDeleted,
+			})
+			if err == nil {
+				t.Error("unexpected nil error")
+			}
+		})
+
+		t.Run("success", func(t *testing.T) {
+			mrs := []*gitlab.MergeRequest{
+				{IID: 1, SourceBranch: "migration/a", Labels: []string{"migration"}},
+				{IID: 2, SourceBranch: "migration/b/c", Labels: []string{"migration"}},
+				{IID: 3, SourceBranch: "other", Labels: []string{"migration"}},
+				{IID: 4, SourceBranch: "migration/d", Labels: []string{"migration"}},
+			}
+
+			p := newGitLabChangesetSourceTestProvider(t)
+			p.mockListMergeRequests(gitlab.ListMergeRequestsOpts{
+				State:  gitlab.MergeRequestStateOpened,
+				Labels: []string{"migration"},
+			}, mrs, nil)
+
+			cs, err := p.source.ListChangesets(p.ctx, p.changeset.Repo, ListChangesetsOpts{
+				State:   campaigns.ChangesetExternalStateOpen,
+				Labels:  []string{"migration"},
+				HeadRef: "migration/*",
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %+v", err)
+			}
+
+			var have []string
+			for _, c := range cs {
+				if c.Repo != p.changeset.Repo {
+					t.Errorf("unexpected repo: have %+v; want %+v", c.Repo, p.changeset.Repo)
+				}
+				have = append(have, c.ExternalID)
+			}
+			if diff := cmp.Diff([]string{"1", "4"}, have); diff != "" {
+				t.Errorf("unexpected changesets: %s", diff)
+			}
+		})
+
+		t.Run("exact head ref", func(t *testing.T) {
+			p := newGitLabChangesetSourceTestProvider(t)
+			p.mockListMergeRequests(gitlab.ListMergeRequestsOpts{SourceBranch: "migration"}, []*gitlab.MergeRequest{
+				{IID: 1, SourceBranch: "migration"},
+			}, nil)
+
+			cs, err := p.source.ListChangesets(p.ctx, p.changeset.Repo, ListChangesetsOpts{HeadRef: "migration"})
+			if err != nil {
+				t.Fatalf("unexpected error: %+v", err)
+			}
+			if len(cs) != 1 {
+				t.Errorf("unexpected number of changesets: have %d; want 1", len(cs))
+			}
+		})
+
+		t.Run("limit", func(t *testing.T) {
+			p := newGitLabChangesetSourceTestProvider(t)
+
+			// Every page contains a matching and a non-matching merge
+			// request, and there's always another page.
+			pages := 0
+			gitlab.MockListMergeRequests = func(client *gitlab.Client, ctx context.Context, project *gitlab.Project, opts gitlab.ListMergeRequestsOpts) func() ([]*gitlab.MergeRequest, error) {
+				return func() ([]*gitlab.MergeRequest, error) {
+					pages++
+					return []*gitlab.MergeRequest{
+						{IID: gitlab.ID(2 * pages), SourceBranch: "migration/a"},
+						{IID: gitlab.ID(2*pages + 1), SourceBranch: "other"},
+					}, nil
+				}
+			}
+
+			cs, err := p.source.ListChangesets(p.ctx, p.changeset.Repo, ListChangesetsOpts{
+				HeadRef: "migration/*",
+				Limit:   2,
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %+v", err)
+			}
+			if len(cs) != 3 {
+				t.Errorf("unexpected number of changesets: have %d; want 3", len(cs))
+			}
+			if pages != 3 {
+				t.Errorf("unexpected number of pages listed: have %d; want 3", pages)
+			}
+		})
+	})
+
 	t.Run("UpdateChangeset", func(t *testing.T) {
 		t.Run("invalid metadata", func(t *testing.T) {
 			p := newGitLabChangesetSourceTestProvider(t)
@@ -572,6 +786,25 @@ func TestGitLabSource_ChangesetSource(t *testing.T) {
 				t.Errorf("metadata not correctly updated: have %+v; want %+v", p.changeset.Changeset.Metadata, out)
 			}
 		})
+
+		t.Run("draft", func(t *testing.T) {
+			in := &gitlab.MergeRequest{Title: "WIP: old title", WorkInProgress: true}
+			out := &gitlab.MergeRequest{}
+
+			p := newGitLabChangesetSourceTestProvider(t)
+			p.changeset.Changeset.Metadata = in
+			gitlab.MockUpdateMergeRequest = func(client *gitlab.Client, ctx context.Context, project *gitlab.Project, mrIn *gitlab.MergeRequest, opts gitlab.UpdateMergeRequestOpts) (*gitlab.MergeRequest, error) {
+				p.testCommonParams(ctx, client, project)
+				if have, want := opts.Title, "Draft: title"; have != want {
+					t.Errorf("unexpected Title: have %q; want %q", have, want)
+				}
+				return out, nil
+			}
+
+			if err := p.source.UpdateChangeset(p.ctx, p.changeset); err != nil {
+				t.Errorf("unexpected non-nil error: %+v", err)
+			}
+		})
 	})
 }
 
@@ -857,6 +1090,28 @@ func (p *gitLabChangesetSourceTestProvider) mockGetOpenMergeRequestByRefs(mr *gi
 	}
 }
 
+func (p *gitLabChangesetSourceTestProvider) mockListMergeRequests(expected gitlab.ListMergeRequestsOpts, mrs []*gitlab.MergeRequest, err error) {
+	gitlab.MockListMergeRequests = func(client *gitlab.Client, ctx context.Context, project *gitlab.Project, opts gitlab.ListMergeRequestsOpts) func() ([]*gitlab.MergeRequest, error) {
+		p.testCommonParams(ctx, client, project)
+		if diff := cmp.Diff(expected, opts); diff != "" {
+			p.t.Errorf("unexpected options: %s", diff)
+		}
+
+		if err != nil {
+			return func() ([]*gitlab.MergeRequest, error) { return nil, err }
+		}
+
+		done := false
+		return func() ([]*gitlab.MergeRequest, error) {
+			if done {
+				return []*gitlab.MergeRequest{}, nil
+			}
+			done = true
+			return mrs, nil
+		}
+	}
+}
+
 func (p *gitLabChangesetSourceTestProvider) mockUpdateMergeRequest(expectedMR, updated *gitlab.MergeRequest, err error) {
 	gitlab.MockUpdateMergeRequest = func(client *gitlab.Client, ctx context.Context, project *gitlab.Project, mrIn *gitlab.MergeRequest, opts gitlab.UpdateMergeRequestOpts) (*gitlab.MergeRequest, error) {
 		p.testCommonParams(ctx, client, project)
@@ -867,13 +1122,42 @@ func (p *gitLabChangesetSourceTestProvider) mockUpdateMergeRequest(expectedMR, u
 	}
 }
 
+func (p *gitLabChangesetSourceTestProvider) mockCreateMergeRequestNote(expectedMR *gitlab.MergeRequest, expectedBody string, err error) {
+	gitlab.MockCreateMergeRequestNote = func(client *gitlab.Client, ctx context.Context, project *gitlab.Project, mrIn *gitlab.MergeRequest, body string) (*gitlab.Note, error) {
+		p.testCommonParams(ctx, client, project)
+		if expectedMR != mrIn {
+			p.t.Errorf("unexpected MergeRequest: have %+v; want %+v", mrIn, expectedMR)
+		}
+		if expectedBody != body {
+			p.t.Errorf("unexpected body: have %q; want %q", body, expectedBody)
+		}
+		if err != nil {
+			return nil, err
+		}
+		return &gitlab.Note{Body: body}, nil
+	}
+}
+
+func (p *gitLabChangesetSourceTestProvider) mockMergeMergeRequest(expectedMR, merged *gitlab.MergeRequest, err error) {
+	gitlab.MockMergeMergeRequest = func(client *gitlab.Client, ctx context.Context, project *gitlab.Project, mrIn *gitlab.MergeRequest) (*gitlab.MergeRequest, error) {
+		p.testCommonParams(ctx, client, project)
+		if expectedMR != mrIn {
+			p.t.Errorf("unexpected MergeRequest: have %+v; want %+v", mrIn, expectedMR)
+		}
+		return merged, err
+	}
+}
+
 func (p *gitLabChangesetSourceTestProvider) unmock() {
 	gitlab.MockCreateMergeRequest = nil
 	gitlab.MockGetMergeRequest = nil
 	gitlab.MockGetMergeRequestNotes = nil
 	gitlab.MockGetMergeRequestPipelines = nil
 	gitlab.MockGetOpenMergeRequestByRefs = nil
+	gitlab.MockListMergeRequests = nil
 	gitlab.MockUpdateMergeRequest = nil
+	gitlab.MockCreateMergeRequestNote = nil
+	gitlab.MockMergeMergeRequest = nil
 }
 
 // paginatedNoteIterator essentially fakes the pagination behaviour implemented
@@ -6,8 +6,10 @@ import (
 	"strings"
 	"sync"
 
+	"github.com/gobwas/glob"
 	"github.com/hashicorp/go-multierror"
 	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/internal/campaigns"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
 	"github.com/sourcegraph/sourcegraph/internal/httpcli"
 )
@@ -114,6 +116,104 @@ type ChangesetSource interface {
 	CloseChangeset(context.Context, *Changeset) error
 	// UpdateChangeset can update Changesets.
 	UpdateChangeset(context.Context, *Changeset) error
+	// CreateComment posts a comment with the given body on the Changeset.
+	CreateComment(context.Context, *Changeset, string) error
+	// MergeChangeset merges the Changeset on the source. If the codehost
+	// doesn't allow merging the Changeset, an error is returned.
+	MergeChangeset(context.Context, *Changeset) error
+}
+
+// A DraftChangesetSource can create draft Changesets and mark them as ready
+// for review. Not all code hosts support drafts.
+type DraftChangesetSource interface {
+	// CreateDraftChangeset will create the Changeset on the source as a
+	// draft. If it already exists, *Changeset will be populated and the
+	// return value will be true.
+	CreateDraftChangeset(context.Context, *Changeset) (bool, error)
+	// UndraftChangeset marks the draft Changeset on the source as ready for
+	// review.
+	UndraftChangeset(context.Context, *Changeset) error
+}
+
+// A ChangesetListingSource can search the code host for existing Changesets.
+// Not all code hosts support this.
+type ChangesetListingSource interface {
+	// ListChangesets returns the Changesets in the given repository that
+	// match the given options. Depending on the code host, the Metadata of
+	// the returned Changesets may not be complete, so they need to be loaded
+	// with LoadChangesets before they're synced.
+	ListChangesets(context.Context, *Repo, ListChangesetsOpts) ([]*Changeset, error)
+}
+
+// ListChangesetsOpts are the options for ChangesetListingSource.ListChangesets.
+type ListChangesetsOpts struct {
+	// State restricts the Changesets to those in the given state. If it's
+	// empty, Changesets in all states are listed.
+	State campaigns.ChangesetExternalState
+	// Labels restricts the Changesets to those that have all of the given
+	// labels.
+	Labels []string
+	// HeadRef restricts the Changesets to those whose head branch matches
+	// the given glob pattern, in which "*" doesn't match "/" and "**" does.
+	HeadRef string
+	// Limit, if greater than zero, makes the source stop listing once more
+	// than Limit Changesets matched, so that callers can tell that too many
+	// Changesets match without the source listing all of them.
+	Limit int
+}
+
+// exceedsLimit returns whether the given number of matching Changesets is
+// above the Limit of the options.
+func (o ListChangesetsOpts) exceedsLimit(n int) bool {
+	return o.Limit > 0 && n > o.Limit
+}
+
+// filter returns the Changesets that match the labels and the head ref of
+// the options. It's used by the sources to apply the filters that the code
+// host can't apply itself.
+func (o ListChangesetsOpts) filter(cs []*Changeset) ([]*Changeset, error) {
+	var matchHeadRef func(string) bool
+	if o.HeadRef != "" {
+		g, err := glob.Compile(o.HeadRef, '/')
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid head ref pattern")
+		}
+		matchHeadRef = g.Match
+	}
+
+	filtered := cs[:0]
+	for _, c := range cs {
+		if matchHeadRef != nil && !matchHeadRef(c.ExternalBranch) {
+			continue
+		}
+		if !hasLabels(c.Changeset, o.Labels) {
+			continue
+		}
+		filtered = append(filtered, c)
+	}
+	return filtered, nil
+}
+
+// exactHeadRef returns the head ref of the options if it isn't a pattern, so
+// that the code host can filter by it.
+func (o ListChangesetsOpts) exactHeadRef() string {
+	if glob.QuoteMeta(o.HeadRef) != o.HeadRef {
+		return ""
+	}
+	return o.HeadRef
+}
+
+func hasLabels(c *campaigns.Changeset, labels []string) bool {
+	names := make(map[string]struct{})
+	for _, l := range c.Labels() {
+		names[l.Name] = struct{}{}
+	}
+	for _, l := range labels {
+		if _, ok := names[l]; !ok {
+			return false
+		}
+	}
+	return true
 }
 
 // ChangesetsNotFoundError is returned by LoadChangesets if any of the passed
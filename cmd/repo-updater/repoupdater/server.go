@@ -60,6 +60,10 @@ type Server struct {
 		ScheduleUsers(ctx context.Context, userIDs ...int32)
 		// ScheduleRepos schedules new permissions syncing requests for given repositories.
 		ScheduleRepos(ctx context.Context, repoIDs ...api.RepoID)
+		// SyncUsers syncs permissions of given users and returns when it is done.
+		SyncUsers(ctx context.Context, userIDs ...int32) error
+		// SyncRepos syncs permissions of given repositories and returns when it is done.
+		SyncRepos(ctx context.Context, repoIDs ...api.RepoID) error
 	}
 }
 
@@ -78,6 +82,7 @@ func (s *Server) Handler() http.Handler {
 	mux.HandleFunc("/status-messages", s.handleStatusMessages)
 	mux.HandleFunc("/enqueue-changeset-sync", s.handleEnqueueChangesetSync)
 	mux.HandleFunc("/schedule-perms-sync", s.handleSchedulePermsSync)
+	mux.HandleFunc("/sync-perms", s.handleSyncPerms)
 	return mux
 }
 
@@ -651,6 +656,37 @@ func (s *Server) handleSchedulePermsSync(w http.ResponseWriter, r *http.Request)
 	respond(w, http.StatusOK, nil)
 }
 
+// handleSyncPerms syncs permissions of the requested users and repositories, and only
+// responds once it is done. Unlike handleSchedulePermsSync, it does not wait for the
+// requests' turns in the queue of the PermsSyncer.
+func (s *Server) handleSyncPerms(w http.ResponseWriter, r *http.Request) {
+	if s.PermsSyncer == nil {
+		respond(w, http.StatusForbidden, nil)
+		return
+	}
+
+	var req protocol.PermsSyncRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respond(w, http.StatusBadRequest, err)
+		return
+	}
+	if len(req.UserIDs) == 0 && len(req.RepoIDs) == 0 {
+		respond(w, http.StatusBadRequest, errors.New("neither user and repo ids provided"))
+		return
+	}
+
+	err := s.PermsSyncer.SyncUsers(r.Context(), req.UserIDs...)
+	if err == nil {
+		err = s.PermsSyncer.SyncRepos(r.Context(), req.RepoIDs...)
+	}
+	if err != nil {
+		respond(w, http.StatusOK, &protocol.PermsSyncResponse{Error: err.Error()})
+		return
+	}
+
+	respond(w, http.StatusOK, nil)
+}
+
 func newRepoInfo(r *repos.Repo) (*protocol.RepoInfo, error) {
 	urls := r.CloneURLs()
 	if len(urls) == 0 {
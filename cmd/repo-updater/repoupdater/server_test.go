@@ -1325,7 +1325,9 @@ func (g *fakeGitserverClient) ListCloned(ctx context.Context) ([]string, error)
 	return g.listClonedResponse, nil
 }
 
-type fakePermsSyncer struct{}
+type fakePermsSyncer struct {
+	syncErr error
+}
 
 func (*fakePermsSyncer) ScheduleUsers(ctx context.Context, userIDs ...int32) {
 }
@@ -1333,6 +1335,14 @@ func (*fakePermsSyncer) ScheduleUsers(ctx context.Context, userIDs ...int32) {
 func (*fakePermsSyncer) ScheduleRepos(ctx context.Context, repoIDs ...api.RepoID) {
 }
 
+func (s *fakePermsSyncer) SyncUsers(ctx context.Context, userIDs ...int32) error {
+	return s.syncErr
+}
+
+func (s *fakePermsSyncer) SyncRepos(ctx context.Context, repoIDs ...api.RepoID) error {
+	return s.syncErr
+}
+
 func TestServer_handleSchedulePermsSync(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -1405,6 +1415,61 @@ func TestServer_handleSchedulePermsSync(t *testing.T) {
 	}
 }
 
+func TestServer_handleSyncPerms(t *testing.T) {
+	tests := []struct {
+		name           string
+		permsSyncer    *fakePermsSyncer
+		body           string
+		wantStatusCode int
+		wantBody       string
+	}{
+		{
+			name:           "PermsSyncer not available",
+			wantStatusCode: http.StatusForbidden,
+			wantBody:       "null",
+		},
+		{
+			name:           "missing ids",
+			permsSyncer:    &fakePermsSyncer{},
+			body:           "{}",
+			wantStatusCode: http.StatusBadRequest,
+			wantBody:       "neither user and repo ids provided",
+		},
+		{
+			name:           "sync error",
+			permsSyncer:    &fakePermsSyncer{syncErr: errors.New("rate limit exceeded")},
+			body:           `{"user_ids": [1]}`,
+			wantStatusCode: http.StatusOK,
+			wantBody:       `{"Error":"rate limit exceeded"}`,
+		},
+		{
+			name:           "successful call with both IDs",
+			permsSyncer:    &fakePermsSyncer{},
+			body:           `{"user_ids": [1], "repo_ids":[1]}`,
+			wantStatusCode: http.StatusOK,
+			wantBody:       "null",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			r := httptest.NewRequest("POST", "/sync-perms", strings.NewReader(test.body))
+			w := httptest.NewRecorder()
+
+			s := &Server{}
+			if test.permsSyncer != nil {
+				s.PermsSyncer = test.permsSyncer
+			}
+			s.handleSyncPerms(w, r)
+
+			if w.Code != test.wantStatusCode {
+				t.Fatalf("Code: want %v but got %v", test.wantStatusCode, w.Code)
+			} else if diff := cmp.Diff(test.wantBody, w.Body.String()); diff != "" {
+				t.Fatalf("Body mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
 func formatJSON(s string) string {
 	formatted, err := jsonc.Format(s, nil)
 	if err != nil {
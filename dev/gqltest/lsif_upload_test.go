@@ -0,0 +1,222 @@
+// +build gqltest
+
+package main
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/klauspost/compress/zstd"
+
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/gqltestutil"
+)
+
+func TestLSIFUpload(t *testing.T) {
+	if len(*githubToken) == 0 {
+		t.Skip("Environment variable GITHUB_TOKEN is not set")
+	}
+
+	// Set up external service
+	esID, err := client.AddExternalService(gqltestutil.AddExternalServiceInput{
+		Kind:        extsvc.KindGitHub,
+		DisplayName: "gqltest-github-lsif-upload",
+		Config: mustMarshalJSONString(struct {
+			URL   string   `json:"url"`
+			Token string   `json:"token"`
+			Repos []string `json:"repos"`
+		}{
+			URL:   "http://github.com",
+			Token: *githubToken,
+			Repos: []string{"sgtest/go-diff"},
+		}),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		err := client.DeleteExternalService(esID)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}()
+
+	const repoName = "github.com/sgtest/go-diff"
+	err = client.WaitForReposToBeCloned(repoName)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	commit, err := client.ResolveRevision(repoName, "HEAD")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	token, err := client.CreateAccessToken("TestLSIFUpload", []string{authz.ScopeCodeIntelUpload})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		err := client.DeleteAccessToken(token)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}()
+
+	args := gqltestutil.LSIFUploadArgs{
+		Repository: repoName,
+		Commit:     commit,
+		Root:       "gqltest/",
+	}
+
+	// The payloads span multiple MiB, so that zstd payloads consist of many blocks and
+	// multipart uploads of many parts.
+	dump := lsifDump(t, 4<<20)
+	payloads := map[string][]byte{
+		"gzip": gzipCompress(t, dump),
+		"zstd": zstdCompress(t, dump),
+	}
+
+	for format, payload := range payloads {
+		t.Run(format+" single payload", func(t *testing.T) {
+			// The indexer name is read from the payload when it is not supplied.
+			id, err := client.UploadLSIF(token, args, payload)
+			if err != nil {
+				t.Fatal(err)
+			}
+			assertLSIFUploadEnqueued(t, token, id, "lsif-gqltest")
+		})
+
+		t.Run(format+" multipart", func(t *testing.T) {
+			argsWithIndexer := args
+			argsWithIndexer.IndexerName = "lsif-gqltest"
+
+			parts := splitPayload(payload, 5)
+			id, err := client.UploadLSIFMultipart(token, argsWithIndexer, parts, []int{0, 1, 2, 3, 4})
+			if err != nil {
+				t.Fatal(err)
+			}
+			assertLSIFUploadEnqueued(t, token, id, "lsif-gqltest")
+		})
+
+		t.Run(format+" resumed multipart", func(t *testing.T) {
+			argsWithIndexer := args
+			argsWithIndexer.IndexerName = "lsif-gqltest"
+
+			// Simulate an interrupted upload that only sent some of the parts.
+			parts := splitPayload(payload, 5)
+			id, err := client.UploadLSIFMultipart(token, argsWithIndexer, parts, []int{0, 3})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			status, err := client.LSIFUploadStatus(token, id)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if status.State != "uploading" || fmt.Sprint(status.UploadedParts) != "[0 3]" {
+				t.Fatalf("unexpected status of interrupted upload: %+v", status)
+			}
+
+			// Resume the upload with the missing parts.
+			var missing []int
+			for i := range parts {
+				if i != 0 && i != 3 {
+					missing = append(missing, i)
+				}
+			}
+			err = client.ResumeLSIFMultipartUpload(token, id, parts, missing)
+			if err != nil {
+				t.Fatal(err)
+			}
+			assertLSIFUploadEnqueued(t, token, id, "lsif-gqltest")
+		})
+	}
+
+	t.Run("unknown compression format", func(t *testing.T) {
+		_, err := client.UploadLSIF(token, args, dump)
+		if err == nil {
+			t.Fatal("want error for an uncompressed payload but got nil")
+		}
+	})
+}
+
+// assertLSIFUploadEnqueued fails the test unless the upload received all of its parts and is no
+// longer accepting data, and was created for the given indexer.
+func assertLSIFUploadEnqueued(t *testing.T, token, id, indexer string) {
+	t.Helper()
+
+	status, err := client.LSIFUploadStatus(token, id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if status.State == "uploading" || len(status.UploadedParts) != status.NumParts {
+		t.Fatalf("upload was not enqueued: %+v", status)
+	}
+
+	upload, err := client.GetLSIFUpload(id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if upload.InputIndexer != indexer {
+		t.Fatalf("unexpected indexer name. want=%q have=%q", indexer, upload.InputIndexer)
+	}
+}
+
+// lsifDump returns an uncompressed LSIF dump of at least the given size that starts with the
+// metadata vertex of the lsif-gqltest indexer. The remaining vertices are poorly compressible.
+func lsifDump(t *testing.T, size int) []byte {
+	var buf bytes.Buffer
+	buf.WriteString(`{"id":1,"type":"vertex","label":"metaData","version":"0.4.3","projectRoot":"file:///","toolInfo":{"name":"lsif-gqltest"}}` + "\n")
+
+	rng := rand.New(rand.NewSource(0))
+	for id := 2; buf.Len() < size; id++ {
+		fmt.Fprintf(&buf, `{"id":%d,"type":"vertex","label":"hoverResult","result":{"contents":"%x"}}`+"\n", id, rng.Int63())
+	}
+	return buf.Bytes()
+}
+
+func gzipCompress(t *testing.T, p []byte) []byte {
+	var buf bytes.Buffer
+	w := gzip.NewWriter(&buf)
+	if _, err := w.Write(p); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func zstdCompress(t *testing.T, p []byte) []byte {
+	var buf bytes.Buffer
+	w, err := zstd.NewWriter(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := w.Write(p); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+// splitPayload splits the payload into n parts of about the same size.
+func splitPayload(payload []byte, n int) [][]byte {
+	parts := make([][]byte, 0, n)
+	size := (len(payload) + n - 1) / n
+	for i := 0; i < n; i++ {
+		start, end := i*size, (i+1)*size
+		if end > len(payload) {
+			end = len(payload)
+		}
+		parts = append(parts, payload[start:end])
+	}
+	return parts
+}
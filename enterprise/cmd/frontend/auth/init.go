@@ -10,9 +10,11 @@ import (
 	"github.com/sourcegraph/sourcegraph/enterprise/cmd/frontend/auth/githuboauth"
 	"github.com/sourcegraph/sourcegraph/enterprise/cmd/frontend/auth/gitlaboauth"
 	"github.com/sourcegraph/sourcegraph/enterprise/cmd/frontend/auth/httpheader"
+	"github.com/sourcegraph/sourcegraph/enterprise/cmd/frontend/auth/ldap"
 	"github.com/sourcegraph/sourcegraph/enterprise/cmd/frontend/auth/openidconnect"
 	"github.com/sourcegraph/sourcegraph/enterprise/cmd/frontend/auth/saml"
 	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/goroutine"
 )
 
 func init() {
@@ -23,9 +25,12 @@ func init() {
 		httpheader.Middleware,
 		githuboauth.Middleware,
 		gitlaboauth.Middleware,
+		ldap.Middleware,
 	)
 	// Register app-level sign-out handler
 	app.RegisterSSOSignOutHandler(ssoSignOutHandler)
+	// Sync LDAP groups to organizations and site admin status
+	goroutine.Go(ldap.StartGroupSync)
 }
 
 func ssoSignOutHandler(w http.ResponseWriter, r *http.Request) (signOutURLs []app.SignOutURL) {
@@ -0,0 +1,215 @@
+package ldap
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"net"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/go-ldap/ldap/v3"
+	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// dialTimeout is the timeout of connecting to the LDAP server and of each operation on the
+// connection.
+const dialTimeout = 10 * time.Second
+
+// errInvalidCredentials is returned by authenticate when the username or password is invalid.
+var errInvalidCredentials = errors.New("invalid username or password")
+
+// ldapUser is the entry of a user in the LDAP directory.
+type ldapUser struct {
+	DN          string `json:"dn"`
+	Username    string `json:"username"`
+	Email       string `json:"email"`
+	DisplayName string `json:"displayName"`
+}
+
+// client talks to the LDAP server of an ldap auth provider. It opens a new connection for every
+// operation, as they are infrequent (sign-ins and periodic group syncs).
+type client struct {
+	config *schema.LDAPAuthProvider
+}
+
+// dial connects to the LDAP server, upgrades the connection to TLS if needed and binds as the
+// service account (or anonymously if no service account is configured).
+func (c *client) dial() (*ldap.Conn, error) {
+	u, err := url.Parse(c.config.Url)
+	if err != nil {
+		return nil, errors.Wrap(err, "parse URL")
+	}
+	tlsConfig, err := c.tlsConfig(u)
+	if err != nil {
+		return nil, err
+	}
+
+	conn, err := ldap.DialURL(c.config.Url,
+		ldap.DialWithDialer(&net.Dialer{Timeout: dialTimeout}),
+		ldap.DialWithTLSConfig(tlsConfig),
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "dial")
+	}
+	conn.SetTimeout(dialTimeout)
+
+	if u.Scheme == "ldap" && c.config.StartTLS {
+		if err = conn.StartTLS(tlsConfig); err != nil {
+			conn.Close()
+			return nil, errors.Wrap(err, "start TLS")
+		}
+	}
+
+	if c.config.BindDN == "" {
+		err = conn.UnauthenticatedBind("")
+	} else {
+		err = conn.Bind(c.config.BindDN, c.config.BindPassword)
+	}
+	if err != nil {
+		conn.Close()
+		return nil, errors.Wrap(err, "bind service account")
+	}
+	return conn, nil
+}
+
+func (c *client) tlsConfig(u *url.URL) (*tls.Config, error) {
+	tlsConfig := &tls.Config{
+		ServerName:         u.Hostname(),
+		InsecureSkipVerify: c.config.InsecureSkipVerify,
+	}
+	if c.config.Certificate != "" {
+		roots, err := x509.SystemCertPool()
+		if err != nil || roots == nil {
+			roots = x509.NewCertPool()
+		}
+		if !roots.AppendCertsFromPEM([]byte(c.config.Certificate)) {
+			return nil, errors.New("invalid certificate")
+		}
+		tlsConfig.RootCAs = roots
+	}
+	return tlsConfig, nil
+}
+
+// authenticate looks up the entry of the user with the given username and verifies the password
+// by binding as that entry. It returns errInvalidCredentials if there is no such user or the
+// password is incorrect.
+func (c *client) authenticate(username, password string) (*ldapUser, error) {
+	// 🚨 SECURITY: Most LDAP servers treat a simple bind with an empty password as an
+	// unauthenticated bind, which succeeds for any DN.
+	if username == "" || password == "" {
+		return nil, errInvalidCredentials
+	}
+
+	conn, err := c.dial()
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	filter := strings.Replace(c.userFilter(), "{username}", ldap.EscapeFilter(username), -1)
+	res, err := conn.Search(ldap.NewSearchRequest(
+		c.config.UserBaseDN,
+		ldap.ScopeWholeSubtree, ldap.NeverDerefAliases,
+		2, // We only need to know whether there is more than 1 match.
+		int(dialTimeout/time.Second),
+		false,
+		filter,
+		[]string{c.usernameAttribute(), c.emailAttribute(), c.displayNameAttribute()},
+		nil,
+	))
+	if err != nil && !ldap.IsErrorWithCode(err, ldap.LDAPResultSizeLimitExceeded) {
+		return nil, errors.Wrap(err, "search user")
+	}
+	if res == nil || len(res.Entries) == 0 {
+		return nil, errInvalidCredentials
+	}
+	// 🚨 SECURITY: Refuse to guess which of several matching entries is the user.
+	if len(res.Entries) > 1 {
+		return nil, errors.Errorf("user filter %q matches more than one entry", filter)
+	}
+
+	entry := res.Entries[0]
+	if err = conn.Bind(entry.DN, password); err != nil {
+		if ldap.IsErrorWithCode(err, ldap.LDAPResultInvalidCredentials) {
+			return nil, errInvalidCredentials
+		}
+		return nil, errors.Wrap(err, "bind user")
+	}
+
+	return &ldapUser{
+		DN:          entry.DN,
+		Username:    entry.GetEqualFoldAttributeValue(c.usernameAttribute()),
+		Email:       entry.GetEqualFoldAttributeValue(c.emailAttribute()),
+		DisplayName: entry.GetEqualFoldAttributeValue(c.displayNameAttribute()),
+	}, nil
+}
+
+// groups returns the names of the groups the user belongs to, according to the group sync
+// configuration.
+func (c *client) groups(conn *ldap.Conn, u *ldapUser) ([]string, error) {
+	gs := c.config.GroupSync
+	filter := gs.GroupFilter
+	if filter == "" {
+		filter = "(member={dn})"
+	}
+	filter = strings.NewReplacer(
+		"{dn}", ldap.EscapeFilter(u.DN),
+		"{username}", ldap.EscapeFilter(u.Username),
+	).Replace(filter)
+
+	nameAttr := gs.GroupNameAttribute
+	if nameAttr == "" {
+		nameAttr = "cn"
+	}
+
+	res, err := conn.SearchWithPaging(ldap.NewSearchRequest(
+		gs.GroupBaseDN,
+		ldap.ScopeWholeSubtree, ldap.NeverDerefAliases,
+		0, int(dialTimeout/time.Second),
+		false,
+		filter,
+		[]string{nameAttr},
+		nil,
+	), 500)
+	if err != nil {
+		return nil, errors.Wrap(err, "search groups")
+	}
+
+	names := make([]string, 0, len(res.Entries))
+	for _, e := range res.Entries {
+		if name := e.GetEqualFoldAttributeValue(nameAttr); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names, nil
+}
+
+func (c *client) userFilter() string {
+	if c.config.UserFilter != "" {
+		return c.config.UserFilter
+	}
+	return "(uid={username})"
+}
+
+func (c *client) usernameAttribute() string {
+	if c.config.UsernameAttribute != "" {
+		return c.config.UsernameAttribute
+	}
+	return "uid"
+}
+
+func (c *client) emailAttribute() string {
+	if c.config.EmailAttribute != "" {
+		return c.config.EmailAttribute
+	}
+	return "mail"
+}
+
+func (c *client) displayNameAttribute() string {
+	if c.config.DisplayNameAttribute != "" {
+		return c.config.DisplayNameAttribute
+	}
+	return "cn"
+}
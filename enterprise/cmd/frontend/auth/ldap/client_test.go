@@ -0,0 +1,222 @@
+package ldap
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+var testEntries = []testEntry{
+	{
+		dn:       "cn=sourcegraph,ou=services,dc=example,dc=com",
+		password: "service-secret",
+	},
+	{
+		dn:       "uid=alice,ou=people,dc=example,dc=com",
+		password: "alice-secret",
+		attrs: map[string][]string{
+			"objectClass": {"person"},
+			"uid":         {"alice"},
+			"mail":        {"alice@example.com"},
+			"cn":          {"Alice Liddell"},
+		},
+	},
+	{
+		dn:       "uid=bob,ou=people,dc=example,dc=com",
+		password: "bob-secret",
+		attrs: map[string][]string{
+			"objectClass": {"person"},
+			"uid":         {"bob"},
+			"mail":        {"bob@example.com"},
+			"cn":          {"Bob"},
+		},
+	},
+	{
+		dn: "cn=engineering,ou=groups,dc=example,dc=com",
+		attrs: map[string][]string{
+			"objectClass": {"groupOfNames"},
+			"cn":          {"Engineering"},
+			"member":      {"uid=alice,ou=people,dc=example,dc=com", "uid=bob,ou=people,dc=example,dc=com"},
+		},
+	},
+	{
+		dn: "cn=admins,ou=groups,dc=example,dc=com",
+		attrs: map[string][]string{
+			"objectClass": {"groupOfNames"},
+			"cn":          {"admins"},
+			"member":      {"uid=alice,ou=people,dc=example,dc=com"},
+		},
+	},
+}
+
+func newTestConfig(url string) *schema.LDAPAuthProvider {
+	return &schema.LDAPAuthProvider{
+		Type:         providerType,
+		Url:          url,
+		BindDN:       "cn=sourcegraph,ou=services,dc=example,dc=com",
+		BindPassword: "service-secret",
+		UserBaseDN:   "ou=people,dc=example,dc=com",
+		GroupSync: &schema.LDAPGroupSync{
+			GroupBaseDN: "ou=groups,dc=example,dc=com",
+		},
+	}
+}
+
+func TestClient_authenticate(t *testing.T) {
+	s := newTestServer(t, testEntries...)
+	defer s.Close()
+
+	tests := []struct {
+		name       string
+		userFilter string
+		username   string
+		password   string
+		wantUser   *ldapUser
+		wantErr    string
+	}{
+		{
+			name:     "valid credentials",
+			username: "alice",
+			password: "alice-secret",
+			wantUser: &ldapUser{
+				DN:          "uid=alice,ou=people,dc=example,dc=com",
+				Username:    "alice",
+				Email:       "alice@example.com",
+				DisplayName: "Alice Liddell",
+			},
+		},
+		{
+			name:     "wrong password",
+			username: "alice",
+			password: "bob-secret",
+			wantErr:  errInvalidCredentials.Error(),
+		},
+		{
+			name:     "empty password",
+			username: "alice",
+			password: "",
+			wantErr:  errInvalidCredentials.Error(),
+		},
+		{
+			name:     "unknown user",
+			username: "carol",
+			password: "carol-secret",
+			wantErr:  errInvalidCredentials.Error(),
+		},
+		{
+			name:     "filter injection",
+			username: "*",
+			password: "alice-secret",
+			wantErr:  errInvalidCredentials.Error(),
+		},
+		{
+			name:       "filter matches more than one entry",
+			userFilter: "(|(uid={username})(objectClass=person))",
+			username:   "alice",
+			password:   "alice-secret",
+			wantErr:    `user filter "(|(uid=alice)(objectClass=person))" matches more than one entry`,
+		},
+		{
+			name:       "custom filter and attributes",
+			userFilter: "(&(objectClass=person)(mail={username}))",
+			username:   "bob@example.com",
+			password:   "bob-secret",
+			wantUser: &ldapUser{
+				DN:          "uid=bob,ou=people,dc=example,dc=com",
+				Username:    "bob",
+				Email:       "bob@example.com",
+				DisplayName: "Bob",
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cfg := newTestConfig(s.URL())
+			cfg.UserFilter = test.userFilter
+
+			u, err := (&client{config: cfg}).authenticate(test.username, test.password)
+			gotErr := ""
+			if err != nil {
+				gotErr = err.Error()
+			}
+			if gotErr != test.wantErr {
+				t.Fatalf("err: want %q but got %q", test.wantErr, gotErr)
+			}
+			if diff := cmp.Diff(test.wantUser, u); diff != "" {
+				t.Fatalf("user: %v", diff)
+			}
+		})
+	}
+}
+
+func TestClient_authenticate_invalidServiceAccount(t *testing.T) {
+	s := newTestServer(t, testEntries...)
+	defer s.Close()
+
+	cfg := newTestConfig(s.URL())
+	cfg.BindPassword = "wrong"
+
+	_, err := (&client{config: cfg}).authenticate("alice", "alice-secret")
+	if err == nil || err == errInvalidCredentials {
+		t.Fatalf("err: want a bind error but got %v", err)
+	}
+	if binds := s.Binds(); len(binds) != 0 {
+		t.Fatalf("binds: want none but got %v", binds)
+	}
+}
+
+func TestClient_groups(t *testing.T) {
+	s := newTestServer(t, testEntries...)
+	defer s.Close()
+
+	alice := &ldapUser{DN: "uid=alice,ou=people,dc=example,dc=com", Username: "alice"}
+	tests := []struct {
+		name      string
+		groupSync schema.LDAPGroupSync
+		wantNames []string
+	}{
+		{
+			name:      "default filter",
+			groupSync: schema.LDAPGroupSync{GroupBaseDN: "ou=groups,dc=example,dc=com"},
+			wantNames: []string{"Engineering", "admins"},
+		},
+		{
+			name: "username filter",
+			groupSync: schema.LDAPGroupSync{
+				GroupBaseDN: "ou=groups,dc=example,dc=com",
+				GroupFilter: "(&(objectClass=groupOfNames)(member=uid={username},ou=people,dc=example,dc=com))",
+			},
+			wantNames: []string{"Engineering", "admins"},
+		},
+		{
+			name: "custom name attribute",
+			groupSync: schema.LDAPGroupSync{
+				GroupBaseDN:        "ou=groups,dc=example,dc=com",
+				GroupNameAttribute: "objectClass",
+			},
+			wantNames: []string{"groupOfNames", "groupOfNames"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cfg := newTestConfig(s.URL())
+			cfg.GroupSync = &test.groupSync
+
+			c := &client{config: cfg}
+			conn, err := c.dial()
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer conn.Close()
+
+			names, err := c.groups(conn, alice)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(test.wantNames, names); diff != "" {
+				t.Fatalf("names: %v", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,68 @@
+package ldap
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/auth/providers"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+var mockGetProviderValue *provider
+
+// getProvider looks up the registered ldap auth provider with the given ID.
+func getProvider(id string) *provider {
+	if mockGetProviderValue != nil {
+		return mockGetProviderValue
+	}
+	p, _ := providers.GetProviderByConfigID(providers.ConfigID{Type: providerType, ID: id}).(*provider)
+	return p
+}
+
+func init() {
+	conf.ContributeValidator(validateConfig)
+}
+
+func validateConfig(c conf.Unified) (problems conf.Problems) {
+	seen := map[string]int{}
+	for i, p := range c.AuthProviders {
+		if p.Ldap == nil {
+			continue
+		}
+
+		u, err := url.Parse(p.Ldap.Url)
+		if err != nil || (u.Scheme != "ldap" && u.Scheme != "ldaps") || u.Host == "" {
+			problems = append(problems, conf.NewSiteProblem(fmt.Sprintf("LDAP auth provider at index %d has an invalid url %q (example: ldaps://ldap.example.com)", i, p.Ldap.Url)))
+		} else if u.Scheme == "ldap" && !p.Ldap.StartTLS {
+			problems = append(problems, conf.NewSiteProblem(fmt.Sprintf("LDAP auth provider at index %d sends passwords unencrypted: use an ldaps:// url or set startTLS to true", i)))
+		}
+		if p.Ldap.UserFilter != "" && !strings.Contains(p.Ldap.UserFilter, "{username}") {
+			problems = append(problems, conf.NewSiteProblem(fmt.Sprintf("LDAP auth provider at index %d has a userFilter without the {username} placeholder", i)))
+		}
+
+		id := providerConfigID(p.Ldap)
+		if j, ok := seen[id]; ok {
+			problems = append(problems, conf.NewSiteProblem(fmt.Sprintf("LDAP auth provider at index %d is duplicate of index %d, ignoring", i, j)))
+		} else {
+			seen[id] = i
+		}
+	}
+	return problems
+}
+
+// providerConfigID produces a semi-stable identifier for an ldap auth provider config object. It
+// is used to tell which provider a sign-in request is for. Its value is never persisted, and it
+// must be deterministic.
+func providerConfigID(pc *schema.LDAPAuthProvider) string {
+	data, err := json.Marshal(pc)
+	if err != nil {
+		panic(err)
+	}
+	b := sha256.Sum256(data)
+	return base64.RawURLEncoding.EncodeToString(b[:16])
+}
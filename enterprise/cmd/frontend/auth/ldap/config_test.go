@@ -0,0 +1,51 @@
+package ldap
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestValidateConfig(t *testing.T) {
+	tests := []struct {
+		name         string
+		config       schema.LDAPAuthProvider
+		wantProblems []string
+	}{
+		{
+			name:   "valid",
+			config: schema.LDAPAuthProvider{Type: providerType, Url: "ldaps://ldap.example.com", UserBaseDN: "dc=example,dc=com"},
+		},
+		{
+			name:   "StartTLS",
+			config: schema.LDAPAuthProvider{Type: providerType, Url: "ldap://ldap.example.com", StartTLS: true, UserBaseDN: "dc=example,dc=com"},
+		},
+		{
+			name:         "unencrypted",
+			config:       schema.LDAPAuthProvider{Type: providerType, Url: "ldap://ldap.example.com", UserBaseDN: "dc=example,dc=com"},
+			wantProblems: []string{"LDAP auth provider at index 0 sends passwords unencrypted: use an ldaps:// url or set startTLS to true"},
+		},
+		{
+			name:         "invalid url",
+			config:       schema.LDAPAuthProvider{Type: providerType, Url: "https://ldap.example.com", UserBaseDN: "dc=example,dc=com"},
+			wantProblems: []string{`LDAP auth provider at index 0 has an invalid url "https://ldap.example.com" (example: ldaps://ldap.example.com)`},
+		},
+		{
+			name:         "user filter without placeholder",
+			config:       schema.LDAPAuthProvider{Type: providerType, Url: "ldaps://ldap.example.com", UserBaseDN: "dc=example,dc=com", UserFilter: "(uid=alice)"},
+			wantProblems: []string{"LDAP auth provider at index 0 has a userFilter without the {username} placeholder"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var c conf.Unified
+			c.AuthProviders = []schema.AuthProviders{{Ldap: &test.config}}
+			problems := validateConfig(c)
+			if diff := cmp.Diff(test.wantProblems, problems.Messages()); diff != "" {
+				t.Fatalf("problems: %v", diff)
+			}
+		})
+	}
+}
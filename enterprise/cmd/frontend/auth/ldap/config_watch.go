@@ -0,0 +1,25 @@
+package ldap
+
+import (
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/auth/providers"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+)
+
+func getProviders() []providers.Provider {
+	var ps []providers.Provider
+	for _, p := range conf.Get().AuthProviders {
+		if p.Ldap == nil {
+			continue
+		}
+		ps = append(ps, &provider{config: *p.Ldap})
+	}
+	return ps
+}
+
+func init() {
+	go func() {
+		conf.Watch(func() {
+			providers.Update("ldap", getProviders())
+		})
+	}()
+}
@@ -0,0 +1,208 @@
+package ldap
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-ldap/ldap/v3"
+	"github.com/hashicorp/go-multierror"
+	"github.com/inconshreveable/log15"
+	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/auth/providers"
+	"github.com/sourcegraph/sourcegraph/internal/db"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/leader"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// defaultGroupSyncInterval is the interval between periodic group syncs when the group sync
+// configuration does not specify one.
+const defaultGroupSyncInterval = time.Hour
+
+// StartGroupSync periodically syncs the LDAP groups of all users who signed in with an ldap auth
+// provider that has group sync configured. Only one frontend instance syncs at a time.
+func StartGroupSync() {
+	leader.Do(context.Background(), "ldap-group-sync", leader.Options{}, func(ctx context.Context) {
+		lastSynced := make(map[string]time.Time)
+		for {
+			for _, pp := range providers.Providers() {
+				p, ok := pp.(*provider)
+				if !ok || p.config.GroupSync == nil {
+					continue
+				}
+
+				id := providerConfigID(&p.config)
+				if time.Since(lastSynced[id]) < groupSyncInterval(p.config.GroupSync) {
+					continue
+				}
+				lastSynced[id] = time.Now()
+
+				if err := p.syncAllGroups(ctx); err != nil {
+					log15.Error("ldap.StartGroupSync: failed to sync LDAP groups.", "url", p.config.Url, "error", err)
+				}
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(time.Minute):
+			}
+		}
+	})
+}
+
+func groupSyncInterval(gs *schema.LDAPGroupSync) time.Duration {
+	if gs.IntervalMinutes > 0 {
+		return time.Duration(gs.IntervalMinutes) * time.Minute
+	}
+	return defaultGroupSyncInterval
+}
+
+// syncUserGroups syncs the LDAP groups of a single user.
+func (p *provider) syncUserGroups(ctx context.Context, userID int32, u *ldapUser) error {
+	c := p.client()
+	conn, err := c.dial()
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	return p.syncGroups(ctx, c, conn, userID, u)
+}
+
+// syncAllGroups syncs the LDAP groups of all users who signed in with the provider.
+func (p *provider) syncAllGroups(ctx context.Context) error {
+	accounts, err := db.ExternalAccounts.List(ctx, db.ExternalAccountsListOptions{
+		ServiceType: providerType,
+		ServiceID:   p.config.Url,
+	})
+	if err != nil {
+		return errors.Wrap(err, "list external accounts")
+	}
+	if len(accounts) == 0 {
+		return nil
+	}
+
+	c := p.client()
+	conn, err := c.dial()
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	var errs *multierror.Error
+	for _, acct := range accounts {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		var u ldapUser
+		if acct.Data != nil {
+			if err := acct.GetAccountData(&u); err != nil {
+				errs = multierror.Append(errs, errors.Wrapf(err, "get account data of external account %d", acct.ID))
+				continue
+			}
+		}
+		u.DN = acct.AccountID
+
+		if err := p.syncGroups(ctx, c, conn, acct.UserID, &u); err != nil {
+			errs = multierror.Append(errs, errors.Wrapf(err, "sync groups of user %d", acct.UserID))
+		}
+	}
+	return errs.ErrorOrNil()
+}
+
+func (p *provider) syncGroups(ctx context.Context, c *client, conn *ldap.Conn, userID int32, u *ldapUser) error {
+	groups, err := c.groups(conn, u)
+	if err != nil {
+		return err
+	}
+	return applyGroups(ctx, p.config.GroupSync, userID, groups)
+}
+
+// applyGroups updates the memberships of the organizations mapped by the group sync configuration
+// and the site admin status of the user to match the LDAP groups the user belongs to.
+//
+// 🚨 SECURITY: A user who is in none of the groups of a mapped organization is removed from it,
+// and when site admin groups are configured, a user who is in none of them is not a site admin.
+// This must hold even for users who were added to an organization or promoted in Sourcegraph.
+func applyGroups(ctx context.Context, gs *schema.LDAPGroupSync, userID int32, groups []string) error {
+	inGroup := make(map[string]bool, len(groups))
+	for _, g := range groups {
+		inGroup[strings.ToLower(g)] = true
+	}
+
+	if len(gs.Orgs) > 0 {
+		wantOrgs := make(map[string]bool, len(gs.Orgs))
+		for _, m := range gs.Orgs {
+			wantOrgs[m.Org] = wantOrgs[m.Org] || inGroup[strings.ToLower(m.Group)]
+		}
+		if err := applyOrgMemberships(ctx, userID, wantOrgs); err != nil {
+			return err
+		}
+	}
+
+	if len(gs.SiteAdminGroups) > 0 {
+		wantSiteAdmin := false
+		for _, g := range gs.SiteAdminGroups {
+			if inGroup[strings.ToLower(g)] {
+				wantSiteAdmin = true
+				break
+			}
+		}
+
+		user, err := db.Users.GetByID(ctx, userID)
+		if err != nil {
+			return errors.Wrap(err, "get user")
+		}
+		if user.SiteAdmin != wantSiteAdmin {
+			if err := db.Users.SetIsSiteAdmin(ctx, userID, wantSiteAdmin); err != nil {
+				return errors.Wrap(err, "set site admin status")
+			}
+		}
+	}
+	return nil
+}
+
+// applyOrgMemberships adds the user to or removes the user from the named organizations according
+// to whether the user should be a member of each.
+func applyOrgMemberships(ctx context.Context, userID int32, wantOrgs map[string]bool) error {
+	memberships, err := db.OrgMembers.GetByUserID(ctx, userID)
+	if err != nil {
+		return errors.Wrap(err, "list organization memberships")
+	}
+	isMember := make(map[int32]bool, len(memberships))
+	for _, m := range memberships {
+		isMember[m.OrgID] = true
+	}
+
+	names := make([]string, 0, len(wantOrgs))
+	for name := range wantOrgs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		org, err := db.Orgs.GetByName(ctx, name)
+		if errcode.IsNotFound(err) {
+			log15.Warn("ldap.applyOrgMemberships: organization mapped by LDAP group sync does not exist.", "org", name)
+			continue
+		} else if err != nil {
+			return errors.Wrapf(err, "get organization %q", name)
+		}
+
+		switch want := wantOrgs[name]; {
+		case want && !isMember[org.ID]:
+			if _, err := db.OrgMembers.Create(ctx, org.ID, userID); err != nil {
+				return errors.Wrapf(err, "add user to organization %q", name)
+			}
+		case !want && isMember[org.ID]:
+			if err := db.OrgMembers.Remove(ctx, org.ID, userID); err != nil {
+				return errors.Wrapf(err, "remove user from organization %q", name)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,195 @@
+package ldap
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/db"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// mockOrgsAndUsers mocks the organizations "engineering" (ID 1) and "admins" (ID 2), and records
+// the changes made to memberships and site admin status.
+type mockOrgsAndUsers struct {
+	memberships map[int32][]int32 // user ID -> org IDs
+	siteAdmins  map[int32]bool
+	changes     []string
+}
+
+func (m *mockOrgsAndUsers) install() (cleanup func()) {
+	orgs := map[string]int32{"engineering": 1, "admins": 2}
+	db.Mocks.Orgs.GetByName = func(_ context.Context, name string) (*types.Org, error) {
+		if id, ok := orgs[name]; ok {
+			return &types.Org{ID: id, Name: name}, nil
+		}
+		return nil, &db.OrgNotFoundError{Message: name}
+	}
+	db.Mocks.OrgMembers.GetByUserID = func(_ context.Context, userID int32) ([]*types.OrgMembership, error) {
+		var ms []*types.OrgMembership
+		for _, orgID := range m.memberships[userID] {
+			ms = append(ms, &types.OrgMembership{OrgID: orgID, UserID: userID})
+		}
+		return ms, nil
+	}
+	db.Mocks.OrgMembers.Create = func(_ context.Context, orgID, userID int32) (*types.OrgMembership, error) {
+		m.changes = append(m.changes, fmt.Sprintf("add user %d to org %d", userID, orgID))
+		return &types.OrgMembership{OrgID: orgID, UserID: userID}, nil
+	}
+	db.Mocks.OrgMembers.Remove = func(_ context.Context, orgID, userID int32) error {
+		m.changes = append(m.changes, fmt.Sprintf("remove user %d from org %d", userID, orgID))
+		return nil
+	}
+	db.Mocks.Users.GetByID = func(_ context.Context, id int32) (*types.User, error) {
+		return &types.User{ID: id, SiteAdmin: m.siteAdmins[id]}, nil
+	}
+	db.Mocks.Users.SetIsSiteAdmin = func(id int32, isSiteAdmin bool) error {
+		m.changes = append(m.changes, fmt.Sprintf("set user %d site admin %v", id, isSiteAdmin))
+		return nil
+	}
+	return func() {
+		db.Mocks.Orgs = db.MockOrgs{}
+		db.Mocks.OrgMembers = db.MockOrgMembers{}
+		db.Mocks.Users = db.MockUsers{}
+	}
+}
+
+func TestApplyGroups(t *testing.T) {
+	gs := &schema.LDAPGroupSync{
+		GroupBaseDN: "ou=groups,dc=example,dc=com",
+		Orgs: []*schema.LDAPGroupOrg{
+			{Group: "Engineering", Org: "engineering"},
+			{Group: "Platform", Org: "engineering"},
+			{Group: "admins", Org: "admins"},
+			{Group: "ghosts", Org: "missing"},
+		},
+		SiteAdminGroups: []string{"admins"},
+	}
+
+	tests := []struct {
+		name        string
+		groups      []string
+		memberships []int32
+		siteAdmin   bool
+		wantChanges []string
+	}{
+		{
+			name:        "new member of all groups",
+			groups:      []string{"engineering", "ADMINS", "ghosts"},
+			wantChanges: []string{"add user 1 to org 2", "add user 1 to org 1", "set user 1 site admin true"},
+		},
+		{
+			name:        "already in sync",
+			groups:      []string{"Platform", "admins"},
+			memberships: []int32{1, 2},
+			siteAdmin:   true,
+			wantChanges: nil,
+		},
+		{
+			name:        "removed from groups",
+			groups:      []string{"Other"},
+			memberships: []int32{1, 2, 3},
+			siteAdmin:   true,
+			wantChanges: []string{"remove user 1 from org 2", "remove user 1 from org 1", "set user 1 site admin false"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			m := &mockOrgsAndUsers{
+				memberships: map[int32][]int32{1: test.memberships},
+				siteAdmins:  map[int32]bool{1: test.siteAdmin},
+			}
+			defer m.install()()
+
+			if err := applyGroups(context.Background(), gs, 1, test.groups); err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(test.wantChanges, m.changes); diff != "" {
+				t.Fatalf("changes: %v", diff)
+			}
+		})
+	}
+
+	t.Run("site admin groups not set", func(t *testing.T) {
+		m := &mockOrgsAndUsers{siteAdmins: map[int32]bool{1: true}}
+		defer m.install()()
+
+		gs := &schema.LDAPGroupSync{GroupBaseDN: "ou=groups,dc=example,dc=com"}
+		if err := applyGroups(context.Background(), gs, 1, nil); err != nil {
+			t.Fatal(err)
+		}
+		if len(m.changes) != 0 {
+			t.Fatalf("changes: want none but got %v", m.changes)
+		}
+	})
+}
+
+func TestProvider_syncAllGroups(t *testing.T) {
+	s := newTestServer(t, testEntries...)
+	defer s.Close()
+
+	p := &provider{config: *newTestConfig(s.URL())}
+	p.config.GroupSync.Orgs = []*schema.LDAPGroupOrg{{Group: "engineering", Org: "engineering"}}
+	p.config.GroupSync.SiteAdminGroups = []string{"admins"}
+
+	accountData := func(u ldapUser) *json.RawMessage {
+		var data extsvc.AccountData
+		data.SetAccountData(u)
+		return data.Data
+	}
+	db.Mocks.ExternalAccounts.List = func(opt db.ExternalAccountsListOptions) ([]*extsvc.Account, error) {
+		if opt.ServiceType != providerType || opt.ServiceID != s.URL() {
+			return nil, fmt.Errorf("unexpected options %+v", opt)
+		}
+		return []*extsvc.Account{
+			{
+				UserID:      1,
+				AccountSpec: extsvc.AccountSpec{AccountID: "uid=alice,ou=people,dc=example,dc=com"},
+				AccountData: extsvc.AccountData{Data: accountData(ldapUser{Username: "alice"})},
+			},
+			{
+				UserID:      2,
+				AccountSpec: extsvc.AccountSpec{AccountID: "uid=bob,ou=people,dc=example,dc=com"},
+				AccountData: extsvc.AccountData{Data: accountData(ldapUser{Username: "bob"})},
+			},
+			{
+				// Deleted from the directory since the user signed in.
+				UserID:      3,
+				AccountSpec: extsvc.AccountSpec{AccountID: "uid=carol,ou=people,dc=example,dc=com"},
+			},
+		}, nil
+	}
+	defer func() { db.Mocks.ExternalAccounts = db.MockExternalAccounts{} }()
+
+	m := &mockOrgsAndUsers{
+		memberships: map[int32][]int32{2: {1}, 3: {1}},
+		siteAdmins:  map[int32]bool{2: true, 3: true},
+	}
+	defer m.install()()
+
+	if err := p.syncAllGroups(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	sort.Strings(m.changes)
+	wantChanges := []string{
+		"add user 1 to org 1",
+		"remove user 3 from org 1",
+		"set user 1 site admin true",
+		"set user 2 site admin false",
+		"set user 3 site admin false",
+	}
+	if diff := cmp.Diff(wantChanges, m.changes); diff != "" {
+		t.Fatalf("changes: %v", diff)
+	}
+
+	// All searches must use a single connection bound as the service account.
+	if diff := cmp.Diff([]string{"cn=sourcegraph,ou=services,dc=example,dc=com"}, s.Binds()); diff != "" {
+		t.Fatalf("binds: %v", diff)
+	}
+}
@@ -0,0 +1,96 @@
+// Package ldap implements auth via LDAP (username and password sign-in against an LDAP directory)
+// and syncs LDAP groups to Sourcegraph organizations and site admin status.
+package ldap
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/inconshreveable/log15"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/auth"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/external/session"
+)
+
+// All LDAP endpoints are under this path prefix.
+const authPrefix = auth.AuthURLPrefix + "/ldap"
+
+// Middleware is middleware for LDAP authentication, adding the sign-in endpoint under the auth path
+// prefix ("/.auth/ldap/sign-in").
+//
+// 🚨 SECURITY
+var Middleware = &auth.Middleware{
+	API: func(next http.Handler) http.Handler { return next },
+	App: func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == authPrefix+"/sign-in" {
+				signInHandler(w, r)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	},
+}
+
+type credentials struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+// signInHandler accepts a POST containing LDAP username-password credentials and authenticates
+// the current session if they are valid.
+//
+// 🚨 SECURITY
+func signInHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "POST" {
+		http.Error(w, fmt.Sprintf("Unsupported method %s", r.Method), http.StatusBadRequest)
+		return
+	}
+
+	p := getProvider(r.URL.Query().Get("pc"))
+	if p == nil {
+		log15.Error("No LDAP auth provider found with ID.", "id", r.URL.Query().Get("pc"))
+		http.Error(w, "Misconfigured LDAP auth provider.", http.StatusInternalServerError)
+		return
+	}
+
+	var creds credentials
+	if err := json.NewDecoder(r.Body).Decode(&creds); err != nil {
+		http.Error(w, "Could not decode request body", http.StatusBadRequest)
+		return
+	}
+
+	u, err := p.client().authenticate(creds.Username, creds.Password)
+	if err == errInvalidCredentials {
+		http.Error(w, "Authentication failed", http.StatusUnauthorized)
+		return
+	} else if err != nil {
+		log15.Error("LDAP auth failed: unable to authenticate user.", "username", creds.Username, "error", err)
+		http.Error(w, "Authentication failed. Unexpected error while contacting the LDAP server. Ask a site admin for help.", http.StatusInternalServerError)
+		return
+	}
+
+	actor, safeErrMsg, err := getOrCreateUser(r.Context(), p, u)
+	if err != nil {
+		log15.Error("LDAP auth failed: error looking up or creating user from LDAP entry.", "dn", u.DN, "error", err, "userErr", safeErrMsg)
+		http.Error(w, safeErrMsg, http.StatusInternalServerError)
+		return
+	}
+
+	// 🚨 SECURITY: Sync groups before the session is created, so that the user does not keep
+	// organization memberships or site admin status that were revoked in LDAP since the last
+	// periodic sync.
+	if p.config.GroupSync != nil {
+		if err := p.syncUserGroups(r.Context(), actor.UID, u); err != nil {
+			log15.Error("LDAP auth failed: unable to sync groups of user.", "dn", u.DN, "error", err)
+			http.Error(w, "Authentication failed. Unable to sync your LDAP groups. Ask a site admin for help.", http.StatusInternalServerError)
+			return
+		}
+	}
+
+	if err := session.SetActor(w, r, actor, 0); err != nil {
+		log15.Error("LDAP auth failed: could not initiate session.", "error", err)
+		http.Error(w, "Authentication failed. Could not create new user session.", http.StatusInternalServerError)
+		return
+	}
+}
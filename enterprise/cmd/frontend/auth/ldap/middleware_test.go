@@ -0,0 +1,162 @@
+package ldap
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/auth"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/external/session"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/db"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+)
+
+func TestMiddleware(t *testing.T) {
+	cleanup := session.ResetMockSessionStore(t)
+	defer cleanup()
+
+	s := newTestServer(t, testEntries...)
+	defer s.Close()
+
+	mockGetProviderValue = &provider{config: *newTestConfig(s.URL())}
+	mockGetProviderValue.config.GroupSync.SiteAdminGroups = []string{"admins"}
+	defer func() { mockGetProviderValue = nil }()
+
+	var gotOp auth.GetAndSaveUserOp
+	auth.MockGetAndSaveUser = func(ctx context.Context, op auth.GetAndSaveUserOp) (userID int32, safeErrMsg string, err error) {
+		gotOp = op
+		if op.ExternalAccount.ServiceType == providerType && op.ExternalAccount.ServiceID == s.URL() && op.ExternalAccount.AccountID == "uid=alice,ou=people,dc=example,dc=com" {
+			return 1, "", nil
+		}
+		return 0, "safeErr", fmt.Errorf("account %v not found in mock", op.ExternalAccount)
+	}
+	defer func() { auth.MockGetAndSaveUser = nil }()
+
+	m := &mockOrgsAndUsers{}
+	defer m.install()()
+
+	h := Middleware.App(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTeapot)
+	}))
+	doRequest := func(method, urlStr, body string) *http.Response {
+		req := httptest.NewRequest(method, urlStr, strings.NewReader(body))
+		respRecorder := httptest.NewRecorder()
+		h.ServeHTTP(respRecorder, req)
+		return respRecorder.Result()
+	}
+
+	t.Run("other paths are passed through", func(t *testing.T) {
+		resp := doRequest("GET", "http://example.com/search", "")
+		if want := http.StatusTeapot; resp.StatusCode != want {
+			t.Fatalf("got response code %v, want %v", resp.StatusCode, want)
+		}
+	})
+
+	t.Run("GET is not supported", func(t *testing.T) {
+		resp := doRequest("GET", "http://example.com/.auth/ldap/sign-in", "")
+		if want := http.StatusBadRequest; resp.StatusCode != want {
+			t.Fatalf("got response code %v, want %v", resp.StatusCode, want)
+		}
+	})
+
+	t.Run("invalid credentials", func(t *testing.T) {
+		resp := doRequest("POST", "http://example.com/.auth/ldap/sign-in", `{"username": "alice", "password": "wrong"}`)
+		if want := http.StatusUnauthorized; resp.StatusCode != want {
+			t.Fatalf("got response code %v, want %v", resp.StatusCode, want)
+		}
+		if len(resp.Cookies()) != 0 {
+			t.Fatalf("got cookies %v, want none", resp.Cookies())
+		}
+	})
+
+	t.Run("valid credentials", func(t *testing.T) {
+		m.changes = nil
+
+		resp := doRequest("POST", "http://example.com/.auth/ldap/sign-in", `{"username": "alice", "password": "alice-secret"}`)
+		if want := http.StatusOK; resp.StatusCode != want {
+			t.Fatalf("got response code %v, want %v", resp.StatusCode, want)
+		}
+		if len(resp.Cookies()) == 0 {
+			t.Fatal("got no session cookie")
+		}
+
+		if gotOp.UserProps.Username != "alice" || gotOp.UserProps.Email != "alice@example.com" || !gotOp.UserProps.EmailIsVerified {
+			t.Fatalf("unexpected user props %+v", gotOp.UserProps)
+		}
+		if !gotOp.CreateIfNotExist || gotOp.LookUpByUsername {
+			t.Fatalf("want sign up allowed, got %+v", gotOp)
+		}
+		if diff := cmp.Diff([]string{"set user 1 site admin true"}, m.changes); diff != "" {
+			t.Fatalf("group sync changes: %v", diff)
+		}
+	})
+
+	t.Run("sign up not allowed", func(t *testing.T) {
+		allowSignup := false
+		mockGetProviderValue.config.AllowSignup = &allowSignup
+		defer func() { mockGetProviderValue.config.AllowSignup = nil }()
+
+		resp := doRequest("POST", "http://example.com/.auth/ldap/sign-in", `{"username": "alice", "password": "alice-secret"}`)
+		if want := http.StatusOK; resp.StatusCode != want {
+			t.Fatalf("got response code %v, want %v", resp.StatusCode, want)
+		}
+		if gotOp.CreateIfNotExist || gotOp.LookUpByUsername {
+			t.Fatalf("want sign up not allowed and no linking by username, got %+v", gotOp)
+		}
+	})
+
+	t.Run("link existing users by username", func(t *testing.T) {
+		mockGetProviderValue.config.LinkExistingUsersByUsername = true
+		defer func() { mockGetProviderValue.config.LinkExistingUsersByUsername = false }()
+
+		db.Mocks.ExternalAccounts.List = func(db.ExternalAccountsListOptions) ([]*extsvc.Account, error) { return nil, nil }
+		defer func() { db.Mocks.ExternalAccounts.List = nil }()
+
+		tests := []struct {
+			name     string
+			user     *types.User
+			wantCode int
+		}{
+			{name: "regular user", user: &types.User{ID: 1, Username: "alice"}, wantCode: http.StatusOK},
+			{name: "site admin", user: &types.User{ID: 1, Username: "alice", SiteAdmin: true}, wantCode: http.StatusInternalServerError},
+			{name: "builtin password", user: &types.User{ID: 1, Username: "alice", BuiltinAuth: true}, wantCode: http.StatusInternalServerError},
+		}
+		for _, test := range tests {
+			t.Run(test.name, func(t *testing.T) {
+				gotOp = auth.GetAndSaveUserOp{}
+				db.Mocks.Users.GetByUsername = func(_ context.Context, username string) (*types.User, error) {
+					return test.user, nil
+				}
+				defer func() { db.Mocks.Users.GetByUsername = nil }()
+
+				resp := doRequest("POST", "http://example.com/.auth/ldap/sign-in", `{"username": "alice", "password": "alice-secret"}`)
+				if resp.StatusCode != test.wantCode {
+					t.Fatalf("got response code %v, want %v", resp.StatusCode, test.wantCode)
+				}
+				if linked := gotOp.LookUpByUsername; linked != (test.wantCode == http.StatusOK) {
+					t.Fatalf("got LookUpByUsername %v", linked)
+				}
+			})
+		}
+
+		t.Run("already linked site admin", func(t *testing.T) {
+			db.Mocks.ExternalAccounts.List = func(db.ExternalAccountsListOptions) ([]*extsvc.Account, error) {
+				return []*extsvc.Account{{UserID: 1}}, nil
+			}
+			db.Mocks.Users.GetByUsername = func(_ context.Context, username string) (*types.User, error) {
+				return &types.User{ID: 1, Username: "alice", SiteAdmin: true}, nil
+			}
+			defer func() { db.Mocks.Users.GetByUsername = nil }()
+
+			resp := doRequest("POST", "http://example.com/.auth/ldap/sign-in", `{"username": "alice", "password": "alice-secret"}`)
+			if want := http.StatusOK; resp.StatusCode != want {
+				t.Fatalf("got response code %v, want %v", resp.StatusCode, want)
+			}
+		})
+	})
+}
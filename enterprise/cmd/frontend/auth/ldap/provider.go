@@ -0,0 +1,52 @@
+package ldap
+
+import (
+	"context"
+	"net/url"
+	"path"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/auth/providers"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+const providerType = "ldap"
+
+type provider struct {
+	config schema.LDAPAuthProvider
+}
+
+// ConfigID implements providers.Provider.
+func (p *provider) ConfigID() providers.ConfigID {
+	return providers.ConfigID{
+		Type: providerType,
+		ID:   providerConfigID(&p.config),
+	}
+}
+
+// Config implements providers.Provider.
+func (p *provider) Config() schema.AuthProviders {
+	return schema.AuthProviders{Ldap: &p.config}
+}
+
+// Refresh implements providers.Provider.
+func (p *provider) Refresh(context.Context) error { return nil }
+
+// CachedInfo implements providers.Provider.
+func (p *provider) CachedInfo() *providers.Info {
+	info := providers.Info{
+		ServiceID:   p.config.Url,
+		DisplayName: p.config.DisplayName,
+		AuthenticationURL: (&url.URL{
+			Path:     path.Join(authPrefix, "sign-in"),
+			RawQuery: (url.Values{"pc": []string{providerConfigID(&p.config)}}).Encode(),
+		}).String(),
+	}
+	if info.DisplayName == "" {
+		info.DisplayName = "LDAP"
+	}
+	return &info
+}
+
+func (p *provider) client() *client {
+	return &client{config: &p.config}
+}
@@ -0,0 +1,211 @@
+package ldap
+
+import (
+	"net"
+	"strings"
+	"sync"
+	"testing"
+
+	ber "github.com/go-asn1-ber/asn1-ber"
+	"github.com/go-ldap/ldap/v3"
+)
+
+// testEntry is an entry of the directory served by testServer.
+type testEntry struct {
+	dn       string
+	password string
+	attrs    map[string][]string
+}
+
+// testServer is a minimal in-process LDAP server for tests. It supports simple binds, searches
+// with the and, or, not, equality and presence filters, and unbinds.
+type testServer struct {
+	t       *testing.T
+	ln      net.Listener
+	entries []testEntry
+
+	mu    sync.Mutex
+	binds []string // DNs of successful binds, "" for anonymous binds
+}
+
+func newTestServer(t *testing.T, entries ...testEntry) *testServer {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := &testServer{t: t, ln: ln, entries: entries}
+	go s.serve()
+	return s
+}
+
+// URL returns the ldap:// URL of the server.
+func (s *testServer) URL() string { return "ldap://" + s.ln.Addr().String() }
+
+func (s *testServer) Close() { _ = s.ln.Close() }
+
+func (s *testServer) Binds() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.binds...)
+}
+
+func (s *testServer) serve() {
+	for {
+		conn, err := s.ln.Accept()
+		if err != nil {
+			return
+		}
+		go s.handle(conn)
+	}
+}
+
+func (s *testServer) handle(conn net.Conn) {
+	defer conn.Close()
+	for {
+		packet, err := ber.ReadPacket(conn)
+		if err != nil {
+			return
+		}
+		if len(packet.Children) < 2 {
+			return
+		}
+		messageID := packet.Children[0].Value.(int64)
+		req := packet.Children[1]
+
+		var responses []*ber.Packet
+		switch req.Tag {
+		case ldap.ApplicationBindRequest:
+			responses = append(responses, s.bind(req))
+		case ldap.ApplicationSearchRequest:
+			responses = append(responses, s.search(req)...)
+		case ldap.ApplicationUnbindRequest:
+			return
+		default:
+			s.t.Errorf("testServer: unsupported LDAP operation %d", req.Tag)
+			return
+		}
+
+		for _, resp := range responses {
+			envelope := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "LDAP Response")
+			envelope.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagInteger, messageID, "MessageID"))
+			envelope.AppendChild(resp)
+			if _, err := conn.Write(envelope.Bytes()); err != nil {
+				return
+			}
+		}
+	}
+}
+
+func (s *testServer) bind(req *ber.Packet) *ber.Packet {
+	dn := req.Children[1].Value.(string)
+	password := req.Children[2].Data.String()
+
+	code := uint16(ldap.LDAPResultInvalidCredentials)
+	if dn == "" && password == "" {
+		code = ldap.LDAPResultSuccess
+	} else if e := s.entry(dn); e != nil && e.password != "" && e.password == password {
+		code = ldap.LDAPResultSuccess
+	}
+
+	if code == ldap.LDAPResultSuccess {
+		s.mu.Lock()
+		s.binds = append(s.binds, dn)
+		s.mu.Unlock()
+	}
+	return ldapResult(ldap.ApplicationBindResponse, code)
+}
+
+func (s *testServer) search(req *ber.Packet) []*ber.Packet {
+	baseDN := strings.ToLower(req.Children[0].Value.(string))
+	sizeLimit := int(req.Children[3].Value.(int64))
+	filter := req.Children[6]
+
+	var responses []*ber.Packet
+	for _, e := range s.entries {
+		dn := strings.ToLower(e.dn)
+		if dn != baseDN && !strings.HasSuffix(dn, ","+baseDN) {
+			continue
+		}
+		if !matchFilter(filter, e) {
+			continue
+		}
+		if sizeLimit > 0 && len(responses) == sizeLimit {
+			return append(responses, ldapResult(ldap.ApplicationSearchResultDone, ldap.LDAPResultSizeLimitExceeded))
+		}
+
+		entry := ber.Encode(ber.ClassApplication, ber.TypeConstructed, ldap.ApplicationSearchResultEntry, nil, "Search Result Entry")
+		entry.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, e.dn, "Object Name"))
+		attrs := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "Attributes")
+		for name, values := range e.attrs {
+			attr := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "Attribute")
+			attr.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, name, "Type"))
+			vals := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSet, nil, "Values")
+			for _, v := range values {
+				vals.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, v, "Value"))
+			}
+			attr.AppendChild(vals)
+			attrs.AppendChild(attr)
+		}
+		entry.AppendChild(attrs)
+		responses = append(responses, entry)
+	}
+	return append(responses, ldapResult(ldap.ApplicationSearchResultDone, ldap.LDAPResultSuccess))
+}
+
+func (s *testServer) entry(dn string) *testEntry {
+	for i := range s.entries {
+		if strings.EqualFold(s.entries[i].dn, dn) {
+			return &s.entries[i]
+		}
+	}
+	return nil
+}
+
+func matchFilter(f *ber.Packet, e testEntry) bool {
+	switch f.Tag {
+	case ldap.FilterAnd:
+		for _, c := range f.Children {
+			if !matchFilter(c, e) {
+				return false
+			}
+		}
+		return true
+	case ldap.FilterOr:
+		for _, c := range f.Children {
+			if matchFilter(c, e) {
+				return true
+			}
+		}
+		return false
+	case ldap.FilterNot:
+		return !matchFilter(f.Children[0], e)
+	case ldap.FilterEqualityMatch:
+		name, value := f.Children[0].Value.(string), f.Children[1].Value.(string)
+		for _, v := range attrValues(e, name) {
+			if strings.EqualFold(v, value) {
+				return true
+			}
+		}
+		return false
+	case ldap.FilterPresent:
+		return len(attrValues(e, f.Data.String())) > 0
+	}
+	return false
+}
+
+func attrValues(e testEntry, name string) []string {
+	for n, values := range e.attrs {
+		if strings.EqualFold(n, name) {
+			return values
+		}
+	}
+	return nil
+}
+
+func ldapResult(tag ber.Tag, code uint16) *ber.Packet {
+	p := ber.Encode(ber.ClassApplication, ber.TypeConstructed, tag, nil, "Result")
+	p.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagEnumerated, int64(code), "Result Code"))
+	p.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, "", "Matched DN"))
+	p.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, "", "Diagnostic Message"))
+	return p
+}
@@ -0,0 +1,87 @@
+package ldap
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/auth"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/db"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+)
+
+// getOrCreateUser gets or creates a user account based on the LDAP entry of the user. It returns
+// the authenticated actor if successful; otherwise it returns an friendly error message
+// (safeErrMsg) that is safe to display to users, and a non-nil err with lower-level error details.
+func getOrCreateUser(ctx context.Context, p *provider, u *ldapUser) (_ *actor.Actor, safeErrMsg string, err error) {
+	var data extsvc.AccountData
+	data.SetAccountData(u)
+
+	username, err := auth.NormalizeUsername(u.Username)
+	if err != nil {
+		return nil, fmt.Sprintf("Error normalizing the username %q. See https://docs.sourcegraph.com/admin/auth/#username-normalization.", u.Username), err
+	}
+
+	spec := extsvc.AccountSpec{
+		ServiceType: providerType,
+		ServiceID:   p.config.Url,
+		AccountID:   u.DN,
+	}
+
+	// 🚨 SECURITY: Linking by username is opt-in, and never links to accounts that could have
+	// been created by someone other than the LDAP user who signs in.
+	linkByUsername := p.config.LinkExistingUsersByUsername
+	if linkByUsername {
+		if safeErrMsg, err := checkLinkableByUsername(ctx, spec, username); err != nil {
+			return nil, safeErrMsg, err
+		}
+	}
+
+	allowSignup := p.config.AllowSignup == nil || *p.config.AllowSignup
+	userID, safeErrMsg, err := auth.GetAndSaveUser(ctx, auth.GetAndSaveUserOp{
+		UserProps: db.NewUser{
+			Username:        username,
+			Email:           u.Email,
+			EmailIsVerified: u.Email != "", // Emails of the LDAP directory are managed by its admins
+			DisplayName:     u.DisplayName,
+		},
+		ExternalAccount:     spec,
+		ExternalAccountData: data,
+		CreateIfNotExist:    allowSignup,
+		LookUpByUsername:    linkByUsername,
+	})
+	if err != nil {
+		return nil, safeErrMsg, err
+	}
+	return actor.FromUser(userID), "", nil
+}
+
+// checkLinkableByUsername returns an error if the LDAP account isn't linked to a Sourcegraph user
+// yet and the existing user with the username must not be linked to it: site admins and users
+// with a builtin password may not be the same person as the LDAP user.
+func checkLinkableByUsername(ctx context.Context, spec extsvc.AccountSpec, username string) (safeErrMsg string, err error) {
+	accts, err := db.ExternalAccounts.List(ctx, db.ExternalAccountsListOptions{
+		ServiceType: spec.ServiceType,
+		ServiceID:   spec.ServiceID,
+		AccountID:   spec.AccountID,
+	})
+	if err != nil {
+		return "Unexpected error looking up the Sourcegraph user account associated with the external account. Ask a site admin for help.", err
+	}
+	if len(accts) > 0 {
+		return "", nil
+	}
+
+	user, err := db.Users.GetByUsername(ctx, username)
+	if errcode.IsNotFound(err) {
+		return "", nil
+	} else if err != nil {
+		return "Unexpected error looking up the Sourcegraph user by username. Ask a site admin for help.", err
+	}
+	if user.SiteAdmin || user.BuiltinAuth {
+		return fmt.Sprintf("The Sourcegraph user with username %q can't be linked to your LDAP account. Ask a site admin for help.", username),
+			fmt.Errorf("refusing to link LDAP account %q to user %d by username: user is a site admin or has a builtin password", spec.AccountID, user.ID)
+	}
+	return "", nil
+}
@@ -43,8 +43,8 @@ func Init(d dbutil.DB, clock func() time.Time) {
 			return nil
 		}
 
-		// We currently support three types of authz providers: GitHub, GitLab and Bitbucket Server.
-		authzTypes := make(map[string]struct{}, 3)
+		// We currently support four types of authz providers: GitHub, GitLab, Bitbucket Server and Bitbucket Cloud.
+		authzTypes := make(map[string]struct{}, 4)
 		for _, p := range providers {
 			authzTypes[p.ServiceType()] = struct{}{}
 		}
@@ -58,6 +58,8 @@ func Init(d dbutil.DB, clock func() time.Time) {
 				authzNames = append(authzNames, "GitLab")
 			case extsvc.TypeBitbucketServer:
 				authzNames = append(authzNames, "Bitbucket Server")
+			case extsvc.TypeBitbucketCloud:
+				authzNames = append(authzNames, "Bitbucket Cloud")
 			default:
 				authzNames = append(authzNames, t)
 			}
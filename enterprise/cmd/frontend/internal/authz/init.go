@@ -2,12 +2,15 @@ package authz
 
 import (
 	"context"
+	"database/sql"
 	"time"
 
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/enterprise"
+	"github.com/sourcegraph/sourcegraph/cmd/repo-updater/repos"
 	eauthz "github.com/sourcegraph/sourcegraph/enterprise/cmd/frontend/authz"
 	"github.com/sourcegraph/sourcegraph/enterprise/cmd/frontend/internal/authz/resolvers"
 	eiauthz "github.com/sourcegraph/sourcegraph/enterprise/internal/authz"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/authz/webhooks"
 	"github.com/sourcegraph/sourcegraph/internal/authz"
 	"github.com/sourcegraph/sourcegraph/internal/conf"
 	"github.com/sourcegraph/sourcegraph/internal/db"
@@ -27,6 +30,14 @@ func Init(ctx context.Context, enterpriseServices *enterprise.Services) error {
 	}()
 
 	enterpriseServices.AuthzResolver = resolvers.NewResolver(dbconn.Global, msResolutionClock)
+	enterpriseServices.PermissionsImportHandler = resolvers.NewPermissionsImportHandler(dbconn.Global, msResolutionClock)
+
+	// Schedule permissions syncs for webhook events that change permissions on code hosts
+	// before passing them on to the campaigns webhook handlers.
+	repositories := repos.NewDBStore(dbconn.Global, sql.TxOptions{})
+	enterpriseServices.GitHubWebhook = webhooks.NewGitHubWebhook(repositories, enterpriseServices.GitHubWebhook)
+	enterpriseServices.GitLabWebhook = webhooks.NewGitLabWebhook(repositories, enterpriseServices.GitLabWebhook)
+	enterpriseServices.BitbucketServerWebhook = webhooks.NewBitbucketServerWebhook(repositories, enterpriseServices.BitbucketServerWebhook)
 
 	return nil
 }
@@ -0,0 +1,377 @@
+package resolvers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/globals"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/db"
+	"github.com/sourcegraph/sourcegraph/internal/repoupdater/protocol"
+)
+
+// syncPermissionsNowTimeout is the maximum time to wait for the permissions of a user and a
+// repository to be synced from code hosts.
+const syncPermissionsNowTimeout = 2 * time.Minute
+
+func (r *Resolver) ExplainRepositoryPermission(ctx context.Context, args *graphqlbackend.UserRepositoryArgs) (graphqlbackend.RepositoryPermissionExplanationResolver, error) {
+	// 🚨 SECURITY: Only site admins can query repository permissions.
+	if err := backend.CheckCurrentUserIsSiteAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	user, repo, err := userAndRepo(ctx, args)
+	if err != nil {
+		return nil, err
+	}
+	return r.explainRepositoryPermission(ctx, user, repo)
+}
+
+func (r *Resolver) SyncRepositoryPermissionsNow(ctx context.Context, args *graphqlbackend.UserRepositoryArgs) (graphqlbackend.RepositoryPermissionExplanationResolver, error) {
+	// 🚨 SECURITY: Only site admins can mutate repository permissions.
+	if err := backend.CheckCurrentUserIsSiteAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	user, repo, err := userAndRepo(ctx, args)
+	if err != nil {
+		return nil, err
+	}
+
+	// Syncing would overwrite the explicit permissions with permissions from code hosts.
+	if userMapping := globals.PermissionsUserMapping(); userMapping.Enabled && len(userMapping.ExternalServices) == 0 {
+		return nil, errors.New("permissions are managed via the explicit permissions API when the permissions user mapping (site configuration `permissions.userMapping`) is enabled for all repositories, and cannot be synced from code hosts")
+	}
+
+	syncCtx, cancel := context.WithTimeout(ctx, syncPermissionsNowTimeout)
+	defer cancel()
+	err = r.repoupdaterClient.SyncPerms(syncCtx, protocol.PermsSyncRequest{
+		UserIDs: []int32{user.ID},
+		RepoIDs: []api.RepoID{repo.ID},
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "sync permissions")
+	}
+
+	return r.explainRepositoryPermission(ctx, user, repo)
+}
+
+func userAndRepo(ctx context.Context, args *graphqlbackend.UserRepositoryArgs) (*types.User, *types.Repo, error) {
+	userID, err := graphqlbackend.UnmarshalUserID(args.User)
+	if err != nil {
+		return nil, nil, err
+	}
+	user, err := db.Users.GetByID(ctx, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	repoID, err := graphqlbackend.UnmarshalRepositoryID(args.Repository)
+	if err != nil {
+		return nil, nil, err
+	}
+	repo, err := db.Repos.Get(ctx, repoID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return user, repo, nil
+}
+
+// explainRepositoryPermission evaluates the rules that authzFilter (internal/db/repos_perm.go)
+// enforces for the user and the repository, in the same order. Unlike authzFilter, it never
+// fetches external accounts from code hosts, so that it has no side effects.
+func (r *Resolver) explainRepositoryPermission(ctx context.Context, user *types.User, repo *types.Repo) (*repositoryPermissionExplanationResolver, error) {
+	e := &repositoryPermissionExplanationResolver{
+		user: user,
+		repo: repo,
+	}
+
+	up := &authz.UserPermissions{
+		UserID: user.ID,
+		Perm:   authz.Read, // Note: We currently only support read for repository permissions.
+		Type:   authz.PermRepos,
+	}
+	err := r.store.LoadUserPermissions(ctx, up)
+	if err != nil && err != authz.ErrPermsNotFound {
+		return nil, errors.Wrap(err, "load user permissions")
+	} else if err == nil {
+		e.userPerms = &permissionsInfoResolver{
+			perms:     up.Perm,
+			syncedAt:  up.SyncedAt,
+			updatedAt: up.UpdatedAt,
+		}
+		e.userPermsIncludeRepo = up.IDs != nil && up.IDs.Contains(uint32(repo.ID))
+	}
+
+	orgRepoIDs, err := r.store.LoadUserOrgRepoIDs(ctx, user.ID, authz.Read)
+	if err != nil {
+		return nil, errors.Wrap(err, "load repositories granted to organizations")
+	}
+	e.orgPermsIncludeRepo = orgRepoIDs.Contains(uint32(repo.ID))
+
+	rp := &authz.RepoPermissions{
+		RepoID: int32(repo.ID),
+		Perm:   authz.Read, // Note: We currently only support read for repository permissions.
+	}
+	err = r.store.LoadRepoPermissions(ctx, rp)
+	if err != nil && err != authz.ErrPermsNotFound {
+		return nil, errors.Wrap(err, "load repository permissions")
+	} else if err == nil {
+		e.repoPerms = &permissionsInfoResolver{
+			perms:     rp.Perm,
+			syncedAt:  rp.SyncedAt,
+			updatedAt: rp.UpdatedAt,
+		}
+		e.repoPermsIncludeUser = rp.UserIDs != nil && rp.UserIDs.Contains(uint32(user.ID))
+	}
+
+	userMapping := globals.PermissionsUserMapping()
+	if userMapping.Enabled {
+		e.pending, err = r.pendingPermissionsBindIDs(ctx, user, repo, userMapping.BindID)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(userMapping.ExternalServices) == 0 {
+			e.explicit = true
+		} else {
+			externalServiceIDs := make([]int64, len(userMapping.ExternalServices))
+			for i := range userMapping.ExternalServices {
+				externalServiceIDs[i] = int64(userMapping.ExternalServices[i])
+			}
+			ids, err := db.Repos.ListIDsByExternalServices(ctx, externalServiceIDs, repo.ID)
+			if err != nil {
+				return nil, errors.Wrap(err, "list repositories of external services")
+			}
+			e.explicit = len(ids) > 0
+		}
+	}
+
+	allowByDefault, providers := authz.GetProviders()
+	var provider authz.Provider
+	for _, p := range providers {
+		if p.ServiceID() == repo.ExternalRepo.ServiceID {
+			provider = p
+			break
+		}
+	}
+	if provider != nil {
+		e.provider = &authzProviderExplanationResolver{
+			serviceType: provider.ServiceType(),
+			serviceID:   provider.ServiceID(),
+		}
+
+		accts, err := r.store.ListExternalAccounts(ctx, user.ID)
+		if err != nil {
+			return nil, errors.Wrap(err, "list external accounts")
+		}
+		for _, acct := range accts {
+			if acct.ServiceType == provider.ServiceType() && acct.ServiceID == provider.ServiceID() {
+				accountID := acct.AccountID
+				e.provider.accountID = &accountID
+				break
+			}
+		}
+	}
+
+	e.unrestricted = !e.explicit && (!repo.Private || (allowByDefault && provider == nil))
+
+	switch {
+	case user.SiteAdmin:
+		e.conclude(true, "The user is a site admin, site admins can access all repositories.")
+
+	case userMapping.Enabled && len(userMapping.ExternalServices) == 0 && len(providers) > 0:
+		e.conclude(false, "The permissions user mapping (site configuration `permissions.userMapping`) cannot be enabled for all repositories when authorization providers are configured, no repositories are accessible until the conflict is resolved.")
+
+	case e.explicit:
+		if len(userMapping.ExternalServices) == 0 {
+			e.reason("The permissions user mapping (site configuration `permissions.userMapping`) is enabled for all repositories, access is only granted by explicit permissions.")
+		} else {
+			e.reason("The repository belongs to an external service selected by the permissions user mapping (site configuration `permissions.userMapping.externalServices`), access is only granted by explicit permissions regardless of whether the repository is private.")
+		}
+		e.concludeByUserPermissions()
+
+	case allowByDefault && len(providers) == 0:
+		e.conclude(true, "No authorization providers are configured and `authzAllowByDefault` is true, all repositories are accessible to everyone.")
+
+	case !repo.Private:
+		e.conclude(true, "The repository is public.")
+
+	case provider == nil && allowByDefault:
+		e.conclude(true, fmt.Sprintf("No authorization provider is configured for the code host %q of the repository and `authzAllowByDefault` is true.", repo.ExternalRepo.ServiceID))
+
+	case len(providers) == 0:
+		e.conclude(false, "The repository is private, no authorization providers are configured and `authzAllowByDefault` is false, private repositories are only accessible to site admins.")
+
+	case provider == nil:
+		e.reason(fmt.Sprintf("The repository is private, no authorization provider is configured for the code host %q of the repository and `authzAllowByDefault` is false.", repo.ExternalRepo.ServiceID))
+		e.concludeByUserPermissions()
+
+	default:
+		e.reason(fmt.Sprintf("The repository is private, access is determined by the permissions synced from the %s authorization provider for %q.", provider.ServiceType(), provider.ServiceID()))
+		if e.provider.accountID != nil {
+			e.reason(fmt.Sprintf("The user has the external account %q on the code host.", *e.provider.accountID))
+		} else {
+			e.reason("The user has no external account on the code host, no permissions can be synced for the user from it. The account is looked up the next time the user accesses repositories.")
+		}
+		e.concludeByUserPermissions()
+	}
+	return e, nil
+}
+
+// pendingPermissionsBindIDs returns the bind IDs of the user that have pending permissions for
+// the repository. Pending permissions of an email address are only granted once it is verified,
+// so unverified email addresses are included.
+func (r *Resolver) pendingPermissionsBindIDs(ctx context.Context, user *types.User, repo *types.Repo, bindIDType string) ([]string, error) {
+	var bindIDs []string
+	if bindIDType == "username" {
+		bindIDs = []string{user.Username}
+	} else {
+		emails, err := db.UserEmails.ListByUser(ctx, db.UserEmailsListOptions{UserID: user.ID})
+		if err != nil {
+			return nil, errors.Wrap(err, "list user emails")
+		}
+		for _, email := range emails {
+			bindIDs = append(bindIDs, email.Email)
+		}
+	}
+
+	var pending []string
+	for _, bindID := range bindIDs {
+		p := &authz.UserPendingPermissions{
+			ServiceType: authz.SourcegraphServiceType,
+			ServiceID:   authz.SourcegraphServiceID,
+			BindID:      bindID,
+			Perm:        authz.Read, // Note: We currently only support read for repository permissions.
+			Type:        authz.PermRepos,
+		}
+		err := r.store.LoadUserPendingPermissions(ctx, p)
+		if err == authz.ErrPermsNotFound {
+			continue
+		} else if err != nil {
+			return nil, errors.Wrap(err, "load user pending permissions")
+		}
+		if p.IDs != nil && p.IDs.Contains(uint32(repo.ID)) {
+			pending = append(pending, bindID)
+		}
+	}
+	return pending, nil
+}
+
+type repositoryPermissionExplanationResolver struct {
+	user *types.User
+	repo *types.Repo
+
+	access       bool
+	reasons      []string
+	unrestricted bool
+	explicit     bool
+	provider     *authzProviderExplanationResolver
+
+	userPerms            *permissionsInfoResolver
+	userPermsIncludeRepo bool
+	orgPermsIncludeRepo  bool
+	repoPerms            *permissionsInfoResolver
+	repoPermsIncludeUser bool
+	pending              []string
+}
+
+func (e *repositoryPermissionExplanationResolver) reason(reason string) {
+	e.reasons = append(e.reasons, reason)
+}
+
+func (e *repositoryPermissionExplanationResolver) conclude(access bool, reason string) {
+	e.access = access
+	e.reason(reason)
+}
+
+// concludeByUserPermissions concludes the access by the stored permissions of the user, which is
+// what authzFilter checks for restricted repositories.
+func (e *repositoryPermissionExplanationResolver) concludeByUserPermissions() {
+	switch {
+	case e.orgPermsIncludeRepo:
+		e.conclude(true, "The user is a member of an organization that is granted access to the repository.")
+	case e.userPerms == nil:
+		e.conclude(false, "The permissions of the user have never been stored.")
+	case e.userPermsIncludeRepo:
+		e.conclude(true, fmt.Sprintf("The permissions of the user (last updated at %s) include the repository.", e.userPerms.updatedAt.Format(time.RFC3339)))
+	default:
+		e.conclude(false, fmt.Sprintf("The permissions of the user (last updated at %s) do not include the repository.", e.userPerms.updatedAt.Format(time.RFC3339)))
+	}
+
+	for _, bindID := range e.pending {
+		e.reason(fmt.Sprintf("The user has pending permissions for the repository via %q that have not been granted yet.", bindID))
+	}
+}
+
+func (e *repositoryPermissionExplanationResolver) User() *graphqlbackend.UserResolver {
+	return graphqlbackend.NewUserResolver(e.user)
+}
+
+func (e *repositoryPermissionExplanationResolver) Repository() *graphqlbackend.RepositoryResolver {
+	return graphqlbackend.NewRepositoryResolver(e.repo)
+}
+
+func (e *repositoryPermissionExplanationResolver) Access() bool { return e.access }
+
+func (e *repositoryPermissionExplanationResolver) Reasons() []string { return e.reasons }
+
+func (e *repositoryPermissionExplanationResolver) Private() bool { return e.repo.Private }
+
+func (e *repositoryPermissionExplanationResolver) Unrestricted() bool { return e.unrestricted }
+
+func (e *repositoryPermissionExplanationResolver) ExplicitPermissions() bool { return e.explicit }
+
+func (e *repositoryPermissionExplanationResolver) AuthzProvider() graphqlbackend.AuthzProviderExplanationResolver {
+	if e.provider == nil {
+		return nil
+	}
+	return e.provider
+}
+
+func (e *repositoryPermissionExplanationResolver) UserPermissions() graphqlbackend.PermissionsInfoResolver {
+	if e.userPerms == nil {
+		return nil
+	}
+	return e.userPerms
+}
+
+func (e *repositoryPermissionExplanationResolver) UserPermissionsIncludeRepository() bool {
+	return e.userPermsIncludeRepo
+}
+
+func (e *repositoryPermissionExplanationResolver) RepositoryPermissions() graphqlbackend.PermissionsInfoResolver {
+	if e.repoPerms == nil {
+		return nil
+	}
+	return e.repoPerms
+}
+
+func (e *repositoryPermissionExplanationResolver) RepositoryPermissionsIncludeUser() bool {
+	return e.repoPermsIncludeUser
+}
+
+func (e *repositoryPermissionExplanationResolver) PendingPermissions() []string {
+	if e.pending == nil {
+		return []string{}
+	}
+	return e.pending
+}
+
+type authzProviderExplanationResolver struct {
+	serviceType string
+	serviceID   string
+	accountID   *string
+}
+
+func (r *authzProviderExplanationResolver) ServiceType() string { return r.serviceType }
+
+func (r *authzProviderExplanationResolver) ServiceID() string { return r.serviceID }
+
+func (r *authzProviderExplanationResolver) AccountID() *string { return r.accountID }
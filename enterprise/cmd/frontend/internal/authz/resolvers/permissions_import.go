@@ -0,0 +1,158 @@
+package resolvers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/inconshreveable/log15"
+	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	edb "github.com/sourcegraph/sourcegraph/enterprise/internal/db"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/auditlog"
+	"github.com/sourcegraph/sourcegraph/internal/db"
+	"github.com/sourcegraph/sourcegraph/internal/db/dbutil"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+)
+
+// maxPermissionsImportSize is the maximum size of a permissions import request body.
+const maxPermissionsImportSize = 32 << 20
+
+// PermissionsImportRequest is the request body of the permissions import endpoint.
+type PermissionsImportRequest struct {
+	Repositories []PermissionsImportRepository `json:"repositories"`
+}
+
+// PermissionsImportRepository is the full set of permissions of a repository to import.
+type PermissionsImportRepository struct {
+	// Name is the name of the repository on Sourcegraph.
+	Name string `json:"name"`
+	// Users are the usernames or verified emails of the users who may view the repository,
+	// depending on the permissions user mapping.
+	Users []string `json:"users"`
+	// Orgs are the names of the organizations whose members may view the repository.
+	Orgs []string `json:"orgs"`
+}
+
+// PermissionsImportResponse is the response body of the permissions import endpoint.
+type PermissionsImportResponse struct {
+	// Updated is the number of repositories whose permissions were set.
+	Updated int `json:"updated"`
+}
+
+// NewPermissionsImportHandler returns an HTTP handler that sets the explicit permissions of many
+// repositories at once. The permissions of each repository in the request are overwritten like
+// the setRepositoryPermissionsForUsers mutation does, and all of them are set in a single
+// transaction.
+func NewPermissionsImportHandler(db dbutil.DB, clock func() time.Time) http.Handler {
+	store := edb.NewPermsStore(db, clock)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// 🚨 SECURITY: Only site admins can mutate repository permissions.
+		if err := backend.CheckCurrentUserIsSiteAdmin(r.Context()); err != nil {
+			status := http.StatusUnauthorized
+			if err == backend.ErrMustBeSiteAdmin {
+				status = http.StatusForbidden
+			}
+			http.Error(w, err.Error(), status)
+			return
+		}
+
+		var req PermissionsImportRequest
+		if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxPermissionsImportSize)).Decode(&req); err != nil {
+			http.Error(w, fmt.Sprintf("invalid request body: %v", err), http.StatusBadRequest)
+			return
+		}
+
+		updated, err := importPermissions(r.Context(), store, &req)
+		if err != nil {
+			if errcode.IsBadRequest(err) {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			log15.Error("permissions import", "error", err)
+			http.Error(w, "failed to import permissions", http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(&PermissionsImportResponse{Updated: updated})
+	})
+}
+
+// importPermissionsError is an error caused by the content of a permissions import request.
+type importPermissionsError struct{ error }
+
+func (importPermissionsError) BadRequest() bool { return true }
+
+// importPermissions validates all repositories and organizations of the request before setting
+// any permissions, so that an invalid request does not leave the permissions partially updated.
+func importPermissions(ctx context.Context, store *edb.PermsStore, req *PermissionsImportRequest) (updated int, err error) {
+	repos := make([]*types.Repo, 0, len(req.Repositories))
+	perms := make([]*explicitRepoPermissions, 0, len(req.Repositories))
+	seen := make(map[api.RepoID]struct{}, len(req.Repositories))
+	orgIDs := make(map[string]int32)
+	for _, rp := range req.Repositories {
+		repo, err := db.Repos.GetByName(ctx, api.RepoName(rp.Name))
+		if err != nil {
+			if errcode.IsNotFound(err) {
+				return 0, importPermissionsError{fmt.Errorf("repository %q not found", rp.Name)}
+			}
+			return 0, errors.Wrapf(err, "get repository %q", rp.Name)
+		}
+		if _, ok := seen[repo.ID]; ok {
+			return 0, importPermissionsError{fmt.Errorf("repository %q is listed more than once", rp.Name)}
+		}
+		seen[repo.ID] = struct{}{}
+
+		p := &explicitRepoPermissions{
+			repoID:  repo.ID,
+			bindIDs: rp.Users,
+		}
+		for _, name := range rp.Orgs {
+			id, ok := orgIDs[name]
+			if !ok {
+				org, err := db.Orgs.GetByName(ctx, name)
+				if err != nil {
+					if errcode.IsNotFound(err) {
+						return 0, importPermissionsError{fmt.Errorf("organization %q not found", name)}
+					}
+					return 0, errors.Wrapf(err, "get organization %q", name)
+				}
+				id = org.ID
+				orgIDs[name] = id
+			}
+			p.orgIDs = append(p.orgIDs, id)
+		}
+
+		repos = append(repos, repo)
+		perms = append(perms, p)
+	}
+
+	if err = checkExplicitPermsRepos(ctx, repos...); err != nil {
+		return 0, importPermissionsError{err}
+	}
+
+	txs, err := store.Transact(ctx)
+	if err != nil {
+		return 0, errors.Wrap(err, "start transaction")
+	}
+	defer func() {
+		if err == nil {
+			for _, p := range perms {
+				auditlog.Log(ctx, p.auditEvent())
+			}
+		}
+	}()
+	defer txs.Done(&err)
+
+	for _, p := range perms {
+		if err = setExplicitRepoPermissions(ctx, txs, p); err != nil {
+			return 0, errors.Wrapf(err, "set permissions of repository %d", p.repoID)
+		}
+	}
+	return len(perms), nil
+}
@@ -0,0 +1,172 @@
+package resolvers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/globals"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	edb "github.com/sourcegraph/sourcegraph/enterprise/internal/db"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/db"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestPermissionsImportHandler(t *testing.T) {
+	globals.SetPermissionsUserMapping(&schema.PermissionsUserMapping{BindID: "username"})
+	defer globals.SetPermissionsUserMapping(&schema.PermissionsUserMapping{BindID: "email"})
+
+	db.Mocks.Repos.GetByName = func(_ context.Context, name api.RepoName) (*types.Repo, error) {
+		switch name {
+		case "github.com/owner/repo1":
+			return &types.Repo{ID: 1, Name: name}, nil
+		case "github.com/owner/repo2":
+			return &types.Repo{ID: 2, Name: name}, nil
+		}
+		return nil, &db.RepoNotFoundErr{Name: name}
+	}
+	db.Mocks.Orgs.GetByName = func(_ context.Context, name string) (*types.Org, error) {
+		if name == "acme" {
+			return &types.Org{ID: 1, Name: name}, nil
+		}
+		return nil, &db.OrgNotFoundError{Message: name}
+	}
+	db.Mocks.Users.GetByUsernames = func(_ context.Context, usernames ...string) ([]*types.User, error) {
+		users := make([]*types.User, 0, len(usernames))
+		for _, username := range usernames {
+			if username == "alice" {
+				users = append(users, &types.User{ID: 1, Username: username})
+			}
+		}
+		return users, nil
+	}
+	defer func() {
+		db.Mocks = db.MockStores{}
+		edb.Mocks.Perms = edb.MockPerms{}
+	}()
+
+	tests := []struct {
+		name       string
+		siteAdmin  bool
+		body       string
+		wantStatus int
+		wantBody   string
+		wantPerms  map[int32][]uint32
+		wantOrgs   map[int32][]int32
+	}{
+		{
+			name:       "non-admin",
+			siteAdmin:  false,
+			body:       `{"repositories": []}`,
+			wantStatus: http.StatusForbidden,
+			wantBody:   "must be site admin\n",
+		},
+		{
+			name:       "invalid body",
+			siteAdmin:  true,
+			body:       `{"repositories": `,
+			wantStatus: http.StatusBadRequest,
+			wantBody:   "invalid request body: unexpected EOF\n",
+		},
+		{
+			name:       "repository not found",
+			siteAdmin:  true,
+			body:       `{"repositories": [{"name": "github.com/owner/repo1"}, {"name": "github.com/owner/404"}]}`,
+			wantStatus: http.StatusBadRequest,
+			wantBody:   "repository \"github.com/owner/404\" not found\n",
+		},
+		{
+			name:       "organization not found",
+			siteAdmin:  true,
+			body:       `{"repositories": [{"name": "github.com/owner/repo1", "orgs": ["404"]}]}`,
+			wantStatus: http.StatusBadRequest,
+			wantBody:   "organization \"404\" not found\n",
+		},
+		{
+			name:       "duplicated repository",
+			siteAdmin:  true,
+			body:       `{"repositories": [{"name": "github.com/owner/repo1"}, {"name": "github.com/owner/repo1"}]}`,
+			wantStatus: http.StatusBadRequest,
+			wantBody:   "repository \"github.com/owner/repo1\" is listed more than once\n",
+		},
+		{
+			name:      "success",
+			siteAdmin: true,
+			body: `{"repositories": [
+				{"name": "github.com/owner/repo1", "users": ["alice"]},
+				{"name": "github.com/owner/repo2", "orgs": ["acme"]}
+			]}`,
+			wantStatus: http.StatusOK,
+			wantBody:   "{\"updated\":2}\n",
+			wantPerms: map[int32][]uint32{
+				1: {1},
+				2: {},
+			},
+			wantOrgs: map[int32][]int32{
+				1: nil,
+				2: {1},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			db.Mocks.Users.GetByCurrentAuthUser = func(context.Context) (*types.User, error) {
+				return &types.User{ID: 1, SiteAdmin: test.siteAdmin}, nil
+			}
+
+			perms := make(map[int32][]uint32)
+			edb.Mocks.Perms.Transact = func(context.Context) (*edb.PermsStore, error) {
+				return &edb.PermsStore{}, nil
+			}
+			edb.Mocks.Perms.SetRepoPermissions = func(_ context.Context, p *authz.RepoPermissions) error {
+				perms[p.RepoID] = p.UserIDs.ToArray()
+				return nil
+			}
+			edb.Mocks.Perms.SetRepoPendingPermissions = func(context.Context, *extsvc.Accounts, *authz.RepoPermissions) error {
+				return nil
+			}
+			orgs := make(map[int32][]int32)
+			edb.Mocks.Perms.SetRepoOrgPermissions = func(_ context.Context, repoID int32, _ authz.Perms, orgIDs []int32) error {
+				orgs[repoID] = orgIDs
+				return nil
+			}
+			var audited int
+			db.Mocks.AuditLog.Insert = func(context.Context, *db.AuditLogEntry) error {
+				audited++
+				return nil
+			}
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest("POST", "/.api/permissions/import", strings.NewReader(test.body))
+			NewPermissionsImportHandler(nil, clock).ServeHTTP(w, r)
+
+			if w.Code != test.wantStatus {
+				t.Fatalf("status: want %d but got %d", test.wantStatus, w.Code)
+			}
+			if diff := cmp.Diff(test.wantBody, w.Body.String()); diff != "" {
+				t.Fatalf("body: %v", diff)
+			}
+			if test.wantPerms == nil {
+				test.wantPerms = map[int32][]uint32{}
+			}
+			if diff := cmp.Diff(test.wantPerms, perms); diff != "" {
+				t.Fatalf("perms: %v", diff)
+			}
+			if test.wantOrgs == nil {
+				test.wantOrgs = map[int32][]int32{}
+			}
+			if diff := cmp.Diff(test.wantOrgs, orgs); diff != "" {
+				t.Fatalf("orgs: %v", diff)
+			}
+			if audited != len(test.wantPerms) {
+				t.Fatalf("audit log entries: want %d but got %d", len(test.wantPerms), audited)
+			}
+		})
+	}
+}
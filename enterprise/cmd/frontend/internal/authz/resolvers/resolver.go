@@ -3,6 +3,7 @@ package resolvers
 import (
 	"context"
 	"fmt"
+	"strconv"
 	"strings"
 	"time"
 
@@ -15,6 +16,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
 	edb "github.com/sourcegraph/sourcegraph/enterprise/internal/db"
 	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/auditlog"
 	"github.com/sourcegraph/sourcegraph/internal/authz"
 	"github.com/sourcegraph/sourcegraph/internal/db"
 	"github.com/sourcegraph/sourcegraph/internal/db/dbutil"
@@ -28,6 +30,7 @@ type Resolver struct {
 	store             *edb.PermsStore
 	repoupdaterClient interface {
 		SchedulePermsSync(ctx context.Context, args protocol.PermsSyncRequest) error
+		SyncPerms(ctx context.Context, args protocol.PermsSyncRequest) error
 	}
 }
 
@@ -49,14 +52,78 @@ func (r *Resolver) SetRepositoryPermissionsForUsers(ctx context.Context, args *g
 		return nil, err
 	}
 	// Make sure the repo ID is valid.
-	if _, err = db.Repos.Get(ctx, repoID); err != nil {
+	repo, err := db.Repos.Get(ctx, repoID)
+	if err != nil {
+		return nil, err
+	}
+	if err = checkExplicitPermsRepos(ctx, repo); err != nil {
+		return nil, err
+	}
+
+	perms := &explicitRepoPermissions{
+		repoID:  repoID,
+		bindIDs: make([]string, 0, len(args.UserPermissions)),
+	}
+	for _, p := range args.UserPermissions {
+		perms.bindIDs = append(perms.bindIDs, p.BindID)
+	}
+	if args.Orgs != nil {
+		for _, id := range *args.Orgs {
+			orgID, err := graphqlbackend.UnmarshalOrgID(id)
+			if err != nil {
+				return nil, err
+			}
+			perms.orgIDs = append(perms.orgIDs, orgID)
+		}
+	}
+
+	txs, err := r.store.Transact(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "start transaction")
+	}
+	defer func() {
+		if err == nil {
+			auditlog.Log(ctx, perms.auditEvent())
+		}
+	}()
+	defer txs.Done(&err)
+
+	if err = setExplicitRepoPermissions(ctx, txs, perms); err != nil {
 		return nil, err
 	}
+	return &graphqlbackend.EmptyResponse{}, nil
+}
+
+// explicitRepoPermissions are the permissions of a repository that are set via the explicit
+// permissions API.
+type explicitRepoPermissions struct {
+	repoID api.RepoID
+	// bindIDs identify users by either their verified emails or usernames, depending on the
+	// permissions user mapping.
+	bindIDs []string
+	// orgIDs are the organizations whose members are granted access.
+	orgIDs []int32
+}
+
+// auditEvent returns the audit log event recording that the permissions were set.
+func (p *explicitRepoPermissions) auditEvent() auditlog.Event {
+	return auditlog.Event{
+		Action:      auditlog.ActionRepositoryPermissionsUpdated,
+		SubjectType: auditlog.SubjectRepository,
+		SubjectID:   strconv.Itoa(int(p.repoID)),
+		After:       map[string]interface{}{"bindIDs": p.bindIDs, "orgIDs": p.orgIDs},
+	}
+}
 
+// setExplicitRepoPermissions performs a full update of the permissions of a repository. Users
+// identified by bind IDs that do not exist yet are granted pending permissions, which will take
+// effect once they sign up. Organizations are stored as such and their members are looked up when
+// permissions are checked.
+func setExplicitRepoPermissions(ctx context.Context, txs *edb.PermsStore, perms *explicitRepoPermissions) error {
 	// Filter out bind IDs that only contains whitespaces.
-	bindIDs := make([]string, 0, len(args.UserPermissions))
-	for _, perms := range args.UserPermissions {
-		bindID := strings.TrimSpace(perms.BindID)
+	bindIDs := make([]string, 0, len(perms.bindIDs))
+	for _, bindID := range perms.bindIDs {
+		bindID = strings.TrimSpace(bindID)
 		if bindID == "" {
 			continue
 		}
@@ -69,7 +136,7 @@ func (r *Resolver) SetRepositoryPermissionsForUsers(ctx context.Context, args *g
 	}
 
 	p := &authz.RepoPermissions{
-		RepoID:  int32(repoID),
+		RepoID:  int32(perms.repoID),
 		Perm:    authz.Read, // Note: We currently only support read for repository permissions.
 		UserIDs: roaring.NewBitmap(),
 	}
@@ -78,7 +145,7 @@ func (r *Resolver) SetRepositoryPermissionsForUsers(ctx context.Context, args *g
 	case "email":
 		emails, err := db.UserEmails.GetVerifiedEmails(ctx, bindIDs...)
 		if err != nil {
-			return nil, err
+			return err
 		}
 
 		for i := range emails {
@@ -89,7 +156,7 @@ func (r *Resolver) SetRepositoryPermissionsForUsers(ctx context.Context, args *g
 	case "username":
 		users, err := db.Users.GetByUsernames(ctx, bindIDs...)
 		if err != nil {
-			return nil, err
+			return err
 		}
 
 		for i := range users {
@@ -98,7 +165,7 @@ func (r *Resolver) SetRepositoryPermissionsForUsers(ctx context.Context, args *g
 		}
 
 	default:
-		return nil, fmt.Errorf("unrecognized user mapping bind ID type %q", cfg.BindID)
+		return fmt.Errorf("unrecognized user mapping bind ID type %q", cfg.BindID)
 	}
 
 	pendingBindIDs := make([]string, 0, len(bindIDSet))
@@ -106,27 +173,156 @@ func (r *Resolver) SetRepositoryPermissionsForUsers(ctx context.Context, args *g
 		pendingBindIDs = append(pendingBindIDs, id)
 	}
 
-	txs, err := r.store.Transact(ctx)
-	if err != nil {
-		return nil, errors.Wrap(err, "start transaction")
-	}
-	defer txs.Done(&err)
-
 	accounts := &extsvc.Accounts{
 		ServiceType: authz.SourcegraphServiceType,
 		ServiceID:   authz.SourcegraphServiceID,
 		AccountIDs:  pendingBindIDs,
 	}
 
-	if err = txs.SetRepoPermissions(ctx, p); err != nil {
-		return nil, errors.Wrap(err, "set repository permissions")
+	if err := txs.SetRepoPermissions(ctx, p); err != nil {
+		return errors.Wrap(err, "set repository permissions")
 	} else if err = txs.SetRepoPendingPermissions(ctx, accounts, p); err != nil {
-		return nil, errors.Wrap(err, "set repository pending permissions")
+		return errors.Wrap(err, "set repository pending permissions")
+	} else if err = txs.SetRepoOrgPermissions(ctx, p.RepoID, p.Perm, perms.orgIDs); err != nil {
+		return errors.Wrap(err, "set repository organization permissions")
+	}
+	return nil
+}
+
+func (r *Resolver) SetSubRepositoryPermissionsForUsers(ctx context.Context, args *graphqlbackend.SubRepoPermsArgs) (*graphqlbackend.EmptyResponse, error) {
+	// 🚨 SECURITY: Only site admins can mutate repository permissions.
+	if err := backend.CheckCurrentUserIsSiteAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	repoID, err := graphqlbackend.UnmarshalRepositoryID(args.Repository)
+	if err != nil {
+		return nil, err
+	}
+	// Make sure the repo ID is valid.
+	repo, err := db.Repos.Get(ctx, repoID)
+	if err != nil {
+		return nil, err
+	}
+	if err = checkExplicitPermsRepos(ctx, repo); err != nil {
+		return nil, err
+	}
+
+	bindIDs := make([]string, 0, len(args.UserPermissions))
+	for _, p := range args.UserPermissions {
+		bindIDs = append(bindIDs, strings.TrimSpace(p.BindID))
+	}
+	userIDs, err := userIDsByBindIDs(ctx, bindIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	ps := make([]*authz.SubRepoPermissions, 0, len(args.UserPermissions))
+	after := make(map[string]interface{}, len(args.UserPermissions))
+	for i, p := range args.UserPermissions {
+		userID, ok := userIDs[bindIDs[i]]
+		if !ok {
+			// Path rules can't be pending like repository permissions, because they
+			// only restrict access of users who can read the repository already.
+			return nil, fmt.Errorf("user with bind ID %q does not exist", bindIDs[i])
+		}
+
+		sp := &authz.SubRepoPermissions{UserID: userID}
+		if p.PathIncludes != nil {
+			sp.PathIncludes = *p.PathIncludes
+		}
+		if p.PathExcludes != nil {
+			sp.PathExcludes = *p.PathExcludes
+		}
+		if err = authz.ValidateSubRepoPatterns(sp.PathIncludes); err != nil {
+			return nil, err
+		}
+		if err = authz.ValidateSubRepoPatterns(sp.PathExcludes); err != nil {
+			return nil, err
+		}
+		ps = append(ps, sp)
+		after[bindIDs[i]] = map[string][]string{"pathIncludes": sp.PathIncludes, "pathExcludes": sp.PathExcludes}
 	}
 
+	if err = r.store.SetRepoSubRepoPermissions(ctx, int32(repoID), ps); err != nil {
+		return nil, errors.Wrap(err, "set repository sub-repo permissions")
+	}
+
+	auditlog.Log(ctx, auditlog.Event{
+		Action:      auditlog.ActionSubRepositoryPermissionsUpdated,
+		SubjectType: auditlog.SubjectRepository,
+		SubjectID:   strconv.Itoa(int(repoID)),
+		After:       after,
+	})
 	return &graphqlbackend.EmptyResponse{}, nil
 }
 
+// userIDsByBindIDs returns the IDs of the existing users identified by the given bind IDs,
+// depending on the permissions user mapping. Bind IDs of users that do not exist are missing
+// from the returned map.
+func userIDsByBindIDs(ctx context.Context, bindIDs []string) (map[string]int32, error) {
+	userIDs := make(map[string]int32, len(bindIDs))
+	cfg := globals.PermissionsUserMapping()
+	switch cfg.BindID {
+	case "email":
+		emails, err := db.UserEmails.GetVerifiedEmails(ctx, bindIDs...)
+		if err != nil {
+			return nil, err
+		}
+		for i := range emails {
+			userIDs[emails[i].Email] = emails[i].UserID
+		}
+
+	case "username":
+		users, err := db.Users.GetByUsernames(ctx, bindIDs...)
+		if err != nil {
+			return nil, err
+		}
+		for i := range users {
+			userIDs[users[i].Username] = users[i].ID
+		}
+
+	default:
+		return nil, fmt.Errorf("unrecognized user mapping bind ID type %q", cfg.BindID)
+	}
+	return userIDs, nil
+}
+
+// checkExplicitPermsRepos returns an error if the permissions user mapping selects external
+// services and any of the repositories does not belong to them, because their permissions would
+// be overwritten by background permissions syncing.
+func checkExplicitPermsRepos(ctx context.Context, repos ...*types.Repo) error {
+	cfg := globals.PermissionsUserMapping()
+	if len(cfg.ExternalServices) == 0 || len(repos) == 0 {
+		return nil
+	}
+
+	externalServiceIDs := make([]int64, len(cfg.ExternalServices))
+	for i := range cfg.ExternalServices {
+		externalServiceIDs[i] = int64(cfg.ExternalServices[i])
+	}
+	repoIDs := make([]api.RepoID, len(repos))
+	for i := range repos {
+		repoIDs[i] = repos[i].ID
+	}
+
+	ids, err := db.Repos.ListIDsByExternalServices(ctx, externalServiceIDs, repoIDs...)
+	if err != nil {
+		return errors.Wrap(err, "list repositories of external services")
+	}
+
+	explicit := make(map[api.RepoID]struct{}, len(ids))
+	for _, id := range ids {
+		explicit[id] = struct{}{}
+	}
+	for _, r := range repos {
+		if _, ok := explicit[r.ID]; !ok {
+			return fmt.Errorf("repository %q does not belong to any external service selected by the permissions user mapping (site configuration `permissions.userMapping.externalServices`)", r.Name)
+		}
+	}
+	return nil
+}
+
 func (r *Resolver) ScheduleRepositoryPermissionsSync(ctx context.Context, args *graphqlbackend.RepositoryIDArgs) (*graphqlbackend.EmptyResponse, error) {
 	// 🚨 SECURITY: Only site admins can query repository permissions.
 	if err := backend.CheckCurrentUserIsSiteAdmin(ctx); err != nil {
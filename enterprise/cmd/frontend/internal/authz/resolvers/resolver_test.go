@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"testing"
@@ -197,7 +198,12 @@ func TestResolver_SetRepositoryPermissionsForUsers(t *testing.T) {
 				}
 				return nil
 			}
+			edb.Mocks.Perms.SetRepoOrgPermissions = func(context.Context, int32, authz.Perms, []int32) error {
+				return nil
+			}
+			db.Mocks.AuditLog.Insert = func(context.Context, *db.AuditLogEntry) error { return nil }
 			defer func() {
+				db.Mocks.AuditLog = db.MockAuditLog{}
 				db.Mocks.UserEmails = db.MockUserEmails{}
 				db.Mocks.Users = db.MockUsers{}
 				db.Mocks.Repos = db.MockRepos{}
@@ -207,6 +213,247 @@ func TestResolver_SetRepositoryPermissionsForUsers(t *testing.T) {
 			gqltesting.RunTests(t, test.gqlTests)
 		})
 	}
+
+	t.Run("set permissions for organization members", func(t *testing.T) {
+		globals.SetPermissionsUserMapping(&schema.PermissionsUserMapping{BindID: "username"})
+
+		db.Mocks.Users.GetByCurrentAuthUser = func(context.Context) (*types.User, error) {
+			return &types.User{SiteAdmin: true}, nil
+		}
+		db.Mocks.Users.GetByUsernames = func(context.Context, ...string) ([]*types.User, error) {
+			return []*types.User{{ID: 1, Username: "alice"}}, nil
+		}
+		db.Mocks.Repos.Get = func(_ context.Context, id api.RepoID) (*types.Repo, error) {
+			return &types.Repo{ID: id}, nil
+		}
+		edb.Mocks.Perms.Transact = func(_ context.Context) (*edb.PermsStore, error) {
+			return &edb.PermsStore{}, nil
+		}
+		edb.Mocks.Perms.SetRepoPermissions = func(_ context.Context, p *authz.RepoPermissions) error {
+			if diff := cmp.Diff([]uint32{1}, p.UserIDs.ToArray()); diff != "" {
+				return fmt.Errorf("p.UserIDs: %v", diff)
+			}
+			return nil
+		}
+		edb.Mocks.Perms.SetRepoPendingPermissions = func(context.Context, *extsvc.Accounts, *authz.RepoPermissions) error {
+			return nil
+		}
+		// Members of the organization are not expanded, they are looked up when permissions are checked.
+		edb.Mocks.Perms.SetRepoOrgPermissions = func(_ context.Context, repoID int32, _ authz.Perms, orgIDs []int32) error {
+			if diff := cmp.Diff([]int32{1}, orgIDs); diff != "" {
+				return fmt.Errorf("orgIDs: %v", diff)
+			}
+			return nil
+		}
+		var auditLogEntries []*db.AuditLogEntry
+		db.Mocks.AuditLog.Insert = func(_ context.Context, e *db.AuditLogEntry) error {
+			auditLogEntries = append(auditLogEntries, e)
+			return nil
+		}
+		defer func() {
+			db.Mocks.AuditLog = db.MockAuditLog{}
+			db.Mocks.Users = db.MockUsers{}
+			db.Mocks.Repos = db.MockRepos{}
+			edb.Mocks.Perms = edb.MockPerms{}
+		}()
+
+		gqltesting.RunTests(t, []*gqltesting.Test{
+			{
+				Schema: mustParseGraphQLSchema(t, nil),
+				Query: `
+				mutation {
+					setRepositoryPermissionsForUsers(
+						repository: "UmVwb3NpdG9yeTox",
+						userPermissions: [{ bindID: "alice" }],
+						orgs: ["T3JnOjE="]) {
+						alwaysNil
+					}
+				}
+			`,
+				ExpectedResult: `
+				{
+					"setRepositoryPermissionsForUsers": {
+						"alwaysNil": null
+					}
+				}
+			`,
+			},
+		})
+
+		if len(auditLogEntries) != 1 {
+			t.Fatalf("got %d audit log entries, want 1", len(auditLogEntries))
+		}
+		if e := auditLogEntries[0]; e.Action != "RepositoryPermissionsUpdated" || e.SubjectID != "1" || string(e.After) != `{"bindIDs":["alice"],"orgIDs":[1]}` {
+			t.Errorf("got audit log entry %+v", e)
+		}
+	})
+
+	t.Run("repository does not belong to selected external services", func(t *testing.T) {
+		globals.SetPermissionsUserMapping(&schema.PermissionsUserMapping{BindID: "username", ExternalServices: []int{1}})
+		defer globals.SetPermissionsUserMapping(&schema.PermissionsUserMapping{BindID: "email"})
+
+		db.Mocks.Users.GetByCurrentAuthUser = func(context.Context) (*types.User, error) {
+			return &types.User{SiteAdmin: true}, nil
+		}
+		db.Mocks.Repos.Get = func(_ context.Context, id api.RepoID) (*types.Repo, error) {
+			return &types.Repo{ID: id, Name: "github.com/owner/repo"}, nil
+		}
+		db.Mocks.Repos.ListIDsByExternalServices = func(context.Context, []int64, ...api.RepoID) ([]api.RepoID, error) {
+			return []api.RepoID{}, nil
+		}
+		defer func() {
+			db.Mocks.Users = db.MockUsers{}
+			db.Mocks.Repos = db.MockRepos{}
+		}()
+
+		_, err := (&Resolver{}).SetRepositoryPermissionsForUsers(context.Background(), &graphqlbackend.RepoPermsArgs{
+			Repository: graphqlbackend.MarshalRepositoryID(1),
+		})
+		want := "repository \"github.com/owner/repo\" does not belong to any external service selected by the permissions user mapping (site configuration `permissions.userMapping.externalServices`)"
+		if err == nil || err.Error() != want {
+			t.Fatalf("err: want %q but got %v", want, err)
+		}
+	})
+}
+
+func TestResolver_SetSubRepositoryPermissionsForUsers(t *testing.T) {
+	t.Run("authenticated as non-admin", func(t *testing.T) {
+		db.Mocks.Users.GetByCurrentAuthUser = func(context.Context) (*types.User, error) {
+			return &types.User{}, nil
+		}
+		t.Cleanup(func() {
+			db.Mocks.Users = db.MockUsers{}
+		})
+
+		ctx := actor.WithActor(context.Background(), &actor.Actor{UID: 1})
+		result, err := (&Resolver{}).SetSubRepositoryPermissionsForUsers(ctx, &graphqlbackend.SubRepoPermsArgs{})
+		if want := backend.ErrMustBeSiteAdmin; err != want {
+			t.Errorf("err: want %q but got %v", want, err)
+		}
+		if result != nil {
+			t.Errorf("result: want nil but got %v", result)
+		}
+	})
+
+	globals.SetPermissionsUserMapping(&schema.PermissionsUserMapping{BindID: "username"})
+	defer globals.SetPermissionsUserMapping(&schema.PermissionsUserMapping{BindID: "email"})
+
+	db.Mocks.Users.GetByCurrentAuthUser = func(context.Context) (*types.User, error) {
+		return &types.User{SiteAdmin: true}, nil
+	}
+	db.Mocks.Users.GetByUsernames = func(context.Context, ...string) ([]*types.User, error) {
+		return []*types.User{{ID: 1, Username: "alice"}, {ID: 2, Username: "bob"}}, nil
+	}
+	db.Mocks.Repos.Get = func(_ context.Context, id api.RepoID) (*types.Repo, error) {
+		return &types.Repo{ID: id}, nil
+	}
+	t.Cleanup(func() {
+		db.Mocks.Users = db.MockUsers{}
+		db.Mocks.Repos = db.MockRepos{}
+	})
+
+	t.Run("set path rules", func(t *testing.T) {
+		var calledSet bool
+		edb.Mocks.Perms.SetRepoSubRepoPermissions = func(_ context.Context, repoID int32, ps []*authz.SubRepoPermissions) error {
+			calledSet = true
+			want := []*authz.SubRepoPermissions{
+				{UserID: 1, PathIncludes: []string{"src/**"}, PathExcludes: []string{"src/secrets"}},
+				{UserID: 2, PathExcludes: []string{"**/*.key"}},
+			}
+			if repoID != 1 {
+				return fmt.Errorf("repoID: want 1 but got %d", repoID)
+			}
+			if diff := cmp.Diff(want, ps); diff != "" {
+				return fmt.Errorf("ps: %v", diff)
+			}
+			return nil
+		}
+		var auditLogEntries []*db.AuditLogEntry
+		db.Mocks.AuditLog.Insert = func(_ context.Context, e *db.AuditLogEntry) error {
+			auditLogEntries = append(auditLogEntries, e)
+			return nil
+		}
+		t.Cleanup(func() {
+			db.Mocks.AuditLog = db.MockAuditLog{}
+			edb.Mocks.Perms = edb.MockPerms{}
+		})
+
+		gqltesting.RunTests(t, []*gqltesting.Test{
+			{
+				Schema: mustParseGraphQLSchema(t, nil),
+				Query: `
+				mutation {
+					setSubRepositoryPermissionsForUsers(
+						repository: "UmVwb3NpdG9yeTox",
+						userPermissions: [
+							{ bindID: "alice", pathIncludes: ["src/**"], pathExcludes: ["src/secrets"] },
+							{ bindID: "bob", pathExcludes: ["**/*.key"] }
+						]) {
+						alwaysNil
+					}
+				}
+			`,
+				ExpectedResult: `
+				{
+					"setSubRepositoryPermissionsForUsers": {
+						"alwaysNil": null
+					}
+				}
+			`,
+			},
+		})
+
+		if !calledSet {
+			t.Fatal("!calledSet")
+		}
+		if len(auditLogEntries) != 1 {
+			t.Fatalf("got %d audit log entries, want 1", len(auditLogEntries))
+		}
+		wantAfter := `{"alice":{"pathExcludes":["src/secrets"],"pathIncludes":["src/**"]},"bob":{"pathExcludes":["**/*.key"],"pathIncludes":null}}`
+		if e := auditLogEntries[0]; e.Action != "SubRepositoryPermissionsUpdated" || e.SubjectID != "1" || string(e.After) != wantAfter {
+			t.Errorf("got audit log entry %+v", e)
+		}
+	})
+
+	type userPermission = struct {
+		BindID       string
+		PathIncludes *[]string
+		PathExcludes *[]string
+	}
+	for _, tc := range []struct {
+		name            string
+		userPermissions []userPermission
+		wantErr         string
+	}{
+		{
+			name:            "user does not exist",
+			userPermissions: []userPermission{{BindID: "carol"}},
+			wantErr:         `user with bind ID "carol" does not exist`,
+		},
+		{
+			name:            "invalid pattern",
+			userPermissions: []userPermission{{BindID: "alice", PathExcludes: &[]string{"src/[a"}}},
+			wantErr:         "invalid path pattern",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			edb.Mocks.Perms.SetRepoSubRepoPermissions = func(context.Context, int32, []*authz.SubRepoPermissions) error {
+				t.Fatal("path rules must not be set")
+				return nil
+			}
+			t.Cleanup(func() {
+				edb.Mocks.Perms = edb.MockPerms{}
+			})
+
+			_, err := (&Resolver{}).SetSubRepositoryPermissionsForUsers(context.Background(), &graphqlbackend.SubRepoPermsArgs{
+				Repository:      graphqlbackend.MarshalRepositoryID(1),
+				UserPermissions: tc.userPermissions,
+			})
+			if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Fatalf("err: want %q but got %v", tc.wantErr, err)
+			}
+		})
+	}
 }
 
 func TestResolver_ScheduleRepositoryPermissionsSync(t *testing.T) {
@@ -299,12 +546,17 @@ func TestResolver_ScheduleUserPermissionsSync(t *testing.T) {
 
 type fakeRepoupdaterClient struct {
 	mockSchedulePermsSync func(ctx context.Context, args protocol.PermsSyncRequest) error
+	mockSyncPerms         func(ctx context.Context, args protocol.PermsSyncRequest) error
 }
 
 func (c *fakeRepoupdaterClient) SchedulePermsSync(ctx context.Context, args protocol.PermsSyncRequest) error {
 	return c.mockSchedulePermsSync(ctx, args)
 }
 
+func (c *fakeRepoupdaterClient) SyncPerms(ctx context.Context, args protocol.PermsSyncRequest) error {
+	return c.mockSyncPerms(ctx, args)
+}
+
 func TestResolver_AuthorizedUserRepositories(t *testing.T) {
 	t.Run("authenticated as non-admin", func(t *testing.T) {
 		db.Mocks.Users.GetByCurrentAuthUser = func(context.Context) (*types.User, error) {
@@ -791,3 +1043,303 @@ func TestResolver_UserPermissionsInfo(t *testing.T) {
 		})
 	}
 }
+
+type fakeProvider struct {
+	authz.Provider
+	serviceType string
+	serviceID   string
+}
+
+func (p *fakeProvider) ServiceType() string { return p.serviceType }
+func (p *fakeProvider) ServiceID() string   { return p.serviceID }
+
+func TestResolver_ExplainRepositoryPermission(t *testing.T) {
+	t.Run("authenticated as non-admin", func(t *testing.T) {
+		db.Mocks.Users.GetByCurrentAuthUser = func(context.Context) (*types.User, error) {
+			return &types.User{}, nil
+		}
+		t.Cleanup(func() {
+			db.Mocks.Users.GetByCurrentAuthUser = nil
+		})
+
+		ctx := actor.WithActor(context.Background(), &actor.Actor{UID: 1})
+		result, err := (&Resolver{}).ExplainRepositoryPermission(ctx, &graphqlbackend.UserRepositoryArgs{
+			User:       graphqlbackend.MarshalUserID(2),
+			Repository: graphqlbackend.MarshalRepositoryID(1),
+		})
+		if want := backend.ErrMustBeSiteAdmin; err != want {
+			t.Errorf("err: want %q but got %v", want, err)
+		}
+		if result != nil {
+			t.Errorf("result: want nil but got %v", result)
+		}
+	})
+
+	provider := &fakeProvider{serviceType: extsvc.TypeGitHub, serviceID: "https://github.com/"}
+	authz.SetProviders(false, []authz.Provider{provider})
+
+	var repo types.Repo
+	db.Mocks.Users.GetByCurrentAuthUser = func(context.Context) (*types.User, error) {
+		return &types.User{SiteAdmin: true}, nil
+	}
+	db.Mocks.Users.GetByID = func(_ context.Context, id int32) (*types.User, error) {
+		return &types.User{ID: id, Username: "alice"}, nil
+	}
+	db.Mocks.Repos.Get = func(context.Context, api.RepoID) (*types.Repo, error) {
+		return &repo, nil
+	}
+	db.Mocks.UserEmails.ListByUser = func(context.Context, db.UserEmailsListOptions) ([]*db.UserEmail, error) {
+		return []*db.UserEmail{{Email: "alice@example.com"}}, nil
+	}
+	edb.Mocks.Perms.LoadRepoPermissions = func(_ context.Context, p *authz.RepoPermissions) error {
+		return authz.ErrPermsNotFound
+	}
+	edb.Mocks.Perms.LoadUserPendingPermissions = func(_ context.Context, p *authz.UserPendingPermissions) error {
+		if p.BindID != "alice@example.com" {
+			return authz.ErrPermsNotFound
+		}
+		p.IDs = roaring.BitmapOf(1)
+		return nil
+	}
+	defer func() {
+		authz.SetProviders(true, nil)
+		db.Mocks.Users = db.MockUsers{}
+		db.Mocks.Repos = db.MockRepos{}
+		db.Mocks.UserEmails = db.MockUserEmails{}
+		edb.Mocks.Perms = edb.MockPerms{}
+	}()
+
+	ctx := actor.WithActor(context.Background(), &actor.Actor{UID: 1})
+	tests := []struct {
+		name           string
+		repo           types.Repo
+		userMapping    *schema.PermissionsUserMapping
+		accounts       []*extsvc.Account
+		userRepoIDs    []uint32
+		orgRepoIDs     []uint32
+		wantAccess     bool
+		wantReasons    []string
+		wantAccountID  *string
+		wantPending    []string
+		wantUnrestrict bool
+	}{
+		{
+			name:           "public repository",
+			repo:           types.Repo{ID: 1, ExternalRepo: api.ExternalRepoSpec{ServiceID: "https://github.com/"}},
+			wantAccess:     true,
+			wantReasons:    []string{"The repository is public."},
+			wantPending:    []string{},
+			wantUnrestrict: true,
+		},
+		{
+			name: "private repository granted by synced permissions",
+			repo: types.Repo{ID: 1, Private: true, ExternalRepo: api.ExternalRepoSpec{ServiceID: "https://github.com/"}},
+			accounts: []*extsvc.Account{{
+				AccountSpec: extsvc.AccountSpec{ServiceType: extsvc.TypeGitHub, ServiceID: "https://github.com/", AccountID: "alice-gh"},
+			}},
+			userRepoIDs: []uint32{1},
+			wantAccess:  true,
+			wantReasons: []string{
+				`The repository is private, access is determined by the permissions synced from the github authorization provider for "https://github.com/".`,
+				`The user has the external account "alice-gh" on the code host.`,
+				fmt.Sprintf("The permissions of the user (last updated at %s) include the repository.", clock().Format(time.RFC3339)),
+			},
+			wantAccountID: strPtr("alice-gh"),
+			wantPending:   []string{},
+		},
+		{
+			name:        "private repository without external account",
+			repo:        types.Repo{ID: 1, Private: true, ExternalRepo: api.ExternalRepoSpec{ServiceID: "https://github.com/"}},
+			userRepoIDs: []uint32{2},
+			wantAccess:  false,
+			wantReasons: []string{
+				`The repository is private, access is determined by the permissions synced from the github authorization provider for "https://github.com/".`,
+				"The user has no external account on the code host, no permissions can be synced for the user from it. The account is looked up the next time the user accesses repositories.",
+				fmt.Sprintf("The permissions of the user (last updated at %s) do not include the repository.", clock().Format(time.RFC3339)),
+			},
+			wantPending: []string{},
+		},
+		{
+			name: "repository of external service selected by permissions user mapping",
+			repo: types.Repo{ID: 1, ExternalRepo: api.ExternalRepoSpec{ServiceID: "https://gitlab.com/"}},
+			userMapping: &schema.PermissionsUserMapping{
+				Enabled:          true,
+				BindID:           "email",
+				ExternalServices: []int{1},
+			},
+			wantAccess: false,
+			wantReasons: []string{
+				"The repository belongs to an external service selected by the permissions user mapping (site configuration `permissions.userMapping.externalServices`), access is only granted by explicit permissions regardless of whether the repository is private.",
+				fmt.Sprintf("The permissions of the user (last updated at %s) do not include the repository.", clock().Format(time.RFC3339)),
+				`The user has pending permissions for the repository via "alice@example.com" that have not been granted yet.`,
+			},
+			wantPending: []string{"alice@example.com"},
+		},
+		{
+			name: "repository granted to organization of the user",
+			repo: types.Repo{ID: 1, ExternalRepo: api.ExternalRepoSpec{ServiceID: "https://gitlab.com/"}},
+			userMapping: &schema.PermissionsUserMapping{
+				Enabled:          true,
+				BindID:           "username",
+				ExternalServices: []int{1},
+			},
+			orgRepoIDs: []uint32{1},
+			wantAccess: true,
+			wantReasons: []string{
+				"The repository belongs to an external service selected by the permissions user mapping (site configuration `permissions.userMapping.externalServices`), access is only granted by explicit permissions regardless of whether the repository is private.",
+				"The user is a member of an organization that is granted access to the repository.",
+			},
+			wantPending: []string{},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			repo = test.repo
+			if test.userMapping != nil {
+				globals.SetPermissionsUserMapping(test.userMapping)
+				defer globals.SetPermissionsUserMapping(&schema.PermissionsUserMapping{BindID: "email"})
+			}
+			db.Mocks.Repos.ListIDsByExternalServices = func(_ context.Context, _ []int64, ids ...api.RepoID) ([]api.RepoID, error) {
+				return ids, nil
+			}
+			edb.Mocks.Perms.ListExternalAccounts = func(context.Context, int32) ([]*extsvc.Account, error) {
+				return test.accounts, nil
+			}
+			edb.Mocks.Perms.LoadUserPermissions = func(_ context.Context, p *authz.UserPermissions) error {
+				p.IDs = roaring.BitmapOf(test.userRepoIDs...)
+				p.UpdatedAt = clock()
+				return nil
+			}
+			edb.Mocks.Perms.LoadUserOrgRepoIDs = func(context.Context, int32, authz.Perms) (*roaring.Bitmap, error) {
+				return roaring.BitmapOf(test.orgRepoIDs...), nil
+			}
+
+			result, err := (&Resolver{}).ExplainRepositoryPermission(ctx, &graphqlbackend.UserRepositoryArgs{
+				User:       graphqlbackend.MarshalUserID(2),
+				Repository: graphqlbackend.MarshalRepositoryID(1),
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if result.Access() != test.wantAccess {
+				t.Errorf("access: want %v but got %v", test.wantAccess, result.Access())
+			}
+			if diff := cmp.Diff(test.wantReasons, result.Reasons()); diff != "" {
+				t.Errorf("reasons mismatch (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(test.wantPending, result.PendingPermissions()); diff != "" {
+				t.Errorf("pending permissions mismatch (-want +got):\n%s", diff)
+			}
+			if result.Unrestricted() != test.wantUnrestrict {
+				t.Errorf("unrestricted: want %v but got %v", test.wantUnrestrict, result.Unrestricted())
+			}
+
+			var gotAccountID *string
+			if p := result.AuthzProvider(); p != nil {
+				gotAccountID = p.AccountID()
+			}
+			if diff := cmp.Diff(test.wantAccountID, gotAccountID); diff != "" {
+				t.Errorf("account ID mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestResolver_SyncRepositoryPermissionsNow(t *testing.T) {
+	t.Run("authenticated as non-admin", func(t *testing.T) {
+		db.Mocks.Users.GetByCurrentAuthUser = func(context.Context) (*types.User, error) {
+			return &types.User{}, nil
+		}
+		t.Cleanup(func() {
+			db.Mocks.Users.GetByCurrentAuthUser = nil
+		})
+
+		ctx := actor.WithActor(context.Background(), &actor.Actor{UID: 1})
+		result, err := (&Resolver{}).SyncRepositoryPermissionsNow(ctx, &graphqlbackend.UserRepositoryArgs{
+			User:       graphqlbackend.MarshalUserID(2),
+			Repository: graphqlbackend.MarshalRepositoryID(1),
+		})
+		if want := backend.ErrMustBeSiteAdmin; err != want {
+			t.Errorf("err: want %q but got %v", want, err)
+		}
+		if result != nil {
+			t.Errorf("result: want nil but got %v", result)
+		}
+	})
+
+	db.Mocks.Users.GetByCurrentAuthUser = func(context.Context) (*types.User, error) {
+		return &types.User{SiteAdmin: true}, nil
+	}
+	db.Mocks.Users.GetByID = func(_ context.Context, id int32) (*types.User, error) {
+		return &types.User{ID: id}, nil
+	}
+	db.Mocks.Repos.Get = func(_ context.Context, id api.RepoID) (*types.Repo, error) {
+		return &types.Repo{ID: id}, nil
+	}
+	edb.Mocks.Perms.LoadUserPermissions = func(context.Context, *authz.UserPermissions) error {
+		return authz.ErrPermsNotFound
+	}
+	edb.Mocks.Perms.LoadRepoPermissions = func(context.Context, *authz.RepoPermissions) error {
+		return authz.ErrPermsNotFound
+	}
+	edb.Mocks.Perms.LoadUserOrgRepoIDs = func(context.Context, int32, authz.Perms) (*roaring.Bitmap, error) {
+		return roaring.NewBitmap(), nil
+	}
+	defer func() {
+		db.Mocks.Users = db.MockUsers{}
+		db.Mocks.Repos = db.MockRepos{}
+		edb.Mocks.Perms = edb.MockPerms{}
+	}()
+
+	ctx := actor.WithActor(context.Background(), &actor.Actor{UID: 1})
+	args := &graphqlbackend.UserRepositoryArgs{
+		User:       graphqlbackend.MarshalUserID(2),
+		Repository: graphqlbackend.MarshalRepositoryID(1),
+	}
+
+	t.Run("explicit permissions for all repositories", func(t *testing.T) {
+		globals.SetPermissionsUserMapping(&schema.PermissionsUserMapping{Enabled: true, BindID: "username"})
+		defer globals.SetPermissionsUserMapping(&schema.PermissionsUserMapping{BindID: "email"})
+
+		r := &Resolver{
+			repoupdaterClient: &fakeRepoupdaterClient{
+				mockSyncPerms: func(context.Context, protocol.PermsSyncRequest) error {
+					t.Fatal("unexpected permissions sync")
+					return nil
+				},
+			},
+		}
+		if _, err := r.SyncRepositoryPermissionsNow(ctx, args); err == nil {
+			t.Fatal("want error but got nil")
+		}
+	})
+
+	t.Run("sync and explain", func(t *testing.T) {
+		var synced bool
+		r := &Resolver{
+			repoupdaterClient: &fakeRepoupdaterClient{
+				mockSyncPerms: func(_ context.Context, args protocol.PermsSyncRequest) error {
+					want := protocol.PermsSyncRequest{UserIDs: []int32{2}, RepoIDs: []api.RepoID{1}}
+					if diff := cmp.Diff(want, args); diff != "" {
+						t.Fatalf("request mismatch (-want +got):\n%s", diff)
+					}
+					synced = true
+					return nil
+				},
+			},
+		}
+		result, err := r.SyncRepositoryPermissionsNow(ctx, args)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !synced {
+			t.Fatal("permissions were not synced")
+		}
+		if !result.Access() {
+			t.Errorf("access: want true but got false, reasons: %v", result.Reasons())
+		}
+	})
+}
+
+func strPtr(s string) *string { return &s }
@@ -58,6 +58,7 @@ func Init(ctx context.Context, enterpriseServices *enterprise.Services) error {
 		store,
 		bundleManagerClient,
 		api,
+		codeintelgitserver.DefaultClient,
 		hunkCache,
 	))
 
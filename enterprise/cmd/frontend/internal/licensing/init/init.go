@@ -64,6 +64,8 @@ func Init(ctx context.Context, enterpriseServices *enterprise.Services) error {
 
 type usersStore struct{}
 
+// Count returns the number of users that count towards the licensed user count. Deactivated users
+// do not.
 func (usersStore) Count(ctx context.Context) (int, error) {
-	return db.Users.Count(ctx, nil)
+	return db.Users.Count(ctx, &db.UsersListOptions{ExcludeDeactivated: true})
 }
@@ -28,11 +28,16 @@ func main() {
 	shared.Main(enterpriseSetupHook)
 }
 
-var initFunctions = map[string]func(ctx context.Context, enterpriseServices *enterprise.Services) error{
-	"authz":     authz.Init,
-	"campaigns": campaigns.Init,
-	"codeintel": codeintel.Init,
-	"licensing": licensing.Init,
+// initFunctions are run in order. authz must run after campaigns, since it wraps the webhook
+// handlers of campaigns.
+var initFunctions = []struct {
+	name string
+	fn   func(ctx context.Context, enterpriseServices *enterprise.Services) error
+}{
+	{"campaigns", campaigns.Init},
+	{"authz", authz.Init},
+	{"codeintel", codeintel.Init},
+	{"licensing", licensing.Init},
 }
 
 func enterpriseSetupHook() enterprise.Services {
@@ -44,9 +49,9 @@ func enterpriseSetupHook() enterprise.Services {
 	ctx := context.Background()
 	enterpriseServices := enterprise.DefaultServices()
 
-	for name, fn := range initFunctions {
-		if err := fn(ctx, &enterpriseServices); err != nil {
-			log.Fatal(fmt.Sprintf("failed to initialize %s: %s", name, err))
+	for _, f := range initFunctions {
+		if err := f.fn(ctx, &enterpriseServices); err != nil {
+			log.Fatal(fmt.Sprintf("failed to initialize %s: %s", f.name, err))
 		}
 	}
 
@@ -7,17 +7,21 @@ import (
 
 	"github.com/inconshreveable/log15"
 	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/gitserver"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/store"
 	"github.com/sourcegraph/sourcegraph/internal/goroutine"
 )
 
 type Janitor struct {
 	store              store.Store
+	gitserverClient    gitserver.Client
 	bundleDir          string
 	desiredPercentFree int
 	maxUploadAge       time.Duration
 	maxUploadPartAge   time.Duration
 	maxDatabasePartAge time.Duration
+	retentionInterval  time.Duration
+	lastRetentionRun   time.Time
 	metrics            JanitorMetrics
 }
 
@@ -25,21 +29,25 @@ var _ goroutine.Handler = &Janitor{}
 
 func New(
 	store store.Store,
+	gitserverClient gitserver.Client,
 	bundleDir string,
 	desiredPercentFree int,
 	janitorInterval time.Duration,
 	maxUploadAge time.Duration,
 	maxUploadPartAge time.Duration,
 	maxDatabasePartAge time.Duration,
+	retentionInterval time.Duration,
 	metrics JanitorMetrics,
 ) goroutine.BackgroundRoutine {
 	return goroutine.NewPeriodicGoroutine(context.Background(), janitorInterval, &Janitor{
 		store:              store,
+		gitserverClient:    gitserverClient,
 		bundleDir:          bundleDir,
 		desiredPercentFree: desiredPercentFree,
 		maxUploadAge:       maxUploadAge,
 		maxUploadPartAge:   maxUploadPartAge,
 		maxDatabasePartAge: maxDatabasePartAge,
+		retentionInterval:  retentionInterval,
 		metrics:            metrics,
 	})
 }
@@ -78,6 +86,10 @@ func (j *Janitor) Handle(ctx context.Context) error {
 		return errors.Wrap(err, "janitor.removeOldUploadingRecords")
 	}
 
+	if err := j.removeExpiredUploads(ctx); err != nil {
+		return errors.Wrap(err, "janitor.removeExpiredUploads")
+	}
+
 	if err := j.freeSpace(ctx); err != nil {
 		return errors.Wrap(err, "janitor.freeSpace")
 	}
@@ -7,6 +7,7 @@ type JanitorMetrics struct {
 	PartFilesRemoved          prometheus.Counter
 	OrphanedFilesRemoved      prometheus.Counter
 	EvictedBundleFilesRemoved prometheus.Counter
+	ExpiredUploadsRemoved     prometheus.Counter
 	UploadRecordsRemoved      prometheus.Counter
 	Errors                    prometheus.Counter
 }
@@ -36,6 +37,12 @@ func NewJanitorMetrics(r prometheus.Registerer) JanitorMetrics {
 	})
 	r.MustRegister(evictedBundleFilesRemoved)
 
+	expiredUploadsRemoved := prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "src_bundle_manager_janitor_expired_uploads_removed_total",
+		Help: "Total number of upload records and bundle files removed (not retained by any retention policy)",
+	})
+	r.MustRegister(expiredUploadsRemoved)
+
 	uploadRecordsRemoved := prometheus.NewCounter(prometheus.CounterOpts{
 		Name: "src_bundle_manager_janitor_upload_records_removed_total",
 		Help: "Total number of processed upload records removed",
@@ -53,6 +60,7 @@ func NewJanitorMetrics(r prometheus.Registerer) JanitorMetrics {
 		PartFilesRemoved:          partFilesRemoved,
 		OrphanedFilesRemoved:      orphanedFilesRemoved,
 		EvictedBundleFilesRemoved: evictedBundleFilesRemoved,
+		ExpiredUploadsRemoved:     expiredUploadsRemoved,
 		UploadRecordsRemoved:      uploadRecordsRemoved,
 		Errors:                    errors,
 	}
@@ -0,0 +1,88 @@
+package janitor
+
+import (
+	"context"
+	"time"
+
+	"github.com/inconshreveable/log15"
+	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/enterprise/cmd/precise-code-intel-bundle-manager/internal/paths"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/retention"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+)
+
+// removeExpiredUploads evaluates the configured retention policies against the commit graph of
+// every repository with completed uploads and removes the uploads (and their bundle files) that
+// are not retained by any policy. When dry-run mode is enabled, expired uploads are only logged.
+// This process runs at most once per retention interval.
+func (j *Janitor) removeExpiredUploads(ctx context.Context) error {
+	now := time.Now()
+	if now.Sub(j.lastRetentionRun) < j.retentionInterval {
+		return nil
+	}
+	j.lastRetentionRun = now
+
+	siteConfig := conf.Get().SiteConfiguration
+	if len(siteConfig.CodeIntelRetentionPolicies) == 0 {
+		return nil
+	}
+
+	policies, err := retention.Policies(siteConfig)
+	if err != nil {
+		return errors.Wrap(err, "retention.Policies")
+	}
+
+	repositoryIDs, err := j.store.RepositoryIDsWithCompletedUploads(ctx)
+	if err != nil {
+		return errors.Wrap(err, "store.RepositoryIDsWithCompletedUploads")
+	}
+
+	for _, repositoryID := range repositoryIDs {
+		retained, err := retention.Evaluate(ctx, j.store, j.gitserverClient, repositoryID, policies, now)
+		if err != nil {
+			// A single repository that cannot be resolved by gitserver should not
+			// block the evaluation of the remaining repositories.
+			j.metrics.Errors.Inc()
+			log15.Error("Failed to evaluate retention policies", "repository_id", repositoryID, "err", err)
+			continue
+		}
+
+		for id, policyNames := range retained {
+			if len(policyNames) > 0 {
+				continue
+			}
+
+			if siteConfig.CodeIntelRetentionDryRun {
+				log15.Info("Upload is not retained by any retention policy (dry run)", "repository_id", repositoryID, "id", id)
+				continue
+			}
+
+			if err := j.removeExpiredUpload(ctx, id); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// removeExpiredUpload removes the upload record with the given identifier, then deletes the
+// associated bundle file.
+func (j *Janitor) removeExpiredUpload(ctx context.Context, id int) error {
+	deleted, err := j.store.DeleteUploadByID(ctx, id)
+	if err != nil {
+		return errors.Wrap(err, "store.DeleteUploadByID")
+	}
+	if !deleted {
+		return nil
+	}
+
+	log15.Debug("Removed upload record not retained by any retention policy", "id", id)
+	j.metrics.ExpiredUploadsRemoved.Inc()
+
+	if path := paths.DBDir(j.bundleDir, int64(id)); j.remove(path) {
+		log15.Debug("Removed expired bundle file", "id", id, "path", path)
+	}
+
+	return nil
+}
@@ -0,0 +1,122 @@
+package janitor
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	gitservermocks "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/gitserver/mocks"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/store"
+	storemocks "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/store/mocks"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/metrics"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestRemoveExpiredUploads(t *testing.T) {
+	bundleDir := testRoot(t)
+	for _, id := range []int{1, 2, 3} {
+		path := filepath.Join(bundleDir, "dbs", fmt.Sprintf("%d", id), "sqlite.db")
+		if err := makeFile(path, time.Now()); err != nil {
+			t.Fatalf("unexpected error creating file %s: %s", path, err)
+		}
+	}
+
+	conf.Mock(&conf.Unified{SiteConfiguration: schema.SiteConfiguration{
+		CodeIntelRetentionPolicies: []*schema.CodeIntelRetentionPolicy{{Name: "tags", Type: "tags"}},
+	}})
+	defer conf.Mock(nil)
+
+	mockStore := storemocks.NewMockStore()
+	mockStore.RepositoryIDsWithCompletedUploadsFunc.SetDefaultReturn([]int{42}, nil)
+	mockStore.EvaluateRetentionPoliciesFunc.SetDefaultReturn(map[int][]string{
+		1: {store.DefaultBranchRetentionReason},
+		2: {},
+		3: {"tags"},
+	}, nil)
+	mockStore.DeleteUploadByIDFunc.SetDefaultReturn(true, nil)
+
+	j := &Janitor{
+		store:             mockStore,
+		gitserverClient:   gitservermocks.NewMockClient(),
+		bundleDir:         bundleDir,
+		retentionInterval: time.Hour,
+		metrics:           NewJanitorMetrics(metrics.TestRegisterer),
+	}
+
+	if err := j.removeExpiredUploads(context.Background()); err != nil {
+		t.Fatalf("unexpected error removing expired uploads: %s", err)
+	}
+
+	if len(mockStore.DeleteUploadByIDFunc.History()) != 1 {
+		t.Fatalf("unexpected number of DeleteUploadByID calls. want=%d have=%d", 1, len(mockStore.DeleteUploadByIDFunc.History()))
+	}
+	if id := mockStore.DeleteUploadByIDFunc.History()[0].Arg1; id != 2 {
+		t.Errorf("unexpected upload deleted. want=%d have=%d", 2, id)
+	}
+
+	names, err := getFilenames(filepath.Join(bundleDir, "dbs"))
+	if err != nil {
+		t.Fatalf("unexpected error listing directory: %s", err)
+	}
+	if diff := cmp.Diff([]string{"1/sqlite.db", "3/sqlite.db"}, names); diff != "" {
+		t.Errorf("unexpected directory contents (-want +got):\n%s", diff)
+	}
+
+	// A second call within the retention interval should be a no-op
+	if err := j.removeExpiredUploads(context.Background()); err != nil {
+		t.Fatalf("unexpected error removing expired uploads: %s", err)
+	}
+	if len(mockStore.EvaluateRetentionPoliciesFunc.History()) != 1 {
+		t.Errorf("expected retention policies to be evaluated once")
+	}
+}
+
+func TestRemoveExpiredUploadsDryRun(t *testing.T) {
+	conf.Mock(&conf.Unified{SiteConfiguration: schema.SiteConfiguration{
+		CodeIntelRetentionPolicies: []*schema.CodeIntelRetentionPolicy{{Name: "tags", Type: "tags"}},
+		CodeIntelRetentionDryRun:   true,
+	}})
+	defer conf.Mock(nil)
+
+	mockStore := storemocks.NewMockStore()
+	mockStore.RepositoryIDsWithCompletedUploadsFunc.SetDefaultReturn([]int{42}, nil)
+	mockStore.EvaluateRetentionPoliciesFunc.SetDefaultReturn(map[int][]string{1: {}}, nil)
+
+	j := &Janitor{
+		store:           mockStore,
+		gitserverClient: gitservermocks.NewMockClient(),
+		bundleDir:       testRoot(t),
+		metrics:         NewJanitorMetrics(metrics.TestRegisterer),
+	}
+
+	if err := j.removeExpiredUploads(context.Background()); err != nil {
+		t.Fatalf("unexpected error removing expired uploads: %s", err)
+	}
+	if len(mockStore.DeleteUploadByIDFunc.History()) != 0 {
+		t.Errorf("expected no uploads to be deleted in dry-run mode")
+	}
+}
+
+func TestRemoveExpiredUploadsNoPolicies(t *testing.T) {
+	conf.Mock(&conf.Unified{})
+	defer conf.Mock(nil)
+
+	mockStore := storemocks.NewMockStore()
+	j := &Janitor{
+		store:           mockStore,
+		gitserverClient: gitservermocks.NewMockClient(),
+		bundleDir:       testRoot(t),
+		metrics:         NewJanitorMetrics(metrics.TestRegisterer),
+	}
+
+	if err := j.removeExpiredUploads(context.Background()); err != nil {
+		t.Fatalf("unexpected error removing expired uploads: %s", err)
+	}
+	if len(mockStore.RepositoryIDsWithCompletedUploadsFunc.History()) != 0 {
+		t.Errorf("expected no repositories to be evaluated without retention policies")
+	}
+}
@@ -15,6 +15,7 @@ var (
 	rawWorkerBudget          = env.Get("PRECISE_CODE_INTEL_WORKER_BUDGET", "0", "The amount of compressed input data (in bytes) a worker can process concurrently. Zero acts as an infinite budget.")
 	rawResetInterval         = env.Get("PRECISE_CODE_INTEL_RESET_INTERVAL", "1m", "How often to reset stalled uploads.")
 	rawCommitUpdaterInterval = env.Get("PRECISE_CODE_INTEL_COMMIT_UPDATER_INTERVAL", "5s", "How often to update commits for dirty repositories.")
+	rawTipCheckerInterval    = env.Get("PRECISE_CODE_INTEL_TIP_CHECKER_INTERVAL", "1m", "How often to check repositories for new commits on the default branch.")
 )
 
 // mustGet returns the non-empty version of the given raw value fatally logs on failure.
@@ -0,0 +1,80 @@
+package commitupdater
+
+import (
+	"context"
+	"time"
+
+	"github.com/inconshreveable/log15"
+	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/gitserver"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/store"
+	"github.com/sourcegraph/sourcegraph/internal/goroutine"
+)
+
+// TipChecker periodically marks repositories as dirty when the tip of their default branch differs
+// from the tip at which the repository's commit graph was last calculated. This catches repository updates (new commits
+// pushed after the last processed upload) so that the visibility graph can be recalculated in
+// the background by the Updater instead of synchronously on the first code intel query for the
+// new commit.
+type TipChecker struct {
+	store           store.Store
+	gitserverClient gitserver.Client
+}
+
+var _ goroutine.Handler = &TipChecker{}
+
+type TipCheckerOptions struct {
+	Interval time.Duration
+}
+
+func NewTipChecker(store store.Store, gitserverClient gitserver.Client, options TipCheckerOptions) goroutine.BackgroundRoutine {
+	return goroutine.NewPeriodicGoroutine(context.Background(), options.Interval, &TipChecker{
+		store:           store,
+		gitserverClient: gitserverClient,
+	})
+}
+
+// Handle checks the tip commit of each repository with LSIF data and marks the repository as
+// dirty if that commit differs from the tip of its last commit graph. A failure for one repository
+// is logged and does not prevent the remaining repositories from being checked.
+func (c *TipChecker) Handle(ctx context.Context) error {
+	repositoryIDs, err := c.store.RepositoryIDsWithCompletedUploads(ctx)
+	if err != nil {
+		return errors.Wrap(err, "store.RepositoryIDsWithCompletedUploads")
+	}
+
+	for _, repositoryID := range repositoryIDs {
+		if err := c.checkRepository(ctx, repositoryID); err != nil {
+			log15.Error("Failed to check repository tip", "repositoryID", repositoryID, "err", err)
+		}
+	}
+
+	return nil
+}
+
+func (c *TipChecker) checkRepository(ctx context.Context, repositoryID int) error {
+	tipCommit, err := c.gitserverClient.Head(ctx, c.store, repositoryID)
+	if err != nil {
+		// The repository may be mid-clone or may have been removed; skip it for now
+		log15.Warn("Failed to resolve tip commit", "repositoryID", repositoryID, "err", err)
+		return nil
+	}
+
+	graphTipCommit, ok, err := c.store.CommitGraphTip(ctx, repositoryID)
+	if err != nil {
+		return errors.Wrap(err, "store.CommitGraphTip")
+	}
+	if ok && graphTipCommit == tipCommit {
+		return nil
+	}
+
+	if err := c.store.MarkRepositoryAsDirty(ctx, repositoryID); err != nil {
+		return errors.Wrap(err, "store.MarkRepositoryAsDirty")
+	}
+
+	return nil
+}
+
+func (c *TipChecker) HandleError(err error) {
+	log15.Error("Failed to check repository tips", "err", err)
+}
@@ -0,0 +1,57 @@
+package commitupdater
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	gitservermocks "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/gitserver/mocks"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/store"
+	storemocks "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/store/mocks"
+)
+
+func TestTipChecker(t *testing.T) {
+	mockStore := storemocks.NewMockStore()
+	mockGitserverClient := gitservermocks.NewMockClient()
+
+	mockStore.RepositoryIDsWithCompletedUploadsFunc.SetDefaultReturn([]int{50, 51, 52, 53, 54}, nil)
+	mockGitserverClient.HeadFunc.SetDefaultHook(func(ctx context.Context, store store.Store, repositoryID int) (string, error) {
+		if repositoryID == 52 {
+			return "", errors.New("repository not cloned")
+		}
+		return "deadbeef", nil
+	})
+	mockStore.CommitGraphTipFunc.SetDefaultHook(func(ctx context.Context, repositoryID int) (string, bool, error) {
+		switch repositoryID {
+		case 50:
+			// Tip unchanged since the last commit graph
+			return "deadbeef", true, nil
+		case 51:
+			// New commits on the default branch
+			return "cafebabe", true, nil
+		case 53:
+			return "", false, errors.New("database unavailable")
+		}
+
+		// Commit graph never calculated
+		return "", false, nil
+	})
+
+	checker := &TipChecker{
+		store:           mockStore,
+		gitserverClient: mockGitserverClient,
+	}
+
+	if err := checker.Handle(context.Background()); err != nil {
+		t.Fatalf("unexpected error checking tips: %s", err)
+	}
+
+	var dirtyRepositoryIDs []int
+	for _, call := range mockStore.MarkRepositoryAsDirtyFunc.History() {
+		dirtyRepositoryIDs = append(dirtyRepositoryIDs, call.Arg1)
+	}
+	if diff := cmp.Diff([]int{51, 54}, dirtyRepositoryIDs); diff != "" {
+		t.Errorf("unexpected dirty repositories (-want +got):\n%s", diff)
+	}
+}
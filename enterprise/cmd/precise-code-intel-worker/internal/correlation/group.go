@@ -25,6 +25,8 @@ type GroupedBundleData struct {
 	References        chan types.MonikerLocations
 	Packages          []types.Package
 	PackageReferences []types.PackageReference
+	MonikerReferences []types.MonikerReference
+	Diagnostics       []types.Diagnostic
 }
 
 const MaxNumResultChunks = 1000
@@ -51,6 +53,8 @@ func groupBundleData(ctx context.Context, state *State, dumpID int) (*GroupedBun
 	if err != nil {
 		return nil, err
 	}
+	monikerReferences := gatherMonikerReferences(state, dumpID)
+	diagnostics := gatherDiagnostics(state, dumpID)
 
 	return &GroupedBundleData{
 		Meta:              meta,
@@ -60,6 +64,8 @@ func groupBundleData(ctx context.Context, state *State, dumpID int) (*GroupedBun
 		References:        referenceRows,
 		Packages:          packages,
 		PackageReferences: packageReferences,
+		MonikerReferences: monikerReferences,
+		Diagnostics:       diagnostics,
 	}, nil
 }
 
@@ -370,6 +376,90 @@ func gatherPackageReferences(state *State, dumpID int) ([]types.PackageReference
 	return packageReferences, nil
 }
 
+// gatherMonikerReferences counts the number of reference locations attached to each imported moniker.
+// These counts, along with the package that provides the moniker, are used to build a precise index
+// of the dumps that reference a particular symbol from another repository.
+func gatherMonikerReferences(state *State, dumpID int) []types.MonikerReference {
+	monikers := datastructures.NewDefaultIDSetMap()
+	for rangeID, r := range state.RangeData {
+		if r.ReferenceResultID != 0 {
+			monikers.SetUnion(r.ReferenceResultID, state.Monikers.Get(rangeID))
+		}
+	}
+
+	uniques := map[string]types.MonikerReference{}
+	for id, documentRanges := range state.ReferenceData {
+		count := 0
+		documentRanges.Each(func(documentID int, rangeIDs *datastructures.IDSet) {
+			if !strings.HasPrefix(state.DocumentData[documentID], "..") {
+				count += rangeIDs.Len()
+			}
+		})
+		if count == 0 {
+			continue
+		}
+
+		monikers.SetEach(id, func(monikerID int) {
+			if !state.ImportedMonikers.Contains(monikerID) {
+				return
+			}
+
+			source := state.MonikerData[monikerID]
+			packageInfo := state.PackageInformationData[source.PackageInformationID]
+
+			key := makeKey(source.Scheme, source.Identifier, packageInfo.Name, packageInfo.Version)
+			uniques[key] = types.MonikerReference{
+				DumpID:     dumpID,
+				Scheme:     source.Scheme,
+				Identifier: source.Identifier,
+				Name:       packageInfo.Name,
+				Version:    packageInfo.Version,
+				Count:      uniques[key].Count + count,
+			}
+		})
+	}
+
+	monikerReferences := make([]types.MonikerReference, 0, len(uniques))
+	for _, v := range uniques {
+		monikerReferences = append(monikerReferences, v)
+	}
+
+	return monikerReferences
+}
+
+// gatherDiagnostics flattens the diagnostics attached to each document of the index so that
+// they can be searched outside of the bundle. Diagnostics of documents outside of the index
+// root are skipped.
+func gatherDiagnostics(state *State, dumpID int) []types.Diagnostic {
+	var diagnostics []types.Diagnostic
+	for documentID, uri := range state.DocumentData {
+		if strings.HasPrefix(uri, "..") {
+			continue
+		}
+
+		state.Diagnostics.SetEach(documentID, func(diagnosticID int) {
+			for _, diagnostic := range state.DiagnosticResults[diagnosticID] {
+				diagnostics = append(diagnostics, types.Diagnostic{
+					DumpID: dumpID,
+					Path:   uri,
+					DiagnosticData: types.DiagnosticData{
+						Severity:       diagnostic.Severity,
+						Code:           diagnostic.Code,
+						Message:        diagnostic.Message,
+						Source:         diagnostic.Source,
+						StartLine:      diagnostic.StartLine,
+						StartCharacter: diagnostic.StartCharacter,
+						EndLine:        diagnostic.EndLine,
+						EndCharacter:   diagnostic.EndCharacter,
+					},
+				})
+			}
+		})
+	}
+
+	return diagnostics
+}
+
 func makeKey(parts ...string) string {
 	return strings.Join(parts, ":")
 }
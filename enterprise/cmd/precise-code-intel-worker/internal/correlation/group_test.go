@@ -595,6 +595,104 @@ func TestGroupBundleData(t *testing.T) {
 	}
 }
 
+func TestGatherMonikerReferences(t *testing.T) {
+	state := &State{
+		DocumentData: map[int]string{
+			1001: "foo.go",
+			1002: "bar.go",
+			1003: "../vendor/baz.go",
+		},
+		RangeData: map[int]lsif.Range{
+			2001: {ReferenceResultID: 3001},
+			2002: {ReferenceResultID: 3002},
+			2003: {ReferenceResultID: 3002},
+			2004: {ReferenceResultID: 3003},
+		},
+		ReferenceData: map[int]*datastructures.DefaultIDSetMap{
+			3001: datastructures.DefaultIDSetMapWith(map[int]*datastructures.IDSet{
+				1001: datastructures.IDSetWith(2001, 2002),
+				1002: datastructures.IDSetWith(2003),
+				1003: datastructures.IDSetWith(2004),
+			}),
+			3002: datastructures.DefaultIDSetMapWith(map[int]*datastructures.IDSet{
+				1002: datastructures.IDSetWith(2002, 2003),
+			}),
+			3003: datastructures.DefaultIDSetMapWith(map[int]*datastructures.IDSet{
+				1003: datastructures.IDSetWith(2004),
+			}),
+		},
+		MonikerData: map[int]lsif.Moniker{
+			4001: {Kind: "import", Scheme: "gomod", Identifier: "pad", PackageInformationID: 5001},
+			4002: {Kind: "import", Scheme: "gomod", Identifier: "trim", PackageInformationID: 5001},
+			4003: {Kind: "export", Scheme: "gomod", Identifier: "local", PackageInformationID: 5002},
+			4004: {Kind: "import", Scheme: "gomod", Identifier: "vendored", PackageInformationID: 5001},
+		},
+		PackageInformationData: map[int]lsif.PackageInformation{
+			5001: {Name: "leftpad", Version: "0.1.0"},
+			5002: {Name: "main", Version: "1.0.0"},
+		},
+		ImportedMonikers: datastructures.IDSetWith(4001, 4002, 4004),
+		ExportedMonikers: datastructures.IDSetWith(4003),
+		Monikers: datastructures.DefaultIDSetMapWith(map[int]*datastructures.IDSet{
+			2001: datastructures.IDSetWith(4001),
+			2002: datastructures.IDSetWith(4002, 4003),
+			2003: datastructures.IDSetWith(4002),
+			2004: datastructures.IDSetWith(4004),
+		}),
+	}
+
+	monikerReferences := gatherMonikerReferences(state, 42)
+	sort.Slice(monikerReferences, func(i, j int) bool {
+		return monikerReferences[i].Identifier < monikerReferences[j].Identifier
+	})
+
+	expectedMonikerReferences := []types.MonikerReference{
+		{DumpID: 42, Scheme: "gomod", Identifier: "pad", Name: "leftpad", Version: "0.1.0", Count: 3},
+		{DumpID: 42, Scheme: "gomod", Identifier: "trim", Name: "leftpad", Version: "0.1.0", Count: 2},
+	}
+	if diff := cmp.Diff(expectedMonikerReferences, monikerReferences); diff != "" {
+		t.Errorf("unexpected moniker references (-want +got):\n%s", diff)
+	}
+}
+
+func TestGatherDiagnostics(t *testing.T) {
+	state := &State{
+		DocumentData: map[int]string{
+			1001: "foo.go",
+			1002: "bar.go",
+			1003: "../vendor/baz.go",
+		},
+		DiagnosticResults: map[int][]lsif.Diagnostic{
+			2001: {
+				{Severity: 1, Code: "SA1019", Message: "deprecated", Source: "staticcheck", StartLine: 1, StartCharacter: 2, EndLine: 3, EndCharacter: 4},
+				{Severity: 2, Code: "S1000", Message: "simplify", Source: "staticcheck", StartLine: 5, StartCharacter: 6, EndLine: 7, EndCharacter: 8},
+			},
+			2002: {
+				{Severity: 1, Code: "E1", Message: "missing", Source: "go", StartLine: 9, StartCharacter: 10, EndLine: 11, EndCharacter: 12},
+			},
+		},
+		Diagnostics: datastructures.DefaultIDSetMapWith(map[int]*datastructures.IDSet{
+			1001: datastructures.IDSetWith(2001),
+			1002: datastructures.IDSetWith(2002),
+			1003: datastructures.IDSetWith(2002),
+		}),
+	}
+
+	diagnostics := gatherDiagnostics(state, 42)
+	sort.Slice(diagnostics, func(i, j int) bool {
+		return diagnostics[i].Message < diagnostics[j].Message
+	})
+
+	expectedDiagnostics := []types.Diagnostic{
+		{DumpID: 42, Path: "foo.go", DiagnosticData: types.DiagnosticData{Severity: 1, Code: "SA1019", Message: "deprecated", Source: "staticcheck", StartLine: 1, StartCharacter: 2, EndLine: 3, EndCharacter: 4}},
+		{DumpID: 42, Path: "bar.go", DiagnosticData: types.DiagnosticData{Severity: 1, Code: "E1", Message: "missing", Source: "go", StartLine: 9, StartCharacter: 10, EndLine: 11, EndCharacter: 12}},
+		{DumpID: 42, Path: "foo.go", DiagnosticData: types.DiagnosticData{Severity: 2, Code: "S1000", Message: "simplify", Source: "staticcheck", StartLine: 5, StartCharacter: 6, EndLine: 7, EndCharacter: 8}},
+	}
+	if diff := cmp.Diff(expectedDiagnostics, diagnostics); diff != "" {
+		t.Errorf("unexpected diagnostics (-want +got):\n%s", diff)
+	}
+}
+
 //
 //
 
@@ -157,7 +157,7 @@ func (h *handler) handle(ctx context.Context, store store.Store, upload store.Up
 		err = tx.Done(err)
 	}()
 
-	if err := h.updateXrepoData(ctx, store, upload, groupedBundleData.Packages, groupedBundleData.PackageReferences); err != nil {
+	if err := h.updateXrepoData(ctx, store, upload, groupedBundleData.Packages, groupedBundleData.PackageReferences, groupedBundleData.MonikerReferences, groupedBundleData.Diagnostics); err != nil {
 		return false, err
 	}
 
@@ -240,7 +240,7 @@ func (h *handler) write(ctx context.Context, dirname string, groupedBundleData *
 }
 
 // TODO(efritz) - refactor/simplify this after last change
-func (h *handler) updateXrepoData(ctx context.Context, store store.Store, upload store.Upload, packages []types.Package, packageReferences []types.PackageReference) (err error) {
+func (h *handler) updateXrepoData(ctx context.Context, store store.Store, upload store.Upload, packages []types.Package, packageReferences []types.PackageReference, monikerReferences []types.MonikerReference, diagnostics []types.Diagnostic) (err error) {
 	ctx, endOperation := h.metrics.UpdateXrepoDatabaseOperation.With(ctx, &err, observation.Args{})
 	defer endOperation(1, observation.Args{})
 
@@ -251,6 +251,14 @@ func (h *handler) updateXrepoData(ctx context.Context, store store.Store, upload
 	if err := store.UpdatePackageReferences(ctx, packageReferences); err != nil {
 		return errors.Wrap(err, "store.UpdatePackageReferences")
 	}
+	if err := store.UpdateMonikerReferences(ctx, monikerReferences); err != nil {
+		return errors.Wrap(err, "store.UpdateMonikerReferences")
+	}
+
+	// Index diagnostics to support searching diagnostics across dumps.
+	if err := store.UpdateDiagnostics(ctx, diagnostics); err != nil {
+		return errors.Wrap(err, "store.UpdateDiagnostics")
+	}
 
 	// Before we mark the upload as complete, we need to delete any existing completed uploads
 	// that have the same repository_id, commit, root, and indexer values. Otherwise the transaction
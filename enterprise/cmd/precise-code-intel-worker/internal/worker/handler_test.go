@@ -100,6 +100,14 @@ func TestHandle(t *testing.T) {
 		t.Errorf("unexpected UpdatePackageReferencesFunc args (-want +got):\n%s", diff)
 	}
 
+	if len(mockStore.UpdateMonikerReferencesFunc.History()) != 1 {
+		t.Errorf("unexpected number of UpdateMonikerReferences calls. want=%d have=%d", 1, len(mockStore.UpdateMonikerReferencesFunc.History()))
+	}
+
+	if len(mockStore.UpdateDiagnosticsFunc.History()) != 1 {
+		t.Errorf("unexpected number of UpdateDiagnostics calls. want=%d have=%d", 1, len(mockStore.UpdateDiagnosticsFunc.History()))
+	}
+
 	if len(mockStore.DeleteOverlappingDumpsFunc.History()) != 1 {
 		t.Errorf("unexpected number of DeleteOverlappingDumps calls. want=%d have=%d", 1, len(mockStore.DeleteOverlappingDumpsFunc.History()))
 	} else if mockStore.DeleteOverlappingDumpsFunc.History()[0].Arg1 != 50 {
@@ -44,6 +44,7 @@ func main() {
 		workerBudget          = mustParseInt64(rawWorkerBudget, "PRECISE_CODE_INTEL_WORKER_BUDGET")
 		resetInterval         = mustParseInterval(rawResetInterval, "PRECISE_CODE_INTEL_RESET_INTERVAL")
 		commitUpdaterInterval = mustParseInterval(rawCommitUpdaterInterval, "PRECISE_CODE_INTEL_COMMIT_UPDATER_INTERVAL")
+		tipCheckerInterval    = mustParseInterval(rawTipCheckerInterval, "PRECISE_CODE_INTEL_TIP_CHECKER_INTERVAL")
 	)
 
 	observationContext := &observation.Context{
@@ -65,6 +66,13 @@ func main() {
 			Interval: commitUpdaterInterval,
 		},
 	)
+	tipChecker := commitupdater.NewTipChecker(
+		store,
+		gitserver.DefaultClient,
+		commitupdater.TipCheckerOptions{
+			Interval: tipCheckerInterval,
+		},
+	)
 	worker := worker.NewWorker(
 		store,
 		bundles.New(bundleManagerURL),
@@ -76,7 +84,7 @@ func main() {
 	)
 
 	go debugserver.Start()
-	goroutine.MonitorBackgroundRoutines(server, uploadResetter, commitUpdater, worker)
+	goroutine.MonitorBackgroundRoutines(server, uploadResetter, commitUpdater, tipChecker, worker)
 }
 
 func mustInitializeStore() store.Store {
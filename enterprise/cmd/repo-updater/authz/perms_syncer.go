@@ -19,6 +19,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/authz"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketcloud"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
 	"github.com/sourcegraph/sourcegraph/internal/ratelimit"
 	"github.com/sourcegraph/sourcegraph/internal/trace"
@@ -136,6 +137,38 @@ func (s *PermsSyncer) scheduleRepos(ctx context.Context, repos ...scheduledRepo)
 	}
 }
 
+// SyncUsers syncs permissions of given users immediately and returns when it is done,
+// instead of waiting for their turns in the queue. Requests of the users that are waiting
+// in the queue are removed once their syncs succeeded because they would be redundant,
+// and are kept otherwise so that they are retried.
+//
+// This method implements the repoupdater.Server.PermsSyncer in the OSS namespace.
+func (s *PermsSyncer) SyncUsers(ctx context.Context, userIDs ...int32) error {
+	for _, userID := range userIDs {
+		if err := s.syncUserPerms(ctx, userID, false); err != nil {
+			return errors.Wrapf(err, "sync permissions of user %d", userID)
+		}
+		s.queue.remove(requestTypeUser, userID, false)
+	}
+	return nil
+}
+
+// SyncRepos syncs permissions of given repositories immediately and returns when it is
+// done, instead of waiting for their turns in the queue. Requests of the repositories that
+// are waiting in the queue are removed once their syncs succeeded because they would be
+// redundant, and are kept otherwise so that they are retried.
+//
+// This method implements the repoupdater.Server.PermsSyncer in the OSS namespace.
+func (s *PermsSyncer) SyncRepos(ctx context.Context, repoIDs ...api.RepoID) error {
+	for _, repoID := range repoIDs {
+		if err := s.syncRepoPerms(ctx, repoID, false); err != nil {
+			return errors.Wrapf(err, "sync permissions of repository %d", repoID)
+		}
+		s.queue.remove(requestTypeRepo, int32(repoID), false)
+	}
+	return nil
+}
+
 // providersByServiceID returns a list of authz.Provider configured in the external services.
 // Keys are ServiceID, e.g. "https://github.com/".
 func (s *PermsSyncer) providersByServiceID() map[string]authz.Provider {
@@ -172,6 +205,8 @@ func (s *PermsSyncer) syncUserPerms(ctx context.Context, userID int32, noPerms b
 	providers := s.providersByServiceID()
 
 	var repoSpecs []api.ExternalRepoSpec
+	// Path rules of repositories, only populated by providers that support sub-repository permissions.
+	var subRepoPerms map[api.ExternalRepoSpec]*authz.SubRepoPermissions
 	for _, acct := range accts {
 		provider := providers[acct.ServiceID]
 		if provider == nil {
@@ -199,6 +234,32 @@ func (s *PermsSyncer) syncUserPerms(ctx context.Context, userID int32, noPerms b
 				ServiceID:   provider.ServiceID(),
 			})
 		}
+
+		subRepoProvider, ok := provider.(authz.SubRepoPermsProvider)
+		if !ok {
+			continue
+		}
+		if subRepoPerms == nil {
+			subRepoPerms = make(map[api.ExternalRepoSpec]*authz.SubRepoPermissions)
+		}
+
+		if err := s.waitForRateLimit(ctx, provider.ServiceID(), 1); err != nil {
+			return errors.Wrap(err, "wait for rate limiter")
+		}
+
+		// 🚨 SECURITY: Path rules only restrict access, so we must not save the repository
+		// permissions without the path rules that come with them.
+		rules, err := subRepoProvider.FetchUserSubRepoPerms(ctx, acct)
+		if err != nil {
+			return errors.Wrap(err, "fetch user sub-repo permissions")
+		}
+		for id, p := range rules {
+			subRepoPerms[api.ExternalRepoSpec{
+				ID:          string(id),
+				ServiceType: provider.ServiceType(),
+				ServiceID:   provider.ServiceID(),
+			}] = p
+		}
 	}
 
 	var rs []*repos.Repo
@@ -224,7 +285,30 @@ func (s *PermsSyncer) syncUserPerms(ctx context.Context, userID int32, noPerms b
 		p.IDs.Add(uint32(rs[i].ID))
 	}
 
-	err = s.permsStore.SetUserPermissions(ctx, p)
+	// Save path rules before repository permissions, so that newly granted
+	// repositories never become readable in full by accident.
+	if subRepoPerms != nil {
+		ps := make([]*authz.SubRepoPermissions, 0, len(subRepoPerms))
+		for i := range rs {
+			if sp, ok := subRepoPerms[rs[i].ExternalRepo]; ok {
+				sp.RepoID = int32(rs[i].ID)
+				ps = append(ps, sp)
+			}
+		}
+
+		err = s.permsStore.SetUserSubRepoPermissions(ctx, userID, ps)
+		if err != nil {
+			return errors.Wrap(err, "set user sub-repo permissions")
+		}
+	}
+
+	// Permissions of repositories that belong to the external services selected by the
+	// permissions user mapping are managed via the explicit permissions API.
+	if externalServiceIDs := explicitPermsExternalServiceIDs(); len(externalServiceIDs) > 0 {
+		err = s.permsStore.SetUserPermissionsKeepingExternalServices(ctx, p, externalServiceIDs)
+	} else {
+		err = s.permsStore.SetUserPermissions(ctx, p)
+	}
 	if err != nil {
 		return errors.Wrap(err, "set user permissions")
 	}
@@ -255,6 +339,20 @@ func (s *PermsSyncer) syncRepoPerms(ctx context.Context, repoID api.RepoID, noPe
 		return nil
 	}
 
+	// Permissions of repositories that belong to the external services selected by the permissions
+	// user mapping are managed via the explicit permissions API and must not be overwritten. We only
+	// mark them as synced to prevent the scheduler from keep scheduling this repository.
+	if externalServiceIDs := explicitPermsExternalServiceIDs(); len(externalServiceIDs) > 0 {
+		for _, si := range repo.Sources {
+			for _, id := range externalServiceIDs {
+				if si.ExternalServiceID() == id {
+					log15.Debug("PermsSyncer.syncRepoPerms.explicitPerms", "repoID", repo.ID)
+					return errors.Wrap(s.permsStore.TouchRepoPermissions(ctx, int32(repoID)), "touch repository permissions")
+				}
+			}
+		}
+	}
+
 	// Loop over repository's sources and see if matching any authz provider's URN.
 	var provider authz.Provider
 	providers := s.providersByURNs()
@@ -289,11 +387,15 @@ func (s *PermsSyncer) syncRepoPerms(ctx context.Context, repoID api.RepoID, noPe
 	})
 
 	// Detect 404 error (i.e. not authorized to call given APIs) that often happens with GitHub.com
-	// when the owner of the token only has READ access. However, we don't want to fail entirely
-	// so the scheduler won't keep trying to fetch permissions of this same repository.
+	// when the owner of the token only has READ access, and with Bitbucket Cloud when the repository
+	// is not in one of the configured workspaces. However, we don't want to fail entirely so the
+	// scheduler won't keep trying to fetch permissions of this same repository.
 	if apiErr, ok := err.(*github.APIError); ok && apiErr.Code == http.StatusNotFound {
 		log15.Debug("PermsSyncer.syncRepoPerms.ignoreUnauthorizedAPIError", "repoID", repo.ID, "err", err)
 		err = nil
+	} else if bitbucketcloud.IsNotFound(err) {
+		log15.Debug("PermsSyncer.syncRepoPerms.ignoreUnauthorizedAPIError", "repoID", repo.ID, "err", err)
+		err = nil
 	}
 
 	if err != nil {
@@ -304,6 +406,24 @@ func (s *PermsSyncer) syncRepoPerms(ctx context.Context, repoID api.RepoID, noPe
 		log15.Debug("PermsSyncer.syncRepoPerms.proceedWithPartialResults", "repoID", repo.ID, "err", err)
 	}
 
+	// Path rules of users, only populated by providers that support sub-repository permissions.
+	var subRepoPerms map[extsvc.AccountID]*authz.SubRepoPermissions
+	if subRepoProvider, ok := provider.(authz.SubRepoPermsProvider); ok {
+		if err := s.waitForRateLimit(ctx, provider.ServiceID(), 1); err != nil {
+			return errors.Wrap(err, "wait for rate limiter")
+		}
+
+		// 🚨 SECURITY: Path rules only restrict access, so we must not save the repository
+		// permissions without the path rules that come with them.
+		subRepoPerms, err = subRepoProvider.FetchRepoSubRepoPerms(ctx, &extsvc.Repository{
+			URI:              repo.URI,
+			ExternalRepoSpec: repo.ExternalRepo,
+		})
+		if err != nil {
+			return errors.Wrap(err, "fetch repository sub-repo permissions")
+		}
+	}
+
 	pendingAccountIDsSet := make(map[string]struct{})
 	var userIDs map[string]int32 // Account ID -> User ID
 	if len(extAccountIDs) > 0 {
@@ -336,16 +456,27 @@ func (s *PermsSyncer) syncRepoPerms(ctx context.Context, repoID api.RepoID, noPe
 		UserIDs: roaring.NewBitmap(),
 	}
 
+	var subRepoPs []*authz.SubRepoPermissions
 	for aid, uid := range userIDs {
 		// Add existing user to permissions
 		p.UserIDs.Add(uint32(uid))
 
 		// Remove existing user from the set of pending users
 		delete(pendingAccountIDsSet, aid)
+
+		if sp, ok := subRepoPerms[extsvc.AccountID(aid)]; ok {
+			sp.UserID = uid
+			subRepoPs = append(subRepoPs, sp)
+		}
 	}
 
 	pendingAccountIDs := make([]string, 0, len(pendingAccountIDsSet))
 	for aid := range pendingAccountIDsSet {
+		// 🚨 SECURITY: Pending permissions are granted without path rules, so accounts
+		// with path rules only get access once their user permissions are synced.
+		if _, ok := subRepoPerms[extsvc.AccountID(aid)]; ok {
+			continue
+		}
 		pendingAccountIDs = append(pendingAccountIDs, aid)
 	}
 
@@ -361,6 +492,12 @@ func (s *PermsSyncer) syncRepoPerms(ctx context.Context, repoID api.RepoID, noPe
 		AccountIDs:  pendingAccountIDs,
 	}
 
+	if subRepoPerms != nil {
+		if err = txs.SetRepoSubRepoPermissions(ctx, int32(repoID), subRepoPs); err != nil {
+			return errors.Wrap(err, "set repository sub-repo permissions")
+		}
+	}
+
 	if err = txs.SetRepoPermissions(ctx, p); err != nil {
 		return errors.Wrap(err, "set repository permissions")
 	} else if err = txs.SetRepoPendingPermissions(ctx, accounts, p); err != nil {
@@ -617,8 +754,9 @@ func (s *PermsSyncer) runSchedule(ctx context.Context) {
 			return
 		}
 
-		// Skip if permissions user mapping is enabled or no authz provider is configured
-		if globals.PermissionsUserMapping().Enabled ||
+		// Skip if permissions user mapping is enabled for all repositories or no authz provider
+		// is configured
+		if userMapping := globals.PermissionsUserMapping(); (userMapping.Enabled && len(userMapping.ExternalServices) == 0) ||
 			len(s.providersByServiceID()) == 0 {
 			continue
 		}
@@ -634,6 +772,22 @@ func (s *PermsSyncer) runSchedule(ctx context.Context) {
 	}
 }
 
+// explicitPermsExternalServiceIDs returns the IDs of the external services selected by the
+// permissions user mapping, whose repository permissions are managed via the explicit permissions
+// API. It returns nil when the permissions user mapping is disabled or enabled for all repositories.
+func explicitPermsExternalServiceIDs() []int64 {
+	userMapping := globals.PermissionsUserMapping()
+	if !userMapping.Enabled || len(userMapping.ExternalServices) == 0 {
+		return nil
+	}
+
+	ids := make([]int64, len(userMapping.ExternalServices))
+	for i := range userMapping.ExternalServices {
+		ids[i] = int64(userMapping.ExternalServices[i])
+	}
+	return ids
+}
+
 // DebugDump returns the state of the permissions syncer for debugging.
 func (s *PermsSyncer) DebugDump() interface{} {
 	type requestInfo struct {
@@ -9,6 +9,7 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"github.com/pkg/errors"
 
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/globals"
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
 	"github.com/sourcegraph/sourcegraph/cmd/repo-updater/repos"
 	edb "github.com/sourcegraph/sourcegraph/enterprise/internal/db"
@@ -16,6 +17,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/authz"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
 	"github.com/sourcegraph/sourcegraph/internal/ratelimit"
+	"github.com/sourcegraph/sourcegraph/schema"
 )
 
 func TestPermsSyncer_ScheduleUsers(t *testing.T) {
@@ -50,6 +52,51 @@ func TestPermsSyncer_ScheduleRepos(t *testing.T) {
 	}
 }
 
+func TestPermsSyncer_SyncUsers(t *testing.T) {
+	var synced []int32
+	edb.Mocks.Perms.ListExternalAccounts = func(_ context.Context, userID int32) ([]*extsvc.Account, error) {
+		synced = append(synced, userID)
+		return nil, nil
+	}
+	edb.Mocks.Perms.SetUserPermissions = func(context.Context, *authz.UserPermissions) error {
+		return nil
+	}
+	defer func() {
+		edb.Mocks.Perms = edb.MockPerms{}
+	}()
+
+	clock := func() time.Time {
+		return time.Now().UTC().Truncate(time.Microsecond)
+	}
+	s := NewPermsSyncer(nil, edb.NewPermsStore(nil, clock), clock, nil)
+	s.ScheduleUsers(context.Background(), 1)
+
+	if err := s.SyncUsers(context.Background(), 1); err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff([]int32{1}, synced); diff != "" {
+		t.Fatalf("synced users mismatch (-want +got):\n%s", diff)
+	}
+	// The scheduled request is redundant after the sync.
+	if len(s.queue.heap) != 0 {
+		t.Fatalf("heap: want empty but got %d requests", len(s.queue.heap))
+	}
+
+	t.Run("failed sync keeps the scheduled request", func(t *testing.T) {
+		edb.Mocks.Perms.SetUserPermissions = func(context.Context, *authz.UserPermissions) error {
+			return errors.New("database unavailable")
+		}
+		s.ScheduleUsers(context.Background(), 2)
+
+		if err := s.SyncUsers(context.Background(), 2); err == nil {
+			t.Fatal("want error but got nil")
+		}
+		if len(s.queue.heap) != 1 || s.queue.heap[0].ID != 2 {
+			t.Fatalf("heap: want the request of user 2 but got %v", s.queue.heap)
+		}
+	})
+}
+
 type mockProvider struct {
 	id          int64
 	serviceType string
@@ -76,6 +123,21 @@ func (p *mockProvider) FetchRepoPerms(ctx context.Context, repo *extsvc.Reposito
 	return p.fetchRepoPerms(ctx, repo)
 }
 
+type mockSubRepoProvider struct {
+	*mockProvider
+
+	fetchUserSubRepoPerms func(context.Context, *extsvc.Account) (map[extsvc.RepoID]*authz.SubRepoPermissions, error)
+	fetchRepoSubRepoPerms func(context.Context, *extsvc.Repository) (map[extsvc.AccountID]*authz.SubRepoPermissions, error)
+}
+
+func (p *mockSubRepoProvider) FetchUserSubRepoPerms(ctx context.Context, acct *extsvc.Account) (map[extsvc.RepoID]*authz.SubRepoPermissions, error) {
+	return p.fetchUserSubRepoPerms(ctx, acct)
+}
+
+func (p *mockSubRepoProvider) FetchRepoSubRepoPerms(ctx context.Context, repo *extsvc.Repository) (map[extsvc.AccountID]*authz.SubRepoPermissions, error) {
+	return p.fetchRepoSubRepoPerms(ctx, repo)
+}
+
 type mockReposStore struct {
 	listRepos func(context.Context, repos.StoreListReposArgs) ([]*repos.Repo, error)
 }
@@ -399,6 +461,283 @@ func TestPermsSyncer_syncRepoPerms(t *testing.T) {
 	}
 }
 
+func TestPermsSyncer_syncUserPerms_subRepoPerms(t *testing.T) {
+	p := &mockSubRepoProvider{
+		mockProvider: &mockProvider{
+			serviceType: extsvc.TypeGitLab,
+			serviceID:   "https://gitlab.com/",
+			fetchUserPerms: func(context.Context, *extsvc.Account) ([]extsvc.RepoID, error) {
+				return []extsvc.RepoID{"1", "2"}, nil
+			},
+		},
+	}
+	authz.SetProviders(false, []authz.Provider{p})
+	defer authz.SetProviders(true, nil)
+
+	edb.Mocks.Perms.ListExternalAccounts = func(context.Context, int32) ([]*extsvc.Account, error) {
+		return []*extsvc.Account{{
+			AccountSpec: extsvc.AccountSpec{
+				ServiceType: p.ServiceType(),
+				ServiceID:   p.ServiceID(),
+			},
+		}}, nil
+	}
+	calledSetUserSubRepoPermissions := false
+	edb.Mocks.Perms.SetUserSubRepoPermissions = func(_ context.Context, userID int32, ps []*authz.SubRepoPermissions) error {
+		calledSetUserSubRepoPermissions = true
+		want := []*authz.SubRepoPermissions{{RepoID: 2, PathExcludes: []string{"secrets/**"}}}
+		if userID != 1 {
+			return fmt.Errorf("userID: want 1 but got %d", userID)
+		} else if diff := cmp.Diff(want, ps); diff != "" {
+			return fmt.Errorf("ps mismatch (-want +got):\n%s", diff)
+		}
+		return nil
+	}
+	edb.Mocks.Perms.SetUserPermissions = func(context.Context, *authz.UserPermissions) error {
+		return nil
+	}
+	defer func() {
+		edb.Mocks.Perms = edb.MockPerms{}
+	}()
+
+	reposStore := &mockReposStore{
+		listRepos: func(context.Context, repos.StoreListReposArgs) ([]*repos.Repo, error) {
+			return []*repos.Repo{
+				{ID: 1, ExternalRepo: api.ExternalRepoSpec{ID: "1", ServiceType: p.ServiceType(), ServiceID: p.ServiceID()}},
+				{ID: 2, ExternalRepo: api.ExternalRepoSpec{ID: "2", ServiceType: p.ServiceType(), ServiceID: p.ServiceID()}},
+			}, nil
+		},
+	}
+	clock := func() time.Time {
+		return time.Now().UTC().Truncate(time.Microsecond)
+	}
+	s := NewPermsSyncer(reposStore, edb.NewPermsStore(nil, clock), clock, nil)
+
+	t.Run("fetch error", func(t *testing.T) {
+		p.fetchUserSubRepoPerms = func(context.Context, *extsvc.Account) (map[extsvc.RepoID]*authz.SubRepoPermissions, error) {
+			return nil, errors.New("random error")
+		}
+
+		err := s.syncUserPerms(context.Background(), 1, true)
+		if err == nil {
+			t.Fatal("expected an error")
+		}
+		if calledSetUserSubRepoPermissions {
+			t.Fatal("calledSetUserSubRepoPermissions")
+		}
+	})
+
+	t.Run("path rules are saved with internal repository IDs", func(t *testing.T) {
+		p.fetchUserSubRepoPerms = func(context.Context, *extsvc.Account) (map[extsvc.RepoID]*authz.SubRepoPermissions, error) {
+			return map[extsvc.RepoID]*authz.SubRepoPermissions{
+				"2": {PathExcludes: []string{"secrets/**"}},
+			}, nil
+		}
+
+		err := s.syncUserPerms(context.Background(), 1, false)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !calledSetUserSubRepoPermissions {
+			t.Fatal("!calledSetUserSubRepoPermissions")
+		}
+	})
+}
+
+func TestPermsSyncer_syncRepoPerms_subRepoPerms(t *testing.T) {
+	p := &mockSubRepoProvider{
+		mockProvider: &mockProvider{
+			serviceType: extsvc.TypeGitLab,
+			serviceID:   "https://gitlab.com/",
+			fetchRepoPerms: func(context.Context, *extsvc.Repository) ([]extsvc.AccountID, error) {
+				return []extsvc.AccountID{"user", "pending_user", "pending_restricted_user"}, nil
+			},
+		},
+		fetchRepoSubRepoPerms: func(context.Context, *extsvc.Repository) (map[extsvc.AccountID]*authz.SubRepoPermissions, error) {
+			return map[extsvc.AccountID]*authz.SubRepoPermissions{
+				"user":                    {PathIncludes: []string{"src/**"}},
+				"pending_restricted_user": {PathIncludes: []string{"src/**"}},
+			}, nil
+		},
+	}
+	authz.SetProviders(false, []authz.Provider{p})
+	defer authz.SetProviders(true, nil)
+
+	edb.Mocks.Perms.Transact = func(context.Context) (*edb.PermsStore, error) {
+		return &edb.PermsStore{}, nil
+	}
+	edb.Mocks.Perms.GetUserIDsByExternalAccounts = func(context.Context, *extsvc.Accounts) (map[string]int32, error) {
+		return map[string]int32{"user": 1}, nil
+	}
+	calledSetRepoSubRepoPermissions := false
+	edb.Mocks.Perms.SetRepoSubRepoPermissions = func(_ context.Context, repoID int32, ps []*authz.SubRepoPermissions) error {
+		calledSetRepoSubRepoPermissions = true
+		want := []*authz.SubRepoPermissions{{UserID: 1, PathIncludes: []string{"src/**"}}}
+		if repoID != 1 {
+			return fmt.Errorf("repoID: want 1 but got %d", repoID)
+		} else if diff := cmp.Diff(want, ps); diff != "" {
+			return fmt.Errorf("ps mismatch (-want +got):\n%s", diff)
+		}
+		return nil
+	}
+	edb.Mocks.Perms.SetRepoPermissions = func(context.Context, *authz.RepoPermissions) error {
+		return nil
+	}
+	edb.Mocks.Perms.SetRepoPendingPermissions = func(_ context.Context, accounts *extsvc.Accounts, _ *authz.RepoPermissions) error {
+		// Pending accounts with path rules must not be granted access to the whole repository.
+		wantAccounts := &extsvc.Accounts{
+			ServiceType: p.ServiceType(),
+			ServiceID:   p.ServiceID(),
+			AccountIDs:  []string{"pending_user"},
+		}
+		if diff := cmp.Diff(wantAccounts, accounts); diff != "" {
+			return fmt.Errorf("accounts mismatch (-want +got):\n%s", diff)
+		}
+		return nil
+	}
+	defer func() {
+		edb.Mocks.Perms = edb.MockPerms{}
+	}()
+
+	reposStore := &mockReposStore{
+		listRepos: func(context.Context, repos.StoreListReposArgs) ([]*repos.Repo, error) {
+			return []*repos.Repo{
+				{
+					ID:      1,
+					Private: true,
+					ExternalRepo: api.ExternalRepoSpec{
+						ServiceID: p.ServiceID(),
+					},
+					Sources: map[string]*repos.SourceInfo{
+						p.URN(): {},
+					},
+				},
+			}, nil
+		},
+	}
+	clock := func() time.Time {
+		return time.Now().UTC().Truncate(time.Microsecond)
+	}
+	s := NewPermsSyncer(reposStore, edb.NewPermsStore(nil, clock), clock, nil)
+
+	err := s.syncRepoPerms(context.Background(), 1, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !calledSetRepoSubRepoPermissions {
+		t.Fatal("!calledSetRepoSubRepoPermissions")
+	}
+}
+
+func TestPermsSyncer_permissionsUserMappingExternalServices(t *testing.T) {
+	before := globals.PermissionsUserMapping()
+	globals.SetPermissionsUserMapping(&schema.PermissionsUserMapping{Enabled: true, ExternalServices: []int{1}})
+	defer globals.SetPermissionsUserMapping(before)
+
+	p := &mockProvider{
+		serviceType: extsvc.TypeGitLab,
+		serviceID:   "https://gitlab.com/",
+		fetchUserPerms: func(context.Context, *extsvc.Account) ([]extsvc.RepoID, error) {
+			return []extsvc.RepoID{"1"}, nil
+		},
+		fetchRepoPerms: func(context.Context, *extsvc.Repository) ([]extsvc.AccountID, error) {
+			return nil, errors.New("not supposed to be called")
+		},
+	}
+	authz.SetProviders(false, []authz.Provider{p})
+	defer authz.SetProviders(true, nil)
+
+	clock := func() time.Time {
+		return time.Now().UTC().Truncate(time.Microsecond)
+	}
+	newPermsSyncer := func(reposStore repos.Store) *PermsSyncer {
+		return NewPermsSyncer(reposStore, edb.NewPermsStore(nil, clock), clock, nil)
+	}
+
+	t.Run("user permissions of selected external services are kept", func(t *testing.T) {
+		edb.Mocks.Perms.ListExternalAccounts = func(context.Context, int32) ([]*extsvc.Account, error) {
+			return []*extsvc.Account{{
+				AccountSpec: extsvc.AccountSpec{
+					ServiceType: p.ServiceType(),
+					ServiceID:   p.ServiceID(),
+				},
+			}}, nil
+		}
+		calledSetUserPermissionsKeepingExternalServices := false
+		edb.Mocks.Perms.SetUserPermissionsKeepingExternalServices = func(_ context.Context, _ *authz.UserPermissions, externalServiceIDs []int64) error {
+			calledSetUserPermissionsKeepingExternalServices = true
+			if diff := cmp.Diff([]int64{1}, externalServiceIDs); diff != "" {
+				return fmt.Errorf("externalServiceIDs mismatch (-want +got):\n%s", diff)
+			}
+			return nil
+		}
+		edb.Mocks.Perms.SetUserPermissions = func(context.Context, *authz.UserPermissions) error {
+			return errors.New("not supposed to be called")
+		}
+		defer func() {
+			edb.Mocks.Perms = edb.MockPerms{}
+		}()
+
+		reposStore := &mockReposStore{
+			listRepos: func(context.Context, repos.StoreListReposArgs) ([]*repos.Repo, error) {
+				return []*repos.Repo{{ID: 1}}, nil
+			},
+		}
+		s := newPermsSyncer(reposStore)
+
+		err := s.syncUserPerms(context.Background(), 1, false)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !calledSetUserPermissionsKeepingExternalServices {
+			t.Fatal("!calledSetUserPermissionsKeepingExternalServices")
+		}
+	})
+
+	t.Run("repository permissions of selected external services are not synced", func(t *testing.T) {
+		calledTouchRepoPermissions := false
+		edb.Mocks.Perms.TouchRepoPermissions = func(_ context.Context, repoID int32) error {
+			calledTouchRepoPermissions = true
+			if repoID != 1 {
+				return fmt.Errorf("repoID: want 1 but got %d", repoID)
+			}
+			return nil
+		}
+		edb.Mocks.Perms.SetRepoPermissions = func(context.Context, *authz.RepoPermissions) error {
+			return errors.New("not supposed to be called")
+		}
+		defer func() {
+			edb.Mocks.Perms = edb.MockPerms{}
+		}()
+
+		reposStore := &mockReposStore{
+			listRepos: func(context.Context, repos.StoreListReposArgs) ([]*repos.Repo, error) {
+				return []*repos.Repo{
+					{
+						ID:      1,
+						Private: true,
+						ExternalRepo: api.ExternalRepoSpec{
+							ServiceID: p.ServiceID(),
+						},
+						Sources: map[string]*repos.SourceInfo{
+							extsvc.URN(extsvc.TypeGitLab, 1): {ID: extsvc.URN(extsvc.TypeGitLab, 1)},
+						},
+					},
+				}, nil
+			},
+		}
+		s := newPermsSyncer(reposStore)
+
+		err := s.syncRepoPerms(context.Background(), 1, false)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !calledTouchRepoPermissions {
+			t.Fatal("!calledTouchRepoPermissions")
+		}
+	})
+}
+
 func TestPermsSyncer_waitForRateLimit(t *testing.T) {
 	ctx := context.Background()
 	t.Run("no rate limit registry", func(t *testing.T) {
@@ -26,6 +26,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/gitserver"
 	"github.com/sourcegraph/sourcegraph/internal/httpcli"
 	"github.com/sourcegraph/sourcegraph/internal/ratelimit"
+	"github.com/sourcegraph/sourcegraph/internal/secrets"
 )
 
 func main() {
@@ -43,6 +44,12 @@ func enterpriseInit(
 	server *repoupdater.Server,
 ) (debugDumpers []debugserver.Dumper) {
 	ctx := context.Background()
+
+	// Campaign credentials are encrypted with the secrets package.
+	if err := secrets.Init(); err != nil {
+		log.Fatalf("failed to initialize secrets: %v", err)
+	}
+
 	campaignsStore := campaigns.NewStore(db)
 
 	syncRegistry := campaigns.NewSyncRegistry(ctx, campaignsStore, repoStore, cf)
@@ -56,6 +63,10 @@ func enterpriseInit(
 
 	sourcer := repos.NewSourcer(cf)
 	go campaigns.RunWorkers(ctx, campaignsStore, gitserver.DefaultClient, sourcer)
+	go campaigns.RunExecutorWorkers(ctx, campaignsStore, gitserver.DefaultClient, campaigns.VMStepRunner)
+	go campaigns.RunBulkProcessorWorkers(ctx, campaignsStore, sourcer)
+	go campaigns.RunCampaignSnapshotter(ctx, campaignsStore)
+	go campaigns.RunChangesetRebaser(ctx, campaignsStore, gitserver.DefaultClient, syncRegistry)
 
 	// Set up expired spec deletion
 	go func() {
@@ -69,6 +80,9 @@ func enterpriseInit(
 			if err := campaignsStore.DeleteExpiredCampaignSpecs(ctx); err != nil {
 				log15.Error("DeleteExpiredCampaignSpecs", "error", err)
 			}
+			if err := campaignsStore.DeleteExpiredCampaignStepCacheEntries(ctx); err != nil {
+				log15.Error("DeleteExpiredCampaignStepCacheEntries", "error", err)
+			}
 
 			time.Sleep(2 * time.Minute)
 		}
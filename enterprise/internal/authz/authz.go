@@ -11,6 +11,7 @@ import (
 
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
 	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/authz/bitbucketcloud"
 	"github.com/sourcegraph/sourcegraph/internal/authz/bitbucketserver"
 	"github.com/sourcegraph/sourcegraph/internal/authz/github"
 	"github.com/sourcegraph/sourcegraph/internal/authz/gitlab"
@@ -51,6 +52,7 @@ func ProvidersFromConfig(
 			extsvc.KindGitHub,
 			extsvc.KindGitLab,
 			extsvc.KindBitbucketServer,
+			extsvc.KindBitbucketCloud,
 		},
 		LimitOffset: &db.LimitOffset{
 			Limit: 500, // The number is randomly chosen
@@ -61,6 +63,7 @@ func ProvidersFromConfig(
 		gitHubConns          []*types.GitHubConnection
 		gitLabConns          []*types.GitLabConnection
 		bitbucketServerConns []*types.BitbucketServerConnection
+		bitbucketCloudConns  []*types.BitbucketCloudConnection
 	)
 	for {
 		svcs, err := store.List(ctx, opt)
@@ -96,6 +99,11 @@ func ProvidersFromConfig(
 					URN:                       svc.URN(),
 					BitbucketServerConnection: c,
 				})
+			case *schema.BitbucketCloudConnection:
+				bitbucketCloudConns = append(bitbucketCloudConns, &types.BitbucketCloudConnection{
+					URN:                      svc.URN(),
+					BitbucketCloudConnection: c,
+				})
 			default:
 				log15.Error("ProvidersFromConfig", "error", errors.Errorf("unexpected connection type: %T", cfg))
 				continue
@@ -129,9 +137,16 @@ func ProvidersFromConfig(
 		warnings = append(warnings, bbsWarnings...)
 	}
 
+	if len(bitbucketCloudConns) > 0 {
+		bbcProviders, bbcProblems, bbcWarnings := bitbucketcloud.NewAuthzProviders(bitbucketCloudConns)
+		providers = append(providers, bbcProviders...)
+		seriousProblems = append(seriousProblems, bbcProblems...)
+		warnings = append(warnings, bbcWarnings...)
+	}
+
 	// 🚨 SECURITY: Warn the admin when both code host authz provider and the permissions user mapping are configured.
-	if cfg.SiteConfiguration.PermissionsUserMapping != nil &&
-		cfg.SiteConfiguration.PermissionsUserMapping.Enabled && len(providers) > 0 {
+	if userMapping := cfg.SiteConfiguration.PermissionsUserMapping; userMapping != nil &&
+		userMapping.Enabled && len(userMapping.ExternalServices) == 0 && len(providers) > 0 {
 		serviceTypes := make([]string, len(providers))
 		for i := range providers {
 			serviceTypes[i] = strconv.Quote(providers[i].ServiceType())
@@ -142,5 +157,29 @@ func ProvidersFromConfig(
 		seriousProblems = append(seriousProblems, msg)
 	}
 
+	// 🚨 SECURITY: Warn the admin when the permissions user mapping is enabled for external services
+	// that have code host authz providers configured.
+	if userMapping := cfg.SiteConfiguration.PermissionsUserMapping; userMapping != nil &&
+		userMapping.Enabled && len(userMapping.ExternalServices) > 0 {
+		selected := make(map[string]bool, len(userMapping.ExternalServices))
+		for _, id := range userMapping.ExternalServices {
+			selected[strconv.Itoa(id)] = true
+		}
+
+		var conflicts []string
+		for _, p := range providers {
+			urn := p.URN()
+			if id := urn[strings.LastIndex(urn, ":")+1:]; selected[id] {
+				conflicts = append(conflicts, id)
+			}
+		}
+		if len(conflicts) > 0 {
+			msg := fmt.Sprintf(
+				"The permissions user mapping (site configuration `permissions.userMapping`) cannot be enabled for external services %s because they have authorization configured. Blocking access to all repositories until the conflict is resolved.",
+				strings.Join(conflicts, ", "))
+			seriousProblems = append(seriousProblems, msg)
+		}
+	}
+
 	return allowAccessByDefault, providers, seriousProblems, warnings
 }
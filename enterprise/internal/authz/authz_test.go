@@ -43,7 +43,10 @@ func (m gitlabAuthzProviderParams) ServiceType() string {
 }
 
 func (m gitlabAuthzProviderParams) URN() string {
-	panic("should never be called")
+	if m.SudoOp.URN != "" {
+		return m.SudoOp.URN
+	}
+	return m.OAuthOp.URN
 }
 
 func (m gitlabAuthzProviderParams) Validate() []string { return nil }
@@ -78,7 +81,9 @@ func TestAuthzProvidersFromConfig(t *testing.T) {
 		description                  string
 		cfg                          conf.Unified
 		gitlabConnections            []*schema.GitLabConnection
+		githubConnections            []*schema.GitHubConnection
 		bitbucketServerConnections   []*schema.BitbucketServerConnection
+		bitbucketCloudConnections    []*schema.BitbucketCloudConnection
 		expAuthzAllowAccessByDefault bool
 		expAuthzProviders            func(*testing.T, []authz.Provider)
 		expSeriousProblems           []string
@@ -390,6 +395,73 @@ func TestAuthzProvidersFromConfig(t *testing.T) {
 				}
 			},
 		},
+		{
+			description: "GitHub App with invalid private key",
+			githubConnections: []*schema.GitHubConnection{
+				{
+					Authorization: &schema.GitHubAuthorization{
+						GithubApp: &schema.GitHubApp{AppID: 1, InstallationID: 2, PrivateKey: "Invalid Key"},
+					},
+					Url:   "https://github.mycorp.org",
+					Token: "secret-token",
+				},
+			},
+			expAuthzAllowAccessByDefault: false,
+			expSeriousProblems:           []string{`Could not decode the private key of the GitHub App for GitHub instance "https://github.mycorp.org": illegal base64 data at input byte 7`},
+		},
+		{
+			description: "GitHub App",
+			githubConnections: []*schema.GitHubConnection{
+				{
+					Authorization: &schema.GitHubAuthorization{
+						GithubApp: &schema.GitHubApp{AppID: 1, InstallationID: 2, PrivateKey: bogusKey},
+					},
+					Url:   "https://github.mycorp.org",
+					Token: "secret-token",
+				},
+			},
+			expAuthzAllowAccessByDefault: true,
+			expAuthzProviders: func(t *testing.T, have []authz.Provider) {
+				if len(have) != 1 || have[0].ServiceType() != extsvc.TypeGitHub {
+					t.Fatalf("got providers %v, want one GitHub authz provider", have)
+				}
+			},
+		},
+		{
+			description: "Bitbucket Cloud without identity provider",
+			bitbucketCloudConnections: []*schema.BitbucketCloudConnection{
+				{
+					Authorization: &schema.BitbucketCloudAuthorization{},
+					Url:           "https://bitbucket.org",
+					ApiURL:        "https://api.bitbucket.mycorp.org",
+					Username:      "admin",
+					AppPassword:   "secret-password",
+				},
+			},
+			expAuthzAllowAccessByDefault: false,
+			expSeriousProblems:           []string{"No identityProvider was specified"},
+		},
+		{
+			description: "Bitbucket Cloud exact username matching",
+			bitbucketCloudConnections: []*schema.BitbucketCloudConnection{
+				{
+					Authorization: &schema.BitbucketCloudAuthorization{
+						IdentityProvider: schema.BitbucketCloudIdentityProvider{Type: "username"},
+					},
+					Url:         "https://bitbucket.org",
+					ApiURL:      "https://api.bitbucket.mycorp.org",
+					Username:    "admin",
+					AppPassword: "secret-password",
+					Teams:       []string{"acme"},
+				},
+			},
+			expAuthzAllowAccessByDefault: true,
+			expAuthzProviders: func(t *testing.T, have []authz.Provider) {
+				if len(have) != 1 || have[0].ServiceType() != extsvc.TypeBitbucketCloud {
+					t.Fatalf("got providers %v, want one Bitbucket Cloud authz provider", have)
+				}
+			},
+		},
 
 		// For Sourcegraph authz provider
 		{
@@ -454,6 +526,69 @@ func TestAuthzProvidersFromConfig(t *testing.T) {
 			expAuthzAllowAccessByDefault: false,
 			expSeriousProblems:           []string{"The permissions user mapping (site configuration `permissions.userMapping`) cannot be enabled when \"bitbucketServer\" authorization providers are in use. Blocking access to all repositories until the conflict is resolved."},
 		},
+		{
+			description: "Permissions user mapping for other external services than the GitLab authz provider",
+			cfg: conf.Unified{
+				SiteConfiguration: schema.SiteConfiguration{
+					PermissionsUserMapping: &schema.PermissionsUserMapping{
+						Enabled:          true,
+						BindID:           "email",
+						ExternalServices: []int{1},
+					},
+					AuthProviders: []schema.AuthProviders{{
+						Gitlab: &schema.GitLabAuthProvider{
+							ClientID:     "clientID",
+							ClientSecret: "clientSecret",
+							DisplayName:  "GitLab",
+							Type:         extsvc.TypeGitLab,
+							Url:          "https://gitlab.mine",
+						},
+					}},
+				},
+			},
+			gitlabConnections: []*schema.GitLabConnection{
+				{
+					Authorization: &schema.GitLabAuthorization{
+						IdentityProvider: schema.IdentityProvider{Oauth: &schema.OAuthIdentity{Type: "oauth"}},
+					},
+					Url:   "https://gitlab.mine",
+					Token: "asdf",
+				},
+			},
+			expAuthzAllowAccessByDefault: true,
+		},
+		{
+			description: "Permissions user mapping for the external service of the GitLab authz provider",
+			cfg: conf.Unified{
+				SiteConfiguration: schema.SiteConfiguration{
+					PermissionsUserMapping: &schema.PermissionsUserMapping{
+						Enabled:          true,
+						BindID:           "email",
+						ExternalServices: []int{0},
+					},
+					AuthProviders: []schema.AuthProviders{{
+						Gitlab: &schema.GitLabAuthProvider{
+							ClientID:     "clientID",
+							ClientSecret: "clientSecret",
+							DisplayName:  "GitLab",
+							Type:         extsvc.TypeGitLab,
+							Url:          "https://gitlab.mine",
+						},
+					}},
+				},
+			},
+			gitlabConnections: []*schema.GitLabConnection{
+				{
+					Authorization: &schema.GitLabAuthorization{
+						IdentityProvider: schema.IdentityProvider{Oauth: &schema.OAuthIdentity{Type: "oauth"}},
+					},
+					Url:   "https://gitlab.mine",
+					Token: "asdf",
+				},
+			},
+			expAuthzAllowAccessByDefault: false,
+			expSeriousProblems:           []string{"The permissions user mapping (site configuration `permissions.userMapping`) cannot be enabled for external services 0 because they have authorization configured. Blocking access to all repositories until the conflict is resolved."},
+		},
 	}
 
 	for _, test := range tests {
@@ -461,7 +596,9 @@ func TestAuthzProvidersFromConfig(t *testing.T) {
 
 		store := fakeStore{
 			gitlabs:          test.gitlabConnections,
+			githubs:          test.githubConnections,
 			bitbucketServers: test.bitbucketServerConnections,
+			bitbucketClouds:  test.bitbucketCloudConnections,
 		}
 
 		allowAccessByDefault, authzProviders, seriousProblems, _ :=
@@ -498,6 +635,7 @@ type fakeStore struct {
 	gitlabs          []*schema.GitLabConnection
 	githubs          []*schema.GitHubConnection
 	bitbucketServers []*schema.BitbucketServerConnection
+	bitbucketClouds  []*schema.BitbucketCloudConnection
 }
 
 func (s fakeStore) List(ctx context.Context, opt db.ExternalServicesListOptions) ([]*types.ExternalService, error) {
@@ -533,6 +671,13 @@ func (s fakeStore) List(ctx context.Context, opt db.ExternalServicesListOptions)
 					Config: mustMarshalJSONString(bbs),
 				})
 			}
+		case extsvc.KindBitbucketCloud:
+			for _, bbc := range s.bitbucketClouds {
+				svcs = append(svcs, &types.ExternalService{
+					Kind:   kind,
+					Config: mustMarshalJSONString(bbc),
+				})
+			}
 		default:
 			return nil, errors.Errorf("unexpected kind: %s", kind)
 		}
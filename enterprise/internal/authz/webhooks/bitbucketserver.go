@@ -0,0 +1,84 @@
+package webhooks
+
+import (
+	"net/http"
+	"strconv"
+
+	gh "github.com/google/go-github/v28/github"
+
+	"github.com/sourcegraph/sourcegraph/cmd/repo-updater/repos"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketserver"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// BitbucketServerWebhook schedules permissions syncs for the permission change events sent by
+// the Sourcegraph Bitbucket Server plugin.
+type BitbucketServerWebhook struct {
+	*Webhook
+}
+
+// NewBitbucketServerWebhook returns a BitbucketServerWebhook that passes all requests on to
+// next.
+func NewBitbucketServerWebhook(repos repos.Store, next http.Handler) *BitbucketServerWebhook {
+	return &BitbucketServerWebhook{&Webhook{Repos: repos, ServiceType: extsvc.TypeBitbucketServer, next: next}}
+}
+
+// ServeHTTP implements the http.Handler interface.
+func (h *BitbucketServerWebhook) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.serve(w, r, h.handle)
+}
+
+func (h *BitbucketServerWebhook) handle(r *http.Request, payload []byte) (*repos.ExternalService, *permsChange, error) {
+	es, err := h.listExternalServices(r, extsvc.KindBitbucketServer)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// 🚨 SECURITY: Authenticate the request with any of the webhook secrets of the Bitbucket
+	// Server external services. The plugin signs payloads the same way GitHub does.
+	sig := r.Header.Get("X-Hub-Signature")
+	var extSvc *repos.ExternalService
+	for _, e := range es {
+		c, err := e.Configuration()
+		if err != nil {
+			continue
+		}
+		con, ok := c.(*schema.BitbucketServerConnection)
+		if !ok {
+			continue
+		}
+		if secret := con.WebhookSecret(); secret != "" && gh.ValidateSignature(sig, payload, []byte(secret)) == nil {
+			extSvc = e
+			break
+		}
+	}
+	if extSvc == nil {
+		return nil, nil, nil
+	}
+
+	eventType := bitbucketserver.WebhookEventType(r)
+	e, err := bitbucketserver.ParseWebhookEvent(eventType, payload)
+	if err != nil {
+		// Unknown event types are none of our business.
+		return extSvc, nil, nil
+	}
+	pe, ok := e.(*bitbucketserver.PermissionsChangedEvent)
+	if !ok {
+		return extSvc, nil, nil
+	}
+
+	// Changes of group permissions on projects can't be mapped to users or repositories
+	// without asking Bitbucket Server, so they are left to the periodic syncs.
+	change := permsChange{event: eventType}
+	if pe.User != nil && pe.User.ID != 0 {
+		change.accountIDs = []string{strconv.Itoa(pe.User.ID)}
+	}
+	if pe.Repo != nil && pe.Repo.ID != 0 {
+		change.repoIDs = []string{strconv.Itoa(pe.Repo.ID)}
+	}
+	if len(change.accountIDs) == 0 && len(change.repoIDs) == 0 {
+		return extSvc, nil, nil
+	}
+	return extSvc, &change, nil
+}
@@ -0,0 +1,121 @@
+package webhooks
+
+import (
+	"net/http"
+	"strconv"
+
+	gh "github.com/google/go-github/v28/github"
+
+	"github.com/sourcegraph/sourcegraph/cmd/repo-updater/repos"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// GitHubWebhook schedules permissions syncs for GitHub membership, organization, member,
+// team, team_add and repository events.
+type GitHubWebhook struct {
+	*Webhook
+}
+
+// NewGitHubWebhook returns a GitHubWebhook that passes all requests on to next.
+func NewGitHubWebhook(repos repos.Store, next http.Handler) *GitHubWebhook {
+	return &GitHubWebhook{&Webhook{Repos: repos, ServiceType: extsvc.TypeGitHub, next: next}}
+}
+
+// ServeHTTP implements the http.Handler interface.
+func (h *GitHubWebhook) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.serve(w, r, h.handle)
+}
+
+func (h *GitHubWebhook) handle(r *http.Request, payload []byte) (*repos.ExternalService, *permsChange, error) {
+	es, err := h.listExternalServices(r, extsvc.KindGitHub)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// 🚨 SECURITY: Authenticate the request with any of the webhook secrets of the GitHub
+	// external services.
+	sig := r.Header.Get("X-Hub-Signature")
+	var extSvc *repos.ExternalService
+	for _, e := range es {
+		c, err := e.Configuration()
+		if err != nil {
+			continue
+		}
+		for _, hook := range c.(*schema.GitHubConnection).Webhooks {
+			if hook.Secret != "" && gh.ValidateSignature(sig, payload, []byte(hook.Secret)) == nil {
+				extSvc = e
+				break
+			}
+		}
+		if extSvc != nil {
+			break
+		}
+	}
+	if extSvc == nil {
+		return nil, nil, nil
+	}
+
+	eventType := gh.WebHookType(r)
+	e, err := gh.ParseWebHook(eventType, payload)
+	if err != nil {
+		// Unknown event types are none of our business.
+		return extSvc, nil, nil
+	}
+
+	change := githubPermsChange(e)
+	if change != nil {
+		change.event = eventType
+	}
+	return extSvc, change, nil
+}
+
+// githubPermsChange returns the users and repositories whose permissions are changed by the
+// given event, or nil if it doesn't change any permissions. Users are identified by their
+// database IDs and repositories by their node IDs, as in external accounts and repositories.
+func githubPermsChange(e interface{}) *permsChange {
+	var users []*gh.User
+	var repos []*gh.Repository
+	switch e := e.(type) {
+	case *gh.MembershipEvent:
+		// A user was added to or removed from a team.
+		users = append(users, e.Member)
+	case *gh.OrganizationEvent:
+		// Invitations don't grant access until they are accepted.
+		if a := e.GetAction(); (a == "member_added" || a == "member_removed") && e.Membership != nil {
+			users = append(users, e.Membership.User)
+		}
+	case *gh.MemberEvent:
+		// A collaborator was added to or removed from a repository, or their permission changed.
+		users = append(users, e.Member)
+		repos = append(repos, e.Repo)
+	case *gh.TeamEvent:
+		// Only changes to the repositories of a team include the repository. Members of
+		// deleted teams are synced by the periodic syncs.
+		repos = append(repos, e.Repo)
+	case *gh.TeamAddEvent:
+		repos = append(repos, e.Repo)
+	case *gh.RepositoryEvent:
+		switch e.GetAction() {
+		case "privatized", "publicized", "transferred":
+			repos = append(repos, e.Repo)
+		}
+	}
+
+	var change permsChange
+	for _, u := range users {
+		if u.GetID() != 0 {
+			change.accountIDs = append(change.accountIDs, strconv.FormatInt(u.GetID(), 10))
+		}
+	}
+	for _, r := range repos {
+		if r.GetNodeID() != "" {
+			change.repoIDs = append(change.repoIDs, r.GetNodeID())
+		}
+	}
+
+	if len(change.accountIDs) == 0 && len(change.repoIDs) == 0 {
+		return nil
+	}
+	return &change
+}
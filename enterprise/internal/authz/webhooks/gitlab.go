@@ -0,0 +1,82 @@
+package webhooks
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strconv"
+
+	"github.com/sourcegraph/sourcegraph/cmd/repo-updater/repos"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitlab/webhooks"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// GitLabWebhook schedules permissions syncs for GitLab group and project member events.
+type GitLabWebhook struct {
+	*Webhook
+}
+
+// NewGitLabWebhook returns a GitLabWebhook that passes all requests on to next.
+func NewGitLabWebhook(repos repos.Store, next http.Handler) *GitLabWebhook {
+	return &GitLabWebhook{&Webhook{Repos: repos, ServiceType: extsvc.TypeGitLab, next: next}}
+}
+
+// ServeHTTP implements the http.Handler interface.
+func (h *GitLabWebhook) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.serve(w, r, h.handle)
+}
+
+func (h *GitLabWebhook) handle(r *http.Request, payload []byte) (*repos.ExternalService, *permsChange, error) {
+	// GitLab webhooks don't sign their payloads, so the webhook URL must include the ID of
+	// the external service to look up the secret.
+	id, err := externalServiceID(r)
+	if err != nil || id == 0 {
+		return nil, nil, err
+	}
+	es, err := h.listExternalServices(r, extsvc.KindGitLab)
+	if err != nil || len(es) == 0 {
+		return nil, nil, err
+	}
+
+	// 🚨 SECURITY: Verify the shared secret against the webhooks of the GitLab external
+	// service, in constant time to avoid leaking the secret through timing attacks.
+	secret := r.Header.Get(webhooks.TokenHeaderName)
+	if secret == "" {
+		return nil, nil, nil
+	}
+	c, err := es[0].Configuration()
+	if err != nil {
+		return nil, nil, err
+	}
+	var ok bool
+	for _, hook := range c.(*schema.GitLabConnection).Webhooks {
+		if subtle.ConstantTimeCompare([]byte(hook.Secret), []byte(secret)) == 1 {
+			ok = true
+			break
+		}
+	}
+	if !ok {
+		return nil, nil, nil
+	}
+
+	e, err := webhooks.UnmarshalEvent(payload)
+	if err != nil {
+		// Unknown event types are none of our business.
+		return es[0], nil, nil
+	}
+	member, ok := e.(*webhooks.MemberEvent)
+	if !ok || member.UserID == 0 {
+		return es[0], nil, nil
+	}
+
+	// Group memberships apply to all projects of the group and its subgroups, so only the
+	// user is synced.
+	change := &permsChange{
+		event:      member.EventName,
+		accountIDs: []string{strconv.Itoa(member.UserID)},
+	}
+	if member.ProjectID != 0 {
+		change.repoIDs = []string{strconv.Itoa(member.ProjectID)}
+	}
+	return es[0], change, nil
+}
@@ -0,0 +1,22 @@
+package webhooks
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// The metrics that are exposed to Prometheus.
+var (
+	metricsWebhookEvents = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "src_frontend_authz_webhook_events_total",
+		Help: "Total number of received webhook events that change permissions",
+	}, []string{"service_type", "event"})
+	metricsWebhookSyncs = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "src_frontend_authz_webhook_perms_syncs_total",
+		Help: "Total number of permissions syncs scheduled by webhook events",
+	}, []string{"service_type", "type"})
+	metricsWebhookErrors = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "src_frontend_authz_webhook_errors_total",
+		Help: "Total number of errors when handling webhook events",
+	}, []string{"service_type"})
+)
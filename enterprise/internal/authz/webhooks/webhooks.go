@@ -0,0 +1,200 @@
+// Package webhooks contains webhook handlers that schedule permissions syncs of the users and
+// repositories whose permissions were changed on a code host, so that these changes are
+// reflected on Sourcegraph without waiting for the next periodic sync.
+//
+// The handlers share their endpoints with the campaigns webhook handlers: they read the
+// payload, schedule the syncs and then pass the request on to the next handler, which writes
+// the response.
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/inconshreveable/log15"
+	"github.com/pkg/errors"
+
+	"github.com/sourcegraph/sourcegraph/cmd/repo-updater/repos"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/db"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/repoupdater"
+	"github.com/sourcegraph/sourcegraph/internal/repoupdater/protocol"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// Webhook contains the state shared by the webhook handlers of all code hosts.
+type Webhook struct {
+	Repos repos.Store
+
+	// ServiceType corresponds to api.ExternalRepoSpec.ServiceType
+	// Example values: extsvc.TypeBitbucketServer, extsvc.TypeGitHub
+	ServiceType string
+
+	// next is the handler that requests are passed on to.
+	next http.Handler
+}
+
+// permsChange describes the users and repositories whose permissions were changed by a
+// webhook event, identified by their IDs on the code host.
+type permsChange struct {
+	// event is the name of the webhook event, used as a metrics label.
+	event      string
+	accountIDs []string
+	repoIDs    []string
+}
+
+// handleFunc authenticates and parses the payload of a webhook request. It returns a nil
+// external service if the request could not be authenticated, and a nil permsChange if the
+// event doesn't change any permissions.
+type handleFunc func(r *http.Request, payload []byte) (*repos.ExternalService, *permsChange, error)
+
+// serve schedules the permissions syncs of the request with handle and then passes the
+// request on to the next handler. Errors are only logged, since the response is written by
+// the next handler.
+func (h *Webhook) serve(w http.ResponseWriter, r *http.Request, handle handleFunc) {
+	payload, err := ioutil.ReadAll(r.Body)
+	// The next handler reads the payload again.
+	r.Body = ioutil.NopCloser(bytes.NewReader(payload))
+	if err == nil {
+		h.syncPerms(r, payload, handle)
+	}
+	h.next.ServeHTTP(w, r)
+}
+
+func (h *Webhook) syncPerms(r *http.Request, payload []byte, handle handleFunc) {
+	extSvc, change, err := handle(r, payload)
+	if err != nil {
+		metricsWebhookErrors.WithLabelValues(h.ServiceType).Inc()
+		log15.Error("authz.webhooks: failed to handle webhook", "serviceType", h.ServiceType, "error", err)
+		return
+	} else if extSvc == nil || change == nil {
+		// Either the request is not authenticated, which the next handler responds to, or
+		// there is nothing to do.
+		return
+	}
+
+	metricsWebhookEvents.WithLabelValues(h.ServiceType, change.event).Inc()
+	if err = h.schedule(r.Context(), extSvc, change); err != nil {
+		metricsWebhookErrors.WithLabelValues(h.ServiceType).Inc()
+		log15.Error("authz.webhooks: failed to schedule permissions sync", "serviceType", h.ServiceType, "event", change.event, "error", err)
+	}
+}
+
+// schedule schedules permissions syncs with high priority for the Sourcegraph users and
+// repositories of the given change. Users without an external account and repositories that
+// are not synced from the code host are skipped.
+func (h *Webhook) schedule(ctx context.Context, extSvc *repos.ExternalService, change *permsChange) error {
+	serviceID, err := extractServiceID(extSvc)
+	if err != nil {
+		return err
+	}
+
+	var req protocol.PermsSyncRequest
+	for _, accountID := range change.accountIDs {
+		accts, err := db.ExternalAccounts.List(ctx, db.ExternalAccountsListOptions{
+			ServiceType: h.ServiceType,
+			ServiceID:   serviceID,
+			AccountID:   accountID,
+		})
+		if err != nil {
+			return errors.Wrap(err, "list external accounts")
+		}
+		for _, acct := range accts {
+			req.UserIDs = append(req.UserIDs, acct.UserID)
+		}
+	}
+
+	if len(change.repoIDs) > 0 {
+		specs := make([]api.ExternalRepoSpec, 0, len(change.repoIDs))
+		for _, id := range change.repoIDs {
+			specs = append(specs, api.ExternalRepoSpec{
+				ID:          id,
+				ServiceType: h.ServiceType,
+				ServiceID:   serviceID,
+			})
+		}
+		rs, err := h.Repos.ListRepos(ctx, repos.StoreListReposArgs{ExternalRepos: specs})
+		if err != nil {
+			return errors.Wrap(err, "list repositories")
+		}
+		for _, r := range rs {
+			req.RepoIDs = append(req.RepoIDs, r.ID)
+		}
+	}
+
+	if len(req.UserIDs) == 0 && len(req.RepoIDs) == 0 {
+		log15.Debug("authz.webhooks: no users or repositories to sync", "serviceType", h.ServiceType, "event", change.event)
+		return nil
+	}
+
+	if err = repoupdater.DefaultClient.SchedulePermsSync(ctx, req); err != nil {
+		return err
+	}
+
+	metricsWebhookSyncs.WithLabelValues(h.ServiceType, "user").Add(float64(len(req.UserIDs)))
+	metricsWebhookSyncs.WithLabelValues(h.ServiceType, "repo").Add(float64(len(req.RepoIDs)))
+	return nil
+}
+
+// externalServiceID returns the ID of the external service that the request was sent for,
+// or zero if the webhook URL doesn't include it. It is read from the URL query rather than
+// with FormValue, which would consume form encoded payloads.
+func externalServiceID(r *http.Request) (int64, error) {
+	raw := r.URL.Query().Get(extsvc.IDParam)
+	if raw == "" {
+		return 0, nil
+	}
+	id, err := strconv.ParseInt(raw, 10, 64)
+	if err != nil {
+		return 0, errors.Wrap(err, "invalid external service id")
+	}
+	return id, nil
+}
+
+// listExternalServices lists the external services of the given kind that the request may
+// have been sent for.
+func (h *Webhook) listExternalServices(r *http.Request, kind string) ([]*repos.ExternalService, error) {
+	id, err := externalServiceID(r)
+	if err != nil {
+		return nil, err
+	}
+
+	args := repos.StoreListExternalServicesArgs{Kinds: []string{kind}}
+	if id != 0 {
+		args.IDs = []int64{id}
+	}
+	return h.Repos.ListExternalServices(r.Context(), args)
+}
+
+// extractServiceID returns the normalized base URL of the code host of the external service,
+// which is the api.ExternalRepoSpec.ServiceID of its repositories.
+func extractServiceID(extSvc *repos.ExternalService) (string, error) {
+	c, err := extSvc.Configuration()
+	if err != nil {
+		return "", errors.Wrap(err, "get external service config")
+	}
+
+	var serviceID string
+	switch c := c.(type) {
+	case *schema.GitHubConnection:
+		serviceID = c.Url
+	case *schema.BitbucketServerConnection:
+		serviceID = c.Url
+	case *schema.GitLabConnection:
+		serviceID = c.Url
+	default:
+		return "", fmt.Errorf("unexpected external service config type %T", c)
+	}
+
+	u, err := url.Parse(serviceID)
+	if err != nil {
+		return "", errors.Wrap(err, "parse service ID")
+	}
+	return extsvc.NormalizeBaseURL(u).String(), nil
+}
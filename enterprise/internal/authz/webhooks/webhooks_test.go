@@ -0,0 +1,251 @@
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha1"
+	"encoding/hex"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/cmd/repo-updater/repos"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/db"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/repoupdater"
+	"github.com/sourcegraph/sourcegraph/internal/repoupdater/protocol"
+)
+
+const testSecret = "s3cr3t"
+
+// fakeStore serves the external services and repositories of the tests.
+type fakeStore struct {
+	repos.Store
+	svcs  []*repos.ExternalService
+	repos []*repos.Repo
+}
+
+func (s *fakeStore) ListExternalServices(_ context.Context, args repos.StoreListExternalServicesArgs) ([]*repos.ExternalService, error) {
+	var svcs []*repos.ExternalService
+	for _, svc := range s.svcs {
+		if svc.Kind != args.Kinds[0] || (len(args.IDs) > 0 && svc.ID != args.IDs[0]) {
+			continue
+		}
+		svcs = append(svcs, svc)
+	}
+	return svcs, nil
+}
+
+func (s *fakeStore) ListRepos(_ context.Context, args repos.StoreListReposArgs) ([]*repos.Repo, error) {
+	var rs []*repos.Repo
+	for _, r := range s.repos {
+		for _, spec := range args.ExternalRepos {
+			if r.ExternalRepo == spec {
+				rs = append(rs, r)
+			}
+		}
+	}
+	return rs, nil
+}
+
+func newFakeStore() *fakeStore {
+	return &fakeStore{
+		svcs: []*repos.ExternalService{
+			{ID: 1, Kind: extsvc.KindGitHub, Config: `{"url": "https://github.com", "webhooks": [{"org": "acme", "secret": "` + testSecret + `"}]}`},
+			{ID: 2, Kind: extsvc.KindGitLab, Config: `{"url": "https://gitlab.com", "webhooks": [{"secret": "` + testSecret + `"}]}`},
+			{ID: 3, Kind: extsvc.KindBitbucketServer, Config: `{"url": "https://bitbucket.example.com", "plugin": {"webhooks": {"secret": "` + testSecret + `"}}}`},
+		},
+		repos: []*repos.Repo{
+			{ID: 10, ExternalRepo: api.ExternalRepoSpec{ID: "MDEwOlJlcG9zaXRvcnkx", ServiceType: extsvc.TypeGitHub, ServiceID: "https://github.com/"}},
+			{ID: 11, ExternalRepo: api.ExternalRepoSpec{ID: "42", ServiceType: extsvc.TypeGitLab, ServiceID: "https://gitlab.com/"}},
+			{ID: 12, ExternalRepo: api.ExternalRepoSpec{ID: "7", ServiceType: extsvc.TypeBitbucketServer, ServiceID: "https://bitbucket.example.com/"}},
+		},
+	}
+}
+
+// mockPermsSync mocks the external accounts of user 1 and returns the scheduled permissions
+// sync requests.
+func mockPermsSync(t *testing.T) *[]protocol.PermsSyncRequest {
+	var reqs []protocol.PermsSyncRequest
+	repoupdater.MockSchedulePermsSync = func(_ context.Context, args protocol.PermsSyncRequest) error {
+		reqs = append(reqs, args)
+		return nil
+	}
+	db.Mocks.ExternalAccounts.List = func(opt db.ExternalAccountsListOptions) ([]*extsvc.Account, error) {
+		accounts := map[string]string{
+			extsvc.TypeGitHub:          "https://github.com/",
+			extsvc.TypeGitLab:          "https://gitlab.com/",
+			extsvc.TypeBitbucketServer: "https://bitbucket.example.com/",
+		}
+		if opt.ServiceID != accounts[opt.ServiceType] || opt.AccountID != "64" {
+			return nil, nil
+		}
+		return []*extsvc.Account{{UserID: 1}}, nil
+	}
+	t.Cleanup(func() {
+		repoupdater.MockSchedulePermsSync = nil
+		db.Mocks.ExternalAccounts = db.MockExternalAccounts{}
+	})
+	return &reqs
+}
+
+// nextHandler is the handler the webhooks pass requests on to, which records the payloads.
+type nextHandler struct {
+	payloads []string
+}
+
+func (h *nextHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	payload, _ := ioutil.ReadAll(r.Body)
+	h.payloads = append(h.payloads, string(payload))
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func sign(payload string) string {
+	mac := hmac.New(sha1.New, []byte(testSecret))
+	_, _ = mac.Write([]byte(payload))
+	return "sha1=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func TestGitHubWebhook(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		event   string
+		payload string
+		sig     string
+		want    []protocol.PermsSyncRequest
+	}{
+		{
+			name:    "membership",
+			event:   "membership",
+			payload: `{"action": "removed", "scope": "team", "member": {"id": 64}, "team": {"id": 1}}`,
+			want:    []protocol.PermsSyncRequest{{UserIDs: []int32{1}}},
+		},
+		{
+			name:    "member",
+			event:   "member",
+			payload: `{"action": "added", "member": {"id": 64}, "repository": {"node_id": "MDEwOlJlcG9zaXRvcnkx"}}`,
+			want:    []protocol.PermsSyncRequest{{UserIDs: []int32{1}, RepoIDs: []api.RepoID{10}}},
+		},
+		{
+			name:    "team_add",
+			event:   "team_add",
+			payload: `{"team": {"id": 1}, "repository": {"node_id": "MDEwOlJlcG9zaXRvcnkx"}}`,
+			want:    []protocol.PermsSyncRequest{{RepoIDs: []api.RepoID{10}}},
+		},
+		{
+			name:    "unknown user",
+			event:   "membership",
+			payload: `{"action": "added", "scope": "team", "member": {"id": 65}, "team": {"id": 1}}`,
+		},
+		{
+			name:    "organization invitation",
+			event:   "organization",
+			payload: `{"action": "member_invited", "invitation": {"id": 1}}`,
+		},
+		{
+			name:    "unrelated event",
+			event:   "pull_request",
+			payload: `{"action": "opened", "number": 1}`,
+		},
+		{
+			name:    "invalid signature",
+			event:   "membership",
+			payload: `{"action": "removed", "scope": "team", "member": {"id": 64}, "team": {"id": 1}}`,
+			sig:     sign("something else"),
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			reqs := mockPermsSync(t)
+			next := &nextHandler{}
+			h := NewGitHubWebhook(newFakeStore(), next)
+
+			req := httptest.NewRequest("POST", "/.api/github-webhooks?externalServiceID=1", bytes.NewBufferString(tc.payload))
+			req.Header.Set("X-Github-Event", tc.event)
+			sig := tc.sig
+			if sig == "" {
+				sig = sign(tc.payload)
+			}
+			req.Header.Set("X-Hub-Signature", sig)
+			h.ServeHTTP(httptest.NewRecorder(), req)
+
+			if !reflect.DeepEqual(*reqs, tc.want) {
+				t.Errorf("got perms sync requests %+v, want %+v", *reqs, tc.want)
+			}
+			if want := []string{tc.payload}; !reflect.DeepEqual(next.payloads, want) {
+				t.Errorf("got payloads %q passed on, want %q", next.payloads, want)
+			}
+		})
+	}
+}
+
+func TestGitLabWebhook(t *testing.T) {
+	payload := `{"event_name": "user_add_to_team", "project_id": 42, "user_id": 64, "user_username": "alice"}`
+	for _, tc := range []struct {
+		name   string
+		url    string
+		secret string
+		want   []protocol.PermsSyncRequest
+	}{
+		{
+			name:   "project member",
+			url:    "/.api/gitlab-webhooks?externalServiceID=2",
+			secret: testSecret,
+			want:   []protocol.PermsSyncRequest{{UserIDs: []int32{1}, RepoIDs: []api.RepoID{11}}},
+		},
+		{
+			name:   "invalid secret",
+			url:    "/.api/gitlab-webhooks?externalServiceID=2",
+			secret: "wrong",
+		},
+		{
+			name:   "no external service",
+			url:    "/.api/gitlab-webhooks",
+			secret: testSecret,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			reqs := mockPermsSync(t)
+			next := &nextHandler{}
+			h := NewGitLabWebhook(newFakeStore(), next)
+
+			req := httptest.NewRequest("POST", tc.url, bytes.NewBufferString(payload))
+			req.Header.Set("X-Gitlab-Token", tc.secret)
+			h.ServeHTTP(httptest.NewRecorder(), req)
+
+			if !reflect.DeepEqual(*reqs, tc.want) {
+				t.Errorf("got perms sync requests %+v, want %+v", *reqs, tc.want)
+			}
+			if len(next.payloads) != 1 {
+				t.Errorf("got %d requests passed on, want 1", len(next.payloads))
+			}
+		})
+	}
+}
+
+func TestBitbucketServerWebhook(t *testing.T) {
+	reqs := mockPermsSync(t)
+	next := &nextHandler{}
+	h := NewBitbucketServerWebhook(newFakeStore(), next)
+
+	for _, payload := range []string{
+		`{"user": {"id": 64, "name": "alice"}, "repository": {"id": 7, "slug": "foo"}, "permission": "REPO_READ"}`,
+		`{"group": {"name": "developers"}, "project": {"id": 1, "key": "ACME"}, "permission": "PROJECT_WRITE"}`,
+	} {
+		req := httptest.NewRequest("POST", "/.api/bitbucket-server-webhooks?externalServiceID=3", bytes.NewBufferString(payload))
+		req.Header.Set("X-Event-Key", "repo:permissions:changed")
+		req.Header.Set("X-Hub-Signature", sign(payload))
+		h.ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	want := []protocol.PermsSyncRequest{{UserIDs: []int32{1}, RepoIDs: []api.RepoID{12}}}
+	if !reflect.DeepEqual(*reqs, want) {
+		t.Errorf("got perms sync requests %+v, want %+v", *reqs, want)
+	}
+	if len(next.payloads) != 2 {
+		t.Errorf("got %d requests passed on, want 2", len(next.payloads))
+	}
+}
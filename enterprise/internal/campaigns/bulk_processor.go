@@ -0,0 +1,191 @@
+package campaigns
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/cmd/repo-updater/repos"
+	"github.com/sourcegraph/sourcegraph/internal/campaigns"
+	"github.com/sourcegraph/sourcegraph/internal/workerutil"
+	"github.com/sourcegraph/sourcegraph/internal/workerutil/dbworker"
+	dbworkerstore "github.com/sourcegraph/sourcegraph/internal/workerutil/dbworker/store"
+)
+
+// bulkProcessor processes the ChangesetJobs of bulk operations by applying
+// their operation to a single changeset.
+type bulkProcessor struct {
+	store   *Store
+	sourcer repos.Sourcer
+}
+
+// HandlerFunc returns a dbworker.HandlerFunc that can be passed to a
+// workerutil.Worker to process queued changeset jobs.
+func (b *bulkProcessor) HandlerFunc() dbworker.HandlerFunc {
+	return func(ctx context.Context, tx dbworkerstore.Store, record workerutil.Record) error {
+		return b.process(ctx, b.store.With(tx), record.(*campaigns.ChangesetJob))
+	}
+}
+
+// process applies the operation of the given job to its changeset. An error
+// marks the job as errored, which is how per-changeset failures of a bulk
+// operation are reported.
+func (b *bulkProcessor) process(ctx context.Context, tx *Store, job *campaigns.ChangesetJob) error {
+	ch, err := tx.GetChangeset(ctx, GetChangesetOpts{ID: job.ChangesetID})
+	if err != nil {
+		return errors.Wrap(err, "loading changeset")
+	}
+
+	// The changeset might have been detached since the job was enqueued.
+	if !changesetAttachedTo(ch, job.CampaignID) {
+		return errors.New("changeset is not attached to the campaign")
+	}
+
+	switch job.JobType {
+	case campaigns.ChangesetJobTypeComment:
+		payload, ok := job.Payload.(*campaigns.ChangesetJobCommentPayload)
+		if !ok {
+			return errors.Errorf("invalid payload for changeset job of type %s", job.JobType)
+		}
+		return b.comment(ctx, tx, ch, job.CampaignID, payload.Message)
+
+	case campaigns.ChangesetJobTypeReenqueue:
+		return b.reenqueue(ctx, tx, ch)
+
+	case campaigns.ChangesetJobTypeMerge:
+		return b.merge(ctx, tx, ch, job.CampaignID)
+
+	case campaigns.ChangesetJobTypeClose:
+		return b.close(ctx, tx, ch, job.CampaignID)
+
+	case campaigns.ChangesetJobTypeDetach:
+		return b.detach(ctx, tx, ch, job.CampaignID)
+
+	default:
+		return errors.Errorf("unknown changeset job type %q", job.JobType)
+	}
+}
+
+func (b *bulkProcessor) comment(ctx context.Context, tx *Store, ch *campaigns.Changeset, campaignID int64, message string) error {
+	// 🚨 SECURITY: Campaigns can only comment on the changesets they created,
+	// not the ones that were imported.
+	if ch.OwnedByCampaignID != campaignID {
+		return errors.New("only changesets created by the campaign can be commented on")
+	}
+
+	if !ch.Published() {
+		return errors.New("cannot comment on an unpublished changeset")
+	}
+
+	cs, ccs, err := b.changesetSource(ctx, tx, ch)
+	if err != nil {
+		return err
+	}
+
+	return ccs.CreateComment(ctx, cs, message)
+}
+
+// reenqueue resets a changeset whose publication failed, so that the
+// reconciler retries it.
+func (b *bulkProcessor) reenqueue(ctx context.Context, tx *Store, ch *campaigns.Changeset) error {
+	if ch.ReconcilerState != campaigns.ReconcilerStateErrored {
+		return errors.New("only changesets that failed to be processed can be retried")
+	}
+
+	ch.ResetQueued()
+	return tx.UpdateChangeset(ctx, ch)
+}
+
+func (b *bulkProcessor) merge(ctx context.Context, tx *Store, ch *campaigns.Changeset, campaignID int64) error {
+	// 🚨 SECURITY: Campaigns can only merge the changesets they created, not
+	// the ones that were imported.
+	if ch.OwnedByCampaignID != campaignID {
+		return errors.New("only changesets created by the campaign can be merged")
+	}
+
+	if !ch.Published() || ch.ExternalState != campaigns.ChangesetExternalStateOpen {
+		return errors.New("only open changesets can be merged")
+	}
+
+	cs, ccs, err := b.changesetSource(ctx, tx, ch)
+	if err != nil {
+		return err
+	}
+
+	if err := ccs.MergeChangeset(ctx, cs); err != nil {
+		return errors.Wrap(err, "merging changeset")
+	}
+
+	return b.syncChangeset(ctx, tx, ch)
+}
+
+func (b *bulkProcessor) close(ctx context.Context, tx *Store, ch *campaigns.Changeset, campaignID int64) error {
+	// 🚨 SECURITY: Campaigns can only close the changesets they created, not
+	// the ones that were imported.
+	if ch.OwnedByCampaignID != campaignID {
+		return errors.New("only changesets created by the campaign can be closed")
+	}
+
+	if !ch.Published() || ch.ExternalState != campaigns.ChangesetExternalStateOpen {
+		return errors.New("only open changesets can be closed")
+	}
+
+	cs, ccs, err := b.changesetSource(ctx, tx, ch)
+	if err != nil {
+		return err
+	}
+
+	if err := ccs.CloseChangeset(ctx, cs); err != nil {
+		return errors.Wrap(err, "closing changeset")
+	}
+
+	return b.syncChangeset(ctx, tx, ch)
+}
+
+func (b *bulkProcessor) detach(ctx context.Context, tx *Store, ch *campaigns.Changeset, campaignID int64) error {
+	// Changesets created by the campaign would be left open without anyone
+	// managing them, so they need to be closed or merged first.
+	if ch.OwnedByCampaignID == campaignID {
+		if !ch.Published() || ch.ExternalState == campaigns.ChangesetExternalStateOpen {
+			return errors.New("changesets created by the campaign can only be detached once they are closed or merged")
+		}
+	}
+
+	ch.RemoveCampaignID(campaignID)
+	return tx.UpdateChangeset(ctx, ch)
+}
+
+// changesetSource loads the associations of the given changeset and builds a
+// ChangesetSource for its code host.
+func (b *bulkProcessor) changesetSource(ctx context.Context, tx *Store, ch *campaigns.Changeset) (*repos.Changeset, repos.ChangesetSource, error) {
+	repo, extSvc, _, err := loadAssociations(ctx, tx, ch)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to load associations")
+	}
+
+	ccs, err := buildChangesetSource(b.sourcer, repo, extSvc)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return &repos.Changeset{Changeset: ch, Repo: repo}, ccs, nil
+}
+
+func (b *bulkProcessor) syncChangeset(ctx context.Context, tx *Store, ch *campaigns.Changeset) error {
+	rstore := repos.NewDBStore(tx.Handle().DB(), sql.TxOptions{})
+
+	if err := SyncChangesets(ctx, rstore, tx, b.sourcer, ch); err != nil {
+		return errors.Wrapf(err, "syncing changeset with external ID %q failed", ch.ExternalID)
+	}
+
+	return nil
+}
+
+func changesetAttachedTo(ch *campaigns.Changeset, campaignID int64) bool {
+	for _, id := range ch.CampaignIDs {
+		if id == campaignID {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,225 @@
+package campaigns
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
+	"github.com/sourcegraph/sourcegraph/cmd/repo-updater/repos"
+	ct "github.com/sourcegraph/sourcegraph/enterprise/internal/campaigns/testing"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/campaigns"
+	"github.com/sourcegraph/sourcegraph/internal/db/dbconn"
+	"github.com/sourcegraph/sourcegraph/internal/db/dbtesting"
+	"github.com/sourcegraph/sourcegraph/internal/repoupdater/protocol"
+)
+
+func TestBulkProcessorProcess(t *testing.T) {
+	ctx := backend.WithAuthzBypass(context.Background())
+	dbtesting.SetupGlobalTestDB(t)
+
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	clock := func() time.Time {
+		return now.UTC().Truncate(time.Microsecond)
+	}
+	store := NewStoreWithClock(dbconn.Global, clock)
+
+	admin := createTestUser(ctx, t)
+	rs, extSvc := createTestRepos(t, ctx, dbconn.Global, 1)
+
+	state := ct.MockChangesetSyncState(&protocol.RepoInfo{
+		Name: api.RepoName(rs[0].Name),
+		VCS:  protocol.VCSInfo{URL: rs[0].URI},
+	})
+	defer state.Unmock()
+
+	spec := createCampaignSpec(t, ctx, store, "bulk", admin.ID)
+	campaign := createCampaign(t, ctx, store, "bulk", admin.ID, spec.ID)
+
+	tests := map[string]struct {
+		changeset testChangesetOpts
+		job       *campaigns.ChangesetJob
+
+		sourcerMetadata interface{}
+
+		wantErr             bool
+		wantComment         string
+		wantMerged          bool
+		wantClosed          bool
+		wantReconcilerState campaigns.ReconcilerState
+		wantDetached        bool
+	}{
+		"comment": {
+			changeset: testChangesetOpts{
+				publicationState: campaigns.ChangesetPublicationStatePublished,
+				externalState:    campaigns.ChangesetExternalStateOpen,
+				ownedByCampaign:  campaign.ID,
+			},
+			job: &campaigns.ChangesetJob{
+				JobType: campaigns.ChangesetJobTypeComment,
+				Payload: &campaigns.ChangesetJobCommentPayload{Message: "hello"},
+			},
+			wantComment: "hello",
+		},
+		"comment on imported changeset": {
+			changeset: testChangesetOpts{
+				publicationState: campaigns.ChangesetPublicationStatePublished,
+				externalState:    campaigns.ChangesetExternalStateOpen,
+			},
+			job: &campaigns.ChangesetJob{
+				JobType: campaigns.ChangesetJobTypeComment,
+				Payload: &campaigns.ChangesetJobCommentPayload{Message: "hello"},
+			},
+			wantErr: true,
+		},
+		"comment on unpublished changeset": {
+			changeset: testChangesetOpts{
+				publicationState: campaigns.ChangesetPublicationStateUnpublished,
+				ownedByCampaign:  campaign.ID,
+			},
+			job: &campaigns.ChangesetJob{
+				JobType: campaigns.ChangesetJobTypeComment,
+				Payload: &campaigns.ChangesetJobCommentPayload{Message: "hello"},
+			},
+			wantErr: true,
+		},
+		"reenqueue failed changeset": {
+			changeset: testChangesetOpts{
+				publicationState: campaigns.ChangesetPublicationStateUnpublished,
+				reconcilerState:  campaigns.ReconcilerStateErrored,
+				failureMessage:   "publishing failed",
+				numFailures:      5,
+			},
+			job:                 &campaigns.ChangesetJob{JobType: campaigns.ChangesetJobTypeReenqueue},
+			wantReconcilerState: campaigns.ReconcilerStateQueued,
+		},
+		"reenqueue completed changeset": {
+			changeset: testChangesetOpts{
+				publicationState: campaigns.ChangesetPublicationStatePublished,
+				reconcilerState:  campaigns.ReconcilerStateCompleted,
+			},
+			job:     &campaigns.ChangesetJob{JobType: campaigns.ChangesetJobTypeReenqueue},
+			wantErr: true,
+		},
+		"merge": {
+			changeset: testChangesetOpts{
+				publicationState: campaigns.ChangesetPublicationStatePublished,
+				externalState:    campaigns.ChangesetExternalStateOpen,
+				ownedByCampaign:  campaign.ID,
+			},
+			job:             &campaigns.ChangesetJob{JobType: campaigns.ChangesetJobTypeMerge},
+			sourcerMetadata: buildGithubPR(clock(), "OPEN"),
+			wantMerged:      true,
+		},
+		"merge imported changeset": {
+			changeset: testChangesetOpts{
+				publicationState: campaigns.ChangesetPublicationStatePublished,
+				externalState:    campaigns.ChangesetExternalStateOpen,
+			},
+			job:     &campaigns.ChangesetJob{JobType: campaigns.ChangesetJobTypeMerge},
+			wantErr: true,
+		},
+		"merge closed changeset": {
+			changeset: testChangesetOpts{
+				publicationState: campaigns.ChangesetPublicationStatePublished,
+				externalState:    campaigns.ChangesetExternalStateClosed,
+				ownedByCampaign:  campaign.ID,
+			},
+			job:     &campaigns.ChangesetJob{JobType: campaigns.ChangesetJobTypeMerge},
+			wantErr: true,
+		},
+		"close owned changeset": {
+			changeset: testChangesetOpts{
+				publicationState: campaigns.ChangesetPublicationStatePublished,
+				externalState:    campaigns.ChangesetExternalStateOpen,
+				ownedByCampaign:  campaign.ID,
+			},
+			job:             &campaigns.ChangesetJob{JobType: campaigns.ChangesetJobTypeClose},
+			sourcerMetadata: buildGithubPR(clock(), "CLOSED"),
+			wantClosed:      true,
+		},
+		"close imported changeset": {
+			changeset: testChangesetOpts{
+				publicationState: campaigns.ChangesetPublicationStatePublished,
+				externalState:    campaigns.ChangesetExternalStateOpen,
+			},
+			job:     &campaigns.ChangesetJob{JobType: campaigns.ChangesetJobTypeClose},
+			wantErr: true,
+		},
+		"detach imported changeset": {
+			changeset: testChangesetOpts{
+				publicationState: campaigns.ChangesetPublicationStatePublished,
+				externalState:    campaigns.ChangesetExternalStateOpen,
+			},
+			job:          &campaigns.ChangesetJob{JobType: campaigns.ChangesetJobTypeDetach},
+			wantDetached: true,
+		},
+		"detach open owned changeset": {
+			changeset: testChangesetOpts{
+				publicationState: campaigns.ChangesetPublicationStatePublished,
+				externalState:    campaigns.ChangesetExternalStateOpen,
+				ownedByCampaign:  campaign.ID,
+			},
+			job:     &campaigns.ChangesetJob{JobType: campaigns.ChangesetJobTypeDetach},
+			wantErr: true,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			tc.changeset.repo = rs[0].ID
+			tc.changeset.campaign = campaign.ID
+			tc.changeset.externalID = name
+			changeset := createChangeset(t, ctx, store, tc.changeset)
+
+			tc.job.BulkGroup = name
+			tc.job.UserID = admin.ID
+			tc.job.CampaignID = campaign.ID
+			tc.job.ChangesetID = changeset.ID
+
+			fakeSource := &ct.FakeChangesetSource{Svc: extSvc, FakeMetadata: tc.sourcerMetadata}
+			b := bulkProcessor{store: store, sourcer: repos.NewFakeSourcer(nil, fakeSource)}
+
+			err := b.process(ctx, store, tc.job)
+			if tc.wantErr {
+				if err == nil {
+					t.Fatal("expected error but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("bulk processor process failed: %s", err)
+			}
+
+			if tc.wantComment != "" {
+				if len(fakeSource.Comments) != 1 || fakeSource.Comments[0] != tc.wantComment {
+					t.Fatalf("wrong comments. want=%q, have=%q", tc.wantComment, fakeSource.Comments)
+				}
+			}
+			if have, want := fakeSource.MergeChangesetCalled, tc.wantMerged; have != want {
+				t.Fatalf("wrong MergeChangeset call. wantCalled=%t, wasCalled=%t", want, have)
+			}
+			if have, want := fakeSource.CloseChangesetCalled, tc.wantClosed; have != want {
+				t.Fatalf("wrong CloseChangeset call. wantCalled=%t, wasCalled=%t", want, have)
+			}
+
+			reloaded, err := store.GetChangeset(ctx, GetChangesetOpts{ID: changeset.ID})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if tc.wantReconcilerState != "" {
+				if have, want := reloaded.ReconcilerState, tc.wantReconcilerState; have != want {
+					t.Fatalf("wrong reconciler state. want=%s, have=%s", want, have)
+				}
+				if reloaded.NumFailures != 0 || reloaded.FailureMessage != nil {
+					t.Fatalf("failures not reset: %d, %v", reloaded.NumFailures, reloaded.FailureMessage)
+				}
+			}
+			if have, want := !changesetAttachedTo(reloaded, campaign.ID), tc.wantDetached; have != want {
+				t.Fatalf("wrong detached state. want=%t, have=%t", want, have)
+			}
+		})
+	}
+}
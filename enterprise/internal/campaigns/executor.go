@@ -0,0 +1,401 @@
+package campaigns
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/cmd/repo-updater/repos"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/campaigns"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/tar"
+	"github.com/sourcegraph/sourcegraph/internal/workerutil"
+	"github.com/sourcegraph/sourcegraph/internal/workerutil/dbworker"
+	dbworkerstore "github.com/sourcegraph/sourcegraph/internal/workerutil/dbworker/store"
+)
+
+// ArchiveClient is the subset of the gitserver client used by the executor to
+// fetch the contents of a repository.
+type ArchiveClient interface {
+	Archive(ctx context.Context, repo gitserver.Repo, opt gitserver.ArchiveOptions) (io.ReadCloser, error)
+}
+
+// executor processes campaign spec executions: it runs the steps of a
+// campaign spec over a single repository and turns the resulting diff into a
+// changeset spec that is attached to the campaign spec.
+type executor struct {
+	store         *Store
+	archiveClient ArchiveClient
+	runner        StepRunner
+}
+
+// HandlerFunc returns a dbworker.HandlerFunc that can be passed to a
+// workerutil.Worker to process queued campaign spec executions.
+func (e *executor) HandlerFunc() dbworker.HandlerFunc {
+	return func(ctx context.Context, tx dbworkerstore.Store, record workerutil.Record) error {
+		return e.process(ctx, e.store.With(tx), record.(*campaigns.CampaignSpecExecution))
+	}
+}
+
+func (e *executor) process(ctx context.Context, tx *Store, ex *campaigns.CampaignSpecExecution) error {
+	campaignSpec, err := tx.GetCampaignSpec(ctx, GetCampaignSpecOpts{ID: ex.CampaignSpecID})
+	if err != nil {
+		return errors.Wrap(err, "failed to load campaign spec")
+	}
+
+	reposStore := repos.NewDBStore(tx.Handle().DB(), sql.TxOptions{})
+	repo, err := loadRepo(ctx, reposStore, ex.RepoID)
+	if err != nil {
+		return errors.Wrap(err, "failed to load repository")
+	}
+	repoName := api.RepoName(repo.Name)
+
+	entry, cacheHit, err := e.runSteps(ctx, tx, campaignSpec, ex, repoName)
+	if err != nil {
+		return err
+	}
+	ex.CacheHit = cacheHit
+
+	if err := attachDiff(ctx, tx, campaignSpec, ex, repoName, entry); err != nil {
+		return err
+	}
+	return tx.UpdateCampaignSpecExecution(ctx, ex)
+}
+
+// templateRepository returns the repository metadata that the templates of a
+// campaign spec are evaluated with for the given execution.
+func templateRepository(repoName api.RepoName, ex *campaigns.CampaignSpecExecution) campaigns.TemplateRepository {
+	return campaigns.TemplateRepository{
+		Name:              string(repoName),
+		Branch:            strings.TrimPrefix(ex.BaseRef, "refs/heads/"),
+		SearchResultPaths: ex.SearchResultPaths,
+	}
+}
+
+// runSteps returns the diff and the outputs produced by the steps of the
+// given campaign spec in the repository and at the revision of the given
+// execution. If the steps were already run on that revision, they are taken
+// from the step cache and the returned cacheHit flag is true. Otherwise the
+// steps are run and their results are added to the cache.
+func (e *executor) runSteps(ctx context.Context, tx *Store, campaignSpec *campaigns.CampaignSpec, ex *campaigns.CampaignSpecExecution, repoName api.RepoName) (_ *campaigns.CampaignStepCacheEntry, cacheHit bool, err error) {
+	repo := templateRepository(repoName, ex)
+
+	stepsHash, err := campaigns.CampaignSpecStepsHash(campaignSpec.Spec.Steps, repo)
+	if err != nil {
+		return nil, false, err
+	}
+
+	entry, err := tx.GetCampaignStepCacheEntry(ctx, GetCampaignStepCacheEntryOpts{
+		RepoID:    ex.RepoID,
+		BaseRev:   ex.BaseRev,
+		StepsHash: stepsHash,
+	})
+	if err == nil {
+		return entry, true, nil
+	}
+	if err != ErrNoResults {
+		return nil, false, errors.Wrap(err, "failed to query step cache")
+	}
+
+	workspace, err := e.fetchWorkspace(ctx, repoName, ex.BaseRev)
+	if err != nil {
+		return nil, false, errors.Wrap(err, "failed to fetch repository")
+	}
+	defer os.RemoveAll(workspace)
+
+	c := &campaigns.TemplateContext{Repository: repo, Outputs: map[string]interface{}{}}
+	for i, step := range campaignSpec.Spec.Steps {
+		run, err := step.ShouldRun(c)
+		if err != nil {
+			return nil, false, errors.Wrapf(err, "failed to evaluate condition of step %d", i+1)
+		}
+		if !run {
+			continue
+		}
+
+		rendered, err := step.Render(c)
+		if err != nil {
+			return nil, false, errors.Wrapf(err, "failed to render step %d", i+1)
+		}
+
+		result, err := e.runner.Run(ctx, workspace, rendered)
+		if err != nil {
+			return nil, false, errors.Wrapf(err, "step %d failed", i+1)
+		}
+
+		c.Step = result
+		if err := step.SetOutputs(c); err != nil {
+			return nil, false, errors.Wrapf(err, "failed to evaluate outputs of step %d", i+1)
+		}
+		c.PreviousStep = result
+	}
+
+	diff, err := workspaceDiff(ctx, workspace)
+	if err != nil {
+		return nil, false, errors.Wrap(err, "failed to compute diff")
+	}
+
+	entry = &campaigns.CampaignStepCacheEntry{
+		RepoID:    ex.RepoID,
+		BaseRev:   ex.BaseRev,
+		StepsHash: stepsHash,
+		Diff:      diff,
+		Outputs:   c.Outputs,
+	}
+	if err := tx.UpsertCampaignStepCacheEntry(ctx, entry); err != nil {
+		return nil, false, errors.Wrap(err, "failed to update step cache")
+	}
+
+	return entry, false, nil
+}
+
+// attachDiff creates ChangesetSpecs for the diff of the given step results,
+// attaches them to the campaign spec and records them on the given execution.
+// The changes are split into several ChangesetSpecs if the campaign spec
+// groups them with transformChanges. Steps that didn't change anything don't
+// result in a ChangesetSpec.
+func attachDiff(ctx context.Context, tx *Store, campaignSpec *campaigns.CampaignSpec, ex *campaigns.CampaignSpecExecution, repoName api.RepoName, entry *campaigns.CampaignStepCacheEntry) error {
+	if len(entry.Diff) == 0 {
+		return nil
+	}
+
+	c := &campaigns.TemplateContext{
+		Repository: templateRepository(repoName, ex),
+		Outputs:    entry.Outputs,
+	}
+
+	tmpl, err := campaignSpec.Spec.ChangesetTemplate.ForRepository(string(repoName)).Render(c)
+	if err != nil {
+		return errors.Wrap(err, "failed to render changeset template")
+	}
+
+	groups := campaignSpec.Spec.TransformChanges.GroupsForRepository(string(repoName))
+	branches := make([]string, len(groups))
+	for i, g := range groups {
+		if branches[i], err = campaigns.RenderTemplate("branch", g.Branch, c); err != nil {
+			return errors.Wrap(err, "failed to render branch of transformChanges group")
+		}
+	}
+
+	ex.ChangesetSpecIDs = nil
+	for _, d := range groupDiff(entry.Diff, tmpl.Branch, groups, branches) {
+		t := tmpl
+		t.Branch = d.branch
+
+		spec, err := buildChangesetSpec(campaignSpec, ex, t, d.diff)
+		if err != nil {
+			return errors.Wrap(err, "failed to build changeset spec")
+		}
+		if err := tx.CreateChangesetSpec(ctx, spec); err != nil {
+			return err
+		}
+
+		ex.ChangesetSpecIDs = append(ex.ChangesetSpecIDs, spec.ID)
+	}
+
+	return nil
+}
+
+// branchDiff is the part of a diff that is proposed on a single branch.
+type branchDiff struct {
+	branch string
+	diff   string
+}
+
+// groupDiff splits the given diff into the changes on the default branch and
+// the changes in the directories of the given transformChanges groups, whose
+// rendered branches are passed in branches. Files are assigned to the group
+// with the most specific directory that contains them. Branches without any
+// changes are omitted, and the changes of groups with the same branch are
+// combined.
+func groupDiff(diff, defaultBranch string, groups []campaigns.TransformChangesGroup, branches []string) []branchDiff {
+	var (
+		result  = []branchDiff{{branch: defaultBranch}}
+		indexes = map[string]int{defaultBranch: 0}
+	)
+	for _, b := range branches {
+		if _, ok := indexes[b]; !ok {
+			indexes[b] = len(result)
+			result = append(result, branchDiff{branch: b})
+		}
+	}
+
+	for _, file := range splitFileDiffs(diff) {
+		branch, longest := defaultBranch, -1
+		for i, g := range groups {
+			dir := strings.Trim(g.Directory, "/")
+			if (file.path == dir || strings.HasPrefix(file.path, dir+"/")) && len(dir) > longest {
+				branch, longest = branches[i], len(dir)
+			}
+		}
+		result[indexes[branch]].diff += file.diff
+	}
+
+	nonEmpty := result[:0]
+	for _, d := range result {
+		if d.diff != "" {
+			nonEmpty = append(nonEmpty, d)
+		}
+	}
+	return nonEmpty
+}
+
+// fileDiff is the diff of a single file in the output of git diff.
+type fileDiff struct {
+	path string
+	diff string
+}
+
+const fileDiffHeader = "diff --git "
+
+// splitFileDiffs splits the output of git diff into the diffs of the
+// individual files. The path of a file is taken from the b/ side of its
+// header.
+func splitFileDiffs(diff string) []fileDiff {
+	var files []fileDiff
+	for len(diff) > 0 {
+		end := strings.Index(diff[len(fileDiffHeader):], "\n"+fileDiffHeader)
+		if end == -1 {
+			end = len(diff)
+		} else {
+			end += len(fileDiffHeader) + 1
+		}
+
+		chunk := diff[:end]
+		diff = diff[end:]
+
+		header := chunk
+		if i := strings.IndexByte(header, '\n'); i != -1 {
+			header = header[:i]
+		}
+		path := header
+		if i := strings.LastIndex(header, " b/"); i != -1 {
+			path = header[i+len(" b/"):]
+		}
+
+		files = append(files, fileDiff{path: path, diff: chunk})
+	}
+	return files
+}
+
+// The author of the commits created by the executor.
+const (
+	executorAuthorName  = "Sourcegraph"
+	executorAuthorEmail = "campaigns@sourcegraph.com"
+)
+
+// workspaceGitArgs are the arguments passed to every git invocation in a
+// workspace, so that the executor doesn't depend on the git configuration of
+// the host.
+var workspaceGitArgs = []string{
+	"-c", "user.name=" + executorAuthorName,
+	"-c", "user.email=" + executorAuthorEmail,
+	"-c", "core.autocrlf=false",
+}
+
+// fetchWorkspace creates a temporary directory, extracts an archive of the
+// given repository at the given revision into it and commits its contents, so
+// that changes made by the steps can be diffed against the base revision. If
+// there is an error, the temporary directory is removed.
+func (e *executor) fetchWorkspace(ctx context.Context, repoName api.RepoName, rev string) (_ string, err error) {
+	tempDir, err := ioutil.TempDir("", "campaign-spec-execution")
+	if err != nil {
+		return "", err
+	}
+	defer func() {
+		if err != nil {
+			_ = os.RemoveAll(tempDir)
+		}
+	}()
+
+	archive, err := e.archiveClient.Archive(ctx, gitserver.Repo{Name: repoName}, gitserver.ArchiveOptions{
+		Treeish: rev,
+		Format:  "tar",
+	})
+	if err != nil {
+		return "", err
+	}
+	defer archive.Close()
+
+	if err := tar.Extract(tempDir, archive); err != nil {
+		return "", errors.Wrap(err, "failed to extract archive")
+	}
+
+	commands := [][]string{
+		{"init", "--quiet"},
+		{"add", "--all"},
+		{"commit", "--quiet", "--allow-empty", "--no-verify", "-m", fmt.Sprintf("%s@%s", repoName, rev)},
+	}
+
+	for _, args := range commands {
+		if err := runCommand(ctx, tempDir, nil, "git", append(workspaceGitArgs, args...)...); err != nil {
+			return "", errors.Wrap(err, fmt.Sprintf("failed `git %s`", strings.Join(args, " ")))
+		}
+	}
+
+	return tempDir, nil
+}
+
+// workspaceDiff returns the diff between the base revision committed by
+// fetchWorkspace and the current contents of the workspace.
+func workspaceDiff(ctx context.Context, workspace string) (string, error) {
+	if err := runCommand(ctx, workspace, nil, "git", append(workspaceGitArgs, "add", "--all")...); err != nil {
+		return "", err
+	}
+
+	out, err := runCommandOutput(ctx, workspace, nil, "git", append(workspaceGitArgs, "diff", "--cached", "--binary")...)
+	if err != nil {
+		return "", err
+	}
+
+	return string(out), nil
+}
+
+// buildChangesetSpec builds a ChangesetSpec for the given execution from the
+// given rendered changeset template and diff.
+func buildChangesetSpec(campaignSpec *campaigns.CampaignSpec, ex *campaigns.CampaignSpecExecution, tmpl campaigns.ChangesetTemplate, diff string) (*campaigns.ChangesetSpec, error) {
+	repoGraphQLID := graphqlbackend.MarshalRepositoryID(ex.RepoID)
+
+	// We can't marshal a ChangesetSpecDescription here, since its fields are
+	// tagged with omitempty but the schema requires all of them to be set,
+	// even if they are empty or false.
+	rawSpec, err := json.Marshal(map[string]interface{}{
+		"baseRepository": repoGraphQLID,
+		"baseRev":        ex.BaseRev,
+		"baseRef":        ex.BaseRef,
+		"headRepository": repoGraphQLID,
+		"headRef":        "refs/heads/" + tmpl.Branch,
+		"title":          tmpl.Title,
+		"body":           tmpl.Body,
+		"commits": []campaigns.GitCommitDescription{
+			{
+				Message:     tmpl.Commit.Message,
+				Diff:        diff,
+				AuthorName:  executorAuthorName,
+				AuthorEmail: executorAuthorEmail,
+			},
+		},
+		"published": tmpl.Published,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	spec, err := campaigns.NewChangesetSpecFromRaw(string(rawSpec))
+	if err != nil {
+		return nil, err
+	}
+	spec.CampaignSpecID = campaignSpec.ID
+	spec.RepoID = ex.RepoID
+	spec.UserID = ex.UserID
+
+	return spec, nil
+}
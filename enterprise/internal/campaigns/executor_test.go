@@ -0,0 +1,355 @@
+package campaigns
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/campaigns"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+type fakeArchiveClient struct {
+	files map[string]string
+
+	repo gitserver.Repo
+	opts gitserver.ArchiveOptions
+}
+
+func (c *fakeArchiveClient) Archive(ctx context.Context, repo gitserver.Repo, opts gitserver.ArchiveOptions) (io.ReadCloser, error) {
+	c.repo = repo
+	c.opts = opts
+
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	for name, contents := range c.files {
+		hdr := &tar.Header{Name: name, Mode: 0644, Size: int64(len(contents)), Typeflag: tar.TypeReg}
+		if err := tw.WriteHeader(hdr); err != nil {
+			return nil, err
+		}
+		if _, err := tw.Write([]byte(contents)); err != nil {
+			return nil, err
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return nil, err
+	}
+
+	return ioutil.NopCloser(&buf), nil
+}
+
+func TestExecutorWorkspace(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+
+	ctx := context.Background()
+	archiveClient := &fakeArchiveClient{files: map[string]string{
+		"README.md": "# Hello World\n",
+		"main.go":   "package main\n",
+	}}
+	e := &executor{archiveClient: archiveClient, runner: ProcessStepRunner}
+
+	workspace, err := e.fetchWorkspace(ctx, api.RepoName("github.com/sourcegraph/sourcegraph"), "d34db33f")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(workspace)
+
+	if have, want := archiveClient.repo.Name, api.RepoName("github.com/sourcegraph/sourcegraph"); have != want {
+		t.Errorf("unexpected repo name. have=%q want=%q", have, want)
+	}
+	if have, want := archiveClient.opts.Treeish, "d34db33f"; have != want {
+		t.Errorf("unexpected treeish. have=%q want=%q", have, want)
+	}
+
+	// An untouched workspace has no diff.
+	diff, err := workspaceDiff(ctx, workspace)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff != "" {
+		t.Fatalf("unexpected diff for untouched workspace: %q", diff)
+	}
+
+	steps := []campaigns.CampaignSpecStep{
+		{Run: `echo "$GREETING" >> README.md`, Env: map[string]string{"GREETING": "Hello Campaigns"}},
+		{Run: "rm main.go && touch new.txt"},
+	}
+	for _, step := range steps {
+		if _, err := e.runner.Run(ctx, workspace, step); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	diff, err = workspaceDiff(ctx, workspace)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, want := range []string{
+		"+++ b/README.md",
+		"+Hello Campaigns",
+		"deleted file mode 100644",
+		"--- a/main.go",
+		"new file mode 100644",
+	} {
+		if !strings.Contains(diff, want) {
+			t.Errorf("expected diff to contain %q:\n%s", want, diff)
+		}
+	}
+}
+
+func TestExecutorStepFailure(t *testing.T) {
+	workspace, err := ioutil.TempDir("", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(workspace)
+
+	_, err = ProcessStepRunner.Run(context.Background(), workspace, campaigns.CampaignSpecStep{Run: "echo oh no && exit 1"})
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	if !strings.Contains(err.Error(), "oh no") {
+		t.Errorf("expected error to contain the output of the step, got %q", err)
+	}
+}
+
+func TestExecutorStepResult(t *testing.T) {
+	workspace, err := ioutil.TempDir("", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(workspace)
+
+	result, err := ProcessStepRunner.Run(context.Background(), workspace, campaigns.CampaignSpecStep{Run: "echo out && echo err >&2"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := (campaigns.StepResult{Stdout: "out\n", Stderr: "err\n"}); result != want {
+		t.Errorf("unexpected result. have=%+v want=%+v", result, want)
+	}
+}
+
+func TestVMStepRunner(t *testing.T) {
+	if _, err := exec.LookPath("tar"); err != nil {
+		t.Skip("tar is not installed")
+	}
+
+	workspace, err := ioutil.TempDir("", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(workspace)
+	if err := ioutil.WriteFile(filepath.Join(workspace, "main.go"), []byte("package main\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// The workspace inside of the virtual machine after running the step.
+	var archive bytes.Buffer
+	tw := tar.NewWriter(&archive)
+	contents := "Hello Campaigns\n"
+	if err := tw.WriteHeader(&tar.Header{Name: "README.md", Mode: 0644, Size: int64(len(contents)), Typeflag: tar.TypeReg}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := tw.Write([]byte(contents)); err != nil {
+		t.Fatal(err)
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	var (
+		commands [][]string
+		envFile  string
+	)
+	runner := &vmStepRunner{runCommand: func(ctx context.Context, dir string, env []string, command string, args ...string) ([]byte, []byte, error) {
+		if command == "tar" {
+			return runCommandStreams(ctx, dir, env, command, args...)
+		}
+		commands = append(commands, append([]string{command}, args...))
+
+		switch {
+		case args[0] == "run":
+			for i, arg := range args {
+				if arg == "--copy-files" && strings.HasSuffix(args[i+1], ":"+vmStepDir) {
+					data, err := ioutil.ReadFile(filepath.Join(strings.TrimSuffix(args[i+1], ":"+vmStepDir), "env"))
+					if err != nil {
+						return nil, nil, err
+					}
+					envFile = string(data)
+				}
+			}
+		case args[0] == "exec" && args[3] == "docker" && args[4] == "run":
+			return []byte("out\n"), []byte("err\n"), nil
+		case args[0] == "exec" && args[3] == "tar":
+			return archive.Bytes(), nil, nil
+		}
+		return nil, nil, nil
+	}}
+
+	step := campaigns.CampaignSpecStep{
+		Run:       `echo "$GREETING" > README.md && rm main.go`,
+		Container: "alpine:3",
+		Env:       map[string]string{"GREETING": "Hello Campaigns"},
+	}
+
+	if _, err := runner.Run(context.Background(), workspace, step); err != ErrServerSideExecutionDisabled {
+		t.Fatalf("unexpected error with server-side execution disabled: %v", err)
+	}
+	if len(commands) != 0 {
+		t.Fatalf("unexpected commands with server-side execution disabled: %v", commands)
+	}
+
+	conf.Mock(&conf.Unified{SiteConfiguration: schema.SiteConfiguration{
+		CampaignsServerSideExecution: &schema.CampaignsServerSideExecution{Enabled: true, Cpus: 2, Memory: "4G"},
+	}})
+	defer conf.Mock(nil)
+
+	if _, err := runner.Run(context.Background(), workspace, campaigns.CampaignSpecStep{Run: "true", Container: "alpine; rm -rf /"}); err == nil {
+		t.Fatal("expected error for container image with shell metacharacters")
+	}
+
+	result, err := runner.Run(context.Background(), workspace, step)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := (campaigns.StepResult{Stdout: "out\n", Stderr: "err\n"}); result != want {
+		t.Errorf("unexpected result. have=%+v want=%+v", result, want)
+	}
+	if have, want := envFile, "GREETING=Hello Campaigns"; have != want {
+		t.Errorf("unexpected env file. have=%q want=%q", have, want)
+	}
+
+	var dockerRun []string
+	for _, command := range commands {
+		if len(command) > 5 && command[4] == "docker" && command[5] == "run" {
+			dockerRun = command
+		}
+	}
+	for _, want := range []string{"--network none", "--cpus 2", "--memory 4G", "--env-file /step/env", "alpine:3 /step/run.sh"} {
+		if !strings.Contains(strings.Join(dockerRun, " "), want) {
+			t.Errorf("expected step container to be run with %q, got %v", want, dockerRun)
+		}
+	}
+	if have, want := commands[len(commands)-1][1:3], []string{"rm", "-f"}; !cmp.Equal(have, want) {
+		t.Errorf("expected firecracker vm to be removed, got %v", commands[len(commands)-1])
+	}
+
+	if data, err := ioutil.ReadFile(filepath.Join(workspace, "README.md")); err != nil || string(data) != contents {
+		t.Errorf("unexpected README.md in workspace. have=%q (err=%v) want=%q", data, err, contents)
+	}
+	if _, err := os.Stat(filepath.Join(workspace, "main.go")); !os.IsNotExist(err) {
+		t.Errorf("expected main.go to be deleted from workspace, got %v", err)
+	}
+}
+
+func TestProcessStepRunnerEnv(t *testing.T) {
+	os.Setenv("CAMPAIGNS_TEST_SECRET", "hunter2")
+	defer os.Unsetenv("CAMPAIGNS_TEST_SECRET")
+
+	workspace, err := ioutil.TempDir("", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(workspace)
+
+	step := campaigns.CampaignSpecStep{Run: `echo "$GREETING:$CAMPAIGNS_TEST_SECRET"`, Env: map[string]string{"GREETING": "hello"}}
+	result, err := ProcessStepRunner.Run(context.Background(), workspace, step)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if have, want := result.Stdout, "hello:\n"; have != want {
+		t.Errorf("unexpected output. have=%q want=%q", have, want)
+	}
+}
+
+func TestBuildChangesetSpec(t *testing.T) {
+	campaignSpec := &campaigns.CampaignSpec{
+		ID: 42,
+		Spec: campaigns.CampaignSpecFields{
+			ChangesetTemplate: campaigns.ChangesetTemplate{
+				Title:  "Hello World",
+				Branch: "hello-world",
+				Commit: campaigns.CommitTemplate{Message: "Say hello"},
+			},
+		},
+	}
+	ex := &campaigns.CampaignSpecExecution{
+		RepoID:  api.RepoID(5),
+		BaseRef: "refs/heads/master",
+		BaseRev: "d34db33f",
+		UserID:  1234,
+	}
+	diff := "diff --git a/README.md b/README.md\n--- a/README.md\n+++ b/README.md\n@@ -1 +1,2 @@\n # Hello World\n+Hello Campaigns\n"
+
+	spec, err := buildChangesetSpec(campaignSpec, ex, campaignSpec.Spec.ChangesetTemplate, diff)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if spec.CampaignSpecID != 42 || spec.RepoID != 5 || spec.UserID != 1234 {
+		t.Errorf("unexpected associations: %+v", spec)
+	}
+	if have, want := spec.Spec.HeadRef, "refs/heads/hello-world"; have != want {
+		t.Errorf("unexpected head ref. have=%q want=%q", have, want)
+	}
+	if have, want := spec.Spec.Published.False(), true; have != want {
+		t.Errorf("unexpected published. have=%v want=%v", have, want)
+	}
+	if have, want := spec.DiffStatAdded, int32(1); have != want {
+		t.Errorf("unexpected diff stat added. have=%d want=%d", have, want)
+	}
+}
+
+func TestGroupDiff(t *testing.T) {
+	fileDiff := func(path string) string {
+		return fmt.Sprintf("diff --git a/%[1]s b/%[1]s\n--- a/%[1]s\n+++ b/%[1]s\n@@ -1 +1 @@\n-foo\n+bar\n", path)
+	}
+	diff := fileDiff("README.md") + fileDiff("docs/README.md") + fileDiff("docs/api/README.md") + fileDiff("docsite/main.go")
+
+	groups := []campaigns.TransformChangesGroup{
+		{Directory: "docs", Branch: "ignored"},
+		{Directory: "/docs/api/", Branch: "ignored"},
+		{Directory: "client", Branch: "ignored"},
+	}
+	branches := []string{"docs", "api-docs", "client"}
+
+	have := groupDiff(diff, "main", groups, branches)
+	want := []branchDiff{
+		{branch: "main", diff: fileDiff("README.md") + fileDiff("docsite/main.go")},
+		{branch: "docs", diff: fileDiff("docs/README.md")},
+		{branch: "api-docs", diff: fileDiff("docs/api/README.md")},
+	}
+	if diff := cmp.Diff(want, have, cmp.AllowUnexported(branchDiff{})); diff != "" {
+		t.Fatalf("unexpected diffs (-want +got):\n%s", diff)
+	}
+
+	// Groups with the default branch are merged into the default changeset.
+	have = groupDiff(diff, "main", groups[:1], []string{"main"})
+	want = []branchDiff{{branch: "main", diff: diff}}
+	if diff := cmp.Diff(want, have, cmp.AllowUnexported(branchDiff{})); diff != "" {
+		t.Fatalf("unexpected diffs (-want +got):\n%s", diff)
+	}
+
+	// Without groups, the whole diff is proposed on the default branch.
+	have = groupDiff(diff, "main", nil, nil)
+	want = []branchDiff{{branch: "main", diff: diff}}
+	if diff := cmp.Diff(want, have, cmp.AllowUnexported(branchDiff{})); diff != "" {
+		t.Fatalf("unexpected diffs (-want +got):\n%s", diff)
+	}
+}
@@ -29,6 +29,11 @@ func TestIntegration(t *testing.T) {
 		t.Run("ListChangesetSyncData", storeTest(db, testStoreListChangesetSyncData))
 		t.Run("CampaignSpecs", storeTest(db, testStoreCampaignSpecs))
 		t.Run("ChangesetSpecs", storeTest(db, testStoreChangesetSpecs))
+		t.Run("CampaignSpecExecutions", storeTest(db, testStoreCampaignSpecExecutions))
+		t.Run("CampaignStepCache", storeTest(db, testStoreCampaignStepCache))
+		t.Run("ChangesetJobs", storeTest(db, testStoreChangesetJobs))
+		t.Run("CampaignSnapshots", storeTest(db, testStoreCampaignSnapshots))
+		t.Run("CampaignCredentials", storeTest(db, testStoreCampaignCredentials))
 	})
 
 	t.Run("GitHubWebhook", testGitHubWebhook(db, userID))
@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/inconshreveable/log15"
+	"github.com/sourcegraph/sourcegraph/internal/secrets"
 )
 
 func TestMain(m *testing.M) {
@@ -13,5 +14,9 @@ func TestMain(m *testing.M) {
 	if !testing.Verbose() {
 		log15.Root().SetHandler(log15.DiscardHandler())
 	}
+	if err := secrets.Init(); err != nil {
+		log15.Crit("initializing secrets", "error", err)
+		os.Exit(1)
+	}
 	os.Exit(m.Run())
 }
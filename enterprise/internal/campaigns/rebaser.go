@@ -0,0 +1,181 @@
+package campaigns
+
+import (
+	"context"
+	"time"
+
+	"github.com/inconshreveable/log15"
+	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/campaigns"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/protocol"
+	"github.com/sourcegraph/sourcegraph/internal/vcs/git"
+)
+
+// changesetRebaserInterval is the interval in which the changeset rebaser
+// checks whether the base branches of changesets have moved.
+const changesetRebaserInterval = 5 * time.Minute
+
+// RunChangesetRebaser periodically checks whether the base branches of the
+// open changesets created by campaigns have moved. If they have, it re-applies
+// the patch of each changeset on the new head of its base branch and force
+// pushes the resulting commit. Changesets whose patch doesn't apply anymore
+// are marked as conflicting. It blocks until ctx is canceled.
+func RunChangesetRebaser(ctx context.Context, s *Store, gitClient GitserverClient, syncRegistry *SyncRegistry) {
+	r := &changesetRebaser{store: s, gitserverClient: gitClient}
+
+	t := time.NewTicker(changesetRebaserInterval)
+	defer t.Stop()
+
+	for {
+		rebased, err := r.rebaseAll(ctx)
+		if err != nil {
+			log15.Error("Rebasing changesets", "error", err)
+		}
+
+		// Sync the rebased changesets, so that they reflect the new commits.
+		if len(rebased) > 0 {
+			if err := syncRegistry.EnqueueChangesetSyncs(ctx, rebased); err != nil {
+				log15.Error("EnqueueChangesetSyncs", "error", err)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+		}
+	}
+}
+
+// changesetRebaser re-applies the patches of changesets created by campaigns
+// when their base branches move.
+type changesetRebaser struct {
+	store           *Store
+	gitserverClient GitserverClient
+}
+
+// rebaseAll rebases all open changesets created by campaigns whose base
+// branches have moved and returns the IDs of the changesets for which a new
+// commit was pushed.
+func (r *changesetRebaser) rebaseAll(ctx context.Context) ([]int64, error) {
+	open := campaigns.ChangesetExternalStateOpen
+	cs, _, err := r.store.ListChangesets(ctx, ListChangesetsOpts{
+		OnlyPublished:    true,
+		WithoutDeleted:   true,
+		ExternalState:    &open,
+		ReconcilerStates: []campaigns.ReconcilerState{campaigns.ReconcilerStateCompleted},
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "listing changesets")
+	}
+
+	var rebased []int64
+	for _, ch := range cs {
+		// We only push to changesets that we created ourselves.
+		if !ch.CreatedByCampaign || ch.OwnedByCampaignID == 0 || ch.CurrentSpecID == 0 {
+			continue
+		}
+
+		ok, err := r.rebaseChangeset(ctx, ch)
+		if err != nil {
+			log15.Error("Rebasing changeset", "changeset", ch.ID, "error", err)
+			continue
+		}
+		if ok {
+			rebased = append(rebased, ch.ID)
+		}
+	}
+
+	return rebased, nil
+}
+
+// rebaseChangeset re-applies the patch of the current ChangesetSpec of the
+// given Changeset on the current head of its base branch, if the base branch
+// has moved since the last commit was created. If the patch applies cleanly,
+// the new commit is pushed and true is returned. Otherwise the Changeset is
+// marked as conflicting.
+//
+// The Changeset is locked while the commit is created and pushed, and skipped
+// if its ChangesetSpec changed or the reconciler picked it up since it was
+// listed, so that a rebased commit never overwrites the commit of a newly
+// applied ChangesetSpec.
+func (r *changesetRebaser) rebaseChangeset(ctx context.Context, ch *campaigns.Changeset) (pushed bool, err error) {
+	tx, err := r.store.Transact(ctx)
+	if err != nil {
+		return false, err
+	}
+	defer func() { err = tx.Done(err) }()
+
+	locked, err := tx.GetChangeset(ctx, GetChangesetOpts{ID: ch.ID, ForUpdate: true})
+	if err != nil {
+		if err == ErrNoResults {
+			return false, nil
+		}
+		return false, errors.Wrap(err, "locking changeset")
+	}
+	if locked.CurrentSpecID != ch.CurrentSpecID ||
+		locked.ReconcilerState != campaigns.ReconcilerStateCompleted ||
+		locked.ExternalState != campaigns.ChangesetExternalStateOpen {
+		return false, nil
+	}
+	ch = locked
+
+	spec, err := tx.GetChangesetSpecByID(ctx, ch.CurrentSpecID)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to load changeset spec")
+	}
+	if spec.Spec.IsImportingExisting() {
+		return false, nil
+	}
+
+	repo, extSvc, cred, err := loadAssociations(ctx, tx, ch)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to load associations")
+	}
+
+	baseRev, err := git.ResolveRevision(ctx, gitserver.Repo{Name: api.RepoName(repo.Name)}, nil, spec.Spec.BaseRef, git.ResolveRevisionOptions{})
+	if err != nil {
+		return false, errors.Wrap(err, "resolving base revision")
+	}
+
+	lastBaseRev := ch.RebaseBaseRev
+	if lastBaseRev == "" {
+		lastBaseRev = spec.Spec.BaseRev
+	}
+	if string(baseRev) == lastBaseRev {
+		return false, nil
+	}
+
+	opts, err := buildPushCommitOpts(repo, extSvc, spec, cred)
+	if err != nil {
+		return false, err
+	}
+	opts.BaseCommit = baseRev
+
+	pushed = true
+	ch.RebaseState = campaigns.ChangesetRebaseStateUpToDate
+	if _, err := r.gitserverClient.CreateCommitFromPatch(ctx, opts); err != nil {
+		diffErr, ok := err.(*protocol.CreateCommitFromPatchError)
+		if !ok || !diffErr.PatchDidNotApply() {
+			return false, errors.Wrap(err, "creating commit from patch")
+		}
+		pushed = false
+		ch.RebaseState = campaigns.ChangesetRebaseStateConflicting
+	}
+	ch.RebaseBaseRev = string(baseRev)
+
+	if err := tx.UpdateChangesetRebaseState(ctx, ch); err != nil {
+		return false, err
+	}
+
+	return pushed, nil
+}
+
+// resetRebaseState resets the rebase state of the given Changeset after a
+// commit based on the BaseRev of its current ChangesetSpec has been pushed.
+func resetRebaseState(ch *campaigns.Changeset) {
+	ch.RebaseState = ""
+	ch.RebaseBaseRev = ""
+}
@@ -0,0 +1,313 @@
+package campaigns
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
+	ct "github.com/sourcegraph/sourcegraph/enterprise/internal/campaigns/testing"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/campaigns"
+	"github.com/sourcegraph/sourcegraph/internal/db/dbconn"
+	"github.com/sourcegraph/sourcegraph/internal/db/dbtesting"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/protocol"
+	"github.com/sourcegraph/sourcegraph/internal/vcs/git"
+)
+
+func TestChangesetRebaser(t *testing.T) {
+	ctx := backend.WithAuthzBypass(context.Background())
+	dbtesting.SetupGlobalTestDB(t)
+
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	clock := func() time.Time {
+		return now.UTC().Truncate(time.Microsecond)
+	}
+	store := NewStoreWithClock(dbconn.Global, clock)
+
+	admin := createTestUser(ctx, t)
+	rs, _ := createTestRepos(t, ctx, dbconn.Global, 1)
+
+	const (
+		specBaseRev = "spec-base-rev"
+		headRev     = "current-head-rev"
+	)
+
+	conflictErr := &protocol.CreateCommitFromPatchError{
+		RepositoryName: rs[0].Name,
+		InternalError:  "gitserver: applying patch: exit status 1",
+		Command:        "git apply --cached -p0",
+		CombinedOutput: "error: patch failed: README.md:1",
+	}
+
+	tests := map[string]struct {
+		rebaseState   campaigns.ChangesetRebaseState
+		rebaseBaseRev string
+		baseRev       string
+		gitserverErr  error
+
+		wantCommit        bool
+		wantPushed        bool
+		wantErr           bool
+		wantRebaseState   campaigns.ChangesetRebaseState
+		wantRebaseBaseRev string
+	}{
+		"base branch hasn't moved": {
+			baseRev: specBaseRev,
+		},
+		"base branch moved": {
+			baseRev: headRev,
+
+			wantCommit:        true,
+			wantPushed:        true,
+			wantRebaseState:   campaigns.ChangesetRebaseStateUpToDate,
+			wantRebaseBaseRev: headRev,
+		},
+		"base branch moved and patch doesn't apply": {
+			baseRev:      headRev,
+			gitserverErr: conflictErr,
+
+			wantCommit:        true,
+			wantRebaseState:   campaigns.ChangesetRebaseStateConflicting,
+			wantRebaseBaseRev: headRev,
+		},
+		"conflicting and base branch hasn't moved since": {
+			rebaseState:   campaigns.ChangesetRebaseStateConflicting,
+			rebaseBaseRev: headRev,
+			baseRev:       headRev,
+
+			wantRebaseState:   campaigns.ChangesetRebaseStateConflicting,
+			wantRebaseBaseRev: headRev,
+		},
+		"conflicting and base branch moved again": {
+			rebaseState:   campaigns.ChangesetRebaseStateConflicting,
+			rebaseBaseRev: "previous-head-rev",
+			baseRev:       headRev,
+
+			wantCommit:        true,
+			wantPushed:        true,
+			wantRebaseState:   campaigns.ChangesetRebaseStateUpToDate,
+			wantRebaseBaseRev: headRev,
+		},
+		"gitserver error": {
+			baseRev:      headRev,
+			gitserverErr: errors.New("gitserver is down"),
+
+			wantCommit: true,
+			wantErr:    true,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			truncateTables(t, dbconn.Global, "changesets", "campaigns", "campaign_specs", "changeset_specs")
+
+			campaignSpec := createCampaignSpec(t, ctx, store, "rebaser-test-campaign", admin.ID)
+			campaign := createCampaign(t, ctx, store, "rebaser-test-campaign", admin.ID, campaignSpec.ID)
+
+			spec := createChangesetSpec(t, ctx, store, testSpecOpts{
+				user:          admin.ID,
+				repo:          rs[0].ID,
+				campaignSpec:  campaignSpec.ID,
+				headRef:       "refs/heads/my-branch",
+				published:     true,
+				baseRef:       "refs/heads/master",
+				baseRev:       specBaseRev,
+				commitMessage: "Add a line",
+				commitDiff:    "diff",
+			})
+
+			changeset := createChangeset(t, ctx, store, testChangesetOpts{
+				repo:              rs[0].ID,
+				campaign:          campaign.ID,
+				currentSpec:       spec.ID,
+				publicationState:  campaigns.ChangesetPublicationStatePublished,
+				externalID:        "12345",
+				externalState:     campaigns.ChangesetExternalStateOpen,
+				reconcilerState:   campaigns.ReconcilerStateCompleted,
+				createdByCampaign: true,
+				ownedByCampaign:   campaign.ID,
+			})
+			changeset.RebaseState = tc.rebaseState
+			changeset.RebaseBaseRev = tc.rebaseBaseRev
+			if err := store.UpdateChangeset(ctx, changeset); err != nil {
+				t.Fatal(err)
+			}
+
+			git.Mocks.ResolveRevision = func(ref string, opts git.ResolveRevisionOptions) (api.CommitID, error) {
+				if ref != spec.Spec.BaseRef {
+					t.Fatalf("wrong ref resolved. want=%q, have=%q", spec.Spec.BaseRef, ref)
+				}
+				return api.CommitID(tc.baseRev), nil
+			}
+			defer git.ResetMocks()
+
+			gitClient := &ct.FakeGitserverClient{Response: spec.Spec.HeadRef, ResponseErr: tc.gitserverErr}
+			r := &changesetRebaser{store: store, gitserverClient: gitClient}
+
+			pushed, err := r.rebaseChangeset(ctx, changeset)
+			if have, want := err != nil, tc.wantErr; have != want {
+				t.Fatalf("wrong error. wantErr=%t, have=%v", want, err)
+			}
+			if have, want := pushed, tc.wantPushed; have != want {
+				t.Fatalf("wrong pushed result. want=%t, have=%t", want, have)
+			}
+
+			if have, want := gitClient.CreateCommitFromPatchCalled, tc.wantCommit; have != want {
+				t.Fatalf("wrong CreateCommitFromPatch call. wantCalled=%t, wasCalled=%t", want, have)
+			}
+			if tc.wantCommit {
+				req := gitClient.CreateCommitFromPatchReq
+				if have, want := string(req.BaseCommit), tc.baseRev; have != want {
+					t.Fatalf("wrong base commit. want=%q, have=%q", want, have)
+				}
+				if have, want := req.TargetRef, spec.Spec.HeadRef; have != want {
+					t.Fatalf("wrong target ref. want=%q, have=%q", want, have)
+				}
+				if !req.Push {
+					t.Fatalf("commit is not pushed")
+				}
+			}
+
+			reloaded, err := store.GetChangeset(ctx, GetChangesetOpts{ID: changeset.ID})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if have, want := reloaded.RebaseState, tc.wantRebaseState; have != want {
+				t.Fatalf("wrong RebaseState. want=%q, have=%q", want, have)
+			}
+			if have, want := reloaded.RebaseBaseRev, tc.wantRebaseBaseRev; have != want {
+				t.Fatalf("wrong RebaseBaseRev. want=%q, have=%q", want, have)
+			}
+		})
+	}
+
+	t.Run("rebaseAll skips changesets not created by campaigns", func(t *testing.T) {
+		truncateTables(t, dbconn.Global, "changesets", "campaigns", "campaign_specs", "changeset_specs")
+
+		campaignSpec := createCampaignSpec(t, ctx, store, "rebaser-test-campaign", admin.ID)
+		campaign := createCampaign(t, ctx, store, "rebaser-test-campaign", admin.ID, campaignSpec.ID)
+
+		spec := createChangesetSpec(t, ctx, store, testSpecOpts{
+			user:         admin.ID,
+			repo:         rs[0].ID,
+			campaignSpec: campaignSpec.ID,
+			headRef:      "refs/heads/my-branch",
+			published:    true,
+			baseRef:      "refs/heads/master",
+			baseRev:      specBaseRev,
+		})
+
+		created := createChangeset(t, ctx, store, testChangesetOpts{
+			repo:              rs[0].ID,
+			campaign:          campaign.ID,
+			currentSpec:       spec.ID,
+			publicationState:  campaigns.ChangesetPublicationStatePublished,
+			externalID:        "1",
+			externalState:     campaigns.ChangesetExternalStateOpen,
+			reconcilerState:   campaigns.ReconcilerStateCompleted,
+			createdByCampaign: true,
+			ownedByCampaign:   campaign.ID,
+		})
+		createChangeset(t, ctx, store, testChangesetOpts{
+			repo:             rs[0].ID,
+			campaign:         campaign.ID,
+			publicationState: campaigns.ChangesetPublicationStatePublished,
+			externalID:       "2",
+			externalState:    campaigns.ChangesetExternalStateOpen,
+			reconcilerState:  campaigns.ReconcilerStateCompleted,
+		})
+		createChangeset(t, ctx, store, testChangesetOpts{
+			repo:              rs[0].ID,
+			campaign:          campaign.ID,
+			currentSpec:       spec.ID,
+			publicationState:  campaigns.ChangesetPublicationStatePublished,
+			externalID:        "3",
+			externalState:     campaigns.ChangesetExternalStateMerged,
+			reconcilerState:   campaigns.ReconcilerStateCompleted,
+			createdByCampaign: true,
+			ownedByCampaign:   campaign.ID,
+		})
+
+		git.Mocks.ResolveRevision = func(ref string, opts git.ResolveRevisionOptions) (api.CommitID, error) {
+			return headRev, nil
+		}
+		defer git.ResetMocks()
+
+		r := &changesetRebaser{store: store, gitserverClient: &ct.FakeGitserverClient{}}
+		rebased, err := r.rebaseAll(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(rebased) != 1 || rebased[0] != created.ID {
+			t.Fatalf("wrong changesets rebased. want=%v, have=%v", []int64{created.ID}, rebased)
+		}
+	})
+
+	t.Run("rebaseChangeset skips changesets changed since they were listed", func(t *testing.T) {
+		for name, update := range map[string]func(ch *campaigns.Changeset, newSpecID int64){
+			"new changeset spec applied": func(ch *campaigns.Changeset, newSpecID int64) {
+				ch.CurrentSpecID = newSpecID
+				ch.ReconcilerState = campaigns.ReconcilerStateQueued
+			},
+			"reconciler processing": func(ch *campaigns.Changeset, _ int64) {
+				ch.ReconcilerState = campaigns.ReconcilerStateProcessing
+			},
+		} {
+			t.Run(name, func(t *testing.T) {
+				truncateTables(t, dbconn.Global, "changesets", "campaigns", "campaign_specs", "changeset_specs")
+
+				campaignSpec := createCampaignSpec(t, ctx, store, "rebaser-test-campaign", admin.ID)
+				campaign := createCampaign(t, ctx, store, "rebaser-test-campaign", admin.ID, campaignSpec.ID)
+
+				specOpts := testSpecOpts{
+					user:         admin.ID,
+					repo:         rs[0].ID,
+					campaignSpec: campaignSpec.ID,
+					headRef:      "refs/heads/my-branch",
+					published:    true,
+					baseRef:      "refs/heads/master",
+					baseRev:      specBaseRev,
+				}
+				spec := createChangesetSpec(t, ctx, store, specOpts)
+				newSpec := createChangesetSpec(t, ctx, store, specOpts)
+
+				listed := createChangeset(t, ctx, store, testChangesetOpts{
+					repo:              rs[0].ID,
+					campaign:          campaign.ID,
+					currentSpec:       spec.ID,
+					publicationState:  campaigns.ChangesetPublicationStatePublished,
+					externalID:        "1",
+					externalState:     campaigns.ChangesetExternalStateOpen,
+					reconcilerState:   campaigns.ReconcilerStateCompleted,
+					createdByCampaign: true,
+					ownedByCampaign:   campaign.ID,
+				})
+
+				// The changeset changes after the rebaser listed it.
+				changed := listed.Clone()
+				update(changed, newSpec.ID)
+				if err := store.UpdateChangeset(ctx, changed); err != nil {
+					t.Fatal(err)
+				}
+
+				git.Mocks.ResolveRevision = func(ref string, opts git.ResolveRevisionOptions) (api.CommitID, error) {
+					return headRev, nil
+				}
+				defer git.ResetMocks()
+
+				gitClient := &ct.FakeGitserverClient{}
+				r := &changesetRebaser{store: store, gitserverClient: gitClient}
+
+				pushed, err := r.rebaseChangeset(ctx, listed)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if pushed || gitClient.CreateCommitFromPatchCalled {
+					t.Fatalf("changeset was rebased. pushed=%t, commitCreated=%t", pushed, gitClient.CreateCommitFromPatchCalled)
+				}
+			})
+		}
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"net/url"
 	"strings"
 	"time"
 
@@ -12,7 +13,9 @@ import (
 	"github.com/sourcegraph/sourcegraph/cmd/repo-updater/repos"
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/campaigns"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver/protocol"
+	"github.com/sourcegraph/sourcegraph/internal/jsonc"
 	"github.com/sourcegraph/sourcegraph/internal/vcs/git"
 	"github.com/sourcegraph/sourcegraph/internal/workerutil"
 	"github.com/sourcegraph/sourcegraph/internal/workerutil/dbworker"
@@ -72,7 +75,13 @@ func (r *reconciler) process(ctx context.Context, tx *Store, ch *campaigns.Chang
 		return r.syncChangeset(ctx, tx, ch)
 
 	case actionPublish:
-		return r.publishChangeset(ctx, tx, ch, action.spec)
+		return r.publishChangeset(ctx, tx, ch, action.spec, false)
+
+	case actionPublishDraft:
+		return r.publishChangeset(ctx, tx, ch, action.spec, true)
+
+	case actionUndraft:
+		return r.undraftChangeset(ctx, tx, ch, action.spec, action.delta)
 
 	case actionUpdate:
 		return r.updateChangeset(ctx, tx, ch, action.spec, action.delta)
@@ -102,9 +111,14 @@ func (r *reconciler) syncChangeset(ctx context.Context, tx *Store, ch *campaigns
 // already exists in the database and is owned by another campaign.
 var ErrPublishSameBranch = errors.New("cannot create changeset on the same branch in multiple campaigns")
 
-// publishChangeset creates the given changeset on its code host.
-func (r *reconciler) publishChangeset(ctx context.Context, tx *Store, ch *campaigns.Changeset, spec *campaigns.ChangesetSpec) (err error) {
-	repo, extSvc, err := loadAssociations(ctx, tx, ch)
+// ErrDraftNotSupported is returned by publishChangeset if the changeset should
+// be published as a draft, but its code host doesn't support drafts.
+var ErrDraftNotSupported = errors.New("cannot create draft changesets on this code host")
+
+// publishChangeset creates the given changeset on its code host. If asDraft is
+// true, it's created as a draft.
+func (r *reconciler) publishChangeset(ctx context.Context, tx *Store, ch *campaigns.Changeset, spec *campaigns.ChangesetSpec, asDraft bool) (err error) {
+	repo, extSvc, cred, err := loadAssociations(ctx, tx, ch)
 	if err != nil {
 		return errors.Wrap(err, "failed to load associations")
 	}
@@ -123,20 +137,29 @@ func (r *reconciler) publishChangeset(ctx context.Context, tx *Store, ch *campai
 	}
 
 	// Set up a source with which we can create a changeset
-	ccs, err := r.buildChangesetSource(repo, extSvc)
+	ccs, err := buildChangesetSource(r.sourcer, repo, extSvc)
 	if err != nil {
 		return err
 	}
 
+	var dcs repos.DraftChangesetSource
+	if asDraft {
+		var ok bool
+		if dcs, ok = ccs.(repos.DraftChangesetSource); !ok {
+			return ErrDraftNotSupported
+		}
+	}
+
 	// Create a commit and push it
-	opts, err := buildCommitOpts(repo, spec)
+	opts, err := buildPushCommitOpts(repo, extSvc, spec, cred)
 	if err != nil {
 		return err
 	}
-	ref, err := r.pushCommit(ctx, opts)
+	ref, err := r.pushCommit(ctx, opts, cred)
 	if err != nil {
 		return err
 	}
+	resetRebaseState(ch)
 
 	// Now create the actual pull request on the code host
 	cs := &repos.Changeset{
@@ -152,7 +175,12 @@ func (r *reconciler) publishChangeset(ctx context.Context, tx *Store, ch *campai
 	// ephemeral error, there's a race condition here.
 	// It's possible that `CreateChangeset` doesn't return the newest head ref
 	// commit yet, because the API of the codehost doesn't return it yet.
-	exists, err := ccs.CreateChangeset(ctx, cs)
+	var exists bool
+	if asDraft {
+		exists, err = dcs.CreateDraftChangeset(ctx, cs)
+	} else {
+		exists, err = ccs.CreateChangeset(ctx, cs)
+	}
 	if err != nil {
 		return errors.Wrap(err, "creating changeset")
 	}
@@ -170,6 +198,15 @@ func (r *reconciler) publishChangeset(ctx context.Context, tx *Store, ch *campai
 		}
 	}
 
+	// We set the publication state before computing the derived state,
+	// because that overrides it if the changeset on the code host turns out
+	// to be (or not be) a draft.
+	if asDraft {
+		ch.PublicationState = campaigns.ChangesetPublicationStateDraft
+	} else {
+		ch.PublicationState = campaigns.ChangesetPublicationStatePublished
+	}
+
 	events := ch.Events()
 	SetDerivedState(ctx, ch, events)
 
@@ -179,7 +216,69 @@ func (r *reconciler) publishChangeset(ctx context.Context, tx *Store, ch *campai
 	}
 
 	ch.CreatedByCampaign = true
+	ch.FailureMessage = nil
+	return tx.UpdateChangeset(ctx, ch)
+}
+
+// undraftChangeset marks the given draft changeset as ready for review on its
+// code host. If the spec changed in the meantime, the changeset is updated
+// first.
+func (r *reconciler) undraftChangeset(ctx context.Context, tx *Store, ch *campaigns.Changeset, spec *campaigns.ChangesetSpec, delta *changesetSpecDelta) (err error) {
+	repo, extSvc, cred, err := loadAssociations(ctx, tx, ch)
+	if err != nil {
+		return errors.Wrap(err, "failed to load associations")
+	}
+
+	ccs, err := buildChangesetSource(r.sourcer, repo, extSvc)
+	if err != nil {
+		return err
+	}
+
+	dcs, ok := ccs.(repos.DraftChangesetSource)
+	if !ok {
+		return ErrDraftNotSupported
+	}
+
+	if delta.NeedCommitUpdate() {
+		opts, err := buildPushCommitOpts(repo, extSvc, spec, cred)
+		if err != nil {
+			return err
+		}
+
+		if _, err = r.pushCommit(ctx, opts, cred); err != nil {
+			return err
+		}
+		resetRebaseState(ch)
+	}
+
+	cs := repos.Changeset{
+		Title:     spec.Spec.Title,
+		Body:      spec.Spec.Body,
+		BaseRef:   spec.Spec.BaseRef,
+		HeadRef:   git.EnsureRefPrefix(spec.Spec.HeadRef),
+		Repo:      repo,
+		Changeset: ch,
+	}
+
+	if delta.NeedCodeHostUpdate() {
+		if err := ccs.UpdateChangeset(ctx, &cs); err != nil {
+			return errors.Wrap(err, "updating changeset")
+		}
+	}
+
+	if err := dcs.UndraftChangeset(ctx, &cs); err != nil {
+		return errors.Wrap(err, "undrafting changeset")
+	}
+
 	ch.PublicationState = campaigns.ChangesetPublicationStatePublished
+
+	events := ch.Events()
+	SetDerivedState(ctx, ch, events)
+	if err := tx.UpsertChangesetEvents(ctx, events...); err != nil {
+		log15.Error("UpsertChangesetEvents", "err", err)
+		return err
+	}
+
 	ch.FailureMessage = nil
 	return tx.UpdateChangeset(ctx, ch)
 }
@@ -191,26 +290,27 @@ func (r *reconciler) publishChangeset(ctx context.Context, tx *Store, ch *campai
 // If the delta requires updates to the changeset on the code host, it will
 // update the changeset there.
 func (r *reconciler) updateChangeset(ctx context.Context, tx *Store, ch *campaigns.Changeset, spec *campaigns.ChangesetSpec, delta *changesetSpecDelta) (err error) {
-	repo, extSvc, err := loadAssociations(ctx, tx, ch)
+	repo, extSvc, cred, err := loadAssociations(ctx, tx, ch)
 	if err != nil {
 		return errors.Wrap(err, "failed to load associations")
 	}
 
 	// Set up a source with which we can update the changeset on the code host.
-	ccs, err := r.buildChangesetSource(repo, extSvc)
+	ccs, err := buildChangesetSource(r.sourcer, repo, extSvc)
 	if err != nil {
 		return err
 	}
 
 	if delta.NeedCommitUpdate() {
-		opts, err := buildCommitOpts(repo, spec)
+		opts, err := buildPushCommitOpts(repo, extSvc, spec, cred)
 		if err != nil {
 			return err
 		}
 
-		if _, err = r.pushCommit(ctx, opts); err != nil {
+		if _, err = r.pushCommit(ctx, opts, cred); err != nil {
 			return err
 		}
+		resetRebaseState(ch)
 	}
 
 	// If we only need to update the diff, we're done, because we already
@@ -269,13 +369,13 @@ func (r *reconciler) closeChangeset(ctx context.Context, tx *Store, ch *campaign
 		return tx.UpdateChangeset(ctx, ch)
 	}
 
-	repo, extSvc, err := loadAssociations(ctx, tx, ch)
+	repo, extSvc, _, err := loadAssociations(ctx, tx, ch)
 	if err != nil {
 		return errors.Wrap(err, "failed to load associations")
 	}
 
 	// Set up a source with which we can close the changeset
-	ccs, err := r.buildChangesetSource(repo, extSvc)
+	ccs, err := buildChangesetSource(r.sourcer, repo, extSvc)
 	if err != nil {
 		return err
 	}
@@ -290,10 +390,19 @@ func (r *reconciler) closeChangeset(ctx context.Context, tx *Store, ch *campaign
 	return r.syncChangeset(ctx, tx, ch)
 }
 
-func (r *reconciler) pushCommit(ctx context.Context, opts protocol.CreateCommitFromPatchRequest) (string, error) {
+func (r *reconciler) pushCommit(ctx context.Context, opts protocol.CreateCommitFromPatchRequest, cred *campaigns.CampaignCredential) (string, error) {
 	ref, err := r.gitserverClient.CreateCommitFromPatch(ctx, opts)
 	if err != nil {
 		if diffErr, ok := err.(*protocol.CreateCommitFromPatchError); ok {
+			if cred != nil && diffErr.PushFailed() {
+				return "", errors.Errorf(
+					"pushing to repository %q failed: the %s might not have push access to the repository\n"+
+						"```\n"+
+						"$ %s\n"+
+						"%s\n"+
+						"```",
+					diffErr.RepositoryName, describeCampaignCredential(cred), diffErr.Command, strings.TrimSpace(diffErr.CombinedOutput))
+			}
 			return "", errors.Errorf(
 				"creating commit from patch for repository %q: %s\n"+
 					"```\n"+
@@ -308,8 +417,8 @@ func (r *reconciler) pushCommit(ctx context.Context, opts protocol.CreateCommitF
 	return ref, nil
 }
 
-func (r *reconciler) buildChangesetSource(repo *repos.Repo, extSvc *repos.ExternalService) (repos.ChangesetSource, error) {
-	sources, err := r.sourcer(extSvc)
+func buildChangesetSource(sourcer repos.Sourcer, repo *repos.Repo, extSvc *repos.ExternalService) (repos.ChangesetSource, error) {
+	sources, err := sourcer(extSvc)
 	if err != nil {
 		return nil, err
 	}
@@ -384,11 +493,13 @@ func buildCommitOpts(repo *repos.Repo, spec *campaigns.ChangesetSpec) (protocol.
 type actionType string
 
 const (
-	actionNone    actionType = "none"
-	actionUpdate  actionType = "update"
-	actionPublish actionType = "publish"
-	actionSync    actionType = "sync"
-	actionClose   actionType = "close"
+	actionNone         actionType = "none"
+	actionUpdate       actionType = "update"
+	actionPublish      actionType = "publish"
+	actionPublishDraft actionType = "publish-draft"
+	actionUndraft      actionType = "undraft"
+	actionSync         actionType = "sync"
+	actionClose        actionType = "close"
 )
 
 // reconcilerAction represents the possible actions the reconciler can take for
@@ -448,15 +559,22 @@ func determineAction(ctx context.Context, tx *Store, ch *campaigns.Changeset) (r
 
 	switch ch.PublicationState {
 	case campaigns.ChangesetPublicationStateUnpublished:
-		if curr.Spec.Published {
+		if curr.Spec.Published.True() {
 			action.actionType = actionPublish
+		} else if curr.Spec.Published.Draft() {
+			action.actionType = actionPublishDraft
 		}
-	case campaigns.ChangesetPublicationStatePublished:
+	case campaigns.ChangesetPublicationStatePublished, campaigns.ChangesetPublicationStateDraft:
 		delta, err := CompareChangesetSpecs(prev, curr)
 		if err != nil {
 			return action, nil
 		}
-		if delta.AttributesChanged() {
+		// Drafts are promoted once the spec says they should be published,
+		// but we never turn a published changeset back into a draft.
+		if ch.PublicationState.Draft() && curr.Spec.Published.True() {
+			action.actionType = actionUndraft
+			action.delta = delta
+		} else if delta.AttributesChanged() {
 			action.actionType = actionUpdate
 			action.delta = delta
 		}
@@ -485,20 +603,148 @@ func checkSpecAppliedToCampaign(ctx context.Context, tx *Store, spec *campaigns.
 	return nil
 }
 
-func loadAssociations(ctx context.Context, tx *Store, ch *campaigns.Changeset) (*repos.Repo, *repos.ExternalService, error) {
+// loadAssociations loads the repository and the external service of the given
+// Changeset. If a CampaignCredential applies to the Changeset, it's returned
+// too and the token in the configuration of the returned external service is
+// replaced with it.
+func loadAssociations(ctx context.Context, tx *Store, ch *campaigns.Changeset) (*repos.Repo, *repos.ExternalService, *campaigns.CampaignCredential, error) {
 	reposStore := repos.NewDBStore(tx.Handle().DB(), sql.TxOptions{})
 
 	repo, err := loadRepo(ctx, reposStore, ch.RepoID)
 	if err != nil {
-		return nil, nil, errors.Wrap(err, "failed to load repository")
+		return nil, nil, nil, errors.Wrap(err, "failed to load repository")
 	}
 
 	extSvc, err := loadExternalService(ctx, reposStore, repo)
 	if err != nil {
-		return nil, nil, errors.Wrap(err, "failed to load external service")
+		return nil, nil, nil, errors.Wrap(err, "failed to load external service")
+	}
+
+	cred, err := loadCampaignCredential(ctx, tx, ch, repo)
+	if err != nil {
+		return nil, nil, nil, errors.Wrap(err, "failed to load campaign credential")
+	}
+
+	extSvc, err = applyCampaignCredential(extSvc, cred)
+	if err != nil {
+		return nil, nil, nil, errors.Wrap(err, "failed to apply campaign credential")
+	}
+
+	return repo, extSvc, cred, nil
+}
+
+// loadCampaignCredential returns the CampaignCredential with which the given
+// Changeset is pushed and published on the code host of the given repository.
+// That's the credential of the namespace of the campaign owning the Changeset
+// or, if the namespace has none, the site credential. If neither exists, nil
+// is returned and the token of the external service is used.
+func loadCampaignCredential(ctx context.Context, tx *Store, ch *campaigns.Changeset, repo *repos.Repo) (*campaigns.CampaignCredential, error) {
+	opts := GetCampaignCredentialOpts{
+		ExternalServiceType: repo.ExternalRepo.ServiceType,
+		ExternalServiceID:   repo.ExternalRepo.ServiceID,
+	}
+
+	if ch.OwnedByCampaignID != 0 {
+		campaign, err := tx.GetCampaign(ctx, GetCampaignOpts{ID: ch.OwnedByCampaignID})
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to load owning campaign")
+		}
+
+		opts.NamespaceUserID = campaign.NamespaceUserID
+		opts.NamespaceOrgID = campaign.NamespaceOrgID
+		cred, err := tx.GetCampaignCredential(ctx, opts)
+		if err != ErrNoResults {
+			return cred, err
+		}
+		opts.NamespaceUserID, opts.NamespaceOrgID = 0, 0
+	}
+
+	cred, err := tx.GetCampaignCredential(ctx, opts)
+	if err == ErrNoResults {
+		return nil, nil
+	}
+	return cred, err
+}
+
+// applyCampaignCredential returns a copy of the given external service whose
+// configuration uses the given CampaignCredential instead of its own token.
+// If cred is nil, the external service is returned unchanged.
+func applyCampaignCredential(extSvc *repos.ExternalService, cred *campaigns.CampaignCredential) (*repos.ExternalService, error) {
+	if cred == nil {
+		return extSvc, nil
+	}
+
+	config, err := jsonc.Edit(extSvc.Config, cred.Token, "token")
+	if err != nil {
+		return nil, err
 	}
 
-	return repo, extSvc, nil
+	if cred.ExternalServiceType == extsvc.TypeBitbucketServer {
+		if config, err = jsonc.Edit(config, cred.Username, "username"); err != nil {
+			return nil, err
+		}
+	}
+
+	e := extSvc.Clone()
+	e.Config = config
+	return e, nil
+}
+
+// buildPushCommitOpts returns the options with which the commit of the given
+// ChangesetSpec is created and pushed. If cred is not nil, it's used to push
+// the commit instead of the credentials in the repository's clone URL.
+func buildPushCommitOpts(repo *repos.Repo, extSvc *repos.ExternalService, spec *campaigns.ChangesetSpec, cred *campaigns.CampaignCredential) (protocol.CreateCommitFromPatchRequest, error) {
+	opts, err := buildCommitOpts(repo, spec)
+	if err != nil {
+		return opts, err
+	}
+
+	if cred == nil {
+		return opts, nil
+	}
+
+	opts.PushRemoteURL, err = campaignCredentialRemoteURL(repo, extSvc, cred)
+	return opts, err
+}
+
+// campaignCredentialRemoteURL returns the HTTP(S) clone URL of the given
+// repository on the given external service, authenticated with the given
+// CampaignCredential.
+func campaignCredentialRemoteURL(repo *repos.Repo, extSvc *repos.ExternalService, cred *campaigns.CampaignCredential) (string, error) {
+	info, ok := repo.Sources[extSvc.URN()]
+	if !ok || info.CloneURL == "" {
+		return "", errors.Errorf("no clone URL for repository %q on external service %q", repo.Name, extSvc.DisplayName)
+	}
+
+	u, err := url.Parse(info.CloneURL)
+	if err != nil {
+		return "", errors.Wrap(err, "parsing clone URL")
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return "", errors.Errorf("cannot push to repository %q with a campaign credential: it's not cloned over HTTP(S)", repo.Name)
+	}
+
+	switch cred.ExternalServiceType {
+	case extsvc.TypeGitHub:
+		u.User = url.User(cred.Token)
+	case extsvc.TypeGitLab:
+		u.User = url.UserPassword("git", cred.Token)
+	case extsvc.TypeBitbucketServer:
+		u.User = url.UserPassword(cred.Username, cred.Token)
+	default:
+		return "", errors.Errorf("campaign credentials for code host type %q are not supported", cred.ExternalServiceType)
+	}
+
+	return u.String(), nil
+}
+
+// describeCampaignCredential returns a description of the given
+// CampaignCredential that can be shown to users in error messages.
+func describeCampaignCredential(cred *campaigns.CampaignCredential) string {
+	if cred.IsSiteCredential() {
+		return fmt.Sprintf("site campaign credential for %s", cred.ExternalServiceID)
+	}
+	return fmt.Sprintf("campaign credential of the campaign's namespace for %s", cred.ExternalServiceID)
 }
 
 func loadRepo(ctx context.Context, tx repos.Store, id api.RepoID) (*repos.Repo, error) {
@@ -132,6 +132,7 @@ type Changeset struct {
 	ExternalURL      ExternalURL
 	ReviewState      string
 	CheckState       string
+	RebaseState      string
 	Events           ChangesetEventConnection
 
 	Diff Comparison
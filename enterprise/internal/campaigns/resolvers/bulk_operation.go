@@ -0,0 +1,130 @@
+package resolvers
+
+import (
+	"context"
+
+	"github.com/graph-gophers/graphql-go"
+	"github.com/graph-gophers/graphql-go/relay"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	ee "github.com/sourcegraph/sourcegraph/enterprise/internal/campaigns"
+	"github.com/sourcegraph/sourcegraph/internal/campaigns"
+	"github.com/sourcegraph/sourcegraph/internal/db"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+)
+
+const bulkOperationIDKind = "BulkOperation"
+
+func marshalBulkOperationID(id string) graphql.ID {
+	return relay.MarshalID(bulkOperationIDKind, id)
+}
+
+var _ graphqlbackend.BulkOperationResolver = &bulkOperationResolver{}
+
+type bulkOperationResolver struct {
+	store       *ee.Store
+	httpFactory *httpcli.Factory
+
+	bulkOperation *campaigns.BulkOperation
+}
+
+func (r *bulkOperationResolver) ID() graphql.ID {
+	return marshalBulkOperationID(r.bulkOperation.ID)
+}
+
+func (r *bulkOperationResolver) Type() campaigns.ChangesetJobType {
+	return r.bulkOperation.Type
+}
+
+func (r *bulkOperationResolver) State() campaigns.BulkOperationState {
+	return r.bulkOperation.State
+}
+
+func (r *bulkOperationResolver) Progress() float64 {
+	return r.bulkOperation.Progress
+}
+
+func (r *bulkOperationResolver) Errors(ctx context.Context) ([]graphqlbackend.ChangesetJobErrorResolver, error) {
+	errs, err := r.store.ListBulkOperationErrors(ctx, ee.ListBulkOperationErrorsOpts{BulkOperationID: r.bulkOperation.ID})
+	if err != nil {
+		return nil, err
+	}
+
+	resolvers := make([]graphqlbackend.ChangesetJobErrorResolver, 0, len(errs))
+	if len(errs) == 0 {
+		return resolvers, nil
+	}
+
+	changesetIDs := make([]int64, 0, len(errs))
+	for _, e := range errs {
+		changesetIDs = append(changesetIDs, e.ChangesetID)
+	}
+
+	cs, _, err := r.store.ListChangesets(ctx, ee.ListChangesetsOpts{IDs: changesetIDs})
+	if err != nil {
+		return nil, err
+	}
+
+	// 🚨 SECURITY: db.Repos.GetReposSetByIDs uses the authzFilter under the
+	// hood and filters out repositories that the user doesn't have access to.
+	// Changesets in those repositories are resolved as hidden changesets.
+	accessibleReposByID, err := db.Repos.GetReposSetByIDs(ctx, cs.RepoIDs()...)
+	if err != nil {
+		return nil, err
+	}
+
+	changesetsByID := make(map[int64]*campaigns.Changeset, len(cs))
+	for _, c := range cs {
+		changesetsByID[c.ID] = c
+	}
+
+	for _, e := range errs {
+		// Changesets that were deleted in the meantime have their jobs
+		// deleted as well, but we might race with that.
+		c, ok := changesetsByID[e.ChangesetID]
+		if !ok {
+			continue
+		}
+
+		message := e.Error
+		resolvers = append(resolvers, &changesetJobErrorResolver{
+			changeset: NewChangesetResolver(r.store, r.httpFactory, c, accessibleReposByID[c.RepoID]),
+			error:     &message,
+		})
+	}
+
+	return resolvers, nil
+}
+
+func (r *bulkOperationResolver) Initiator(ctx context.Context) (*graphqlbackend.UserResolver, error) {
+	return graphqlbackend.UserByIDInt32(ctx, r.bulkOperation.UserID)
+}
+
+func (r *bulkOperationResolver) ChangesetCount() int32 {
+	return r.bulkOperation.ChangesetCount
+}
+
+func (r *bulkOperationResolver) CreatedAt() graphqlbackend.DateTime {
+	return graphqlbackend.DateTime{Time: r.bulkOperation.CreatedAt}
+}
+
+func (r *bulkOperationResolver) FinishedAt() *graphqlbackend.DateTime {
+	if r.bulkOperation.FinishedAt.IsZero() {
+		return nil
+	}
+	return &graphqlbackend.DateTime{Time: r.bulkOperation.FinishedAt}
+}
+
+var _ graphqlbackend.ChangesetJobErrorResolver = &changesetJobErrorResolver{}
+
+type changesetJobErrorResolver struct {
+	changeset graphqlbackend.ChangesetResolver
+	error     *string
+}
+
+func (r *changesetJobErrorResolver) Changeset() graphqlbackend.ChangesetResolver {
+	return r.changeset
+}
+
+func (r *changesetJobErrorResolver) Error() *string {
+	return r.error
+}
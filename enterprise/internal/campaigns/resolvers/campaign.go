@@ -167,8 +167,7 @@ func (r *campaignResolver) ChangesetCountsOverTime(
 
 	resolvers := []graphqlbackend.ChangesetCountsResolver{}
 
-	publishedState := campaigns.ChangesetPublicationStatePublished
-	opts := ee.ListChangesetsOpts{CampaignID: r.Campaign.ID, PublicationState: &publishedState}
+	opts := ee.ListChangesetsOpts{CampaignID: r.Campaign.ID, OnlyPublished: true}
 	cs, _, err := r.store.ListChangesets(ctx, opts)
 	if err != nil {
 		return resolvers, err
@@ -249,3 +248,37 @@ func (r *campaignResolver) CurrentSpec(ctx context.Context) (graphqlbackend.Camp
 
 	return &campaignSpecResolver{store: r.store, httpFactory: r.httpFactory, campaignSpec: campaignSpec}, nil
 }
+
+func (r *campaignResolver) BulkOperations(ctx context.Context) ([]graphqlbackend.BulkOperationResolver, error) {
+	ops, err := r.store.ListBulkOperations(ctx, ee.ListBulkOperationsOpts{CampaignID: r.Campaign.ID})
+	if err != nil {
+		return nil, err
+	}
+
+	resolvers := make([]graphqlbackend.BulkOperationResolver, 0, len(ops))
+	for _, op := range ops {
+		resolvers = append(resolvers, &bulkOperationResolver{store: r.store, httpFactory: r.httpFactory, bulkOperation: op})
+	}
+	return resolvers, nil
+}
+
+func (r *campaignResolver) Analytics(ctx context.Context, args *graphqlbackend.CampaignAnalyticsArgs) (graphqlbackend.CampaignAnalyticsResolver, error) {
+	if err := campaignsEnabled(); err != nil {
+		return nil, err
+	}
+
+	resolver := &campaignAnalyticsResolver{
+		store:      r.store,
+		campaignID: r.Campaign.ID,
+		from:       r.Campaign.CreatedAt.UTC(),
+		to:         r.store.Clock()().UTC(),
+	}
+	if args.From != nil {
+		resolver.from = args.From.Time.UTC()
+	}
+	if args.To != nil && args.To.Time.Before(resolver.to) {
+		resolver.to = args.To.Time.UTC()
+	}
+
+	return resolver, nil
+}
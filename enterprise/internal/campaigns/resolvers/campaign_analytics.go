@@ -0,0 +1,190 @@
+package resolvers
+
+import (
+	"context"
+	"math"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend/graphqlutil"
+	ee "github.com/sourcegraph/sourcegraph/enterprise/internal/campaigns"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/campaigns"
+	"github.com/sourcegraph/sourcegraph/internal/db"
+)
+
+var _ graphqlbackend.CampaignAnalyticsResolver = &campaignAnalyticsResolver{}
+
+type campaignAnalyticsResolver struct {
+	store      *ee.Store
+	campaignID int64
+	from, to   time.Time
+}
+
+func (r *campaignAnalyticsResolver) Snapshots(ctx context.Context, args *graphqlbackend.CampaignSnapshotsConnectionArgs) (graphqlbackend.CampaignSnapshotConnectionResolver, error) {
+	opts := ee.ListCampaignSnapshotsOpts{
+		CampaignID: r.campaignID,
+		From:       r.from,
+		To:         r.to,
+	}
+	if err := validateFirstParamDefaults(args.First); err != nil {
+		return nil, err
+	}
+	opts.Limit = int(args.First)
+	if args.After != nil {
+		id, err := strconv.Atoi(*args.After)
+		if err != nil {
+			return nil, err
+		}
+		opts.Cursor = int64(id)
+	}
+
+	return &campaignSnapshotConnectionResolver{store: r.store, opts: opts}, nil
+}
+
+func (r *campaignAnalyticsResolver) Current(ctx context.Context) (graphqlbackend.CampaignSnapshotResolver, error) {
+	snapshot, err := ee.CurrentCampaignSnapshot(ctx, r.store, r.campaignID)
+	if err != nil {
+		return nil, err
+	}
+	return &campaignSnapshotResolver{snapshot: snapshot}, nil
+}
+
+var _ graphqlbackend.CampaignSnapshotConnectionResolver = &campaignSnapshotConnectionResolver{}
+
+type campaignSnapshotConnectionResolver struct {
+	store *ee.Store
+	opts  ee.ListCampaignSnapshotsOpts
+
+	// Cache results because they are used by multiple fields
+	once      sync.Once
+	snapshots []*campaigns.CampaignSnapshot
+	next      int64
+	err       error
+}
+
+func (r *campaignSnapshotConnectionResolver) Nodes(ctx context.Context) ([]graphqlbackend.CampaignSnapshotResolver, error) {
+	snapshots, _, err := r.compute(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	resolvers := make([]graphqlbackend.CampaignSnapshotResolver, 0, len(snapshots))
+	for _, s := range snapshots {
+		resolvers = append(resolvers, &campaignSnapshotResolver{snapshot: s})
+	}
+	return resolvers, nil
+}
+
+func (r *campaignSnapshotConnectionResolver) TotalCount(ctx context.Context) (int32, error) {
+	count, err := r.store.CountCampaignSnapshots(ctx, ee.CountCampaignSnapshotsOpts{
+		CampaignID: r.opts.CampaignID,
+		From:       r.opts.From,
+		To:         r.opts.To,
+	})
+	if err != nil {
+		return 0, err
+	}
+	return int32(count), nil
+}
+
+func (r *campaignSnapshotConnectionResolver) PageInfo(ctx context.Context) (*graphqlutil.PageInfo, error) {
+	_, next, err := r.compute(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if next != 0 {
+		return graphqlutil.NextPageCursor(strconv.Itoa(int(next))), nil
+	}
+	return graphqlutil.HasNextPage(false), nil
+}
+
+func (r *campaignSnapshotConnectionResolver) compute(ctx context.Context) ([]*campaigns.CampaignSnapshot, int64, error) {
+	r.once.Do(func() {
+		r.snapshots, r.next, r.err = r.store.ListCampaignSnapshots(ctx, r.opts)
+	})
+	return r.snapshots, r.next, r.err
+}
+
+var _ graphqlbackend.CampaignSnapshotResolver = &campaignSnapshotResolver{}
+
+type campaignSnapshotResolver struct {
+	snapshot *campaigns.CampaignSnapshot
+}
+
+func (r *campaignSnapshotResolver) Date() graphqlbackend.DateTime {
+	return graphqlbackend.DateTime{Time: r.snapshot.CreatedAt}
+}
+func (r *campaignSnapshotResolver) Total() int32        { return r.snapshot.Total }
+func (r *campaignSnapshotResolver) Open() int32         { return r.snapshot.Open }
+func (r *campaignSnapshotResolver) Merged() int32       { return r.snapshot.Merged }
+func (r *campaignSnapshotResolver) Closed() int32       { return r.snapshot.Closed }
+func (r *campaignSnapshotResolver) Draft() int32        { return r.snapshot.Draft }
+func (r *campaignSnapshotResolver) FailedChecks() int32 { return r.snapshot.FailedChecks }
+func (r *campaignSnapshotResolver) OpenApproved() int32 { return r.snapshot.OpenApproved }
+func (r *campaignSnapshotResolver) OpenChangesRequested() int32 {
+	return r.snapshot.OpenChangesRequested
+}
+func (r *campaignSnapshotResolver) OpenPending() int32 { return r.snapshot.OpenPending }
+
+func (r *campaignSnapshotResolver) MedianTimeToMergeSeconds() *int32 {
+	if r.snapshot.Merged == 0 || r.snapshot.MedianTimeToMerge == 0 {
+		return nil
+	}
+	seconds := durationSeconds(r.snapshot.MedianTimeToMerge)
+	return &seconds
+}
+
+func (r *campaignSnapshotResolver) RepositoryStallTimes(ctx context.Context) ([]graphqlbackend.RepositoryStallTimeResolver, error) {
+	repoIDs := make([]api.RepoID, 0, len(r.snapshot.RepoStallTimes))
+	for _, st := range r.snapshot.RepoStallTimes {
+		repoIDs = append(repoIDs, st.RepoID)
+	}
+
+	// 🚨 SECURITY: db.Repos.GetReposSetByIDs uses the authzFilter under the
+	// hood and filters out repositories that the user doesn't have access to.
+	// Stall times of those repositories are omitted.
+	accessibleReposByID, err := db.Repos.GetReposSetByIDs(ctx, repoIDs...)
+	if err != nil {
+		return nil, err
+	}
+
+	resolvers := make([]graphqlbackend.RepositoryStallTimeResolver, 0, len(r.snapshot.RepoStallTimes))
+	for _, st := range r.snapshot.RepoStallTimes {
+		repo, ok := accessibleReposByID[st.RepoID]
+		if !ok {
+			continue
+		}
+
+		resolvers = append(resolvers, &repositoryStallTimeResolver{
+			repo:      graphqlbackend.NewRepositoryResolver(repo),
+			stallTime: st.StallTime,
+		})
+	}
+	return resolvers, nil
+}
+
+var _ graphqlbackend.RepositoryStallTimeResolver = &repositoryStallTimeResolver{}
+
+type repositoryStallTimeResolver struct {
+	repo      *graphqlbackend.RepositoryResolver
+	stallTime time.Duration
+}
+
+func (r *repositoryStallTimeResolver) Repository() *graphqlbackend.RepositoryResolver { return r.repo }
+func (r *repositoryStallTimeResolver) StallTimeSeconds() int32 {
+	return durationSeconds(r.stallTime)
+}
+
+// durationSeconds converts the given duration into whole seconds, capped to
+// fit into a GraphQL Int.
+func durationSeconds(d time.Duration) int32 {
+	seconds := int64(d / time.Second)
+	if seconds > math.MaxInt32 {
+		return math.MaxInt32
+	}
+	return int32(seconds)
+}
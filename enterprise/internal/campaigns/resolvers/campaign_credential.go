@@ -0,0 +1,136 @@
+package resolvers
+
+import (
+	"context"
+	"strconv"
+	"sync"
+
+	"github.com/graph-gophers/graphql-go"
+	"github.com/graph-gophers/graphql-go/relay"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend/graphqlutil"
+	ee "github.com/sourcegraph/sourcegraph/enterprise/internal/campaigns"
+	"github.com/sourcegraph/sourcegraph/internal/campaigns"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+)
+
+const campaignCredentialIDKind = "CampaignCredential"
+
+func marshalCampaignCredentialID(id int64) graphql.ID {
+	return relay.MarshalID(campaignCredentialIDKind, id)
+}
+
+func unmarshalCampaignCredentialID(id graphql.ID) (credentialID int64, err error) {
+	err = relay.UnmarshalSpec(id, &credentialID)
+	return
+}
+
+var _ graphqlbackend.CampaignCredentialResolver = &campaignCredentialResolver{}
+
+type campaignCredentialResolver struct {
+	credential *campaigns.CampaignCredential
+}
+
+func (r *campaignCredentialResolver) ID() graphql.ID {
+	return marshalCampaignCredentialID(r.credential.ID)
+}
+
+func (r *campaignCredentialResolver) Namespace(ctx context.Context) (*graphqlbackend.NamespaceResolver, error) {
+	var (
+		n   graphqlbackend.NamespaceResolver
+		err error
+	)
+	switch {
+	case r.credential.NamespaceUserID != 0:
+		n.Namespace, err = graphqlbackend.UserByIDInt32(ctx, r.credential.NamespaceUserID)
+	case r.credential.NamespaceOrgID != 0:
+		n.Namespace, err = graphqlbackend.OrgByIDInt32(ctx, r.credential.NamespaceOrgID)
+	default:
+		return nil, nil
+	}
+	if errcode.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &n, nil
+}
+
+func (r *campaignCredentialResolver) ExternalServiceKind() string {
+	switch r.credential.ExternalServiceType {
+	case extsvc.TypeGitHub:
+		return extsvc.KindGitHub
+	case extsvc.TypeGitLab:
+		return extsvc.KindGitLab
+	case extsvc.TypeBitbucketServer:
+		return extsvc.KindBitbucketServer
+	default:
+		return extsvc.KindOther
+	}
+}
+
+func (r *campaignCredentialResolver) ExternalServiceURL() string {
+	return r.credential.ExternalServiceID
+}
+
+func (r *campaignCredentialResolver) Username() *string {
+	if r.credential.Username == "" {
+		return nil
+	}
+	return &r.credential.Username
+}
+
+func (r *campaignCredentialResolver) CreatedAt() graphqlbackend.DateTime {
+	return graphqlbackend.DateTime{Time: r.credential.CreatedAt}
+}
+
+func (r *campaignCredentialResolver) UpdatedAt() graphqlbackend.DateTime {
+	return graphqlbackend.DateTime{Time: r.credential.UpdatedAt}
+}
+
+var _ graphqlbackend.CampaignCredentialConnectionResolver = &campaignCredentialConnectionResolver{}
+
+type campaignCredentialConnectionResolver struct {
+	svc  *ee.Service
+	opts ee.ListCampaignCredentialsOpts
+
+	// cache results because they are used by multiple fields
+	once        sync.Once
+	credentials []*campaigns.CampaignCredential
+	next        int64
+	err         error
+}
+
+func (r *campaignCredentialConnectionResolver) Nodes(ctx context.Context) ([]graphqlbackend.CampaignCredentialResolver, error) {
+	nodes, _, err := r.compute(ctx)
+	if err != nil {
+		return nil, err
+	}
+	resolvers := make([]graphqlbackend.CampaignCredentialResolver, 0, len(nodes))
+	for _, c := range nodes {
+		resolvers = append(resolvers, &campaignCredentialResolver{credential: c})
+	}
+	return resolvers, nil
+}
+
+func (r *campaignCredentialConnectionResolver) PageInfo(ctx context.Context) (*graphqlutil.PageInfo, error) {
+	_, next, err := r.compute(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if next != 0 {
+		return graphqlutil.NextPageCursor(strconv.Itoa(int(next))), nil
+	}
+	return graphqlutil.HasNextPage(false), nil
+}
+
+func (r *campaignCredentialConnectionResolver) compute(ctx context.Context) ([]*campaigns.CampaignCredential, int64, error) {
+	r.once.Do(func() {
+		// 🚨 SECURITY: ListCampaignCredentials checks whether the current user
+		// has access to the credentials of the namespace.
+		r.credentials, r.next, r.err = r.svc.ListCampaignCredentials(ctx, r.opts)
+	})
+	return r.credentials, r.next, r.err
+}
@@ -200,3 +200,23 @@ func (r *campaignSpecResolver) AppliesToCampaign(ctx context.Context) (graphqlba
 		Campaign:    campaign,
 	}, nil
 }
+
+func (r *campaignSpecResolver) Executions(ctx context.Context) ([]graphqlbackend.CampaignSpecExecutionResolver, error) {
+	executions, _, err := r.store.ListCampaignSpecExecutions(ctx, ee.ListCampaignSpecExecutionsOpts{
+		CampaignSpecID: r.campaignSpec.ID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resolvers := make([]graphqlbackend.CampaignSpecExecutionResolver, 0, len(executions))
+	for _, e := range executions {
+		resolvers = append(resolvers, &campaignSpecExecutionResolver{
+			store:       r.store,
+			httpFactory: r.httpFactory,
+			execution:   e,
+		})
+	}
+
+	return resolvers, nil
+}
@@ -0,0 +1,214 @@
+package resolvers
+
+import (
+	"context"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	ee "github.com/sourcegraph/sourcegraph/enterprise/internal/campaigns"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/campaigns"
+	"github.com/sourcegraph/sourcegraph/internal/db"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+)
+
+var _ graphqlbackend.CampaignSpecExecutionResolver = &campaignSpecExecutionResolver{}
+
+type campaignSpecExecutionResolver struct {
+	store       *ee.Store
+	httpFactory *httpcli.Factory
+
+	execution *campaigns.CampaignSpecExecution
+}
+
+func (r *campaignSpecExecutionResolver) Repository(ctx context.Context) (*graphqlbackend.RepositoryResolver, error) {
+	// 🚨 SECURITY: db.Repos.Get uses the authzFilter under the hood and
+	// filters out repositories that the user doesn't have access to.
+	repo, err := db.Repos.Get(ctx, r.execution.RepoID)
+	if err != nil {
+		return nil, err
+	}
+	return graphqlbackend.NewRepositoryResolver(repo), nil
+}
+
+func (r *campaignSpecExecutionResolver) BaseRef() string {
+	return r.execution.BaseRef
+}
+
+func (r *campaignSpecExecutionResolver) BaseRev() string {
+	return r.execution.BaseRev
+}
+
+func (r *campaignSpecExecutionResolver) State() campaigns.CampaignSpecExecutionState {
+	return r.execution.State
+}
+
+func (r *campaignSpecExecutionResolver) FailureMessage() *string {
+	return r.execution.FailureMessage
+}
+
+func (r *campaignSpecExecutionResolver) ChangesetSpecs(ctx context.Context) ([]graphqlbackend.ChangesetSpecResolver, error) {
+	if len(r.execution.ChangesetSpecIDs) == 0 {
+		return []graphqlbackend.ChangesetSpecResolver{}, nil
+	}
+
+	changesetSpecs, _, err := r.store.ListChangesetSpecs(ctx, ee.ListChangesetSpecsOpts{IDs: r.execution.ChangesetSpecIDs})
+	if err != nil {
+		return nil, err
+	}
+
+	resolvers := make([]graphqlbackend.ChangesetSpecResolver, 0, len(changesetSpecs))
+	for _, changesetSpec := range changesetSpecs {
+		resolvers = append(resolvers, &changesetSpecResolver{
+			store:         r.store,
+			httpFactory:   r.httpFactory,
+			changesetSpec: changesetSpec,
+			repoCtx:       ctx,
+		})
+	}
+
+	return resolvers, nil
+}
+
+func (r *campaignSpecExecutionResolver) CacheHit() bool {
+	return r.execution.CacheHit
+}
+
+func (r *campaignSpecExecutionResolver) CreatedAt() graphqlbackend.DateTime {
+	return graphqlbackend.DateTime{Time: r.execution.CreatedAt}
+}
+
+func (r *campaignSpecExecutionResolver) FinishedAt() *graphqlbackend.DateTime {
+	if r.execution.FinishedAt.IsZero() {
+		return nil
+	}
+	return &graphqlbackend.DateTime{Time: r.execution.FinishedAt}
+}
+
+// resolveExecutionTargets resolves the `on` clauses of the given campaign
+// spec to the repositories the steps of the campaign spec should be executed
+// in. Each repository is executed on the current tip of its default branch.
+func resolveExecutionTargets(ctx context.Context, spec *campaigns.CampaignSpec) ([]ee.ExecutionTarget, error) {
+	var (
+		matches []*repositoryMatch
+		byID    = map[api.RepoID]*repositoryMatch{}
+	)
+	add := func(r *graphqlbackend.RepositoryResolver, paths ...string) {
+		m, ok := byID[r.Type().ID]
+		if !ok {
+			m = &repositoryMatch{repo: r}
+			byID[r.Type().ID] = m
+			matches = append(matches, m)
+		}
+		m.addPaths(paths...)
+	}
+
+	for _, on := range spec.Spec.On {
+		if on.Repository != "" {
+			repo, err := backend.Repos.GetByName(ctx, api.RepoName(on.Repository))
+			if err != nil {
+				return nil, errors.Wrapf(err, "resolving repository %q", on.Repository)
+			}
+			add(graphqlbackend.NewRepositoryResolver(repo))
+			continue
+		}
+
+		ms, err := resolveRepositoriesMatchingQuery(ctx, on.RepositoriesMatchingQuery)
+		if err != nil {
+			return nil, errors.Wrapf(err, "resolving repositories matching %q", on.RepositoriesMatchingQuery)
+		}
+		for _, m := range ms {
+			add(m.repo, m.paths...)
+		}
+	}
+
+	targets := make([]ee.ExecutionTarget, 0, len(matches))
+	for _, m := range matches {
+		branch, err := m.repo.DefaultBranch(ctx)
+		if err != nil {
+			return nil, err
+		}
+		// Repositories that are empty or still being cloned have no default
+		// branch and are skipped.
+		if branch == nil {
+			continue
+		}
+
+		oid, err := branch.Target().OID(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		targets = append(targets, ee.ExecutionTarget{
+			RepoID:            m.repo.Type().ID,
+			BaseRef:           branch.Name(),
+			BaseRev:           string(oid),
+			SearchResultPaths: m.paths,
+		})
+	}
+
+	return targets, nil
+}
+
+// repositoryMatch is a repository matched by the `on` clauses of a campaign
+// spec, together with the paths of the files in it that matched.
+type repositoryMatch struct {
+	repo  *graphqlbackend.RepositoryResolver
+	paths []string
+}
+
+func (m *repositoryMatch) addPaths(paths ...string) {
+	for _, p := range paths {
+		if !containsString(m.paths, p) {
+			m.paths = append(m.paths, p)
+		}
+	}
+}
+
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+// resolveRepositoriesMatchingQuery returns the repositories of all results
+// matched by the given search query, with the paths of the matched files.
+func resolveRepositoriesMatchingQuery(ctx context.Context, query string) ([]*repositoryMatch, error) {
+	if !strings.Contains(query, "count:") {
+		query += " count:999999"
+	}
+
+	search, err := graphqlbackend.NewSearchImplementer(ctx, &graphqlbackend.SearchArgs{
+		Query:   query,
+		Version: "V2",
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	results, err := search.Results(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if alert := results.Alert(); alert != nil {
+		return nil, errors.Errorf("search returned an alert: %s", alert.Title())
+	}
+
+	var matches []*repositoryMatch
+	for _, result := range results.Results() {
+		if r, ok := result.ToRepository(); ok {
+			matches = append(matches, &repositoryMatch{repo: r})
+		} else if fm, ok := result.ToFileMatch(); ok {
+			matches = append(matches, &repositoryMatch{repo: fm.Repository(), paths: []string{fm.File().Path()}})
+		} else if c, ok := result.ToCommitSearchResult(); ok {
+			matches = append(matches, &repositoryMatch{repo: c.Commit().Repository()})
+		}
+	}
+
+	return matches, nil
+}
@@ -322,6 +322,13 @@ func (r *changesetResolver) CheckState() *campaigns.ChangesetCheckState {
 	return &state
 }
 
+func (r *changesetResolver) RebaseState() *campaigns.ChangesetRebaseState {
+	if r.changeset.RebaseState == "" {
+		return nil
+	}
+	return &r.changeset.RebaseState
+}
+
 func (r *changesetResolver) Error() *string { return r.changeset.FailureMessage }
 
 func (r *changesetResolver) CurrentSpec(ctx context.Context) (graphqlbackend.VisibleChangesetSpecResolver, error) {
@@ -178,7 +178,10 @@ func (r *changesetDescriptionResolver) HeadRepository() *graphqlbackend.Reposito
 func (r *changesetDescriptionResolver) HeadRef() string { return git.AbbreviateRef(r.desc.HeadRef) }
 func (r *changesetDescriptionResolver) Title() string   { return r.desc.Title }
 func (r *changesetDescriptionResolver) Body() string    { return r.desc.Body }
-func (r *changesetDescriptionResolver) Published() bool { return r.desc.Published }
+func (r *changesetDescriptionResolver) Published() bool {
+	return r.desc.Published.True() || r.desc.Published.Draft()
+}
+func (r *changesetDescriptionResolver) Draft() bool { return r.desc.Published.Draft() }
 
 func (r *changesetDescriptionResolver) DiffStat() *graphqlbackend.DiffStat {
 	return graphqlbackend.NewDiffStat(r.diffStat)
@@ -100,6 +100,7 @@ func TestChangesetResolver(t *testing.T) {
 		externalState:       campaigns.ChangesetExternalStateOpen,
 		externalCheckState:  campaigns.ChangesetCheckStatePending,
 		externalReviewState: campaigns.ChangesetReviewStateChangesRequested,
+		rebaseState:         campaigns.ChangesetRebaseStateConflicting,
 		publicationState:    campaigns.ChangesetPublicationStatePublished,
 		reconcilerState:     campaigns.ReconcilerStateCompleted,
 		createdByCampaign:   false,
@@ -239,6 +240,7 @@ func TestChangesetResolver(t *testing.T) {
 				ExternalID:    "12345",
 				CheckState:    "PENDING",
 				ReviewState:   "CHANGES_REQUESTED",
+				RebaseState:   "CONFLICTING",
 				NextSyncAt:    marshalDateTime(t, now.Add(8*time.Hour)),
 				Repository:    apitest.Repository{Name: repo.Name},
 				ExternalURL: apitest.ExternalURL{
@@ -317,6 +319,7 @@ query($changeset: ID!) {
       externalState
       reviewState
       checkState
+      rebaseState
       externalURL { url, serviceType }
       nextSyncAt
       publicationState
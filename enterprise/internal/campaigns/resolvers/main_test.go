@@ -26,6 +26,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/db/dbtesting"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/secrets"
 	"github.com/sourcegraph/sourcegraph/internal/vcs/git"
 )
 
@@ -40,6 +41,10 @@ func TestMain(m *testing.M) {
 	if !testing.Verbose() {
 		log15.Root().SetHandler(log15.DiscardHandler())
 	}
+	if err := secrets.Init(); err != nil {
+		log15.Crit("initializing secrets", "error", err)
+		os.Exit(1)
+	}
 	os.Exit(m.Run())
 }
 
@@ -269,6 +274,8 @@ type testChangesetOpts struct {
 	externalReviewState campaigns.ChangesetReviewState
 	externalCheckState  campaigns.ChangesetCheckState
 
+	rebaseState campaigns.ChangesetRebaseState
+
 	publicationState campaigns.ChangesetPublicationState
 	reconcilerState  campaigns.ReconcilerState
 	failureMessage   string
@@ -303,6 +310,8 @@ func createChangeset(
 		ExternalReviewState: opts.externalReviewState,
 		ExternalCheckState:  opts.externalCheckState,
 
+		RebaseState: opts.rebaseState,
+
 		PublicationState: opts.publicationState,
 		ReconcilerState:  opts.reconcilerState,
 		Unsynced:         opts.unsynced,
@@ -351,7 +360,7 @@ type testSpecOpts struct {
 
 	// If this is set along with headRef, the changesetSpec will have published
 	// set.
-	published bool
+	published interface{}
 
 	title         string
 	body          string
@@ -382,7 +391,7 @@ func createChangesetSpec(
 
 			ExternalID: opts.externalID,
 			HeadRef:    opts.headRef,
-			Published:  opts.published,
+			Published:  campaigns.PublishedValue{Val: opts.published},
 
 			Title: opts.title,
 			Body:  opts.body,
@@ -355,6 +355,36 @@ func TestPermissionLevels(t *testing.T) {
 					return fmt.Sprintf(`mutation { moveCampaign(campaign: %q, newName: "foobar") { id } }`, campaignID)
 				},
 			},
+			{
+				name: "createChangesetComments",
+				mutationFunc: func(campaignID, changesetID, campaignSpecID string) string {
+					return fmt.Sprintf(`mutation { createChangesetComments(campaign: %q, changesets: [%q], body: "hello") { id } }`, campaignID, changesetID)
+				},
+			},
+			{
+				name: "reenqueueChangesets",
+				mutationFunc: func(campaignID, changesetID, campaignSpecID string) string {
+					return fmt.Sprintf(`mutation { reenqueueChangesets(campaign: %q, changesets: [%q]) { id } }`, campaignID, changesetID)
+				},
+			},
+			{
+				name: "mergeChangesets",
+				mutationFunc: func(campaignID, changesetID, campaignSpecID string) string {
+					return fmt.Sprintf(`mutation { mergeChangesets(campaign: %q, changesets: [%q]) { id } }`, campaignID, changesetID)
+				},
+			},
+			{
+				name: "closeChangesets",
+				mutationFunc: func(campaignID, changesetID, campaignSpecID string) string {
+					return fmt.Sprintf(`mutation { closeChangesets(campaign: %q, changesets: [%q]) { id } }`, campaignID, changesetID)
+				},
+			},
+			{
+				name: "detachChangesets",
+				mutationFunc: func(campaignID, changesetID, campaignSpecID string) string {
+					return fmt.Sprintf(`mutation { detachChangesets(campaign: %q, changesets: [%q]) { id } }`, campaignID, changesetID)
+				},
+			},
 		}
 
 		for _, m := range mutations {
@@ -510,6 +540,119 @@ func TestPermissionLevels(t *testing.T) {
 			})
 		}
 	})
+
+	t.Run("campaign credentials", func(t *testing.T) {
+		adminCred := &campaigns.CampaignCredential{
+			NamespaceUserID:     adminID,
+			ExternalServiceType: extsvc.TypeGitHub,
+			ExternalServiceID:   "https://github.com/",
+			Token:               "admin-token",
+		}
+		if err := store.CreateCampaignCredential(ctx, adminCred); err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() {
+			if err := store.DeleteCampaignCredential(ctx, adminCred.ID); err != nil {
+				t.Fatal(err)
+			}
+		})
+
+		createMutation := func(namespace string) string {
+			ns := "null"
+			if namespace != "" {
+				ns = fmt.Sprintf("%q", namespace)
+			}
+			return fmt.Sprintf(`
+			mutation {
+				createCampaignCredential(namespace: %s, externalServiceKind: GITLAB, externalServiceURL: "https://gitlab.com", credential: "token") {
+					id
+				}
+			}`, ns)
+		}
+
+		listQuery := func(namespace string) string {
+			if namespace == "" {
+				return `query { campaignCredentials { nodes { id } } }`
+			}
+			return fmt.Sprintf(`query { campaignCredentials(namespace: %q) { nodes { id } } }`, namespace)
+		}
+
+		adminNamespace := string(graphqlbackend.MarshalUserID(adminID))
+		userNamespace := string(graphqlbackend.MarshalUserID(userID))
+
+		tests := []struct {
+			name        string
+			currentUser int32
+			query       string
+			wantErr     string
+		}{
+			{
+				name:        "create for own namespace",
+				currentUser: userID,
+				query:       createMutation(userNamespace),
+			},
+			{
+				name:        "create for other user's namespace",
+				currentUser: userID,
+				query:       createMutation(adminNamespace),
+				wantErr:     "must be authenticated",
+			},
+			{
+				name:        "create site credential as user",
+				currentUser: userID,
+				query:       createMutation(""),
+				wantErr:     "must be site admin",
+			},
+			{
+				name:        "create site credential as site-admin",
+				currentUser: adminID,
+				query:       createMutation(""),
+			},
+			{
+				name:        "list other user's credentials",
+				currentUser: userID,
+				query:       listQuery(adminNamespace),
+				wantErr:     "must be authenticated",
+			},
+			{
+				name:        "list site credentials as user",
+				currentUser: userID,
+				query:       listQuery(""),
+				wantErr:     "must be site admin",
+			},
+			{
+				name:        "list site credentials as site-admin",
+				currentUser: adminID,
+				query:       listQuery(""),
+			},
+			{
+				name:        "delete other user's credential",
+				currentUser: userID,
+				query:       fmt.Sprintf(`mutation { deleteCampaignCredential(campaignCredential: %q) { alwaysNil } }`, marshalCampaignCredentialID(adminCred.ID)),
+				wantErr:     "must be authenticated",
+			},
+		}
+
+		for _, tc := range tests {
+			t.Run(tc.name, func(t *testing.T) {
+				actorCtx := actor.WithActor(ctx, actor.FromUser(tc.currentUser))
+
+				var response struct{}
+				errs := apitest.Exec(actorCtx, t, s, nil, &response, tc.query)
+
+				if tc.wantErr != "" {
+					if len(errs) != 1 {
+						t.Fatalf("expected 1 error, but got %d: %s", len(errs), errs)
+					}
+					if !strings.Contains(errs[0].Error(), tc.wantErr) {
+						t.Fatalf("wrong error: %s %T", errs[0], errs[0])
+					}
+				} else if len(errs) != 0 {
+					t.Fatalf("unexpected errors: %s", errs)
+				}
+			})
+		}
+	})
 }
 
 func TestRepositoryPermissions(t *testing.T) {
@@ -320,6 +320,50 @@ func (r *Resolver) CreateCampaignSpec(ctx context.Context, args *graphqlbackend.
 	return specResolver, nil
 }
 
+func (r *Resolver) ExecuteCampaignSpec(ctx context.Context, args *graphqlbackend.ExecuteCampaignSpecArgs) (graphqlbackend.CampaignSpecResolver, error) {
+	var err error
+	tr, ctx := trace.New(ctx, "Resolver.ExecuteCampaignSpec", fmt.Sprintf("CampaignSpec %s", args.CampaignSpec))
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+
+	if err := campaignsEnabled(); err != nil {
+		return nil, err
+	}
+
+	if err := campaignsCreateAccess(ctx); err != nil {
+		return nil, err
+	}
+
+	campaignSpecRandID, err := unmarshalCampaignSpecID(args.CampaignSpec)
+	if err != nil {
+		return nil, err
+	}
+
+	if campaignSpecRandID == "" {
+		return nil, ErrIDIsZero
+	}
+
+	campaignSpec, err := r.store.GetCampaignSpec(ctx, ee.GetCampaignSpecOpts{RandID: campaignSpecRandID})
+	if err != nil {
+		return nil, err
+	}
+
+	targets, err := resolveExecutionTargets(ctx, campaignSpec)
+	if err != nil {
+		return nil, err
+	}
+
+	svc := ee.NewService(r.store, r.httpFactory)
+	opts := ee.ExecuteCampaignSpecOpts{CampaignSpecRandID: campaignSpecRandID, Targets: targets}
+	if _, err = svc.ExecuteCampaignSpec(ctx, opts); err != nil {
+		return nil, err
+	}
+
+	return &campaignSpecResolver{store: r.store, httpFactory: r.httpFactory, campaignSpec: campaignSpec}, nil
+}
+
 func (r *Resolver) CreateChangesetSpec(ctx context.Context, args *graphqlbackend.CreateChangesetSpecArgs) (graphqlbackend.ChangesetSpecResolver, error) {
 	var err error
 	tr, ctx := trace.New(ctx, "Resolver.CreateChangesetSpec", "")
@@ -425,6 +469,99 @@ func (r *Resolver) DeleteCampaign(ctx context.Context, args *graphqlbackend.Dele
 	return &graphqlbackend.EmptyResponse{}, err
 }
 
+func (r *Resolver) CreateCampaignCredential(ctx context.Context, args *graphqlbackend.CreateCampaignCredentialArgs) (_ graphqlbackend.CampaignCredentialResolver, err error) {
+	tr, ctx := trace.New(ctx, "Resolver.CreateCampaignCredential", fmt.Sprintf("ExternalServiceURL: %q", args.ExternalServiceURL))
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+	if err := campaignsEnabled(); err != nil {
+		return nil, err
+	}
+
+	opts := ee.CreateCampaignCredentialOpts{
+		ExternalServiceKind: args.ExternalServiceKind,
+		ExternalServiceURL:  args.ExternalServiceURL,
+		Token:               args.Credential,
+	}
+	if args.Username != nil {
+		opts.Username = *args.Username
+	}
+	if args.Namespace != nil {
+		err := graphqlbackend.UnmarshalNamespaceID(*args.Namespace, &opts.NamespaceUserID, &opts.NamespaceOrgID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	svc := ee.NewService(r.store, r.httpFactory)
+	// 🚨 SECURITY: CreateCampaignCredential checks whether current user is
+	// authorized.
+	cred, err := svc.CreateCampaignCredential(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return &campaignCredentialResolver{credential: cred}, nil
+}
+
+func (r *Resolver) DeleteCampaignCredential(ctx context.Context, args *graphqlbackend.DeleteCampaignCredentialArgs) (_ *graphqlbackend.EmptyResponse, err error) {
+	tr, ctx := trace.New(ctx, "Resolver.DeleteCampaignCredential", fmt.Sprintf("CampaignCredential: %q", args.CampaignCredential))
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+	if err := campaignsEnabled(); err != nil {
+		return nil, err
+	}
+
+	credentialID, err := unmarshalCampaignCredentialID(args.CampaignCredential)
+	if err != nil {
+		return nil, err
+	}
+
+	if credentialID == 0 {
+		return nil, ErrIDIsZero
+	}
+
+	svc := ee.NewService(r.store, r.httpFactory)
+	// 🚨 SECURITY: DeleteCampaignCredential checks whether current user is
+	// authorized.
+	err = svc.DeleteCampaignCredential(ctx, credentialID)
+	return &graphqlbackend.EmptyResponse{}, err
+}
+
+func (r *Resolver) CampaignCredentials(ctx context.Context, args *graphqlbackend.ListCampaignCredentialsArgs) (graphqlbackend.CampaignCredentialConnectionResolver, error) {
+	if err := campaignsEnabled(); err != nil {
+		return nil, err
+	}
+
+	var opts ee.ListCampaignCredentialsOpts
+	if err := validateFirstParamDefaults(args.First); err != nil {
+		return nil, err
+	}
+	opts.Limit = int(args.First)
+	if args.After != nil {
+		cursor, err := strconv.ParseInt(*args.After, 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		opts.Cursor = cursor
+	}
+
+	if args.Namespace != nil {
+		err := graphqlbackend.UnmarshalNamespaceID(*args.Namespace, &opts.NamespaceUserID, &opts.NamespaceOrgID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &campaignCredentialConnectionResolver{
+		svc:  ee.NewService(r.store, r.httpFactory),
+		opts: opts,
+	}, nil
+}
+
 func (r *Resolver) Campaigns(ctx context.Context, args *graphqlbackend.ListCampaignsArgs) (graphqlbackend.CampaignsConnectionResolver, error) {
 	if err := campaignsEnabled(); err != nil {
 		return nil, err
@@ -549,10 +686,8 @@ func listChangesetOptsFromArgs(args *graphqlbackend.ListChangesetsArgs, campaign
 		safe = false
 	}
 	if args.OnlyPublishedByThisCampaign != nil {
-		published := campaigns.ChangesetPublicationStatePublished
-
 		opts.OwnedByCampaignID = campaignID
-		opts.PublicationState = &published
+		opts.OnlyPublished = true
 	}
 
 	return opts, safe, nil
@@ -616,6 +751,102 @@ func (r *Resolver) SyncChangeset(ctx context.Context, args *graphqlbackend.SyncC
 	return &graphqlbackend.EmptyResponse{}, nil
 }
 
+func (r *Resolver) CreateChangesetComments(ctx context.Context, args *graphqlbackend.CreateChangesetCommentsArgs) (_ graphqlbackend.BulkOperationResolver, err error) {
+	tr, ctx := trace.New(ctx, "Resolver.CreateChangesetComments", fmt.Sprintf("Campaign: %q, Changesets: %d", args.Campaign, len(args.Changesets)))
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+
+	if args.Body == "" {
+		return nil, errors.New("empty comment body is not allowed")
+	}
+
+	payload := &campaigns.ChangesetJobCommentPayload{Message: args.Body}
+	return r.createBulkOperation(ctx, args.Campaign, args.Changesets, campaigns.ChangesetJobTypeComment, payload)
+}
+
+func (r *Resolver) ReenqueueChangesets(ctx context.Context, args *graphqlbackend.BulkChangesetsArgs) (_ graphqlbackend.BulkOperationResolver, err error) {
+	tr, ctx := trace.New(ctx, "Resolver.ReenqueueChangesets", fmt.Sprintf("Campaign: %q, Changesets: %d", args.Campaign, len(args.Changesets)))
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+
+	return r.createBulkOperation(ctx, args.Campaign, args.Changesets, campaigns.ChangesetJobTypeReenqueue, nil)
+}
+
+func (r *Resolver) MergeChangesets(ctx context.Context, args *graphqlbackend.BulkChangesetsArgs) (_ graphqlbackend.BulkOperationResolver, err error) {
+	tr, ctx := trace.New(ctx, "Resolver.MergeChangesets", fmt.Sprintf("Campaign: %q, Changesets: %d", args.Campaign, len(args.Changesets)))
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+
+	return r.createBulkOperation(ctx, args.Campaign, args.Changesets, campaigns.ChangesetJobTypeMerge, nil)
+}
+
+func (r *Resolver) CloseChangesets(ctx context.Context, args *graphqlbackend.BulkChangesetsArgs) (_ graphqlbackend.BulkOperationResolver, err error) {
+	tr, ctx := trace.New(ctx, "Resolver.CloseChangesets", fmt.Sprintf("Campaign: %q, Changesets: %d", args.Campaign, len(args.Changesets)))
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+
+	return r.createBulkOperation(ctx, args.Campaign, args.Changesets, campaigns.ChangesetJobTypeClose, nil)
+}
+
+func (r *Resolver) DetachChangesets(ctx context.Context, args *graphqlbackend.BulkChangesetsArgs) (_ graphqlbackend.BulkOperationResolver, err error) {
+	tr, ctx := trace.New(ctx, "Resolver.DetachChangesets", fmt.Sprintf("Campaign: %q, Changesets: %d", args.Campaign, len(args.Changesets)))
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+
+	return r.createBulkOperation(ctx, args.Campaign, args.Changesets, campaigns.ChangesetJobTypeDetach, nil)
+}
+
+func (r *Resolver) createBulkOperation(ctx context.Context, campaign graphql.ID, changesets []graphql.ID, jobType campaigns.ChangesetJobType, payload interface{}) (graphqlbackend.BulkOperationResolver, error) {
+	if err := campaignsEnabled(); err != nil {
+		return nil, err
+	}
+
+	campaignID, err := unmarshalCampaignID(campaign)
+	if err != nil {
+		return nil, errors.Wrap(err, "unmarshaling campaign id")
+	}
+
+	if campaignID == 0 {
+		return nil, ErrIDIsZero
+	}
+
+	changesetIDs := make([]int64, 0, len(changesets))
+	for _, id := range changesets {
+		changesetID, err := unmarshalChangesetID(id)
+		if err != nil {
+			return nil, errors.Wrap(err, "unmarshaling changeset id")
+		}
+		if changesetID == 0 {
+			return nil, ErrIDIsZero
+		}
+		changesetIDs = append(changesetIDs, changesetID)
+	}
+
+	svc := ee.NewService(r.store, r.httpFactory)
+	// 🚨 SECURITY: CreateBulkOperation checks whether current user is authorized.
+	op, err := svc.CreateBulkOperation(ctx, ee.CreateBulkOperationOpts{
+		CampaignID:   campaignID,
+		ChangesetIDs: changesetIDs,
+		JobType:      jobType,
+		Payload:      payload,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &bulkOperationResolver{store: r.store, httpFactory: r.httpFactory, bulkOperation: op}, nil
+}
+
 func parseCampaignState(s *string) (campaigns.CampaignState, error) {
 	if s == nil {
 		return campaigns.CampaignStateAny, nil
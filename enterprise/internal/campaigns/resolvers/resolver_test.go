@@ -296,7 +296,7 @@ func TestApplyCampaign(t *testing.T) {
 				Commit: campaigns.CommitTemplate{
 					Message: "Add hello world",
 				},
-				Published: false,
+				Published: campaigns.PublishedValue{Val: false},
 			},
 		},
 		UserID:          userID,
@@ -712,7 +712,7 @@ func TestListChangesetOptsFromArgs(t *testing.T) {
 			},
 			wantSafe: true,
 			wantParsed: ee.ListChangesetsOpts{
-				PublicationState:  &wantPublicationStates[0],
+				OnlyPublished:     true,
 				OwnedByCampaignID: campaignID,
 			},
 		},
@@ -77,11 +77,56 @@ func (s *Service) CreateCampaignSpec(ctx context.Context, opts CreateCampaignSpe
 	spec.NamespaceUserID = opts.NamespaceUserID
 	spec.UserID = actor.UID
 
-	if len(opts.ChangesetSpecRandIDs) == 0 {
+	var cs campaigns.ChangesetSpecs
+	if len(opts.ChangesetSpecRandIDs) > 0 {
+		if cs, err = s.listChangesetSpecsByRandIDs(ctx, opts.ChangesetSpecRandIDs); err != nil {
+			return nil, err
+		}
+	}
+
+	imported, err := s.importChangesetSpecs(ctx, spec, cs)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(cs) == 0 && len(imported) == 0 {
 		return spec, s.store.CreateCampaignSpec(ctx, spec)
 	}
 
-	listOpts := ListChangesetSpecsOpts{RandIDs: opts.ChangesetSpecRandIDs}
+	tx, err := s.store.Transact(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { err = tx.Done(err) }()
+
+	if err := tx.CreateCampaignSpec(ctx, spec); err != nil {
+		return nil, err
+	}
+
+	for _, changesetSpec := range cs {
+		changesetSpec.CampaignSpecID = spec.ID
+
+		if err := tx.UpdateChangesetSpec(ctx, changesetSpec); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, changesetSpec := range imported {
+		changesetSpec.CampaignSpecID = spec.ID
+
+		if err := tx.CreateChangesetSpec(ctx, changesetSpec); err != nil {
+			return nil, err
+		}
+	}
+
+	return spec, nil
+}
+
+// listChangesetSpecsByRandIDs returns the ChangesetSpecs with the given
+// RandIDs. An error is returned if one of them doesn't exist or if the user
+// doesn't have access to the repository of one of them.
+func (s *Service) listChangesetSpecsByRandIDs(ctx context.Context, randIDs []string) (campaigns.ChangesetSpecs, error) {
+	listOpts := ListChangesetSpecsOpts{RandIDs: randIDs}
 	cs, _, err := s.store.ListChangesetSpecs(ctx, listOpts)
 	if err != nil {
 		return nil, err
@@ -105,31 +150,13 @@ func (s *Service) CreateCampaignSpec(ctx context.Context, opts CreateCampaignSpe
 	}
 
 	// Check if a changesetSpec was not found
-	for _, randID := range opts.ChangesetSpecRandIDs {
+	for _, randID := range randIDs {
 		if _, ok := byRandID[randID]; !ok {
 			return nil, &changesetSpecNotFoundErr{RandID: randID}
 		}
 	}
 
-	tx, err := s.store.Transact(ctx)
-	if err != nil {
-		return nil, err
-	}
-	defer func() { err = tx.Done(err) }()
-
-	if err := tx.CreateCampaignSpec(ctx, spec); err != nil {
-		return nil, err
-	}
-
-	for _, changesetSpec := range cs {
-		changesetSpec.CampaignSpecID = spec.ID
-
-		if err := tx.UpdateChangesetSpec(ctx, changesetSpec); err != nil {
-			return nil, err
-		}
-	}
-
-	return spec, nil
+	return cs, nil
 }
 
 // CreateChangesetSpec validates the given raw spec input and creates the ChangesetSpec.
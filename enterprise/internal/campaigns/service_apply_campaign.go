@@ -25,6 +25,12 @@ var ErrApplyClosedCampaign = errors.New("existing campaign matched by campaign s
 // campaign spec already exists and FailIfExists was set.
 var ErrMatchingCampaignExists = errors.New("a campaign matching the given campaign spec already exists")
 
+// ErrCampaignSpecExecutionIncomplete is returned by ApplyCampaign if the
+// campaign spec was executed on Sourcegraph and some of its executions are
+// still queued or processing. Applying it before they complete would treat
+// the repositories without a changeset spec yet as removed from the campaign.
+var ErrCampaignSpecExecutionIncomplete = errors.New("campaign spec is still being executed")
+
 type ApplyCampaignOpts struct {
 	CampaignSpecRandID string
 	EnsureCampaignID   int64
@@ -43,6 +49,10 @@ func (o ApplyCampaignOpts) String() string {
 }
 
 // ApplyCampaign creates the CampaignSpec.
+//
+// ApplyCampaign doesn't run the steps of the CampaignSpec or consult the step
+// cache: cached diffs are reused by ExecuteCampaignSpec, and ApplyCampaign
+// returns ErrCampaignSpecExecutionIncomplete until all executions completed.
 func (s *Service) ApplyCampaign(ctx context.Context, opts ApplyCampaignOpts) (campaign *campaigns.Campaign, err error) {
 	tr, ctx := trace.New(ctx, "Service.ApplyCampaign", opts.String())
 	defer func() {
@@ -63,6 +73,24 @@ func (s *Service) ApplyCampaign(ctx context.Context, opts ApplyCampaignOpts) (ca
 		return nil, err
 	}
 
+	// Executions whose diffs were taken from the step cache by
+	// ExecuteCampaignSpec have already completed at this point, so this only
+	// waits for the repositories that changed.
+	pending, _, err := s.store.ListCampaignSpecExecutions(ctx, ListCampaignSpecExecutionsOpts{
+		LimitOpts:      LimitOpts{Limit: 1},
+		CampaignSpecID: campaignSpec.ID,
+		States: []campaigns.CampaignSpecExecutionState{
+			campaigns.CampaignSpecExecutionStateQueued,
+			campaigns.CampaignSpecExecutionStateProcessing,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(pending) != 0 {
+		return nil, ErrCampaignSpecExecutionIncomplete
+	}
+
 	campaign, err = s.GetCampaignMatchingCampaignSpec(ctx, s.store, campaignSpec)
 	if err != nil {
 		return nil, err
@@ -605,6 +605,34 @@ func TestServiceApplyCampaign(t *testing.T) {
 			t.Fatalf("ApplyCampaign returned unexpected error: %s", err)
 		}
 	})
+
+	t.Run("applying while executions are pending", func(t *testing.T) {
+		campaignSpec := createCampaignSpec(t, ctx, store, "pending-executions", admin.ID)
+
+		execution := &campaigns.CampaignSpecExecution{
+			CampaignSpecID: campaignSpec.ID,
+			RepoID:         repos[0].ID,
+			BaseRef:        "refs/heads/master",
+			BaseRev:        "d34db33f",
+		}
+		if err := store.CreateCampaignSpecExecution(ctx, execution); err != nil {
+			t.Fatal(err)
+		}
+
+		opts := ApplyCampaignOpts{CampaignSpecRandID: campaignSpec.RandID}
+		if _, err := svc.ApplyCampaign(adminCtx, opts); err != ErrCampaignSpecExecutionIncomplete {
+			t.Fatalf("ApplyCampaign returned unexpected error: %s", err)
+		}
+
+		execution.State = campaigns.CampaignSpecExecutionStateCompleted
+		if err := store.UpdateCampaignSpecExecution(ctx, execution); err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := svc.ApplyCampaign(adminCtx, opts); err != nil {
+			t.Fatalf("ApplyCampaign returned unexpected error: %s", err)
+		}
+	})
 }
 
 type changesetAssertions struct {
@@ -805,12 +833,15 @@ type testSpecOpts struct {
 
 	// If this is set along with headRef, the changesetSpec will have published
 	// set.
-	published bool
+	published interface{}
 
 	title         string
 	body          string
 	commitMessage string
 	commitDiff    string
+
+	baseRef string
+	baseRev string
 }
 
 var testChangsetSpecDiffStat = &diff.Stat{Added: 10, Changed: 5, Deleted: 2}
@@ -832,7 +863,9 @@ func createChangesetSpec(
 
 			ExternalID: opts.externalID,
 			HeadRef:    opts.headRef,
-			Published:  opts.published,
+			BaseRef:    opts.baseRef,
+			BaseRev:    opts.baseRev,
+			Published:  campaigns.PublishedValue{Val: opts.published},
 
 			Title: opts.title,
 			Body:  opts.body,
@@ -0,0 +1,124 @@
+package campaigns
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/dineshappavoo/basex"
+	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/campaigns"
+	"github.com/sourcegraph/sourcegraph/internal/db"
+	"github.com/sourcegraph/sourcegraph/internal/trace"
+)
+
+// ErrNoChangesetsSelected is returned by CreateBulkOperation if no changesets
+// were given.
+var ErrNoChangesetsSelected = errors.New("no changesets selected")
+
+// ErrChangesetsNotInCampaign is returned by CreateBulkOperation if one of the
+// given changesets doesn't exist or isn't attached to the campaign.
+var ErrChangesetsNotInCampaign = errors.New("not all changesets are attached to the campaign")
+
+// CreateBulkOperationOpts are the options for CreateBulkOperation.
+type CreateBulkOperationOpts struct {
+	CampaignID   int64
+	ChangesetIDs []int64
+
+	JobType campaigns.ChangesetJobType
+	// Payload is passed to each ChangesetJob. See campaigns.ChangesetJob for
+	// the payload each job type expects.
+	Payload interface{}
+}
+
+// CreateBulkOperation enqueues a ChangesetJob for each of the given
+// changesets of the campaign, which are processed in the background, and
+// returns the resulting BulkOperation.
+func (s *Service) CreateBulkOperation(ctx context.Context, opts CreateBulkOperationOpts) (op *campaigns.BulkOperation, err error) {
+	traceTitle := fmt.Sprintf("campaign: %d, type: %s, changesets: %d", opts.CampaignID, opts.JobType, len(opts.ChangesetIDs))
+	tr, ctx := trace.New(ctx, "service.CreateBulkOperation", traceTitle)
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+
+	if !opts.JobType.Valid() {
+		return nil, errors.Errorf("invalid changeset job type %q", opts.JobType)
+	}
+
+	if len(opts.ChangesetIDs) == 0 {
+		return nil, ErrNoChangesetsSelected
+	}
+
+	campaign, err := s.store.GetCampaign(ctx, GetCampaignOpts{ID: opts.CampaignID})
+	if err != nil {
+		return nil, errors.Wrap(err, "getting campaign")
+	}
+
+	// 🚨 SECURITY: Only the author of the campaign and site admins can apply
+	// operations to its changesets.
+	if err := backend.CheckSiteAdminOrSameUser(ctx, campaign.InitialApplierID); err != nil {
+		return nil, err
+	}
+
+	cs, _, err := s.store.ListChangesets(ctx, ListChangesetsOpts{
+		CampaignID: campaign.ID,
+		IDs:        opts.ChangesetIDs,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "listing changesets")
+	}
+
+	// 🚨 SECURITY: db.Repos.GetReposSetByIDs uses the authzFilter under the
+	// hood and filters out repositories that the user doesn't have access to.
+	accessibleReposByID, err := db.Repos.GetReposSetByIDs(ctx, cs.RepoIDs()...)
+	if err != nil {
+		return nil, err
+	}
+
+	selected := make(map[int64]struct{}, len(opts.ChangesetIDs))
+	for _, id := range opts.ChangesetIDs {
+		selected[id] = struct{}{}
+	}
+	if len(cs) != len(selected) {
+		return nil, ErrChangesetsNotInCampaign
+	}
+
+	for _, c := range cs {
+		// 🚨 SECURITY: We return an error if the user doesn't have access to
+		// one of the repositories of the selected changesets.
+		if _, ok := accessibleReposByID[c.RepoID]; !ok {
+			return nil, &db.RepoNotFoundErr{ID: c.RepoID}
+		}
+	}
+
+	bulkGroup, err := basex.Encode(strconv.Itoa(seededRand.Int()))
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := s.store.Transact(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { err = tx.Done(err) }()
+
+	userID := actor.FromContext(ctx).UID
+	for _, c := range cs {
+		job := &campaigns.ChangesetJob{
+			BulkGroup:   bulkGroup,
+			UserID:      userID,
+			CampaignID:  campaign.ID,
+			ChangesetID: c.ID,
+			JobType:     opts.JobType,
+			Payload:     opts.Payload,
+		}
+		if err := tx.CreateChangesetJob(ctx, job); err != nil {
+			return nil, err
+		}
+	}
+
+	return tx.GetBulkOperation(ctx, GetBulkOperationOpts{ID: bulkGroup})
+}
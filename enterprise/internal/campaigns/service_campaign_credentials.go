@@ -0,0 +1,171 @@
+package campaigns
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
+	"github.com/sourcegraph/sourcegraph/internal/campaigns"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/trace"
+)
+
+// ErrCampaignCredentialKindNotSupported is returned by
+// CreateCampaignCredential if campaigns can't push to the given kind of code
+// host.
+var ErrCampaignCredentialKindNotSupported = errors.New("campaign credentials are only supported for GitHub, GitLab and Bitbucket Server")
+
+// ErrCampaignCredentialUsernameRequired is returned by
+// CreateCampaignCredential if a credential for Bitbucket Server is created
+// without a username.
+var ErrCampaignCredentialUsernameRequired = errors.New("a username is required for Bitbucket Server credentials")
+
+// CreateCampaignCredentialOpts holds the arguments to
+// CreateCampaignCredential.
+//
+// If neither NamespaceUserID nor NamespaceOrgID is set, a site credential is
+// created.
+type CreateCampaignCredentialOpts struct {
+	NamespaceUserID int32
+	NamespaceOrgID  int32
+
+	ExternalServiceKind string
+	ExternalServiceURL  string
+
+	Username string
+	Token    string
+}
+
+func (o CreateCampaignCredentialOpts) String() string {
+	return fmt.Sprintf(
+		"NamespaceUserID %d, NamespaceOrgID %d, ExternalServiceKind %q, ExternalServiceURL %q",
+		o.NamespaceUserID,
+		o.NamespaceOrgID,
+		o.ExternalServiceKind,
+		o.ExternalServiceURL,
+	)
+}
+
+// CreateCampaignCredential stores the given code host credential for the
+// given namespace. If the namespace already has a credential for the code
+// host, it's replaced.
+func (s *Service) CreateCampaignCredential(ctx context.Context, opts CreateCampaignCredentialOpts) (cred *campaigns.CampaignCredential, err error) {
+	tr, ctx := trace.New(ctx, "Service.CreateCampaignCredential", opts.String())
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+
+	// 🚨 SECURITY: Only site admins can create site credentials and only
+	// members of a namespace can create the credentials of the namespace.
+	if err := checkCampaignCredentialAccess(ctx, opts.NamespaceUserID, opts.NamespaceOrgID); err != nil {
+		return nil, err
+	}
+
+	switch opts.ExternalServiceKind {
+	case extsvc.KindGitHub, extsvc.KindGitLab:
+	case extsvc.KindBitbucketServer:
+		if opts.Username == "" {
+			return nil, ErrCampaignCredentialUsernameRequired
+		}
+	default:
+		return nil, ErrCampaignCredentialKindNotSupported
+	}
+
+	if opts.Token == "" {
+		return nil, errors.New("credential must not be blank")
+	}
+
+	baseURL, err := url.Parse(opts.ExternalServiceURL)
+	if err != nil || baseURL.Host == "" {
+		return nil, errors.Errorf("invalid external service URL %q", opts.ExternalServiceURL)
+	}
+	serviceID := extsvc.NormalizeBaseURL(baseURL).String()
+	serviceType := extsvc.KindToType(opts.ExternalServiceKind)
+
+	tx, err := s.store.Transact(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { err = tx.Done(err) }()
+
+	cred, err = tx.GetCampaignCredential(ctx, GetCampaignCredentialOpts{
+		NamespaceUserID:     opts.NamespaceUserID,
+		NamespaceOrgID:      opts.NamespaceOrgID,
+		ExternalServiceType: serviceType,
+		ExternalServiceID:   serviceID,
+	})
+	if err != nil && err != ErrNoResults {
+		return nil, err
+	}
+
+	if err == ErrNoResults {
+		cred = &campaigns.CampaignCredential{
+			NamespaceUserID:     opts.NamespaceUserID,
+			NamespaceOrgID:      opts.NamespaceOrgID,
+			ExternalServiceType: serviceType,
+			ExternalServiceID:   serviceID,
+			Username:            opts.Username,
+			Token:               opts.Token,
+		}
+		return cred, tx.CreateCampaignCredential(ctx, cred)
+	}
+
+	cred.Username = opts.Username
+	cred.Token = opts.Token
+	return cred, tx.UpdateCampaignCredential(ctx, cred)
+}
+
+// DeleteCampaignCredential deletes the CampaignCredential with the given ID.
+func (s *Service) DeleteCampaignCredential(ctx context.Context, id int64) (err error) {
+	traceTitle := fmt.Sprintf("campaignCredential: %d", id)
+	tr, ctx := trace.New(ctx, "service.DeleteCampaignCredential", traceTitle)
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+
+	cred, err := s.store.GetCampaignCredential(ctx, GetCampaignCredentialOpts{ID: id})
+	if err != nil {
+		return err
+	}
+
+	// 🚨 SECURITY: Only site admins can delete site credentials and only
+	// members of a namespace can delete the credentials of the namespace.
+	if err := checkCampaignCredentialAccess(ctx, cred.NamespaceUserID, cred.NamespaceOrgID); err != nil {
+		return err
+	}
+
+	return s.store.DeleteCampaignCredential(ctx, id)
+}
+
+// ListCampaignCredentials lists the CampaignCredentials of the namespace in
+// the given options, or the site credentials if no namespace is given.
+func (s *Service) ListCampaignCredentials(ctx context.Context, opts ListCampaignCredentialsOpts) (cs []*campaigns.CampaignCredential, next int64, err error) {
+	traceTitle := fmt.Sprintf("namespaceUserID: %d, namespaceOrgID: %d", opts.NamespaceUserID, opts.NamespaceOrgID)
+	tr, ctx := trace.New(ctx, "service.ListCampaignCredentials", traceTitle)
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+
+	// 🚨 SECURITY: Only site admins can list site credentials and only
+	// members of a namespace can list the credentials of the namespace.
+	if err := checkCampaignCredentialAccess(ctx, opts.NamespaceUserID, opts.NamespaceOrgID); err != nil {
+		return nil, 0, err
+	}
+
+	return s.store.ListCampaignCredentials(ctx, opts)
+}
+
+// checkCampaignCredentialAccess checks whether the current user in the ctx has
+// access to the campaign credentials of the given namespace. If neither
+// namespaceUserID nor namespaceOrgID is set, only site admins have access.
+func checkCampaignCredentialAccess(ctx context.Context, namespaceUserID, namespaceOrgID int32) error {
+	if namespaceUserID == 0 && namespaceOrgID == 0 {
+		return backend.CheckCurrentUserIsSiteAdmin(ctx)
+	}
+	return checkNamespaceAccess(ctx, namespaceUserID, namespaceOrgID)
+}
@@ -0,0 +1,155 @@
+package campaigns
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/campaigns"
+	"github.com/sourcegraph/sourcegraph/internal/db"
+	"github.com/sourcegraph/sourcegraph/internal/trace"
+)
+
+// ErrCampaignSpecHasNoSteps is returned by ExecuteCampaignSpec if the
+// CampaignSpec doesn't define any steps that could be executed.
+var ErrCampaignSpecHasNoSteps = errors.New("campaign spec has no steps to execute")
+
+// ErrCampaignSpecAlreadyExecuted is returned by ExecuteCampaignSpec if the
+// CampaignSpec has already been executed.
+var ErrCampaignSpecAlreadyExecuted = errors.New("campaign spec has already been executed")
+
+// ExecutionTarget is a repository and revision that the steps of a
+// CampaignSpec are executed on.
+type ExecutionTarget struct {
+	RepoID  api.RepoID
+	BaseRef string
+	BaseRev string
+
+	// SearchResultPaths are the paths of the files in the repository that
+	// matched the `on` clauses.
+	SearchResultPaths []string
+}
+
+type ExecuteCampaignSpecOpts struct {
+	CampaignSpecRandID string
+
+	// Targets are the repositories and revisions that the `on` clauses of
+	// the CampaignSpec resolved to.
+	Targets []ExecutionTarget
+}
+
+// ExecuteCampaignSpec enqueues a CampaignSpecExecution for each of the given
+// targets, which will be picked up by the executor that runs the steps of
+// the CampaignSpec and creates ChangesetSpecs from the resulting diffs. It
+// returns ErrServerSideExecutionDisabled unless server-side execution is
+// enabled in the site configuration.
+//
+// Targets for which the step cache already contains the diff of the same
+// steps on the same revision are not enqueued: their executions are completed
+// right away with the cached diff, so that re-applying a campaign only runs
+// the steps in repositories that changed.
+func (s *Service) ExecuteCampaignSpec(ctx context.Context, opts ExecuteCampaignSpecOpts) (executions []*campaigns.CampaignSpecExecution, err error) {
+	tr, ctx := trace.New(ctx, "Service.ExecuteCampaignSpec", fmt.Sprintf("CampaignSpec %s", opts.CampaignSpecRandID))
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+
+	if _, ok := serverSideExecutionConfig(); !ok {
+		return nil, ErrServerSideExecutionDisabled
+	}
+
+	tx, err := s.store.Transact(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { err = tx.Done(err) }()
+
+	campaignSpec, err := tx.GetCampaignSpec(ctx, GetCampaignSpecOpts{RandID: opts.CampaignSpecRandID})
+	if err != nil {
+		return nil, err
+	}
+
+	// 🚨 SECURITY: Only site-admins or the creator of campaignSpec can execute
+	// it.
+	if err := backend.CheckSiteAdminOrSameUser(ctx, campaignSpec.UserID); err != nil {
+		return nil, err
+	}
+
+	if len(campaignSpec.Spec.Steps) == 0 {
+		return nil, ErrCampaignSpecHasNoSteps
+	}
+
+	existing, _, err := tx.ListCampaignSpecExecutions(ctx, ListCampaignSpecExecutionsOpts{
+		LimitOpts:      LimitOpts{Limit: 1},
+		CampaignSpecID: campaignSpec.ID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(existing) != 0 {
+		return nil, ErrCampaignSpecAlreadyExecuted
+	}
+
+	repoIDs := make([]api.RepoID, 0, len(opts.Targets))
+	for _, target := range opts.Targets {
+		repoIDs = append(repoIDs, target.RepoID)
+	}
+
+	// 🚨 SECURITY: db.Repos.GetReposSetByIDs uses the authzFilter under the
+	// hood and filters out repositories that the user doesn't have access to.
+	accessibleReposByID, err := db.Repos.GetReposSetByIDs(ctx, repoIDs...)
+	if err != nil {
+		return nil, err
+	}
+
+	executions = make([]*campaigns.CampaignSpecExecution, 0, len(opts.Targets))
+	for _, target := range opts.Targets {
+		// 🚨 SECURITY: We return an error if the user doesn't have access to
+		// one of the repositories.
+		repo, ok := accessibleReposByID[target.RepoID]
+		if !ok {
+			return nil, &db.RepoNotFoundErr{ID: target.RepoID}
+		}
+
+		execution := &campaigns.CampaignSpecExecution{
+			CampaignSpecID:    campaignSpec.ID,
+			RepoID:            target.RepoID,
+			BaseRef:           target.BaseRef,
+			BaseRev:           target.BaseRev,
+			SearchResultPaths: target.SearchResultPaths,
+			UserID:            campaignSpec.UserID,
+		}
+
+		stepsHash, err := campaigns.CampaignSpecStepsHash(campaignSpec.Spec.Steps, templateRepository(repo.Name, execution))
+		if err != nil {
+			return nil, err
+		}
+
+		entry, err := tx.GetCampaignStepCacheEntry(ctx, GetCampaignStepCacheEntryOpts{
+			RepoID:    target.RepoID,
+			BaseRev:   target.BaseRev,
+			StepsHash: stepsHash,
+		})
+		if err != nil && err != ErrNoResults {
+			return nil, err
+		}
+		if entry != nil {
+			if err := attachDiff(ctx, tx, campaignSpec, execution, repo.Name, entry); err != nil {
+				return nil, err
+			}
+			execution.CacheHit = true
+			execution.State = campaigns.CampaignSpecExecutionStateCompleted
+			execution.FinishedAt = s.clock()
+		}
+
+		if err := tx.CreateCampaignSpecExecution(ctx, execution); err != nil {
+			return nil, err
+		}
+		executions = append(executions, execution)
+	}
+
+	return executions, nil
+}
@@ -0,0 +1,146 @@
+package campaigns
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+
+	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/cmd/repo-updater/repos"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/campaigns"
+	"github.com/sourcegraph/sourcegraph/internal/db"
+)
+
+// ErrImportByQueryNotSupported is returned by CreateCampaignSpec if the
+// campaign spec imports changesets by query from a code host that can't
+// search for changesets.
+var ErrImportByQueryNotSupported = errors.New("importing changesets by query is not supported on this code host")
+
+// MaxImportedChangesetsByQuery is the maximum number of changesets that each
+// query, and all queries together, in the importChangesets of a campaign spec
+// may import. Changesets are searched while the campaign spec is created, so
+// the number of code host requests needs to be bounded.
+const MaxImportedChangesetsByQuery = 500
+
+// ErrTooManyImportedChangesets is returned by CreateCampaignSpec if the
+// queries in the importChangesets of the campaign spec match more than
+// MaxImportedChangesetsByQuery changesets.
+var ErrTooManyImportedChangesets = errors.Errorf("the importChangesets queries match more than %d changesets: narrow them down with state, labels or headRef", MaxImportedChangesetsByQuery)
+
+// importChangesetSpecs searches the code hosts for the changesets matching the
+// queries in the importChangesets of the given CampaignSpec and returns a
+// ChangesetSpec that imports each of them. The ChangesetSpecs are not
+// persisted and not yet attached to the CampaignSpec.
+//
+// Changesets that are already imported by one of the given existing
+// ChangesetSpecs, or by an earlier query, are skipped. If the queries match
+// more than MaxImportedChangesetsByQuery changesets,
+// ErrTooManyImportedChangesets is returned.
+func (s *Service) importChangesetSpecs(ctx context.Context, spec *campaigns.CampaignSpec, existing campaigns.ChangesetSpecs) ([]*campaigns.ChangesetSpec, error) {
+	seen := make(map[repoExternalID]struct{}, len(existing))
+	for _, cs := range existing {
+		if cs.Spec.IsImportingExisting() {
+			seen[repoExternalID{repo: cs.RepoID, externalID: cs.Spec.ExternalID}] = struct{}{}
+		}
+	}
+
+	var (
+		specs      []*campaigns.ChangesetSpec
+		reposStore = repos.NewDBStore(s.store.Handle().DB(), sql.TxOptions{})
+	)
+	for i, ic := range spec.Spec.ImportChangesets {
+		if ic.Query == nil {
+			continue
+		}
+
+		// 🚨 SECURITY: db.Repos.GetByName uses the authzFilter under the hood
+		// and returns an error if the user doesn't have access to the
+		// repository.
+		r, err := db.Repos.GetByName(ctx, api.RepoName(ic.Repository))
+		if err != nil {
+			return nil, errors.Wrapf(err, "importChangesets[%d]", i)
+		}
+
+		found, err := s.listChangesets(ctx, reposStore, r.ID, repos.ListChangesetsOpts{
+			State:   ic.Query.ExternalState(),
+			Labels:  ic.Query.Labels,
+			HeadRef: ic.Query.HeadRef,
+			Limit:   MaxImportedChangesetsByQuery,
+		})
+		if err != nil {
+			return nil, errors.Wrapf(err, "importChangesets[%d]", i)
+		}
+		if len(found) > MaxImportedChangesetsByQuery {
+			return nil, errors.Wrapf(ErrTooManyImportedChangesets, "importChangesets[%d]", i)
+		}
+
+		for _, c := range found {
+			k := repoExternalID{repo: r.ID, externalID: c.ExternalID}
+			if _, ok := seen[k]; ok {
+				continue
+			}
+			seen[k] = struct{}{}
+
+			cs, err := buildImportChangesetSpec(r.ID, c.ExternalID)
+			if err != nil {
+				return nil, err
+			}
+			cs.UserID = spec.UserID
+			specs = append(specs, cs)
+		}
+
+		if len(specs) > MaxImportedChangesetsByQuery {
+			return nil, ErrTooManyImportedChangesets
+		}
+	}
+
+	return specs, nil
+}
+
+// listChangesets lists the changesets in the given repository that match the
+// given options on its code host.
+func (s *Service) listChangesets(ctx context.Context, reposStore repos.Store, repoID api.RepoID, opts repos.ListChangesetsOpts) ([]*repos.Changeset, error) {
+	repo, err := loadRepo(ctx, reposStore, repoID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to load repository")
+	}
+
+	extSvc, err := loadExternalService(ctx, reposStore, repo)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to load external service")
+	}
+
+	ccs, err := buildChangesetSource(s.sourcer, repo, extSvc)
+	if err != nil {
+		return nil, err
+	}
+
+	lister, ok := ccs.(repos.ChangesetListingSource)
+	if !ok {
+		return nil, ErrImportByQueryNotSupported
+	}
+
+	return lister.ListChangesets(ctx, repo, opts)
+}
+
+// buildImportChangesetSpec returns a ChangesetSpec that imports the existing
+// changeset with the given external ID in the given repository.
+func buildImportChangesetSpec(repoID api.RepoID, externalID string) (*campaigns.ChangesetSpec, error) {
+	rawSpec, err := json.Marshal(campaigns.ChangesetSpecDescription{
+		BaseRepository: graphqlbackend.MarshalRepositoryID(repoID),
+		ExternalID:     externalID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	spec, err := campaigns.NewChangesetSpecFromRaw(string(rawSpec))
+	if err != nil {
+		return nil, err
+	}
+	spec.RepoID = repoID
+
+	return spec, nil
+}
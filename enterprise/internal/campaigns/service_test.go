@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
@@ -17,6 +18,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/actor"
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/campaigns"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
 	"github.com/sourcegraph/sourcegraph/internal/db"
 	"github.com/sourcegraph/sourcegraph/internal/db/dbconn"
 	"github.com/sourcegraph/sourcegraph/internal/db/dbtesting"
@@ -151,6 +153,15 @@ func TestServicePermissionLevels(t *testing.T) {
 				tc.assertFunc(t, err)
 			})
 
+			t.Run("CreateBulkOperation", func(t *testing.T) {
+				_, err := svc.CreateBulkOperation(currentUserCtx, CreateBulkOperationOpts{
+					CampaignID:   campaign.ID,
+					ChangesetIDs: []int64{changeset.ID},
+					JobType:      campaigns.ChangesetJobTypeMerge,
+				})
+				tc.assertFunc(t, err)
+			})
+
 			t.Run("CloseCampaign", func(t *testing.T) {
 				_, err := svc.CloseCampaign(currentUserCtx, campaign.ID, false)
 				tc.assertFunc(t, err)
@@ -351,6 +362,98 @@ func TestService(t *testing.T) {
 		}
 	})
 
+	t.Run("CreateBulkOperation", func(t *testing.T) {
+		spec := testCampaignSpec(admin.ID)
+		if err := store.CreateCampaignSpec(ctx, spec); err != nil {
+			t.Fatal(err)
+		}
+
+		campaign := testCampaign(admin.ID, spec)
+		if err := store.CreateCampaign(ctx, campaign); err != nil {
+			t.Fatal(err)
+		}
+
+		changeset := testChangeset(rs[0].ID, campaign.ID, campaigns.ChangesetExternalStateOpen)
+		if err := store.CreateChangeset(ctx, changeset); err != nil {
+			t.Fatal(err)
+		}
+		otherChangeset := testChangeset(rs[1].ID, 0, campaigns.ChangesetExternalStateOpen)
+		if err := store.CreateChangeset(ctx, otherChangeset); err != nil {
+			t.Fatal(err)
+		}
+
+		adminCtx := actor.WithActor(context.Background(), actor.FromUser(admin.ID))
+
+		t.Run("success", func(t *testing.T) {
+			op, err := svc.CreateBulkOperation(adminCtx, CreateBulkOperationOpts{
+				CampaignID:   campaign.ID,
+				ChangesetIDs: []int64{changeset.ID},
+				JobType:      campaigns.ChangesetJobTypeComment,
+				Payload:      &campaigns.ChangesetJobCommentPayload{Message: "hello"},
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			want := &campaigns.BulkOperation{
+				ID:             op.ID,
+				Type:           campaigns.ChangesetJobTypeComment,
+				State:          campaigns.BulkOperationStateProcessing,
+				UserID:         admin.ID,
+				CampaignID:     campaign.ID,
+				ChangesetCount: 1,
+				CreatedAt:      now,
+			}
+			if diff := cmp.Diff(want, op); diff != "" {
+				t.Fatalf("unexpected bulk operation (-want +got):\n%s", diff)
+			}
+
+			jobs, _, err := store.ListChangesetJobs(ctx, ListChangesetJobsOpts{BulkGroup: op.ID})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(jobs) != 1 {
+				t.Fatalf("wrong number of jobs. want=1, have=%d", len(jobs))
+			}
+			wantPayload := &campaigns.ChangesetJobCommentPayload{Message: "hello"}
+			if diff := cmp.Diff(wantPayload, jobs[0].Payload); diff != "" {
+				t.Fatalf("unexpected payload (-want +got):\n%s", diff)
+			}
+		})
+
+		t.Run("changeset not in campaign", func(t *testing.T) {
+			_, err := svc.CreateBulkOperation(adminCtx, CreateBulkOperationOpts{
+				CampaignID:   campaign.ID,
+				ChangesetIDs: []int64{changeset.ID, otherChangeset.ID},
+				JobType:      campaigns.ChangesetJobTypeMerge,
+			})
+			if err != ErrChangesetsNotInCampaign {
+				t.Fatalf("wrong error. want=%s, have=%v", ErrChangesetsNotInCampaign, err)
+			}
+		})
+
+		t.Run("no changesets", func(t *testing.T) {
+			_, err := svc.CreateBulkOperation(adminCtx, CreateBulkOperationOpts{
+				CampaignID: campaign.ID,
+				JobType:    campaigns.ChangesetJobTypeMerge,
+			})
+			if err != ErrNoChangesetsSelected {
+				t.Fatalf("wrong error. want=%s, have=%v", ErrNoChangesetsSelected, err)
+			}
+		})
+
+		t.Run("invalid job type", func(t *testing.T) {
+			_, err := svc.CreateBulkOperation(adminCtx, CreateBulkOperationOpts{
+				CampaignID:   campaign.ID,
+				ChangesetIDs: []int64{changeset.ID},
+				JobType:      campaigns.ChangesetJobType("EXPLODE"),
+			})
+			if err == nil {
+				t.Fatal("expected error for invalid job type")
+			}
+		})
+	})
+
 	t.Run("CreateCampaignSpec", func(t *testing.T) {
 		changesetSpecs := make([]*campaigns.ChangesetSpec, 0, len(rs))
 		changesetSpecRandIDs := make([]string, 0, len(rs))
@@ -431,6 +534,120 @@ func TestService(t *testing.T) {
 			}
 		})
 
+		t.Run("import by query", func(t *testing.T) {
+			fakeSource.ListedMetadata = []interface{}{
+				&github.PullRequest{Number: 1, HeadRefName: "migration-1"},
+				&github.PullRequest{Number: 2, HeadRefName: "migration-2"},
+			}
+			fakeSource.ListChangesetsOpts = nil
+			defer func() { fakeSource.ListedMetadata = nil }()
+
+			// Changeset 1 is already imported by its external ID, so only
+			// changeset 2 should be imported by the query.
+			existing := &campaigns.ChangesetSpec{
+				RepoID: rs[0].ID,
+				UserID: admin.ID,
+				Spec: &campaigns.ChangesetSpecDescription{
+					BaseRepository: graphqlbackend.MarshalRepositoryID(rs[0].ID),
+					ExternalID:     "1",
+				},
+			}
+			if err := store.CreateChangesetSpec(ctx, existing); err != nil {
+				t.Fatal(err)
+			}
+
+			rawSpec := fmt.Sprintf(`
+name: import-by-query
+importChangesets:
+- repository: %s
+  query:
+    labels: [migration]
+    headRef: migration-*
+`, rs[0].Name)
+
+			spec, err := svc.CreateCampaignSpec(adminCtx, CreateCampaignSpecOpts{
+				NamespaceUserID:      admin.ID,
+				RawSpec:              rawSpec,
+				ChangesetSpecRandIDs: []string{existing.RandID},
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			wantOpts := []repos.ListChangesetsOpts{{
+				State:   campaigns.ChangesetExternalStateOpen,
+				Labels:  []string{"migration"},
+				HeadRef: "migration-*",
+				Limit:   MaxImportedChangesetsByQuery,
+			}}
+			if diff := cmp.Diff(wantOpts, fakeSource.ListChangesetsOpts); diff != "" {
+				t.Fatalf("wrong list options (-want +got):\n%s", diff)
+			}
+
+			cs, _, err := store.ListChangesetSpecs(ctx, ListChangesetSpecsOpts{CampaignSpecID: spec.ID})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var have []string
+			for _, c := range cs {
+				if c.RepoID != rs[0].ID {
+					t.Errorf("changeset spec has wrong repo. want=%d, have=%d", rs[0].ID, c.RepoID)
+				}
+				if c.UserID != admin.ID {
+					t.Errorf("changeset spec has wrong user. want=%d, have=%d", admin.ID, c.UserID)
+				}
+				have = append(have, c.Spec.ExternalID)
+			}
+			if diff := cmp.Diff([]string{"1", "2"}, have); diff != "" {
+				t.Fatalf("wrong imported changesets (-want +got):\n%s", diff)
+			}
+		})
+
+		t.Run("import by query matching too many changesets", func(t *testing.T) {
+			fakeSource.ListedMetadata = nil
+			for i := 0; i <= MaxImportedChangesetsByQuery; i++ {
+				fakeSource.ListedMetadata = append(fakeSource.ListedMetadata, &github.PullRequest{Number: int64(i + 1)})
+			}
+			defer func() { fakeSource.ListedMetadata = nil }()
+
+			rawSpec := fmt.Sprintf(`
+name: import-by-query
+importChangesets:
+- repository: %s
+  query:
+    state: all
+`, rs[0].Name)
+
+			_, err := svc.CreateCampaignSpec(adminCtx, CreateCampaignSpecOpts{
+				NamespaceUserID: admin.ID,
+				RawSpec:         rawSpec,
+			})
+			if !errors.Is(err, ErrTooManyImportedChangesets) {
+				t.Fatalf("expected ErrTooManyImportedChangesets but got %v", err)
+			}
+		})
+
+		t.Run("import by query without repository permissions", func(t *testing.T) {
+			ct.AuthzFilterRepos(t, rs[0].ID)
+
+			rawSpec := fmt.Sprintf(`
+name: import-by-query
+importChangesets:
+- repository: %s
+  query:
+    state: all
+`, rs[0].Name)
+
+			_, err := svc.CreateCampaignSpec(adminCtx, CreateCampaignSpecOpts{
+				NamespaceUserID: admin.ID,
+				RawSpec:         rawSpec,
+			})
+			if !errcode.IsNotFound(err) {
+				t.Fatalf("expected not-found error but got %s", err)
+			}
+		})
+
 		t.Run("missing repository permissions", func(t *testing.T) {
 			// Single repository filtered out by authzFilter
 			ct.AuthzFilterRepos(t, changesetSpecs[0].RepoID)
@@ -597,6 +814,178 @@ func TestService(t *testing.T) {
 		// See TestServiceApplyCampaign
 	})
 
+	t.Run("ExecuteCampaignSpec", func(t *testing.T) {
+		createSpec := func(t *testing.T, steps []campaigns.CampaignSpecStep) *campaigns.CampaignSpec {
+			t.Helper()
+
+			spec := testCampaignSpec(admin.ID)
+			spec.Spec.Steps = steps
+			if err := store.CreateCampaignSpec(ctx, spec); err != nil {
+				t.Fatal(err)
+			}
+			return spec
+		}
+
+		steps := []campaigns.CampaignSpecStep{{Run: "echo hello >> README.md", Container: "alpine:3"}}
+		targets := []ExecutionTarget{
+			{RepoID: rs[0].ID, BaseRef: "refs/heads/master", BaseRev: "d34db33f"},
+			{RepoID: rs[1].ID, BaseRef: "refs/heads/main", BaseRev: "f00b4r"},
+		}
+
+		t.Run("server-side execution disabled", func(t *testing.T) {
+			spec := createSpec(t, steps)
+
+			opts := ExecuteCampaignSpecOpts{CampaignSpecRandID: spec.RandID, Targets: targets}
+			if _, err := svc.ExecuteCampaignSpec(ctx, opts); err != ErrServerSideExecutionDisabled {
+				t.Fatalf("ExecuteCampaignSpec returned unexpected error: %s", err)
+			}
+		})
+
+		conf.Mock(&conf.Unified{SiteConfiguration: schema.SiteConfiguration{
+			CampaignsServerSideExecution: &schema.CampaignsServerSideExecution{Enabled: true},
+		}})
+		defer conf.Mock(nil)
+
+		t.Run("success", func(t *testing.T) {
+			spec := createSpec(t, steps)
+
+			opts := ExecuteCampaignSpecOpts{CampaignSpecRandID: spec.RandID, Targets: targets}
+			executions, err := svc.ExecuteCampaignSpec(ctx, opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			have, _, err := store.ListCampaignSpecExecutions(ctx, ListCampaignSpecExecutionsOpts{CampaignSpecID: spec.ID})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(executions, have); diff != "" {
+				t.Fatalf("unexpected executions (-want +got):\n%s", diff)
+			}
+
+			for i, e := range have {
+				if e.RepoID != targets[i].RepoID || e.BaseRev != targets[i].BaseRev || e.BaseRef != targets[i].BaseRef {
+					t.Errorf("execution %d does not match target: %+v", i, e)
+				}
+				if e.State != campaigns.CampaignSpecExecutionStateQueued {
+					t.Errorf("execution %d has state %s, want queued", i, e.State)
+				}
+			}
+
+			if _, err := svc.ExecuteCampaignSpec(ctx, opts); err != ErrCampaignSpecAlreadyExecuted {
+				t.Fatalf("expected ErrCampaignSpecAlreadyExecuted, got %v", err)
+			}
+		})
+
+		t.Run("cache hit", func(t *testing.T) {
+			spec := testCampaignSpec(admin.ID)
+			spec.Spec.Steps = steps
+			spec.Spec.TransformChanges = &campaigns.TransformChanges{
+				Group: []campaigns.TransformChangesGroup{{Directory: "docs", Branch: "hello-docs"}},
+			}
+			spec.Spec.ChangesetTemplate = campaigns.ChangesetTemplate{
+				Title:  "Hello ${{ repository.name }}",
+				Branch: "hello",
+				Commit: campaigns.CommitTemplate{Message: "Say ${{ outputs.greeting }}"},
+			}
+			if err := store.CreateCampaignSpec(ctx, spec); err != nil {
+				t.Fatal(err)
+			}
+
+			readmeDiff := "diff --git a/README.md b/README.md\n--- a/README.md\n+++ b/README.md\n@@ -1 +1,2 @@\n # README\n+hello\n"
+			docsDiff := "diff --git a/docs/README.md b/docs/README.md\n--- a/docs/README.md\n+++ b/docs/README.md\n@@ -1 +1,2 @@\n # Docs\n+hello\n"
+
+			stepsHash, err := campaigns.CampaignSpecStepsHash(steps, campaigns.TemplateRepository{
+				Name:   string(rs[0].Name),
+				Branch: "master",
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			// The first target's steps already ran on the same revision.
+			err = store.UpsertCampaignStepCacheEntry(ctx, &campaigns.CampaignStepCacheEntry{
+				RepoID:    targets[0].RepoID,
+				BaseRev:   targets[0].BaseRev,
+				StepsHash: stepsHash,
+				Diff:      readmeDiff + docsDiff,
+				Outputs:   map[string]interface{}{"greeting": "hello"},
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			opts := ExecuteCampaignSpecOpts{CampaignSpecRandID: spec.RandID, Targets: targets}
+			executions, err := svc.ExecuteCampaignSpec(ctx, opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			hit, miss := executions[0], executions[1]
+			if !hit.CacheHit || hit.State != campaigns.CampaignSpecExecutionStateCompleted || len(hit.ChangesetSpecIDs) != 2 {
+				t.Fatalf("expected cached execution to be completed with two changeset specs: %+v", hit)
+			}
+			if miss.CacheHit || miss.State != campaigns.CampaignSpecExecutionStateQueued || len(miss.ChangesetSpecIDs) != 0 {
+				t.Fatalf("expected uncached execution to be queued: %+v", miss)
+			}
+
+			for i, want := range []struct{ headRef, diff string }{
+				{headRef: "refs/heads/hello", diff: readmeDiff},
+				{headRef: "refs/heads/hello-docs", diff: docsDiff},
+			} {
+				changesetSpec, err := store.GetChangesetSpec(ctx, GetChangesetSpecOpts{ID: hit.ChangesetSpecIDs[i]})
+				if err != nil {
+					t.Fatal(err)
+				}
+				if changesetSpec.CampaignSpecID != spec.ID {
+					t.Fatalf("changeset spec is attached to campaign spec %d, want %d", changesetSpec.CampaignSpecID, spec.ID)
+				}
+				if have := changesetSpec.Spec.HeadRef; have != want.headRef {
+					t.Fatalf("unexpected head ref. have=%q want=%q", have, want.headRef)
+				}
+				if have, want := changesetSpec.Spec.Title, "Hello "+string(rs[0].Name); have != want {
+					t.Fatalf("unexpected title. have=%q want=%q", have, want)
+				}
+				if diff, err := changesetSpec.Spec.Diff(); err != nil || diff != want.diff {
+					t.Fatalf("unexpected changeset spec diff %q (err: %v)", diff, err)
+				}
+				if msg, err := changesetSpec.Spec.CommitMessage(); err != nil || msg != "Say hello" {
+					t.Fatalf("unexpected commit message %q (err: %v)", msg, err)
+				}
+			}
+		})
+
+		t.Run("no steps", func(t *testing.T) {
+			spec := createSpec(t, nil)
+
+			opts := ExecuteCampaignSpecOpts{CampaignSpecRandID: spec.RandID, Targets: targets}
+			if _, err := svc.ExecuteCampaignSpec(ctx, opts); err != ErrCampaignSpecHasNoSteps {
+				t.Fatalf("expected ErrCampaignSpecHasNoSteps, got %v", err)
+			}
+		})
+
+		t.Run("not creator", func(t *testing.T) {
+			spec := createSpec(t, steps)
+
+			userCtx := actor.WithActor(context.Background(), actor.FromUser(user.ID))
+			opts := ExecuteCampaignSpecOpts{CampaignSpecRandID: spec.RandID, Targets: targets}
+			if _, err := svc.ExecuteCampaignSpec(userCtx, opts); !errcode.IsUnauthorized(err) {
+				t.Fatalf("expected unauthorized error but got %s", err)
+			}
+		})
+
+		t.Run("missing repository permissions", func(t *testing.T) {
+			spec := createSpec(t, steps)
+
+			ct.AuthzFilterRepos(t, rs[0].ID)
+
+			opts := ExecuteCampaignSpecOpts{CampaignSpecRandID: spec.RandID, Targets: targets}
+			if _, err := svc.ExecuteCampaignSpec(ctx, opts); !errcode.IsNotFound(err) {
+				t.Fatalf("expected not-found error but got %s", err)
+			}
+		})
+	})
+
 	t.Run("MoveCampaign", func(t *testing.T) {
 		createCampaign := func(t *testing.T, name string, authorID, userID, orgID int32) *campaigns.Campaign {
 			t.Helper()
@@ -717,6 +1106,108 @@ func TestService(t *testing.T) {
 		})
 	})
 
+	t.Run("CampaignCredentials", func(t *testing.T) {
+		adminCtx := actor.WithActor(context.Background(), actor.FromUser(admin.ID))
+		userCtx := actor.WithActor(context.Background(), actor.FromUser(user.ID))
+
+		t.Run("create and replace user credential", func(t *testing.T) {
+			opts := CreateCampaignCredentialOpts{
+				NamespaceUserID:     user.ID,
+				ExternalServiceKind: extsvc.KindGitHub,
+				ExternalServiceURL:  "https://GitHub.com",
+				Token:               "user-token",
+			}
+			cred, err := svc.CreateCampaignCredential(userCtx, opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if have, want := cred.ExternalServiceID, "https://github.com/"; have != want {
+				t.Fatalf("wrong ExternalServiceID. want=%q, have=%q", want, have)
+			}
+			if have, want := cred.ExternalServiceType, extsvc.TypeGitHub; have != want {
+				t.Fatalf("wrong ExternalServiceType. want=%q, have=%q", want, have)
+			}
+
+			opts.Token = "new-user-token"
+			replaced, err := svc.CreateCampaignCredential(userCtx, opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if have, want := replaced.ID, cred.ID; have != want {
+				t.Fatalf("credential not replaced. want ID=%d, have ID=%d", want, have)
+			}
+			if have, want := replaced.Token, opts.Token; have != want {
+				t.Fatalf("wrong Token. want=%q, have=%q", want, have)
+			}
+
+			if err := svc.DeleteCampaignCredential(userCtx, cred.ID); err != nil {
+				t.Fatal(err)
+			}
+		})
+
+		t.Run("credential for other user", func(t *testing.T) {
+			_, err := svc.CreateCampaignCredential(userCtx, CreateCampaignCredentialOpts{
+				NamespaceUserID:     admin.ID,
+				ExternalServiceKind: extsvc.KindGitHub,
+				ExternalServiceURL:  "https://github.com",
+				Token:               "token",
+			})
+			if !errcode.IsUnauthorized(err) {
+				t.Fatalf("expected unauthorized error but got %s", err)
+			}
+		})
+
+		t.Run("site credential", func(t *testing.T) {
+			opts := CreateCampaignCredentialOpts{
+				ExternalServiceKind: extsvc.KindGitHub,
+				ExternalServiceURL:  "https://github.com",
+				Token:               "site-token",
+			}
+			if _, err := svc.CreateCampaignCredential(userCtx, opts); err != backend.ErrMustBeSiteAdmin {
+				t.Fatalf("expected %s error but got %s", backend.ErrMustBeSiteAdmin, err)
+			}
+
+			cred, err := svc.CreateCampaignCredential(adminCtx, opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !cred.IsSiteCredential() {
+				t.Fatalf("credential is not a site credential: %+v", cred)
+			}
+
+			if err := svc.DeleteCampaignCredential(userCtx, cred.ID); err != backend.ErrMustBeSiteAdmin {
+				t.Fatalf("expected %s error but got %s", backend.ErrMustBeSiteAdmin, err)
+			}
+			if err := svc.DeleteCampaignCredential(adminCtx, cred.ID); err != nil {
+				t.Fatal(err)
+			}
+		})
+
+		t.Run("Bitbucket Server credential without username", func(t *testing.T) {
+			_, err := svc.CreateCampaignCredential(userCtx, CreateCampaignCredentialOpts{
+				NamespaceUserID:     user.ID,
+				ExternalServiceKind: extsvc.KindBitbucketServer,
+				ExternalServiceURL:  "https://bitbucket.sgdev.org",
+				Token:               "token",
+			})
+			if have, want := err, ErrCampaignCredentialUsernameRequired; have != want {
+				t.Fatalf("expected %s error but got %s", want, have)
+			}
+		})
+
+		t.Run("unsupported code host", func(t *testing.T) {
+			_, err := svc.CreateCampaignCredential(userCtx, CreateCampaignCredentialOpts{
+				NamespaceUserID:     user.ID,
+				ExternalServiceKind: extsvc.KindPhabricator,
+				ExternalServiceURL:  "https://phabricator.sgdev.org",
+				Token:               "token",
+			})
+			if have, want := err, ErrCampaignCredentialKindNotSupported; have != want {
+				t.Fatalf("expected %s error but got %s", want, have)
+			}
+		})
+	})
+
 	t.Run("GetCampaignMatchingCampaignSpec", func(t *testing.T) {
 		campaignSpec := createCampaignSpec(t, ctx, store, "matching-campaign-spec", admin.ID)
 
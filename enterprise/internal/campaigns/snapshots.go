@@ -0,0 +1,217 @@
+package campaigns
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/inconshreveable/log15"
+	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/campaigns"
+)
+
+// RunCampaignSnapshotter periodically takes a CampaignSnapshot of every open
+// campaign that doesn't have a snapshot taken within the last
+// campaigns.CampaignSnapshotInterval, and downsamples the snapshots older than
+// campaigns.CampaignSnapshotDownsampleAge. It blocks until ctx is canceled.
+func RunCampaignSnapshotter(ctx context.Context, s *Store) {
+	// We check more often than the snapshot interval, so that snapshots of
+	// new campaigns and of campaigns whose snapshot failed don't have to wait
+	// for a full interval.
+	t := time.NewTicker(campaigns.CampaignSnapshotInterval / 6)
+	defer t.Stop()
+
+	for {
+		ids, err := s.ListCampaignIDsDueForSnapshot(ctx)
+		if err != nil {
+			log15.Error("ListCampaignIDsDueForSnapshot", "error", err)
+		}
+
+		for _, id := range ids {
+			if _, err := TakeCampaignSnapshot(ctx, s, id); err != nil {
+				log15.Error("TakeCampaignSnapshot", "campaign", id, "error", err)
+			}
+		}
+
+		if err := s.DownsampleCampaignSnapshots(ctx); err != nil {
+			log15.Error("DownsampleCampaignSnapshots", "error", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+		}
+	}
+}
+
+// TakeCampaignSnapshot calculates a CampaignSnapshot of the current state of
+// the published changesets of the given campaign and stores it.
+func TakeCampaignSnapshot(ctx context.Context, s *Store, campaignID int64) (*campaigns.CampaignSnapshot, error) {
+	snapshot, err := CurrentCampaignSnapshot(ctx, s, campaignID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.CreateCampaignSnapshot(ctx, snapshot); err != nil {
+		return nil, errors.Wrap(err, "creating campaign snapshot")
+	}
+
+	return snapshot, nil
+}
+
+// CurrentCampaignSnapshot calculates a CampaignSnapshot of the current state
+// of the published changesets of the given campaign without storing it.
+func CurrentCampaignSnapshot(ctx context.Context, s *Store, campaignID int64) (*campaigns.CampaignSnapshot, error) {
+	cs, _, err := s.ListChangesets(ctx, ListChangesetsOpts{CampaignID: campaignID, OnlyPublished: true})
+	if err != nil {
+		return nil, errors.Wrap(err, "listing changesets")
+	}
+
+	var es []*campaigns.ChangesetEvent
+	if len(cs) > 0 {
+		es, _, err = s.ListChangesetEvents(ctx, ListChangesetEventsOpts{ChangesetIDs: cs.IDs()})
+		if err != nil {
+			return nil, errors.Wrap(err, "listing changeset events")
+		}
+	}
+
+	snapshot := CalcCampaignSnapshot(s.now(), cs, es...)
+	snapshot.CampaignID = campaignID
+	return snapshot, nil
+}
+
+// CalcCampaignSnapshot calculates a CampaignSnapshot for the given Changesets
+// and their ChangesetEvents at the given point in time. The counts are based on
+// the current states of the Changesets, while the ChangesetEvents are used to
+// determine when Changesets were merged and when they last saw activity.
+func CalcCampaignSnapshot(now time.Time, cs []*campaigns.Changeset, es ...*campaigns.ChangesetEvent) *campaigns.CampaignSnapshot {
+	snapshot := &campaigns.CampaignSnapshot{CreatedAt: now}
+
+	// Sort all events once by their timestamps
+	events := ChangesetEvents(es)
+	sort.Sort(events)
+
+	// Grouping Events by their Changeset ID
+	byChangesetID := make(map[int64]ChangesetEvents)
+	for _, e := range events {
+		id := e.Changeset()
+		byChangesetID[id] = append(byChangesetID[id], e)
+	}
+
+	var timesToMerge []time.Duration
+	stallTimes := make(map[api.RepoID]time.Duration)
+
+	for _, c := range cs {
+		csEvents := byChangesetID[c.ID]
+
+		snapshot.Total++
+		switch c.ExternalState {
+		case campaigns.ChangesetExternalStateOpen:
+			snapshot.Open++
+			if c.PublicationState == campaigns.ChangesetPublicationStateDraft {
+				snapshot.Draft++
+			}
+			if c.ExternalCheckState == campaigns.ChangesetCheckStateFailed {
+				snapshot.FailedChecks++
+			}
+			switch c.ExternalReviewState {
+			case campaigns.ChangesetReviewStatePending:
+				snapshot.OpenPending++
+			case campaigns.ChangesetReviewStateApproved:
+				snapshot.OpenApproved++
+			case campaigns.ChangesetReviewStateChangesRequested:
+				snapshot.OpenChangesRequested++
+			}
+
+			// Changesets that haven't been synced yet don't have any known
+			// activity, so we can't tell whether they're stalled.
+			if last := lastActivityAt(c, csEvents); !last.IsZero() {
+				stallTime := now.Sub(last)
+				if current, ok := stallTimes[c.RepoID]; !ok || stallTime > current {
+					stallTimes[c.RepoID] = stallTime
+				}
+			}
+
+		case campaigns.ChangesetExternalStateMerged:
+			snapshot.Merged++
+			if d, ok := timeToMerge(c, csEvents); ok {
+				timesToMerge = append(timesToMerge, d)
+			}
+
+		case campaigns.ChangesetExternalStateClosed:
+			snapshot.Closed++
+		}
+	}
+
+	snapshot.MedianTimeToMerge = medianDuration(timesToMerge)
+
+	snapshot.RepoStallTimes = make([]campaigns.CampaignSnapshotRepoStallTime, 0, len(stallTimes))
+	for repoID, stallTime := range stallTimes {
+		snapshot.RepoStallTimes = append(snapshot.RepoStallTimes, campaigns.CampaignSnapshotRepoStallTime{
+			RepoID:    repoID,
+			StallTime: stallTime,
+		})
+	}
+	sort.Slice(snapshot.RepoStallTimes, func(i, j int) bool {
+		a, b := snapshot.RepoStallTimes[i], snapshot.RepoStallTimes[j]
+		if a.StallTime != b.StallTime {
+			return a.StallTime > b.StallTime
+		}
+		return a.RepoID < b.RepoID
+	})
+
+	return snapshot
+}
+
+// lastActivityAt returns the time of the most recent activity on the given
+// Changeset. The events MUST be sorted by their Timestamp.
+func lastActivityAt(c *campaigns.Changeset, events ChangesetEvents) time.Time {
+	last := c.ExternalUpdatedAt
+	if len(events) > 0 {
+		if t := events[len(events)-1].Timestamp(); t.After(last) {
+			last = t
+		}
+	}
+	if last.IsZero() {
+		last = c.ExternalCreatedAt()
+	}
+	return last
+}
+
+// timeToMerge returns the time between the opening and the merging of the
+// given merged Changeset, based on its history. The second return value is
+// false if the history doesn't contain the merge.
+func timeToMerge(c *campaigns.Changeset, events ChangesetEvents) (time.Duration, bool) {
+	history, err := computeHistory(c, events)
+	if err != nil {
+		return 0, false
+	}
+
+	for _, s := range history {
+		if s.externalState == campaigns.ChangesetExternalStateMerged {
+			return s.t.Sub(c.ExternalCreatedAt()), true
+		}
+	}
+
+	return 0, false
+}
+
+// medianDuration returns the median of the given durations, or zero if there
+// are none.
+func medianDuration(ds []time.Duration) time.Duration {
+	if len(ds) == 0 {
+		return 0
+	}
+
+	sorted := make([]time.Duration, len(ds))
+	copy(sorted, ds)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return (sorted[mid-1] + sorted[mid]) / 2
+	}
+	return sorted[mid]
+}
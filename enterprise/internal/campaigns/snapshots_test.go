@@ -0,0 +1,136 @@
+package campaigns
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/campaigns"
+)
+
+func TestCalcCampaignSnapshot(t *testing.T) {
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	daysAgo := func(days int) time.Time { return now.AddDate(0, 0, -days) }
+
+	withState := func(c *campaigns.Changeset, repoID api.RepoID, state campaigns.ChangesetExternalState) *campaigns.Changeset {
+		c.RepoID = repoID
+		c.ExternalState = state
+		c.ExternalReviewState = campaigns.ChangesetReviewStatePending
+		c.PublicationState = campaigns.ChangesetPublicationStatePublished
+		return c
+	}
+
+	tests := []struct {
+		name       string
+		changesets []*campaigns.Changeset
+		events     []*campaigns.ChangesetEvent
+		want       *campaigns.CampaignSnapshot
+	}{
+		{
+			name: "no changesets",
+			want: &campaigns.CampaignSnapshot{
+				CreatedAt:      now,
+				RepoStallTimes: []campaigns.CampaignSnapshotRepoStallTime{},
+			},
+		},
+		{
+			name: "open changesets",
+			changesets: func() []*campaigns.Changeset {
+				draft := withState(ghChangeset(1, daysAgo(5)), 1, campaigns.ChangesetExternalStateOpen)
+				draft.PublicationState = campaigns.ChangesetPublicationStateDraft
+
+				failed := withState(ghChangeset(2, daysAgo(3)), 1, campaigns.ChangesetExternalStateOpen)
+				failed.ExternalCheckState = campaigns.ChangesetCheckStateFailed
+				failed.ExternalReviewState = campaigns.ChangesetReviewStateChangesRequested
+				failed.ExternalUpdatedAt = daysAgo(1)
+
+				approved := withState(ghChangeset(3, daysAgo(4)), 2, campaigns.ChangesetExternalStateOpen)
+				approved.ExternalReviewState = campaigns.ChangesetReviewStateApproved
+
+				return []*campaigns.Changeset{draft, failed, approved}
+			}(),
+			events: []*campaigns.ChangesetEvent{
+				ghReview(3, daysAgo(2), "reviewer", "APPROVED"),
+			},
+			want: &campaigns.CampaignSnapshot{
+				Total:                3,
+				Open:                 3,
+				Draft:                1,
+				FailedChecks:         1,
+				OpenApproved:         1,
+				OpenChangesRequested: 1,
+				OpenPending:          1,
+				RepoStallTimes: []campaigns.CampaignSnapshotRepoStallTime{
+					// The least recently active changeset in repo 1 is the
+					// draft, which hasn't seen any activity since it was
+					// opened.
+					{RepoID: 1, StallTime: 5 * 24 * time.Hour},
+					{RepoID: 2, StallTime: 2 * 24 * time.Hour},
+				},
+				CreatedAt: now,
+			},
+		},
+		{
+			name: "merged and closed changesets",
+			changesets: []*campaigns.Changeset{
+				withState(ghChangeset(1, daysAgo(10)), 1, campaigns.ChangesetExternalStateMerged),
+				withState(ghChangeset(2, daysAgo(10)), 1, campaigns.ChangesetExternalStateMerged),
+				withState(ghChangeset(3, daysAgo(10)), 2, campaigns.ChangesetExternalStateMerged),
+				withState(ghChangeset(4, daysAgo(10)), 2, campaigns.ChangesetExternalStateClosed),
+			},
+			events: []*campaigns.ChangesetEvent{
+				event(t, daysAgo(9), campaigns.ChangesetEventKindGitHubMerged, 1),
+				event(t, daysAgo(6), campaigns.ChangesetEventKindGitHubMerged, 2),
+				event(t, daysAgo(4), campaigns.ChangesetEventKindGitHubMerged, 3),
+				event(t, daysAgo(8), campaigns.ChangesetEventKindGitHubClosed, 4),
+			},
+			want: &campaigns.CampaignSnapshot{
+				Total:             4,
+				Merged:            3,
+				Closed:            1,
+				MedianTimeToMerge: 4 * 24 * time.Hour,
+				RepoStallTimes:    []campaigns.CampaignSnapshotRepoStallTime{},
+				CreatedAt:         now,
+			},
+		},
+		{
+			name: "merged changeset without merge event",
+			changesets: []*campaigns.Changeset{
+				withState(ghChangeset(1, daysAgo(10)), 1, campaigns.ChangesetExternalStateMerged),
+			},
+			want: &campaigns.CampaignSnapshot{
+				Total:          1,
+				Merged:         1,
+				RepoStallTimes: []campaigns.CampaignSnapshotRepoStallTime{},
+				CreatedAt:      now,
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			have := CalcCampaignSnapshot(now, tc.changesets, tc.events...)
+			if diff := cmp.Diff(tc.want, have); diff != "" {
+				t.Errorf("wrong snapshot calculated. diff=%s", diff)
+			}
+		})
+	}
+}
+
+func TestMedianDuration(t *testing.T) {
+	for name, tc := range map[string]struct {
+		ds   []time.Duration
+		want time.Duration
+	}{
+		"empty": {ds: nil, want: 0},
+		"odd":   {ds: []time.Duration{3, 1, 2}, want: 2},
+		"even":  {ds: []time.Duration{4, 1, 2, 3}, want: 2},
+	} {
+		t.Run(name, func(t *testing.T) {
+			if have := medianDuration(tc.ds); have != tc.want {
+				t.Errorf("unexpected median: have %v; want %v", have, tc.want)
+			}
+		})
+	}
+}
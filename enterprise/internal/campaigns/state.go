@@ -17,6 +17,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketserver"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitlab"
+	gitlabwebhooks "github.com/sourcegraph/sourcegraph/internal/extsvc/gitlab/webhooks"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver"
 	"github.com/sourcegraph/sourcegraph/internal/vcs/git"
 )
@@ -31,6 +32,11 @@ func SetDerivedState(ctx context.Context, c *campaigns.Changeset, es []*campaign
 
 	c.ExternalCheckState = computeCheckState(c, events)
 
+	// Only changesets that exist on the code host can be drafts.
+	if c.Published() {
+		c.PublicationState = computePublicationState(c, events)
+	}
+
 	history, err := computeHistory(c, events)
 	if err != nil {
 		log15.Warn("Computing changeset history", "err", err)
@@ -109,6 +115,42 @@ func computeCheckState(c *campaigns.Changeset, events ChangesetEvents) campaigns
 	return campaigns.ChangesetCheckStateUnknown
 }
 
+// computePublicationState computes whether the published changeset is a draft,
+// based on the synced metadata and any webhook events that have arrived after
+// the most recent sync. The events should be presorted.
+func computePublicationState(c *campaigns.Changeset, events ChangesetEvents) campaigns.ChangesetPublicationState {
+	var draft bool
+	switch m := c.Metadata.(type) {
+	case *github.PullRequest:
+		draft = m.IsDraft
+	case *gitlab.MergeRequest:
+		draft = m.WorkInProgress
+	default:
+		// Bitbucket Server doesn't support drafts.
+		return c.PublicationState
+	}
+
+	for _, e := range events {
+		if !e.Timestamp().After(c.UpdatedAt) {
+			continue
+		}
+
+		switch e.Kind {
+		case campaigns.ChangesetEventKindGitHubConvertToDraft,
+			campaigns.ChangesetEventKindGitLabMarkWorkInProgress:
+			draft = true
+		case campaigns.ChangesetEventKindGitHubReadyForReview,
+			campaigns.ChangesetEventKindGitLabUnmarkWorkInProgress:
+			draft = false
+		}
+	}
+
+	if draft {
+		return campaigns.ChangesetPublicationStateDraft
+	}
+	return campaigns.ChangesetPublicationStatePublished
+}
+
 // computeExternalState computes the external state for the changeset and its
 // associated events.
 func computeExternalState(c *campaigns.Changeset, history []changesetStatesAtTime) (campaigns.ChangesetExternalState, error) {
@@ -650,6 +692,21 @@ func ComputeLabels(c *campaigns.Changeset, events ChangesetEvents) []campaigns.C
 				Color:       e.Label.Color,
 				Description: e.Label.Description,
 			}
+
+		case *gitlabwebhooks.LabelEvent:
+			if e.CreatedAt.Before(since) {
+				continue
+			}
+			if e.Removed {
+				delete(set, e.Name())
+				continue
+			}
+
+			set[e.Name()] = campaigns.ChangesetLabel{
+				Name:        e.Name(),
+				Color:       strings.TrimPrefix(e.Label.Color, "#"),
+				Description: e.Label.Description,
+			}
 		}
 	}
 	labels := make([]campaigns.ChangesetLabel, 0, len(set))
@@ -12,6 +12,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketserver"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitlab"
+	gitlabwebhooks "github.com/sourcegraph/sourcegraph/internal/extsvc/gitlab/webhooks"
 )
 
 func TestComputeGithubCheckState(t *testing.T) {
@@ -681,6 +682,19 @@ func TestComputeLabels(t *testing.T) {
 			},
 		}
 	}
+	gitLabLabelEvent := func(name string, kind cmpgn.ChangesetEventKind, when time.Time) *cmpgn.ChangesetEvent {
+		return &cmpgn.ChangesetEvent{
+			Kind:      kind,
+			UpdatedAt: when,
+			Metadata: &gitlabwebhooks.LabelEvent{
+				Label: gitlab.Label{
+					Title: name,
+				},
+				CreatedAt: gitlab.Time{Time: when},
+				Removed:   kind == cmpgn.ChangesetEventKindGitLabUnlabeled,
+			},
+		}
+	}
 	changeset := func(names []string, updated time.Time) *cmpgn.Changeset {
 		meta := &github.PullRequest{}
 		for _, name := range names {
@@ -749,6 +763,23 @@ func TestComputeLabels(t *testing.T) {
 			},
 			want: labels("label1", "label2", "label3", "label4"),
 		},
+		{
+			name:      "gitlab add and remove events",
+			changeset: changeset([]string{"label1"}, time.Time{}),
+			events: ChangesetEvents{
+				gitLabLabelEvent("label1", cmpgn.ChangesetEventKindGitLabUnlabeled, now),
+				gitLabLabelEvent("label2", cmpgn.ChangesetEventKindGitLabLabeled, now),
+			},
+			want: labels("label2"),
+		},
+		{
+			name:      "old gitlab add event",
+			changeset: changeset([]string{"label1"}, now.Add(5*time.Minute)),
+			events: ChangesetEvents{
+				gitLabLabelEvent("label2", cmpgn.ChangesetEventKindGitLabLabeled, now),
+			},
+			want: labels("label1"),
+		},
 	}
 
 	for _, tc := range tests {
@@ -762,6 +793,100 @@ func TestComputeLabels(t *testing.T) {
 	}
 }
 
+func TestComputePublicationState(t *testing.T) {
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	daysAgo := func(days int) time.Time { return now.AddDate(0, 0, -days) }
+
+	published := func(c *cmpgn.Changeset) *cmpgn.Changeset {
+		c.PublicationState = cmpgn.ChangesetPublicationStatePublished
+		return c
+	}
+	githubDraft := func(updatedAt time.Time, draft bool) *cmpgn.Changeset {
+		c := published(githubChangeset(updatedAt, "OPEN"))
+		c.Metadata.(*github.PullRequest).IsDraft = draft
+		return c
+	}
+	gitLabDraft := func(updatedAt time.Time, draft bool) *cmpgn.Changeset {
+		c := published(gitLabChangeset(updatedAt, gitlab.MergeRequestStateOpened, nil))
+		c.Metadata.(*gitlab.MergeRequest).WorkInProgress = draft
+		return c
+	}
+	event := func(kind cmpgn.ChangesetEventKind, metadata interface{}) *cmpgn.ChangesetEvent {
+		return &cmpgn.ChangesetEvent{Kind: kind, Metadata: metadata}
+	}
+
+	tests := []struct {
+		name      string
+		changeset *cmpgn.Changeset
+		events    ChangesetEvents
+		want      cmpgn.ChangesetPublicationState
+	}{
+		{
+			name:      "github - no events, ready",
+			changeset: githubDraft(daysAgo(10), false),
+			want:      cmpgn.ChangesetPublicationStatePublished,
+		},
+		{
+			name:      "github - no events, draft",
+			changeset: githubDraft(daysAgo(10), true),
+			want:      cmpgn.ChangesetPublicationStateDraft,
+		},
+		{
+			name:      "github - converted to draft after sync",
+			changeset: githubDraft(daysAgo(10), false),
+			events: ChangesetEvents{
+				event(cmpgn.ChangesetEventKindGitHubConvertToDraft, &github.ConvertToDraftEvent{CreatedAt: daysAgo(1)}),
+			},
+			want: cmpgn.ChangesetPublicationStateDraft,
+		},
+		{
+			name:      "github - ready for review after sync",
+			changeset: githubDraft(daysAgo(10), true),
+			events: ChangesetEvents{
+				event(cmpgn.ChangesetEventKindGitHubConvertToDraft, &github.ConvertToDraftEvent{CreatedAt: daysAgo(2)}),
+				event(cmpgn.ChangesetEventKindGitHubReadyForReview, &github.ReadyForReviewEvent{CreatedAt: daysAgo(1)}),
+			},
+			want: cmpgn.ChangesetPublicationStatePublished,
+		},
+		{
+			name:      "github - events older than sync",
+			changeset: githubDraft(daysAgo(0), false),
+			events: ChangesetEvents{
+				event(cmpgn.ChangesetEventKindGitHubConvertToDraft, &github.ConvertToDraftEvent{CreatedAt: daysAgo(1)}),
+			},
+			want: cmpgn.ChangesetPublicationStatePublished,
+		},
+		{
+			name:      "gitlab - no events, wip",
+			changeset: gitLabDraft(daysAgo(10), true),
+			want:      cmpgn.ChangesetPublicationStateDraft,
+		},
+		{
+			name:      "gitlab - unmarked as wip after sync",
+			changeset: gitLabDraft(daysAgo(10), true),
+			events: ChangesetEvents{
+				event(cmpgn.ChangesetEventKindGitLabUnmarkWorkInProgress, &gitlab.UnmarkWorkInProgressEvent{
+					Note: &gitlab.Note{System: true, CreatedAt: gitlab.Time{Time: daysAgo(1)}},
+				}),
+			},
+			want: cmpgn.ChangesetPublicationStatePublished,
+		},
+		{
+			name:      "bitbucketserver - unchanged",
+			changeset: published(bitbucketChangeset(daysAgo(10), "OPEN", "NEEDS_WORK")),
+			want:      cmpgn.ChangesetPublicationStatePublished,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if have, want := computePublicationState(tc.changeset, tc.events), tc.want; have != want {
+				t.Errorf("wrong publication state. have=%s, want=%s", have, want)
+			}
+		})
+	}
+}
+
 func bitbucketChangeset(updatedAt time.Time, state, reviewStatus string) *campaigns.Changeset {
 	return &campaigns.Changeset{
 		ExternalServiceType: extsvc.TypeBitbucketServer,
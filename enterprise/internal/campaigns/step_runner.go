@@ -0,0 +1,287 @@
+package campaigns
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/inconshreveable/log15"
+	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/internal/campaigns"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// StepRunner abstracts running a single step of a campaign spec over a
+// workspace containing a checkout of a repository.
+type StepRunner interface {
+	// Run invokes the given step with the workspace as its working directory
+	// and returns the output of the step.
+	Run(ctx context.Context, workspace string, step campaigns.CampaignSpecStep) (campaigns.StepResult, error)
+}
+
+// StepRunnerFunc is a function version of the StepRunner interface.
+type StepRunnerFunc func(ctx context.Context, workspace string, step campaigns.CampaignSpecStep) (campaigns.StepResult, error)
+
+// Run invokes the given step. See the StepRunner interface for additional details.
+func (f StepRunnerFunc) Run(ctx context.Context, workspace string, step campaigns.CampaignSpecStep) (campaigns.StepResult, error) {
+	return f(ctx, workspace, step)
+}
+
+// VMStepRunner is a step runner that runs each step inside of the step's
+// container in a fresh Firecracker virtual machine, as configured by the
+// `campaigns.serverSideExecution` site configuration. The container has no
+// network access and only sees a copy of the workspace and the environment of
+// the step. Steps fail with ErrServerSideExecutionDisabled unless server-side
+// execution is enabled.
+var VMStepRunner StepRunner = &vmStepRunner{runCommand: runCommandStreams}
+
+// ProcessStepRunner is a step runner that runs each step as a shell process on
+// the host machine. It ignores the step's container and provides no sandboxing,
+// so it must only be used in tests and local development.
+var ProcessStepRunner StepRunner = StepRunnerFunc(runStepInProcess)
+
+// ErrServerSideExecutionDisabled is returned when campaign spec steps would be
+// run on the server but server-side execution is disabled in the site
+// configuration.
+var ErrServerSideExecutionDisabled = errors.New("server-side execution of campaign specs is disabled; set campaigns.serverSideExecution.enabled in the site configuration to enable it")
+
+// serverSideExecutionConfig returns the server-side execution configuration
+// with defaults applied, and false if server-side execution is disabled.
+func serverSideExecutionConfig() (schema.CampaignsServerSideExecution, bool) {
+	c := conf.Get().CampaignsServerSideExecution
+	if c == nil || !c.Enabled {
+		return schema.CampaignsServerSideExecution{}, false
+	}
+
+	cfg := *c
+	if cfg.FirecrackerImage == "" {
+		cfg.FirecrackerImage = "sourcegraph/ignite-ubuntu:insiders"
+	}
+	if cfg.Cpus == 0 {
+		cfg.Cpus = 4
+	}
+	if cfg.Memory == "" {
+		cfg.Memory = "12G"
+	}
+	return cfg, true
+}
+
+const (
+	// vmWorkspace is the path of the copy of the workspace inside of the
+	// virtual machine, which is also the working directory of the step.
+	vmWorkspace = "/work"
+
+	// vmStepDir is the path of the directory holding the script and the
+	// environment of the step inside of the virtual machine.
+	vmStepDir = "/step"
+)
+
+// containerImagePattern matches the container images that steps may run in.
+// The arguments of `ignite exec` are interpreted by a shell inside of the
+// virtual machine, so the image must not contain any shell metacharacters.
+var containerImagePattern = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9._/:@-]*$`)
+
+// igniteArgs are the arguments passed to every ignite invocation, as in the
+// precise-code-intel-indexer-vm.
+var igniteArgs = []string{"--runtime", "docker", "--network-plugin", "docker-bridge"}
+
+type vmStepRunner struct {
+	// runCommand invokes a command on the host machine and returns its
+	// standard output and standard error. It is replaced in tests.
+	runCommand func(ctx context.Context, dir string, env []string, command string, args ...string) ([]byte, []byte, error)
+}
+
+// Run starts a virtual machine with a copy of the workspace, runs the step in
+// its container inside of the virtual machine, and copies the resulting
+// workspace back.
+func (r *vmStepRunner) Run(ctx context.Context, workspace string, step campaigns.CampaignSpecStep) (_ campaigns.StepResult, err error) {
+	cfg, ok := serverSideExecutionConfig()
+	if !ok {
+		return campaigns.StepResult{}, ErrServerSideExecutionDisabled
+	}
+	if !containerImagePattern.MatchString(step.Container) {
+		return campaigns.StepResult{}, errors.Errorf("invalid container image %q", step.Container)
+	}
+
+	// The script and the environment of the step are passed to the virtual
+	// machine as files, so that they are not interpreted by its shell.
+	stepDir, err := ioutil.TempDir("", "campaign-step")
+	if err != nil {
+		return campaigns.StepResult{}, err
+	}
+	defer os.RemoveAll(stepDir)
+
+	env := stepEnv(step)
+	for _, kv := range env {
+		if strings.ContainsAny(kv, "\r\n") {
+			return campaigns.StepResult{}, errors.Errorf("environment variable %q of step contains a line break", strings.SplitN(kv, "=", 2)[0])
+		}
+	}
+	if err := ioutil.WriteFile(filepath.Join(stepDir, "run.sh"), []byte(step.Run), 0644); err != nil {
+		return campaigns.StepResult{}, err
+	}
+	if err := ioutil.WriteFile(filepath.Join(stepDir, "env"), []byte(strings.Join(env, "\n")), 0644); err != nil {
+		return campaigns.StepResult{}, err
+	}
+
+	name := uuid.New().String()
+
+	runArgs := append([]string{"run"}, igniteArgs...)
+	runArgs = append(runArgs,
+		"--cpus", strconv.Itoa(cfg.Cpus),
+		"--memory", cfg.Memory,
+		"--copy-files", fmt.Sprintf("%s:%s", workspace, vmWorkspace),
+		"--copy-files", fmt.Sprintf("%s:%s", stepDir, vmStepDir),
+		"--ssh",
+		"--name", name,
+		cfg.FirecrackerImage,
+	)
+	if _, _, err := r.runCommand(ctx, "", nil, "ignite", runArgs...); err != nil {
+		return campaigns.StepResult{}, errors.Wrap(err, "failed to start firecracker vm")
+	}
+	defer func() {
+		if _, _, err := r.runCommand(ctx, "", nil, "ignite", append(append([]string{"stop"}, igniteArgs...), name)...); err != nil {
+			log15.Warn("failed to stop firecracker vm", "name", name, "err", err)
+		}
+		if _, _, err := r.runCommand(ctx, "", nil, "ignite", append(append([]string{"rm", "-f"}, igniteArgs...), name)...); err != nil {
+			log15.Warn("failed to remove firecracker vm", "name", name, "err", err)
+		}
+	}()
+
+	// The virtual machine needs network access to pull the image, but the
+	// step itself is run without any.
+	if _, _, err := r.runCommand(ctx, "", nil, "ignite", "exec", name, "--", "docker", "pull", step.Container); err != nil {
+		return campaigns.StepResult{}, errors.Wrapf(err, "failed to pull %s", step.Container)
+	}
+
+	stdout, stderr, err := r.runCommand(ctx, "", nil, "ignite",
+		"exec", name, "--",
+		"docker", "run", "--rm", "--init",
+		"--network", "none",
+		"--cpus", strconv.Itoa(cfg.Cpus),
+		"--memory", cfg.Memory,
+		"--security-opt", "no-new-privileges",
+		"--env-file", path.Join(vmStepDir, "env"),
+		"-v", fmt.Sprintf("%s:%s", vmWorkspace, vmWorkspace),
+		"-v", fmt.Sprintf("%s:%s:ro", vmStepDir, vmStepDir),
+		"-w", vmWorkspace,
+		"--entrypoint", "/bin/sh",
+		step.Container,
+		path.Join(vmStepDir, "run.sh"),
+	)
+	if err != nil {
+		return campaigns.StepResult{}, err
+	}
+
+	if err := r.copyWorkspaceFromVM(ctx, name, workspace); err != nil {
+		return campaigns.StepResult{}, errors.Wrap(err, "failed to copy workspace from firecracker vm")
+	}
+
+	return campaigns.StepResult{Stdout: string(stdout), Stderr: string(stderr)}, nil
+}
+
+// copyWorkspaceFromVM replaces the contents of the workspace with the copy of
+// the workspace inside of the given virtual machine.
+func (r *vmStepRunner) copyWorkspaceFromVM(ctx context.Context, name, workspace string) error {
+	archive, _, err := r.runCommand(ctx, "", nil, "ignite", "exec", name, "--", "tar", "-C", vmWorkspace, "-cf", "-", ".")
+	if err != nil {
+		return err
+	}
+
+	archiveFile, err := ioutil.TempFile("", "campaign-workspace-*.tar")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(archiveFile.Name())
+	if _, err := archiveFile.Write(archive); err != nil {
+		archiveFile.Close()
+		return err
+	}
+	if err := archiveFile.Close(); err != nil {
+		return err
+	}
+
+	// Files deleted by the step must be deleted from the workspace too.
+	entries, err := ioutil.ReadDir(workspace)
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		if err := os.RemoveAll(filepath.Join(workspace, entry.Name())); err != nil {
+			return err
+		}
+	}
+
+	_, _, err = r.runCommand(ctx, workspace, nil, "tar", "-xf", archiveFile.Name())
+	return err
+}
+
+func runStepInProcess(ctx context.Context, workspace string, step campaigns.CampaignSpecStep) (campaigns.StepResult, error) {
+	// Only the environment of the step is passed, so that the step can't read
+	// the secrets in the environment of the current process.
+	return runStep(ctx, workspace, stepEnv(step), "/bin/sh", "-c", step.Run)
+}
+
+func runStep(ctx context.Context, workspace string, env []string, command string, args ...string) (campaigns.StepResult, error) {
+	stdout, stderr, err := runCommandStreams(ctx, workspace, env, command, args...)
+	if err != nil {
+		return campaigns.StepResult{}, err
+	}
+	return campaigns.StepResult{Stdout: string(stdout), Stderr: string(stderr)}, nil
+}
+
+// stepEnv returns the environment of the given step as a sorted list of
+// KEY=value pairs.
+func stepEnv(step campaigns.CampaignSpecStep) []string {
+	env := make([]string, 0, len(step.Env))
+	for k, v := range step.Env {
+		env = append(env, k+"="+v)
+	}
+	sort.Strings(env)
+	return env
+}
+
+// runCommand invokes the given command in dir on the host machine. If env is
+// nil, the command inherits the environment of the current process. The
+// combined output of the command is included in the returned error.
+func runCommand(ctx context.Context, dir string, env []string, command string, args ...string) error {
+	_, err := runCommandOutput(ctx, dir, env, command, args...)
+	return err
+}
+
+// runCommandOutput is like runCommand, but returns the standard output of the
+// command on success.
+func runCommandOutput(ctx context.Context, dir string, env []string, command string, args ...string) ([]byte, error) {
+	stdout, _, err := runCommandStreams(ctx, dir, env, command, args...)
+	return stdout, err
+}
+
+// runCommandStreams is like runCommand, but returns the standard output and
+// the standard error of the command on success.
+func runCommandStreams(ctx context.Context, dir string, env []string, command string, args ...string) ([]byte, []byte, error) {
+	log15.Debug(fmt.Sprintf("Running command: %s %s", command, strings.Join(args, " ")))
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, command, args...)
+	cmd.Dir = dir
+	cmd.Env = env
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return nil, nil, errors.Wrapf(err, "running %q failed: %s", command, strings.TrimSpace(stdout.String()+stderr.String()))
+	}
+
+	return stdout.Bytes(), stderr.Bytes(), nil
+}
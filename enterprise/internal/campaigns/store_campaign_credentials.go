@@ -0,0 +1,267 @@
+package campaigns
+
+import (
+	"context"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/internal/campaigns"
+	"github.com/sourcegraph/sourcegraph/internal/db/dbutil"
+	"github.com/sourcegraph/sourcegraph/internal/secrets"
+)
+
+// campaignCredentialColumns are used by the campaign credential related Store
+// methods to insert, update and query campaign credentials.
+var campaignCredentialColumns = []*sqlf.Query{
+	sqlf.Sprintf("campaign_credentials.id"),
+	sqlf.Sprintf("campaign_credentials.namespace_user_id"),
+	sqlf.Sprintf("campaign_credentials.namespace_org_id"),
+	sqlf.Sprintf("campaign_credentials.external_service_type"),
+	sqlf.Sprintf("campaign_credentials.external_service_id"),
+	sqlf.Sprintf("campaign_credentials.username"),
+	sqlf.Sprintf("campaign_credentials.credential"),
+	sqlf.Sprintf("campaign_credentials.created_at"),
+	sqlf.Sprintf("campaign_credentials.updated_at"),
+}
+
+// campaignCredentialInsertColumns is the list of campaign credential columns
+// that are modified in CreateCampaignCredential and UpdateCampaignCredential.
+var campaignCredentialInsertColumns = []*sqlf.Query{
+	sqlf.Sprintf("namespace_user_id"),
+	sqlf.Sprintf("namespace_org_id"),
+	sqlf.Sprintf("external_service_type"),
+	sqlf.Sprintf("external_service_id"),
+	sqlf.Sprintf("username"),
+	sqlf.Sprintf("credential"),
+	sqlf.Sprintf("created_at"),
+	sqlf.Sprintf("updated_at"),
+}
+
+// CreateCampaignCredential creates the given CampaignCredential. The token is
+// encrypted before it's stored.
+func (s *Store) CreateCampaignCredential(ctx context.Context, c *campaigns.CampaignCredential) error {
+	if c.CreatedAt.IsZero() {
+		c.CreatedAt = s.now()
+	}
+
+	if c.UpdatedAt.IsZero() {
+		c.UpdatedAt = c.CreatedAt
+	}
+
+	q, err := campaignCredentialWriteQuery(createCampaignCredentialQueryFmtstr, c)
+	if err != nil {
+		return err
+	}
+
+	return s.query(ctx, q, func(sc scanner) error { return scanCampaignCredential(c, sc) })
+}
+
+var createCampaignCredentialQueryFmtstr = `
+-- source: enterprise/internal/campaigns/store_campaign_credentials.go:CreateCampaignCredential
+INSERT INTO campaign_credentials (%s)
+VALUES (%s, %s, %s, %s, %s, %s, %s, %s)
+RETURNING %s
+`
+
+// UpdateCampaignCredential updates the given CampaignCredential. The token is
+// encrypted before it's stored.
+func (s *Store) UpdateCampaignCredential(ctx context.Context, c *campaigns.CampaignCredential) error {
+	c.UpdatedAt = s.now()
+
+	q, err := campaignCredentialWriteQuery(updateCampaignCredentialQueryFmtstr, c)
+	if err != nil {
+		return err
+	}
+
+	return s.query(ctx, q, func(sc scanner) error { return scanCampaignCredential(c, sc) })
+}
+
+var updateCampaignCredentialQueryFmtstr = `
+-- source: enterprise/internal/campaigns/store_campaign_credentials.go:UpdateCampaignCredential
+UPDATE campaign_credentials
+SET (%s) = (%s, %s, %s, %s, %s, %s, %s, %s)
+WHERE id = %s
+RETURNING %s
+`
+
+func campaignCredentialWriteQuery(q string, c *campaigns.CampaignCredential) (*sqlf.Query, error) {
+	credential, err := secrets.EncryptBytes([]byte(c.Token))
+	if err != nil {
+		return nil, errors.Wrap(err, "encrypting campaign credential")
+	}
+
+	vars := []interface{}{
+		sqlf.Join(campaignCredentialInsertColumns, ", "),
+		nullInt32Column(c.NamespaceUserID),
+		nullInt32Column(c.NamespaceOrgID),
+		c.ExternalServiceType,
+		c.ExternalServiceID,
+		nullStringColumn(c.Username),
+		credential,
+		c.CreatedAt,
+		c.UpdatedAt,
+	}
+
+	if c.ID != 0 {
+		vars = append(vars, c.ID)
+	}
+
+	vars = append(vars, sqlf.Join(campaignCredentialColumns, ", "))
+
+	return sqlf.Sprintf(q, vars...), nil
+}
+
+// DeleteCampaignCredential deletes the CampaignCredential with the given ID.
+func (s *Store) DeleteCampaignCredential(ctx context.Context, id int64) error {
+	return s.Store.Exec(ctx, sqlf.Sprintf(deleteCampaignCredentialQueryFmtstr, id))
+}
+
+var deleteCampaignCredentialQueryFmtstr = `
+-- source: enterprise/internal/campaigns/store_campaign_credentials.go:DeleteCampaignCredential
+DELETE FROM campaign_credentials WHERE id = %s
+`
+
+// GetCampaignCredentialOpts captures the query options needed for getting a
+// CampaignCredential.
+//
+// If ID is zero, the credential of the given namespace for the given code host
+// is returned. If neither NamespaceUserID nor NamespaceOrgID is set, that's the
+// site credential for the code host.
+type GetCampaignCredentialOpts struct {
+	ID int64
+
+	NamespaceUserID     int32
+	NamespaceOrgID      int32
+	ExternalServiceType string
+	ExternalServiceID   string
+}
+
+// GetCampaignCredential gets a CampaignCredential matching the given options.
+func (s *Store) GetCampaignCredential(ctx context.Context, opts GetCampaignCredentialOpts) (*campaigns.CampaignCredential, error) {
+	q := getCampaignCredentialQuery(&opts)
+
+	var c campaigns.CampaignCredential
+	err := s.query(ctx, q, func(sc scanner) error {
+		return scanCampaignCredential(&c, sc)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if c.ID == 0 {
+		return nil, ErrNoResults
+	}
+
+	return &c, nil
+}
+
+var getCampaignCredentialQueryFmtstr = `
+-- source: enterprise/internal/campaigns/store_campaign_credentials.go:GetCampaignCredential
+SELECT %s FROM campaign_credentials
+WHERE %s
+LIMIT 1
+`
+
+func getCampaignCredentialQuery(opts *GetCampaignCredentialOpts) *sqlf.Query {
+	var preds []*sqlf.Query
+	if opts.ID != 0 {
+		preds = append(preds, sqlf.Sprintf("campaign_credentials.id = %s", opts.ID))
+	} else {
+		preds = append(preds,
+			sqlf.Sprintf("COALESCE(campaign_credentials.namespace_user_id, 0) = %s", opts.NamespaceUserID),
+			sqlf.Sprintf("COALESCE(campaign_credentials.namespace_org_id, 0) = %s", opts.NamespaceOrgID),
+			sqlf.Sprintf("campaign_credentials.external_service_type = %s", opts.ExternalServiceType),
+			sqlf.Sprintf("campaign_credentials.external_service_id = %s", opts.ExternalServiceID),
+		)
+	}
+
+	return sqlf.Sprintf(
+		getCampaignCredentialQueryFmtstr,
+		sqlf.Join(campaignCredentialColumns, ", "),
+		sqlf.Join(preds, "\n AND "),
+	)
+}
+
+// ListCampaignCredentialsOpts captures the query options needed for listing
+// campaign credentials.
+type ListCampaignCredentialsOpts struct {
+	LimitOpts
+	Cursor int64
+
+	// NamespaceUserID and NamespaceOrgID restrict the list to the credentials
+	// of the given namespace. If neither is set, only the site credentials are
+	// listed.
+	NamespaceUserID int32
+	NamespaceOrgID  int32
+}
+
+// ListCampaignCredentials lists the CampaignCredentials matching the given
+// options.
+func (s *Store) ListCampaignCredentials(ctx context.Context, opts ListCampaignCredentialsOpts) (cs []*campaigns.CampaignCredential, next int64, err error) {
+	q := listCampaignCredentialsQuery(&opts)
+
+	cs = make([]*campaigns.CampaignCredential, 0, opts.DBLimit())
+	err = s.query(ctx, q, func(sc scanner) error {
+		var c campaigns.CampaignCredential
+		if err := scanCampaignCredential(&c, sc); err != nil {
+			return err
+		}
+		cs = append(cs, &c)
+		return nil
+	})
+
+	if opts.Limit != 0 && len(cs) == opts.DBLimit() {
+		next = cs[len(cs)-1].ID
+		cs = cs[:len(cs)-1]
+	}
+
+	return cs, next, err
+}
+
+var listCampaignCredentialsQueryFmtstr = `
+-- source: enterprise/internal/campaigns/store_campaign_credentials.go:ListCampaignCredentials
+SELECT %s FROM campaign_credentials
+WHERE %s
+ORDER BY id ASC
+`
+
+func listCampaignCredentialsQuery(opts *ListCampaignCredentialsOpts) *sqlf.Query {
+	preds := []*sqlf.Query{
+		sqlf.Sprintf("campaign_credentials.id >= %s", opts.Cursor),
+		sqlf.Sprintf("COALESCE(campaign_credentials.namespace_user_id, 0) = %s", opts.NamespaceUserID),
+		sqlf.Sprintf("COALESCE(campaign_credentials.namespace_org_id, 0) = %s", opts.NamespaceOrgID),
+	}
+
+	return sqlf.Sprintf(
+		listCampaignCredentialsQueryFmtstr+opts.LimitOpts.ToDB(),
+		sqlf.Join(campaignCredentialColumns, ", "),
+		sqlf.Join(preds, "\n AND "),
+	)
+}
+
+func scanCampaignCredential(c *campaigns.CampaignCredential, s scanner) error {
+	var credential []byte
+
+	err := s.Scan(
+		&c.ID,
+		&dbutil.NullInt32{N: &c.NamespaceUserID},
+		&dbutil.NullInt32{N: &c.NamespaceOrgID},
+		&c.ExternalServiceType,
+		&c.ExternalServiceID,
+		&dbutil.NullString{S: &c.Username},
+		&credential,
+		&c.CreatedAt,
+		&c.UpdatedAt,
+	)
+	if err != nil {
+		return errors.Wrap(err, "scanning campaign credential")
+	}
+
+	token, err := secrets.DecryptBytes(credential)
+	if err != nil {
+		return errors.Wrap(err, "decrypting campaign credential")
+	}
+	c.Token = string(token)
+
+	return nil
+}
@@ -0,0 +1,161 @@
+package campaigns
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sourcegraph/sourcegraph/cmd/repo-updater/repos"
+	cmpgn "github.com/sourcegraph/sourcegraph/internal/campaigns"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+)
+
+func testStoreCampaignCredentials(t *testing.T, ctx context.Context, s *Store, _ repos.Store, clock clock) {
+	const (
+		githubID = "https://github.com/"
+		bbsID    = "https://bitbucket.sgdev.org/"
+	)
+
+	credentials := []*cmpgn.CampaignCredential{
+		{
+			ExternalServiceType: extsvc.TypeGitHub,
+			ExternalServiceID:   githubID,
+			Token:               "site-token",
+		},
+		{
+			NamespaceUserID:     23,
+			ExternalServiceType: extsvc.TypeGitHub,
+			ExternalServiceID:   githubID,
+			Token:               "user-token",
+		},
+		{
+			NamespaceOrgID:      42,
+			ExternalServiceType: extsvc.TypeBitbucketServer,
+			ExternalServiceID:   bbsID,
+			Username:            "org-bot",
+			Token:               "org-token",
+		},
+	}
+
+	t.Run("Create", func(t *testing.T) {
+		for _, c := range credentials {
+			token := c.Token
+			if err := s.CreateCampaignCredential(ctx, c); err != nil {
+				t.Fatal(err)
+			}
+
+			if c.ID == 0 {
+				t.Fatal("ID should not be zero")
+			}
+			if have, want := c.Token, token; have != want {
+				t.Fatalf("token not decrypted. want=%q, have=%q", want, have)
+			}
+			if have, want := c.CreatedAt, clock.now(); have != want {
+				t.Fatalf("CreatedAt wrong. want=%s, have=%s", want, have)
+			}
+		}
+	})
+
+	t.Run("Get", func(t *testing.T) {
+		t.Run("ByID", func(t *testing.T) {
+			for _, want := range credentials {
+				have, err := s.GetCampaignCredential(ctx, GetCampaignCredentialOpts{ID: want.ID})
+				if err != nil {
+					t.Fatal(err)
+				}
+				if diff := cmp.Diff(want, have); diff != "" {
+					t.Fatalf("diff: %s", diff)
+				}
+			}
+		})
+
+		t.Run("ByNamespaceAndCodeHost", func(t *testing.T) {
+			for _, want := range credentials {
+				have, err := s.GetCampaignCredential(ctx, GetCampaignCredentialOpts{
+					NamespaceUserID:     want.NamespaceUserID,
+					NamespaceOrgID:      want.NamespaceOrgID,
+					ExternalServiceType: want.ExternalServiceType,
+					ExternalServiceID:   want.ExternalServiceID,
+				})
+				if err != nil {
+					t.Fatal(err)
+				}
+				if diff := cmp.Diff(want, have); diff != "" {
+					t.Fatalf("diff: %s", diff)
+				}
+			}
+		})
+
+		t.Run("NoResults", func(t *testing.T) {
+			_, err := s.GetCampaignCredential(ctx, GetCampaignCredentialOpts{
+				NamespaceUserID:     23,
+				ExternalServiceType: extsvc.TypeBitbucketServer,
+				ExternalServiceID:   bbsID,
+			})
+			if have, want := err, ErrNoResults; have != want {
+				t.Fatalf("have err %v, want %v", have, want)
+			}
+		})
+	})
+
+	t.Run("List", func(t *testing.T) {
+		for name, tc := range map[string]struct {
+			opts ListCampaignCredentialsOpts
+			want []*cmpgn.CampaignCredential
+		}{
+			"Site":  {opts: ListCampaignCredentialsOpts{}, want: credentials[0:1]},
+			"User":  {opts: ListCampaignCredentialsOpts{NamespaceUserID: 23}, want: credentials[1:2]},
+			"Org":   {opts: ListCampaignCredentialsOpts{NamespaceOrgID: 42}, want: credentials[2:3]},
+			"Empty": {opts: ListCampaignCredentialsOpts{NamespaceUserID: 1234}, want: []*cmpgn.CampaignCredential{}},
+		} {
+			t.Run(name, func(t *testing.T) {
+				have, next, err := s.ListCampaignCredentials(ctx, tc.opts)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if next != 0 {
+					t.Fatalf("unexpected next cursor %d", next)
+				}
+				if diff := cmp.Diff(tc.want, have); diff != "" {
+					t.Fatalf("diff: %s", diff)
+				}
+			})
+		}
+	})
+
+	t.Run("Update", func(t *testing.T) {
+		clock.add(1 * time.Second)
+
+		c := credentials[2]
+		c.Username = "other-bot"
+		c.Token = "new-org-token"
+		if err := s.UpdateCampaignCredential(ctx, c); err != nil {
+			t.Fatal(err)
+		}
+		if have, want := c.UpdatedAt, clock.now(); have != want {
+			t.Fatalf("UpdatedAt wrong. want=%s, have=%s", want, have)
+		}
+
+		have, err := s.GetCampaignCredential(ctx, GetCampaignCredentialOpts{ID: c.ID})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(c, have); diff != "" {
+			t.Fatalf("diff: %s", diff)
+		}
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		for _, c := range credentials {
+			if err := s.DeleteCampaignCredential(ctx, c.ID); err != nil {
+				t.Fatal(err)
+			}
+
+			_, err := s.GetCampaignCredential(ctx, GetCampaignCredentialOpts{ID: c.ID})
+			if have, want := err, ErrNoResults; have != want {
+				t.Fatalf("have err %v, want %v", have, want)
+			}
+		}
+	})
+}
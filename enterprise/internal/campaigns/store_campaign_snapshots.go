@@ -0,0 +1,274 @@
+package campaigns
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/internal/campaigns"
+)
+
+// campaignSnapshotColumns are used by the campaign snapshot related Store
+// methods to insert and query snapshots.
+var campaignSnapshotColumns = []*sqlf.Query{
+	sqlf.Sprintf("campaign_snapshots.id"),
+	sqlf.Sprintf("campaign_snapshots.campaign_id"),
+	sqlf.Sprintf("campaign_snapshots.total"),
+	sqlf.Sprintf("campaign_snapshots.open"),
+	sqlf.Sprintf("campaign_snapshots.merged"),
+	sqlf.Sprintf("campaign_snapshots.closed"),
+	sqlf.Sprintf("campaign_snapshots.draft"),
+	sqlf.Sprintf("campaign_snapshots.failed_checks"),
+	sqlf.Sprintf("campaign_snapshots.open_approved"),
+	sqlf.Sprintf("campaign_snapshots.open_changes_requested"),
+	sqlf.Sprintf("campaign_snapshots.open_pending"),
+	sqlf.Sprintf("campaign_snapshots.median_time_to_merge"),
+	sqlf.Sprintf("campaign_snapshots.repo_stall_times"),
+	sqlf.Sprintf("campaign_snapshots.created_at"),
+}
+
+// CreateCampaignSnapshot creates the given CampaignSnapshot.
+func (s *Store) CreateCampaignSnapshot(ctx context.Context, cs *campaigns.CampaignSnapshot) error {
+	stallTimes := cs.RepoStallTimes
+	if stallTimes == nil {
+		stallTimes = []campaigns.CampaignSnapshotRepoStallTime{}
+	}
+	repoStallTimes, err := jsonbColumn(stallTimes)
+	if err != nil {
+		return err
+	}
+
+	if cs.CreatedAt.IsZero() {
+		cs.CreatedAt = s.now()
+	}
+
+	q := sqlf.Sprintf(
+		createCampaignSnapshotQueryFmtstr,
+		cs.CampaignID,
+		cs.Total,
+		cs.Open,
+		cs.Merged,
+		cs.Closed,
+		cs.Draft,
+		cs.FailedChecks,
+		cs.OpenApproved,
+		cs.OpenChangesRequested,
+		cs.OpenPending,
+		int64(cs.MedianTimeToMerge),
+		repoStallTimes,
+		cs.CreatedAt,
+		sqlf.Join(campaignSnapshotColumns, ", "),
+	)
+
+	return s.query(ctx, q, func(sc scanner) error { return scanCampaignSnapshot(cs, sc) })
+}
+
+var createCampaignSnapshotQueryFmtstr = `
+-- source: enterprise/internal/campaigns/store_campaign_snapshots.go:CreateCampaignSnapshot
+INSERT INTO campaign_snapshots (
+  campaign_id,
+  total,
+  open,
+  merged,
+  closed,
+  draft,
+  failed_checks,
+  open_approved,
+  open_changes_requested,
+  open_pending,
+  median_time_to_merge,
+  repo_stall_times,
+  created_at
+)
+VALUES (%s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s)
+RETURNING %s`
+
+// CountCampaignSnapshotsOpts captures the query options needed for counting
+// campaign snapshots.
+type CountCampaignSnapshotsOpts struct {
+	CampaignID int64
+	// From and To restrict the snapshots to those taken within the given
+	// time range (inclusive) if they're not zero.
+	From time.Time
+	To   time.Time
+}
+
+// CountCampaignSnapshots returns the number of CampaignSnapshots matching the
+// given options.
+func (s *Store) CountCampaignSnapshots(ctx context.Context, opts CountCampaignSnapshotsOpts) (int, error) {
+	return s.queryCount(ctx, sqlf.Sprintf(
+		countCampaignSnapshotsQueryFmtstr,
+		sqlf.Join(campaignSnapshotsPreds(opts.CampaignID, opts.From, opts.To), "\n AND "),
+	))
+}
+
+var countCampaignSnapshotsQueryFmtstr = `
+-- source: enterprise/internal/campaigns/store_campaign_snapshots.go:CountCampaignSnapshots
+SELECT COUNT(campaign_snapshots.id) FROM campaign_snapshots
+WHERE %s
+`
+
+// ListCampaignSnapshotsOpts captures the query options needed for listing
+// campaign snapshots.
+type ListCampaignSnapshotsOpts struct {
+	LimitOpts
+	Cursor int64
+
+	CampaignID int64
+	// From and To restrict the snapshots to those taken within the given
+	// time range (inclusive) if they're not zero.
+	From time.Time
+	To   time.Time
+}
+
+// ListCampaignSnapshots lists the CampaignSnapshots matching the given
+// options, oldest first. Snapshots are taken in order, so they're paginated by
+// their IDs.
+func (s *Store) ListCampaignSnapshots(ctx context.Context, opts ListCampaignSnapshotsOpts) (cs []*campaigns.CampaignSnapshot, next int64, err error) {
+	q := listCampaignSnapshotsQuery(&opts)
+
+	cs = make([]*campaigns.CampaignSnapshot, 0, opts.DBLimit())
+	err = s.query(ctx, q, func(sc scanner) error {
+		var c campaigns.CampaignSnapshot
+		if err := scanCampaignSnapshot(&c, sc); err != nil {
+			return err
+		}
+		cs = append(cs, &c)
+		return nil
+	})
+
+	if opts.Limit != 0 && len(cs) == opts.DBLimit() {
+		next = cs[len(cs)-1].ID
+		cs = cs[:len(cs)-1]
+	}
+
+	return cs, next, err
+}
+
+var listCampaignSnapshotsQueryFmtstr = `
+-- source: enterprise/internal/campaigns/store_campaign_snapshots.go:ListCampaignSnapshots
+SELECT %s FROM campaign_snapshots
+WHERE %s
+ORDER BY campaign_snapshots.id ASC
+`
+
+func listCampaignSnapshotsQuery(opts *ListCampaignSnapshotsOpts) *sqlf.Query {
+	preds := append(
+		campaignSnapshotsPreds(opts.CampaignID, opts.From, opts.To),
+		sqlf.Sprintf("campaign_snapshots.id >= %s", opts.Cursor),
+	)
+
+	return sqlf.Sprintf(
+		listCampaignSnapshotsQueryFmtstr+opts.LimitOpts.ToDB(),
+		sqlf.Join(campaignSnapshotColumns, ", "),
+		sqlf.Join(preds, "\n AND "),
+	)
+}
+
+func campaignSnapshotsPreds(campaignID int64, from, to time.Time) []*sqlf.Query {
+	preds := []*sqlf.Query{
+		sqlf.Sprintf("campaign_snapshots.campaign_id = %s", campaignID),
+	}
+
+	if !from.IsZero() {
+		preds = append(preds, sqlf.Sprintf("campaign_snapshots.created_at >= %s", from))
+	}
+
+	if !to.IsZero() {
+		preds = append(preds, sqlf.Sprintf("campaign_snapshots.created_at <= %s", to))
+	}
+
+	return preds
+}
+
+// DownsampleCampaignSnapshots deletes the CampaignSnapshots that are older
+// than CampaignSnapshotDownsampleAge, except for the first snapshot of each
+// campaign on each (UTC) day.
+func (s *Store) DownsampleCampaignSnapshots(ctx context.Context) error {
+	before := s.now().Add(-campaigns.CampaignSnapshotDownsampleAge)
+	q := sqlf.Sprintf(downsampleCampaignSnapshotsQueryFmtstr, before, before)
+	return s.Store.Exec(ctx, q)
+}
+
+var downsampleCampaignSnapshotsQueryFmtstr = `
+-- source: enterprise/internal/campaigns/store_campaign_snapshots.go:DownsampleCampaignSnapshots
+DELETE FROM campaign_snapshots
+WHERE campaign_snapshots.created_at < %s
+AND campaign_snapshots.id NOT IN (
+  SELECT DISTINCT ON (campaign_id, date_trunc('day', created_at AT TIME ZONE 'UTC')) id
+  FROM campaign_snapshots
+  WHERE created_at < %s
+  ORDER BY campaign_id, date_trunc('day', created_at AT TIME ZONE 'UTC'), created_at ASC, id ASC
+)
+`
+
+// ListCampaignIDsDueForSnapshot returns the IDs of the open campaigns that
+// haven't had a CampaignSnapshot taken within the last
+// CampaignSnapshotInterval.
+func (s *Store) ListCampaignIDsDueForSnapshot(ctx context.Context) (ids []int64, err error) {
+	q := sqlf.Sprintf(
+		listCampaignIDsDueForSnapshotQueryFmtstr,
+		s.now().Add(-campaigns.CampaignSnapshotInterval),
+	)
+
+	err = s.query(ctx, q, func(sc scanner) error {
+		var id int64
+		if err := sc.Scan(&id); err != nil {
+			return err
+		}
+		ids = append(ids, id)
+		return nil
+	})
+
+	return ids, err
+}
+
+var listCampaignIDsDueForSnapshotQueryFmtstr = `
+-- source: enterprise/internal/campaigns/store_campaign_snapshots.go:ListCampaignIDsDueForSnapshot
+SELECT campaigns.id FROM campaigns
+WHERE campaigns.closed_at IS NULL
+AND NOT EXISTS (
+  SELECT 1 FROM campaign_snapshots
+  WHERE campaign_snapshots.campaign_id = campaigns.id
+  AND campaign_snapshots.created_at > %s
+)
+ORDER BY campaigns.id ASC
+`
+
+func scanCampaignSnapshot(cs *campaigns.CampaignSnapshot, s scanner) error {
+	var (
+		medianTimeToMerge int64
+		repoStallTimes    json.RawMessage
+	)
+
+	err := s.Scan(
+		&cs.ID,
+		&cs.CampaignID,
+		&cs.Total,
+		&cs.Open,
+		&cs.Merged,
+		&cs.Closed,
+		&cs.Draft,
+		&cs.FailedChecks,
+		&cs.OpenApproved,
+		&cs.OpenChangesRequested,
+		&cs.OpenPending,
+		&medianTimeToMerge,
+		&repoStallTimes,
+		&cs.CreatedAt,
+	)
+	if err != nil {
+		return errors.Wrap(err, "scanning campaign snapshot")
+	}
+
+	cs.MedianTimeToMerge = time.Duration(medianTimeToMerge)
+
+	cs.RepoStallTimes = []campaigns.CampaignSnapshotRepoStallTime{}
+	if err := json.Unmarshal(repoStallTimes, &cs.RepoStallTimes); err != nil {
+		return errors.Wrap(err, "scanning campaign snapshot repo stall times")
+	}
+
+	return nil
+}
@@ -0,0 +1,220 @@
+package campaigns
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sourcegraph/sourcegraph/cmd/repo-updater/repos"
+	cmpgn "github.com/sourcegraph/sourcegraph/internal/campaigns"
+)
+
+func testStoreCampaignSnapshots(t *testing.T, ctx context.Context, s *Store, _ repos.Store, clock clock) {
+	campaigns := make([]*cmpgn.Campaign, 0, 2)
+	for i := 0; i < cap(campaigns); i++ {
+		c := &cmpgn.Campaign{
+			Name:             "test-campaign",
+			NamespaceUserID:  int32(i) + 50,
+			InitialApplierID: int32(i) + 50,
+			LastApplierID:    int32(i) + 50,
+			LastAppliedAt:    clock.now(),
+			CampaignSpecID:   1742 + int64(i),
+		}
+		if err := s.CreateCampaign(ctx, c); err != nil {
+			t.Fatal(err)
+		}
+		campaigns = append(campaigns, c)
+	}
+
+	snapshots := make([]*cmpgn.CampaignSnapshot, 0, 3)
+
+	t.Run("Create", func(t *testing.T) {
+		for i := 0; i < cap(snapshots); i++ {
+			snapshot := &cmpgn.CampaignSnapshot{
+				CampaignID:        campaigns[0].ID,
+				Total:             int32(i) + 3,
+				Open:              2,
+				Merged:            int32(i) + 1,
+				Draft:             1,
+				FailedChecks:      1,
+				OpenPending:       2,
+				MedianTimeToMerge: time.Duration(i+1) * time.Hour,
+				RepoStallTimes: []cmpgn.CampaignSnapshotRepoStallTime{
+					{RepoID: 1, StallTime: 2 * time.Hour},
+				},
+				CreatedAt: clock.now(),
+			}
+			if i == 0 {
+				// Check that a nil list of stall times is stored as an
+				// empty list.
+				snapshot.RepoStallTimes = nil
+			}
+
+			if err := s.CreateCampaignSnapshot(ctx, snapshot); err != nil {
+				t.Fatal(err)
+			}
+
+			if snapshot.ID == 0 {
+				t.Fatal("ID should not be zero")
+			}
+			if snapshot.RepoStallTimes == nil {
+				t.Fatal("RepoStallTimes should not be nil")
+			}
+
+			snapshots = append(snapshots, snapshot)
+			clock.add(1 * time.Hour)
+		}
+	})
+
+	t.Run("List", func(t *testing.T) {
+		t.Run("All", func(t *testing.T) {
+			have, next, err := s.ListCampaignSnapshots(ctx, ListCampaignSnapshotsOpts{CampaignID: campaigns[0].ID})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if next != 0 {
+				t.Fatalf("got next %d, want 0", next)
+			}
+			if diff := cmp.Diff(snapshots, have); diff != "" {
+				t.Fatalf("diff: %s", diff)
+			}
+		})
+
+		t.Run("WithLimit", func(t *testing.T) {
+			var have []*cmpgn.CampaignSnapshot
+			var cursor int64
+			for i := 0; ; i++ {
+				page, next, err := s.ListCampaignSnapshots(ctx, ListCampaignSnapshotsOpts{
+					LimitOpts:  LimitOpts{Limit: 2},
+					Cursor:     cursor,
+					CampaignID: campaigns[0].ID,
+				})
+				if err != nil {
+					t.Fatal(err)
+				}
+				have = append(have, page...)
+				if next == 0 {
+					break
+				}
+				if i > len(snapshots) {
+					t.Fatal("pagination doesn't terminate")
+				}
+				cursor = next
+			}
+			if diff := cmp.Diff(snapshots, have); diff != "" {
+				t.Fatalf("diff: %s", diff)
+			}
+		})
+
+		t.Run("TimeRange", func(t *testing.T) {
+			have, _, err := s.ListCampaignSnapshots(ctx, ListCampaignSnapshotsOpts{
+				CampaignID: campaigns[0].ID,
+				From:       snapshots[1].CreatedAt,
+				To:         snapshots[1].CreatedAt,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(snapshots[1:2], have); diff != "" {
+				t.Fatalf("diff: %s", diff)
+			}
+		})
+
+		t.Run("OtherCampaign", func(t *testing.T) {
+			have, _, err := s.ListCampaignSnapshots(ctx, ListCampaignSnapshotsOpts{CampaignID: campaigns[1].ID})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(have) != 0 {
+				t.Fatalf("unexpected snapshots: %+v", have)
+			}
+		})
+	})
+
+	t.Run("Count", func(t *testing.T) {
+		count, err := s.CountCampaignSnapshots(ctx, CountCampaignSnapshotsOpts{CampaignID: campaigns[0].ID})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if have, want := count, len(snapshots); have != want {
+			t.Fatalf("have count: %d, want: %d", have, want)
+		}
+
+		count, err = s.CountCampaignSnapshots(ctx, CountCampaignSnapshotsOpts{
+			CampaignID: campaigns[0].ID,
+			From:       snapshots[1].CreatedAt,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if have, want := count, len(snapshots)-1; have != want {
+			t.Fatalf("have count: %d, want: %d", have, want)
+		}
+	})
+
+	t.Run("ListCampaignIDsDueForSnapshot", func(t *testing.T) {
+		// The last snapshot of the first campaign was taken an hour ago, and
+		// the second campaign doesn't have any snapshots.
+		clock.add(-1 * time.Minute)
+		have, err := s.ListCampaignIDsDueForSnapshot(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff([]int64{campaigns[1].ID}, have); diff != "" {
+			t.Fatalf("diff: %s", diff)
+		}
+
+		clock.add(2 * time.Minute)
+		have, err = s.ListCampaignIDsDueForSnapshot(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff([]int64{campaigns[0].ID, campaigns[1].ID}, have); diff != "" {
+			t.Fatalf("diff: %s", diff)
+		}
+	})
+
+	t.Run("Downsample", func(t *testing.T) {
+		// Take snapshots of the second campaign every 6 hours for two days,
+		// starting at midnight (UTC) well before the downsample age.
+		start := clock.now().UTC().Add(-cmpgn.CampaignSnapshotDownsampleAge - 72*time.Hour).Truncate(24 * time.Hour)
+		var old []*cmpgn.CampaignSnapshot
+		for i := 0; i < 8; i++ {
+			snapshot := &cmpgn.CampaignSnapshot{
+				CampaignID: campaigns[1].ID,
+				CreatedAt:  start.Add(time.Duration(i) * 6 * time.Hour),
+			}
+			if err := s.CreateCampaignSnapshot(ctx, snapshot); err != nil {
+				t.Fatal(err)
+			}
+			old = append(old, snapshot)
+		}
+		recent := &cmpgn.CampaignSnapshot{CampaignID: campaigns[1].ID, CreatedAt: clock.now()}
+		if err := s.CreateCampaignSnapshot(ctx, recent); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := s.DownsampleCampaignSnapshots(ctx); err != nil {
+			t.Fatal(err)
+		}
+
+		have, _, err := s.ListCampaignSnapshots(ctx, ListCampaignSnapshotsOpts{CampaignID: campaigns[1].ID})
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := []*cmpgn.CampaignSnapshot{old[0], old[4], recent}
+		if diff := cmp.Diff(want, have); diff != "" {
+			t.Fatalf("diff: %s", diff)
+		}
+
+		// The recent snapshots of the first campaign are kept.
+		have, _, err = s.ListCampaignSnapshots(ctx, ListCampaignSnapshotsOpts{CampaignID: campaigns[0].ID})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(snapshots, have); diff != "" {
+			t.Fatalf("diff: %s", diff)
+		}
+	})
+}
@@ -0,0 +1,355 @@
+package campaigns
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"sort"
+	"strings"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/internal/campaigns"
+	"github.com/sourcegraph/sourcegraph/internal/db/dbutil"
+)
+
+// campaignSpecExecutionColumns are used by the campaign spec execution
+// related Store methods to insert, update and query executions.
+var campaignSpecExecutionColumns = []*sqlf.Query{
+	sqlf.Sprintf("campaign_spec_executions.id"),
+	sqlf.Sprintf("campaign_spec_executions.campaign_spec_id"),
+	sqlf.Sprintf("campaign_spec_executions.repo_id"),
+	sqlf.Sprintf("campaign_spec_executions.base_ref"),
+	sqlf.Sprintf("campaign_spec_executions.base_rev"),
+	sqlf.Sprintf("campaign_spec_executions.search_result_paths"),
+	sqlf.Sprintf("campaign_spec_executions.changeset_spec_ids"),
+	sqlf.Sprintf("campaign_spec_executions.cache_hit"),
+	sqlf.Sprintf("campaign_spec_executions.user_id"),
+	sqlf.Sprintf("campaign_spec_executions.state"),
+	sqlf.Sprintf("campaign_spec_executions.failure_message"),
+	sqlf.Sprintf("campaign_spec_executions.started_at"),
+	sqlf.Sprintf("campaign_spec_executions.finished_at"),
+	sqlf.Sprintf("campaign_spec_executions.process_after"),
+	sqlf.Sprintf("campaign_spec_executions.num_resets"),
+	sqlf.Sprintf("campaign_spec_executions.num_failures"),
+	sqlf.Sprintf("campaign_spec_executions.created_at"),
+	sqlf.Sprintf("campaign_spec_executions.updated_at"),
+}
+
+// campaignSpecExecutionInsertColumns is the list of campaign_spec_executions
+// columns that are modified when inserting or updating an execution.
+var campaignSpecExecutionInsertColumns = []*sqlf.Query{
+	sqlf.Sprintf("campaign_spec_id"),
+	sqlf.Sprintf("repo_id"),
+	sqlf.Sprintf("base_ref"),
+	sqlf.Sprintf("base_rev"),
+	sqlf.Sprintf("search_result_paths"),
+	sqlf.Sprintf("changeset_spec_ids"),
+	sqlf.Sprintf("cache_hit"),
+	sqlf.Sprintf("user_id"),
+	sqlf.Sprintf("state"),
+	sqlf.Sprintf("failure_message"),
+	sqlf.Sprintf("started_at"),
+	sqlf.Sprintf("finished_at"),
+	sqlf.Sprintf("process_after"),
+	sqlf.Sprintf("num_resets"),
+	sqlf.Sprintf("num_failures"),
+	sqlf.Sprintf("created_at"),
+	sqlf.Sprintf("updated_at"),
+}
+
+const campaignSpecExecutionInsertColsFmt = `(%s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s)`
+
+// CreateCampaignSpecExecution creates the given CampaignSpecExecution.
+func (s *Store) CreateCampaignSpecExecution(ctx context.Context, e *campaigns.CampaignSpecExecution) error {
+	q, err := s.createCampaignSpecExecutionQuery(e)
+	if err != nil {
+		return err
+	}
+
+	return s.query(ctx, q, func(sc scanner) error {
+		return scanCampaignSpecExecution(e, sc)
+	})
+}
+
+var createCampaignSpecExecutionQueryFmtstr = `
+-- source: enterprise/internal/campaigns/store_campaign_spec_executions.go:CreateCampaignSpecExecution
+INSERT INTO campaign_spec_executions (%s)
+VALUES ` + campaignSpecExecutionInsertColsFmt + `
+RETURNING %s`
+
+func (s *Store) createCampaignSpecExecutionQuery(e *campaigns.CampaignSpecExecution) (*sqlf.Query, error) {
+	searchResultPaths, changesetSpecIDs, err := campaignSpecExecutionJSONColumns(e)
+	if err != nil {
+		return nil, err
+	}
+
+	if e.CreatedAt.IsZero() {
+		e.CreatedAt = s.now()
+	}
+
+	if e.UpdatedAt.IsZero() {
+		e.UpdatedAt = e.CreatedAt
+	}
+
+	if e.State == "" {
+		e.State = campaigns.CampaignSpecExecutionStateQueued
+	}
+
+	return sqlf.Sprintf(
+		createCampaignSpecExecutionQueryFmtstr,
+		sqlf.Join(campaignSpecExecutionInsertColumns, ", "),
+		e.CampaignSpecID,
+		e.RepoID,
+		e.BaseRef,
+		e.BaseRev,
+		searchResultPaths,
+		changesetSpecIDs,
+		e.CacheHit,
+		nullInt32Column(e.UserID),
+		e.State.ToDB(),
+		e.FailureMessage,
+		nullTimeColumn(e.StartedAt),
+		nullTimeColumn(e.FinishedAt),
+		nullTimeColumn(e.ProcessAfter),
+		e.NumResets,
+		e.NumFailures,
+		e.CreatedAt,
+		e.UpdatedAt,
+		sqlf.Join(campaignSpecExecutionColumns, ", "),
+	), nil
+}
+
+// UpdateCampaignSpecExecution updates the given CampaignSpecExecution.
+func (s *Store) UpdateCampaignSpecExecution(ctx context.Context, e *campaigns.CampaignSpecExecution) error {
+	q, err := s.updateCampaignSpecExecutionQuery(e)
+	if err != nil {
+		return err
+	}
+
+	return s.query(ctx, q, func(sc scanner) error {
+		return scanCampaignSpecExecution(e, sc)
+	})
+}
+
+var updateCampaignSpecExecutionQueryFmtstr = `
+-- source: enterprise/internal/campaigns/store_campaign_spec_executions.go:UpdateCampaignSpecExecution
+UPDATE campaign_spec_executions
+SET (%s) = ` + campaignSpecExecutionInsertColsFmt + `
+WHERE id = %s
+RETURNING %s`
+
+func (s *Store) updateCampaignSpecExecutionQuery(e *campaigns.CampaignSpecExecution) (*sqlf.Query, error) {
+	searchResultPaths, changesetSpecIDs, err := campaignSpecExecutionJSONColumns(e)
+	if err != nil {
+		return nil, err
+	}
+
+	e.UpdatedAt = s.now()
+
+	return sqlf.Sprintf(
+		updateCampaignSpecExecutionQueryFmtstr,
+		sqlf.Join(campaignSpecExecutionInsertColumns, ", "),
+		e.CampaignSpecID,
+		e.RepoID,
+		e.BaseRef,
+		e.BaseRev,
+		searchResultPaths,
+		changesetSpecIDs,
+		e.CacheHit,
+		nullInt32Column(e.UserID),
+		e.State.ToDB(),
+		e.FailureMessage,
+		nullTimeColumn(e.StartedAt),
+		nullTimeColumn(e.FinishedAt),
+		nullTimeColumn(e.ProcessAfter),
+		e.NumResets,
+		e.NumFailures,
+		e.CreatedAt,
+		e.UpdatedAt,
+		e.ID,
+		sqlf.Join(campaignSpecExecutionColumns, ", "),
+	), nil
+}
+
+func campaignSpecExecutionJSONColumns(e *campaigns.CampaignSpecExecution) (searchResultPaths, changesetSpecIDs []byte, err error) {
+	paths := e.SearchResultPaths
+	if paths == nil {
+		paths = []string{}
+	}
+	if searchResultPaths, err = json.Marshal(paths); err != nil {
+		return nil, nil, err
+	}
+
+	if changesetSpecIDs, err = jsonSetColumn(e.ChangesetSpecIDs); err != nil {
+		return nil, nil, err
+	}
+
+	return searchResultPaths, changesetSpecIDs, nil
+}
+
+// GetCampaignSpecExecutionOpts captures the query options needed for getting
+// a CampaignSpecExecution.
+type GetCampaignSpecExecutionOpts struct {
+	ID int64
+}
+
+// GetCampaignSpecExecution gets a campaign spec execution matching the given
+// options.
+func (s *Store) GetCampaignSpecExecution(ctx context.Context, opts GetCampaignSpecExecutionOpts) (*campaigns.CampaignSpecExecution, error) {
+	q := sqlf.Sprintf(
+		getCampaignSpecExecutionQueryFmtstr,
+		sqlf.Join(campaignSpecExecutionColumns, ", "),
+		opts.ID,
+	)
+
+	var e campaigns.CampaignSpecExecution
+	err := s.query(ctx, q, func(sc scanner) error {
+		return scanCampaignSpecExecution(&e, sc)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if e.ID == 0 {
+		return nil, ErrNoResults
+	}
+
+	return &e, nil
+}
+
+var getCampaignSpecExecutionQueryFmtstr = `
+-- source: enterprise/internal/campaigns/store_campaign_spec_executions.go:GetCampaignSpecExecution
+SELECT %s FROM campaign_spec_executions
+WHERE campaign_spec_executions.id = %s
+LIMIT 1
+`
+
+// ListCampaignSpecExecutionsOpts captures the query options needed for
+// listing campaign spec executions.
+type ListCampaignSpecExecutionsOpts struct {
+	LimitOpts
+	Cursor int64
+
+	CampaignSpecID int64
+	States         []campaigns.CampaignSpecExecutionState
+}
+
+// ListCampaignSpecExecutions lists CampaignSpecExecutions with the given
+// filters.
+func (s *Store) ListCampaignSpecExecutions(ctx context.Context, opts ListCampaignSpecExecutionsOpts) (es []*campaigns.CampaignSpecExecution, next int64, err error) {
+	q := listCampaignSpecExecutionsQuery(&opts)
+
+	es = make([]*campaigns.CampaignSpecExecution, 0, opts.DBLimit())
+	err = s.query(ctx, q, func(sc scanner) error {
+		var e campaigns.CampaignSpecExecution
+		if err := scanCampaignSpecExecution(&e, sc); err != nil {
+			return err
+		}
+		es = append(es, &e)
+		return nil
+	})
+
+	if opts.Limit != 0 && len(es) == opts.DBLimit() {
+		next = es[len(es)-1].ID
+		es = es[:len(es)-1]
+	}
+
+	return es, next, err
+}
+
+var listCampaignSpecExecutionsQueryFmtstr = `
+-- source: enterprise/internal/campaigns/store_campaign_spec_executions.go:ListCampaignSpecExecutions
+SELECT %s FROM campaign_spec_executions
+WHERE %s
+ORDER BY campaign_spec_executions.id ASC
+`
+
+func listCampaignSpecExecutionsQuery(opts *ListCampaignSpecExecutionsOpts) *sqlf.Query {
+	preds := []*sqlf.Query{
+		sqlf.Sprintf("campaign_spec_executions.id >= %s", opts.Cursor),
+	}
+
+	if opts.CampaignSpecID != 0 {
+		preds = append(preds, sqlf.Sprintf("campaign_spec_executions.campaign_spec_id = %s", opts.CampaignSpecID))
+	}
+
+	if len(opts.States) != 0 {
+		states := make([]*sqlf.Query, len(opts.States))
+		for i, state := range opts.States {
+			states[i] = sqlf.Sprintf("%s", state.ToDB())
+		}
+		preds = append(preds, sqlf.Sprintf("campaign_spec_executions.state IN (%s)", sqlf.Join(states, ",")))
+	}
+
+	return sqlf.Sprintf(
+		listCampaignSpecExecutionsQueryFmtstr+opts.LimitOpts.ToDB(),
+		sqlf.Join(campaignSpecExecutionColumns, ", "),
+		sqlf.Join(preds, "\n AND "),
+	)
+}
+
+func scanCampaignSpecExecution(e *campaigns.CampaignSpecExecution, s scanner) error {
+	var (
+		searchResultPaths json.RawMessage
+		state             string
+		failureMessage    string
+	)
+
+	err := s.Scan(
+		&e.ID,
+		&e.CampaignSpecID,
+		&e.RepoID,
+		&e.BaseRef,
+		&e.BaseRev,
+		&searchResultPaths,
+		&dbutil.JSONInt64Set{Set: &e.ChangesetSpecIDs},
+		&e.CacheHit,
+		&dbutil.NullInt32{N: &e.UserID},
+		&state,
+		&dbutil.NullString{S: &failureMessage},
+		&dbutil.NullTime{Time: &e.StartedAt},
+		&dbutil.NullTime{Time: &e.FinishedAt},
+		&dbutil.NullTime{Time: &e.ProcessAfter},
+		&e.NumResets,
+		&e.NumFailures,
+		&e.CreatedAt,
+		&e.UpdatedAt,
+	)
+	if err != nil {
+		return errors.Wrap(err, "scanning campaign spec execution")
+	}
+
+	if err := json.Unmarshal(searchResultPaths, &e.SearchResultPaths); err != nil {
+		return errors.Wrap(err, "scanning campaign spec execution search result paths")
+	}
+	// The set of changeset spec IDs is unordered in the database.
+	sort.Slice(e.ChangesetSpecIDs, func(i, j int) bool { return e.ChangesetSpecIDs[i] < e.ChangesetSpecIDs[j] })
+	if failureMessage != "" {
+		e.FailureMessage = &failureMessage
+	}
+	e.State = campaigns.CampaignSpecExecutionState(strings.ToUpper(state))
+
+	return nil
+}
+
+func scanFirstCampaignSpecExecution(rows *sql.Rows, err error) (*campaigns.CampaignSpecExecution, bool, error) {
+	if err != nil {
+		return nil, false, err
+	}
+
+	var es []*campaigns.CampaignSpecExecution
+	err = scanAll(rows, func(sc scanner) error {
+		var e campaigns.CampaignSpecExecution
+		if err := scanCampaignSpecExecution(&e, sc); err != nil {
+			return err
+		}
+		es = append(es, &e)
+		return nil
+	})
+	if err != nil || len(es) == 0 {
+		return &campaigns.CampaignSpecExecution{}, false, err
+	}
+
+	return es[0], true, nil
+}
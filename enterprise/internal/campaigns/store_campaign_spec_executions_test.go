@@ -0,0 +1,156 @@
+package campaigns
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sourcegraph/sourcegraph/cmd/repo-updater/repos"
+	cmpgn "github.com/sourcegraph/sourcegraph/internal/campaigns"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+)
+
+func testStoreCampaignSpecExecutions(t *testing.T, ctx context.Context, s *Store, rs repos.Store, clock clock) {
+	repo := testRepo(t, rs, extsvc.TypeGitHub)
+	if err := rs.InsertRepos(ctx, repo); err != nil {
+		t.Fatal(err)
+	}
+
+	executions := make([]*cmpgn.CampaignSpecExecution, 0, 3)
+	for i := 0; i < cap(executions); i++ {
+		executions = append(executions, &cmpgn.CampaignSpecExecution{
+			CampaignSpecID:    int64(i%2 + 910),
+			RepoID:            repo.ID,
+			BaseRef:           "refs/heads/master",
+			BaseRev:           "d34db33f",
+			SearchResultPaths: []string{"README.md"},
+			UserID:            int32(i + 1234),
+		})
+	}
+
+	t.Run("Create", func(t *testing.T) {
+		for _, e := range executions {
+			want := e.Clone()
+			have := e
+
+			if err := s.CreateCampaignSpecExecution(ctx, have); err != nil {
+				t.Fatal(err)
+			}
+
+			if have.ID == 0 {
+				t.Fatal("ID should not be zero")
+			}
+
+			want.ID = have.ID
+			want.ChangesetSpecIDs = []int64{}
+			want.State = cmpgn.CampaignSpecExecutionStateQueued
+			want.CreatedAt = clock.now()
+			want.UpdatedAt = clock.now()
+
+			if diff := cmp.Diff(have, want); diff != "" {
+				t.Fatal(diff)
+			}
+		}
+	})
+
+	t.Run("Get", func(t *testing.T) {
+		for _, want := range executions {
+			have, err := s.GetCampaignSpecExecution(ctx, GetCampaignSpecExecutionOpts{ID: want.ID})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(have, want); diff != "" {
+				t.Fatal(diff)
+			}
+		}
+
+		t.Run("NoResults", func(t *testing.T) {
+			_, have := s.GetCampaignSpecExecution(ctx, GetCampaignSpecExecutionOpts{ID: 0xdeadbeef})
+			if want := ErrNoResults; have != want {
+				t.Fatalf("have err %v, want %v", have, want)
+			}
+		})
+	})
+
+	t.Run("List", func(t *testing.T) {
+		t.Run("NoLimit", func(t *testing.T) {
+			have, next, err := s.ListCampaignSpecExecutions(ctx, ListCampaignSpecExecutionsOpts{})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if next != 0 {
+				t.Fatalf("have next %d, want 0", next)
+			}
+
+			if diff := cmp.Diff(have, executions); diff != "" {
+				t.Fatal(diff)
+			}
+		})
+
+		t.Run("WithLimit", func(t *testing.T) {
+			have, next, err := s.ListCampaignSpecExecutions(ctx, ListCampaignSpecExecutionsOpts{LimitOpts: LimitOpts{Limit: 1}})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if want := executions[1].ID; next != want {
+				t.Fatalf("have next %d, want %d", next, want)
+			}
+
+			if diff := cmp.Diff(have, executions[:1]); diff != "" {
+				t.Fatal(diff)
+			}
+		})
+
+		t.Run("WithCampaignSpecID", func(t *testing.T) {
+			have, _, err := s.ListCampaignSpecExecutions(ctx, ListCampaignSpecExecutionsOpts{CampaignSpecID: 910})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			want := []*cmpgn.CampaignSpecExecution{executions[0], executions[2]}
+			if diff := cmp.Diff(have, want); diff != "" {
+				t.Fatal(diff)
+			}
+		})
+
+		t.Run("WithStates", func(t *testing.T) {
+			opts := ListCampaignSpecExecutionsOpts{
+				States: []cmpgn.CampaignSpecExecutionState{cmpgn.CampaignSpecExecutionStateErrored},
+			}
+			have, _, err := s.ListCampaignSpecExecutions(ctx, opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if len(have) != 0 {
+				t.Fatalf("have %d executions, want none", len(have))
+			}
+		})
+	})
+
+	t.Run("Update", func(t *testing.T) {
+		clock.add(1 * time.Second)
+
+		for _, e := range executions {
+			failureMessage := "boom"
+			e.State = cmpgn.CampaignSpecExecutionStateErrored
+			e.FailureMessage = &failureMessage
+			e.ChangesetSpecIDs = []int64{4242, 4243}
+
+			want := e.Clone()
+			want.UpdatedAt = clock.now()
+
+			if err := s.UpdateCampaignSpecExecution(ctx, e); err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(e, want); diff != "" {
+				t.Fatal(diff)
+			}
+		}
+	})
+}
@@ -28,7 +28,7 @@ func testStoreCampaignSpecs(t *testing.T, ctx context.Context, s *Store, _ repos
 						Commit: campaigns.CommitTemplate{
 							Message: "commit message",
 						},
-						Published: false,
+						Published: campaigns.PublishedValue{Val: false},
 					},
 				},
 				UserID: int32(i + 1234),
@@ -0,0 +1,144 @@
+package campaigns
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/campaigns"
+)
+
+// campaignStepCacheEntryColumns are used by the step cache related Store
+// methods to insert, update and query cache entries.
+var campaignStepCacheEntryColumns = []*sqlf.Query{
+	sqlf.Sprintf("campaign_step_cache_entries.id"),
+	sqlf.Sprintf("campaign_step_cache_entries.repo_id"),
+	sqlf.Sprintf("campaign_step_cache_entries.base_rev"),
+	sqlf.Sprintf("campaign_step_cache_entries.steps_hash"),
+	sqlf.Sprintf("campaign_step_cache_entries.diff"),
+	sqlf.Sprintf("campaign_step_cache_entries.outputs"),
+	sqlf.Sprintf("campaign_step_cache_entries.created_at"),
+	sqlf.Sprintf("campaign_step_cache_entries.last_used_at"),
+}
+
+// UpsertCampaignStepCacheEntry creates the given CampaignStepCacheEntry or,
+// if an entry with the same repository, revision and steps hash already
+// exists, replaces its diff and outputs.
+func (s *Store) UpsertCampaignStepCacheEntry(ctx context.Context, e *campaigns.CampaignStepCacheEntry) error {
+	outputs, err := jsonbColumn(e.Outputs)
+	if err != nil {
+		return err
+	}
+
+	if e.CreatedAt.IsZero() {
+		e.CreatedAt = s.now()
+	}
+
+	if e.LastUsedAt.IsZero() {
+		e.LastUsedAt = e.CreatedAt
+	}
+
+	q := sqlf.Sprintf(
+		upsertCampaignStepCacheEntryQueryFmtstr,
+		e.RepoID,
+		e.BaseRev,
+		e.StepsHash,
+		e.Diff,
+		outputs,
+		e.CreatedAt,
+		e.LastUsedAt,
+		sqlf.Join(campaignStepCacheEntryColumns, ", "),
+	)
+
+	return s.query(ctx, q, func(sc scanner) error { return scanCampaignStepCacheEntry(e, sc) })
+}
+
+var upsertCampaignStepCacheEntryQueryFmtstr = `
+-- source: enterprise/internal/campaigns/store_campaign_step_cache.go:UpsertCampaignStepCacheEntry
+INSERT INTO campaign_step_cache_entries (repo_id, base_rev, steps_hash, diff, outputs, created_at, last_used_at)
+VALUES (%s, %s, %s, %s, %s, %s, %s)
+ON CONFLICT (repo_id, base_rev, steps_hash) DO UPDATE SET
+  diff = excluded.diff,
+  outputs = excluded.outputs,
+  last_used_at = excluded.last_used_at
+RETURNING %s`
+
+// GetCampaignStepCacheEntryOpts captures the query options needed for
+// getting a CampaignStepCacheEntry.
+type GetCampaignStepCacheEntryOpts struct {
+	RepoID    api.RepoID
+	BaseRev   string
+	StepsHash string
+}
+
+// GetCampaignStepCacheEntry gets the cache entry matching the given options
+// and marks it as used, so that it doesn't expire. If no entry matches,
+// ErrNoResults is returned.
+func (s *Store) GetCampaignStepCacheEntry(ctx context.Context, opts GetCampaignStepCacheEntryOpts) (*campaigns.CampaignStepCacheEntry, error) {
+	q := sqlf.Sprintf(
+		getCampaignStepCacheEntryQueryFmtstr,
+		s.now(),
+		opts.RepoID,
+		opts.BaseRev,
+		opts.StepsHash,
+		sqlf.Join(campaignStepCacheEntryColumns, ", "),
+	)
+
+	var e campaigns.CampaignStepCacheEntry
+	err := s.query(ctx, q, func(sc scanner) error { return scanCampaignStepCacheEntry(&e, sc) })
+	if err != nil {
+		return nil, err
+	}
+
+	if e.ID == 0 {
+		return nil, ErrNoResults
+	}
+
+	return &e, nil
+}
+
+var getCampaignStepCacheEntryQueryFmtstr = `
+-- source: enterprise/internal/campaigns/store_campaign_step_cache.go:GetCampaignStepCacheEntry
+UPDATE campaign_step_cache_entries
+SET last_used_at = %s
+WHERE repo_id = %s AND base_rev = %s AND steps_hash = %s
+RETURNING %s`
+
+// DeleteExpiredCampaignStepCacheEntries deletes cache entries that haven't
+// been used within CampaignStepCacheEntryTTL.
+func (s *Store) DeleteExpiredCampaignStepCacheEntries(ctx context.Context) error {
+	expirationTime := s.now().Add(-campaigns.CampaignStepCacheEntryTTL)
+	return s.Store.Exec(ctx, sqlf.Sprintf(deleteExpiredCampaignStepCacheEntriesQueryFmtstr, expirationTime))
+}
+
+var deleteExpiredCampaignStepCacheEntriesQueryFmtstr = `
+-- source: enterprise/internal/campaigns/store_campaign_step_cache.go:DeleteExpiredCampaignStepCacheEntries
+DELETE FROM campaign_step_cache_entries WHERE last_used_at < %s
+`
+
+func scanCampaignStepCacheEntry(e *campaigns.CampaignStepCacheEntry, s scanner) error {
+	var outputs json.RawMessage
+
+	err := s.Scan(
+		&e.ID,
+		&e.RepoID,
+		&e.BaseRev,
+		&e.StepsHash,
+		&e.Diff,
+		&outputs,
+		&e.CreatedAt,
+		&e.LastUsedAt,
+	)
+	if err != nil {
+		return errors.Wrap(err, "scanning campaign step cache entry")
+	}
+
+	e.Outputs = map[string]interface{}{}
+	if err := json.Unmarshal(outputs, &e.Outputs); err != nil {
+		return errors.Wrap(err, "scanning campaign step cache entry outputs")
+	}
+
+	return nil
+}
@@ -0,0 +1,114 @@
+package campaigns
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sourcegraph/sourcegraph/cmd/repo-updater/repos"
+	cmpgn "github.com/sourcegraph/sourcegraph/internal/campaigns"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+)
+
+func testStoreCampaignStepCache(t *testing.T, ctx context.Context, s *Store, rs repos.Store, clock clock) {
+	repo := testRepo(t, rs, extsvc.TypeGitHub)
+	if err := rs.InsertRepos(ctx, repo); err != nil {
+		t.Fatal(err)
+	}
+
+	entry := &cmpgn.CampaignStepCacheEntry{
+		RepoID:    repo.ID,
+		BaseRev:   "d34db33f",
+		StepsHash: "abc",
+		Diff:      "diff --git a/README.md b/README.md",
+		Outputs:   map[string]interface{}{"greeting": "hello"},
+	}
+	opts := GetCampaignStepCacheEntryOpts{RepoID: repo.ID, BaseRev: "d34db33f", StepsHash: "abc"}
+
+	t.Run("Upsert", func(t *testing.T) {
+		want := entry.Clone()
+		if err := s.UpsertCampaignStepCacheEntry(ctx, entry); err != nil {
+			t.Fatal(err)
+		}
+		if entry.ID == 0 {
+			t.Fatal("ID should not be zero")
+		}
+
+		want.ID = entry.ID
+		want.CreatedAt = clock.now()
+		want.LastUsedAt = clock.now()
+		if diff := cmp.Diff(want, entry); diff != "" {
+			t.Fatalf("unexpected entry (-want +got):\n%s", diff)
+		}
+
+		t.Run("Conflict", func(t *testing.T) {
+			clock.add(1 * time.Second)
+
+			updated := &cmpgn.CampaignStepCacheEntry{
+				RepoID:    repo.ID,
+				BaseRev:   "d34db33f",
+				StepsHash: "abc",
+				Diff:      "",
+				Outputs:   map[string]interface{}{},
+			}
+			if err := s.UpsertCampaignStepCacheEntry(ctx, updated); err != nil {
+				t.Fatal(err)
+			}
+
+			if updated.ID != entry.ID {
+				t.Fatalf("expected entry %d to be updated, got %d", entry.ID, updated.ID)
+			}
+			if updated.Diff != "" || len(updated.Outputs) != 0 {
+				t.Fatalf("expected diff and outputs to be replaced, got %q and %v", updated.Diff, updated.Outputs)
+			}
+			if !updated.CreatedAt.Equal(entry.CreatedAt) {
+				t.Fatalf("expected created_at to be kept. have=%s want=%s", updated.CreatedAt, entry.CreatedAt)
+			}
+			*entry = *updated
+		})
+	})
+
+	t.Run("Get", func(t *testing.T) {
+		clock.add(1 * time.Second)
+
+		have, err := s.GetCampaignStepCacheEntry(ctx, opts)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		want := entry.Clone()
+		want.LastUsedAt = clock.now()
+		if diff := cmp.Diff(want, have); diff != "" {
+			t.Fatalf("unexpected entry (-want +got):\n%s", diff)
+		}
+		*entry = *have
+
+		for _, opts := range []GetCampaignStepCacheEntryOpts{
+			{RepoID: repo.ID, BaseRev: "d34db33f", StepsHash: "def"},
+			{RepoID: repo.ID, BaseRev: "f00b4r", StepsHash: "abc"},
+		} {
+			if _, err := s.GetCampaignStepCacheEntry(ctx, opts); err != ErrNoResults {
+				t.Fatalf("opts %+v: have err %v, want %v", opts, err, ErrNoResults)
+			}
+		}
+	})
+
+	t.Run("DeleteExpired", func(t *testing.T) {
+		if err := s.DeleteExpiredCampaignStepCacheEntries(ctx); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := s.GetCampaignStepCacheEntry(ctx, opts); err != nil {
+			t.Fatalf("recently used entry was deleted: %v", err)
+		}
+
+		clock.add(cmpgn.CampaignStepCacheEntryTTL + time.Second)
+
+		if err := s.DeleteExpiredCampaignStepCacheEntries(ctx); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := s.GetCampaignStepCacheEntry(ctx, opts); err != ErrNoResults {
+			t.Fatalf("have err %v, want %v", err, ErrNoResults)
+		}
+	})
+}
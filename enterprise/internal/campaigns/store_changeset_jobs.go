@@ -0,0 +1,446 @@
+package campaigns
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/internal/campaigns"
+	"github.com/sourcegraph/sourcegraph/internal/db/dbutil"
+)
+
+// changesetJobColumns are used by the changeset job related Store methods to
+// insert, update and query changeset jobs.
+var changesetJobColumns = []*sqlf.Query{
+	sqlf.Sprintf("changeset_jobs.id"),
+	sqlf.Sprintf("changeset_jobs.bulk_group"),
+	sqlf.Sprintf("changeset_jobs.user_id"),
+	sqlf.Sprintf("changeset_jobs.campaign_id"),
+	sqlf.Sprintf("changeset_jobs.changeset_id"),
+	sqlf.Sprintf("changeset_jobs.job_type"),
+	sqlf.Sprintf("changeset_jobs.payload"),
+	sqlf.Sprintf("changeset_jobs.state"),
+	sqlf.Sprintf("changeset_jobs.failure_message"),
+	sqlf.Sprintf("changeset_jobs.started_at"),
+	sqlf.Sprintf("changeset_jobs.finished_at"),
+	sqlf.Sprintf("changeset_jobs.process_after"),
+	sqlf.Sprintf("changeset_jobs.num_resets"),
+	sqlf.Sprintf("changeset_jobs.num_failures"),
+	sqlf.Sprintf("changeset_jobs.created_at"),
+	sqlf.Sprintf("changeset_jobs.updated_at"),
+}
+
+// changesetJobInsertColumns is the list of changeset_jobs columns that are
+// modified when inserting a changeset job.
+var changesetJobInsertColumns = []*sqlf.Query{
+	sqlf.Sprintf("bulk_group"),
+	sqlf.Sprintf("user_id"),
+	sqlf.Sprintf("campaign_id"),
+	sqlf.Sprintf("changeset_id"),
+	sqlf.Sprintf("job_type"),
+	sqlf.Sprintf("payload"),
+	sqlf.Sprintf("state"),
+	sqlf.Sprintf("failure_message"),
+	sqlf.Sprintf("started_at"),
+	sqlf.Sprintf("finished_at"),
+	sqlf.Sprintf("process_after"),
+	sqlf.Sprintf("num_resets"),
+	sqlf.Sprintf("num_failures"),
+	sqlf.Sprintf("created_at"),
+	sqlf.Sprintf("updated_at"),
+}
+
+// CreateChangesetJob creates the given ChangesetJob.
+func (s *Store) CreateChangesetJob(ctx context.Context, j *campaigns.ChangesetJob) error {
+	q, err := s.createChangesetJobQuery(j)
+	if err != nil {
+		return err
+	}
+
+	return s.query(ctx, q, func(sc scanner) error {
+		return scanChangesetJob(j, sc)
+	})
+}
+
+var createChangesetJobQueryFmtstr = `
+-- source: enterprise/internal/campaigns/store_changeset_jobs.go:CreateChangesetJob
+INSERT INTO changeset_jobs (%s)
+VALUES (%s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s)
+RETURNING %s`
+
+func (s *Store) createChangesetJobQuery(j *campaigns.ChangesetJob) (*sqlf.Query, error) {
+	payload, err := jsonbColumn(j.Payload)
+	if err != nil {
+		return nil, err
+	}
+
+	if j.CreatedAt.IsZero() {
+		j.CreatedAt = s.now()
+	}
+
+	if j.UpdatedAt.IsZero() {
+		j.UpdatedAt = j.CreatedAt
+	}
+
+	if j.State == "" {
+		j.State = campaigns.ChangesetJobStateQueued
+	}
+
+	return sqlf.Sprintf(
+		createChangesetJobQueryFmtstr,
+		sqlf.Join(changesetJobInsertColumns, ", "),
+		j.BulkGroup,
+		j.UserID,
+		j.CampaignID,
+		j.ChangesetID,
+		j.JobType,
+		payload,
+		j.State.ToDB(),
+		j.FailureMessage,
+		nullTimeColumn(j.StartedAt),
+		nullTimeColumn(j.FinishedAt),
+		nullTimeColumn(j.ProcessAfter),
+		j.NumResets,
+		j.NumFailures,
+		j.CreatedAt,
+		j.UpdatedAt,
+		sqlf.Join(changesetJobColumns, ", "),
+	), nil
+}
+
+// GetChangesetJobOpts captures the query options needed for getting a
+// ChangesetJob.
+type GetChangesetJobOpts struct {
+	ID int64
+}
+
+// GetChangesetJob gets a changeset job matching the given options.
+func (s *Store) GetChangesetJob(ctx context.Context, opts GetChangesetJobOpts) (*campaigns.ChangesetJob, error) {
+	q := sqlf.Sprintf(
+		getChangesetJobQueryFmtstr,
+		sqlf.Join(changesetJobColumns, ", "),
+		opts.ID,
+	)
+
+	var j campaigns.ChangesetJob
+	err := s.query(ctx, q, func(sc scanner) error {
+		return scanChangesetJob(&j, sc)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if j.ID == 0 {
+		return nil, ErrNoResults
+	}
+
+	return &j, nil
+}
+
+var getChangesetJobQueryFmtstr = `
+-- source: enterprise/internal/campaigns/store_changeset_jobs.go:GetChangesetJob
+SELECT %s FROM changeset_jobs
+WHERE changeset_jobs.id = %s
+LIMIT 1
+`
+
+// ListChangesetJobsOpts captures the query options needed for listing
+// changeset jobs.
+type ListChangesetJobsOpts struct {
+	LimitOpts
+	Cursor int64
+
+	BulkGroup  string
+	CampaignID int64
+	States     []campaigns.ChangesetJobState
+}
+
+// ListChangesetJobs lists ChangesetJobs with the given filters.
+func (s *Store) ListChangesetJobs(ctx context.Context, opts ListChangesetJobsOpts) (js []*campaigns.ChangesetJob, next int64, err error) {
+	q := listChangesetJobsQuery(&opts)
+
+	js = make([]*campaigns.ChangesetJob, 0, opts.DBLimit())
+	err = s.query(ctx, q, func(sc scanner) error {
+		var j campaigns.ChangesetJob
+		if err := scanChangesetJob(&j, sc); err != nil {
+			return err
+		}
+		js = append(js, &j)
+		return nil
+	})
+
+	if opts.Limit != 0 && len(js) == opts.DBLimit() {
+		next = js[len(js)-1].ID
+		js = js[:len(js)-1]
+	}
+
+	return js, next, err
+}
+
+var listChangesetJobsQueryFmtstr = `
+-- source: enterprise/internal/campaigns/store_changeset_jobs.go:ListChangesetJobs
+SELECT %s FROM changeset_jobs
+WHERE %s
+ORDER BY changeset_jobs.id ASC
+`
+
+func listChangesetJobsQuery(opts *ListChangesetJobsOpts) *sqlf.Query {
+	preds := []*sqlf.Query{
+		sqlf.Sprintf("changeset_jobs.id >= %s", opts.Cursor),
+	}
+
+	if opts.BulkGroup != "" {
+		preds = append(preds, sqlf.Sprintf("changeset_jobs.bulk_group = %s", opts.BulkGroup))
+	}
+
+	if opts.CampaignID != 0 {
+		preds = append(preds, sqlf.Sprintf("changeset_jobs.campaign_id = %s", opts.CampaignID))
+	}
+
+	if len(opts.States) != 0 {
+		states := make([]*sqlf.Query, len(opts.States))
+		for i, state := range opts.States {
+			states[i] = sqlf.Sprintf("%s", state.ToDB())
+		}
+		preds = append(preds, sqlf.Sprintf("changeset_jobs.state IN (%s)", sqlf.Join(states, ",")))
+	}
+
+	return sqlf.Sprintf(
+		listChangesetJobsQueryFmtstr+opts.LimitOpts.ToDB(),
+		sqlf.Join(changesetJobColumns, ", "),
+		sqlf.Join(preds, "\n AND "),
+	)
+}
+
+// bulkOperationColumns are used to aggregate the changeset jobs of a bulk
+// group into a BulkOperation. A group only ever contains jobs of the same
+// type, created by the same user for the same campaign at the same time.
+var bulkOperationColumns = []*sqlf.Query{
+	sqlf.Sprintf("changeset_jobs.bulk_group"),
+	sqlf.Sprintf("MIN(changeset_jobs.job_type)"),
+	sqlf.Sprintf("MIN(changeset_jobs.user_id)"),
+	sqlf.Sprintf("MIN(changeset_jobs.campaign_id)"),
+	sqlf.Sprintf("COUNT(*)"),
+	sqlf.Sprintf("COUNT(*) FILTER (WHERE changeset_jobs.state IN ('completed', 'errored'))"),
+	sqlf.Sprintf("COUNT(*) FILTER (WHERE changeset_jobs.state = 'errored')"),
+	sqlf.Sprintf("MIN(changeset_jobs.created_at)"),
+	sqlf.Sprintf("MAX(changeset_jobs.finished_at)"),
+}
+
+// GetBulkOperationOpts captures the query options needed for getting a
+// BulkOperation.
+type GetBulkOperationOpts struct {
+	ID string
+}
+
+// GetBulkOperation gets the BulkOperation with the given ID, aggregated from
+// its ChangesetJobs.
+func (s *Store) GetBulkOperation(ctx context.Context, opts GetBulkOperationOpts) (*campaigns.BulkOperation, error) {
+	q := sqlf.Sprintf(
+		getBulkOperationQueryFmtstr,
+		sqlf.Join(bulkOperationColumns, ", "),
+		opts.ID,
+	)
+
+	var op campaigns.BulkOperation
+	err := s.query(ctx, q, func(sc scanner) error {
+		return scanBulkOperation(&op, sc)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if op.ID == "" {
+		return nil, ErrNoResults
+	}
+
+	return &op, nil
+}
+
+var getBulkOperationQueryFmtstr = `
+-- source: enterprise/internal/campaigns/store_changeset_jobs.go:GetBulkOperation
+SELECT %s FROM changeset_jobs
+WHERE changeset_jobs.bulk_group = %s
+GROUP BY changeset_jobs.bulk_group
+LIMIT 1
+`
+
+// ListBulkOperationsOpts captures the query options needed for listing bulk
+// operations.
+type ListBulkOperationsOpts struct {
+	CampaignID int64
+}
+
+// ListBulkOperations lists the BulkOperations of a campaign, newest first.
+func (s *Store) ListBulkOperations(ctx context.Context, opts ListBulkOperationsOpts) (ops []*campaigns.BulkOperation, err error) {
+	q := sqlf.Sprintf(
+		listBulkOperationsQueryFmtstr,
+		sqlf.Join(bulkOperationColumns, ", "),
+		opts.CampaignID,
+	)
+
+	err = s.query(ctx, q, func(sc scanner) error {
+		var op campaigns.BulkOperation
+		if err := scanBulkOperation(&op, sc); err != nil {
+			return err
+		}
+		ops = append(ops, &op)
+		return nil
+	})
+
+	return ops, err
+}
+
+var listBulkOperationsQueryFmtstr = `
+-- source: enterprise/internal/campaigns/store_changeset_jobs.go:ListBulkOperations
+SELECT %s FROM changeset_jobs
+WHERE changeset_jobs.campaign_id = %s
+GROUP BY changeset_jobs.bulk_group
+ORDER BY MIN(changeset_jobs.created_at) DESC, changeset_jobs.bulk_group ASC
+`
+
+// ListBulkOperationErrorsOpts captures the query options needed for listing
+// the errors of a bulk operation.
+type ListBulkOperationErrorsOpts struct {
+	BulkOperationID string
+}
+
+// ListBulkOperationErrors lists the errors of the ChangesetJobs of the given
+// BulkOperation that failed.
+func (s *Store) ListBulkOperationErrors(ctx context.Context, opts ListBulkOperationErrorsOpts) (es []*campaigns.BulkOperationError, err error) {
+	q := sqlf.Sprintf(listBulkOperationErrorsQueryFmtstr, opts.BulkOperationID)
+
+	err = s.query(ctx, q, func(sc scanner) error {
+		var e campaigns.BulkOperationError
+		if err := sc.Scan(&e.ChangesetID, &dbutil.NullString{S: &e.Error}); err != nil {
+			return errors.Wrap(err, "scanning bulk operation error")
+		}
+		es = append(es, &e)
+		return nil
+	})
+
+	return es, err
+}
+
+var listBulkOperationErrorsQueryFmtstr = `
+-- source: enterprise/internal/campaigns/store_changeset_jobs.go:ListBulkOperationErrors
+SELECT changeset_jobs.changeset_id, changeset_jobs.failure_message
+FROM changeset_jobs
+WHERE changeset_jobs.bulk_group = %s
+AND changeset_jobs.state = 'errored'
+ORDER BY changeset_jobs.id ASC
+`
+
+func scanBulkOperation(op *campaigns.BulkOperation, s scanner) error {
+	var (
+		jobType   string
+		processed int32
+		errored   int32
+	)
+
+	err := s.Scan(
+		&op.ID,
+		&jobType,
+		&op.UserID,
+		&op.CampaignID,
+		&op.ChangesetCount,
+		&processed,
+		&errored,
+		&op.CreatedAt,
+		&dbutil.NullTime{Time: &op.FinishedAt},
+	)
+	if err != nil {
+		return errors.Wrap(err, "scanning bulk operation")
+	}
+
+	op.Type = campaigns.ChangesetJobType(jobType)
+	if op.ChangesetCount > 0 {
+		op.Progress = float64(processed) / float64(op.ChangesetCount)
+	}
+
+	switch {
+	case processed < op.ChangesetCount:
+		op.State = campaigns.BulkOperationStateProcessing
+		// The operation isn't finished until all of its jobs are.
+		op.FinishedAt = time.Time{}
+	case errored > 0:
+		op.State = campaigns.BulkOperationStateFailed
+	default:
+		op.State = campaigns.BulkOperationStateCompleted
+	}
+
+	return nil
+}
+
+func scanChangesetJob(j *campaigns.ChangesetJob, s scanner) error {
+	var (
+		jobType        string
+		payload        json.RawMessage
+		state          string
+		failureMessage string
+	)
+
+	err := s.Scan(
+		&j.ID,
+		&j.BulkGroup,
+		&j.UserID,
+		&j.CampaignID,
+		&j.ChangesetID,
+		&jobType,
+		&payload,
+		&state,
+		&dbutil.NullString{S: &failureMessage},
+		&dbutil.NullTime{Time: &j.StartedAt},
+		&dbutil.NullTime{Time: &j.FinishedAt},
+		&dbutil.NullTime{Time: &j.ProcessAfter},
+		&j.NumResets,
+		&j.NumFailures,
+		&j.CreatedAt,
+		&j.UpdatedAt,
+	)
+	if err != nil {
+		return errors.Wrap(err, "scanning changeset job")
+	}
+
+	j.JobType = campaigns.ChangesetJobType(jobType)
+	switch j.JobType {
+	case campaigns.ChangesetJobTypeComment:
+		p := new(campaigns.ChangesetJobCommentPayload)
+		if err := json.Unmarshal(payload, p); err != nil {
+			return errors.Wrap(err, "scanning changeset job payload")
+		}
+		j.Payload = p
+	}
+
+	if failureMessage != "" {
+		j.FailureMessage = &failureMessage
+	}
+	j.State = campaigns.ChangesetJobState(strings.ToUpper(state))
+
+	return nil
+}
+
+func scanFirstChangesetJob(rows *sql.Rows, err error) (*campaigns.ChangesetJob, bool, error) {
+	if err != nil {
+		return nil, false, err
+	}
+
+	var js []*campaigns.ChangesetJob
+	err = scanAll(rows, func(sc scanner) error {
+		var j campaigns.ChangesetJob
+		if err := scanChangesetJob(&j, sc); err != nil {
+			return err
+		}
+		js = append(js, &j)
+		return nil
+	})
+	if err != nil || len(js) == 0 {
+		return &campaigns.ChangesetJob{}, false, err
+	}
+
+	return js[0], true, nil
+}
@@ -0,0 +1,214 @@
+package campaigns
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/keegancsmith/sqlf"
+	"github.com/sourcegraph/sourcegraph/cmd/repo-updater/repos"
+	cmpgn "github.com/sourcegraph/sourcegraph/internal/campaigns"
+)
+
+func testStoreChangesetJobs(t *testing.T, ctx context.Context, s *Store, _ repos.Store, clock clock) {
+	jobs := []*cmpgn.ChangesetJob{
+		{
+			BulkGroup:   "group-1",
+			UserID:      1234,
+			CampaignID:  910,
+			ChangesetID: 1,
+			JobType:     cmpgn.ChangesetJobTypeComment,
+			Payload:     &cmpgn.ChangesetJobCommentPayload{Message: "hello"},
+		},
+		{
+			BulkGroup:   "group-1",
+			UserID:      1234,
+			CampaignID:  910,
+			ChangesetID: 2,
+			JobType:     cmpgn.ChangesetJobTypeComment,
+			Payload:     &cmpgn.ChangesetJobCommentPayload{Message: "hello"},
+		},
+		{
+			BulkGroup:   "group-2",
+			UserID:      1234,
+			CampaignID:  910,
+			ChangesetID: 1,
+			JobType:     cmpgn.ChangesetJobTypeMerge,
+		},
+		{
+			BulkGroup:   "group-3",
+			UserID:      1234,
+			CampaignID:  911,
+			ChangesetID: 3,
+			JobType:     cmpgn.ChangesetJobTypeClose,
+		},
+	}
+
+	t.Run("Create", func(t *testing.T) {
+		for _, j := range jobs {
+			want := j.Clone()
+			have := j
+
+			if err := s.CreateChangesetJob(ctx, have); err != nil {
+				t.Fatal(err)
+			}
+
+			if have.ID == 0 {
+				t.Fatal("ID should not be zero")
+			}
+
+			want.ID = have.ID
+			want.State = cmpgn.ChangesetJobStateQueued
+			want.CreatedAt = clock.now()
+			want.UpdatedAt = clock.now()
+
+			if diff := cmp.Diff(have, want); diff != "" {
+				t.Fatal(diff)
+			}
+		}
+	})
+
+	t.Run("Get", func(t *testing.T) {
+		for _, want := range jobs {
+			have, err := s.GetChangesetJob(ctx, GetChangesetJobOpts{ID: want.ID})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(have, want); diff != "" {
+				t.Fatal(diff)
+			}
+		}
+
+		t.Run("NoResults", func(t *testing.T) {
+			_, have := s.GetChangesetJob(ctx, GetChangesetJobOpts{ID: 0xdeadbeef})
+			if want := ErrNoResults; have != want {
+				t.Fatalf("have err %v, want %v", have, want)
+			}
+		})
+	})
+
+	t.Run("List", func(t *testing.T) {
+		t.Run("ByBulkGroup", func(t *testing.T) {
+			have, _, err := s.ListChangesetJobs(ctx, ListChangesetJobsOpts{BulkGroup: "group-1"})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(have, jobs[:2]); diff != "" {
+				t.Fatal(diff)
+			}
+		})
+
+		t.Run("ByCampaignID", func(t *testing.T) {
+			have, _, err := s.ListChangesetJobs(ctx, ListChangesetJobsOpts{CampaignID: 911})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(have, jobs[3:]); diff != "" {
+				t.Fatal(diff)
+			}
+		})
+
+		t.Run("WithLimit", func(t *testing.T) {
+			for i := 1; i <= len(jobs); i++ {
+				cs, next, err := s.ListChangesetJobs(ctx, ListChangesetJobsOpts{LimitOpts: LimitOpts{Limit: i}})
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				{
+					have, want := next, int64(0)
+					if i < len(jobs) {
+						want = jobs[i].ID
+					}
+
+					if have != want {
+						t.Fatalf("limit: %v: have next %v, want %v", i, have, want)
+					}
+				}
+
+				if diff := cmp.Diff(cs, jobs[:i]); diff != "" {
+					t.Fatal(diff)
+				}
+			}
+		})
+	})
+
+	t.Run("BulkOperations", func(t *testing.T) {
+		// Fail the first comment job and complete the second one, so that
+		// the first group is finished.
+		q := sqlf.Sprintf(
+			`UPDATE changeset_jobs SET state = 'errored', failure_message = 'boom', finished_at = %s WHERE id = %s`,
+			clock.now(), jobs[0].ID,
+		)
+		if err := s.Exec(ctx, q); err != nil {
+			t.Fatal(err)
+		}
+		q = sqlf.Sprintf(
+			`UPDATE changeset_jobs SET state = 'completed', finished_at = %s WHERE id = %s`,
+			clock.now(), jobs[1].ID,
+		)
+		if err := s.Exec(ctx, q); err != nil {
+			t.Fatal(err)
+		}
+
+		group1 := &cmpgn.BulkOperation{
+			ID:             "group-1",
+			Type:           cmpgn.ChangesetJobTypeComment,
+			State:          cmpgn.BulkOperationStateFailed,
+			UserID:         1234,
+			CampaignID:     910,
+			ChangesetCount: 2,
+			Progress:       1,
+			CreatedAt:      clock.now(),
+			FinishedAt:     clock.now(),
+		}
+		group2 := &cmpgn.BulkOperation{
+			ID:             "group-2",
+			Type:           cmpgn.ChangesetJobTypeMerge,
+			State:          cmpgn.BulkOperationStateProcessing,
+			UserID:         1234,
+			CampaignID:     910,
+			ChangesetCount: 1,
+			CreatedAt:      clock.now(),
+		}
+
+		t.Run("Get", func(t *testing.T) {
+			have, err := s.GetBulkOperation(ctx, GetBulkOperationOpts{ID: "group-1"})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(have, group1); diff != "" {
+				t.Fatal(diff)
+			}
+
+			_, err = s.GetBulkOperation(ctx, GetBulkOperationOpts{ID: "unknown"})
+			if want := ErrNoResults; err != want {
+				t.Fatalf("have err %v, want %v", err, want)
+			}
+		})
+
+		t.Run("List", func(t *testing.T) {
+			have, err := s.ListBulkOperations(ctx, ListBulkOperationsOpts{CampaignID: 910})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(have, []*cmpgn.BulkOperation{group1, group2}); diff != "" {
+				t.Fatal(diff)
+			}
+		})
+
+		t.Run("ListErrors", func(t *testing.T) {
+			have, err := s.ListBulkOperationErrors(ctx, ListBulkOperationErrorsOpts{BulkOperationID: "group-1"})
+			if err != nil {
+				t.Fatal(err)
+			}
+			want := []*cmpgn.BulkOperationError{{ChangesetID: jobs[0].ChangesetID, Error: "boom"}}
+			if diff := cmp.Diff(have, want); diff != "" {
+				t.Fatal(diff)
+			}
+		})
+	})
+}
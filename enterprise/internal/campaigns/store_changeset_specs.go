@@ -261,6 +261,7 @@ type ListChangesetSpecsOpts struct {
 
 	CampaignSpecID int64
 	RandIDs        []string
+	IDs            []int64
 }
 
 // ListChangesetSpecs lists ChangesetSpecs with the given filters.
@@ -313,6 +314,14 @@ func listChangesetSpecsQuery(opts *ListChangesetSpecsOpts) *sqlf.Query {
 		preds = append(preds, sqlf.Sprintf("changeset_specs.rand_id IN (%s)", sqlf.Join(ids, ",")))
 	}
 
+	if len(opts.IDs) != 0 {
+		ids := make([]*sqlf.Query, 0, len(opts.IDs))
+		for _, id := range opts.IDs {
+			ids = append(ids, sqlf.Sprintf("%s", id))
+		}
+		preds = append(preds, sqlf.Sprintf("changeset_specs.id IN (%s)", sqlf.Join(ids, ",")))
+	}
+
 	return sqlf.Sprintf(
 		listChangesetSpecsQueryFmtstr+opts.LimitOpts.ToDB(),
 		sqlf.Join(changesetSpecColumns, ", "),
@@ -55,6 +55,8 @@ var changesetColumns = []*sqlf.Query{
 	sqlf.Sprintf("changesets.num_failures"),
 	sqlf.Sprintf("changesets.unsynced"),
 	sqlf.Sprintf("changesets.closing"),
+	sqlf.Sprintf("changesets.rebase_state"),
+	sqlf.Sprintf("changesets.rebase_base_rev"),
 }
 
 // changesetInsertColumns is the list of changeset columns that are modified in
@@ -92,6 +94,8 @@ var changesetInsertColumns = []*sqlf.Query{
 	sqlf.Sprintf("num_failures"),
 	sqlf.Sprintf("unsynced"),
 	sqlf.Sprintf("closing"),
+	sqlf.Sprintf("rebase_state"),
+	sqlf.Sprintf("rebase_base_rev"),
 }
 
 func (s *Store) changesetWriteQuery(q string, includeID bool, c *campaigns.Changeset) (*sqlf.Query, error) {
@@ -144,6 +148,8 @@ func (s *Store) changesetWriteQuery(q string, includeID bool, c *campaigns.Chang
 		c.NumFailures,
 		c.Unsynced,
 		c.Closing,
+		nullStringColumn(string(c.RebaseState)),
+		nullStringColumn(c.RebaseBaseRev),
 	}
 
 	if includeID {
@@ -176,7 +182,7 @@ func (s *Store) CreateChangeset(ctx context.Context, c *campaigns.Changeset) err
 var createChangesetQueryFmtstr = `
 -- source: enterprise/internal/campaigns/store.go:CreateChangeset
 INSERT INTO changesets (%s)
-VALUES (%s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s)
+VALUES (%s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s)
 RETURNING %s
 `
 
@@ -251,6 +257,8 @@ type GetChangesetOpts struct {
 	ExternalID          string
 	ExternalServiceType string
 	ExternalBranch      string
+	// ForUpdate locks the changeset until the end of the transaction.
+	ForUpdate bool
 }
 
 // GetChangeset gets a changeset matching the given options.
@@ -300,8 +308,13 @@ func getChangesetQuery(opts *GetChangesetOpts) *sqlf.Query {
 		preds = append(preds, sqlf.Sprintf("changesets.external_branch = %s", opts.ExternalBranch))
 	}
 
+	var lock string
+	if opts.ForUpdate {
+		lock = "FOR UPDATE OF changesets"
+	}
+
 	return sqlf.Sprintf(
-		getChangesetsQueryFmtstr,
+		getChangesetsQueryFmtstr+lock,
 		sqlf.Join(changesetColumns, ", "),
 		sqlf.Join(preds, "\n AND "),
 	)
@@ -360,7 +373,7 @@ func listChangesetSyncData(opts ListChangesetSyncDataOpts) *sqlf.Query {
 	preds := []*sqlf.Query{
 		sqlf.Sprintf("campaigns.closed_at IS NULL"),
 		sqlf.Sprintf("r.deleted_at IS NULL"),
-		sqlf.Sprintf("changesets.publication_state = %s", campaigns.ChangesetPublicationStatePublished),
+		sqlf.Sprintf("changesets.publication_state IN (%s, %s)", campaigns.ChangesetPublicationStatePublished, campaigns.ChangesetPublicationStateDraft),
 		sqlf.Sprintf("changesets.reconciler_state = %s", campaigns.ReconcilerStateCompleted.ToDB()),
 	}
 	if len(opts.ChangesetIDs) > 0 {
@@ -380,11 +393,14 @@ func listChangesetSyncData(opts ListChangesetSyncDataOpts) *sqlf.Query {
 // listing changesets.
 type ListChangesetsOpts struct {
 	LimitOpts
-	Cursor               int64
-	CampaignID           int64
-	IDs                  []int64
-	WithoutDeleted       bool
-	PublicationState     *campaigns.ChangesetPublicationState
+	Cursor           int64
+	CampaignID       int64
+	IDs              []int64
+	WithoutDeleted   bool
+	PublicationState *campaigns.ChangesetPublicationState
+	// OnlyPublished only includes changesets that have been created on the
+	// code host, either as a draft or not.
+	OnlyPublished        bool
 	ReconcilerStates     []campaigns.ReconcilerState
 	ExternalState        *campaigns.ChangesetExternalState
 	ExternalReviewState  *campaigns.ChangesetReviewState
@@ -450,6 +466,9 @@ func listChangesetsQuery(opts *ListChangesetsOpts) *sqlf.Query {
 	if opts.PublicationState != nil {
 		preds = append(preds, sqlf.Sprintf("changesets.publication_state = %s", *opts.PublicationState))
 	}
+	if opts.OnlyPublished {
+		preds = append(preds, sqlf.Sprintf("changesets.publication_state IN (%s, %s)", campaigns.ChangesetPublicationStatePublished, campaigns.ChangesetPublicationStateDraft))
+	}
 	if len(opts.ReconcilerStates) != 0 {
 		states := make([]*sqlf.Query, len(opts.ReconcilerStates))
 		for i, reconcilerState := range opts.ReconcilerStates {
@@ -498,8 +517,48 @@ func (s *Store) UpdateChangeset(ctx context.Context, cs *campaigns.Changeset) er
 var updateChangesetQueryFmtstr = `
 -- source: enterprise/internal/campaigns/store_changesets.go:UpdateChangeset
 UPDATE changesets
-SET (%s) = (%s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s)
+SET (%s) = (%s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s)
+WHERE id = %s
+RETURNING
+  %s
+`
+
+// UpdateChangesetRebaseState updates only the RebaseState and RebaseBaseRev
+// of the given Changeset, so that it doesn't overwrite changes made by the
+// reconciler in the meantime. The update is skipped and ErrNoResults is
+// returned if the CurrentSpecID of the Changeset has changed since it was
+// loaded.
+func (s *Store) UpdateChangesetRebaseState(ctx context.Context, cs *campaigns.Changeset) error {
+	q := sqlf.Sprintf(
+		updateChangesetRebaseStateQueryFmtstr,
+		nullStringColumn(string(cs.RebaseState)),
+		nullStringColumn(cs.RebaseBaseRev),
+		s.now(),
+		cs.ID,
+		nullInt64Column(cs.CurrentSpecID),
+		sqlf.Join(changesetColumns, ", "),
+	)
+
+	var updated bool
+	err := s.query(ctx, q, func(sc scanner) (err error) {
+		updated = true
+		return scanChangeset(cs, sc)
+	})
+	if err != nil {
+		return err
+	}
+	if !updated {
+		return ErrNoResults
+	}
+	return nil
+}
+
+var updateChangesetRebaseStateQueryFmtstr = `
+-- source: enterprise/internal/campaigns/store_changesets.go:UpdateChangesetRebaseState
+UPDATE changesets
+SET (rebase_state, rebase_base_rev, updated_at) = (%s, %s, %s)
 WHERE id = %s
+AND current_spec_id IS NOT DISTINCT FROM %s
 RETURNING
   %s
 `
@@ -646,6 +705,7 @@ func scanChangeset(t *campaigns.Changeset, s scanner) error {
 		externalCheckState  string
 		failureMessage      string
 		reconcilerState     string
+		rebaseState         string
 	)
 	err := s.Scan(
 		&t.ID,
@@ -681,6 +741,8 @@ func scanChangeset(t *campaigns.Changeset, s scanner) error {
 		&t.NumFailures,
 		&t.Unsynced,
 		&t.Closing,
+		&dbutil.NullString{S: &rebaseState},
+		&dbutil.NullString{S: &t.RebaseBaseRev},
 	)
 	if err != nil {
 		return errors.Wrap(err, "scanning changeset")
@@ -693,6 +755,7 @@ func scanChangeset(t *campaigns.Changeset, s scanner) error {
 		t.FailureMessage = &failureMessage
 	}
 	t.ReconcilerState = campaigns.ReconcilerState(strings.ToUpper(reconcilerState))
+	t.RebaseState = campaigns.ChangesetRebaseState(rebaseState)
 
 	switch t.ExternalServiceType {
 	case extsvc.TypeGitHub:
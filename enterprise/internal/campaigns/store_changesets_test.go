@@ -108,6 +108,9 @@ func testStoreChangesets(t *testing.T, ctx context.Context, s *Store, reposStore
 				th.StartedAt = clock.now()
 				th.FinishedAt = clock.now()
 				th.ProcessAfter = clock.now()
+
+				th.RebaseState = cmpgn.ChangesetRebaseStateUpToDate
+				th.RebaseBaseRev = fmt.Sprintf("rebase-base-rev-%d", i)
 			}
 
 			if err := s.CreateChangeset(ctx, th); err != nil {
@@ -795,6 +798,50 @@ func testStoreChangesets(t *testing.T, ctx context.Context, s *Store, reposStore
 		}
 	})
 
+	t.Run("UpdateChangesetRebaseState", func(t *testing.T) {
+		c := changesets[0]
+
+		clock.add(1 * time.Second)
+		have := c.Clone()
+		have.RebaseState = cmpgn.ChangesetRebaseStateConflicting
+		have.RebaseBaseRev = "d34db33f"
+		// Changes to other fields must not be persisted.
+		have.NumFailures = 1234
+
+		if err := s.UpdateChangesetRebaseState(ctx, have); err != nil {
+			t.Fatal(err)
+		}
+
+		want := c.Clone()
+		want.RebaseState = cmpgn.ChangesetRebaseStateConflicting
+		want.RebaseBaseRev = "d34db33f"
+		want.UpdatedAt = clock.now()
+		if diff := cmp.Diff(have, want); diff != "" {
+			t.Fatal(diff)
+		}
+
+		// The update is skipped if a new spec has been applied.
+		outdated := want.Clone()
+		outdated.CurrentSpecID = outdated.CurrentSpecID + 1
+		outdated.RebaseState = cmpgn.ChangesetRebaseStateUpToDate
+		if err := s.UpdateChangesetRebaseState(ctx, outdated); err != ErrNoResults {
+			t.Fatalf("wrong error. want=%s, have=%v", ErrNoResults, err)
+		}
+
+		reloaded, err := s.GetChangeset(ctx, GetChangesetOpts{ID: c.ID})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(reloaded, want); diff != "" {
+			t.Fatal(diff)
+		}
+
+		// Reset the changeset for the following tests.
+		if err := s.UpdateChangeset(ctx, c); err != nil {
+			t.Fatal(err)
+		}
+	})
+
 	t.Run("CancelQueuedCampaignChangesets", func(t *testing.T) {
 		var campaignID int64 = 99999
 
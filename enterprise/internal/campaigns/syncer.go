@@ -491,7 +491,7 @@ func (s *ChangesetSyncer) prioritizeChangesetsWithoutDiffStats(ctx context.Conte
 	ids := make([]int64, 0, len(changesets))
 	for _, cs := range changesets {
 		// TODO: This needs to go into ListChangesetsOpts
-		if cs.PublicationState != campaigns.ChangesetPublicationStatePublished ||
+		if !cs.Published() ||
 			cs.ReconcilerState != campaigns.ReconcilerStateCompleted {
 			continue
 		}
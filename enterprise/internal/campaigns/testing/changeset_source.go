@@ -6,6 +6,7 @@ import (
 
 	"github.com/pkg/errors"
 	"github.com/sourcegraph/sourcegraph/cmd/repo-updater/repos"
+	"github.com/sourcegraph/sourcegraph/internal/campaigns"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver/protocol"
 )
 
@@ -14,12 +15,17 @@ import (
 type FakeChangesetSource struct {
 	Svc *repos.ExternalService
 
-	CreateChangesetCalled  bool
-	UpdateChangesetCalled  bool
-	ListReposCalled        bool
-	ExternalServicesCalled bool
-	LoadChangesetsCalled   bool
-	CloseChangesetCalled   bool
+	CreateChangesetCalled      bool
+	CreateDraftChangesetCalled bool
+	UndraftChangesetCalled     bool
+	UpdateChangesetCalled      bool
+	ListReposCalled            bool
+	ExternalServicesCalled     bool
+	LoadChangesetsCalled       bool
+	CloseChangesetCalled       bool
+	CreateCommentCalled        bool
+	MergeChangesetCalled       bool
+	ListChangesetsCalled       bool
 
 	// The Changeset.HeadRef to be expected in CreateChangeset/UpdateChangeset calls.
 	WantHeadRef string
@@ -42,11 +48,32 @@ type FakeChangesetSource struct {
 
 	// LoadedChangesets contains the changesets that were passed to LoadChangesets
 	LoadedChangesets []*repos.Changeset
+
+	// Comments contains the comments that were passed to CreateComment
+	Comments []string
+
+	// MergedChangesets contains the changesets that were passed to MergeChangeset
+	MergedChangesets []*repos.Changeset
+
+	// ListedMetadata is the metadata of the changesets returned by
+	// ListChangesets.
+	ListedMetadata []interface{}
+
+	// ListChangesetsOpts contains the options that were passed to ListChangesets
+	ListChangesetsOpts []repos.ListChangesetsOpts
 }
 
 func (s *FakeChangesetSource) CreateChangeset(ctx context.Context, c *repos.Changeset) (bool, error) {
 	s.CreateChangesetCalled = true
+	return s.createChangeset(c)
+}
+
+func (s *FakeChangesetSource) CreateDraftChangeset(ctx context.Context, c *repos.Changeset) (bool, error) {
+	s.CreateDraftChangesetCalled = true
+	return s.createChangeset(c)
+}
 
+func (s *FakeChangesetSource) createChangeset(c *repos.Changeset) (bool, error) {
 	if s.Err != nil {
 		return s.ChangesetExists, s.Err
 	}
@@ -87,6 +114,19 @@ func (s *FakeChangesetSource) UpdateChangeset(ctx context.Context, c *repos.Chan
 	return c.SetMetadata(s.FakeMetadata)
 }
 
+func (s *FakeChangesetSource) UndraftChangeset(ctx context.Context, c *repos.Changeset) error {
+	s.UndraftChangesetCalled = true
+
+	if s.Err != nil {
+		return s.Err
+	}
+	if c.Repo == nil {
+		return NoReposErr
+	}
+
+	return c.SetMetadata(s.FakeMetadata)
+}
+
 var fakeNotImplemented = errors.New("not implemented in FakeChangesetSource")
 
 func (s *FakeChangesetSource) ListRepos(ctx context.Context, results chan repos.SourceResult) {
@@ -138,6 +178,36 @@ func (s *FakeChangesetSource) CloseChangeset(ctx context.Context, c *repos.Chang
 	return nil
 }
 
+func (s *FakeChangesetSource) CreateComment(ctx context.Context, c *repos.Changeset, text string) error {
+	s.CreateCommentCalled = true
+
+	if s.Err != nil {
+		return s.Err
+	}
+
+	if c.Repo == nil {
+		return NoReposErr
+	}
+
+	s.Comments = append(s.Comments, text)
+	return nil
+}
+
+func (s *FakeChangesetSource) MergeChangeset(ctx context.Context, c *repos.Changeset) error {
+	s.MergeChangesetCalled = true
+
+	if s.Err != nil {
+		return s.Err
+	}
+
+	if c.Repo == nil {
+		return NoReposErr
+	}
+
+	s.MergedChangesets = append(s.MergedChangesets, c)
+	return nil
+}
+
 // FakeGitserverClient is a test implementation of the GitserverClient
 // interface required by ExecChangesetJob.
 type FakeGitserverClient struct {
@@ -145,9 +215,31 @@ type FakeGitserverClient struct {
 	ResponseErr error
 
 	CreateCommitFromPatchCalled bool
+	CreateCommitFromPatchReq    *protocol.CreateCommitFromPatchRequest
 }
 
 func (f *FakeGitserverClient) CreateCommitFromPatch(ctx context.Context, req protocol.CreateCommitFromPatchRequest) (string, error) {
 	f.CreateCommitFromPatchCalled = true
+	f.CreateCommitFromPatchReq = &req
 	return f.Response, f.ResponseErr
 }
+
+func (s *FakeChangesetSource) ListChangesets(ctx context.Context, r *repos.Repo, opts repos.ListChangesetsOpts) ([]*repos.Changeset, error) {
+	s.ListChangesetsCalled = true
+
+	if s.Err != nil {
+		return nil, s.Err
+	}
+
+	s.ListChangesetsOpts = append(s.ListChangesetsOpts, opts)
+
+	cs := make([]*repos.Changeset, 0, len(s.ListedMetadata))
+	for _, meta := range s.ListedMetadata {
+		c := &repos.Changeset{Changeset: &campaigns.Changeset{RepoID: r.ID}, Repo: r}
+		if err := c.SetMetadata(meta); err != nil {
+			return nil, err
+		}
+		cs = append(cs, c)
+	}
+	return cs, nil
+}
@@ -335,6 +335,10 @@ func (h *GitHubWebhook) convertEvent(ctx context.Context, externalServiceID stri
 			ours = h.closedOrMergeEvent(e)
 		case "reopened":
 			ours = h.reopenedEvent(e)
+		case "converted_to_draft":
+			ours = h.convertToDraftEvent(e)
+		case "ready_for_review":
+			ours = h.readyForReviewEvent(e)
 		case "labeled", "unlabeled":
 			ours = h.labeledEvent(e)
 		}
@@ -695,6 +699,38 @@ func (*GitHubWebhook) reopenedEvent(e *gh.PullRequestEvent) *github.ReopenedEven
 	return event
 }
 
+func (*GitHubWebhook) convertToDraftEvent(e *gh.PullRequestEvent) *github.ConvertToDraftEvent {
+	event := &github.ConvertToDraftEvent{}
+
+	if s := e.GetSender(); s != nil {
+		event.Actor.AvatarURL = s.GetAvatarURL()
+		event.Actor.Login = s.GetLogin()
+		event.Actor.URL = s.GetURL()
+	}
+
+	if pr := e.GetPullRequest(); pr != nil {
+		event.CreatedAt = pr.GetUpdatedAt()
+	}
+
+	return event
+}
+
+func (*GitHubWebhook) readyForReviewEvent(e *gh.PullRequestEvent) *github.ReadyForReviewEvent {
+	event := &github.ReadyForReviewEvent{}
+
+	if s := e.GetSender(); s != nil {
+		event.Actor.AvatarURL = s.GetAvatarURL()
+		event.Actor.Login = s.GetLogin()
+		event.Actor.URL = s.GetURL()
+	}
+
+	if pr := e.GetPullRequest(); pr != nil {
+		event.CreatedAt = pr.GetUpdatedAt()
+	}
+
+	return event
+}
+
 func (*GitHubWebhook) pullRequestReviewEvent(e *gh.PullRequestReviewEvent) *github.PullRequestReview {
 	review := &github.PullRequestReview{}
 
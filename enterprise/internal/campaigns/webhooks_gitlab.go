@@ -2,6 +2,9 @@ package campaigns
 
 import (
 	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
 	"fmt"
 	"io/ioutil"
 	"net/http"
@@ -15,14 +18,36 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitlab"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitlab/webhooks"
+	"github.com/sourcegraph/sourcegraph/internal/rcache"
 	"github.com/sourcegraph/sourcegraph/internal/repoupdater"
 	"github.com/sourcegraph/sourcegraph/schema"
 )
 
-type GitLabWebhook struct{ *Webhook }
+type GitLabWebhook struct {
+	*Webhook
+
+	// deliveries records the payloads of the webhooks that have been handled
+	// successfully, so that redeliveries can be skipped.
+	deliveries deliveryCache
+}
+
+// deliveryCache is the subset of the rcache.Cache methods used to record
+// webhook deliveries.
+type deliveryCache interface {
+	Get(key string) ([]byte, bool)
+	Set(key string, b []byte)
+}
 
 func NewGitLabWebhook(store *Store, repos repos.Store, now func() time.Time) *GitLabWebhook {
-	return &GitLabWebhook{&Webhook{store, repos, now, extsvc.TypeGitLab}}
+	return &GitLabWebhook{
+		Webhook: &Webhook{store, repos, now, extsvc.TypeGitLab},
+		// GitLab doesn't include a delivery ID in its webhooks, but redelivered
+		// payloads are identical to the original, whereas distinct events
+		// differ in at least their timestamps, so we key deliveries by the
+		// hash of the payload. GitLab gives up on redeliveries well within an
+		// hour.
+		deliveries: rcache.NewWithTTL("campaigns:gitlab-webhook-deliveries", 60*60),
+	}
 }
 
 // ServeHTTP implements the http.Handler interface.
@@ -59,6 +84,16 @@ func (h *GitLabWebhook) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// GitLab redelivers webhooks that it considers to have failed, which
+	// includes ones that merely timed out, so we skip payloads that we've
+	// already handled successfully.
+	deliveryKey := gitLabDeliveryKey(extSvc.ID, payload)
+	if _, ok := h.deliveries.Get(deliveryKey); ok {
+		log15.Debug("ignoring redelivered GitLab webhook", "key", deliveryKey)
+		respond(w, http.StatusNoContent, nil)
+		return
+	}
+
 	event, err := webhooks.UnmarshalEvent(payload)
 	if err != nil {
 		if errors.Is(err, webhooks.ErrObjectKindUnknown) {
@@ -82,14 +117,21 @@ func (h *GitLabWebhook) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	if err := h.handleEvent(r.Context(), extSvc, event); err != nil {
 		respond(w, err.code, err)
 	} else {
+		h.deliveries.Set(deliveryKey, []byte{})
 		respond(w, http.StatusNoContent, nil)
 	}
 }
 
+func gitLabDeliveryKey(externalServiceID int64, payload []byte) string {
+	sum := sha256.Sum256(payload)
+	return fmt.Sprintf("%d:%s", externalServiceID, hex.EncodeToString(sum[:]))
+}
+
 var (
 	errExternalServiceNotFound     = errors.New("external service not found")
 	errExternalServiceWrongKind    = errors.New("external service is not of the expected kind")
 	errPipelineMissingMergeRequest = errors.New("pipeline event does not include a merge request")
+	errNoteMissingMergeRequest     = errors.New("note event does not include a merge request")
 )
 
 // getExternalServiceFromRawID retrieves the external service matching the
@@ -141,26 +183,43 @@ func (h *GitLabWebhook) handleEvent(ctx context.Context, extSvc *repos.ExternalS
 	}
 
 	switch e := event.(type) {
-	// Some merge request event types require us to do a full resync.
-	//
-	// For example, approvals and unapprovals manifest in normal syncs as
-	// system notes, but we _don't_ get them as note events in webhooks.
-	// Instead, we get a merge request webhook with an "approved" or
-	// "unapproved" action field that magically appears in the object (merge
-	// request) attributes and no further details on who changed the approval
-	// status, the note ID, or anything else we can use to deduplicate later.
-	//
-	// Similarly, for update events, we don't get the full set of fields that
-	// we get when we sync using the REST API (presumably because this reflects
-	// the data types at the point webhooks were added to GitLab several years
-	// ago, and not today): labels come in a different format outside of the
-	// merge request, and we'd still have to go query for notes and pipelines.
+	// Approvals and unapprovals manifest in normal syncs as system notes, but
+	// we _don't_ get them as note events in webhooks. Instead, we get a merge
+	// request webhook with an "approved" or "unapproved" action field and no
+	// note ID. We convert them into the same review types that we derive from
+	// the system notes, keyed by the approving user and the time of the
+	// approval, so that the review state is updated immediately. Since the
+	// review state is computed per author, the events created by the next sync
+	// for the same approval don't change the outcome.
+	case *webhooks.MergeRequestApprovedEvent:
+		if err := h.handleMergeRequestReviewEvent(ctx, esID, e.ToEvent(), e.ToReview()); err != nil {
+			return &httpError{
+				code: http.StatusInternalServerError,
+				err:  err,
+			}
+		}
+		return nil
+
+	case *webhooks.MergeRequestUnapprovedEvent:
+		if err := h.handleMergeRequestReviewEvent(ctx, esID, e.ToEvent(), e.ToReview()); err != nil {
+			return &httpError{
+				code: http.StatusInternalServerError,
+				err:  err,
+			}
+		}
+		return nil
+
+	// For update events, we don't get the full set of fields that we get when
+	// we sync using the REST API (presumably because this reflects the data
+	// types at the point webhooks were added to GitLab several years ago, and
+	// not today): we'd still have to go query for notes and pipelines.
 	//
-	// Therefore, the only realistic action we can take here is to re-sync the
-	// changeset as a whole. The problem is that — since we only have the merge
-	// request — this requires three requests to the REST API, and GitLab's
-	// documentation is quite clear that webhooks should run as fast as possible
-	// to avoid unexpected retries.
+	// Label changes are described fully by the changes in the payload, so we
+	// store them as label events. For any other change, the only realistic
+	// action we can take is to re-sync the changeset as a whole. The problem is
+	// that — since we only have the merge request — this requires three
+	// requests to the REST API, and GitLab's documentation is quite clear that
+	// webhooks should run as fast as possible to avoid unexpected retries.
 	//
 	// To meet this goal, rather than synchronously synchronizing here, we'll
 	// instead ask repo-updater to prioritize the sync of this changeset and let
@@ -169,10 +228,8 @@ func (h *GitLabWebhook) handleEvent(ctx context.Context, extSvc *repos.ExternalS
 	// changeset state won't appear _quite_ as instantaneously to the user, but
 	// this is the best compromise given the limited payload we get in the
 	// webhook.
-	case *webhooks.MergeRequestApprovedEvent,
-		*webhooks.MergeRequestUnapprovedEvent,
-		*webhooks.MergeRequestUpdateEvent:
-		if err := h.enqueueChangesetSyncFromEvent(ctx, esID, e.(webhooks.MergeRequestEventContainer).ToEvent()); err != nil {
+	case *webhooks.MergeRequestUpdateEvent:
+		if err := h.handleMergeRequestUpdateEvent(ctx, esID, e); err != nil {
 			return &httpError{
 				code: http.StatusInternalServerError,
 				err:  err,
@@ -198,6 +255,15 @@ func (h *GitLabWebhook) handleEvent(ctx context.Context, extSvc *repos.ExternalS
 			}
 		}
 		return nil
+
+	case *webhooks.NoteEvent:
+		if err := h.handleNoteEvent(ctx, esID, e); err != nil && err != errNoteMissingMergeRequest {
+			return &httpError{
+				code: http.StatusInternalServerError,
+				err:  err,
+			}
+		}
+		return nil
 	}
 
 	// We don't want to return a non-2XX status code and have GitLab retry the
@@ -237,6 +303,58 @@ func (h *GitLabWebhook) handleMergeRequestStateEvent(ctx context.Context, esID s
 	return nil
 }
 
+func (h *GitLabWebhook) handleMergeRequestReviewEvent(ctx context.Context, esID string, e *webhooks.MergeRequestEventCommon, review keyer) error {
+	pr := gitlabToPR(&e.Project, e.MergeRequest)
+	if err := h.upsertChangesetEvent(ctx, esID, pr, review); err != nil {
+		return errors.Wrap(err, "upserting changeset event")
+	}
+	return nil
+}
+
+func (h *GitLabWebhook) handleMergeRequestUpdateEvent(ctx context.Context, esID string, event *webhooks.MergeRequestUpdateEvent) error {
+	e := event.ToEvent()
+	pr := gitlabToPR(&e.Project, e.MergeRequest)
+	for _, le := range event.LabelEvents() {
+		if err := h.upsertChangesetEvent(ctx, esID, pr, le); err != nil {
+			return errors.Wrap(err, "upserting changeset event")
+		}
+	}
+
+	if event.OnlyLabelsChanged() {
+		return nil
+	}
+	return h.enqueueChangesetSyncFromEvent(ctx, esID, e)
+}
+
+func (h *GitLabWebhook) handleNoteEvent(ctx context.Context, esID string, event *webhooks.NoteEvent) error {
+	// Note events are sent for comments on commits, issues and snippets, too,
+	// but we only care about the ones made on merge requests.
+	if event.MergeRequest == nil {
+		log15.Debug("ignoring note event without a merge request", "payload", event)
+		return errNoteMissingMergeRequest
+	}
+
+	// GitLab doesn't currently send webhooks for system notes, but in case it
+	// does, we want to convert them the same way we do when syncing.
+	note := event.ToNote()
+	var ev keyer
+	if review := note.ToReview(); review != nil {
+		ev = review.(keyer)
+	} else if wip := note.ToWorkInProgressEvent(); wip != nil {
+		ev = wip.(keyer)
+	} else if !note.System {
+		ev = note
+	} else {
+		return nil
+	}
+
+	pr := gitlabToPR(&event.Project, event.MergeRequest)
+	if err := h.upsertChangesetEvent(ctx, esID, pr, ev); err != nil {
+		return errors.Wrap(err, "upserting changeset event")
+	}
+	return nil
+}
+
 func (h *GitLabWebhook) handlePipelineEvent(ctx context.Context, esID string, event *webhooks.PipelineEvent) error {
 	// Pipeline webhook payloads don't include the merge request very reliably:
 	// for example, re-running a pipeline from the GitLab UI will result in no
@@ -295,8 +413,11 @@ func validateGitLabSecret(extSvc *repos.ExternalService, secret string) (bool, e
 	// Iterate over the webhooks and look for one with the right secret. The
 	// number of webhooks in an external service should be small enough that a
 	// linear search like this is sufficient.
+	//
+	// 🚨 SECURITY: We compare in constant time to avoid leaking the secret
+	// through timing attacks.
 	for _, webhook := range config.Webhooks {
-		if webhook.Secret == secret {
+		if subtle.ConstantTimeCompare([]byte(webhook.Secret), []byte(secret)) == 1 {
 			return true, nil
 		}
 	}
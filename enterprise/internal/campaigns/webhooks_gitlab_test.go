@@ -34,7 +34,7 @@ func testGitLabWebhook(db *sql.DB, userID int32) func(*testing.T) {
 		t.Run("ServeHTTP", func(t *testing.T) {
 			t.Run("missing external service", func(t *testing.T) {
 				store, rstore, clock := gitLabTestSetup(t, db)
-				h := NewGitLabWebhook(store, rstore, clock.now)
+				h := newTestGitLabWebhook(store, rstore, clock.now)
 
 				u := extsvc.WebhookURL(extsvc.TypeGitLab, 12345, "https://example.com/")
 				req, err := http.NewRequest("POST", u, nil)
@@ -52,7 +52,7 @@ func testGitLabWebhook(db *sql.DB, userID int32) func(*testing.T) {
 
 			t.Run("invalid external service", func(t *testing.T) {
 				store, rstore, clock := gitLabTestSetup(t, db)
-				h := NewGitLabWebhook(store, rstore, clock.now)
+				h := newTestGitLabWebhook(store, rstore, clock.now)
 
 				u := strings.ReplaceAll(extsvc.WebhookURL(extsvc.TypeGitLab, 12345, "https://example.com/"), "12345", "foo")
 				req, err := http.NewRequest("POST", u, nil)
@@ -72,7 +72,7 @@ func testGitLabWebhook(db *sql.DB, userID int32) func(*testing.T) {
 
 			t.Run("malformed external service", func(t *testing.T) {
 				store, rstore, clock := gitLabTestSetup(t, db)
-				h := NewGitLabWebhook(store, rstore, clock.now)
+				h := newTestGitLabWebhook(store, rstore, clock.now)
 				es := createGitLabExternalService(t, ctx, rstore)
 
 				es.Config = "invalid JSON"
@@ -99,7 +99,7 @@ func testGitLabWebhook(db *sql.DB, userID int32) func(*testing.T) {
 
 			t.Run("missing secret", func(t *testing.T) {
 				store, rstore, clock := gitLabTestSetup(t, db)
-				h := NewGitLabWebhook(store, rstore, clock.now)
+				h := newTestGitLabWebhook(store, rstore, clock.now)
 				es := createGitLabExternalService(t, ctx, rstore)
 
 				u := extsvc.WebhookURL(extsvc.TypeGitLab, es.ID, "https://example.com/")
@@ -120,7 +120,7 @@ func testGitLabWebhook(db *sql.DB, userID int32) func(*testing.T) {
 
 			t.Run("incorrect secret", func(t *testing.T) {
 				store, rstore, clock := gitLabTestSetup(t, db)
-				h := NewGitLabWebhook(store, rstore, clock.now)
+				h := newTestGitLabWebhook(store, rstore, clock.now)
 				es := createGitLabExternalService(t, ctx, rstore)
 
 				u := extsvc.WebhookURL(extsvc.TypeGitLab, es.ID, "https://example.com/")
@@ -142,7 +142,7 @@ func testGitLabWebhook(db *sql.DB, userID int32) func(*testing.T) {
 
 			t.Run("missing body", func(t *testing.T) {
 				store, rstore, clock := gitLabTestSetup(t, db)
-				h := NewGitLabWebhook(store, rstore, clock.now)
+				h := newTestGitLabWebhook(store, rstore, clock.now)
 				es := createGitLabExternalService(t, ctx, rstore)
 
 				u := extsvc.WebhookURL(extsvc.TypeGitLab, es.ID, "https://example.com/")
@@ -164,7 +164,7 @@ func testGitLabWebhook(db *sql.DB, userID int32) func(*testing.T) {
 
 			t.Run("unreadable body", func(t *testing.T) {
 				store, rstore, clock := gitLabTestSetup(t, db)
-				h := NewGitLabWebhook(store, rstore, clock.now)
+				h := newTestGitLabWebhook(store, rstore, clock.now)
 				es := createGitLabExternalService(t, ctx, rstore)
 
 				u := extsvc.WebhookURL(extsvc.TypeGitLab, es.ID, "https://example.com/")
@@ -187,7 +187,7 @@ func testGitLabWebhook(db *sql.DB, userID int32) func(*testing.T) {
 
 			t.Run("malformed body", func(t *testing.T) {
 				store, rstore, clock := gitLabTestSetup(t, db)
-				h := NewGitLabWebhook(store, rstore, clock.now)
+				h := newTestGitLabWebhook(store, rstore, clock.now)
 				es := createGitLabExternalService(t, ctx, rstore)
 
 				u := extsvc.WebhookURL(extsvc.TypeGitLab, es.ID, "https://example.com/")
@@ -209,7 +209,7 @@ func testGitLabWebhook(db *sql.DB, userID int32) func(*testing.T) {
 
 			t.Run("invalid body", func(t *testing.T) {
 				store, rstore, clock := gitLabTestSetup(t, db)
-				h := NewGitLabWebhook(store, rstore, clock.now)
+				h := newTestGitLabWebhook(store, rstore, clock.now)
 				es := createGitLabExternalService(t, ctx, rstore)
 
 				u := extsvc.WebhookURL(extsvc.TypeGitLab, es.ID, "https://example.com/")
@@ -233,7 +233,7 @@ func testGitLabWebhook(db *sql.DB, userID int32) func(*testing.T) {
 
 			t.Run("error from handleEvent", func(t *testing.T) {
 				store, rstore, clock := gitLabTestSetup(t, db)
-				h := NewGitLabWebhook(store, rstore, clock.now)
+				h := newTestGitLabWebhook(store, rstore, clock.now)
 				es := createGitLabExternalService(t, ctx, rstore)
 				repo := createGitLabRepo(t, ctx, rstore, es)
 				changeset := createGitLabChangeset(t, ctx, store, repo)
@@ -276,14 +276,17 @@ func testGitLabWebhook(db *sql.DB, userID int32) func(*testing.T) {
 			// seems fair.)
 
 			t.Run("valid merge request approval events", func(t *testing.T) {
-				for _, action := range []string{"approved", "unapproved"} {
+				for action, want := range map[string]campaigns.ChangesetEventKind{
+					"approved":   campaigns.ChangesetEventKindGitLabApproved,
+					"unapproved": campaigns.ChangesetEventKindGitLabUnapproved,
+				} {
 					t.Run(action, func(t *testing.T) {
 						store, rstore, clock := gitLabTestSetup(t, db)
-						h := NewGitLabWebhook(store, rstore, clock.now)
+						h := newTestGitLabWebhook(store, rstore, clock.now)
 						es := createGitLabExternalService(t, ctx, rstore)
 						repo := createGitLabRepo(t, ctx, rstore, es)
 						changeset := createGitLabChangeset(t, ctx, store, repo)
-						body := createMergeRequestPayload(t, repo, changeset, "approved")
+						body := createMergeRequestPayload(t, repo, changeset, action)
 
 						u := extsvc.WebhookURL(extsvc.TypeGitLab, es.ID, "https://example.com/")
 						req, err := http.NewRequest("POST", u, bytes.NewBufferString(body))
@@ -292,16 +295,6 @@ func testGitLabWebhook(db *sql.DB, userID int32) func(*testing.T) {
 						}
 						req.Header.Add(webhooks.TokenHeaderName, "secret")
 
-						changesetEnqueued := false
-						repoupdater.MockEnqueueChangesetSync = func(ctx context.Context, ids []int64) error {
-							changesetEnqueued = true
-							if diff := cmp.Diff(ids, []int64{changeset.ID}); diff != "" {
-								t.Errorf("unexpected changeset ID: %s", diff)
-							}
-							return nil
-						}
-						defer func() { repoupdater.MockEnqueueChangesetSync = nil }()
-
 						rec := httptest.NewRecorder()
 						h.ServeHTTP(rec, req)
 
@@ -309,13 +302,101 @@ func testGitLabWebhook(db *sql.DB, userID int32) func(*testing.T) {
 						if have, want := resp.StatusCode, http.StatusNoContent; have != want {
 							t.Errorf("unexpected status code: have %d; want %d", have, want)
 						}
-						if !changesetEnqueued {
-							t.Error("changeset was not enqueued")
-						}
+
+						// Verify that the review was upserted as a changeset
+						// event.
+						assertChangesetEventForChangeset(t, ctx, store, changeset, want)
 					})
 				}
 			})
 
+			t.Run("valid merge request label update events", func(t *testing.T) {
+				store, rstore, clock := gitLabTestSetup(t, db)
+				h := newTestGitLabWebhook(store, rstore, clock.now)
+				es := createGitLabExternalService(t, ctx, rstore)
+				repo := createGitLabRepo(t, ctx, rstore, es)
+				changeset := createGitLabChangeset(t, ctx, store, repo)
+				body := createMergeRequestUpdatePayload(t, repo, changeset, map[string]interface{}{
+					"labels": map[string]interface{}{
+						"previous": []interface{}{},
+						"current": []interface{}{
+							map[string]interface{}{"id": 1, "title": "bug", "color": "#ff0000"},
+						},
+					},
+					"updated_at": map[string]interface{}{
+						"previous": "2020-06-01 10:00:00 UTC",
+						"current":  "2020-06-01 11:00:00 UTC",
+					},
+				})
+
+				u := extsvc.WebhookURL(extsvc.TypeGitLab, es.ID, "https://example.com/")
+				req, err := http.NewRequest("POST", u, bytes.NewBufferString(body))
+				if err != nil {
+					t.Fatal(err)
+				}
+				req.Header.Add(webhooks.TokenHeaderName, "secret")
+
+				// Label changes are fully described by the payload, so there's
+				// no need to sync the changeset.
+				repoupdater.MockEnqueueChangesetSync = func(ctx context.Context, ids []int64) error {
+					t.Error("unexpected changeset sync")
+					return nil
+				}
+				defer func() { repoupdater.MockEnqueueChangesetSync = nil }()
+
+				rec := httptest.NewRecorder()
+				h.ServeHTTP(rec, req)
+
+				resp := rec.Result()
+				if have, want := resp.StatusCode, http.StatusNoContent; have != want {
+					t.Errorf("unexpected status code: have %d; want %d", have, want)
+				}
+
+				assertChangesetEventForChangeset(t, ctx, store, changeset, campaigns.ChangesetEventKindGitLabLabeled)
+			})
+
+			t.Run("valid merge request update events", func(t *testing.T) {
+				store, rstore, clock := gitLabTestSetup(t, db)
+				h := newTestGitLabWebhook(store, rstore, clock.now)
+				es := createGitLabExternalService(t, ctx, rstore)
+				repo := createGitLabRepo(t, ctx, rstore, es)
+				changeset := createGitLabChangeset(t, ctx, store, repo)
+				body := createMergeRequestUpdatePayload(t, repo, changeset, map[string]interface{}{
+					"title": map[string]interface{}{
+						"previous": "foo",
+						"current":  "bar",
+					},
+				})
+
+				u := extsvc.WebhookURL(extsvc.TypeGitLab, es.ID, "https://example.com/")
+				req, err := http.NewRequest("POST", u, bytes.NewBufferString(body))
+				if err != nil {
+					t.Fatal(err)
+				}
+				req.Header.Add(webhooks.TokenHeaderName, "secret")
+
+				changesetEnqueued := false
+				repoupdater.MockEnqueueChangesetSync = func(ctx context.Context, ids []int64) error {
+					changesetEnqueued = true
+					if diff := cmp.Diff(ids, []int64{changeset.ID}); diff != "" {
+						t.Errorf("unexpected changeset ID: %s", diff)
+					}
+					return nil
+				}
+				defer func() { repoupdater.MockEnqueueChangesetSync = nil }()
+
+				rec := httptest.NewRecorder()
+				h.ServeHTTP(rec, req)
+
+				resp := rec.Result()
+				if have, want := resp.StatusCode, http.StatusNoContent; have != want {
+					t.Errorf("unexpected status code: have %d; want %d", have, want)
+				}
+				if !changesetEnqueued {
+					t.Error("changeset was not enqueued")
+				}
+			})
+
 			t.Run("valid merge request state change events", func(t *testing.T) {
 				for action, want := range map[string]campaigns.ChangesetEventKind{
 					"close":  campaigns.ChangesetEventKindGitLabClosed,
@@ -324,7 +405,7 @@ func testGitLabWebhook(db *sql.DB, userID int32) func(*testing.T) {
 				} {
 					t.Run(action, func(t *testing.T) {
 						store, rstore, clock := gitLabTestSetup(t, db)
-						h := NewGitLabWebhook(store, rstore, clock.now)
+						h := newTestGitLabWebhook(store, rstore, clock.now)
 						es := createGitLabExternalService(t, ctx, rstore)
 						repo := createGitLabRepo(t, ctx, rstore, es)
 						changeset := createGitLabChangeset(t, ctx, store, repo)
@@ -353,7 +434,7 @@ func testGitLabWebhook(db *sql.DB, userID int32) func(*testing.T) {
 
 			t.Run("valid pipeline events", func(t *testing.T) {
 				store, rstore, clock := gitLabTestSetup(t, db)
-				h := NewGitLabWebhook(store, rstore, clock.now)
+				h := newTestGitLabWebhook(store, rstore, clock.now)
 				es := createGitLabExternalService(t, ctx, rstore)
 				repo := createGitLabRepo(t, ctx, rstore, es)
 				changeset := createGitLabChangeset(t, ctx, store, repo)
@@ -379,13 +460,77 @@ func testGitLabWebhook(db *sql.DB, userID int32) func(*testing.T) {
 
 				assertChangesetEventForChangeset(t, ctx, store, changeset, campaigns.ChangesetEventKindGitLabPipeline)
 			})
+
+			t.Run("valid note events", func(t *testing.T) {
+				store, rstore, clock := gitLabTestSetup(t, db)
+				h := newTestGitLabWebhook(store, rstore, clock.now)
+				es := createGitLabExternalService(t, ctx, rstore)
+				repo := createGitLabRepo(t, ctx, rstore, es)
+				changeset := createGitLabChangeset(t, ctx, store, repo)
+				body := createNotePayload(t, repo, changeset, "LGTM")
+
+				u := extsvc.WebhookURL(extsvc.TypeGitLab, es.ID, "https://example.com/")
+				req, err := http.NewRequest("POST", u, bytes.NewBufferString(body))
+				if err != nil {
+					t.Fatal(err)
+				}
+				req.Header.Add(webhooks.TokenHeaderName, "secret")
+
+				rec := httptest.NewRecorder()
+				h.ServeHTTP(rec, req)
+
+				resp := rec.Result()
+				if have, want := resp.StatusCode, http.StatusNoContent; have != want {
+					t.Errorf("unexpected status code: have %d; want %d", have, want)
+				}
+
+				assertChangesetEventForChangeset(t, ctx, store, changeset, campaigns.ChangesetEventKindGitLabCommented)
+			})
+
+			t.Run("redelivered events", func(t *testing.T) {
+				store, rstore, clock := gitLabTestSetup(t, db)
+				h := newTestGitLabWebhook(store, rstore, clock.now)
+				es := createGitLabExternalService(t, ctx, rstore)
+				repo := createGitLabRepo(t, ctx, rstore, es)
+				changeset := createGitLabChangeset(t, ctx, store, repo)
+				body := createMergeRequestPayload(t, repo, changeset, "update")
+
+				u := extsvc.WebhookURL(extsvc.TypeGitLab, es.ID, "https://example.com/")
+
+				enqueued := 0
+				repoupdater.MockEnqueueChangesetSync = func(ctx context.Context, ids []int64) error {
+					enqueued++
+					return nil
+				}
+				defer func() { repoupdater.MockEnqueueChangesetSync = nil }()
+
+				for i := 0; i < 2; i++ {
+					req, err := http.NewRequest("POST", u, bytes.NewBufferString(body))
+					if err != nil {
+						t.Fatal(err)
+					}
+					req.Header.Add(webhooks.TokenHeaderName, "secret")
+
+					rec := httptest.NewRecorder()
+					h.ServeHTTP(rec, req)
+
+					resp := rec.Result()
+					if have, want := resp.StatusCode, http.StatusNoContent; have != want {
+						t.Errorf("unexpected status code: have %d; want %d", have, want)
+					}
+				}
+
+				if enqueued != 1 {
+					t.Errorf("unexpected number of changeset syncs: have %d; want 1", enqueued)
+				}
+			})
 		})
 
 		t.Run("getExternalServiceFromRawID", func(t *testing.T) {
 			// Since these tests don't write to the database, we can just share
 			// the same database setup.
 			store, rstore, clock := gitLabTestSetup(t, db)
-			h := NewGitLabWebhook(store, rstore, clock.now)
+			h := newTestGitLabWebhook(store, rstore, clock.now)
 
 			// Set up two GitLab external services.
 			a := createGitLabExternalService(t, ctx, rstore)
@@ -454,7 +599,7 @@ func testGitLabWebhook(db *sql.DB, userID int32) func(*testing.T) {
 			// connection on the repo store.
 			store, _, clock := gitLabTestSetup(t, db)
 			rstore := repos.NewDBStore(&brokenDB{errors.New("foo")}, sql.TxOptions{})
-			h := NewGitLabWebhook(store, rstore, clock.now)
+			h := newTestGitLabWebhook(store, rstore, clock.now)
 
 			_, err := h.getExternalServiceFromRawID(ctx, "12345")
 			if err == nil {
@@ -465,7 +610,7 @@ func testGitLabWebhook(db *sql.DB, userID int32) func(*testing.T) {
 		t.Run("broken campaign store", func(t *testing.T) {
 			// We can induce an error with a broken database connection.
 			store, rstore, clock := gitLabTestSetup(t, db)
-			h := NewGitLabWebhook(store, rstore, clock.now)
+			h := newTestGitLabWebhook(store, rstore, clock.now)
 			h.Store = NewStoreWithClock(&brokenDB{errors.New("foo")}, clock.now)
 
 			es, err := h.getExternalServiceFromRawID(ctx, "12345")
@@ -484,7 +629,7 @@ func testGitLabWebhook(db *sql.DB, userID int32) func(*testing.T) {
 
 			t.Run("unknown event type", func(t *testing.T) {
 				store, rstore, clock := gitLabTestSetup(t, db)
-				h := NewGitLabWebhook(store, rstore, clock.now)
+				h := newTestGitLabWebhook(store, rstore, clock.now)
 				es := createGitLabExternalService(t, ctx, rstore)
 
 				err := h.handleEvent(ctx, es, nil)
@@ -495,12 +640,12 @@ func testGitLabWebhook(db *sql.DB, userID int32) func(*testing.T) {
 
 			t.Run("error from enqueueChangesetSyncFromEvent", func(t *testing.T) {
 				store, rstore, clock := gitLabTestSetup(t, db)
-				h := NewGitLabWebhook(store, rstore, clock.now)
+				h := newTestGitLabWebhook(store, rstore, clock.now)
 				es := createGitLabExternalService(t, ctx, rstore)
 
 				// We can induce an error with an incomplete merge request
 				// event that's missing a project.
-				event := &webhooks.MergeRequestApprovedEvent{
+				event := &webhooks.MergeRequestUpdateEvent{
 					MergeRequestEventCommon: webhooks.MergeRequestEventCommon{
 						MergeRequest: &gitlab.MergeRequest{IID: 42},
 					},
@@ -516,7 +661,7 @@ func testGitLabWebhook(db *sql.DB, userID int32) func(*testing.T) {
 
 			t.Run("error from handleMergeRequestStateEvent", func(t *testing.T) {
 				store, rstore, clock := gitLabTestSetup(t, db)
-				h := NewGitLabWebhook(store, rstore, clock.now)
+				h := newTestGitLabWebhook(store, rstore, clock.now)
 				es := createGitLabExternalService(t, ctx, rstore)
 
 				event := &webhooks.MergeRequestCloseEvent{
@@ -538,7 +683,7 @@ func testGitLabWebhook(db *sql.DB, userID int32) func(*testing.T) {
 
 			t.Run("error from handlePipelineEvent", func(t *testing.T) {
 				store, rstore, clock := gitLabTestSetup(t, db)
-				h := NewGitLabWebhook(store, rstore, clock.now)
+				h := newTestGitLabWebhook(store, rstore, clock.now)
 				es := createGitLabExternalService(t, ctx, rstore)
 
 				event := &webhooks.PipelineEvent{
@@ -561,7 +706,7 @@ func testGitLabWebhook(db *sql.DB, userID int32) func(*testing.T) {
 			// Since these tests don't write to the database, we can just share
 			// the same database setup.
 			store, rstore, clock := gitLabTestSetup(t, db)
-			h := NewGitLabWebhook(store, rstore, clock.now)
+			h := newTestGitLabWebhook(store, rstore, clock.now)
 			es := createGitLabExternalService(t, ctx, rstore)
 			repo := createGitLabRepo(t, ctx, rstore, es)
 			changeset := createGitLabChangeset(t, ctx, store, repo)
@@ -659,7 +804,7 @@ func testGitLabWebhook(db *sql.DB, userID int32) func(*testing.T) {
 			// error path.
 			store, rstore, clock := gitLabTestSetup(t, db)
 			store = NewStoreWithClock(&noNestingTx{store.DB()}, clock.now)
-			h := NewGitLabWebhook(store, rstore, clock.now)
+			h := newTestGitLabWebhook(store, rstore, clock.now)
 
 			event := &webhooks.MergeRequestCloseEvent{
 				MergeRequestEventCommon: webhooks.MergeRequestEventCommon{
@@ -685,7 +830,7 @@ func testGitLabWebhook(db *sql.DB, userID int32) func(*testing.T) {
 			// error if a transaction is started.
 			store, rstore, clock := gitLabTestSetup(t, db)
 			store = NewStoreWithClock(&noNestingTx{store.DB()}, clock.now)
-			h := NewGitLabWebhook(store, rstore, clock.now)
+			h := newTestGitLabWebhook(store, rstore, clock.now)
 
 			t.Run("missing merge request", func(t *testing.T) {
 				event := &webhooks.PipelineEvent{}
@@ -837,6 +982,24 @@ func (nntx *noNestingTx) BeginTx(ctx context.Context, opts *sql.TxOptions) error
 	return errors.New("foo")
 }
 
+// newTestGitLabWebhook creates a GitLabWebhook that records deliveries in
+// memory rather than in Redis, so that identical payloads sent by different
+// tests aren't treated as redeliveries.
+func newTestGitLabWebhook(store *Store, repos repos.Store, now func() time.Time) *GitLabWebhook {
+	h := NewGitLabWebhook(store, repos, now)
+	h.deliveries = memoryDeliveryCache{}
+	return h
+}
+
+type memoryDeliveryCache map[string][]byte
+
+func (c memoryDeliveryCache) Get(key string) ([]byte, bool) {
+	b, ok := c[key]
+	return b, ok
+}
+
+func (c memoryDeliveryCache) Set(key string, b []byte) { c[key] = b }
+
 // gitLabTestSetup instantiates the stores and a clock for use within tests.
 // Any changes made to the stores will be rolled back after the test is
 // complete.
@@ -970,6 +1133,72 @@ func createMergeRequestPayload(t *testing.T, repo *repos.Repo, changeset *campai
 	})
 }
 
+// createMergeRequestUpdatePayload creates a mock GitLab webhook payload of the
+// merge request object kind with the update action and the given changes.
+func createMergeRequestUpdatePayload(t *testing.T, repo *repos.Repo, changeset *campaigns.Changeset, changes map[string]interface{}) string {
+	cid, err := strconv.Atoi(changeset.ExternalID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pid, err := strconv.Atoi(repo.ExternalRepo.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return marshalJSON(t, map[string]interface{}{
+		"object_kind": "merge_request",
+		"project": map[string]interface{}{
+			"id": pid,
+		},
+		"user": map[string]interface{}{
+			"username": "admin",
+		},
+		"object_attributes": map[string]interface{}{
+			"iid":        cid,
+			"action":     "update",
+			"updated_at": "2020-06-01T11:00:00Z",
+		},
+		"changes": changes,
+	})
+}
+
+// createNotePayload creates a mock GitLab webhook payload of the note object
+// kind for a comment on the given changeset.
+func createNotePayload(t *testing.T, repo *repos.Repo, changeset *campaigns.Changeset, note string) string {
+	cid, err := strconv.Atoi(changeset.ExternalID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pid, err := strconv.Atoi(repo.ExternalRepo.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return marshalJSON(t, map[string]interface{}{
+		"object_kind": "note",
+		"project": map[string]interface{}{
+			"id": pid,
+		},
+		"user": map[string]interface{}{
+			"username": "admin",
+		},
+		"merge_request": map[string]interface{}{
+			"iid": cid,
+			"labels": []interface{}{
+				map[string]interface{}{"id": 1, "title": "bug"},
+			},
+		},
+		"object_attributes": map[string]interface{}{
+			"id":            123,
+			"note":          note,
+			"noteable_type": "MergeRequest",
+			"created_at":    "2020-06-01T11:00:00Z",
+		},
+	})
+}
+
 // createPipelinePayload creates a mock GitLab webhook payload of the pipeline
 // object kind.
 func createPipelinePayload(t *testing.T, repo *repos.Repo, changeset *campaigns.Changeset, pipeline gitlab.Pipeline) string {
@@ -34,7 +34,7 @@ func RunWorkers(
 		NumHandlers: 5,
 		Interval:    5 * time.Second,
 		Metrics: workerutil.WorkerMetrics{
-			HandleOperation: newObservationOperation(),
+			HandleOperation: newObservationOperation("campaigns_reconciler", "Reconciler.Process"),
 		},
 	}
 
@@ -72,7 +72,109 @@ func scanFirstChangesetRecord(rows *sql.Rows, err error) (workerutil.Record, boo
 	return scanFirstChangeset(rows, err)
 }
 
-func newObservationOperation() *observation.Operation {
+// RunExecutorWorkers starts a dbworker.NewWorker that fetches enqueued
+// campaign spec executions from the database and runs the steps of their
+// campaign specs with the given StepRunner.
+func RunExecutorWorkers(
+	ctx context.Context,
+	s *Store,
+	archiveClient ArchiveClient,
+	runner StepRunner,
+) {
+	e := &executor{store: s, archiveClient: archiveClient, runner: runner}
+
+	options := dbworker.WorkerOptions{
+		Handler:     e.HandlerFunc(),
+		NumHandlers: 2,
+		Interval:    5 * time.Second,
+		Metrics: workerutil.WorkerMetrics{
+			HandleOperation: newObservationOperation("campaigns_executor", "Executor.Process"),
+		},
+	}
+
+	workerStore := dbworkerstore.NewStore(s.Handle(), dbworkerstore.StoreOptions{
+		TableName:         "campaign_spec_executions",
+		ColumnExpressions: campaignSpecExecutionColumns,
+		Scan:              scanFirstCampaignSpecExecutionRecord,
+		OrderByExpression: sqlf.Sprintf("campaign_spec_executions.created_at, campaign_spec_executions.id"),
+
+		// Steps can run for a long time, so we give them more leeway than
+		// the reconciler before considering them stalled.
+		StalledMaxAge: 10 * time.Minute,
+		MaxNumResets:  executorMaxNumResets,
+
+		RetryAfter:    30 * time.Second,
+		MaxNumRetries: executorMaxNumRetries,
+	})
+
+	worker := dbworker.NewWorker(ctx, workerStore, options)
+	worker.Start()
+}
+
+// executorMaxNumRetries is the maximum number of attempts the executor makes
+// to run the steps of a campaign spec in a repository when they fail.
+const executorMaxNumRetries = 3
+
+// executorMaxNumResets is the maximum number of attempts the executor makes
+// to run the steps of a campaign spec in a repository when they stall.
+const executorMaxNumResets = 3
+
+func scanFirstCampaignSpecExecutionRecord(rows *sql.Rows, err error) (workerutil.Record, bool, error) {
+	return scanFirstCampaignSpecExecution(rows, err)
+}
+
+// RunBulkProcessorWorkers starts a dbworker.NewWorker that fetches enqueued
+// changeset jobs of bulk operations from the database and applies them to
+// their changesets.
+func RunBulkProcessorWorkers(
+	ctx context.Context,
+	s *Store,
+	sourcer repos.Sourcer,
+) {
+	b := &bulkProcessor{store: s, sourcer: sourcer}
+
+	options := dbworker.WorkerOptions{
+		Handler:     b.HandlerFunc(),
+		NumHandlers: 5,
+		Interval:    5 * time.Second,
+		Metrics: workerutil.WorkerMetrics{
+			HandleOperation: newObservationOperation("campaigns_bulk_processor", "BulkProcessor.Process"),
+		},
+	}
+
+	workerStore := dbworkerstore.NewStore(s.Handle(), dbworkerstore.StoreOptions{
+		TableName:         "changeset_jobs",
+		ColumnExpressions: changesetJobColumns,
+		Scan:              scanFirstChangesetJobRecord,
+		OrderByExpression: sqlf.Sprintf("changeset_jobs.created_at, changeset_jobs.id"),
+
+		StalledMaxAge: 60 * time.Second,
+		MaxNumResets:  bulkProcessorMaxNumResets,
+
+		RetryAfter:    5 * time.Second,
+		MaxNumRetries: bulkProcessorMaxNumRetries,
+	})
+
+	worker := dbworker.NewWorker(ctx, workerStore, options)
+	worker.Start()
+}
+
+// bulkProcessorMaxNumRetries is the maximum number of attempts the bulk
+// processor makes to process a changeset job when it fails. Jobs aren't
+// retried, since operations like posting a comment aren't idempotent and the
+// failure is reported to the user instead.
+const bulkProcessorMaxNumRetries = 0
+
+// bulkProcessorMaxNumResets is the maximum number of attempts the bulk
+// processor makes to process a changeset job when it stalls (process crashes,
+// etc.).
+const bulkProcessorMaxNumResets = 60
+
+func scanFirstChangesetJobRecord(rows *sql.Rows, err error) (workerutil.Record, bool, error) {
+	return scanFirstChangesetJob(rows, err)
+}
+
+func newObservationOperation(metricName, opName string) *observation.Operation {
 	observationContext := &observation.Context{
 		Logger:     log15.Root(),
 		Tracer:     &trace.Tracer{Tracer: opentracing.GlobalTracer()},
@@ -81,13 +183,13 @@ func newObservationOperation() *observation.Operation {
 
 	metrics := metrics.NewOperationMetrics(
 		observationContext.Registerer,
-		"campaigns_reconciler",
+		metricName,
 		metrics.WithLabels("op"),
 		metrics.WithCountHelp("Total number of results returned"),
 	)
 
 	return observationContext.Operation(observation.Op{
-		Name:         "Reconciler.Process",
+		Name:         opName,
 		MetricLabels: []string{"process"},
 		Metrics:      metrics,
 	})
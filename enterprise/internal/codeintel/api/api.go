@@ -30,6 +30,11 @@ type CodeIntelAPI interface {
 	// This may include references from other dumps and repositories.
 	References(ctx context.Context, repositoryID int, commit string, limit int, cursor Cursor) ([]ResolvedLocation, Cursor, bool, error)
 
+	// ReferenceCounts returns the number of locations in each repository that reference the symbol at the
+	// given position from another package. If allVersions is true, references to any version of the package
+	// defining the symbol are counted.
+	ReferenceCounts(ctx context.Context, file string, line, character, uploadID int, allVersions bool) ([]store.RepositoryReferenceCount, error)
+
 	// Hover returns the hover text and range for the symbol at the given position.
 	Hover(ctx context.Context, file string, line, character, uploadID int) (string, bundles.Range, bool, error)
 
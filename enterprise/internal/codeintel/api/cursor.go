@@ -20,15 +20,18 @@ type Cursor struct {
 	Character              int                   // same-dump/same-dump-monikers
 	Monikers               []bundles.MonikerData // same-dump/same-dump-monikers/definition-monikers
 	SkipResults            int                   // same-dump/same-dump-monikers/definition-monikers
-	Identifier             string                // same-repo/remote-repo
-	Scheme                 string                // same-repo/remote-repo
-	Name                   string                // same-repo/remote-repo
-	Version                string                // same-repo/remote-repo
+	Identifier             string                // same-repo/remote-repo-monikers/remote-repo
+	Scheme                 string                // same-repo/remote-repo-monikers/remote-repo
+	Name                   string                // same-repo/remote-repo-monikers/remote-repo
+	Version                string                // same-repo/remote-repo-monikers/remote-repo
 	DumpIDs                []int                 // same-repo/remote-repo
 	TotalDumpsWhenBatching int                   // same-repo/remote-repo
 	SkipDumpsWhenBatching  int                   // same-repo/remote-repo
 	SkipDumpsInBatch       int                   // same-repo/remote-repo
-	SkipResultsInDump      int                   // same-repo/remote-repo
+	SkipResultsInDump      int                   // same-repo/remote-repo-monikers/remote-repo
+	AllVersions            bool                  // common
+	AfterRepositoryID      int                   // remote-repo-monikers
+	AfterDumpID            int                   // remote-repo-monikers
 }
 
 // EncodeCursor returns an encoding of the given cursor suitable for a URL.
@@ -50,8 +53,9 @@ func decodeCursor(rawEncoded string) (Cursor, error) {
 }
 
 // DecodeOrCreateCursor decodes and returns the raw cursor, or creates a new initial page cursor
-// if a raw cursor is not supplied.
-func DecodeOrCreateCursor(path string, line, character, uploadID int, rawCursor string, store store.Store, bundleManagerClient bundles.BundleManagerClient) (Cursor, error) {
+// if a raw cursor is not supplied. If allVersions is true, the new cursor will include references
+// from remote repositories that depend on any version of the package that defines the symbol.
+func DecodeOrCreateCursor(path string, line, character, uploadID int, allVersions bool, rawCursor string, store store.Store, bundleManagerClient bundles.BundleManagerClient) (Cursor, error) {
 	if rawCursor != "" {
 		cursor, err := decodeCursor(rawCursor)
 		if err != nil {
@@ -90,5 +94,6 @@ func DecodeOrCreateCursor(path string, line, character, uploadID int, rawCursor
 		Character:   character,
 		Monikers:    flattened,
 		SkipResults: 0,
+		AllVersions: allVersions,
 	}, nil
 }
@@ -62,7 +62,7 @@ func TestDecodeOrCreateCursor(t *testing.T) {
 		Monikers:  []bundles.MonikerData{testMoniker1, testMoniker2},
 	}
 
-	if cursor, err := DecodeOrCreateCursor("sub1/main.go", 10, 20, 42, "", mockStore, mockBundleManagerClient); err != nil {
+	if cursor, err := DecodeOrCreateCursor("sub1/main.go", 10, 20, 42, false, "", mockStore, mockBundleManagerClient); err != nil {
 		t.Fatalf("unexpected error decoding cursor: %s", err)
 	} else if diff := cmp.Diff(expectedCursor, cursor); diff != "" {
 		t.Errorf("unexpected cursor (-want +got):\n%s", diff)
@@ -74,7 +74,7 @@ func TestDecodeOrCreateCursorUnknownDump(t *testing.T) {
 	mockBundleManagerClient := bundlemocks.NewMockBundleManagerClient()
 	setMockStoreGetDumpByID(t, mockStore, nil)
 
-	if _, err := DecodeOrCreateCursor("sub1/main.go", 10, 20, 42, "", mockStore, mockBundleManagerClient); err != ErrMissingDump {
+	if _, err := DecodeOrCreateCursor("sub1/main.go", 10, 20, 42, false, "", mockStore, mockBundleManagerClient); err != ErrMissingDump {
 		t.Fatalf("unexpected error decoding cursor. want=%q have =%q", ErrMissingDump, err)
 	}
 }
@@ -106,7 +106,7 @@ func TestDecodeOrCreateCursorExisting(t *testing.T) {
 	mockStore := storemocks.NewMockStore()
 	mockBundleManagerClient := bundlemocks.NewMockBundleManagerClient()
 
-	if cursor, err := DecodeOrCreateCursor("", 0, 0, 0, EncodeCursor(expectedCursor), mockStore, mockBundleManagerClient); err != nil {
+	if cursor, err := DecodeOrCreateCursor("", 0, 0, 0, false, EncodeCursor(expectedCursor), mockStore, mockBundleManagerClient); err != nil {
 		t.Fatalf("unexpected error decoding cursor: %s", err)
 	} else if diff := cmp.Diff(expectedCursor, cursor); diff != "" {
 		t.Errorf("unexpected cursor (-want +got):\n%s", diff)
@@ -8,6 +8,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/google/go-cmp/cmp"
 	bundles "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/bundles/client"
 	bundlemocks "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/bundles/client/mocks"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/bundles/types"
@@ -141,6 +142,24 @@ func setMockStorePackageReferencePager(t *testing.T, mockStore *storemocks.MockS
 	})
 }
 
+func setMockStoreMonikerReferences(t *testing.T, mockStore *storemocks.MockStore, expectedOpts store.MonikerReferencesOptions, references []store.MonikerReference) {
+	mockStore.MonikerReferencesFunc.SetDefaultHook(func(ctx context.Context, opts store.MonikerReferencesOptions) ([]store.MonikerReference, error) {
+		if diff := cmp.Diff(expectedOpts, opts); diff != "" {
+			t.Errorf("unexpected options for MonikerReferences (-want +got):\n%s", diff)
+		}
+		return references, nil
+	})
+}
+
+func setMockStoreMonikerReferenceCounts(t *testing.T, mockStore *storemocks.MockStore, expectedOpts store.MonikerReferencesOptions, counts []store.RepositoryReferenceCount) {
+	mockStore.MonikerReferenceCountsFunc.SetDefaultHook(func(ctx context.Context, opts store.MonikerReferencesOptions) ([]store.RepositoryReferenceCount, error) {
+		if diff := cmp.Diff(expectedOpts, opts); diff != "" {
+			t.Errorf("unexpected options for MonikerReferenceCounts (-want +got):\n%s", diff)
+		}
+		return counts, nil
+	})
+}
+
 func setMockStoreHasRepository(t *testing.T, mockStore *storemocks.MockStore, expectedRepositoryID int, exists bool) {
 	mockStore.HasRepositoryFunc.SetDefaultHook(func(ctx context.Context, repositoryID int) (bool, error) {
 		if repositoryID != expectedRepositoryID {
@@ -30,6 +30,9 @@ type MockCodeIntelAPI struct {
 	// RangesFunc is an instance of a mock function object controlling the
 	// behavior of the method Ranges.
 	RangesFunc *CodeIntelAPIRangesFunc
+	// ReferenceCountsFunc is an instance of a mock function object
+	// controlling the behavior of the method ReferenceCounts.
+	ReferenceCountsFunc *CodeIntelAPIReferenceCountsFunc
 	// ReferencesFunc is an instance of a mock function object controlling
 	// the behavior of the method References.
 	ReferencesFunc *CodeIntelAPIReferencesFunc
@@ -64,6 +67,11 @@ func NewMockCodeIntelAPI() *MockCodeIntelAPI {
 				return nil, nil
 			},
 		},
+		ReferenceCountsFunc: &CodeIntelAPIReferenceCountsFunc{
+			defaultHook: func(context.Context, string, int, int, int, bool) ([]store.RepositoryReferenceCount, error) {
+				return nil, nil
+			},
+		},
 		ReferencesFunc: &CodeIntelAPIReferencesFunc{
 			defaultHook: func(context.Context, int, string, int, api.Cursor) ([]api.ResolvedLocation, api.Cursor, bool, error) {
 				return nil, api.Cursor{}, false, nil
@@ -92,6 +100,9 @@ func NewMockCodeIntelAPIFrom(i api.CodeIntelAPI) *MockCodeIntelAPI {
 		RangesFunc: &CodeIntelAPIRangesFunc{
 			defaultHook: i.Ranges,
 		},
+		ReferenceCountsFunc: &CodeIntelAPIReferenceCountsFunc{
+			defaultHook: i.ReferenceCounts,
+		},
 		ReferencesFunc: &CodeIntelAPIReferencesFunc{
 			defaultHook: i.References,
 		},
@@ -699,6 +710,128 @@ func (c CodeIntelAPIRangesFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// CodeIntelAPIReferenceCountsFunc describes the behavior when the
+// ReferenceCounts method of the parent MockCodeIntelAPI instance is
+// invoked.
+type CodeIntelAPIReferenceCountsFunc struct {
+	defaultHook func(context.Context, string, int, int, int, bool) ([]store.RepositoryReferenceCount, error)
+	hooks       []func(context.Context, string, int, int, int, bool) ([]store.RepositoryReferenceCount, error)
+	history     []CodeIntelAPIReferenceCountsFuncCall
+	mutex       sync.Mutex
+}
+
+// ReferenceCounts delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockCodeIntelAPI) ReferenceCounts(v0 context.Context, v1 string, v2 int, v3 int, v4 int, v5 bool) ([]store.RepositoryReferenceCount, error) {
+	r0, r1 := m.ReferenceCountsFunc.nextHook()(v0, v1, v2, v3, v4, v5)
+	m.ReferenceCountsFunc.appendCall(CodeIntelAPIReferenceCountsFuncCall{v0, v1, v2, v3, v4, v5, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the ReferenceCounts
+// method of the parent MockCodeIntelAPI instance is invoked and the hook
+// queue is empty.
+func (f *CodeIntelAPIReferenceCountsFunc) SetDefaultHook(hook func(context.Context, string, int, int, int, bool) ([]store.RepositoryReferenceCount, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// ReferenceCounts method of the parent MockCodeIntelAPI instance inovkes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *CodeIntelAPIReferenceCountsFunc) PushHook(hook func(context.Context, string, int, int, int, bool) ([]store.RepositoryReferenceCount, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *CodeIntelAPIReferenceCountsFunc) SetDefaultReturn(r0 []store.RepositoryReferenceCount, r1 error) {
+	f.SetDefaultHook(func(context.Context, string, int, int, int, bool) ([]store.RepositoryReferenceCount, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *CodeIntelAPIReferenceCountsFunc) PushReturn(r0 []store.RepositoryReferenceCount, r1 error) {
+	f.PushHook(func(context.Context, string, int, int, int, bool) ([]store.RepositoryReferenceCount, error) {
+		return r0, r1
+	})
+}
+
+func (f *CodeIntelAPIReferenceCountsFunc) nextHook() func(context.Context, string, int, int, int, bool) ([]store.RepositoryReferenceCount, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *CodeIntelAPIReferenceCountsFunc) appendCall(r0 CodeIntelAPIReferenceCountsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of CodeIntelAPIReferenceCountsFuncCall objects
+// describing the invocations of this function.
+func (f *CodeIntelAPIReferenceCountsFunc) History() []CodeIntelAPIReferenceCountsFuncCall {
+	f.mutex.Lock()
+	history := make([]CodeIntelAPIReferenceCountsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// CodeIntelAPIReferenceCountsFuncCall is an object that describes an
+// invocation of method ReferenceCounts on an instance of MockCodeIntelAPI.
+type CodeIntelAPIReferenceCountsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 string
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 int
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 int
+	// Arg4 is the value of the 5th argument passed to this method
+	// invocation.
+	Arg4 int
+	// Arg5 is the value of the 6th argument passed to this method
+	// invocation.
+	Arg5 bool
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []store.RepositoryReferenceCount
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c CodeIntelAPIReferenceCountsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3, c.Arg4, c.Arg5}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c CodeIntelAPIReferenceCountsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // CodeIntelAPIReferencesFunc describes the behavior when the References
 // method of the parent MockCodeIntelAPI instance is invoked.
 type CodeIntelAPIReferencesFunc struct {
@@ -16,6 +16,7 @@ type ObservedCodeIntelAPI struct {
 	rangesOperation           *observation.Operation
 	definitionsOperation      *observation.Operation
 	referencesOperation       *observation.Operation
+	referenceCountsOperation  *observation.Operation
 	hoverOperation            *observation.Operation
 	diagnosticsOperation      *observation.Operation
 }
@@ -53,6 +54,11 @@ func NewObserved(codeIntelAPI CodeIntelAPI, observationContext *observation.Cont
 			MetricLabels: []string{"references"},
 			Metrics:      metrics,
 		}),
+		referenceCountsOperation: observationContext.Operation(observation.Op{
+			Name:         "CodeIntelAPI.ReferenceCounts",
+			MetricLabels: []string{"reference_counts"},
+			Metrics:      metrics,
+		}),
 		hoverOperation: observationContext.Operation(observation.Op{
 			Name:         "CodeIntelAPI.Hover",
 			MetricLabels: []string{"hover"},
@@ -94,6 +100,13 @@ func (api *ObservedCodeIntelAPI) References(ctx context.Context, repositoryID in
 	return api.codeIntelAPI.References(ctx, repositoryID, commit, limit, cursor)
 }
 
+// ReferenceCounts calls into the inner CodeIntelAPI and registers the observed results.
+func (api *ObservedCodeIntelAPI) ReferenceCounts(ctx context.Context, file string, line, character, uploadID int, allVersions bool) (counts []store.RepositoryReferenceCount, err error) {
+	ctx, endObservation := api.referenceCountsOperation.With(ctx, &err, observation.Args{})
+	defer func() { endObservation(float64(len(counts)), observation.Args{}) }()
+	return api.codeIntelAPI.ReferenceCounts(ctx, file, line, character, uploadID, allVersions)
+}
+
 // Hover calls into the inner CodeIntelAPI and registers the observed results.
 func (api *ObservedCodeIntelAPI) Hover(ctx context.Context, file string, line, character, uploadID int) (_ string, _ bundles.Range, _ bool, err error) {
 	ctx, endObservation := api.hoverOperation.With(ctx, &err, observation.Args{})
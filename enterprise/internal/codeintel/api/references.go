@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/inconshreveable/log15"
 	pkgerrors "github.com/pkg/errors"
@@ -38,6 +39,63 @@ func (api *codeIntelAPI) References(ctx context.Context, repositoryID int, commi
 	return rpr.resolvePage(ctx, cursor)
 }
 
+// ReferenceCounts returns the number of locations in each repository that reference the symbol at the given
+// position from another package. These counts are read from the moniker reference index and therefore only
+// include dumps visible at the tip of their repository's default branch.
+func (api *codeIntelAPI) ReferenceCounts(ctx context.Context, file string, line, character, uploadID int, allVersions bool) ([]store.RepositoryReferenceCount, error) {
+	dump, exists, err := api.store.GetDumpByID(ctx, uploadID)
+	if err != nil {
+		return nil, pkgerrors.Wrap(err, "store.GetDumpByID")
+	}
+	if !exists {
+		return nil, ErrMissingDump
+	}
+
+	pathInBundle := strings.TrimPrefix(file, dump.Root)
+	bundleClient := api.bundleManagerClient.BundleClient(dump.ID)
+
+	rangeMonikers, err := bundleClient.MonikersByPosition(ctx, pathInBundle, line, character)
+	if err != nil {
+		if err == bundles.ErrNotFound {
+			log15.Warn("Bundle does not exist")
+			return nil, nil
+		}
+		return nil, pkgerrors.Wrap(err, "bundleClient.MonikersByPosition")
+	}
+
+	for _, monikers := range rangeMonikers {
+		for _, moniker := range monikers {
+			if moniker.PackageInformationID == "" {
+				continue
+			}
+
+			packageInformation, err := bundleClient.PackageInformation(ctx, pathInBundle, moniker.PackageInformationID)
+			if err != nil {
+				if err == bundles.ErrNotFound {
+					log15.Warn("Bundle does not exist")
+					return nil, nil
+				}
+				return nil, pkgerrors.Wrap(err, "bundleClient.PackageInformation")
+			}
+
+			counts, err := api.store.MonikerReferenceCounts(ctx, store.MonikerReferencesOptions{
+				Scheme:      moniker.Scheme,
+				Identifier:  moniker.Identifier,
+				Name:        packageInformation.Name,
+				Version:     packageInformation.Version,
+				AllVersions: allVersions,
+			})
+			if err != nil {
+				return nil, pkgerrors.Wrap(err, "store.MonikerReferenceCounts")
+			}
+
+			return counts, nil
+		}
+	}
+
+	return nil, nil
+}
+
 type ReferencePageResolver struct {
 	store               store.Store
 	bundleManagerClient bundles.BundleManagerClient
@@ -69,11 +127,12 @@ func (s *ReferencePageResolver) resolvePage(ctx context.Context, cursor Cursor)
 
 func (s *ReferencePageResolver) dispatchCursorHandler(ctx context.Context, cursor Cursor) ([]ResolvedLocation, Cursor, bool, error) {
 	fns := map[string]func(context.Context, Cursor) ([]ResolvedLocation, Cursor, bool, error){
-		"same-dump":           s.handleSameDumpCursor,
-		"same-dump-monikers":  s.handleSameDumpMonikersCursor,
-		"definition-monikers": s.handleDefinitionMonikersCursor,
-		"same-repo":           s.handleSameRepoCursor,
-		"remote-repo":         s.handleRemoteRepoCursor,
+		"same-dump":            s.handleSameDumpCursor,
+		"same-dump-monikers":   s.handleSameDumpMonikersCursor,
+		"definition-monikers":  s.handleDefinitionMonikersCursor,
+		"same-repo":            s.handleSameRepoCursor,
+		"remote-repo-monikers": s.handleRemoteRepoMonikersCursor,
+		"remote-repo":          s.handleRemoteRepoCursor,
 	}
 
 	fn, exists := fns[cursor.Phase]
@@ -118,6 +177,7 @@ func (s *ReferencePageResolver) handleSameDumpCursor(ctx context.Context, cursor
 			Character:   cursor.Character,
 			Monikers:    cursor.Monikers,
 			SkipResults: newOffset,
+			AllVersions: cursor.AllVersions,
 		}
 		return resolvedLocations, newCursor, true, nil
 	}
@@ -130,6 +190,7 @@ func (s *ReferencePageResolver) handleSameDumpCursor(ctx context.Context, cursor
 		Character:   cursor.Character,
 		Monikers:    cursor.Monikers,
 		SkipResults: 0,
+		AllVersions: cursor.AllVersions,
 	}
 	return resolvedLocations, newCursor, true, nil
 }
@@ -201,6 +262,7 @@ func (s *ReferencePageResolver) handleSameDumpMonikersCursor(ctx context.Context
 			Character:   cursor.Character,
 			Monikers:    cursor.Monikers,
 			SkipResults: newOffset,
+			AllVersions: cursor.AllVersions,
 		}
 		return resolvedLocations, newCursor, true, nil
 	}
@@ -211,6 +273,7 @@ func (s *ReferencePageResolver) handleSameDumpMonikersCursor(ctx context.Context
 		Path:        cursor.Path,
 		Monikers:    cursor.Monikers,
 		SkipResults: 0,
+		AllVersions: cursor.AllVersions,
 	}
 	return resolvedLocations, newCursor, true, nil
 }
@@ -245,6 +308,7 @@ func (s *ReferencePageResolver) handleDefinitionMonikersCursor(ctx context.Conte
 			SkipDumpsWhenBatching:  0,
 			SkipDumpsInBatch:       0,
 			SkipResultsInDump:      0,
+			AllVersions:            cursor.AllVersions,
 		}
 		break
 	}
@@ -269,6 +333,7 @@ func (s *ReferencePageResolver) handleDefinitionMonikersCursor(ctx context.Conte
 				Path:        cursor.Path,
 				Monikers:    cursor.Monikers,
 				SkipResults: newOffset,
+				AllVersions: cursor.AllVersions,
 			}
 			return locations, newCursor, true, nil
 		}
@@ -293,21 +358,85 @@ func (s *ReferencePageResolver) handleSameRepoCursor(ctx context.Context, cursor
 	}
 
 	newCursor = Cursor{
-		DumpID:                 cursor.DumpID,
-		Phase:                  "remote-repo",
-		Scheme:                 cursor.Scheme,
-		Identifier:             cursor.Identifier,
-		Name:                   cursor.Name,
-		Version:                cursor.Version,
-		DumpIDs:                nil,
-		TotalDumpsWhenBatching: 0,
-		SkipDumpsWhenBatching:  0,
-		SkipDumpsInBatch:       0,
-		SkipResultsInDump:      0,
+		DumpID:      cursor.DumpID,
+		Phase:       "remote-repo-monikers",
+		Scheme:      cursor.Scheme,
+		Identifier:  cursor.Identifier,
+		Name:        cursor.Name,
+		Version:     cursor.Version,
+		AllVersions: cursor.AllVersions,
 	}
 	return locations, newCursor, true, nil
 }
 
+// handleRemoteRepoMonikersCursor returns references from dumps of remote repositories that have been
+// recorded in the moniker reference index. Dumps are visited in a stable order (by repository, then by
+// dump) so that the cursor remains valid as new dumps are indexed. Once the index is exhausted, the
+// cursor moves to the remote-repo phase, which handles dumps that predate the index.
+func (s *ReferencePageResolver) handleRemoteRepoMonikersCursor(ctx context.Context, cursor Cursor) ([]ResolvedLocation, Cursor, bool, error) {
+	references, err := s.store.MonikerReferences(ctx, store.MonikerReferencesOptions{
+		Scheme:              cursor.Scheme,
+		Identifier:          cursor.Identifier,
+		Name:                cursor.Name,
+		Version:             cursor.Version,
+		AllVersions:         cursor.AllVersions,
+		ExcludeRepositoryID: s.repositoryID,
+		AfterRepositoryID:   cursor.AfterRepositoryID,
+		AfterDumpID:         cursor.AfterDumpID,
+		Limit:               s.remoteDumpLimit,
+	})
+	if err != nil {
+		return nil, Cursor{}, false, pkgerrors.Wrap(err, "store.MonikerReferences")
+	}
+
+	newCursor := cursor
+	for _, reference := range references {
+		dump, exists, err := s.store.GetDumpByID(ctx, reference.DumpID)
+		if err != nil {
+			return nil, Cursor{}, false, pkgerrors.Wrap(err, "store.GetDumpByID")
+		}
+
+		var results []bundles.Location
+		var count int
+		if exists {
+			results, count, err = s.bundleManagerClient.BundleClient(reference.DumpID).MonikerResults(ctx, "reference", cursor.Scheme, cursor.Identifier, newCursor.SkipResultsInDump, s.limit)
+			if err != nil {
+				if err != bundles.ErrNotFound {
+					return nil, Cursor{}, false, pkgerrors.Wrap(err, "bundleClient.MonikerResults")
+				}
+				log15.Warn("Bundle does not exist")
+			}
+		}
+
+		if newResultOffset := newCursor.SkipResultsInDump + len(results); len(results) > 0 && newResultOffset < count {
+			newCursor.SkipResultsInDump = newResultOffset
+		} else {
+			newCursor.AfterRepositoryID = reference.RepositoryID
+			newCursor.AfterDumpID = reference.DumpID
+			newCursor.SkipResultsInDump = 0
+		}
+
+		if len(results) > 0 {
+			return resolveLocationsWithDump(dump, results), newCursor, true, nil
+		}
+	}
+
+	if len(references) == s.remoteDumpLimit {
+		return nil, newCursor, true, nil
+	}
+
+	newCursor = Cursor{
+		DumpID:      cursor.DumpID,
+		Phase:       "remote-repo",
+		Scheme:      cursor.Scheme,
+		Identifier:  cursor.Identifier,
+		Name:        cursor.Name,
+		Version:     cursor.Version,
+		AllVersions: cursor.AllVersions,
+	}
+	return nil, newCursor, true, nil
+}
+
 func (s *ReferencePageResolver) handleRemoteRepoCursor(ctx context.Context, cursor Cursor) ([]ResolvedLocation, Cursor, bool, error) {
 	return s.resolveLocationsViaReferencePager(ctx, cursor, func(ctx context.Context) (int, store.ReferencePager, error) {
 		totalCount, pager, err := s.store.PackageReferencePager(ctx, cursor.Scheme, cursor.Name, cursor.Version, s.repositoryID, s.remoteDumpLimit)
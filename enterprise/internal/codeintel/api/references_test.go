@@ -468,7 +468,7 @@ func TestHandleSameRepoCursor(t *testing.T) {
 		}
 
 		expectedNewCursor := Cursor{
-			Phase:      "remote-repo",
+			Phase:      "remote-repo-monikers",
 			DumpID:     42,
 			Scheme:     "gomod",
 			Identifier: "bar",
@@ -560,6 +560,217 @@ func TestHandleSameRepoCursorMultipleDumpBatches(t *testing.T) {
 //
 //
 
+func TestHandleRemoteRepoMonikersCursor(t *testing.T) {
+	mockStore := storemocks.NewMockStore()
+	mockBundleManagerClient := bundlemocks.NewMockBundleManagerClient()
+	mockBundleClient1 := bundlemocks.NewMockBundleClient()
+	mockBundleClient2 := bundlemocks.NewMockBundleClient()
+
+	setMockStoreGetDumpByID(t, mockStore, map[int]store.Dump{42: testDump1, 50: testDump2, 51: testDump3})
+	setMockBundleManagerClientBundleClient(t, mockBundleManagerClient, map[int]bundles.BundleClient{50: mockBundleClient1, 51: mockBundleClient2})
+
+	rpr := &ReferencePageResolver{
+		store:               mockStore,
+		bundleManagerClient: mockBundleManagerClient,
+		repositoryID:        100,
+		commit:              testCommit,
+		remoteDumpLimit:     5,
+		limit:               2,
+	}
+
+	t.Run("partial dump", func(t *testing.T) {
+		setMockStoreMonikerReferences(t, mockStore, store.MonikerReferencesOptions{
+			Scheme:              "gomod",
+			Identifier:          "bar",
+			Name:                "leftpad",
+			Version:             "0.1.0",
+			AllVersions:         true,
+			ExcludeRepositoryID: 100,
+			Limit:               5,
+		}, []store.MonikerReference{
+			{DumpID: 50, RepositoryID: 60, Count: 3},
+			{DumpID: 51, RepositoryID: 61, Count: 2},
+		})
+		setMockBundleClientMonikerResults(t, mockBundleClient1, "reference", "gomod", "bar", 0, 2, []bundles.Location{
+			{DumpID: 50, Path: "foo.go", Range: testRange1},
+			{DumpID: 50, Path: "bar.go", Range: testRange2},
+		}, 3)
+
+		references, newCursor, hasNewCursor, err := rpr.dispatchCursorHandler(context.Background(), Cursor{
+			Phase:       "remote-repo-monikers",
+			DumpID:      42,
+			Scheme:      "gomod",
+			Identifier:  "bar",
+			Name:        "leftpad",
+			Version:     "0.1.0",
+			AllVersions: true,
+		})
+		if err != nil {
+			t.Fatalf("expected error getting references: %s", err)
+		}
+
+		expectedReferences := []ResolvedLocation{
+			{Dump: testDump2, Path: "sub2/foo.go", Range: testRange1},
+			{Dump: testDump2, Path: "sub2/bar.go", Range: testRange2},
+		}
+		if diff := cmp.Diff(expectedReferences, references); diff != "" {
+			t.Errorf("unexpected references (-want +got):\n%s", diff)
+		}
+
+		expectedNewCursor := Cursor{
+			Phase:             "remote-repo-monikers",
+			DumpID:            42,
+			Scheme:            "gomod",
+			Identifier:        "bar",
+			Name:              "leftpad",
+			Version:           "0.1.0",
+			AllVersions:       true,
+			SkipResultsInDump: 2,
+		}
+		if !hasNewCursor {
+			t.Errorf("expected new cursor")
+		} else if diff := cmp.Diff(expectedNewCursor, newCursor); diff != "" {
+			t.Errorf("unexpected new cursor (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("next dump", func(t *testing.T) {
+		setMockStoreMonikerReferences(t, mockStore, store.MonikerReferencesOptions{
+			Scheme:              "gomod",
+			Identifier:          "bar",
+			Name:                "leftpad",
+			Version:             "0.1.0",
+			ExcludeRepositoryID: 100,
+			AfterRepositoryID:   60,
+			AfterDumpID:         50,
+			Limit:               5,
+		}, []store.MonikerReference{
+			{DumpID: 51, RepositoryID: 61, Count: 2},
+		})
+		setMockBundleClientMonikerResults(t, mockBundleClient2, "reference", "gomod", "bar", 0, 2, []bundles.Location{
+			{DumpID: 51, Path: "baz.go", Range: testRange3},
+			{DumpID: 51, Path: "bonk.go", Range: testRange4},
+		}, 2)
+
+		references, newCursor, hasNewCursor, err := rpr.dispatchCursorHandler(context.Background(), Cursor{
+			Phase:             "remote-repo-monikers",
+			DumpID:            42,
+			Scheme:            "gomod",
+			Identifier:        "bar",
+			Name:              "leftpad",
+			Version:           "0.1.0",
+			AfterRepositoryID: 60,
+			AfterDumpID:       50,
+		})
+		if err != nil {
+			t.Fatalf("expected error getting references: %s", err)
+		}
+
+		expectedReferences := []ResolvedLocation{
+			{Dump: testDump3, Path: "sub3/baz.go", Range: testRange3},
+			{Dump: testDump3, Path: "sub3/bonk.go", Range: testRange4},
+		}
+		if diff := cmp.Diff(expectedReferences, references); diff != "" {
+			t.Errorf("unexpected references (-want +got):\n%s", diff)
+		}
+
+		expectedNewCursor := Cursor{
+			Phase:             "remote-repo-monikers",
+			DumpID:            42,
+			Scheme:            "gomod",
+			Identifier:        "bar",
+			Name:              "leftpad",
+			Version:           "0.1.0",
+			AfterRepositoryID: 61,
+			AfterDumpID:       51,
+		}
+		if !hasNewCursor {
+			t.Errorf("expected new cursor")
+		} else if diff := cmp.Diff(expectedNewCursor, newCursor); diff != "" {
+			t.Errorf("unexpected new cursor (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("exhausted", func(t *testing.T) {
+		setMockStoreMonikerReferences(t, mockStore, store.MonikerReferencesOptions{
+			Scheme:              "gomod",
+			Identifier:          "bar",
+			Name:                "leftpad",
+			Version:             "0.1.0",
+			ExcludeRepositoryID: 100,
+			AfterRepositoryID:   61,
+			AfterDumpID:         51,
+			Limit:               5,
+		}, nil)
+
+		references, newCursor, hasNewCursor, err := rpr.dispatchCursorHandler(context.Background(), Cursor{
+			Phase:             "remote-repo-monikers",
+			DumpID:            42,
+			Scheme:            "gomod",
+			Identifier:        "bar",
+			Name:              "leftpad",
+			Version:           "0.1.0",
+			AfterRepositoryID: 61,
+			AfterDumpID:       51,
+		})
+		if err != nil {
+			t.Fatalf("expected error getting references: %s", err)
+		}
+		if len(references) != 0 {
+			t.Errorf("unexpected references. want=%d have=%d", 0, len(references))
+		}
+
+		expectedNewCursor := Cursor{
+			Phase:      "remote-repo",
+			DumpID:     42,
+			Scheme:     "gomod",
+			Identifier: "bar",
+			Name:       "leftpad",
+			Version:    "0.1.0",
+		}
+		if !hasNewCursor {
+			t.Errorf("expected new cursor")
+		} else if diff := cmp.Diff(expectedNewCursor, newCursor); diff != "" {
+			t.Errorf("unexpected new cursor (-want +got):\n%s", diff)
+		}
+	})
+}
+
+func TestReferenceCounts(t *testing.T) {
+	mockStore := storemocks.NewMockStore()
+	mockBundleManagerClient := bundlemocks.NewMockBundleManagerClient()
+	mockBundleClient := bundlemocks.NewMockBundleClient()
+
+	setMockStoreGetDumpByID(t, mockStore, map[int]store.Dump{42: testDump1})
+	setMockBundleManagerClientBundleClient(t, mockBundleManagerClient, map[int]bundles.BundleClient{42: mockBundleClient})
+	setMockBundleClientMonikersByPosition(t, mockBundleClient, "main.go", 10, 50, [][]bundles.MonikerData{{testMoniker3, testMoniker2}})
+	setMockBundleClientPackageInformation(t, mockBundleClient, "main.go", "1234", testPackageInformation)
+	setMockStoreMonikerReferenceCounts(t, mockStore, store.MonikerReferencesOptions{
+		Scheme:      "gomod",
+		Identifier:  "pad",
+		Name:        "leftpad",
+		Version:     "0.1.0",
+		AllVersions: true,
+	}, []store.RepositoryReferenceCount{
+		{RepositoryID: 60, Count: 3},
+		{RepositoryID: 61, Count: 2},
+	})
+
+	api := New(mockStore, mockBundleManagerClient, nil, nil)
+	counts, err := api.ReferenceCounts(context.Background(), "sub1/main.go", 10, 50, 42, true)
+	if err != nil {
+		t.Fatalf("expected error getting reference counts: %s", err)
+	}
+
+	expectedCounts := []store.RepositoryReferenceCount{
+		{RepositoryID: 60, Count: 3},
+		{RepositoryID: 61, Count: 2},
+	}
+	if diff := cmp.Diff(expectedCounts, counts); diff != "" {
+		t.Errorf("unexpected counts (-want +got):\n%s", diff)
+	}
+}
+
 func TestHandleRemoteRepoCursor(t *testing.T) {
 	mockStore := storemocks.NewMockStore()
 	mockBundleManagerClient := bundlemocks.NewMockBundleManagerClient()
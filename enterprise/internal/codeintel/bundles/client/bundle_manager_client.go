@@ -1,7 +1,6 @@
 package client
 
 import (
-	"compress/gzip"
 	"context"
 	"encoding/json"
 	"errors"
@@ -22,6 +21,7 @@ import (
 	"github.com/opentracing-contrib/go-stdlib/nethttp"
 	"github.com/opentracing/opentracing-go/ext"
 	"github.com/sourcegraph/codeintelutils"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/compression"
 	"github.com/sourcegraph/sourcegraph/internal/metrics"
 	"github.com/sourcegraph/sourcegraph/internal/trace/ot"
 	"golang.org/x/net/context/ctxhttp"
@@ -57,7 +57,7 @@ type BundleManagerClient interface {
 	DeleteUpload(ctx context.Context, bundleID int) error
 
 	// GetUpload retrieves a reader containing the content of a raw, uncompressed LSIF upload
-	// from the bundle manager.
+	// from the bundle manager. The upload may be stored either gzip or zstd-compressed.
 	GetUpload(ctx context.Context, bundleID int) (io.ReadCloser, error)
 
 	// SendDB transfers a converted database to the bundle manager to be stored on disk.
@@ -171,7 +171,7 @@ func (c *bundleManagerClientImpl) DeleteUpload(ctx context.Context, bundleID int
 }
 
 // GetUpload retrieves a reader containing the content of a raw, uncompressed LSIF upload
-// from the bundle manager.
+// from the bundle manager. The upload may be stored either gzip or zstd-compressed.
 func (c *bundleManagerClientImpl) GetUpload(ctx context.Context, bundleID int) (io.ReadCloser, error) {
 	url, err := makeURL(c.bundleManagerURL, fmt.Sprintf("uploads/%d", bundleID), nil)
 	if err != nil {
@@ -220,7 +220,14 @@ func (c *bundleManagerClientImpl) GetUpload(ctx context.Context, bundleID int) (
 		}
 	}()
 
-	return gzip.NewReader(pr)
+	r, err := compression.Decompress(pr)
+	if err != nil {
+		// Unblock the writer goroutine, which is no longer being read
+		_ = pr.CloseWithError(err)
+		return nil, err
+	}
+
+	return r, nil
 }
 
 // getUploadChunk retrieves a raw LSIF upload from the bundle manager starting from the offset as
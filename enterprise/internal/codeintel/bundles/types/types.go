@@ -123,3 +123,22 @@ type PackageReference struct {
 	Version string
 	Filter  []byte // a bloom filter of identifiers imported by this dependent
 }
+
+// MonikerReference pairs an imported moniker and the package that provides it with a dump
+// that references it, along with the number of reference locations within that dump.
+type MonikerReference struct {
+	DumpID     int
+	Scheme     string
+	Identifier string
+	Name       string
+	Version    string
+	Count      int
+}
+
+// Diagnostic pairs diagnostic data with the dump and the (root-relative) document path
+// that reported it. These are indexed to support searching diagnostics across dumps.
+type Diagnostic struct {
+	DumpID int
+	Path   string
+	DiagnosticData
+}
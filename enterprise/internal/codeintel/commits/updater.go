@@ -10,8 +10,9 @@ import (
 
 // Updater calculates, denormalizes, and stores the set of uploads visible from every commit
 // for a given repository. A repository's commit graph is updated when we receive code intel
-// queries for a commit we are unaware of (a commit newer than our latest LSIF upload), and
-// after processing an upload for a repository.
+// queries for a commit we are unaware of (a commit newer than our latest LSIF upload), after
+// processing an upload for a repository, and after a new commit is detected on the tip of the
+// repository's default branch.
 type Updater interface {
 	// Update pulls the commit graph for the given repository from gitserver, pulls the set of
 	// LSIF upload objects for the given repository from Postgres, and correlates them into a
@@ -0,0 +1,55 @@
+package compression
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"io"
+	"io/ioutil"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/pkg/errors"
+)
+
+var (
+	gzipMagic = []byte{0x1f, 0x8b}
+	zstdMagic = []byte{0x28, 0xb5, 0x2f, 0xfd}
+)
+
+// ErrUnknownFormat occurs when the payload is neither gzip nor zstd-compressed.
+var ErrUnknownFormat = errors.New("unknown compression format: expected gzip or zstd")
+
+// Decompress returns a reader of the decompressed content of r. The compression format is
+// detected from the leading magic bytes of the payload. Both gzip and zstd are supported.
+func Decompress(r io.Reader) (io.ReadCloser, error) {
+	br := bufio.NewReader(r)
+
+	header, err := br.Peek(len(zstdMagic))
+	if err != nil && err != io.EOF {
+		return nil, err
+	}
+
+	switch {
+	case bytes.HasPrefix(header, gzipMagic):
+		return gzip.NewReader(br)
+
+	case bytes.HasPrefix(header, zstdMagic):
+		decoder, err := zstd.NewReader(br)
+		if err != nil {
+			return nil, err
+		}
+		return &zstdReadCloser{decoder}, nil
+	}
+
+	return ioutil.NopCloser(br), ErrUnknownFormat
+}
+
+// zstdReadCloser releases the resources of a zstd decoder on close.
+type zstdReadCloser struct {
+	*zstd.Decoder
+}
+
+func (r *zstdReadCloser) Close() error {
+	r.Decoder.Close()
+	return nil
+}
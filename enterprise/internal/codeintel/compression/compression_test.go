@@ -0,0 +1,59 @@
+package compression
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io/ioutil"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/klauspost/compress/zstd"
+)
+
+func TestDecompress(t *testing.T) {
+	expected := []byte(`{"id":"1","type":"vertex","label":"metaData"}`)
+
+	var gzipBuf bytes.Buffer
+	gzipWriter := gzip.NewWriter(&gzipBuf)
+	if _, err := gzipWriter.Write(expected); err != nil {
+		t.Fatalf("unexpected error writing gzip payload: %s", err)
+	}
+	if err := gzipWriter.Close(); err != nil {
+		t.Fatalf("unexpected error closing gzip writer: %s", err)
+	}
+
+	var zstdBuf bytes.Buffer
+	zstdWriter, err := zstd.NewWriter(&zstdBuf)
+	if err != nil {
+		t.Fatalf("unexpected error creating zstd writer: %s", err)
+	}
+	if _, err := zstdWriter.Write(expected); err != nil {
+		t.Fatalf("unexpected error writing zstd payload: %s", err)
+	}
+	if err := zstdWriter.Close(); err != nil {
+		t.Fatalf("unexpected error closing zstd writer: %s", err)
+	}
+
+	for name, payload := range map[string][]byte{"gzip": gzipBuf.Bytes(), "zstd": zstdBuf.Bytes()} {
+		r, err := Decompress(bytes.NewReader(payload))
+		if err != nil {
+			t.Fatalf("unexpected error decompressing %s payload: %s", name, err)
+		}
+
+		contents, err := ioutil.ReadAll(r)
+		if err != nil {
+			t.Fatalf("unexpected error reading %s payload: %s", name, err)
+		}
+		_ = r.Close()
+
+		if diff := cmp.Diff(expected, contents); diff != "" {
+			t.Errorf("unexpected %s contents (-want +got):\n%s", name, diff)
+		}
+	}
+}
+
+func TestDecompressUnknownFormat(t *testing.T) {
+	if _, err := Decompress(bytes.NewReader([]byte("plain text"))); err != ErrUnknownFormat {
+		t.Errorf("unexpected error. want=%q have=%q", ErrUnknownFormat, err)
+	}
+}
@@ -17,6 +17,9 @@ type Client interface {
 	// to its parents.
 	CommitGraph(ctx context.Context, store store.Store, repositoryID int) (map[string][]string, error)
 
+	// Refs returns the branches and tags of the given repository along with the commits they point to.
+	Refs(ctx context.Context, store store.Store, repositoryID int) ([]store.GitRef, error)
+
 	// DirectoryChildren determines all children known to git for the given directory names via an invocation
 	// of git ls-tree. The keys of the resulting map are the input (unsanitized) dirnames, and the value of
 	// that key are the files nested under that directory.
@@ -46,6 +49,10 @@ func (c *defaultClient) CommitGraph(ctx context.Context, store store.Store, repo
 	return CommitGraph(ctx, store, repositoryID)
 }
 
+func (c *defaultClient) Refs(ctx context.Context, store store.Store, repositoryID int) ([]store.GitRef, error) {
+	return Refs(ctx, store, repositoryID)
+}
+
 func (c *defaultClient) DirectoryChildren(ctx context.Context, store store.Store, repositoryID int, commit string, dirnames []string) (map[string][]string, error) {
 	return DirectoryChildren(ctx, store, repositoryID, commit, dirnames)
 }
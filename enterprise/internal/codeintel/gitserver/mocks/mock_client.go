@@ -30,6 +30,9 @@ type MockClient struct {
 	// HeadFunc is an instance of a mock function object controlling the
 	// behavior of the method Head.
 	HeadFunc *ClientHeadFunc
+	// RefsFunc is an instance of a mock function object controlling the
+	// behavior of the method Refs.
+	RefsFunc *ClientRefsFunc
 	// TagsFunc is an instance of a mock function object controlling the
 	// behavior of the method Tags.
 	TagsFunc *ClientTagsFunc
@@ -64,6 +67,11 @@ func NewMockClient() *MockClient {
 				return "", nil
 			},
 		},
+		RefsFunc: &ClientRefsFunc{
+			defaultHook: func(context.Context, store.Store, int) ([]store.GitRef, error) {
+				return nil, nil
+			},
+		},
 		TagsFunc: &ClientTagsFunc{
 			defaultHook: func(context.Context, store.Store, int, string) (string, bool, error) {
 				return "", false, nil
@@ -91,6 +99,9 @@ func NewMockClientFrom(i gitserver.Client) *MockClient {
 		HeadFunc: &ClientHeadFunc{
 			defaultHook: i.Head,
 		},
+		RefsFunc: &ClientRefsFunc{
+			defaultHook: i.Refs,
+		},
 		TagsFunc: &ClientTagsFunc{
 			defaultHook: i.Tags,
 		},
@@ -668,6 +679,117 @@ func (c ClientHeadFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// ClientRefsFunc describes the behavior when the Refs method of the parent
+// MockClient instance is invoked.
+type ClientRefsFunc struct {
+	defaultHook func(context.Context, store.Store, int) ([]store.GitRef, error)
+	hooks       []func(context.Context, store.Store, int) ([]store.GitRef, error)
+	history     []ClientRefsFuncCall
+	mutex       sync.Mutex
+}
+
+// Refs delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockClient) Refs(v0 context.Context, v1 store.Store, v2 int) ([]store.GitRef, error) {
+	r0, r1 := m.RefsFunc.nextHook()(v0, v1, v2)
+	m.RefsFunc.appendCall(ClientRefsFuncCall{v0, v1, v2, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the Refs method of the
+// parent MockClient instance is invoked and the hook queue is empty.
+func (f *ClientRefsFunc) SetDefaultHook(hook func(context.Context, store.Store, int) ([]store.GitRef, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// Refs method of the parent MockClient instance inovkes the hook at the
+// front of the queue and discards it. After the queue is empty, the default
+// hook function is invoked for any future action.
+func (f *ClientRefsFunc) PushHook(hook func(context.Context, store.Store, int) ([]store.GitRef, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *ClientRefsFunc) SetDefaultReturn(r0 []store.GitRef, r1 error) {
+	f.SetDefaultHook(func(context.Context, store.Store, int) ([]store.GitRef, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *ClientRefsFunc) PushReturn(r0 []store.GitRef, r1 error) {
+	f.PushHook(func(context.Context, store.Store, int) ([]store.GitRef, error) {
+		return r0, r1
+	})
+}
+
+func (f *ClientRefsFunc) nextHook() func(context.Context, store.Store, int) ([]store.GitRef, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *ClientRefsFunc) appendCall(r0 ClientRefsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of ClientRefsFuncCall objects describing the
+// invocations of this function.
+func (f *ClientRefsFunc) History() []ClientRefsFuncCall {
+	f.mutex.Lock()
+	history := make([]ClientRefsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// ClientRefsFuncCall is an object that describes an invocation of method
+// Refs on an instance of MockClient.
+type ClientRefsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 store.Store
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 int
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []store.GitRef
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c ClientRefsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c ClientRefsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // ClientTagsFunc describes the behavior when the Tags method of the parent
 // MockClient instance is invoked.
 type ClientTagsFunc struct {
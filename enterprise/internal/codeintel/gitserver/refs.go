@@ -0,0 +1,71 @@
+package gitserver
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/store"
+)
+
+// refFormat is the format string passed to git for-each-ref. Each ref is printed as a tab-separated
+// line containing the ref name, the object name, the peeled commit of an annotated tag, the creation
+// date of the ref (the tagger date for annotated tags and the committer date otherwise), and a marker
+// indicating whether or not the ref is the current HEAD.
+const refFormat = "%(refname)%09%(objectname)%09%(*objectname)%09%(creatordate:unix)%09%(HEAD)"
+
+// Refs returns the branches and tags of the given repository along with the commits they point to.
+func Refs(ctx context.Context, store store.Store, repositoryID int) ([]store.GitRef, error) {
+	out, err := execGitCommand(ctx, store, repositoryID, "for-each-ref", "--format="+refFormat, "refs/heads/", "refs/tags/")
+	if err != nil {
+		return nil, err
+	}
+
+	return parseRefs(strings.Split(out, "\n"))
+}
+
+// parseRefs converts the output of git for-each-ref into a list of refs.
+func parseRefs(lines []string) ([]store.GitRef, error) {
+	var refs []store.GitRef
+	for _, line := range lines {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+
+		parts := strings.Split(line, "\t")
+		if len(parts) < 4 {
+			return nil, errors.Errorf("unexpected for-each-ref output %q", line)
+		}
+
+		createdAt, err := strconv.ParseInt(parts[3], 10, 64)
+		if err != nil {
+			return nil, errors.Wrap(err, "strconv.ParseInt")
+		}
+
+		ref := store.GitRef{
+			Commit:          parts[1],
+			CreatedAt:       time.Unix(createdAt, 0).UTC(),
+			IsDefaultBranch: len(parts) > 4 && strings.TrimSpace(parts[4]) == "*",
+		}
+		if parts[2] != "" {
+			// Annotated tags point to a tag object rather than a commit
+			ref.Commit = parts[2]
+		}
+
+		if name := strings.TrimPrefix(parts[0], "refs/heads/"); name != parts[0] {
+			ref.Name = name
+			ref.Type = store.GitRefTypeBranch
+		} else if name := strings.TrimPrefix(parts[0], "refs/tags/"); name != parts[0] {
+			ref.Name = name
+			ref.Type = store.GitRefTypeTag
+		} else {
+			continue
+		}
+
+		refs = append(refs, ref)
+	}
+
+	return refs, nil
+}
@@ -0,0 +1,33 @@
+package gitserver
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/store"
+)
+
+func TestParseRefs(t *testing.T) {
+	lines := []string{
+		"refs/heads/feature/x\t683cafd122632142bda6e36563f5719e5b0fa37d\t\t1587396557\t ",
+		"refs/heads/master\t9ad62c7ec68e377b41a8b8dd846e573b76634172\t\t1587396600\t*",
+		"refs/tags/v1.0.0\t1afa9c06d8bb8b2c5746e539ed4eb80c23b21db3\t02f41985f46b400b7a673c3dfb6bab8fd1ac6a6d\t1587000000\t ",
+		"refs/tags/v0.9.0\ta94fb112d1f2e70f55851c6c569916a9e31caee1\t\t1586000000",
+	}
+
+	refs, err := parseRefs(lines)
+	if err != nil {
+		t.Fatalf("unexpected error parsing refs: %s", err)
+	}
+
+	expected := []store.GitRef{
+		{Name: "feature/x", Type: store.GitRefTypeBranch, Commit: "683cafd122632142bda6e36563f5719e5b0fa37d", CreatedAt: time.Unix(1587396557, 0).UTC()},
+		{Name: "master", Type: store.GitRefTypeBranch, Commit: "9ad62c7ec68e377b41a8b8dd846e573b76634172", CreatedAt: time.Unix(1587396600, 0).UTC(), IsDefaultBranch: true},
+		{Name: "v1.0.0", Type: store.GitRefTypeTag, Commit: "02f41985f46b400b7a673c3dfb6bab8fd1ac6a6d", CreatedAt: time.Unix(1587000000, 0).UTC()},
+		{Name: "v0.9.0", Type: store.GitRefTypeTag, Commit: "a94fb112d1f2e70f55851c6c569916a9e31caee1", CreatedAt: time.Unix(1586000000, 0).UTC()},
+	}
+	if diff := cmp.Diff(expected, refs); diff != "" {
+		t.Errorf("unexpected refs (-want +got):\n%s", diff)
+	}
+}
@@ -51,13 +51,18 @@ func enforceAuthGithub(ctx context.Context, w http.ResponseWriter, r *http.Reque
 	// the user via each in turn.
 
 	authViaGithubApp := func() error {
-		repos, err := client.ListInstallationRepositories(ctx)
-		if err != nil {
-			return err
-		}
-		for _, repo := range repos {
-			if repo.NameWithOwner == nameWithOwner {
-				return nil
+		hasNextPage := true
+		for page := 1; hasNextPage; page++ {
+			var repos []*github.Repository
+			var err error
+			repos, hasNextPage, err = client.ListInstallationRepositories(ctx, page)
+			if err != nil {
+				return err
+			}
+			for _, repo := range repos {
+				if repo.NameWithOwner == nameWithOwner {
+					return nil
+				}
 			}
 		}
 		return fmt.Errorf("given repository %s not listed in installed repositories", nameWithOwner)
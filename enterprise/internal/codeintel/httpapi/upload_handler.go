@@ -2,7 +2,6 @@ package httpapi
 
 import (
 	"bytes"
-	"compress/gzip"
 	"context"
 	"fmt"
 	"io"
@@ -14,6 +13,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
 	bundles "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/bundles/client"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/compression"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/store"
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/conf"
@@ -99,6 +99,13 @@ type enqueuePayload struct {
 	ID string `json:"id"`
 }
 
+type statusPayload struct {
+	ID            string `json:"id"`
+	State         string `json:"state"`
+	NumParts      int    `json:"numParts"`
+	UploadedParts []int  `json:"uploadedParts"`
+}
+
 // handleEnqueueErr dispatches to the correct handler function based on query args. Running the
 // `src lsif upload` command will cause one of two sequences of requests to occur. For uploads that
 // are small enough repos (that can be uploaded in one-shot), only one request will be made:
@@ -112,12 +119,22 @@ type enqueuePayload struct {
 //   - POST `/upload?uploadId={id},index={i}`
 //   - POST `/upload?uploadId={id},done=true`
 //
+// An interrupted multipart upload can be resumed by requesting the set of parts that have already
+// been received and re-sending only the missing parts before finalizing the upload. Re-sending a
+// part that was already received replaces its content.
+//
+//   - POST `/upload?uploadId={id},status=true`
+//
+// Payloads may be either gzip or zstd-compressed. Parts of a multipart upload are slices of a
+// single compressed payload and are concatenated by the bundle manager before decompression.
+//
 // See the functions the following functions for details on how each request is handled:
 //
 //   - handleEnqueueSinglePayload
 //   - handleEnqueueMultipartSetup
 //   - handleEnqueueMultipartUpload
 //   - handleEnqueueMultipartFinalize
+//   - handleEnqueueMultipartStatus
 func (h *UploadHandler) handleEnqueueErr(w http.ResponseWriter, r *http.Request, repositoryID int) (interface{}, error) {
 	ctx := r.Context()
 
@@ -152,6 +169,14 @@ func (h *UploadHandler) handleEnqueueErr(w http.ResponseWriter, r *http.Request,
 		return nil, clientError("upload not found")
 	}
 
+	if hasQuery(r, "status") {
+		return h.handleEnqueueMultipartStatus(upload)
+	}
+
+	if upload.State != "uploading" {
+		return nil, clientError("upload is not accepting data: upload is in state %q", upload.State)
+	}
+
 	if hasQuery(r, "index") {
 		if partIndex := getQueryInt(r, "index"); partIndex < 0 || partIndex >= upload.NumParts {
 			return nil, clientError("illegal part index: index %d is outside the range [0, %d)", partIndex, upload.NumParts)
@@ -167,6 +192,28 @@ func (h *UploadHandler) handleEnqueueErr(w http.ResponseWriter, r *http.Request,
 	return nil, clientError("no index supplied")
 }
 
+// indexerNamePrefixSize is the number of compressed bytes read from the start of a payload to
+// find the indexer name. The metadata vertex is the first line of a payload and can be up to
+// codeintelutils.MaxBufferSize bytes long, which fits into this many compressed bytes for both
+// gzip and zstd (whose blocks are at most 128KiB).
+const indexerNamePrefixSize = 4 * codeintelutils.MaxBufferSize
+
+// readIndexerName decompresses the given prefix of a payload and returns the name of the
+// indexer from its metadata vertex.
+func readIndexerName(prefix []byte) (string, error) {
+	decompressedReader, err := compression.Decompress(bytes.NewReader(prefix))
+	if err != nil {
+		if err == compression.ErrUnknownFormat {
+			return "", clientError("%s", err)
+		}
+		return "", err
+	}
+	// Stop the decoder, which would otherwise keep its goroutines alive.
+	defer decompressedReader.Close()
+
+	return codeintelutils.ReadIndexerName(decompressedReader)
+}
+
 // handleEnqueueSinglePayload handles a non-multipart upload. This creates an upload record
 // with state 'queued', proxies the data to the bundle manager, and returns the generated ID.
 func (h *UploadHandler) handleEnqueueSinglePayload(r *http.Request, uploadArgs UploadArgs) (_ interface{}, err error) {
@@ -178,26 +225,24 @@ func (h *UploadHandler) handleEnqueueSinglePayload(r *http.Request, uploadArgs U
 	// compatibility on single-payload uploads, as everything else is as new as the version
 	// of src-cli that always sends the indexer name.
 	if uploadArgs.Indexer == "" {
-		// Tee all reads from the body into a buffer so that we don't destructively consume
-		// any data from the body payload.
-		var buf bytes.Buffer
-		teeReader := io.TeeReader(r.Body, &buf)
-
-		gzipReader, err := gzip.NewReader(teeReader)
+		// Read a bounded prefix of the body into memory and look for the indexer name in it. The
+		// decompressor may read ahead of the data it returns from other goroutines, so it must
+		// never read from the body directly.
+		prefix, err := ioutil.ReadAll(io.LimitReader(r.Body, indexerNamePrefixSize))
 		if err != nil {
 			return nil, err
 		}
 
-		name, err := codeintelutils.ReadIndexerName(gzipReader)
+		name, err := readIndexerName(prefix)
 		if err != nil {
 			return nil, err
 		}
 		uploadArgs.Indexer = name
 
 		// Replace the body of the request with a reader that will produce all of the same
-		// content: all of the data that was already read from r.Body, plus the remaining
-		// content from r.Body.
-		r.Body = ioutil.NopCloser(io.MultiReader(bytes.NewReader(buf.Bytes()), r.Body))
+		// content: the prefix that was already read from r.Body, plus the remaining content
+		// from r.Body.
+		r.Body = ioutil.NopCloser(io.MultiReader(bytes.NewReader(prefix), r.Body))
 	}
 
 	tx, err := h.store.Transact(ctx)
@@ -324,6 +369,22 @@ func (h *UploadHandler) handleEnqueueMultipartFinalize(r *http.Request, upload s
 	return nil, nil
 }
 
+// handleEnqueueMultipartStatus returns the state of a multipart upload along with the indexes of
+// the parts that have been received so far. This allows an interrupted upload to be resumed.
+func (h *UploadHandler) handleEnqueueMultipartStatus(upload store.Upload) (interface{}, error) {
+	uploadedParts := upload.UploadedParts
+	if uploadedParts == nil {
+		uploadedParts = []int{}
+	}
+
+	return statusPayload{
+		ID:            fmt.Sprintf("%d", upload.ID),
+		State:         upload.State,
+		NumParts:      upload.NumParts,
+		UploadedParts: uploadedParts,
+	}, nil
+}
+
 func ensureRepoAndCommitExist(ctx context.Context, w http.ResponseWriter, repoName, commit string) (*types.Repo, bool) {
 	repo, err := backend.Repos.GetByName(ctx, api.RepoName(repoName))
 	if err != nil {
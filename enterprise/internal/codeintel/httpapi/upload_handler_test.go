@@ -5,8 +5,10 @@ import (
 	"compress/gzip"
 	"context"
 	"flag"
+	"fmt"
 	"io"
 	"io/ioutil"
+	"math/rand"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
@@ -16,6 +18,7 @@ import (
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/inconshreveable/log15"
+	"github.com/klauspost/compress/zstd"
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
 	bundlemocks "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/bundles/client/mocks"
@@ -179,6 +182,143 @@ func TestHandleEnqueueSinglePayloadNoIndexerName(t *testing.T) {
 	}
 }
 
+func TestHandleEnqueueSinglePayloadNoIndexerNameZstd(t *testing.T) {
+	setupRepoMocks(t)
+
+	mockStore := storemocks.NewMockStore()
+	mockBundleManagerClient := bundlemocks.NewMockBundleManagerClient()
+
+	mockStore.TransactFunc.SetDefaultReturn(mockStore, nil)
+	mockStore.InsertUploadFunc.SetDefaultReturn(42, nil)
+
+	testURL, err := url.Parse("http://test.com/upload")
+	if err != nil {
+		t.Fatalf("unexpected error constructing url: %s", err)
+	}
+	testURL.RawQuery = (url.Values{
+		"commit":     []string{"deadbeef"},
+		"root":       []string{"proj/"},
+		"repository": []string{"github.com/test/test"},
+	}).Encode()
+
+	var buf bytes.Buffer
+	zstdWriter, err := zstd.NewWriter(&buf)
+	if err != nil {
+		t.Fatalf("unexpected error creating zstd writer: %s", err)
+	}
+	_, _ = io.Copy(zstdWriter, strings.NewReader(`{"label": "metaData", "toolInfo": {"name": "lsif-java"}}`))
+	zstdWriter.Close()
+	expectedContents := buf.Bytes()
+
+	w := httptest.NewRecorder()
+	r, err := http.NewRequest("POST", testURL.String(), bytes.NewReader(expectedContents))
+	if err != nil {
+		t.Fatalf("unexpected error constructing request: %s", err)
+	}
+
+	h := &UploadHandler{
+		store:               mockStore,
+		bundleManagerClient: mockBundleManagerClient,
+	}
+	h.handleEnqueue(w, r)
+
+	if w.Code != http.StatusAccepted {
+		t.Errorf("unexpected status code. want=%d have=%d", http.StatusAccepted, w.Code)
+	}
+
+	if len(mockStore.InsertUploadFunc.History()) != 1 {
+		t.Errorf("unexpected number of InsertUploadFunc calls. want=%d have=%d", 1, len(mockStore.InsertUploadFunc.History()))
+	} else if indexer := mockStore.InsertUploadFunc.History()[0].Arg1.Indexer; indexer != "lsif-java" {
+		t.Errorf("unexpected indexer name. want=%q have=%q", "lsif-java", indexer)
+	}
+
+	if len(mockBundleManagerClient.SendUploadFunc.History()) != 1 {
+		t.Errorf("unexpected number of SendUploadFunc calls. want=%d have=%d", 1, len(mockBundleManagerClient.SendUploadFunc.History()))
+	} else {
+		contents, err := ioutil.ReadAll(mockBundleManagerClient.SendUploadFunc.History()[0].Arg2)
+		if err != nil {
+			t.Fatalf("unexpected error reading payload: %s", err)
+		}
+
+		if diff := cmp.Diff(expectedContents, contents); diff != "" {
+			t.Errorf("unexpected file contents (-want +got):\n%s", diff)
+		}
+	}
+}
+
+func TestHandleEnqueueSinglePayloadNoIndexerNameLargeZstd(t *testing.T) {
+	setupRepoMocks(t)
+
+	mockStore := storemocks.NewMockStore()
+	mockBundleManagerClient := bundlemocks.NewMockBundleManagerClient()
+
+	mockStore.TransactFunc.SetDefaultReturn(mockStore, nil)
+	mockStore.InsertUploadFunc.SetDefaultReturn(42, nil)
+
+	testURL, err := url.Parse("http://test.com/upload")
+	if err != nil {
+		t.Fatalf("unexpected error constructing url: %s", err)
+	}
+	testURL.RawQuery = (url.Values{
+		"commit":     []string{"deadbeef"},
+		"root":       []string{"proj/"},
+		"repository": []string{"github.com/test/test"},
+	}).Encode()
+
+	// Write several MiB of poorly compressible lines, so that the payload spans many zstd blocks
+	// and is much larger than the prefix that is read to find the indexer name.
+	var buf bytes.Buffer
+	zstdWriter, err := zstd.NewWriter(&buf)
+	if err != nil {
+		t.Fatalf("unexpected error creating zstd writer: %s", err)
+	}
+	_, _ = io.WriteString(zstdWriter, `{"label": "metaData", "toolInfo": {"name": "lsif-java"}}`+"\n")
+	rng := rand.New(rand.NewSource(0))
+	for i := 0; i < 256*1024; i++ {
+		_, _ = fmt.Fprintf(zstdWriter, `{"id": %d, "type": "vertex", "label": "range", "payload": "%x"}`+"\n", i, rng.Int63())
+	}
+	zstdWriter.Close()
+	expectedContents := buf.Bytes()
+	if len(expectedContents) < 2*indexerNamePrefixSize {
+		t.Fatalf("payload is too small: %d bytes", len(expectedContents))
+	}
+
+	w := httptest.NewRecorder()
+	r, err := http.NewRequest("POST", testURL.String(), bytes.NewReader(expectedContents))
+	if err != nil {
+		t.Fatalf("unexpected error constructing request: %s", err)
+	}
+
+	h := &UploadHandler{
+		store:               mockStore,
+		bundleManagerClient: mockBundleManagerClient,
+	}
+	h.handleEnqueue(w, r)
+
+	if w.Code != http.StatusAccepted {
+		t.Errorf("unexpected status code. want=%d have=%d", http.StatusAccepted, w.Code)
+	}
+
+	if len(mockStore.InsertUploadFunc.History()) != 1 {
+		t.Errorf("unexpected number of InsertUploadFunc calls. want=%d have=%d", 1, len(mockStore.InsertUploadFunc.History()))
+	} else if indexer := mockStore.InsertUploadFunc.History()[0].Arg1.Indexer; indexer != "lsif-java" {
+		t.Errorf("unexpected indexer name. want=%q have=%q", "lsif-java", indexer)
+	}
+
+	if len(mockBundleManagerClient.SendUploadFunc.History()) != 1 {
+		t.Errorf("unexpected number of SendUploadFunc calls. want=%d have=%d", 1, len(mockBundleManagerClient.SendUploadFunc.History()))
+	} else {
+		contents, err := ioutil.ReadAll(mockBundleManagerClient.SendUploadFunc.History()[0].Arg2)
+		if err != nil {
+			t.Fatalf("unexpected error reading payload: %s", err)
+		}
+
+		if !bytes.Equal(expectedContents, contents) {
+			t.Errorf("unexpected file contents: want %d bytes, have %d bytes", len(expectedContents), len(contents))
+		}
+	}
+}
+
 func TestHandleEnqueueMultipartSetup(t *testing.T) {
 	setupRepoMocks(t)
 
@@ -245,6 +385,7 @@ func TestHandleEnqueueMultipartUpload(t *testing.T) {
 
 	upload := store.Upload{
 		ID:            42,
+		State:         "uploading",
 		NumParts:      5,
 		UploadedParts: []int{0, 1, 2, 3, 4},
 	}
@@ -322,6 +463,7 @@ func TestHandleEnqueueMultipartFinalize(t *testing.T) {
 
 	upload := store.Upload{
 		ID:            42,
+		State:         "uploading",
 		NumParts:      5,
 		UploadedParts: []int{0, 1, 2, 3, 4},
 	}
@@ -376,6 +518,7 @@ func TestHandleEnqueueMultipartFinalizeIncompleteUpload(t *testing.T) {
 
 	upload := store.Upload{
 		ID:            42,
+		State:         "uploading",
 		NumParts:      5,
 		UploadedParts: []int{0, 1, 3, 4},
 	}
@@ -407,6 +550,88 @@ func TestHandleEnqueueMultipartFinalizeIncompleteUpload(t *testing.T) {
 	}
 }
 
+func TestHandleEnqueueMultipartUploadNotUploading(t *testing.T) {
+	mockStore := storemocks.NewMockStore()
+	mockBundleManagerClient := bundlemocks.NewMockBundleManagerClient()
+
+	upload := store.Upload{
+		ID:            42,
+		State:         "queued",
+		NumParts:      5,
+		UploadedParts: []int{0, 1, 2, 3, 4},
+	}
+	mockStore.GetUploadByIDFunc.SetDefaultReturn(upload, true, nil)
+
+	testURL, err := url.Parse("http://test.com/upload")
+	if err != nil {
+		t.Fatalf("unexpected error constructing url: %s", err)
+	}
+	testURL.RawQuery = (url.Values{
+		"uploadId": []string{"42"},
+		"index":    []string{"3"},
+	}).Encode()
+
+	w := httptest.NewRecorder()
+	r, err := http.NewRequest("POST", testURL.String(), strings.NewReader("payload"))
+	if err != nil {
+		t.Fatalf("unexpected error constructing request: %s", err)
+	}
+
+	h := &UploadHandler{
+		store:               mockStore,
+		bundleManagerClient: mockBundleManagerClient,
+	}
+	h.handleEnqueue(w, r)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("unexpected status code. want=%d have=%d", http.StatusBadRequest, w.Code)
+	}
+	if len(mockBundleManagerClient.SendUploadPartFunc.History()) != 0 {
+		t.Errorf("unexpected number of SendUploadPartFunc calls. want=%d have=%d", 0, len(mockBundleManagerClient.SendUploadPartFunc.History()))
+	}
+}
+
+func TestHandleEnqueueMultipartStatus(t *testing.T) {
+	mockStore := storemocks.NewMockStore()
+	mockBundleManagerClient := bundlemocks.NewMockBundleManagerClient()
+
+	upload := store.Upload{
+		ID:            42,
+		State:         "uploading",
+		NumParts:      5,
+		UploadedParts: []int{0, 1, 3},
+	}
+	mockStore.GetUploadByIDFunc.SetDefaultReturn(upload, true, nil)
+
+	testURL, err := url.Parse("http://test.com/upload")
+	if err != nil {
+		t.Fatalf("unexpected error constructing url: %s", err)
+	}
+	testURL.RawQuery = (url.Values{
+		"uploadId": []string{"42"},
+		"status":   []string{"true"},
+	}).Encode()
+
+	w := httptest.NewRecorder()
+	r, err := http.NewRequest("POST", testURL.String(), nil)
+	if err != nil {
+		t.Fatalf("unexpected error constructing request: %s", err)
+	}
+
+	h := &UploadHandler{
+		store:               mockStore,
+		bundleManagerClient: mockBundleManagerClient,
+	}
+	h.handleEnqueue(w, r)
+
+	if w.Code != http.StatusAccepted {
+		t.Errorf("unexpected status code. want=%d have=%d", http.StatusAccepted, w.Code)
+	}
+	if diff := cmp.Diff(`{"id":"42","state":"uploading","numParts":5,"uploadedParts":[0,1,3]}`, w.Body.String()); diff != "" {
+		t.Errorf("unexpected response payload (-want +got):\n%s", diff)
+	}
+}
+
 func setupRepoMocks(t *testing.T) {
 	t.Cleanup(func() {
 		backend.Mocks.Repos.GetByName = nil
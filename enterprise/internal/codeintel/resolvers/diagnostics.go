@@ -0,0 +1,74 @@
+package resolvers
+
+import (
+	"context"
+	"sync"
+
+	bundles "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/bundles/client"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/store"
+)
+
+// DiagnosticsResolver wraps store.GetDiagnostics so that the underlying function can be
+// invoked lazily and its results memoized.
+type DiagnosticsResolver struct {
+	store store.Store
+	opts  store.GetDiagnosticsOptions
+	once  sync.Once
+	//
+	Diagnostics []AdjustedDiagnostic
+	TotalCount  int
+	NextOffset  *int
+	err         error
+}
+
+// NewDiagnosticsResolver creates a new DiagnosticsResolver which wil invoke store.GetDiagnostics
+// with the given options.
+func NewDiagnosticsResolver(store store.Store, opts store.GetDiagnosticsOptions) *DiagnosticsResolver {
+	return &DiagnosticsResolver{store: store, opts: opts}
+}
+
+// Resolve ensures that store.GetDiagnostics has been invoked. This function returns the
+// error from the invocation, if any. If the error is nil, then the resolver's Diagnostics,
+// TotalCount, and NextOffset fields will be populated.
+func (r *DiagnosticsResolver) Resolve(ctx context.Context) error {
+	r.once.Do(func() { r.err = r.resolve(ctx) })
+	return r.err
+}
+
+func (r *DiagnosticsResolver) resolve(ctx context.Context) error {
+	diagnostics, totalCount, err := r.store.GetDiagnostics(ctx, r.opts)
+	if err != nil {
+		return err
+	}
+
+	// Indexed diagnostics are reported by dumps visible at the tip of the default branch,
+	// so their locations are given relative to the dump's commit without adjustment.
+	adjustedDiagnostics := make([]AdjustedDiagnostic, 0, len(diagnostics))
+	for _, diagnostic := range diagnostics {
+		adjustedDiagnostics = append(adjustedDiagnostics, AdjustedDiagnostic{
+			Diagnostic: bundles.Diagnostic{
+				DumpID:         diagnostic.Dump.ID,
+				Path:           diagnostic.Dump.Root + diagnostic.Path,
+				Severity:       diagnostic.Severity,
+				Code:           diagnostic.Code,
+				Message:        diagnostic.Message,
+				Source:         diagnostic.Source,
+				StartLine:      diagnostic.StartLine,
+				StartCharacter: diagnostic.StartCharacter,
+				EndLine:        diagnostic.EndLine,
+				EndCharacter:   diagnostic.EndCharacter,
+			},
+			Dump:           diagnostic.Dump,
+			AdjustedCommit: diagnostic.Dump.Commit,
+			AdjustedRange: bundles.Range{
+				Start: bundles.Position{Line: diagnostic.StartLine, Character: diagnostic.StartCharacter},
+				End:   bundles.Position{Line: diagnostic.EndLine, Character: diagnostic.EndCharacter},
+			},
+		})
+	}
+
+	r.Diagnostics = adjustedDiagnostics
+	r.NextOffset = nextOffset(r.opts.Offset, len(diagnostics), totalCount)
+	r.TotalCount = totalCount
+	return nil
+}
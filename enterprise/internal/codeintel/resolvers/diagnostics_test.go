@@ -0,0 +1,71 @@
+package resolvers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	bundles "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/bundles/client"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/bundles/types"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/store"
+	storemocks "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/store/mocks"
+)
+
+func TestDiagnosticsResolver(t *testing.T) {
+	mockStore := storemocks.NewMockStore()
+
+	dump := store.Dump{ID: 42, Commit: "deadbeef", Root: "sub/", RepositoryID: 50}
+	mockStore.GetDiagnosticsFunc.SetDefaultReturn([]store.IndexedDiagnostic{
+		{
+			Dump: dump,
+			Path: "main.go",
+			DiagnosticData: types.DiagnosticData{
+				Severity:       2,
+				Code:           "SA1019",
+				Source:         "staticcheck",
+				Message:        "deprecated",
+				StartLine:      1,
+				StartCharacter: 2,
+				EndLine:        3,
+				EndCharacter:   4,
+			},
+		},
+	}, 3, nil)
+
+	resolver := NewDiagnosticsResolver(mockStore, store.GetDiagnosticsOptions{Limit: 1, Offset: 1})
+	if err := resolver.Resolve(context.Background()); err != nil {
+		t.Fatalf("unexpected error resolving diagnostics: %s", err)
+	}
+
+	expected := []AdjustedDiagnostic{
+		{
+			Diagnostic: bundles.Diagnostic{
+				DumpID:         42,
+				Path:           "sub/main.go",
+				Severity:       2,
+				Code:           "SA1019",
+				Source:         "staticcheck",
+				Message:        "deprecated",
+				StartLine:      1,
+				StartCharacter: 2,
+				EndLine:        3,
+				EndCharacter:   4,
+			},
+			Dump:           dump,
+			AdjustedCommit: "deadbeef",
+			AdjustedRange: bundles.Range{
+				Start: bundles.Position{Line: 1, Character: 2},
+				End:   bundles.Position{Line: 3, Character: 4},
+			},
+		},
+	}
+	if diff := cmp.Diff(expected, resolver.Diagnostics); diff != "" {
+		t.Errorf("unexpected diagnostics (-want +got):\n%s", diff)
+	}
+	if resolver.TotalCount != 3 {
+		t.Errorf("unexpected total count. want=%d have=%d", 3, resolver.TotalCount)
+	}
+	if resolver.NextOffset == nil || *resolver.NextOffset != 2 {
+		t.Errorf("unexpected next offset. want=%d have=%v", 2, resolver.NextOffset)
+	}
+}
@@ -53,3 +53,19 @@ func toSeverity(val int) (*string, error) {
 
 	return &severity, nil
 }
+
+// fromSeverity returns the integer value of the given severity name. The empty string
+// returns zero.
+func fromSeverity(val string) (int, error) {
+	if val == "" {
+		return 0, nil
+	}
+
+	for severity, name := range severities {
+		if name == val {
+			return severity, nil
+		}
+	}
+
+	return 0, fmt.Errorf("unknown diagnostic severity %q", val)
+}
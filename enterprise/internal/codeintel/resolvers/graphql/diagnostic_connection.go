@@ -6,6 +6,9 @@ import (
 	gql "github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend/graphqlutil"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/resolvers"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/db"
 )
 
 type DiagnosticConnectionResolver struct {
@@ -23,8 +26,24 @@ func NewDiagnosticConnectionResolver(diagnostics []resolvers.AdjustedDiagnostic,
 }
 
 func (r *DiagnosticConnectionResolver) Nodes(ctx context.Context) ([]gql.DiagnosticResolver, error) {
+	subRepoPerms := map[int]*authz.SubRepoPermsMatcher{}
+
 	resolvers := make([]gql.DiagnosticResolver, 0, len(r.diagnostics))
 	for i := range r.diagnostics {
+		// 🚨 SECURITY: Omit diagnostics in paths the user cannot read.
+		repositoryID := r.diagnostics[i].Dump.RepositoryID
+		m, ok := subRepoPerms[repositoryID]
+		if !ok {
+			var err error
+			if m, err = db.SubRepoPermsForActor(ctx, api.RepoID(repositoryID)); err != nil {
+				return nil, err
+			}
+			subRepoPerms[repositoryID] = m
+		}
+		if !m.CanReadFile(r.diagnostics[i].Path) {
+			continue
+		}
+
 		resolvers = append(resolvers, NewDiagnosticResolver(r.diagnostics[i], r.locationResolver))
 	}
 	return resolvers, nil
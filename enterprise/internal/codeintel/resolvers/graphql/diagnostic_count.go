@@ -0,0 +1,36 @@
+package graphql
+
+import (
+	"context"
+
+	gql "github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/resolvers"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/store"
+)
+
+type DiagnosticCountResolver struct {
+	count            store.DiagnosticCount
+	resolver         resolvers.Resolver
+	locationResolver *CachedLocationResolver
+}
+
+func NewDiagnosticCountResolver(count store.DiagnosticCount, resolver resolvers.Resolver, locationResolver *CachedLocationResolver) gql.LSIFDiagnosticCountResolver {
+	return &DiagnosticCountResolver{
+		count:            count,
+		resolver:         resolver,
+		locationResolver: locationResolver,
+	}
+}
+
+func (r *DiagnosticCountResolver) Commit() string           { return r.count.Commit }
+func (r *DiagnosticCountResolver) FinishedAt() gql.DateTime { return gql.DateTime{Time: r.count.FinishedAt} }
+func (r *DiagnosticCountResolver) Count() int32             { return int32(r.count.Count) }
+
+func (r *DiagnosticCountResolver) Upload(ctx context.Context) (gql.LSIFUploadResolver, error) {
+	upload, exists, err := r.resolver.GetUploadByID(ctx, r.count.UploadID)
+	if err != nil || !exists {
+		return nil, err
+	}
+
+	return NewUploadResolver(upload, r.locationResolver), nil
+}
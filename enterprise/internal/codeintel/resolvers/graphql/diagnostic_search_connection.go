@@ -0,0 +1,49 @@
+package graphql
+
+import (
+	"context"
+
+	gql "github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend/graphqlutil"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/resolvers"
+)
+
+// DiagnosticSearchConnectionResolver resolves a page of indexed diagnostics. Unlike the
+// DiagnosticConnectionResolver, the page info of this connection carries a cursor.
+type DiagnosticSearchConnectionResolver struct {
+	resolver         *resolvers.DiagnosticsResolver
+	locationResolver *CachedLocationResolver
+}
+
+func NewDiagnosticSearchConnectionResolver(resolver *resolvers.DiagnosticsResolver, locationResolver *CachedLocationResolver) gql.DiagnosticConnectionResolver {
+	return &DiagnosticSearchConnectionResolver{
+		resolver:         resolver,
+		locationResolver: locationResolver,
+	}
+}
+
+func (r *DiagnosticSearchConnectionResolver) Nodes(ctx context.Context) ([]gql.DiagnosticResolver, error) {
+	if err := r.resolver.Resolve(ctx); err != nil {
+		return nil, err
+	}
+
+	resolvers := make([]gql.DiagnosticResolver, 0, len(r.resolver.Diagnostics))
+	for i := range r.resolver.Diagnostics {
+		resolvers = append(resolvers, NewDiagnosticResolver(r.resolver.Diagnostics[i], r.locationResolver))
+	}
+	return resolvers, nil
+}
+
+func (r *DiagnosticSearchConnectionResolver) TotalCount(ctx context.Context) (int32, error) {
+	if err := r.resolver.Resolve(ctx); err != nil {
+		return 0, err
+	}
+	return int32(r.resolver.TotalCount), nil
+}
+
+func (r *DiagnosticSearchConnectionResolver) PageInfo(ctx context.Context) (*graphqlutil.PageInfo, error) {
+	if err := r.resolver.Resolve(ctx); err != nil {
+		return nil, err
+	}
+	return encodeIntCursor(toInt32(r.resolver.NextOffset)), nil
+}
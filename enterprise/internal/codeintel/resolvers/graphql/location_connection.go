@@ -6,11 +6,14 @@ import (
 	gql "github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend/graphqlutil"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/resolvers"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/store"
+	"github.com/sourcegraph/sourcegraph/internal/api"
 )
 
 type LocationConnectionResolver struct {
 	locations        []resolvers.AdjustedLocation
 	cursor           *string
+	repositoryCounts func(ctx context.Context) ([]store.RepositoryReferenceCount, error)
 	locationResolver *CachedLocationResolver
 }
 
@@ -29,3 +32,41 @@ func (r *LocationConnectionResolver) Nodes(ctx context.Context) ([]gql.LocationR
 func (r *LocationConnectionResolver) PageInfo(ctx context.Context) (*graphqlutil.PageInfo, error) {
 	return encodeCursor(r.cursor), nil
 }
+
+func (r *LocationConnectionResolver) RepositoryCounts(ctx context.Context) (*[]gql.RepositoryReferenceCountResolver, error) {
+	if r.repositoryCounts == nil {
+		return nil, nil
+	}
+
+	counts, err := r.repositoryCounts(ctx)
+	if err != nil || len(counts) == 0 {
+		return nil, err
+	}
+
+	resolvers := make([]gql.RepositoryReferenceCountResolver, 0, len(counts))
+	for _, count := range counts {
+		repositoryResolver, err := r.locationResolver.Repository(ctx, api.RepoID(count.RepositoryID))
+		if err != nil {
+			return nil, err
+		}
+		if repositoryResolver == nil {
+			// Repository has been deleted since the dump was indexed
+			continue
+		}
+
+		resolvers = append(resolvers, &RepositoryReferenceCountResolver{
+			repository: repositoryResolver,
+			count:      count.Count,
+		})
+	}
+
+	return &resolvers, nil
+}
+
+type RepositoryReferenceCountResolver struct {
+	repository *gql.RepositoryResolver
+	count      int
+}
+
+func (r *RepositoryReferenceCountResolver) Repository() *gql.RepositoryResolver { return r.repository }
+func (r *RepositoryReferenceCountResolver) Count() int32                        { return int32(r.count) }
@@ -8,6 +8,7 @@ import (
 	gql "github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/resolvers"
 	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/db"
 	"github.com/sourcegraph/sourcegraph/internal/errcode"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver"
 	"github.com/sourcegraph/sourcegraph/internal/vcs/git"
@@ -68,7 +69,8 @@ func (r *CachedLocationResolver) Commit(ctx context.Context, id api.RepoID, comm
 }
 
 // Path resolves the git tree entry with the given repository identifier, commit hash, and relative path.
-// This method may return a nil resolver if the commit is not known by gitserver.
+// This method may return a nil resolver if the commit is not known by gitserver or if the current user
+// cannot read the path.
 func (r *CachedLocationResolver) Path(ctx context.Context, id api.RepoID, commit, path string) (*gql.GitTreeEntryResolver, error) {
 	pathResolver, err := r.cachedPath(ctx, id, commit, path)
 	if err != nil {
@@ -231,9 +233,19 @@ func (r *CachedLocationResolver) resolveCommit(ctx context.Context, repositoryRe
 	return repositoryResolver.CommitFromID(ctx, &gql.RepositoryCommitArgs{Rev: commit}, commitID)
 }
 
-// Path resolves the git tree entry with the given commit resolver and relative path. This method must be
-// called only when constructing a resolver to populate the cache.
+// Path resolves the git tree entry with the given commit resolver and relative path. This method may
+// return a nil resolver if the current user cannot read the path. This method must be called only when
+// constructing a resolver to populate the cache.
 func (r *CachedLocationResolver) resolvePath(ctx context.Context, commitResolver *gql.GitCommitResolver, path string) (*gql.GitTreeEntryResolver, error) {
+	// 🚨 SECURITY: Omit locations in paths the user cannot read.
+	subRepoPerms, err := db.SubRepoPermsForActor(ctx, commitResolver.Repository().Type().ID)
+	if err != nil {
+		return nil, err
+	}
+	if !subRepoPerms.CanReadFile(path) {
+		return nil, nil
+	}
+
 	return gql.NewGitTreeEntryResolver(commitResolver, gql.CreateFileInfo(path, true)), nil
 }
 
@@ -258,7 +270,8 @@ func resolveLocations(ctx context.Context, locationResolver *CachedLocationResol
 }
 
 // resolveLocation creates a LocationResolver for the given adjusted location. This function may return a
-// nil resolver if the location's commit is not known by gitserver.
+// nil resolver if the location's commit is not known by gitserver or if the current user cannot read the
+// location's path.
 func resolveLocation(ctx context.Context, locationResolver *CachedLocationResolver, location resolvers.AdjustedLocation) (gql.LocationResolver, error) {
 	treeResolver, err := locationResolver.Path(ctx, api.RepoID(location.Dump.RepositoryID), location.AdjustedCommit, location.Path)
 	if err != nil || treeResolver == nil {
@@ -6,6 +6,7 @@ import (
 	"github.com/pkg/errors"
 	gql "github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/resolvers"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/store"
 )
 
 // DefaultReferencesPageSize is the reference result page size when no limit is supplied.
@@ -77,12 +78,19 @@ func (r *QueryResolver) References(ctx context.Context, args *gql.LSIFPagedQuery
 		return nil, err
 	}
 
-	locations, cursor, err := r.resolver.References(ctx, int(args.Line), int(args.Character), limit, cursor)
+	locations, cursor, err := r.resolver.References(ctx, int(args.Line), int(args.Character), limit, args.AllVersions, cursor)
 	if err != nil {
 		return nil, err
 	}
 
-	return NewLocationConnectionResolver(locations, strPtr(cursor), r.locationResolver), nil
+	return &LocationConnectionResolver{
+		locations: locations,
+		cursor:    strPtr(cursor),
+		repositoryCounts: func(ctx context.Context) ([]store.RepositoryReferenceCount, error) {
+			return r.resolver.ReferenceCounts(ctx, int(args.Line), int(args.Character), args.AllVersions)
+		},
+		locationResolver: r.locationResolver,
+	}, nil
 }
 
 func (r *QueryResolver) Hover(ctx context.Context, args *gql.LSIFQueryPositionArgs) (gql.HoverResolver, error) {
@@ -65,6 +65,7 @@ func TestReferences(t *testing.T) {
 		},
 		ConnectionArgs: graphqlutil.ConnectionArgs{First: &offset},
 		After:          &cursor,
+		AllVersions:    true,
 	}
 
 	if _, err := resolver.References(context.Background(), args); err != nil {
@@ -83,11 +84,47 @@ func TestReferences(t *testing.T) {
 	if val := mockResolver.ReferencesFunc.History()[0].Arg3; val != 25 {
 		t.Fatalf("unexpected character. want=%d have=%d", 25, val)
 	}
-	if val := mockResolver.ReferencesFunc.History()[0].Arg4; val != "test-cursor" {
+	if val := mockResolver.ReferencesFunc.History()[0].Arg4; !val {
+		t.Fatalf("unexpected all versions. want=%v have=%v", true, val)
+	}
+	if val := mockResolver.ReferencesFunc.History()[0].Arg5; val != "test-cursor" {
 		t.Fatalf("unexpected character. want=%s have=%s", "test-cursor", val)
 	}
 }
 
+func TestReferencesRepositoryCounts(t *testing.T) {
+	mockResolver := resolvermocks.NewMockQueryResolver()
+	resolver := NewQueryResolver(mockResolver, NewCachedLocationResolver())
+
+	args := &gql.LSIFPagedQueryPositionArgs{
+		LSIFQueryPositionArgs: gql.LSIFQueryPositionArgs{
+			Line:      10,
+			Character: 15,
+		},
+		AllVersions: true,
+	}
+
+	connection, err := resolver.References(context.Background(), args)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	counts, err := connection.RepositoryCounts(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if counts != nil {
+		t.Errorf("unexpected counts. want=%v have=%v", nil, *counts)
+	}
+
+	if len(mockResolver.ReferenceCountsFunc.History()) != 1 {
+		t.Fatalf("unexpected call count. want=%d have=%d", 1, len(mockResolver.ReferenceCountsFunc.History()))
+	}
+	if call := mockResolver.ReferenceCountsFunc.History()[0]; call.Arg1 != 10 || call.Arg2 != 15 || !call.Arg3 {
+		t.Fatalf("unexpected arguments. want=(%d, %d, %v) have=(%d, %d, %v)", 10, 15, true, call.Arg1, call.Arg2, call.Arg3)
+	}
+}
+
 func TestReferencesDefaultLimit(t *testing.T) {
 	mockResolver := resolvermocks.NewMockQueryResolver()
 	resolver := NewQueryResolver(mockResolver, NewCachedLocationResolver())
@@ -13,6 +13,7 @@ import (
 
 const DefaultUploadPageSize = 50
 const DefaultIndexPageSize = 50
+const DefaultDiagnosticPageSize = 50
 
 // Resolver is the main interface to code intel-related operations exposted to the GraphQL API. This
 // resolver concerns itself with GraphQL/API-specific behaviors (auth, validation, marshaling, etc.).
@@ -77,6 +78,73 @@ func (r *Resolver) DeleteLSIFUpload(ctx context.Context, id graphql.ID) (*gql.Em
 	return &gql.EmptyResponse{}, nil
 }
 
+func (r *Resolver) LSIFUploadRetentionByRepo(ctx context.Context, id graphql.ID) ([]gql.LSIFUploadRetentionResolver, error) {
+	// 🚨 SECURITY: Only site admins may view retention policy evaluations for now
+	if err := backend.CheckCurrentUserIsSiteAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	repositoryID, err := resolveRepositoryID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	retentions, err := r.resolver.UploadRetention(ctx, repositoryID)
+	if err != nil {
+		return nil, err
+	}
+
+	resolvers := make([]gql.LSIFUploadRetentionResolver, 0, len(retentions))
+	for _, retention := range retentions {
+		resolvers = append(resolvers, NewUploadRetentionResolver(retention, r.locationResolver))
+	}
+
+	return resolvers, nil
+}
+
+func (r *Resolver) LSIFDiagnostics(ctx context.Context, args *gql.LSIFDiagnosticsQueryArgs) (gql.DiagnosticConnectionResolver, error) {
+	// 🚨 SECURITY: Only site admins may search diagnostics across all repositories for now
+	if err := backend.CheckCurrentUserIsSiteAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	// Delegate behavior to LSIFDiagnosticsByRepo with no specified repository identifier
+	return r.LSIFDiagnosticsByRepo(ctx, &gql.LSIFRepositoryDiagnosticsQueryArgs{LSIFDiagnosticsQueryArgs: args})
+}
+
+func (r *Resolver) LSIFDiagnosticsByRepo(ctx context.Context, args *gql.LSIFRepositoryDiagnosticsQueryArgs) (gql.DiagnosticConnectionResolver, error) {
+	opts, err := makeGetDiagnosticsOptions(ctx, args)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewDiagnosticSearchConnectionResolver(r.resolver.DiagnosticConnectionResolver(opts), r.locationResolver), nil
+}
+
+func (r *Resolver) LSIFDiagnosticCountsByRepo(ctx context.Context, id graphql.ID, args *gql.LSIFDiagnosticFilterArgs) ([]gql.LSIFDiagnosticCountResolver, error) {
+	repositoryID, err := resolveRepositoryID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	filter, err := makeDiagnosticFilter(args)
+	if err != nil {
+		return nil, err
+	}
+
+	counts, err := r.resolver.DiagnosticCounts(ctx, repositoryID, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	resolvers := make([]gql.LSIFDiagnosticCountResolver, 0, len(counts))
+	for _, count := range counts {
+		resolvers = append(resolvers, NewDiagnosticCountResolver(count, r.resolver, r.locationResolver))
+	}
+
+	return resolvers, nil
+}
+
 func (r *Resolver) LSIFIndexByID(ctx context.Context, id graphql.ID) (gql.LSIFIndexResolver, error) {
 	indexID, err := unmarshalLSIFIndexGQLID(id)
 	if err != nil {
@@ -177,6 +245,46 @@ func makeGetIndexesOptions(ctx context.Context, args *gql.LSIFRepositoryIndexesQ
 	}, nil
 }
 
+// makeGetDiagnosticsOptions translates the given GraphQL arguments into options defined by the
+// store.GetDiagnostics operations.
+func makeGetDiagnosticsOptions(ctx context.Context, args *gql.LSIFRepositoryDiagnosticsQueryArgs) (store.GetDiagnosticsOptions, error) {
+	repositoryID, err := resolveRepositoryID(ctx, args.RepositoryID)
+	if err != nil {
+		return store.GetDiagnosticsOptions{}, err
+	}
+
+	filter, err := makeDiagnosticFilter(&args.LSIFDiagnosticFilterArgs)
+	if err != nil {
+		return store.GetDiagnosticsOptions{}, err
+	}
+
+	offset, err := decodeIntCursor(args.After)
+	if err != nil {
+		return store.GetDiagnosticsOptions{}, err
+	}
+
+	return store.GetDiagnosticsOptions{
+		DiagnosticFilter: filter,
+		RepositoryID:     repositoryID,
+		Limit:            derefInt32(args.First, DefaultDiagnosticPageSize),
+		Offset:           offset,
+	}, nil
+}
+
+// makeDiagnosticFilter translates the given GraphQL arguments into a store.DiagnosticFilter.
+func makeDiagnosticFilter(args *gql.LSIFDiagnosticFilterArgs) (store.DiagnosticFilter, error) {
+	severity, err := fromSeverity(derefString(args.Severity, ""))
+	if err != nil {
+		return store.DiagnosticFilter{}, err
+	}
+
+	return store.DiagnosticFilter{
+		Severity: severity,
+		Source:   derefString(args.Source, ""),
+		Code:     derefString(args.Code, ""),
+	}, nil
+}
+
 // resolveRepositoryByID gets a repository's internal identifier from a GraphQL identifier.
 func resolveRepositoryID(ctx context.Context, id graphql.ID) (int, error) {
 	if id == "" {
@@ -11,6 +11,7 @@ import (
 	gql "github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend/graphqlutil"
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/resolvers"
 	resolvermocks "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/resolvers/mocks"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/store"
 	"github.com/sourcegraph/sourcegraph/internal/api"
@@ -81,6 +82,163 @@ func TestDeleteLSIFIndexUnauthenticated(t *testing.T) {
 	}
 }
 
+func TestLSIFUploadRetentionByRepo(t *testing.T) {
+	t.Cleanup(func() {
+		db.Mocks.Users.GetByCurrentAuthUser = nil
+		db.Mocks.Repos.Get = nil
+	})
+	db.Mocks.Users.GetByCurrentAuthUser = func(ctx context.Context) (*types.User, error) {
+		return &types.User{SiteAdmin: true}, nil
+	}
+	db.Mocks.Repos.Get = func(v0 context.Context, id api.RepoID) (*types.Repo, error) {
+		return &types.Repo{ID: id}, nil
+	}
+
+	mockResolver := resolvermocks.NewMockResolver()
+	mockResolver.UploadRetentionFunc.SetDefaultReturn([]resolvers.UploadRetention{
+		{Upload: store.Upload{ID: 1}, RetainedBy: []string{"release tags"}, Evaluated: true},
+		{Upload: store.Upload{ID: 2}, RetainedBy: []string{}, Evaluated: true},
+		{Upload: store.Upload{ID: 3}},
+	}, nil)
+
+	id := graphql.ID(base64.StdEncoding.EncodeToString([]byte("Repo:50")))
+	retentions, err := NewResolver(mockResolver).LSIFUploadRetentionByRepo(context.Background(), id)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if len(mockResolver.UploadRetentionFunc.History()) != 1 {
+		t.Fatalf("unexpected call count. want=%d have=%d", 1, len(mockResolver.UploadRetentionFunc.History()))
+	}
+	if val := mockResolver.UploadRetentionFunc.History()[0].Arg1; val != 50 {
+		t.Fatalf("unexpected repository id. want=%d have=%d", 50, val)
+	}
+
+	if len(retentions) != 3 {
+		t.Fatalf("unexpected number of results. want=%d have=%d", 3, len(retentions))
+	}
+	if retentions[0].Expired() || !retentions[1].Expired() || retentions[2].Expired() {
+		t.Errorf("unexpected expired flags. want=[false true false] have=[%v %v %v]", retentions[0].Expired(), retentions[1].Expired(), retentions[2].Expired())
+	}
+	if retentions[2].Evaluated() || len(retentions[2].RetainedBy()) != 0 {
+		t.Errorf("unexpected retention of unevaluated upload. evaluated=%v retainedBy=%v", retentions[2].Evaluated(), retentions[2].RetainedBy())
+	}
+	if diff := cmp.Diff([]string{"release tags"}, retentions[0].RetainedBy()); diff != "" {
+		t.Errorf("unexpected retained by (-want +got):\n%s", diff)
+	}
+}
+
+func TestLSIFUploadRetentionByRepoUnauthenticated(t *testing.T) {
+	id := graphql.ID(base64.StdEncoding.EncodeToString([]byte("Repo:50")))
+	mockResolver := resolvermocks.NewMockResolver()
+
+	if _, err := NewResolver(mockResolver).LSIFUploadRetentionByRepo(context.Background(), id); err != backend.ErrNotAuthenticated {
+		t.Errorf("unexpected error. want=%q have=%q", backend.ErrNotAuthenticated, err)
+	}
+}
+
+func TestLSIFDiagnosticsUnauthenticated(t *testing.T) {
+	mockResolver := resolvermocks.NewMockResolver()
+
+	if _, err := NewResolver(mockResolver).LSIFDiagnostics(context.Background(), &gql.LSIFDiagnosticsQueryArgs{}); err != backend.ErrNotAuthenticated {
+		t.Errorf("unexpected error. want=%q have=%q", backend.ErrNotAuthenticated, err)
+	}
+}
+
+func TestLSIFDiagnosticCountsByRepo(t *testing.T) {
+	t.Cleanup(func() {
+		db.Mocks.Repos.Get = nil
+	})
+	db.Mocks.Repos.Get = func(v0 context.Context, id api.RepoID) (*types.Repo, error) {
+		return &types.Repo{ID: id}, nil
+	}
+
+	mockResolver := resolvermocks.NewMockResolver()
+	mockResolver.DiagnosticCountsFunc.SetDefaultReturn([]store.DiagnosticCount{
+		{UploadID: 1, Commit: "deadbeef", Count: 3},
+		{UploadID: 2, Commit: "cafebabe", Count: 1},
+	}, nil)
+
+	id := graphql.ID(base64.StdEncoding.EncodeToString([]byte("Repo:50")))
+	counts, err := NewResolver(mockResolver).LSIFDiagnosticCountsByRepo(context.Background(), id, &gql.LSIFDiagnosticFilterArgs{
+		Severity: strPtr("WARNING"),
+		Source:   strPtr("staticcheck"),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if len(mockResolver.DiagnosticCountsFunc.History()) != 1 {
+		t.Fatalf("unexpected call count. want=%d have=%d", 1, len(mockResolver.DiagnosticCountsFunc.History()))
+	}
+	call := mockResolver.DiagnosticCountsFunc.History()[0]
+	if call.Arg1 != 50 {
+		t.Errorf("unexpected repository id. want=%d have=%d", 50, call.Arg1)
+	}
+	if diff := cmp.Diff(store.DiagnosticFilter{Severity: 2, Source: "staticcheck"}, call.Arg2); diff != "" {
+		t.Errorf("unexpected filter (-want +got):\n%s", diff)
+	}
+
+	if len(counts) != 2 {
+		t.Fatalf("unexpected number of results. want=%d have=%d", 2, len(counts))
+	}
+	if counts[0].Commit() != "deadbeef" || counts[0].Count() != 3 {
+		t.Errorf("unexpected count. want=(%s, %d) have=(%s, %d)", "deadbeef", 3, counts[0].Commit(), counts[0].Count())
+	}
+}
+
+func TestMakeGetDiagnosticsOptions(t *testing.T) {
+	t.Cleanup(func() {
+		db.Mocks.Repos.Get = nil
+	})
+	db.Mocks.Repos.Get = func(v0 context.Context, id api.RepoID) (*types.Repo, error) {
+		return &types.Repo{ID: id}, nil
+	}
+
+	opts, err := makeGetDiagnosticsOptions(context.Background(), &gql.LSIFRepositoryDiagnosticsQueryArgs{
+		LSIFDiagnosticsQueryArgs: &gql.LSIFDiagnosticsQueryArgs{
+			ConnectionArgs: graphqlutil.ConnectionArgs{
+				First: intPtr(5),
+			},
+			LSIFDiagnosticFilterArgs: gql.LSIFDiagnosticFilterArgs{
+				Severity: strPtr("ERROR"),
+				Source:   strPtr("staticcheck"),
+				Code:     strPtr("SA1019"),
+			},
+			After: encodeIntCursor(intPtr(25)).EndCursor(),
+		},
+		RepositoryID: graphql.ID(base64.StdEncoding.EncodeToString([]byte("Repo:50"))),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error making options: %s", err)
+	}
+
+	expected := store.GetDiagnosticsOptions{
+		DiagnosticFilter: store.DiagnosticFilter{
+			Severity: 1,
+			Source:   "staticcheck",
+			Code:     "SA1019",
+		},
+		RepositoryID: 50,
+		Limit:        5,
+		Offset:       25,
+	}
+	if diff := cmp.Diff(expected, opts); diff != "" {
+		t.Errorf("unexpected opts (-want +got):\n%s", diff)
+	}
+}
+
+func TestMakeGetDiagnosticsOptionsInvalidSeverity(t *testing.T) {
+	_, err := makeGetDiagnosticsOptions(context.Background(), &gql.LSIFRepositoryDiagnosticsQueryArgs{
+		LSIFDiagnosticsQueryArgs: &gql.LSIFDiagnosticsQueryArgs{
+			LSIFDiagnosticFilterArgs: gql.LSIFDiagnosticFilterArgs{Severity: strPtr("FATAL")},
+		},
+	})
+	if err == nil {
+		t.Fatalf("expected error making options")
+	}
+}
+
 func TestMakeGetUploadsOptions(t *testing.T) {
 	t.Cleanup(func() {
 		db.Mocks.Repos.Get = nil
@@ -0,0 +1,32 @@
+package graphql
+
+import (
+	gql "github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/resolvers"
+)
+
+type UploadRetentionResolver struct {
+	retention        resolvers.UploadRetention
+	locationResolver *CachedLocationResolver
+}
+
+func NewUploadRetentionResolver(retention resolvers.UploadRetention, locationResolver *CachedLocationResolver) gql.LSIFUploadRetentionResolver {
+	return &UploadRetentionResolver{
+		retention:        retention,
+		locationResolver: locationResolver,
+	}
+}
+
+func (r *UploadRetentionResolver) Evaluated() bool { return r.retention.Evaluated }
+func (r *UploadRetentionResolver) Expired() bool   { return r.retention.Expired() }
+
+func (r *UploadRetentionResolver) RetainedBy() []string {
+	if r.retention.RetainedBy == nil {
+		return []string{}
+	}
+	return r.retention.RetainedBy
+}
+
+func (r *UploadRetentionResolver) Upload() gql.LSIFUploadResolver {
+	return NewUploadResolver(r.retention.Upload, r.locationResolver)
+}
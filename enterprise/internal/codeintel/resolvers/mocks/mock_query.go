@@ -6,6 +6,7 @@ import (
 	"context"
 	client "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/bundles/client"
 	resolvers "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/resolvers"
+	store "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/store"
 	"sync"
 )
 
@@ -26,6 +27,9 @@ type MockQueryResolver struct {
 	// RangesFunc is an instance of a mock function object controlling the
 	// behavior of the method Ranges.
 	RangesFunc *QueryResolverRangesFunc
+	// ReferenceCountsFunc is an instance of a mock function object
+	// controlling the behavior of the method ReferenceCounts.
+	ReferenceCountsFunc *QueryResolverReferenceCountsFunc
 	// ReferencesFunc is an instance of a mock function object controlling
 	// the behavior of the method References.
 	ReferencesFunc *QueryResolverReferencesFunc
@@ -55,8 +59,13 @@ func NewMockQueryResolver() *MockQueryResolver {
 				return nil, nil
 			},
 		},
+		ReferenceCountsFunc: &QueryResolverReferenceCountsFunc{
+			defaultHook: func(context.Context, int, int, bool) ([]store.RepositoryReferenceCount, error) {
+				return nil, nil
+			},
+		},
 		ReferencesFunc: &QueryResolverReferencesFunc{
-			defaultHook: func(context.Context, int, int, int, string) ([]resolvers.AdjustedLocation, string, error) {
+			defaultHook: func(context.Context, int, int, int, bool, string) ([]resolvers.AdjustedLocation, string, error) {
 				return nil, "", nil
 			},
 		},
@@ -80,6 +89,9 @@ func NewMockQueryResolverFrom(i resolvers.QueryResolver) *MockQueryResolver {
 		RangesFunc: &QueryResolverRangesFunc{
 			defaultHook: i.Ranges,
 		},
+		ReferenceCountsFunc: &QueryResolverReferenceCountsFunc{
+			defaultHook: i.ReferenceCounts,
+		},
 		ReferencesFunc: &QueryResolverReferencesFunc{
 			defaultHook: i.References,
 		},
@@ -538,27 +550,143 @@ func (c QueryResolverRangesFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// QueryResolverReferenceCountsFunc describes the behavior when the
+// ReferenceCounts method of the parent MockQueryResolver instance is
+// invoked.
+type QueryResolverReferenceCountsFunc struct {
+	defaultHook func(context.Context, int, int, bool) ([]store.RepositoryReferenceCount, error)
+	hooks       []func(context.Context, int, int, bool) ([]store.RepositoryReferenceCount, error)
+	history     []QueryResolverReferenceCountsFuncCall
+	mutex       sync.Mutex
+}
+
+// ReferenceCounts delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockQueryResolver) ReferenceCounts(v0 context.Context, v1 int, v2 int, v3 bool) ([]store.RepositoryReferenceCount, error) {
+	r0, r1 := m.ReferenceCountsFunc.nextHook()(v0, v1, v2, v3)
+	m.ReferenceCountsFunc.appendCall(QueryResolverReferenceCountsFuncCall{v0, v1, v2, v3, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the ReferenceCounts
+// method of the parent MockQueryResolver instance is invoked and the hook
+// queue is empty.
+func (f *QueryResolverReferenceCountsFunc) SetDefaultHook(hook func(context.Context, int, int, bool) ([]store.RepositoryReferenceCount, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// ReferenceCounts method of the parent MockQueryResolver instance inovkes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *QueryResolverReferenceCountsFunc) PushHook(hook func(context.Context, int, int, bool) ([]store.RepositoryReferenceCount, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *QueryResolverReferenceCountsFunc) SetDefaultReturn(r0 []store.RepositoryReferenceCount, r1 error) {
+	f.SetDefaultHook(func(context.Context, int, int, bool) ([]store.RepositoryReferenceCount, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *QueryResolverReferenceCountsFunc) PushReturn(r0 []store.RepositoryReferenceCount, r1 error) {
+	f.PushHook(func(context.Context, int, int, bool) ([]store.RepositoryReferenceCount, error) {
+		return r0, r1
+	})
+}
+
+func (f *QueryResolverReferenceCountsFunc) nextHook() func(context.Context, int, int, bool) ([]store.RepositoryReferenceCount, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *QueryResolverReferenceCountsFunc) appendCall(r0 QueryResolverReferenceCountsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of QueryResolverReferenceCountsFuncCall
+// objects describing the invocations of this function.
+func (f *QueryResolverReferenceCountsFunc) History() []QueryResolverReferenceCountsFuncCall {
+	f.mutex.Lock()
+	history := make([]QueryResolverReferenceCountsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// QueryResolverReferenceCountsFuncCall is an object that describes an
+// invocation of method ReferenceCounts on an instance of MockQueryResolver.
+type QueryResolverReferenceCountsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 int
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 bool
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []store.RepositoryReferenceCount
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c QueryResolverReferenceCountsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c QueryResolverReferenceCountsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // QueryResolverReferencesFunc describes the behavior when the References
 // method of the parent MockQueryResolver instance is invoked.
 type QueryResolverReferencesFunc struct {
-	defaultHook func(context.Context, int, int, int, string) ([]resolvers.AdjustedLocation, string, error)
-	hooks       []func(context.Context, int, int, int, string) ([]resolvers.AdjustedLocation, string, error)
+	defaultHook func(context.Context, int, int, int, bool, string) ([]resolvers.AdjustedLocation, string, error)
+	hooks       []func(context.Context, int, int, int, bool, string) ([]resolvers.AdjustedLocation, string, error)
 	history     []QueryResolverReferencesFuncCall
 	mutex       sync.Mutex
 }
 
 // References delegates to the next hook function in the queue and stores
 // the parameter and result values of this invocation.
-func (m *MockQueryResolver) References(v0 context.Context, v1 int, v2 int, v3 int, v4 string) ([]resolvers.AdjustedLocation, string, error) {
-	r0, r1, r2 := m.ReferencesFunc.nextHook()(v0, v1, v2, v3, v4)
-	m.ReferencesFunc.appendCall(QueryResolverReferencesFuncCall{v0, v1, v2, v3, v4, r0, r1, r2})
+func (m *MockQueryResolver) References(v0 context.Context, v1 int, v2 int, v3 int, v4 bool, v5 string) ([]resolvers.AdjustedLocation, string, error) {
+	r0, r1, r2 := m.ReferencesFunc.nextHook()(v0, v1, v2, v3, v4, v5)
+	m.ReferencesFunc.appendCall(QueryResolverReferencesFuncCall{v0, v1, v2, v3, v4, v5, r0, r1, r2})
 	return r0, r1, r2
 }
 
 // SetDefaultHook sets function that is called when the References method of
 // the parent MockQueryResolver instance is invoked and the hook queue is
 // empty.
-func (f *QueryResolverReferencesFunc) SetDefaultHook(hook func(context.Context, int, int, int, string) ([]resolvers.AdjustedLocation, string, error)) {
+func (f *QueryResolverReferencesFunc) SetDefaultHook(hook func(context.Context, int, int, int, bool, string) ([]resolvers.AdjustedLocation, string, error)) {
 	f.defaultHook = hook
 }
 
@@ -566,7 +694,7 @@ func (f *QueryResolverReferencesFunc) SetDefaultHook(hook func(context.Context,
 // References method of the parent MockQueryResolver instance inovkes the
 // hook at the front of the queue and discards it. After the queue is empty,
 // the default hook function is invoked for any future action.
-func (f *QueryResolverReferencesFunc) PushHook(hook func(context.Context, int, int, int, string) ([]resolvers.AdjustedLocation, string, error)) {
+func (f *QueryResolverReferencesFunc) PushHook(hook func(context.Context, int, int, int, bool, string) ([]resolvers.AdjustedLocation, string, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -575,7 +703,7 @@ func (f *QueryResolverReferencesFunc) PushHook(hook func(context.Context, int, i
 // SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
 // the given values.
 func (f *QueryResolverReferencesFunc) SetDefaultReturn(r0 []resolvers.AdjustedLocation, r1 string, r2 error) {
-	f.SetDefaultHook(func(context.Context, int, int, int, string) ([]resolvers.AdjustedLocation, string, error) {
+	f.SetDefaultHook(func(context.Context, int, int, int, bool, string) ([]resolvers.AdjustedLocation, string, error) {
 		return r0, r1, r2
 	})
 }
@@ -583,12 +711,12 @@ func (f *QueryResolverReferencesFunc) SetDefaultReturn(r0 []resolvers.AdjustedLo
 // PushReturn calls PushDefaultHook with a function that returns the given
 // values.
 func (f *QueryResolverReferencesFunc) PushReturn(r0 []resolvers.AdjustedLocation, r1 string, r2 error) {
-	f.PushHook(func(context.Context, int, int, int, string) ([]resolvers.AdjustedLocation, string, error) {
+	f.PushHook(func(context.Context, int, int, int, bool, string) ([]resolvers.AdjustedLocation, string, error) {
 		return r0, r1, r2
 	})
 }
 
-func (f *QueryResolverReferencesFunc) nextHook() func(context.Context, int, int, int, string) ([]resolvers.AdjustedLocation, string, error) {
+func (f *QueryResolverReferencesFunc) nextHook() func(context.Context, int, int, int, bool, string) ([]resolvers.AdjustedLocation, string, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -635,7 +763,10 @@ type QueryResolverReferencesFuncCall struct {
 	Arg3 int
 	// Arg4 is the value of the 5th argument passed to this method
 	// invocation.
-	Arg4 string
+	Arg4 bool
+	// Arg5 is the value of the 6th argument passed to this method
+	// invocation.
+	Arg5 string
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
 	Result0 []resolvers.AdjustedLocation
@@ -650,7 +781,7 @@ type QueryResolverReferencesFuncCall struct {
 // Args returns an interface slice containing the arguments of this
 // invocation.
 func (c QueryResolverReferencesFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3, c.Arg4}
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3, c.Arg4, c.Arg5}
 }
 
 // Results returns an interface slice containing the results of this
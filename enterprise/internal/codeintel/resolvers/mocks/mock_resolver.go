@@ -21,6 +21,13 @@ type MockResolver struct {
 	// DeleteUploadByIDFunc is an instance of a mock function object
 	// controlling the behavior of the method DeleteUploadByID.
 	DeleteUploadByIDFunc *ResolverDeleteUploadByIDFunc
+	// DiagnosticConnectionResolverFunc is an instance of a mock function
+	// object controlling the behavior of the method
+	// DiagnosticConnectionResolver.
+	DiagnosticConnectionResolverFunc *ResolverDiagnosticConnectionResolverFunc
+	// DiagnosticCountsFunc is an instance of a mock function object
+	// controlling the behavior of the method DiagnosticCounts.
+	DiagnosticCountsFunc *ResolverDiagnosticCountsFunc
 	// GetIndexByIDFunc is an instance of a mock function object controlling
 	// the behavior of the method GetIndexByID.
 	GetIndexByIDFunc *ResolverGetIndexByIDFunc
@@ -36,6 +43,9 @@ type MockResolver struct {
 	// UploadConnectionResolverFunc is an instance of a mock function object
 	// controlling the behavior of the method UploadConnectionResolver.
 	UploadConnectionResolverFunc *ResolverUploadConnectionResolverFunc
+	// UploadRetentionFunc is an instance of a mock function object
+	// controlling the behavior of the method UploadRetention.
+	UploadRetentionFunc *ResolverUploadRetentionFunc
 }
 
 // NewMockResolver creates a new mock of the Resolver interface. All methods
@@ -52,6 +62,16 @@ func NewMockResolver() *MockResolver {
 				return nil
 			},
 		},
+		DiagnosticConnectionResolverFunc: &ResolverDiagnosticConnectionResolverFunc{
+			defaultHook: func(store.GetDiagnosticsOptions) *resolvers.DiagnosticsResolver {
+				return nil
+			},
+		},
+		DiagnosticCountsFunc: &ResolverDiagnosticCountsFunc{
+			defaultHook: func(context.Context, int, store.DiagnosticFilter) ([]store.DiagnosticCount, error) {
+				return nil, nil
+			},
+		},
 		GetIndexByIDFunc: &ResolverGetIndexByIDFunc{
 			defaultHook: func(context.Context, int) (store.Index, bool, error) {
 				return store.Index{}, false, nil
@@ -77,6 +97,11 @@ func NewMockResolver() *MockResolver {
 				return nil
 			},
 		},
+		UploadRetentionFunc: &ResolverUploadRetentionFunc{
+			defaultHook: func(context.Context, int) ([]resolvers.UploadRetention, error) {
+				return nil, nil
+			},
+		},
 	}
 }
 
@@ -90,6 +115,12 @@ func NewMockResolverFrom(i resolvers.Resolver) *MockResolver {
 		DeleteUploadByIDFunc: &ResolverDeleteUploadByIDFunc{
 			defaultHook: i.DeleteUploadByID,
 		},
+		DiagnosticConnectionResolverFunc: &ResolverDiagnosticConnectionResolverFunc{
+			defaultHook: i.DiagnosticConnectionResolver,
+		},
+		DiagnosticCountsFunc: &ResolverDiagnosticCountsFunc{
+			defaultHook: i.DiagnosticCounts,
+		},
 		GetIndexByIDFunc: &ResolverGetIndexByIDFunc{
 			defaultHook: i.GetIndexByID,
 		},
@@ -105,6 +136,9 @@ func NewMockResolverFrom(i resolvers.Resolver) *MockResolver {
 		UploadConnectionResolverFunc: &ResolverUploadConnectionResolverFunc{
 			defaultHook: i.UploadConnectionResolver,
 		},
+		UploadRetentionFunc: &ResolverUploadRetentionFunc{
+			defaultHook: i.UploadRetention,
+		},
 	}
 }
 
@@ -320,6 +354,225 @@ func (c ResolverDeleteUploadByIDFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
+// ResolverDiagnosticConnectionResolverFunc describes the behavior when the
+// DiagnosticConnectionResolver method of the parent MockResolver instance
+// is invoked.
+type ResolverDiagnosticConnectionResolverFunc struct {
+	defaultHook func(store.GetDiagnosticsOptions) *resolvers.DiagnosticsResolver
+	hooks       []func(store.GetDiagnosticsOptions) *resolvers.DiagnosticsResolver
+	history     []ResolverDiagnosticConnectionResolverFuncCall
+	mutex       sync.Mutex
+}
+
+// DiagnosticConnectionResolver delegates to the next hook function in the
+// queue and stores the parameter and result values of this invocation.
+func (m *MockResolver) DiagnosticConnectionResolver(v0 store.GetDiagnosticsOptions) *resolvers.DiagnosticsResolver {
+	r0 := m.DiagnosticConnectionResolverFunc.nextHook()(v0)
+	m.DiagnosticConnectionResolverFunc.appendCall(ResolverDiagnosticConnectionResolverFuncCall{v0, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the
+// DiagnosticConnectionResolver method of the parent MockResolver instance
+// is invoked and the hook queue is empty.
+func (f *ResolverDiagnosticConnectionResolverFunc) SetDefaultHook(hook func(store.GetDiagnosticsOptions) *resolvers.DiagnosticsResolver) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// DiagnosticConnectionResolver method of the parent MockResolver instance
+// inovkes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *ResolverDiagnosticConnectionResolverFunc) PushHook(hook func(store.GetDiagnosticsOptions) *resolvers.DiagnosticsResolver) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *ResolverDiagnosticConnectionResolverFunc) SetDefaultReturn(r0 *resolvers.DiagnosticsResolver) {
+	f.SetDefaultHook(func(store.GetDiagnosticsOptions) *resolvers.DiagnosticsResolver {
+		return r0
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *ResolverDiagnosticConnectionResolverFunc) PushReturn(r0 *resolvers.DiagnosticsResolver) {
+	f.PushHook(func(store.GetDiagnosticsOptions) *resolvers.DiagnosticsResolver {
+		return r0
+	})
+}
+
+func (f *ResolverDiagnosticConnectionResolverFunc) nextHook() func(store.GetDiagnosticsOptions) *resolvers.DiagnosticsResolver {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *ResolverDiagnosticConnectionResolverFunc) appendCall(r0 ResolverDiagnosticConnectionResolverFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// ResolverDiagnosticConnectionResolverFuncCall objects describing the
+// invocations of this function.
+func (f *ResolverDiagnosticConnectionResolverFunc) History() []ResolverDiagnosticConnectionResolverFuncCall {
+	f.mutex.Lock()
+	history := make([]ResolverDiagnosticConnectionResolverFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// ResolverDiagnosticConnectionResolverFuncCall is an object that describes
+// an invocation of method DiagnosticConnectionResolver on an instance of
+// MockResolver.
+type ResolverDiagnosticConnectionResolverFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 store.GetDiagnosticsOptions
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 *resolvers.DiagnosticsResolver
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c ResolverDiagnosticConnectionResolverFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c ResolverDiagnosticConnectionResolverFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// ResolverDiagnosticCountsFunc describes the behavior when the
+// DiagnosticCounts method of the parent MockResolver instance is invoked.
+type ResolverDiagnosticCountsFunc struct {
+	defaultHook func(context.Context, int, store.DiagnosticFilter) ([]store.DiagnosticCount, error)
+	hooks       []func(context.Context, int, store.DiagnosticFilter) ([]store.DiagnosticCount, error)
+	history     []ResolverDiagnosticCountsFuncCall
+	mutex       sync.Mutex
+}
+
+// DiagnosticCounts delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockResolver) DiagnosticCounts(v0 context.Context, v1 int, v2 store.DiagnosticFilter) ([]store.DiagnosticCount, error) {
+	r0, r1 := m.DiagnosticCountsFunc.nextHook()(v0, v1, v2)
+	m.DiagnosticCountsFunc.appendCall(ResolverDiagnosticCountsFuncCall{v0, v1, v2, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the DiagnosticCounts
+// method of the parent MockResolver instance is invoked and the hook queue
+// is empty.
+func (f *ResolverDiagnosticCountsFunc) SetDefaultHook(hook func(context.Context, int, store.DiagnosticFilter) ([]store.DiagnosticCount, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// DiagnosticCounts method of the parent MockResolver instance inovkes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *ResolverDiagnosticCountsFunc) PushHook(hook func(context.Context, int, store.DiagnosticFilter) ([]store.DiagnosticCount, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *ResolverDiagnosticCountsFunc) SetDefaultReturn(r0 []store.DiagnosticCount, r1 error) {
+	f.SetDefaultHook(func(context.Context, int, store.DiagnosticFilter) ([]store.DiagnosticCount, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *ResolverDiagnosticCountsFunc) PushReturn(r0 []store.DiagnosticCount, r1 error) {
+	f.PushHook(func(context.Context, int, store.DiagnosticFilter) ([]store.DiagnosticCount, error) {
+		return r0, r1
+	})
+}
+
+func (f *ResolverDiagnosticCountsFunc) nextHook() func(context.Context, int, store.DiagnosticFilter) ([]store.DiagnosticCount, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *ResolverDiagnosticCountsFunc) appendCall(r0 ResolverDiagnosticCountsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of ResolverDiagnosticCountsFuncCall objects
+// describing the invocations of this function.
+func (f *ResolverDiagnosticCountsFunc) History() []ResolverDiagnosticCountsFuncCall {
+	f.mutex.Lock()
+	history := make([]ResolverDiagnosticCountsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// ResolverDiagnosticCountsFuncCall is an object that describes an
+// invocation of method DiagnosticCounts on an instance of MockResolver.
+type ResolverDiagnosticCountsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 store.DiagnosticFilter
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []store.DiagnosticCount
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c ResolverDiagnosticCountsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c ResolverDiagnosticCountsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // ResolverGetIndexByIDFunc describes the behavior when the GetIndexByID
 // method of the parent MockResolver instance is invoked.
 type ResolverGetIndexByIDFunc struct {
@@ -864,3 +1117,112 @@ func (c ResolverUploadConnectionResolverFuncCall) Args() []interface{} {
 func (c ResolverUploadConnectionResolverFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
+
+// ResolverUploadRetentionFunc describes the behavior when the
+// UploadRetention method of the parent MockResolver instance is invoked.
+type ResolverUploadRetentionFunc struct {
+	defaultHook func(context.Context, int) ([]resolvers.UploadRetention, error)
+	hooks       []func(context.Context, int) ([]resolvers.UploadRetention, error)
+	history     []ResolverUploadRetentionFuncCall
+	mutex       sync.Mutex
+}
+
+// UploadRetention delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockResolver) UploadRetention(v0 context.Context, v1 int) ([]resolvers.UploadRetention, error) {
+	r0, r1 := m.UploadRetentionFunc.nextHook()(v0, v1)
+	m.UploadRetentionFunc.appendCall(ResolverUploadRetentionFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the UploadRetention
+// method of the parent MockResolver instance is invoked and the hook queue
+// is empty.
+func (f *ResolverUploadRetentionFunc) SetDefaultHook(hook func(context.Context, int) ([]resolvers.UploadRetention, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// UploadRetention method of the parent MockResolver instance inovkes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *ResolverUploadRetentionFunc) PushHook(hook func(context.Context, int) ([]resolvers.UploadRetention, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *ResolverUploadRetentionFunc) SetDefaultReturn(r0 []resolvers.UploadRetention, r1 error) {
+	f.SetDefaultHook(func(context.Context, int) ([]resolvers.UploadRetention, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *ResolverUploadRetentionFunc) PushReturn(r0 []resolvers.UploadRetention, r1 error) {
+	f.PushHook(func(context.Context, int) ([]resolvers.UploadRetention, error) {
+		return r0, r1
+	})
+}
+
+func (f *ResolverUploadRetentionFunc) nextHook() func(context.Context, int) ([]resolvers.UploadRetention, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *ResolverUploadRetentionFunc) appendCall(r0 ResolverUploadRetentionFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of ResolverUploadRetentionFuncCall objects
+// describing the invocations of this function.
+func (f *ResolverUploadRetentionFunc) History() []ResolverUploadRetentionFuncCall {
+	f.mutex.Lock()
+	history := make([]ResolverUploadRetentionFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// ResolverUploadRetentionFuncCall is an object that describes an invocation
+// of method UploadRetention on an instance of MockResolver.
+type ResolverUploadRetentionFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []resolvers.UploadRetention
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c ResolverUploadRetentionFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c ResolverUploadRetentionFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
@@ -43,7 +43,8 @@ type AdjustedCodeIntelligenceRange struct {
 type QueryResolver interface {
 	Ranges(ctx context.Context, startLine, endLine int) ([]AdjustedCodeIntelligenceRange, error)
 	Definitions(ctx context.Context, line, character int) ([]AdjustedLocation, error)
-	References(ctx context.Context, line, character, limit int, rawCursor string) ([]AdjustedLocation, string, error)
+	References(ctx context.Context, line, character, limit int, allVersions bool, rawCursor string) ([]AdjustedLocation, string, error)
+	ReferenceCounts(ctx context.Context, line, character int, allVersions bool) ([]store.RepositoryReferenceCount, error)
 	Hover(ctx context.Context, line, character int) (string, bundles.Range, bool, error)
 	Diagnostics(ctx context.Context, limit int) ([]AdjustedDiagnostic, int, error)
 }
@@ -164,8 +165,10 @@ func (r *queryResolver) Definitions(ctx context.Context, line, character int) ([
 
 // References returns the list of source locations that reference the symbol at the given position.
 // This may include references from other dumps and repositories. If there are multiple bundles
-// associated with this resolver, results from all bundles will be concatenated and returned.
-func (r *queryResolver) References(ctx context.Context, line, character, limit int, rawCursor string) ([]AdjustedLocation, string, error) {
+// associated with this resolver, results from all bundles will be concatenated and returned. If
+// allVersions is true, references from remote repositories depending on any version of the package
+// defining the symbol are included. This flag is ignored when a cursor is supplied.
+func (r *queryResolver) References(ctx context.Context, line, character, limit int, allVersions bool, rawCursor string) ([]AdjustedLocation, string, error) {
 	position := bundles.Position{Line: line, Character: character}
 
 	// Decode a map of upload ids to the next url that serves
@@ -202,7 +205,7 @@ func (r *queryResolver) References(ctx context.Context, line, character, limit i
 			continue
 		}
 
-		cursor, err := codeintelapi.DecodeOrCreateCursor(adjustedPath, adjustedPosition.Line, adjustedPosition.Character, r.uploads[i].ID, rawCursor, r.store, r.bundleManagerClient)
+		cursor, err := codeintelapi.DecodeOrCreateCursor(adjustedPath, adjustedPosition.Line, adjustedPosition.Character, r.uploads[i].ID, allVersions, rawCursor, r.store, r.bundleManagerClient)
 		if err != nil {
 			return nil, "", err
 		}
@@ -231,6 +234,33 @@ func (r *queryResolver) References(ctx context.Context, line, character, limit i
 	return adjustedLocations, endCursor, nil
 }
 
+// ReferenceCounts returns the number of locations in each repository that reference the symbol at the
+// given position from another package. If there are multiple bundles associated with this resolver, the
+// counts from the first bundle with any results will be returned.
+func (r *queryResolver) ReferenceCounts(ctx context.Context, line, character int, allVersions bool) ([]store.RepositoryReferenceCount, error) {
+	position := bundles.Position{Line: line, Character: character}
+
+	for i := range r.uploads {
+		adjustedPath, adjustedPosition, ok, err := r.positionAdjuster.AdjustPosition(ctx, r.uploads[i].Commit, r.path, position, false)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			continue
+		}
+
+		counts, err := r.codeIntelAPI.ReferenceCounts(ctx, adjustedPath, adjustedPosition.Line, adjustedPosition.Character, r.uploads[i].ID, allVersions)
+		if err != nil {
+			return nil, err
+		}
+		if len(counts) > 0 {
+			return counts, nil
+		}
+	}
+
+	return nil, nil
+}
+
 // Hover returns the hover text and range for the symbol at the given position. If there are
 // multiple bundles associated with this resolver, the hover text and range from the first
 // bundle with any results will be returned.
@@ -352,7 +352,7 @@ func TestReferences(t *testing.T) {
 		t.Fatalf("unexpected error creating cursor: %s", err)
 	}
 
-	references, nextCursor, err := queryResolver.References(context.Background(), 10, 15, 3, cursor)
+	references, nextCursor, err := queryResolver.References(context.Background(), 10, 15, 3, false, cursor)
 	if err != nil {
 		t.Fatalf("unexpected error resolving references: %s", err)
 	}
@@ -425,6 +425,61 @@ func TestReferences(t *testing.T) {
 	}
 }
 
+func TestReferenceCounts(t *testing.T) {
+	mockStore := storemocks.NewMockStore()
+	mockBundleManagerClient := bundlemocks.NewMockBundleManagerClient()
+	mockCodeIntelAPI := apimocks.NewMockCodeIntelAPI()
+	mockPositionAdjuster := NewMockPositionAdjuster()
+
+	// position can be translated for subsequent dumps
+	mockPositionAdjuster.AdjustPositionFunc.SetDefaultReturn("sub/main.go", bundles.Position{Line: 20, Character: 15}, true, nil)
+
+	// first requested dump (dump 42) has no equivalent position
+	mockPositionAdjuster.AdjustPositionFunc.PushReturn("", bundles.Position{}, false, nil)
+
+	mockCodeIntelAPI.ReferenceCountsFunc.SetDefaultReturn([]store.RepositoryReferenceCount{
+		{RepositoryID: 51, Count: 10},
+		{RepositoryID: 52, Count: 20},
+	}, nil)
+
+	// second requested dump (dump 43) has no reference counts
+	mockCodeIntelAPI.ReferenceCountsFunc.PushReturn(nil, nil)
+
+	queryResolver := NewQueryResolver(
+		mockStore,
+		mockBundleManagerClient,
+		mockCodeIntelAPI,
+		mockPositionAdjuster,
+		50,
+		"deadbeef2",
+		"/foo/bar.go",
+		[]store.Dump{
+			{ID: 42, RepositoryID: 50, Commit: "deadbeef1"},
+			{ID: 43, RepositoryID: 50, Commit: "deadbeef1"},
+			{ID: 44, RepositoryID: 50, Commit: "deadbeef1"},
+		},
+	)
+
+	counts, err := queryResolver.ReferenceCounts(context.Background(), 10, 15, true)
+	if err != nil {
+		t.Fatalf("unexpected error resolving reference counts: %s", err)
+	}
+
+	expectedCounts := []store.RepositoryReferenceCount{
+		{RepositoryID: 51, Count: 10},
+		{RepositoryID: 52, Count: 20},
+	}
+	if diff := cmp.Diff(expectedCounts, counts); diff != "" {
+		t.Errorf("unexpected counts (-want +got):\n%s", diff)
+	}
+
+	if history := mockCodeIntelAPI.ReferenceCountsFunc.History(); len(history) != 2 {
+		t.Errorf("unexpected number of ReferenceCounts calls. want=%d have=%d", 2, len(history))
+	} else if history[1].Arg4 != 44 || !history[1].Arg5 {
+		t.Errorf("unexpected ReferenceCounts arguments. want=(%d, %v) have=(%d, %v)", 44, true, history[1].Arg4, history[1].Arg5)
+	}
+}
+
 func TestHover(t *testing.T) {
 	mockStore := storemocks.NewMockStore()
 	mockBundleManagerClient := bundlemocks.NewMockBundleManagerClient()
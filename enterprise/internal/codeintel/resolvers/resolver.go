@@ -6,6 +6,7 @@ import (
 	gql "github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
 	codeintelapi "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/api"
 	bundles "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/bundles/client"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/gitserver"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/store"
 )
 
@@ -19,7 +20,10 @@ type Resolver interface {
 	GetIndexByID(ctx context.Context, id int) (store.Index, bool, error)
 	UploadConnectionResolver(opts store.GetUploadsOptions) *UploadsResolver
 	IndexConnectionResolver(opts store.GetIndexesOptions) *IndexesResolver
+	DiagnosticConnectionResolver(opts store.GetDiagnosticsOptions) *DiagnosticsResolver
+	DiagnosticCounts(ctx context.Context, repositoryID int, filter store.DiagnosticFilter) ([]store.DiagnosticCount, error)
 	DeleteUploadByID(ctx context.Context, uploadID int) error
+	UploadRetention(ctx context.Context, repositoryID int) ([]UploadRetention, error)
 	DeleteIndexByID(ctx context.Context, id int) error
 	QueryResolver(ctx context.Context, args *gql.GitBlobLSIFDataArgs) (QueryResolver, error)
 }
@@ -28,15 +32,17 @@ type resolver struct {
 	store               store.Store
 	bundleManagerClient bundles.BundleManagerClient
 	codeIntelAPI        codeintelapi.CodeIntelAPI
+	gitserverClient     gitserver.Client
 	hunkCache           HunkCache
 }
 
 // NewResolver creates a new resolver with the given services.
-func NewResolver(store store.Store, bundleManagerClient bundles.BundleManagerClient, codeIntelAPI codeintelapi.CodeIntelAPI, hunkCache HunkCache) Resolver {
+func NewResolver(store store.Store, bundleManagerClient bundles.BundleManagerClient, codeIntelAPI codeintelapi.CodeIntelAPI, gitserverClient gitserver.Client, hunkCache HunkCache) Resolver {
 	return &resolver{
 		store:               store,
 		bundleManagerClient: bundleManagerClient,
 		codeIntelAPI:        codeIntelAPI,
+		gitserverClient:     gitserverClient,
 		hunkCache:           hunkCache,
 	}
 }
@@ -57,6 +63,14 @@ func (r *resolver) IndexConnectionResolver(opts store.GetIndexesOptions) *Indexe
 	return NewIndexesResolver(r.store, opts)
 }
 
+func (r *resolver) DiagnosticConnectionResolver(opts store.GetDiagnosticsOptions) *DiagnosticsResolver {
+	return NewDiagnosticsResolver(r.store, opts)
+}
+
+func (r *resolver) DiagnosticCounts(ctx context.Context, repositoryID int, filter store.DiagnosticFilter) ([]store.DiagnosticCount, error) {
+	return r.store.DiagnosticCounts(ctx, repositoryID, filter)
+}
+
 func (r *resolver) DeleteUploadByID(ctx context.Context, uploadID int) error {
 	_, err := r.store.DeleteUploadByID(ctx, uploadID)
 	return err
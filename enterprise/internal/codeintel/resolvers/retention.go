@@ -0,0 +1,71 @@
+package resolvers
+
+import (
+	"context"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/retention"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/store"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+)
+
+// uploadRetentionPageSize is the maximum number of uploads to request from the database at once.
+const uploadRetentionPageSize = 100
+
+// UploadRetention pairs a completed upload with the names of the retention policies that retain it.
+type UploadRetention struct {
+	Upload     store.Upload
+	RetainedBy []string
+
+	// Evaluated is false if no retention policies are configured, in which case every upload is
+	// kept, or if the commit of the upload is not in the commit graph yet.
+	Evaluated bool
+}
+
+// Expired returns true if the upload was evaluated and is not retained by any policy.
+func (r UploadRetention) Expired() bool {
+	return r.Evaluated && len(r.RetainedBy) == 0
+}
+
+// UploadRetention evaluates the configured retention policies against the completed uploads of the
+// given repository. This does not modify any upload.
+func (r *resolver) UploadRetention(ctx context.Context, repositoryID int) ([]UploadRetention, error) {
+	policies, err := retention.Policies(conf.Get().SiteConfiguration)
+	if err != nil {
+		return nil, err
+	}
+
+	// Retention is disabled without policies, so nothing is evaluated
+	var retained map[int][]string
+	if len(policies) > 0 {
+		retained, err = retention.Evaluate(ctx, r.store, r.gitserverClient, repositoryID, policies, time.Now())
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	results := make([]UploadRetention, 0, len(retained))
+	for offset := 0; ; {
+		uploads, totalCount, err := r.store.GetUploads(ctx, store.GetUploadsOptions{
+			RepositoryID: repositoryID,
+			State:        "completed",
+			Limit:        uploadRetentionPageSize,
+			Offset:       offset,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, upload := range uploads {
+			// Uploads that completed after the policies were evaluated are not evaluated either
+			policyNames, ok := retained[upload.ID]
+			results = append(results, UploadRetention{Upload: upload, RetainedBy: policyNames, Evaluated: ok})
+		}
+
+		if offset += len(uploads); len(uploads) == 0 || offset >= totalCount {
+			break
+		}
+	}
+
+	return results, nil
+}
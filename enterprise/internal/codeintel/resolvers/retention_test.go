@@ -0,0 +1,73 @@
+package resolvers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	gitservermocks "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/gitserver/mocks"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/store"
+	storemocks "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/store/mocks"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestUploadRetention(t *testing.T) {
+	conf.Mock(&conf.Unified{SiteConfiguration: schema.SiteConfiguration{
+		CodeIntelRetentionPolicies: []*schema.CodeIntelRetentionPolicy{{Name: "tags", Type: "tags"}},
+	}})
+	defer conf.Mock(nil)
+
+	mockStore := storemocks.NewMockStore()
+	mockStore.GetUploadsFunc.SetDefaultReturn([]store.Upload{{ID: 1}, {ID: 2}, {ID: 3}}, 3, nil)
+	// Upload 3 is missing because its commit is not in the commit graph yet
+	mockStore.EvaluateRetentionPoliciesFunc.SetDefaultReturn(map[int][]string{1: {"tags"}, 2: {}}, nil)
+	mockGitserverClient := gitservermocks.NewMockClient()
+
+	retentions, err := NewResolver(mockStore, nil, nil, mockGitserverClient, nil).UploadRetention(context.Background(), 42)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := []UploadRetention{
+		{Upload: store.Upload{ID: 1}, RetainedBy: []string{"tags"}, Evaluated: true},
+		{Upload: store.Upload{ID: 2}, RetainedBy: []string{}, Evaluated: true},
+		{Upload: store.Upload{ID: 3}},
+	}
+	if diff := cmp.Diff(expected, retentions); diff != "" {
+		t.Errorf("unexpected retentions (-want +got):\n%s", diff)
+	}
+
+	var expired []int
+	for _, retention := range retentions {
+		if retention.Expired() {
+			expired = append(expired, retention.Upload.ID)
+		}
+	}
+	if diff := cmp.Diff([]int{2}, expired); diff != "" {
+		t.Errorf("unexpected expired uploads (-want +got):\n%s", diff)
+	}
+}
+
+func TestUploadRetentionNoPolicies(t *testing.T) {
+	mockStore := storemocks.NewMockStore()
+	mockStore.GetUploadsFunc.SetDefaultReturn([]store.Upload{{ID: 1}, {ID: 2}}, 2, nil)
+	mockGitserverClient := gitservermocks.NewMockClient()
+
+	retentions, err := NewResolver(mockStore, nil, nil, mockGitserverClient, nil).UploadRetention(context.Background(), 42)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	for _, retention := range retentions {
+		if retention.Evaluated || retention.Expired() {
+			t.Errorf("unexpected retention of upload %d without policies: %+v", retention.Upload.ID, retention)
+		}
+	}
+	if len(retentions) != 2 {
+		t.Errorf("unexpected number of results. want=%d have=%d", 2, len(retentions))
+	}
+	if len(mockStore.EvaluateRetentionPoliciesFunc.History()) != 0 {
+		t.Errorf("unexpected call to EvaluateRetentionPolicies")
+	}
+}
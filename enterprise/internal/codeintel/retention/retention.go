@@ -0,0 +1,73 @@
+package retention
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gobwas/glob"
+	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/gitserver"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/store"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// Policies converts the retention policies defined in the given site configuration into
+// the form evaluated by the store. An error is returned if any policy is malformed.
+func Policies(siteConfig schema.SiteConfiguration) ([]store.RetentionPolicy, error) {
+	policies := make([]store.RetentionPolicy, 0, len(siteConfig.CodeIntelRetentionPolicies))
+	for _, p := range siteConfig.CodeIntelRetentionPolicies {
+		policy := store.RetentionPolicy{
+			Name: p.Name,
+			Type: store.RetentionPolicyType(p.Type),
+		}
+
+		switch policy.Type {
+		case store.RetentionPolicyTypeBranches, store.RetentionPolicyTypeTags, store.RetentionPolicyTypeUploads:
+		default:
+			return nil, fmt.Errorf("retention policy %q: unknown type %q", p.Name, p.Type)
+		}
+
+		if p.Pattern != "" {
+			pattern, err := glob.Compile(p.Pattern)
+			if err != nil {
+				return nil, errors.Wrap(err, fmt.Sprintf("retention policy %q: invalid pattern", p.Name))
+			}
+			policy.Pattern = pattern
+		}
+
+		if p.MaxAge != "" {
+			maxAge, err := time.ParseDuration(p.MaxAge)
+			if err != nil {
+				return nil, errors.Wrap(err, fmt.Sprintf("retention policy %q: invalid maxAge", p.Name))
+			}
+			policy.MaxAge = maxAge
+		}
+
+		policies = append(policies, policy)
+	}
+
+	return policies, nil
+}
+
+// Evaluate pulls the commit graph and the set of branches and tags of the given repository from
+// gitserver and determines which completed uploads are retained by the given policies. See the
+// EvaluateRetentionPolicies method of the store for a description of the result.
+func Evaluate(ctx context.Context, s store.Store, gitserverClient gitserver.Client, repositoryID int, policies []store.RetentionPolicy, now time.Time) (map[int][]string, error) {
+	graph, err := gitserverClient.CommitGraph(ctx, s, repositoryID)
+	if err != nil {
+		return nil, errors.Wrap(err, "gitserver.CommitGraph")
+	}
+
+	refs, err := gitserverClient.Refs(ctx, s, repositoryID)
+	if err != nil {
+		return nil, errors.Wrap(err, "gitserver.Refs")
+	}
+
+	retained, err := s.EvaluateRetentionPolicies(ctx, repositoryID, graph, refs, policies, now)
+	if err != nil {
+		return nil, errors.Wrap(err, "store.EvaluateRetentionPolicies")
+	}
+
+	return retained, nil
+}
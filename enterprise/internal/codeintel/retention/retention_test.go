@@ -0,0 +1,83 @@
+package retention
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	gitservermocks "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/gitserver/mocks"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/store"
+	storemocks "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/store/mocks"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestPolicies(t *testing.T) {
+	policies, err := Policies(schema.SiteConfiguration{
+		CodeIntelRetentionPolicies: []*schema.CodeIntelRetentionPolicy{
+			{Name: "release tags", Type: "tags", Pattern: "v*", MaxAge: "8760h"},
+			{Name: "all branches", Type: "branches"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error converting policies: %s", err)
+	}
+
+	if len(policies) != 2 {
+		t.Fatalf("unexpected number of policies. want=%d have=%d", 2, len(policies))
+	}
+	if policies[0].Type != store.RetentionPolicyTypeTags || policies[0].MaxAge != 8760*time.Hour {
+		t.Errorf("unexpected policy: %+v", policies[0])
+	}
+	if policies[0].Pattern == nil || !policies[0].Pattern.Match("v1.2.3") || policies[0].Pattern.Match("nightly") {
+		t.Errorf("unexpected pattern for policy %q", policies[0].Name)
+	}
+	if policies[1].Type != store.RetentionPolicyTypeBranches || policies[1].Pattern != nil || policies[1].MaxAge != 0 {
+		t.Errorf("unexpected policy: %+v", policies[1])
+	}
+}
+
+func TestPoliciesInvalid(t *testing.T) {
+	for _, policy := range []*schema.CodeIntelRetentionPolicy{
+		{Name: "bad type", Type: "commits"},
+		{Name: "bad pattern", Type: "tags", Pattern: "v[1"},
+		{Name: "bad age", Type: "uploads", MaxAge: "1 year"},
+	} {
+		if _, err := Policies(schema.SiteConfiguration{CodeIntelRetentionPolicies: []*schema.CodeIntelRetentionPolicy{policy}}); err == nil {
+			t.Errorf("expected error converting policy %q", policy.Name)
+		}
+	}
+}
+
+func TestEvaluate(t *testing.T) {
+	mockStore := storemocks.NewMockStore()
+	gitserverClient := gitservermocks.NewMockClient()
+
+	graph := map[string][]string{"a": {}, "b": {"a"}}
+	refs := []store.GitRef{{Name: "master", Commit: "b", IsDefaultBranch: true}}
+	retained := map[int][]string{1: {store.DefaultBranchRetentionReason}, 2: {}}
+	now := time.Unix(1587396557, 0)
+
+	gitserverClient.CommitGraphFunc.SetDefaultReturn(graph, nil)
+	gitserverClient.RefsFunc.SetDefaultReturn(refs, nil)
+	mockStore.EvaluateRetentionPoliciesFunc.SetDefaultReturn(retained, nil)
+
+	result, err := Evaluate(context.Background(), mockStore, gitserverClient, 42, nil, now)
+	if err != nil {
+		t.Fatalf("unexpected error evaluating policies: %s", err)
+	}
+	if diff := cmp.Diff(retained, result); diff != "" {
+		t.Errorf("unexpected result (-want +got):\n%s", diff)
+	}
+
+	if len(mockStore.EvaluateRetentionPoliciesFunc.History()) != 1 {
+		t.Fatalf("expected EvaluateRetentionPolicies to be called once")
+	}
+	call := mockStore.EvaluateRetentionPoliciesFunc.History()[0]
+	if call.Arg1 != 42 || !call.Arg5.Equal(now) {
+		t.Errorf("unexpected arguments. repositoryID=%d now=%s", call.Arg1, call.Arg5)
+	}
+	if diff := cmp.Diff(refs, call.Arg3); diff != "" {
+		t.Errorf("unexpected refs (-want +got):\n%s", diff)
+	}
+}
@@ -58,6 +58,14 @@ func (s *store) HasCommit(ctx context.Context, repositoryID int, commit string)
 	return count > 0, err
 }
 
+// CommitGraphTip returns the tip commit of the default branch of the given repository at the time its commit graph
+// was last calculated, and a flag indicating whether its commit graph was ever calculated.
+func (s *store) CommitGraphTip(ctx context.Context, repositoryID int) (string, bool, error) {
+	return scanFirstString(s.query(ctx, sqlf.Sprintf(`
+		SELECT "commit" FROM lsif_commit_graph_tips WHERE repository_id = %s
+	`, repositoryID)))
+}
+
 // MarkRepositoryAsDirty marks the given repository's commit graph as out of date.
 func (s *store) MarkRepositoryAsDirty(ctx context.Context, repositoryID int) error {
 	return s.queryForEffect(
@@ -186,6 +194,15 @@ func (s *store) CalculateVisibleUploads(ctx context.Context, repositoryID int, g
 		}
 	}
 
+	// Record the tip the commit graph was calculated at, so that new commits on the default
+	// branch can be detected even if no upload is visible from the tip.
+	if err := tx.queryForEffect(ctx, sqlf.Sprintf(`
+		INSERT INTO lsif_commit_graph_tips (repository_id, "commit") VALUES (%s, %s)
+		ON CONFLICT (repository_id) DO UPDATE SET "commit" = EXCLUDED."commit"
+	`, repositoryID, tipCommit)); err != nil {
+		return err
+	}
+
 	if dirtyToken != 0 {
 		// If the user requests us to clear a dirty token, set the updated_token value to
 		// the dirty token if it wouldn't decrease the value. Dirty repositories are determined
@@ -107,6 +107,39 @@ func TestMarkRepositoryAsDirty(t *testing.T) {
 	}
 }
 
+func TestCommitGraphTip(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	dbtesting.SetupGlobalTestDB(t)
+	store := testStore()
+
+	if _, ok, err := store.CommitGraphTip(context.Background(), 50); err != nil {
+		t.Fatalf("unexpected error getting commit graph tip: %s", err)
+	} else if ok {
+		t.Fatalf("unexpected commit graph tip before calculating visible uploads")
+	}
+
+	graph := map[string][]string{
+		makeCommit(1): {},
+		makeCommit(2): {makeCommit(1)},
+	}
+
+	for _, tipCommit := range []string{makeCommit(1), makeCommit(2)} {
+		if err := store.CalculateVisibleUploads(context.Background(), 50, graph, tipCommit, 0); err != nil {
+			t.Fatalf("unexpected error while calculating visible uploads: %s", err)
+		}
+
+		commit, ok, err := store.CommitGraphTip(context.Background(), 50)
+		if err != nil {
+			t.Fatalf("unexpected error getting commit graph tip: %s", err)
+		}
+		if !ok || commit != tipCommit {
+			t.Errorf("unexpected commit graph tip. want=%s have=%s (ok=%v)", tipCommit, commit, ok)
+		}
+	}
+}
+
 func TestCalculateVisibleUploads(t *testing.T) {
 	if testing.Short() {
 		t.Skip()
@@ -0,0 +1,240 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/bundles/types"
+)
+
+// DiagnosticsInsertBatchSize is the maximum number of diagnostics inserted in a single statement.
+const DiagnosticsInsertBatchSize = 1000
+
+// IndexedDiagnostic is a diagnostic reported by a dump. The path of the diagnostic is relative to
+// the root of the dump.
+type IndexedDiagnostic struct {
+	Dump Dump
+	Path string
+	types.DiagnosticData
+}
+
+// DiagnosticCount is the number of diagnostics reported by a completed upload.
+type DiagnosticCount struct {
+	UploadID   int
+	Commit     string
+	FinishedAt time.Time
+	Count      int
+}
+
+// DiagnosticFilter determines the set of diagnostics matched by GetDiagnostics and DiagnosticCounts.
+// Zero-valued fields do not restrict the set of matched diagnostics.
+type DiagnosticFilter struct {
+	Severity int
+	Source   string
+	Code     string
+}
+
+// GetDiagnosticsOptions determines the set of diagnostics returned from GetDiagnostics. Only dumps
+// that are visible at the tip of the default branch of their repository are searched.
+type GetDiagnosticsOptions struct {
+	DiagnosticFilter
+	RepositoryID int
+	Limit        int
+	Offset       int
+}
+
+// scanIndexedDiagnostics scans a slice of indexed diagnostics from the return value of `*store.query`.
+func scanIndexedDiagnostics(rows *sql.Rows, queryErr error) (_ []IndexedDiagnostic, err error) {
+	if queryErr != nil {
+		return nil, queryErr
+	}
+	defer func() { err = closeRows(rows, err) }()
+
+	var diagnostics []IndexedDiagnostic
+	for rows.Next() {
+		var diagnostic IndexedDiagnostic
+		if err := rows.Scan(
+			&diagnostic.Dump.ID,
+			&diagnostic.Dump.Commit,
+			&diagnostic.Dump.Root,
+			&diagnostic.Dump.VisibleAtTip,
+			&diagnostic.Dump.UploadedAt,
+			&diagnostic.Dump.State,
+			&diagnostic.Dump.FailureMessage,
+			&diagnostic.Dump.StartedAt,
+			&diagnostic.Dump.FinishedAt,
+			&diagnostic.Dump.ProcessAfter,
+			&diagnostic.Dump.NumResets,
+			&diagnostic.Dump.NumFailures,
+			&diagnostic.Dump.RepositoryID,
+			&diagnostic.Dump.RepositoryName,
+			&diagnostic.Dump.Indexer,
+			&diagnostic.Path,
+			&diagnostic.Severity,
+			&diagnostic.Code,
+			&diagnostic.Source,
+			&diagnostic.Message,
+			&diagnostic.StartLine,
+			&diagnostic.StartCharacter,
+			&diagnostic.EndLine,
+			&diagnostic.EndCharacter,
+		); err != nil {
+			return nil, err
+		}
+
+		diagnostics = append(diagnostics, diagnostic)
+	}
+
+	return diagnostics, nil
+}
+
+// scanDiagnosticCounts scans a slice of diagnostic counts from the return value of `*store.query`.
+func scanDiagnosticCounts(rows *sql.Rows, queryErr error) (_ []DiagnosticCount, err error) {
+	if queryErr != nil {
+		return nil, queryErr
+	}
+	defer func() { err = closeRows(rows, err) }()
+
+	var counts []DiagnosticCount
+	for rows.Next() {
+		var count DiagnosticCount
+		if err := rows.Scan(&count.UploadID, &count.Commit, &count.FinishedAt, &count.Count); err != nil {
+			return nil, err
+		}
+
+		counts = append(counts, count)
+	}
+
+	return counts, nil
+}
+
+// UpdateDiagnostics inserts diagnostics data tied to the given upload.
+func (s *store) UpdateDiagnostics(ctx context.Context, diagnostics []types.Diagnostic) (err error) {
+	for len(diagnostics) > 0 {
+		batch := diagnostics
+		if len(batch) > DiagnosticsInsertBatchSize {
+			batch = batch[:DiagnosticsInsertBatchSize]
+		}
+		diagnostics = diagnostics[len(batch):]
+
+		var values []*sqlf.Query
+		for _, d := range batch {
+			values = append(values, sqlf.Sprintf(
+				"(%s, %s, %s, %s, %s, %s, %s, %s, %s, %s)",
+				d.DumpID, d.Path, d.Severity, d.Code, d.Source, d.Message, d.StartLine, d.StartCharacter, d.EndLine, d.EndCharacter,
+			))
+		}
+
+		if err := s.queryForEffect(ctx, sqlf.Sprintf(`
+			INSERT INTO lsif_diagnostics (dump_id, path, severity, code, source, message, start_line, start_character, end_line, end_character)
+			VALUES %s
+		`, sqlf.Join(values, ","))); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// GetDiagnostics returns a page of diagnostics matching the given options along with the total
+// number of matching diagnostics.
+func (s *store) GetDiagnostics(ctx context.Context, opts GetDiagnosticsOptions) (_ []IndexedDiagnostic, _ int, err error) {
+	tx, err := s.transact(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer func() { err = tx.Done(err) }()
+
+	conds := append(
+		diagnosticFilterConditions(opts.DiagnosticFilter),
+		sqlf.Sprintf("EXISTS (SELECT 1 FROM lsif_uploads_visible_at_tip where repository_id = d.repository_id and upload_id = d.id)"),
+	)
+	if opts.RepositoryID != 0 {
+		conds = append(conds, sqlf.Sprintf("d.repository_id = %s", opts.RepositoryID))
+	}
+
+	count, _, err := scanFirstInt(tx.query(ctx, sqlf.Sprintf(`
+		SELECT COUNT(*) FROM lsif_diagnostics g
+		JOIN lsif_dumps_with_repository_name d ON d.id = g.dump_id
+		WHERE %s
+	`, sqlf.Join(conds, " AND "))))
+	if err != nil {
+		return nil, 0, err
+	}
+
+	diagnostics, err := scanIndexedDiagnostics(tx.query(ctx, sqlf.Sprintf(`
+		SELECT
+			d.id,
+			d.commit,
+			d.root,
+			TRUE AS visible_at_tip,
+			d.uploaded_at,
+			d.state,
+			d.failure_message,
+			d.started_at,
+			d.finished_at,
+			d.process_after,
+			d.num_resets,
+			d.num_failures,
+			d.repository_id,
+			d.repository_name,
+			d.indexer,
+			g.path,
+			g.severity,
+			g.code,
+			g.source,
+			g.message,
+			g.start_line,
+			g.start_character,
+			g.end_line,
+			g.end_character
+		FROM lsif_diagnostics g
+		JOIN lsif_dumps_with_repository_name d ON d.id = g.dump_id
+		WHERE %s
+		ORDER BY d.repository_name, d.root, g.path, g.start_line, g.start_character, g.id
+		LIMIT %d OFFSET %d
+	`, sqlf.Join(conds, " AND "), opts.Limit, opts.Offset)))
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return diagnostics, count, nil
+}
+
+// DiagnosticCounts returns the number of diagnostics matching the given filter reported by each
+// completed upload of the given repository, ordered by the time the upload was processed. This
+// includes uploads that are no longer visible from the tip of the default branch, so that the
+// counts can be tracked over time.
+func (s *store) DiagnosticCounts(ctx context.Context, repositoryID int, filter DiagnosticFilter) ([]DiagnosticCount, error) {
+	conds := append(diagnosticFilterConditions(filter), sqlf.Sprintf("g.dump_id = d.id"))
+
+	return scanDiagnosticCounts(s.query(ctx, sqlf.Sprintf(`
+		SELECT
+			d.id,
+			d.commit,
+			COALESCE(d.finished_at, d.uploaded_at) AS finished_at,
+			(SELECT COUNT(*) FROM lsif_diagnostics g WHERE %s) AS count
+		FROM lsif_dumps d
+		WHERE d.repository_id = %s
+		ORDER BY finished_at, d.id
+	`, sqlf.Join(conds, " AND "), repositoryID)))
+}
+
+// diagnosticFilterConditions returns the conditions on the lsif_diagnostics table (aliased as g)
+// shared by GetDiagnostics and DiagnosticCounts.
+func diagnosticFilterConditions(filter DiagnosticFilter) []*sqlf.Query {
+	conds := []*sqlf.Query{sqlf.Sprintf("TRUE")}
+	if filter.Severity != 0 {
+		conds = append(conds, sqlf.Sprintf("g.severity = %s", filter.Severity))
+	}
+	if filter.Source != "" {
+		conds = append(conds, sqlf.Sprintf("g.source = %s", filter.Source))
+	}
+	if filter.Code != "" {
+		conds = append(conds, sqlf.Sprintf("g.code = %s", filter.Code))
+	}
+
+	return conds
+}
@@ -0,0 +1,113 @@
+package store
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/bundles/types"
+	"github.com/sourcegraph/sourcegraph/internal/db/dbconn"
+	"github.com/sourcegraph/sourcegraph/internal/db/dbtesting"
+)
+
+func TestGetDiagnostics(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	dbtesting.SetupGlobalTestDB(t)
+	store := testStore()
+
+	insertUploads(t, dbconn.Global,
+		Upload{ID: 1, RepositoryID: 50, RepositoryName: "a"},
+		Upload{ID: 2, RepositoryID: 51, RepositoryName: "b", Root: "sub/"},
+		Upload{ID: 3, RepositoryID: 51, RepositoryName: "b"},
+	)
+	insertVisibleAtTip(t, dbconn.Global, 50, 1)
+	insertVisibleAtTip(t, dbconn.Global, 51, 2)
+
+	insertDiagnostics(t, store, []types.Diagnostic{
+		{DumpID: 1, Path: "a.go", DiagnosticData: types.DiagnosticData{Severity: 2, Code: "SA1019", Source: "staticcheck", Message: "m1", StartLine: 3}},
+		{DumpID: 1, Path: "a.go", DiagnosticData: types.DiagnosticData{Severity: 1, Code: "E1", Source: "go", Message: "m2", StartLine: 1}},
+		{DumpID: 2, Path: "b.go", DiagnosticData: types.DiagnosticData{Severity: 2, Code: "SA1019", Source: "staticcheck", Message: "m3", StartLine: 5}},
+		{DumpID: 3, Path: "c.go", DiagnosticData: types.DiagnosticData{Severity: 2, Code: "SA1019", Source: "staticcheck", Message: "m4"}}, // not visible
+	})
+
+	diagnostics, totalCount, err := store.GetDiagnostics(context.Background(), GetDiagnosticsOptions{
+		DiagnosticFilter: DiagnosticFilter{Source: "staticcheck", Code: "SA1019"},
+		Limit:            1,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error getting diagnostics: %s", err)
+	}
+	if totalCount != 2 {
+		t.Errorf("unexpected total count. want=%d have=%d", 2, totalCount)
+	}
+
+	var messages []string
+	for _, diagnostic := range diagnostics {
+		messages = append(messages, diagnostic.Message)
+	}
+	if diff := cmp.Diff([]string{"m1"}, messages); diff != "" {
+		t.Errorf("unexpected diagnostics (-want +got):\n%s", diff)
+	}
+
+	diagnostics, totalCount, err = store.GetDiagnostics(context.Background(), GetDiagnosticsOptions{
+		RepositoryID: 51,
+		Limit:        5,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error getting diagnostics: %s", err)
+	}
+	if totalCount != 1 {
+		t.Errorf("unexpected total count. want=%d have=%d", 1, totalCount)
+	}
+	if len(diagnostics) != 1 {
+		t.Fatalf("unexpected number of diagnostics. want=%d have=%d", 1, len(diagnostics))
+	}
+	if diagnostics[0].Dump.ID != 2 || diagnostics[0].Dump.Root != "sub/" || diagnostics[0].Path != "b.go" {
+		t.Errorf("unexpected diagnostic: %v", diagnostics[0])
+	}
+}
+
+func TestDiagnosticCounts(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	dbtesting.SetupGlobalTestDB(t)
+	store := testStore()
+
+	t1 := time.Unix(1587396557, 0).UTC()
+	t2 := t1.Add(time.Hour)
+	t3 := t1.Add(time.Hour * 2)
+
+	insertUploads(t, dbconn.Global,
+		Upload{ID: 1, RepositoryID: 50, FinishedAt: &t2},
+		Upload{ID: 2, RepositoryID: 50, FinishedAt: &t1},
+		Upload{ID: 3, RepositoryID: 50, FinishedAt: &t3},
+		Upload{ID: 4, RepositoryID: 51, FinishedAt: &t1},
+		Upload{ID: 5, RepositoryID: 50, State: "errored"},
+	)
+
+	insertDiagnostics(t, store, []types.Diagnostic{
+		{DumpID: 1, Path: "a.go", DiagnosticData: types.DiagnosticData{Severity: 2, Source: "staticcheck"}},
+		{DumpID: 2, Path: "a.go", DiagnosticData: types.DiagnosticData{Severity: 2, Source: "staticcheck"}},
+		{DumpID: 2, Path: "b.go", DiagnosticData: types.DiagnosticData{Severity: 2, Source: "staticcheck"}},
+		{DumpID: 2, Path: "b.go", DiagnosticData: types.DiagnosticData{Severity: 1, Source: "go"}},
+		{DumpID: 4, Path: "a.go", DiagnosticData: types.DiagnosticData{Severity: 2, Source: "staticcheck"}},
+	})
+
+	counts, err := store.DiagnosticCounts(context.Background(), 50, DiagnosticFilter{Source: "staticcheck"})
+	if err != nil {
+		t.Fatalf("unexpected error getting diagnostic counts: %s", err)
+	}
+
+	expectedCounts := []DiagnosticCount{
+		{UploadID: 2, Commit: makeCommit(2), FinishedAt: t1, Count: 2},
+		{UploadID: 1, Commit: makeCommit(1), FinishedAt: t2, Count: 1},
+		{UploadID: 3, Commit: makeCommit(3), FinishedAt: t3, Count: 0},
+	}
+	if diff := cmp.Diff(expectedCounts, counts); diff != "" {
+		t.Errorf("unexpected counts (-want +got):\n%s", diff)
+	}
+}
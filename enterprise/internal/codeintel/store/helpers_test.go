@@ -177,6 +177,20 @@ func insertPackageReferences(t *testing.T, store Store, packageReferences []type
 	}
 }
 
+// insertMonikerReferences populates the lsif_moniker_references table with the given moniker references.
+func insertMonikerReferences(t *testing.T, store Store, monikerReferences []types.MonikerReference) {
+	if err := store.UpdateMonikerReferences(context.Background(), monikerReferences); err != nil {
+		t.Fatalf("unexpected error updating moniker references: %s", err)
+	}
+}
+
+// insertDiagnostics populates the lsif_diagnostics table with the given diagnostics.
+func insertDiagnostics(t *testing.T, store Store, diagnostics []types.Diagnostic) {
+	if err := store.UpdateDiagnostics(context.Background(), diagnostics); err != nil {
+		t.Fatalf("unexpected error updating diagnostics: %s", err)
+	}
+}
+
 // insertVisibleAtTip populates rows of the lsif_uploads_visible_at_tip table for the given repository
 // with the given identifiers.
 func insertVisibleAtTip(t *testing.T, db *sql.DB, repositoryID int, uploadIDs ...int) {
@@ -22,6 +22,9 @@ type MockStore struct {
 	// CalculateVisibleUploadsFunc is an instance of a mock function object
 	// controlling the behavior of the method CalculateVisibleUploads.
 	CalculateVisibleUploadsFunc *StoreCalculateVisibleUploadsFunc
+	// CommitGraphTipFunc is an instance of a mock function object
+	// controlling the behavior of the method CommitGraphTip.
+	CommitGraphTipFunc *StoreCommitGraphTipFunc
 	// DeleteIndexByIDFunc is an instance of a mock function object
 	// controlling the behavior of the method DeleteIndexByID.
 	DeleteIndexByIDFunc *StoreDeleteIndexByIDFunc
@@ -48,15 +51,25 @@ type MockStore struct {
 	// DequeueIndexFunc is an instance of a mock function object controlling
 	// the behavior of the method DequeueIndex.
 	DequeueIndexFunc *StoreDequeueIndexFunc
+	// DiagnosticCountsFunc is an instance of a mock function object
+	// controlling the behavior of the method DiagnosticCounts.
+	DiagnosticCountsFunc *StoreDiagnosticCountsFunc
 	// DirtyRepositoriesFunc is an instance of a mock function object
 	// controlling the behavior of the method DirtyRepositories.
 	DirtyRepositoriesFunc *StoreDirtyRepositoriesFunc
 	// DoneFunc is an instance of a mock function object controlling the
 	// behavior of the method Done.
 	DoneFunc *StoreDoneFunc
+	// EvaluateRetentionPoliciesFunc is an instance of a mock function
+	// object controlling the behavior of the method
+	// EvaluateRetentionPolicies.
+	EvaluateRetentionPoliciesFunc *StoreEvaluateRetentionPoliciesFunc
 	// FindClosestDumpsFunc is an instance of a mock function object
 	// controlling the behavior of the method FindClosestDumps.
 	FindClosestDumpsFunc *StoreFindClosestDumpsFunc
+	// GetDiagnosticsFunc is an instance of a mock function object
+	// controlling the behavior of the method GetDiagnostics.
+	GetDiagnosticsFunc *StoreGetDiagnosticsFunc
 	// GetDumpByIDFunc is an instance of a mock function object controlling
 	// the behavior of the method GetDumpByID.
 	GetDumpByIDFunc *StoreGetDumpByIDFunc
@@ -123,6 +136,12 @@ type MockStore struct {
 	// MarkRepositoryAsDirtyFunc is an instance of a mock function object
 	// controlling the behavior of the method MarkRepositoryAsDirty.
 	MarkRepositoryAsDirtyFunc *StoreMarkRepositoryAsDirtyFunc
+	// MonikerReferenceCountsFunc is an instance of a mock function object
+	// controlling the behavior of the method MonikerReferenceCounts.
+	MonikerReferenceCountsFunc *StoreMonikerReferenceCountsFunc
+	// MonikerReferencesFunc is an instance of a mock function object
+	// controlling the behavior of the method MonikerReferences.
+	MonikerReferencesFunc *StoreMonikerReferencesFunc
 	// PackageReferencePagerFunc is an instance of a mock function object
 	// controlling the behavior of the method PackageReferencePager.
 	PackageReferencePagerFunc *StorePackageReferencePagerFunc
@@ -135,6 +154,10 @@ type MockStore struct {
 	// RepoUsageStatisticsFunc is an instance of a mock function object
 	// controlling the behavior of the method RepoUsageStatistics.
 	RepoUsageStatisticsFunc *StoreRepoUsageStatisticsFunc
+	// RepositoryIDsWithCompletedUploadsFunc is an instance of a mock
+	// function object controlling the behavior of the method
+	// RepositoryIDsWithCompletedUploads.
+	RepositoryIDsWithCompletedUploadsFunc *StoreRepositoryIDsWithCompletedUploadsFunc
 	// RequeueFunc is an instance of a mock function object controlling the
 	// behavior of the method Requeue.
 	RequeueFunc *StoreRequeueFunc
@@ -157,10 +180,16 @@ type MockStore struct {
 	// TransactFunc is an instance of a mock function object controlling the
 	// behavior of the method Transact.
 	TransactFunc *StoreTransactFunc
+	// UpdateDiagnosticsFunc is an instance of a mock function object
+	// controlling the behavior of the method UpdateDiagnostics.
+	UpdateDiagnosticsFunc *StoreUpdateDiagnosticsFunc
 	// UpdateIndexableRepositoryFunc is an instance of a mock function
 	// object controlling the behavior of the method
 	// UpdateIndexableRepository.
 	UpdateIndexableRepositoryFunc *StoreUpdateIndexableRepositoryFunc
+	// UpdateMonikerReferencesFunc is an instance of a mock function object
+	// controlling the behavior of the method UpdateMonikerReferences.
+	UpdateMonikerReferencesFunc *StoreUpdateMonikerReferencesFunc
 	// UpdatePackageReferencesFunc is an instance of a mock function object
 	// controlling the behavior of the method UpdatePackageReferences.
 	UpdatePackageReferencesFunc *StoreUpdatePackageReferencesFunc
@@ -186,6 +215,11 @@ func NewMockStore() *MockStore {
 				return nil
 			},
 		},
+		CommitGraphTipFunc: &StoreCommitGraphTipFunc{
+			defaultHook: func(context.Context, int) (string, bool, error) {
+				return "", false, nil
+			},
+		},
 		DeleteIndexByIDFunc: &StoreDeleteIndexByIDFunc{
 			defaultHook: func(context.Context, int) (bool, error) {
 				return false, nil
@@ -226,6 +260,11 @@ func NewMockStore() *MockStore {
 				return store.Index{}, nil, false, nil
 			},
 		},
+		DiagnosticCountsFunc: &StoreDiagnosticCountsFunc{
+			defaultHook: func(context.Context, int, store.DiagnosticFilter) ([]store.DiagnosticCount, error) {
+				return nil, nil
+			},
+		},
 		DirtyRepositoriesFunc: &StoreDirtyRepositoriesFunc{
 			defaultHook: func(context.Context) (map[int]int, error) {
 				return nil, nil
@@ -236,11 +275,21 @@ func NewMockStore() *MockStore {
 				return nil
 			},
 		},
+		EvaluateRetentionPoliciesFunc: &StoreEvaluateRetentionPoliciesFunc{
+			defaultHook: func(context.Context, int, map[string][]string, []store.GitRef, []store.RetentionPolicy, time.Time) (map[int][]string, error) {
+				return nil, nil
+			},
+		},
 		FindClosestDumpsFunc: &StoreFindClosestDumpsFunc{
 			defaultHook: func(context.Context, int, string, string, bool, string) ([]store.Dump, error) {
 				return nil, nil
 			},
 		},
+		GetDiagnosticsFunc: &StoreGetDiagnosticsFunc{
+			defaultHook: func(context.Context, store.GetDiagnosticsOptions) ([]store.IndexedDiagnostic, int, error) {
+				return nil, 0, nil
+			},
+		},
 		GetDumpByIDFunc: &StoreGetDumpByIDFunc{
 			defaultHook: func(context.Context, int) (store.Dump, bool, error) {
 				return store.Dump{}, false, nil
@@ -351,6 +400,16 @@ func NewMockStore() *MockStore {
 				return nil
 			},
 		},
+		MonikerReferenceCountsFunc: &StoreMonikerReferenceCountsFunc{
+			defaultHook: func(context.Context, store.MonikerReferencesOptions) ([]store.RepositoryReferenceCount, error) {
+				return nil, nil
+			},
+		},
+		MonikerReferencesFunc: &StoreMonikerReferencesFunc{
+			defaultHook: func(context.Context, store.MonikerReferencesOptions) ([]store.MonikerReference, error) {
+				return nil, nil
+			},
+		},
 		PackageReferencePagerFunc: &StorePackageReferencePagerFunc{
 			defaultHook: func(context.Context, string, string, string, int, int) (int, store.ReferencePager, error) {
 				return 0, nil, nil
@@ -371,6 +430,11 @@ func NewMockStore() *MockStore {
 				return nil, nil
 			},
 		},
+		RepositoryIDsWithCompletedUploadsFunc: &StoreRepositoryIDsWithCompletedUploadsFunc{
+			defaultHook: func(context.Context) ([]int, error) {
+				return nil, nil
+			},
+		},
 		RequeueFunc: &StoreRequeueFunc{
 			defaultHook: func(context.Context, int, time.Time) error {
 				return nil
@@ -406,11 +470,21 @@ func NewMockStore() *MockStore {
 				return nil, nil
 			},
 		},
+		UpdateDiagnosticsFunc: &StoreUpdateDiagnosticsFunc{
+			defaultHook: func(context.Context, []types.Diagnostic) error {
+				return nil
+			},
+		},
 		UpdateIndexableRepositoryFunc: &StoreUpdateIndexableRepositoryFunc{
 			defaultHook: func(context.Context, store.UpdateableIndexableRepository, time.Time) error {
 				return nil
 			},
 		},
+		UpdateMonikerReferencesFunc: &StoreUpdateMonikerReferencesFunc{
+			defaultHook: func(context.Context, []types.MonikerReference) error {
+				return nil
+			},
+		},
 		UpdatePackageReferencesFunc: &StoreUpdatePackageReferencesFunc{
 			defaultHook: func(context.Context, []types.PackageReference) error {
 				return nil
@@ -439,6 +513,9 @@ func NewMockStoreFrom(i store.Store) *MockStore {
 		CalculateVisibleUploadsFunc: &StoreCalculateVisibleUploadsFunc{
 			defaultHook: i.CalculateVisibleUploads,
 		},
+		CommitGraphTipFunc: &StoreCommitGraphTipFunc{
+			defaultHook: i.CommitGraphTip,
+		},
 		DeleteIndexByIDFunc: &StoreDeleteIndexByIDFunc{
 			defaultHook: i.DeleteIndexByID,
 		},
@@ -463,15 +540,24 @@ func NewMockStoreFrom(i store.Store) *MockStore {
 		DequeueIndexFunc: &StoreDequeueIndexFunc{
 			defaultHook: i.DequeueIndex,
 		},
+		DiagnosticCountsFunc: &StoreDiagnosticCountsFunc{
+			defaultHook: i.DiagnosticCounts,
+		},
 		DirtyRepositoriesFunc: &StoreDirtyRepositoriesFunc{
 			defaultHook: i.DirtyRepositories,
 		},
 		DoneFunc: &StoreDoneFunc{
 			defaultHook: i.Done,
 		},
+		EvaluateRetentionPoliciesFunc: &StoreEvaluateRetentionPoliciesFunc{
+			defaultHook: i.EvaluateRetentionPolicies,
+		},
 		FindClosestDumpsFunc: &StoreFindClosestDumpsFunc{
 			defaultHook: i.FindClosestDumps,
 		},
+		GetDiagnosticsFunc: &StoreGetDiagnosticsFunc{
+			defaultHook: i.GetDiagnostics,
+		},
 		GetDumpByIDFunc: &StoreGetDumpByIDFunc{
 			defaultHook: i.GetDumpByID,
 		},
@@ -538,6 +624,12 @@ func NewMockStoreFrom(i store.Store) *MockStore {
 		MarkRepositoryAsDirtyFunc: &StoreMarkRepositoryAsDirtyFunc{
 			defaultHook: i.MarkRepositoryAsDirty,
 		},
+		MonikerReferenceCountsFunc: &StoreMonikerReferenceCountsFunc{
+			defaultHook: i.MonikerReferenceCounts,
+		},
+		MonikerReferencesFunc: &StoreMonikerReferencesFunc{
+			defaultHook: i.MonikerReferences,
+		},
 		PackageReferencePagerFunc: &StorePackageReferencePagerFunc{
 			defaultHook: i.PackageReferencePager,
 		},
@@ -550,6 +642,9 @@ func NewMockStoreFrom(i store.Store) *MockStore {
 		RepoUsageStatisticsFunc: &StoreRepoUsageStatisticsFunc{
 			defaultHook: i.RepoUsageStatistics,
 		},
+		RepositoryIDsWithCompletedUploadsFunc: &StoreRepositoryIDsWithCompletedUploadsFunc{
+			defaultHook: i.RepositoryIDsWithCompletedUploads,
+		},
 		RequeueFunc: &StoreRequeueFunc{
 			defaultHook: i.Requeue,
 		},
@@ -571,9 +666,15 @@ func NewMockStoreFrom(i store.Store) *MockStore {
 		TransactFunc: &StoreTransactFunc{
 			defaultHook: i.Transact,
 		},
+		UpdateDiagnosticsFunc: &StoreUpdateDiagnosticsFunc{
+			defaultHook: i.UpdateDiagnostics,
+		},
 		UpdateIndexableRepositoryFunc: &StoreUpdateIndexableRepositoryFunc{
 			defaultHook: i.UpdateIndexableRepository,
 		},
+		UpdateMonikerReferencesFunc: &StoreUpdateMonikerReferencesFunc{
+			defaultHook: i.UpdateMonikerReferences,
+		},
 		UpdatePackageReferencesFunc: &StoreUpdatePackageReferencesFunc{
 			defaultHook: i.UpdatePackageReferences,
 		},
@@ -810,6 +911,117 @@ func (c StoreCalculateVisibleUploadsFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
+// StoreCommitGraphTipFunc describes the behavior when the CommitGraphTip
+// method of the parent MockStore instance is invoked.
+type StoreCommitGraphTipFunc struct {
+	defaultHook func(context.Context, int) (string, bool, error)
+	hooks       []func(context.Context, int) (string, bool, error)
+	history     []StoreCommitGraphTipFuncCall
+	mutex       sync.Mutex
+}
+
+// CommitGraphTip delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockStore) CommitGraphTip(v0 context.Context, v1 int) (string, bool, error) {
+	r0, r1, r2 := m.CommitGraphTipFunc.nextHook()(v0, v1)
+	m.CommitGraphTipFunc.appendCall(StoreCommitGraphTipFuncCall{v0, v1, r0, r1, r2})
+	return r0, r1, r2
+}
+
+// SetDefaultHook sets function that is called when the CommitGraphTip method
+// of the parent MockStore instance is invoked and the hook queue is empty.
+func (f *StoreCommitGraphTipFunc) SetDefaultHook(hook func(context.Context, int) (string, bool, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// CommitGraphTip method of the parent MockStore instance inovkes the hook at
+// the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *StoreCommitGraphTipFunc) PushHook(hook func(context.Context, int) (string, bool, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *StoreCommitGraphTipFunc) SetDefaultReturn(r0 string, r1 bool, r2 error) {
+	f.SetDefaultHook(func(context.Context, int) (string, bool, error) {
+		return r0, r1, r2
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *StoreCommitGraphTipFunc) PushReturn(r0 string, r1 bool, r2 error) {
+	f.PushHook(func(context.Context, int) (string, bool, error) {
+		return r0, r1, r2
+	})
+}
+
+func (f *StoreCommitGraphTipFunc) nextHook() func(context.Context, int) (string, bool, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *StoreCommitGraphTipFunc) appendCall(r0 StoreCommitGraphTipFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of StoreCommitGraphTipFuncCall objects
+// describing the invocations of this function.
+func (f *StoreCommitGraphTipFunc) History() []StoreCommitGraphTipFuncCall {
+	f.mutex.Lock()
+	history := make([]StoreCommitGraphTipFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// StoreCommitGraphTipFuncCall is an object that describes an invocation of
+// method CommitGraphTip on an instance of MockStore.
+type StoreCommitGraphTipFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 string
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 bool
+	// Result2 is the value of the 3rd result returned from this method
+	// invocation.
+	Result2 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c StoreCommitGraphTipFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c StoreCommitGraphTipFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1, c.Result2}
+}
+
 // StoreDeleteIndexByIDFunc describes the behavior when the DeleteIndexByID
 // method of the parent MockStore instance is invoked.
 type StoreDeleteIndexByIDFunc struct {
@@ -1702,6 +1914,118 @@ func (c StoreDequeueIndexFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1, c.Result2, c.Result3}
 }
 
+// StoreDiagnosticCountsFunc describes the behavior when the
+// DiagnosticCounts method of the parent MockStore instance is invoked.
+type StoreDiagnosticCountsFunc struct {
+	defaultHook func(context.Context, int, store.DiagnosticFilter) ([]store.DiagnosticCount, error)
+	hooks       []func(context.Context, int, store.DiagnosticFilter) ([]store.DiagnosticCount, error)
+	history     []StoreDiagnosticCountsFuncCall
+	mutex       sync.Mutex
+}
+
+// DiagnosticCounts delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockStore) DiagnosticCounts(v0 context.Context, v1 int, v2 store.DiagnosticFilter) ([]store.DiagnosticCount, error) {
+	r0, r1 := m.DiagnosticCountsFunc.nextHook()(v0, v1, v2)
+	m.DiagnosticCountsFunc.appendCall(StoreDiagnosticCountsFuncCall{v0, v1, v2, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the DiagnosticCounts
+// method of the parent MockStore instance is invoked and the hook queue is
+// empty.
+func (f *StoreDiagnosticCountsFunc) SetDefaultHook(hook func(context.Context, int, store.DiagnosticFilter) ([]store.DiagnosticCount, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// DiagnosticCounts method of the parent MockStore instance inovkes the hook
+// at the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *StoreDiagnosticCountsFunc) PushHook(hook func(context.Context, int, store.DiagnosticFilter) ([]store.DiagnosticCount, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *StoreDiagnosticCountsFunc) SetDefaultReturn(r0 []store.DiagnosticCount, r1 error) {
+	f.SetDefaultHook(func(context.Context, int, store.DiagnosticFilter) ([]store.DiagnosticCount, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *StoreDiagnosticCountsFunc) PushReturn(r0 []store.DiagnosticCount, r1 error) {
+	f.PushHook(func(context.Context, int, store.DiagnosticFilter) ([]store.DiagnosticCount, error) {
+		return r0, r1
+	})
+}
+
+func (f *StoreDiagnosticCountsFunc) nextHook() func(context.Context, int, store.DiagnosticFilter) ([]store.DiagnosticCount, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *StoreDiagnosticCountsFunc) appendCall(r0 StoreDiagnosticCountsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of StoreDiagnosticCountsFuncCall objects
+// describing the invocations of this function.
+func (f *StoreDiagnosticCountsFunc) History() []StoreDiagnosticCountsFuncCall {
+	f.mutex.Lock()
+	history := make([]StoreDiagnosticCountsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// StoreDiagnosticCountsFuncCall is an object that describes an invocation
+// of method DiagnosticCounts on an instance of MockStore.
+type StoreDiagnosticCountsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 store.DiagnosticFilter
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []store.DiagnosticCount
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c StoreDiagnosticCountsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c StoreDiagnosticCountsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // StoreDirtyRepositoriesFunc describes the behavior when the
 // DirtyRepositories method of the parent MockStore instance is invoked.
 type StoreDirtyRepositoriesFunc struct {
@@ -1910,35 +2234,36 @@ func (c StoreDoneFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
-// StoreFindClosestDumpsFunc describes the behavior when the
-// FindClosestDumps method of the parent MockStore instance is invoked.
-type StoreFindClosestDumpsFunc struct {
-	defaultHook func(context.Context, int, string, string, bool, string) ([]store.Dump, error)
-	hooks       []func(context.Context, int, string, string, bool, string) ([]store.Dump, error)
-	history     []StoreFindClosestDumpsFuncCall
+// StoreEvaluateRetentionPoliciesFunc describes the behavior when the
+// EvaluateRetentionPolicies method of the parent MockStore instance is
+// invoked.
+type StoreEvaluateRetentionPoliciesFunc struct {
+	defaultHook func(context.Context, int, map[string][]string, []store.GitRef, []store.RetentionPolicy, time.Time) (map[int][]string, error)
+	hooks       []func(context.Context, int, map[string][]string, []store.GitRef, []store.RetentionPolicy, time.Time) (map[int][]string, error)
+	history     []StoreEvaluateRetentionPoliciesFuncCall
 	mutex       sync.Mutex
 }
 
-// FindClosestDumps delegates to the next hook function in the queue and
-// stores the parameter and result values of this invocation.
-func (m *MockStore) FindClosestDumps(v0 context.Context, v1 int, v2 string, v3 string, v4 bool, v5 string) ([]store.Dump, error) {
-	r0, r1 := m.FindClosestDumpsFunc.nextHook()(v0, v1, v2, v3, v4, v5)
-	m.FindClosestDumpsFunc.appendCall(StoreFindClosestDumpsFuncCall{v0, v1, v2, v3, v4, v5, r0, r1})
+// EvaluateRetentionPolicies delegates to the next hook function in the
+// queue and stores the parameter and result values of this invocation.
+func (m *MockStore) EvaluateRetentionPolicies(v0 context.Context, v1 int, v2 map[string][]string, v3 []store.GitRef, v4 []store.RetentionPolicy, v5 time.Time) (map[int][]string, error) {
+	r0, r1 := m.EvaluateRetentionPoliciesFunc.nextHook()(v0, v1, v2, v3, v4, v5)
+	m.EvaluateRetentionPoliciesFunc.appendCall(StoreEvaluateRetentionPoliciesFuncCall{v0, v1, v2, v3, v4, v5, r0, r1})
 	return r0, r1
 }
 
-// SetDefaultHook sets function that is called when the FindClosestDumps
-// method of the parent MockStore instance is invoked and the hook queue is
-// empty.
-func (f *StoreFindClosestDumpsFunc) SetDefaultHook(hook func(context.Context, int, string, string, bool, string) ([]store.Dump, error)) {
+// SetDefaultHook sets function that is called when the
+// EvaluateRetentionPolicies method of the parent MockStore instance is
+// invoked and the hook queue is empty.
+func (f *StoreEvaluateRetentionPoliciesFunc) SetDefaultHook(hook func(context.Context, int, map[string][]string, []store.GitRef, []store.RetentionPolicy, time.Time) (map[int][]string, error)) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// FindClosestDumps method of the parent MockStore instance inovkes the hook
-// at the front of the queue and discards it. After the queue is empty, the
-// default hook function is invoked for any future action.
-func (f *StoreFindClosestDumpsFunc) PushHook(hook func(context.Context, int, string, string, bool, string) ([]store.Dump, error)) {
+// EvaluateRetentionPolicies method of the parent MockStore instance inovkes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *StoreEvaluateRetentionPoliciesFunc) PushHook(hook func(context.Context, int, map[string][]string, []store.GitRef, []store.RetentionPolicy, time.Time) (map[int][]string, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -1946,21 +2271,21 @@ func (f *StoreFindClosestDumpsFunc) PushHook(hook func(context.Context, int, str
 
 // SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
 // the given values.
-func (f *StoreFindClosestDumpsFunc) SetDefaultReturn(r0 []store.Dump, r1 error) {
-	f.SetDefaultHook(func(context.Context, int, string, string, bool, string) ([]store.Dump, error) {
+func (f *StoreEvaluateRetentionPoliciesFunc) SetDefaultReturn(r0 map[int][]string, r1 error) {
+	f.SetDefaultHook(func(context.Context, int, map[string][]string, []store.GitRef, []store.RetentionPolicy, time.Time) (map[int][]string, error) {
 		return r0, r1
 	})
 }
 
 // PushReturn calls PushDefaultHook with a function that returns the given
 // values.
-func (f *StoreFindClosestDumpsFunc) PushReturn(r0 []store.Dump, r1 error) {
-	f.PushHook(func(context.Context, int, string, string, bool, string) ([]store.Dump, error) {
+func (f *StoreEvaluateRetentionPoliciesFunc) PushReturn(r0 map[int][]string, r1 error) {
+	f.PushHook(func(context.Context, int, map[string][]string, []store.GitRef, []store.RetentionPolicy, time.Time) (map[int][]string, error) {
 		return r0, r1
 	})
 }
 
-func (f *StoreFindClosestDumpsFunc) nextHook() func(context.Context, int, string, string, bool, string) ([]store.Dump, error) {
+func (f *StoreEvaluateRetentionPoliciesFunc) nextHook() func(context.Context, int, map[string][]string, []store.GitRef, []store.RetentionPolicy, time.Time) (map[int][]string, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -1973,26 +2298,27 @@ func (f *StoreFindClosestDumpsFunc) nextHook() func(context.Context, int, string
 	return hook
 }
 
-func (f *StoreFindClosestDumpsFunc) appendCall(r0 StoreFindClosestDumpsFuncCall) {
+func (f *StoreEvaluateRetentionPoliciesFunc) appendCall(r0 StoreEvaluateRetentionPoliciesFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of StoreFindClosestDumpsFuncCall objects
-// describing the invocations of this function.
-func (f *StoreFindClosestDumpsFunc) History() []StoreFindClosestDumpsFuncCall {
+// History returns a sequence of StoreEvaluateRetentionPoliciesFuncCall
+// objects describing the invocations of this function.
+func (f *StoreEvaluateRetentionPoliciesFunc) History() []StoreEvaluateRetentionPoliciesFuncCall {
 	f.mutex.Lock()
-	history := make([]StoreFindClosestDumpsFuncCall, len(f.history))
+	history := make([]StoreEvaluateRetentionPoliciesFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// StoreFindClosestDumpsFuncCall is an object that describes an invocation
-// of method FindClosestDumps on an instance of MockStore.
-type StoreFindClosestDumpsFuncCall struct {
+// StoreEvaluateRetentionPoliciesFuncCall is an object that describes an
+// invocation of method EvaluateRetentionPolicies on an instance of
+// MockStore.
+type StoreEvaluateRetentionPoliciesFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
@@ -2001,19 +2327,19 @@ type StoreFindClosestDumpsFuncCall struct {
 	Arg1 int
 	// Arg2 is the value of the 3rd argument passed to this method
 	// invocation.
-	Arg2 string
+	Arg2 map[string][]string
 	// Arg3 is the value of the 4th argument passed to this method
 	// invocation.
-	Arg3 string
+	Arg3 []store.GitRef
 	// Arg4 is the value of the 5th argument passed to this method
 	// invocation.
-	Arg4 bool
+	Arg4 []store.RetentionPolicy
 	// Arg5 is the value of the 6th argument passed to this method
 	// invocation.
-	Arg5 string
+	Arg5 time.Time
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 []store.Dump
+	Result0 map[int][]string
 	// Result1 is the value of the 2nd result returned from this method
 	// invocation.
 	Result1 error
@@ -2021,22 +2347,255 @@ type StoreFindClosestDumpsFuncCall struct {
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c StoreFindClosestDumpsFuncCall) Args() []interface{} {
+func (c StoreEvaluateRetentionPoliciesFuncCall) Args() []interface{} {
 	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3, c.Arg4, c.Arg5}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c StoreFindClosestDumpsFuncCall) Results() []interface{} {
+func (c StoreEvaluateRetentionPoliciesFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
-// StoreGetDumpByIDFunc describes the behavior when the GetDumpByID method
-// of the parent MockStore instance is invoked.
-type StoreGetDumpByIDFunc struct {
-	defaultHook func(context.Context, int) (store.Dump, bool, error)
-	hooks       []func(context.Context, int) (store.Dump, bool, error)
-	history     []StoreGetDumpByIDFuncCall
+// StoreFindClosestDumpsFunc describes the behavior when the
+// FindClosestDumps method of the parent MockStore instance is invoked.
+type StoreFindClosestDumpsFunc struct {
+	defaultHook func(context.Context, int, string, string, bool, string) ([]store.Dump, error)
+	hooks       []func(context.Context, int, string, string, bool, string) ([]store.Dump, error)
+	history     []StoreFindClosestDumpsFuncCall
+	mutex       sync.Mutex
+}
+
+// FindClosestDumps delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockStore) FindClosestDumps(v0 context.Context, v1 int, v2 string, v3 string, v4 bool, v5 string) ([]store.Dump, error) {
+	r0, r1 := m.FindClosestDumpsFunc.nextHook()(v0, v1, v2, v3, v4, v5)
+	m.FindClosestDumpsFunc.appendCall(StoreFindClosestDumpsFuncCall{v0, v1, v2, v3, v4, v5, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the FindClosestDumps
+// method of the parent MockStore instance is invoked and the hook queue is
+// empty.
+func (f *StoreFindClosestDumpsFunc) SetDefaultHook(hook func(context.Context, int, string, string, bool, string) ([]store.Dump, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// FindClosestDumps method of the parent MockStore instance inovkes the hook
+// at the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *StoreFindClosestDumpsFunc) PushHook(hook func(context.Context, int, string, string, bool, string) ([]store.Dump, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *StoreFindClosestDumpsFunc) SetDefaultReturn(r0 []store.Dump, r1 error) {
+	f.SetDefaultHook(func(context.Context, int, string, string, bool, string) ([]store.Dump, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *StoreFindClosestDumpsFunc) PushReturn(r0 []store.Dump, r1 error) {
+	f.PushHook(func(context.Context, int, string, string, bool, string) ([]store.Dump, error) {
+		return r0, r1
+	})
+}
+
+func (f *StoreFindClosestDumpsFunc) nextHook() func(context.Context, int, string, string, bool, string) ([]store.Dump, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *StoreFindClosestDumpsFunc) appendCall(r0 StoreFindClosestDumpsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of StoreFindClosestDumpsFuncCall objects
+// describing the invocations of this function.
+func (f *StoreFindClosestDumpsFunc) History() []StoreFindClosestDumpsFuncCall {
+	f.mutex.Lock()
+	history := make([]StoreFindClosestDumpsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// StoreFindClosestDumpsFuncCall is an object that describes an invocation
+// of method FindClosestDumps on an instance of MockStore.
+type StoreFindClosestDumpsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 string
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 string
+	// Arg4 is the value of the 5th argument passed to this method
+	// invocation.
+	Arg4 bool
+	// Arg5 is the value of the 6th argument passed to this method
+	// invocation.
+	Arg5 string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []store.Dump
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c StoreFindClosestDumpsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3, c.Arg4, c.Arg5}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c StoreFindClosestDumpsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// StoreGetDiagnosticsFunc describes the behavior when the GetDiagnostics
+// method of the parent MockStore instance is invoked.
+type StoreGetDiagnosticsFunc struct {
+	defaultHook func(context.Context, store.GetDiagnosticsOptions) ([]store.IndexedDiagnostic, int, error)
+	hooks       []func(context.Context, store.GetDiagnosticsOptions) ([]store.IndexedDiagnostic, int, error)
+	history     []StoreGetDiagnosticsFuncCall
+	mutex       sync.Mutex
+}
+
+// GetDiagnostics delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockStore) GetDiagnostics(v0 context.Context, v1 store.GetDiagnosticsOptions) ([]store.IndexedDiagnostic, int, error) {
+	r0, r1, r2 := m.GetDiagnosticsFunc.nextHook()(v0, v1)
+	m.GetDiagnosticsFunc.appendCall(StoreGetDiagnosticsFuncCall{v0, v1, r0, r1, r2})
+	return r0, r1, r2
+}
+
+// SetDefaultHook sets function that is called when the GetDiagnostics
+// method of the parent MockStore instance is invoked and the hook queue is
+// empty.
+func (f *StoreGetDiagnosticsFunc) SetDefaultHook(hook func(context.Context, store.GetDiagnosticsOptions) ([]store.IndexedDiagnostic, int, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetDiagnostics method of the parent MockStore instance inovkes the hook
+// at the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *StoreGetDiagnosticsFunc) PushHook(hook func(context.Context, store.GetDiagnosticsOptions) ([]store.IndexedDiagnostic, int, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *StoreGetDiagnosticsFunc) SetDefaultReturn(r0 []store.IndexedDiagnostic, r1 int, r2 error) {
+	f.SetDefaultHook(func(context.Context, store.GetDiagnosticsOptions) ([]store.IndexedDiagnostic, int, error) {
+		return r0, r1, r2
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *StoreGetDiagnosticsFunc) PushReturn(r0 []store.IndexedDiagnostic, r1 int, r2 error) {
+	f.PushHook(func(context.Context, store.GetDiagnosticsOptions) ([]store.IndexedDiagnostic, int, error) {
+		return r0, r1, r2
+	})
+}
+
+func (f *StoreGetDiagnosticsFunc) nextHook() func(context.Context, store.GetDiagnosticsOptions) ([]store.IndexedDiagnostic, int, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *StoreGetDiagnosticsFunc) appendCall(r0 StoreGetDiagnosticsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of StoreGetDiagnosticsFuncCall objects
+// describing the invocations of this function.
+func (f *StoreGetDiagnosticsFunc) History() []StoreGetDiagnosticsFuncCall {
+	f.mutex.Lock()
+	history := make([]StoreGetDiagnosticsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// StoreGetDiagnosticsFuncCall is an object that describes an invocation of
+// method GetDiagnostics on an instance of MockStore.
+type StoreGetDiagnosticsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 store.GetDiagnosticsOptions
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []store.IndexedDiagnostic
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 int
+	// Result2 is the value of the 3rd result returned from this method
+	// invocation.
+	Result2 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c StoreGetDiagnosticsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c StoreGetDiagnosticsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1, c.Result2}
+}
+
+// StoreGetDumpByIDFunc describes the behavior when the GetDumpByID method
+// of the parent MockStore instance is invoked.
+type StoreGetDumpByIDFunc struct {
+	defaultHook func(context.Context, int) (store.Dump, bool, error)
+	hooks       []func(context.Context, int) (store.Dump, bool, error)
+	history     []StoreGetDumpByIDFuncCall
 	mutex       sync.Mutex
 }
 
@@ -4427,35 +4986,36 @@ func (c StoreMarkRepositoryAsDirtyFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
-// StorePackageReferencePagerFunc describes the behavior when the
-// PackageReferencePager method of the parent MockStore instance is invoked.
-type StorePackageReferencePagerFunc struct {
-	defaultHook func(context.Context, string, string, string, int, int) (int, store.ReferencePager, error)
-	hooks       []func(context.Context, string, string, string, int, int) (int, store.ReferencePager, error)
-	history     []StorePackageReferencePagerFuncCall
+// StoreMonikerReferenceCountsFunc describes the behavior when the
+// MonikerReferenceCounts method of the parent MockStore instance is
+// invoked.
+type StoreMonikerReferenceCountsFunc struct {
+	defaultHook func(context.Context, store.MonikerReferencesOptions) ([]store.RepositoryReferenceCount, error)
+	hooks       []func(context.Context, store.MonikerReferencesOptions) ([]store.RepositoryReferenceCount, error)
+	history     []StoreMonikerReferenceCountsFuncCall
 	mutex       sync.Mutex
 }
 
-// PackageReferencePager delegates to the next hook function in the queue
+// MonikerReferenceCounts delegates to the next hook function in the queue
 // and stores the parameter and result values of this invocation.
-func (m *MockStore) PackageReferencePager(v0 context.Context, v1 string, v2 string, v3 string, v4 int, v5 int) (int, store.ReferencePager, error) {
-	r0, r1, r2 := m.PackageReferencePagerFunc.nextHook()(v0, v1, v2, v3, v4, v5)
-	m.PackageReferencePagerFunc.appendCall(StorePackageReferencePagerFuncCall{v0, v1, v2, v3, v4, v5, r0, r1, r2})
-	return r0, r1, r2
+func (m *MockStore) MonikerReferenceCounts(v0 context.Context, v1 store.MonikerReferencesOptions) ([]store.RepositoryReferenceCount, error) {
+	r0, r1 := m.MonikerReferenceCountsFunc.nextHook()(v0, v1)
+	m.MonikerReferenceCountsFunc.appendCall(StoreMonikerReferenceCountsFuncCall{v0, v1, r0, r1})
+	return r0, r1
 }
 
 // SetDefaultHook sets function that is called when the
-// PackageReferencePager method of the parent MockStore instance is invoked
+// MonikerReferenceCounts method of the parent MockStore instance is invoked
 // and the hook queue is empty.
-func (f *StorePackageReferencePagerFunc) SetDefaultHook(hook func(context.Context, string, string, string, int, int) (int, store.ReferencePager, error)) {
+func (f *StoreMonikerReferenceCountsFunc) SetDefaultHook(hook func(context.Context, store.MonikerReferencesOptions) ([]store.RepositoryReferenceCount, error)) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// PackageReferencePager method of the parent MockStore instance inovkes the
-// hook at the front of the queue and discards it. After the queue is empty,
-// the default hook function is invoked for any future action.
-func (f *StorePackageReferencePagerFunc) PushHook(hook func(context.Context, string, string, string, int, int) (int, store.ReferencePager, error)) {
+// MonikerReferenceCounts method of the parent MockStore instance inovkes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *StoreMonikerReferenceCountsFunc) PushHook(hook func(context.Context, store.MonikerReferencesOptions) ([]store.RepositoryReferenceCount, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -4463,21 +5023,21 @@ func (f *StorePackageReferencePagerFunc) PushHook(hook func(context.Context, str
 
 // SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
 // the given values.
-func (f *StorePackageReferencePagerFunc) SetDefaultReturn(r0 int, r1 store.ReferencePager, r2 error) {
-	f.SetDefaultHook(func(context.Context, string, string, string, int, int) (int, store.ReferencePager, error) {
-		return r0, r1, r2
+func (f *StoreMonikerReferenceCountsFunc) SetDefaultReturn(r0 []store.RepositoryReferenceCount, r1 error) {
+	f.SetDefaultHook(func(context.Context, store.MonikerReferencesOptions) ([]store.RepositoryReferenceCount, error) {
+		return r0, r1
 	})
 }
 
 // PushReturn calls PushDefaultHook with a function that returns the given
 // values.
-func (f *StorePackageReferencePagerFunc) PushReturn(r0 int, r1 store.ReferencePager, r2 error) {
-	f.PushHook(func(context.Context, string, string, string, int, int) (int, store.ReferencePager, error) {
-		return r0, r1, r2
+func (f *StoreMonikerReferenceCountsFunc) PushReturn(r0 []store.RepositoryReferenceCount, r1 error) {
+	f.PushHook(func(context.Context, store.MonikerReferencesOptions) ([]store.RepositoryReferenceCount, error) {
+		return r0, r1
 	})
 }
 
-func (f *StorePackageReferencePagerFunc) nextHook() func(context.Context, string, string, string, int, int) (int, store.ReferencePager, error) {
+func (f *StoreMonikerReferenceCountsFunc) nextHook() func(context.Context, store.MonikerReferencesOptions) ([]store.RepositoryReferenceCount, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -4490,39 +5050,257 @@ func (f *StorePackageReferencePagerFunc) nextHook() func(context.Context, string
 	return hook
 }
 
-func (f *StorePackageReferencePagerFunc) appendCall(r0 StorePackageReferencePagerFuncCall) {
+func (f *StoreMonikerReferenceCountsFunc) appendCall(r0 StoreMonikerReferenceCountsFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of StorePackageReferencePagerFuncCall objects
+// History returns a sequence of StoreMonikerReferenceCountsFuncCall objects
 // describing the invocations of this function.
-func (f *StorePackageReferencePagerFunc) History() []StorePackageReferencePagerFuncCall {
+func (f *StoreMonikerReferenceCountsFunc) History() []StoreMonikerReferenceCountsFuncCall {
 	f.mutex.Lock()
-	history := make([]StorePackageReferencePagerFuncCall, len(f.history))
+	history := make([]StoreMonikerReferenceCountsFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// StorePackageReferencePagerFuncCall is an object that describes an
-// invocation of method PackageReferencePager on an instance of MockStore.
-type StorePackageReferencePagerFuncCall struct {
+// StoreMonikerReferenceCountsFuncCall is an object that describes an
+// invocation of method MonikerReferenceCounts on an instance of MockStore.
+type StoreMonikerReferenceCountsFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
 	// Arg1 is the value of the 2nd argument passed to this method
 	// invocation.
-	Arg1 string
-	// Arg2 is the value of the 3rd argument passed to this method
-	// invocation.
-	Arg2 string
-	// Arg3 is the value of the 4th argument passed to this method
+	Arg1 store.MonikerReferencesOptions
+	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Arg3 string
-	// Arg4 is the value of the 5th argument passed to this method
+	Result0 []store.RepositoryReferenceCount
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c StoreMonikerReferenceCountsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c StoreMonikerReferenceCountsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// StoreMonikerReferencesFunc describes the behavior when the
+// MonikerReferences method of the parent MockStore instance is invoked.
+type StoreMonikerReferencesFunc struct {
+	defaultHook func(context.Context, store.MonikerReferencesOptions) ([]store.MonikerReference, error)
+	hooks       []func(context.Context, store.MonikerReferencesOptions) ([]store.MonikerReference, error)
+	history     []StoreMonikerReferencesFuncCall
+	mutex       sync.Mutex
+}
+
+// MonikerReferences delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockStore) MonikerReferences(v0 context.Context, v1 store.MonikerReferencesOptions) ([]store.MonikerReference, error) {
+	r0, r1 := m.MonikerReferencesFunc.nextHook()(v0, v1)
+	m.MonikerReferencesFunc.appendCall(StoreMonikerReferencesFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the MonikerReferences
+// method of the parent MockStore instance is invoked and the hook queue is
+// empty.
+func (f *StoreMonikerReferencesFunc) SetDefaultHook(hook func(context.Context, store.MonikerReferencesOptions) ([]store.MonikerReference, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// MonikerReferences method of the parent MockStore instance inovkes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *StoreMonikerReferencesFunc) PushHook(hook func(context.Context, store.MonikerReferencesOptions) ([]store.MonikerReference, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *StoreMonikerReferencesFunc) SetDefaultReturn(r0 []store.MonikerReference, r1 error) {
+	f.SetDefaultHook(func(context.Context, store.MonikerReferencesOptions) ([]store.MonikerReference, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *StoreMonikerReferencesFunc) PushReturn(r0 []store.MonikerReference, r1 error) {
+	f.PushHook(func(context.Context, store.MonikerReferencesOptions) ([]store.MonikerReference, error) {
+		return r0, r1
+	})
+}
+
+func (f *StoreMonikerReferencesFunc) nextHook() func(context.Context, store.MonikerReferencesOptions) ([]store.MonikerReference, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *StoreMonikerReferencesFunc) appendCall(r0 StoreMonikerReferencesFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of StoreMonikerReferencesFuncCall objects
+// describing the invocations of this function.
+func (f *StoreMonikerReferencesFunc) History() []StoreMonikerReferencesFuncCall {
+	f.mutex.Lock()
+	history := make([]StoreMonikerReferencesFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// StoreMonikerReferencesFuncCall is an object that describes an invocation
+// of method MonikerReferences on an instance of MockStore.
+type StoreMonikerReferencesFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 store.MonikerReferencesOptions
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []store.MonikerReference
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c StoreMonikerReferencesFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c StoreMonikerReferencesFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// StorePackageReferencePagerFunc describes the behavior when the
+// PackageReferencePager method of the parent MockStore instance is invoked.
+type StorePackageReferencePagerFunc struct {
+	defaultHook func(context.Context, string, string, string, int, int) (int, store.ReferencePager, error)
+	hooks       []func(context.Context, string, string, string, int, int) (int, store.ReferencePager, error)
+	history     []StorePackageReferencePagerFuncCall
+	mutex       sync.Mutex
+}
+
+// PackageReferencePager delegates to the next hook function in the queue
+// and stores the parameter and result values of this invocation.
+func (m *MockStore) PackageReferencePager(v0 context.Context, v1 string, v2 string, v3 string, v4 int, v5 int) (int, store.ReferencePager, error) {
+	r0, r1, r2 := m.PackageReferencePagerFunc.nextHook()(v0, v1, v2, v3, v4, v5)
+	m.PackageReferencePagerFunc.appendCall(StorePackageReferencePagerFuncCall{v0, v1, v2, v3, v4, v5, r0, r1, r2})
+	return r0, r1, r2
+}
+
+// SetDefaultHook sets function that is called when the
+// PackageReferencePager method of the parent MockStore instance is invoked
+// and the hook queue is empty.
+func (f *StorePackageReferencePagerFunc) SetDefaultHook(hook func(context.Context, string, string, string, int, int) (int, store.ReferencePager, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// PackageReferencePager method of the parent MockStore instance inovkes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *StorePackageReferencePagerFunc) PushHook(hook func(context.Context, string, string, string, int, int) (int, store.ReferencePager, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *StorePackageReferencePagerFunc) SetDefaultReturn(r0 int, r1 store.ReferencePager, r2 error) {
+	f.SetDefaultHook(func(context.Context, string, string, string, int, int) (int, store.ReferencePager, error) {
+		return r0, r1, r2
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *StorePackageReferencePagerFunc) PushReturn(r0 int, r1 store.ReferencePager, r2 error) {
+	f.PushHook(func(context.Context, string, string, string, int, int) (int, store.ReferencePager, error) {
+		return r0, r1, r2
+	})
+}
+
+func (f *StorePackageReferencePagerFunc) nextHook() func(context.Context, string, string, string, int, int) (int, store.ReferencePager, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *StorePackageReferencePagerFunc) appendCall(r0 StorePackageReferencePagerFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of StorePackageReferencePagerFuncCall objects
+// describing the invocations of this function.
+func (f *StorePackageReferencePagerFunc) History() []StorePackageReferencePagerFuncCall {
+	f.mutex.Lock()
+	history := make([]StorePackageReferencePagerFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// StorePackageReferencePagerFuncCall is an object that describes an
+// invocation of method PackageReferencePager on an instance of MockStore.
+type StorePackageReferencePagerFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 string
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 string
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 string
+	// Arg4 is the value of the 5th argument passed to this method
 	// invocation.
 	Arg4 int
 	// Arg5 is the value of the 6th argument passed to this method
@@ -4870,6 +5648,116 @@ func (c StoreRepoUsageStatisticsFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// StoreRepositoryIDsWithCompletedUploadsFunc describes the behavior when
+// the RepositoryIDsWithCompletedUploads method of the parent MockStore
+// instance is invoked.
+type StoreRepositoryIDsWithCompletedUploadsFunc struct {
+	defaultHook func(context.Context) ([]int, error)
+	hooks       []func(context.Context) ([]int, error)
+	history     []StoreRepositoryIDsWithCompletedUploadsFuncCall
+	mutex       sync.Mutex
+}
+
+// RepositoryIDsWithCompletedUploads delegates to the next hook function in
+// the queue and stores the parameter and result values of this invocation.
+func (m *MockStore) RepositoryIDsWithCompletedUploads(v0 context.Context) ([]int, error) {
+	r0, r1 := m.RepositoryIDsWithCompletedUploadsFunc.nextHook()(v0)
+	m.RepositoryIDsWithCompletedUploadsFunc.appendCall(StoreRepositoryIDsWithCompletedUploadsFuncCall{v0, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the
+// RepositoryIDsWithCompletedUploads method of the parent MockStore instance
+// is invoked and the hook queue is empty.
+func (f *StoreRepositoryIDsWithCompletedUploadsFunc) SetDefaultHook(hook func(context.Context) ([]int, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// RepositoryIDsWithCompletedUploads method of the parent MockStore instance
+// inovkes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *StoreRepositoryIDsWithCompletedUploadsFunc) PushHook(hook func(context.Context) ([]int, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *StoreRepositoryIDsWithCompletedUploadsFunc) SetDefaultReturn(r0 []int, r1 error) {
+	f.SetDefaultHook(func(context.Context) ([]int, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *StoreRepositoryIDsWithCompletedUploadsFunc) PushReturn(r0 []int, r1 error) {
+	f.PushHook(func(context.Context) ([]int, error) {
+		return r0, r1
+	})
+}
+
+func (f *StoreRepositoryIDsWithCompletedUploadsFunc) nextHook() func(context.Context) ([]int, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *StoreRepositoryIDsWithCompletedUploadsFunc) appendCall(r0 StoreRepositoryIDsWithCompletedUploadsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// StoreRepositoryIDsWithCompletedUploadsFuncCall objects describing the
+// invocations of this function.
+func (f *StoreRepositoryIDsWithCompletedUploadsFunc) History() []StoreRepositoryIDsWithCompletedUploadsFuncCall {
+	f.mutex.Lock()
+	history := make([]StoreRepositoryIDsWithCompletedUploadsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// StoreRepositoryIDsWithCompletedUploadsFuncCall is an object that
+// describes an invocation of method RepositoryIDsWithCompletedUploads on an
+// instance of MockStore.
+type StoreRepositoryIDsWithCompletedUploadsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []int
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c StoreRepositoryIDsWithCompletedUploadsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c StoreRepositoryIDsWithCompletedUploadsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // StoreRequeueFunc describes the behavior when the Requeue method of the
 // parent MockStore instance is invoked.
 type StoreRequeueFunc struct {
@@ -5649,6 +6537,112 @@ func (c StoreTransactFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// StoreUpdateDiagnosticsFunc describes the behavior when the
+// UpdateDiagnostics method of the parent MockStore instance is invoked.
+type StoreUpdateDiagnosticsFunc struct {
+	defaultHook func(context.Context, []types.Diagnostic) error
+	hooks       []func(context.Context, []types.Diagnostic) error
+	history     []StoreUpdateDiagnosticsFuncCall
+	mutex       sync.Mutex
+}
+
+// UpdateDiagnostics delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockStore) UpdateDiagnostics(v0 context.Context, v1 []types.Diagnostic) error {
+	r0 := m.UpdateDiagnosticsFunc.nextHook()(v0, v1)
+	m.UpdateDiagnosticsFunc.appendCall(StoreUpdateDiagnosticsFuncCall{v0, v1, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the UpdateDiagnostics
+// method of the parent MockStore instance is invoked and the hook queue is
+// empty.
+func (f *StoreUpdateDiagnosticsFunc) SetDefaultHook(hook func(context.Context, []types.Diagnostic) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// UpdateDiagnostics method of the parent MockStore instance inovkes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *StoreUpdateDiagnosticsFunc) PushHook(hook func(context.Context, []types.Diagnostic) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *StoreUpdateDiagnosticsFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, []types.Diagnostic) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *StoreUpdateDiagnosticsFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, []types.Diagnostic) error {
+		return r0
+	})
+}
+
+func (f *StoreUpdateDiagnosticsFunc) nextHook() func(context.Context, []types.Diagnostic) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *StoreUpdateDiagnosticsFunc) appendCall(r0 StoreUpdateDiagnosticsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of StoreUpdateDiagnosticsFuncCall objects
+// describing the invocations of this function.
+func (f *StoreUpdateDiagnosticsFunc) History() []StoreUpdateDiagnosticsFuncCall {
+	f.mutex.Lock()
+	history := make([]StoreUpdateDiagnosticsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// StoreUpdateDiagnosticsFuncCall is an object that describes an invocation
+// of method UpdateDiagnostics on an instance of MockStore.
+type StoreUpdateDiagnosticsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 []types.Diagnostic
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c StoreUpdateDiagnosticsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c StoreUpdateDiagnosticsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
 // StoreUpdateIndexableRepositoryFunc describes the behavior when the
 // UpdateIndexableRepository method of the parent MockStore instance is
 // invoked.
@@ -5760,6 +6754,113 @@ func (c StoreUpdateIndexableRepositoryFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
+// StoreUpdateMonikerReferencesFunc describes the behavior when the
+// UpdateMonikerReferences method of the parent MockStore instance is
+// invoked.
+type StoreUpdateMonikerReferencesFunc struct {
+	defaultHook func(context.Context, []types.MonikerReference) error
+	hooks       []func(context.Context, []types.MonikerReference) error
+	history     []StoreUpdateMonikerReferencesFuncCall
+	mutex       sync.Mutex
+}
+
+// UpdateMonikerReferences delegates to the next hook function in the queue
+// and stores the parameter and result values of this invocation.
+func (m *MockStore) UpdateMonikerReferences(v0 context.Context, v1 []types.MonikerReference) error {
+	r0 := m.UpdateMonikerReferencesFunc.nextHook()(v0, v1)
+	m.UpdateMonikerReferencesFunc.appendCall(StoreUpdateMonikerReferencesFuncCall{v0, v1, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the
+// UpdateMonikerReferences method of the parent MockStore instance is
+// invoked and the hook queue is empty.
+func (f *StoreUpdateMonikerReferencesFunc) SetDefaultHook(hook func(context.Context, []types.MonikerReference) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// UpdateMonikerReferences method of the parent MockStore instance inovkes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *StoreUpdateMonikerReferencesFunc) PushHook(hook func(context.Context, []types.MonikerReference) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *StoreUpdateMonikerReferencesFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, []types.MonikerReference) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *StoreUpdateMonikerReferencesFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, []types.MonikerReference) error {
+		return r0
+	})
+}
+
+func (f *StoreUpdateMonikerReferencesFunc) nextHook() func(context.Context, []types.MonikerReference) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *StoreUpdateMonikerReferencesFunc) appendCall(r0 StoreUpdateMonikerReferencesFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of StoreUpdateMonikerReferencesFuncCall
+// objects describing the invocations of this function.
+func (f *StoreUpdateMonikerReferencesFunc) History() []StoreUpdateMonikerReferencesFuncCall {
+	f.mutex.Lock()
+	history := make([]StoreUpdateMonikerReferencesFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// StoreUpdateMonikerReferencesFuncCall is an object that describes an
+// invocation of method UpdateMonikerReferences on an instance of MockStore.
+type StoreUpdateMonikerReferencesFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 []types.MonikerReference
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c StoreUpdateMonikerReferencesFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c StoreUpdateMonikerReferencesFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
 // StoreUpdatePackageReferencesFunc describes the behavior when the
 // UpdatePackageReferences method of the parent MockStore instance is
 // invoked.
@@ -0,0 +1,149 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/bundles/types"
+)
+
+// MonikerReference is a dump that references a particular moniker imported from another package.
+type MonikerReference struct {
+	DumpID       int
+	RepositoryID int
+	Count        int
+}
+
+// RepositoryReferenceCount is the number of locations within a repository that reference a particular moniker.
+type RepositoryReferenceCount struct {
+	RepositoryID int
+	Count        int
+}
+
+// MonikerReferencesOptions determines the set of moniker references returned from the store. Moniker references
+// are only returned for dumps that are visible at the tip of the default branch of their repository.
+type MonikerReferencesOptions struct {
+	Scheme     string
+	Identifier string
+	Name       string
+	Version    string
+
+	// AllVersions disables version filtering so that references to any version of the package are returned.
+	AllVersions bool
+
+	// ExcludeRepositoryID, if non-zero, omits dumps of the given repository.
+	ExcludeRepositoryID int
+
+	// AfterRepositoryID and AfterDumpID form a cursor for keyset pagination: only references ordered after
+	// the given repository and dump pair are returned. Results are ordered by repository, then by dump.
+	AfterRepositoryID int
+	AfterDumpID       int
+
+	Limit int
+}
+
+// scanMonikerReferences scans a slice of moniker references from the return value of `*store.query`.
+func scanMonikerReferences(rows *sql.Rows, queryErr error) (_ []MonikerReference, err error) {
+	if queryErr != nil {
+		return nil, queryErr
+	}
+	defer func() { err = closeRows(rows, err) }()
+
+	var references []MonikerReference
+	for rows.Next() {
+		var reference MonikerReference
+		if err := rows.Scan(&reference.DumpID, &reference.RepositoryID, &reference.Count); err != nil {
+			return nil, err
+		}
+
+		references = append(references, reference)
+	}
+
+	return references, nil
+}
+
+// scanRepositoryReferenceCounts scans a slice of repository reference counts from the return value of `*store.query`.
+func scanRepositoryReferenceCounts(rows *sql.Rows, queryErr error) (_ []RepositoryReferenceCount, err error) {
+	if queryErr != nil {
+		return nil, queryErr
+	}
+	defer func() { err = closeRows(rows, err) }()
+
+	var counts []RepositoryReferenceCount
+	for rows.Next() {
+		var count RepositoryReferenceCount
+		if err := rows.Scan(&count.RepositoryID, &count.Count); err != nil {
+			return nil, err
+		}
+
+		counts = append(counts, count)
+	}
+
+	return counts, nil
+}
+
+// UpdateMonikerReferences inserts moniker reference data tied to the given upload.
+func (s *store) UpdateMonikerReferences(ctx context.Context, references []types.MonikerReference) (err error) {
+	if len(references) == 0 {
+		return nil
+	}
+
+	var values []*sqlf.Query
+	for _, r := range references {
+		values = append(values, sqlf.Sprintf("(%s, %s, %s, %s, %s, %s)", r.DumpID, r.Scheme, r.Identifier, r.Name, r.Version, r.Count))
+	}
+
+	return s.queryForEffect(ctx, sqlf.Sprintf(`
+		INSERT INTO lsif_moniker_references (dump_id, scheme, identifier, name, version, count)
+		VALUES %s
+	`, sqlf.Join(values, ",")))
+}
+
+// MonikerReferences returns a page of dumps that reference the moniker described by the given options along
+// with the number of references in each dump. Unlike PackageReferencePager, the results are exact.
+func (s *store) MonikerReferences(ctx context.Context, opts MonikerReferencesOptions) ([]MonikerReference, error) {
+	conds := monikerReferenceConditions(opts)
+	if opts.AfterRepositoryID != 0 || opts.AfterDumpID != 0 {
+		conds = append(conds, sqlf.Sprintf("(d.repository_id, d.id) > (%s, %s)", opts.AfterRepositoryID, opts.AfterDumpID))
+	}
+
+	return scanMonikerReferences(s.query(ctx, sqlf.Sprintf(`
+		SELECT d.id, d.repository_id, SUM(m.count) FROM lsif_moniker_references m
+		JOIN lsif_dumps_with_repository_name d ON d.id = m.dump_id
+		WHERE %s
+		GROUP BY d.repository_id, d.id
+		ORDER BY d.repository_id, d.id
+		LIMIT %d
+	`, sqlf.Join(conds, " AND "), opts.Limit)))
+}
+
+// MonikerReferenceCounts returns the number of locations referencing the moniker described by the given options,
+// grouped by repository. The pagination fields of the given options are ignored.
+func (s *store) MonikerReferenceCounts(ctx context.Context, opts MonikerReferencesOptions) ([]RepositoryReferenceCount, error) {
+	return scanRepositoryReferenceCounts(s.query(ctx, sqlf.Sprintf(`
+		SELECT d.repository_id, SUM(m.count) FROM lsif_moniker_references m
+		JOIN lsif_dumps_with_repository_name d ON d.id = m.dump_id
+		WHERE %s
+		GROUP BY d.repository_id
+		ORDER BY d.repository_id
+	`, sqlf.Join(monikerReferenceConditions(opts), " AND "))))
+}
+
+// monikerReferenceConditions returns the conditions shared by MonikerReferences and MonikerReferenceCounts.
+func monikerReferenceConditions(opts MonikerReferencesOptions) []*sqlf.Query {
+	conds := []*sqlf.Query{
+		sqlf.Sprintf("m.scheme = %s", opts.Scheme),
+		sqlf.Sprintf("m.identifier = %s", opts.Identifier),
+		sqlf.Sprintf("m.name = %s", opts.Name),
+		sqlf.Sprintf("EXISTS (SELECT 1 FROM lsif_uploads_visible_at_tip where repository_id = d.repository_id and upload_id = d.id)"),
+	}
+	if !opts.AllVersions {
+		conds = append(conds, sqlf.Sprintf("m.version = %s", opts.Version))
+	}
+	if opts.ExcludeRepositoryID != 0 {
+		conds = append(conds, sqlf.Sprintf("d.repository_id != %s", opts.ExcludeRepositoryID))
+	}
+
+	return conds
+}
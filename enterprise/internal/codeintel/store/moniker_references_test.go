@@ -0,0 +1,174 @@
+package store
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/bundles/types"
+	"github.com/sourcegraph/sourcegraph/internal/db/dbconn"
+	"github.com/sourcegraph/sourcegraph/internal/db/dbtesting"
+)
+
+func TestMonikerReferences(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	dbtesting.SetupGlobalTestDB(t)
+	store := testStore()
+
+	insertUploads(t, dbconn.Global,
+		Upload{ID: 1, Commit: makeCommit(1)},
+		Upload{ID: 2, Commit: makeCommit(2), RepositoryID: 51},
+		Upload{ID: 3, Commit: makeCommit(3), RepositoryID: 51, Root: "sub/"},
+		Upload{ID: 4, Commit: makeCommit(4), RepositoryID: 52},
+		Upload{ID: 5, Commit: makeCommit(5), RepositoryID: 53},
+		Upload{ID: 6, Commit: makeCommit(6), RepositoryID: 54},
+	)
+	insertVisibleAtTip(t, dbconn.Global, 50, 1)
+	insertVisibleAtTip(t, dbconn.Global, 51, 2, 3)
+	insertVisibleAtTip(t, dbconn.Global, 52, 4)
+	insertVisibleAtTip(t, dbconn.Global, 53, 5)
+
+	insertMonikerReferences(t, store, []types.MonikerReference{
+		{DumpID: 1, Scheme: "gomod", Identifier: "pad", Name: "leftpad", Version: "0.1.0", Count: 1},
+		{DumpID: 2, Scheme: "gomod", Identifier: "pad", Name: "leftpad", Version: "0.1.0", Count: 2},
+		{DumpID: 3, Scheme: "gomod", Identifier: "pad", Name: "leftpad", Version: "0.1.0", Count: 3},
+		{DumpID: 3, Scheme: "gomod", Identifier: "trim", Name: "leftpad", Version: "0.1.0", Count: 4},
+		{DumpID: 4, Scheme: "gomod", Identifier: "pad", Name: "leftpad", Version: "0.2.0", Count: 5},
+		{DumpID: 5, Scheme: "gomod", Identifier: "pad", Name: "leftpad", Version: "0.1.0", Count: 6},
+		{DumpID: 6, Scheme: "gomod", Identifier: "pad", Name: "leftpad", Version: "0.1.0", Count: 7}, // not visible
+	})
+
+	opts := MonikerReferencesOptions{
+		Scheme:              "gomod",
+		Identifier:          "pad",
+		Name:                "leftpad",
+		Version:             "0.1.0",
+		ExcludeRepositoryID: 50,
+		Limit:               2,
+	}
+
+	references, err := store.MonikerReferences(context.Background(), opts)
+	if err != nil {
+		t.Fatalf("unexpected error getting moniker references: %s", err)
+	}
+	expectedReferences := []MonikerReference{
+		{DumpID: 2, RepositoryID: 51, Count: 2},
+		{DumpID: 3, RepositoryID: 51, Count: 3},
+	}
+	if diff := cmp.Diff(expectedReferences, references); diff != "" {
+		t.Errorf("unexpected moniker references (-want +got):\n%s", diff)
+	}
+
+	opts.AfterRepositoryID = 51
+	opts.AfterDumpID = 3
+	references, err = store.MonikerReferences(context.Background(), opts)
+	if err != nil {
+		t.Fatalf("unexpected error getting moniker references: %s", err)
+	}
+	expectedReferences = []MonikerReference{
+		{DumpID: 5, RepositoryID: 53, Count: 6},
+	}
+	if diff := cmp.Diff(expectedReferences, references); diff != "" {
+		t.Errorf("unexpected moniker references (-want +got):\n%s", diff)
+	}
+
+	opts.AllVersions = true
+	references, err = store.MonikerReferences(context.Background(), opts)
+	if err != nil {
+		t.Fatalf("unexpected error getting moniker references: %s", err)
+	}
+	expectedReferences = []MonikerReference{
+		{DumpID: 4, RepositoryID: 52, Count: 5},
+		{DumpID: 5, RepositoryID: 53, Count: 6},
+	}
+	if diff := cmp.Diff(expectedReferences, references); diff != "" {
+		t.Errorf("unexpected moniker references (-want +got):\n%s", diff)
+	}
+}
+
+func TestMonikerReferenceCounts(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	dbtesting.SetupGlobalTestDB(t)
+	store := testStore()
+
+	insertUploads(t, dbconn.Global,
+		Upload{ID: 1, Commit: makeCommit(1)},
+		Upload{ID: 2, Commit: makeCommit(2), RepositoryID: 51},
+		Upload{ID: 3, Commit: makeCommit(3), RepositoryID: 51, Root: "sub/"},
+		Upload{ID: 4, Commit: makeCommit(4), RepositoryID: 52},
+	)
+	insertVisibleAtTip(t, dbconn.Global, 50, 1)
+	insertVisibleAtTip(t, dbconn.Global, 51, 2, 3)
+	insertVisibleAtTip(t, dbconn.Global, 52, 4)
+
+	insertMonikerReferences(t, store, []types.MonikerReference{
+		{DumpID: 1, Scheme: "gomod", Identifier: "pad", Name: "leftpad", Version: "0.1.0", Count: 1},
+		{DumpID: 2, Scheme: "gomod", Identifier: "pad", Name: "leftpad", Version: "0.1.0", Count: 2},
+		{DumpID: 3, Scheme: "gomod", Identifier: "pad", Name: "leftpad", Version: "0.1.0", Count: 3},
+		{DumpID: 4, Scheme: "gomod", Identifier: "pad", Name: "leftpad", Version: "0.2.0", Count: 4},
+	})
+
+	counts, err := store.MonikerReferenceCounts(context.Background(), MonikerReferencesOptions{
+		Scheme:      "gomod",
+		Identifier:  "pad",
+		Name:        "leftpad",
+		AllVersions: true,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error getting moniker reference counts: %s", err)
+	}
+
+	expectedCounts := []RepositoryReferenceCount{
+		{RepositoryID: 50, Count: 1},
+		{RepositoryID: 51, Count: 5},
+		{RepositoryID: 52, Count: 4},
+	}
+	if diff := cmp.Diff(expectedCounts, counts); diff != "" {
+		t.Errorf("unexpected counts (-want +got):\n%s", diff)
+	}
+}
+
+func TestPackageReferencePagerSkipsIndexedDumps(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	dbtesting.SetupGlobalTestDB(t)
+	store := testStore()
+
+	insertUploads(t, dbconn.Global,
+		Upload{ID: 1, Commit: makeCommit(1), RepositoryID: 51},
+		Upload{ID: 2, Commit: makeCommit(2), RepositoryID: 52},
+	)
+	insertVisibleAtTip(t, dbconn.Global, 51, 1)
+	insertVisibleAtTip(t, dbconn.Global, 52, 2)
+
+	expected := []types.PackageReference{
+		{DumpID: 2, Scheme: "gomod", Name: "leftpad", Version: "0.1.0", Filter: []byte("f2")},
+	}
+	insertPackageReferences(t, store, append([]types.PackageReference{
+		{DumpID: 1, Scheme: "gomod", Name: "leftpad", Version: "0.1.0", Filter: []byte("f1")},
+	}, expected...))
+	insertMonikerReferences(t, store, []types.MonikerReference{
+		{DumpID: 1, Scheme: "gomod", Identifier: "pad", Name: "leftpad", Version: "0.1.0", Count: 1},
+	})
+
+	totalCount, pager, err := store.PackageReferencePager(context.Background(), "gomod", "leftpad", "0.1.0", 50, 5)
+	if err != nil {
+		t.Fatalf("unexpected error getting pager: %s", err)
+	}
+	defer func() { _ = pager.Done(nil) }()
+
+	if totalCount != 1 {
+		t.Errorf("unexpected dump. want=%d have=%d", 1, totalCount)
+	}
+
+	if references, err := pager.PageFromOffset(context.Background(), 0); err != nil {
+		t.Fatalf("unexpected error getting next page: %s", err)
+	} else if diff := cmp.Diff(expected, references); diff != "" {
+		t.Errorf("unexpected references (-want +got):\n%s", diff)
+	}
+}
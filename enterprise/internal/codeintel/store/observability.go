@@ -38,11 +38,20 @@ type ObservedStore struct {
 	sameRepoPagerOperation                  *observation.Operation
 	updatePackageReferencesOperation        *observation.Operation
 	packageReferencePagerOperation          *observation.Operation
+	updateMonikerReferencesOperation        *observation.Operation
+	monikerReferencesOperation              *observation.Operation
+	monikerReferenceCountsOperation         *observation.Operation
+	updateDiagnosticsOperation              *observation.Operation
+	getDiagnosticsOperation                 *observation.Operation
+	diagnosticCountsOperation               *observation.Operation
 	hasRepositoryOperation                  *observation.Operation
 	hasCommitOperation                      *observation.Operation
+	commitGraphTipOperation                 *observation.Operation
 	markRepositoryAsDirtyOperation          *observation.Operation
 	dirtyRepositoriesOperation              *observation.Operation
 	fixCommitsOperation                     *observation.Operation
+	repositoriesWithUploadsOperation        *observation.Operation
+	evaluateRetentionPoliciesOperation      *observation.Operation
 	indexableRepositoriesOperation          *observation.Operation
 	updateIndexableRepositoryOperation      *observation.Operation
 	resetIndexableRepositoriesOperation     *observation.Operation
@@ -200,6 +209,36 @@ func NewObserved(store Store, observationContext *observation.Context) Store {
 			MetricLabels: []string{"package_reference_pager"},
 			Metrics:      metrics,
 		}),
+		updateMonikerReferencesOperation: observationContext.Operation(observation.Op{
+			Name:         "store.UpdateMonikerReferences",
+			MetricLabels: []string{"update_moniker_references"},
+			Metrics:      metrics,
+		}),
+		monikerReferencesOperation: observationContext.Operation(observation.Op{
+			Name:         "store.MonikerReferences",
+			MetricLabels: []string{"moniker_references"},
+			Metrics:      metrics,
+		}),
+		monikerReferenceCountsOperation: observationContext.Operation(observation.Op{
+			Name:         "store.MonikerReferenceCounts",
+			MetricLabels: []string{"moniker_reference_counts"},
+			Metrics:      metrics,
+		}),
+		updateDiagnosticsOperation: observationContext.Operation(observation.Op{
+			Name:         "store.UpdateDiagnostics",
+			MetricLabels: []string{"update_diagnostics"},
+			Metrics:      metrics,
+		}),
+		getDiagnosticsOperation: observationContext.Operation(observation.Op{
+			Name:         "store.GetDiagnostics",
+			MetricLabels: []string{"get_diagnostics"},
+			Metrics:      metrics,
+		}),
+		diagnosticCountsOperation: observationContext.Operation(observation.Op{
+			Name:         "store.DiagnosticCounts",
+			MetricLabels: []string{"diagnostic_counts"},
+			Metrics:      metrics,
+		}),
 		hasRepositoryOperation: observationContext.Operation(observation.Op{
 			Name:         "store.HasRepository",
 			MetricLabels: []string{"has_repository"},
@@ -210,6 +249,11 @@ func NewObserved(store Store, observationContext *observation.Context) Store {
 			MetricLabels: []string{"has_commit"},
 			Metrics:      metrics,
 		}),
+		commitGraphTipOperation: observationContext.Operation(observation.Op{
+			Name:         "store.CommitGraphTip",
+			MetricLabels: []string{"commit_graph_tip"},
+			Metrics:      metrics,
+		}),
 		markRepositoryAsDirtyOperation: observationContext.Operation(observation.Op{
 			Name:         "store.MarkRepositoryAsDirty",
 			MetricLabels: []string{"mark_repository_as_dirty"},
@@ -225,6 +269,16 @@ func NewObserved(store Store, observationContext *observation.Context) Store {
 			MetricLabels: []string{"fix_commits"},
 			Metrics:      metrics,
 		}),
+		repositoriesWithUploadsOperation: observationContext.Operation(observation.Op{
+			Name:         "store.RepositoryIDsWithCompletedUploads",
+			MetricLabels: []string{"repository_ids_with_completed_uploads"},
+			Metrics:      metrics,
+		}),
+		evaluateRetentionPoliciesOperation: observationContext.Operation(observation.Op{
+			Name:         "store.EvaluateRetentionPolicies",
+			MetricLabels: []string{"evaluate_retention_policies"},
+			Metrics:      metrics,
+		}),
 		indexableRepositoriesOperation: observationContext.Operation(observation.Op{
 			Name:         "store.IndexableRepositories",
 			MetricLabels: []string{"indexable_repositories"},
@@ -346,11 +400,20 @@ func (s *ObservedStore) wrap(other Store) Store {
 		sameRepoPagerOperation:                  s.sameRepoPagerOperation,
 		updatePackageReferencesOperation:        s.updatePackageReferencesOperation,
 		packageReferencePagerOperation:          s.packageReferencePagerOperation,
+		updateMonikerReferencesOperation:        s.updateMonikerReferencesOperation,
+		monikerReferencesOperation:              s.monikerReferencesOperation,
+		monikerReferenceCountsOperation:         s.monikerReferenceCountsOperation,
+		updateDiagnosticsOperation:              s.updateDiagnosticsOperation,
+		getDiagnosticsOperation:                 s.getDiagnosticsOperation,
+		diagnosticCountsOperation:               s.diagnosticCountsOperation,
 		hasRepositoryOperation:                  s.hasRepositoryOperation,
 		hasCommitOperation:                      s.hasCommitOperation,
+		commitGraphTipOperation:                 s.commitGraphTipOperation,
 		markRepositoryAsDirtyOperation:          s.markRepositoryAsDirtyOperation,
 		dirtyRepositoriesOperation:              s.dirtyRepositoriesOperation,
 		fixCommitsOperation:                     s.fixCommitsOperation,
+		repositoriesWithUploadsOperation:        s.repositoriesWithUploadsOperation,
+		evaluateRetentionPoliciesOperation:      s.evaluateRetentionPoliciesOperation,
 		indexableRepositoriesOperation:          s.indexableRepositoriesOperation,
 		updateIndexableRepositoryOperation:      s.updateIndexableRepositoryOperation,
 		resetIndexableRepositoriesOperation:     s.resetIndexableRepositoriesOperation,
@@ -582,6 +645,48 @@ func (s *ObservedStore) PackageReferencePager(ctx context.Context, scheme, name,
 	return s.store.PackageReferencePager(ctx, scheme, name, version, repositoryID, limit)
 }
 
+// UpdateMonikerReferences calls into the inner store and registers the observed results.
+func (s *ObservedStore) UpdateMonikerReferences(ctx context.Context, monikerReferences []types.MonikerReference) (err error) {
+	ctx, endObservation := s.updateMonikerReferencesOperation.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+	return s.store.UpdateMonikerReferences(ctx, monikerReferences)
+}
+
+// MonikerReferences calls into the inner store and registers the observed results.
+func (s *ObservedStore) MonikerReferences(ctx context.Context, opts MonikerReferencesOptions) (_ []MonikerReference, err error) {
+	ctx, endObservation := s.monikerReferencesOperation.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+	return s.store.MonikerReferences(ctx, opts)
+}
+
+// MonikerReferenceCounts calls into the inner store and registers the observed results.
+func (s *ObservedStore) MonikerReferenceCounts(ctx context.Context, opts MonikerReferencesOptions) (_ []RepositoryReferenceCount, err error) {
+	ctx, endObservation := s.monikerReferenceCountsOperation.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+	return s.store.MonikerReferenceCounts(ctx, opts)
+}
+
+// UpdateDiagnostics calls into the inner store and registers the observed results.
+func (s *ObservedStore) UpdateDiagnostics(ctx context.Context, diagnostics []types.Diagnostic) (err error) {
+	ctx, endObservation := s.updateDiagnosticsOperation.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+	return s.store.UpdateDiagnostics(ctx, diagnostics)
+}
+
+// GetDiagnostics calls into the inner store and registers the observed results.
+func (s *ObservedStore) GetDiagnostics(ctx context.Context, opts GetDiagnosticsOptions) (diagnostics []IndexedDiagnostic, _ int, err error) {
+	ctx, endObservation := s.getDiagnosticsOperation.With(ctx, &err, observation.Args{})
+	defer func() { endObservation(float64(len(diagnostics)), observation.Args{}) }()
+	return s.store.GetDiagnostics(ctx, opts)
+}
+
+// DiagnosticCounts calls into the inner store and registers the observed results.
+func (s *ObservedStore) DiagnosticCounts(ctx context.Context, repositoryID int, filter DiagnosticFilter) (_ []DiagnosticCount, err error) {
+	ctx, endObservation := s.diagnosticCountsOperation.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+	return s.store.DiagnosticCounts(ctx, repositoryID, filter)
+}
+
 // HasRepository calls into the inner store and registers the observed results.
 func (s *ObservedStore) HasRepository(ctx context.Context, repositoryID int) (_ bool, err error) {
 	ctx, endObservation := s.hasRepositoryOperation.With(ctx, &err, observation.Args{})
@@ -596,6 +701,13 @@ func (s *ObservedStore) HasCommit(ctx context.Context, repositoryID int, commit
 	return s.store.HasCommit(ctx, repositoryID, commit)
 }
 
+// CommitGraphTip calls into the inner store and registers the observed results.
+func (s *ObservedStore) CommitGraphTip(ctx context.Context, repositoryID int) (_ string, _ bool, err error) {
+	ctx, endObservation := s.commitGraphTipOperation.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+	return s.store.CommitGraphTip(ctx, repositoryID)
+}
+
 // MarkRepositoryAsDirty calls into the inner store and registers the observed results.
 func (s *ObservedStore) MarkRepositoryAsDirty(ctx context.Context, repositoryID int) (err error) {
 	ctx, endObservation := s.markRepositoryAsDirtyOperation.With(ctx, &err, observation.Args{})
@@ -617,6 +729,20 @@ func (s *ObservedStore) CalculateVisibleUploads(ctx context.Context, repositoryI
 	return s.store.CalculateVisibleUploads(ctx, repositoryID, graph, tipCommit, dirtyToken)
 }
 
+// RepositoryIDsWithCompletedUploads calls into the inner store and registers the observed results.
+func (s *ObservedStore) RepositoryIDsWithCompletedUploads(ctx context.Context) (repositoryIDs []int, err error) {
+	ctx, endObservation := s.repositoriesWithUploadsOperation.With(ctx, &err, observation.Args{})
+	defer func() { endObservation(float64(len(repositoryIDs)), observation.Args{}) }()
+	return s.store.RepositoryIDsWithCompletedUploads(ctx)
+}
+
+// EvaluateRetentionPolicies calls into the inner store and registers the observed results.
+func (s *ObservedStore) EvaluateRetentionPolicies(ctx context.Context, repositoryID int, graph map[string][]string, refs []GitRef, policies []RetentionPolicy, now time.Time) (retained map[int][]string, err error) {
+	ctx, endObservation := s.evaluateRetentionPoliciesOperation.With(ctx, &err, observation.Args{})
+	defer func() { endObservation(float64(len(retained)), observation.Args{}) }()
+	return s.store.EvaluateRetentionPolicies(ctx, repositoryID, graph, refs, policies, now)
+}
+
 // IndexableRepositories calls into the inner store and registers the observed results.
 func (s *ObservedStore) IndexableRepositories(ctx context.Context, opts IndexableRepositoryQueryOptions) (repos []IndexableRepository, err error) {
 	ctx, endObservation := s.indexableRepositoriesOperation.With(ctx, &err, observation.Args{})
@@ -73,7 +73,8 @@ func (s *store) SameRepoPager(ctx context.Context, repositoryID int, commit, sch
 
 // PackageReferencePager returns a ReferencePager for dumps that belong to a remote repository (distinct from the given repository id)
 // and reference the package with the given scheme, name, and version. All resulting dumps are visible at the tip of their repository's
-// default branch.
+// default branch. Dumps with entries in the moniker reference index are excluded as they are precisely handled by
+// MonikerReferences.
 func (s *store) PackageReferencePager(ctx context.Context, scheme, name, version string, repositoryID, limit int) (_ int, _ ReferencePager, err error) {
 	tx, err := s.transact(ctx)
 	if err != nil {
@@ -86,6 +87,7 @@ func (s *store) PackageReferencePager(ctx context.Context, scheme, name, version
 		sqlf.Sprintf("r.version = %s", version),
 		sqlf.Sprintf("d.repository_id != %s", repositoryID),
 		sqlf.Sprintf("EXISTS (SELECT 1 FROM lsif_uploads_visible_at_tip where repository_id = d.repository_id and upload_id = d.id)"),
+		sqlf.Sprintf("NOT EXISTS (SELECT 1 FROM lsif_moniker_references m WHERE m.dump_id = r.dump_id)"),
 	}
 
 	totalCount, _, err := scanFirstInt(tx.query(
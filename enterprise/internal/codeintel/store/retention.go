@@ -0,0 +1,220 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"sort"
+	"time"
+
+	"github.com/gobwas/glob"
+	"github.com/keegancsmith/sqlf"
+)
+
+// RetentionPolicyType determines the set of objects to which a retention policy applies.
+type RetentionPolicyType string
+
+const (
+	// RetentionPolicyTypeBranches retains the uploads visible from the tips of matching branches.
+	RetentionPolicyTypeBranches RetentionPolicyType = "branches"
+
+	// RetentionPolicyTypeTags retains the uploads visible from the commits of matching tags.
+	RetentionPolicyTypeTags RetentionPolicyType = "tags"
+
+	// RetentionPolicyTypeUploads retains uploads based on their own age.
+	RetentionPolicyTypeUploads RetentionPolicyType = "uploads"
+)
+
+// DefaultBranchRetentionReason is reported for uploads that are visible from the tip of the
+// default branch. These uploads are retained regardless of the configured retention policies.
+const DefaultBranchRetentionReason = "tip of default branch"
+
+// RetentionPolicy describes a set of completed uploads that should not be removed.
+type RetentionPolicy struct {
+	Name string
+	Type RetentionPolicyType
+
+	// Pattern is matched against branch and tag names. A nil pattern matches every name.
+	Pattern glob.Glob
+
+	// MaxAge is the maximum age of the branch tip, tag, or upload. A zero value indicates
+	// that matching uploads should be retained indefinitely.
+	MaxAge time.Duration
+}
+
+// GitRefType distinguishes between branches and tags.
+type GitRefType int
+
+const (
+	GitRefTypeBranch GitRefType = iota
+	GitRefTypeTag
+)
+
+// GitRef describes a branch or a tag of a repository.
+type GitRef struct {
+	Name            string
+	Type            GitRefType
+	Commit          string
+	CreatedAt       time.Time
+	IsDefaultBranch bool
+}
+
+// uploadRetentionMeta contains the subset of fields from the lsif_uploads table that are used to
+// evaluate retention policies.
+type uploadRetentionMeta struct {
+	UploadMeta
+	Commit     string
+	UploadedAt time.Time
+}
+
+// scanUploadRetentionMeta scans upload retention metadata from the return value of `*store.query`.
+func scanUploadRetentionMeta(rows *sql.Rows, queryErr error) (_ []uploadRetentionMeta, err error) {
+	if queryErr != nil {
+		return nil, queryErr
+	}
+	defer func() { err = closeRows(rows, err) }()
+
+	var uploads []uploadRetentionMeta
+	for rows.Next() {
+		var upload uploadRetentionMeta
+		if err := rows.Scan(&upload.UploadID, &upload.Commit, &upload.Root, &upload.Indexer, &upload.UploadedAt); err != nil {
+			return nil, err
+		}
+
+		uploads = append(uploads, upload)
+	}
+
+	return uploads, nil
+}
+
+// RepositoryIDsWithCompletedUploads returns the identifiers of all repositories with at least one completed upload.
+func (s *store) RepositoryIDsWithCompletedUploads(ctx context.Context) ([]int, error) {
+	return scanInts(s.query(ctx, sqlf.Sprintf(`
+		SELECT DISTINCT repository_id
+		FROM lsif_uploads
+		WHERE state = 'completed'
+		ORDER BY repository_id
+	`)))
+}
+
+// EvaluateRetentionPolicies determines which of the completed uploads of the given repository are retained by the
+// given policies when evaluated against the given commit graph and set of branches and tags. The resulting map
+// contains an entry for every completed upload of the repository whose commit is in the commit graph, and its
+// value is the sorted set of names of the policies that retain it. Uploads mapped to an empty slice are not
+// retained by any policy. Uploads of commits that are not in the commit graph yet are not evaluated and are
+// missing from the map.
+func (s *store) EvaluateRetentionPolicies(ctx context.Context, repositoryID int, graph map[string][]string, refs []GitRef, policies []RetentionPolicy, now time.Time) (map[int][]string, error) {
+	uploads, err := scanUploadRetentionMeta(s.query(ctx, sqlf.Sprintf(`
+		SELECT id, commit, root, indexer, uploaded_at
+		FROM lsif_uploads
+		WHERE state = 'completed' AND repository_id = %s
+	`, repositoryID)))
+	if err != nil {
+		return nil, err
+	}
+
+	return calculateRetainedUploads(graph, uploads, refs, policies, now)
+}
+
+// calculateRetainedUploads determines the set of policies that retain each of the given uploads. An upload
+// is retained by a branch or tag policy if it is one of the uploads visible from the commit of a matching ref
+// (as determined by calculateVisibleUploads). Uploads visible from the tip of the default branch are always
+// retained. Uploads whose commit is not in the graph are skipped.
+func calculateRetainedUploads(graph map[string][]string, uploads []uploadRetentionMeta, refs []GitRef, policies []RetentionPolicy, now time.Time) (map[int][]string, error) {
+	// The commit graph of gitserver may lag behind uploads of recently pushed commits. It is
+	// not known yet which refs can see them, so we must not consider them expired.
+	known := uploads[:0:0]
+	for _, upload := range uploads {
+		if _, ok := graph[upload.Commit]; ok {
+			known = append(known, upload)
+		}
+	}
+	uploads = known
+
+	uploadMeta := map[string][]UploadMeta{}
+	for _, upload := range uploads {
+		uploadMeta[upload.Commit] = append(uploadMeta[upload.Commit], upload.UploadMeta)
+	}
+
+	visibleUploads, err := calculateVisibleUploads(graph, uploadMeta)
+	if err != nil {
+		return nil, err
+	}
+
+	reasons := make(map[int]map[string]struct{}, len(uploads))
+	for _, upload := range uploads {
+		reasons[upload.UploadID] = map[string]struct{}{}
+	}
+
+	retain := func(uploads []UploadMeta, reason string) {
+		for _, upload := range uploads {
+			if set, ok := reasons[upload.UploadID]; ok {
+				set[reason] = struct{}{}
+			}
+		}
+	}
+
+	for _, ref := range refs {
+		if ref.IsDefaultBranch {
+			retain(visibleUploads[ref.Commit], DefaultBranchRetentionReason)
+		}
+	}
+
+	for _, policy := range policies {
+		if policy.Type == RetentionPolicyTypeUploads {
+			for _, upload := range uploads {
+				if withinMaxAge(policy.MaxAge, upload.UploadedAt, now) {
+					reasons[upload.UploadID][policy.Name] = struct{}{}
+				}
+			}
+
+			continue
+		}
+
+		for _, ref := range refs {
+			if policyMatchesRef(policy, ref, now) {
+				retain(visibleUploads[ref.Commit], policy.Name)
+			}
+		}
+	}
+
+	retained := make(map[int][]string, len(reasons))
+	for uploadID, set := range reasons {
+		names := make([]string, 0, len(set))
+		for name := range set {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		retained[uploadID] = names
+	}
+
+	return retained, nil
+}
+
+// policyMatchesRef determines if the given branch or tag policy applies to the given ref.
+func policyMatchesRef(policy RetentionPolicy, ref GitRef, now time.Time) bool {
+	switch policy.Type {
+	case RetentionPolicyTypeBranches:
+		if ref.Type != GitRefTypeBranch {
+			return false
+		}
+	case RetentionPolicyTypeTags:
+		if ref.Type != GitRefTypeTag {
+			return false
+		}
+	default:
+		return false
+	}
+
+	if policy.Pattern != nil && !policy.Pattern.Match(ref.Name) {
+		return false
+	}
+
+	return withinMaxAge(policy.MaxAge, ref.CreatedAt, now)
+}
+
+// withinMaxAge returns true if the given time is no older than the given maximum age. A zero
+// maximum age matches every time.
+func withinMaxAge(maxAge time.Duration, t, now time.Time) bool {
+	return maxAge == 0 || now.Sub(t) <= maxAge
+}
@@ -0,0 +1,138 @@
+package store
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gobwas/glob"
+	"github.com/google/go-cmp/cmp"
+	"github.com/sourcegraph/sourcegraph/internal/db/dbconn"
+	"github.com/sourcegraph/sourcegraph/internal/db/dbtesting"
+)
+
+func TestRepositoryIDsWithCompletedUploads(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	dbtesting.SetupGlobalTestDB(t)
+	store := testStore()
+
+	insertUploads(t, dbconn.Global,
+		Upload{ID: 1, RepositoryID: 50},
+		Upload{ID: 2, RepositoryID: 50},
+		Upload{ID: 3, RepositoryID: 51, State: "queued"},
+		Upload{ID: 4, RepositoryID: 52},
+	)
+
+	repositoryIDs, err := store.RepositoryIDsWithCompletedUploads(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error getting repositories: %s", err)
+	}
+
+	if diff := cmp.Diff([]int{50, 52}, repositoryIDs); diff != "" {
+		t.Errorf("unexpected repository ids (-want +got):\n%s", diff)
+	}
+}
+
+func TestEvaluateRetentionPolicies(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	dbtesting.SetupGlobalTestDB(t)
+	store := testStore()
+
+	now := time.Unix(1587396557, 0).UTC()
+	insertUploads(t, dbconn.Global,
+		Upload{ID: 1, Commit: makeCommit(1), UploadedAt: now.Add(-time.Hour * 24 * 30)},
+		Upload{ID: 2, Commit: makeCommit(3), UploadedAt: now.Add(-time.Hour * 24 * 30)},
+		Upload{ID: 3, Commit: makeCommit(4), State: "errored"},
+	)
+
+	graph := map[string][]string{
+		makeCommit(1): {},
+		makeCommit(2): {makeCommit(1)},
+		makeCommit(3): {makeCommit(1)},
+	}
+	refs := []GitRef{
+		{Name: "master", Type: GitRefTypeBranch, Commit: makeCommit(2), CreatedAt: now, IsDefaultBranch: true},
+		{Name: "feature", Type: GitRefTypeBranch, Commit: makeCommit(3), CreatedAt: now},
+	}
+	policies := []RetentionPolicy{
+		{Name: "active branches", Type: RetentionPolicyTypeBranches, Pattern: glob.MustCompile("feature*")},
+	}
+
+	retained, err := store.EvaluateRetentionPolicies(context.Background(), 50, graph, refs, policies, now)
+	if err != nil {
+		t.Fatalf("unexpected error evaluating retention policies: %s", err)
+	}
+
+	expected := map[int][]string{
+		1: {DefaultBranchRetentionReason},
+		2: {"active branches"},
+	}
+	if diff := cmp.Diff(expected, retained); diff != "" {
+		t.Errorf("unexpected retained uploads (-want +got):\n%s", diff)
+	}
+}
+
+func TestCalculateRetainedUploads(t *testing.T) {
+	// This test uses the following commit graph:
+	//
+	// [1] --+-- 2 -- [3] -- 4           (master)
+	//       |               |
+	//       |               +-- [7]     (v1.0)
+	//       +-- [5] -- 6                (feature/old)
+
+	now := time.Unix(1587396557, 0).UTC()
+	day := time.Hour * 24
+
+	graph := map[string][]string{
+		makeCommit(1): {},
+		makeCommit(2): {makeCommit(1)},
+		makeCommit(3): {makeCommit(2)},
+		makeCommit(4): {makeCommit(3)},
+		makeCommit(5): {makeCommit(1)},
+		makeCommit(6): {makeCommit(5)},
+		makeCommit(7): {makeCommit(4)},
+	}
+
+	uploads := []uploadRetentionMeta{
+		{UploadMeta: UploadMeta{UploadID: 1, Indexer: "lsif-go"}, Commit: makeCommit(1), UploadedAt: now.Add(-day * 500)},
+		{UploadMeta: UploadMeta{UploadID: 2, Indexer: "lsif-go"}, Commit: makeCommit(3), UploadedAt: now.Add(-day * 300)},
+		{UploadMeta: UploadMeta{UploadID: 3, Indexer: "lsif-go"}, Commit: makeCommit(5), UploadedAt: now.Add(-day * 60)},
+		{UploadMeta: UploadMeta{UploadID: 4, Indexer: "lsif-go"}, Commit: makeCommit(7), UploadedAt: now.Add(-day * 2)},
+		{UploadMeta: UploadMeta{UploadID: 5, Root: "web/", Indexer: "lsif-tsc"}, Commit: makeCommit(5), UploadedAt: now.Add(-day)},
+		// Commit 8 is not in the commit graph yet
+		{UploadMeta: UploadMeta{UploadID: 6, Indexer: "lsif-go"}, Commit: makeCommit(8), UploadedAt: now.Add(-day * 400)},
+	}
+
+	refs := []GitRef{
+		{Name: "master", Type: GitRefTypeBranch, Commit: makeCommit(4), CreatedAt: now.Add(-day), IsDefaultBranch: true},
+		{Name: "feature/old", Type: GitRefTypeBranch, Commit: makeCommit(6), CreatedAt: now.Add(-day * 60)},
+		{Name: "v1.0", Type: GitRefTypeTag, Commit: makeCommit(7), CreatedAt: now.Add(-day * 2)},
+		{Name: "nightly", Type: GitRefTypeTag, Commit: makeCommit(1), CreatedAt: now.Add(-day * 10)},
+	}
+
+	policies := []RetentionPolicy{
+		{Name: "release tags", Type: RetentionPolicyTypeTags, Pattern: glob.MustCompile("v*"), MaxAge: day * 365},
+		{Name: "active branches", Type: RetentionPolicyTypeBranches, MaxAge: day * 30},
+		{Name: "recent uploads", Type: RetentionPolicyTypeUploads, MaxAge: day * 7},
+	}
+
+	retained, err := calculateRetainedUploads(graph, uploads, refs, policies, now)
+	if err != nil {
+		t.Fatalf("unexpected error calculating retained uploads: %s", err)
+	}
+
+	expected := map[int][]string{
+		1: {},
+		2: {"active branches", DefaultBranchRetentionReason},
+		3: {},
+		4: {"recent uploads", "release tags"},
+		5: {"recent uploads"},
+	}
+	if diff := cmp.Diff(expected, retained); diff != "" {
+		t.Errorf("unexpected retained uploads (-want +got):\n%s", diff)
+	}
+}
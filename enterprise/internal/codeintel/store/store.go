@@ -119,15 +119,41 @@ type Store interface {
 
 	// PackageReferencePager returns a ReferencePager for dumps that belong to a remote repository (distinct from the given repository id)
 	// and reference the package with the given scheme, name, and version. All resulting dumps are visible at the tip of their repository's
-	// default branch.
+	// default branch. Dumps with entries in the moniker reference index are excluded.
 	PackageReferencePager(ctx context.Context, scheme, name, version string, repositoryID, limit int) (int, ReferencePager, error)
 
+	// UpdateMonikerReferences bulk inserts moniker reference data.
+	UpdateMonikerReferences(ctx context.Context, monikerReferences []types.MonikerReference) error
+
+	// MonikerReferences returns a page of dumps that reference the moniker described by the given options along with the
+	// number of references in each dump. Results are ordered by repository and dump identifier.
+	MonikerReferences(ctx context.Context, opts MonikerReferencesOptions) ([]MonikerReference, error)
+
+	// MonikerReferenceCounts returns the number of locations referencing the moniker described by the given options, grouped
+	// by repository.
+	MonikerReferenceCounts(ctx context.Context, opts MonikerReferencesOptions) ([]RepositoryReferenceCount, error)
+
+	// UpdateDiagnostics bulk inserts diagnostics data.
+	UpdateDiagnostics(ctx context.Context, diagnostics []types.Diagnostic) error
+
+	// GetDiagnostics returns a page of diagnostics reported by dumps visible at the tip of their repository's default branch
+	// matching the given options, along with the total number of matching diagnostics.
+	GetDiagnostics(ctx context.Context, opts GetDiagnosticsOptions) ([]IndexedDiagnostic, int, error)
+
+	// DiagnosticCounts returns the number of diagnostics matching the given filter reported by each completed upload of the
+	// given repository, ordered by the time the upload was processed.
+	DiagnosticCounts(ctx context.Context, repositoryID int, filter DiagnosticFilter) ([]DiagnosticCount, error)
+
 	// HasRepository determines if there is LSIF data for the given repository.
 	HasRepository(ctx context.Context, repositoryID int) (bool, error)
 
 	// HasCommit determines if the given commit is known for the given repository.
 	HasCommit(ctx context.Context, repositoryID int, commit string) (bool, error)
 
+	// CommitGraphTip returns the tip commit of the default branch of the given repository at the time its commit graph
+	// was last calculated, and a flag indicating whether its commit graph was ever calculated.
+	CommitGraphTip(ctx context.Context, repositoryID int) (string, bool, error)
+
 	// MarkRepositoryAsDirty marks the given repository's commit graph as out of date.
 	MarkRepositoryAsDirty(ctx context.Context, repositoryID int) error
 
@@ -144,6 +170,15 @@ type Store interface {
 	// token has been read.
 	CalculateVisibleUploads(ctx context.Context, repositoryID int, graph map[string][]string, tipCommit string, dirtyToken int) error
 
+	// RepositoryIDsWithCompletedUploads returns the identifiers of all repositories with at least one completed upload.
+	RepositoryIDsWithCompletedUploads(ctx context.Context) ([]int, error)
+
+	// EvaluateRetentionPolicies determines which of the completed uploads of the given repository are retained by the
+	// given policies when evaluated against the given commit graph and set of branches and tags. The resulting map
+	// contains an entry for every completed upload of the repository whose value is the sorted set of names of the
+	// policies that retain it. Uploads mapped to an empty slice are not retained by any policy.
+	EvaluateRetentionPolicies(ctx context.Context, repositoryID int, graph map[string][]string, refs []GitRef, policies []RetentionPolicy, now time.Time) (map[int][]string, error)
+
 	// IndexableRepositories returns the identifiers of all indexable repositories.
 	IndexableRepositories(ctx context.Context, opts IndexableRepositoryQueryOptions) ([]IndexableRepository, error)
 
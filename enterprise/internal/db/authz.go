@@ -8,6 +8,7 @@ import (
 	"github.com/pkg/errors"
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/globals"
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/authz"
 	"github.com/sourcegraph/sourcegraph/internal/db"
 	"github.com/sourcegraph/sourcegraph/internal/db/dbutil"
@@ -122,13 +123,24 @@ func (s *authzStore) AuthorizedRepos(ctx context.Context, args *db.AuthorizedRep
 		Perm:   args.Perm,
 		Type:   args.Type,
 	}
-	if err := s.store.LoadUserPermissions(ctx, p); err != nil {
-		if err == authz.ErrPermsNotFound {
-			return []*types.Repo{}, nil
-		}
+	if err := s.store.LoadUserPermissions(ctx, p); err != nil && err != authz.ErrPermsNotFound {
 		return nil, err
 	}
 
+	// 🚨 SECURITY: Permissions granted to organizations are checked against the current members
+	// of the organizations, so that removed members lose access immediately.
+	if args.Type == authz.PermRepos {
+		orgRepoIDs, err := s.store.LoadUserOrgRepoIDs(ctx, args.UserID, args.Perm)
+		if err != nil {
+			return nil, errors.Wrap(err, "load repositories granted to organizations")
+		}
+		if p.IDs == nil {
+			p.IDs = orgRepoIDs
+		} else {
+			p.IDs.Or(orgRepoIDs)
+		}
+	}
+
 	perms := p.AuthorizedRepos(args.Repos)
 	filtered := make([]*types.Repo, len(perms))
 	for i, r := range perms {
@@ -158,3 +170,26 @@ func (s *authzStore) RevokeUserPermissions(ctx context.Context, args *db.RevokeU
 	}
 	return nil
 }
+
+// SubRepoPermissions returns the path rules that restrict what a user can read within a repository,
+// which implements the db.AuthzStore interface. It returns nil if the user has no path rules for the
+// repository.
+func (s *authzStore) SubRepoPermissions(ctx context.Context, args *db.SubRepoPermissionsArgs) (*authz.SubRepoPermissions, error) {
+	p := &authz.SubRepoPermissions{
+		UserID: args.UserID,
+		RepoID: int32(args.RepoID),
+	}
+	if err := s.store.LoadUserSubRepoPermissions(ctx, p); err != nil {
+		if err == authz.ErrPermsNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return p, nil
+}
+
+// RepoHasSubRepoPermissions returns true if any user has path rules for the repository, which
+// implements the db.AuthzStore interface.
+func (s *authzStore) RepoHasSubRepoPermissions(ctx context.Context, repoID api.RepoID) (bool, error) {
+	return s.store.RepoHasSubRepoPermissions(ctx, int32(repoID))
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/globals"
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/authz"
 	"github.com/sourcegraph/sourcegraph/internal/db"
 	"github.com/sourcegraph/sourcegraph/internal/db/dbconn"
@@ -383,3 +384,74 @@ func TestAuthzStore_RevokeUserPermissions(t *testing.T) {
 		}
 	}
 }
+
+func TestAuthzStore_AuthorizedRepos_OrgMembers(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	dbtesting.SetupGlobalTestDB(t)
+	ctx := context.Background()
+
+	s := NewAuthzStore(dbconn.Global, clock).(*authzStore)
+
+	user, err := db.Users.Create(ctx, db.NewUser{Username: "alice"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	org, err := db.Orgs.Create(ctx, "acme", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var repoID int32
+	err = dbconn.Global.QueryRowContext(ctx, `INSERT INTO repo(name, private) VALUES('private_repo', TRUE) RETURNING id`).Scan(&repoID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The organization is granted access before the user joins it
+	if err = s.store.SetRepoOrgPermissions(ctx, repoID, authz.Read, []int32{org.ID}); err != nil {
+		t.Fatal(err)
+	}
+
+	args := &db.AuthorizedReposArgs{
+		Repos:  []*types.Repo{{ID: api.RepoID(repoID)}},
+		UserID: user.ID,
+		Perm:   authz.Read,
+		Type:   authz.PermRepos,
+	}
+	authorized := func(t *testing.T) []*types.Repo {
+		repos, err := s.AuthorizedRepos(ctx, args)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return repos
+	}
+
+	if repos := authorized(t); len(repos) != 0 {
+		t.Fatalf("want no repos before joining the organization but got %v", repos)
+	}
+
+	if _, err = db.OrgMembers.Create(ctx, org.ID, user.ID); err != nil {
+		t.Fatal(err)
+	}
+	equal(t, "repos", []*types.Repo{{ID: api.RepoID(repoID)}}, authorized(t))
+
+	// A removed member must lose access immediately
+	if err = db.OrgMembers.Remove(ctx, org.ID, user.ID); err != nil {
+		t.Fatal(err)
+	}
+	if repos := authorized(t); len(repos) != 0 {
+		t.Fatalf("want no repos after leaving the organization but got %v", repos)
+	}
+
+	// Revoking the grant of the organization removes access of its members
+	if _, err = db.OrgMembers.Create(ctx, org.ID, user.ID); err != nil {
+		t.Fatal(err)
+	}
+	if err = s.store.SetRepoOrgPermissions(ctx, repoID, authz.Read, nil); err != nil {
+		t.Fatal(err)
+	}
+	if repos := authorized(t); len(repos) != 0 {
+		t.Fatalf("want no repos after revoking the organization but got %v", repos)
+	}
+}
@@ -45,6 +45,7 @@ func TestIntegration_PermsStore(t *testing.T) {
 		{"UserIDsWithOldestPerms", testPermsStore_UserIDsWithOldestPerms(db)},
 		{"ReposIDsWithOldestPerms", testPermsStore_ReposIDsWithOldestPerms(db)},
 		{"Metrics", testPermsStore_Metrics(db)},
+		{"SubRepoPermissions", testPermsStore_SubRepoPermissions(db)},
 	} {
 		t.Run(tc.name, tc.test)
 	}
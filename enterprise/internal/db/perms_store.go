@@ -145,6 +145,25 @@ func (s *PermsStore) SetUserPermissions(ctx context.Context, p *authz.UserPermis
 	ctx, save := s.observe(ctx, "SetUserPermissions", "")
 	defer func() { save(&err, p.TracingFields()...) }()
 
+	return s.setUserPermissions(ctx, p, nil)
+}
+
+// SetUserPermissionsKeepingExternalServices performs a full update for p like SetUserPermissions,
+// except that stored object IDs of repositories that belong to any of the given external services
+// are never removed. It is used by background permissions syncing to not overwrite permissions
+// that are managed via the explicit permissions API.
+func (s *PermsStore) SetUserPermissionsKeepingExternalServices(ctx context.Context, p *authz.UserPermissions, externalServiceIDs []int64) (err error) {
+	if Mocks.Perms.SetUserPermissionsKeepingExternalServices != nil {
+		return Mocks.Perms.SetUserPermissionsKeepingExternalServices(ctx, p, externalServiceIDs)
+	}
+
+	ctx, save := s.observe(ctx, "SetUserPermissionsKeepingExternalServices", "")
+	defer func() { save(&err, append(p.TracingFields(), otlog.Int("externalServiceIDs.Count", len(externalServiceIDs)))...) }()
+
+	return s.setUserPermissions(ctx, p, externalServiceIDs)
+}
+
+func (s *PermsStore) setUserPermissions(ctx context.Context, p *authz.UserPermissions, keepExternalServiceIDs []int64) (err error) {
 	// Open a transaction for update consistency.
 	txs, err := s.Transact(ctx)
 	if err != nil {
@@ -169,6 +188,14 @@ func (s *PermsStore) SetUserPermissions(ctx context.Context, p *authz.UserPermis
 		p.IDs = roaring.NewBitmap()
 	}
 
+	if len(keepExternalServiceIDs) > 0 && !oldIDs.IsEmpty() {
+		keptIDs, err := txs.externalServiceRepoIDs(ctx, keepExternalServiceIDs, oldIDs.ToArray())
+		if err != nil {
+			return errors.Wrap(err, "load repositories of external services")
+		}
+		p.IDs.AddMany(keptIDs)
+	}
+
 	// Compute differences between the old and new sets.
 	added := roaring.AndNot(p.IDs, oldIDs)
 	removed := roaring.AndNot(oldIDs, p.IDs)
@@ -1189,6 +1216,358 @@ AND bind_id IN (%s)`,
 	return nil
 }
 
+// externalServiceRepoIDs returns the IDs of the given repositories that belong to any of the given
+// external services.
+func (s *PermsStore) externalServiceRepoIDs(ctx context.Context, externalServiceIDs []int64, repoIDs []uint32) ([]uint32, error) {
+	q := sqlf.Sprintf(`
+-- source: enterprise/internal/db/perms_store.go:PermsStore.externalServiceRepoIDs
+SELECT DISTINCT repo_id FROM external_service_repos
+WHERE external_service_id = ANY (%s)
+AND repo_id = ANY (%s)
+`, pq.Array(externalServiceIDs), pq.Array(repoIDs))
+
+	rows, err := s.db.QueryContext(ctx, q.Query(sqlf.PostgresBindVar), q.Args()...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []uint32
+	for rows.Next() {
+		var id uint32
+		if err = rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+// TouchRepoPermissions marks the permissions of the repository as synced without changing them,
+// and creates empty permissions if the repository has none. It is used by background permissions
+// syncing for repositories whose permissions are managed via the explicit permissions API.
+func (s *PermsStore) TouchRepoPermissions(ctx context.Context, repoID int32) (err error) {
+	if Mocks.Perms.TouchRepoPermissions != nil {
+		return Mocks.Perms.TouchRepoPermissions(ctx, repoID)
+	}
+
+	ctx, save := s.observe(ctx, "TouchRepoPermissions", "")
+	defer func() { save(&err, otlog.Int32("repoID", repoID)) }()
+
+	emptyIDs, err := roaring.NewBitmap().ToBytes()
+	if err != nil {
+		return err
+	}
+
+	now := s.clock().UTC()
+	q := sqlf.Sprintf(`
+-- source: enterprise/internal/db/perms_store.go:PermsStore.TouchRepoPermissions
+INSERT INTO repo_permissions
+  (repo_id, permission, user_ids, user_ids_ints, updated_at, synced_at)
+VALUES
+  (%s, %s, %s, '{}', %s, %s)
+ON CONFLICT ON CONSTRAINT
+  repo_permissions_perm_unique
+DO UPDATE SET
+  synced_at = excluded.synced_at
+`, repoID, authz.Read.String(), emptyIDs, now, now)
+
+	return s.execute(ctx, q)
+}
+
+// SetRepoOrgPermissions performs a full update of the organizations whose members are granted
+// the permission to the repository. Organizations no longer in orgIDs will be removed.
+//
+// Unlike user permissions, the members of the organizations are not stored. They are looked up
+// when permissions are checked, so that changes of organization memberships take effect
+// immediately.
+func (s *PermsStore) SetRepoOrgPermissions(ctx context.Context, repoID int32, perm authz.Perms, orgIDs []int32) (err error) {
+	if Mocks.Perms.SetRepoOrgPermissions != nil {
+		return Mocks.Perms.SetRepoOrgPermissions(ctx, repoID, perm, orgIDs)
+	}
+
+	ctx, save := s.observe(ctx, "SetRepoOrgPermissions", "")
+	defer func() { save(&err, otlog.Int32("repoID", repoID), otlog.Int("orgIDs.Count", len(orgIDs))) }()
+
+	var txs *PermsStore
+	if s.inTx() {
+		txs = s
+	} else {
+		txs, err = s.Transact(ctx)
+		if err != nil {
+			return err
+		}
+		defer txs.Done(&err)
+	}
+
+	// A nil array is NULL in Postgres, which would match no rows to delete.
+	if orgIDs == nil {
+		orgIDs = []int32{}
+	}
+
+	q := sqlf.Sprintf(`
+-- source: enterprise/internal/db/perms_store.go:PermsStore.SetRepoOrgPermissions
+DELETE FROM org_repo_permissions
+WHERE repo_id = %s
+AND permission = %s
+AND NOT (org_id = ANY (%s))
+`, repoID, perm.String(), pq.Array(orgIDs))
+	if err = txs.execute(ctx, q); err != nil {
+		return errors.Wrap(err, "execute delete repo org permissions query")
+	}
+
+	if len(orgIDs) == 0 {
+		return nil
+	}
+
+	updatedAt := txs.clock().UTC()
+	items := make([]*sqlf.Query, len(orgIDs))
+	for i, orgID := range orgIDs {
+		items[i] = sqlf.Sprintf("(%s, %s, %s, %s)", orgID, repoID, perm.String(), updatedAt)
+	}
+	q = sqlf.Sprintf(`
+-- source: enterprise/internal/db/perms_store.go:PermsStore.SetRepoOrgPermissions
+INSERT INTO org_repo_permissions
+  (org_id, repo_id, permission, updated_at)
+VALUES
+  %s
+ON CONFLICT (org_id, repo_id, permission)
+DO UPDATE SET
+  updated_at = excluded.updated_at
+`, sqlf.Join(items, ","))
+	if err = txs.execute(ctx, q); err != nil {
+		return errors.Wrap(err, "execute upsert repo org permissions query")
+	}
+	return nil
+}
+
+// LoadUserOrgRepoIDs returns the IDs of the repositories the user is granted the permission to
+// as a current member of organizations.
+func (s *PermsStore) LoadUserOrgRepoIDs(ctx context.Context, userID int32, perm authz.Perms) (ids *roaring.Bitmap, err error) {
+	if Mocks.Perms.LoadUserOrgRepoIDs != nil {
+		return Mocks.Perms.LoadUserOrgRepoIDs(ctx, userID, perm)
+	}
+
+	ctx, save := s.observe(ctx, "LoadUserOrgRepoIDs", "")
+	defer func() { save(&err, otlog.Int32("userID", userID)) }()
+
+	q := sqlf.Sprintf(`
+-- source: enterprise/internal/db/perms_store.go:PermsStore.LoadUserOrgRepoIDs
+SELECT DISTINCT p.repo_id
+FROM org_repo_permissions p
+JOIN org_members m ON m.org_id = p.org_id
+JOIN orgs o ON o.id = p.org_id
+WHERE m.user_id = %s
+AND p.permission = %s
+AND o.deleted_at IS NULL
+`, userID, perm.String())
+
+	rows, err := s.db.QueryContext(ctx, q.Query(sqlf.PostgresBindVar), q.Args()...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	ids = roaring.NewBitmap()
+	for rows.Next() {
+		var id uint32
+		if err = rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids.Add(id)
+	}
+	return ids, rows.Err()
+}
+
+// LoadUserSubRepoPermissions loads the stored sub-repository permissions of the user for
+// the repository into p. An ErrPermsNotFound is returned when the user has no path rules for
+// the repository, which means all paths of the repository are readable.
+func (s *PermsStore) LoadUserSubRepoPermissions(ctx context.Context, p *authz.SubRepoPermissions) (err error) {
+	if Mocks.Perms.LoadUserSubRepoPermissions != nil {
+		return Mocks.Perms.LoadUserSubRepoPermissions(ctx, p)
+	}
+
+	ctx, save := s.observe(ctx, "LoadUserSubRepoPermissions", "")
+	defer func() { save(&err, p.TracingFields()...) }()
+
+	q := sqlf.Sprintf(`
+-- source: enterprise/internal/db/perms_store.go:PermsStore.LoadUserSubRepoPermissions
+SELECT path_includes, path_excludes, updated_at
+FROM user_sub_repo_permissions
+WHERE user_id = %s
+AND repo_id = %s
+`, p.UserID, p.RepoID)
+
+	var includes, excludes []string
+	if err = s.execute(ctx, q, pq.Array(&includes), pq.Array(&excludes), &p.UpdatedAt); err != nil {
+		return err
+	}
+	p.PathIncludes = includes
+	p.PathExcludes = excludes
+	return nil
+}
+
+// RepoHasSubRepoPermissions returns true if any user has path rules for the repository.
+func (s *PermsStore) RepoHasSubRepoPermissions(ctx context.Context, repoID int32) (exists bool, err error) {
+	if Mocks.Perms.RepoHasSubRepoPermissions != nil {
+		return Mocks.Perms.RepoHasSubRepoPermissions(ctx, repoID)
+	}
+
+	ctx, save := s.observe(ctx, "RepoHasSubRepoPermissions", "")
+	defer func() { save(&err, otlog.Int32("repoID", repoID), otlog.Bool("exists", exists)) }()
+
+	q := sqlf.Sprintf(`
+-- source: enterprise/internal/db/perms_store.go:PermsStore.RepoHasSubRepoPermissions
+SELECT EXISTS (
+	SELECT 1 FROM user_sub_repo_permissions
+	WHERE repo_id = %s
+)
+`, repoID)
+	err = s.execute(ctx, q, &exists)
+	return exists, err
+}
+
+// SetUserSubRepoPermissions performs a full update of the sub-repository permissions of the
+// user. Path rules of repositories found in ps will be upserted and path rules of repositories
+// no longer in ps will be removed. The UserID and UpdatedAt fields of ps are set by this method.
+//
+// This method starts its own transaction for update consistency if the caller hasn't started one already.
+func (s *PermsStore) SetUserSubRepoPermissions(ctx context.Context, userID int32, ps []*authz.SubRepoPermissions) (err error) {
+	if Mocks.Perms.SetUserSubRepoPermissions != nil {
+		return Mocks.Perms.SetUserSubRepoPermissions(ctx, userID, ps)
+	}
+
+	ctx, save := s.observe(ctx, "SetUserSubRepoPermissions", "")
+	defer func() { save(&err, otlog.Int32("userID", userID), otlog.Int("count", len(ps))) }()
+
+	var txs *PermsStore
+	if s.inTx() {
+		txs = s
+	} else {
+		txs, err = s.Transact(ctx)
+		if err != nil {
+			return err
+		}
+		defer txs.Done(&err)
+	}
+
+	updatedAt := txs.clock()
+	repoIDs := make([]int32, len(ps))
+	for i, p := range ps {
+		p.UserID = userID
+		p.UpdatedAt = updatedAt
+		repoIDs[i] = p.RepoID
+	}
+
+	q := sqlf.Sprintf(`
+-- source: enterprise/internal/db/perms_store.go:PermsStore.SetUserSubRepoPermissions
+DELETE FROM user_sub_repo_permissions
+WHERE user_id = %s
+AND NOT (repo_id = ANY (%s))
+`, userID, pq.Array(repoIDs))
+	if err = txs.execute(ctx, q); err != nil {
+		return errors.Wrap(err, "execute delete user sub-repo permissions query")
+	}
+
+	if len(ps) == 0 {
+		return nil
+	}
+
+	if err = txs.execute(ctx, upsertUserSubRepoPermissionsBatchQuery(ps...)); err != nil {
+		return errors.Wrap(err, "execute upsert user sub-repo permissions batch query")
+	}
+	return nil
+}
+
+// SetRepoSubRepoPermissions performs a full update of the sub-repository permissions of the
+// repository. Path rules of users found in ps will be upserted and path rules of users no
+// longer in ps will be removed. The RepoID and UpdatedAt fields of ps are set by this method.
+//
+// This method starts its own transaction for update consistency if the caller hasn't started one already.
+func (s *PermsStore) SetRepoSubRepoPermissions(ctx context.Context, repoID int32, ps []*authz.SubRepoPermissions) (err error) {
+	if Mocks.Perms.SetRepoSubRepoPermissions != nil {
+		return Mocks.Perms.SetRepoSubRepoPermissions(ctx, repoID, ps)
+	}
+
+	ctx, save := s.observe(ctx, "SetRepoSubRepoPermissions", "")
+	defer func() { save(&err, otlog.Int32("repoID", repoID), otlog.Int("count", len(ps))) }()
+
+	var txs *PermsStore
+	if s.inTx() {
+		txs = s
+	} else {
+		txs, err = s.Transact(ctx)
+		if err != nil {
+			return err
+		}
+		defer txs.Done(&err)
+	}
+
+	updatedAt := txs.clock()
+	userIDs := make([]int32, len(ps))
+	for i, p := range ps {
+		p.RepoID = repoID
+		p.UpdatedAt = updatedAt
+		userIDs[i] = p.UserID
+	}
+
+	q := sqlf.Sprintf(`
+-- source: enterprise/internal/db/perms_store.go:PermsStore.SetRepoSubRepoPermissions
+DELETE FROM user_sub_repo_permissions
+WHERE repo_id = %s
+AND NOT (user_id = ANY (%s))
+`, repoID, pq.Array(userIDs))
+	if err = txs.execute(ctx, q); err != nil {
+		return errors.Wrap(err, "execute delete repo sub-repo permissions query")
+	}
+
+	if len(ps) == 0 {
+		return nil
+	}
+
+	if err = txs.execute(ctx, upsertUserSubRepoPermissionsBatchQuery(ps...)); err != nil {
+		return errors.Wrap(err, "execute upsert user sub-repo permissions batch query")
+	}
+	return nil
+}
+
+func upsertUserSubRepoPermissionsBatchQuery(ps ...*authz.SubRepoPermissions) *sqlf.Query {
+	const format = `
+-- source: enterprise/internal/db/perms_store.go:upsertUserSubRepoPermissionsBatchQuery
+INSERT INTO user_sub_repo_permissions
+  (user_id, repo_id, path_includes, path_excludes, updated_at)
+VALUES
+  %s
+ON CONFLICT (user_id, repo_id)
+DO UPDATE SET
+  path_includes = excluded.path_includes,
+  path_excludes = excluded.path_excludes,
+  updated_at = excluded.updated_at
+`
+
+	items := make([]*sqlf.Query, len(ps))
+	for i := range ps {
+		items[i] = sqlf.Sprintf("(%s, %s, %s, %s, %s)",
+			ps[i].UserID,
+			ps[i].RepoID,
+			pq.Array(nonNilStrings(ps[i].PathIncludes)),
+			pq.Array(nonNilStrings(ps[i].PathExcludes)),
+			ps[i].UpdatedAt.UTC(),
+		)
+	}
+
+	return sqlf.Sprintf(format, sqlf.Join(items, ","))
+}
+
+// nonNilStrings returns an empty slice for nil, because a nil slice is stored
+// as NULL rather than an empty array.
+func nonNilStrings(ss []string) []string {
+	if ss == nil {
+		return []string{}
+	}
+	return ss
+}
+
 func (s *PermsStore) execute(ctx context.Context, q *sqlf.Query, vs ...interface{}) (err error) {
 	ctx, save := s.observe(ctx, "execute", "")
 	defer func() { save(&err, otlog.Object("q", q)) }()
@@ -3,19 +3,28 @@ package db
 import (
 	"context"
 
+	"github.com/RoaringBitmap/roaring"
 	"github.com/sourcegraph/sourcegraph/internal/authz"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
 )
 
 type MockPerms struct {
-	Transact                     func(ctx context.Context) (*PermsStore, error)
-	LoadRepoPermissions          func(ctx context.Context, p *authz.RepoPermissions) error
-	LoadUserPermissions          func(ctx context.Context, p *authz.UserPermissions) error
-	LoadUserPendingPermissions   func(ctx context.Context, p *authz.UserPendingPermissions) error
-	SetUserPermissions           func(ctx context.Context, p *authz.UserPermissions) error
-	SetRepoPermissions           func(ctx context.Context, p *authz.RepoPermissions) error
-	SetRepoPendingPermissions    func(ctx context.Context, accounts *extsvc.Accounts, p *authz.RepoPermissions) error
-	ListPendingUsers             func(ctx context.Context) ([]string, error)
-	ListExternalAccounts         func(ctx context.Context, userID int32) ([]*extsvc.Account, error)
-	GetUserIDsByExternalAccounts func(ctx context.Context, accounts *extsvc.Accounts) (map[string]int32, error)
+	Transact                                  func(ctx context.Context) (*PermsStore, error)
+	LoadRepoPermissions                       func(ctx context.Context, p *authz.RepoPermissions) error
+	LoadUserPermissions                       func(ctx context.Context, p *authz.UserPermissions) error
+	LoadUserPendingPermissions                func(ctx context.Context, p *authz.UserPendingPermissions) error
+	SetUserPermissions                        func(ctx context.Context, p *authz.UserPermissions) error
+	SetUserPermissionsKeepingExternalServices func(ctx context.Context, p *authz.UserPermissions, externalServiceIDs []int64) error
+	SetRepoPermissions                        func(ctx context.Context, p *authz.RepoPermissions) error
+	SetRepoPendingPermissions                 func(ctx context.Context, accounts *extsvc.Accounts, p *authz.RepoPermissions) error
+	TouchRepoPermissions                      func(ctx context.Context, repoID int32) error
+	SetRepoOrgPermissions                     func(ctx context.Context, repoID int32, perm authz.Perms, orgIDs []int32) error
+	LoadUserOrgRepoIDs                        func(ctx context.Context, userID int32, perm authz.Perms) (*roaring.Bitmap, error)
+	LoadUserSubRepoPermissions                func(ctx context.Context, p *authz.SubRepoPermissions) error
+	RepoHasSubRepoPermissions                 func(ctx context.Context, repoID int32) (bool, error)
+	SetUserSubRepoPermissions                 func(ctx context.Context, userID int32, ps []*authz.SubRepoPermissions) error
+	SetRepoSubRepoPermissions                 func(ctx context.Context, repoID int32, ps []*authz.SubRepoPermissions) error
+	ListPendingUsers                          func(ctx context.Context) ([]string, error)
+	ListExternalAccounts                      func(ctx context.Context, userID int32) ([]*extsvc.Account, error)
+	GetUserIDsByExternalAccounts              func(ctx context.Context, accounts *extsvc.Accounts) (map[string]int32, error)
 }
@@ -27,7 +27,7 @@ func cleanupPermsTables(t *testing.T, s *PermsStore) {
 		return
 	}
 
-	q := `TRUNCATE TABLE user_permissions, repo_permissions, user_pending_permissions, repo_pending_permissions;`
+	q := `TRUNCATE TABLE user_permissions, repo_permissions, user_pending_permissions, repo_pending_permissions, org_repo_permissions;`
 	if err := s.execute(context.Background(), sqlf.Sprintf(q)); err != nil {
 		t.Fatal(err)
 	}
@@ -2671,3 +2671,107 @@ func testPermsStore_Metrics(db *sql.DB) func(*testing.T) {
 		}
 	}
 }
+
+func testPermsStore_SubRepoPermissions(db *sql.DB) func(*testing.T) {
+	return func(t *testing.T) {
+		s := NewPermsStore(db, time.Now)
+		t.Cleanup(func() {
+			cleanupUsersTable(t, s)
+			cleanupReposTable(t, s)
+		})
+
+		ctx := context.Background()
+
+		qs := []*sqlf.Query{
+			sqlf.Sprintf(`INSERT INTO users(username) VALUES('alice')`),                    // ID=1
+			sqlf.Sprintf(`INSERT INTO users(username) VALUES('bob')`),                      // ID=2
+			sqlf.Sprintf(`INSERT INTO repo(name, private) VALUES('private_repo', TRUE)`),   // ID=1
+			sqlf.Sprintf(`INSERT INTO repo(name, private) VALUES('private_repo_2', TRUE)`), // ID=2
+		}
+		for _, q := range qs {
+			if err := s.execute(ctx, q); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		load := func(t *testing.T, userID, repoID int32) *authz.SubRepoPermissions {
+			p := &authz.SubRepoPermissions{UserID: userID, RepoID: repoID}
+			err := s.LoadUserSubRepoPermissions(ctx, p)
+			if err == authz.ErrPermsNotFound {
+				return nil
+			} else if err != nil {
+				t.Fatal(err)
+			}
+			p.UpdatedAt = time.Time{}
+			return p
+		}
+
+		// Set path rules of alice for both repositories
+		err := s.SetUserSubRepoPermissions(ctx, 1, []*authz.SubRepoPermissions{
+			{RepoID: 1, PathIncludes: []string{"src/**"}},
+			{RepoID: 2, PathExcludes: []string{"secrets/**"}},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		want := &authz.SubRepoPermissions{UserID: 1, RepoID: 2, PathIncludes: []string{}, PathExcludes: []string{"secrets/**"}}
+		if diff := cmp.Diff(want, load(t, 1, 2)); diff != "" {
+			t.Fatalf("mismatch (-want +got):\n%s", diff)
+		}
+
+		// A full update of alice should remove path rules no longer present
+		err = s.SetUserSubRepoPermissions(ctx, 1, []*authz.SubRepoPermissions{
+			{RepoID: 1, PathIncludes: []string{"docs/**"}},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		want = &authz.SubRepoPermissions{UserID: 1, RepoID: 1, PathIncludes: []string{"docs/**"}, PathExcludes: []string{}}
+		if diff := cmp.Diff(want, load(t, 1, 1)); diff != "" {
+			t.Fatalf("mismatch (-want +got):\n%s", diff)
+		}
+		if p := load(t, 1, 2); p != nil {
+			t.Fatalf("want no path rules but got %+v", p)
+		}
+
+		// A full update of the repository should replace path rules of alice with those of bob
+		err = s.SetRepoSubRepoPermissions(ctx, 1, []*authz.SubRepoPermissions{
+			{UserID: 2, PathExcludes: []string{"internal/**"}},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if p := load(t, 1, 1); p != nil {
+			t.Fatalf("want no path rules but got %+v", p)
+		}
+		want = &authz.SubRepoPermissions{UserID: 2, RepoID: 1, PathIncludes: []string{}, PathExcludes: []string{"internal/**"}}
+		if diff := cmp.Diff(want, load(t, 2, 1)); diff != "" {
+			t.Fatalf("mismatch (-want +got):\n%s", diff)
+		}
+
+		hasRules := func(t *testing.T, repoID int32) bool {
+			exists, err := s.RepoHasSubRepoPermissions(ctx, repoID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			return exists
+		}
+		if !hasRules(t, 1) {
+			t.Fatal("want path rules for repository 1")
+		}
+		if hasRules(t, 2) {
+			t.Fatal("want no path rules for repository 2")
+		}
+
+		// Removing all path rules of the repository makes it unrestricted again
+		if err = s.SetRepoSubRepoPermissions(ctx, 1, nil); err != nil {
+			t.Fatal(err)
+		}
+		if hasRules(t, 1) {
+			t.Fatal("want no path rules for repository 1")
+		}
+	}
+}
@@ -45,7 +45,9 @@ require (
 	github.com/gitchander/permutation v0.0.0-20181107151852-9e56b92e9909
 	github.com/gliderlabs/ssh v0.3.0 // indirect
 	github.com/glycerine/go-unsnap-stream v0.0.0-20190901134440-81cf024a9e0a // indirect
+	github.com/go-asn1-ber/asn1-ber v1.5.1
 	github.com/go-git/go-git/v5 v5.1.0 // indirect
+	github.com/go-ldap/ldap/v3 v3.2.3
 	github.com/go-openapi/strfmt v0.19.5
 	github.com/go-playground/validator/v10 v10.3.0 // indirect
 	github.com/go-redsync/redsync v1.4.2
@@ -92,7 +94,7 @@ require (
 	github.com/keegancsmith/sqlf v1.1.0
 	github.com/keegancsmith/tmpfriend v0.0.0-20180423180255-86e88902a513
 	github.com/kevinburke/go-bindata v3.21.0+incompatible
-	github.com/klauspost/compress v1.10.10 // indirect
+	github.com/klauspost/compress v1.10.10
 	github.com/kr/text v0.2.0
 	github.com/kylelemons/godebug v1.1.0
 	github.com/leanovate/gopter v0.2.8
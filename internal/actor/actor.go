@@ -24,6 +24,11 @@ type Actor struct {
 	// to selectively display a logout link. (If the actor wasn't authenticated with a session
 	// cookie, logout would be ineffective.)
 	FromSessionCookie bool `json:"-"`
+
+	// AccessTokenScope is the access token scope that authorized the request of the actor (see
+	// authz.ScopesGranting), or empty if the actor wasn't authenticated with an access token. It
+	// is used to restrict which GraphQL fields the actor can resolve.
+	AccessTokenScope string `json:"-"`
 }
 
 // FromUser returns an actor corresponding to a user
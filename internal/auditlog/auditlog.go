@@ -0,0 +1,223 @@
+// Package auditlog records security-relevant actions (such as site configuration changes,
+// permission changes and access token usage) in an append-only audit log.
+//
+// Unlike event logs, which are product telemetry and are deleted after a fixed period, audit log
+// entries are never modified or deleted by Sourcegraph. They are stored in the database and,
+// optionally, exported to the sinks configured in the site configuration ("log.auditLog").
+package auditlog
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/inconshreveable/log15"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/db"
+	"github.com/sourcegraph/sourcegraph/internal/httputil"
+	"github.com/sourcegraph/sourcegraph/internal/jsonc"
+)
+
+// Actions recorded in the audit log.
+const (
+	ActionSiteConfigUpdated               = "SiteConfigUpdated"
+	ActionExternalServiceCreated          = "ExternalServiceCreated"
+	ActionExternalServiceUpdated          = "ExternalServiceUpdated"
+	ActionExternalServiceDeleted          = "ExternalServiceDeleted"
+	ActionRepositoryPermissionsUpdated    = "RepositoryPermissionsUpdated"
+	ActionSubRepositoryPermissionsUpdated = "SubRepositoryPermissionsUpdated"
+	ActionUserSiteAdminUpdated            = "UserSiteAdminUpdated"
+	ActionUserDeleted                     = "UserDeleted"
+	ActionAccessTokenCreated              = "AccessTokenCreated"
+	ActionAccessTokenDeleted              = "AccessTokenDeleted"
+	ActionAccessTokenSudo                 = "AccessTokenSudo"
+	ActionRepositoryAccessed              = "RepositoryAccessedBySiteAdmin"
+)
+
+// Subject types of audit log entries.
+const (
+	SubjectSiteConfiguration = "SiteConfiguration"
+	SubjectExternalService   = "ExternalService"
+	SubjectRepository        = "Repository"
+	SubjectUser              = "User"
+	SubjectAccessToken       = "AccessToken"
+)
+
+// Event describes an action to record in the audit log.
+type Event struct {
+	Action      string
+	SubjectType string
+	SubjectID   string
+
+	// Before and After describe the state of the subject before and after the action. They are
+	// marshaled to JSON, and the values of secret-looking properties (such as tokens and
+	// passwords) are redacted. Either may be nil.
+	Before interface{}
+	After  interface{}
+}
+
+// JSONC returns the parsed value of a JSONC document (such as the site configuration or an
+// external service configuration), for use in Event.Before and Event.After. If the document can't
+// be parsed, it returns nil so that no unredacted secrets are recorded.
+func JSONC(text string) interface{} {
+	var v interface{}
+	if err := jsonc.Unmarshal(text, &v); err != nil {
+		return nil
+	}
+	return v
+}
+
+var errorCounter = promauto.NewCounter(prometheus.CounterOpts{
+	Name: "src_audit_log_errors_total",
+	Help: "Number of audit log entries that could not be recorded or exported.",
+})
+
+// Log records the event in the audit log, attributing it to the actor and client IP address of the
+// context. Failures are logged but not returned, because they must not prevent the action itself.
+func Log(ctx context.Context, e Event) {
+	entry := &db.AuditLogEntry{
+		Action:      e.Action,
+		ActorUserID: actor.FromContext(ctx).UID,
+		ActorIP:     RemoteAddrFromContext(ctx),
+		SubjectType: e.SubjectType,
+		SubjectID:   e.SubjectID,
+		Before:      marshalRedacted(e.Before),
+		After:       marshalRedacted(e.After),
+		CreatedAt:   time.Now().UTC(),
+	}
+	logEntry(entry)
+}
+
+func logEntry(entry *db.AuditLogEntry) {
+	// Use a context that isn't canceled when the client goes away, so that the action is always
+	// recorded.
+	if err := db.AuditLog.Insert(context.Background(), entry); err != nil {
+		errorCounter.Inc()
+		log15.Error("auditlog: failed to record entry", "action", entry.Action, "error", err)
+	}
+	export(entry)
+}
+
+// marshalRedacted returns the JSON encoding of v with the values of secret-looking properties
+// redacted, or nil if v is nil.
+func marshalRedacted(v interface{}) json.RawMessage {
+	if v == nil {
+		return nil
+	}
+	// Round-trip through JSON so that structs are redacted, too.
+	b, err := json.Marshal(v)
+	if err != nil {
+		errorCounter.Inc()
+		log15.Error("auditlog: failed to marshal value", "error", err)
+		return nil
+	}
+	var generic interface{}
+	if err := json.Unmarshal(b, &generic); err != nil {
+		return nil
+	}
+	b, err = json.Marshal(redact(generic))
+	if err != nil {
+		return nil
+	}
+	return b
+}
+
+// redactedValue replaces the values of secret-looking properties.
+const redactedValue = "REDACTED"
+
+// secretPropertySubstrings are the (lowercase) substrings of property names whose values are
+// redacted.
+var secretPropertySubstrings = []string{"password", "passphrase", "secret", "token", "privatekey", "credential", "apikey", "dsn", "routingkey", "integrationkey", "licensekey"}
+
+// secretPropertiesByType are the (lowercase) names of properties whose values are redacted in
+// objects with the given "type", such as the "url" of Slack and webhook notifiers, which
+// authorizes posting to the webhook.
+var secretPropertiesByType = map[string][]string{
+	"slack":   {"url"},
+	"webhook": {"url"},
+}
+
+func redact(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		typ, _ := v["type"].(string)
+		for k, value := range v {
+			if value != nil && (isSecretProperty(k) || isSecretPropertyOfType(typ, k)) {
+				v[k] = redactedValue
+			} else {
+				v[k] = redact(value)
+			}
+		}
+	case []interface{}:
+		for i, value := range v {
+			v[i] = redact(value)
+		}
+	case string:
+		return stripURLUserinfo(v)
+	}
+	return v
+}
+
+// stripURLUserinfo removes the userinfo (such as "user:password@") from s if it is a URL, because
+// URLs of code hosts and other services may embed credentials.
+func stripURLUserinfo(s string) string {
+	if !strings.Contains(s, "://") {
+		return s
+	}
+	u, err := url.Parse(s)
+	if err != nil || u.User == nil {
+		return s
+	}
+	u.User = nil
+	return u.String()
+}
+
+func isSecretProperty(name string) bool {
+	name = strings.ToLower(name)
+	for _, s := range secretPropertySubstrings {
+		if strings.Contains(name, s) {
+			return true
+		}
+	}
+	return false
+}
+
+func isSecretPropertyOfType(typ, name string) bool {
+	name = strings.ToLower(name)
+	for _, p := range secretPropertiesByType[strings.ToLower(typ)] {
+		if name == p {
+			return true
+		}
+	}
+	return false
+}
+
+type contextKey int
+
+const remoteAddrKey contextKey = iota
+
+// WithRemoteAddr returns a copy of the context with the given client IP address, which is
+// recorded as the actor's IP address of audit log entries.
+func WithRemoteAddr(ctx context.Context, remoteAddr string) context.Context {
+	return context.WithValue(ctx, remoteAddrKey, remoteAddr)
+}
+
+// RemoteAddrFromContext returns the client IP address stored in the context by WithRemoteAddr or
+// Middleware, or "" if there is none.
+func RemoteAddrFromContext(ctx context.Context) string {
+	remoteAddr, _ := ctx.Value(remoteAddrKey).(string)
+	return remoteAddr
+}
+
+// Middleware stores the client IP address of the request in the request context, so that audit
+// log entries for actions performed by the request are attributed to it.
+func Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		next.ServeHTTP(w, r.WithContext(WithRemoteAddr(r.Context(), httputil.RemoteAddr(r))))
+	})
+}
@@ -0,0 +1,72 @@
+package auditlog
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/db"
+)
+
+// repoAccessInterval is the interval in which repeated accesses of a repository by the same site
+// admin are only recorded once. Viewing a single page of a repository results in many accesses.
+const repoAccessInterval = time.Hour
+
+type repoAccessKey struct {
+	userID int32
+	repoID api.RepoID
+}
+
+var repoAccesses = struct {
+	mu   sync.Mutex
+	seen map[repoAccessKey]time.Time
+}{seen: map[repoAccessKey]time.Time{}}
+
+// LogRepositoryAccess records the access of the repository if the actor is a site admin, because
+// site admins bypass repository permissions. Accesses by other users are not recorded.
+func LogRepositoryAccess(ctx context.Context, repo *types.Repo) {
+	a := actor.FromContext(ctx)
+	if !a.IsAuthenticated() || a.Internal || repo == nil {
+		return
+	}
+	if !firstRepoAccessInInterval(repoAccessKey{userID: a.UID, repoID: repo.ID}, time.Now()) {
+		return
+	}
+
+	user, err := db.Users.GetByID(ctx, a.UID)
+	if err != nil || !user.SiteAdmin {
+		return
+	}
+	Log(ctx, Event{
+		Action:      ActionRepositoryAccessed,
+		SubjectType: SubjectRepository,
+		SubjectID:   strconv.Itoa(int(repo.ID)),
+		After:       map[string]string{"name": string(repo.Name)},
+	})
+}
+
+// firstRepoAccessInInterval reports whether the access is the first one by the user of the
+// repository within repoAccessInterval.
+func firstRepoAccessInInterval(key repoAccessKey, now time.Time) bool {
+	repoAccesses.mu.Lock()
+	defer repoAccesses.mu.Unlock()
+
+	if last, ok := repoAccesses.seen[key]; ok && now.Sub(last) < repoAccessInterval {
+		return false
+	}
+
+	// Prune old accesses so that the map doesn't grow without bounds.
+	if len(repoAccesses.seen) >= 10000 {
+		for k, last := range repoAccesses.seen {
+			if now.Sub(last) >= repoAccessInterval {
+				delete(repoAccesses.seen, k)
+			}
+		}
+	}
+	repoAccesses.seen[key] = now
+	return true
+}
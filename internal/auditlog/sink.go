@@ -0,0 +1,200 @@
+package auditlog
+
+import (
+	"encoding/json"
+	"log/syslog"
+	"os"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/inconshreveable/log15"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/db"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// exportedEntry is the JSON representation of an audit log entry written to the sinks.
+type exportedEntry struct {
+	Timestamp   time.Time       `json:"timestamp"`
+	Action      string          `json:"action"`
+	ActorUserID int32           `json:"actorUserID,omitempty"`
+	ActorIP     string          `json:"actorIP,omitempty"`
+	SubjectType string          `json:"subjectType,omitempty"`
+	SubjectID   string          `json:"subjectID,omitempty"`
+	Before      json.RawMessage `json:"before,omitempty"`
+	After       json.RawMessage `json:"after,omitempty"`
+}
+
+const (
+	// exportQueueSize is the number of entries that can wait to be exported. Entries are dropped
+	// (and only recorded in the database) when the sinks can't keep up.
+	exportQueueSize = 1000
+
+	// reopenInterval is the minimum time between attempts to open a sink that failed to open.
+	reopenInterval = 10 * time.Second
+)
+
+// exportRequest is an entry to export, or a request to signal done once all entries queued
+// before it have been exported.
+type exportRequest struct {
+	entry *db.AuditLogEntry
+	done  chan struct{}
+}
+
+var (
+	exportQueue     = make(chan exportRequest, exportQueueSize)
+	startExportOnce sync.Once
+)
+
+// export queues the entry to be written to the sinks configured in the site configuration by a
+// background goroutine, so that slow or unavailable sinks don't block the request that performed
+// the action.
+func export(entry *db.AuditLogEntry) {
+	startExportOnce.Do(func() { go runExport() })
+
+	select {
+	case exportQueue <- exportRequest{entry: entry}:
+	default:
+		errorCounter.Inc()
+		log15.Error("auditlog: export queue is full, dropping entry", "action", entry.Action)
+	}
+}
+
+// flushExports waits until all entries queued so far have been exported.
+func flushExports() {
+	startExportOnce.Do(func() { go runExport() })
+
+	done := make(chan struct{})
+	exportQueue <- exportRequest{done: done}
+	<-done
+}
+
+func runExport() {
+	var s sinks
+	for req := range exportQueue {
+		if req.done != nil {
+			close(req.done)
+			continue
+		}
+
+		var config *schema.AuditLog
+		if c := conf.Get().Log; c != nil {
+			config = c.AuditLog
+		}
+		s.write(config, req.entry, time.Now())
+	}
+}
+
+// sinks are the writers of the configured sinks. They are only used by the goroutine running
+// runExport.
+type sinks struct {
+	config *schema.AuditLog // the configuration that the writers were opened with
+	file   *os.File
+	syslog *syslog.Writer
+
+	// The time of the last failed attempt to open the file or syslog writer.
+	fileFailedAt, syslogFailedAt time.Time
+}
+
+// write writes the entry to the sinks of the configuration, opening (or reopening) the writers
+// as needed.
+func (s *sinks) write(config *schema.AuditLog, entry *db.AuditLogEntry, now time.Time) {
+	if !reflect.DeepEqual(config, s.config) {
+		s.close()
+		s.config = config
+	}
+	if config == nil {
+		return
+	}
+	s.open(now)
+	if s.file == nil && s.syslog == nil {
+		return
+	}
+
+	b, err := json.Marshal(exportedEntry{
+		Timestamp:   entry.CreatedAt,
+		Action:      entry.Action,
+		ActorUserID: entry.ActorUserID,
+		ActorIP:     entry.ActorIP,
+		SubjectType: entry.SubjectType,
+		SubjectID:   entry.SubjectID,
+		Before:      entry.Before,
+		After:       entry.After,
+	})
+	if err != nil {
+		errorCounter.Inc()
+		log15.Error("auditlog: failed to marshal entry", "action", entry.Action, "error", err)
+		return
+	}
+
+	// Writers that fail are closed, so that they are reopened for the next entry.
+	if s.file != nil {
+		if _, err := s.file.Write(append(b, '\n')); err != nil {
+			errorCounter.Inc()
+			log15.Error("auditlog: failed to write entry to file", "path", s.config.JsonLinesFile, "error", err)
+			s.file.Close()
+			s.file = nil
+		}
+	}
+	if s.syslog != nil {
+		if err := s.syslog.Notice(string(b)); err != nil {
+			errorCounter.Inc()
+			log15.Error("auditlog: failed to send entry to syslog", "error", err)
+			s.syslog.Close()
+			s.syslog = nil
+		}
+	}
+}
+
+// open opens the writers of the configured sinks that aren't open yet. Writers that failed to
+// open are retried at most once per reopenInterval.
+func (s *sinks) open(now time.Time) {
+	if s.config.JsonLinesFile != "" && s.file == nil && now.Sub(s.fileFailedAt) >= reopenInterval {
+		f, err := os.OpenFile(s.config.JsonLinesFile, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0600)
+		if err != nil {
+			s.fileFailedAt = now
+			errorCounter.Inc()
+			log15.Error("auditlog: failed to open JSON Lines file", "path", s.config.JsonLinesFile, "error", err)
+		} else {
+			s.file = f
+		}
+	}
+
+	if c := s.config.Syslog; c != nil && s.syslog == nil && now.Sub(s.syslogFailedAt) >= reopenInterval {
+		tag := c.Tag
+		if tag == "" {
+			tag = "sourcegraph-audit"
+		}
+		var (
+			w   *syslog.Writer
+			err error
+		)
+		if c.Network == "" {
+			w, err = syslog.New(syslog.LOG_NOTICE|syslog.LOG_AUTH, tag)
+		} else {
+			w, err = syslog.Dial(c.Network, c.Address, syslog.LOG_NOTICE|syslog.LOG_AUTH, tag)
+		}
+		if err != nil {
+			s.syslogFailedAt = now
+			errorCounter.Inc()
+			log15.Error("auditlog: failed to connect to syslog", "network", c.Network, "address", c.Address, "error", err)
+		} else {
+			s.syslog = w
+		}
+	}
+}
+
+// close closes the writers of the sinks.
+func (s *sinks) close() {
+	if s.file != nil {
+		s.file.Close()
+		s.file = nil
+	}
+	if s.syslog != nil {
+		s.syslog.Close()
+		s.syslog = nil
+	}
+	s.fileFailedAt = time.Time{}
+	s.syslogFailedAt = time.Time{}
+}
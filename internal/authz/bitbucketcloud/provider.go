@@ -0,0 +1,245 @@
+// Package bitbucketcloud contains an authorization provider for Bitbucket Cloud.
+package bitbucketcloud
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	otlog "github.com/opentracing/opentracing-go/log"
+	"github.com/pkg/errors"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketcloud"
+	"github.com/sourcegraph/sourcegraph/internal/trace"
+)
+
+// Provider is an implementation of AuthzProvider that provides repository permissions as
+// determined from the workspace permissions API of Bitbucket Cloud.
+type Provider struct {
+	urn      string
+	client   *bitbucketcloud.Client
+	codeHost *extsvc.CodeHost
+	pageSize int // Page size to use in paginated requests.
+
+	// workspaces are the slugs of the workspaces whose repository permissions are read.
+	workspaces []string
+}
+
+var _ authz.Provider = (*Provider)(nil)
+
+// NewProvider returns a new Bitbucket Cloud authorization provider that uses the given
+// bitbucketcloud.Client to read the repository permissions of the given workspaces. The
+// client must be authenticated as an administrator of these workspaces. It assumes usernames
+// of Sourcegraph accounts match 1-1 with nicknames of Bitbucket Cloud users.
+func NewProvider(cli *bitbucketcloud.Client, urn string, baseURL *url.URL, workspaces []string) *Provider {
+	return &Provider{
+		urn:        urn,
+		client:     cli,
+		codeHost:   extsvc.NewCodeHost(baseURL, extsvc.TypeBitbucketCloud),
+		pageSize:   100,
+		workspaces: workspaces,
+	}
+}
+
+// Validate validates that the Provider has access to the Bitbucket Cloud API with the
+// app password it was configured with.
+func (p *Provider) Validate() []string {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var problems []string
+	if _, err := p.client.CurrentUser(ctx); err != nil {
+		problems = append(problems, err.Error())
+	}
+	if problem := authz.UsernameMatchingProblem(conf.Get()); problem != "" {
+		problems = append(problems, problem)
+	}
+	return problems
+}
+
+func (p *Provider) URN() string {
+	return p.urn
+}
+
+// ServiceID returns the absolute URL that identifies the Bitbucket Cloud instance
+// this provider is configured with.
+func (p *Provider) ServiceID() string { return p.codeHost.ServiceID }
+
+// ServiceType returns the type of this Provider, namely, "bitbucketCloud".
+func (p *Provider) ServiceType() string { return p.codeHost.ServiceType }
+
+// FetchAccount satisfies the authz.Provider interface. It returns the account of the member
+// of the configured workspaces whose nickname is the username of the given user.
+func (p *Provider) FetchAccount(ctx context.Context, user *types.User, _ []*extsvc.Account) (acct *extsvc.Account, err error) {
+	if user == nil {
+		return nil, nil
+	}
+
+	tr, ctx := trace.New(ctx, "bitbucketcloud.authz.provider.FetchAccount", "")
+	defer func() {
+		tr.LogFields(
+			otlog.String("user.name", user.Username),
+			otlog.Int32("user.id", user.ID),
+		)
+
+		if err != nil {
+			tr.SetError(err)
+		}
+
+		tr.Finish()
+	}()
+
+	// 🚨 SECURITY: Only match by username when users can't claim the usernames of others.
+	if problem := authz.UsernameMatchingProblem(conf.Get()); problem != "" {
+		return nil, errors.New(problem)
+	}
+
+	bitbucketUser, err := p.member(ctx, user.Username)
+	if err != nil || bitbucketUser == nil {
+		return nil, err
+	}
+
+	accountData, err := json.Marshal(bitbucketUser)
+	if err != nil {
+		return nil, err
+	}
+
+	return &extsvc.Account{
+		UserID: user.ID,
+		AccountSpec: extsvc.AccountSpec{
+			ServiceType: p.codeHost.ServiceType,
+			ServiceID:   p.codeHost.ServiceID,
+			AccountID:   bitbucketUser.UUID,
+		},
+		AccountData: extsvc.AccountData{
+			Data: (*json.RawMessage)(&accountData),
+		},
+	}, nil
+}
+
+// FetchUserPerms returns a list of repository IDs (on code host) that the given account
+// has read access on the code host. The repository ID has the same value as it would be
+// used as api.ExternalRepoSpec.ID. The returned list only includes private repository IDs.
+//
+// This method may return partial but valid results in case of error, and it is up to
+// callers to decide whether to discard.
+//
+// API docs: https://developer.atlassian.com/bitbucket/api/2/reference/resource/workspaces/%7Bworkspace%7D/permissions/repositories
+func (p *Provider) FetchUserPerms(ctx context.Context, account *extsvc.Account) ([]extsvc.RepoID, error) {
+	switch {
+	case account == nil:
+		return nil, errors.New("no account provided")
+	case !extsvc.IsHostOfAccount(p.codeHost, account):
+		return nil, fmt.Errorf("not a code host of the account: want %q but have %q",
+			p.codeHost.ServiceID, account.AccountSpec.ServiceID)
+	}
+
+	var repoIDs []extsvc.RepoID
+	for _, workspace := range p.workspaces {
+		t := &bitbucketcloud.PageToken{Pagelen: p.pageSize}
+		for {
+			perms, next, err := p.client.UserRepoPermissions(ctx, t, workspace, account.AccountID)
+			if err != nil {
+				return repoIDs, errors.Wrapf(err, "list repository permissions of workspace %q", workspace)
+			}
+
+			for _, perm := range perms {
+				if perm.Repo != nil && perm.Repo.IsPrivate {
+					repoIDs = append(repoIDs, extsvc.RepoID(perm.Repo.UUID))
+				}
+			}
+
+			if !next.HasMore() {
+				break
+			}
+			t = next
+		}
+	}
+
+	return repoIDs, nil
+}
+
+// FetchRepoPerms returns a list of user IDs (on code host) who have read access to
+// the given repo on the code host. The user ID has the same value as it would
+// be used as extsvc.Account.AccountID. The returned list includes both direct access
+// and inherited from the group membership.
+//
+// This method may return partial but valid results in case of error, and it is up to
+// callers to decide whether to discard.
+//
+// API docs: https://developer.atlassian.com/bitbucket/api/2/reference/resource/workspaces/%7Bworkspace%7D/permissions/repositories/%7Brepo_slug%7D
+func (p *Provider) FetchRepoPerms(ctx context.Context, repo *extsvc.Repository) ([]extsvc.AccountID, error) {
+	switch {
+	case repo == nil:
+		return nil, errors.New("no repo provided")
+	case !extsvc.IsHostOfRepo(p.codeHost, &repo.ExternalRepoSpec):
+		return nil, fmt.Errorf("not a code host of the repo: want %q but have %q",
+			p.codeHost.ServiceID, repo.ServiceID)
+	}
+
+	// NOTE: We do not store port or scheme in our URI, so stripping the hostname alone is enough.
+	fullName := strings.TrimPrefix(repo.URI, p.codeHost.BaseURL.Hostname())
+	fullName = strings.TrimPrefix(fullName, "/")
+
+	i := strings.Index(fullName, "/")
+	if i <= 0 || i == len(fullName)-1 {
+		return nil, fmt.Errorf("invalid Bitbucket Cloud repository full name %q", fullName)
+	}
+	workspace, slug := fullName[:i], fullName[i+1:]
+
+	var userIDs []extsvc.AccountID
+	t := &bitbucketcloud.PageToken{Pagelen: p.pageSize}
+	for {
+		perms, next, err := p.client.RepoPermissions(ctx, t, workspace, slug)
+		if err != nil {
+			return userIDs, err
+		}
+
+		for _, perm := range perms {
+			if perm.User != nil {
+				userIDs = append(userIDs, extsvc.AccountID(perm.User.UUID))
+			}
+		}
+
+		if !next.HasMore() {
+			break
+		}
+		t = next
+	}
+
+	return userIDs, nil
+}
+
+// member returns the member of the configured workspaces with the given nickname, or nil
+// if there is none.
+func (p *Provider) member(ctx context.Context, nickname string) (*bitbucketcloud.User, error) {
+	for _, workspace := range p.workspaces {
+		t := &bitbucketcloud.PageToken{Pagelen: p.pageSize}
+		for {
+			users, next, err := p.client.WorkspaceMembers(ctx, t, workspace)
+			if err != nil {
+				return nil, errors.Wrapf(err, "list members of workspace %q", workspace)
+			}
+
+			for _, u := range users {
+				if u.Nickname == nickname {
+					return u, nil
+				}
+			}
+
+			if !next.HasMore() {
+				break
+			}
+			t = next
+		}
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,155 @@
+package bitbucketcloud
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketcloud"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// newTestProvider returns a Provider for the workspaces "alice" and "acme" that talks to a fake
+// Bitbucket Cloud API serving the given responses, keyed by request path.
+func newTestProvider(t *testing.T, responses map[string]interface{}) *Provider {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if user, pass, _ := r.BasicAuth(); user != "alice" || pass != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if r.URL.Path == "/2.0/workspaces/acme/permissions/repositories" {
+			if got, want := r.URL.Query().Get("q"), `user.uuid="{bob}"`; got != want {
+				t.Errorf("got query %q, want %q", got, want)
+			}
+		}
+
+		resp, ok := responses[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	t.Cleanup(srv.Close)
+
+	apiURL, _ := url.Parse(srv.URL)
+	cli := bitbucketcloud.NewClient(apiURL, nil)
+	cli.Username = "alice"
+	cli.AppPassword = "secret"
+
+	baseURL, _ := url.Parse("https://bitbucket.org")
+	return NewProvider(cli, "extsvc:bitbucketcloud:1", baseURL, []string{"alice", "acme"})
+}
+
+func page(values ...interface{}) map[string]interface{} {
+	return map[string]interface{}{"values": values}
+}
+
+var bob = map[string]interface{}{"uuid": "{bob}", "nickname": "bob", "display_name": "Bob"}
+
+func TestProvider_FetchAccount(t *testing.T) {
+	p := newTestProvider(t, map[string]interface{}{
+		"/2.0/workspaces/alice/members": page(),
+		"/2.0/workspaces/acme/members":  page(map[string]interface{}{"user": bob}),
+	})
+
+	acct, err := p.FetchAccount(context.Background(), &types.User{ID: 1, Username: "bob"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := extsvc.AccountSpec{ServiceType: "bitbucketCloud", ServiceID: "https://bitbucket.org/", AccountID: "{bob}"}
+	if acct == nil || acct.UserID != 1 || acct.AccountSpec != want {
+		t.Fatalf("got account %+v, want %+v", acct, want)
+	}
+
+	acct, err = p.FetchAccount(context.Background(), &types.User{ID: 2, Username: "carol"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if acct != nil {
+		t.Fatalf("got account %+v, want none", acct)
+	}
+
+	t.Run("users can choose their usernames", func(t *testing.T) {
+		conf.Mock(&conf.Unified{SiteConfiguration: schema.SiteConfiguration{
+			AuthProviders: []schema.AuthProviders{{Builtin: &schema.BuiltinAuthProvider{Type: "builtin", AllowSignup: true}}},
+		}})
+		defer conf.Mock(nil)
+
+		acct, err := p.FetchAccount(context.Background(), &types.User{ID: 1, Username: "bob"}, nil)
+		if err == nil || acct != nil {
+			t.Fatalf("got account %+v and error %v, want an error", acct, err)
+		}
+	})
+}
+
+func TestProvider_FetchUserPerms(t *testing.T) {
+	p := newTestProvider(t, map[string]interface{}{
+		"/2.0/workspaces/alice/permissions/repositories": page(),
+		"/2.0/workspaces/acme/permissions/repositories": page(
+			map[string]interface{}{"permission": "read", "user": bob, "repository": map[string]interface{}{"uuid": "{private}", "full_name": "acme/private", "is_private": true}},
+			map[string]interface{}{"permission": "write", "user": bob, "repository": map[string]interface{}{"uuid": "{public}", "full_name": "acme/public", "is_private": false}},
+		),
+	})
+
+	t.Run("not the code host of the account", func(t *testing.T) {
+		_, err := p.FetchUserPerms(context.Background(), &extsvc.Account{
+			AccountSpec: extsvc.AccountSpec{ServiceType: "github", ServiceID: "https://github.com/", AccountID: "1"},
+		})
+		if err == nil {
+			t.Fatal("expected an error")
+		}
+	})
+
+	repoIDs, err := p.FetchUserPerms(context.Background(), &extsvc.Account{
+		AccountSpec: extsvc.AccountSpec{ServiceType: "bitbucketCloud", ServiceID: "https://bitbucket.org/", AccountID: "{bob}"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff([]extsvc.RepoID{"{private}"}, repoIDs); diff != "" {
+		t.Fatalf("repoIDs mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestProvider_FetchRepoPerms(t *testing.T) {
+	p := newTestProvider(t, map[string]interface{}{
+		"/2.0/workspaces/acme/permissions/repositories/private": page(
+			map[string]interface{}{"permission": "admin", "user": map[string]interface{}{"uuid": "{alice}"}},
+			map[string]interface{}{"permission": "read", "user": bob},
+		),
+	})
+
+	repo := func(uri string) *extsvc.Repository {
+		return &extsvc.Repository{
+			URI: uri,
+			ExternalRepoSpec: api.ExternalRepoSpec{
+				ID:          "{private}",
+				ServiceType: "bitbucketCloud",
+				ServiceID:   "https://bitbucket.org/",
+			},
+		}
+	}
+
+	userIDs, err := p.FetchRepoPerms(context.Background(), repo("bitbucket.org/acme/private"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff([]extsvc.AccountID{"{alice}", "{bob}"}, userIDs); diff != "" {
+		t.Fatalf("userIDs mismatch (-want +got):\n%s", diff)
+	}
+
+	_, err = p.FetchRepoPerms(context.Background(), repo("bitbucket.org/acme/deleted"))
+	if !bitbucketcloud.IsNotFound(err) {
+		t.Fatalf("got error %v, want a not found error", err)
+	}
+}
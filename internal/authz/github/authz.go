@@ -1,11 +1,13 @@
 package github
 
 import (
+	"encoding/base64"
 	"fmt"
 	"net/url"
 
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
 	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
 	"github.com/sourcegraph/sourcegraph/schema"
 )
 
@@ -45,7 +47,21 @@ func newAuthzProvider(urn string, a *schema.GitHubAuthorization, instanceURL, to
 		return nil, fmt.Errorf("Could not parse URL for GitHub instance %q: %s", instanceURL, err)
 	}
 
-	return NewProvider(urn, ghURL, token, nil), nil
+	if a.GithubApp == nil {
+		return NewProvider(urn, ghURL, token, nil), nil
+	}
+
+	privateKey, err := base64.StdEncoding.DecodeString(a.GithubApp.PrivateKey)
+	if err != nil {
+		return nil, fmt.Errorf("Could not decode the private key of the GitHub App for GitHub instance %q: %s", instanceURL, err)
+	}
+	apiURL, _ := github.APIRoot(ghURL)
+	client := github.NewClient(apiURL, "", nil)
+	tokens, err := github.NewInstallationTokenSource(client, int64(a.GithubApp.AppID), int64(a.GithubApp.InstallationID), privateKey)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid private key of the GitHub App for GitHub instance %q: %s", instanceURL, err)
+	}
+	return NewAppProvider(urn, ghURL, tokens, client), nil
 }
 
 // ValidateGitHubAuthz validates the authorization fields of the given GitHub external
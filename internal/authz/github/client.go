@@ -3,6 +3,8 @@ package github
 import (
 	"context"
 
+	gogithub "github.com/google/go-github/github"
+
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
 )
 
@@ -12,7 +14,10 @@ import (
 type client interface {
 	GetRepositoryByNodeID(ctx context.Context, id string) (*github.Repository, error)
 	GetRepositoriesByNodeIDFromAPI(ctx context.Context, nodeIDs []string) (map[string]*github.Repository, error)
+	GetUser(ctx context.Context, login string) (*gogithub.User, error)
+	IsRepositoryCollaborator(ctx context.Context, owner, repo, login string) (bool, error)
 	ListAffiliatedRepositories(ctx context.Context, visibility github.Visibility, page int) (repos []*github.Repository, hasNextPage bool, rateLimitCost int, err error)
+	ListInstallationRepositories(ctx context.Context, page int) (repos []*github.Repository, hasNextPage bool, _ error)
 	ListRepositoryCollaborators(ctx context.Context, owner, repo string, page int) (users []*github.Collaborator, hasNextPage bool, _ error)
 	WithToken(token string) client
 }
@@ -33,7 +38,10 @@ var _ client = (*mockClient)(nil)
 type mockClient struct {
 	MockGetRepositoryByNodeID          func(ctx context.Context, id string) (*github.Repository, error)
 	MockGetRepositoriesByNodeIDFromAPI func(ctx context.Context, nodeIDs []string) (map[string]*github.Repository, error)
+	MockGetUser                        func(ctx context.Context, login string) (*gogithub.User, error)
+	MockIsRepositoryCollaborator       func(ctx context.Context, owner, repo, login string) (bool, error)
 	MockListAffiliatedRepositories     func(ctx context.Context, visibility github.Visibility, page int) (repos []*github.Repository, hasNextPage bool, rateLimitCost int, err error)
+	MockListInstallationRepositories   func(ctx context.Context, page int) (repos []*github.Repository, hasNextPage bool, _ error)
 	MockListRepositoryCollaborators    func(ctx context.Context, owner, repo string, page int) (users []*github.Collaborator, hasNextPage bool, _ error)
 	MockWithToken                      func(token string) client
 }
@@ -46,10 +54,22 @@ func (m *mockClient) GetRepositoriesByNodeIDFromAPI(ctx context.Context, nodeIDs
 	return m.MockGetRepositoriesByNodeIDFromAPI(ctx, nodeIDs)
 }
 
+func (m *mockClient) GetUser(ctx context.Context, login string) (*gogithub.User, error) {
+	return m.MockGetUser(ctx, login)
+}
+
+func (m *mockClient) IsRepositoryCollaborator(ctx context.Context, owner, repo, login string) (bool, error) {
+	return m.MockIsRepositoryCollaborator(ctx, owner, repo, login)
+}
+
 func (m *mockClient) ListAffiliatedRepositories(ctx context.Context, visibility github.Visibility, page int) ([]*github.Repository, bool, int, error) {
 	return m.MockListAffiliatedRepositories(ctx, visibility, page)
 }
 
+func (m *mockClient) ListInstallationRepositories(ctx context.Context, page int) ([]*github.Repository, bool, error) {
+	return m.MockListInstallationRepositories(ctx, page)
+}
+
 func (m *mockClient) ListRepositoryCollaborators(ctx context.Context, owner, repo string, page int) ([]*github.Collaborator, bool, error) {
 	return m.MockListRepositoryCollaborators(ctx, owner, repo, page)
 }
@@ -6,11 +6,13 @@ import (
 	"net/url"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/pkg/errors"
 
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
 	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
 )
@@ -20,6 +22,15 @@ type Provider struct {
 	urn      string
 	client   client
 	codeHost *extsvc.CodeHost
+
+	// installationTokens is set when the provider authenticates as the installation of a
+	// GitHub App, instead of with OAuth tokens of users.
+	installationTokens tokenSource
+}
+
+// tokenSource returns access tokens, such as the access tokens of a GitHub App installation.
+type tokenSource interface {
+	Token(ctx context.Context) (string, error)
 }
 
 func NewProvider(urn string, githubURL *url.URL, baseToken string, client *github.Client) *Provider {
@@ -35,12 +46,64 @@ func NewProvider(urn string, githubURL *url.URL, baseToken string, client *githu
 	}
 }
 
+// NewAppProvider returns a Provider that authenticates as the installation of a GitHub App
+// with the access tokens of the given source. It computes repository permissions without
+// OAuth tokens of users, and assumes usernames of Sourcegraph accounts match 1-1 with logins
+// of GitHub users.
+func NewAppProvider(urn string, githubURL *url.URL, tokens *github.InstallationTokenSource, client *github.Client) *Provider {
+	p := NewProvider(urn, githubURL, "", client)
+	p.installationTokens = tokens
+	return p
+}
+
 var _ authz.Provider = (*Provider)(nil)
 
-// FetchAccount implements the authz.Provider interface. It always returns nil, because the GitHub
-// API doesn't currently provide a way to fetch user by external SSO account.
+// FetchAccount implements the authz.Provider interface. Unless the provider authenticates as a
+// GitHub App, it always returns nil, because the GitHub API doesn't currently provide a way to
+// fetch user by external SSO account. Otherwise, it returns the account of the GitHub user whose
+// login is the username of the given user.
 func (p *Provider) FetchAccount(ctx context.Context, user *types.User, current []*extsvc.Account) (mine *extsvc.Account, err error) {
-	return nil, nil
+	if p.installationTokens == nil || user == nil {
+		return nil, nil
+	}
+
+	// 🚨 SECURITY: Only match by username when users can't claim the usernames of others.
+	if problem := authz.UsernameMatchingProblem(conf.Get()); problem != "" {
+		return nil, errors.New(problem)
+	}
+
+	client, err := p.installationClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ghUser, err := client.GetUser(ctx, user.Username)
+	if github.IsNotFound(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Wrap(err, "get user")
+	}
+
+	var data extsvc.AccountData
+	data.SetAccountData(ghUser)
+	return &extsvc.Account{
+		UserID: user.ID,
+		AccountSpec: extsvc.AccountSpec{
+			ServiceType: p.codeHost.ServiceType,
+			ServiceID:   p.codeHost.ServiceID,
+			AccountID:   strconv.FormatInt(ghUser.GetID(), 10),
+		},
+		AccountData: data,
+	}, nil
+}
+
+// installationClient returns a client authenticated as the installation of the GitHub App.
+func (p *Provider) installationClient(ctx context.Context) (client, error) {
+	tok, err := p.installationTokens.Token(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "get installation access token")
+	}
+	return p.client.WithToken(tok), nil
 }
 
 func (p *Provider) URN() string {
@@ -55,8 +118,23 @@ func (p *Provider) ServiceType() string {
 	return p.codeHost.ServiceType
 }
 
+// Validate validates that the Provider is able to create access tokens of the GitHub App
+// installation, if it authenticates as one.
 func (p *Provider) Validate() (problems []string) {
-	return nil
+	if p.installationTokens == nil {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if _, err := p.installationTokens.Token(ctx); err != nil {
+		problems = append(problems, err.Error())
+	}
+	if problem := authz.UsernameMatchingProblem(conf.Get()); problem != "" {
+		problems = append(problems, problem)
+	}
+	return problems
 }
 
 // FetchUserPerms returns a list of repository IDs (on code host) that the given account
@@ -66,6 +144,9 @@ func (p *Provider) Validate() (problems []string) {
 // This method may return partial but valid results in case of error, and it is up to
 // callers to decide whether to discard.
 //
+// When the account has no OAuth token and the provider authenticates as a GitHub App, the
+// private repositories of the installation are checked one by one.
+//
 // API docs: https://developer.github.com/v3/repos/#list-repositories-for-the-authenticated-user
 func (p *Provider) FetchUserPerms(ctx context.Context, account *extsvc.Account) ([]extsvc.RepoID, error) {
 	if account == nil {
@@ -75,10 +156,13 @@ func (p *Provider) FetchUserPerms(ctx context.Context, account *extsvc.Account)
 			account.AccountSpec.ServiceID, p.codeHost.ServiceID)
 	}
 
-	_, tok, err := github.GetExternalAccountData(&account.AccountData)
+	usr, tok, err := github.GetExternalAccountData(&account.AccountData)
 	if err != nil {
 		return nil, errors.Wrap(err, "get external account data")
-	} else if tok == nil {
+	} else if tok == nil || tok.AccessToken == "" {
+		if p.installationTokens != nil && usr != nil && usr.GetLogin() != "" {
+			return p.fetchInstallationUserPerms(ctx, usr.GetLogin())
+		}
 		return nil, errors.New("no token found in the external account data")
 	}
 
@@ -104,6 +188,49 @@ func (p *Provider) FetchUserPerms(ctx context.Context, account *extsvc.Account)
 	return repoIDs, nil
 }
 
+// fetchInstallationUserPerms returns the IDs of the private repositories of the GitHub App
+// installation that the GitHub user with the given login has access to. It costs one API
+// request per private repository.
+//
+// API docs: https://developer.github.com/v3/repos/collaborators/#check-if-a-user-is-a-repository-collaborator
+func (p *Provider) fetchInstallationUserPerms(ctx context.Context, login string) ([]extsvc.RepoID, error) {
+	var repoIDs []extsvc.RepoID
+	hasNextPage := true
+	for page := 1; hasNextPage; page++ {
+		// Get a client for every page, so that long running syncs do not use expired tokens.
+		client, err := p.installationClient(ctx)
+		if err != nil {
+			return repoIDs, err
+		}
+
+		var repos []*github.Repository
+		repos, hasNextPage, err = client.ListInstallationRepositories(ctx, page)
+		if err != nil {
+			return repoIDs, err
+		}
+
+		for _, r := range repos {
+			if !r.IsPrivate {
+				continue
+			}
+
+			owner, name, err := github.SplitRepositoryNameWithOwner(r.NameWithOwner)
+			if err != nil {
+				return repoIDs, errors.Wrap(err, "split nameWithOwner")
+			}
+
+			ok, err := client.IsRepositoryCollaborator(ctx, owner, name, login)
+			if err != nil {
+				return repoIDs, err
+			} else if ok {
+				repoIDs = append(repoIDs, extsvc.RepoID(r.ID))
+			}
+		}
+	}
+
+	return repoIDs, nil
+}
+
 // FetchRepoPerms returns a list of user IDs (on code host) who have read access to
 // the given project on the code host. The user ID has the same value as it would
 // be used as extsvc.Account.AccountID. The returned list includes both direct access
@@ -130,6 +257,13 @@ func (p *Provider) FetchRepoPerms(ctx context.Context, repo *extsvc.Repository)
 		return nil, errors.Wrap(err, "split nameWithOwner")
 	}
 
+	client := p.client
+	if p.installationTokens != nil {
+		if client, err = p.installationClient(ctx); err != nil {
+			return nil, err
+		}
+	}
+
 	// 100 matches the maximum page size, thus a good default to avoid multiple allocations
 	// when appending the first 100 results to the slice.
 	userIDs := make([]extsvc.AccountID, 0, 100)
@@ -137,7 +271,7 @@ func (p *Provider) FetchRepoPerms(ctx context.Context, repo *extsvc.Repository)
 	for page := 1; hasNextPage; page++ {
 		var err error
 		var users []*github.Collaborator
-		users, hasNextPage, err = p.client.ListRepositoryCollaborators(ctx, owner, name, page)
+		users, hasNextPage, err = client.ListRepositoryCollaborators(ctx, owner, name, page)
 		if err != nil {
 			return userIDs, err
 		}
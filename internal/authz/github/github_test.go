@@ -4,14 +4,19 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"net/http"
 	"net/url"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	gogithub "github.com/google/go-github/github"
 
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
 	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
+	"github.com/sourcegraph/sourcegraph/schema"
 )
 
 func mustURL(t *testing.T, u string) *url.URL {
@@ -195,3 +200,95 @@ func TestProvider_FetchRepoPerms(t *testing.T) {
 		t.Fatalf("AccountIDs mismatch (-want +got):\n%s", diff)
 	}
 }
+
+type staticTokenSource string
+
+func (s staticTokenSource) Token(context.Context) (string, error) { return string(s), nil }
+
+func TestProvider_GitHubApp(t *testing.T) {
+	var tokens []string
+	appClient := &mockClient{
+		MockGetUser: func(ctx context.Context, login string) (*gogithub.User, error) {
+			if login != "alice" {
+				return nil, &github.APIError{Code: http.StatusNotFound}
+			}
+			return &gogithub.User{ID: gogithub.Int64(42), Login: gogithub.String("alice")}, nil
+		},
+		MockListInstallationRepositories: func(ctx context.Context, page int) ([]*github.Repository, bool, error) {
+			switch page {
+			case 1:
+				return []*github.Repository{
+					{ID: "private_1", NameWithOwner: "acme/private-1", IsPrivate: true},
+					{ID: "public", NameWithOwner: "acme/public"},
+				}, true, nil
+			case 2:
+				return []*github.Repository{
+					{ID: "private_2", NameWithOwner: "acme/private-2", IsPrivate: true},
+				}, false, nil
+			}
+			return nil, false, nil
+		},
+		MockIsRepositoryCollaborator: func(ctx context.Context, owner, repo, login string) (bool, error) {
+			if owner != "acme" || login != "alice" {
+				t.Errorf("unexpected collaborator check %s/%s for %s", owner, repo, login)
+			}
+			return repo == "private-2", nil
+		},
+		MockListRepositoryCollaborators: func(ctx context.Context, owner, repo string, page int) ([]*github.Collaborator, bool, error) {
+			return []*github.Collaborator{{DatabaseID: 42}}, false, nil
+		},
+	}
+
+	p := NewProvider("", mustURL(t, "https://github.com"), "", nil)
+	p.installationTokens = staticTokenSource("installation_token")
+	p.client = &mockClient{
+		MockWithToken: func(token string) client {
+			tokens = append(tokens, token)
+			return appClient
+		},
+	}
+
+	acct, err := p.FetchAccount(context.Background(), &types.User{ID: 1, Username: "alice"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantSpec := extsvc.AccountSpec{ServiceType: "github", ServiceID: "https://github.com/", AccountID: "42"}
+	if acct == nil || acct.AccountSpec != wantSpec {
+		t.Fatalf("got account %+v, want %+v", acct, wantSpec)
+	}
+
+	if acct, err := p.FetchAccount(context.Background(), &types.User{ID: 2, Username: "bob"}, nil); err != nil || acct != nil {
+		t.Fatalf("got account %+v and error %v, want neither", acct, err)
+	}
+
+	conf.Mock(&conf.Unified{SiteConfiguration: schema.SiteConfiguration{AuthEnableUsernameChanges: true}})
+	if acct, err := p.FetchAccount(context.Background(), &types.User{ID: 1, Username: "alice"}, nil); err == nil || acct != nil {
+		t.Fatalf("got account %+v and error %v, want an error when users can change their usernames", acct, err)
+	}
+	conf.Mock(nil)
+
+	repoIDs, err := p.FetchUserPerms(context.Background(), acct)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff([]extsvc.RepoID{"private_2"}, repoIDs); diff != "" {
+		t.Fatalf("RepoIDs mismatch (-want +got):\n%s", diff)
+	}
+
+	accountIDs, err := p.FetchRepoPerms(context.Background(), &extsvc.Repository{
+		URI:              "github.com/acme/private-2",
+		ExternalRepoSpec: api.ExternalRepoSpec{ID: "private_2", ServiceType: "github", ServiceID: "https://github.com/"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff([]extsvc.AccountID{"42"}, accountIDs); diff != "" {
+		t.Fatalf("AccountIDs mismatch (-want +got):\n%s", diff)
+	}
+
+	for _, tok := range tokens {
+		if tok != "installation_token" {
+			t.Errorf("got client with token %q, want the installation token", tok)
+		}
+	}
+}
@@ -68,3 +68,20 @@ type Provider interface {
 	// problems.
 	Validate() (problems []string)
 }
+
+// SubRepoPermsProvider is an optional capability of a Provider that is able to
+// restrict which paths within a repository a user may read. The perms syncer
+// checks whether a Provider implements it with a type assertion.
+type SubRepoPermsProvider interface {
+	// FetchUserSubRepoPerms returns the path rules that apply to the given account,
+	// keyed by repository/project IDs (on code host). The repository ID should be the
+	// same value as it would be used as api.ExternalRepoSpec.ID. Repositories without
+	// an entry are readable in full, as long as the account has access to them at all.
+	FetchUserSubRepoPerms(ctx context.Context, account *extsvc.Account) (map[extsvc.RepoID]*SubRepoPermissions, error)
+
+	// FetchRepoSubRepoPerms returns the path rules that apply to the users of the given
+	// repository/project on the code host, keyed by user IDs (on code host). The user ID
+	// should be the same value as it would be used as extsvc.Account.AccountID. Users
+	// without an entry can read all paths of the repository.
+	FetchRepoSubRepoPerms(ctx context.Context, repo *extsvc.Repository) (map[extsvc.AccountID]*SubRepoPermissions, error)
+}
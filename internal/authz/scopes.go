@@ -2,12 +2,32 @@ package authz
 
 const (
 	// Access token scopes.
-	ScopeUserAll       = "user:all"        // Full control of all resources accessible to the user account.
-	ScopeSiteAdminSudo = "site-admin:sudo" // Ability to perform any action as any other user.
+	ScopeUserAll         = "user:all"         // Full control of all resources accessible to the user account.
+	ScopeSiteAdminSudo   = "site-admin:sudo"  // Ability to perform any action as any other user.
+	ScopeSearchRead      = "search:read"      // Read-only access to searches and repositories, but no other GraphQL queries and no mutations.
+	ScopeCodeIntelUpload = "codeintel:upload" // Ability to upload LSIF code intelligence data.
+	ScopeCampaignsWrite  = "campaigns:write"  // Read-only access to searches, repositories and campaigns plus the ability to create and modify campaigns.
 )
 
 // AllScopes is a list of all known access token scopes.
 var AllScopes = []string{
 	ScopeUserAll,
 	ScopeSiteAdminSudo,
+	ScopeSearchRead,
+	ScopeCodeIntelUpload,
+	ScopeCampaignsWrite,
+}
+
+// ScopesGranting returns the list of scopes that each grant the required scope. A scope always
+// grants itself; "user:all" additionally grants every scope except "site-admin:sudo", and
+// "campaigns:write" grants "search:read".
+func ScopesGranting(requiredScope string) []string {
+	switch requiredScope {
+	case ScopeSiteAdminSudo, ScopeUserAll:
+		return []string{requiredScope}
+	case ScopeSearchRead:
+		return []string{requiredScope, ScopeCampaignsWrite, ScopeUserAll}
+	default:
+		return []string{requiredScope, ScopeUserAll}
+	}
 }
@@ -0,0 +1,183 @@
+package authz
+
+import (
+	"strings"
+	"time"
+
+	"github.com/gobwas/glob"
+	otlog "github.com/opentracing/opentracing-go/log"
+	"github.com/pkg/errors"
+)
+
+// SubRepoPermissions declares which paths within a repository a user is allowed
+// to read. A user without SubRepoPermissions for a repository can read all of its
+// paths, as long as they have access to the repository at all.
+//
+// Paths are relative to the repository root and matched as globs, where "*" matches
+// within a single path segment and "**" matches across segments. A rule that matches
+// a directory also applies to everything beneath it.
+type SubRepoPermissions struct {
+	UserID int32 // The internal database ID of a user
+	RepoID int32 // The internal database ID of a repository
+
+	// PathIncludes are the paths the user may read. An empty list means all paths.
+	PathIncludes []string
+	// PathExcludes are the paths the user may not read, even if they are included.
+	PathExcludes []string
+
+	UpdatedAt time.Time // The last updated time
+}
+
+// TracingFields returns tracing fields for the opentracing log.
+func (p *SubRepoPermissions) TracingFields() []otlog.Field {
+	return []otlog.Field{
+		otlog.Int32("SubRepoPermissions.UserID", p.UserID),
+		otlog.Int32("SubRepoPermissions.RepoID", p.RepoID),
+		otlog.Int("SubRepoPermissions.PathIncludes.Count", len(p.PathIncludes)),
+		otlog.Int("SubRepoPermissions.PathExcludes.Count", len(p.PathExcludes)),
+		otlog.String("SubRepoPermissions.UpdatedAt", p.UpdatedAt.String()),
+	}
+}
+
+// SubRepoPermsMatcher checks paths against compiled SubRepoPermissions. A nil
+// *SubRepoPermsMatcher allows reading every path.
+type SubRepoPermsMatcher struct {
+	includes []glob.Glob
+	excludes []glob.Glob
+
+	// includePrefixes are the literal leading parts of the include patterns, used
+	// to keep the directories leading to included paths visible.
+	includePrefixes []string
+}
+
+// NewSubRepoPermsMatcher compiles the path rules of p into a SubRepoPermsMatcher.
+func NewSubRepoPermsMatcher(p *SubRepoPermissions) (*SubRepoPermsMatcher, error) {
+	m := &SubRepoPermsMatcher{}
+	for _, pattern := range p.PathIncludes {
+		g, err := compileSubRepoPattern(pattern)
+		if err != nil {
+			return nil, err
+		}
+		m.includes = append(m.includes, g)
+		m.includePrefixes = append(m.includePrefixes, literalPrefix(cleanSubRepoPath(pattern)))
+	}
+	for _, pattern := range p.PathExcludes {
+		g, err := compileSubRepoPattern(pattern)
+		if err != nil {
+			return nil, err
+		}
+		m.excludes = append(m.excludes, g)
+	}
+	return m, nil
+}
+
+// NewDenyAllSubRepoPermsMatcher returns a SubRepoPermsMatcher that allows reading no
+// path but the repository root.
+func NewDenyAllSubRepoPermsMatcher() *SubRepoPermsMatcher {
+	return &SubRepoPermsMatcher{excludes: []glob.Glob{glob.MustCompile("**", '/')}}
+}
+
+// ValidateSubRepoPatterns returns an error for the first pattern that is not a valid glob.
+func ValidateSubRepoPatterns(patterns []string) error {
+	for _, pattern := range patterns {
+		if _, err := compileSubRepoPattern(pattern); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func compileSubRepoPattern(pattern string) (glob.Glob, error) {
+	g, err := glob.Compile(cleanSubRepoPath(pattern), '/')
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid path pattern %q", pattern)
+	}
+	return g, nil
+}
+
+// CanReadFile reports whether the file at the given path may be read.
+func (m *SubRepoPermsMatcher) CanReadFile(path string) bool {
+	if m == nil {
+		return true
+	}
+
+	path = cleanSubRepoPath(path)
+	if path == "" {
+		return true
+	}
+	if matchPathOrParent(m.excludes, path, false) {
+		return false
+	}
+	return len(m.includes) == 0 || matchPathOrParent(m.includes, path, false)
+}
+
+// CanReadDir reports whether the directory at the given path may be listed. A
+// directory is listable when it is included itself or contains included paths.
+func (m *SubRepoPermsMatcher) CanReadDir(path string) bool {
+	if m == nil {
+		return true
+	}
+
+	path = cleanSubRepoPath(path)
+	if path == "" {
+		return true
+	}
+	if matchPathOrParent(m.excludes, path, true) {
+		return false
+	}
+	if len(m.includes) == 0 || matchPathOrParent(m.includes, path, true) {
+		return true
+	}
+
+	dir := path + "/"
+	for _, prefix := range m.includePrefixes {
+		if strings.HasPrefix(prefix, dir) || strings.HasPrefix(dir, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// CanRead calls CanReadDir or CanReadFile depending on isDir.
+func (m *SubRepoPermsMatcher) CanRead(path string, isDir bool) bool {
+	if isDir {
+		return m.CanReadDir(path)
+	}
+	return m.CanReadFile(path)
+}
+
+// matchPathOrParent reports whether any of the globs matches path or one of its
+// parent directories. Directories are tried with and without a trailing slash so
+// that both "secrets" and "secrets/**" match the "secrets" directory.
+func matchPathOrParent(globs []glob.Glob, path string, isDir bool) bool {
+	if len(globs) == 0 {
+		return false
+	}
+
+	for p := path; p != ""; {
+		for _, g := range globs {
+			if g.Match(p) || ((isDir || p != path) && g.Match(p+"/")) {
+				return true
+			}
+		}
+		i := strings.LastIndexByte(p, '/')
+		if i < 0 {
+			break
+		}
+		p = p[:i]
+	}
+	return false
+}
+
+func cleanSubRepoPath(path string) string {
+	return strings.Trim(path, "/")
+}
+
+// literalPrefix returns the part of the glob pattern before its first special
+// character.
+func literalPrefix(pattern string) string {
+	if i := strings.IndexAny(pattern, `*?[{\`); i >= 0 {
+		return pattern[:i]
+	}
+	return pattern
+}
@@ -0,0 +1,136 @@
+package authz
+
+import (
+	"testing"
+)
+
+func TestNewDenyAllSubRepoPermsMatcher(t *testing.T) {
+	m := NewDenyAllSubRepoPermsMatcher()
+	for _, tc := range []struct {
+		path  string
+		isDir bool
+	}{
+		{path: "README.md"},
+		{path: "secrets/token"},
+		{path: "secrets", isDir: true},
+		{path: "a/b/c", isDir: true},
+	} {
+		if m.CanRead(tc.path, tc.isDir) {
+			t.Errorf("CanRead(%q, %t): want false but got true", tc.path, tc.isDir)
+		}
+	}
+	if !m.CanReadDir("") {
+		t.Error("the repository root should be listable")
+	}
+}
+
+func TestSubRepoPermsMatcher(t *testing.T) {
+	for _, tc := range []struct {
+		name  string
+		perms *SubRepoPermissions
+		path  string
+		isDir bool
+		want  bool
+	}{
+		{
+			name:  "no rules",
+			perms: &SubRepoPermissions{},
+			path:  "secrets/token",
+			want:  true,
+		},
+		{
+			name:  "excluded file",
+			perms: &SubRepoPermissions{PathExcludes: []string{"secrets/**"}},
+			path:  "secrets/token",
+			want:  false,
+		},
+		{
+			name:  "excluded directory by glob",
+			perms: &SubRepoPermissions{PathExcludes: []string{"secrets/**"}},
+			path:  "secrets",
+			isDir: true,
+			want:  false,
+		},
+		{
+			name:  "excluded directory by name",
+			perms: &SubRepoPermissions{PathExcludes: []string{"/secrets/"}},
+			path:  "secrets/nested/token",
+			want:  false,
+		},
+		{
+			name:  "sibling of excluded directory",
+			perms: &SubRepoPermissions{PathExcludes: []string{"secrets/**"}},
+			path:  "secretsauce.go",
+			want:  true,
+		},
+		{
+			name:  "single star stays within a segment",
+			perms: &SubRepoPermissions{PathExcludes: []string{"legal/*.pdf"}},
+			path:  "legal/contracts/nda.pdf",
+			want:  true,
+		},
+		{
+			name:  "included file",
+			perms: &SubRepoPermissions{PathIncludes: []string{"src/**"}},
+			path:  "/src/main.go",
+			want:  true,
+		},
+		{
+			name:  "not included file",
+			perms: &SubRepoPermissions{PathIncludes: []string{"src/**"}},
+			path:  "README.md",
+			want:  false,
+		},
+		{
+			name:  "exclude wins over include",
+			perms: &SubRepoPermissions{PathIncludes: []string{"src/**"}, PathExcludes: []string{"src/internal/**"}},
+			path:  "src/internal/keys.go",
+			want:  false,
+		},
+		{
+			name:  "directory leading to included path",
+			perms: &SubRepoPermissions{PathIncludes: []string{"src/api/**"}},
+			path:  "src",
+			isDir: true,
+			want:  true,
+		},
+		{
+			name:  "directory not leading to included path",
+			perms: &SubRepoPermissions{PathIncludes: []string{"src/api/**"}},
+			path:  "docs",
+			isDir: true,
+			want:  false,
+		},
+		{
+			name:  "root directory",
+			perms: &SubRepoPermissions{PathIncludes: []string{"src/api/**"}},
+			path:  "/",
+			isDir: true,
+			want:  true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			m, err := NewSubRepoPermsMatcher(tc.perms)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if have := m.CanRead(tc.path, tc.isDir); have != tc.want {
+				t.Fatalf("CanRead(%q, %t): have %t, want %t", tc.path, tc.isDir, have, tc.want)
+			}
+		})
+	}
+
+	t.Run("nil matcher", func(t *testing.T) {
+		var m *SubRepoPermsMatcher
+		if !m.CanReadFile("secrets/token") {
+			t.Fatal("nil matcher should allow reading every path")
+		}
+	})
+
+	t.Run("invalid pattern", func(t *testing.T) {
+		_, err := NewSubRepoPermsMatcher(&SubRepoPermissions{PathIncludes: []string{"src/[a"}})
+		if err == nil {
+			t.Fatal("expected error for invalid pattern")
+		}
+	})
+}
@@ -0,0 +1,23 @@
+package authz
+
+import (
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+)
+
+// UsernameMatchingProblem returns why authz providers must not match Sourcegraph users to code
+// host accounts by username with the given site configuration, or "" if they may.
+//
+// Matching by username is only safe when users can't choose their own usernames. Otherwise,
+// anyone could claim the username of a code host user who hasn't signed up yet, and inherit
+// that user's repository permissions.
+func UsernameMatchingProblem(c *conf.Unified) string {
+	if c.AuthEnableUsernameChanges {
+		return "users are matched to code host accounts by username, which is unsafe when `auth.enableUsernameChanges` is true"
+	}
+	for _, p := range c.AuthProviders {
+		if p.Builtin != nil && p.Builtin.AllowSignup {
+			return "users are matched to code host accounts by username, which is unsafe when the builtin auth provider allows sign-up (`allowSignup`)"
+		}
+	}
+	return ""
+}
@@ -0,0 +1,48 @@
+package authz
+
+import (
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestUsernameMatchingProblem(t *testing.T) {
+	for _, tc := range []struct {
+		name        string
+		config      schema.SiteConfiguration
+		wantProblem bool
+	}{
+		{
+			name: "external auth provider only",
+			config: schema.SiteConfiguration{AuthProviders: []schema.AuthProviders{
+				{Saml: &schema.SAMLAuthProvider{Type: "saml"}},
+			}},
+		},
+		{
+			name: "builtin without sign-up",
+			config: schema.SiteConfiguration{AuthProviders: []schema.AuthProviders{
+				{Builtin: &schema.BuiltinAuthProvider{Type: "builtin"}},
+			}},
+		},
+		{
+			name: "builtin with sign-up",
+			config: schema.SiteConfiguration{AuthProviders: []schema.AuthProviders{
+				{Builtin: &schema.BuiltinAuthProvider{Type: "builtin", AllowSignup: true}},
+			}},
+			wantProblem: true,
+		},
+		{
+			name:        "username changes",
+			config:      schema.SiteConfiguration{AuthEnableUsernameChanges: true},
+			wantProblem: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			problem := UsernameMatchingProblem(&conf.Unified{SiteConfiguration: tc.config})
+			if got := problem != ""; got != tc.wantProblem {
+				t.Errorf("got problem %q, want problem: %v", problem, tc.wantProblem)
+			}
+		})
+	}
+}
@@ -0,0 +1,303 @@
+package campaigns
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"path"
+	"strings"
+	"text/template"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/pkg/errors"
+	yamlv3 "gopkg.in/yaml.v3"
+)
+
+// The delimiters of the templates in a CampaignSpec. They differ from the
+// text/template defaults so that they don't clash with the many tools that
+// use `{{` in their own configuration or arguments.
+const (
+	templateLeftDelim  = "${{"
+	templateRightDelim = "}}"
+)
+
+// TemplateRepository is the metadata of a repository that is available as
+// `repository` in the templates of a CampaignSpec.
+type TemplateRepository struct {
+	// Name is the name of the repository.
+	Name string `json:"name"`
+	// Branch is the name of the branch the steps are executed on, without the
+	// refs/heads/ prefix.
+	Branch string `json:"branch"`
+	// SearchResultPaths are the paths of the files in the repository that
+	// matched the `repositoriesMatchingQuery` of the CampaignSpec.
+	SearchResultPaths []string `json:"search_result_paths"`
+}
+
+// StepResult is the output of a CampaignSpecStep that is available as
+// `step` and `previous_step` in the templates of a CampaignSpec.
+type StepResult struct {
+	Stdout string
+	Stderr string
+}
+
+// TemplateContext is the data available in the templates of a CampaignSpec
+// when it's executed in a single repository.
+type TemplateContext struct {
+	Repository TemplateRepository
+
+	// Outputs are the outputs produced by the steps that were run so far,
+	// keyed by their name.
+	Outputs map[string]interface{}
+
+	// Step is the result of the step whose outputs are being evaluated.
+	Step StepResult
+	// PreviousStep is the result of the last step that was run.
+	PreviousStep StepResult
+}
+
+func (c *TemplateContext) funcs() template.FuncMap {
+	stepResult := func(r StepResult) map[string]interface{} {
+		return map[string]interface{}{"stdout": r.Stdout, "stderr": r.Stderr}
+	}
+
+	return template.FuncMap{
+		"repository": func() map[string]interface{} {
+			return map[string]interface{}{
+				"name":                c.Repository.Name,
+				"branch":              c.Repository.Branch,
+				"search_result_paths": c.Repository.SearchResultPaths,
+			}
+		},
+		"outputs":       func() map[string]interface{} { return c.Outputs },
+		"step":          func() map[string]interface{} { return stepResult(c.Step) },
+		"previous_step": func() map[string]interface{} { return stepResult(c.PreviousStep) },
+
+		"join":    func(elems []string, sep string) string { return strings.Join(elems, sep) },
+		"split":   strings.Split,
+		"replace": strings.ReplaceAll,
+		"matches": func(name, pattern string) (bool, error) { return matchRepositoryName(pattern, name) },
+	}
+}
+
+// RenderTemplate evaluates the given template with the given context.
+func RenderTemplate(name, text string, c *TemplateContext) (string, error) {
+	t, err := parseTemplate(name, text, c)
+	if err != nil {
+		return "", err
+	}
+
+	var out bytes.Buffer
+	if err := t.Execute(&out, nil); err != nil {
+		return "", err
+	}
+	return out.String(), nil
+}
+
+func parseTemplate(name, text string, c *TemplateContext) (*template.Template, error) {
+	return template.New(name).
+		Delims(templateLeftDelim, templateRightDelim).
+		Option("missingkey=error").
+		Funcs(c.funcs()).
+		Parse(text)
+}
+
+// matchRepositoryName reports whether the given repository name matches the
+// given pattern, which is a glob as understood by path.Match.
+func matchRepositoryName(pattern, name string) (bool, error) {
+	return path.Match(pattern, name)
+}
+
+// Render returns a copy of the step with its run command and environment
+// evaluated with the given context.
+func (s CampaignSpecStep) Render(c *TemplateContext) (CampaignSpecStep, error) {
+	run, err := RenderTemplate("run", s.Run, c)
+	if err != nil {
+		return s, err
+	}
+
+	rendered := s
+	rendered.Run = run
+	rendered.Env = make(map[string]string, len(s.Env))
+	for k, v := range s.Env {
+		if rendered.Env[k], err = RenderTemplate("env."+k, v, c); err != nil {
+			return s, err
+		}
+	}
+	return rendered, nil
+}
+
+// ShouldRun evaluates the `if` condition of the step with the given context.
+// Steps without a condition are always run.
+func (s CampaignSpecStep) ShouldRun(c *TemplateContext) (bool, error) {
+	switch cond := s.If.(type) {
+	case nil:
+		return true, nil
+	case bool:
+		return cond, nil
+	case string:
+		out, err := RenderTemplate("if", cond, c)
+		if err != nil {
+			return false, err
+		}
+		return strings.TrimSpace(out) == "true", nil
+	default:
+		return false, errors.Errorf("invalid if condition %v", s.If)
+	}
+}
+
+// SetOutputs evaluates the outputs of the step with the given context, whose
+// Step must be the result of the step, and adds them to the context.
+func (s CampaignSpecStep) SetOutputs(c *TemplateContext) error {
+	if c.Outputs == nil {
+		c.Outputs = make(map[string]interface{}, len(s.Outputs))
+	}
+
+	for name, output := range s.Outputs {
+		value, err := RenderTemplate("outputs."+name, output.Value, c)
+		if err != nil {
+			return err
+		}
+
+		switch output.Format {
+		case "json":
+			var parsed interface{}
+			if err := json.Unmarshal([]byte(value), &parsed); err != nil {
+				return errors.Wrapf(err, "parsing output %q as JSON", name)
+			}
+			c.Outputs[name] = parsed
+		case "yaml":
+			var parsed interface{}
+			if err := yamlv3.Unmarshal([]byte(value), &parsed); err != nil {
+				return errors.Wrapf(err, "parsing output %q as YAML", name)
+			}
+			c.Outputs[name] = parsed
+		default:
+			c.Outputs[name] = value
+		}
+	}
+
+	return nil
+}
+
+// ForRepository returns the changeset template with the first override whose
+// repository pattern matches the given repository name applied to it.
+func (t ChangesetTemplate) ForRepository(name string) ChangesetTemplate {
+	merged := t
+	merged.Overrides = nil
+
+	for _, o := range t.Overrides {
+		if ok, _ := matchRepositoryName(o.Repository, name); !ok {
+			continue
+		}
+
+		if o.Title != "" {
+			merged.Title = o.Title
+		}
+		if o.Body != "" {
+			merged.Body = o.Body
+		}
+		if o.Branch != "" {
+			merged.Branch = o.Branch
+		}
+		if o.Commit != nil {
+			merged.Commit = *o.Commit
+		}
+		if o.Published != nil {
+			merged.Published = *o.Published
+		}
+		break
+	}
+
+	return merged
+}
+
+// Render returns a copy of the changeset template with its title, body,
+// branch and commit message evaluated with the given context.
+func (t ChangesetTemplate) Render(c *TemplateContext) (ChangesetTemplate, error) {
+	rendered := t
+	for name, field := range map[string]*string{
+		"title":          &rendered.Title,
+		"body":           &rendered.Body,
+		"branch":         &rendered.Branch,
+		"commit.message": &rendered.Commit.Message,
+	} {
+		out, err := RenderTemplate("changesetTemplate."+name, *field, c)
+		if err != nil {
+			return t, err
+		}
+		*field = out
+	}
+	return rendered, nil
+}
+
+// GroupsForRepository returns the groups that apply to the repository with
+// the given name.
+func (t *TransformChanges) GroupsForRepository(name string) []TransformChangesGroup {
+	if t == nil {
+		return nil
+	}
+
+	var groups []TransformChangesGroup
+	for _, g := range t.Group {
+		if g.Repository == "" || g.Repository == name {
+			groups = append(groups, g)
+		}
+	}
+	return groups
+}
+
+// validateTemplates checks that all templates of the CampaignSpec can be
+// parsed, so that syntax errors are reported when the CampaignSpec is created
+// instead of when it's executed.
+func (cs *CampaignSpec) validateTemplates() error {
+	var (
+		errs *multierror.Error
+		c    = &TemplateContext{}
+	)
+	check := func(name, text string) {
+		if _, err := parseTemplate(name, text, c); err != nil {
+			errs = multierror.Append(errs, err)
+		}
+	}
+
+	for i, step := range cs.Spec.Steps {
+		prefix := fmt.Sprintf("steps[%d].", i)
+		check(prefix+"run", step.Run)
+		for k, v := range step.Env {
+			check(prefix+"env."+k, v)
+		}
+		if cond, ok := step.If.(string); ok {
+			check(prefix+"if", cond)
+		}
+		for name, output := range step.Outputs {
+			check(prefix+"outputs."+name, output.Value)
+		}
+	}
+
+	tmpl := cs.Spec.ChangesetTemplate
+	check("changesetTemplate.title", tmpl.Title)
+	check("changesetTemplate.body", tmpl.Body)
+	check("changesetTemplate.branch", tmpl.Branch)
+	check("changesetTemplate.commit.message", tmpl.Commit.Message)
+	for i, o := range tmpl.Overrides {
+		if _, err := path.Match(o.Repository, ""); err != nil {
+			errs = multierror.Append(errs, errors.Wrapf(err, "changesetTemplate.overrides[%d].repository", i))
+		}
+		prefix := fmt.Sprintf("changesetTemplate.overrides[%d].", i)
+		check(prefix+"title", o.Title)
+		check(prefix+"body", o.Body)
+		check(prefix+"branch", o.Branch)
+		if o.Commit != nil {
+			check(prefix+"commit.message", o.Commit.Message)
+		}
+	}
+
+	if cs.Spec.TransformChanges != nil {
+		for i, g := range cs.Spec.TransformChanges.Group {
+			check(fmt.Sprintf("transformChanges.group[%d].branch", i), g.Branch)
+		}
+	}
+
+	return errs.ErrorOrNil()
+}
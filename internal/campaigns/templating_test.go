@@ -0,0 +1,176 @@
+package campaigns
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestRenderTemplate(t *testing.T) {
+	c := &TemplateContext{
+		Repository: TemplateRepository{
+			Name:              "github.com/sourcegraph/src-cli",
+			Branch:            "main",
+			SearchResultPaths: []string{"README.md", "docs/README.md"},
+		},
+		Outputs: map[string]interface{}{
+			"lastLine": "foo",
+			"config":   map[string]interface{}{"owner": "campaigns"},
+		},
+		Step:         StepResult{Stdout: "hello\n"},
+		PreviousStep: StepResult{Stderr: "oops"},
+	}
+
+	tests := []struct {
+		template string
+		want     string
+		wantErr  bool
+	}{
+		{template: "${{ repository.name }}@${{ repository.branch }}", want: "github.com/sourcegraph/src-cli@main"},
+		{template: `${{ join repository.search_result_paths " " }}`, want: "README.md docs/README.md"},
+		{template: "${{ outputs.lastLine }} ${{ outputs.config.owner }}", want: "foo campaigns"},
+		{template: "${{ step.stdout }}${{ previous_step.stderr }}", want: "hello\noops"},
+		{template: `${{ replace repository.name "/" "-" }}`, want: "github.com-sourcegraph-src-cli"},
+		{template: `${{ matches repository.name "github.com/sourcegraph/*" }}`, want: "true"},
+		{template: `${{ matches repository.name "github.com/*" }}`, want: "false"},
+		{template: "echo {{ not a template }}", want: "echo {{ not a template }}"},
+		{template: "${{ outputs.missing }}", wantErr: true},
+		{template: "${{ repository.name", wantErr: true},
+	}
+
+	for _, tc := range tests {
+		have, err := RenderTemplate("test", tc.template, c)
+		if tc.wantErr {
+			if err == nil {
+				t.Errorf("%q: expected error, got none", tc.template)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%q: unexpected error: %s", tc.template, err)
+			continue
+		}
+		if have != tc.want {
+			t.Errorf("%q: have=%q want=%q", tc.template, have, tc.want)
+		}
+	}
+}
+
+func TestCampaignSpecStepTemplates(t *testing.T) {
+	c := &TemplateContext{Repository: TemplateRepository{Name: "github.com/sourcegraph/src-cli"}}
+
+	step := CampaignSpecStep{
+		Run: "echo ${{ repository.name }}",
+		Env: map[string]string{"REPO": "${{ repository.name }}"},
+		If:  `${{ matches repository.name "github.com/sourcegraph/*" }}`,
+		Outputs: map[string]CampaignSpecStepOutput{
+			"text": {Value: "${{ step.stdout }}"},
+			"json": {Value: "${{ step.stdout }}", Format: "json"},
+		},
+	}
+
+	rendered, err := step.Render(c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if have, want := rendered.Run, "echo github.com/sourcegraph/src-cli"; have != want {
+		t.Errorf("unexpected run. have=%q want=%q", have, want)
+	}
+	if have, want := rendered.Env["REPO"], "github.com/sourcegraph/src-cli"; have != want {
+		t.Errorf("unexpected env. have=%q want=%q", have, want)
+	}
+	if step.Run != "echo ${{ repository.name }}" {
+		t.Errorf("step was modified: %q", step.Run)
+	}
+
+	for cond, want := range map[interface{}]bool{
+		nil:  true,
+		true: true,
+		"${{ matches repository.name \"github.com/sourcegraph/*\" }}": true,
+		"${{ matches repository.name \"gitlab.com/*\" }}":             false,
+		false: false,
+	} {
+		step := CampaignSpecStep{If: cond}
+		have, err := step.ShouldRun(c)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if have != want {
+			t.Errorf("if %v: have=%t want=%t", cond, have, want)
+		}
+	}
+
+	c.Step = StepResult{Stdout: `{"a": [1, 2]}`}
+	if err := step.SetOutputs(c); err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]interface{}{
+		"text": `{"a": [1, 2]}`,
+		"json": map[string]interface{}{"a": []interface{}{1.0, 2.0}},
+	}
+	if diff := cmp.Diff(want, c.Outputs); diff != "" {
+		t.Fatalf("unexpected outputs (-want +got):\n%s", diff)
+	}
+
+	c.Step = StepResult{Stdout: "not json"}
+	if err := step.SetOutputs(c); err == nil {
+		t.Fatal("expected error for invalid JSON output")
+	}
+}
+
+func TestChangesetTemplateForRepository(t *testing.T) {
+	published := PublishedValue{Val: true}
+	tmpl := ChangesetTemplate{
+		Title:  "Hello ${{ repository.name }}",
+		Body:   "Body",
+		Branch: "hello",
+		Commit: CommitTemplate{Message: "Say hello"},
+		Overrides: []ChangesetTemplateOverride{
+			{Repository: "github.com/sourcegraph/*", Title: "Hello Sourcegraph", Published: &published},
+			{Repository: "github.com/sourcegraph/sourcegraph", Body: "Never applied"},
+		},
+	}
+
+	for name, want := range map[string]ChangesetTemplate{
+		"github.com/sourcegraph/sourcegraph": {
+			Title:     "Hello Sourcegraph",
+			Body:      "Body",
+			Branch:    "hello",
+			Commit:    CommitTemplate{Message: "Say hello"},
+			Published: PublishedValue{Val: true},
+		},
+		"gitlab.com/sourcegraph/sourcegraph": {
+			Title:  "Hello gitlab.com/sourcegraph/sourcegraph",
+			Body:   "Body",
+			Branch: "hello",
+			Commit: CommitTemplate{Message: "Say hello"},
+		},
+	} {
+		have, err := tmpl.ForRepository(name).Render(&TemplateContext{Repository: TemplateRepository{Name: name}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(want, have); diff != "" {
+			t.Errorf("%s: unexpected template (-want +got):\n%s", name, diff)
+		}
+	}
+}
+
+func TestTransformChangesGroupsForRepository(t *testing.T) {
+	var nilTransform *TransformChanges
+	if groups := nilTransform.GroupsForRepository("github.com/foo/bar"); groups != nil {
+		t.Fatalf("unexpected groups: %+v", groups)
+	}
+
+	transform := &TransformChanges{Group: []TransformChangesGroup{
+		{Directory: "docs", Branch: "docs"},
+		{Directory: "client", Branch: "client", Repository: "github.com/foo/bar"},
+	}}
+
+	if have, want := len(transform.GroupsForRepository("github.com/foo/bar")), 2; have != want {
+		t.Errorf("unexpected number of groups. have=%d want=%d", have, want)
+	}
+	if have, want := len(transform.GroupsForRepository("github.com/foo/baz")), 1; have != want {
+		t.Errorf("unexpected number of groups. have=%d want=%d", have, want)
+	}
+}
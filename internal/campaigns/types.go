@@ -1,6 +1,8 @@
 package campaigns
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -10,6 +12,7 @@ import (
 	"time"
 
 	"github.com/ghodss/yaml"
+	"github.com/gobwas/glob"
 	"github.com/graph-gophers/graphql-go"
 	"github.com/hashicorp/go-multierror"
 	"github.com/inconshreveable/log15"
@@ -102,20 +105,30 @@ type ChangesetPublicationState string
 const (
 	ChangesetPublicationStateUnpublished ChangesetPublicationState = "UNPUBLISHED"
 	ChangesetPublicationStatePublished   ChangesetPublicationState = "PUBLISHED"
+	ChangesetPublicationStateDraft       ChangesetPublicationState = "DRAFT"
 )
 
 // Valid returns true if the given ChangesetPublicationState is valid.
 func (s ChangesetPublicationState) Valid() bool {
 	switch s {
-	case ChangesetPublicationStateUnpublished, ChangesetPublicationStatePublished:
+	case ChangesetPublicationStateUnpublished,
+		ChangesetPublicationStatePublished,
+		ChangesetPublicationStateDraft:
 		return true
 	default:
 		return false
 	}
 }
 
-// Published returns true if the given state is ChangesetPublicationStatePublished.
-func (s ChangesetPublicationState) Published() bool { return s == ChangesetPublicationStatePublished }
+// Published returns true if the given state is ChangesetPublicationStatePublished
+// or ChangesetPublicationStateDraft, since draft changesets have been created
+// on the code host, too.
+func (s ChangesetPublicationState) Published() bool {
+	return s == ChangesetPublicationStatePublished || s == ChangesetPublicationStateDraft
+}
+
+// Draft returns true if the given state is ChangesetPublicationStateDraft.
+func (s ChangesetPublicationState) Draft() bool { return s == ChangesetPublicationStateDraft }
 
 // Unpublished returns true if the given state is ChangesetPublicationStateUnpublished.
 func (s ChangesetPublicationState) Unpublished() bool {
@@ -241,6 +254,31 @@ func (s ChangesetCheckState) Valid() bool {
 	}
 }
 
+// ChangesetRebaseState describes whether the commit of a changeset created by
+// a campaign is based on the current head of its base branch.
+type ChangesetRebaseState string
+
+// ChangesetRebaseState constants.
+const (
+	// ChangesetRebaseStateUpToDate means that the patch of the changeset was
+	// re-applied on the current head of the base branch.
+	ChangesetRebaseStateUpToDate ChangesetRebaseState = "UP_TO_DATE"
+	// ChangesetRebaseStateConflicting means that the base branch has moved and
+	// the patch of the changeset no longer applies cleanly to it.
+	ChangesetRebaseStateConflicting ChangesetRebaseState = "CONFLICTING"
+)
+
+// Valid returns true if the given ChangesetRebaseState is valid.
+func (s ChangesetRebaseState) Valid() bool {
+	switch s {
+	case ChangesetRebaseStateUpToDate,
+		ChangesetRebaseStateConflicting:
+		return true
+	default:
+		return false
+	}
+}
+
 // A Changeset is a changeset on a code host belonging to a Repository and many
 // Campaigns.
 type Changeset struct {
@@ -274,7 +312,7 @@ type Changeset struct {
 	CurrentSpecID  int64
 	PreviousSpecID int64
 
-	PublicationState ChangesetPublicationState // "unpublished", "published"
+	PublicationState ChangesetPublicationState // "unpublished", "published", "draft"
 
 	// All of the following fields are used by workerutil.Worker.
 	ReconcilerState ReconcilerState
@@ -292,6 +330,14 @@ type Changeset struct {
 	// Closing is set to true (along with the ReocncilerState) when the
 	// reconciler should close the changeset.
 	Closing bool
+
+	// RebaseState is set by the changeset rebaser once the base branch of a
+	// changeset created by a campaign has moved. It's empty as long as the
+	// commit is still based on the BaseRev of the current ChangesetSpec.
+	RebaseState ChangesetRebaseState
+	// RebaseBaseRev is the revision of the base branch that the rebaser last
+	// tried to re-apply the patch on.
+	RebaseBaseRev string
 }
 
 // RecordID is needed to implement the workerutil.Record interface.
@@ -312,9 +358,13 @@ func (c *Changeset) PublishedAndSynced() bool {
 	return !c.Unsynced && c.PublicationState.Published()
 }
 
-// Published returns whether the Changeset's PublicationState is Published.
+// Published returns whether the Changeset's PublicationState is Published or
+// Draft.
 func (c *Changeset) Published() bool { return c.PublicationState.Published() }
 
+// Draft returns whether the Changeset's PublicationState is Draft.
+func (c *Changeset) Draft() bool { return c.PublicationState.Draft() }
+
 // Unpublished returns whether the Changeset's PublicationState is Unpublished.
 func (c *Changeset) Unpublished() bool { return c.PublicationState.Unpublished() }
 
@@ -654,6 +704,22 @@ func (c *Changeset) Events() (events []*ChangesetEvent) {
 					Metadata:    review,
 				})
 			}
+			if wip := note.ToWorkInProgressEvent(); wip != nil {
+				events = append(events, &ChangesetEvent{
+					ChangesetID: c.ID,
+					Key:         wip.(Keyer).Key(),
+					Kind:        ChangesetEventKindFor(wip),
+					Metadata:    wip,
+				})
+			}
+			if !note.System {
+				events = append(events, &ChangesetEvent{
+					ChangesetID: c.ID,
+					Key:         note.Key(),
+					Kind:        ChangesetEventKindFor(note),
+					Metadata:    note,
+				})
+			}
 		}
 
 		for _, pipeline := range m.Pipelines {
@@ -814,6 +880,8 @@ func (e *ChangesetEvent) Actor() string {
 		a = e.Actor.Login
 	case *github.ClosedEvent:
 		a = e.Actor.Login
+	case *github.ConvertToDraftEvent:
+		a = e.Actor.Login
 	case *github.IssueComment:
 		a = e.Author.Login
 	case *github.RenamedTitleEvent:
@@ -824,6 +892,8 @@ func (e *ChangesetEvent) Actor() string {
 		a = e.Author.Login
 	case *github.PullRequestReviewComment:
 		a = e.Author.Login
+	case *github.ReadyForReviewEvent:
+		a = e.Actor.Login
 	case *github.ReopenedEvent:
 		a = e.Actor.Login
 	case *github.ReviewDismissedEvent:
@@ -948,6 +1018,8 @@ func (e *ChangesetEvent) Timestamp() time.Time {
 		t = ev.CreatedAt
 	case *github.ClosedEvent:
 		t = ev.CreatedAt
+	case *github.ConvertToDraftEvent:
+		t = ev.CreatedAt
 	case *github.IssueComment:
 		t = ev.UpdatedAt
 	case *github.RenamedTitleEvent:
@@ -958,6 +1030,8 @@ func (e *ChangesetEvent) Timestamp() time.Time {
 		t = ev.UpdatedAt
 	case *github.PullRequestReviewComment:
 		t = ev.UpdatedAt
+	case *github.ReadyForReviewEvent:
+		t = ev.CreatedAt
 	case *github.ReopenedEvent:
 		t = ev.CreatedAt
 	case *github.ReviewDismissedEvent:
@@ -986,6 +1060,14 @@ func (e *ChangesetEvent) Timestamp() time.Time {
 		return ev.CreatedAt.Time
 	case *gitlab.ReviewUnapproved:
 		return ev.CreatedAt.Time
+	case *gitlab.MarkWorkInProgressEvent:
+		return ev.CreatedAt.Time
+	case *gitlab.UnmarkWorkInProgressEvent:
+		return ev.CreatedAt.Time
+	case *gitlab.Note:
+		return ev.CreatedAt.Time
+	case *gitlabwebhooks.LabelEvent:
+		return ev.CreatedAt.Time
 	case *gitlabwebhooks.MergeRequestCloseEvent,
 		*gitlabwebhooks.MergeRequestMergeEvent,
 		*gitlabwebhooks.MergeRequestReopenEvent,
@@ -1207,6 +1289,28 @@ func (e *ChangesetEvent) Update(o *ChangesetEvent) error {
 			e.Actor = o.Actor
 		}
 
+		if e.CreatedAt.IsZero() {
+			e.CreatedAt = o.CreatedAt
+		}
+
+	case *github.ConvertToDraftEvent:
+		o := o.Metadata.(*github.ConvertToDraftEvent)
+
+		if e.Actor == (github.Actor{}) {
+			e.Actor = o.Actor
+		}
+
+		if e.CreatedAt.IsZero() {
+			e.CreatedAt = o.CreatedAt
+		}
+
+	case *github.ReadyForReviewEvent:
+		o := o.Metadata.(*github.ReadyForReviewEvent)
+
+		if e.Actor == (github.Actor{}) {
+			e.Actor = o.Actor
+		}
+
 		if e.CreatedAt.IsZero() {
 			e.CreatedAt = o.CreatedAt
 		}
@@ -1358,6 +1462,26 @@ func (e *ChangesetEvent) Update(o *ChangesetEvent) error {
 		// We always get the full event, so safe to replace it
 		*e = *o
 
+	case *gitlab.MarkWorkInProgressEvent:
+		o := o.Metadata.(*gitlab.MarkWorkInProgressEvent)
+		// We always get the full event, so safe to replace it
+		*e = *o
+
+	case *gitlab.UnmarkWorkInProgressEvent:
+		o := o.Metadata.(*gitlab.UnmarkWorkInProgressEvent)
+		// We always get the full event, so safe to replace it
+		*e = *o
+
+	case *gitlab.Note:
+		o := o.Metadata.(*gitlab.Note)
+		// We always get the full event, so safe to replace it
+		*e = *o
+
+	case *gitlabwebhooks.LabelEvent:
+		o := o.Metadata.(*gitlabwebhooks.LabelEvent)
+		// We always get the full event, so safe to replace it
+		*e = *o
+
 	case *gitlabwebhooks.MergeRequestCloseEvent:
 		o := o.Metadata.(*gitlabwebhooks.MergeRequestCloseEvent)
 		// We always get the full event, so safe to replace it
@@ -1484,6 +1608,8 @@ func ChangesetEventKindFor(e interface{}) ChangesetEventKind {
 		return ChangesetEventKindGitHubAssigned
 	case *github.ClosedEvent:
 		return ChangesetEventKindGitHubClosed
+	case *github.ConvertToDraftEvent:
+		return ChangesetEventKindGitHubConvertToDraft
 	case *github.IssueComment:
 		return ChangesetEventKindGitHubCommented
 	case *github.RenamedTitleEvent:
@@ -1494,6 +1620,8 @@ func ChangesetEventKindFor(e interface{}) ChangesetEventKind {
 		return ChangesetEventKindGitHubReviewed
 	case *github.PullRequestReviewComment:
 		return ChangesetEventKindGitHubReviewCommented
+	case *github.ReadyForReviewEvent:
+		return ChangesetEventKindGitHubReadyForReview
 	case *github.ReopenedEvent:
 		return ChangesetEventKindGitHubReopened
 	case *github.ReviewDismissedEvent:
@@ -1529,6 +1657,17 @@ func ChangesetEventKindFor(e interface{}) ChangesetEventKind {
 		return ChangesetEventKindGitLabApproved
 	case *gitlab.ReviewUnapproved:
 		return ChangesetEventKindGitLabUnapproved
+	case *gitlab.MarkWorkInProgressEvent:
+		return ChangesetEventKindGitLabMarkWorkInProgress
+	case *gitlab.UnmarkWorkInProgressEvent:
+		return ChangesetEventKindGitLabUnmarkWorkInProgress
+	case *gitlab.Note:
+		return ChangesetEventKindGitLabCommented
+	case *gitlabwebhooks.LabelEvent:
+		if e.Removed {
+			return ChangesetEventKindGitLabUnlabeled
+		}
+		return ChangesetEventKindGitLabLabeled
 	case *gitlabwebhooks.MergeRequestCloseEvent:
 		return ChangesetEventKindGitLabClosed
 	case *gitlabwebhooks.MergeRequestMergeEvent:
@@ -1559,6 +1698,8 @@ func NewChangesetEventMetadata(k ChangesetEventKind) (interface{}, error) {
 			return new(github.AssignedEvent), nil
 		case ChangesetEventKindGitHubClosed:
 			return new(github.ClosedEvent), nil
+		case ChangesetEventKindGitHubConvertToDraft:
+			return new(github.ConvertToDraftEvent), nil
 		case ChangesetEventKindGitHubCommented:
 			return new(github.IssueComment), nil
 		case ChangesetEventKindGitHubRenamedTitle:
@@ -1569,6 +1710,8 @@ func NewChangesetEventMetadata(k ChangesetEventKind) (interface{}, error) {
 			return new(github.PullRequestReview), nil
 		case ChangesetEventKindGitHubReviewCommented:
 			return new(github.PullRequestReviewComment), nil
+		case ChangesetEventKindGitHubReadyForReview:
+			return new(github.ReadyForReviewEvent), nil
 		case ChangesetEventKindGitHubReopened:
 			return new(github.ReopenedEvent), nil
 		case ChangesetEventKindGitHubReviewDismissed:
@@ -1600,6 +1743,16 @@ func NewChangesetEventMetadata(k ChangesetEventKind) (interface{}, error) {
 			return new(gitlab.Pipeline), nil
 		case ChangesetEventKindGitLabUnapproved:
 			return new(gitlab.ReviewUnapproved), nil
+		case ChangesetEventKindGitLabMarkWorkInProgress:
+			return new(gitlab.MarkWorkInProgressEvent), nil
+		case ChangesetEventKindGitLabUnmarkWorkInProgress:
+			return new(gitlab.UnmarkWorkInProgressEvent), nil
+		case ChangesetEventKindGitLabCommented:
+			return new(gitlab.Note), nil
+		case ChangesetEventKindGitLabLabeled:
+			return new(gitlabwebhooks.LabelEvent), nil
+		case ChangesetEventKindGitLabUnlabeled:
+			return &gitlabwebhooks.LabelEvent{Removed: true}, nil
 		case ChangesetEventKindGitLabClosed:
 			return new(gitlabwebhooks.MergeRequestCloseEvent), nil
 		case ChangesetEventKindGitLabMerged:
@@ -1636,6 +1789,8 @@ const (
 	ChangesetEventKindCommitStatus               ChangesetEventKind = "github:commit_status"
 	ChangesetEventKindCheckSuite                 ChangesetEventKind = "github:check_suite"
 	ChangesetEventKindCheckRun                   ChangesetEventKind = "github:check_run"
+	ChangesetEventKindGitHubConvertToDraft       ChangesetEventKind = "github:convert_to_draft"
+	ChangesetEventKindGitHubReadyForReview       ChangesetEventKind = "github:ready_for_review"
 
 	ChangesetEventKindBitbucketServerApproved     ChangesetEventKind = "bitbucketserver:approved"
 	ChangesetEventKindBitbucketServerUnapproved   ChangesetEventKind = "bitbucketserver:unapproved"
@@ -1653,12 +1808,17 @@ const (
 	// clearly convey that it only occurs when a request for changes has been dismissed.
 	ChangesetEventKindBitbucketServerDismissed ChangesetEventKind = "bitbucketserver:participant_status:unapproved"
 
-	ChangesetEventKindGitLabApproved   ChangesetEventKind = "gitlab:approved"
-	ChangesetEventKindGitLabClosed     ChangesetEventKind = "gitlab:closed"
-	ChangesetEventKindGitLabMerged     ChangesetEventKind = "gitlab:merged"
-	ChangesetEventKindGitLabPipeline   ChangesetEventKind = "gitlab:pipeline"
-	ChangesetEventKindGitLabReopened   ChangesetEventKind = "gitlab:reopened"
-	ChangesetEventKindGitLabUnapproved ChangesetEventKind = "gitlab:unapproved"
+	ChangesetEventKindGitLabApproved             ChangesetEventKind = "gitlab:approved"
+	ChangesetEventKindGitLabClosed               ChangesetEventKind = "gitlab:closed"
+	ChangesetEventKindGitLabMerged               ChangesetEventKind = "gitlab:merged"
+	ChangesetEventKindGitLabPipeline             ChangesetEventKind = "gitlab:pipeline"
+	ChangesetEventKindGitLabReopened             ChangesetEventKind = "gitlab:reopened"
+	ChangesetEventKindGitLabUnapproved           ChangesetEventKind = "gitlab:unapproved"
+	ChangesetEventKindGitLabMarkWorkInProgress   ChangesetEventKind = "gitlab:mark_wip"
+	ChangesetEventKindGitLabUnmarkWorkInProgress ChangesetEventKind = "gitlab:unmark_wip"
+	ChangesetEventKindGitLabCommented            ChangesetEventKind = "gitlab:commented"
+	ChangesetEventKindGitLabLabeled              ChangesetEventKind = "gitlab:labeled"
+	ChangesetEventKindGitLabUnlabeled            ChangesetEventKind = "gitlab:unlabeled"
 )
 
 // ChangesetSyncData represents data about the sync status of a changeset
@@ -1714,7 +1874,28 @@ func (cs *CampaignSpec) Clone() *CampaignSpec {
 // UnmarshalValidate unmarshals the RawSpec into Spec and validates it against
 // the CampaignSpec schema and does additional semantic validation.
 func (cs *CampaignSpec) UnmarshalValidate() error {
-	return unmarshalValidate(schema.CampaignSpecSchemaJSON, []byte(cs.RawSpec), &cs.Spec)
+	if err := unmarshalValidate(schema.CampaignSpecSchemaJSON, []byte(cs.RawSpec), &cs.Spec); err != nil {
+		return err
+	}
+	if err := cs.validateTemplates(); err != nil {
+		return err
+	}
+	return cs.validateImportChangesets()
+}
+
+// validateImportChangesets checks that the head ref patterns of the
+// importChangesets queries are valid.
+func (cs *CampaignSpec) validateImportChangesets() error {
+	var errs *multierror.Error
+	for i, ic := range cs.Spec.ImportChangesets {
+		if ic.Query == nil || ic.Query.HeadRef == "" {
+			continue
+		}
+		if _, err := glob.Compile(ic.Query.HeadRef, '/'); err != nil {
+			errs = multierror.Append(errs, errors.Wrapf(err, "importChangesets[%d].query.headRef", i))
+		}
+	}
+	return errs.ErrorOrNil()
 }
 
 // CampaignSpecTTL specifies the TTL of CampaignSpecs that haven't been applied
@@ -1732,7 +1913,41 @@ type CampaignSpecFields struct {
 	Description       string             `json:"description"`
 	On                []CampaignSpecOn   `json:"on"`
 	Steps             []CampaignSpecStep `json:"steps"`
+	TransformChanges  *TransformChanges  `json:"transformChanges,omitempty"`
 	ChangesetTemplate ChangesetTemplate  `json:"changesetTemplate"`
+	ImportChangesets  []ImportChangesets `json:"importChangesets,omitempty"`
+}
+
+// ImportChangesets describes existing changesets in Repository that are
+// tracked by the campaign. They're either listed by their ExternalIDs or
+// found on the code host with the Query.
+type ImportChangesets struct {
+	Repository  string                 `json:"repository"`
+	ExternalIDs []interface{}          `json:"externalIDs,omitempty"`
+	Query       *ImportChangesetsQuery `json:"query,omitempty"`
+}
+
+// ImportChangesetsQuery describes the changesets in a repository that are
+// imported into a campaign.
+type ImportChangesetsQuery struct {
+	State   string   `json:"state,omitempty"`
+	Labels  []string `json:"labels,omitempty"`
+	HeadRef string   `json:"headRef,omitempty"`
+}
+
+// ExternalState returns the ChangesetExternalState of the changesets matching
+// the query. It's empty if changesets in all states match.
+func (q *ImportChangesetsQuery) ExternalState() ChangesetExternalState {
+	switch q.State {
+	case "closed":
+		return ChangesetExternalStateClosed
+	case "merged":
+		return ChangesetExternalStateMerged
+	case "all":
+		return ""
+	default:
+		return ChangesetExternalStateOpen
+	}
 }
 
 type CampaignSpecOn struct {
@@ -1741,23 +1956,405 @@ type CampaignSpecOn struct {
 }
 
 type CampaignSpecStep struct {
-	Run       string            `json:"run"`
-	Container string            `json:"container"`
-	Env       map[string]string `json:"env"`
+	Run       string                            `json:"run"`
+	Container string                            `json:"container"`
+	Env       map[string]string                 `json:"env"`
+	If        interface{}                       `json:"if,omitempty"`
+	Outputs   map[string]CampaignSpecStepOutput `json:"outputs,omitempty"`
+}
+
+// CampaignSpecStepOutput is an output of a CampaignSpecStep that can be used
+// in the templates of later steps and in the ChangesetTemplate.
+type CampaignSpecStepOutput struct {
+	Value  string `json:"value"`
+	Format string `json:"format,omitempty"`
+}
+
+// TransformChanges describes how the diff produced in a repository is split
+// into several changesets.
+type TransformChanges struct {
+	Group []TransformChangesGroup `json:"group,omitempty"`
+}
+
+// TransformChangesGroup moves the changes in Directory into a separate
+// changeset on Branch. If Repository is set, the group only applies to the
+// repository with that name.
+type TransformChangesGroup struct {
+	Directory  string `json:"directory"`
+	Branch     string `json:"branch"`
+	Repository string `json:"repository,omitempty"`
 }
 
 type ChangesetTemplate struct {
-	Title     string         `json:"title"`
-	Body      string         `json:"body"`
-	Branch    string         `json:"branch"`
-	Commit    CommitTemplate `json:"commit"`
-	Published bool           `json:"published"`
+	Title     string                      `json:"title"`
+	Body      string                      `json:"body"`
+	Branch    string                      `json:"branch"`
+	Commit    CommitTemplate              `json:"commit"`
+	Published PublishedValue              `json:"published"`
+	Overrides []ChangesetTemplateOverride `json:"overrides,omitempty"`
 }
 
 type CommitTemplate struct {
 	Message string `json:"message"`
 }
 
+// ChangesetTemplateOverride overrides the non-empty fields of a
+// ChangesetTemplate in the repositories matching Repository.
+type ChangesetTemplateOverride struct {
+	Repository string          `json:"repository"`
+	Title      string          `json:"title,omitempty"`
+	Body       string          `json:"body,omitempty"`
+	Branch     string          `json:"branch,omitempty"`
+	Commit     *CommitTemplate `json:"commit,omitempty"`
+	Published  *PublishedValue `json:"published,omitempty"`
+}
+
+// CampaignSpecExecutionState defines the possible states of a
+// CampaignSpecExecution.
+type CampaignSpecExecutionState string
+
+// CampaignSpecExecutionState constants.
+const (
+	CampaignSpecExecutionStateQueued     CampaignSpecExecutionState = "QUEUED"
+	CampaignSpecExecutionStateProcessing CampaignSpecExecutionState = "PROCESSING"
+	CampaignSpecExecutionStateErrored    CampaignSpecExecutionState = "ERRORED"
+	CampaignSpecExecutionStateCompleted  CampaignSpecExecutionState = "COMPLETED"
+)
+
+// ToDB returns the database representation of the execution state, which
+// needs to be lowercase to work with workerutil.Worker.
+func (s CampaignSpecExecutionState) ToDB() string { return strings.ToLower(string(s)) }
+
+// A CampaignSpecExecution is the server-side execution of the Steps of a
+// CampaignSpec in a single repository. Once the steps have run, the resulting
+// diff is turned into a ChangesetSpec that is attached to the CampaignSpec.
+type CampaignSpecExecution struct {
+	ID             int64
+	CampaignSpecID int64
+
+	RepoID  api.RepoID
+	BaseRef string
+	BaseRev string
+
+	// SearchResultPaths are the paths of the files in the repository that
+	// matched the `on` clauses of the CampaignSpec, which are available in
+	// the templates of the CampaignSpec.
+	SearchResultPaths []string
+
+	// ChangesetSpecIDs are the ChangesetSpecs created from the diff of the
+	// execution. This is empty until the execution completed, and contains
+	// more than one ID if the CampaignSpec groups changes with
+	// transformChanges.
+	ChangesetSpecIDs []int64
+
+	// CacheHit is true if the diff of the execution was taken from a
+	// CampaignStepCacheEntry instead of running the steps.
+	CacheHit bool
+
+	UserID int32
+
+	// All of the following fields are used by workerutil.Worker.
+	State          CampaignSpecExecutionState
+	FailureMessage *string
+	StartedAt      time.Time
+	FinishedAt     time.Time
+	ProcessAfter   time.Time
+	NumResets      int64
+	NumFailures    int64
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// RecordID is needed to implement the workerutil.Record interface.
+func (e *CampaignSpecExecution) RecordID() int { return int(e.ID) }
+
+// Clone returns a clone of a CampaignSpecExecution.
+func (e *CampaignSpecExecution) Clone() *CampaignSpecExecution {
+	ee := *e
+	ee.SearchResultPaths = e.SearchResultPaths[:len(e.SearchResultPaths):len(e.SearchResultPaths)]
+	ee.ChangesetSpecIDs = e.ChangesetSpecIDs[:len(e.ChangesetSpecIDs):len(e.ChangesetSpecIDs)]
+	return &ee
+}
+
+// CampaignStepCacheEntryTTL specifies how long a CampaignStepCacheEntry is
+// kept after it was last used.
+const CampaignStepCacheEntryTTL = 7 * 24 * time.Hour
+
+// A CampaignStepCacheEntry is the diff and the outputs that running a list of
+// steps produced in a repository at a given revision. Since the output of the
+// steps only depends on these inputs, later executions of the same steps on
+// the same revision can reuse them instead of running the steps again.
+type CampaignStepCacheEntry struct {
+	ID int64
+
+	RepoID    api.RepoID
+	BaseRev   string
+	StepsHash string
+
+	// Diff is empty if the steps didn't change anything.
+	Diff string
+	// Outputs are the outputs of the steps, keyed by their name.
+	Outputs map[string]interface{}
+
+	CreatedAt  time.Time
+	LastUsedAt time.Time
+}
+
+// Clone returns a clone of a CampaignStepCacheEntry.
+func (e *CampaignStepCacheEntry) Clone() *CampaignStepCacheEntry {
+	ee := *e
+	return &ee
+}
+
+// CampaignSpecStepsHash returns a hash of the given steps, including their
+// containers and environments, and of the repository metadata their templates
+// are evaluated with. It is used as part of the cache key of a
+// CampaignStepCacheEntry.
+func CampaignSpecStepsHash(steps []CampaignSpecStep, repo TemplateRepository) (string, error) {
+	// Executions loaded from the database have an empty instead of a nil
+	// list of paths, which must hash equally.
+	if repo.SearchResultPaths == nil {
+		repo.SearchResultPaths = []string{}
+	}
+
+	// encoding/json sorts map keys, so equal steps always result in the same
+	// hash.
+	b, err := json.Marshal(struct {
+		Steps      []CampaignSpecStep
+		Repository TemplateRepository
+	}{steps, repo})
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// ChangesetJobType defines the operations that can be applied to a Changeset
+// as part of a BulkOperation.
+type ChangesetJobType string
+
+// ChangesetJobType constants.
+const (
+	// ChangesetJobTypeComment posts a comment on the Changeset.
+	ChangesetJobTypeComment ChangesetJobType = "COMMENT"
+	// ChangesetJobTypeReenqueue re-enqueues a Changeset whose publication
+	// failed, so that the reconciler retries publishing it.
+	ChangesetJobTypeReenqueue ChangesetJobType = "REENQUEUE"
+	// ChangesetJobTypeMerge merges the Changeset on the code host.
+	ChangesetJobTypeMerge ChangesetJobType = "MERGE"
+	// ChangesetJobTypeClose closes the Changeset on the code host.
+	ChangesetJobTypeClose ChangesetJobType = "CLOSE"
+	// ChangesetJobTypeDetach removes the Changeset from the Campaign.
+	ChangesetJobTypeDetach ChangesetJobType = "DETACH"
+)
+
+// Valid returns true if the given ChangesetJobType is valid.
+func (t ChangesetJobType) Valid() bool {
+	switch t {
+	case ChangesetJobTypeComment,
+		ChangesetJobTypeReenqueue,
+		ChangesetJobTypeMerge,
+		ChangesetJobTypeClose,
+		ChangesetJobTypeDetach:
+		return true
+	default:
+		return false
+	}
+}
+
+// ChangesetJobState defines the possible states of a ChangesetJob.
+type ChangesetJobState string
+
+// ChangesetJobState constants.
+const (
+	ChangesetJobStateQueued     ChangesetJobState = "QUEUED"
+	ChangesetJobStateProcessing ChangesetJobState = "PROCESSING"
+	ChangesetJobStateErrored    ChangesetJobState = "ERRORED"
+	ChangesetJobStateCompleted  ChangesetJobState = "COMPLETED"
+)
+
+// ToDB returns the database representation of the job state, which needs to
+// be lowercase to work with workerutil.Worker.
+func (s ChangesetJobState) ToDB() string { return strings.ToLower(string(s)) }
+
+// ChangesetJobCommentPayload is the payload of a ChangesetJob of type
+// ChangesetJobTypeComment.
+type ChangesetJobCommentPayload struct {
+	Message string `json:"message"`
+}
+
+// A ChangesetJob applies a single operation of a BulkOperation to a single
+// Changeset of a Campaign. It's processed by a workerutil.Worker, so that a
+// failure to apply the operation to one Changeset doesn't affect the others.
+type ChangesetJob struct {
+	ID int64
+	// BulkGroup is the ID of the BulkOperation the job belongs to.
+	BulkGroup string
+
+	UserID      int32
+	CampaignID  int64
+	ChangesetID int64
+
+	JobType ChangesetJobType
+	// Payload depends on the JobType. It's a *ChangesetJobCommentPayload for
+	// ChangesetJobTypeComment and nil for all other types.
+	Payload interface{}
+
+	// All of the following fields are used by workerutil.Worker.
+	State          ChangesetJobState
+	FailureMessage *string
+	StartedAt      time.Time
+	FinishedAt     time.Time
+	ProcessAfter   time.Time
+	NumResets      int64
+	NumFailures    int64
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// RecordID is needed to implement the workerutil.Record interface.
+func (j *ChangesetJob) RecordID() int { return int(j.ID) }
+
+// Clone returns a clone of a ChangesetJob.
+func (j *ChangesetJob) Clone() *ChangesetJob {
+	jj := *j
+	return &jj
+}
+
+// BulkOperationState defines the possible states of a BulkOperation.
+type BulkOperationState string
+
+// BulkOperationState constants.
+const (
+	BulkOperationStateProcessing BulkOperationState = "PROCESSING"
+	BulkOperationStateFailed     BulkOperationState = "FAILED"
+	BulkOperationStateCompleted  BulkOperationState = "COMPLETED"
+)
+
+// A BulkOperation is the group of ChangesetJobs that were created together to
+// apply the same operation to a set of Changesets. It's not stored by itself
+// but aggregated from the ChangesetJobs with the same BulkGroup.
+type BulkOperation struct {
+	ID     string
+	Type   ChangesetJobType
+	State  BulkOperationState
+	UserID int32
+
+	CampaignID     int64
+	ChangesetCount int32
+	// Progress is the share of ChangesetJobs that have been processed, between
+	// 0 and 1.
+	Progress float64
+
+	CreatedAt  time.Time
+	FinishedAt time.Time
+}
+
+// A BulkOperationError is the failure to apply a BulkOperation to a single
+// Changeset.
+type BulkOperationError struct {
+	ChangesetID int64
+	Error       string
+}
+
+// CampaignSnapshotInterval specifies how often a CampaignSnapshot is taken of
+// each open campaign.
+const CampaignSnapshotInterval = 1 * time.Hour
+
+// CampaignSnapshotDownsampleAge specifies the age after which the
+// CampaignSnapshots of a campaign are downsampled to one per (UTC) day, so
+// that the number of stored snapshots of long-running campaigns stays bounded.
+const CampaignSnapshotDownsampleAge = 7 * 24 * time.Hour
+
+// A CampaignSnapshot records the state of the published Changesets of a
+// Campaign at a point in time. Snapshots are taken periodically, so that the
+// progress of a campaign can be reported without recomputing its history from
+// the ChangesetEvents.
+type CampaignSnapshot struct {
+	ID         int64
+	CampaignID int64
+
+	Total  int32
+	Open   int32
+	Merged int32
+	Closed int32
+	// Draft and FailedChecks are subsets of Open, as are the three review
+	// state counts below.
+	Draft                int32
+	FailedChecks         int32
+	OpenApproved         int32
+	OpenChangesRequested int32
+	OpenPending          int32
+
+	// MedianTimeToMerge is the median time between opening and merging of the
+	// merged Changesets. It's zero if no Changeset has been merged.
+	MedianTimeToMerge time.Duration
+
+	// RepoStallTimes contains an entry for each repository with open
+	// Changesets, sorted by descending StallTime.
+	RepoStallTimes []CampaignSnapshotRepoStallTime
+
+	CreatedAt time.Time
+}
+
+// Clone returns a clone of a CampaignSnapshot.
+func (s *CampaignSnapshot) Clone() *CampaignSnapshot {
+	ss := *s
+	ss.RepoStallTimes = s.RepoStallTimes[:len(s.RepoStallTimes):len(s.RepoStallTimes)]
+	return &ss
+}
+
+// CampaignSnapshotRepoStallTime is the time for which the least recently
+// active open Changeset in a repository has seen no activity.
+type CampaignSnapshotRepoStallTime struct {
+	RepoID    api.RepoID    `json:"repoID"`
+	StallTime time.Duration `json:"stallTime"`
+}
+
+// A CampaignCredential is a code host credential with which the changesets of
+// the campaigns in a namespace are pushed, created and updated, so that they
+// are authored by the owner of the credential instead of the account of the
+// external service.
+//
+// If neither NamespaceUserID nor NamespaceOrgID is set, it's a site-wide
+// credential that is used for namespaces without a credential of their own.
+type CampaignCredential struct {
+	ID int64
+
+	NamespaceUserID int32
+	NamespaceOrgID  int32
+
+	// ExternalServiceType and ExternalServiceID identify the code host, in the
+	// same way as the api.ExternalRepoSpec of its repositories.
+	ExternalServiceType string
+	ExternalServiceID   string
+
+	// Username is only required by Bitbucket Server, which needs it in
+	// addition to the token to push over HTTPS.
+	Username string
+	// Token is the decrypted personal access token. It's encrypted with the
+	// secrets package when it's stored in the database.
+	Token string
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// Clone returns a clone of a CampaignCredential.
+func (c *CampaignCredential) Clone() *CampaignCredential {
+	cc := *c
+	return &cc
+}
+
+// IsSiteCredential returns true if the CampaignCredential isn't bound to a
+// namespace.
+func (c *CampaignCredential) IsSiteCredential() bool {
+	return c.NamespaceUserID == 0 && c.NamespaceOrgID == 0
+}
+
 func NewChangesetSpecFromRaw(rawSpec string) (*ChangesetSpec, error) {
 	c := &ChangesetSpec{RawSpec: rawSpec}
 
@@ -1894,7 +2491,67 @@ type ChangesetSpecDescription struct {
 
 	Commits []GitCommitDescription `json:"commits,omitempty"`
 
-	Published bool `json:"published,omitempty"`
+	Published PublishedValue `json:"published,omitempty"`
+}
+
+// PublishedValue is the value of the `published` field of a changeset spec
+// or a changeset template: either a boolean or the string "draft".
+type PublishedValue struct {
+	Val interface{}
+}
+
+// True returns true if the changeset should be published.
+func (p PublishedValue) True() bool {
+	b, ok := p.Val.(bool)
+	return ok && b
+}
+
+// False returns true if the changeset should not be published. A missing
+// value is treated as false.
+func (p PublishedValue) False() bool {
+	b, ok := p.Val.(bool)
+	return p.Val == nil || (ok && !b)
+}
+
+// Draft returns true if the changeset should be published as a draft.
+func (p PublishedValue) Draft() bool {
+	s, ok := p.Val.(string)
+	return ok && s == "draft"
+}
+
+// Valid returns true if the value is a boolean, "draft" or missing.
+func (p PublishedValue) Valid() bool {
+	return p.True() || p.False() || p.Draft()
+}
+
+// PublicationState returns the ChangesetPublicationState a changeset with
+// this PublishedValue should end up in once it has been reconciled.
+func (p PublishedValue) PublicationState() ChangesetPublicationState {
+	switch {
+	case p.True():
+		return ChangesetPublicationStatePublished
+	case p.Draft():
+		return ChangesetPublicationStateDraft
+	default:
+		return ChangesetPublicationStateUnpublished
+	}
+}
+
+func (p PublishedValue) MarshalJSON() ([]byte, error) {
+	if p.Val == nil {
+		return []byte("false"), nil
+	}
+	return json.Marshal(p.Val)
+}
+
+func (p *PublishedValue) UnmarshalJSON(b []byte) error {
+	if err := json.Unmarshal(b, &p.Val); err != nil {
+		return err
+	}
+	if !p.Valid() {
+		return errors.Errorf("invalid value for published: %s", string(b))
+	}
+	return nil
 }
 
 // Type returns the ChangesetSpecDescriptionType of the ChangesetSpecDescription.
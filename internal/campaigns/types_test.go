@@ -310,6 +310,12 @@ func TestChangesetEvents(t *testing.T) {
 				Metadata: mr,
 			},
 			events: []*ChangesetEvent{
+				{
+					ChangesetID: 1234,
+					Kind:        ChangesetEventKindGitLabCommented,
+					Key:         notes[0].Key(),
+					Metadata:    notes[0],
+				},
 				{
 					ChangesetID: 1234,
 					Kind:        ChangesetEventKindGitLabApproved,
@@ -1004,6 +1010,45 @@ func TestChangesetSpecUnmarshalValidate(t *testing.T) {
 				}]
 			}`,
 		},
+		{
+			name: "draft GitBranchChangesetDescription",
+			rawSpec: `{
+				"baseRepository": "graphql-id",
+				"baseRef": "refs/heads/master",
+				"baseRev": "d34db33f",
+				"headRef": "refs/heads/my-branch",
+				"headRepository": "graphql-id",
+				"title": "my title",
+				"body": "my body",
+				"published": "draft",
+				"commits": [{
+				  "message": "commit message",
+				  "diff": "the diff",
+				  "authorName": "Mary McButtons",
+				  "authorEmail": "mary@example.com"
+				}]
+			}`,
+		},
+		{
+			name: "invalid published value in GitBranchChangesetDescription",
+			rawSpec: `{
+				"baseRepository": "graphql-id",
+				"baseRef": "refs/heads/master",
+				"baseRev": "d34db33f",
+				"headRef": "refs/heads/my-branch",
+				"headRepository": "graphql-id",
+				"title": "my title",
+				"body": "my body",
+				"published": "maybe",
+				"commits": [{
+				  "message": "commit message",
+				  "diff": "the diff",
+				  "authorName": "Mary McButtons",
+				  "authorEmail": "mary@example.com"
+				}]
+			}`,
+			err: "4 errors occurred:\n\t* Must validate one and only one schema (oneOf)\n\t* published: Must validate one and only one schema (oneOf)\n\t* published: published must be one of the following: \"draft\"\n\t* invalid value for published: \"maybe\"\n\n",
+		},
 		{
 			name: "missing fields in GitBranchChangesetDescription",
 			rawSpec: `{
@@ -1179,6 +1224,108 @@ changesetTemplate:
 			}`,
 			err: "1 error occurred:\n\t* name: Does not match pattern '^[\\w.-]+$'\n\n",
 		},
+		{
+			name: "valid with templates",
+			rawSpec: `
+name: my-unique-name
+on:
+- repositoriesMatchingQuery: file:README.md
+steps:
+- run: echo "${{ join repository.search_result_paths " " }}"
+  container: alpine
+  if: ${{ matches repository.name "github.com/sourcegraph/*" }}
+  outputs:
+    readmes:
+      value: ${{ step.stdout }}
+- run: echo ${{ outputs.readmes }}
+  container: alpine
+  if: false
+transformChanges:
+  group:
+  - directory: docs
+    branch: ${{ repository.branch }}-docs
+changesetTemplate:
+  title: Hello ${{ repository.name }}
+  branch: hello-world
+  commit:
+    message: Update ${{ outputs.readmes }}
+  published: false
+  overrides:
+  - repository: github.com/sourcegraph/*
+    title: Hello Sourcegraph
+    published: true
+`,
+		},
+		{
+			name: "invalid template",
+			rawSpec: `
+name: my-unique-name
+steps:
+- run: echo ${{ repository.name
+  container: alpine
+changesetTemplate:
+  title: Hello World
+  branch: hello-world
+  commit:
+    message: Hello
+  published: false
+`,
+			err: "1 error occurred:\n\t* template: steps[0].run:1: unclosed action\n\n",
+		},
+		{
+			name: "invalid output format",
+			rawSpec: `
+name: my-unique-name
+steps:
+- run: echo
+  container: alpine
+  outputs:
+    foo:
+      value: bar
+      format: xml
+changesetTemplate:
+  title: Hello World
+  branch: hello-world
+  commit:
+    message: Hello
+  published: false
+`,
+			err: "1 error occurred:\n\t* steps.0.outputs.foo.format: steps.0.outputs.foo.format must be one of the following: \"text\", \"json\", \"yaml\"\n\n",
+		},
+		{
+			name: "valid import by query",
+			rawSpec: `
+name: my-unique-name
+importChangesets:
+- repository: github.com/sourcegraph/sourcegraph
+  externalIDs: [1, "2"]
+- repository: github.com/sourcegraph/src-cli
+  query:
+    state: all
+    labels: [migration]
+    headRef: migrations/**
+`,
+		},
+		{
+			name: "import without external IDs or query",
+			rawSpec: `
+name: my-unique-name
+importChangesets:
+- repository: github.com/sourcegraph/sourcegraph
+`,
+			err: "2 errors occurred:\n\t* importChangesets.0: Must validate one and only one schema (oneOf)\n\t* importChangesets.0: externalIDs is required\n\n",
+		},
+		{
+			name: "invalid import head ref pattern",
+			rawSpec: `
+name: my-unique-name
+importChangesets:
+- repository: github.com/sourcegraph/sourcegraph
+  query:
+    headRef: "migrations/[a"
+`,
+			err: "1 error occurred:\n\t* importChangesets[0].query.headRef: unexpected end of input\n\n",
+		},
 	}
 
 	for _, tc := range tests {
@@ -1194,3 +1341,55 @@ changesetTemplate:
 		})
 	}
 }
+
+func TestCampaignSpecStepsHash(t *testing.T) {
+	steps := []CampaignSpecStep{
+		{Run: "echo hello", Container: "alpine:3", Env: map[string]string{"A": "1", "B": "2"}},
+		{Run: "gofmt -w .", Container: "golang:1.14"},
+	}
+
+	repo := TemplateRepository{Name: "github.com/sourcegraph/sourcegraph", Branch: "master"}
+
+	hash := func(steps []CampaignSpecStep) string {
+		h, err := CampaignSpecStepsHash(steps, repo)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return h
+	}
+
+	base := hash(steps)
+
+	// Equal steps with env maps built in a different order hash equally.
+	reordered := []CampaignSpecStep{
+		{Run: "echo hello", Container: "alpine:3", Env: map[string]string{"B": "2", "A": "1"}},
+		{Run: "gofmt -w .", Container: "golang:1.14"},
+	}
+	if have := hash(reordered); have != base {
+		t.Errorf("unexpected hash for equal steps. have=%s want=%s", have, base)
+	}
+
+	for name, changed := range map[string][]CampaignSpecStep{
+		"run":       {{Run: "echo bye", Container: "alpine:3", Env: map[string]string{"A": "1", "B": "2"}}, steps[1]},
+		"container": {{Run: "echo hello", Container: "alpine:4", Env: map[string]string{"A": "1", "B": "2"}}, steps[1]},
+		"env":       {{Run: "echo hello", Container: "alpine:3", Env: map[string]string{"A": "1"}}, steps[1]},
+		"order":     {steps[1], steps[0]},
+	} {
+		if hash(changed) == base {
+			t.Errorf("expected hash to change when %s changes", name)
+		}
+	}
+
+	// A nil list of search result paths is the same as an empty one.
+	repo.SearchResultPaths = []string{}
+	if have := hash(steps); have != base {
+		t.Errorf("unexpected hash for empty search result paths. have=%s want=%s", have, base)
+	}
+
+	// The templates of the steps are evaluated with the repository metadata,
+	// so it's part of the hash too.
+	repo.SearchResultPaths = []string{"README.md"}
+	if hash(steps) == base {
+		t.Errorf("expected hash to change when the repository metadata changes")
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/keegancsmith/sqlf"
 	"github.com/lib/pq"
 	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
 	"github.com/sourcegraph/sourcegraph/internal/db/dbconn"
 )
 
@@ -24,6 +25,13 @@ type AccessToken struct {
 	CreatorUserID int32
 	CreatedAt     time.Time
 	LastUsedAt    *time.Time
+	LastUsedIP    string     // the client IP address of the request that last used the access token
+	ExpiresAt     *time.Time // nil if the access token never expires
+}
+
+// Expired reports whether the access token has expired.
+func (t *AccessToken) Expired(now time.Time) bool {
+	return t.ExpiresAt != nil && !now.Before(*t.ExpiresAt)
 }
 
 // ErrAccessTokenNotFound occurs when a database operation expects a specific access token to exist
@@ -46,11 +54,13 @@ type accessTokens struct{}
 // space; also bcrypt is slow and would add noticeable latency to each request that supplied a
 // token.
 //
+// If expiresAt is nil, the access token never expires.
+//
 // 🚨 SECURITY: The caller must ensure that the actor is permitted to create tokens for the
 // specified user (i.e., that the actor is either the user or a site admin).
-func (s *accessTokens) Create(ctx context.Context, subjectUserID int32, scopes []string, note string, creatorUserID int32) (id int64, token string, err error) {
+func (s *accessTokens) Create(ctx context.Context, subjectUserID int32, scopes []string, note string, creatorUserID int32, expiresAt *time.Time) (id int64, token string, err error) {
 	if Mocks.AccessTokens.Create != nil {
-		return Mocks.AccessTokens.Create(subjectUserID, scopes, note, creatorUserID)
+		return Mocks.AccessTokens.Create(subjectUserID, scopes, note, creatorUserID, expiresAt)
 	}
 
 	var b [20]byte
@@ -76,26 +86,28 @@ creator_user AS (
   SELECT id FROM users WHERE id=$5 AND deleted_at IS NULL FOR UPDATE
 ),
 insert_values AS (
-  SELECT subject_user.id AS subject_user_id, $2::text[] AS scopes, $3::bytea AS value_sha256, $4::text AS note, creator_user.id AS creator_user_id
+  SELECT subject_user.id AS subject_user_id, $2::text[] AS scopes, $3::bytea AS value_sha256, $4::text AS note, creator_user.id AS creator_user_id, $6::timestamptz AS expires_at
   FROM subject_user, creator_user
 )
-INSERT INTO access_tokens(subject_user_id, scopes, value_sha256, note, creator_user_id) SELECT * FROM insert_values RETURNING id
+INSERT INTO access_tokens(subject_user_id, scopes, value_sha256, note, creator_user_id, expires_at) SELECT * FROM insert_values RETURNING id
 `,
-		subjectUserID, pq.Array(scopes), toSHA256Bytes(b[:]), note, creatorUserID,
+		subjectUserID, pq.Array(scopes), toSHA256Bytes(b[:]), note, creatorUserID, expiresAt,
 	).Scan(&id); err != nil {
 		return 0, "", err
 	}
 	return id, token, nil
 }
 
-// Lookup looks up the access token. If it's valid and contains the required scope, it returns the
-// subject's user ID. Otherwise ErrAccessTokenNotFound is returned.
+// Lookup looks up the access token. If it's valid and has a scope that grants the required scope
+// (see authz.ScopesGranting), it returns the subject's user ID. Otherwise ErrAccessTokenNotFound is
+// returned.
 //
-// Calling Lookup also updates the access token's last-used-at date.
+// Calling Lookup also updates the access token's last-used-at date and records remoteAddr as the
+// client IP address it was last used from.
 //
 // 🚨 SECURITY: This returns a user ID if and only if the tokenHexEncoded corresponds to a valid,
-// non-deleted access token.
-func (s *accessTokens) Lookup(ctx context.Context, tokenHexEncoded string, requiredScope string) (subjectUserID int32, err error) {
+// non-deleted, non-expired access token.
+func (s *accessTokens) Lookup(ctx context.Context, tokenHexEncoded, requiredScope, remoteAddr string) (subjectUserID int32, err error) {
 	if Mocks.AccessTokens.Lookup != nil {
 		return Mocks.AccessTokens.Lookup(tokenHexEncoded, requiredScope)
 	}
@@ -110,19 +122,20 @@ func (s *accessTokens) Lookup(ctx context.Context, tokenHexEncoded string, requi
 	}
 
 	if err := dbconn.Global.QueryRowContext(ctx,
-		// Ensure that subject and creator users still exist.
+		// Ensure that subject and creator users still exist and are not deactivated.
 		`
-UPDATE access_tokens t SET last_used_at=now()
+UPDATE access_tokens t SET last_used_at=now(), last_used_ip=$3
 WHERE t.id IN (
 	SELECT t2.id FROM access_tokens t2
-	JOIN users subject_user ON t2.subject_user_id=subject_user.id AND subject_user.deleted_at IS NULL
-	JOIN users creator_user ON t2.creator_user_id=creator_user.id AND creator_user.deleted_at IS NULL
+	JOIN users subject_user ON t2.subject_user_id=subject_user.id AND subject_user.deleted_at IS NULL AND subject_user.deactivated_at IS NULL
+	JOIN users creator_user ON t2.creator_user_id=creator_user.id AND creator_user.deleted_at IS NULL AND creator_user.deactivated_at IS NULL
 	WHERE t2.value_sha256=$1 AND t2.deleted_at IS NULL AND
-	$2 = ANY (t2.scopes)
+	(t2.expires_at IS NULL OR t2.expires_at > now()) AND
+	t2.scopes && $2::text[]
 )
 RETURNING t.subject_user_id
 `,
-		toSHA256Bytes(token), requiredScope,
+		toSHA256Bytes(token), pq.Array(authz.ScopesGranting(requiredScope)), remoteAddr,
 	).Scan(&subjectUserID); err != nil {
 		if err == sql.ErrNoRows {
 			return 0, ErrAccessTokenNotFound
@@ -182,7 +195,7 @@ func (s *accessTokens) List(ctx context.Context, opt AccessTokensListOptions) ([
 
 func (s *accessTokens) list(ctx context.Context, conds []*sqlf.Query, limitOffset *LimitOffset) ([]*AccessToken, error) {
 	q := sqlf.Sprintf(`
-SELECT id, subject_user_id, scopes, note, creator_user_id, created_at, last_used_at FROM access_tokens
+SELECT id, subject_user_id, scopes, note, creator_user_id, created_at, last_used_at, last_used_ip, expires_at FROM access_tokens
 WHERE (%s)
 ORDER BY now() - created_at < interval '5 minutes' DESC, -- show recently created tokens first
 last_used_at DESC NULLS FIRST, -- ensure newly created tokens show first
@@ -201,7 +214,7 @@ created_at DESC
 	var results []*AccessToken
 	for rows.Next() {
 		var t AccessToken
-		if err := rows.Scan(&t.ID, &t.SubjectUserID, pq.Array(&t.Scopes), &t.Note, &t.CreatorUserID, &t.CreatedAt, &t.LastUsedAt); err != nil {
+		if err := rows.Scan(&t.ID, &t.SubjectUserID, pq.Array(&t.Scopes), &t.Note, &t.CreatorUserID, &t.CreatedAt, &t.LastUsedAt, &t.LastUsedIP, &t.ExpiresAt); err != nil {
 			return nil, err
 		}
 		results = append(results, &t)
@@ -265,7 +278,7 @@ func toSHA256Bytes(input []byte) []byte {
 }
 
 type MockAccessTokens struct {
-	Create     func(subjectUserID int32, scopes []string, note string, creatorUserID int32) (id int64, token string, err error)
+	Create     func(subjectUserID int32, scopes []string, note string, creatorUserID int32, expiresAt *time.Time) (id int64, token string, err error)
 	DeleteByID func(id int64, subjectUserID int32) error
 	Lookup     func(tokenHexEncoded, requiredScope string) (subjectUserID int32, err error)
 	GetByID    func(id int64) (*AccessToken, error)
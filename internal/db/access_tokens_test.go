@@ -4,7 +4,9 @@ import (
 	"context"
 	"reflect"
 	"testing"
+	"time"
 
+	"github.com/sourcegraph/sourcegraph/internal/authz"
 	"github.com/sourcegraph/sourcegraph/internal/db/dbtesting"
 )
 
@@ -34,7 +36,7 @@ func TestAccessTokens_Create(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	tid0, tv0, err := AccessTokens.Create(ctx, subject.ID, []string{"a", "b"}, "n0", creator.ID)
+	tid0, tv0, err := AccessTokens.Create(ctx, subject.ID, []string{"a", "b"}, "n0", creator.ID, nil)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -53,7 +55,7 @@ func TestAccessTokens_Create(t *testing.T) {
 		t.Errorf("got %q, want %q", got.Note, want)
 	}
 
-	gotSubjectUserID, err := AccessTokens.Lookup(ctx, tv0, "a")
+	gotSubjectUserID, err := AccessTokens.Lookup(ctx, tv0, "a", "")
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -108,11 +110,11 @@ func TestAccessTokens_List(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	_, _, err = AccessTokens.Create(ctx, subject1.ID, []string{"a", "b"}, "n0", subject1.ID)
+	_, _, err = AccessTokens.Create(ctx, subject1.ID, []string{"a", "b"}, "n0", subject1.ID, nil)
 	if err != nil {
 		t.Fatal(err)
 	}
-	_, _, err = AccessTokens.Create(ctx, subject1.ID, []string{"a", "b"}, "n1", subject1.ID)
+	_, _, err = AccessTokens.Create(ctx, subject1.ID, []string{"a", "b"}, "n1", subject1.ID, nil)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -187,13 +189,13 @@ func TestAccessTokens_Lookup(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	tid0, tv0, err := AccessTokens.Create(ctx, subject.ID, []string{"a", "b"}, "n0", creator.ID)
+	tid0, tv0, err := AccessTokens.Create(ctx, subject.ID, []string{"a", "b"}, "n0", creator.ID, nil)
 	if err != nil {
 		t.Fatal(err)
 	}
 
 	for _, scope := range []string{"a", "b"} {
-		gotSubjectUserID, err := AccessTokens.Lookup(ctx, tv0, scope)
+		gotSubjectUserID, err := AccessTokens.Lookup(ctx, tv0, scope, "")
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -203,12 +205,12 @@ func TestAccessTokens_Lookup(t *testing.T) {
 	}
 
 	// Lookup with a nonexistent scope and ensure it fails.
-	if _, err := AccessTokens.Lookup(ctx, tv0, "x"); err == nil {
+	if _, err := AccessTokens.Lookup(ctx, tv0, "x", ""); err == nil {
 		t.Fatal(err)
 	}
 
 	// Lookup with an empty scope and ensure it fails.
-	if _, err := AccessTokens.Lookup(ctx, tv0, ""); err == nil {
+	if _, err := AccessTokens.Lookup(ctx, tv0, "", ""); err == nil {
 		t.Fatal(err)
 	}
 
@@ -216,16 +218,72 @@ func TestAccessTokens_Lookup(t *testing.T) {
 	if err := AccessTokens.DeleteByID(ctx, tid0, subject.ID); err != nil {
 		t.Fatal(err)
 	}
-	if _, err := AccessTokens.Lookup(ctx, tv0, "a"); err == nil {
+	if _, err := AccessTokens.Lookup(ctx, tv0, "a", ""); err == nil {
 		t.Fatal(err)
 	}
 
 	// Try to Lookup a token that was never created.
-	if _, err := AccessTokens.Lookup(ctx, "abcdefg" /* this token value was never created */, "a"); err == nil {
+	if _, err := AccessTokens.Lookup(ctx, "abcdefg" /* this token value was never created */, "a", ""); err == nil {
 		t.Fatal(err)
 	}
 }
 
+// 🚨 SECURITY: This tests that expired access tokens are rejected and that broader scopes grant the
+// narrower scopes they include.
+func TestAccessTokens_Lookup_expiryAndScopes(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	dbtesting.SetupGlobalTestDB(t)
+	ctx := context.Background()
+
+	subject, err := Users.Create(ctx, NewUser{
+		Email:                 "a@example.com",
+		Username:              "u1",
+		Password:              "p1",
+		EmailVerificationCode: "c1",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	past := time.Now().Add(-time.Minute)
+	_, expired, err := AccessTokens.Create(ctx, subject.ID, []string{authz.ScopeUserAll}, "expired", subject.ID, &past)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := AccessTokens.Lookup(ctx, expired, authz.ScopeUserAll, ""); err != ErrAccessTokenNotFound {
+		t.Fatalf("got err %v, want %v", err, ErrAccessTokenNotFound)
+	}
+
+	future := time.Now().Add(time.Hour)
+	tid, tv, err := AccessTokens.Create(ctx, subject.ID, []string{authz.ScopeCampaignsWrite}, "campaigns", subject.ID, &future)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, scope := range []string{authz.ScopeCampaignsWrite, authz.ScopeSearchRead} {
+		if _, err := AccessTokens.Lookup(ctx, tv, scope, "127.0.0.1"); err != nil {
+			t.Fatalf("scope %q: %s", scope, err)
+		}
+	}
+	for _, scope := range []string{authz.ScopeUserAll, authz.ScopeCodeIntelUpload, authz.ScopeSiteAdminSudo} {
+		if _, err := AccessTokens.Lookup(ctx, tv, scope, ""); err != ErrAccessTokenNotFound {
+			t.Fatalf("scope %q: got err %v, want %v", scope, err, ErrAccessTokenNotFound)
+		}
+	}
+
+	got, err := AccessTokens.GetByID(ctx, tid)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "127.0.0.1"; got.LastUsedIP != want {
+		t.Errorf("got last used IP %q, want %q", got.LastUsedIP, want)
+	}
+	if got.ExpiresAt == nil || got.Expired(time.Now()) {
+		t.Errorf("got expires at %v, want %v", got.ExpiresAt, future)
+	}
+}
+
 // 🚨 SECURITY: This tests that deleting the subject or creator user of an access token invalidates
 // the token, and that no new access tokens may be created for deleted users.
 func TestAccessTokens_Lookup_deletedUser(t *testing.T) {
@@ -255,18 +313,18 @@ func TestAccessTokens_Lookup_deletedUser(t *testing.T) {
 			t.Fatal(err)
 		}
 
-		_, tv0, err := AccessTokens.Create(ctx, subject.ID, []string{"a"}, "n0", creator.ID)
+		_, tv0, err := AccessTokens.Create(ctx, subject.ID, []string{"a"}, "n0", creator.ID, nil)
 		if err != nil {
 			t.Fatal(err)
 		}
 		if err := Users.Delete(ctx, subject.ID); err != nil {
 			t.Fatal(err)
 		}
-		if _, err := AccessTokens.Lookup(ctx, tv0, "a"); err == nil {
+		if _, err := AccessTokens.Lookup(ctx, tv0, "a", ""); err == nil {
 			t.Fatal("Lookup: want error looking up token for deleted subject user")
 		}
 
-		if _, _, err := AccessTokens.Create(ctx, subject.ID, nil, "n0", creator.ID); err == nil {
+		if _, _, err := AccessTokens.Create(ctx, subject.ID, nil, "n0", creator.ID, nil); err == nil {
 			t.Fatal("Create: want error creating token for deleted subject user")
 		}
 	})
@@ -291,18 +349,18 @@ func TestAccessTokens_Lookup_deletedUser(t *testing.T) {
 			t.Fatal(err)
 		}
 
-		_, tv0, err := AccessTokens.Create(ctx, subject.ID, []string{"a"}, "n0", creator.ID)
+		_, tv0, err := AccessTokens.Create(ctx, subject.ID, []string{"a"}, "n0", creator.ID, nil)
 		if err != nil {
 			t.Fatal(err)
 		}
 		if err := Users.Delete(ctx, creator.ID); err != nil {
 			t.Fatal(err)
 		}
-		if _, err := AccessTokens.Lookup(ctx, tv0, "a"); err == nil {
+		if _, err := AccessTokens.Lookup(ctx, tv0, "a", ""); err == nil {
 			t.Fatal("Lookup: want error looking up token for deleted creator user")
 		}
 
-		if _, _, err := AccessTokens.Create(ctx, subject.ID, nil, "n0", creator.ID); err == nil {
+		if _, _, err := AccessTokens.Create(ctx, subject.ID, nil, "n0", creator.ID, nil); err == nil {
 			t.Fatal("Create: want error creating token for deleted creator user")
 		}
 	})
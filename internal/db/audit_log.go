@@ -0,0 +1,161 @@
+package db
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/internal/db/dbconn"
+	"github.com/sourcegraph/sourcegraph/internal/db/dbutil"
+)
+
+// AuditLogEntry describes a security-relevant action recorded in the audit log. Unlike event
+// logs, audit log entries are never modified or deleted (which is enforced by a trigger on the
+// `audit_log` table).
+type AuditLogEntry struct {
+	ID          int64
+	Action      string
+	ActorUserID int32  // the user who performed the action (0 for internal or anonymous actors)
+	ActorIP     string // the client IP address of the request that performed the action
+	SubjectType string // the kind of resource the action was performed on (e.g. "ExternalService")
+	SubjectID   string // the ID of the resource the action was performed on
+	Before      json.RawMessage
+	After       json.RawMessage
+	CreatedAt   time.Time
+}
+
+// auditLog provides access to the `audit_log` table.
+type auditLog struct{}
+
+// Insert appends an entry to the audit log. The entry's ID and CreatedAt fields are ignored.
+func (*auditLog) Insert(ctx context.Context, e *AuditLogEntry) error {
+	if Mocks.AuditLog.Insert != nil {
+		return Mocks.AuditLog.Insert(ctx, e)
+	}
+
+	_, err := dbconn.Global.ExecContext(ctx,
+		`INSERT INTO audit_log(action, actor_user_id, actor_ip, subject_type, subject_id, before, after) VALUES($1, $2, $3, $4, $5, $6, $7)`,
+		e.Action,
+		nullInt32Column(e.ActorUserID),
+		e.ActorIP,
+		e.SubjectType,
+		e.SubjectID,
+		nullJSONColumn(e.Before),
+		nullJSONColumn(e.After),
+	)
+	if err != nil {
+		return errors.Wrap(err, "INSERT")
+	}
+	return nil
+}
+
+// AuditLogListOptions specifies the options for listing audit log entries.
+type AuditLogListOptions struct {
+	// Actions, if non-empty, only includes entries with one of the given actions.
+	Actions []string
+	// ActorUserID, if non-zero, only includes entries of actions performed by the given user.
+	ActorUserID int32
+	// SubjectType and SubjectID, if non-empty, only include entries of actions performed on the
+	// given resource.
+	SubjectType string
+	SubjectID   string
+	// Since and Until, if non-nil, only include entries created in the given time range.
+	Since *time.Time
+	Until *time.Time
+
+	*LimitOffset
+}
+
+func (o AuditLogListOptions) sqlConditions() []*sqlf.Query {
+	conds := []*sqlf.Query{sqlf.Sprintf("TRUE")}
+	if len(o.Actions) > 0 {
+		items := make([]*sqlf.Query, 0, len(o.Actions))
+		for _, action := range o.Actions {
+			items = append(items, sqlf.Sprintf("%s", action))
+		}
+		conds = append(conds, sqlf.Sprintf("action IN (%s)", sqlf.Join(items, ",")))
+	}
+	if o.ActorUserID != 0 {
+		conds = append(conds, sqlf.Sprintf("actor_user_id = %d", o.ActorUserID))
+	}
+	if o.SubjectType != "" {
+		conds = append(conds, sqlf.Sprintf("subject_type = %s", o.SubjectType))
+	}
+	if o.SubjectID != "" {
+		conds = append(conds, sqlf.Sprintf("subject_id = %s", o.SubjectID))
+	}
+	if o.Since != nil {
+		conds = append(conds, sqlf.Sprintf("created_at >= %s", *o.Since))
+	}
+	if o.Until != nil {
+		conds = append(conds, sqlf.Sprintf("created_at < %s", *o.Until))
+	}
+	return conds
+}
+
+// List returns the audit log entries matching the options, most recent first.
+//
+// 🚨 SECURITY: The caller must ensure that the actor is a site admin.
+func (*auditLog) List(ctx context.Context, opt AuditLogListOptions) ([]*AuditLogEntry, error) {
+	if Mocks.AuditLog.List != nil {
+		return Mocks.AuditLog.List(ctx, opt)
+	}
+
+	q := sqlf.Sprintf(`
+SELECT id, action, actor_user_id, actor_ip, subject_type, subject_id, before, after, created_at
+FROM audit_log
+WHERE (%s)
+ORDER BY created_at DESC, id DESC
+%s`, sqlf.Join(opt.sqlConditions(), ") AND ("), opt.LimitOffset.SQL())
+	rows, err := dbconn.Global.QueryContext(ctx, q.Query(sqlf.PostgresBindVar), q.Args()...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var entries []*AuditLogEntry
+	for rows.Next() {
+		var (
+			e             AuditLogEntry
+			before, after dbutil.NullJSONRawMessage
+		)
+		if err := rows.Scan(&e.ID, &e.Action, &dbutil.NullInt32{N: &e.ActorUserID}, &e.ActorIP, &e.SubjectType, &e.SubjectID, &before, &after, &e.CreatedAt); err != nil {
+			return nil, err
+		}
+		e.Before, e.After = before.Raw, after.Raw
+		entries = append(entries, &e)
+	}
+	return entries, rows.Err()
+}
+
+// Count counts the audit log entries matching the options (ignoring LimitOffset).
+//
+// 🚨 SECURITY: The caller must ensure that the actor is a site admin.
+func (*auditLog) Count(ctx context.Context, opt AuditLogListOptions) (int, error) {
+	if Mocks.AuditLog.Count != nil {
+		return Mocks.AuditLog.Count(ctx, opt)
+	}
+
+	q := sqlf.Sprintf("SELECT COUNT(*) FROM audit_log WHERE (%s)", sqlf.Join(opt.sqlConditions(), ") AND ("))
+	var count int
+	err := dbconn.Global.QueryRowContext(ctx, q.Query(sqlf.PostgresBindVar), q.Args()...).Scan(&count)
+	return count, err
+}
+
+// nullInt32Column returns nil for the zero value, so that it is stored as NULL.
+func nullInt32Column(n int32) interface{} {
+	if n == 0 {
+		return nil
+	}
+	return n
+}
+
+// nullJSONColumn returns nil for an empty JSON value, so that it is stored as NULL.
+func nullJSONColumn(raw json.RawMessage) interface{} {
+	if len(raw) == 0 {
+		return nil
+	}
+	return string(raw)
+}
@@ -0,0 +1,9 @@
+package db
+
+import "context"
+
+type MockAuditLog struct {
+	Insert func(ctx context.Context, e *AuditLogEntry) error
+	List   func(ctx context.Context, opt AuditLogListOptions) ([]*AuditLogEntry, error)
+	Count  func(ctx context.Context, opt AuditLogListOptions) (int, error)
+}
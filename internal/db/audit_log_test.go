@@ -0,0 +1,84 @@
+package db
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/internal/db/dbconn"
+	"github.com/sourcegraph/sourcegraph/internal/db/dbtesting"
+)
+
+func TestAuditLog(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	dbtesting.SetupGlobalTestDB(t)
+	ctx := context.Background()
+
+	entries := []*AuditLogEntry{
+		{Action: "SiteConfigUpdated", ActorUserID: 1, ActorIP: "10.0.0.1", Before: json.RawMessage(`{"a":1}`), After: json.RawMessage(`{"a":2}`)},
+		{Action: "ExternalServiceDeleted", ActorUserID: 2, SubjectType: "ExternalService", SubjectID: "3", Before: json.RawMessage(`{"kind":"GITHUB"}`)},
+		{Action: "AccessTokenCreated", SubjectType: "User", SubjectID: "2"},
+	}
+	for _, e := range entries {
+		if err := AuditLog.Insert(ctx, e); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	all, err := AuditLog.List(ctx, AuditLogListOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(all) != 3 {
+		t.Fatalf("got %d entries, want 3", len(all))
+	}
+	if got := all[2]; got.Action != "SiteConfigUpdated" || got.ActorUserID != 1 || got.ActorIP != "10.0.0.1" || string(got.After) != `{"a": 2}` {
+		t.Errorf("got oldest entry %+v", got)
+	}
+	if got := all[0]; got.ActorUserID != 0 || got.Before != nil || got.After != nil {
+		t.Errorf("got newest entry %+v, want no actor and no before/after", got)
+	}
+
+	hourAgo := time.Now().Add(-time.Hour)
+	for name, tc := range map[string]struct {
+		opt  AuditLogListOptions
+		want int
+	}{
+		"actions":  {opt: AuditLogListOptions{Actions: []string{"SiteConfigUpdated", "AccessTokenCreated"}}, want: 2},
+		"actor":    {opt: AuditLogListOptions{ActorUserID: 2}, want: 1},
+		"subject":  {opt: AuditLogListOptions{SubjectType: "ExternalService", SubjectID: "3"}, want: 1},
+		"until":    {opt: AuditLogListOptions{Until: &hourAgo}, want: 0},
+		"since":    {opt: AuditLogListOptions{Since: &hourAgo}, want: 3},
+		"paginate": {opt: AuditLogListOptions{LimitOffset: &LimitOffset{Limit: 1, Offset: 2}}, want: 1},
+	} {
+		t.Run(name, func(t *testing.T) {
+			got, err := AuditLog.List(ctx, tc.opt)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(got) != tc.want {
+				t.Errorf("got %d entries, want %d", len(got), tc.want)
+			}
+			if tc.opt.LimitOffset == nil {
+				count, err := AuditLog.Count(ctx, tc.opt)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if count != tc.want {
+					t.Errorf("got count %d, want %d", count, tc.want)
+				}
+			}
+		})
+	}
+
+	// The audit log is append-only.
+	if _, err := dbconn.Global.ExecContext(ctx, "UPDATE audit_log SET action='x'"); err == nil {
+		t.Error("got no error updating audit log entries")
+	}
+	if _, err := dbconn.Global.ExecContext(ctx, "DELETE FROM audit_log"); err == nil {
+		t.Error("got no error deleting audit log entries")
+	}
+}
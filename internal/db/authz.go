@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/authz"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
 )
@@ -42,6 +43,15 @@ type RevokeUserPermissionsArgs struct {
 	Accounts []*extsvc.Accounts
 }
 
+// SubRepoPermissionsArgs contains required arguments to retrieve the path rules that restrict what
+// a user can read within a repository.
+type SubRepoPermissionsArgs struct {
+	// The user whose path rules are being retrieved.
+	UserID int32
+	// The repository the path rules apply to.
+	RepoID api.RepoID
+}
+
 // AuthzStore contains methods for manipulating user permissions.
 type AuthzStore interface {
 	// GrantPendingPermissions grants pending permissions for a user. It is a no-op in the OSS version.
@@ -53,6 +63,13 @@ type AuthzStore interface {
 	// RevokeUserPermissions deletes both effective and pending permissions that could be related to a user.
 	// It is a no-op in the OSS version.
 	RevokeUserPermissions(ctx context.Context, args *RevokeUserPermissionsArgs) error
+	// SubRepoPermissions returns the path rules that restrict what a user can read within a
+	// repository, or nil if the user can read all paths of the repository.
+	// It is a no-op in the OSS version.
+	SubRepoPermissions(ctx context.Context, args *SubRepoPermissionsArgs) (*authz.SubRepoPermissions, error)
+	// RepoHasSubRepoPermissions returns true if any user has path rules for the repository.
+	// It is a no-op in the OSS version.
+	RepoHasSubRepoPermissions(ctx context.Context, repoID api.RepoID) (bool, error)
 }
 
 // authzStore is a no-op placeholder for the OSS version.
@@ -78,3 +95,17 @@ func (*authzStore) RevokeUserPermissions(ctx context.Context, args *RevokeUserPe
 	}
 	return nil
 }
+
+func (*authzStore) SubRepoPermissions(ctx context.Context, args *SubRepoPermissionsArgs) (*authz.SubRepoPermissions, error) {
+	if Mocks.Authz.SubRepoPermissions != nil {
+		return Mocks.Authz.SubRepoPermissions(ctx, args)
+	}
+	return nil, nil
+}
+
+func (*authzStore) RepoHasSubRepoPermissions(ctx context.Context, repoID api.RepoID) (bool, error) {
+	if Mocks.Authz.RepoHasSubRepoPermissions != nil {
+		return Mocks.Authz.RepoHasSubRepoPermissions(ctx, repoID)
+	}
+	return false, nil
+}
@@ -33,6 +33,7 @@ type ExternalServicesStore struct {
 	GitHubValidators          []func(*schema.GitHubConnection) error
 	GitLabValidators          []func(*schema.GitLabConnection, []schema.AuthProviders) error
 	BitbucketServerValidators []func(*schema.BitbucketServerConnection) error
+	BitbucketCloudValidators  []func(*schema.BitbucketCloudConnection) error
 }
 
 // ExternalServiceKinds contains a map of all supported kinds of
@@ -277,7 +278,14 @@ func (e *ExternalServicesStore) validateBitbucketServerConnection(ctx context.Co
 }
 
 func (e *ExternalServicesStore) validateBitbucketCloudConnection(ctx context.Context, id int64, c *schema.BitbucketCloudConnection) error {
-	return e.validateDuplicateRateLimits(ctx, id, extsvc.KindBitbucketCloud, c)
+	err := new(multierror.Error)
+	for _, validate := range e.BitbucketCloudValidators {
+		err = multierror.Append(err, validate(c))
+	}
+
+	err = multierror.Append(err, e.validateDuplicateRateLimits(ctx, id, extsvc.KindBitbucketCloud, c))
+
+	return err.ErrorOrNil()
 }
 
 // validateDuplicateRateLimits returns an error if given config has duplicated non-default rate limit
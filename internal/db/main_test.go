@@ -0,0 +1,23 @@
+package db
+
+import (
+	"flag"
+	"os"
+	"testing"
+
+	"github.com/inconshreveable/log15"
+
+	encryption "github.com/sourcegraph/sourcegraph/internal/secrets"
+)
+
+func TestMain(m *testing.M) {
+	flag.Parse()
+	if !testing.Verbose() {
+		log15.Root().SetHandler(log15.DiscardHandler())
+	}
+	if err := encryption.Init(); err != nil {
+		log15.Crit("initializing secrets", "error", err)
+		os.Exit(1)
+	}
+	os.Exit(m.Run())
+}
@@ -14,6 +14,12 @@ type MockStores struct {
 	Users         MockUsers
 	UserEmails    MockUserEmails
 
+	UserTwoFactorAuth MockUserTwoFactorAuth
+
+	UserSessions MockUserSessions
+
+	AuditLog MockAuditLog
+
 	Phabricator MockPhabricator
 
 	ExternalAccounts MockExternalAccounts
@@ -8,7 +8,11 @@ import (
 )
 
 type MockOrgMembers struct {
+	Create              func(ctx context.Context, orgID, userID int32) (*types.OrgMembership, error)
+	GetByUserID         func(ctx context.Context, userID int32) ([]*types.OrgMembership, error)
 	GetByOrgIDAndUserID func(ctx context.Context, orgID, userID int32) (*types.OrgMembership, error)
+	Remove              func(ctx context.Context, orgID, userID int32) error
+	GetByOrgID          func(ctx context.Context, orgID int32) ([]*types.OrgMembership, error)
 }
 
 func (s *MockOrgMembers) MockGetByOrgIDAndUserID_Return(t *testing.T, returns *types.OrgMembership, returnsErr error) (called *bool) {
@@ -152,6 +152,10 @@ func (*orgs) getBySQL(ctx context.Context, query string, args ...interface{}) ([
 }
 
 func (*orgs) Create(ctx context.Context, name string, displayName *string) (*types.Org, error) {
+	if Mocks.Orgs.Create != nil {
+		return Mocks.Orgs.Create(ctx, name, displayName)
+	}
+
 	tx, err := dbconn.Global.BeginTx(ctx, nil)
 	if err != nil {
 		return nil, err
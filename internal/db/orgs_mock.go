@@ -10,6 +10,7 @@ import (
 type MockOrgs struct {
 	GetByID   func(ctx context.Context, id int32) (*types.Org, error)
 	GetByName func(ctx context.Context, name string) (*types.Org, error)
+	Create    func(ctx context.Context, name string, displayName *string) (*types.Org, error)
 	Count     func(ctx context.Context, opt OrgsListOptions) (int, error)
 	List      func(ctx context.Context, opt *OrgsListOptions) ([]*types.Org, error)
 }
@@ -8,6 +8,7 @@ import (
 	"strings"
 
 	"github.com/keegancsmith/sqlf"
+	"github.com/lib/pq"
 	"github.com/pkg/errors"
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
 	"github.com/sourcegraph/sourcegraph/internal/api"
@@ -399,6 +400,47 @@ func (s *repos) ListEnabledNames(ctx context.Context) ([]string, error) {
 	return names, nil
 }
 
+// ListIDsByExternalServices returns the IDs of the given repositories that belong to any of the
+// given external services.
+//
+// 🚨 SECURITY: It does not enforce repository permissions, and must only be used by code that
+// does the permission checks itself.
+func (s *repos) ListIDsByExternalServices(ctx context.Context, externalServiceIDs []int64, ids ...api.RepoID) ([]api.RepoID, error) {
+	if Mocks.Repos.ListIDsByExternalServices != nil {
+		return Mocks.Repos.ListIDsByExternalServices(ctx, externalServiceIDs, ids...)
+	}
+
+	if len(externalServiceIDs) == 0 || len(ids) == 0 {
+		return []api.RepoID{}, nil
+	}
+
+	repoIDs := make([]int32, len(ids))
+	for i := range ids {
+		repoIDs[i] = int32(ids[i])
+	}
+
+	q := sqlf.Sprintf(`
+SELECT DISTINCT repo_id FROM external_service_repos
+WHERE external_service_id = ANY (%s)
+AND repo_id = ANY (%s)
+`, pq.Array(externalServiceIDs), pq.Array(repoIDs))
+	rows, err := dbconn.Global.QueryContext(ctx, q.Query(sqlf.PostgresBindVar), q.Args()...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	results := make([]api.RepoID, 0, len(ids))
+	for rows.Next() {
+		var id api.RepoID
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		results = append(results, id)
+	}
+	return results, rows.Err()
+}
+
 func parsePattern(p string) ([]*sqlf.Query, error) {
 	exact, like, pattern, err := parseIncludePattern(p)
 	if err != nil {
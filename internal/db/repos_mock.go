@@ -15,6 +15,8 @@ type MockRepos struct {
 	GetByIDs  func(ctx context.Context, ids ...api.RepoID) ([]*types.Repo, error)
 	List      func(v0 context.Context, v1 ReposListOptions) ([]*types.Repo, error)
 	Count     func(ctx context.Context, opt ReposListOptions) (int, error)
+
+	ListIDsByExternalServices func(ctx context.Context, externalServiceIDs []int64, ids ...api.RepoID) ([]api.RepoID, error)
 }
 
 func (s *MockRepos) MockGet(t *testing.T, wantRepo api.RepoID) (called *bool) {
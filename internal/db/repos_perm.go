@@ -15,6 +15,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/globals"
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
 	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/authz"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
 	"github.com/sourcegraph/sourcegraph/internal/trace"
@@ -39,7 +40,12 @@ var MockAuthzFilter func(ctx context.Context, repos []*types.Repo, p authz.Perms
 //
 // The enforcement policy:
 //
-// - If permissions user mapping is enabled, directly check permissions against local Postgres.
+// - If permissions user mapping is enabled for all repositories, directly check permissions against
+//   local Postgres.
+//
+// - If permissions user mapping is enabled for selected external services, repositories of those
+//   external services are checked against local Postgres regardless of whether they are private,
+//   and the remaining repositories are checked by the rules below.
 //
 // - If there are no authz providers and `authzAllowByDefault` is true, then the repository is
 //   accessible to everyone.
@@ -108,7 +114,8 @@ func authzFilter(ctx context.Context, repos []*types.Repo, p authz.Perms) (filte
 
 	// 🚨 SECURITY: Blocking access to all repositories if both code host authz provider(s) and permissions user mapping
 	// are configured.
-	if globals.PermissionsUserMapping().Enabled {
+	userMapping := globals.PermissionsUserMapping()
+	if userMapping.Enabled && len(userMapping.ExternalServices) == 0 {
 		if len(authzProviders) > 0 {
 			return nil, errors.New("The permissions user mapping (site configuration `permissions.userMapping`) cannot be enabled when other authorization providers are in use, please contact site admin to resolve it.")
 		} else if currentUser == nil {
@@ -131,6 +138,26 @@ func authzFilter(ctx context.Context, repos []*types.Repo, p authz.Perms) (filte
 		return repos, nil
 	}
 
+	// 🚨 SECURITY: Repositories of the external services selected by the permissions user mapping
+	// are only accessible with explicit permissions, the remaining ones are checked as usual.
+	if userMapping.Enabled && len(userMapping.ExternalServices) > 0 {
+		var explicit []*types.Repo
+		explicit, repos, err = authzFilterExplicit(ctx, repos, currentUser, p, userMapping.ExternalServices)
+		if err != nil {
+			return nil, errors.Wrap(err, "authorize repositories with explicit permissions")
+		}
+		if len(explicit) > 0 {
+			defer func() {
+				if err == nil {
+					filtered = append(filtered, explicit...)
+				}
+			}()
+		}
+		if len(repos) == 0 {
+			return repos, nil
+		}
+	}
+
 	// Permissions are not enforced by authz providers and everyone can see all repositories.
 	if authzAllowByDefault && len(authzProviders) == 0 {
 		return repos, nil
@@ -256,6 +283,59 @@ func authzFilter(ctx context.Context, repos []*types.Repo, p authz.Perms) (filte
 	return append(filtered, verified...), nil
 }
 
+// authzFilterExplicit splits repos into those that belong to any of the given external services,
+// whose permissions are exclusively managed via the explicit permissions API, and the rest. It
+// returns the former ones the user is authorized to access, and the rest.
+func authzFilterExplicit(ctx context.Context, repos []*types.Repo, currentUser *types.User, p authz.Perms, externalServiceIDs []int) (authorized, rest []*types.Repo, err error) {
+	serviceIDs := make([]int64, len(externalServiceIDs))
+	for i := range externalServiceIDs {
+		serviceIDs[i] = int64(externalServiceIDs[i])
+	}
+	repoIDs := make([]api.RepoID, len(repos))
+	for i := range repos {
+		repoIDs[i] = repos[i].ID
+	}
+
+	explicitIDs, err := Repos.ListIDsByExternalServices(ctx, serviceIDs, repoIDs...)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(explicitIDs) == 0 {
+		return nil, repos, nil
+	}
+
+	isExplicit := make(map[api.RepoID]struct{}, len(explicitIDs))
+	for _, id := range explicitIDs {
+		isExplicit[id] = struct{}{}
+	}
+
+	explicit := make([]*types.Repo, 0, len(explicitIDs))
+	rest = make([]*types.Repo, 0, len(repos)-len(explicitIDs))
+	for _, r := range repos {
+		if _, ok := isExplicit[r.ID]; ok {
+			explicit = append(explicit, r)
+		} else {
+			rest = append(rest, r)
+		}
+	}
+
+	// Anonymous users are never granted explicit permissions.
+	if currentUser == nil {
+		return nil, rest, nil
+	}
+
+	authorized, err = Authz.AuthorizedRepos(ctx, &AuthorizedReposArgs{
+		Repos:  explicit,
+		UserID: currentUser.ID,
+		Perm:   p,
+		Type:   authz.PermRepos,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return authorized, rest, nil
+}
+
 // isInternalActor returns true if the actor represents an internal agent (i.e., non-user-bound
 // request that originates from within Sourcegraph itself).
 //
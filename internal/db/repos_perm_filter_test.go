@@ -101,6 +101,81 @@ func Test_authzFilter_permissionsUserMapping(t *testing.T) {
 	})
 }
 
+func Test_authzFilter_permissionsUserMappingExternalServices(t *testing.T) {
+	before := globals.PermissionsUserMapping()
+	globals.SetPermissionsUserMapping(&schema.PermissionsUserMapping{Enabled: true, ExternalServices: []int{1}})
+	defer globals.SetPermissionsUserMapping(before)
+	defer func() { Mocks = MockStores{} }()
+
+	// Repositories of external service 1 are only accessible with explicit permissions,
+	// even when they are public.
+	publicGitoliteRepo := makeRepo("gitolite.mine/public", 1, false)
+	privateGitoliteRepo := makeRepo("gitolite.mine/private", 2, true)
+	publicGitLabRepo := makeRepo("gitlab.mine/user/public", 3, false)
+	privateGitLabRepo := makeRepo("gitlab.mine/user/private", 4, true)
+
+	Mocks.Repos.ListIDsByExternalServices = func(_ context.Context, externalServiceIDs []int64, ids ...api.RepoID) ([]api.RepoID, error) {
+		if diff := cmp.Diff([]int64{1}, externalServiceIDs); diff != "" {
+			return nil, fmt.Errorf("externalServiceIDs mismatch (-want +got):\n%s", diff)
+		}
+		return []api.RepoID{1, 2}, nil
+	}
+	Mocks.Authz.AuthorizedRepos = func(_ context.Context, args *AuthorizedReposArgs) ([]*types.Repo, error) {
+		var authorized []*types.Repo
+		for _, r := range args.Repos {
+			if r.ID == privateGitoliteRepo.ID {
+				authorized = append(authorized, r)
+			}
+		}
+		return authorized, nil
+	}
+
+	// Code host authz providers do not conflict with permissions user mapping for selected
+	// external services.
+	authz.SetProviders(false,
+		[]authz.Provider{
+			&MockAuthzProvider{
+				serviceID:   "https://gitlab.mine/",
+				serviceType: extsvc.TypeGitLab,
+			},
+		},
+	)
+	defer authz.SetProviders(true, nil)
+
+	t.Run("unauthenticated user", func(t *testing.T) {
+		repos, err := authzFilter(context.Background(), []*types.Repo{publicGitoliteRepo, privateGitoliteRepo, publicGitLabRepo, privateGitLabRepo}, authz.Read)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		wantRepos := []*types.Repo{publicGitLabRepo}
+		if diff := cmp.Diff(wantRepos, repos); diff != "" {
+			t.Fatal(diff)
+		}
+	})
+
+	t.Run("authenticated user", func(t *testing.T) {
+		user := &types.User{ID: 1}
+		Mocks.Users.GetByCurrentAuthUser = func(context.Context) (*types.User, error) {
+			return user, nil
+		}
+		Mocks.ExternalAccounts.List = func(ExternalAccountsListOptions) ([]*extsvc.Account, error) {
+			return nil, nil
+		}
+		ctx := actor.WithActor(context.Background(), &actor.Actor{UID: user.ID})
+
+		repos, err := authzFilter(ctx, []*types.Repo{publicGitoliteRepo, privateGitoliteRepo, publicGitLabRepo, privateGitLabRepo}, authz.Read)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		wantRepos := []*types.Repo{publicGitLabRepo, privateGitoliteRepo}
+		if diff := cmp.Diff(wantRepos, repos); diff != "" {
+			t.Fatal(diff)
+		}
+	})
+}
+
 func Test_authzFilter(t *testing.T) {
 	publicGitLabRepo := makeRepo("gitlab.mine/user/public", 1, false)
 	privateGitLabRepo := makeRepo("gitlab.mine/user/private", 2, true)
@@ -0,0 +1,85 @@
+package db
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+)
+
+var MockSubRepoPermsForActor func(ctx context.Context, repoID api.RepoID) (*authz.SubRepoPermsMatcher, error)
+
+// SubRepoPermsForActor is the enforcement mechanism for path-level permissions within a
+// repository. It returns the matcher that decides which paths of the given repository the
+// current actor may read. Callers must have checked repository-level permissions already.
+//
+// A nil matcher, which allows reading every path, is returned when:
+//
+// - The actor is internal.
+//
+// - No user has path rules for the repository.
+//
+// - The user is a site admin.
+//
+// Once any user has path rules for a repository, unauthenticated actors and users without
+// path rules for it can't read any of its paths.
+func SubRepoPermsForActor(ctx context.Context, repoID api.RepoID) (*authz.SubRepoPermsMatcher, error) {
+	if MockSubRepoPermsForActor != nil {
+		return MockSubRepoPermsForActor(ctx, repoID)
+	}
+
+	if isInternalActor(ctx) {
+		return nil, nil
+	}
+
+	a := actor.FromContext(ctx)
+	if !a.IsAuthenticated() {
+		// 🚨 SECURITY: Path rules only exist for users, so there is no rule that would let
+		// anonymous users read the paths that are hidden from some users.
+		restricted, err := Authz.RepoHasSubRepoPermissions(ctx, repoID)
+		if err != nil {
+			return nil, errors.Wrap(err, "check for sub-repo permissions")
+		}
+		if restricted {
+			return authz.NewDenyAllSubRepoPermsMatcher(), nil
+		}
+		return nil, nil
+	}
+
+	p, err := Authz.SubRepoPermissions(ctx, &SubRepoPermissionsArgs{
+		UserID: a.UID,
+		RepoID: repoID,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "load sub-repo permissions")
+	}
+	if p == nil {
+		// 🚨 SECURITY: Paths are denied by default once the repository has path rules,
+		// so that users whose rules weren't synced yet can't read the hidden paths.
+		restricted, err := Authz.RepoHasSubRepoPermissions(ctx, repoID)
+		if err != nil {
+			return nil, errors.Wrap(err, "check for sub-repo permissions")
+		}
+		if !restricted {
+			return nil, nil
+		}
+	}
+
+	// Checking for site admins only for repositories with path rules avoids an
+	// additional query for the common case of repositories without any.
+	currentUser, err := Users.GetByCurrentAuthUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if currentUser.SiteAdmin {
+		return nil, nil
+	}
+
+	if p == nil {
+		return authz.NewDenyAllSubRepoPermsMatcher(), nil
+	}
+	return authz.NewSubRepoPermsMatcher(p)
+}
@@ -0,0 +1,115 @@
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+)
+
+func TestSubRepoPermsForActor(t *testing.T) {
+	defer func() { Mocks = MockStores{} }()
+
+	Mocks.Authz.SubRepoPermissions = func(ctx context.Context, args *SubRepoPermissionsArgs) (*authz.SubRepoPermissions, error) {
+		// User 2 has no path rules for repository 42.
+		if args.UserID != 1 || args.RepoID != 42 {
+			return nil, nil
+		}
+		return &authz.SubRepoPermissions{
+			UserID:       args.UserID,
+			RepoID:       int32(args.RepoID),
+			PathExcludes: []string{"secrets/**"},
+		}, nil
+	}
+
+	Mocks.Authz.RepoHasSubRepoPermissions = func(ctx context.Context, repoID api.RepoID) (bool, error) {
+		return repoID == 42, nil
+	}
+
+	siteAdmin := false
+	Mocks.Users.GetByCurrentAuthUser = func(ctx context.Context) (*types.User, error) {
+		return &types.User{ID: actor.FromContext(ctx).UID, SiteAdmin: siteAdmin}, nil
+	}
+
+	for _, tc := range []struct {
+		name       string
+		ctx        context.Context
+		siteAdmin  bool
+		repoID     api.RepoID
+		wantAccess bool
+		// wantDenyAll is true if no path of the repository is readable.
+		wantDenyAll bool
+	}{
+		{
+			name:       "internal actor",
+			ctx:        actor.WithActor(context.Background(), &actor.Actor{Internal: true}),
+			repoID:     42,
+			wantAccess: true,
+		},
+		{
+			name:        "anonymous user",
+			ctx:         context.Background(),
+			repoID:      42,
+			wantAccess:  false,
+			wantDenyAll: true,
+		},
+		{
+			name:       "anonymous user for a repository without path rules",
+			ctx:        context.Background(),
+			repoID:     43,
+			wantAccess: true,
+		},
+		{
+			name:       "user without path rules for the repository",
+			ctx:        actor.WithActor(context.Background(), &actor.Actor{UID: 1}),
+			repoID:     43,
+			wantAccess: true,
+		},
+		{
+			name:       "user with path rules for the repository",
+			ctx:        actor.WithActor(context.Background(), &actor.Actor{UID: 1}),
+			repoID:     42,
+			wantAccess: false,
+		},
+		{
+			name:        "user without path rules for a repository with path rules",
+			ctx:         actor.WithActor(context.Background(), &actor.Actor{UID: 2}),
+			repoID:      42,
+			wantAccess:  false,
+			wantDenyAll: true,
+		},
+		{
+			name:       "site admin without path rules for a repository with path rules",
+			ctx:        actor.WithActor(context.Background(), &actor.Actor{UID: 2}),
+			siteAdmin:  true,
+			repoID:     42,
+			wantAccess: true,
+		},
+		{
+			name:       "site admin with path rules for the repository",
+			ctx:        actor.WithActor(context.Background(), &actor.Actor{UID: 1}),
+			siteAdmin:  true,
+			repoID:     42,
+			wantAccess: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			siteAdmin = tc.siteAdmin
+
+			m, err := SubRepoPermsForActor(tc.ctx, tc.repoID)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if have := m.CanReadFile("secrets/token"); have != tc.wantAccess {
+				t.Fatalf("CanReadFile: have %t, want %t", have, tc.wantAccess)
+			}
+			if have := m.CanReadFile("README.md"); have == tc.wantDenyAll {
+				t.Fatalf("CanReadFile(README.md): have %t, want %t", have, !tc.wantDenyAll)
+			}
+		})
+	}
+}
@@ -15,10 +15,16 @@ var (
 	UserEmails       = &userEmails{}
 	EventLogs        = &eventLogs{}
 
+	AuditLog = &auditLog{}
+
 	SurveyResponses = &surveyResponses{}
 
 	ExternalAccounts = &userExternalAccounts{}
 
+	UserTwoFactorAuth = &userTwoFactorAuth{}
+
+	UserSessions = &userSessions{}
+
 	OrgInvitations = &orgInvitations{}
 
 	Authz AuthzStore = &authzStore{}
@@ -92,6 +92,10 @@ func (*userEmails) Get(ctx context.Context, userID int32, email string) (emailCa
 
 // Add adds new user email. When added, it is always unverified.
 func (*userEmails) Add(ctx context.Context, userID int32, email string, verificationCode *string) error {
+	if Mocks.UserEmails.Add != nil {
+		return Mocks.UserEmails.Add(userID, email, verificationCode)
+	}
+
 	_, err := dbconn.Global.ExecContext(ctx, "INSERT INTO user_emails(user_id, email, verification_code) VALUES($1, $2, $3)", userID, email, verificationCode)
 	return err
 }
@@ -0,0 +1,154 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/internal/db/dbconn"
+)
+
+// UserSession describes a browser session of a user. The session data itself is held by the
+// session store; a row in the `user_sessions` table exists for as long as the session is valid, so
+// that users can list and revoke their sessions.
+type UserSession struct {
+	ID           int64
+	UserID       int32
+	UserAgent    string
+	LastIP       string // the client IP address of the last request made with the session
+	CreatedAt    time.Time
+	LastActiveAt time.Time
+	ExpiresAt    time.Time
+}
+
+// userSessionNotFoundError is the error that is returned when a user session is not found, has
+// expired or has been revoked.
+type userSessionNotFoundError struct {
+	id int64
+}
+
+func (err userSessionNotFoundError) Error() string {
+	return fmt.Sprintf("user session not found: %d", err.id)
+}
+
+func (err userSessionNotFoundError) NotFound() bool {
+	return true
+}
+
+// userSessions provides access to the `user_sessions` table.
+type userSessions struct{}
+
+// Create records a new session for the user and returns its ID. Expired sessions of the user are
+// removed at the same time.
+func (*userSessions) Create(ctx context.Context, s UserSession) (int64, error) {
+	if Mocks.UserSessions.Create != nil {
+		return Mocks.UserSessions.Create(ctx, s)
+	}
+
+	var id int64
+	err := dbconn.Global.QueryRowContext(ctx, `
+WITH expired AS (
+  DELETE FROM user_sessions WHERE user_id=$1 AND expires_at <= now()
+)
+INSERT INTO user_sessions (user_id, user_agent, last_ip, expires_at) VALUES ($1, $2, $3, $4) RETURNING id`,
+		s.UserID, s.UserAgent, s.LastIP, s.ExpiresAt,
+	).Scan(&id)
+	return id, err
+}
+
+// GetByID returns the session with the given ID, if it has neither expired nor been revoked.
+func (*userSessions) GetByID(ctx context.Context, id int64) (*UserSession, error) {
+	if Mocks.UserSessions.GetByID != nil {
+		return Mocks.UserSessions.GetByID(ctx, id)
+	}
+
+	var s UserSession
+	err := dbconn.Global.QueryRowContext(ctx, `
+SELECT id, user_id, user_agent, last_ip, created_at, last_active_at, expires_at
+FROM user_sessions WHERE id=$1 AND expires_at > now()`, id).Scan(
+		&s.ID, &s.UserID, &s.UserAgent, &s.LastIP, &s.CreatedAt, &s.LastActiveAt, &s.ExpiresAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, userSessionNotFoundError{id: id}
+	} else if err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+// Touch records activity on the session from the given client IP address and extends its expiry.
+func (*userSessions) Touch(ctx context.Context, id int64, lastIP string, expiresAt time.Time) error {
+	if Mocks.UserSessions.Touch != nil {
+		return Mocks.UserSessions.Touch(ctx, id, lastIP, expiresAt)
+	}
+
+	_, err := dbconn.Global.ExecContext(ctx,
+		`UPDATE user_sessions SET last_ip=$2, last_active_at=now(), expires_at=$3 WHERE id=$1`,
+		id, lastIP, expiresAt,
+	)
+	return err
+}
+
+// ListByUserID returns the user's sessions that have not expired, most recently active first.
+//
+// 🚨 SECURITY: The caller must ensure that the actor is permitted to list the user's sessions.
+func (*userSessions) ListByUserID(ctx context.Context, userID int32) ([]*UserSession, error) {
+	if Mocks.UserSessions.ListByUserID != nil {
+		return Mocks.UserSessions.ListByUserID(ctx, userID)
+	}
+
+	rows, err := dbconn.Global.QueryContext(ctx, `
+SELECT id, user_id, user_agent, last_ip, created_at, last_active_at, expires_at
+FROM user_sessions WHERE user_id=$1 AND expires_at > now()
+ORDER BY last_active_at DESC, id DESC`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var sessions []*UserSession
+	for rows.Next() {
+		var s UserSession
+		if err := rows.Scan(&s.ID, &s.UserID, &s.UserAgent, &s.LastIP, &s.CreatedAt, &s.LastActiveAt, &s.ExpiresAt); err != nil {
+			return nil, err
+		}
+		sessions = append(sessions, &s)
+	}
+	return sessions, rows.Err()
+}
+
+// Delete revokes the session with the given ID. The session store still holds the session data,
+// but it is no longer accepted for authentication.
+//
+// 🚨 SECURITY: The caller must ensure that the actor is permitted to revoke the session.
+func (*userSessions) Delete(ctx context.Context, id int64) error {
+	if Mocks.UserSessions.Delete != nil {
+		return Mocks.UserSessions.Delete(ctx, id)
+	}
+
+	res, err := dbconn.Global.ExecContext(ctx, `DELETE FROM user_sessions WHERE id=$1`, id)
+	if err != nil {
+		return err
+	}
+	nrows, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if nrows == 0 {
+		return userSessionNotFoundError{id: id}
+	}
+	return nil
+}
+
+// DeleteByUserID revokes all sessions of the user.
+//
+// 🚨 SECURITY: The caller must ensure that the actor is permitted to revoke the user's sessions.
+func (*userSessions) DeleteByUserID(ctx context.Context, userID int32) error {
+	if Mocks.UserSessions.DeleteByUserID != nil {
+		return Mocks.UserSessions.DeleteByUserID(ctx, userID)
+	}
+
+	_, err := dbconn.Global.ExecContext(ctx, `DELETE FROM user_sessions WHERE user_id=$1`, userID)
+	return err
+}
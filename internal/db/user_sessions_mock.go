@@ -0,0 +1,15 @@
+package db
+
+import (
+	"context"
+	"time"
+)
+
+type MockUserSessions struct {
+	Create         func(ctx context.Context, s UserSession) (int64, error)
+	GetByID        func(ctx context.Context, id int64) (*UserSession, error)
+	Touch          func(ctx context.Context, id int64, lastIP string, expiresAt time.Time) error
+	ListByUserID   func(ctx context.Context, userID int32) ([]*UserSession, error)
+	Delete         func(ctx context.Context, id int64) error
+	DeleteByUserID func(ctx context.Context, userID int32) error
+}
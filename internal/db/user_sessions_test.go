@@ -0,0 +1,76 @@
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/internal/db/dbtesting"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+)
+
+func TestUserSessions(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	dbtesting.SetupGlobalTestDB(t)
+	ctx := context.Background()
+
+	usr, err := Users.Create(ctx, NewUser{Username: "u", Password: "p", Email: "u@example.com", EmailIsVerified: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expiredID, err := UserSessions.Create(ctx, UserSession{UserID: usr.ID, ExpiresAt: time.Now().Add(-time.Minute)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := UserSessions.GetByID(ctx, expiredID); !errcode.IsNotFound(err) {
+		t.Fatalf("got error %v, want not found", err)
+	}
+
+	var ids []int64
+	for _, ua := range []string{"ua1", "ua2"} {
+		id, err := UserSessions.Create(ctx, UserSession{UserID: usr.ID, UserAgent: ua, LastIP: "10.0.0.1", ExpiresAt: time.Now().Add(time.Hour)})
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids = append(ids, id)
+	}
+
+	if err := UserSessions.Touch(ctx, ids[0], "10.0.0.2", time.Now().Add(2*time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+	s, err := UserSessions.GetByID(ctx, ids[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.UserID != usr.ID || s.UserAgent != "ua1" || s.LastIP != "10.0.0.2" {
+		t.Fatalf("unexpected session %+v", s)
+	}
+
+	sessions, err := UserSessions.ListByUserID(ctx, usr.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sessions) != 2 || sessions[0].ID != ids[0] {
+		t.Fatalf("got sessions %+v, want the 2 unexpired sessions, most recently active first", sessions)
+	}
+
+	if err := UserSessions.Delete(ctx, ids[0]); err != nil {
+		t.Fatal(err)
+	}
+	if err := UserSessions.Delete(ctx, ids[0]); !errcode.IsNotFound(err) {
+		t.Fatalf("got error %v, want not found", err)
+	}
+	if _, err := UserSessions.GetByID(ctx, ids[0]); !errcode.IsNotFound(err) {
+		t.Fatalf("got error %v, want not found", err)
+	}
+
+	if err := UserSessions.DeleteByUserID(ctx, usr.ID); err != nil {
+		t.Fatal(err)
+	}
+	if sessions, err := UserSessions.ListByUserID(ctx, usr.ID); err != nil || len(sessions) != 0 {
+		t.Fatalf("got sessions %+v, err %v, want none", sessions, err)
+	}
+}
@@ -527,6 +527,36 @@ func (u *users) SetIsSiteAdmin(ctx context.Context, id int32, isSiteAdmin bool)
 	return err
 }
 
+// SetDeactivated deactivates or reactivates the user. Deactivating a user also invalidates all of
+// their sessions.
+//
+// Unlike Delete, deactivation keeps the user's account, emails and external accounts, so that the
+// user can be reactivated later.
+func (u *users) SetDeactivated(ctx context.Context, id int32, deactivated bool) error {
+	if Mocks.Users.SetDeactivated != nil {
+		return Mocks.Users.SetDeactivated(ctx, id, deactivated)
+	}
+
+	var q *sqlf.Query
+	if deactivated {
+		q = sqlf.Sprintf("UPDATE users SET deactivated_at=COALESCE(deactivated_at, now()), invalidated_sessions_at=now(), updated_at=now() WHERE id=%d AND deleted_at IS NULL", id)
+	} else {
+		q = sqlf.Sprintf("UPDATE users SET deactivated_at=NULL, updated_at=now() WHERE id=%d AND deleted_at IS NULL", id)
+	}
+	res, err := dbconn.Global.ExecContext(ctx, q.Query(sqlf.PostgresBindVar), q.Args()...)
+	if err != nil {
+		return err
+	}
+	nrows, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if nrows == 0 {
+		return userNotFoundErr{args: []interface{}{id}}
+	}
+	return nil
+}
+
 // CheckAndDecrementInviteQuota should be called before the user (identified
 // by userID) is allowed to invite any other user. If ok is false, then the
 // user is not allowed to invite any other user (either because they've
@@ -683,6 +713,8 @@ type UsersListOptions struct {
 
 	Tag string // only include users with this tag
 
+	ExcludeDeactivated bool // exclude deactivated users
+
 	*LimitOffset
 }
 
@@ -762,6 +794,9 @@ func (*users) listSQL(opt UsersListOptions) (conds []*sqlf.Query) {
 	if opt.Tag != "" {
 		conds = append(conds, sqlf.Sprintf("%s::text = ANY(u.tags)", opt.Tag))
 	}
+	if opt.ExcludeDeactivated {
+		conds = append(conds, sqlf.Sprintf("deactivated_at IS NULL"))
+	}
 	return conds
 }
 
@@ -778,7 +813,7 @@ func (u *users) getOneBySQL(ctx context.Context, query string, args ...interface
 
 // getBySQL returns users matching the SQL query, if any exist.
 func (*users) getBySQL(ctx context.Context, query string, args ...interface{}) ([]*types.User, error) {
-	rows, err := dbconn.Global.QueryContext(ctx, "SELECT u.id, u.username, u.display_name, u.avatar_url, u.created_at, u.updated_at, u.site_admin, u.passwd IS NOT NULL, u.tags, u.invalidated_sessions_at FROM users u "+query, args...)
+	rows, err := dbconn.Global.QueryContext(ctx, "SELECT u.id, u.username, u.display_name, u.avatar_url, u.created_at, u.updated_at, u.site_admin, u.passwd IS NOT NULL, u.tags, u.invalidated_sessions_at, u.deactivated_at IS NOT NULL FROM users u "+query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -788,7 +823,7 @@ func (*users) getBySQL(ctx context.Context, query string, args ...interface{}) (
 	for rows.Next() {
 		var u types.User
 		var displayName, avatarURL sql.NullString
-		err := rows.Scan(&u.ID, &u.Username, &displayName, &avatarURL, &u.CreatedAt, &u.UpdatedAt, &u.SiteAdmin, &u.BuiltinAuth, pq.Array(&u.Tags), &u.InvalidatedSessionsAt)
+		err := rows.Scan(&u.ID, &u.Username, &displayName, &avatarURL, &u.CreatedAt, &u.UpdatedAt, &u.SiteAdmin, &u.BuiltinAuth, pq.Array(&u.Tags), &u.InvalidatedSessionsAt, &u.Deactivated)
 		if err != nil {
 			return nil, err
 		}
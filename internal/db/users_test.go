@@ -672,3 +672,58 @@ func normalizeUsers(users []*types.User) []*types.User {
 	}
 	return users
 }
+
+func TestUsers_SetDeactivated(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	dbtesting.SetupGlobalTestDB(t)
+	ctx := context.Background()
+
+	user, err := Users.Create(ctx, NewUser{Username: "alice"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if user.Deactivated {
+		t.Fatal("new user is deactivated")
+	}
+
+	if err := Users.SetDeactivated(ctx, user.ID, true); err != nil {
+		t.Fatal(err)
+	}
+	deactivated, err := Users.GetByID(ctx, user.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !deactivated.Deactivated {
+		t.Error("user is not deactivated")
+	}
+	if !deactivated.InvalidatedSessionsAt.After(user.InvalidatedSessionsAt) {
+		t.Error("sessions of deactivated user were not invalidated")
+	}
+
+	// Deactivated users are listed, but do not count towards the licensed user count.
+	if n, err := Users.Count(ctx, nil); err != nil {
+		t.Fatal(err)
+	} else if n != 1 {
+		t.Errorf("got %d users, want 1", n)
+	}
+	if n, err := Users.Count(ctx, &UsersListOptions{ExcludeDeactivated: true}); err != nil {
+		t.Fatal(err)
+	} else if n != 0 {
+		t.Errorf("got %d active users, want 0", n)
+	}
+
+	if err := Users.SetDeactivated(ctx, user.ID, false); err != nil {
+		t.Fatal(err)
+	}
+	if reactivated, err := Users.GetByID(ctx, user.ID); err != nil {
+		t.Fatal(err)
+	} else if reactivated.Deactivated {
+		t.Error("user is still deactivated")
+	}
+
+	if err := Users.SetDeactivated(ctx, 12345, true); !errcode.IsNotFound(err) {
+		t.Errorf("got error %v, want not found", err)
+	}
+}
@@ -0,0 +1,226 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+
+	"github.com/sourcegraph/sourcegraph/internal/db/dbconn"
+	encryption "github.com/sourcegraph/sourcegraph/internal/secrets"
+)
+
+// TwoFactorAuth represents a row in the `user_two_factor_auth` table, which holds the
+// TOTP-based two-factor authentication of a builtin auth user.
+type TwoFactorAuth struct {
+	UserID     int32
+	TOTPSecret string // base32-encoded, without padding; encrypted at rest
+
+	// RecoveryCodes are the SHA-256 hashes of the unused recovery codes.
+	RecoveryCodes []string
+
+	// LastUsedTimeStep is the TOTP time step of the last accepted code, so that codes can't be
+	// reused.
+	LastUsedTimeStep int64
+
+	FailedAttempts      int
+	LastFailedAttemptAt *time.Time
+
+	// EnabledAt is nil while the enrollment is pending, i.e. until the user has proven that
+	// they can generate codes for the secret.
+	EnabledAt *time.Time
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+const (
+	// twoFactorAuthMaxFailedAttempts is the number of failed verifications after which further
+	// verifications are rejected until twoFactorAuthFailedAttemptsWindow has passed (see
+	// BeginAttempt).
+	twoFactorAuthMaxFailedAttempts    = 5
+	twoFactorAuthFailedAttemptsWindow = 15 * time.Minute
+)
+
+var (
+	ErrTwoFactorAuthEnabled = errors.New("two-factor authentication is already enabled")
+	ErrTwoFactorAuthPending = errors.New("two-factor authentication enrollment is not pending")
+)
+
+// userTwoFactorAuthNotFoundError is the error that is returned when a user has no two-factor
+// authentication.
+type userTwoFactorAuthNotFoundError struct {
+	userID int32
+}
+
+func (err userTwoFactorAuthNotFoundError) Error() string {
+	return fmt.Sprintf("two-factor authentication not found for user %d", err.userID)
+}
+
+func (err userTwoFactorAuthNotFoundError) NotFound() bool {
+	return true
+}
+
+// userTwoFactorAuth provides access to the `user_two_factor_auth` table.
+type userTwoFactorAuth struct{}
+
+// GetByUserID returns the two-factor authentication of the user, whether it is enabled or
+// pending.
+func (*userTwoFactorAuth) GetByUserID(ctx context.Context, userID int32) (*TwoFactorAuth, error) {
+	if Mocks.UserTwoFactorAuth.GetByUserID != nil {
+		return Mocks.UserTwoFactorAuth.GetByUserID(ctx, userID)
+	}
+
+	var (
+		a      TwoFactorAuth
+		secret []byte
+	)
+	err := dbconn.Global.QueryRowContext(ctx, `
+SELECT user_id, totp_secret, recovery_codes, last_used_time_step, failed_attempts, last_failed_attempt_at, enabled_at, created_at, updated_at
+FROM user_two_factor_auth WHERE user_id=$1`, userID).Scan(
+		&a.UserID, &secret, pq.Array(&a.RecoveryCodes), &a.LastUsedTimeStep, &a.FailedAttempts,
+		&a.LastFailedAttemptAt, &a.EnabledAt, &a.CreatedAt, &a.UpdatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, userTwoFactorAuthNotFoundError{userID: userID}
+	} else if err != nil {
+		return nil, err
+	}
+
+	secret, err = encryption.DecryptBytes(secret)
+	if err != nil {
+		return nil, errors.Wrap(err, "decrypting TOTP secret")
+	}
+	a.TOTPSecret = string(secret)
+	return &a, nil
+}
+
+// SetPending starts (or restarts) the enrollment of the user with the given TOTP secret. It
+// returns ErrTwoFactorAuthEnabled if the user has already enabled two-factor authentication.
+func (*userTwoFactorAuth) SetPending(ctx context.Context, userID int32, totpSecret string) error {
+	if Mocks.UserTwoFactorAuth.SetPending != nil {
+		return Mocks.UserTwoFactorAuth.SetPending(ctx, userID, totpSecret)
+	}
+
+	// 🚨 SECURITY: The secret allows generating valid codes, so it must not be stored in
+	// plaintext.
+	secret, err := encryption.EncryptBytes([]byte(totpSecret))
+	if err != nil {
+		return errors.Wrap(err, "encrypting TOTP secret")
+	}
+
+	res, err := dbconn.Global.ExecContext(ctx, `
+INSERT INTO user_two_factor_auth (user_id, totp_secret) VALUES ($1, $2)
+ON CONFLICT (user_id) DO UPDATE
+SET totp_secret=excluded.totp_secret, recovery_codes='{}', last_used_time_step=0, updated_at=now()
+WHERE user_two_factor_auth.enabled_at IS NULL`, userID, secret)
+	if err != nil {
+		return err
+	}
+	return checkTwoFactorAuthAffected(res, ErrTwoFactorAuthEnabled)
+}
+
+// Enable completes the pending enrollment of the user, storing the hashes of the recovery codes
+// and the time step of the code the enrollment was confirmed with. It returns
+// ErrTwoFactorAuthPending if there is no pending enrollment.
+func (*userTwoFactorAuth) Enable(ctx context.Context, userID int32, recoveryCodes []string, timeStep int64) error {
+	if Mocks.UserTwoFactorAuth.Enable != nil {
+		return Mocks.UserTwoFactorAuth.Enable(ctx, userID, recoveryCodes, timeStep)
+	}
+
+	res, err := dbconn.Global.ExecContext(ctx, `
+UPDATE user_two_factor_auth
+SET enabled_at=now(), recovery_codes=$2, last_used_time_step=$3, failed_attempts=0, last_failed_attempt_at=NULL, updated_at=now()
+WHERE user_id=$1 AND enabled_at IS NULL`, userID, pq.Array(recoveryCodes), timeStep)
+	if err != nil {
+		return err
+	}
+	return checkTwoFactorAuthAffected(res, ErrTwoFactorAuthPending)
+}
+
+// UseTimeStep records that a TOTP code of the given time step was accepted and resets the
+// failed attempts. It returns false if a code of the same or a later time step was already
+// accepted, in which case the code must be rejected to prevent replay attacks.
+func (*userTwoFactorAuth) UseTimeStep(ctx context.Context, userID int32, timeStep int64) (bool, error) {
+	if Mocks.UserTwoFactorAuth.UseTimeStep != nil {
+		return Mocks.UserTwoFactorAuth.UseTimeStep(ctx, userID, timeStep)
+	}
+
+	res, err := dbconn.Global.ExecContext(ctx, `
+UPDATE user_two_factor_auth
+SET last_used_time_step=$2, failed_attempts=0, last_failed_attempt_at=NULL, updated_at=now()
+WHERE user_id=$1 AND last_used_time_step < $2`, userID, timeStep)
+	if err != nil {
+		return false, err
+	}
+	affected, err := res.RowsAffected()
+	return affected == 1, err
+}
+
+// UseRecoveryCode removes the recovery code with the given hash and resets the failed
+// attempts. It returns false if the user has no such recovery code.
+func (*userTwoFactorAuth) UseRecoveryCode(ctx context.Context, userID int32, hash string) (bool, error) {
+	if Mocks.UserTwoFactorAuth.UseRecoveryCode != nil {
+		return Mocks.UserTwoFactorAuth.UseRecoveryCode(ctx, userID, hash)
+	}
+
+	res, err := dbconn.Global.ExecContext(ctx, `
+UPDATE user_two_factor_auth
+SET recovery_codes=array_remove(recovery_codes, $2), failed_attempts=0, last_failed_attempt_at=NULL, updated_at=now()
+WHERE user_id=$1 AND enabled_at IS NOT NULL AND $2=ANY(recovery_codes)`, userID, hash)
+	if err != nil {
+		return false, err
+	}
+	affected, err := res.RowsAffected()
+	return affected == 1, err
+}
+
+// BeginAttempt counts an attempt to verify a code before the code is checked, so that
+// concurrent verifications can't exceed the rate limit. It returns false if the user has
+// already made twoFactorAuthMaxFailedAttempts attempts within twoFactorAuthFailedAttemptsWindow,
+// in which case the code must not be checked. Accepting a code resets the attempts, and
+// attempts older than the window are forgotten.
+func (*userTwoFactorAuth) BeginAttempt(ctx context.Context, userID int32) (bool, error) {
+	if Mocks.UserTwoFactorAuth.BeginAttempt != nil {
+		return Mocks.UserTwoFactorAuth.BeginAttempt(ctx, userID)
+	}
+
+	// 🚨 SECURITY: The check and the increment happen in a single statement, which locks the
+	// row, so that concurrent attempts see each other's increments.
+	var attempts int
+	err := dbconn.Global.QueryRowContext(ctx, `
+UPDATE user_two_factor_auth
+SET failed_attempts=CASE WHEN last_failed_attempt_at IS NULL OR last_failed_attempt_at + $2 * interval '1 second' < now() THEN 1 ELSE failed_attempts + 1 END,
+	last_failed_attempt_at=now()
+WHERE user_id=$1 AND NOT (failed_attempts >= $3 AND last_failed_attempt_at + $2 * interval '1 second' >= now())
+RETURNING failed_attempts`, userID, twoFactorAuthFailedAttemptsWindow.Seconds(), twoFactorAuthMaxFailedAttempts).Scan(&attempts)
+	if err == sql.ErrNoRows {
+		// Either the user is rate limited or the two-factor authentication was deleted in the
+		// meantime, and the code must be rejected in both cases.
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// Delete disables the two-factor authentication of the user, or cancels a pending enrollment.
+func (*userTwoFactorAuth) Delete(ctx context.Context, userID int32) error {
+	if Mocks.UserTwoFactorAuth.Delete != nil {
+		return Mocks.UserTwoFactorAuth.Delete(ctx, userID)
+	}
+
+	_, err := dbconn.Global.ExecContext(ctx, "DELETE FROM user_two_factor_auth WHERE user_id=$1", userID)
+	return err
+}
+
+func checkTwoFactorAuthAffected(res sql.Result, errNotAffected error) error {
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return errNotAffected
+	}
+	return nil
+}
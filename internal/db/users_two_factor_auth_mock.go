@@ -0,0 +1,13 @@
+package db
+
+import "context"
+
+type MockUserTwoFactorAuth struct {
+	GetByUserID     func(ctx context.Context, userID int32) (*TwoFactorAuth, error)
+	SetPending      func(ctx context.Context, userID int32, totpSecret string) error
+	Enable          func(ctx context.Context, userID int32, recoveryCodes []string, timeStep int64) error
+	UseTimeStep     func(ctx context.Context, userID int32, timeStep int64) (bool, error)
+	UseRecoveryCode func(ctx context.Context, userID int32, hash string) (bool, error)
+	BeginAttempt    func(ctx context.Context, userID int32) (bool, error)
+	Delete          func(ctx context.Context, userID int32) error
+}
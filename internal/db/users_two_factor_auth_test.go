@@ -0,0 +1,154 @@
+package db
+
+import (
+	"context"
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/internal/db/dbconn"
+	"github.com/sourcegraph/sourcegraph/internal/db/dbtesting"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	encryption "github.com/sourcegraph/sourcegraph/internal/secrets"
+)
+
+func TestUserTwoFactorAuth(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	dbtesting.SetupGlobalTestDB(t)
+	ctx := context.Background()
+
+	usr, err := Users.Create(ctx, NewUser{Username: "u", Password: "p", Email: "u@example.com", EmailIsVerified: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := UserTwoFactorAuth.GetByUserID(ctx, usr.ID); !errcode.IsNotFound(err) {
+		t.Fatalf("got error %v, want not found", err)
+	}
+
+	// Enrollments can be restarted until they are confirmed.
+	for _, secret := range []string{"SECRET1", "SECRET2"} {
+		if err := UserTwoFactorAuth.SetPending(ctx, usr.ID, secret); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := UserTwoFactorAuth.Enable(ctx, usr.ID, []string{"h1", "h2"}, 100); err != nil {
+		t.Fatal(err)
+	}
+	if err := UserTwoFactorAuth.SetPending(ctx, usr.ID, "SECRET3"); err != ErrTwoFactorAuthEnabled {
+		t.Fatalf("got error %v, want %v", err, ErrTwoFactorAuthEnabled)
+	}
+	if err := UserTwoFactorAuth.Enable(ctx, usr.ID, nil, 100); err != ErrTwoFactorAuthPending {
+		t.Fatalf("got error %v, want %v", err, ErrTwoFactorAuthPending)
+	}
+
+	a, err := UserTwoFactorAuth.GetByUserID(ctx, usr.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a.TOTPSecret != "SECRET2" || a.EnabledAt == nil || a.LastUsedTimeStep != 100 || !reflect.DeepEqual(a.RecoveryCodes, []string{"h1", "h2"}) {
+		t.Fatalf("unexpected two-factor auth %+v", a)
+	}
+
+	if encryption.ConfiguredToEncrypt() {
+		var stored []byte
+		if err := dbconn.Global.QueryRowContext(ctx, "SELECT totp_secret FROM user_two_factor_auth WHERE user_id=$1", usr.ID).Scan(&stored); err != nil {
+			t.Fatal(err)
+		}
+		if string(stored) == a.TOTPSecret {
+			t.Fatal("TOTP secret is stored in plaintext")
+		}
+	}
+
+	// Time steps and recovery codes can only be used once.
+	for _, want := range []bool{true, false} {
+		if ok, err := UserTwoFactorAuth.UseTimeStep(ctx, usr.ID, 101); err != nil || ok != want {
+			t.Fatalf("UseTimeStep: got %v, %v, want %v", ok, err, want)
+		}
+		if ok, err := UserTwoFactorAuth.UseRecoveryCode(ctx, usr.ID, "h1"); err != nil || ok != want {
+			t.Fatalf("UseRecoveryCode: got %v, %v, want %v", ok, err, want)
+		}
+	}
+
+	// Accepting a code resets the attempts, so all attempts are allowed until the limit.
+	for i := 0; i < twoFactorAuthMaxFailedAttempts; i++ {
+		if ok, err := UserTwoFactorAuth.BeginAttempt(ctx, usr.ID); err != nil || !ok {
+			t.Fatalf("attempt %d: got %v, %v, want allowed", i, ok, err)
+		}
+	}
+	if ok, err := UserTwoFactorAuth.BeginAttempt(ctx, usr.ID); err != nil || ok {
+		t.Fatalf("got %v, %v, want rate limited", ok, err)
+	}
+
+	// Attempts older than the window are forgotten.
+	if _, err := dbconn.Global.ExecContext(ctx, "UPDATE user_two_factor_auth SET last_failed_attempt_at = now() - $1 * interval '1 second'", (twoFactorAuthFailedAttemptsWindow + time.Minute).Seconds()); err != nil {
+		t.Fatal(err)
+	}
+	if ok, err := UserTwoFactorAuth.BeginAttempt(ctx, usr.ID); err != nil || !ok {
+		t.Fatalf("got %v, %v, want allowed after the window", ok, err)
+	}
+	if a, err = UserTwoFactorAuth.GetByUserID(ctx, usr.ID); err != nil {
+		t.Fatal(err)
+	} else if a.FailedAttempts != 1 {
+		t.Fatalf("got %d failed attempts, want 1", a.FailedAttempts)
+	}
+
+	if err := UserTwoFactorAuth.Delete(ctx, usr.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := UserTwoFactorAuth.GetByUserID(ctx, usr.ID); !errcode.IsNotFound(err) {
+		t.Fatalf("got error %v, want not found", err)
+	}
+	if ok, err := UserTwoFactorAuth.BeginAttempt(ctx, usr.ID); err != nil || ok {
+		t.Fatalf("got %v, %v, want no attempt without two-factor authentication", ok, err)
+	}
+}
+
+func TestUserTwoFactorAuth_BeginAttemptConcurrently(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	dbtesting.SetupGlobalTestDB(t)
+	ctx := context.Background()
+
+	usr, err := Users.Create(ctx, NewUser{Username: "u", Password: "p", Email: "u@example.com", EmailIsVerified: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := UserTwoFactorAuth.SetPending(ctx, usr.ID, "SECRET"); err != nil {
+		t.Fatal(err)
+	}
+
+	const n = 4 * twoFactorAuthMaxFailedAttempts
+	var (
+		wg      sync.WaitGroup
+		allowed int32
+		errs    = make(chan error, n)
+	)
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ok, err := UserTwoFactorAuth.BeginAttempt(ctx, usr.ID)
+			if err != nil {
+				errs <- err
+				return
+			}
+			if ok {
+				atomic.AddInt32(&allowed, 1)
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Fatal(err)
+	}
+	if allowed != twoFactorAuthMaxFailedAttempts {
+		t.Fatalf("%d of %d concurrent attempts were allowed, want %d", allowed, n, twoFactorAuthMaxFailedAttempts)
+	}
+}
@@ -103,6 +103,80 @@ func (c *Client) Repos(ctx context.Context, pageToken *PageToken, accountName st
 	return repos, next, err
 }
 
+// CurrentUser returns the user authenticated by the app password of the client.
+func (c *Client) CurrentUser(ctx context.Context) (*User, error) {
+	req, err := http.NewRequest("GET", "/2.0/user", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var user User
+	if err := c.do(ctx, req, &user); err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+// WorkspaceMembers returns a list of users who are members of the given workspace, based on
+// the given pagination criteria.
+//
+// API docs: https://developer.atlassian.com/bitbucket/api/2/reference/resource/workspaces/%7Bworkspace%7D/members
+func (c *Client) WorkspaceMembers(ctx context.Context, pageToken *PageToken, workspace string) ([]*User, *PageToken, error) {
+	var memberships []struct {
+		User *User `json:"user"`
+	}
+	var next *PageToken
+	var err error
+	if pageToken.HasMore() {
+		next, err = c.reqPage(ctx, pageToken.Next, &memberships)
+	} else {
+		next, err = c.page(ctx, fmt.Sprintf("/2.0/workspaces/%s/members", workspace), nil, pageToken, &memberships)
+	}
+
+	users := make([]*User, 0, len(memberships))
+	for _, m := range memberships {
+		if m.User != nil {
+			users = append(users, m.User)
+		}
+	}
+	return users, next, err
+}
+
+// UserRepoPermissions returns the permissions that the user with the given UUID has on
+// repositories of the given workspace, based on the given pagination criteria. The
+// app password's user must be an administrator of the workspace.
+//
+// API docs: https://developer.atlassian.com/bitbucket/api/2/reference/resource/workspaces/%7Bworkspace%7D/permissions/repositories
+func (c *Client) UserRepoPermissions(ctx context.Context, pageToken *PageToken, workspace, userUUID string) ([]*RepoPermission, *PageToken, error) {
+	qry := url.Values{
+		"q": []string{fmt.Sprintf("user.uuid=%q", userUUID)},
+		// Repository objects of the response do not include the visibility by default.
+		"fields": []string{"+values.repository.is_private"},
+	}
+	return c.repoPermissions(ctx, pageToken, fmt.Sprintf("/2.0/workspaces/%s/permissions/repositories", workspace), qry)
+}
+
+// RepoPermissions returns the permissions that users have on the given repository of the
+// given workspace, based on the given pagination criteria. The app password's user must be
+// an administrator of the workspace.
+//
+// API docs: https://developer.atlassian.com/bitbucket/api/2/reference/resource/workspaces/%7Bworkspace%7D/permissions/repositories/%7Brepo_slug%7D
+func (c *Client) RepoPermissions(ctx context.Context, pageToken *PageToken, workspace, repoSlug string) ([]*RepoPermission, *PageToken, error) {
+	return c.repoPermissions(ctx, pageToken, fmt.Sprintf("/2.0/workspaces/%s/permissions/repositories/%s", workspace, repoSlug), nil)
+}
+
+func (c *Client) repoPermissions(ctx context.Context, pageToken *PageToken, path string, qry url.Values) ([]*RepoPermission, *PageToken, error) {
+	var perms []*RepoPermission
+	var next *PageToken
+	var err error
+	if pageToken.HasMore() {
+		next, err = c.reqPage(ctx, pageToken.Next, &perms)
+	} else {
+		next, err = c.page(ctx, path, qry, pageToken, &perms)
+	}
+	return perms, next, err
+}
+
 func (c *Client) page(ctx context.Context, path string, qry url.Values, token *PageToken, results interface{}) (*PageToken, error) {
 	if qry == nil {
 		qry = make(url.Values)
@@ -235,6 +309,23 @@ type Repo struct {
 	Links       Links  `json:"links"`
 }
 
+// User is a Bitbucket Cloud user. Users are identified by their UUID, the nickname is what
+// is displayed as their username.
+type User struct {
+	UUID        string `json:"uuid"`
+	AccountID   string `json:"account_id"`
+	Nickname    string `json:"nickname"`
+	DisplayName string `json:"display_name"`
+}
+
+// RepoPermission is the permission ("read", "write" or "admin") that a user has on a
+// repository.
+type RepoPermission struct {
+	Permission string `json:"permission"`
+	User       *User  `json:"user"`
+	Repo       *Repo  `json:"repository"`
+}
+
 type Links struct {
 	Clone CloneLinks `json:"clone"`
 	HTML  Link       `json:"html"`
@@ -276,3 +367,10 @@ func (e *httpError) Unauthorized() bool {
 func (e *httpError) NotFound() bool {
 	return e.StatusCode == http.StatusNotFound
 }
+
+// IsNotFound reports whether err is a Bitbucket Cloud API error of a resource that does not
+// exist or that the client is not allowed to see.
+func IsNotFound(err error) bool {
+	e, ok := errors.Cause(err).(*httpError)
+	return ok && e.NotFound()
+}
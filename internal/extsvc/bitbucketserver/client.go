@@ -428,6 +428,36 @@ func (c *Client) LoadPullRequest(ctx context.Context, pr *PullRequest) error {
 	return c.send(ctx, "GET", path, nil, nil, pr)
 }
 
+// ListPullRequestsOpts are the options for ListPullRequests.
+type ListPullRequestsOpts struct {
+	// State restricts the pull requests to those in the given state (OPEN,
+	// DECLINED or MERGED). If it's empty, pull requests in all states are
+	// listed.
+	State string
+	// FromRef restricts the pull requests to those opened from the given
+	// fully qualified ref.
+	FromRef string
+}
+
+// ListPullRequests lists the pull requests of the given repository that
+// match the given options.
+func (c *Client) ListPullRequests(ctx context.Context, projectKey, repoSlug string, pageToken *PageToken, opts ListPullRequestsOpts) ([]*PullRequest, *PageToken, error) {
+	qry := url.Values{"state": []string{"ALL"}}
+	if opts.State != "" {
+		qry.Set("state", opts.State)
+	}
+	if opts.FromRef != "" {
+		qry.Set("at", opts.FromRef)
+		qry.Set("direction", "OUTGOING")
+	}
+
+	u := fmt.Sprintf("rest/api/1.0/projects/%s/repos/%s/pull-requests", projectKey, repoSlug)
+
+	var prs []*PullRequest
+	next, err := c.page(ctx, u, qry, pageToken, &prs)
+	return prs, next, err
+}
+
 type UpdatePullRequestInput struct {
 	PullRequestID string `json:"-"`
 	Version       int    `json:"version"`
@@ -550,6 +580,52 @@ func (c *Client) DeclinePullRequest(ctx context.Context, pr *PullRequest) error
 	return c.send(ctx, "POST", path, qry, nil, pr)
 }
 
+// CreatePullRequestComment creates a comment with the given text on the given
+// PullRequest, returning an error in case of failure.
+func (c *Client) CreatePullRequestComment(ctx context.Context, pr *PullRequest, text string) error {
+	if pr.ToRef.Repository.Slug == "" {
+		return errors.New("repository slug empty")
+	}
+
+	if pr.ToRef.Repository.Project.Key == "" {
+		return errors.New("project key empty")
+	}
+
+	path := fmt.Sprintf(
+		"rest/api/1.0/projects/%s/repos/%s/pull-requests/%d/comments",
+		pr.ToRef.Repository.Project.Key,
+		pr.ToRef.Repository.Slug,
+		pr.ID,
+	)
+
+	payload := map[string]interface{}{"text": text}
+
+	return c.send(ctx, "POST", path, nil, payload, nil)
+}
+
+// MergePullRequest merges the given PullRequest, returning an error in case of
+// failure.
+func (c *Client) MergePullRequest(ctx context.Context, pr *PullRequest) error {
+	if pr.ToRef.Repository.Slug == "" {
+		return errors.New("repository slug empty")
+	}
+
+	if pr.ToRef.Repository.Project.Key == "" {
+		return errors.New("project key empty")
+	}
+
+	path := fmt.Sprintf(
+		"rest/api/1.0/projects/%s/repos/%s/pull-requests/%d/merge",
+		pr.ToRef.Repository.Project.Key,
+		pr.ToRef.Repository.Slug,
+		pr.ID,
+	)
+
+	qry := url.Values{"version": {strconv.Itoa(pr.Version)}}
+
+	return c.send(ctx, "POST", path, qry, nil, pr)
+}
+
 // LoadPullRequestActivities loads the given PullRequest's timeline of activities,
 // returning an error in case of failure.
 func (c *Client) LoadPullRequestActivities(ctx context.Context, pr *PullRequest) (err error) {
@@ -28,6 +28,9 @@ func ParseWebhookEvent(eventType string, payload []byte) (e interface{}, err err
 	case "pr:participant:status":
 		e = &PullRequestParticipantStatusEvent{}
 		return e, json.Unmarshal(payload, e)
+	case "repo:permissions:changed", "project:permissions:changed":
+		e = &PermissionsChangedEvent{}
+		return e, json.Unmarshal(payload, e)
 	default:
 		return nil, fmt.Errorf("unknown webhook event type: %q", eventType)
 	}
@@ -63,3 +66,16 @@ type BuildStatusEvent struct {
 	Status       BuildStatus   `json:"status"`
 	PullRequests []PullRequest `json:"pullRequests"`
 }
+
+// PermissionsChangedEvent is sent by the Sourcegraph Bitbucket Server plugin when a permission
+// of a user or group on a project or repository is granted, changed or revoked. Exactly one of
+// User and Group, and one of Project and Repo is set.
+type PermissionsChangedEvent struct {
+	Date       time.Time `json:"date"`
+	Actor      User      `json:"actor"`
+	User       *User     `json:"user"`
+	Group      *Group    `json:"group"`
+	Project    *Project  `json:"project"`
+	Repo       *Repo     `json:"repository"`
+	Permission string    `json:"permission"`
+}
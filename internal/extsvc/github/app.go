@@ -0,0 +1,157 @@
+package github
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// InstallationAccessToken is an access token of a GitHub App installation.
+type InstallationAccessToken struct {
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// CreateInstallationAccessToken creates an access token of the GitHub App installation with
+// the given ID. The client must be authenticated with a JSON Web Token of the GitHub App (see
+// AppJWT).
+//
+// API docs: https://developer.github.com/v3/apps/#create-an-installation-access-token-for-an-app
+func (c *Client) CreateInstallationAccessToken(ctx context.Context, installationID int64) (*InstallationAccessToken, error) {
+	req, err := http.NewRequest("POST", fmt.Sprintf("/app/installations/%d/access_tokens", installationID), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Add("Accept", "application/vnd.github.machine-man-preview+json")
+
+	if err := c.rateLimit.Wait(ctx); err != nil {
+		return nil, errors.Wrap(err, "rate limit")
+	}
+
+	var tok InstallationAccessToken
+	if err := c.do(ctx, req, &tok); err != nil {
+		return nil, err
+	}
+	return &tok, nil
+}
+
+// ParseAppPrivateKey parses the PEM encoded RSA private key of a GitHub App. GitHub generates
+// keys in PKCS #1 form, but PKCS #8 is accepted as well.
+func ParseAppPrivateKey(data []byte) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("no PEM encoded private key found")
+	}
+
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, errors.Wrap(err, "parse private key")
+	}
+	rsaKey, ok := key.(*rsa.PrivateKey)
+	if !ok {
+		return nil, errors.New("private key is not an RSA key")
+	}
+	return rsaKey, nil
+}
+
+// AppJWT returns a JSON Web Token that authenticates as the GitHub App with the given ID,
+// signed with the private key of the GitHub App. The token is valid for 10 minutes, the
+// maximum allowed by GitHub.
+//
+// API docs: https://developer.github.com/apps/building-github-apps/authenticating-with-github-apps/#authenticating-as-a-github-app
+func AppJWT(appID int64, key *rsa.PrivateKey, now time.Time) (string, error) {
+	header, err := json.Marshal(map[string]string{"alg": "RS256", "typ": "JWT"})
+	if err != nil {
+		return "", err
+	}
+	claims, err := json.Marshal(map[string]int64{
+		// Issue the token a bit in the past to allow for clock drift.
+		"iat": now.Add(-time.Minute).Unix(),
+		"exp": now.Add(10 * time.Minute).Unix(),
+		"iss": appID,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	enc := base64.RawURLEncoding
+	unsigned := enc.EncodeToString(header) + "." + enc.EncodeToString(claims)
+	digest := sha256.Sum256([]byte(unsigned))
+	sig, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+	if err != nil {
+		return "", errors.Wrap(err, "sign token")
+	}
+	return unsigned + "." + enc.EncodeToString(sig), nil
+}
+
+// InstallationTokenSource returns access tokens of a GitHub App installation, creating new
+// ones shortly before the previous one expires.
+type InstallationTokenSource struct {
+	client         *Client
+	appID          int64
+	installationID int64
+	key            *rsa.PrivateKey
+
+	mu  sync.Mutex
+	tok *InstallationAccessToken
+
+	now func() time.Time
+}
+
+// NewInstallationTokenSource returns an InstallationTokenSource for the installation with the
+// given ID of the GitHub App with the given ID and PEM encoded private key. The client is only
+// used to talk to the GitHub API, its token is ignored.
+func NewInstallationTokenSource(client *Client, appID, installationID int64, privateKey []byte) (*InstallationTokenSource, error) {
+	key, err := ParseAppPrivateKey(privateKey)
+	if err != nil {
+		return nil, err
+	}
+	return &InstallationTokenSource{
+		client:         client,
+		appID:          appID,
+		installationID: installationID,
+		key:            key,
+		now:            time.Now,
+	}, nil
+}
+
+// installationTokenExpiryMargin is how long before its expiry an installation access token is
+// replaced, so that tokens do not expire while being used.
+const installationTokenExpiryMargin = 5 * time.Minute
+
+// Token returns a valid installation access token.
+func (s *InstallationTokenSource) Token(ctx context.Context) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	if s.tok != nil && now.Add(installationTokenExpiryMargin).Before(s.tok.ExpiresAt) {
+		return s.tok.Token, nil
+	}
+
+	jwt, err := AppJWT(s.appID, s.key, now)
+	if err != nil {
+		return "", err
+	}
+	tok, err := s.client.WithToken(jwt).CreateInstallationAccessToken(ctx, s.installationID)
+	if err != nil {
+		return "", errors.Wrap(err, "create installation access token")
+	}
+	s.tok = tok
+	return tok.Token, nil
+}
@@ -0,0 +1,118 @@
+package github
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestAppJWT(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Unix(1600000000, 0)
+	jwt, err := AppJWT(1234, key, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	parts := strings.Split(jwt, ".")
+	if len(parts) != 3 {
+		t.Fatalf("got %d parts, want 3", len(parts))
+	}
+
+	sig, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		t.Fatal(err)
+	}
+	digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	if err := rsa.VerifyPKCS1v15(&key.PublicKey, crypto.SHA256, digest[:], sig); err != nil {
+		t.Fatalf("invalid signature: %v", err)
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		t.Fatal(err)
+	}
+	var claims map[string]int64
+	if err := json.Unmarshal(data, &claims); err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]int64{"iss": 1234, "iat": 1599999940, "exp": 1600000600}
+	for k, v := range want {
+		if claims[k] != v {
+			t.Errorf("got claim %s %d, want %d", k, claims[k], v)
+		}
+	}
+}
+
+func TestInstallationTokenSource(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pemKey := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+
+	now := time.Now()
+	var created int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" || r.URL.Path != "/app/installations/99/access_tokens" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		if !strings.HasPrefix(r.Header.Get("Authorization"), "bearer ") {
+			t.Errorf("missing JWT in Authorization header")
+		}
+		created++
+		_ = json.NewEncoder(w).Encode(InstallationAccessToken{
+			Token:     fmt.Sprintf("token-%d", created),
+			ExpiresAt: now.Add(time.Hour),
+		})
+	}))
+	defer srv.Close()
+
+	apiURL, _ := url.Parse(srv.URL)
+	src, err := NewInstallationTokenSource(NewClient(apiURL, "", nil), 1234, 99, pemKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	src.now = func() time.Time { return now }
+
+	for i := 0; i < 2; i++ {
+		tok, err := src.Token(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if tok != "token-1" {
+			t.Fatalf("got token %q, want the cached token-1", tok)
+		}
+	}
+
+	// Tokens are replaced shortly before they expire.
+	now = now.Add(56 * time.Minute)
+	tok, err := src.Token(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tok != "token-2" {
+		t.Fatalf("got token %q, want token-2", tok)
+	}
+
+	if _, err := NewInstallationTokenSource(nil, 1, 1, []byte("not a key")); err == nil {
+		t.Fatal("expected an error for an invalid private key")
+	}
+}
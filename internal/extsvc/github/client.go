@@ -203,6 +203,9 @@ func (c *Client) do(ctx context.Context, req *http.Request, result interface{})
 		err.Code = resp.StatusCode
 		return &err
 	}
+	if resp.StatusCode == http.StatusNoContent {
+		return nil
+	}
 	return json.NewDecoder(resp.Body).Decode(result)
 }
 
@@ -227,20 +230,23 @@ func (c *Client) listRepositories(ctx context.Context, requestURI string) ([]*Re
 }
 
 // ListInstallationRepositories lists repositories on which the authenticated
-// GitHub App has been installed.
-func (c *Client) ListInstallationRepositories(ctx context.Context) ([]*Repository, error) {
+// GitHub App has been installed. The page is the page of results to return, and
+// is 1-indexed (so the first call should be for page 1).
+func (c *Client) ListInstallationRepositories(ctx context.Context, page int) (repos []*Repository, hasNextPage bool, _ error) {
 	type response struct {
+		TotalCount   int              `json:"total_count"`
 		Repositories []restRepository `json:"repositories"`
 	}
 	var resp response
-	if err := c.requestGet(ctx, "installation/repositories", &resp); err != nil {
-		return nil, err
+	path := fmt.Sprintf("installation/repositories?page=%d&per_page=100", page)
+	if err := c.requestGet(ctx, path, &resp); err != nil {
+		return nil, false, err
 	}
-	repos := make([]*Repository, 0, len(resp.Repositories))
+	repos = make([]*Repository, 0, len(resp.Repositories))
 	for _, restRepo := range resp.Repositories {
 		repos = append(repos, convertRestRepo(restRepo))
 	}
-	return repos, nil
+	return repos, page*100 < resp.TotalCount, nil
 }
 
 func (c *Client) requestGet(ctx context.Context, requestURI string, result interface{}) error {
@@ -294,6 +300,9 @@ func (c *Client) requestGraphQL(ctx context.Context, query string, vars map[stri
 	// Enable Checks API
 	// https://developer.github.com/v4/previews/#checks
 	req.Header.Add("Accept", "application/vnd.github.antiope-preview+json")
+	// Enable draft pull requests on GitHub Enterprise
+	// https://developer.github.com/v4/previews/#draft-pull-requests-preview
+	req.Header.Add("Accept", "application/vnd.github.shadow-cat-preview+json")
 	var respBody struct {
 		Data   json.RawMessage `json:"data"`
 		Errors graphqlErrors   `json:"errors"`
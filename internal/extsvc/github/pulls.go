@@ -137,6 +137,7 @@ type PullRequest struct {
 	HeadRefName   string
 	BaseRefName   string
 	Number        int64
+	IsDraft       bool
 	Author        Actor
 	Participants  []Actor
 	Labels        struct{ Nodes []Label }
@@ -294,6 +295,30 @@ func (e ReopenedEvent) Key() string {
 	return fmt.Sprintf("%s:%d", e.Actor.Login, e.CreatedAt.UnixNano())
 }
 
+// ConvertToDraftEvent represents a 'convert_to_draft' event on a pull
+// request.
+type ConvertToDraftEvent struct {
+	Actor     Actor
+	CreatedAt time.Time
+}
+
+// Key is a unique key identifying this event in the context of its pull request.
+func (e ConvertToDraftEvent) Key() string {
+	return fmt.Sprintf("convert_to_draft:%s:%d", e.Actor.Login, e.CreatedAt.UnixNano())
+}
+
+// ReadyForReviewEvent represents a 'ready_for_review' event on a pull
+// request.
+type ReadyForReviewEvent struct {
+	Actor     Actor
+	CreatedAt time.Time
+}
+
+// Key is a unique key identifying this event in the context of its pull request.
+func (e ReadyForReviewEvent) Key() string {
+	return fmt.Sprintf("ready_for_review:%s:%d", e.Actor.Login, e.CreatedAt.UnixNano())
+}
+
 // ReviewDismissedEvent represents a 'review_dismissed' event on a pull request.
 type ReviewDismissedEvent struct {
 	Actor            Actor
@@ -413,6 +438,8 @@ func (i *TimelineItem) UnmarshalJSON(data []byte) error {
 		i.Item = new(AssignedEvent)
 	case "ClosedEvent":
 		i.Item = new(ClosedEvent)
+	case "ConvertToDraftEvent":
+		i.Item = new(ConvertToDraftEvent)
 	case "IssueComment":
 		i.Item = new(IssueComment)
 	case "RenamedTitleEvent":
@@ -427,6 +454,8 @@ func (i *TimelineItem) UnmarshalJSON(data []byte) error {
 		i.Item = new(PullRequestReviewThread)
 	case "PullRequestCommit":
 		i.Item = new(PullRequestCommit)
+	case "ReadyForReviewEvent":
+		i.Item = new(ReadyForReviewEvent)
 	case "ReopenedEvent":
 		i.Item = new(ReopenedEvent)
 	case "ReviewDismissedEvent":
@@ -464,12 +493,36 @@ type CreatePullRequestInput struct {
 	Title string `json:"title"`
 	// The body of the pull request (optional).
 	Body string `json:"body"`
+	// Whether to create the pull request as a draft. Only GitHub Enterprise
+	// versions that support draft pull requests accept the field, see
+	// SupportsDraftPullRequests.
+	Draft bool `json:"draft,omitempty"`
 }
 
+// ErrDraftPullRequestsNotSupported is returned by CreatePullRequest when a
+// draft pull request is requested from a GitHub Enterprise version that
+// doesn't support draft pull requests.
+var ErrDraftPullRequestsNotSupported = errors.New("draft pull requests are not supported by this GitHub Enterprise version")
+
 // CreatePullRequest creates a PullRequest on Github.
 func (c *Client) CreatePullRequest(ctx context.Context, in *CreatePullRequestInput) (*PullRequest, error) {
+	if in.Draft {
+		ok, err := c.SupportsDraftPullRequests(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			return nil, ErrDraftPullRequestsNotSupported
+		}
+	}
+
+	fragments, err := c.pullRequestFragments(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	var q strings.Builder
-	q.WriteString(pullRequestFragments)
+	q.WriteString(fragments)
 	q.WriteString(`mutation	CreatePullRequest($input:CreatePullRequestInput!) {
   createPullRequest(input:$input) {
     pullRequest {
@@ -489,7 +542,7 @@ func (c *Client) CreatePullRequest(ctx context.Context, in *CreatePullRequestInp
 	}
 
 	input := map[string]interface{}{"input": in}
-	err := c.requestGraphQL(ctx, q.String(), input, &result)
+	err = c.requestGraphQL(ctx, q.String(), input, &result)
 	if err != nil {
 		if gqlErrs, ok := err.(graphqlErrors); ok && len(gqlErrs) == 1 {
 			e := gqlErrs[0]
@@ -520,8 +573,13 @@ type UpdatePullRequestInput struct {
 
 // UpdatePullRequest creates a PullRequest on Github.
 func (c *Client) UpdatePullRequest(ctx context.Context, in *UpdatePullRequestInput) (*PullRequest, error) {
+	fragments, err := c.pullRequestFragments(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	var q strings.Builder
-	q.WriteString(pullRequestFragments)
+	q.WriteString(fragments)
 	q.WriteString(`mutation	UpdatePullRequest($input:UpdatePullRequestInput!) {
   updatePullRequest(input:$input) {
     pullRequest {
@@ -541,7 +599,7 @@ func (c *Client) UpdatePullRequest(ctx context.Context, in *UpdatePullRequestInp
 	}
 
 	input := map[string]interface{}{"input": in}
-	err := c.requestGraphQL(ctx, q.String(), input, &result)
+	err = c.requestGraphQL(ctx, q.String(), input, &result)
 	if err != nil {
 		if gqlErrs, ok := err.(graphqlErrors); ok && len(gqlErrs) == 1 {
 			e := gqlErrs[0]
@@ -560,8 +618,13 @@ func (c *Client) UpdatePullRequest(ctx context.Context, in *UpdatePullRequestInp
 
 // ClosePullRequest closes the PullRequest on Github.
 func (c *Client) ClosePullRequest(ctx context.Context, pr *PullRequest) error {
+	fragments, err := c.pullRequestFragments(ctx)
+	if err != nil {
+		return err
+	}
+
 	var q strings.Builder
-	q.WriteString(pullRequestFragments)
+	q.WriteString(fragments)
 	q.WriteString(`mutation	ClosePullRequest($input:ClosePullRequestInput!) {
   closePullRequest(input:$input) {
     pullRequest {
@@ -583,7 +646,7 @@ func (c *Client) ClosePullRequest(ctx context.Context, pr *PullRequest) error {
 	input := map[string]interface{}{"input": struct {
 		ID string `json:"pullRequestId"`
 	}{ID: pr.ID}}
-	err := c.requestGraphQL(ctx, q.String(), input, &result)
+	err = c.requestGraphQL(ctx, q.String(), input, &result)
 	if err != nil {
 		return err
 	}
@@ -595,6 +658,114 @@ func (c *Client) ClosePullRequest(ctx context.Context, pr *PullRequest) error {
 	return nil
 }
 
+// MarkPullRequestReadyForReview marks the draft PullRequest on Github as
+// ready for review.
+func (c *Client) MarkPullRequestReadyForReview(ctx context.Context, pr *PullRequest) error {
+	fragments, err := c.pullRequestFragments(ctx)
+	if err != nil {
+		return err
+	}
+
+	var q strings.Builder
+	q.WriteString(fragments)
+	q.WriteString(`mutation	MarkPullRequestReadyForReview($input:MarkPullRequestReadyForReviewInput!) {
+  markPullRequestReadyForReview(input:$input) {
+    pullRequest {
+      ... pr
+    }
+  }
+}`)
+
+	var result struct {
+		MarkPullRequestReadyForReview struct {
+			PullRequest struct {
+				PullRequest
+				Participants  struct{ Nodes []Actor }
+				TimelineItems struct{ Nodes []TimelineItem }
+			} `json:"pullRequest"`
+		} `json:"markPullRequestReadyForReview"`
+	}
+
+	input := map[string]interface{}{"input": struct {
+		ID string `json:"pullRequestId"`
+	}{ID: pr.ID}}
+	err = c.requestGraphQL(ctx, q.String(), input, &result)
+	if err != nil {
+		return err
+	}
+
+	*pr = result.MarkPullRequestReadyForReview.PullRequest.PullRequest
+	pr.TimelineItems = result.MarkPullRequestReadyForReview.PullRequest.TimelineItems.Nodes
+	pr.Participants = result.MarkPullRequestReadyForReview.PullRequest.Participants.Nodes
+
+	return nil
+}
+
+// CreatePullRequestComment creates a comment on the PullRequest on Github.
+func (c *Client) CreatePullRequestComment(ctx context.Context, pr *PullRequest, body string) error {
+	q := `mutation CreatePullRequestComment($input:AddCommentInput!) {
+  addComment(input:$input) {
+    subject { id }
+  }
+}`
+
+	var result struct {
+		AddComment struct {
+			Subject struct {
+				ID string
+			} `json:"subject"`
+		} `json:"addComment"`
+	}
+
+	input := map[string]interface{}{"input": struct {
+		SubjectID string `json:"subjectId"`
+		Body      string `json:"body"`
+	}{SubjectID: pr.ID, Body: body}}
+	return c.requestGraphQL(ctx, q, input, &result)
+}
+
+// MergePullRequest merges the PullRequest on Github.
+func (c *Client) MergePullRequest(ctx context.Context, pr *PullRequest) error {
+	fragments, err := c.pullRequestFragments(ctx)
+	if err != nil {
+		return err
+	}
+
+	var q strings.Builder
+	q.WriteString(fragments)
+	q.WriteString(`mutation MergePullRequest($input:MergePullRequestInput!) {
+  mergePullRequest(input:$input) {
+    pullRequest {
+      ... pr
+    }
+  }
+}`)
+
+	var result struct {
+		MergePullRequest struct {
+			PullRequest struct {
+				PullRequest
+				Participants  struct{ Nodes []Actor }
+				TimelineItems struct{ Nodes []TimelineItem }
+			} `json:"pullRequest"`
+		} `json:"mergePullRequest"`
+	}
+
+	input := map[string]interface{}{"input": struct {
+		ID string `json:"pullRequestId"`
+	}{ID: pr.ID}}
+	err = c.requestGraphQL(ctx, q.String(), input, &result)
+	if err != nil {
+		return err
+	}
+
+	*pr = result.MergePullRequest.PullRequest.PullRequest
+	pr.TimelineItems = result.MergePullRequest.PullRequest.TimelineItems.Nodes
+	pr.Participants = result.MergePullRequest.PullRequest.Participants.Nodes
+
+	return nil
+}
+
 // LoadPullRequests loads a list of PullRequests from Github.
 func (c *Client) LoadPullRequests(ctx context.Context, prs ...*PullRequest) error {
 	const batchSize = 15
@@ -640,8 +811,13 @@ func (c *Client) loadPullRequests(ctx context.Context, prs ...*PullRequest) erro
 		r.PRs[prLabel] = pr
 	}
 
+	fragments, err := c.pullRequestFragments(ctx)
+	if err != nil {
+		return err
+	}
+
 	var q strings.Builder
-	q.WriteString(pullRequestFragments)
+	q.WriteString(fragments)
 	q.WriteString("query {\n")
 
 	for repoLabel, r := range labeled {
@@ -665,7 +841,7 @@ func (c *Client) loadPullRequests(ctx context.Context, prs ...*PullRequest) erro
 		TimelineItems struct{ Nodes []TimelineItem }
 	}
 
-	err := c.requestGraphQL(ctx, q.String(), nil, &results)
+	err = c.requestGraphQL(ctx, q.String(), nil, &results)
 	if err != nil {
 		return err
 	}
@@ -685,8 +861,13 @@ func (c *Client) loadPullRequests(ctx context.Context, prs ...*PullRequest) erro
 // refs. GitHub only allows one open PR by ref at a time.
 // If nothing is found an error is returned.
 func (c *Client) GetOpenPullRequestByRefs(ctx context.Context, owner, name, baseRef, headRef string) (*PullRequest, error) {
+	fragments, err := c.pullRequestFragments(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	var q strings.Builder
-	q.WriteString(pullRequestFragments)
+	q.WriteString(fragments)
 	q.WriteString("query {\n")
 	q.WriteString(fmt.Sprintf("repository(owner: %q, name: %q) {\n",
 		owner, name))
@@ -707,7 +888,7 @@ func (c *Client) GetOpenPullRequestByRefs(ctx context.Context, owner, name, base
 		}
 	}
 
-	err := c.requestGraphQL(ctx, q.String(), nil, &results)
+	err = c.requestGraphQL(ctx, q.String(), nil, &results)
 	if err != nil {
 		return nil, err
 	}
@@ -722,9 +903,149 @@ func (c *Client) GetOpenPullRequestByRefs(ctx context.Context, owner, name, base
 	return &pr, nil
 }
 
+// ListPullRequestsOpts are the options for ListPullRequests.
+type ListPullRequestsOpts struct {
+	// States restricts the pull requests to those in one of the given states
+	// (OPEN, CLOSED or MERGED). If it's empty, pull requests in all states
+	// are listed.
+	States []string
+	// Labels restricts the pull requests to those that have at least one of
+	// the given labels.
+	Labels []string
+	// HeadRefName restricts the pull requests to those opened from the given
+	// branch.
+	HeadRefName string
+}
+
+// ListPullRequests lists a page of the pull requests of the given repository
+// that match the given options, oldest first. The page starts after the given
+// cursor, or at the beginning if it's empty. The returned cursor is that of the
+// next page, and empty if there are no more pages.
+func (c *Client) ListPullRequests(ctx context.Context, owner, name string, opts ListPullRequestsOpts, after string) ([]*PullRequest, string, error) {
+	// Pull requests are rather expensive in terms of GraphQL nodes, so we
+	// keep the pages small to stay below GitHub's node limit.
+	const pageSize = 25
+
+	fragments, err := c.pullRequestFragments(ctx)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var q strings.Builder
+	q.WriteString(fragments)
+	q.WriteString(`query($owner: String!, $name: String!, $first: Int!, $after: String, $states: [PullRequestState!], $labels: [String!], $headRefName: String) {
+  repository(owner: $owner, name: $name) {
+    pullRequests(first: $first, after: $after, states: $states, labels: $labels, headRefName: $headRefName, orderBy: {field: CREATED_AT, direction: ASC}) {
+      nodes { ... pr }
+      pageInfo { hasNextPage endCursor }
+    }
+  }
+}`)
+
+	vars := map[string]interface{}{
+		"owner": owner,
+		"name":  name,
+		"first": pageSize,
+	}
+	if len(opts.States) > 0 {
+		vars["states"] = opts.States
+	}
+	if len(opts.Labels) > 0 {
+		vars["labels"] = opts.Labels
+	}
+	if opts.HeadRefName != "" {
+		vars["headRefName"] = opts.HeadRefName
+	}
+	if after != "" {
+		vars["after"] = after
+	}
+
+	var results struct {
+		Repository struct {
+			PullRequests struct {
+				Nodes []*struct {
+					PullRequest
+					Participants  struct{ Nodes []Actor }
+					TimelineItems struct{ Nodes []TimelineItem }
+				}
+				PageInfo struct {
+					HasNextPage bool
+					EndCursor   string
+				}
+			}
+		}
+	}
+
+	if err := c.requestGraphQL(ctx, q.String(), vars, &results); err != nil {
+		return nil, "", err
+	}
+
+	prs := make([]*PullRequest, 0, len(results.Repository.PullRequests.Nodes))
+	for _, n := range results.Repository.PullRequests.Nodes {
+		pr := n.PullRequest
+		pr.RepoWithOwner = owner + "/" + name
+		pr.Participants = n.Participants.Nodes
+		pr.TimelineItems = n.TimelineItems.Nodes
+		prs = append(prs, &pr)
+	}
+
+	var next string
+	if page := results.Repository.PullRequests.PageInfo; page.HasNextPage {
+		next = page.EndCursor
+	}
+	return prs, next, nil
+}
+
+// pullRequestFragments returns the GraphQL fragments that query the fields of
+// pull requests, leaving out the fields and timeline item types that the
+// version of the GitHub instance the client connects to doesn't support.
+func (c *Client) pullRequestFragments(ctx context.Context) (string, error) {
+	drafts, err := c.supportsVersion(ctx, draftPullRequestsVersion)
+	if err != nil {
+		return "", err
+	}
+	convertToDraft, err := c.supportsVersion(ctx, convertToDraftEventVersion)
+	if err != nil {
+		return "", err
+	}
+
+	var isDraft, itemTypes, items string
+	if drafts {
+		isDraft = "\n  isDraft"
+	}
+	if convertToDraft {
+		itemTypes += ", CONVERT_TO_DRAFT_EVENT"
+		items += convertToDraftEventFragment
+	}
+	if drafts {
+		itemTypes += ", READY_FOR_REVIEW_EVENT"
+		items += readyForReviewEventFragment
+	}
+
+	return fmt.Sprintf(pullRequestFragmentsFmtstr, isDraft, itemTypes, items), nil
+}
+
+const convertToDraftEventFragment = `
+      ... on ConvertToDraftEvent {
+        actor {
+          ...actor
+        }
+        createdAt
+      }`
+
+const readyForReviewEventFragment = `
+      ... on ReadyForReviewEvent {
+        actor {
+          ...actor
+        }
+        createdAt
+      }`
+
 // This fragment was formatted using the "prettify" button in the GitHub API explorer:
 // https://developer.github.com/v4/explorer/
-const pullRequestFragments = `
+//
+// The placeholders are filled in by pullRequestFragments.
+const pullRequestFragmentsFmtstr = `
 fragment actor on Actor {
   avatarUrl
   login
@@ -812,7 +1133,7 @@ fragment pr on PullRequest {
   body
   state
   url
-  number
+  number%s
   createdAt
   updatedAt
   headRefOid
@@ -837,7 +1158,7 @@ fragment pr on PullRequest {
       ...prCommit
     }
   }
-  timelineItems(first: 250, itemTypes: [ASSIGNED_EVENT, CLOSED_EVENT, ISSUE_COMMENT, RENAMED_TITLE_EVENT, MERGED_EVENT, PULL_REQUEST_REVIEW, PULL_REQUEST_REVIEW_THREAD, REOPENED_EVENT, REVIEW_DISMISSED_EVENT, REVIEW_REQUEST_REMOVED_EVENT, REVIEW_REQUESTED_EVENT, UNASSIGNED_EVENT, LABELED_EVENT, UNLABELED_EVENT, PULL_REQUEST_COMMIT]) {
+  timelineItems(first: 250, itemTypes: [ASSIGNED_EVENT, CLOSED_EVENT, ISSUE_COMMENT, RENAMED_TITLE_EVENT, MERGED_EVENT, PULL_REQUEST_REVIEW, PULL_REQUEST_REVIEW_THREAD, REOPENED_EVENT, REVIEW_DISMISSED_EVENT, REVIEW_REQUEST_REMOVED_EVENT, REVIEW_REQUESTED_EVENT, UNASSIGNED_EVENT, LABELED_EVENT, UNLABELED_EVENT, PULL_REQUEST_COMMIT%s]) {
     nodes {
       __typename
       ... on AssignedEvent {
@@ -922,7 +1243,7 @@ fragment pr on PullRequest {
           ...actor
         }
         createdAt
-      }
+      }%s
       ... on ReviewDismissedEvent {
         actor {
           ...actor
@@ -3,6 +3,7 @@ package github
 import (
 	"context"
 	"fmt"
+	"net/url"
 
 	"github.com/google/go-github/github"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
@@ -97,3 +98,25 @@ func (c *Client) ListRepositoryCollaborators(ctx context.Context, owner, repo st
 	}
 	return users, len(users) > 0, nil
 }
+
+// GetUser returns the GitHub user with the given login.
+func (c *Client) GetUser(ctx context.Context, login string) (*github.User, error) {
+	var user github.User
+	if err := c.requestGet(ctx, "/users/"+url.PathEscape(login), &user); err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+// IsRepositoryCollaborator reports whether the GitHub user with the given login has
+// access to the repository, either directly or through the organization.
+func (c *Client) IsRepositoryCollaborator(ctx context.Context, owner, repo, login string) (bool, error) {
+	path := fmt.Sprintf("/repos/%s/%s/collaborators/%s", owner, repo, url.PathEscape(login))
+	err := c.requestGet(ctx, path, nil)
+	if IsNotFound(err) {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+	return true, nil
+}
@@ -0,0 +1,84 @@
+package github
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/Masterminds/semver"
+	"github.com/pkg/errors"
+)
+
+// The first GitHub Enterprise versions that support features of the GitHub API
+// that the client uses if they're available. GitHub.com supports all of them.
+var (
+	// draftPullRequestsVersion is the first version that supports creating
+	// draft pull requests, PullRequest.isDraft and ReadyForReviewEvent.
+	draftPullRequestsVersion = semver.MustParse("2.17.0")
+	// convertToDraftEventVersion is the first version that supports
+	// ConvertToDraftEvent.
+	convertToDraftEventVersion = semver.MustParse("2.21.0")
+)
+
+// versionCacheTTL is how long the version of a GitHub Enterprise instance is
+// cached, so that upgrades are picked up without restarting.
+const versionCacheTTL = 1 * time.Hour
+
+type cachedVersion struct {
+	version   *semver.Version
+	fetchedAt time.Time
+}
+
+var versionCache = struct {
+	sync.Mutex
+	versions map[string]cachedVersion
+}{versions: map[string]cachedVersion{}}
+
+// enterpriseVersion returns the version of the GitHub Enterprise instance the
+// client connects to, or nil if it connects to GitHub.com.
+func (c *Client) enterpriseVersion(ctx context.Context) (*semver.Version, error) {
+	if c.githubDotCom {
+		return nil, nil
+	}
+
+	key := c.apiURL.String()
+	versionCache.Lock()
+	cached, ok := versionCache.versions[key]
+	versionCache.Unlock()
+	if ok && time.Since(cached.fetchedAt) < versionCacheTTL {
+		return cached.version, nil
+	}
+
+	var meta struct {
+		InstalledVersion string `json:"installed_version"`
+	}
+	if err := c.requestGet(ctx, "meta", &meta); err != nil {
+		return nil, errors.Wrap(err, "fetching GitHub Enterprise version")
+	}
+	v, err := semver.NewVersion(meta.InstalledVersion)
+	if err != nil {
+		return nil, errors.Wrapf(err, "parsing GitHub Enterprise version %q", meta.InstalledVersion)
+	}
+
+	versionCache.Lock()
+	versionCache.versions[key] = cachedVersion{version: v, fetchedAt: time.Now()}
+	versionCache.Unlock()
+
+	return v, nil
+}
+
+// supportsVersion returns whether the GitHub instance the client connects to
+// is at least the given GitHub Enterprise version. GitHub.com always is.
+func (c *Client) supportsVersion(ctx context.Context, min *semver.Version) (bool, error) {
+	v, err := c.enterpriseVersion(ctx)
+	if err != nil {
+		return false, err
+	}
+	return v == nil || !v.LessThan(min), nil
+}
+
+// SupportsDraftPullRequests returns whether draft pull requests can be
+// created on the GitHub instance the client connects to.
+func (c *Client) SupportsDraftPullRequests(ctx context.Context) (bool, error) {
+	return c.supportsVersion(ctx, draftPullRequestsVersion)
+}
@@ -0,0 +1,114 @@
+package github
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+// fakeEnterpriseDoer responds to requests for the version of a GitHub
+// Enterprise instance and records the GraphQL queries it receives.
+type fakeEnterpriseDoer struct {
+	version string
+	queries []string
+}
+
+func (d *fakeEnterpriseDoer) Do(req *http.Request) (*http.Response, error) {
+	var body string
+	switch {
+	case strings.HasSuffix(req.URL.Path, "/meta"):
+		body = `{"installed_version": "` + d.version + `"}`
+	case strings.HasSuffix(req.URL.Path, "/graphql"):
+		b, err := ioutil.ReadAll(req.Body)
+		if err != nil {
+			return nil, err
+		}
+		d.queries = append(d.queries, string(b))
+		body = `{"data": {}}`
+	default:
+		return &http.Response{StatusCode: http.StatusNotFound, Body: ioutil.NopCloser(strings.NewReader(`{}`))}, nil
+	}
+	return &http.Response{StatusCode: http.StatusOK, Body: ioutil.NopCloser(strings.NewReader(body))}, nil
+}
+
+func TestClient_pullRequestFragments(t *testing.T) {
+	tests := []struct {
+		version     string
+		wantPresent []string
+		wantAbsent  []string
+	}{
+		{
+			version:    "2.16.5",
+			wantAbsent: []string{"isDraft", "READY_FOR_REVIEW_EVENT", "ReadyForReviewEvent", "CONVERT_TO_DRAFT_EVENT", "ConvertToDraftEvent"},
+		},
+		{
+			version:     "2.20.0",
+			wantPresent: []string{"isDraft", "READY_FOR_REVIEW_EVENT", "ReadyForReviewEvent"},
+			wantAbsent:  []string{"CONVERT_TO_DRAFT_EVENT", "ConvertToDraftEvent"},
+		},
+		{
+			version:     "2.21.1",
+			wantPresent: []string{"isDraft", "READY_FOR_REVIEW_EVENT", "ReadyForReviewEvent", "CONVERT_TO_DRAFT_EVENT", "ConvertToDraftEvent"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.version, func(t *testing.T) {
+			apiURL := &url.URL{Scheme: "https", Host: "ghe-" + test.version + ".example.com", Path: "/api/v3"}
+			c := NewClient(apiURL, "", &fakeEnterpriseDoer{version: test.version})
+
+			fragments, err := c.pullRequestFragments(context.Background())
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, s := range test.wantPresent {
+				if !strings.Contains(fragments, s) {
+					t.Errorf("fragments don't contain %q", s)
+				}
+			}
+			for _, s := range test.wantAbsent {
+				if strings.Contains(fragments, s) {
+					t.Errorf("fragments contain %q", s)
+				}
+			}
+		})
+	}
+
+	t.Run("github.com", func(t *testing.T) {
+		c := NewClient(&url.URL{Scheme: "https", Host: "api.github.com"}, "", &fakeEnterpriseDoer{})
+
+		fragments, err := c.pullRequestFragments(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, s := range []string{"isDraft", "CONVERT_TO_DRAFT_EVENT, READY_FOR_REVIEW_EVENT", "ConvertToDraftEvent", "ReadyForReviewEvent"} {
+			if !strings.Contains(fragments, s) {
+				t.Errorf("fragments don't contain %q", s)
+			}
+		}
+	})
+}
+
+func TestClient_CreatePullRequest_DraftNotSupported(t *testing.T) {
+	doer := &fakeEnterpriseDoer{version: "2.16.0"}
+	c := NewClient(&url.URL{Scheme: "https", Host: "ghe-drafts.example.com", Path: "/api/v3"}, "", doer)
+
+	_, err := c.CreatePullRequest(context.Background(), &CreatePullRequestInput{Draft: true})
+	if err != ErrDraftPullRequestsNotSupported {
+		t.Fatalf("got error %v, want %v", err, ErrDraftPullRequestsNotSupported)
+	}
+	if len(doer.queries) != 0 {
+		t.Fatalf("got GraphQL requests %v, want none", doer.queries)
+	}
+
+	// Non-draft pull requests don't send the draft field.
+	_, _ = c.CreatePullRequest(context.Background(), &CreatePullRequestInput{})
+	if len(doer.queries) != 1 {
+		t.Fatalf("got %d GraphQL requests, want 1", len(doer.queries))
+	}
+	if strings.Contains(doer.queries[0], `"draft"`) || strings.Contains(doer.queries[0], "isDraft") {
+		t.Fatalf("request contains draft fields: %s", doer.queries[0])
+	}
+}
@@ -1,8 +1,10 @@
 package gitlab
 
 type Label struct {
-	ID          ID     `json:"id"`
-	Name        string `json:"name"`
+	ID   ID     `json:"id"`
+	Name string `json:"name"`
+	// Title is used instead of Name in webhook payloads.
+	Title       string `json:"title"`
 	Color       string `json:"color"`
 	TextColor   string `json:"text_color"`
 	Description string `json:"description"`
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"strings"
 
 	"github.com/pkg/errors"
 )
@@ -38,6 +39,9 @@ type MergeRequest struct {
 	SourceBranch string            `json:"source_branch"`
 	TargetBranch string            `json:"target_branch"`
 	WebURL       string            `json:"web_url"`
+	// WorkInProgress is true if the merge request is a draft, which GitLab
+	// determines from a "Draft:" or "WIP:" prefix of its title.
+	WorkInProgress bool `json:"work_in_progress"`
 
 	DiffRefs DiffRefs `json:"diff_refs"`
 
@@ -157,6 +161,76 @@ func (c *Client) GetOpenMergeRequestByRefs(ctx context.Context, project *Project
 	return c.GetMergeRequest(ctx, project, resp[0].IID)
 }
 
+// ListMergeRequestsOpts are the options for ListMergeRequests.
+type ListMergeRequestsOpts struct {
+	// State restricts the merge requests to those in the given state. If it's
+	// empty, merge requests in all states are listed.
+	State MergeRequestState
+	// Labels restricts the merge requests to those that have all of the given
+	// labels.
+	Labels []string
+	// SourceBranch restricts the merge requests to those opened from the
+	// given branch.
+	SourceBranch string
+}
+
+// ListMergeRequests retrieves the merge requests of the given project that
+// match the given options. As the merge requests are paginated, a function is
+// returned that may be invoked to return the next page of results. An empty
+// slice and a nil error indicates that all pages have been returned.
+//
+// The list endpoint doesn't return all of the fields that GetMergeRequest
+// returns, so callers that need those should get the merge requests
+// individually.
+func (c *Client) ListMergeRequests(ctx context.Context, project *Project, opts ListMergeRequestsOpts) func() ([]*MergeRequest, error) {
+	if MockListMergeRequests != nil {
+		return MockListMergeRequests(c, ctx, project, opts)
+	}
+
+	values := make(url.Values)
+	values.Add("per_page", "100")
+	if opts.State != "" {
+		values.Add("state", string(opts.State))
+	}
+	if len(opts.Labels) > 0 {
+		values.Add("labels", strings.Join(opts.Labels, ","))
+	}
+	if opts.SourceBranch != "" {
+		values.Add("source_branch", opts.SourceBranch)
+	}
+
+	path := fmt.Sprintf("projects/%d/merge_requests", project.ID)
+	nextPage := "1"
+	return func() ([]*MergeRequest, error) {
+		page := []*MergeRequest{}
+
+		// If there aren't any further pages, we'll return the empty slice we
+		// just created.
+		if nextPage == "" {
+			return page, nil
+		}
+
+		values.Set("page", nextPage)
+		u := &url.URL{Path: path, RawQuery: values.Encode()}
+
+		req, err := http.NewRequest("GET", u.String(), nil)
+		if err != nil {
+			return nil, errors.Wrap(err, "creating request to list merge requests")
+		}
+
+		header, _, err := c.do(ctx, req, &page)
+		if err != nil {
+			return nil, errors.Wrap(err, "sending request to list merge requests")
+		}
+
+		// X-Next-Page is the number of the next page, and empty on the last
+		// page.
+		nextPage = header.Get("X-Next-Page")
+
+		return page, nil
+	}
+}
+
 type UpdateMergeRequestOpts struct {
 	TargetBranch string                       `json:"target_branch"`
 	Title        string                       `json:"title"`
@@ -201,3 +275,23 @@ func (c *Client) UpdateMergeRequest(ctx context.Context, project *Project, mr *M
 
 	return resp, nil
 }
+
+// MergeMergeRequest accepts the merge request, merging it into its target
+// branch.
+func (c *Client) MergeMergeRequest(ctx context.Context, project *Project, mr *MergeRequest) (*MergeRequest, error) {
+	if MockMergeMergeRequest != nil {
+		return MockMergeMergeRequest(c, ctx, project, mr)
+	}
+
+	req, err := http.NewRequest("PUT", fmt.Sprintf("projects/%d/merge_requests/%d/merge", project.ID, mr.IID), nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "creating request to merge a merge request")
+	}
+
+	resp := &MergeRequest{}
+	if _, _, err := c.do(ctx, req, resp); err != nil {
+		return nil, errors.Wrap(err, "sending request to merge a merge request")
+	}
+
+	return resp, nil
+}
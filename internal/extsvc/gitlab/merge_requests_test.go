@@ -3,9 +3,11 @@ package gitlab
 import (
 	"context"
 	"net/http"
+	"net/url"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
 )
 
 func TestCreateMergeRequest(t *testing.T) {
@@ -272,6 +274,97 @@ func TestGetOpenMergeRequestByRefs(t *testing.T) {
 	})
 }
 
+func TestListMergeRequests(t *testing.T) {
+	ctx := context.Background()
+	project := &Project{ProjectCommon: ProjectCommon{ID: 1}}
+
+	assertNextPage := func(t *testing.T, it func() ([]*MergeRequest, error), want []*MergeRequest) {
+		t.Helper()
+		mrs, err := it()
+		if diff := cmp.Diff(mrs, want); diff != "" {
+			t.Errorf("unexpected merge requests: %s", diff)
+		}
+		if err != nil {
+			t.Errorf("unexpected error: %+v", err)
+		}
+	}
+
+	t.Run("error status code", func(t *testing.T) {
+		client := newTestClient(t)
+		client.httpClient = &mockHTTPEmptyResponse{http.StatusNotFound}
+
+		it := client.ListMergeRequests(ctx, project, ListMergeRequestsOpts{})
+		mrs, err := it()
+		if mrs != nil {
+			t.Errorf("unexpected non-nil merge requests: %+v", mrs)
+		}
+		if err == nil {
+			t.Error("unexpected nil error")
+		}
+	})
+
+	t.Run("options", func(t *testing.T) {
+		doer := &mockHTTPResponseBody{responseBody: `[]`}
+		var requested *url.URL
+		client := newTestClient(t)
+		client.httpClient = httpcli.DoerFunc(func(req *http.Request) (*http.Response, error) {
+			requested = req.URL
+			return doer.Do(req)
+		})
+
+		it := client.ListMergeRequests(ctx, project, ListMergeRequestsOpts{
+			State:        MergeRequestStateOpened,
+			Labels:       []string{"a", "b"},
+			SourceBranch: "migration",
+		})
+		assertNextPage(t, it, []*MergeRequest{})
+
+		if requested == nil {
+			t.Fatal("no request sent")
+		}
+		if have, want := requested.Path, "/projects/1/merge_requests"; have != want {
+			t.Errorf("unexpected path: have %q; want %q", have, want)
+		}
+		want := url.Values{
+			"labels":        {"a,b"},
+			"page":          {"1"},
+			"per_page":      {"100"},
+			"source_branch": {"migration"},
+			"state":         {"opened"},
+		}
+		if diff := cmp.Diff(want, requested.Query()); diff != "" {
+			t.Errorf("unexpected query: %s", diff)
+		}
+	})
+
+	t.Run("multiple pages", func(t *testing.T) {
+		header := make(http.Header)
+		header.Add("X-Next-Page", "2")
+
+		var pages []string
+		doer := &mockHTTPResponseBody{header: header, responseBody: `[{"iid":1},{"iid":2}]`}
+		client := newTestClient(t)
+		client.httpClient = httpcli.DoerFunc(func(req *http.Request) (*http.Response, error) {
+			pages = append(pages, req.URL.Query().Get("page"))
+			return doer.Do(req)
+		})
+
+		it := client.ListMergeRequests(ctx, project, ListMergeRequestsOpts{})
+		assertNextPage(t, it, []*MergeRequest{{IID: 1}, {IID: 2}})
+
+		doer.header = nil
+		doer.responseBody = `[{"iid":3}]`
+		assertNextPage(t, it, []*MergeRequest{{IID: 3}})
+
+		// Calls after iteration should continue to return empty pages.
+		assertNextPage(t, it, []*MergeRequest{})
+
+		if diff := cmp.Diff([]string{"1", "2"}, pages); diff != "" {
+			t.Errorf("unexpected pages requested: %s", diff)
+		}
+	})
+}
+
 func TestUpdateMergeRequest(t *testing.T) {
 	ctx := context.Background()
 	empty := &MergeRequest{}
@@ -339,3 +432,37 @@ func TestUpdateMergeRequest(t *testing.T) {
 	})
 
 }
+
+func TestMergeMergeRequest(t *testing.T) {
+	ctx := context.Background()
+	mr := &MergeRequest{IID: 42}
+	project := &Project{}
+
+	t.Run("error status code", func(t *testing.T) {
+		client := newTestClient(t)
+		client.httpClient = &mockHTTPEmptyResponse{http.StatusMethodNotAllowed}
+
+		have, err := client.MergeMergeRequest(ctx, project, mr)
+		if have != nil {
+			t.Errorf("unexpected non-nil merge request: %+v", have)
+		}
+		if err == nil {
+			t.Error("unexpected nil error")
+		}
+	})
+
+	t.Run("success", func(t *testing.T) {
+		client := newTestClient(t)
+		client.httpClient = &mockHTTPResponseBody{
+			responseBody: `{"iid":42,"state":"merged"}`,
+		}
+
+		have, err := client.MergeMergeRequest(ctx, project, mr)
+		if err != nil {
+			t.Fatalf("unexpected non-nil error: %+v", err)
+		}
+		if diff := cmp.Diff(have, &MergeRequest{IID: 42, State: MergeRequestStateMerged}); diff != "" {
+			t.Errorf("unexpected merge request: %s", diff)
+		}
+	})
+}
@@ -1,9 +1,13 @@
 package gitlab
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
+	"strings"
+	"time"
 
 	"github.com/pkg/errors"
 )
@@ -46,6 +50,33 @@ func (c *Client) GetMergeRequestNotes(ctx context.Context, project *Project, iid
 	}
 }
 
+// CreateMergeRequestNote creates a note with the given body on the given merge
+// request.
+func (c *Client) CreateMergeRequestNote(ctx context.Context, project *Project, mr *MergeRequest, body string) (*Note, error) {
+	if MockCreateMergeRequestNote != nil {
+		return MockCreateMergeRequestNote(c, ctx, project, mr, body)
+	}
+
+	data, err := json.Marshal(struct {
+		Body string `json:"body"`
+	}{Body: body})
+	if err != nil {
+		return nil, errors.Wrap(err, "marshalling note")
+	}
+
+	req, err := http.NewRequest("POST", fmt.Sprintf("projects/%d/merge_requests/%d/notes", project.ID, mr.IID), bytes.NewBuffer(data))
+	if err != nil {
+		return nil, errors.Wrap(err, "creating request to create a note")
+	}
+
+	resp := &Note{}
+	if _, _, err := c.do(ctx, req, resp); err != nil {
+		return nil, errors.Wrap(err, "sending request to create a note")
+	}
+
+	return resp, nil
+}
+
 type Note struct {
 	ID        ID     `json:"id"`
 	Body      string `json:"body"`
@@ -55,6 +86,11 @@ type Note struct {
 }
 
 func (n *Note) Key() string {
+	// Notes that are synthesised from webhook payloads don't have an ID, so we
+	// key them by their author, body and creation time instead.
+	if n.ID == 0 {
+		return fmt.Sprintf("Note:%s:%s:%s", n.Author.Username, n.Body, n.CreatedAt.Format(time.RFC3339))
+	}
 	return fmt.Sprintf("Note:%d", n.ID)
 }
 
@@ -80,3 +116,27 @@ func (n *Note) ToReview() interface{} {
 
 	return nil
 }
+
+type MarkWorkInProgressEvent struct{ *Note }
+type UnmarkWorkInProgressEvent struct{ *Note }
+
+// ToWorkInProgressEvent returns a pointer to a MarkWorkInProgressEvent or
+// UnmarkWorkInProgressEvent struct, or nil if the Note is not a system note
+// changing the draft status of the merge request.
+func (n *Note) ToWorkInProgressEvent() interface{} {
+	if !n.System {
+		return nil
+	}
+
+	switch {
+	// GitLab 13.2 renamed "Work In Progress" merge requests to drafts.
+	case strings.HasPrefix(n.Body, "marked as a **Work In Progress**"),
+		n.Body == "marked this merge request as **draft**":
+		return &MarkWorkInProgressEvent{n}
+	case strings.HasPrefix(n.Body, "unmarked as a **Work In Progress**"),
+		n.Body == "marked this merge request as **ready**":
+		return &UnmarkWorkInProgressEvent{n}
+	}
+
+	return nil
+}
@@ -177,3 +177,78 @@ func TestNoteToReview(t *testing.T) {
 		}
 	})
 }
+
+func TestNoteToWorkInProgressEvent(t *testing.T) {
+	t.Run("non-system note", func(t *testing.T) {
+		note := &Note{System: false, Body: "marked as a **Work In Progress**"}
+		if v := note.ToWorkInProgressEvent(); v != nil {
+			t.Errorf("unexpected non-nil ToWorkInProgressEvent value: %+v", v)
+		}
+	})
+
+	t.Run("system, unrelated note", func(t *testing.T) {
+		note := &Note{System: true, Body: "approved this merge request"}
+		if v := note.ToWorkInProgressEvent(); v != nil {
+			t.Errorf("unexpected non-nil ToWorkInProgressEvent value: %+v", v)
+		}
+	})
+
+	for _, body := range []string{
+		"marked as a **Work In Progress**",
+		"marked as a **Work In Progress** from 0123abcd",
+		"marked this merge request as **draft**",
+	} {
+		t.Run("system, mark note: "+body, func(t *testing.T) {
+			note := &Note{System: true, Body: body}
+			if v, ok := note.ToWorkInProgressEvent().(*MarkWorkInProgressEvent); v == nil || !ok {
+				t.Errorf("unexpected ToWorkInProgressEvent value: %+v", v)
+			}
+		})
+	}
+
+	for _, body := range []string{
+		"unmarked as a **Work In Progress**",
+		"marked this merge request as **ready**",
+	} {
+		t.Run("system, unmark note: "+body, func(t *testing.T) {
+			note := &Note{System: true, Body: body}
+			if v, ok := note.ToWorkInProgressEvent().(*UnmarkWorkInProgressEvent); v == nil || !ok {
+				t.Errorf("unexpected ToWorkInProgressEvent value: %+v", v)
+			}
+		})
+	}
+}
+
+func TestCreateMergeRequestNote(t *testing.T) {
+	ctx := context.Background()
+	mr := &MergeRequest{IID: 42}
+	project := &Project{}
+
+	t.Run("error status code", func(t *testing.T) {
+		client := newTestClient(t)
+		client.httpClient = &mockHTTPEmptyResponse{http.StatusNotFound}
+
+		note, err := client.CreateMergeRequestNote(ctx, project, mr, "hello")
+		if note != nil {
+			t.Errorf("unexpected non-nil note: %+v", note)
+		}
+		if err == nil {
+			t.Error("unexpected nil error")
+		}
+	})
+
+	t.Run("success", func(t *testing.T) {
+		client := newTestClient(t)
+		client.httpClient = &mockHTTPResponseBody{
+			responseBody: `{"id":1,"body":"hello"}`,
+		}
+
+		note, err := client.CreateMergeRequestNote(ctx, project, mr, "hello")
+		if err != nil {
+			t.Fatalf("unexpected non-nil error: %+v", err)
+		}
+		if diff := cmp.Diff(note, &Note{ID: 1, Body: "hello"}); diff != "" {
+			t.Errorf("unexpected note: %s", diff)
+		}
+	})
+}
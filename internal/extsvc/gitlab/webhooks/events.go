@@ -31,7 +31,8 @@ type downcaster interface {
 }
 
 // UnmarshalEvent unmarshals the given JSON into an event type. Possible return
-// types are *MergeRequestEvent.
+// types are the merge request event types, *NoteEvent, *PipelineEvent and
+// *MemberEvent.
 //
 // Errors caused by a valid payload being of an unknown type may be
 // distinguished from other errors by checking for ErrObjectKindUnknown in the
@@ -44,9 +45,11 @@ func UnmarshalEvent(data []byte) (interface{}, error) {
 	// Since we only care about the object_kind field, we'll start by
 	// unmarshalling into a minimal type that only has that field. We use
 	// object_kind instead of event_type because not all GitLab webhook types
-	// include event_type, whereas object_kind is generally reliable.
+	// include event_type, whereas object_kind is generally reliable. The
+	// exception are member events, which only include event_name.
 	var event struct {
 		ObjectKind string `json:"object_kind"`
+		EventName  string `json:"event_name"`
 	}
 	if err := json.Unmarshal(data, &event); err != nil {
 		return nil, errors.Wrap(err, "determining object kind")
@@ -57,8 +60,15 @@ func UnmarshalEvent(data []byte) (interface{}, error) {
 	switch event.ObjectKind {
 	case "merge_request":
 		typedEvent = &mergeRequestEvent{}
+	case "note":
+		typedEvent = &noteEvent{}
 	case "pipeline":
 		typedEvent = &PipelineEvent{}
+	case "":
+		if !memberEventNames[event.EventName] {
+			return nil, errors.Wrapf(ErrObjectKindUnknown, "event name: %s", event.EventName)
+		}
+		typedEvent = &MemberEvent{}
 	default:
 		return nil, errors.Wrapf(ErrObjectKindUnknown, "kind: %s", event.ObjectKind)
 	}
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"testing"
 
+	"github.com/google/go-cmp/cmp"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitlab"
 )
 
@@ -88,6 +89,51 @@ func TestUnmarshalEvent(t *testing.T) {
 		}
 	})
 
+	t.Run("valid note", func(t *testing.T) {
+		event, err := UnmarshalEvent([]byte(`
+			{
+				"object_kind": "note",
+				"user": {
+					"username": "admin"
+				},
+				"merge_request": {
+					"iid": 42,
+					"labels": [{"id": 1, "title": "bug"}]
+				},
+				"object_attributes":{
+					"id": 123,
+					"note": "LGTM",
+					"noteable_type": "MergeRequest"
+				}
+			}
+		`))
+		if event == nil {
+			t.Error("unexpected nil event")
+		}
+		if err != nil {
+			t.Errorf("unexpected error: %+v", err)
+		}
+
+		ne := event.(*NoteEvent)
+		if want := gitlab.ID(42); ne.MergeRequest.IID != want {
+			t.Errorf("unexpected IID: have %d; want %d", ne.MergeRequest.IID, want)
+		}
+		if diff := cmp.Diff([]string{"bug"}, ne.MergeRequest.Labels); diff != "" {
+			t.Errorf("unexpected labels: %s", diff)
+		}
+
+		note := ne.ToNote()
+		if want := gitlab.ID(123); note.ID != want {
+			t.Errorf("unexpected note ID: have %d; want %d", note.ID, want)
+		}
+		if want := "LGTM"; note.Body != want {
+			t.Errorf("unexpected note body: have %s; want %s", note.Body, want)
+		}
+		if want := "admin"; note.Author.Username != want {
+			t.Errorf("unexpected note author: have %s; want %s", note.Author.Username, want)
+		}
+	})
+
 	t.Run("valid pipeline", func(t *testing.T) {
 		event, err := UnmarshalEvent([]byte(`
 			{
@@ -109,4 +155,25 @@ func TestUnmarshalEvent(t *testing.T) {
 			t.Errorf("unexpected IID: have %d; want %d", pe.Pipeline.ID, want)
 		}
 	})
+
+	t.Run("valid member", func(t *testing.T) {
+		event, err := UnmarshalEvent([]byte(`
+			{
+				"event_name": "user_remove_from_group",
+				"group_id": 78,
+				"group_path": "acme",
+				"user_id": 64,
+				"user_username": "alice",
+				"group_access": "Developer"
+			}
+		`))
+		if err != nil {
+			t.Fatalf("unexpected error: %+v", err)
+		}
+
+		me := event.(*MemberEvent)
+		if want := (MemberEvent{EventName: "user_remove_from_group", UserID: 64, UserUsername: "alice", GroupID: 78}); *me != want {
+			t.Errorf("unexpected event: have %+v; want %+v", *me, want)
+		}
+	})
 }
@@ -0,0 +1,49 @@
+package webhooks
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitlab"
+)
+
+// LabelEvent represents a label being added to or removed from a merge
+// request. GitLab doesn't have a webhook event for this, so LabelEvents are
+// derived from the changes included in merge request update events.
+type LabelEvent struct {
+	MergeRequestIID gitlab.ID    `json:"merge_request_iid"`
+	Label           gitlab.Label `json:"label"`
+	User            gitlab.User  `json:"user"`
+	CreatedAt       gitlab.Time  `json:"created_at"`
+	Removed         bool         `json:"removed"`
+}
+
+func (e *LabelEvent) Key() string {
+	action := "add"
+	if e.Removed {
+		action = "remove"
+	}
+	return fmt.Sprintf("Label:%d:%d:%s:%s", e.MergeRequestIID, e.Label.ID, action, e.CreatedAt.Format(time.RFC3339))
+}
+
+// Name returns the name of the label.
+func (e *LabelEvent) Name() string {
+	if e.Label.Title != "" {
+		return e.Label.Title
+	}
+	return e.Label.Name
+}
+
+// labelTitles converts the label objects included in webhook payloads into the
+// label names that the REST API returns for merge requests.
+func labelTitles(labels []gitlab.Label) []string {
+	if labels == nil {
+		return nil
+	}
+
+	titles := make([]string, 0, len(labels))
+	for _, l := range labels {
+		titles = append(titles, l.Title)
+	}
+	return titles
+}
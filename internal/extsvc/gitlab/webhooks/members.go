@@ -0,0 +1,25 @@
+package webhooks
+
+// MemberEvent is sent when a user is added to, removed from or has their access level updated
+// in a group (by group webhooks and system hooks) or a project (by system hooks). Member
+// events don't have an object kind, so they are identified by their event name.
+type MemberEvent struct {
+	EventName    string `json:"event_name"`
+	UserID       int    `json:"user_id"`
+	UserUsername string `json:"user_username"`
+
+	// GroupID is only set for group member events, and ProjectID for project member events.
+	GroupID     int    `json:"group_id"`
+	ProjectID   int    `json:"project_id"`
+	AccessLevel string `json:"access_level"`
+}
+
+// memberEventNames are the event names of group and project member events.
+var memberEventNames = map[string]bool{
+	"user_add_to_group":      true,
+	"user_remove_from_group": true,
+	"user_update_for_group":  true,
+	"user_add_to_team":       true,
+	"user_remove_from_team":  true,
+	"user_update_for_team":   true,
+}
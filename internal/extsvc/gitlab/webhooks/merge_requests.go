@@ -1,7 +1,9 @@
 package webhooks
 
 import (
+	"encoding/json"
 	"fmt"
+	"sort"
 	"time"
 
 	"github.com/pkg/errors"
@@ -48,7 +50,12 @@ type MergeRequestCloseEvent struct{ MergeRequestEventCommon }
 type MergeRequestMergeEvent struct{ MergeRequestEventCommon }
 type MergeRequestReopenEvent struct{ MergeRequestEventCommon }
 type MergeRequestUnapprovedEvent struct{ MergeRequestEventCommon }
-type MergeRequestUpdateEvent struct{ MergeRequestEventCommon }
+
+type MergeRequestUpdateEvent struct {
+	MergeRequestEventCommon
+
+	Changes MergeRequestChanges
+}
 
 func (e *MergeRequestApprovedEvent) ToEvent() *MergeRequestEventCommon {
 	return &e.MergeRequestEventCommon
@@ -70,10 +77,93 @@ func (e *MergeRequestUpdateEvent) ToEvent() *MergeRequestEventCommon {
 }
 
 // We don't define Key() methods on MergeRequestApprovedEvent and
-// MergeRequestUnapprovedEvent because we don't need them when handling
-// webhooks: those events don't include enough information (specifically, the
-// system note ID) for us to create changeset events from them, so those types
-// don't need to implement keyer.
+// MergeRequestUnapprovedEvent because they aren't stored as changeset events
+// themselves: instead, they're converted into the same review types that we
+// derive from the system notes GitLab creates for approvals, so that webhooks
+// and syncs result in the same changeset events.
+
+// ToReview returns the approval as a gitlab.ReviewApproved. The webhook
+// doesn't include the ID of the system note GitLab creates for the approval,
+// so the note is synthesised from the user and update time of the event.
+func (e *MergeRequestApprovedEvent) ToReview() *gitlab.ReviewApproved {
+	return &gitlab.ReviewApproved{Note: e.systemNote("approved this merge request")}
+}
+
+// ToReview returns the unapproval as a gitlab.ReviewUnapproved. See
+// MergeRequestApprovedEvent.ToReview for the caveats.
+func (e *MergeRequestUnapprovedEvent) ToReview() *gitlab.ReviewUnapproved {
+	return &gitlab.ReviewUnapproved{Note: e.systemNote("unapproved this merge request")}
+}
+
+func (e *MergeRequestEventCommon) systemNote(body string) *gitlab.Note {
+	note := &gitlab.Note{
+		Body:      body,
+		CreatedAt: e.MergeRequest.UpdatedAt,
+		System:    true,
+	}
+	if e.User != nil {
+		note.Author = *e.User
+	}
+	return note
+}
+
+// LabelEvents returns a LabelEvent for each label that was added to or removed
+// from the merge request by the update.
+func (e *MergeRequestUpdateEvent) LabelEvents() []*LabelEvent {
+	if e.Changes.Labels == nil {
+		return nil
+	}
+
+	previous := make(map[gitlab.ID]gitlab.Label, len(e.Changes.Labels.Previous))
+	for _, l := range e.Changes.Labels.Previous {
+		previous[l.ID] = l
+	}
+	current := make(map[gitlab.ID]gitlab.Label, len(e.Changes.Labels.Current))
+	for _, l := range e.Changes.Labels.Current {
+		current[l.ID] = l
+	}
+
+	var events []*LabelEvent
+	newEvent := func(l gitlab.Label, removed bool) *LabelEvent {
+		event := &LabelEvent{
+			MergeRequestIID: e.MergeRequest.IID,
+			Label:           l,
+			CreatedAt:       e.MergeRequest.UpdatedAt,
+			Removed:         removed,
+		}
+		if e.User != nil {
+			event.User = *e.User
+		}
+		return event
+	}
+	for _, l := range e.Changes.Labels.Current {
+		if _, ok := previous[l.ID]; !ok {
+			events = append(events, newEvent(l, false))
+		}
+	}
+	for _, l := range e.Changes.Labels.Previous {
+		if _, ok := current[l.ID]; !ok {
+			events = append(events, newEvent(l, true))
+		}
+	}
+	return events
+}
+
+// OnlyLabelsChanged returns true if the update didn't change anything but the
+// labels of the merge request, which are fully described by LabelEvents.
+func (e *MergeRequestUpdateEvent) OnlyLabelsChanged() bool {
+	if e.Changes.Labels == nil {
+		return false
+	}
+	for _, field := range e.Changes.Fields {
+		switch field {
+		case "labels", "updated_at", "updated_by_id":
+		default:
+			return false
+		}
+	}
+	return true
+}
 
 func (e *MergeRequestCloseEvent) Key() string  { return e.key("Close") }
 func (e *MergeRequestMergeEvent) Key() string  { return e.key("Merge") }
@@ -95,14 +185,59 @@ type mergeRequestEvent struct {
 	Labels *[]gitlab.Label `json:"labels"`
 
 	ObjectAttributes mergeRequestEventObjectAttributes `json:"object_attributes"`
+	Changes          MergeRequestChanges               `json:"changes"`
 }
 
 type mergeRequestEventObjectAttributes struct {
 	*gitlab.MergeRequest
 	Action string `json:"action"`
+
+	// Unlike the REST API, webhook payloads include the labels of the merge
+	// request as objects rather than strings, so we need to shadow the Labels
+	// field of gitlab.MergeRequest.
+	Labels []gitlab.Label `json:"labels"`
+}
+
+// MergeRequestChanges describes the attributes of a merge request that were
+// changed by an update.
+type MergeRequestChanges struct {
+	// Fields contains the names of all attributes that were changed.
+	Fields []string
+	// Labels is nil if the labels weren't changed.
+	Labels *LabelChanges
+}
+
+type LabelChanges struct {
+	Previous []gitlab.Label `json:"previous"`
+	Current  []gitlab.Label `json:"current"`
+}
+
+func (c *MergeRequestChanges) UnmarshalJSON(data []byte) error {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	c.Fields = make([]string, 0, len(raw))
+	for field := range raw {
+		c.Fields = append(c.Fields, field)
+	}
+	sort.Strings(c.Fields)
+
+	if labels, ok := raw["labels"]; ok {
+		c.Labels = &LabelChanges{}
+		if err := json.Unmarshal(labels, c.Labels); err != nil {
+			return errors.Wrap(err, "unmarshalling label changes")
+		}
+	}
+	return nil
 }
 
 func (mre *mergeRequestEvent) downcast() (interface{}, error) {
+	if mr := mre.ObjectAttributes.MergeRequest; mr != nil && mre.ObjectAttributes.Labels != nil {
+		mr.Labels = labelTitles(mre.ObjectAttributes.Labels)
+	}
+
 	e := MergeRequestEventCommon{
 		EventCommon:  mre.EventCommon,
 		MergeRequest: mre.ObjectAttributes.MergeRequest,
@@ -134,7 +269,7 @@ func (mre *mergeRequestEvent) downcast() (interface{}, error) {
 		return &MergeRequestUnapprovedEvent{e}, nil
 
 	case "update":
-		return &MergeRequestUpdateEvent{e}, nil
+		return &MergeRequestUpdateEvent{MergeRequestEventCommon: e, Changes: mre.Changes}, nil
 	}
 
 	return nil, errors.Wrapf(ErrObjectKindUnknown, "unknown merge request event action: %s", mre.ObjectAttributes.Action)
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitlab"
@@ -81,3 +82,96 @@ func TestMergeRequestDowncast(t *testing.T) {
 		}
 	})
 }
+
+func TestMergeRequestUpdateEventLabelEvents(t *testing.T) {
+	bug := gitlab.Label{ID: 1, Title: "bug"}
+	feature := gitlab.Label{ID: 2, Title: "feature"}
+	docs := gitlab.Label{ID: 3, Title: "docs"}
+	user := gitlab.User{Username: "admin"}
+	updatedAt := gitlab.Time{Time: time.Date(2020, 6, 1, 11, 0, 0, 0, time.UTC)}
+
+	newEvent := func(changes MergeRequestChanges) *MergeRequestUpdateEvent {
+		return &MergeRequestUpdateEvent{
+			MergeRequestEventCommon: MergeRequestEventCommon{
+				User: &user,
+				MergeRequest: &gitlab.MergeRequest{
+					IID:       42,
+					UpdatedAt: updatedAt,
+				},
+			},
+			Changes: changes,
+		}
+	}
+
+	t.Run("no label changes", func(t *testing.T) {
+		e := newEvent(MergeRequestChanges{Fields: []string{"title"}})
+		if have := e.LabelEvents(); have != nil {
+			t.Errorf("unexpected label events: %+v", have)
+		}
+		if e.OnlyLabelsChanged() {
+			t.Error("unexpected true from OnlyLabelsChanged")
+		}
+	})
+
+	t.Run("label changes", func(t *testing.T) {
+		e := newEvent(MergeRequestChanges{
+			Fields: []string{"labels", "updated_at"},
+			Labels: &LabelChanges{
+				Previous: []gitlab.Label{bug, docs},
+				Current:  []gitlab.Label{bug, feature},
+			},
+		})
+
+		want := []*LabelEvent{
+			{MergeRequestIID: 42, Label: feature, User: user, CreatedAt: updatedAt},
+			{MergeRequestIID: 42, Label: docs, User: user, CreatedAt: updatedAt, Removed: true},
+		}
+		if diff := cmp.Diff(want, e.LabelEvents()); diff != "" {
+			t.Errorf("unexpected label events: %s", diff)
+		}
+		if !e.OnlyLabelsChanged() {
+			t.Error("unexpected false from OnlyLabelsChanged")
+		}
+	})
+
+	t.Run("label and other changes", func(t *testing.T) {
+		e := newEvent(MergeRequestChanges{
+			Fields: []string{"labels", "title"},
+			Labels: &LabelChanges{Current: []gitlab.Label{bug}},
+		})
+
+		if have := len(e.LabelEvents()); have != 1 {
+			t.Errorf("unexpected number of label events: have %d; want 1", have)
+		}
+		if e.OnlyLabelsChanged() {
+			t.Error("unexpected true from OnlyLabelsChanged")
+		}
+	})
+}
+
+func TestMergeRequestApprovalReviews(t *testing.T) {
+	user := gitlab.User{Username: "admin"}
+	updatedAt := gitlab.Time{Time: time.Date(2020, 6, 1, 11, 0, 0, 0, time.UTC)}
+	common := MergeRequestEventCommon{
+		User:         &user,
+		MergeRequest: &gitlab.MergeRequest{UpdatedAt: updatedAt},
+	}
+
+	approved := (&MergeRequestApprovedEvent{common}).ToReview()
+	unapproved := (&MergeRequestUnapprovedEvent{common}).ToReview()
+
+	for _, note := range []*gitlab.Note{approved.Note, unapproved.Note} {
+		if !note.System {
+			t.Errorf("unexpected non-system note: %+v", note)
+		}
+		if diff := cmp.Diff(user, note.Author); diff != "" {
+			t.Errorf("unexpected author: %s", diff)
+		}
+		if note.CreatedAt != updatedAt {
+			t.Errorf("unexpected creation time: have %v; want %v", note.CreatedAt, updatedAt)
+		}
+	}
+	if approved.Key() == unapproved.Key() {
+		t.Errorf("unexpected identical keys: %s", approved.Key())
+	}
+}
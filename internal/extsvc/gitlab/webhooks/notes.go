@@ -0,0 +1,64 @@
+package webhooks
+
+import "github.com/sourcegraph/sourcegraph/internal/extsvc/gitlab"
+
+// NoteEvent is sent when a comment is created on a merge request, commit,
+// issue or snippet. MergeRequest is nil unless the comment was made on a merge
+// request.
+type NoteEvent struct {
+	EventCommon
+
+	User         gitlab.User
+	MergeRequest *gitlab.MergeRequest
+
+	ObjectAttributes noteEventObjectAttributes
+}
+
+type noteEvent struct {
+	EventCommon
+
+	User             gitlab.User               `json:"user"`
+	MergeRequest     *noteEventMergeRequest    `json:"merge_request"`
+	ObjectAttributes noteEventObjectAttributes `json:"object_attributes"`
+}
+
+type noteEventMergeRequest struct {
+	*gitlab.MergeRequest
+
+	// As with merge request events, the labels are included as objects rather
+	// than strings, so we need to shadow the Labels field of
+	// gitlab.MergeRequest.
+	Labels []gitlab.Label `json:"labels"`
+}
+
+type noteEventObjectAttributes struct {
+	ID           gitlab.ID   `json:"id"`
+	Note         string      `json:"note"`
+	NoteableType string      `json:"noteable_type"`
+	System       bool        `json:"system"`
+	CreatedAt    gitlab.Time `json:"created_at"`
+}
+
+func (ne *noteEvent) downcast() (interface{}, error) {
+	e := &NoteEvent{
+		EventCommon:      ne.EventCommon,
+		User:             ne.User,
+		ObjectAttributes: ne.ObjectAttributes,
+	}
+	if ne.MergeRequest != nil && ne.MergeRequest.MergeRequest != nil {
+		e.MergeRequest = ne.MergeRequest.MergeRequest
+		e.MergeRequest.Labels = labelTitles(ne.MergeRequest.Labels)
+	}
+	return e, nil
+}
+
+// ToNote returns the note in the same form that the REST API returns it.
+func (e *NoteEvent) ToNote() *gitlab.Note {
+	return &gitlab.Note{
+		ID:        e.ObjectAttributes.ID,
+		Body:      e.ObjectAttributes.Note,
+		Author:    e.User,
+		CreatedAt: e.ObjectAttributes.CreatedAt,
+		System:    e.ObjectAttributes.System,
+	}
+}
@@ -1,6 +1,7 @@
 package protocol
 
 import (
+	"strings"
 	"time"
 
 	"github.com/sourcegraph/sourcegraph/internal/api"
@@ -181,6 +182,9 @@ type CreateCommitFromPatchRequest struct {
 	// GitApplyArgs are the arguments that will be passed to `git apply` along
 	// with `--cached`.
 	GitApplyArgs []string
+	// PushRemoteURL, if set, is the URL, including credentials, that is used
+	// instead of the repository's remote URL to push the TargetRef.
+	PushRemoteURL string
 }
 
 // PatchCommitInfo will be used for commit information when creating a commit from a patch
@@ -233,3 +237,15 @@ type CreateCommitFromPatchError struct {
 func (e *CreateCommitFromPatchError) Error() string {
 	return e.InternalError
 }
+
+// PatchDidNotApply returns true if the commit couldn't be created because the
+// patch doesn't apply cleanly to the base commit.
+func (e *CreateCommitFromPatchError) PatchDidNotApply() bool {
+	return strings.HasPrefix(e.Command, "git apply ")
+}
+
+// PushFailed returns true if the error occurred when pushing the commit to the
+// code host.
+func (e *CreateCommitFromPatchError) PushFailed() bool {
+	return strings.HasPrefix(e.Command, "git push ")
+}
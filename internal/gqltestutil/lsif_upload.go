@@ -0,0 +1,216 @@
+package gqltestutil
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/graph-gophers/graphql-go/relay"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/pkg/errors"
+)
+
+// LSIFUploadArgs contains the arguments of an LSIF upload.
+type LSIFUploadArgs struct {
+	Repository string
+	Commit     string
+	Root       string
+	// IndexerName is optional, the server reads it from the payload when it is empty and the
+	// upload is not a multipart upload.
+	IndexerName string
+}
+
+// LSIFUploadStatus is the state of an LSIF upload and the parts the server has received.
+type LSIFUploadStatus struct {
+	ID            string `json:"id"`
+	State         string `json:"state"`
+	NumParts      int    `json:"numParts"`
+	UploadedParts []int  `json:"uploadedParts"`
+}
+
+// ResolveRevision returns the commit ID the revision of the repository resolves to.
+func (c *Client) ResolveRevision(repoName, revision string) (string, error) {
+	const query = `
+query ResolveRevision($repoName: String!, $revision: String!) {
+	repository(name: $repoName) {
+		commit(rev: $revision) {
+			oid
+		}
+	}
+}
+`
+	variables := map[string]interface{}{
+		"repoName": repoName,
+		"revision": revision,
+	}
+	var resp struct {
+		Data struct {
+			Repository struct {
+				Commit struct {
+					OID string `json:"oid"`
+				} `json:"commit"`
+			} `json:"repository"`
+		} `json:"data"`
+	}
+	err := c.GraphQL("", "", query, variables, &resp)
+	if err != nil {
+		return "", errors.Wrap(err, "request GraphQL")
+	}
+	return resp.Data.Repository.Commit.OID, nil
+}
+
+// UploadLSIF uploads the compressed payload in a single request, authenticated by the given
+// access token. It returns the ID of the upload.
+func (c *Client) UploadLSIF(token string, args LSIFUploadArgs, payload []byte) (string, error) {
+	var resp struct {
+		ID string `json:"id"`
+	}
+	err := c.lsifUploadRequest(token, args.values(), payload, &resp)
+	if err != nil {
+		return "", err
+	}
+	return resp.ID, nil
+}
+
+// UploadLSIFMultipart uploads the compressed payload split into the given parts, authenticated
+// by the given access token. Only the parts whose indexes are in send are uploaded, and the upload
+// is only finalized if all parts are sent, so that interrupted uploads can be simulated. It
+// returns the ID of the upload.
+func (c *Client) UploadLSIFMultipart(token string, args LSIFUploadArgs, parts [][]byte, send []int) (string, error) {
+	values := args.values()
+	values.Set("multiPart", "true")
+	values.Set("numParts", strconv.Itoa(len(parts)))
+
+	var resp struct {
+		ID string `json:"id"`
+	}
+	err := c.lsifUploadRequest(token, values, nil, &resp)
+	if err != nil {
+		return "", errors.Wrap(err, "set up multipart upload")
+	}
+
+	err = c.ResumeLSIFMultipartUpload(token, resp.ID, parts, send)
+	if err != nil {
+		return "", err
+	}
+	return resp.ID, nil
+}
+
+// ResumeLSIFMultipartUpload uploads the parts of an existing multipart upload whose indexes are
+// in send, and finalizes the upload if the server then has all parts.
+func (c *Client) ResumeLSIFMultipartUpload(token, uploadID string, parts [][]byte, send []int) error {
+	for _, index := range send {
+		values := url.Values{"uploadId": {uploadID}, "index": {strconv.Itoa(index)}}
+		err := c.lsifUploadRequest(token, values, parts[index], nil)
+		if err != nil {
+			return errors.Wrapf(err, "upload part %d", index)
+		}
+	}
+
+	status, err := c.LSIFUploadStatus(token, uploadID)
+	if err != nil {
+		return err
+	}
+	if len(status.UploadedParts) != len(parts) {
+		return nil
+	}
+
+	err = c.lsifUploadRequest(token, url.Values{"uploadId": {uploadID}, "done": {"true"}}, nil, nil)
+	if err != nil {
+		return errors.Wrap(err, "finalize multipart upload")
+	}
+	return nil
+}
+
+// LSIFUploadStatus returns the status of the upload with the given ID.
+func (c *Client) LSIFUploadStatus(token, uploadID string) (*LSIFUploadStatus, error) {
+	var status LSIFUploadStatus
+	err := c.lsifUploadRequest(token, url.Values{"uploadId": {uploadID}, "status": {"true"}}, nil, &status)
+	if err != nil {
+		return nil, errors.Wrap(err, "request upload status")
+	}
+	return &status, nil
+}
+
+// LSIFUpload is an LSIF upload as returned by the GraphQL API.
+type LSIFUpload struct {
+	InputIndexer string `json:"inputIndexer"`
+	State        string `json:"state"`
+}
+
+// GetLSIFUpload returns the LSIF upload with the given ID.
+func (c *Client) GetLSIFUpload(uploadID string) (*LSIFUpload, error) {
+	id, err := strconv.ParseInt(uploadID, 10, 64)
+	if err != nil {
+		return nil, errors.Wrap(err, "parse upload ID")
+	}
+
+	const query = `
+query LSIFUpload($id: ID!) {
+	node(id: $id) {
+		... on LSIFUpload {
+			inputIndexer
+			state
+		}
+	}
+}
+`
+	variables := map[string]interface{}{
+		"id": relay.MarshalID("LSIFUpload", id),
+	}
+	var resp struct {
+		Data struct {
+			Node LSIFUpload `json:"node"`
+		} `json:"data"`
+	}
+	err = c.GraphQL("", "", query, variables, &resp)
+	if err != nil {
+		return nil, errors.Wrap(err, "request GraphQL")
+	}
+	return &resp.Data.Node, nil
+}
+
+func (args LSIFUploadArgs) values() url.Values {
+	values := url.Values{
+		"repository": {args.Repository},
+		"commit":     {args.Commit},
+		"root":       {args.Root},
+	}
+	if args.IndexerName != "" {
+		values.Set("indexerName", args.IndexerName)
+	}
+	return values
+}
+
+// lsifUploadRequest makes a request to the LSIF upload endpoint with the given query and body,
+// and unmarshals the JSON response into target unless it is nil.
+func (c *Client) lsifUploadRequest(token string, values url.Values, body []byte, target interface{}) error {
+	req, err := http.NewRequest("POST", fmt.Sprintf("%s/.api/lsif/upload?%s", c.baseURL, values.Encode()), bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", fmt.Sprintf("token %s", token))
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	p, err := ioutil.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return errors.Wrap(err, "read response body")
+	}
+	if resp.StatusCode != http.StatusAccepted && resp.StatusCode != http.StatusNoContent {
+		return errors.Errorf("%d: %s", resp.StatusCode, string(p))
+	}
+
+	if target == nil {
+		return nil
+	}
+	return jsoniter.Unmarshal(p, target)
+}
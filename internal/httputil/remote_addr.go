@@ -0,0 +1,23 @@
+package httputil
+
+import (
+	"net"
+	"net/http"
+	"strings"
+)
+
+// RemoteAddr returns the IP address of the client that made the request. Sourcegraph is commonly
+// deployed behind a reverse proxy, so the first address in the X-Forwarded-For header takes
+// precedence over the address of the connection.
+//
+// The X-Forwarded-For header is set by the client (or the proxies in between), so the result must
+// only be used for informational purposes, never to make authorization decisions.
+func RemoteAddr(r *http.Request) string {
+	if v := r.Header.Get("X-Forwarded-For"); v != "" {
+		return strings.TrimSpace(strings.Split(v, ",")[0])
+	}
+	if host, _, err := net.SplitHostPort(r.RemoteAddr); err == nil {
+		return host
+	}
+	return r.RemoteAddr
+}
@@ -0,0 +1,29 @@
+package httputil
+
+import (
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRemoteAddr(t *testing.T) {
+	tests := []struct {
+		remoteAddr    string
+		xForwardedFor string
+		want          string
+	}{
+		{remoteAddr: "10.0.0.1:1234", want: "10.0.0.1"},
+		{remoteAddr: "10.0.0.1", want: "10.0.0.1"},
+		{remoteAddr: "10.0.0.1:1234", xForwardedFor: "192.168.0.1", want: "192.168.0.1"},
+		{remoteAddr: "10.0.0.1:1234", xForwardedFor: "192.168.0.1, 172.16.0.1", want: "192.168.0.1"},
+	}
+	for _, test := range tests {
+		r := httptest.NewRequest("GET", "/", nil)
+		r.RemoteAddr = test.remoteAddr
+		if test.xForwardedFor != "" {
+			r.Header.Set("X-Forwarded-For", test.xForwardedFor)
+		}
+		if got := RemoteAddr(r); got != test.want {
+			t.Errorf("RemoteAddr(%q, %q) = %q, want %q", test.remoteAddr, test.xForwardedFor, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,16 @@
+BEGIN;
+
+CREATE TABLE lsif_moniker_references (
+    id serial PRIMARY KEY,
+    dump_id integer NOT NULL REFERENCES lsif_uploads(id) ON DELETE CASCADE,
+    scheme text NOT NULL,
+    identifier text NOT NULL,
+    name text NOT NULL,
+    version text,
+    count integer NOT NULL
+);
+
+CREATE INDEX lsif_moniker_references_moniker ON lsif_moniker_references(scheme, identifier, name, version);
+CREATE INDEX lsif_moniker_references_dump_id ON lsif_moniker_references(dump_id);
+
+COMMIT;
@@ -0,0 +1,20 @@
+BEGIN;
+
+CREATE TABLE lsif_diagnostics (
+    id serial PRIMARY KEY,
+    dump_id integer NOT NULL REFERENCES lsif_uploads(id) ON DELETE CASCADE,
+    path text NOT NULL,
+    severity integer NOT NULL,
+    code text NOT NULL,
+    source text NOT NULL,
+    message text NOT NULL,
+    start_line integer NOT NULL,
+    start_character integer NOT NULL,
+    end_line integer NOT NULL,
+    end_character integer NOT NULL
+);
+
+CREATE INDEX lsif_diagnostics_dump_id ON lsif_diagnostics(dump_id);
+CREATE INDEX lsif_diagnostics_source_code ON lsif_diagnostics(source, code);
+
+COMMIT;
@@ -0,0 +1,24 @@
+BEGIN;
+
+CREATE TABLE campaign_spec_executions (
+    id bigserial PRIMARY KEY,
+    campaign_spec_id bigint NOT NULL REFERENCES campaign_specs(id) ON DELETE CASCADE DEFERRABLE,
+    repo_id integer NOT NULL REFERENCES repo(id) DEFERRABLE,
+    base_ref text NOT NULL,
+    base_rev text NOT NULL,
+    changeset_spec_id bigint REFERENCES changeset_specs(id) ON DELETE SET NULL DEFERRABLE,
+    user_id integer REFERENCES users(id) ON DELETE SET NULL DEFERRABLE,
+    state text DEFAULT 'queued',
+    failure_message text,
+    started_at timestamp with time zone,
+    finished_at timestamp with time zone,
+    process_after timestamp with time zone,
+    num_resets integer NOT NULL DEFAULT 0,
+    num_failures integer NOT NULL DEFAULT 0,
+    created_at timestamp with time zone NOT NULL DEFAULT now(),
+    updated_at timestamp with time zone NOT NULL DEFAULT now()
+);
+
+CREATE INDEX campaign_spec_executions_campaign_spec_id ON campaign_spec_executions(campaign_spec_id);
+
+COMMIT;
@@ -0,0 +1,6 @@
+BEGIN;
+
+ALTER TABLE campaign_spec_executions DROP COLUMN IF EXISTS cache_hit;
+DROP TABLE IF EXISTS campaign_step_cache_entries;
+
+COMMIT;
@@ -0,0 +1,17 @@
+BEGIN;
+
+CREATE TABLE campaign_step_cache_entries (
+    id bigserial PRIMARY KEY,
+    repo_id integer NOT NULL REFERENCES repo(id) ON DELETE CASCADE DEFERRABLE,
+    base_rev text NOT NULL,
+    steps_hash text NOT NULL,
+    diff text NOT NULL,
+    created_at timestamp with time zone NOT NULL DEFAULT now(),
+    last_used_at timestamp with time zone NOT NULL DEFAULT now()
+);
+
+CREATE UNIQUE INDEX campaign_step_cache_entries_key ON campaign_step_cache_entries(repo_id, base_rev, steps_hash);
+
+ALTER TABLE campaign_spec_executions ADD COLUMN cache_hit boolean NOT NULL DEFAULT false;
+
+COMMIT;
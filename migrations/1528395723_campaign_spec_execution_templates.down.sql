@@ -0,0 +1,20 @@
+BEGIN;
+
+ALTER TABLE campaign_step_cache_entries DROP COLUMN IF EXISTS outputs;
+
+ALTER TABLE campaign_spec_executions
+    ADD COLUMN changeset_spec_id bigint REFERENCES changeset_specs(id) ON DELETE SET NULL DEFERRABLE;
+
+UPDATE campaign_spec_executions
+SET changeset_spec_id = (
+    SELECT min(cs.id)
+    FROM jsonb_object_keys(changeset_spec_ids) AS key
+    JOIN changeset_specs cs ON cs.id = key::bigint
+)
+WHERE changeset_spec_ids != '{}'::jsonb;
+
+ALTER TABLE campaign_spec_executions
+    DROP COLUMN IF EXISTS changeset_spec_ids,
+    DROP COLUMN IF EXISTS search_result_paths;
+
+COMMIT;
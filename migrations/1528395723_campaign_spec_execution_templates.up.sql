@@ -0,0 +1,19 @@
+BEGIN;
+
+-- Executions can result in several changeset specs when the campaign spec
+-- uses transformChanges, so the single changeset_spec_id is replaced by a
+-- set of IDs, stored like campaigns.changeset_ids.
+ALTER TABLE campaign_spec_executions
+    ADD COLUMN changeset_spec_ids jsonb NOT NULL DEFAULT '{}'::jsonb CHECK (jsonb_typeof(changeset_spec_ids) = 'object'),
+    ADD COLUMN search_result_paths jsonb NOT NULL DEFAULT '[]'::jsonb CHECK (jsonb_typeof(search_result_paths) = 'array');
+
+UPDATE campaign_spec_executions
+SET changeset_spec_ids = jsonb_build_object(changeset_spec_id, null)
+WHERE changeset_spec_id IS NOT NULL;
+
+ALTER TABLE campaign_spec_executions DROP COLUMN changeset_spec_id;
+
+ALTER TABLE campaign_step_cache_entries
+    ADD COLUMN outputs jsonb NOT NULL DEFAULT '{}'::jsonb CHECK (jsonb_typeof(outputs) = 'object');
+
+COMMIT;
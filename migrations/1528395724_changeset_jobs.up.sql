@@ -0,0 +1,26 @@
+BEGIN;
+
+CREATE TABLE changeset_jobs (
+    id bigserial PRIMARY KEY,
+    bulk_group text NOT NULL,
+    user_id integer NOT NULL REFERENCES users(id) ON DELETE CASCADE DEFERRABLE,
+    campaign_id bigint NOT NULL REFERENCES campaigns(id) ON DELETE CASCADE DEFERRABLE,
+    changeset_id bigint NOT NULL REFERENCES changesets(id) ON DELETE CASCADE DEFERRABLE,
+    job_type text NOT NULL,
+    payload jsonb DEFAULT '{}'::jsonb CHECK (jsonb_typeof(payload) = 'object'::text),
+    state text DEFAULT 'queued',
+    failure_message text,
+    started_at timestamp with time zone,
+    finished_at timestamp with time zone,
+    process_after timestamp with time zone,
+    num_resets integer NOT NULL DEFAULT 0,
+    num_failures integer NOT NULL DEFAULT 0,
+    created_at timestamp with time zone NOT NULL DEFAULT now(),
+    updated_at timestamp with time zone NOT NULL DEFAULT now()
+);
+
+CREATE INDEX changeset_jobs_bulk_group_idx ON changeset_jobs(bulk_group);
+CREATE INDEX changeset_jobs_campaign_id_idx ON changeset_jobs(campaign_id);
+CREATE INDEX changeset_jobs_state_idx ON changeset_jobs(state);
+
+COMMIT;
@@ -0,0 +1,22 @@
+BEGIN;
+
+CREATE TABLE campaign_snapshots (
+    id bigserial PRIMARY KEY,
+    campaign_id bigint NOT NULL REFERENCES campaigns(id) ON DELETE CASCADE DEFERRABLE,
+    total integer NOT NULL DEFAULT 0,
+    open integer NOT NULL DEFAULT 0,
+    merged integer NOT NULL DEFAULT 0,
+    closed integer NOT NULL DEFAULT 0,
+    draft integer NOT NULL DEFAULT 0,
+    failed_checks integer NOT NULL DEFAULT 0,
+    open_approved integer NOT NULL DEFAULT 0,
+    open_changes_requested integer NOT NULL DEFAULT 0,
+    open_pending integer NOT NULL DEFAULT 0,
+    median_time_to_merge bigint NOT NULL DEFAULT 0,
+    repo_stall_times jsonb NOT NULL DEFAULT '[]'::jsonb CHECK (jsonb_typeof(repo_stall_times) = 'array'::text),
+    created_at timestamp with time zone NOT NULL DEFAULT now()
+);
+
+CREATE INDEX campaign_snapshots_campaign_id_created_at_idx ON campaign_snapshots(campaign_id, created_at);
+
+COMMIT;
@@ -0,0 +1,6 @@
+BEGIN;
+
+ALTER TABLE changesets DROP COLUMN IF EXISTS rebase_state;
+ALTER TABLE changesets DROP COLUMN IF EXISTS rebase_base_rev;
+
+COMMIT;
@@ -0,0 +1,6 @@
+BEGIN;
+
+ALTER TABLE changesets ADD COLUMN IF NOT EXISTS rebase_state text;
+ALTER TABLE changesets ADD COLUMN IF NOT EXISTS rebase_base_rev text;
+
+COMMIT;
@@ -0,0 +1,21 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS campaign_credentials (
+    id bigserial PRIMARY KEY,
+    namespace_user_id integer REFERENCES users(id) ON DELETE CASCADE DEFERRABLE,
+    namespace_org_id integer REFERENCES orgs(id) ON DELETE CASCADE DEFERRABLE,
+    external_service_type text NOT NULL,
+    external_service_id text NOT NULL,
+    username text,
+    credential bytea NOT NULL,
+    created_at timestamp with time zone NOT NULL DEFAULT now(),
+    updated_at timestamp with time zone NOT NULL DEFAULT now(),
+
+    CONSTRAINT campaign_credentials_has_at_most_1_namespace CHECK (namespace_user_id IS NULL OR namespace_org_id IS NULL),
+    CONSTRAINT campaign_credentials_external_service_type_not_blank CHECK (external_service_type <> ''),
+    CONSTRAINT campaign_credentials_external_service_id_not_blank CHECK (external_service_id <> '')
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS campaign_credentials_namespace_code_host_unique ON campaign_credentials (COALESCE(namespace_user_id, 0), COALESCE(namespace_org_id, 0), external_service_type, external_service_id);
+
+COMMIT;
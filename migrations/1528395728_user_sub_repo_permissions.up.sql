@@ -0,0 +1,15 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS user_sub_repo_permissions (
+    user_id integer NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    repo_id integer NOT NULL REFERENCES repo(id) ON DELETE CASCADE,
+    path_includes text[] NOT NULL DEFAULT '{}'::text[],
+    path_excludes text[] NOT NULL DEFAULT '{}'::text[],
+    updated_at timestamp with time zone NOT NULL DEFAULT now(),
+
+    PRIMARY KEY (user_id, repo_id)
+);
+
+CREATE INDEX IF NOT EXISTS user_sub_repo_permissions_repo_id ON user_sub_repo_permissions (repo_id);
+
+COMMIT;
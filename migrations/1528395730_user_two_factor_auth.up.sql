@@ -0,0 +1,15 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS user_two_factor_auth (
+    user_id integer PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    totp_secret bytea NOT NULL,
+    recovery_codes text[] NOT NULL DEFAULT '{}',
+    last_used_time_step bigint NOT NULL DEFAULT 0,
+    failed_attempts integer NOT NULL DEFAULT 0,
+    last_failed_attempt_at timestamp with time zone,
+    enabled_at timestamp with time zone,
+    created_at timestamp with time zone NOT NULL DEFAULT now(),
+    updated_at timestamp with time zone NOT NULL DEFAULT now()
+);
+
+COMMIT;
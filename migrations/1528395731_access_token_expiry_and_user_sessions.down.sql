@@ -0,0 +1,8 @@
+BEGIN;
+
+DROP TABLE IF EXISTS user_sessions;
+
+ALTER TABLE access_tokens DROP COLUMN IF EXISTS expires_at;
+ALTER TABLE access_tokens DROP COLUMN IF EXISTS last_used_ip;
+
+COMMIT;
@@ -0,0 +1,18 @@
+BEGIN;
+
+ALTER TABLE access_tokens ADD COLUMN IF NOT EXISTS expires_at timestamp with time zone;
+ALTER TABLE access_tokens ADD COLUMN IF NOT EXISTS last_used_ip text NOT NULL DEFAULT '';
+
+CREATE TABLE IF NOT EXISTS user_sessions (
+    id bigserial PRIMARY KEY,
+    user_id integer NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    user_agent text NOT NULL DEFAULT '',
+    last_ip text NOT NULL DEFAULT '',
+    created_at timestamp with time zone NOT NULL DEFAULT now(),
+    last_active_at timestamp with time zone NOT NULL DEFAULT now(),
+    expires_at timestamp with time zone NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS user_sessions_user_id ON user_sessions(user_id);
+
+COMMIT;
@@ -0,0 +1,36 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS audit_log (
+    id bigserial PRIMARY KEY,
+    action text NOT NULL,
+    actor_user_id integer,
+    actor_ip text NOT NULL DEFAULT '',
+    subject_type text NOT NULL DEFAULT '',
+    subject_id text NOT NULL DEFAULT '',
+    before jsonb,
+    after jsonb,
+    created_at timestamp with time zone NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS audit_log_action ON audit_log(action);
+CREATE INDEX IF NOT EXISTS audit_log_actor_user_id ON audit_log(actor_user_id);
+CREATE INDEX IF NOT EXISTS audit_log_subject ON audit_log(subject_type, subject_id);
+CREATE INDEX IF NOT EXISTS audit_log_created_at ON audit_log(created_at);
+
+DROP FUNCTION IF EXISTS audit_log_append_only() CASCADE;
+
+CREATE FUNCTION audit_log_append_only() RETURNS trigger
+    LANGUAGE plpgsql
+AS $$
+BEGIN
+    -- The audit log must not be tampered with, so its entries can be neither
+    -- modified nor deleted.
+    RAISE EXCEPTION 'audit_log is append-only';
+END;
+$$;
+
+CREATE TRIGGER trig_audit_log_append_only
+    BEFORE UPDATE OR DELETE ON audit_log
+    FOR EACH ROW EXECUTE PROCEDURE audit_log_append_only();
+
+COMMIT;
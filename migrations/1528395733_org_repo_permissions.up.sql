@@ -0,0 +1,14 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS org_repo_permissions (
+    org_id integer NOT NULL REFERENCES orgs(id) ON DELETE CASCADE,
+    repo_id integer NOT NULL REFERENCES repo(id) ON DELETE CASCADE,
+    permission text NOT NULL,
+    updated_at timestamp with time zone NOT NULL DEFAULT now(),
+
+    PRIMARY KEY (org_id, repo_id, permission)
+);
+
+CREATE INDEX IF NOT EXISTS org_repo_permissions_repo_id ON org_repo_permissions (repo_id);
+
+COMMIT;
@@ -0,0 +1,8 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS lsif_commit_graph_tips (
+    repository_id integer PRIMARY KEY,
+    "commit" text NOT NULL
+);
+
+COMMIT;
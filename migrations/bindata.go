@@ -138,6 +138,38 @@
 // 1528395717_replicate_permissions_object_ids_to_intarray.up.sql (411B)
 // 1528395718_user_invalidate_session.down.sql (176B)
 // 1528395718_user_invalidate_session.up.sql (1.252kB)
+// 1528395719_lsif_moniker_references.down.sql (63B)
+// 1528395719_lsif_moniker_references.up.sql (478B)
+// 1528395720_lsif_diagnostics.down.sql (56B)
+// 1528395720_lsif_diagnostics.up.sql (570B)
+// 1528395721_campaign_spec_executions.down.sql (64B)
+// 1528395721_campaign_spec_executions.up.sql (971B)
+// 1528395722_campaign_step_cache.down.sql (137B)
+// 1528395722_campaign_step_cache.up.sql (590B)
+// 1528395723_campaign_spec_execution_templates.down.sql (588B)
+// 1528395723_campaign_spec_execution_templates.up.sql (850B)
+// 1528395724_changeset_jobs.down.sql (54B)
+// 1528395724_changeset_jobs.up.sql (1.096kB)
+// 1528395725_campaign_snapshots.down.sql (58B)
+// 1528395725_campaign_snapshots.up.sql (889B)
+// 1528395726_changesets_rebase_state.down.sql (138B)
+// 1528395726_changesets_rebase_state.up.sql (154B)
+// 1528395727_campaign_credentials.down.sql (60B)
+// 1528395727_campaign_credentials.up.sql (1.063kB)
+// 1528395728_user_sub_repo_permissions.down.sql (65B)
+// 1528395728_user_sub_repo_permissions.up.sql (526B)
+// 1528395729_users_deactivated_at.down.sql (73B)
+// 1528395729_users_deactivated_at.up.sql (101B)
+// 1528395730_user_two_factor_auth.down.sql (60B)
+// 1528395730_user_two_factor_auth.up.sql (543B)
+// 1528395731_access_token_expiry_and_user_sessions.down.sql (176B)
+// 1528395731_access_token_expiry_and_user_sessions.up.sql (678B)
+// 1528395732_audit_log.down.sql (106B)
+// 1528395732_audit_log.up.sql (1.125kB)
+// 1528395733_org_repo_permissions.down.sql (60B)
+// 1528395733_org_repo_permissions.up.sql (438B)
+// 1528395734_lsif_commit_graph_tips.down.sql (62B)
+// 1528395734_lsif_commit_graph_tips.up.sql (138B)
 
 package migrations
 
@@ -2966,6 +2998,646 @@ func _1528395718_user_invalidate_sessionUpSql() (*asset, error) {
 	return a, nil
 }
 
+var __1528395719_lsif_moniker_referencesDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x3f\x00\xc0\xff\x42\x45\x47\x49\x4e\x3b\x0a\x0a\x44\x52\x4f\x50\x20\x54\x41\x42\x4c\x45\x20\x49\x46\x20\x45\x58\x49\x53\x54\x53\x20\x6c\x73\x69\x66\x5f\x6d\x6f\x6e\x69\x6b\x65\x72\x5f\x72\x65\x66\x65\x72\x65\x6e\x63\x65\x73\x3b\x0a\x0a\x43\x4f\x4d\x4d\x49\x54\x3b\x0a\x03\x00\xb2\xb6\x8b\x74\x3f\x00\x00\x00")
+
+func _1528395719_lsif_moniker_referencesDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395719_lsif_moniker_referencesDownSql,
+		"1528395719_lsif_moniker_references.down.sql",
+	)
+}
+
+func _1528395719_lsif_moniker_referencesDownSql() (*asset, error) {
+	bytes, err := _1528395719_lsif_moniker_referencesDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395719_lsif_moniker_references.down.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xd4, 0x0, 0x6e, 0x64, 0x4c, 0x2, 0xf4, 0xd4, 0xc7, 0xec, 0xbe, 0x5d, 0xfc, 0xd2, 0x7b, 0x1, 0x3c, 0x7e, 0x62, 0x1, 0xdd, 0xd6, 0x6f, 0xb0, 0x35, 0xde, 0x6f, 0x28, 0x96, 0x40, 0x11, 0x7c}}
+	return a, nil
+}
+
+var __1528395719_lsif_moniker_referencesUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x8c\x90\xd1\x4e\xb4\x30\x10\x85\xef\x79\x8a\xb9\x84\x84\x37\xe0\xaa\x0b\xf3\xff\x21\x42\x31\xdd\x9a\xb8\x57\x84\xd0\x41\x27\x42\xd9\xb4\xc5\xf8\xf8\x46\x58\xd4\xc4\x5d\xe3\xed\x9c\x99\x33\xdf\x39\x07\xfc\x5f\xca\x2c\x8a\x72\x85\x42\x23\x68\x71\xa8\x10\x46\xcf\x43\x3b\xcd\x96\x5f\xc8\xb5\x8e\x06\x72\x64\x7b\xf2\x10\x47\x00\x00\x6c\xc0\x93\xe3\x6e\x84\x7b\x55\xd6\x42\x9d\xe0\x0e\x4f\xe9\x2a\x99\x65\x3a\xb7\x6c\x80\x6d\xa0\x27\x72\x20\x1b\x0d\xf2\xa1\xaa\x40\xe1\x3f\x54\x28\x73\x3c\x6e\xe6\xcb\x79\x9c\x3b\xe3\x63\x36\x09\x34\x12\x0a\xac\x50\x23\xe4\xe2\x98\x8b\x02\x37\x2f\xdf\x3f\xd3\x44\x10\xe8\x2d\x7c\xfa\xa4\x17\x00\xb2\x81\x07\x26\x77\x4d\xb5\xdd\xf5\xab\x57\x72\x9e\x67\xbb\x4a\xdb\xa4\x9f\x17\x1b\x7e\xb0\x46\xc9\x57\x1d\xa5\x2c\xf0\xf1\x56\x1d\xfb\xe8\x23\xc0\x8d\x95\x78\x0b\x91\x7e\x43\x4e\x57\xc0\x74\xc7\x49\xb2\xbf\xfd\xda\x9b\xfd\xe5\xd7\x65\x65\xc5\x6f\xea\xba\xd4\x59\xf4\x3e\x00\x74\xf0\x1f\xd3\xde\x01\x00\x00")
+
+func _1528395719_lsif_moniker_referencesUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395719_lsif_moniker_referencesUpSql,
+		"1528395719_lsif_moniker_references.up.sql",
+	)
+}
+
+func _1528395719_lsif_moniker_referencesUpSql() (*asset, error) {
+	bytes, err := _1528395719_lsif_moniker_referencesUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395719_lsif_moniker_references.up.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x7f, 0x4e, 0x29, 0xfc, 0x83, 0x16, 0xdf, 0x51, 0x64, 0x82, 0x8d, 0x1f, 0x4c, 0x1d, 0xad, 0xb9, 0x88, 0xc9, 0x47, 0x8a, 0xd9, 0xe9, 0x6, 0x6a, 0x75, 0xd3, 0xf9, 0xf0, 0x67, 0x5b, 0x9f, 0x9c}}
+	return a, nil
+}
+
+var __1528395720_lsif_diagnosticsDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x38\x00\xc7\xff\x42\x45\x47\x49\x4e\x3b\x0a\x0a\x44\x52\x4f\x50\x20\x54\x41\x42\x4c\x45\x20\x49\x46\x20\x45\x58\x49\x53\x54\x53\x20\x6c\x73\x69\x66\x5f\x64\x69\x61\x67\x6e\x6f\x73\x74\x69\x63\x73\x3b\x0a\x0a\x43\x4f\x4d\x4d\x49\x54\x3b\x0a\x03\x00\x16\xd7\x1e\xd3\x38\x00\x00\x00")
+
+func _1528395720_lsif_diagnosticsDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395720_lsif_diagnosticsDownSql,
+		"1528395720_lsif_diagnostics.down.sql",
+	)
+}
+
+func _1528395720_lsif_diagnosticsDownSql() (*asset, error) {
+	bytes, err := _1528395720_lsif_diagnosticsDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395720_lsif_diagnostics.down.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xed, 0xae, 0xfe, 0xad, 0x82, 0x34, 0xa4, 0x26, 0xaa, 0x53, 0x13, 0x51, 0x99, 0x1e, 0x93, 0xe7, 0x19, 0xb5, 0xd5, 0xd, 0xd0, 0x8f, 0xff, 0x54, 0xe3, 0x87, 0xdb, 0x46, 0x67, 0xab, 0xe7, 0x3e}}
+	return a, nil
+}
+
+var __1528395720_lsif_diagnosticsUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x84\x91\x4d\x6e\x83\x30\x10\x85\xf7\x9c\x62\x96\x20\xe5\x06\xac\x08\x4c\x2b\x54\x30\x15\xa1\x52\xb3\x42\x96\x3d\x25\x23\x11\x40\xb6\xa9\xda\xdb\x57\xc1\xfd\x59\x00\xcd\xd6\xdf\x9b\x6f\xac\x37\x47\x7c\xcc\x45\x1c\x04\x69\x8d\x49\x83\xd0\x24\xc7\x02\xa1\xb7\xfc\xd6\x6a\x96\xdd\x30\x5a\xc7\xca\x42\x18\x00\x00\xb0\x06\x4b\x86\x65\x0f\xcf\x75\x5e\x26\xf5\x19\x9e\xf0\x7c\x58\x90\x9e\xaf\x53\xcb\x1a\x78\x70\xd4\x91\x01\x51\x35\x20\x5e\x8a\x02\x6a\x7c\xc0\x1a\x45\x8a\x27\x6f\x9d\xa7\x7e\x94\xda\x86\xac\x23\xa8\x04\x64\x58\x60\x83\x90\x26\xa7\x34\xc9\xd0\xbb\x26\xe9\x2e\xe0\xe8\xc3\xfd\x5a\xfc\xbb\xa5\x77\x32\xec\x3e\x57\x4b\x3c\x56\xa3\xa6\xcd\xb1\x71\x36\x6a\x93\x5c\xc9\x5a\xd9\x6d\x0f\x39\x69\x5c\xdb\xf3\x40\x3b\xdb\x7c\x40\x5d\xa4\x91\xca\x91\xd9\x49\xd1\xa0\xff\x93\xdc\xf0\xbe\x22\x88\xfe\xee\x92\x8b\x0c\x5f\x57\x77\x69\x7f\x6a\xaf\xc4\x8a\x85\xdf\x2c\x8a\xef\x38\x7c\x3f\xed\x52\xdf\x96\xc7\xf3\x03\xdc\x02\xcb\x8f\xaa\xb2\xcc\x9b\x38\xf8\x1a\x00\x2d\xb9\x19\x06\x3a\x02\x00\x00")
+
+func _1528395720_lsif_diagnosticsUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395720_lsif_diagnosticsUpSql,
+		"1528395720_lsif_diagnostics.up.sql",
+	)
+}
+
+func _1528395720_lsif_diagnosticsUpSql() (*asset, error) {
+	bytes, err := _1528395720_lsif_diagnosticsUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395720_lsif_diagnostics.up.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x1e, 0x12, 0x50, 0x39, 0x8, 0xbb, 0x6d, 0xa7, 0x5f, 0xa8, 0x1, 0xbe, 0xda, 0xca, 0x1e, 0x91, 0x0, 0x56, 0x6a, 0x1c, 0x8d, 0x94, 0xf9, 0xc4, 0xf, 0x59, 0xf0, 0xa5, 0x13, 0x7e, 0xc2, 0x36}}
+	return a, nil
+}
+
+var __1528395721_campaign_spec_executionsDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x40\x00\xbf\xff\x42\x45\x47\x49\x4e\x3b\x0a\x0a\x44\x52\x4f\x50\x20\x54\x41\x42\x4c\x45\x20\x49\x46\x20\x45\x58\x49\x53\x54\x53\x20\x63\x61\x6d\x70\x61\x69\x67\x6e\x5f\x73\x70\x65\x63\x5f\x65\x78\x65\x63\x75\x74\x69\x6f\x6e\x73\x3b\x0a\x0a\x43\x4f\x4d\x4d\x49\x54\x3b\x0a\x03\x00\xa4\x86\x10\x8e\x40\x00\x00\x00")
+
+func _1528395721_campaign_spec_executionsDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395721_campaign_spec_executionsDownSql,
+		"1528395721_campaign_spec_executions.down.sql",
+	)
+}
+
+func _1528395721_campaign_spec_executionsDownSql() (*asset, error) {
+	bytes, err := _1528395721_campaign_spec_executionsDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395721_campaign_spec_executions.down.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xd4, 0x95, 0x50, 0x42, 0x73, 0xcc, 0xe3, 0xee, 0x28, 0x46, 0xf9, 0x20, 0x59, 0x42, 0x66, 0xfc, 0xd, 0x89, 0xa9, 0x8b, 0xd8, 0x50, 0xef, 0x81, 0x58, 0xeb, 0x2c, 0xff, 0xc5, 0x38, 0x83, 0x94}}
+	return a, nil
+}
+
+var __1528395721_campaign_spec_executionsUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x9c\x93\xcf\xef\x9a\x40\x10\xc5\xef\xfc\x15\x73\xfb\x62\xd2\x43\xef\x9e\x10\xc6\x86\x14\xb1\x41\x4c\xea\x69\xb3\xc2\x88\x9b\xc8\x42\x77\x96\x6a\xfa\xd7\x37\xfc\x68\x15\xd4\x68\xbe\x47\x32\x9f\xf7\xe6\x3d\x36\xb3\xc0\x6f\x61\x3c\x77\x1c\x3f\x41\x2f\x45\x48\xbd\x45\x84\x90\xc9\xb2\x96\xaa\xd0\x82\x6b\xca\x04\x5d\x28\x6b\xac\xaa\x34\x83\xeb\x00\x00\xa8\x1c\xf6\xaa\x60\x32\x4a\x9e\xe0\x47\x12\xae\xbc\x64\x07\xdf\x71\xf7\xa5\x9b\x8e\xc5\x3d\xab\xb4\x85\x78\x9d\x42\xbc\x8d\x22\x48\x70\x89\x09\xc6\x3e\x6e\xc6\x8b\xd8\x55\xf9\x0c\xd6\x31\x04\x18\x61\x8a\xe0\x7b\x1b\xdf\x0b\x10\x82\x96\x4f\xda\x60\xfd\x02\x43\x75\x25\x54\x0e\x4a\x5b\x2a\xc8\x3c\x34\x6e\x99\xce\x6e\x2a\xde\x4b\x26\x61\xe8\x00\x96\x2e\xd7\x4c\xa3\xd9\xef\x47\xb3\xec\x28\x75\x41\x4c\x76\x5a\xeb\xb6\xcd\x88\x99\xd6\xd9\xe0\x10\x73\x1a\xa9\x61\x32\xb7\x7d\x6e\x1c\xdb\xd1\xdb\x3e\x6c\xa5\xa5\x3e\x7b\x80\x4b\x6f\x1b\xa5\xf0\xf1\xab\xa1\x86\xf2\x8f\x7e\xd1\x41\xaa\x53\x63\x48\x94\xc4\x2c\x8b\x1e\xfd\x2f\x35\x96\x72\x21\x2d\x58\x55\x12\x5b\x59\xd6\x70\x56\xf6\xd8\x7d\xc2\x9f\x4a\xd3\x60\xa1\xb4\xe2\xe3\x3b\x64\x6d\xaa\x8c\x98\x85\x3c\x58\x32\x2f\x58\xdd\x94\xc2\xb4\x3f\x8e\xef\x1f\xf5\x5f\x95\xaf\x57\x74\xe8\xf1\x1a\xce\x0c\xc9\x17\xb5\xee\xb5\xba\x3a\xbb\xb3\xe1\x65\xea\xfc\x93\x7a\x67\x76\xbd\xa9\x30\x0e\xf0\xe7\xd3\x9b\x12\xe3\x81\xca\xdb\x0b\x78\x06\xbb\x53\xb8\x5b\xb3\x5e\xad\xc2\x74\xee\xfc\x1d\x00\xa3\x60\x91\x7b\xcb\x03\x00\x00")
+
+func _1528395721_campaign_spec_executionsUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395721_campaign_spec_executionsUpSql,
+		"1528395721_campaign_spec_executions.up.sql",
+	)
+}
+
+func _1528395721_campaign_spec_executionsUpSql() (*asset, error) {
+	bytes, err := _1528395721_campaign_spec_executionsUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395721_campaign_spec_executions.up.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x36, 0x18, 0x0, 0x18, 0xef, 0xde, 0xc1, 0x18, 0xcc, 0xc0, 0x4e, 0x87, 0x22, 0xf5, 0x8c, 0x24, 0xfb, 0xb9, 0x60, 0xb5, 0xfb, 0x68, 0x90, 0x2f, 0x39, 0x1e, 0xfd, 0xde, 0x81, 0xb0, 0xc4, 0x1e}}
+	return a, nil
+}
+
+var __1528395722_campaign_step_cacheDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x4c\xcc\xc1\x0a\xc2\x30\x0c\x80\xe1\x7b\x9e\x22\xef\xd1\xd3\x36\xab\x14\xda\x55\xb6\x0a\xde\x4a\x09\xc1\xe5\x60\x2d\x36\x82\x8f\x2f\x38\x04\xcf\xff\xcf\x37\xda\x93\x9b\x0d\xc0\xe0\x93\x5d\x30\x0d\xa3\xb7\x48\xe5\xde\x8a\xdc\x6a\xee\x8d\x29\xf3\x9b\xe9\xa5\xf2\xa8\x1d\x0f\x4b\x3c\xe3\x14\xfd\x25\xcc\xe8\x8e\x68\xaf\x6e\x4d\x2b\x52\xa1\x8d\xf3\x26\x6a\xe0\x3b\xec\xc8\x7f\xff\x71\xca\x2d\xef\x37\x57\x7d\x0a\x77\x03\x30\xc5\x10\x5c\x32\xf0\x19\x00\x78\x3e\x37\xc9\x89\x00\x00\x00")
+
+func _1528395722_campaign_step_cacheDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395722_campaign_step_cacheDownSql,
+		"1528395722_campaign_step_cache.down.sql",
+	)
+}
+
+func _1528395722_campaign_step_cacheDownSql() (*asset, error) {
+	bytes, err := _1528395722_campaign_step_cacheDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395722_campaign_step_cache.down.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xa3, 0x49, 0x4e, 0xef, 0xa9, 0x46, 0x6c, 0x9b, 0x6, 0x2b, 0xb5, 0x6e, 0x81, 0xea, 0x50, 0x85, 0x65, 0xbf, 0xd1, 0xb8, 0x7f, 0x50, 0x55, 0x5e, 0x63, 0xf0, 0xcf, 0x10, 0x6c, 0x85, 0x5b, 0x87}}
+	return a, nil
+}
+
+var __1528395722_campaign_step_cacheUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x9c\x91\xc1\x6e\xf2\x30\x10\x84\xef\x79\x8a\x3d\x06\x89\x37\xe0\x64\x92\xfd\x7f\x45\x75\x9c\xd6\x38\x52\x39\x59\x26\x59\x88\xd5\xe0\x44\xb1\x29\xb4\x4f\x5f\x19\x2a\x5a\x09\xc4\xa1\x47\x7b\x66\x67\xc7\x9f\x97\xf8\xbf\x10\x8b\x24\xc9\x24\x32\x85\xa0\xd8\x92\x23\x34\x66\x3f\x1a\xbb\x73\xda\x07\x1a\x75\x63\x9a\x8e\x34\xb9\x30\x59\xf2\x90\x26\x00\x00\xb6\x85\x8d\xdd\x79\x9a\xac\xe9\xe1\x59\x16\x25\x93\x6b\x78\xc2\xf5\xfc\xac\x4e\x34\x0e\xda\xb6\x60\x5d\xa0\x1d\x4d\x20\x2a\x05\xa2\xe6\x1c\x24\xfe\x43\x89\x22\xc3\xd5\xd9\x93\xda\x76\x06\x95\x80\x1c\x39\x2a\x84\x8c\xad\x32\x96\x23\xe4\xd1\x25\x63\x93\x4b\xdc\xc6\x78\xd2\x13\xbd\x43\xa0\x53\xb8\x86\x5d\xb4\xd8\xd0\xeb\xce\xf8\xee\x9e\xda\xda\xed\xf6\xde\x7d\x33\x91\x09\xd4\x6a\x13\x20\xd8\x3d\xf9\x60\xf6\x23\x1c\x6d\xe8\xce\x47\xf8\x1c\x1c\x5d\x27\x62\x1d\x56\x73\x05\x6e\x38\xa6\xb3\xcb\x7c\x6f\x7c\xd0\x07\xff\xa7\x84\x64\xf6\x83\xbb\x16\xc5\x4b\x8d\x50\x88\x1c\x5f\x1f\x51\xd7\x6f\xf4\x11\x41\x3d\xb0\xa4\xdf\xd0\xe7\x57\x5c\xf3\x5f\x70\xe2\x4e\xc6\x15\xca\x9b\x1f\x1e\xa9\xd1\x74\xa2\xe6\x10\xec\xe0\x3c\xb0\x3c\x87\xac\xe2\x75\x19\x97\xc5\xf8\xce\x06\xd8\x0c\x43\x4f\xc6\xdd\x3e\x68\x6b\x7a\x4f\x8b\x24\xc9\xaa\xb2\x2c\xd4\x22\xf9\x1a\x00\x3a\x98\x4c\x95\x4e\x02\x00\x00")
+
+func _1528395722_campaign_step_cacheUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395722_campaign_step_cacheUpSql,
+		"1528395722_campaign_step_cache.up.sql",
+	)
+}
+
+func _1528395722_campaign_step_cacheUpSql() (*asset, error) {
+	bytes, err := _1528395722_campaign_step_cacheUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395722_campaign_step_cache.up.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xf1, 0xa1, 0xeb, 0xc4, 0xed, 0xeb, 0x53, 0x76, 0x57, 0x30, 0x9a, 0x63, 0xde, 0x4f, 0x8d, 0x6b, 0x19, 0xd4, 0x39, 0xf, 0xfb, 0xb6, 0x5b, 0xc9, 0x61, 0xb3, 0x75, 0x8f, 0x71, 0x22, 0x7, 0xcc}}
+	return a, nil
+}
+
+var __1528395723_campaign_spec_execution_templatesDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x94\x91\xcd\x6a\xf2\x40\x14\x86\xf7\x73\x15\xe7\x5b\x99\xc0\x47\x2f\x20\xe2\x22\x26\xc7\x36\x25\x3f\x92\x8c\xb4\xbb\x21\x8e\x07\x33\x5a\x27\xc1\x33\x81\x4a\xe9\xbd\x97\x44\xba\x31\xad\xd0\xed\xf0\xfe\x3c\xef\x9c\x25\x3e\x26\xf9\x5c\x88\x30\x95\x58\x82\x0c\x97\x29\x82\xae\x4f\x5d\x6d\xf6\x56\xb1\xa3\x4e\xe9\x5a\x37\xa4\xc8\xba\xb3\x21\x86\xb8\x2c\xd6\x10\x15\xe9\x26\xcb\x21\x59\x01\xbe\x26\x95\xac\xa0\xed\x5d\xd7\x3b\xfe\x35\xa7\x23\xad\xe8\x9d\x74\xef\x4c\x6b\x59\x00\x00\x84\x71\xfc\x9d\xa3\x9b\xda\xee\x89\xc9\x5d\x85\x66\x07\x5b\xb3\x37\xd6\x41\x89\x2b\x2c\x31\x8f\xb0\xba\xd1\xb0\x67\x76\x3e\x14\x39\xc4\x98\xa2\x44\xa8\x50\x42\xbe\x49\x53\x88\x07\x47\x39\xb4\xcf\x85\xd8\xac\xe3\x50\xde\xc1\x18\x5c\xd3\xee\x05\x78\x23\x60\x85\x29\x46\x12\x4e\xc6\x7a\x9a\x1f\xcc\xce\x1f\x5f\x57\x65\x91\xc1\x81\x5b\xbb\x55\xed\xf6\x40\xda\xa9\x23\x5d\xd8\x9b\xc4\xb0\x0f\x61\x05\x47\xba\x8c\xae\xe7\x22\xb9\x9d\xc9\xa0\x79\x58\x30\x66\xc3\x62\x90\x06\xc1\x75\xb7\xf0\xc5\xcb\x13\x96\x38\x85\x63\xf8\xb7\x80\xd9\xc7\xe7\x2c\x08\x46\x86\xbf\x7c\xf8\xcf\x97\x9b\x56\xfc\xbf\xa3\x66\xaa\xcf\xba\x51\x67\xe2\xfe\xcd\xa9\xae\x76\xcd\x70\xf3\xa8\xc8\xb2\x44\xce\xc5\xd7\x00\xea\x79\x07\x31\x4c\x02\x00\x00")
+
+func _1528395723_campaign_spec_execution_templatesDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395723_campaign_spec_execution_templatesDownSql,
+		"1528395723_campaign_spec_execution_templates.down.sql",
+	)
+}
+
+func _1528395723_campaign_spec_execution_templatesDownSql() (*asset, error) {
+	bytes, err := _1528395723_campaign_spec_execution_templatesDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395723_campaign_spec_execution_templates.down.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x61, 0xab, 0x25, 0x4e, 0xd8, 0xb6, 0xe7, 0x26, 0x87, 0x8f, 0xa5, 0x0, 0x6b, 0xa2, 0x80, 0x95, 0x4f, 0x18, 0xd1, 0x65, 0x13, 0x9f, 0xd3, 0xb4, 0xd8, 0xb5, 0x9, 0xef, 0x3b, 0xea, 0x10, 0xcf}}
+	return a, nil
+}
+
+var __1528395723_campaign_spec_execution_templatesUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x9c\x91\x51\x6f\xda\x30\x14\x85\xdf\xfd\x2b\xce\x5b\x8a\x44\xfb\x03\x1a\xf5\x21\x4d\xbc\x15\x2d\x40\x45\x83\xf6\x30\x4d\x96\x71\x2e\xc4\x5d\x6a\x47\xbe\xce\x36\x34\xed\xbf\x4f\x90\x09\xb4\x52\xd0\xd4\xc7\xc4\xf7\x7e\xe7\x9e\x73\xee\xe5\xc7\xc9\x2c\x15\xe2\xfa\x1a\xf2\x27\x99\x3e\x5a\xef\x18\x46\x3b\x04\xe2\xbe\x8d\xb0\x0e\x4c\xdf\x29\xe8\x16\xa6\xd1\x6e\x43\x4c\x11\xdc\x91\x61\xfc\x68\xc8\x21\x36\x04\xa3\x5f\x3a\x6d\x37\x6e\xff\x7f\x87\xea\x99\x18\x31\x68\xc7\x6b\x1f\x5e\xf2\x61\x6f\x0c\xf6\xfb\x71\xb6\x6e\xd3\xd2\x11\xa7\x76\x6b\xca\xd6\xb0\x8c\x40\x5d\xab\x0d\xd5\x58\x6d\xa1\x77\xa8\x9d\x9c\x5f\x63\x52\xf0\x18\x1c\x7d\xa0\x1a\xad\xfd\x76\xd4\xe4\x9b\x23\xc7\xd6\x7c\x23\xb2\xb2\x92\x0b\x54\xd9\x7d\x29\x0f\x43\x83\x02\x1d\x0c\x0a\x00\xc8\x8a\x02\xf9\xbc\x5c\x4e\x67\xa7\xa7\x30\x9e\xd9\xbb\x15\x66\xf3\x0a\xb3\x65\x59\xa2\x90\x1f\xb2\x65\x59\x21\xf9\xf5\x3b\xb9\xbd\x1d\x1e\xf3\x07\x99\x7f\xc2\xd5\xfe\x43\xc5\x6d\x47\x7e\x7d\x75\x4a\x1a\xe1\x0e\x89\x5f\x3d\x93\x89\xc9\x68\xfc\x5a\x99\x49\x07\xd3\xa8\x21\x6c\xd5\xe9\xd8\x9c\x97\xfe\xf2\xf5\xa2\xf4\x1b\xa8\xbd\xb6\x0e\x41\x6f\x93\x51\x2a\xc4\xf2\xb1\xc8\xaa\x0b\xa9\x3c\xc9\xea\xad\x28\xee\x86\x8b\xd4\xaa\xb7\x6d\xad\x06\x2b\xa7\x46\xc7\x70\x7d\xdb\x8e\xc4\xe7\x07\xb9\x90\xa7\x18\x4c\x9e\x0e\x96\x52\xf1\x5f\x2d\xa1\x58\xcc\x1f\xcf\x56\x74\x16\x12\xa9\x53\x46\x9b\x86\x14\xb9\x18\x2c\xf1\xeb\xcc\x7d\x1f\xbb\x3e\xbe\xbb\xe2\xbf\xeb\xff\xf4\x9a\x0a\x91\xcf\xa7\xd3\x49\x95\x8a\x3f\x03\x00\x5b\x0d\xe7\x79\x52\x03\x00\x00")
+
+func _1528395723_campaign_spec_execution_templatesUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395723_campaign_spec_execution_templatesUpSql,
+		"1528395723_campaign_spec_execution_templates.up.sql",
+	)
+}
+
+func _1528395723_campaign_spec_execution_templatesUpSql() (*asset, error) {
+	bytes, err := _1528395723_campaign_spec_execution_templatesUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395723_campaign_spec_execution_templates.up.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x4e, 0x9b, 0x8e, 0x7f, 0xd9, 0x4, 0xb9, 0xf, 0xd9, 0xae, 0x76, 0x9f, 0xf6, 0x4, 0xaf, 0xdf, 0x44, 0xa7, 0x71, 0xc6, 0x9e, 0x11, 0xcc, 0xee, 0x87, 0x99, 0x73, 0xaa, 0x2c, 0xc0, 0x6, 0x15}}
+	return a, nil
+}
+
+var __1528395724_changeset_jobsDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x36\x00\xc9\xff\x42\x45\x47\x49\x4e\x3b\x0a\x0a\x44\x52\x4f\x50\x20\x54\x41\x42\x4c\x45\x20\x49\x46\x20\x45\x58\x49\x53\x54\x53\x20\x63\x68\x61\x6e\x67\x65\x73\x65\x74\x5f\x6a\x6f\x62\x73\x3b\x0a\x0a\x43\x4f\x4d\x4d\x49\x54\x3b\x0a\x03\x00\xd6\x7b\x79\xcc\x36\x00\x00\x00")
+
+func _1528395724_changeset_jobsDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395724_changeset_jobsDownSql,
+		"1528395724_changeset_jobs.down.sql",
+	)
+}
+
+func _1528395724_changeset_jobsDownSql() (*asset, error) {
+	bytes, err := _1528395724_changeset_jobsDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395724_changeset_jobs.down.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x1c, 0x97, 0x6f, 0xe4, 0x29, 0xbe, 0xbb, 0x13, 0x25, 0xa, 0x67, 0xe, 0x67, 0xd5, 0x39, 0xc1, 0x4b, 0x1a, 0x58, 0x3b, 0x7d, 0x1f, 0x6d, 0x7a, 0x4a, 0xa1, 0x1c, 0xd7, 0x6c, 0x21, 0xb0, 0xe7}}
+	return a, nil
+}
+
+var __1528395724_changeset_jobsUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x9c\x93\xcf\x6e\x9b\x40\x10\x87\xef\x3c\xc5\xdc\x00\xa9\x87\x9e\x6d\xf5\x40\xf0\xb6\xb5\x82\x71\x45\x88\xd4\x9c\x56\x0b\x3b\xc6\xeb\x9a\x5d\xba\x7f\x94\xa4\x55\xdf\xbd\x62\xc1\x26\x55\xe2\x62\xe5\xb8\x9e\x6f\x3e\xf3\x1b\xcd\xdc\x90\x2f\xeb\x7c\x19\x04\x69\x41\x92\x92\x40\x99\xdc\x64\x04\xea\x3d\x93\x0d\x1a\xb4\xf4\xa0\x2a\x03\x51\x00\x00\x20\x38\x54\xa2\x31\xa8\x05\x3b\xc2\xb7\x62\xbd\x49\x8a\x07\xb8\x25\x0f\x1f\x7c\xb5\x72\xc7\x1f\xb4\xd1\xca\x75\x60\xf1\xc9\x42\xbe\x2d\x21\xbf\xcf\xb2\xa1\xea\x0c\x6a\x2a\x38\x08\x69\xb1\x41\x7d\xae\x42\x41\x3e\x93\x82\xe4\x29\xb9\xf3\x8c\x89\x04\x8f\x61\x9b\xc3\x8a\x64\xa4\x24\x90\x26\x77\x69\xb2\x22\xb0\xea\xb1\xa2\xff\xb6\xc1\x57\xb3\xb6\x63\xa2\x91\xbd\xb3\x12\x8d\x90\xf6\x4d\xe5\x09\xbb\x5a\x7b\xce\x3d\xe3\x3d\x71\xd7\x8a\x0f\xaa\xa2\xf6\xb9\xc3\xb7\x66\xd3\xb1\xe7\xa3\x62\x1c\x0e\x46\xc9\xaa\x4f\x9a\xdc\x67\x25\x84\xbf\xff\x84\x8b\xc5\xf0\x5b\xfa\x95\xa4\xb7\x10\xf9\x87\xd7\xa8\x5d\x34\x76\xc5\xf0\x09\x42\x55\x1d\xb0\xb6\xe1\x62\xd1\xdb\xe3\xc1\x6a\x2c\xb3\xe3\xdf\x9d\x95\x3f\x1d\x3a\xe4\xe1\x00\xec\x98\x38\x3a\x8d\xb4\x45\x63\x58\x33\xa0\xe7\x56\x6d\x91\x53\x66\xc1\x8a\x16\x8d\x65\x6d\x07\x8f\xc2\xee\xfd\x13\x7e\x29\x89\xa3\x42\x48\x61\xf6\xd7\x90\x9d\x56\x35\x1a\x43\xd9\xce\xa2\x9e\x61\xa5\x6b\xa9\xf6\xc3\x7d\xbd\x2e\xa7\x28\x1f\x27\x74\xcc\x31\x0f\xd7\x1a\xd9\x4c\xac\xd7\xbd\x52\x3d\x46\xe3\x44\x5d\xc7\xdf\xd9\x1f\xc4\xd3\x81\xad\xf3\x15\xf9\x3e\x2d\x90\x3f\x30\x3a\x1d\x0f\x15\xfc\xa9\xdf\xff\x7f\x81\x68\x02\xe2\xe5\x7f\x4d\x2f\x0e\xe3\x82\xea\x05\x31\xe3\xf2\x2b\x74\xc1\xe2\x6b\x3e\xd6\x76\xb3\x59\x97\xcb\xe0\xef\x00\x0f\xab\x13\xb1\x48\x04\x00\x00")
+
+func _1528395724_changeset_jobsUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395724_changeset_jobsUpSql,
+		"1528395724_changeset_jobs.up.sql",
+	)
+}
+
+func _1528395724_changeset_jobsUpSql() (*asset, error) {
+	bytes, err := _1528395724_changeset_jobsUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395724_changeset_jobs.up.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x72, 0xa5, 0xd9, 0xf0, 0x9a, 0xeb, 0x45, 0xee, 0x84, 0xa4, 0x5b, 0x77, 0x34, 0x42, 0x7c, 0x34, 0xa2, 0x9e, 0xdd, 0x4f, 0x90, 0xb2, 0x6c, 0x66, 0xe3, 0x57, 0x6f, 0xab, 0x9b, 0x89, 0x6, 0x6b}}
+	return a, nil
+}
+
+var __1528395725_campaign_snapshotsDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x3a\x00\xc5\xff\x42\x45\x47\x49\x4e\x3b\x0a\x0a\x44\x52\x4f\x50\x20\x54\x41\x42\x4c\x45\x20\x49\x46\x20\x45\x58\x49\x53\x54\x53\x20\x63\x61\x6d\x70\x61\x69\x67\x6e\x5f\x73\x6e\x61\x70\x73\x68\x6f\x74\x73\x3b\x0a\x0a\x43\x4f\x4d\x4d\x49\x54\x3b\x0a\x03\x00\xfa\x15\x3f\xc2\x3a\x00\x00\x00")
+
+func _1528395725_campaign_snapshotsDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395725_campaign_snapshotsDownSql,
+		"1528395725_campaign_snapshots.down.sql",
+	)
+}
+
+func _1528395725_campaign_snapshotsDownSql() (*asset, error) {
+	bytes, err := _1528395725_campaign_snapshotsDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395725_campaign_snapshots.down.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x89, 0x52, 0x1b, 0xad, 0x5, 0x38, 0x31, 0xdc, 0x6f, 0x4b, 0xfe, 0x9a, 0xb6, 0xad, 0x88, 0xe2, 0x87, 0xc3, 0x84, 0x1d, 0xe, 0x36, 0x22, 0x5a, 0xe5, 0x16, 0xc2, 0x34, 0xfa, 0xfe, 0xef, 0x20}}
+	return a, nil
+}
+
+var __1528395725_campaign_snapshotsUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x8c\x92\x41\x6f\xdb\x30\x0c\x85\xef\xfe\x15\xbc\xc5\x06\x7a\xd8\x39\xc5\x0e\xae\xad\x6e\x41\x1d\x67\x70\x5d\x60\xc5\x30\x08\xac\xc5\x38\xda\x6c\x49\x93\xb8\xb5\xdd\xaf\x1f\xa2\x0c\x49\x11\x17\x70\x8e\x34\xbf\xf7\x1e\x2d\xf2\x46\x7c\x5a\xd5\xd7\x49\x52\x34\x22\x6f\x05\xb4\xf9\x4d\x25\xa0\xc3\xd1\xa1\xee\x8d\x0c\x06\x5d\xd8\x59\x0e\x90\x26\x00\x00\x5a\xc1\x93\xee\x03\x79\x8d\x03\x7c\x69\x56\xeb\xbc\x79\x84\x3b\xf1\x78\x15\xbb\x47\xd9\x01\xd3\x86\xa1\xde\xb4\x50\x3f\x54\x15\x34\xe2\x56\x34\xa2\x2e\xc4\xfd\xd1\x3d\xa4\x5a\x65\xb0\xa9\xa1\x14\x95\x68\x05\x14\xf9\x7d\x91\x97\x02\xca\x3d\xda\xec\x07\x39\xd8\xb2\x65\x1c\x40\x1b\xa6\x9e\xfc\xc9\xb1\x14\xb7\xf9\x43\xd5\xc2\x87\x03\x65\x1d\x99\x59\x68\x24\xdf\x93\x9a\xc5\xba\xc1\x86\x0b\x30\xe5\x71\xcb\xb3\xd4\x16\xf5\x40\x4a\x76\x3b\xea\x7e\x86\x59\x7a\xff\x1b\x12\x9d\xf3\xf6\xcf\x05\x13\x44\xba\xdb\xa1\xe9\x29\x48\x4f\xbf\x7e\x53\xe0\x4b\x65\x8e\x8c\xd2\xa6\x9f\x85\x47\x52\x1a\x8d\x64\x3d\x92\x64\x2b\xe3\x13\x4e\xd6\x7b\xa6\xf1\xe4\xac\x0c\x8c\xc3\x10\x75\x01\x7e\x04\x6b\x9e\xa6\xf8\xe2\xdb\xf7\xc5\x72\x79\x68\x16\x9f\x45\x71\x07\x69\x2c\x24\xbf\x3a\xb2\xdb\xf4\xdc\x27\x83\x8f\xb0\x40\xef\xf1\x75\xb1\x5c\x32\xbd\x70\xf6\x7f\x61\x9e\x90\x49\x49\x64\x88\x79\x8c\xa3\x83\x67\xcd\xbb\x58\xc2\x5f\x6b\x68\x1a\x6e\xec\x73\x9a\x25\xd9\xe9\xf8\x57\x75\x29\xbe\xbe\x73\xfc\xf2\xf8\x49\x2b\x79\x8a\x92\x5a\xbd\xec\xcf\x77\x2a\x48\xdf\x08\xae\xde\x0c\x17\xb3\x36\xeb\xf5\xaa\xbd\x4e\xfe\x0d\x00\x74\x33\x0f\x11\x79\x03\x00\x00")
+
+func _1528395725_campaign_snapshotsUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395725_campaign_snapshotsUpSql,
+		"1528395725_campaign_snapshots.up.sql",
+	)
+}
+
+func _1528395725_campaign_snapshotsUpSql() (*asset, error) {
+	bytes, err := _1528395725_campaign_snapshotsUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395725_campaign_snapshots.up.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xc2, 0x85, 0x3e, 0x2f, 0xa6, 0xb0, 0xf0, 0x31, 0xa5, 0x7a, 0x3, 0x66, 0xb, 0xfb, 0xf2, 0x1b, 0x8e, 0x1f, 0x5d, 0x9a, 0xe1, 0xa3, 0x51, 0x65, 0xb2, 0x0, 0x31, 0x2b, 0xb, 0x61, 0xab, 0x70}}
+	return a, nil
+}
+
+var __1528395726_changesets_rebase_stateDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x72\x72\x75\xf7\xf4\xb3\xe6\xe2\x72\xf4\x09\x71\x0d\x52\x08\x71\x74\xf2\x71\x55\x48\xce\x48\xcc\x4b\x4f\x2d\x4e\x2d\x29\x56\x70\x09\xf2\x0f\x50\x70\xf6\xf7\x09\xf5\xf5\x53\xf0\x74\x53\x70\x8d\xf0\x0c\x0e\x09\x56\x28\x4a\x4d\x4a\x2c\x4e\x8d\x2f\x2e\x49\x2c\x49\xb5\x26\x4b\x2b\x98\x28\x4a\x2d\xb3\xe6\xe2\x72\xf6\xf7\xf5\xf5\x0c\xb1\xe6\x02\x0c\x00\x02\x6c\xc8\xf2\x8a\x00\x00\x00")
+
+func _1528395726_changesets_rebase_stateDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395726_changesets_rebase_stateDownSql,
+		"1528395726_changesets_rebase_state.down.sql",
+	)
+}
+
+func _1528395726_changesets_rebase_stateDownSql() (*asset, error) {
+	bytes, err := _1528395726_changesets_rebase_stateDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395726_changesets_rebase_state.down.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x21, 0xf8, 0x6, 0xba, 0x6e, 0x91, 0x83, 0xeb, 0xfb, 0x51, 0xad, 0xd2, 0xd7, 0x15, 0x43, 0x98, 0x2c, 0xa1, 0xbd, 0xc5, 0xd0, 0x3d, 0x45, 0x25, 0xc9, 0xee, 0x75, 0x2, 0xa6, 0x92, 0x97, 0xdb}}
+	return a, nil
+}
+
+var __1528395726_changesets_rebase_stateUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x72\x72\x75\xf7\xf4\xb3\xe6\xe2\x72\xf4\x09\x71\x0d\x52\x08\x71\x74\xf2\x71\x55\x48\xce\x48\xcc\x4b\x4f\x2d\x4e\x2d\x29\x56\x70\x74\x71\x51\x70\xf6\xf7\x09\xf5\xf5\x53\xf0\x74\x53\xf0\xf3\x0f\x51\x70\x8d\xf0\x0c\x0e\x09\x56\x28\x4a\x4d\x4a\x2c\x4e\x8d\x2f\x2e\x49\x2c\x49\x55\x28\x49\xad\x28\xb1\x26\xd7\x08\xb0\x39\x45\xa9\x65\x50\x53\xb8\x9c\xfd\x7d\x7d\x3d\x43\xac\xb9\x00\x03\x00\x1d\xdc\x3b\xf6\x9a\x00\x00\x00")
+
+func _1528395726_changesets_rebase_stateUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395726_changesets_rebase_stateUpSql,
+		"1528395726_changesets_rebase_state.up.sql",
+	)
+}
+
+func _1528395726_changesets_rebase_stateUpSql() (*asset, error) {
+	bytes, err := _1528395726_changesets_rebase_stateUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395726_changesets_rebase_state.up.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x5c, 0x9b, 0x7a, 0x12, 0x36, 0x30, 0xdd, 0x3f, 0x9b, 0x15, 0x33, 0x85, 0x1f, 0x7a, 0x1, 0x90, 0x88, 0xd9, 0x2c, 0x57, 0xea, 0x7c, 0x3f, 0x56, 0xe, 0x74, 0xe6, 0x26, 0xa0, 0xa2, 0x65, 0x21}}
+	return a, nil
+}
+
+var __1528395727_campaign_credentialsDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x3c\x00\xc3\xff\x42\x45\x47\x49\x4e\x3b\x0a\x0a\x44\x52\x4f\x50\x20\x54\x41\x42\x4c\x45\x20\x49\x46\x20\x45\x58\x49\x53\x54\x53\x20\x63\x61\x6d\x70\x61\x69\x67\x6e\x5f\x63\x72\x65\x64\x65\x6e\x74\x69\x61\x6c\x73\x3b\x0a\x0a\x43\x4f\x4d\x4d\x49\x54\x3b\x0a\x03\x00\x3e\x4f\x7c\x7a\x3c\x00\x00\x00")
+
+func _1528395727_campaign_credentialsDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395727_campaign_credentialsDownSql,
+		"1528395727_campaign_credentials.down.sql",
+	)
+}
+
+func _1528395727_campaign_credentialsDownSql() (*asset, error) {
+	bytes, err := _1528395727_campaign_credentialsDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395727_campaign_credentials.down.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x92, 0xd7, 0xd0, 0x26, 0x2a, 0xcc, 0x64, 0x93, 0x62, 0xf2, 0xe7, 0x94, 0x69, 0x1f, 0x22, 0x47, 0x70, 0x4c, 0x18, 0x53, 0x3a, 0x4a, 0xf1, 0xfa, 0xe2, 0xa8, 0x42, 0x26, 0xeb, 0x90, 0xb9, 0x9c}}
+	return a, nil
+}
+
+var __1528395727_campaign_credentialsUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x9c\x53\xc1\x6e\xdb\x30\x0c\xbd\xfb\x2b\x78\xab\x0d\xe4\xb0\x9d\x3b\x0c\x70\x1d\x76\x13\xea\xc8\x9b\xad\x00\xed\x49\x50\x2c\xc2\x11\x16\xcb\x9e\xa4\xac\xed\xbe\x7e\x90\x93\x35\x40\xe3\x02\x41\x8e\xd6\x23\xf9\xc8\xf7\xfc\xee\xf0\x1b\xe3\xb7\x49\x52\xd4\x98\x0b\x04\x91\xdf\x95\x08\xec\x1e\x78\x25\x00\x1f\x59\x23\x1a\x68\x55\x3f\x2a\xd3\x59\xd9\x3a\xd2\x64\x83\x51\x3b\x0f\x69\x02\x00\x60\x34\x6c\x4c\xe7\xc9\x19\xb5\x83\x1f\x35\x5b\xe5\xf5\x13\x3c\xe0\xd3\x62\x42\xad\xea\xc9\x8f\xaa\x25\xb9\xf7\xe4\xa4\xd1\x60\x6c\xa0\x8e\x1c\xd4\x78\x8f\x35\xf2\x02\x1b\x88\x90\x4f\x8d\xce\xa0\xe2\xb0\xc4\x12\x05\x42\x91\x37\x45\xbe\x44\x58\xc6\xb2\x3a\xae\xf4\x7e\xe0\xe0\xba\x0f\xe6\x0d\xae\xbb\x74\x1c\xbd\x04\x72\x56\xed\xa4\x27\xf7\xc7\xb4\x24\xc3\xeb\x48\x10\xe8\x25\x4c\xe7\xf3\x75\x59\x7e\x50\x68\xf4\x5c\x59\x3c\x25\xae\x38\x61\x87\xa7\x93\x64\xb0\x79\x0d\xa4\xde\x75\xb4\x8e\x54\x20\x2d\x55\x80\x60\x7a\xf2\x41\xf5\x23\x3c\x9b\xb0\x9d\x3e\xe1\xef\x60\xe9\xad\x23\xaa\x91\xaf\x4b\x01\x76\x78\x4e\xb3\x23\xe3\xa8\xaf\xee\x9f\x06\x14\x15\x6f\x44\x9d\x33\x2e\x66\x6d\x96\x5b\xe5\xa5\x0a\xb2\x1f\x7c\x90\x9f\xe5\x9b\xfe\x50\x7c\xc7\xe2\x01\xd2\x73\x87\x59\x73\x20\xab\xea\x73\xb7\x8e\x58\xb6\xb8\x88\x7a\xd6\x1d\x69\x87\x20\x37\x3b\x65\x7f\xfd\x5f\x61\xb6\x0c\xbe\x7c\x85\x9b\x9b\x6b\x89\x8c\xbe\x80\xc6\xe8\x23\x49\x92\x9d\xe2\xb3\xe6\xec\xe7\x1a\x81\xf1\x25\x3e\x5e\x90\xa2\x93\xa0\xb2\x1d\x34\xc9\x6d\x94\x79\x6f\xcd\xef\x3d\xc5\x34\xcc\xb5\x40\x5a\x54\x79\x89\x4d\x81\xe7\xe2\x2f\xe0\x53\xb6\x80\x19\xfc\x90\x96\x03\x7c\x76\x48\xfc\xe9\x67\x9e\x8d\x9e\xee\xaa\x56\x2b\x26\x6e\x93\x7f\x03\x00\xd7\x92\xb3\x6f\x27\x04\x00\x00")
+
+func _1528395727_campaign_credentialsUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395727_campaign_credentialsUpSql,
+		"1528395727_campaign_credentials.up.sql",
+	)
+}
+
+func _1528395727_campaign_credentialsUpSql() (*asset, error) {
+	bytes, err := _1528395727_campaign_credentialsUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395727_campaign_credentials.up.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x51, 0xb1, 0xde, 0x9e, 0x19, 0x4f, 0x8b, 0x2a, 0x36, 0xcb, 0xa3, 0x5e, 0x7b, 0xb8, 0x5b, 0xc7, 0x3d, 0xf4, 0x1d, 0x7e, 0x2b, 0xb5, 0xfe, 0xff, 0x8e, 0xc9, 0x28, 0x86, 0xef, 0x22, 0xde, 0xa9}}
+	return a, nil
+}
+
+var __1528395728_user_sub_repo_permissionsDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x41\x00\xbe\xff\x42\x45\x47\x49\x4e\x3b\x0a\x0a\x44\x52\x4f\x50\x20\x54\x41\x42\x4c\x45\x20\x49\x46\x20\x45\x58\x49\x53\x54\x53\x20\x75\x73\x65\x72\x5f\x73\x75\x62\x5f\x72\x65\x70\x6f\x5f\x70\x65\x72\x6d\x69\x73\x73\x69\x6f\x6e\x73\x3b\x0a\x0a\x43\x4f\x4d\x4d\x49\x54\x3b\x0a\x03\x00\xd8\x8d\x17\x79\x41\x00\x00\x00")
+
+func _1528395728_user_sub_repo_permissionsDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395728_user_sub_repo_permissionsDownSql,
+		"1528395728_user_sub_repo_permissions.down.sql",
+	)
+}
+
+func _1528395728_user_sub_repo_permissionsDownSql() (*asset, error) {
+	bytes, err := _1528395728_user_sub_repo_permissionsDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395728_user_sub_repo_permissions.down.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x9, 0x25, 0x74, 0x5e, 0x60, 0x49, 0x1c, 0xcc, 0x2, 0xe9, 0x70, 0x60, 0xaf, 0xca, 0xca, 0x8a, 0x2a, 0xeb, 0xa6, 0xea, 0x89, 0xd3, 0x48, 0x98, 0x25, 0x5b, 0xa9, 0xd0, 0xa0, 0xe0, 0x56, 0x30}}
+	return a, nil
+}
+
+var __1528395728_user_sub_repo_permissionsUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x94\xd1\xdf\x4a\xc3\x30\x14\x06\xf0\xfb\x3c\xc5\xb9\x5b\x0b\x7b\x82\xf5\x2a\x6b\x4f\x25\xd8\xa6\xd2\x66\xb0\x21\x12\xaa\x3d\xb8\x80\xfd\x43\x93\xb2\xa1\xf8\xee\x62\x6a\x99\x20\x4c\xbd\x2c\xa7\xdf\x2f\xf0\x7d\x5b\xbc\x11\x32\x62\x2c\x2e\x91\x2b\x04\xc5\xb7\x19\x82\x48\x41\x16\x0a\x70\x2f\x2a\x55\xc1\x64\x69\xd4\x76\x7a\xd4\x23\x0d\xbd\x1e\x68\x6c\x8d\xb5\xa6\xef\x2c\x04\x0c\x00\xe6\xbb\x69\xc0\x74\x8e\x9e\x69\xf4\x51\xb9\xcb\x32\x28\x31\xc5\x12\x65\x8c\xb3\x61\x03\xd3\x84\x50\x48\x48\x30\x43\x85\x10\xf3\x2a\xe6\x09\xae\x3d\xe2\xed\x5f\x90\xcf\x7f\xae\x19\x43\xed\x8e\xda\x74\x4f\x2f\x53\x43\x16\x1c\x9d\xdd\xfd\xc3\x05\x4a\x30\xe5\xbb\x4c\xc1\xea\xed\x7d\xb5\xd9\xcc\xd7\x6f\x39\x3a\xff\x3b\x37\x0d\x4d\xed\xa8\xd1\xb5\x03\x67\x5a\xb2\xae\x6e\x07\x38\x19\x77\xf4\x9f\xf0\xda\x77\xf4\x93\xe9\xfa\x53\x10\xae\x99\x07\xee\x4a\x91\xf3\xf2\x00\xb7\x78\x80\xe0\xab\xc6\xf5\x52\x45\xc8\xc2\xcb\x2e\x42\x26\xb8\xff\xeb\x2e\x7a\x29\xb3\x90\xd7\xc6\x5b\xde\x89\x18\x8b\x8b\x3c\x17\x2a\x62\x1f\x03\x00\xe6\x24\x92\x9e\x0e\x02\x00\x00")
+
+func _1528395728_user_sub_repo_permissionsUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395728_user_sub_repo_permissionsUpSql,
+		"1528395728_user_sub_repo_permissions.up.sql",
+	)
+}
+
+func _1528395728_user_sub_repo_permissionsUpSql() (*asset, error) {
+	bytes, err := _1528395728_user_sub_repo_permissionsUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395728_user_sub_repo_permissions.up.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xec, 0x38, 0x22, 0xee, 0xe3, 0xa1, 0x31, 0x8f, 0x87, 0xd3, 0x30, 0xab, 0xfc, 0xb1, 0x2f, 0x83, 0xb6, 0xa6, 0x8, 0x92, 0xf9, 0x2b, 0x7e, 0x1, 0x98, 0x89, 0xcd, 0xed, 0x66, 0xea, 0xcd, 0x1d}}
+	return a, nil
+}
+
+var __1528395729_users_deactivated_atDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x49\x00\xb6\xff\x42\x45\x47\x49\x4e\x3b\x0a\x0a\x41\x4c\x54\x45\x52\x20\x54\x41\x42\x4c\x45\x20\x75\x73\x65\x72\x73\x20\x44\x52\x4f\x50\x20\x43\x4f\x4c\x55\x4d\x4e\x20\x49\x46\x20\x45\x58\x49\x53\x54\x53\x20\x64\x65\x61\x63\x74\x69\x76\x61\x74\x65\x64\x5f\x61\x74\x3b\x0a\x0a\x43\x4f\x4d\x4d\x49\x54\x3b\x0a\x03\x00\xc1\x00\x0b\x10\x49\x00\x00\x00")
+
+func _1528395729_users_deactivated_atDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395729_users_deactivated_atDownSql,
+		"1528395729_users_deactivated_at.down.sql",
+	)
+}
+
+func _1528395729_users_deactivated_atDownSql() (*asset, error) {
+	bytes, err := _1528395729_users_deactivated_atDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395729_users_deactivated_at.down.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x8d, 0xeb, 0x49, 0x57, 0xab, 0x77, 0x2, 0x2d, 0xa9, 0xf5, 0x8a, 0x7d, 0xbb, 0xa7, 0x13, 0x8e, 0xfc, 0xd3, 0x37, 0x6c, 0x59, 0xd9, 0xe8, 0x80, 0x9a, 0xc7, 0x62, 0xf7, 0x31, 0xbc, 0x13, 0x48}}
+	return a, nil
+}
+
+var __1528395729_users_deactivated_atUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x65\x00\x9a\xff\x42\x45\x47\x49\x4e\x3b\x0a\x0a\x41\x4c\x54\x45\x52\x20\x54\x41\x42\x4c\x45\x20\x75\x73\x65\x72\x73\x20\x41\x44\x44\x20\x43\x4f\x4c\x55\x4d\x4e\x20\x49\x46\x20\x4e\x4f\x54\x20\x45\x58\x49\x53\x54\x53\x20\x64\x65\x61\x63\x74\x69\x76\x61\x74\x65\x64\x5f\x61\x74\x20\x74\x69\x6d\x65\x73\x74\x61\x6d\x70\x20\x77\x69\x74\x68\x20\x74\x69\x6d\x65\x20\x7a\x6f\x6e\x65\x3b\x0a\x0a\x43\x4f\x4d\x4d\x49\x54\x3b\x0a\x03\x00\x4a\xe4\xdd\x48\x65\x00\x00\x00")
+
+func _1528395729_users_deactivated_atUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395729_users_deactivated_atUpSql,
+		"1528395729_users_deactivated_at.up.sql",
+	)
+}
+
+func _1528395729_users_deactivated_atUpSql() (*asset, error) {
+	bytes, err := _1528395729_users_deactivated_atUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395729_users_deactivated_at.up.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x90, 0xad, 0x4c, 0xfe, 0x1, 0xa4, 0xc4, 0x12, 0xdc, 0x47, 0xa, 0xc6, 0xe3, 0xd1, 0xde, 0x7a, 0xfe, 0xab, 0xb1, 0x1d, 0xe5, 0x4c, 0xc9, 0x4, 0xb5, 0xdb, 0xba, 0x80, 0xda, 0x6a, 0xd, 0x95}}
+	return a, nil
+}
+
+var __1528395730_user_two_factor_authDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x3c\x00\xc3\xff\x42\x45\x47\x49\x4e\x3b\x0a\x0a\x44\x52\x4f\x50\x20\x54\x41\x42\x4c\x45\x20\x49\x46\x20\x45\x58\x49\x53\x54\x53\x20\x75\x73\x65\x72\x5f\x74\x77\x6f\x5f\x66\x61\x63\x74\x6f\x72\x5f\x61\x75\x74\x68\x3b\x0a\x0a\x43\x4f\x4d\x4d\x49\x54\x3b\x0a\x03\x00\xf2\xe1\x6d\x72\x3c\x00\x00\x00")
+
+func _1528395730_user_two_factor_authDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395730_user_two_factor_authDownSql,
+		"1528395730_user_two_factor_auth.down.sql",
+	)
+}
+
+func _1528395730_user_two_factor_authDownSql() (*asset, error) {
+	bytes, err := _1528395730_user_two_factor_authDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395730_user_two_factor_auth.down.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xbc, 0x4f, 0xe9, 0xc, 0xc3, 0xf9, 0x95, 0x8, 0x36, 0x17, 0x14, 0x7d, 0x2f, 0xe2, 0x67, 0x95, 0xf4, 0xc6, 0x9a, 0xaa, 0x6c, 0x3a, 0x57, 0x17, 0x0, 0xbd, 0x80, 0x9d, 0x9c, 0x7, 0x79, 0xb0}}
+	return a, nil
+}
+
+var __1528395730_user_two_factor_authUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x9c\x8f\xc1\x6a\x02\x31\x10\x40\xef\xf9\x8a\xb9\xa9\xd0\x43\xef\x9e\xe2\x3a\x96\xa5\xeb\x5a\xd6\x08\x95\x52\x42\xdc\x8c\x1a\xd0\xcd\x92\xcc\xd6\xda\xd2\x7f\x2f\xdd\x80\x50\xa4\x2d\xf4\x38\xcc\x7b\xf3\x98\x09\xde\xe5\xe5\x58\x88\xac\x42\xa9\x10\x94\x9c\x14\x08\xf9\x0c\xca\x85\x02\x7c\xcc\x97\x6a\x09\x5d\xa4\xa0\xf9\xe4\xf5\xd6\xd4\xec\x83\x36\x1d\xef\x61\x28\x00\x20\xad\x9c\x05\xd7\x30\xed\x28\xc0\x43\x95\xcf\x65\xb5\x86\x7b\x5c\x43\x85\x33\xac\xb0\xcc\x30\x5d\x88\x43\x67\x47\xb0\x28\x61\x8a\x05\x2a\x84\x4c\x2e\x33\x39\xc5\x9b\xfe\x0e\x7b\x6e\x75\xa4\x3a\x10\xc3\xe6\xcc\x64\xfa\x7e\xb9\x2a\x8a\xb4\x0f\x54\xfb\x17\x0a\x67\x5d\x7b\x4b\x11\x98\x5e\xf9\xe9\xf9\xc2\xc0\x14\x67\x72\x55\x28\x18\xbc\x7f\x0c\x92\x70\x30\x91\x75\x17\xc9\x6a\x76\x47\xd2\x91\xa9\x85\x8d\xdb\xb9\x86\xaf\xad\xdb\xa4\x6c\x8d\x3b\x90\xd5\x86\x99\x8e\x2d\xc7\xcb\x4f\x3f\xf1\x7d\xe2\xbb\xa4\x0d\xc3\x57\x2f\xb2\x39\xb6\x70\x72\xbc\xef\x47\x78\xf3\x0d\x25\x89\x1a\xb3\x49\x95\x3f\xc0\x3a\x90\xe1\xdf\xc1\xeb\x4f\x1a\x7f\x1a\x8e\x52\xa8\x6b\xed\x3f\x7d\x31\x1a\x0b\x91\x2d\xe6\xf3\x5c\x8d\xc5\xe7\x00\x95\xa5\x65\xaa\x1f\x02\x00\x00")
+
+func _1528395730_user_two_factor_authUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395730_user_two_factor_authUpSql,
+		"1528395730_user_two_factor_auth.up.sql",
+	)
+}
+
+func _1528395730_user_two_factor_authUpSql() (*asset, error) {
+	bytes, err := _1528395730_user_two_factor_authUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395730_user_two_factor_auth.up.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x6a, 0x46, 0x11, 0xa9, 0x58, 0xa6, 0xd, 0x32, 0x3e, 0x96, 0x4d, 0x59, 0x57, 0xf8, 0x8c, 0xb6, 0x47, 0xe0, 0x9f, 0x50, 0xf5, 0x99, 0x3e, 0x3, 0x32, 0xb, 0x98, 0x55, 0xc3, 0x8f, 0x73, 0x7e}}
+	return a, nil
+}
+
+var __1528395731_access_token_expiry_and_user_sessionsDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x94\xcb\xb1\x0a\xc2\x30\x10\x06\xe0\xfd\x9e\x22\xef\x71\x53\x5b\xa3\x04\x92\x46\xda\x08\x6e\x47\xa8\x37\x04\xa5\x2d\xfd\x53\xf0\xf1\x05\x71\x70\xed\xfe\x7d\xad\xbd\xb8\x9e\x89\x4e\x43\xbc\x9a\xd4\xb4\xde\x1a\x77\x36\xf6\xee\xc6\x34\x9a\x1d\xba\x09\x14\x28\xcb\x0c\x26\x6a\x7c\xb2\xc3\x4f\xe5\x69\x52\x40\xea\xf2\xd4\x19\xe6\xdb\xbb\xe8\x6f\xa1\xff\xfb\xfa\x5e\xcb\xa6\x90\x5c\xf9\xf0\x7d\x65\x54\xd9\xa1\x0f\x29\x2b\x13\x75\x31\x04\x97\x98\x3e\x03\x00\x78\x54\x5b\x2f\xb0\x00\x00\x00")
+
+func _1528395731_access_token_expiry_and_user_sessionsDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395731_access_token_expiry_and_user_sessionsDownSql,
+		"1528395731_access_token_expiry_and_user_sessions.down.sql",
+	)
+}
+
+func _1528395731_access_token_expiry_and_user_sessionsDownSql() (*asset, error) {
+	bytes, err := _1528395731_access_token_expiry_and_user_sessionsDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395731_access_token_expiry_and_user_sessions.down.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x78, 0x81, 0xb9, 0x7, 0xa3, 0x91, 0x3f, 0xaa, 0x62, 0xb6, 0xeb, 0xd5, 0xcf, 0xb3, 0xcf, 0xeb, 0x86, 0x14, 0x6e, 0xf8, 0x9b, 0xef, 0x3f, 0x89, 0x1a, 0xdb, 0xf0, 0xed, 0x9, 0x81, 0xf9, 0x3a}}
+	return a, nil
+}
+
+var __1528395731_access_token_expiry_and_user_sessionsUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x9c\x91\x41\x8b\xe2\x40\x10\x85\xef\xf9\x15\xef\x66\x84\xfd\x07\x39\xb5\x49\xb9\x84\xed\x74\x96\xd8\x82\x9e\x42\x6f\x52\xb8\xcd\x68\x22\xa9\x76\x94\xf9\xf5\x03\xd1\x19\x95\x61\x64\xf0\xd8\xbc\x7a\x5f\x75\xbd\x37\xa3\xdf\xb9\x49\xa2\x48\x69\x4b\x15\xac\x9a\x69\x82\x6b\x1a\x16\xa9\x43\xff\xc2\x9d\x40\x65\x19\xd2\x52\x2f\x0b\x83\x7c\x0e\x53\x5a\xd0\x2a\x5f\xd8\x05\xf8\xb4\xf7\x03\x4b\xed\x02\x82\xdf\xb1\x04\xb7\xdb\xe3\xe8\xc3\xff\xf1\x89\xb7\xbe\xe3\xe4\x19\xee\xd6\x49\xa8\x0f\xc2\x6d\xed\xf7\x08\x7c\x0a\xa3\x68\x96\x5a\x23\xa3\xb9\x5a\x6a\x8b\xc9\x24\x89\xa2\xb4\x22\x65\xe9\xc2\xbe\x47\x1c\x84\x87\x5a\x58\xc4\xf7\x9d\x20\x8e\x00\xc0\xb7\xf8\xe7\x37\xc2\x83\x77\x5b\xfc\xad\xf2\x42\x55\x6b\xfc\xa1\xf5\xaf\x51\x1d\x1d\xbe\x85\xef\x02\x6f\x78\xb8\xae\xac\x68\x4e\x15\x99\x94\xce\x54\x89\x7d\x3b\x45\x69\x90\x91\x26\x4b\x48\xd5\x22\x55\x19\xdd\x40\xdc\x86\xbb\xf0\xed\xbf\xcf\x83\xe3\x89\x0f\xae\x3b\x4f\x35\x03\xbb\xc0\xed\xa3\x80\xbf\x9a\xbb\xfe\x18\x4f\x6f\xb6\xb8\x26\xf8\x57\x7e\x9a\xf1\x83\x92\x3f\xfd\xd1\xf4\xda\x4a\x6e\x32\x5a\x3d\x6a\xa5\xfe\x48\xbc\x34\xf7\x42\x7c\x11\x46\x58\x59\x14\xb9\x4d\xa2\xf7\x01\x00\xc4\x7c\xd9\x9f\xa6\x02\x00\x00")
+
+func _1528395731_access_token_expiry_and_user_sessionsUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395731_access_token_expiry_and_user_sessionsUpSql,
+		"1528395731_access_token_expiry_and_user_sessions.up.sql",
+	)
+}
+
+func _1528395731_access_token_expiry_and_user_sessionsUpSql() (*asset, error) {
+	bytes, err := _1528395731_access_token_expiry_and_user_sessionsUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395731_access_token_expiry_and_user_sessions.up.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xaa, 0x5a, 0x41, 0x30, 0xa3, 0x7b, 0x61, 0x86, 0x16, 0xf3, 0xd4, 0xfd, 0xd6, 0xb8, 0xcf, 0x77, 0xd3, 0x32, 0xc9, 0xb2, 0x7c, 0x1a, 0x44, 0x5, 0x83, 0x98, 0x16, 0x39, 0xb1, 0xf0, 0xac, 0x86}}
+	return a, nil
+}
+
+var __1528395732_audit_logDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x6a\x00\x95\xff\x42\x45\x47\x49\x4e\x3b\x0a\x0a\x44\x52\x4f\x50\x20\x54\x41\x42\x4c\x45\x20\x49\x46\x20\x45\x58\x49\x53\x54\x53\x20\x61\x75\x64\x69\x74\x5f\x6c\x6f\x67\x3b\x0a\x44\x52\x4f\x50\x20\x46\x55\x4e\x43\x54\x49\x4f\x4e\x20\x49\x46\x20\x45\x58\x49\x53\x54\x53\x20\x61\x75\x64\x69\x74\x5f\x6c\x6f\x67\x5f\x61\x70\x70\x65\x6e\x64\x5f\x6f\x6e\x6c\x79\x28\x29\x20\x43\x41\x53\x43\x41\x44\x45\x3b\x0a\x0a\x43\x4f\x4d\x4d\x49\x54\x3b\x0a\x03\x00\xb5\x77\x51\xff\x6a\x00\x00\x00")
+
+func _1528395732_audit_logDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395732_audit_logDownSql,
+		"1528395732_audit_log.down.sql",
+	)
+}
+
+func _1528395732_audit_logDownSql() (*asset, error) {
+	bytes, err := _1528395732_audit_logDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395732_audit_log.down.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x22, 0x1d, 0x1b, 0x37, 0x29, 0x47, 0x3a, 0x3c, 0x85, 0x52, 0x24, 0xba, 0x6e, 0x32, 0x76, 0xcd, 0x99, 0x66, 0xc, 0x45, 0xa7, 0x1f, 0xb6, 0xd0, 0xed, 0x13, 0xb2, 0x61, 0x40, 0x17, 0xd, 0x4f}}
+	return a, nil
+}
+
+var __1528395732_audit_logUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x8c\x93\x51\x6f\xda\x30\x14\x85\xdf\xf3\x2b\xce\x03\x12\x20\x95\xfd\x01\x9e\xd2\xe4\xc2\xa2\xd1\x04\x19\x47\xa3\x4f\x51\x20\xb7\xa9\xab\x60\x67\x8e\x51\xd7\xfd\xfa\x29\x86\x35\xb0\xae\x1a\x8f\xb9\xf7\xdc\x13\x9f\xef\xda\xf7\xb4\x4c\xd2\x79\x10\x44\x82\x42\x49\x90\xe1\xfd\x8a\x90\x2c\x90\x66\x12\xb4\x4d\x36\x72\x83\xf2\x58\x29\x57\x34\xa6\xc6\x24\x00\x00\x55\x61\xa7\xea\x8e\xad\x2a\x1b\xac\x45\xf2\x10\x8a\x47\x7c\xa3\xc7\x3b\xdf\x2d\xf7\x4e\x19\x0d\xc7\x3f\x9d\x37\x49\xf3\xd5\xea\xbd\x63\x6c\x71\xec\xd8\x16\xaa\x82\xd2\x8e\x6b\xb6\x97\x2d\xd5\x5e\x8f\x21\xa6\x45\x98\xaf\x24\xc6\xe3\x93\xac\x3b\xee\x5e\x78\xef\x0a\xf7\xd6\xf2\x8d\x52\x55\xfd\x47\xb8\xe3\x27\x63\x19\x2f\x9d\xd1\xbb\xf3\x61\x9e\x1c\xdb\xcb\xc2\xde\x72\xe9\xb8\x2a\x4a\x07\xa7\x0e\xdc\xb9\xf2\xd0\xe2\x55\xb9\x67\xff\x89\x5f\x46\xf3\x47\x7f\x6d\x5e\x27\xd3\x60\x3a\xa0\x4d\xd2\x98\xb6\x9f\xa1\x2d\xce\xd8\xb2\x74\xa8\x4d\x4e\xb5\xe9\xfc\x66\x87\x0b\xbc\x7f\x1b\x0d\xad\x5b\xfd\xce\x04\xaf\x9d\xfe\x60\xed\x37\x70\x77\x01\xf9\x56\xd3\x0b\x94\x57\xbe\x43\xbd\x27\x16\x8b\x6c\x8d\x45\x9e\x46\x32\xc9\xd2\x1e\xd9\x07\x9f\xb2\x6d\x59\x57\x85\xd1\xcd\xdb\x64\x8a\x28\xdc\x44\x61\x4c\x03\xeb\xf7\xd9\xcf\x26\x04\xc9\x5c\xa4\x1b\x38\xab\xea\x9a\xad\xdf\xfb\x2a\x4c\x97\x79\xb8\x24\xb4\x4d\x5b\x77\x3f\x9a\x20\xdc\x60\x34\x0a\xfc\x0b\xf1\x82\xd9\x0c\xf2\x99\x4f\xa7\x40\xff\x1e\x0e\xc7\xce\x41\x1b\x87\x1d\xa3\xbf\x14\x6c\xb9\xf2\x17\xe3\x0e\x9d\x81\x72\x1d\x58\x3b\xab\xb8\xc3\xbe\xd4\xbd\x48\xb3\x72\xcf\xe7\xdf\xcd\x66\x38\x98\x4a\x3d\x29\xae\xa0\x8d\x45\xc5\x0d\x3b\xae\xbe\xf8\xa6\x08\x93\x0d\x81\xb6\x11\xad\x7d\x8e\xf1\x7b\x10\xa8\x0e\xa7\x2c\xb3\x3e\xfd\x78\x1e\x50\x1a\xcf\x83\xd1\x68\x48\x2f\x45\xb2\x5c\x92\xf0\xe1\x8a\x7f\x12\xf0\xff\xb8\xa7\x45\x26\x08\xf9\x3a\xee\x87\x32\x81\x98\x56\x24\xe9\x6a\x31\x5e\xb8\xc8\x04\x28\x8c\xbe\x42\x64\xdf\x41\x5b\x8a\x72\x49\x58\x8b\x2c\xa2\x38\x17\xf4\xd9\x56\xe6\x41\x10\x65\x0f\x0f\x89\x9c\x07\xbf\x07\x00\x1b\x9e\x03\xda\x65\x04\x00\x00")
+
+func _1528395732_audit_logUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395732_audit_logUpSql,
+		"1528395732_audit_log.up.sql",
+	)
+}
+
+func _1528395732_audit_logUpSql() (*asset, error) {
+	bytes, err := _1528395732_audit_logUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395732_audit_log.up.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x22, 0xc9, 0x25, 0xf4, 0xc2, 0xaa, 0xe7, 0xba, 0x2b, 0x5a, 0x31, 0x71, 0xa, 0x78, 0x97, 0x94, 0xa, 0xa8, 0xfa, 0xbd, 0xd, 0xdb, 0x81, 0xd, 0xd, 0xb3, 0x86, 0x70, 0x25, 0x9b, 0x5, 0xf4}}
+	return a, nil
+}
+
+var __1528395733_org_repo_permissionsDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x3c\x00\xc3\xff\x42\x45\x47\x49\x4e\x3b\x0a\x0a\x44\x52\x4f\x50\x20\x54\x41\x42\x4c\x45\x20\x49\x46\x20\x45\x58\x49\x53\x54\x53\x20\x6f\x72\x67\x5f\x72\x65\x70\x6f\x5f\x70\x65\x72\x6d\x69\x73\x73\x69\x6f\x6e\x73\x3b\x0a\x0a\x43\x4f\x4d\x4d\x49\x54\x3b\x0a\x03\x00\x09\x47\x01\xf2\x3c\x00\x00\x00")
+
+func _1528395733_org_repo_permissionsDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395733_org_repo_permissionsDownSql,
+		"1528395733_org_repo_permissions.down.sql",
+	)
+}
+
+func _1528395733_org_repo_permissionsDownSql() (*asset, error) {
+	bytes, err := _1528395733_org_repo_permissionsDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395733_org_repo_permissions.down.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x66, 0x22, 0xb3, 0x94, 0xd2, 0xd5, 0x1d, 0xf8, 0x87, 0x3b, 0x3a, 0xca, 0xfc, 0x3e, 0x2b, 0x1, 0x3b, 0x4, 0xa5, 0x5c, 0x33, 0xb5, 0xfa, 0x1d, 0x7f, 0x9, 0x5a, 0xdb, 0xb5, 0x7, 0xd9, 0x1a}}
+	return a, nil
+}
+
+var __1528395733_org_repo_permissionsUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x8c\x90\xcd\x6a\x84\x30\x14\x85\xf7\x79\x8a\xb3\x54\xf0\x0d\x66\x95\xd1\x6b\x09\x8d\xb1\xc4\x0c\xcc\xac\x44\x30\x4c\xb3\xd0\x88\x49\x99\xd2\xa7\x2f\xcd\xd4\xda\x4d\x7f\x96\xe1\xde\xfb\x9d\x93\xef\x48\x0f\x42\x1d\x18\x2b\x35\x71\x43\x30\xfc\x28\x09\xa2\x86\x6a\x0d\xe8\x2c\x3a\xd3\xc1\xaf\xd7\x7e\xb5\x8b\xef\x17\xbb\x4e\x2e\x04\xe7\xe7\x80\x8c\x01\x48\x23\x37\xc2\xcd\xd1\x5e\xed\x9a\x8e\xd4\x49\x4a\x68\xaa\x49\x93\x2a\x29\x5d\x87\xcc\x8d\x39\x5a\x85\x8a\x24\x19\x42\xc9\xbb\x92\x57\x54\x24\x44\x22\xff\xc1\xf8\xd8\xf9\x8d\xb1\x17\x43\xb4\xaf\xf1\x8b\x71\x9f\xbe\x2c\xe3\x10\xed\xd8\x0f\x11\xd1\x4d\x36\xc4\x61\x5a\x70\x73\xf1\x39\x3d\xf1\xe6\x67\xbb\xa7\x56\x54\xf3\x93\x34\x98\xfd\x2d\xcb\x0b\x96\x00\x4f\x5a\x34\x5c\x5f\xf0\x48\x17\x64\xf7\x2f\x17\x5b\xef\xe2\x5b\x78\xce\xf2\xdd\xa4\x50\x15\x9d\xff\x61\xb2\xdf\x04\xb4\xea\x07\xd3\x9f\x0b\x89\xdd\x36\x8d\x30\x07\xf6\x3e\x00\xf9\x8a\x99\xbe\xb6\x01\x00\x00")
+
+func _1528395733_org_repo_permissionsUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395733_org_repo_permissionsUpSql,
+		"1528395733_org_repo_permissions.up.sql",
+	)
+}
+
+func _1528395733_org_repo_permissionsUpSql() (*asset, error) {
+	bytes, err := _1528395733_org_repo_permissionsUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395733_org_repo_permissions.up.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x49, 0x5b, 0xac, 0x55, 0x11, 0x65, 0xfc, 0xa8, 0xbe, 0x36, 0x9d, 0x5a, 0xe0, 0xfa, 0xf1, 0x3e, 0xcb, 0xcc, 0xdf, 0x85, 0xdf, 0x8f, 0x8b, 0xb8, 0xa, 0x61, 0x7e, 0xc3, 0xc3, 0xe2, 0x6, 0xb1}}
+	return a, nil
+}
+
+var __1528395734_lsif_commit_graph_tipsDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x3e\x00\xc1\xff\x42\x45\x47\x49\x4e\x3b\x0a\x0a\x44\x52\x4f\x50\x20\x54\x41\x42\x4c\x45\x20\x49\x46\x20\x45\x58\x49\x53\x54\x53\x20\x6c\x73\x69\x66\x5f\x63\x6f\x6d\x6d\x69\x74\x5f\x67\x72\x61\x70\x68\x5f\x74\x69\x70\x73\x3b\x0a\x0a\x43\x4f\x4d\x4d\x49\x54\x3b\x0a\x03\x00\xc9\x0c\xa1\x3c\x3e\x00\x00\x00")
+
+func _1528395734_lsif_commit_graph_tipsDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395734_lsif_commit_graph_tipsDownSql,
+		"1528395734_lsif_commit_graph_tips.down.sql",
+	)
+}
+
+func _1528395734_lsif_commit_graph_tipsDownSql() (*asset, error) {
+	bytes, err := _1528395734_lsif_commit_graph_tipsDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395734_lsif_commit_graph_tips.down.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x8c, 0x1d, 0xb3, 0x82, 0x43, 0x99, 0xa0, 0x1b, 0xd8, 0x4d, 0xc, 0x91, 0xac, 0x6, 0x9c, 0x8e, 0xfb, 0x55, 0x4d, 0xce, 0xd0, 0x71, 0x34, 0xeb, 0xf5, 0xc1, 0x1c, 0xa6, 0xdc, 0x72, 0xdc, 0x47}}
+	return a, nil
+}
+
+var __1528395734_lsif_commit_graph_tipsUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x24\xcc\x4d\x0a\xc2\x30\x10\x47\xf1\xfd\x9c\xe2\x4f\x57\x0a\xde\xa0\xab\xb6\x8c\x12\xec\x87\xb4\x11\xec\x2a\x88\xc6\x3a\x60\x6d\x48\x66\xa1\xb7\x17\xea\xfe\xbd\x5f\xc9\x07\xd3\xe6\x44\x55\xcf\x85\x65\xd8\xa2\xac\x19\x66\x8f\xb6\xb3\xe0\x8b\x19\xec\x80\x57\x92\x87\xbb\x2d\xf3\x2c\xea\xa6\x78\x0d\x4f\xa7\x12\x12\x36\x04\x00\xd1\x87\x25\x89\x2e\xf1\xeb\xe4\x0e\x79\xab\x9f\x7c\xc4\xa9\x37\x4d\xd1\x8f\x38\xf2\xb8\x5b\xb3\xec\xff\x67\x50\xff\xd1\x15\x6f\xcf\x75\x4d\xdb\x9c\xa8\xea\x9a\xc6\xd8\x9c\x7e\x03\x00\x3a\x7e\x7d\x44\x8a\x00\x00\x00")
+
+func _1528395734_lsif_commit_graph_tipsUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395734_lsif_commit_graph_tipsUpSql,
+		"1528395734_lsif_commit_graph_tips.up.sql",
+	)
+}
+
+func _1528395734_lsif_commit_graph_tipsUpSql() (*asset, error) {
+	bytes, err := _1528395734_lsif_commit_graph_tipsUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395734_lsif_commit_graph_tips.up.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x9c, 0x5, 0x3f, 0x4f, 0xc, 0x57, 0x99, 0x7d, 0xda, 0x98, 0x23, 0x1, 0x59, 0x1d, 0x1f, 0xb5, 0xf0, 0xac, 0x40, 0x42, 0x83, 0x8b, 0x12, 0xc4, 0x43, 0xcb, 0x40, 0xfe, 0x87, 0xcc, 0xeb, 0x26}}
+	return a, nil
+}
+
 // Asset loads and returns the asset for the given name.
 // It returns an error if the asset could not be found or
 // could not be loaded.
@@ -3195,6 +3867,38 @@ var _bindata = map[string]func() (*asset, error){
 	"1528395717_replicate_permissions_object_ids_to_intarray.up.sql":               _1528395717_replicate_permissions_object_ids_to_intarrayUpSql,
 	"1528395718_user_invalidate_session.down.sql":                                  _1528395718_user_invalidate_sessionDownSql,
 	"1528395718_user_invalidate_session.up.sql":                                    _1528395718_user_invalidate_sessionUpSql,
+	"1528395719_lsif_moniker_references.down.sql":                                  _1528395719_lsif_moniker_referencesDownSql,
+	"1528395719_lsif_moniker_references.up.sql":                                    _1528395719_lsif_moniker_referencesUpSql,
+	"1528395720_lsif_diagnostics.down.sql":                                         _1528395720_lsif_diagnosticsDownSql,
+	"1528395720_lsif_diagnostics.up.sql":                                           _1528395720_lsif_diagnosticsUpSql,
+	"1528395721_campaign_spec_executions.down.sql":                                 _1528395721_campaign_spec_executionsDownSql,
+	"1528395721_campaign_spec_executions.up.sql":                                   _1528395721_campaign_spec_executionsUpSql,
+	"1528395722_campaign_step_cache.down.sql":                                      _1528395722_campaign_step_cacheDownSql,
+	"1528395722_campaign_step_cache.up.sql":                                        _1528395722_campaign_step_cacheUpSql,
+	"1528395723_campaign_spec_execution_templates.down.sql":                        _1528395723_campaign_spec_execution_templatesDownSql,
+	"1528395723_campaign_spec_execution_templates.up.sql":                          _1528395723_campaign_spec_execution_templatesUpSql,
+	"1528395724_changeset_jobs.down.sql":                                           _1528395724_changeset_jobsDownSql,
+	"1528395724_changeset_jobs.up.sql":                                             _1528395724_changeset_jobsUpSql,
+	"1528395725_campaign_snapshots.down.sql":                                       _1528395725_campaign_snapshotsDownSql,
+	"1528395725_campaign_snapshots.up.sql":                                         _1528395725_campaign_snapshotsUpSql,
+	"1528395726_changesets_rebase_state.down.sql":                                  _1528395726_changesets_rebase_stateDownSql,
+	"1528395726_changesets_rebase_state.up.sql":                                    _1528395726_changesets_rebase_stateUpSql,
+	"1528395727_campaign_credentials.down.sql":                                     _1528395727_campaign_credentialsDownSql,
+	"1528395727_campaign_credentials.up.sql":                                       _1528395727_campaign_credentialsUpSql,
+	"1528395728_user_sub_repo_permissions.down.sql":                                _1528395728_user_sub_repo_permissionsDownSql,
+	"1528395728_user_sub_repo_permissions.up.sql":                                  _1528395728_user_sub_repo_permissionsUpSql,
+	"1528395729_users_deactivated_at.down.sql":                                     _1528395729_users_deactivated_atDownSql,
+	"1528395729_users_deactivated_at.up.sql":                                       _1528395729_users_deactivated_atUpSql,
+	"1528395730_user_two_factor_auth.down.sql":                                     _1528395730_user_two_factor_authDownSql,
+	"1528395730_user_two_factor_auth.up.sql":                                       _1528395730_user_two_factor_authUpSql,
+	"1528395731_access_token_expiry_and_user_sessions.down.sql":                    _1528395731_access_token_expiry_and_user_sessionsDownSql,
+	"1528395731_access_token_expiry_and_user_sessions.up.sql":                      _1528395731_access_token_expiry_and_user_sessionsUpSql,
+	"1528395732_audit_log.down.sql":                                                _1528395732_audit_logDownSql,
+	"1528395732_audit_log.up.sql":                                                  _1528395732_audit_logUpSql,
+	"1528395733_org_repo_permissions.down.sql":                                     _1528395733_org_repo_permissionsDownSql,
+	"1528395733_org_repo_permissions.up.sql":                                       _1528395733_org_repo_permissionsUpSql,
+	"1528395734_lsif_commit_graph_tips.down.sql":                                   _1528395734_lsif_commit_graph_tipsDownSql,
+	"1528395734_lsif_commit_graph_tips.up.sql":                                     _1528395734_lsif_commit_graph_tipsUpSql,
 }
 
 // AssetDebug is true if the assets were built with the debug flag enabled.
@@ -3379,6 +4083,38 @@ var _bintree = &bintree{nil, map[string]*bintree{
 	"1528395717_replicate_permissions_object_ids_to_intarray.up.sql":               {_1528395717_replicate_permissions_object_ids_to_intarrayUpSql, map[string]*bintree{}},
 	"1528395718_user_invalidate_session.down.sql":                                  {_1528395718_user_invalidate_sessionDownSql, map[string]*bintree{}},
 	"1528395718_user_invalidate_session.up.sql":                                    {_1528395718_user_invalidate_sessionUpSql, map[string]*bintree{}},
+	"1528395719_lsif_moniker_references.down.sql":                                  {_1528395719_lsif_moniker_referencesDownSql, map[string]*bintree{}},
+	"1528395719_lsif_moniker_references.up.sql":                                    {_1528395719_lsif_moniker_referencesUpSql, map[string]*bintree{}},
+	"1528395720_lsif_diagnostics.down.sql":                                         {_1528395720_lsif_diagnosticsDownSql, map[string]*bintree{}},
+	"1528395720_lsif_diagnostics.up.sql":                                           {_1528395720_lsif_diagnosticsUpSql, map[string]*bintree{}},
+	"1528395721_campaign_spec_executions.down.sql":                                 {_1528395721_campaign_spec_executionsDownSql, map[string]*bintree{}},
+	"1528395721_campaign_spec_executions.up.sql":                                   {_1528395721_campaign_spec_executionsUpSql, map[string]*bintree{}},
+	"1528395722_campaign_step_cache.down.sql":                                      {_1528395722_campaign_step_cacheDownSql, map[string]*bintree{}},
+	"1528395722_campaign_step_cache.up.sql":                                        {_1528395722_campaign_step_cacheUpSql, map[string]*bintree{}},
+	"1528395723_campaign_spec_execution_templates.down.sql":                        {_1528395723_campaign_spec_execution_templatesDownSql, map[string]*bintree{}},
+	"1528395723_campaign_spec_execution_templates.up.sql":                          {_1528395723_campaign_spec_execution_templatesUpSql, map[string]*bintree{}},
+	"1528395724_changeset_jobs.down.sql":                                           {_1528395724_changeset_jobsDownSql, map[string]*bintree{}},
+	"1528395724_changeset_jobs.up.sql":                                             {_1528395724_changeset_jobsUpSql, map[string]*bintree{}},
+	"1528395725_campaign_snapshots.down.sql":                                       {_1528395725_campaign_snapshotsDownSql, map[string]*bintree{}},
+	"1528395725_campaign_snapshots.up.sql":                                         {_1528395725_campaign_snapshotsUpSql, map[string]*bintree{}},
+	"1528395726_changesets_rebase_state.down.sql":                                  {_1528395726_changesets_rebase_stateDownSql, map[string]*bintree{}},
+	"1528395726_changesets_rebase_state.up.sql":                                    {_1528395726_changesets_rebase_stateUpSql, map[string]*bintree{}},
+	"1528395727_campaign_credentials.down.sql":                                     {_1528395727_campaign_credentialsDownSql, map[string]*bintree{}},
+	"1528395727_campaign_credentials.up.sql":                                       {_1528395727_campaign_credentialsUpSql, map[string]*bintree{}},
+	"1528395728_user_sub_repo_permissions.down.sql":                                {_1528395728_user_sub_repo_permissionsDownSql, map[string]*bintree{}},
+	"1528395728_user_sub_repo_permissions.up.sql":                                  {_1528395728_user_sub_repo_permissionsUpSql, map[string]*bintree{}},
+	"1528395729_users_deactivated_at.down.sql":                                     {_1528395729_users_deactivated_atDownSql, map[string]*bintree{}},
+	"1528395729_users_deactivated_at.up.sql":                                       {_1528395729_users_deactivated_atUpSql, map[string]*bintree{}},
+	"1528395730_user_two_factor_auth.down.sql":                                     {_1528395730_user_two_factor_authDownSql, map[string]*bintree{}},
+	"1528395730_user_two_factor_auth.up.sql":                                       {_1528395730_user_two_factor_authUpSql, map[string]*bintree{}},
+	"1528395731_access_token_expiry_and_user_sessions.down.sql":                    {_1528395731_access_token_expiry_and_user_sessionsDownSql, map[string]*bintree{}},
+	"1528395731_access_token_expiry_and_user_sessions.up.sql":                      {_1528395731_access_token_expiry_and_user_sessionsUpSql, map[string]*bintree{}},
+	"1528395732_audit_log.down.sql":                                                {_1528395732_audit_logDownSql, map[string]*bintree{}},
+	"1528395732_audit_log.up.sql":                                                  {_1528395732_audit_logUpSql, map[string]*bintree{}},
+	"1528395733_org_repo_permissions.down.sql":                                     {_1528395733_org_repo_permissionsDownSql, map[string]*bintree{}},
+	"1528395733_org_repo_permissions.up.sql":                                       {_1528395733_org_repo_permissionsUpSql, map[string]*bintree{}},
+	"1528395734_lsif_commit_graph_tips.down.sql":                                   {_1528395734_lsif_commit_graph_tipsDownSql, map[string]*bintree{}},
+	"1528395734_lsif_commit_graph_tips.up.sql":                                     {_1528395734_lsif_commit_graph_tipsUpSql, map[string]*bintree{}},
 }}
 
 // RestoreAsset restores an asset under the given directory.
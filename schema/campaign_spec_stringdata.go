@@ -82,10 +82,38 @@ const CampaignSpecSchemaJSON = `{
           },
           "env": {
             "type": "object",
-            "description": "Environment variables to set in the environment when running this command.",
+            "description": "Environment variables to set in the environment when running this command. The values are templates.",
             "additionalProperties": {
               "type": "string"
             }
+          },
+          "if": {
+            "description": "A condition to check before executing the step. If the value is a string, it's a template that must evaluate to ` + "`" + `true` + "`" + ` for the step to run. The step is skipped otherwise.",
+            "oneOf": [{ "type": "boolean" }, { "type": "string" }],
+            "examples": ["${{ matches repository.name \"github.com/my-org/*\" }}"]
+          },
+          "outputs": {
+            "type": "object",
+            "description": "Outputs produced by the step that can be used in the templates of later steps and in the changeset template as ` + "`" + `outputs.<name>` + "`" + `.",
+            "additionalProperties": {
+              "title": "StepOutput",
+              "type": "object",
+              "additionalProperties": false,
+              "required": ["value"],
+              "properties": {
+                "value": {
+                  "type": "string",
+                  "description": "The template that's evaluated to produce the value of the output.",
+                  "examples": ["${{ step.stdout }}"]
+                },
+                "format": {
+                  "type": "string",
+                  "description": "The format of the value. Values in the ` + "`" + `json` + "`" + ` or ` + "`" + `yaml` + "`" + ` formats are parsed, so their fields can be accessed in templates.",
+                  "enum": ["text", "json", "yaml"],
+                  "default": "text"
+                }
+              }
+            }
           }
         }
       }
@@ -96,7 +124,8 @@ const CampaignSpecSchemaJSON = `{
       "items": {
         "type": "object",
         "additionalProperties": false,
-        "required": ["repository", "externalIDs"],
+        "required": ["repository"],
+        "oneOf": [{ "required": ["externalIDs"] }, { "required": ["query"] }],
         "properties": {
           "repository": {
             "type": "string",
@@ -110,13 +139,74 @@ const CampaignSpecSchemaJSON = `{
               "oneOf": [{ "type": "string" }, { "type": "integer" }]
             },
             "examples": [120, "120"]
+          },
+          "query": {
+            "title": "ImportChangesetsQuery",
+            "type": "object",
+            "description": "Import all changesets in the repository that match this query on the code host, instead of listing their external IDs.",
+            "additionalProperties": false,
+            "properties": {
+              "state": {
+                "type": "string",
+                "description": "The state of the changesets to import.",
+                "enum": ["open", "closed", "merged", "all"],
+                "default": "open"
+              },
+              "labels": {
+                "type": "array",
+                "description": "Only import changesets that have all of these labels. Not supported on Bitbucket Server, which doesn't have labels.",
+                "items": { "type": "string", "minLength": 1 },
+                "uniqueItems": true,
+                "examples": [["migration"]]
+              },
+              "headRef": {
+                "type": "string",
+                "description": "Only import changesets whose head branch matches this glob pattern.",
+                "minLength": 1,
+                "examples": ["go-1.15-*", "migrations/**"]
+              }
+            }
+          }
+        }
+      }
+    },
+    "transformChanges": {
+      "type": "object",
+      "description": "Optional transformations to apply to the changes produced in each repository.",
+      "additionalProperties": false,
+      "properties": {
+        "group": {
+          "type": "array",
+          "description": "A list of groups of changes in a repository that each create a separate, additional changeset for this repository, with all ungrouped changes being in the default changeset.",
+          "items": {
+            "title": "TransformChangesGroup",
+            "type": "object",
+            "additionalProperties": false,
+            "required": ["directory", "branch"],
+            "properties": {
+              "directory": {
+                "type": "string",
+                "description": "The directory path (relative to the repository root) of the changes to include in this group.",
+                "minLength": 1
+              },
+              "branch": {
+                "type": "string",
+                "description": "The branch on the repository to propose the grouped changes to. The value is a template.",
+                "minLength": 1
+              },
+              "repository": {
+                "type": "string",
+                "description": "Only apply this transformation in the repository with this name (as it is known to Sourcegraph).",
+                "examples": ["github.com/foo/bar"]
+              }
+            }
           }
         }
       }
     },
     "changesetTemplate": {
       "type": "object",
-      "description": "A template describing how to create (and update) changesets with the file changes produced by the command steps.",
+      "description": "A template describing how to create (and update) changesets with the file changes produced by the command steps. The title, body, branch, and commit message are templates.",
       "additionalProperties": false,
       "required": ["title", "branch", "commit", "published"],
       "properties": {
@@ -158,9 +248,48 @@ const CampaignSpecSchemaJSON = `{
           }
         },
         "published": {
-          "type": "boolean",
-          "description": "Whether to publish the changeset. An unpublished changeset can be previewed on Sourcegraph by any person who can view the campaign, but its commit, branch, and pull request aren't created on the code host. A published changeset results in a commit, branch, and pull request being created on the code host.",
+          "oneOf": [{ "type": "boolean" }, { "type": "string", "enum": ["draft"] }],
+          "description": "Whether to publish the changeset. An unpublished changeset can be previewed on Sourcegraph by any person who can view the campaign, but its commit, branch, and pull request aren't created on the code host. A published changeset results in a commit, branch, and pull request being created on the code host. Setting it to \"draft\" creates a draft pull request (on GitHub) or a work-in-progress merge request (on GitLab) that doesn't notify reviewers until it is published.",
           "$comment": "TODO(sqs): Come up with a way to specify that only a subset of changesets should be published. For example, making ` + "`" + `published` + "`" + ` an array with some include/exclude syntax items."
+        },
+        "overrides": {
+          "type": "array",
+          "description": "Per-repository overrides of the changeset template. The first override whose repository matches is merged into the template when creating the changeset for that repository.",
+          "items": {
+            "title": "ChangesetTemplateOverride",
+            "type": "object",
+            "additionalProperties": false,
+            "required": ["repository"],
+            "properties": {
+              "repository": {
+                "type": "string",
+                "description": "A glob pattern matching the names of the repositories (as they are known to Sourcegraph) to override the template for.",
+                "examples": ["github.com/foo/bar", "github.com/foo/*"]
+              },
+              "title": { "type": "string", "description": "The title of the changeset." },
+              "body": { "type": "string", "description": "The body (description) of the changeset." },
+              "branch": {
+                "type": "string",
+                "description": "The name of the Git branch to create or update with the changes."
+              },
+              "commit": {
+                "type": "object",
+                "description": "The Git commit to create with the changes.",
+                "additionalProperties": false,
+                "required": ["message"],
+                "properties": {
+                  "message": {
+                    "type": "string",
+                    "description": "The Git commit message."
+                  }
+                }
+              },
+              "published": {
+                "oneOf": [{ "type": "boolean" }, { "type": "string", "enum": ["draft"] }],
+                "description": "Whether to publish the changeset, or \"draft\" to publish it as a draft."
+              }
+            }
+          }
         }
       }
     }
@@ -87,8 +87,8 @@ const ChangesetSpecSchemaJSON = `{
           }
         },
         "published": {
-          "type": "boolean",
-          "description": "Whether to publish the changeset. An unpublished changeset can be previewed on Sourcegraph by any person who can view the campaign, but its commit, branch, and pull request aren't created on the code host. A published changeset results in a commit, branch, and pull request being created on the code host."
+          "oneOf": [{ "type": "boolean" }, { "type": "string", "enum": ["draft"] }],
+          "description": "Whether to publish the changeset. An unpublished changeset can be previewed on Sourcegraph by any person who can view the campaign, but its commit, branch, and pull request aren't created on the code host. A published changeset results in a commit, branch, and pull request being created on the code host. Setting it to \"draft\" creates a draft pull request (on GitHub) or a work-in-progress merge request (on GitLab) that doesn't notify reviewers until it is published."
         }
       },
       "required": [
@@ -166,9 +166,34 @@ const GitHubSchemaJSON = `{
     },
     "authorization": {
       "title": "GitHubAuthorization",
-      "description": "If non-null, enforces GitHub repository permissions. This requires that there is an item in the ` + "`" + `auth.providers` + "`" + ` field of type \"github\" with the same ` + "`" + `url` + "`" + ` field as specified in this ` + "`" + `GitHubConnection` + "`" + `.",
+      "description": "If non-null, enforces GitHub repository permissions. This requires that there is an item in the ` + "`" + `auth.providers` + "`" + ` field of type \"github\" with the same ` + "`" + `url` + "`" + ` field as specified in this ` + "`" + `GitHubConnection` + "`" + `, unless \"githubApp\" is set.",
       "type": "object",
-      "properties": {}
+      "properties": {
+        "githubApp": {
+          "description": "If non-null, repository permissions are computed with installation access tokens of this GitHub App instead of OAuth tokens of users, so users do not need to sign in with GitHub. Sourcegraph users are matched to GitHub users by username, so ` + "`" + `auth.enableUsernameChanges` + "`" + ` must be set to false for security reasons. The GitHub App must be installed on the organizations of the repositories and have read access to their metadata.",
+          "title": "GitHubApp",
+          "type": "object",
+          "additionalProperties": false,
+          "required": ["appID", "installationID", "privateKey"],
+          "properties": {
+            "appID": {
+              "description": "The ID of the GitHub App.",
+              "type": "integer",
+              "minimum": 1
+            },
+            "installationID": {
+              "description": "The ID of the installation of the GitHub App to use.",
+              "type": "integer",
+              "minimum": 1
+            },
+            "privateKey": {
+              "description": "Base64 encoding of the PEM encoded private key of the GitHub App.",
+              "type": "string",
+              "minLength": 1
+            }
+          }
+        }
+      }
     }
   }
 }
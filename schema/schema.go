@@ -46,6 +46,14 @@ type AWSCodeCommitGitCredentials struct {
 	Username string `json:"username"`
 }
 
+// AuditLog description: Configuration for exporting audit log entries (site configuration changes, external service edits, permission changes, access token usage, user deletion, etc.). Entries are always recorded in the database; these sinks receive a copy of each entry as a JSON object.
+type AuditLog struct {
+	// JsonLinesFile description: Path to a file (in the frontend container) to which each audit log entry is appended as a single line of JSON.
+	JsonLinesFile string `json:"jsonLinesFile,omitempty"`
+	// Syslog description: Send each audit log entry to a syslog daemon.
+	Syslog *Syslog `json:"syslog,omitempty"`
+}
+
 // AuthAccessTokens description: Settings for access tokens, which enable external tools to access the Sourcegraph API with the privileges of the user.
 type AuthAccessTokens struct {
 	// Allow description: Allow or restrict the use of access tokens. The default is "all-users-create", which enables all users to create access tokens. Use "none" to disable access tokens entirely. Use "site-admin-create" to restrict creation of new tokens to admin users (existing tokens will still work until revoked).
@@ -64,6 +72,7 @@ type AuthProviders struct {
 	HttpHeader    *HTTPHeaderAuthProvider
 	Github        *GitHubAuthProvider
 	Gitlab        *GitLabAuthProvider
+	Ldap          *LDAPAuthProvider
 }
 
 func (v AuthProviders) MarshalJSON() ([]byte, error) {
@@ -85,6 +94,9 @@ func (v AuthProviders) MarshalJSON() ([]byte, error) {
 	if v.Gitlab != nil {
 		return json.Marshal(v.Gitlab)
 	}
+	if v.Ldap != nil {
+		return json.Marshal(v.Ldap)
+	}
 	return nil, errors.New("tagged union type must have exactly 1 non-nil field value")
 }
 func (v *AuthProviders) UnmarshalJSON(data []byte) error {
@@ -103,12 +115,28 @@ func (v *AuthProviders) UnmarshalJSON(data []byte) error {
 		return json.Unmarshal(data, &v.Gitlab)
 	case "http-header":
 		return json.Unmarshal(data, &v.HttpHeader)
+	case "ldap":
+		return json.Unmarshal(data, &v.Ldap)
 	case "openidconnect":
 		return json.Unmarshal(data, &v.Openidconnect)
 	case "saml":
 		return json.Unmarshal(data, &v.Saml)
 	}
-	return fmt.Errorf("tagged union type must have a %q property whose value is one of %s", "type", []string{"builtin", "saml", "openidconnect", "http-header", "github", "gitlab"})
+	return fmt.Errorf("tagged union type must have a %q property whose value is one of %s", "type", []string{"builtin", "saml", "openidconnect", "http-header", "github", "gitlab", "ldap"})
+}
+
+// AuthScim description: Enables the SCIM 2.0 endpoint at /.api/scim/v2, which identity providers such as Okta and Azure AD use to provision and deactivate users and to manage organization memberships from groups.
+type AuthScim struct {
+	// AdoptExistingUsers description: Lets the SCIM client take over the management of existing users that were not provisioned by SCIM (e.g. users who signed up before SCIM was enabled). When the SCIM client creates a user whose primary email address is a verified email address of an existing user, that user is linked to the SCIM client instead of creating a new user. Site admins are never adopted.
+	AdoptExistingUsers bool `json:"adoptExistingUsers,omitempty"`
+	// AuthToken description: The bearer token that the SCIM client must send in the Authorization header of every request. Generate a long random value, e.g. with `openssl rand -hex 32`.
+	AuthToken string `json:"authToken"`
+}
+
+// BitbucketCloudAuthorization description: If non-null, enforces Bitbucket Cloud repository permissions. Permissions are read from the workspace permissions API of the workspaces listed in "teams" and of the workspace of "username", so the app password must belong to an administrator of these workspaces and have the "Account: Read" and "Workspace membership: Read" permissions.
+type BitbucketCloudAuthorization struct {
+	// IdentityProvider description: The source of identity to use when computing permissions. This defines how to compute the Bitbucket Cloud identity to use for a given Sourcegraph user. When 'username' is used, Sourcegraph assumes usernames are identical in Sourcegraph and Bitbucket Cloud (the Bitbucket Cloud nickname of workspace members) and `auth.enableUsernameChanges` must be set to false for security reasons.
+	IdentityProvider BitbucketCloudIdentityProvider `json:"identityProvider"`
 }
 
 // BitbucketCloudConnection description: Configuration for a connection to Bitbucket Cloud.
@@ -117,6 +145,8 @@ type BitbucketCloudConnection struct {
 	ApiURL string `json:"apiURL,omitempty"`
 	// AppPassword description: The app password to use when authenticating to the Bitbucket Cloud. Also set the corresponding "username" field.
 	AppPassword string `json:"appPassword"`
+	// Authorization description: If non-null, enforces Bitbucket Cloud repository permissions. Permissions are read from the workspace permissions API of the workspaces listed in "teams" and of the workspace of "username", so the app password must belong to an administrator of these workspaces and have the "Account: Read" and "Workspace membership: Read" permissions.
+	Authorization *BitbucketCloudAuthorization `json:"authorization,omitempty"`
 	// Exclude description: A list of repositories to never mirror from Bitbucket Cloud. Takes precedence over "teams" configuration.
 	//
 	// Supports excluding by name ({"name": "myorg/myrepo"}) or by UUID ({"uuid": "{fceb73c7-cef6-4abe-956d-e471281126bd}"}).
@@ -145,6 +175,11 @@ type BitbucketCloudConnection struct {
 	Username string `json:"username"`
 }
 
+// BitbucketCloudIdentityProvider description: The source of identity to use when computing permissions. This defines how to compute the Bitbucket Cloud identity to use for a given Sourcegraph user. When 'username' is used, Sourcegraph assumes usernames are identical in Sourcegraph and Bitbucket Cloud (the Bitbucket Cloud nickname of workspace members) and `auth.enableUsernameChanges` must be set to false for security reasons.
+type BitbucketCloudIdentityProvider struct {
+	Type string `json:"type"`
+}
+
 // BitbucketCloudRateLimit description: Rate limit applied when making background API requests to Bitbucket Cloud.
 type BitbucketCloudRateLimit struct {
 	// Enabled description: true if rate limiting is enabled.
@@ -299,13 +334,15 @@ type BuiltinAuthProvider struct {
 	// AllowSignup description: Allows new visitors to sign up for accounts. The sign-up page will be enabled and accessible to all visitors.
 	//
 	// SECURITY: If the site has no users (i.e., during initial setup), it will always allow the first user to sign up and become site admin **without any approval** (first user to sign up becomes the admin).
-	AllowSignup bool   `json:"allowSignup,omitempty"`
-	Type        string `json:"type"`
+	AllowSignup bool `json:"allowSignup,omitempty"`
+	// RequireTwoFactorAuth description: Requires builtin users to sign in with a code of an authenticator app in addition to their password. Users who must use two-factor authentication but haven't enrolled yet are asked to enroll when they sign in.
+	RequireTwoFactorAuth string `json:"requireTwoFactorAuth,omitempty"`
+	Type                 string `json:"type"`
 }
 
 // CampaignSpec description: A campaign specification, which describes the campaign and what kinds of changes to make (or what existing changesets to track).
 type CampaignSpec struct {
-	// ChangesetTemplate description: A template describing how to create (and update) changesets with the file changes produced by the command steps.
+	// ChangesetTemplate description: A template describing how to create (and update) changesets with the file changes produced by the command steps. The title, body, branch, and commit message are templates.
 	ChangesetTemplate *ChangesetTemplate `json:"changesetTemplate,omitempty"`
 	// Description description: The description of the campaign.
 	Description string `json:"description,omitempty"`
@@ -317,9 +354,23 @@ type CampaignSpec struct {
 	On []interface{} `json:"on,omitempty"`
 	// Steps description: The sequence of commands to run (for each repository branch matched in the `on` property) to produce the campaign's changes.
 	Steps []*Step `json:"steps,omitempty"`
+	// TransformChanges description: Optional transformations to apply to the changes produced in each repository.
+	TransformChanges *TransformChanges `json:"transformChanges,omitempty"`
+}
+
+// CampaignsServerSideExecution description: Configures server-side execution of the steps of campaign specs. Steps run a container image and command chosen by the author of the campaign spec, so each step is run in an isolated Firecracker virtual machine (managed with ignite) without network access. The repo-updater host must have ignite installed.
+type CampaignsServerSideExecution struct {
+	// Cpus description: The number of CPUs allocated to each step.
+	Cpus int `json:"cpus,omitempty"`
+	// Enabled description: Enables server-side execution of campaign spec steps. When disabled, campaign specs can only be executed with src-cli.
+	Enabled bool `json:"enabled,omitempty"`
+	// FirecrackerImage description: The base image of the virtual machines that steps are run in.
+	FirecrackerImage string `json:"firecrackerImage,omitempty"`
+	// Memory description: The amount of memory allocated to each step.
+	Memory string `json:"memory,omitempty"`
 }
 
-// ChangesetTemplate description: A template describing how to create (and update) changesets with the file changes produced by the command steps.
+// ChangesetTemplate description: A template describing how to create (and update) changesets with the file changes produced by the command steps. The title, body, branch, and commit message are templates.
 type ChangesetTemplate struct {
 	// Body description: The body (description) of the changeset.
 	Body string `json:"body,omitempty"`
@@ -327,11 +378,27 @@ type ChangesetTemplate struct {
 	Branch string `json:"branch"`
 	// Commit description: The Git commit to create with the changes.
 	Commit ExpandedGitCommitDescription `json:"commit"`
-	// Published description: Whether to publish the changeset. An unpublished changeset can be previewed on Sourcegraph by any person who can view the campaign, but its commit, branch, and pull request aren't created on the code host. A published changeset results in a commit, branch, and pull request being created on the code host.
-	Published bool `json:"published"`
+	// Overrides description: Per-repository overrides of the changeset template. The first override whose repository matches is merged into the template when creating the changeset for that repository.
+	Overrides []*ChangesetTemplateOverride `json:"overrides,omitempty"`
+	// Published description: Whether to publish the changeset. An unpublished changeset can be previewed on Sourcegraph by any person who can view the campaign, but its commit, branch, and pull request aren't created on the code host. A published changeset results in a commit, branch, and pull request being created on the code host. Setting it to "draft" creates a draft pull request (on GitHub) or a work-in-progress merge request (on GitLab) that doesn't notify reviewers until it is published.
+	Published interface{} `json:"published"`
 	// Title description: The title of the changeset.
 	Title string `json:"title"`
 }
+type ChangesetTemplateOverride struct {
+	// Body description: The body (description) of the changeset.
+	Body string `json:"body,omitempty"`
+	// Branch description: The name of the Git branch to create or update with the changes.
+	Branch string `json:"branch,omitempty"`
+	// Commit description: The Git commit to create with the changes.
+	Commit *Commit `json:"commit,omitempty"`
+	// Published description: Whether to publish the changeset, or "draft" to publish it as a draft.
+	Published interface{} `json:"published,omitempty"`
+	// Repository description: A glob pattern matching the names of the repositories (as they are known to Sourcegraph) to override the template for.
+	Repository string `json:"repository"`
+	// Title description: The title of the changeset.
+	Title string `json:"title,omitempty"`
+}
 
 // CloneURLToRepositoryName description: Describes a mapping from clone URL to repository name. The `from` field contains a regular expression with named capturing groups. The `to` field contains a template string that references capturing group names. For instance, if `from` is "^../(?P<name>\w+)$" and `to` is "github.com/user/{name}", the clone URL "../myRepository" would be mapped to the repository name "github.com/user/myRepository".
 type CloneURLToRepositoryName struct {
@@ -341,6 +408,24 @@ type CloneURLToRepositoryName struct {
 	To string `json:"to"`
 }
 
+// CodeIntelRetentionPolicy description: A rule describing a set of precise code intelligence uploads that must be retained.
+type CodeIntelRetentionPolicy struct {
+	// MaxAge description: The maximum age of a matching branch tip or tag (by commit or tag date), or of the upload itself for `uploads` policies, expressed as a Go duration such as "720h". When omitted, matching uploads are retained indefinitely.
+	MaxAge string `json:"maxAge,omitempty"`
+	// Name description: A human-readable name for the policy. This name is reported for each upload the policy retains.
+	Name string `json:"name"`
+	// Pattern description: A glob pattern matched against branch or tag names (without the refs/heads/ or refs/tags/ prefix). Defaults to matching every name. Ignored by `uploads` policies.
+	Pattern string `json:"pattern,omitempty"`
+	// Type description: The kind of objects this policy applies to. A `branches` or `tags` policy retains the uploads visible from the commit at the tip of each matching branch or tag. An `uploads` policy retains every upload regardless of the commit it was uploaded for.
+	Type string `json:"type"`
+}
+
+// Commit description: The Git commit to create with the changes.
+type Commit struct {
+	// Message description: The Git commit message.
+	Message string `json:"message"`
+}
+
 // CustomGitFetchMapping description: Mapping from Git clone URl domain/path to git fetch command. The `domainPath` field contains the Git clone URL domain/path part. The `fetch` field contains the custom git fetch command.
 type CustomGitFetchMapping struct {
 	// DomainPath description: Git clone URL domain/path
@@ -482,6 +567,16 @@ type GitCommitDescription struct {
 	Message string `json:"message"`
 }
 
+// GitHubApp description: If non-null, repository permissions are computed with installation access tokens of this GitHub App instead of OAuth tokens of users, so users do not need to sign in with GitHub. Sourcegraph users are matched to GitHub users by username, so `auth.enableUsernameChanges` must be set to false for security reasons. The GitHub App must be installed on the organizations of the repositories and have read access to their metadata.
+type GitHubApp struct {
+	// AppID description: The ID of the GitHub App.
+	AppID int `json:"appID"`
+	// InstallationID description: The ID of the installation of the GitHub App to use.
+	InstallationID int `json:"installationID"`
+	// PrivateKey description: Base64 encoding of the PEM encoded private key of the GitHub App.
+	PrivateKey string `json:"privateKey"`
+}
+
 // GitHubAuthProvider description: Configures the GitHub (or GitHub Enterprise) OAuth authentication provider for SSO. In addition to specifying this configuration object, you must also create a OAuth App on your GitHub instance: https://developer.github.com/apps/building-oauth-apps/creating-an-oauth-app/. When a user signs into Sourcegraph or links their GitHub account to their existing Sourcegraph account, GitHub will prompt the user for the repo scope.
 type GitHubAuthProvider struct {
 	// AllowOrgs description: Restricts new logins to members of these GitHub organizations. Existing sessions won't be invalidated. Leave empty or unset for no org restrictions.
@@ -498,13 +593,15 @@ type GitHubAuthProvider struct {
 	Url string `json:"url,omitempty"`
 }
 
-// GitHubAuthorization description: If non-null, enforces GitHub repository permissions. This requires that there is an item in the `auth.providers` field of type "github" with the same `url` field as specified in this `GitHubConnection`.
+// GitHubAuthorization description: If non-null, enforces GitHub repository permissions. This requires that there is an item in the `auth.providers` field of type "github" with the same `url` field as specified in this `GitHubConnection`, unless "githubApp" is set.
 type GitHubAuthorization struct {
+	// GithubApp description: If non-null, repository permissions are computed with installation access tokens of this GitHub App instead of OAuth tokens of users, so users do not need to sign in with GitHub. Sourcegraph users are matched to GitHub users by username, so `auth.enableUsernameChanges` must be set to false for security reasons. The GitHub App must be installed on the organizations of the repositories and have read access to their metadata.
+	GithubApp *GitHubApp `json:"githubApp,omitempty"`
 }
 
 // GitHubConnection description: Configuration for a connection to GitHub or GitHub Enterprise.
 type GitHubConnection struct {
-	// Authorization description: If non-null, enforces GitHub repository permissions. This requires that there is an item in the `auth.providers` field of type "github" with the same `url` field as specified in this `GitHubConnection`.
+	// Authorization description: If non-null, enforces GitHub repository permissions. This requires that there is an item in the `auth.providers` field of type "github" with the same `url` field as specified in this `GitHubConnection`, unless "githubApp" is set.
 	Authorization *GitHubAuthorization `json:"authorization,omitempty"`
 	// Certificate description: TLS certificate of the GitHub Enterprise instance. This is only necessary if the certificate is self-signed or signed by an internal CA. To get the certificate run `openssl s_client -connect HOST:443 -showcerts < /dev/null 2> /dev/null | openssl x509 -outform PEM`. To escape the value into a JSON string, you may want to use a tool like https://json-escape-text.now.sh.
 	Certificate string `json:"certificate,omitempty"`
@@ -719,13 +816,84 @@ func (v *IdentityProvider) UnmarshalJSON(data []byte) error {
 
 type ImportChangesets struct {
 	// ExternalIDs description: The changesets to import from the code host. For GitHub this is the PR number, for GitLab this is the MR number, for Bitbucket Server this is the PR number.
-	ExternalIDs []interface{} `json:"externalIDs"`
+	ExternalIDs []interface{} `json:"externalIDs,omitempty"`
+	// Query description: Import all changesets in the repository that match this query on the code host, instead of listing their external IDs.
+	Query *ImportChangesetsQuery `json:"query,omitempty"`
 	// Repository description: The repository name as configured on your Sourcegraph instance.
 	Repository string `json:"repository"`
 }
 
+// ImportChangesetsQuery description: Import all changesets in the repository that match this query on the code host, instead of listing their external IDs.
+type ImportChangesetsQuery struct {
+	// HeadRef description: Only import changesets whose head branch matches this glob pattern.
+	HeadRef string `json:"headRef,omitempty"`
+	// Labels description: Only import changesets that have all of these labels. Not supported on Bitbucket Server, which doesn't have labels.
+	Labels []string `json:"labels,omitempty"`
+	// State description: The state of the changesets to import.
+	State string `json:"state,omitempty"`
+}
+
+// LDAPAuthProvider description: Configures the LDAP authentication provider, which signs in users with the username and password of their entry in an LDAP directory (such as OpenLDAP or Active Directory).
+type LDAPAuthProvider struct {
+	// AllowSignup description: Allows users who sign in with LDAP for the first time to create a Sourcegraph account. If false, users signing in with LDAP must have an existing Sourcegraph account with the same verified email (or the same username, if linkExistingUsersByUsername is true).
+	AllowSignup *bool `json:"allowSignup,omitempty"`
+	// BindDN description: The DN of the service account used to search for users and groups. Leave empty to search anonymously.
+	BindDN string `json:"bindDN,omitempty"`
+	// BindPassword description: The password of the service account.
+	BindPassword string `json:"bindPassword,omitempty"`
+	// Certificate description: TLS certificate of the LDAP server or of the certificate authority that signed it, in PEM format. Only needed when the certificate is not signed by a certificate authority trusted by the system.
+	Certificate string `json:"certificate,omitempty"`
+	DisplayName string `json:"displayName,omitempty"`
+	// DisplayNameAttribute description: The attribute of the user entry that holds the display name of the user.
+	DisplayNameAttribute string `json:"displayNameAttribute,omitempty"`
+	// EmailAttribute description: The attribute of the user entry that holds the email address of the user. The email address is considered verified.
+	EmailAttribute string         `json:"emailAttribute,omitempty"`
+	GroupSync      *LDAPGroupSync `json:"groupSync,omitempty"`
+	// InsecureSkipVerify description: Whether to (insecurely) accept any TLS certificate presented by the LDAP server.
+	InsecureSkipVerify bool `json:"insecureSkipVerify,omitempty"`
+	// LinkExistingUsersByUsername description: Links users who sign in with LDAP for the first time to the existing Sourcegraph account with the same username. Only enable this when Sourcegraph usernames are identical to the LDAP usernames of the same people, because anyone who can choose a Sourcegraph username could otherwise take over the account of an LDAP user (or the other way around). Accounts of site admins and accounts with a builtin password are never linked by username.
+	LinkExistingUsersByUsername bool `json:"linkExistingUsersByUsername,omitempty"`
+	// StartTLS description: Whether to upgrade ldap:// connections to TLS with the StartTLS operation before any credentials are sent.
+	StartTLS bool   `json:"startTLS,omitempty"`
+	Type     string `json:"type"`
+	// Url description: URL of the LDAP server. Use the ldaps:// scheme to connect with TLS, or the ldap:// scheme together with `startTLS`.
+	Url string `json:"url"`
+	// UserBaseDN description: The DN under which to search for the entry of the user signing in.
+	UserBaseDN string `json:"userBaseDN"`
+	// UserFilter description: The LDAP filter that finds the entry of the user signing in. `{username}` is replaced with the (escaped) username entered on the sign-in form. The search must match exactly one entry.
+	UserFilter string `json:"userFilter,omitempty"`
+	// UsernameAttribute description: The attribute of the user entry that holds the username used for the Sourcegraph account.
+	UsernameAttribute string `json:"usernameAttribute,omitempty"`
+}
+
+// LDAPGroupOrg description: Maps an LDAP group to a Sourcegraph organization.
+type LDAPGroupOrg struct {
+	// Group description: The name of the LDAP group (matched case-insensitively).
+	Group string `json:"group"`
+	// Org description: The name of the Sourcegraph organization.
+	Org string `json:"org"`
+}
+
+// LDAPGroupSync description: Syncs the LDAP groups of users who signed in with LDAP to Sourcegraph organization memberships and site admin status. Groups are synced when a user signs in and periodically afterwards.
+type LDAPGroupSync struct {
+	// GroupBaseDN description: The DN under which to search for the groups of a user.
+	GroupBaseDN string `json:"groupBaseDN"`
+	// GroupFilter description: The LDAP filter that finds the groups of a user. `{dn}` is replaced with the (escaped) DN of the user entry, and `{username}` with the (escaped) LDAP username of the user.
+	GroupFilter string `json:"groupFilter,omitempty"`
+	// GroupNameAttribute description: The attribute of the group entries that holds the name of the group.
+	GroupNameAttribute string `json:"groupNameAttribute,omitempty"`
+	// IntervalMinutes description: How often to sync the groups of all users who signed in with LDAP, in minutes.
+	IntervalMinutes int `json:"intervalMinutes,omitempty"`
+	// Orgs description: Maps LDAP groups to the Sourcegraph organizations their members belong to. The memberships of the mapped organizations are managed by group sync: users who signed in with LDAP are added to an organization when they are in one of its groups, and removed from it when they are in none of them.
+	Orgs []*LDAPGroupOrg `json:"orgs,omitempty"`
+	// SiteAdminGroups description: The LDAP groups (matched case-insensitively) whose members are site admins. If set, site admin status of users who signed in with LDAP is granted and revoked based on these groups. Leave unset to manage site admins in Sourcegraph.
+	SiteAdminGroups []string `json:"siteAdminGroups,omitempty"`
+}
+
 // Log description: Configuration for logging and alerting, including to external services.
 type Log struct {
+	// AuditLog description: Configuration for exporting audit log entries (site configuration changes, external service edits, permission changes, access token usage, user deletion, etc.). Entries are always recorded in the database; these sinks receive a copy of each entry as a JSON object.
+	AuditLog *AuditLog `json:"auditLog,omitempty"`
 	// Sentry description: Configuration for Sentry
 	Sentry *Sentry `json:"sentry,omitempty"`
 }
@@ -913,8 +1081,10 @@ type ParentSourcegraph struct {
 type PermissionsUserMapping struct {
 	// BindID description: The type of identifier to identify a user. The default is "email", which uses the email address to identify a user. Use "username" to identify a user by their username. Changing this setting will erase any permissions created for users that do not yet exist.
 	BindID string `json:"bindID,omitempty"`
-	// Enabled description: Whether permissions user mapping is enabled. There must be no `authorization` field in any external service configuration before enabling this.
+	// Enabled description: Whether permissions user mapping is enabled. Unless `externalServices` is set, there must be no `authorization` field in any external service configuration before enabling this.
 	Enabled bool `json:"enabled,omitempty"`
+	// ExternalServices description: The IDs of the external services whose repositories are exclusively authorized by permissions set via the GraphQL API or the permissions import endpoint, for example Gitolite or "Other" external services that have no code host permissions. Repositories of all other external services are authorized as usual, and `authorization` may be used in their configurations. When empty, permissions set via the API are authoritative for all repositories.
+	ExternalServices []int `json:"externalServices,omitempty"`
 }
 
 // Phabricator description: Phabricator instance that integrates with this Gitolite instance
@@ -1129,6 +1299,8 @@ type SiteConfiguration struct {
 	AuthProviders []AuthProviders `json:"auth.providers,omitempty"`
 	// AuthPublic description: WARNING: This option has been removed as of 3.8.
 	AuthPublic bool `json:"auth.public,omitempty"`
+	// AuthScim description: Enables the SCIM 2.0 endpoint at /.api/scim/v2, which identity providers such as Okta and Azure AD use to provision and deactivate users and to manage organization memberships from groups.
+	AuthScim *AuthScim `json:"auth.scim,omitempty"`
 	// AuthSessionExpiry description: The duration of a user session, after which it expires and the user is required to re-authenticate. The default is 90 days. There is typically no need to set this, but some users may have specific internal security requirements.
 	//
 	// The string format is that of the Duration type in the Go time package (https://golang.org/pkg/time/#ParseDuration). E.g., "720h", "43200m", "2592000s" all indicate a timespan of 30 days.
@@ -1155,6 +1327,12 @@ type SiteConfiguration struct {
 	CampaignsEnabled *bool `json:"campaigns.enabled,omitempty"`
 	// CampaignsReadAccessEnabled description: DEPRECATED: Enables read-only access to campaigns for non-site-admin users. This doesn't have an effect anymore.
 	CampaignsReadAccessEnabled *bool `json:"campaigns.readAccess.enabled,omitempty"`
+	// CampaignsServerSideExecution description: Configures server-side execution of the steps of campaign specs. Steps run a container image and command chosen by the author of the campaign spec, so each step is run in an isolated Firecracker virtual machine (managed with ignite) without network access. The repo-updater host must have ignite installed.
+	CampaignsServerSideExecution *CampaignsServerSideExecution `json:"campaigns.serverSideExecution,omitempty"`
+	// CodeIntelRetentionDryRun description: Evaluate codeIntel.retentionPolicies without removing any uploads. Expired uploads are logged by the janitor and reported by the GraphQL API so that policies can be verified before they are enforced.
+	CodeIntelRetentionDryRun bool `json:"codeIntel.retentionDryRun,omitempty"`
+	// CodeIntelRetentionPolicies description: Retention policies for precise code intelligence (LSIF) uploads. When at least one policy is configured, the bundle manager janitor periodically removes completed uploads that are not retained by any policy. Uploads visible from the tip of a repository's default branch are always retained.
+	CodeIntelRetentionPolicies []*CodeIntelRetentionPolicy `json:"codeIntel.retentionPolicies,omitempty"`
 	// CorsOrigin description: Required when using any of the native code host integrations for Phabricator, GitLab, or Bitbucket Server. It is a space-separated list of allowed origins for cross-origin HTTP requests which should be the base URL for your Phabricator, GitLab, or Bitbucket Server instance.
 	CorsOrigin string `json:"corsOrigin,omitempty"`
 	// DebugSearchSymbolsParallelism description: (debug) controls the amount of symbol search parallelism. Defaults to 20. It is not recommended to change this outside of debugging scenarios. This option will be removed in a future version.
@@ -1241,11 +1419,31 @@ type SiteConfiguration struct {
 type Step struct {
 	// Container description: The Docker image used to launch the Docker container in which the shell command is run.
 	Container string `json:"container"`
-	// Env description: Environment variables to set in the environment when running this command.
+	// Env description: Environment variables to set in the environment when running this command. The values are templates.
 	Env map[string]string `json:"env,omitempty"`
+	// If description: A condition to check before executing the step. If the value is a string, it's a template that must evaluate to `true` for the step to run. The step is skipped otherwise.
+	If interface{} `json:"if,omitempty"`
+	// Outputs description: Outputs produced by the step that can be used in the templates of later steps and in the changeset template as `outputs.<name>`.
+	Outputs map[string]StepOutput `json:"outputs,omitempty"`
 	// Run description: The shell command to run in the container. It can also be a multi-line shell script. The working directory is the root directory of the repository checkout.
 	Run string `json:"run"`
 }
+type StepOutput struct {
+	// Format description: The format of the value. Values in the `json` or `yaml` formats are parsed, so their fields can be accessed in templates.
+	Format string `json:"format,omitempty"`
+	// Value description: The template that's evaluated to produce the value of the output.
+	Value string `json:"value"`
+}
+
+// Syslog description: Send each audit log entry to a syslog daemon.
+type Syslog struct {
+	// Address description: The address of the syslog daemon (such as "syslog.example.com:514"). Ignored if network is empty.
+	Address string `json:"address,omitempty"`
+	// Network description: The network used to connect to the syslog daemon. If empty, the local syslog daemon is used.
+	Network string `json:"network,omitempty"`
+	// Tag description: The syslog tag of the entries.
+	Tag string `json:"tag,omitempty"`
+}
 
 // TlsExternal description: Global TLS/SSL settings for Sourcegraph to use when communicating with code hosts.
 type TlsExternal struct {
@@ -1255,6 +1453,20 @@ type TlsExternal struct {
 	// If InsecureSkipVerify is true, TLS accepts any certificate presented by the server and any host name in that certificate. In this mode, TLS is susceptible to man-in-the-middle attacks.
 	InsecureSkipVerify bool `json:"insecureSkipVerify,omitempty"`
 }
+
+// TransformChanges description: Optional transformations to apply to the changes produced in each repository.
+type TransformChanges struct {
+	// Group description: A list of groups of changes in a repository that each create a separate, additional changeset for this repository, with all ungrouped changes being in the default changeset.
+	Group []*TransformChangesGroup `json:"group,omitempty"`
+}
+type TransformChangesGroup struct {
+	// Branch description: The branch on the repository to propose the grouped changes to. The value is a template.
+	Branch string `json:"branch"`
+	// Directory description: The directory path (relative to the repository root) of the changes to include in this group.
+	Directory string `json:"directory"`
+	// Repository description: Only apply this transformation in the repository with this name (as it is known to Sourcegraph).
+	Repository string `json:"repository,omitempty"`
+}
 type UsernameIdentity struct {
 	Type string `json:"type"`
 }
@@ -265,6 +265,36 @@ const SiteSchemaJSON = `{
       "group": "Campaigns",
       "default": true
     },
+    "campaigns.serverSideExecution": {
+      "description": "Configures server-side execution of the steps of campaign specs. Steps run a container image and command chosen by the author of the campaign spec, so each step is run in an isolated Firecracker virtual machine (managed with ignite) without network access. The repo-updater host must have ignite installed.",
+      "type": "object",
+      "additionalProperties": false,
+      "properties": {
+        "enabled": {
+          "description": "Enables server-side execution of campaign spec steps. When disabled, campaign specs can only be executed with src-cli.",
+          "type": "boolean",
+          "default": false
+        },
+        "firecrackerImage": {
+          "description": "The base image of the virtual machines that steps are run in.",
+          "type": "string",
+          "default": "sourcegraph/ignite-ubuntu:insiders"
+        },
+        "cpus": {
+          "description": "The number of CPUs allocated to each step.",
+          "type": "integer",
+          "minimum": 1,
+          "default": 4
+        },
+        "memory": {
+          "description": "The amount of memory allocated to each step.",
+          "type": "string",
+          "default": "12G",
+          "examples": ["4G", "12G"]
+        }
+      },
+      "group": "Campaigns"
+    },
     "campaigns.readAccess.enabled": {
       "description": "DEPRECATED: Enables read-only access to campaigns for non-site-admin users. This doesn't have an effect anymore.",
       "type": "boolean",
@@ -278,6 +308,25 @@ const SiteSchemaJSON = `{
       "pattern": "^((https?:\\/\\/[\\w-\\.]+)( https?:\\/\\/[\\w-\\.]+)*)|\\*$",
       "group": "Security"
     },
+    "codeIntel.retentionPolicies": {
+      "description": "Retention policies for precise code intelligence (LSIF) uploads. When at least one policy is configured, the bundle manager janitor periodically removes completed uploads that are not retained by any policy. Uploads visible from the tip of a repository's default branch are always retained.",
+      "type": "array",
+      "items": { "$ref": "#/definitions/CodeIntelRetentionPolicy" },
+      "group": "Code intelligence",
+      "examples": [
+        [
+          { "name": "release tags", "type": "tags", "pattern": "v*", "maxAge": "8760h" },
+          { "name": "active branches", "type": "branches", "maxAge": "720h" },
+          { "name": "recent uploads", "type": "uploads", "maxAge": "168h" }
+        ]
+      ]
+    },
+    "codeIntel.retentionDryRun": {
+      "description": "Evaluate codeIntel.retentionPolicies without removing any uploads. Expired uploads are logged by the janitor and reported by the GraphQL API so that policies can be verified before they are enforced.",
+      "type": "boolean",
+      "default": false,
+      "group": "Code intelligence"
+    },
     "lsifEnforceAuth": {
       "description": "Whether or not LSIF uploads will be blocked unless a valid LSIF upload token is provided.",
       "type": "boolean",
@@ -431,7 +480,7 @@ const SiteSchemaJSON = `{
       "additionalProperties": false,
       "properties": {
         "enabled": {
-          "description": "Whether permissions user mapping is enabled. There must be no ` + "`" + `authorization` + "`" + ` field in any external service configuration before enabling this.",
+          "description": "Whether permissions user mapping is enabled. Unless ` + "`" + `externalServices` + "`" + ` is set, there must be no ` + "`" + `authorization` + "`" + ` field in any external service configuration before enabling this.",
           "type": "boolean",
           "default": false
         },
@@ -440,13 +489,19 @@ const SiteSchemaJSON = `{
           "type": "string",
           "enum": ["email", "username"],
           "default": "email"
+        },
+        "externalServices": {
+          "description": "The IDs of the external services whose repositories are exclusively authorized by permissions set via the GraphQL API or the permissions import endpoint, for example Gitolite or \"Other\" external services that have no code host permissions. Repositories of all other external services are authorized as usual, and ` + "`" + `authorization` + "`" + ` may be used in their configurations. When empty, permissions set via the API are authoritative for all repositories.",
+          "type": "array",
+          "items": { "type": "integer" },
+          "uniqueItems": true
         }
       },
       "default": {
         "enabled": true,
         "bindID": "email"
       },
-      "examples": [{ "bindID": "email" }, { "bindID": "username" }],
+      "examples": [{ "bindID": "email" }, { "bindID": "username" }, { "bindID": "email", "externalServices": [3, 4] }],
       "group": "Security"
     },
     "branding": {
@@ -611,9 +666,46 @@ const SiteSchemaJSON = `{
               "pattern": "^https?://"
             }
           }
+        },
+        "auditLog": {
+          "description": "Configuration for exporting audit log entries (site configuration changes, external service edits, permission changes, access token usage, user deletion, etc.). Entries are always recorded in the database; these sinks receive a copy of each entry as a JSON object.",
+          "type": "object",
+          "additionalProperties": false,
+          "properties": {
+            "jsonLinesFile": {
+              "description": "Path to a file (in the frontend container) to which each audit log entry is appended as a single line of JSON.",
+              "type": "string",
+              "examples": ["/var/log/sourcegraph/audit.jsonl"]
+            },
+            "syslog": {
+              "description": "Send each audit log entry to a syslog daemon.",
+              "type": "object",
+              "additionalProperties": false,
+              "properties": {
+                "network": {
+                  "description": "The network used to connect to the syslog daemon. If empty, the local syslog daemon is used.",
+                  "type": "string",
+                  "enum": ["", "tcp", "udp", "unix"],
+                  "default": ""
+                },
+                "address": {
+                  "description": "The address of the syslog daemon (such as \"syslog.example.com:514\"). Ignored if network is empty.",
+                  "type": "string"
+                },
+                "tag": {
+                  "description": "The syslog tag of the entries.",
+                  "type": "string",
+                  "default": "sourcegraph-audit"
+                }
+              }
+            }
+          }
         }
       },
-      "examples": [{ "sentry": { "dsn": "https://mykey@sentry.io/myproject" } }],
+      "examples": [
+        { "sentry": { "dsn": "https://mykey@sentry.io/myproject" } },
+        { "auditLog": { "jsonLinesFile": "/var/log/sourcegraph/audit.jsonl" } }
+      ],
       "group": "Misc."
     },
     "externalURL": {
@@ -759,7 +851,7 @@ const SiteSchemaJSON = `{
         "properties": {
           "type": {
             "type": "string",
-            "enum": ["builtin", "saml", "openidconnect", "http-header", "github", "gitlab"]
+            "enum": ["builtin", "saml", "openidconnect", "http-header", "github", "gitlab", "ldap"]
           }
         },
         "oneOf": [
@@ -768,7 +860,8 @@ const SiteSchemaJSON = `{
           { "$ref": "#/definitions/OpenIDConnectAuthProvider" },
           { "$ref": "#/definitions/HTTPHeaderAuthProvider" },
           { "$ref": "#/definitions/GitHubAuthProvider" },
-          { "$ref": "#/definitions/GitLabAuthProvider" }
+          { "$ref": "#/definitions/GitLabAuthProvider" },
+          { "$ref": "#/definitions/LDAPAuthProvider" }
         ],
         "!go": {
           "taggedUnionType": true
@@ -802,6 +895,26 @@ const SiteSchemaJSON = `{
       "default": 12,
       "group": "Authentication"
     },
+    "auth.scim": {
+      "description": "Enables the SCIM 2.0 endpoint at /.api/scim/v2, which identity providers such as Okta and Azure AD use to provision and deactivate users and to manage organization memberships from groups.",
+      "type": "object",
+      "additionalProperties": false,
+      "required": ["authToken"],
+      "properties": {
+        "authToken": {
+          "description": "The bearer token that the SCIM client must send in the Authorization header of every request. Generate a long random value, e.g. with ` + "`" + `openssl rand -hex 32` + "`" + `.",
+          "type": "string",
+          "minLength": 32
+        },
+        "adoptExistingUsers": {
+          "description": "Lets the SCIM client take over the management of existing users that were not provisioned by SCIM (e.g. users who signed up before SCIM was enabled). When the SCIM client creates a user whose primary email address is a verified email address of an existing user, that user is linked to the SCIM client instead of creating a new user. Site admins are never adopted.",
+          "type": "boolean",
+          "default": false
+        }
+      },
+      "examples": [{ "authToken": "0123456789abcdef0123456789abcdef" }],
+      "group": "Authentication"
+    },
     "update.channel": {
       "description": "The channel on which to automatically check for Sourcegraph updates.",
       "type": ["string"],
@@ -812,6 +925,35 @@ const SiteSchemaJSON = `{
     }
   },
   "definitions": {
+    "CodeIntelRetentionPolicy": {
+      "description": "A rule describing a set of precise code intelligence uploads that must be retained.",
+      "type": "object",
+      "additionalProperties": false,
+      "required": ["name", "type"],
+      "properties": {
+        "name": {
+          "description": "A human-readable name for the policy. This name is reported for each upload the policy retains.",
+          "type": "string",
+          "minLength": 1
+        },
+        "type": {
+          "description": "The kind of objects this policy applies to. A ` + "`" + `branches` + "`" + ` or ` + "`" + `tags` + "`" + ` policy retains the uploads visible from the commit at the tip of each matching branch or tag. An ` + "`" + `uploads` + "`" + ` policy retains every upload regardless of the commit it was uploaded for.",
+          "type": "string",
+          "enum": ["branches", "tags", "uploads"]
+        },
+        "pattern": {
+          "description": "A glob pattern matched against branch or tag names (without the refs/heads/ or refs/tags/ prefix). Defaults to matching every name. Ignored by ` + "`" + `uploads` + "`" + ` policies.",
+          "type": "string",
+          "examples": ["release/*", "v*"]
+        },
+        "maxAge": {
+          "description": "The maximum age of a matching branch tip or tag (by commit or tag date), or of the upload itself for ` + "`" + `uploads` + "`" + ` policies, expressed as a Go duration such as \"720h\". When omitted, matching uploads are retained indefinitely.",
+          "type": "string",
+          "pattern": "^([0-9]+(\\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$",
+          "examples": ["720h", "8760h"]
+        }
+      }
+    },
     "BrandAssets": {
       "type": "object",
       "properties": {
@@ -841,6 +983,12 @@ const SiteSchemaJSON = `{
           "description": "Allows new visitors to sign up for accounts. The sign-up page will be enabled and accessible to all visitors.\n\nSECURITY: If the site has no users (i.e., during initial setup), it will always allow the first user to sign up and become site admin **without any approval** (first user to sign up becomes the admin).",
           "type": "boolean",
           "default": false
+        },
+        "requireTwoFactorAuth": {
+          "description": "Requires builtin users to sign in with a code of an authenticator app in addition to their password. Users who must use two-factor authentication but haven't enrolled yet are asked to enroll when they sign in.",
+          "type": "string",
+          "enum": ["none", "siteAdmins", "all"],
+          "default": "none"
         }
       }
     },
@@ -1045,6 +1193,150 @@ const SiteSchemaJSON = `{
         "displayName": { "$ref": "#/definitions/AuthProviderCommon/properties/displayName" }
       }
     },
+    "LDAPAuthProvider": {
+      "description": "Configures the LDAP authentication provider, which signs in users with the username and password of their entry in an LDAP directory (such as OpenLDAP or Active Directory).",
+      "type": "object",
+      "additionalProperties": false,
+      "required": ["type", "url", "userBaseDN"],
+      "properties": {
+        "type": {
+          "type": "string",
+          "const": "ldap"
+        },
+        "displayName": { "$ref": "#/definitions/AuthProviderCommon/properties/displayName" },
+        "url": {
+          "description": "URL of the LDAP server. Use the ldaps:// scheme to connect with TLS, or the ldap:// scheme together with ` + "`" + `startTLS` + "`" + `.",
+          "type": "string",
+          "pattern": "^ldaps?://",
+          "examples": ["ldaps://ldap.example.com", "ldap://ldap.example.com:389"]
+        },
+        "startTLS": {
+          "description": "Whether to upgrade ldap:// connections to TLS with the StartTLS operation before any credentials are sent.",
+          "type": "boolean",
+          "default": false
+        },
+        "certificate": {
+          "description": "TLS certificate of the LDAP server or of the certificate authority that signed it, in PEM format. Only needed when the certificate is not signed by a certificate authority trusted by the system.",
+          "type": "string",
+          "pattern": "^-----BEGIN CERTIFICATE-----\n"
+        },
+        "insecureSkipVerify": {
+          "description": "Whether to (insecurely) accept any TLS certificate presented by the LDAP server.",
+          "type": "boolean",
+          "default": false
+        },
+        "bindDN": {
+          "description": "The DN of the service account used to search for users and groups. Leave empty to search anonymously.",
+          "type": "string",
+          "examples": ["cn=sourcegraph,ou=services,dc=example,dc=com"]
+        },
+        "bindPassword": {
+          "description": "The password of the service account.",
+          "type": "string"
+        },
+        "userBaseDN": {
+          "description": "The DN under which to search for the entry of the user signing in.",
+          "type": "string",
+          "examples": ["ou=people,dc=example,dc=com"]
+        },
+        "userFilter": {
+          "description": "The LDAP filter that finds the entry of the user signing in. ` + "`" + `{username}` + "`" + ` is replaced with the (escaped) username entered on the sign-in form. The search must match exactly one entry.",
+          "type": "string",
+          "default": "(uid={username})",
+          "examples": ["(&(objectClass=user)(sAMAccountName={username}))"]
+        },
+        "usernameAttribute": {
+          "description": "The attribute of the user entry that holds the username used for the Sourcegraph account.",
+          "type": "string",
+          "default": "uid",
+          "examples": ["sAMAccountName"]
+        },
+        "emailAttribute": {
+          "description": "The attribute of the user entry that holds the email address of the user. The email address is considered verified.",
+          "type": "string",
+          "default": "mail"
+        },
+        "displayNameAttribute": {
+          "description": "The attribute of the user entry that holds the display name of the user.",
+          "type": "string",
+          "default": "cn",
+          "examples": ["displayName"]
+        },
+        "allowSignup": {
+          "description": "Allows users who sign in with LDAP for the first time to create a Sourcegraph account. If false, users signing in with LDAP must have an existing Sourcegraph account with the same verified email (or the same username, if linkExistingUsersByUsername is true).",
+          "type": "boolean",
+          "default": true,
+          "!go": { "pointer": true }
+        },
+        "linkExistingUsersByUsername": {
+          "description": "Links users who sign in with LDAP for the first time to the existing Sourcegraph account with the same username. Only enable this when Sourcegraph usernames are identical to the LDAP usernames of the same people, because anyone who can choose a Sourcegraph username could otherwise take over the account of an LDAP user (or the other way around). Accounts of site admins and accounts with a builtin password are never linked by username.",
+          "type": "boolean",
+          "default": false
+        },
+        "groupSync": { "$ref": "#/definitions/LDAPGroupSync" }
+      }
+    },
+    "LDAPGroupSync": {
+      "description": "Syncs the LDAP groups of users who signed in with LDAP to Sourcegraph organization memberships and site admin status. Groups are synced when a user signs in and periodically afterwards.",
+      "type": "object",
+      "additionalProperties": false,
+      "required": ["groupBaseDN"],
+      "properties": {
+        "groupBaseDN": {
+          "description": "The DN under which to search for the groups of a user.",
+          "type": "string",
+          "examples": ["ou=groups,dc=example,dc=com"]
+        },
+        "groupFilter": {
+          "description": "The LDAP filter that finds the groups of a user. ` + "`" + `{dn}` + "`" + ` is replaced with the (escaped) DN of the user entry, and ` + "`" + `{username}` + "`" + ` with the (escaped) LDAP username of the user.",
+          "type": "string",
+          "default": "(member={dn})",
+          "examples": ["(&(objectClass=posixGroup)(memberUid={username}))"]
+        },
+        "groupNameAttribute": {
+          "description": "The attribute of the group entries that holds the name of the group.",
+          "type": "string",
+          "default": "cn"
+        },
+        "orgs": {
+          "description": "Maps LDAP groups to the Sourcegraph organizations their members belong to. The memberships of the mapped organizations are managed by group sync: users who signed in with LDAP are added to an organization when they are in one of its groups, and removed from it when they are in none of them.",
+          "type": "array",
+          "items": { "$ref": "#/definitions/LDAPGroupOrg" }
+        },
+        "siteAdminGroups": {
+          "description": "The LDAP groups (matched case-insensitively) whose members are site admins. If set, site admin status of users who signed in with LDAP is granted and revoked based on these groups. Leave unset to manage site admins in Sourcegraph.",
+          "type": "array",
+          "items": {
+            "type": "string",
+            "minLength": 1
+          }
+        },
+        "intervalMinutes": {
+          "description": "How often to sync the groups of all users who signed in with LDAP, in minutes.",
+          "type": "integer",
+          "minimum": 1,
+          "default": 60
+        }
+      }
+    },
+    "LDAPGroupOrg": {
+      "description": "Maps an LDAP group to a Sourcegraph organization.",
+      "type": "object",
+      "additionalProperties": false,
+      "required": ["group", "org"],
+      "properties": {
+        "group": {
+          "description": "The name of the LDAP group (matched case-insensitively).",
+          "type": "string",
+          "minLength": 1
+        },
+        "org": {
+          "description": "The name of the Sourcegraph organization.",
+          "type": "string",
+          "minLength": 1
+        }
+      }
+    },
     "AuthProviderCommon": {
       "$comment": "This schema is not used directly. The *AuthProvider schemas refer to its properties directly.",
       "description": "Common properties for authentication providers.",
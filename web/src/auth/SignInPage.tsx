@@ -40,6 +40,9 @@ export const SignInPage: React.FunctionComponent<SignInPageProps> = props => {
                                 provider.isBuiltin ? (
                                     /* eslint-disable react/no-array-index-key */
                                     <UsernamePasswordSignInForm key={index} {...props} />
+                                ) : provider.serviceType === 'ldap' ? (
+                                    /* eslint-disable react/no-array-index-key */
+                                    <UsernamePasswordSignInForm key={index} {...props} ldapProvider={provider} />
                                 ) : (
                                     /* eslint-disable react/no-array-index-key */
                                     <div className="mb-2" key={index}>
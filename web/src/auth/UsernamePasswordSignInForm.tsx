@@ -11,17 +11,41 @@ import { asError } from '../../../shared/src/util/errors'
 interface Props {
     location: H.Location
     history: H.History
+
+    /**
+     * An LDAP auth provider to sign in with. If not set, the form signs in with the builtin
+     * username-password authentication.
+     */
+    ldapProvider?: {
+        displayName: string
+        authenticationURL?: string
+    }
+}
+
+/**
+ * The JSON response of the sign-in endpoint when a two-factor authentication code is needed, or
+ * when the sign-in completed the user's enrollment in two-factor authentication.
+ */
+interface TwoFactorAuthResponse {
+    twoFactorAuth?: 'required' | 'enroll'
+    secret?: string
+    uri?: string
+    recoveryCodes?: string[]
 }
 
 interface State {
     email: string
     password: string
+    twoFactorAuthCode: string
+    twoFactorAuth?: TwoFactorAuthResponse
+    recoveryCodes?: string[]
     error?: Error
     loading: boolean
 }
 
 /**
- * The form for signing in with a username and password.
+ * The form for signing in with a username and password, either with builtin authentication or
+ * with an LDAP auth provider.
  */
 export class UsernamePasswordSignInForm extends React.Component<Props, State> {
     constructor(props: Props) {
@@ -29,14 +53,32 @@ export class UsernamePasswordSignInForm extends React.Component<Props, State> {
         this.state = {
             email: '',
             password: '',
+            twoFactorAuthCode: '',
             loading: false,
         }
     }
 
     public render(): JSX.Element | null {
+        if (this.state.recoveryCodes) {
+            return (
+                <div className="signin-signup-form signin-form">
+                    <p>
+                        Two-factor authentication is enabled. Store these recovery codes in a safe place. Each of them
+                        can be used once to sign in if you lose access to your authenticator app.
+                    </p>
+                    <pre className="test-recovery-codes">{this.state.recoveryCodes.join('\n')}</pre>
+                    <button className="btn btn-primary btn-block" type="button" onClick={this.onSignedIn}>
+                        Continue
+                    </button>
+                </div>
+            )
+        }
+
         return (
             <Form className="signin-signup-form signin-form test-signin-form" onSubmit={this.handleSubmit}>
-                {window.context.allowSignup ? (
+                {this.props.ldapProvider ? (
+                    <p className="text-muted">Sign in with your {this.props.ldapProvider.displayName} account.</p>
+                ) : window.context.allowSignup ? (
                     <p>
                         <Link to={`/sign-up${this.props.location.search}`}>Don't have an account? Sign up.</Link>
                     </p>
@@ -50,14 +92,14 @@ export class UsernamePasswordSignInForm extends React.Component<Props, State> {
                     <input
                         className="form-control signin-signup-form__input"
                         type="text"
-                        placeholder="Username or email"
+                        placeholder={this.props.ldapProvider ? 'Username' : 'Username or email'}
                         onChange={this.onEmailFieldChange}
                         required={true}
                         value={this.state.email}
                         disabled={this.state.loading}
                         autoCapitalize="off"
                         autoFocus={true}
-                        autoComplete="username email"
+                        autoComplete={this.props.ldapProvider ? 'username' : 'username email'}
                     />
                 </div>
                 <div className="form-group">
@@ -70,11 +112,37 @@ export class UsernamePasswordSignInForm extends React.Component<Props, State> {
                         autoComplete="current-password"
                     />
                 </div>
+                {this.state.twoFactorAuth?.twoFactorAuth === 'enroll' && (
+                    <p>
+                        Two-factor authentication is required. Add this secret to your authenticator app, then enter
+                        the code it generates: <code>{this.state.twoFactorAuth.secret}</code>
+                    </p>
+                )}
+                {this.state.twoFactorAuth && (
+                    <div className="form-group">
+                        <input
+                            className="form-control signin-signup-form__input"
+                            type="text"
+                            placeholder={
+                                this.state.twoFactorAuth.twoFactorAuth === 'enroll'
+                                    ? 'Authentication code'
+                                    : 'Authentication code or recovery code'
+                            }
+                            onChange={this.onTwoFactorAuthCodeFieldChange}
+                            required={true}
+                            value={this.state.twoFactorAuthCode}
+                            disabled={this.state.loading}
+                            autoCapitalize="off"
+                            autoFocus={true}
+                            autoComplete="one-time-code"
+                        />
+                    </div>
+                )}
                 <div className="form-group">
                     <button className="btn btn-primary btn-block" type="submit" disabled={this.state.loading}>
                         Sign in
                     </button>
-                    {window.context.resetPasswordEnabled && (
+                    {!this.props.ldapProvider && window.context.resetPasswordEnabled && (
                         <small className="form-text text-muted">
                             <Link to="/password-reset">Forgot password?</Link>
                         </small>
@@ -97,6 +165,26 @@ export class UsernamePasswordSignInForm extends React.Component<Props, State> {
         this.setState({ password: event.target.value })
     }
 
+    private onTwoFactorAuthCodeFieldChange = (event: React.ChangeEvent<HTMLInputElement>): void => {
+        this.setState({ twoFactorAuthCode: event.target.value })
+    }
+
+    private onSignedIn = (): void => {
+        if (new URLSearchParams(this.props.location.search).get('close') === 'true') {
+            window.close()
+        } else {
+            const returnTo = getReturnTo(this.props.location)
+            window.location.replace(returnTo)
+        }
+    }
+
+    private readTwoFactorAuthResponse = async (response: Response): Promise<TwoFactorAuthResponse | undefined> => {
+        if (!response.headers.get('Content-Type')?.startsWith('application/json')) {
+            return undefined
+        }
+        return (await response.json()) as TwoFactorAuthResponse
+    }
+
     private handleSubmit = (event: React.FormEvent<HTMLFormElement>): void => {
         event.preventDefault()
         if (this.state.loading) {
@@ -105,7 +193,8 @@ export class UsernamePasswordSignInForm extends React.Component<Props, State> {
 
         this.setState({ loading: true })
         eventLogger.log('InitiateSignIn')
-        fetch('/-/sign-in', {
+        const { ldapProvider } = this.props
+        fetch(ldapProvider?.authenticationURL ?? '/-/sign-in', {
             credentials: 'same-origin',
             method: 'POST',
             headers: {
@@ -113,21 +202,34 @@ export class UsernamePasswordSignInForm extends React.Component<Props, State> {
                 Accept: 'application/json',
                 'Content-Type': 'application/json',
             },
-            body: JSON.stringify({
-                email: this.state.email,
-                password: this.state.password,
-            }),
+            body: JSON.stringify(
+                ldapProvider
+                    ? { username: this.state.email, password: this.state.password }
+                    : {
+                          email: this.state.email,
+                          password: this.state.password,
+                          twoFactorAuthCode: this.state.twoFactorAuthCode,
+                      }
+            ),
         })
-            .then(response => {
+            .then(async response => {
+                const twoFactorAuth = await this.readTwoFactorAuthResponse(response)
                 if (response.status === 200) {
-                    if (new URLSearchParams(this.props.location.search).get('close') === 'true') {
-                        window.close()
+                    if (twoFactorAuth?.recoveryCodes) {
+                        this.setState({ loading: false, recoveryCodes: twoFactorAuth.recoveryCodes })
                     } else {
-                        const returnTo = getReturnTo(this.props.location)
-                        window.location.replace(returnTo)
+                        this.onSignedIn()
                     }
+                } else if (response.status === 401 && twoFactorAuth?.twoFactorAuth) {
+                    this.setState({ loading: false, error: undefined, twoFactorAuth, twoFactorAuthCode: '' })
                 } else if (response.status === 401) {
-                    throw new Error('User or password was incorrect')
+                    throw new Error(
+                        this.state.twoFactorAuth
+                            ? 'Authentication code was incorrect'
+                            : 'User or password was incorrect'
+                    )
+                } else if (response.status === 429) {
+                    throw new Error('Too many failed attempts, try again later')
                 } else {
                     throw new Error('Unknown Error')
                 }
@@ -4,4 +4,7 @@
 export enum AccessTokenScopes {
     UserAll = 'user:all',
     SiteAdminSudo = 'site-admin:sudo',
+    SearchRead = 'search:read',
+    CodeIntelUpload = 'codeintel:upload',
+    CampaignsWrite = 'campaigns:write',
 }
@@ -90,6 +90,7 @@ export interface SourcegraphContext extends Pick<Required<SiteConfiguration>, 'e
     authProviders?: {
         displayName: string
         isBuiltin: boolean
+        serviceType: string
         authenticationURL?: string
     }[]
 
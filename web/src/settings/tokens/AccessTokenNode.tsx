@@ -19,6 +19,8 @@ export const accessTokenFragment = gql`
         note
         createdAt
         lastUsedAt
+        lastUsedIP
+        expiresAt
         subject {
             username
         }
@@ -134,6 +136,7 @@ export class AccessTokenNode extends React.PureComponent<AccessTokenNodeProps, A
                             {this.props.node.lastUsedAt ? (
                                 <>
                                     Last used <Timestamp date={this.props.node.lastUsedAt} />
+                                    {this.props.node.lastUsedIP && <> from {this.props.node.lastUsedIP}</>}
                                 </>
                             ) : (
                                 'Never used'
@@ -148,6 +151,16 @@ export class AccessTokenNode extends React.PureComponent<AccessTokenNodeProps, A
                                     </Link>
                                 </>
                             )}
+                            {this.props.node.expiresAt &&
+                                (new Date(this.props.node.expiresAt) > new Date() ? (
+                                    <>
+                                        , expires <Timestamp date={this.props.node.expiresAt} />
+                                    </>
+                                ) : (
+                                    <>
+                                        , <strong>expired</strong> <Timestamp date={this.props.node.expiresAt} />
+                                    </>
+                                ))}
                         </small>
                     </div>
                     <div>
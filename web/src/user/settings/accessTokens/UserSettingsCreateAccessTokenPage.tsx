@@ -17,17 +17,22 @@ import { eventLogger } from '../../../tracking/eventLogger'
 import { UserAreaRouteContext } from '../../area/UserArea'
 import { ErrorAlert } from '../../../components/alerts'
 
-function createAccessToken(user: GQL.ID, scopes: string[], note: string): Observable<GQL.ICreateAccessTokenResult> {
+function createAccessToken(
+    user: GQL.ID,
+    scopes: string[],
+    note: string,
+    expiresAt: string | null
+): Observable<GQL.ICreateAccessTokenResult> {
     return mutateGraphQL(
         gql`
-            mutation CreateAccessToken($user: ID!, $scopes: [String!]!, $note: String!) {
-                createAccessToken(user: $user, scopes: $scopes, note: $note) {
+            mutation CreateAccessToken($user: ID!, $scopes: [String!]!, $note: String!, $expiresAt: DateTime) {
+                createAccessToken(user: $user, scopes: $scopes, note: $note, expiresAt: $expiresAt) {
                     id
                     token
                 }
             }
         `,
-        { user, scopes, note }
+        { user, scopes, note, expiresAt }
     ).pipe(
         map(({ data, errors }) => {
             if (!data || !data.createAccessToken || (errors && errors.length > 0)) {
@@ -40,6 +45,23 @@ function createAccessToken(user: GQL.ID, scopes: string[], note: string): Observ
     )
 }
 
+/** The scopes that any user may select, with their descriptions. */
+const USER_SCOPES: { scope: AccessTokenScopes; description: string }[] = [
+    { scope: AccessTokenScopes.UserAll, description: 'Full control of all resources accessible to the user account' },
+    {
+        scope: AccessTokenScopes.SearchRead,
+        description: 'Read-only access to searches and repositories, but no other API queries and no changes',
+    },
+    { scope: AccessTokenScopes.CodeIntelUpload, description: 'Ability to upload LSIF code intelligence data' },
+    {
+        scope: AccessTokenScopes.CampaignsWrite,
+        description: 'Read-only access to searches and repositories plus the ability to create and modify campaigns',
+    },
+]
+
+/** The selectable token lifetimes in days (0 means the token never expires). */
+const EXPIRY_DAYS = [0, 7, 30, 90, 365]
+
 interface Props extends UserAreaRouteContext, RouteComponentProps<{}> {
     /** Called when a new access token is created and should be temporarily displayed to the user. */
     onDidCreateAccessToken: (result: GQL.ICreateAccessTokenResult) => void
@@ -52,6 +74,9 @@ interface State {
     /** The selected scopes checkboxes. */
     scopes: string[]
 
+    /** The selected token lifetime in days (0 means the token never expires). */
+    expiryDays: number
+
     creationOrError?: 'loading' | GQL.ICreateAccessTokenResult | ErrorLike
 }
 
@@ -62,6 +87,7 @@ export class UserSettingsCreateAccessTokenPage extends React.PureComponent<Props
     public state: State = {
         note: '',
         scopes: [AccessTokenScopes.UserAll],
+        expiryDays: 0,
     }
 
     private submits = new Subject<React.FormEvent<HTMLFormElement>>()
@@ -77,7 +103,14 @@ export class UserSettingsCreateAccessTokenPage extends React.PureComponent<Props
                     concatMap(() =>
                         concat(
                             [{ creationOrError: 'loading' }],
-                            createAccessToken(this.props.user.id, this.state.scopes, this.state.note).pipe(
+                            createAccessToken(
+                                this.props.user.id,
+                                this.state.scopes,
+                                this.state.note,
+                                this.state.expiryDays > 0
+                                    ? new Date(Date.now() + this.state.expiryDays * 24 * 60 * 60 * 1000).toISOString()
+                                    : null
+                            ).pipe(
                                 tap(result => {
                                     // Go back to access tokens list page and display the token secret value.
                                     this.props.history.push(`${this.props.match.url.replace(/\/new$/, '')}`)
@@ -136,29 +169,24 @@ export class UserSettingsCreateAccessTokenPage extends React.PureComponent<Props
                         <label className="mb-1" htmlFor="user-settings-create-access-token-page__note">
                             Token scope
                         </label>
-                        <div>
-                            <small className="form-help text-muted">
-                                Tokens with limited user scopes are not yet supported.
-                            </small>
-                        </div>
-                        <div className="form-check">
-                            <input
-                                className="form-check-input"
-                                type="checkbox"
-                                id="user-settings-create-access-token-page__scope-user:all"
-                                checked={true}
-                                value={AccessTokenScopes.UserAll}
-                                onChange={this.onScopesChange}
-                                disabled={true}
-                            />
-                            <label
-                                className="form-check-label"
-                                htmlFor="user-settings-create-access-token-page__scope-user:all"
-                            >
-                                <strong>{AccessTokenScopes.UserAll}</strong> — Full control of all resources accessible
-                                to the user account
-                            </label>
-                        </div>
+                        {USER_SCOPES.map(({ scope, description }) => (
+                            <div className="form-check" key={scope}>
+                                <input
+                                    className="form-check-input"
+                                    type="checkbox"
+                                    id={`user-settings-create-access-token-page__scope-${scope}`}
+                                    checked={this.state.scopes.includes(scope)}
+                                    value={scope}
+                                    onChange={this.onScopesChange}
+                                />
+                                <label
+                                    className="form-check-label"
+                                    htmlFor={`user-settings-create-access-token-page__scope-${scope}`}
+                                >
+                                    <strong>{scope}</strong> — {description}
+                                </label>
+                            </div>
+                        ))}
                         {this.props.user.siteAdmin && (
                             <div className="form-check">
                                 <input
@@ -174,14 +202,29 @@ export class UserSettingsCreateAccessTokenPage extends React.PureComponent<Props
                                     htmlFor="user-settings-create-access-token-page__scope-site-admin:sudo"
                                 >
                                     <strong>{AccessTokenScopes.SiteAdminSudo}</strong> — Ability to perform any action
-                                    as any other user
+                                    as any other user (requires {AccessTokenScopes.UserAll})
                                 </label>
                             </div>
                         )}
                     </div>
+                    <div className="form-group">
+                        <label htmlFor="user-settings-create-access-token-page__expiry">Expiration</label>
+                        <select
+                            className="form-control"
+                            id="user-settings-create-access-token-page__expiry"
+                            value={this.state.expiryDays}
+                            onChange={this.onExpiryChange}
+                        >
+                            {EXPIRY_DAYS.map(days => (
+                                <option key={days} value={days}>
+                                    {days === 0 ? 'Never' : `${days} days`}
+                                </option>
+                            ))}
+                        </select>
+                    </div>
                     <button
                         type="submit"
-                        disabled={this.state.creationOrError === 'loading'}
+                        disabled={this.state.creationOrError === 'loading' || this.state.scopes.length === 0}
                         className="btn btn-success test-create-access-token-submit"
                     >
                         {this.state.creationOrError === 'loading' ? (
@@ -212,6 +255,9 @@ export class UserSettingsCreateAccessTokenPage extends React.PureComponent<Props
     private onNoteChange: React.ChangeEventHandler<HTMLInputElement> = event =>
         this.setState({ note: event.currentTarget.value })
 
+    private onExpiryChange: React.ChangeEventHandler<HTMLSelectElement> = event =>
+        this.setState({ expiryDays: parseInt(event.currentTarget.value, 10) })
+
     private onScopesChange: React.ChangeEventHandler<HTMLInputElement> = event => {
         const checked = event.currentTarget.checked
         const value = event.currentTarget.value